@@ -2,6 +2,7 @@ package main
 
 import (
 	"context"
+	"fmt"
 	"io"
 	"os"
 	"os/signal"
@@ -11,10 +12,18 @@ import (
 	"github.com/leg100/otf/internal"
 	"github.com/leg100/otf/internal/agent"
 	"github.com/leg100/otf/internal/authenticator"
+	"github.com/leg100/otf/internal/bitbucket"
+	"github.com/leg100/otf/internal/blob"
 	"github.com/leg100/otf/internal/daemon"
+	"github.com/leg100/otf/internal/demo"
+	"github.com/leg100/otf/internal/email"
 	"github.com/leg100/otf/internal/github"
 	"github.com/leg100/otf/internal/gitlab"
 	"github.com/leg100/otf/internal/logr"
+	"github.com/leg100/otf/internal/orgmetrics"
+	"github.com/leg100/otf/internal/run"
+	"github.com/leg100/otf/internal/vcs"
+	"github.com/leg100/otf/internal/workspace"
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 )
@@ -43,7 +52,10 @@ func parseFlags(ctx context.Context, args []string, out io.Writer) error {
 	cfg := daemon.Config{}
 	daemon.ApplyDefaults(&cfg)
 
-	var loggerConfig *logr.Config
+	var (
+		loggerConfig *logr.Config
+		demoMode     bool
+	)
 
 	cmd := &cobra.Command{
 		Use:           "otfd",
@@ -61,12 +73,38 @@ func parseFlags(ctx context.Context, args []string, out io.Writer) error {
 			// Confer superuser privileges on all calls to service endpoints
 			ctx := internal.AddSubjectToContext(cmd.Context(), &internal.Superuser{Username: "app-user"})
 
+			if demoMode {
+				db, err := demo.StartPostgres(ctx, logger)
+				if err != nil {
+					return err
+				}
+				defer db.Stop()
+
+				if err := demo.Configure(&cfg, db); err != nil {
+					return err
+				}
+			}
+
 			d, err := daemon.New(ctx, logger, cfg)
 			if err != nil {
 				return err
 			}
+
+			started := make(chan struct{})
+			if demoMode {
+				if err := demo.Seed(ctx, d); err != nil {
+					return fmt.Errorf("seeding demo data: %w", err)
+				}
+				go func() {
+					select {
+					case <-started:
+						fmt.Fprintf(cmd.OutOrStdout(), "otfd is running in demo mode: login as site admin at %s\n", demo.LoginURL(d))
+					case <-ctx.Done():
+					}
+				}()
+			}
 			// block until ^C received
-			return d.Start(ctx, make(chan struct{}))
+			return d.Start(ctx, started)
 		},
 	}
 	cmd.SetOut(out)
@@ -76,10 +114,26 @@ func parseFlags(ctx context.Context, args []string, out io.Writer) error {
 	cmd.Flags().StringVar(&cfg.Database, "database", defaultDatabase, "Postgres connection string")
 	cmd.Flags().StringVar(&cfg.Host, "hostname", "", "User-facing hostname for otf")
 	cmd.Flags().StringVar(&cfg.SiteToken, "site-token", "", "API token with site-wide unlimited permissions. Use with care.")
+	cmd.Flags().StringVar(&cfg.RecoveryToken, "recovery-token", "", "One-time token for logging in as site admin, for use when all site admins are locked out.")
 	cmd.Flags().StringSliceVar(&cfg.SiteAdmins, "site-admins", nil, "Promote a list of users to site admin.")
 	cmd.Flags().BytesHexVar(&cfg.Secret, "secret", nil, "Hex-encoded 16 byte secret for cryptographic work. Required.")
 	cmd.Flags().Int64Var(&cfg.MaxConfigSize, "max-config-size", cfg.MaxConfigSize, "Maximum permitted configuration size in bytes.")
+	cmd.Flags().Int64Var(&cfg.MaxConfigUnpackedSize, "max-config-unpacked-size", cfg.MaxConfigUnpackedSize, "Maximum permitted size in bytes of a configuration once decompressed.")
+	cmd.Flags().Int64Var(&cfg.MaxConfigFileSize, "max-config-file-size", cfg.MaxConfigFileSize, "Maximum permitted size in bytes of any one file in a decompressed configuration.")
+	cmd.Flags().Int64Var(&cfg.MaxConfigStorage, "max-config-storage", 0, "Maximum permitted total size in bytes of each organization's configurations. 0 means unlimited.")
+	cmd.Flags().StringVar(&cfg.Blob.Backend, "blob-backend", blob.PostgresBackend, "Backend in which to store blobs: postgres, s3, gcs, or azure.")
+	cmd.Flags().StringVar(&cfg.Blob.Bucket, "blob-bucket", "", "Bucket in which to store blobs. For azure, the name of the container.")
+	cmd.Flags().StringVar(&cfg.Blob.Prefix, "blob-prefix", "", "Prefix prepended to the name of each blob stored in the bucket.")
+	cmd.Flags().StringVar(&cfg.Blob.S3Region, "blob-s3-region", "", "AWS region of the S3 bucket. If unspecified then the region is taken from the environment.")
+	cmd.Flags().StringVar(&cfg.Blob.S3Endpoint, "blob-s3-endpoint", "", "URL of an S3-compatible service, e.g. MinIO.")
+	cmd.Flags().BoolVar(&cfg.Blob.S3UsePathStyle, "blob-s3-use-path-style", false, "Address the S3 bucket in the URL path rather than the hostname.")
+	cmd.Flags().StringVar(&cfg.Blob.AzureAccountURL, "blob-azure-account-url", "", "URL of the Azure storage account, e.g. https://myaccount.blob.core.windows.net")
+
 	cmd.Flags().StringVar(&cfg.WebhookHost, "webhook-hostname", "", "External hostname for otf webhooks")
+	cmd.Flags().DurationVar(&cfg.WebhookClockSkew, "webhook-clock-skew", vcs.DefaultWebhookClockSkew, "Maximum permitted difference between the time a webhook delivery is sent and received. 0 disables the check.")
+	cmd.Flags().StringSliceVar(&cfg.WebhookRequireDate, "webhook-require-date", nil, "Kinds of VCS providers whose webhook deliveries are rejected if they lack a Date header, e.g. github,gitlab.")
+	cmd.Flags().IntVar(&cfg.WebhookRateLimit, "webhook-rate-limit", vcs.DefaultEventRateLimit, "Maximum number of VCS events per minute processed for each repository. 0 means no limit.")
+	cmd.Flags().IntVar(&cfg.VCSMaxConcurrentRequests, "vcs-max-concurrent-requests", vcs.DefaultMaxConcurrentRequests, "Maximum number of concurrent requests made to each VCS provider. 0 means no limit.")
 
 	cmd.Flags().IntVar(&cfg.CacheConfig.Size, "cache-size", 0, "Maximum cache size in MB. 0 means unlimited size.")
 	cmd.Flags().DurationVar(&cfg.CacheConfig.TTL, "cache-expiry", internal.DefaultCacheTTL, "Cache entry TTL.")
@@ -89,14 +143,19 @@ func parseFlags(ctx context.Context, args []string, out io.Writer) error {
 	cmd.Flags().StringVar(&cfg.KeyFile, "key-file", "", "Path to SSL key (required if enabling SSL)")
 	cmd.Flags().BoolVar(&cfg.EnableRequestLogging, "log-http-requests", false, "Log HTTP requests")
 	cmd.Flags().BoolVar(&cfg.DevMode, "dev-mode", false, "Enable developer mode.")
+	cmd.Flags().BoolVar(&demoMode, "demo", false, "Run in demo mode, with an ephemeral database seeded with sample data. Not for production use.")
 
 	cmd.Flags().StringVar(&cfg.GithubHostname, "github-hostname", github.DefaultHostname, "github hostname")
 	cmd.Flags().StringVar(&cfg.GithubClientID, "github-client-id", "", "github client ID")
 	cmd.Flags().StringVar(&cfg.GithubClientSecret, "github-client-secret", "", "github client secret")
 
 	cmd.Flags().StringVar(&cfg.GitlabHostname, "gitlab-hostname", gitlab.DefaultHostname, "gitlab hostname")
+	cmd.Flags().StringVar(&cfg.BitbucketHostname, "bitbucket-hostname", bitbucket.DefaultHostname, "bitbucket hostname")
+	cmd.Flags().StringVar(&cfg.BitbucketServerHostname, "bitbucket-server-hostname", "", "bitbucket server or data center hostname")
+	cmd.Flags().StringVar(&cfg.GiteaHostname, "gitea-hostname", "", "gitea or forgejo hostname")
 	cmd.Flags().StringVar(&cfg.GitlabClientID, "gitlab-client-id", "", "gitlab client ID")
 	cmd.Flags().StringVar(&cfg.GitlabClientSecret, "gitlab-client-secret", "", "gitlab client secret")
+	cmd.Flags().StringSliceVar(&cfg.GitlabWebhookGroups, "gitlab-webhook-groups", nil, "gitlab groups on which a single webhook is created for all of their repositories, in place of a webhook on each repository")
 
 	cmd.Flags().StringVar(&cfg.OIDC.Name, "oidc-name", "", "User friendly OIDC name")
 	cmd.Flags().StringVar(&cfg.OIDC.IssuerURL, "oidc-issuer-url", "", "OIDC issuer URL")
@@ -105,6 +164,36 @@ func parseFlags(ctx context.Context, args []string, out io.Writer) error {
 	cmd.Flags().StringSliceVar(&cfg.OIDC.Scopes, "oidc-scopes", authenticator.DefaultOIDCScopes, "OIDC scopes")
 	cmd.Flags().StringVar(&cfg.OIDC.UsernameClaim, "oidc-username-claim", string(authenticator.DefaultUsernameClaim), "OIDC claim to be used for username (name, email, or sub)")
 
+	cmd.Flags().StringVar(&cfg.Email.Host, "smtp-host", "", "SMTP server hostname. If unspecified then no email is sent.")
+	cmd.Flags().IntVar(&cfg.Email.Port, "smtp-port", email.DefaultPort, "SMTP server port")
+	cmd.Flags().StringVar(&cfg.Email.Username, "smtp-username", "", "SMTP username. If unspecified then no authentication is performed.")
+	cmd.Flags().StringVar(&cfg.Email.Password, "smtp-password", "", "SMTP password")
+	cmd.Flags().StringVar(&cfg.Email.From, "smtp-from", "", "Address from which email is sent")
+	cmd.Flags().DurationVar(&cfg.Email.DigestInterval, "email-digest-interval", email.DefaultDigestInterval, "Period over which notifications are collected into a single digest email")
+
+	cmd.Flags().StringSliceVar(&cfg.OrganizationMetrics.Labels, "org-metrics-labels", orgmetrics.DefaultLabels, "Labels to include in organization metrics: organization and/or workspace.")
+	cmd.Flags().IntVar(&cfg.OrganizationMetrics.MaxSeries, "org-metrics-max-series", orgmetrics.DefaultMaxSeries, "Maximum number of metric series per organization. 0 means no maximum.")
+
+	cmd.Flags().StringToStringVar(&cfg.FeatureFlags.Overrides, "feature-flags", nil, "Enable or disable feature flags for the whole installation, overriding flags set via the API, e.g. drift-detection=false.")
+	cmd.Flags().StringVar(&cfg.ChangeTickets.WebhookURL, "change-ticket-webhook-url", "", "URL of an adapter to which requests to open change tickets are sent for runs awaiting confirmation. If unspecified then no change tickets are opened.")
+	cmd.Flags().StringVar(&cfg.ChangeTickets.HMACKey, "change-ticket-hmac-key", "", "Key with which to sign requests sent to the change ticket adapter.")
+	cmd.Flags().StringVar(&cfg.ResourceChanges.WebhookURL, "resource-change-webhook-url", "", "URL of an external service, such as a CMDB, to which changes made to resources by applies are sent. If unspecified then changes are not sent.")
+	cmd.Flags().StringVar(&cfg.ResourceChanges.HMACKey, "resource-change-hmac-key", "", "Key with which to sign requests sent to the resource change webhook.")
+	cmd.Flags().StringVar(&cfg.OrganizationExport.Sink, "org-export-sink", "", "Sink to which changes made to organizations with an export stream are exported: s3 or kafka. If unspecified then export streams cannot be enabled.")
+	cmd.Flags().StringVar(&cfg.OrganizationExport.S3Bucket, "org-export-s3-bucket", "", "S3 bucket to which organization changes are exported.")
+	cmd.Flags().StringVar(&cfg.OrganizationExport.S3Prefix, "org-export-s3-prefix", "", "Prefix prepended to the name of each object of organization changes stored in the S3 bucket.")
+	cmd.Flags().StringVar(&cfg.OrganizationExport.S3Region, "org-export-s3-region", "", "AWS region of the organization export S3 bucket. If unspecified then the region is taken from the environment.")
+	cmd.Flags().StringVar(&cfg.OrganizationExport.S3Endpoint, "org-export-s3-endpoint", "", "URL of an S3-compatible service, e.g. MinIO, to which organization changes are exported.")
+	cmd.Flags().BoolVar(&cfg.OrganizationExport.S3UsePathStyle, "org-export-s3-use-path-style", false, "Address the organization export S3 bucket in the URL path rather than the hostname.")
+	cmd.Flags().StringVar(&cfg.OrganizationExport.KafkaRESTURL, "org-export-kafka-rest-url", "", "URL of a Kafka REST proxy through which organization changes are exported.")
+	cmd.Flags().StringVar(&cfg.OrganizationExport.KafkaTopic, "org-export-kafka-topic", "", "Kafka topic to which organization changes are exported.")
+	cmd.Flags().DurationVar(&cfg.ForceCancelCoolOff, "force-cancel-cool-off", run.DefaultForceCancelCoolOff, "Period that must elapse following the cancelation of a run before it can be forceably canceled.")
+	cmd.Flags().StringVar(&cfg.OPABinary, "opa-binary", "opa", "Path to the opa binary with which OPA policy sets are evaluated. If the binary cannot be found then OPA policy sets are not evaluated.")
+	cmd.Flags().StringSliceVar(&cfg.RunAnnotationWebhooks, "run-annotation-webhooks", nil, "URLs of external services to which planned runs are sent to be annotated.")
+
+	cmd.Flags().IntVar(&cfg.WorkspaceRenameGraceDays, "workspace-rename-grace-days", workspace.DefaultRenameGraceDays, "Number of days for which a renamed workspace can be found using its former name. 0 disables redirects.")
+	cmd.Flags().IntVar(&cfg.WorkspaceRecoveryDays, "workspace-recovery-days", workspace.DefaultRecoveryDays, "Number of days for which a deleted workspace can be restored. 0 disables recovery.")
+
 	cmd.Flags().BoolVar(&cfg.RestrictOrganizationCreation, "restrict-org-creation", false, "Restrict organization creation capability to site admin role")
 
 	cmd.Flags().StringVar(&cfg.GoogleIAPConfig.Audience, "google-jwt-audience", "", "The Google JWT audience claim for validation. If unspecified then validation is skipped")
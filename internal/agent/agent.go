@@ -9,6 +9,7 @@ import (
 	"time"
 
 	"github.com/leg100/otf/internal"
+	"github.com/leg100/otf/internal/resource"
 )
 
 var (
@@ -77,7 +78,7 @@ type registrar struct {
 
 func (f *registrar) register(ctx context.Context, opts registerAgentOptions) (*Agent, error) {
 	agent := &Agent{
-		ID:          internal.NewID("agent"),
+		ID:          resource.NewID(resource.AgentKind),
 		Name:        opts.Name,
 		Version:     opts.Version,
 		MaxJobs:     opts.Concurrency,
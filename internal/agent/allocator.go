@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"slices"
+	"time"
 
 	"github.com/leg100/otf/internal/logr"
 	"github.com/leg100/otf/internal/pubsub"
@@ -13,6 +14,10 @@ import (
 // time.
 const AllocatorLockID int64 = 5577006791947779412
 
+// defaultJobLeaseTimeout is the default length of time an agent has to start
+// a job allocated to it before the job is made visible to other agents.
+const defaultJobLeaseTimeout = 2 * time.Minute
+
 // allocator allocates jobs to agents. Only one allocator must be active on
 // an OTF cluster at any one time.
 type allocator struct {
@@ -26,6 +31,13 @@ type allocator struct {
 	agents map[string]*Agent
 	// jobs awaiting allocation to an agent, keyed by job ID
 	jobs map[JobSpec]*Job
+	// leases on allocated jobs, keyed by job ID: the time by which the agent
+	// must start the job before it is re-allocated to another agent.
+	leases map[JobSpec]time.Time
+	// length of time an agent has to start a job allocated to it. Leases are
+	// checked whenever the allocator receives an event, and agents ping every
+	// few seconds, so there is no need for a separate timer.
+	leaseTimeout time.Duration
 }
 
 type allocatorClient interface {
@@ -119,8 +131,14 @@ func (a *allocator) seed(pools []*Pool, agents []*Agent, jobs []*Job) {
 		a.agents[agent.ID] = agent
 	}
 	a.jobs = make(map[JobSpec]*Job, len(jobs))
+	a.leases = make(map[JobSpec]time.Time)
 	for _, job := range jobs {
 		a.jobs[job.Spec] = job
+		if job.Status == JobAllocated {
+			// the allocator doesn't know when the job was allocated, so
+			// grant its agent a fresh lease.
+			a.leases[job.Spec] = time.Now().Add(a.leaseTimeout)
+		}
 	}
 }
 
@@ -139,19 +157,26 @@ func (a *allocator) allocate(ctx context.Context) error {
 				return fmt.Errorf("agent %s not found in cache", *job.AgentID)
 			}
 			if agent.Status == AgentIdle || agent.Status == AgentBusy {
-				// agent still healthy, wait for agent to start job
-				continue
+				if time.Now().Before(a.leases[job.Spec]) {
+					// agent still healthy, wait for agent to start job
+					continue
+				}
+				// agent is healthy but has failed to start the job before
+				// its lease expired, e.g. because the agent never received
+				// the job, so try reallocating job to another agent.
 			}
-			// another no longer healthy, try reallocating job to another another
+			// agent no longer healthy, try reallocating job to another agent
 			reallocate = true
 		case JobFinished, JobCanceled, JobErrored:
 			// job has completed: remove and adjust number of current jobs
 			// agents has
 			delete(a.jobs, job.Spec)
+			delete(a.leases, job.Spec)
 			a.agents[*job.AgentID].CurrentJobs--
 			continue
 		default:
 			// job running; ignore
+			delete(a.leases, job.Spec)
 			continue
 		}
 		// allocate job to available agent
@@ -161,6 +186,10 @@ func (a *allocator) allocate(ctx context.Context) error {
 				// skip agents that are not ready for jobs
 				continue
 			}
+			// skip agent the job is currently allocated to
+			if reallocate && agent.ID == *job.AgentID {
+				continue
+			}
 			// skip agents with insufficient capacity
 			if agent.CurrentJobs == agent.MaxJobs {
 				continue
@@ -181,6 +210,11 @@ func (a *allocator) allocate(ctx context.Context) error {
 			available = append(available, agent)
 		}
 		if len(available) == 0 {
+			if reallocate && time.Now().After(a.leases[job.Spec]) {
+				// no other agent can take the job, so leave it with its
+				// current agent and renew the lease.
+				a.leases[job.Spec] = time.Now().Add(a.leaseTimeout)
+			}
 			a.Error(nil, "no available agents found for job", "job", job)
 			continue
 		}
@@ -212,6 +246,7 @@ func (a *allocator) allocate(ctx context.Context) error {
 			}
 		}
 		a.jobs[job.Spec] = updatedJob
+		a.leases[job.Spec] = time.Now().Add(a.leaseTimeout)
 		a.agents[agent.ID].CurrentJobs++
 	}
 	return nil
@@ -56,6 +56,9 @@ func TestAllocator_allocate(t *testing.T) {
 		wantJob *Job
 		// want these agents after allocation
 		wantAgents map[string]*Agent
+		// lease timeout granted to allocated jobs; a negative timeout
+		// expires leases immediately
+		leaseTimeout time.Duration
 	}{
 		{
 			name: "allocate job to server agent",
@@ -155,6 +158,70 @@ func TestAllocator_allocate(t *testing.T) {
 				"agent-idle":    {ID: "agent-idle", Status: AgentIdle, MaxJobs: 1, CurrentJobs: 1},
 			},
 		},
+		{
+			name: "wait for healthy agent to start job before its lease expires",
+			agents: []*Agent{
+				{ID: "agent-1", Status: AgentIdle, MaxJobs: 1, CurrentJobs: 1},
+				{ID: "agent-2", Status: AgentIdle, MaxJobs: 1, CurrentJobs: 0},
+			},
+			job: &Job{
+				Spec:    JobSpec{RunID: "run-123", Phase: internal.PlanPhase},
+				Status:  JobAllocated,
+				AgentID: internal.String("agent-1"),
+			},
+			leaseTimeout: time.Minute,
+			wantJob: &Job{
+				Spec:    JobSpec{RunID: "run-123", Phase: internal.PlanPhase},
+				Status:  JobAllocated,
+				AgentID: internal.String("agent-1"),
+			},
+			wantAgents: map[string]*Agent{
+				"agent-1": {ID: "agent-1", Status: AgentIdle, MaxJobs: 1, CurrentJobs: 1},
+				"agent-2": {ID: "agent-2", Status: AgentIdle, MaxJobs: 1, CurrentJobs: 0},
+			},
+		},
+		{
+			name: "re-allocate job whose lease has expired",
+			agents: []*Agent{
+				{ID: "agent-1", Status: AgentIdle, MaxJobs: 1, CurrentJobs: 1},
+				{ID: "agent-2", Status: AgentIdle, MaxJobs: 1, CurrentJobs: 0},
+			},
+			job: &Job{
+				Spec:    JobSpec{RunID: "run-123", Phase: internal.PlanPhase},
+				Status:  JobAllocated,
+				AgentID: internal.String("agent-1"),
+			},
+			leaseTimeout: -time.Minute,
+			wantJob: &Job{
+				Spec:    JobSpec{RunID: "run-123", Phase: internal.PlanPhase},
+				Status:  JobAllocated,
+				AgentID: internal.String("agent-2"),
+			},
+			wantAgents: map[string]*Agent{
+				"agent-1": {ID: "agent-1", Status: AgentIdle, MaxJobs: 1, CurrentJobs: 0},
+				"agent-2": {ID: "agent-2", Status: AgentIdle, MaxJobs: 1, CurrentJobs: 1},
+			},
+		},
+		{
+			name: "keep job with agent whose lease has expired when no other agent is available",
+			agents: []*Agent{
+				{ID: "agent-1", Status: AgentIdle, MaxJobs: 1, CurrentJobs: 1},
+			},
+			job: &Job{
+				Spec:    JobSpec{RunID: "run-123", Phase: internal.PlanPhase},
+				Status:  JobAllocated,
+				AgentID: internal.String("agent-1"),
+			},
+			leaseTimeout: -time.Minute,
+			wantJob: &Job{
+				Spec:    JobSpec{RunID: "run-123", Phase: internal.PlanPhase},
+				Status:  JobAllocated,
+				AgentID: internal.String("agent-1"),
+			},
+			wantAgents: map[string]*Agent{
+				"agent-1": {ID: "agent-1", Status: AgentIdle, MaxJobs: 1, CurrentJobs: 1},
+			},
+		},
 		{
 			name:   "de-allocate finished job",
 			agents: []*Agent{{ID: "agent-1", CurrentJobs: 1}},
@@ -182,6 +249,7 @@ func TestAllocator_allocate(t *testing.T) {
 				client: &fakeService{
 					job: tt.job,
 				},
+				leaseTimeout: tt.leaseTimeout,
 			}
 			a.seed(tt.pools, tt.agents, []*Job{tt.job})
 			err := a.allocate(context.Background())
@@ -7,6 +7,7 @@ import (
 	"net/http"
 
 	"github.com/gorilla/mux"
+	"github.com/leg100/otf/internal"
 	otfapi "github.com/leg100/otf/internal/api"
 	"github.com/leg100/otf/internal/http/decode"
 	"github.com/leg100/otf/internal/tfeapi"
@@ -26,6 +27,12 @@ type (
 		JobSpec
 		finishJobOptions
 	}
+
+	placementConstraintsParams struct {
+		// Labels of the form key=value that an agent pool must carry in order
+		// to run the workspace's runs.
+		Constraints []string `json:"constraints"`
+	}
 )
 
 func (a *api) addHandlers(r *mux.Router) {
@@ -34,12 +41,17 @@ func (a *api) addHandlers(r *mux.Router) {
 	// agents
 	r.HandleFunc("/agents/register", a.registerAgent).Methods("POST")
 	r.HandleFunc("/agents/jobs", a.getJobs).Methods("GET")
+	r.HandleFunc("/agents/status", a.getStatus).Methods("GET")
 	r.HandleFunc("/agents/status", a.updateStatus).Methods("POST")
 	r.HandleFunc("/agents/start", a.startJob).Methods("POST")
 	r.HandleFunc("/agents/finish", a.finishJob).Methods("POST")
 
 	// agent tokens
 	r.HandleFunc("/agent-tokens/{pool_id}/create", a.createAgentToken).Methods("POST")
+
+	// workspace placement constraints
+	r.HandleFunc("/workspaces/{workspace_id}/placement-constraints", a.getPlacementConstraints).Methods("GET")
+	r.HandleFunc("/workspaces/{workspace_id}/placement-constraints", a.setPlacementConstraints).Methods("PUT")
 }
 
 func (a *api) registerAgent(w http.ResponseWriter, r *http.Request) {
@@ -49,8 +61,11 @@ func (a *api) registerAgent(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// determine ip address from connection source address
-	opts.IPAddress = net.ParseIP(r.RemoteAddr)
+	// determine ip address from connection source address, which includes
+	// the port.
+	if host, _, err := net.SplitHostPort(r.RemoteAddr); err == nil {
+		opts.IPAddress = net.ParseIP(host)
+	}
 
 	agent, err := a.Service.registerAgent(r.Context(), opts)
 	if err != nil {
@@ -78,6 +93,25 @@ func (a *api) getJobs(w http.ResponseWriter, r *http.Request) {
 	a.Respond(w, r, jobs, http.StatusOK)
 }
 
+// getStatus returns the calling agent as the server sees it, letting the agent
+// check its status and when its last ping was received.
+func (a *api) getStatus(w http.ResponseWriter, r *http.Request) {
+	// retrieve subject, which contains ID of calling agent
+	subject, err := poolAgentFromContext(r.Context())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusUnprocessableEntity)
+		return
+	}
+
+	agent, err := a.Service.getAgent(r.Context(), subject.agent.ID)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+
+	a.Respond(w, r, agent, http.StatusOK)
+}
+
 // updateStatus receives a status update from an agent
 func (a *api) updateStatus(w http.ResponseWriter, r *http.Request) {
 	// retrieve subject, which contains ID of calling agent
@@ -95,7 +129,9 @@ func (a *api) updateStatus(w http.ResponseWriter, r *http.Request) {
 
 	err = a.Service.updateAgentStatus(r.Context(), subject.agent.ID, params.Status)
 	if err != nil {
-		if errors.Is(err, ErrInvalidAgentStateTransition) {
+		// a conflict informs the agent that the server has de-registered it
+		// and that it needs to re-register.
+		if errors.Is(err, ErrInvalidAgentStateTransition) || errors.Is(err, internal.ErrConflict) {
 			tfeapi.Error(w, err)
 		} else {
 			http.Error(w, err.Error(), http.StatusInternalServerError)
@@ -149,3 +185,38 @@ func (a *api) finishJob(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 }
+
+func (a *api) getPlacementConstraints(w http.ResponseWriter, r *http.Request) {
+	workspaceID, err := decode.Param("workspace_id", r)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	constraints, err := a.GetWorkspacePlacementConstraints(r.Context(), workspaceID)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(placementConstraintsParams{Constraints: constraints})
+}
+
+func (a *api) setPlacementConstraints(w http.ResponseWriter, r *http.Request) {
+	workspaceID, err := decode.Param("workspace_id", r)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	var params placementConstraintsParams
+	if err := json.NewDecoder(r.Body).Decode(&params); err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	constraints, err := a.SetWorkspacePlacementConstraints(r.Context(), workspaceID, params.Constraints)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(placementConstraintsParams{Constraints: constraints})
+}
@@ -0,0 +1,60 @@
+package agent
+
+import (
+	"io"
+
+	otfrun "github.com/leg100/otf/internal/run"
+)
+
+// applySummarizer consumes the stream of events terraform emits when applying
+// with the -json flag, summarizing the apply, and writing each event to the
+// output as the human-readable message it carries.
+type applySummarizer struct {
+	*eventRenderer
+
+	summary otfrun.ApplySummary
+}
+
+func newApplySummarizer(out io.Writer) *applySummarizer {
+	s := &applySummarizer{}
+	s.eventRenderer = newEventRenderer(out, s.handle)
+	return s
+}
+
+// summarize returns the summary of the apply, which is only complete once
+// terraform has exited.
+func (s *applySummarizer) summarize() (*otfrun.ApplySummary, error) {
+	if err := s.flush(); err != nil {
+		return nil, err
+	}
+	// attribute errors to the resources that failed
+	for i, failure := range s.summary.Failures {
+		for _, diag := range s.diagnostics {
+			if diag.Severity == otfrun.DiagnosticError && diag.Address == failure.Address {
+				s.summary.Failures[i].Summary = diag.Summary
+				break
+			}
+		}
+	}
+	return &s.summary, nil
+}
+
+func (s *applySummarizer) handle(event jsonEvent) bool {
+	switch event.Type {
+	case "apply_complete":
+		switch event.Hook.Action {
+		case "create":
+			s.summary.Resources.Additions++
+		case "update":
+			s.summary.Resources.Changes++
+		case "delete":
+			s.summary.Resources.Destructions++
+		}
+	case "apply_errored":
+		s.summary.Failures = append(s.summary.Failures, otfrun.ApplyFailure{
+			Address: event.Hook.Resource.Addr,
+			Action:  event.Hook.Action,
+		})
+	}
+	return true
+}
@@ -0,0 +1,76 @@
+package agent
+
+import (
+	"bytes"
+	"os"
+	"testing"
+
+	otfrun "github.com/leg100/otf/internal/run"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestApplySummarizer(t *testing.T) {
+	events, err := os.ReadFile("./testdata/apply.json")
+	require.NoError(t, err)
+
+	var out bytes.Buffer
+	summarizer := newApplySummarizer(&out)
+	// write events in small chunks to check lines split across writes are
+	// handled.
+	for i := 0; i < len(events); i += 100 {
+		_, err := summarizer.Write(events[i:min(i+100, len(events))])
+		require.NoError(t, err)
+	}
+	got, err := summarizer.summarize()
+	require.NoError(t, err)
+
+	want := &otfrun.ApplySummary{
+		Resources: otfrun.Report{Additions: 1, Destructions: 1},
+		Failures: []otfrun.ApplyFailure{
+			{
+				Address: "aws_instance.web",
+				Action:  "create",
+				Summary: `creating EC2 Instance: InvalidAMIID.Malformed: Invalid id: "foo"`,
+			},
+		},
+	}
+	assert.Equal(t, want, got)
+
+	// the output is rendered in human-readable form, with diagnostics framed
+	// as terraform would frame them.
+	assert.Contains(t, out.String(), "random_pet.pet: Creation complete after 0s [id=hopeful-cat]\n")
+	assert.Equal(t, []otfrun.Diagnostic{
+		{
+			Severity: otfrun.DiagnosticError,
+			Summary:  `creating EC2 Instance: InvalidAMIID.Malformed: Invalid id: "foo"`,
+			Detail:   "status code: 400, request id: 6b8d3a1e",
+			Address:  "aws_instance.web",
+			Range:    &otfrun.DiagnosticRange{Filename: "main.tf", Line: 12},
+		},
+	}, summarizer.diagnostics)
+	assert.Contains(t, out.String(), `╷
+│ Error: creating EC2 Instance: InvalidAMIID.Malformed: Invalid id: "foo"
+│
+│   with aws_instance.web,
+│   on main.tf line 12:
+│
+│ status code: 400, request id: 6b8d3a1e
+╵
+`)
+}
+
+func TestApplySummarizer_NotJSON(t *testing.T) {
+	var out bytes.Buffer
+	summarizer := newApplySummarizer(&out)
+	_, err := summarizer.Write([]byte("panic: oops\n"))
+	require.NoError(t, err)
+	_, err = summarizer.Write([]byte(`{"@message":"Apply complete! Resources: 0 added, 0 changed, 0 destroyed.","type":"change_summary"}`))
+	require.NoError(t, err)
+
+	got, err := summarizer.summarize()
+	require.NoError(t, err)
+
+	assert.Equal(t, &otfrun.ApplySummary{}, got)
+	assert.Equal(t, "panic: oops\nApply complete! Resources: 0 added, 0 changed, 0 destroyed.\n", out.String())
+}
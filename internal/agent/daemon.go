@@ -18,6 +18,7 @@ import (
 	"github.com/leg100/otf/internal/logs"
 	"github.com/leg100/otf/internal/releases"
 	"github.com/leg100/otf/internal/run"
+	"github.com/leg100/otf/internal/sshkey"
 	"github.com/leg100/otf/internal/state"
 	"github.com/leg100/otf/internal/variable"
 	"github.com/leg100/otf/internal/workspace"
@@ -133,6 +134,7 @@ type ServerDaemonOptions struct {
 	RunService                  *run.Service
 	WorkspaceService            *workspace.Service
 	VariableService             *variable.Service
+	SSHKeyService               *sshkey.Service
 	ConfigurationVersionService *configversion.Service
 	StateService                *state.Service
 	LogsService                 *logs.Service
@@ -151,6 +153,7 @@ func NewServerDaemon(logger logr.Logger, cfg Config, opts ServerDaemonOptions) (
 			workspaces: opts.WorkspaceService,
 			state:      opts.StateService,
 			variables:  opts.VariableService,
+			sshkeys:    opts.SSHKeyService,
 			configs:    opts.ConfigurationVersionService,
 			logs:       opts.LogsService,
 			agents:     opts.AgentService,
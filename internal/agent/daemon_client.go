@@ -11,6 +11,7 @@ import (
 	"github.com/leg100/otf/internal/logr"
 	"github.com/leg100/otf/internal/logs"
 	"github.com/leg100/otf/internal/run"
+	"github.com/leg100/otf/internal/sshkey"
 	"github.com/leg100/otf/internal/state"
 	"github.com/leg100/otf/internal/variable"
 	"github.com/leg100/otf/internal/workspace"
@@ -22,6 +23,7 @@ type (
 		runs       runClient
 		workspaces workspaceClient
 		variables  variablesClient
+		sshkeys    sshKeyClient
 		agents     agentClient
 		state      stateClient
 		configs    configClient
@@ -50,6 +52,10 @@ type (
 		ListEffectiveVariables(ctx context.Context, runID string) ([]*variable.Variable, error)
 	}
 
+	sshKeyClient interface {
+		GetWorkspaceKey(ctx context.Context, workspaceID string) ([]byte, error)
+	}
+
 	agentClient interface {
 		registerAgent(ctx context.Context, opts registerAgentOptions) (*Agent, error)
 		getAgentJobs(ctx context.Context, agentID string) ([]*Job, error)
@@ -88,6 +94,7 @@ func newRPCDaemonClient(cfg otfapi.Config, agentID *string) (*daemonClient, erro
 		runs:       &run.Client{Client: apiClient},
 		workspaces: &workspace.Client{Client: apiClient},
 		variables:  &variable.Client{Client: apiClient},
+		sshkeys:    &sshkey.Client{Client: apiClient},
 		agents:     &client{Client: apiClient, agentID: agentID},
 		state:      &state.Client{Client: apiClient},
 		configs:    &configversion.Client{Client: apiClient},
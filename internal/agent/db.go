@@ -16,6 +16,7 @@ type poolresult struct {
 	CreatedAt           pgtype.Timestamptz `json:"created_at"`
 	OrganizationName    pgtype.Text        `json:"organization_name"`
 	OrganizationScoped  pgtype.Bool        `json:"organization_scoped"`
+	Labels              []string           `json:"labels"`
 	WorkspaceIds        []string           `json:"workspace_ids"`
 	AllowedWorkspaceIds []string           `json:"allowed_workspace_ids"`
 }
@@ -29,6 +30,7 @@ func (r poolresult) toPool() *Pool {
 		OrganizationScoped: r.OrganizationScoped.Bool,
 		AssignedWorkspaces: r.WorkspaceIds,
 		AllowedWorkspaces:  r.AllowedWorkspaceIds,
+		Labels:             r.Labels,
 	}
 }
 
@@ -128,6 +130,7 @@ func (db *db) createPool(ctx context.Context, pool *Pool) error {
 			CreatedAt:          sql.Timestamptz(pool.CreatedAt),
 			OrganizationName:   sql.String(pool.Organization),
 			OrganizationScoped: sql.Bool(pool.OrganizationScoped),
+			Labels:             pool.Labels,
 		})
 		if err != nil {
 			return err
@@ -151,6 +154,7 @@ func (db *db) updatePool(ctx context.Context, pool *Pool) error {
 		PoolID:             sql.String(pool.ID),
 		Name:               sql.String(pool.Name),
 		OrganizationScoped: sql.Bool(pool.OrganizationScoped),
+		Labels:             pool.Labels,
 	})
 	if err != nil {
 		return sql.Error(err)
@@ -227,6 +231,34 @@ func (db *db) deleteAgentPool(ctx context.Context, poolID string) error {
 	return nil
 }
 
+// workspace placement constraints
+
+func (db *db) setPlacementConstraints(ctx context.Context, workspaceID string, constraints []string) error {
+	return db.Tx(ctx, func(ctx context.Context, q pggen.Querier) error {
+		if _, err := q.DeleteWorkspacePlacementConstraints(ctx, sql.String(workspaceID)); err != nil {
+			return sql.Error(err)
+		}
+		for _, label := range constraints {
+			if _, err := q.InsertWorkspacePlacementConstraint(ctx, sql.String(workspaceID), sql.String(label)); err != nil {
+				return sql.Error(err)
+			}
+		}
+		return nil
+	})
+}
+
+func (db *db) getPlacementConstraints(ctx context.Context, workspaceID string) ([]string, error) {
+	rows, err := db.Conn(ctx).FindWorkspacePlacementConstraints(ctx, sql.String(workspaceID))
+	if err != nil {
+		return nil, sql.Error(err)
+	}
+	constraints := make([]string, len(rows))
+	for i, r := range rows {
+		constraints[i] = r.String
+	}
+	return constraints, nil
+}
+
 // agents
 
 func (db *db) createAgent(ctx context.Context, agent *Agent) error {
@@ -329,13 +361,21 @@ func (db *db) deleteAgent(ctx context.Context, agentID string) error {
 	return sql.Error(err)
 }
 
+// createJob creates a job, replacing any existing job for the same run phase,
+// which exists only if the phase is being retried.
 func (db *db) createJob(ctx context.Context, job *Job) error {
-	_, err := db.Conn(ctx).InsertJob(ctx, pggen.InsertJobParams{
-		RunID:  sql.String(job.Spec.RunID),
-		Phase:  sql.String(string(job.Spec.Phase)),
-		Status: sql.String(string(job.Status)),
+	return db.Tx(ctx, func(ctx context.Context, q pggen.Querier) error {
+		_, err := q.DeleteJob(ctx, sql.String(job.Spec.RunID), sql.String(string(job.Spec.Phase)))
+		if err != nil {
+			return sql.Error(err)
+		}
+		_, err = q.InsertJob(ctx, pggen.InsertJobParams{
+			RunID:  sql.String(job.Spec.RunID),
+			Phase:  sql.String(string(job.Spec.Phase)),
+			Status: sql.String(string(job.Status)),
+		})
+		return sql.Error(err)
 	})
-	return sql.Error(err)
 }
 
 func (db *db) getAllocatedAndSignaledJobs(ctx context.Context, agentID string) ([]*Job, error) {
@@ -357,6 +397,18 @@ func (db *db) getAllocatedAndSignaledJobs(ctx context.Context, agentID string) (
 	return jobs, nil
 }
 
+func (db *db) getRunningJobs(ctx context.Context, agentID string) ([]*Job, error) {
+	rows, err := db.Conn(ctx).FindRunningJobs(ctx, sql.String(agentID))
+	if err != nil {
+		return nil, sql.Error(err)
+	}
+	jobs := make([]*Job, len(rows))
+	for i, r := range rows {
+		jobs[i] = jobresult(r).toJob()
+	}
+	return jobs, nil
+}
+
 func (db *db) getJob(ctx context.Context, spec JobSpec) (*Job, error) {
 	result, err := db.Conn(ctx).FindJob(ctx, sql.String(spec.RunID), sql.String(string(spec.Phase)))
 	if err != nil {
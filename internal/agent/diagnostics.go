@@ -0,0 +1,79 @@
+package agent
+
+import (
+	"fmt"
+	"strings"
+
+	otfrun "github.com/leg100/otf/internal/run"
+)
+
+// jsonDiagnostic is a diagnostic reported by terraform in a diagnostic event
+// when run with the -json flag.
+//
+// https://developer.hashicorp.com/terraform/internals/machine-readable-ui#diagnostic
+type jsonDiagnostic struct {
+	Severity string `json:"severity"`
+	Summary  string `json:"summary"`
+	Detail   string `json:"detail"`
+	Address  string `json:"address"`
+	Range    *struct {
+		Filename string `json:"filename"`
+		Start    struct {
+			Line int `json:"line"`
+		} `json:"start"`
+	} `json:"range"`
+}
+
+func (d *jsonDiagnostic) convert() otfrun.Diagnostic {
+	diag := otfrun.Diagnostic{
+		Severity: otfrun.DiagnosticSeverity(d.Severity),
+		Summary:  d.Summary,
+		Detail:   d.Detail,
+		Address:  d.Address,
+	}
+	if d.Range != nil {
+		diag.Range = &otfrun.DiagnosticRange{
+			Filename: d.Range.Filename,
+			Line:     d.Range.Start.Line,
+		}
+	}
+	return diag
+}
+
+// renderDiagnostic renders a diagnostic in the same frame in which terraform
+// renders diagnostics in its human-readable output, e.g.:
+//
+//	╷
+//	│ Error: Invalid value
+//	│
+//	│   with aws_instance.web,
+//	│   on main.tf line 12:
+//	│
+//	│ The AMI ID is invalid.
+//	╵
+func renderDiagnostic(diag otfrun.Diagnostic) string {
+	var b strings.Builder
+	b.WriteString("╷\n")
+	severity := string(diag.Severity)
+	if severity != "" {
+		severity = strings.ToUpper(severity[:1]) + severity[1:]
+	}
+	fmt.Fprintf(&b, "│ %s: %s\n", severity, diag.Summary)
+	if diag.Address != "" || diag.Range != nil {
+		b.WriteString("│\n")
+		if diag.Address != "" {
+			fmt.Fprintf(&b, "│   with %s,\n", diag.Address)
+		}
+		if diag.Range != nil {
+			fmt.Fprintf(&b, "│   on %s line %d:\n", diag.Range.Filename, diag.Range.Line)
+		}
+	}
+	if diag.Detail != "" {
+		b.WriteString("│\n")
+		for _, line := range strings.Split(diag.Detail, "\n") {
+			fmt.Fprintf(&b, "│ %s\n", line)
+		}
+	}
+	b.WriteString("╵\n")
+	return b.String()
+}
@@ -0,0 +1,135 @@
+package agent
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	otfrun "github.com/leg100/otf/internal/run"
+)
+
+type (
+	// eventRenderer consumes the stream of events terraform emits when run
+	// with the -json flag, collecting the diagnostics it reports, and writing
+	// each event to the output as the human-readable message it carries.
+	eventRenderer struct {
+		out io.Writer
+		// partial line awaiting its newline
+		buf []byte
+		// handle is invoked with each event, returning false if the event
+		// is not to be written to the output.
+		handle func(event jsonEvent) bool
+
+		diagnostics []otfrun.Diagnostic
+	}
+
+	// jsonEvent is the subset of an event emitted by terraform that is of
+	// interest to otf.
+	//
+	// https://developer.hashicorp.com/terraform/internals/machine-readable-ui
+	jsonEvent struct {
+		Message    string                `json:"@message"`
+		Type       string                `json:"type"`
+		Hook       jsonHook              `json:"hook"`
+		Diagnostic *jsonDiagnostic       `json:"diagnostic"`
+		Outputs    map[string]jsonOutput `json:"outputs"`
+	}
+
+	jsonHook struct {
+		Resource struct {
+			Addr string `json:"addr"`
+		} `json:"resource"`
+		Action string `json:"action"`
+	}
+
+	jsonOutput struct {
+		Sensitive bool            `json:"sensitive"`
+		Value     json.RawMessage `json:"value"`
+	}
+)
+
+func newEventRenderer(out io.Writer, handle func(jsonEvent) bool) *eventRenderer {
+	return &eventRenderer{out: out, handle: handle}
+}
+
+func (r *eventRenderer) Write(p []byte) (int, error) {
+	r.buf = append(r.buf, p...)
+	for {
+		i := bytes.IndexByte(r.buf, '\n')
+		if i < 0 {
+			break
+		}
+		if err := r.handleLine(r.buf[:i]); err != nil {
+			return 0, err
+		}
+		r.buf = r.buf[i+1:]
+	}
+	return len(p), nil
+}
+
+// flush handles any remaining partial line, and should be called once
+// terraform has exited.
+func (r *eventRenderer) flush() error {
+	if len(r.buf) > 0 {
+		if err := r.handleLine(r.buf); err != nil {
+			return err
+		}
+		r.buf = nil
+	}
+	return nil
+}
+
+func (r *eventRenderer) handleLine(line []byte) error {
+	var event jsonEvent
+	if err := json.Unmarshal(line, &event); err != nil {
+		// not an event, e.g. a crash report, so relay it as-is.
+		_, err := fmt.Fprintf(r.out, "%s\n", line)
+		return err
+	}
+	var diag *otfrun.Diagnostic
+	if event.Type == "diagnostic" && event.Diagnostic != nil {
+		converted := event.Diagnostic.convert()
+		r.diagnostics = append(r.diagnostics, converted)
+		diag = &converted
+	}
+	if r.handle != nil && !r.handle(event) {
+		return nil
+	}
+	switch event.Type {
+	case "diagnostic":
+		if diag != nil {
+			_, err := io.WriteString(r.out, renderDiagnostic(*diag))
+			return err
+		}
+	case "outputs":
+		if len(event.Outputs) > 0 {
+			_, err := io.WriteString(r.out, renderOutputs(event.Outputs))
+			return err
+		}
+	}
+	_, err := fmt.Fprintf(r.out, "%s\n", event.Message)
+	return err
+}
+
+// renderOutputs renders outputs in the manner of terraform's human-readable
+// output, albeit with values rendered as JSON.
+func renderOutputs(outputs map[string]jsonOutput) string {
+	var b strings.Builder
+	b.WriteString("\nOutputs:\n\n")
+	names := make([]string, 0, len(outputs))
+	for name := range outputs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if outputs[name].Sensitive {
+			fmt.Fprintf(&b, "%s = <sensitive>\n", name)
+			continue
+		}
+		fmt.Fprintf(&b, "%s = %s\n", name, outputs[name].Value)
+	}
+	return b.String()
+}
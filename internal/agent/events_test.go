@@ -0,0 +1,76 @@
+package agent
+
+import (
+	"bytes"
+	"os"
+	"testing"
+
+	otfrun "github.com/leg100/otf/internal/run"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEventRenderer(t *testing.T) {
+	events, err := os.ReadFile("./testdata/plan.json")
+	require.NoError(t, err)
+
+	var out bytes.Buffer
+	renderer := newEventRenderer(&out, func(event jsonEvent) bool {
+		return event.Type != "planned_change"
+	})
+	// write events in small chunks to check lines split across writes are
+	// handled.
+	for i := 0; i < len(events); i += 100 {
+		_, err := renderer.Write(events[i:min(i+100, len(events))])
+		require.NoError(t, err)
+	}
+	require.NoError(t, renderer.flush())
+
+	want := []otfrun.Diagnostic{
+		{
+			Severity: otfrun.DiagnosticWarning,
+			Summary:  "Argument is deprecated",
+			Detail:   "Use tags instead.",
+			Address:  "aws_instance.web",
+			Range:    &otfrun.DiagnosticRange{Filename: "main.tf", Line: 14},
+		},
+		{
+			Severity: otfrun.DiagnosticError,
+			Summary:  "Unsupported argument",
+			Detail:   "An argument named \"defualt\" is not expected here. Did you mean\n\"default\"?",
+			Range:    &otfrun.DiagnosticRange{Filename: "variables.tf", Line: 3},
+		},
+		{
+			Severity: otfrun.DiagnosticError,
+			Summary:  "Failed to query available provider packages",
+			Detail:   "Could not retrieve the list of available versions for provider\nhashicorp/foo: provider registry registry.terraform.io does not have a\nprovider named registry.terraform.io/hashicorp/foo",
+		},
+	}
+	assert.Equal(t, want, renderer.diagnostics)
+
+	// events are rendered as their messages, bar those that are skipped,
+	// with diagnostics framed as terraform would frame them.
+	assert.Contains(t, out.String(), "random_pet.pet: Refresh complete [id=hopeful-cat]\n")
+	assert.NotContains(t, out.String(), "Plan to create")
+	assert.Contains(t, out.String(), `╷
+│ Warning: Argument is deprecated
+│
+│   with aws_instance.web,
+│   on main.tf line 14:
+│
+│ Use tags instead.
+╵
+`)
+}
+
+func TestEventRenderer_SkippedDiagnostic(t *testing.T) {
+	var out bytes.Buffer
+	renderer := newEventRenderer(&out, func(jsonEvent) bool { return false })
+	_, err := renderer.Write([]byte(`{"@message":"Error: oops","type":"diagnostic","diagnostic":{"severity":"error","summary":"oops"}}`))
+	require.NoError(t, err)
+	require.NoError(t, renderer.flush())
+
+	// diagnostics are collected even if they are not rendered
+	assert.Equal(t, []otfrun.Diagnostic{{Severity: otfrun.DiagnosticError, Summary: "oops"}}, renderer.diagnostics)
+	assert.Empty(t, out.String())
+}
@@ -0,0 +1,81 @@
+package agent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/leg100/otf/internal"
+	otfrun "github.com/leg100/otf/internal/run"
+)
+
+// interruptJobs errors the running jobs of an agent that has stopped
+// responding, e.g. because it or the otfd node hosting it restarted, so that
+// their runs do not remain in a running state indefinitely. Each run is errored
+// with a diagnostic explaining what happened to its state, which in turn
+// notifies the run's subscribers and releases the workspace lock.
+func (s *Service) interruptJobs(ctx context.Context, agentID string) error {
+	ctx = internal.AddSubjectToContext(ctx, &internal.Superuser{Username: "agent-manager"})
+
+	jobs, err := s.db.getRunningJobs(ctx, agentID)
+	if err != nil {
+		return err
+	}
+	for _, job := range jobs {
+		if err := s.interruptJob(ctx, job); err != nil {
+			s.Error(err, "interrupting job", "job", job, "agent_id", agentID)
+			return err
+		}
+		s.V(0).Info("interrupted job of unresponsive agent", "job", job, "agent_id", agentID)
+	}
+	return nil
+}
+
+func (s *Service) interruptJob(ctx context.Context, job *Job) error {
+	run, err := s.phases.Get(ctx, job.Spec.RunID)
+	if err != nil {
+		return err
+	}
+	diag := otfrun.Diagnostic{
+		Phase:    job.Spec.Phase,
+		Severity: otfrun.DiagnosticError,
+		Summary:  fmt.Sprintf("%s interrupted", job.Spec.Phase),
+		Detail:   s.interruptedDetail(ctx, job, run),
+	}
+	_, err = s.db.updateJob(ctx, job.Spec, func(job *Job) error {
+		_, err := s.phases.FinishPhase(ctx, job.Spec.RunID, job.Spec.Phase, otfrun.PhaseFinishOptions{
+			Errored:     true,
+			Diagnostics: []otfrun.Diagnostic{diag},
+		})
+		// the run may have since been canceled, in which case only the job
+		// needs finishing.
+		if err != nil && !errors.Is(err, otfrun.ErrInvalidRunStateTransition) {
+			return err
+		}
+		return job.finishJob(JobErrored)
+	})
+	return err
+}
+
+// interruptedDetail explains the interruption of a job, and for an apply, what
+// state terraform managed to upload before it was interrupted.
+func (s *Service) interruptedDetail(ctx context.Context, job *Job, run *otfrun.Run) string {
+	const msg = "The agent running this job stopped responding, most likely because it or the server restarted."
+	if job.Spec.Phase != internal.ApplyPhase {
+		return msg + " No changes were made; start a new run to try again."
+	}
+	current, err := s.states.GetCurrent(ctx, job.WorkspaceID)
+	if errors.Is(err, internal.ErrResourceNotFound) {
+		return msg + " Terraform uploaded no state before the apply was interrupted, so any resources it created are not recorded in state and may need to be imported."
+	} else if err != nil {
+		s.Error(err, "retrieving state of interrupted apply", "job", job)
+		return msg + " The state of the workspace could not be determined; review it before starting another run."
+	}
+	if started := run.Apply.StartedAt(); !started.IsZero() && current.CreatedAt.After(started) {
+		return fmt.Sprintf("%s Terraform uploaded state (serial %d) at %s, before the apply was interrupted, and it is now the workspace's current state. Any changes made after that are not recorded in state; review them before starting another run.",
+			msg, current.Serial, current.CreatedAt.Format(time.RFC3339))
+	}
+	return fmt.Sprintf("%s Terraform uploaded no state before the apply was interrupted, so the workspace's current state (serial %d) predates it. Any resources the apply changed are not recorded in state and may need to be imported.",
+		msg, current.Serial)
+}
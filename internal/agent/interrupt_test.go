@@ -0,0 +1,77 @@
+package agent
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/leg100/otf/internal"
+	otfrun "github.com/leg100/otf/internal/run"
+	"github.com/leg100/otf/internal/state"
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeCurrentStateClient struct {
+	current *state.Version
+}
+
+func (f *fakeCurrentStateClient) GetCurrent(context.Context, string) (*state.Version, error) {
+	if f.current == nil {
+		return nil, internal.ErrResourceNotFound
+	}
+	return f.current, nil
+}
+
+func TestService_interruptedDetail(t *testing.T) {
+	started := time.Now().Add(-time.Hour)
+	run := &otfrun.Run{
+		Apply: otfrun.Phase{
+			StatusTimestamps: []otfrun.PhaseStatusTimestamp{
+				{Status: otfrun.PhaseRunning, Timestamp: started},
+			},
+		},
+	}
+
+	tests := []struct {
+		name    string
+		phase   internal.PhaseType
+		current *state.Version
+		want    string
+	}{
+		{
+			name:  "plan",
+			phase: internal.PlanPhase,
+			want:  "No changes were made",
+		},
+		{
+			name:  "apply with no state",
+			phase: internal.ApplyPhase,
+			want:  "Terraform uploaded no state before the apply was interrupted, so any resources",
+		},
+		{
+			name:    "apply with state uploaded before apply",
+			phase:   internal.ApplyPhase,
+			current: &state.Version{Serial: 3, CreatedAt: started.Add(-time.Minute)},
+			want:    "current state (serial 3) predates it",
+		},
+		{
+			name:    "apply with state uploaded during apply",
+			phase:   internal.ApplyPhase,
+			current: &state.Version{Serial: 4, CreatedAt: started.Add(time.Minute)},
+			want:    "Terraform uploaded state (serial 4)",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := &Service{
+				Logger: logr.Discard(),
+				states: &fakeCurrentStateClient{current: tt.current},
+			}
+			job := &Job{Spec: JobSpec{Phase: tt.phase}, WorkspaceID: "ws-123"}
+
+			got := svc.interruptedDetail(context.Background(), job, run)
+			assert.Contains(t, got, tt.want)
+		})
+	}
+}
@@ -119,6 +119,9 @@ func (j *Job) LogValue() slog.Value {
 	return slog.GroupValue(attrs...)
 }
 
+// RunID returns the ID of the run on whose behalf the job acts.
+func (j *Job) RunID() string { return j.Spec.RunID }
+
 func (j *Job) Organizations() []string { return nil }
 
 func (j *Job) IsSiteAdmin() bool   { return false }
@@ -130,7 +133,7 @@ func (j *Job) CanAccessSite(action rbac.Action) bool {
 
 func (j *Job) CanAccessOrganization(action rbac.Action, name string) bool {
 	switch action {
-	case rbac.GetOrganizationAction, rbac.GetEntitlementsAction, rbac.GetModuleAction, rbac.ListModulesAction:
+	case rbac.GetOrganizationAction, rbac.GetEntitlementsAction, rbac.GetModuleAction, rbac.ListModulesAction, rbac.GetRegistryProviderAction:
 		return j.Organization == name
 	default:
 		return false
@@ -153,7 +156,7 @@ func (j *Job) CanAccessWorkspace(action rbac.Action, policy internal.WorkspacePo
 	}
 	// allow actions on same workspace as job depending on run phase
 	switch action {
-	case rbac.DownloadStateAction, rbac.GetStateVersionAction, rbac.GetWorkspaceAction, rbac.GetRunAction, rbac.ListVariableSetsAction, rbac.ListWorkspaceVariablesAction, rbac.PutChunkAction, rbac.DownloadConfigurationVersionAction, rbac.GetPlanFileAction, rbac.CancelRunAction:
+	case rbac.DownloadStateAction, rbac.GetStateVersionAction, rbac.GetWorkspaceAction, rbac.GetRunAction, rbac.ListVariableSetsAction, rbac.ListWorkspaceVariablesAction, rbac.PutChunkAction, rbac.DownloadConfigurationVersionAction, rbac.GetPlanFileAction, rbac.CancelRunAction, rbac.GetWorkspaceSSHKeyAction:
 		// any phase
 		return true
 	case rbac.UploadLockFileAction, rbac.UploadPlanFileAction, rbac.ApplyRunAction:
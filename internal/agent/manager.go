@@ -32,6 +32,7 @@ type managerClient interface {
 	listAgents(ctx context.Context) ([]*Agent, error)
 	updateAgentStatus(ctx context.Context, agentID string, status AgentStatus) error
 	deleteAgent(ctx context.Context, agentID string) error
+	interruptJobs(ctx context.Context, agentID string) error
 }
 
 func newManager(s *Service) *manager {
@@ -92,13 +93,21 @@ func (m *manager) update(ctx context.Context, agent *Agent) error {
 		// update agent status from unknown to errored if a further period of 5
 		// minutes has elapsed.
 		if time.Since(agent.LastStatusAt) > 5*time.Minute {
-			// update agent status to errored.
-			return m.client.updateAgentStatus(ctx, agent.ID, AgentErrored)
+			// update agent status to errored, and error any jobs it was
+			// running, because it won't be finishing them.
+			if err := m.client.updateAgentStatus(ctx, agent.ID, AgentErrored); err != nil {
+				return err
+			}
+			return m.client.interruptJobs(ctx, agent.ID)
 		}
 	case AgentErrored, AgentExited:
 		// purge agent from database once a further 1 hour has elapsed for
-		// agents in a terminal state.
+		// agents in a terminal state, first erroring any jobs still running,
+		// which would otherwise be deleted along with the agent.
 		if time.Since(agent.LastStatusAt) > time.Hour {
+			if err := m.client.interruptJobs(ctx, agent.ID); err != nil {
+				return err
+			}
 			return m.client.deleteAgent(ctx, agent.ID)
 		}
 	}
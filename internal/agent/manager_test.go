@@ -13,31 +13,34 @@ func TestManager(t *testing.T) {
 	now := time.Now()
 
 	tests := []struct {
-		name        string
-		agent       *Agent
-		want        AgentStatus
-		wantDeleted bool
+		name            string
+		agent           *Agent
+		want            AgentStatus
+		wantInterrupted bool
+		wantDeleted     bool
 	}{
 		{
 			name:  "no update",
-			agent: &Agent{Status: AgentIdle, LastPingAt: now},
+			agent: &Agent{ID: "agent-123", Status: AgentIdle, LastPingAt: now},
 			want:  "",
 		},
 		{
 			name:  "update from idle to unknown",
-			agent: &Agent{Status: AgentIdle, LastPingAt: now.Add(-pingTimeout).Add(-time.Second)},
+			agent: &Agent{ID: "agent-123", Status: AgentIdle, LastPingAt: now.Add(-pingTimeout).Add(-time.Second)},
 			want:  AgentUnknown,
 		},
 		{
-			name:  "update from unknown to errored",
-			agent: &Agent{Status: AgentUnknown, LastStatusAt: now.Add(-6 * time.Minute)},
-			want:  AgentErrored,
+			name:            "update from unknown to errored",
+			agent:           &Agent{ID: "agent-123", Status: AgentUnknown, LastStatusAt: now.Add(-6 * time.Minute)},
+			want:            AgentErrored,
+			wantInterrupted: true,
 		},
 		{
-			name:        "delete",
-			agent:       &Agent{Status: AgentErrored, LastStatusAt: now.Add(-2 * time.Hour)},
-			want:        "",
-			wantDeleted: true,
+			name:            "delete",
+			agent:           &Agent{ID: "agent-123", Status: AgentErrored, LastStatusAt: now.Add(-2 * time.Hour)},
+			want:            "",
+			wantInterrupted: true,
+			wantDeleted:     true,
 		},
 	}
 	for _, tt := range tests {
@@ -47,6 +50,8 @@ func TestManager(t *testing.T) {
 			err := m.update(context.Background(), tt.agent)
 			require.NoError(t, err)
 			assert.Equal(t, tt.want, svc.status)
+			assert.Equal(t, tt.wantInterrupted, svc.interruptedAgentID == "agent-123")
+			assert.Equal(t, tt.wantDeleted, svc.deletedAgentID == "agent-123")
 		})
 	}
 }
@@ -53,6 +53,10 @@ type operation struct {
 	token         []byte
 	agentID       string
 	isPoolAgent   bool
+	// diagnostics reported by failed terraform commands
+	diagnostics []run.Diagnostic
+	// summary of the apply, if terraform was run to apply changes
+	applySummary *run.ApplySummary
 
 	*workdir
 }
@@ -111,9 +115,12 @@ func (o *operation) doAndFinish() {
 	case err != nil:
 		opts.Status = JobErrored
 		opts.Error = err.Error()
+		opts.Diagnostics = o.diagnostics
+		opts.ApplySummary = o.applySummary
 		o.Error(err, "finished job with error")
 	default:
 		opts.Status = JobFinished
+		opts.ApplySummary = o.applySummary
 		o.V(0).Info("finished job successfully")
 	}
 	if err := o.agents.finishJob(o.ctx, o.job.Spec, opts); err != nil {
@@ -160,6 +167,19 @@ func (o *operation) do() error {
 	}
 	defer wd.close()
 	o.workdir = wd
+	// retrieve SSH key assigned to workspace, if any, and configure git to
+	// use it when cloning module sources.
+	key, err := o.sshkeys.GetWorkspaceKey(o.ctx, ws.ID)
+	if err == nil {
+		keyPath, err := writeSSHKey(key)
+		if err != nil {
+			return fmt.Errorf("writing ssh key: %w", err)
+		}
+		defer os.Remove(keyPath)
+		o.envs = append(o.envs, sshCommandEnv(keyPath))
+	} else if !errors.Is(err, internal.ErrResourceNotFound) {
+		return fmt.Errorf("retrieving ssh key: %w", err)
+	}
 	// retrieve variables and add them to the environment
 	variables, err := o.daemonClient.variables.ListEffectiveVariables(o.ctx, run.ID)
 	if err != nil {
@@ -211,6 +231,7 @@ func (o *operation) do() error {
 	case internal.PlanPhase:
 		steps = append(steps, o.terraformInit)
 		steps = append(steps, o.terraformPlan)
+		steps = append(steps, o.renderPlan)
 		steps = append(steps, o.convertPlanToJSON)
 		steps = append(steps, o.uploadPlan)
 		steps = append(steps, o.uploadJSONPlan)
@@ -272,6 +293,7 @@ type (
 	executionOptions struct {
 		sandboxIfEnabled bool
 		redirectStdout   *string
+		stdout           io.Writer
 	}
 
 	executionOptionFunc func(*executionOptions)
@@ -292,6 +314,13 @@ func redirectStdout(dst string) executionOptionFunc {
 	}
 }
 
+// writeStdout writes stdout to the writer rather than to the output.
+func writeStdout(w io.Writer) executionOptionFunc {
+	return func(e *executionOptions) {
+		e.stdout = w
+	}
+}
+
 // execute executes a process.
 func (o *operation) execute(args []string, funcs ...executionOptionFunc) error {
 	if len(args) == 0 {
@@ -316,6 +345,8 @@ func (o *operation) execute(args []string, funcs ...executionOptionFunc) error {
 		}
 		defer dst.Close()
 		cmd.Stdout = dst
+	} else if opts.stdout != nil {
+		cmd.Stdout = opts.stdout
 	} else {
 		cmd.Stdout = o.out
 	}
@@ -422,12 +453,40 @@ func (o *operation) terraformInit(ctx context.Context) error {
 }
 
 func (o *operation) terraformPlan(ctx context.Context) error {
-	args := []string{"plan"}
+	args := []string{"plan", "-json"}
 	if o.IsDestroy {
 		args = append(args, "-destroy")
 	}
+	if o.RefreshOnly {
+		args = append(args, "-refresh-only")
+	}
 	args = append(args, "-out="+planFilename)
-	return o.execute(append([]string{o.terraformPath}, args...))
+	// emit events in order to collect diagnostics, each of which is rendered
+	// for the output as the message terraform would otherwise have printed,
+	// apart from the planned changes, which are rendered in full afterwards
+	// (see renderPlan).
+	renderer := newEventRenderer(o.out, func(event jsonEvent) bool {
+		switch event.Type {
+		case "planned_change", "resource_drift", "change_summary", "outputs":
+			return false
+		}
+		return true
+	})
+	err := o.execute(append([]string{o.terraformPath}, args...), writeStdout(renderer))
+	if flushErr := renderer.flush(); flushErr != nil {
+		return errors.Join(err, flushErr)
+	}
+	if err != nil {
+		// with the -json flag, terraform reports diagnostics as events
+		// rather than writing them to stderr.
+		o.diagnostics = append(o.diagnostics, renderer.diagnostics...)
+	}
+	return err
+}
+
+// renderPlan writes the plan to the output in human-readable form.
+func (o *operation) renderPlan(ctx context.Context) error {
+	return o.execute([]string{o.terraformPath, "show", planFilename})
 }
 
 func (o *operation) terraformApply(ctx context.Context) (err error) {
@@ -458,12 +517,26 @@ func (o *operation) terraformApply(ctx context.Context) (err error) {
 		}
 	}()
 
-	args := []string{"apply"}
+	// emit events in order to summarize the apply, each of which is rendered
+	// for the output as the message terraform would otherwise have printed.
+	args := []string{"apply", "-json"}
 	if o.IsDestroy {
 		args = append(args, "-destroy")
 	}
 	args = append(args, planFilename)
-	return o.execute(append([]string{o.terraformPath}, args...), sandboxIfEnabled())
+	summarizer := newApplySummarizer(o.out)
+	err = o.execute(append([]string{o.terraformPath}, args...), sandboxIfEnabled(), writeStdout(summarizer))
+	summary, summaryErr := summarizer.summarize()
+	if summaryErr != nil {
+		return errors.Join(err, summaryErr)
+	}
+	o.applySummary = summary
+	if err != nil {
+		// with the -json flag, terraform reports diagnostics as events
+		// rather than writing them to stderr.
+		o.diagnostics = append(o.diagnostics, summarizer.diagnostics...)
+	}
+	return err
 }
 
 func (o *operation) convertPlanToJSON(ctx context.Context) error {
@@ -0,0 +1,160 @@
+package agent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/leg100/otf/internal"
+	"github.com/leg100/otf/internal/rbac"
+	otfrun "github.com/leg100/otf/internal/run"
+)
+
+// parseLabels validates and normalizes a list of labels, each of which must be
+// of the form key=value. Empty entries are ignored, and the returned list is
+// sorted and de-duplicated.
+func parseLabels(labels []string) ([]string, error) {
+	parsed := make([]string, 0, len(labels))
+	for _, label := range labels {
+		label = strings.TrimSpace(label)
+		if label == "" {
+			continue
+		}
+		key, value, found := strings.Cut(label, "=")
+		key, value = strings.TrimSpace(key), strings.TrimSpace(value)
+		if !found || key == "" || value == "" {
+			return nil, fmt.Errorf("invalid label %q: must be of the form key=value", label)
+		}
+		parsed = append(parsed, key+"="+value)
+	}
+	slices.Sort(parsed)
+	return slices.Compact(parsed), nil
+}
+
+// satisfies determines whether the pool carries every label in constraints.
+func (p *Pool) satisfies(constraints []string) bool {
+	for _, label := range constraints {
+		if !slices.Contains(p.Labels, label) {
+			return false
+		}
+	}
+	return true
+}
+
+// SetWorkspacePlacementConstraints replaces the placement constraints for a
+// workspace. Runs for the workspace are then only scheduled on an agent pool
+// carrying every one of the labels in constraints. An empty list removes all
+// constraints.
+func (s *Service) SetWorkspacePlacementConstraints(ctx context.Context, workspaceID string, constraints []string) ([]string, error) {
+	subject, err := s.workspace.CanAccess(ctx, rbac.UpdateWorkspaceAction, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+	constraints, err = parseLabels(constraints)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.db.setPlacementConstraints(ctx, workspaceID, constraints); err != nil {
+		s.Error(err, "setting workspace placement constraints", "workspace_id", workspaceID, "subject", subject)
+		return nil, err
+	}
+	s.V(0).Info("set workspace placement constraints", "workspace_id", workspaceID, "constraints", constraints, "subject", subject)
+	return constraints, nil
+}
+
+func (s *Service) GetWorkspacePlacementConstraints(ctx context.Context, workspaceID string) ([]string, error) {
+	subject, err := s.workspace.CanAccess(ctx, rbac.GetWorkspaceAction, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+	constraints, err := s.db.getPlacementConstraints(ctx, workspaceID)
+	if err != nil {
+		s.Error(err, "retrieving workspace placement constraints", "workspace_id", workspaceID, "subject", subject)
+		return nil, err
+	}
+	s.V(9).Info("retrieved workspace placement constraints", "workspace_id", workspaceID, "subject", subject)
+	return constraints, nil
+}
+
+// checkPlacement determines whether the run can be scheduled, returning an
+// error for which isPlacementError is true if it cannot:
+//
+// * the workspace's placement constraints must be satisfied by the run's agent
+// pool. Server agents carry no labels and are therefore never eligible for a
+// workspace with constraints.
+// * the run's agent pool must still allow the workspace to use the pool.
+// * the run's agent pool must have at least one agent that is online.
+func (s *Service) checkPlacement(ctx context.Context, run *otfrun.Run) error {
+	constraints, err := s.scheduling.getPlacementConstraints(ctx, run.WorkspaceID)
+	if err != nil {
+		return err
+	}
+	if run.AgentPoolID == nil {
+		if len(constraints) > 0 {
+			return fmt.Errorf("%w: %s: workspace is not configured to use an agent pool", ErrNoEligibleAgentPool, strings.Join(constraints, ","))
+		}
+		return nil
+	}
+	pool, err := s.scheduling.getPool(ctx, *run.AgentPoolID)
+	if err != nil {
+		return err
+	}
+	agents, err := s.scheduling.listAgentsByPool(ctx, pool.ID)
+	if err != nil {
+		return err
+	}
+	return pool.place(run.WorkspaceID, constraints, agents)
+}
+
+// place determines whether a job for the workspace can be placed on the pool
+// of agents.
+func (p *Pool) place(workspaceID string, constraints []string, agents []*Agent) error {
+	if !p.allows(workspaceID) {
+		return fmt.Errorf("%w: agent pool %s", ErrWorkspaceNotAllowedToUsePool, p.Name)
+	}
+	if !p.satisfies(constraints) {
+		return fmt.Errorf("%w: %s: agent pool %s has labels: %s", ErrNoEligibleAgentPool, strings.Join(constraints, ","), p.Name, strings.Join(p.Labels, ","))
+	}
+	for _, agent := range agents {
+		// an agent in an unknown state has missed a ping but may yet recover,
+		// so it is given the benefit of the doubt.
+		switch agent.Status {
+		case AgentIdle, AgentBusy, AgentUnknown:
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: agent pool %s has no agents online", ErrNoEligibleAgent, p.Name)
+}
+
+// isPlacementError determines whether the error is the reason a run cannot be
+// scheduled.
+func isPlacementError(err error) bool {
+	return errors.Is(err, ErrNoEligibleAgentPool) ||
+		errors.Is(err, ErrWorkspaceNotAllowedToUsePool) ||
+		errors.Is(err, ErrNoEligibleAgent)
+}
+
+// failPlacement errors the run's current phase, writing the reason to the
+// phase's logs so that it is visible to the user.
+func (s *Service) failPlacement(ctx context.Context, run *otfrun.Run, reason error) error {
+	ctx = internal.AddSubjectToContext(ctx, &internal.Superuser{Username: "agent-scheduler"})
+	phase := run.Phase()
+	msg := fmt.Sprintf("Error: unable to schedule run: %s\n", reason.Error())
+	err := s.logs.PutChunk(ctx, internal.PutChunkOptions{
+		RunID: run.ID,
+		Phase: phase,
+		Data:  append(append([]byte{internal.STX}, msg...), internal.ETX),
+	})
+	if err != nil {
+		return fmt.Errorf("writing placement failure to logs: %w", err)
+	}
+	if _, err := s.phases.StartPhase(ctx, run.ID, phase, otfrun.PhaseStartOptions{}); err != nil {
+		return err
+	}
+	if _, err := s.phases.FinishPhase(ctx, run.ID, phase, otfrun.PhaseFinishOptions{Errored: true}); err != nil {
+		return err
+	}
+	return nil
+}
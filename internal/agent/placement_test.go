@@ -0,0 +1,195 @@
+package agent
+
+import (
+	"context"
+	"testing"
+
+	"github.com/leg100/otf/internal"
+	"github.com/leg100/otf/internal/logr"
+	otfrun "github.com/leg100/otf/internal/run"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_parseLabels(t *testing.T) {
+	got, err := parseLabels([]string{" tier = prod", "region=eu", "", "region=eu"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"region=eu", "tier=prod"}, got)
+
+	got, err = parseLabels(nil)
+	require.NoError(t, err)
+	assert.Equal(t, []string{}, got)
+
+	for _, invalid := range []string{"region", "=eu", "region="} {
+		_, err := parseLabels([]string{invalid})
+		assert.Error(t, err, invalid)
+	}
+}
+
+func TestPool_satisfies(t *testing.T) {
+	pool := &Pool{Labels: []string{"region=eu", "tier=prod"}}
+
+	assert.True(t, pool.satisfies(nil))
+	assert.True(t, pool.satisfies([]string{"region=eu"}))
+	assert.True(t, pool.satisfies([]string{"region=eu", "tier=prod"}))
+	assert.False(t, pool.satisfies([]string{"region=us"}))
+	assert.False(t, pool.satisfies([]string{"region=eu", "gpu=true"}))
+}
+
+func TestPool_place(t *testing.T) {
+	online := []*Agent{{Status: AgentExited}, {Status: AgentIdle}}
+
+	t.Run("organization scoped", func(t *testing.T) {
+		pool := &Pool{Name: "pool-1", OrganizationScoped: true}
+		assert.NoError(t, pool.place("ws-123", nil, online))
+	})
+
+	t.Run("allowed workspace", func(t *testing.T) {
+		pool := &Pool{Name: "pool-1", AllowedWorkspaces: []string{"ws-123"}}
+		assert.NoError(t, pool.place("ws-123", nil, online))
+	})
+
+	t.Run("workspace not allowed", func(t *testing.T) {
+		pool := &Pool{Name: "pool-1", AllowedWorkspaces: []string{"ws-123"}}
+		err := pool.place("ws-456", nil, online)
+		assert.ErrorIs(t, err, ErrWorkspaceNotAllowedToUsePool)
+		assert.True(t, isPlacementError(err))
+	})
+
+	t.Run("unsatisfied constraints", func(t *testing.T) {
+		pool := &Pool{Name: "pool-1", OrganizationScoped: true, Labels: []string{"region=eu"}}
+		err := pool.place("ws-123", []string{"region=us"}, online)
+		assert.ErrorIs(t, err, ErrNoEligibleAgentPool)
+	})
+
+	t.Run("agent in unknown state", func(t *testing.T) {
+		pool := &Pool{Name: "pool-1", OrganizationScoped: true}
+		assert.NoError(t, pool.place("ws-123", nil, []*Agent{{Status: AgentUnknown}}))
+	})
+
+	t.Run("no agents online", func(t *testing.T) {
+		pool := &Pool{Name: "pool-1", OrganizationScoped: true}
+		err := pool.place("ws-123", nil, []*Agent{{Status: AgentExited}, {Status: AgentErrored}})
+		assert.ErrorIs(t, err, ErrNoEligibleAgent)
+		assert.True(t, isPlacementError(err))
+
+		err = pool.place("ws-123", nil, nil)
+		assert.ErrorIs(t, err, ErrNoEligibleAgent)
+	})
+}
+
+func TestService_createJob(t *testing.T) {
+	ctx := context.Background()
+	poolID := "apool-123"
+
+	t.Run("eligible pool", func(t *testing.T) {
+		store := &fakeSchedulingStore{
+			constraints: []string{"region=eu"},
+			pool:        &Pool{ID: poolID, Name: "pool-1", OrganizationScoped: true, Labels: []string{"region=eu"}},
+			agents:      []*Agent{{Status: AgentIdle}},
+		}
+		phases := &fakePlacementPhaseClient{}
+		logs := &fakePlacementLogs{}
+		svc := &Service{Logger: logr.Discard(), scheduling: store, phases: phases, logs: logs}
+		run := &otfrun.Run{ID: "run-123", WorkspaceID: "ws-123", Status: otfrun.RunPlanQueued, AgentPoolID: &poolID}
+
+		require.NoError(t, svc.createJob(ctx, run))
+
+		require.Equal(t, 1, len(store.jobs))
+		assert.Equal(t, JobSpec{RunID: "run-123", Phase: internal.PlanPhase}, store.jobs[0].Spec)
+		assert.Empty(t, phases.finished)
+		assert.Empty(t, logs.chunks)
+	})
+
+	t.Run("no eligible agent pool", func(t *testing.T) {
+		store := &fakeSchedulingStore{
+			constraints: []string{"region=us"},
+			pool:        &Pool{ID: poolID, Name: "pool-1", OrganizationScoped: true, Labels: []string{"region=eu"}},
+			agents:      []*Agent{{Status: AgentIdle}},
+		}
+		phases := &fakePlacementPhaseClient{}
+		logs := &fakePlacementLogs{}
+		svc := &Service{Logger: logr.Discard(), scheduling: store, phases: phases, logs: logs}
+		run := &otfrun.Run{ID: "run-123", WorkspaceID: "ws-123", Status: otfrun.RunPlanQueued, AgentPoolID: &poolID}
+
+		require.NoError(t, svc.createJob(ctx, run))
+
+		// no job is created and instead the run is failed, with the reason
+		// written to the logs of the phase.
+		assert.Empty(t, store.jobs)
+		assert.Equal(t, []internal.PhaseType{internal.PlanPhase}, phases.started)
+		assert.Equal(t, []otfrun.PhaseFinishOptions{{Errored: true}}, phases.finished)
+		require.Equal(t, 1, len(logs.chunks))
+		assert.Equal(t, "run-123", logs.chunks[0].RunID)
+		assert.Equal(t, internal.PlanPhase, logs.chunks[0].Phase)
+		assert.Contains(t, string(logs.chunks[0].Data), ErrNoEligibleAgentPool.Error())
+		assert.Contains(t, string(logs.chunks[0].Data), "region=us")
+	})
+
+	t.Run("constraints without agent pool", func(t *testing.T) {
+		store := &fakeSchedulingStore{constraints: []string{"region=eu"}}
+		phases := &fakePlacementPhaseClient{}
+		logs := &fakePlacementLogs{}
+		svc := &Service{Logger: logr.Discard(), scheduling: store, phases: phases, logs: logs}
+		run := &otfrun.Run{ID: "run-123", WorkspaceID: "ws-123", Status: otfrun.RunPlanQueued}
+
+		require.NoError(t, svc.createJob(ctx, run))
+
+		assert.Empty(t, store.jobs)
+		assert.Equal(t, []otfrun.PhaseFinishOptions{{Errored: true}}, phases.finished)
+		require.Equal(t, 1, len(logs.chunks))
+		assert.Contains(t, string(logs.chunks[0].Data), "workspace is not configured to use an agent pool")
+	})
+}
+
+type (
+	fakeSchedulingStore struct {
+		constraints []string
+		pool        *Pool
+		agents      []*Agent
+		jobs        []*Job
+	}
+
+	fakePlacementPhaseClient struct {
+		phaseClient
+
+		started  []internal.PhaseType
+		finished []otfrun.PhaseFinishOptions
+	}
+
+	fakePlacementLogs struct {
+		chunks []internal.PutChunkOptions
+	}
+)
+
+func (f *fakeSchedulingStore) getPlacementConstraints(context.Context, string) ([]string, error) {
+	return f.constraints, nil
+}
+
+func (f *fakeSchedulingStore) getPool(context.Context, string) (*Pool, error) {
+	return f.pool, nil
+}
+
+func (f *fakeSchedulingStore) listAgentsByPool(context.Context, string) ([]*Agent, error) {
+	return f.agents, nil
+}
+
+func (f *fakeSchedulingStore) createJob(_ context.Context, job *Job) error {
+	f.jobs = append(f.jobs, job)
+	return nil
+}
+
+func (f *fakePlacementPhaseClient) StartPhase(_ context.Context, _ string, phase internal.PhaseType, _ otfrun.PhaseStartOptions) (*otfrun.Run, error) {
+	f.started = append(f.started, phase)
+	return nil, nil
+}
+
+func (f *fakePlacementPhaseClient) FinishPhase(_ context.Context, _ string, _ internal.PhaseType, opts otfrun.PhaseFinishOptions) (*otfrun.Run, error) {
+	f.finished = append(f.finished, opts)
+	return nil, nil
+}
+
+func (f *fakePlacementLogs) PutChunk(_ context.Context, opts internal.PutChunkOptions) error {
+	f.chunks = append(f.chunks, opts)
+	return nil
+}
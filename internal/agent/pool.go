@@ -16,6 +16,8 @@ var (
 	ErrCannotDeletePoolReferencedByWorkspaces = errors.New("agent pool is still being used by workspaces in your organization. You must switch your workspaces to a different agent pool or execution mode before you can delete this agent pool")
 	ErrWorkspaceNotAllowedToUsePool           = errors.New("access to this agent pool is not allowed - you must explictly grant access to the workspace first")
 	ErrPoolAssignedWorkspacesNotAllowed       = errors.New("workspaces assigned to the pool have not been granted access to the pool")
+	ErrNoEligibleAgentPool                    = errors.New("no agent pool satisfies the workspace's placement constraints")
+	ErrNoEligibleAgent                        = errors.New("no agent is available to run the job")
 )
 
 type (
@@ -37,6 +39,9 @@ type (
 		// IDs of workspaces assigned to the pool. Note: this is a subset of
 		// AllowedWorkspaces.
 		AssignedWorkspaces []string
+		// Labels describing the pool, e.g. region=eu, which are matched
+		// against workspace placement constraints.
+		Labels []string
 	}
 
 	CreateAgentPoolOptions struct {
@@ -47,6 +52,8 @@ type (
 		OrganizationScoped *bool
 		// IDs of workspaces allowed to access the pool.
 		AllowedWorkspaces []string
+		// Labels describing the pool. Each label must be of the form key=value.
+		Labels []string
 	}
 
 	updatePoolOptions struct {
@@ -57,6 +64,8 @@ type (
 		// IDs of workspaces assigned to the pool. Note: this is a subset of
 		// AssignedWorkspaces.
 		AssignedWorkspaces []string `schema:"assigned_workspaces"`
+		// Labels describing the pool. Each label must be of the form key=value.
+		Labels []string `schema:"labels"`
 	}
 
 	listPoolOptions struct {
@@ -78,13 +87,18 @@ func newPool(opts CreateAgentPoolOptions) (*Pool, error) {
 	if opts.Organization == "" {
 		return nil, errors.New("organization must not be empty")
 	}
+	labels, err := parseLabels(opts.Labels)
+	if err != nil {
+		return nil, err
+	}
 	pool := &Pool{
-		ID:                 internal.NewID("apool"),
+		ID:                 resource.NewID(resource.AgentPoolKind),
 		CreatedAt:          internal.CurrentTimestamp(nil),
 		Name:               opts.Name,
 		Organization:       opts.Organization,
 		OrganizationScoped: true,
 		AllowedWorkspaces:  opts.AllowedWorkspaces,
+		Labels:             labels,
 	}
 	if opts.OrganizationScoped != nil {
 		pool.OrganizationScoped = *opts.OrganizationScoped
@@ -105,6 +119,13 @@ func (p *Pool) update(opts updatePoolOptions) error {
 	if opts.AllowedWorkspaces != nil {
 		p.AllowedWorkspaces = opts.AllowedWorkspaces
 	}
+	if opts.Labels != nil {
+		labels, err := parseLabels(opts.Labels)
+		if err != nil {
+			return err
+		}
+		p.Labels = labels
+	}
 	// if not organization scoped then each assigned workspace must also be
 	// allowed.
 	if !p.OrganizationScoped {
@@ -117,6 +138,11 @@ func (p *Pool) update(opts updatePoolOptions) error {
 	return nil
 }
 
+// allows determines whether the workspace is allowed to use the pool.
+func (p *Pool) allows(workspaceID string) bool {
+	return p.OrganizationScoped || slices.Contains(p.AllowedWorkspaces, workspaceID)
+}
+
 func (p *Pool) LogValue() slog.Value {
 	return slog.GroupValue(
 		slog.String("id", p.ID),
@@ -125,5 +151,6 @@ func (p *Pool) LogValue() slog.Value {
 		slog.Bool("organization_scoped", p.OrganizationScoped),
 		slog.Any("workspaces", p.AssignedWorkspaces),
 		slog.Any("allowed_workspaces", p.AllowedWorkspaces),
+		slog.Any("labels", p.Labels),
 	)
 }
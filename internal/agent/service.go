@@ -4,7 +4,6 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"slices"
 
 	"github.com/gorilla/mux"
 	"github.com/leg100/otf/internal"
@@ -17,6 +16,7 @@ import (
 	otfrun "github.com/leg100/otf/internal/run"
 	"github.com/leg100/otf/internal/sql"
 	"github.com/leg100/otf/internal/sql/pggen"
+	"github.com/leg100/otf/internal/state"
 	"github.com/leg100/otf/internal/tfeapi"
 	"github.com/leg100/otf/internal/tokens"
 	"github.com/leg100/otf/internal/workspace"
@@ -27,6 +27,7 @@ type (
 		logr.Logger
 
 		organization internal.Authorizer
+		workspace    internal.Authorizer
 
 		tfeapi      *tfe
 		api         *api
@@ -35,6 +36,11 @@ type (
 		agentBroker pubsub.SubscriptionService[*Agent]
 		jobBroker   pubsub.SubscriptionService[*Job]
 		phases      phaseClient
+		states      currentStateClient
+		logs        internal.PutChunkService
+		// scheduling is the store from which runs are placed and into which
+		// their jobs are created.
+		scheduling schedulingStore
 
 		db *db
 		*registrar
@@ -51,13 +57,27 @@ type (
 		RunService       *otfrun.Service
 		WorkspaceService *workspace.Service
 		TokensService    *tokens.Service
+		LogsService      internal.PutChunkService
+		StateService     *state.Service
 	}
 
 	phaseClient interface {
+		Get(ctx context.Context, runID string) (*otfrun.Run, error)
 		StartPhase(ctx context.Context, runID string, phase internal.PhaseType, _ otfrun.PhaseStartOptions) (*otfrun.Run, error)
 		FinishPhase(ctx context.Context, runID string, phase internal.PhaseType, opts otfrun.PhaseFinishOptions) (*otfrun.Run, error)
 		Cancel(ctx context.Context, runID string) error
 	}
+
+	currentStateClient interface {
+		GetCurrent(ctx context.Context, workspaceID string) (*state.Version, error)
+	}
+
+	schedulingStore interface {
+		getPlacementConstraints(ctx context.Context, workspaceID string) ([]string, error)
+		getPool(ctx context.Context, poolID string) (*Pool, error)
+		listAgentsByPool(ctx context.Context, poolID string) ([]*Agent, error)
+		createJob(ctx context.Context, job *Job) error
+	}
 )
 
 func NewService(opts ServiceOptions) *Service {
@@ -65,11 +85,15 @@ func NewService(opts ServiceOptions) *Service {
 		Logger:       opts.Logger,
 		db:           &db{DB: opts.DB},
 		organization: &organization.Authorizer{Logger: opts.Logger},
+		workspace:    opts.WorkspaceService,
 		tokenFactory: &tokenFactory{
 			tokens: opts.TokensService,
 		},
 		phases: opts.RunService,
+		states: opts.StateService,
+		logs:   opts.LogsService,
 	}
+	svc.scheduling = svc.db
 	svc.tfeapi = &tfe{
 		Service:   svc,
 		Responder: opts.Responder,
@@ -184,8 +208,9 @@ func (s *Service) AddHandlers(r *mux.Router) {
 
 func (s *Service) NewAllocator(logger logr.Logger) *allocator {
 	return &allocator{
-		Logger: logger,
-		client: s,
+		Logger:       logger,
+		client:       s,
+		leaseTimeout: defaultJobLeaseTimeout,
 	}
 }
 
@@ -337,10 +362,7 @@ func (s *Service) checkWorkspacePoolAccess(ctx context.Context, ws *workspace.Wo
 	if err != nil {
 		return err
 	}
-	if pool.OrganizationScoped {
-		return nil
-	} else if slices.Contains(pool.AllowedWorkspaces, ws.ID) {
-		// is explicitly granted
+	if pool.allows(ws.ID) {
 		return nil
 	}
 	return ErrWorkspaceNotAllowedToUsePool
@@ -472,8 +494,17 @@ func (s *Service) deleteAgent(ctx context.Context, agentID string) error {
 }
 
 func (s *Service) createJob(ctx context.Context, run *otfrun.Run) error {
+	// rather than create a job that no agent is permitted or available to
+	// run, fail the run.
+	if err := s.checkPlacement(ctx, run); err != nil {
+		if !isPlacementError(err) {
+			return err
+		}
+		s.Error(err, "scheduling run", "run_id", run.ID, "workspace_id", run.WorkspaceID)
+		return s.failPlacement(ctx, run, err)
+	}
 	job := newJob(run)
-	if err := s.db.createJob(ctx, job); err != nil {
+	if err := s.scheduling.createJob(ctx, job); err != nil {
 		return err
 	}
 	return nil
@@ -632,6 +663,10 @@ func (s *Service) startJob(ctx context.Context, spec JobSpec) ([]byte, error) {
 type finishJobOptions struct {
 	Status JobStatus `json:"status"`
 	Error  string    `json:"error,omitempty"`
+	// Diagnostics reported by terraform
+	Diagnostics []otfrun.Diagnostic `json:"diagnostics,omitempty"`
+	// ApplySummary summarizes an apply job
+	ApplySummary *otfrun.ApplySummary `json:"apply_summary,omitempty"`
 }
 
 // finishJob finishes a job. Only the job itself may call this endpoint.
@@ -652,7 +687,9 @@ func (s *Service) finishJob(ctx context.Context, spec JobSpec, opts finishJobOpt
 		switch opts.Status {
 		case JobFinished, JobErrored:
 			_, err = s.phases.FinishPhase(ctx, spec.RunID, spec.Phase, otfrun.PhaseFinishOptions{
-				Errored: opts.Status == JobErrored,
+				Errored:      opts.Status == JobErrored,
+				Diagnostics:  opts.Diagnostics,
+				ApplySummary: opts.ApplySummary,
 			})
 		case JobCanceled:
 			err = s.phases.Cancel(ctx, spec.RunID)
@@ -0,0 +1,30 @@
+package agent
+
+import (
+	"fmt"
+	"os"
+)
+
+// writeSSHKey writes an SSH private key to a temporary file readable only by
+// the current user, returning its path.
+func writeSSHKey(key []byte) (string, error) {
+	f, err := os.CreateTemp("", "otf-ssh-key-")
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	if err := f.Chmod(0o600); err != nil {
+		return "", err
+	}
+	if _, err := f.Write(key); err != nil {
+		return "", err
+	}
+	return f.Name(), nil
+}
+
+// sshCommandEnv returns an environment variable instructing git to
+// authenticate with the SSH key at the given path.
+func sshCommandEnv(keyPath string) string {
+	return fmt.Sprintf("GIT_SSH_COMMAND=ssh -i %s -o IdentitiesOnly=yes -o StrictHostKeyChecking=accept-new", keyPath)
+}
@@ -9,6 +9,7 @@ type fakeService struct {
 	token                  []byte
 	status                 AgentStatus
 	deletedAgentID         string
+	interruptedAgentID     string
 	job                    *Job
 
 	Service
@@ -44,6 +45,11 @@ func (f *fakeService) updateAgentStatus(ctx context.Context, agentID string, sta
 	return nil
 }
 
+func (f *fakeService) interruptJobs(ctx context.Context, agentID string) error {
+	f.interruptedAgentID = agentID
+	return nil
+}
+
 func (f *fakeService) deleteAgent(ctx context.Context, agentID string) error {
 	f.deletedAgentID = agentID
 	return nil
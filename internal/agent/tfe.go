@@ -1,6 +1,7 @@
 package agent
 
 import (
+	"context"
 	"net/http"
 
 	"github.com/gorilla/mux"
@@ -27,6 +28,8 @@ func (a *tfe) addHandlers(r *mux.Router) {
 	r.HandleFunc("/agent-pools/{pool_id}", a.getAgentPool).Methods("GET")
 	r.HandleFunc("/agent-pools/{pool_id}", a.updateAgentPool).Methods("PATCH")
 	r.HandleFunc("/agent-pools/{pool_id}", a.deleteAgentPool).Methods("DELETE")
+	r.HandleFunc("/agent-pools/{pool_id}/agents", a.listAgents).Methods("GET")
+	r.HandleFunc("/agents/{agent_id}", a.getAgent).Methods("GET")
 
 	// Agent Tokens API
 	//
@@ -92,7 +95,12 @@ func (a *tfe) createAgentPool(w http.ResponseWriter, r *http.Request) {
 		tfeapi.Error(w, err)
 		return
 	}
-	a.Respond(w, r, a.toPool(pool), http.StatusCreated)
+	to, err := a.toPool(r.Context(), pool)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	a.Respond(w, r, to, http.StatusCreated)
 }
 
 func (a *tfe) updateAgentPool(w http.ResponseWriter, r *http.Request) {
@@ -124,7 +132,12 @@ func (a *tfe) updateAgentPool(w http.ResponseWriter, r *http.Request) {
 		tfeapi.Error(w, err)
 		return
 	}
-	a.Respond(w, r, a.toPool(pool), http.StatusOK)
+	to, err := a.toPool(r.Context(), pool)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	a.Respond(w, r, to, http.StatusOK)
 }
 
 func (a *tfe) getAgentPool(w http.ResponseWriter, r *http.Request) {
@@ -140,7 +153,12 @@ func (a *tfe) getAgentPool(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	a.Respond(w, r, a.toPool(pool), http.StatusOK)
+	to, err := a.toPool(r.Context(), pool)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	a.Respond(w, r, to, http.StatusOK)
 }
 
 func (a *tfe) listAgentPools(w http.ResponseWriter, r *http.Request) {
@@ -171,11 +189,11 @@ func (a *tfe) listAgentPools(w http.ResponseWriter, r *http.Request) {
 	// convert items
 	items := make([]*types.AgentPool, len(page.Items))
 	for i, from := range page.Items {
+		items[i], err = a.toPool(r.Context(), from)
 		if err != nil {
 			tfeapi.Error(w, err)
 			return
 		}
-		items[i] = a.toPool(from)
 	}
 	a.RespondWithPage(w, r, items, page.Pagination)
 }
@@ -196,7 +214,86 @@ func (a *tfe) deleteAgentPool(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusNoContent)
 }
 
-func (a *tfe) toPool(from *Pool) *types.AgentPool {
+func (a *tfe) listAgents(w http.ResponseWriter, r *http.Request) {
+	poolID, err := decode.Param("pool_id", r)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	var params types.AgentListOptions
+	if err := decode.All(&params, r); err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+
+	// retrieve pool first in order to authorize access to its agents
+	if _, err := a.Service.GetAgentPool(r.Context(), poolID); err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	agents, err := a.Service.listAgentsByPool(r.Context(), poolID)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+
+	// client expects a page, whereas listAgentsByPool returns full result
+	// set, so convert to page first
+	page := resource.NewPage(agents, resource.PageOptions(params.ListOptions), nil)
+
+	// convert items
+	items := make([]*types.Agent, len(page.Items))
+	for i, from := range page.Items {
+		items[i] = a.toAgent(from)
+	}
+	a.RespondWithPage(w, r, items, page.Pagination)
+}
+
+func (a *tfe) getAgent(w http.ResponseWriter, r *http.Request) {
+	agentID, err := decode.Param("agent_id", r)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+
+	agent, err := a.Service.getAgent(r.Context(), agentID)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	// only pool agents are exposed via the API; server agents are an
+	// implementation detail of otfd.
+	if agent.AgentPoolID == nil {
+		tfeapi.Error(w, internal.ErrResourceNotFound)
+		return
+	}
+	// retrieve agent's pool in order to authorize access to the agent
+	if _, err := a.Service.GetAgentPool(r.Context(), *agent.AgentPoolID); err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+
+	a.Respond(w, r, a.toAgent(agent), http.StatusOK)
+}
+
+func (a *tfe) toAgent(from *Agent) *types.Agent {
+	to := &types.Agent{
+		ID:         from.ID,
+		Name:       from.Name,
+		Status:     string(from.Status),
+		LastPingAt: from.LastPingAt,
+	}
+	if from.IPAddress != nil {
+		to.IP = from.IPAddress.String()
+	}
+	return to
+}
+
+func (a *tfe) toPool(ctx context.Context, from *Pool) (*types.AgentPool, error) {
+	agents, err := a.Service.listAgentsByPool(ctx, from.ID)
+	if err != nil {
+		return nil, err
+	}
 	to := &types.AgentPool{
 		ID:   from.ID,
 		Name: from.Name,
@@ -213,7 +310,13 @@ func (a *tfe) toPool(from *Pool) *types.AgentPool {
 	for i, workspaceID := range from.AllowedWorkspaces {
 		to.AllowedWorkspaces[i] = &types.Workspace{ID: workspaceID}
 	}
-	return to
+	// exited agents no longer count towards the pool's agents
+	for _, agent := range agents {
+		if agent.Status != AgentExited {
+			to.AgentCount++
+		}
+	}
+	return to, nil
 }
 
 // Agent token handlers
@@ -6,6 +6,7 @@ import (
 	"math"
 	"net/http"
 	"slices"
+	"strings"
 
 	"github.com/gorilla/mux"
 	"github.com/leg100/otf/internal"
@@ -164,6 +165,7 @@ func (h *webHandlers) updateAgentPool(w http.ResponseWriter, r *http.Request) {
 		OrganizationScoped   bool              `schema:"organization_scoped"`
 		AllowedButUnassigned poolWorkspaceList `schema:"allowed_workspaces"`
 		AllowedAndAssigned   poolWorkspaceList `schema:"assigned_workspaces"`
+		Labels               string            `schema:"labels"`
 	}
 	if err := decode.All(&params, r); err != nil {
 		h.Error(w, err.Error(), http.StatusUnprocessableEntity)
@@ -173,6 +175,8 @@ func (h *webHandlers) updateAgentPool(w http.ResponseWriter, r *http.Request) {
 		Name:               &params.Name,
 		OrganizationScoped: &params.OrganizationScoped,
 		AllowedWorkspaces:  make([]string, len(params.AllowedButUnassigned)+len(params.AllowedAndAssigned)),
+		// labels are sent as a comma-separated list
+		Labels: strings.Split(params.Labels, ","),
 	}
 	for i, allowed := range append(params.AllowedButUnassigned, params.AllowedAndAssigned...) {
 		opts.AllowedWorkspaces[i] = allowed.ID
@@ -20,10 +20,11 @@ import (
 
 type (
 	Client struct {
-		baseURL *url.URL
-		token   string
-		headers http.Header
-		http    *retryablehttp.Client
+		baseURL        *url.URL
+		token          string
+		headers        http.Header
+		http           *retryablehttp.Client
+		warningHandler func(string)
 	}
 
 	// Config provides configuration details to the API client.
@@ -42,6 +43,10 @@ type (
 		RetryLogHook retryablehttp.RequestLogHook
 		// Override default http transport
 		Transport http.RoundTripper
+		// WarningHandler is invoked with each warning the server relays in a
+		// response, e.g. warning of deprecated behaviour. Warnings are
+		// discarded if nil.
+		WarningHandler func(string)
 	}
 )
 
@@ -81,9 +86,10 @@ func NewClient(config Config) (*Client, error) {
 
 	// Create the client.
 	client := &Client{
-		baseURL: baseURL,
-		token:   config.Token,
-		headers: config.Headers,
+		baseURL:        baseURL,
+		token:          config.Token,
+		headers:        config.Headers,
+		warningHandler: config.WarningHandler,
 	}
 	client.http = &retryablehttp.Client{
 		Backoff:        retryablehttp.DefaultBackoff,
@@ -248,6 +254,8 @@ func (c *Client) Do(ctx context.Context, req *retryablehttp.Request, v interface
 	}
 	defer resp.Body.Close()
 
+	c.handleWarnings(resp)
+
 	// Basic response checking.
 	if err := checkResponseCode(resp); err != nil {
 		return err
@@ -267,6 +275,19 @@ func (c *Client) Do(ctx context.Context, req *retryablehttp.Request, v interface
 	return unmarshalResponse(resp.Body, v)
 }
 
+// handleWarnings invokes the warning handler with each warning relayed in the
+// response.
+func (c *Client) handleWarnings(resp *http.Response) {
+	if c.warningHandler == nil {
+		return
+	}
+	for _, value := range resp.Header.Values(otfhttp.WarningHeader) {
+		if msg, ok := otfhttp.ParseWarning(value); ok {
+			c.warningHandler(msg)
+		}
+	}
+}
+
 func unmarshalResponse(r io.Reader, v any) error {
 	b, err := io.ReadAll(r)
 	if err != nil {
@@ -0,0 +1,120 @@
+package assessment
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgtype"
+	"github.com/leg100/otf/internal/sql"
+	"github.com/leg100/otf/internal/sql/pggen"
+)
+
+type (
+	// pgdb is a database of assessment results on postgres
+	pgdb struct {
+		*sql.DB // provides access to generated SQL queries
+	}
+
+	resultRow struct {
+		AssessmentResultID pgtype.Text        `json:"assessment_result_id"`
+		CreatedAt          pgtype.Timestamptz `json:"created_at"`
+		UpdatedAt          pgtype.Timestamptz `json:"updated_at"`
+		Status             pgtype.Text        `json:"status"`
+		Drifted            pgtype.Bool        `json:"drifted"`
+		DriftedResources   []string           `json:"drifted_resources"`
+		ErrorMessage       pgtype.Text        `json:"error_message"`
+		RunID              pgtype.Text        `json:"run_id"`
+		WorkspaceID        pgtype.Text        `json:"workspace_id"`
+	}
+)
+
+func (r resultRow) toResult() *Result {
+	result := &Result{
+		ID:               r.AssessmentResultID.String,
+		CreatedAt:        r.CreatedAt.Time.UTC(),
+		UpdatedAt:        r.UpdatedAt.Time.UTC(),
+		WorkspaceID:      r.WorkspaceID.String,
+		Status:           Status(r.Status.String),
+		Drifted:          r.Drifted.Bool,
+		DriftedResources: r.DriftedResources,
+		ErrorMessage:     r.ErrorMessage.String,
+	}
+	if r.RunID.Status == pgtype.Present {
+		result.RunID = &r.RunID.String
+	}
+	return result
+}
+
+func (db *pgdb) createResult(ctx context.Context, result *Result) error {
+	_, err := db.Conn(ctx).InsertAssessmentResult(ctx, pggen.InsertAssessmentResultParams{
+		AssessmentResultID: sql.String(result.ID),
+		CreatedAt:          sql.Timestamptz(result.CreatedAt),
+		UpdatedAt:          sql.Timestamptz(result.UpdatedAt),
+		Status:             sql.String(string(result.Status)),
+		Drifted:            sql.Bool(result.Drifted),
+		DriftedResources:   result.DriftedResources,
+		ErrorMessage:       sql.String(result.ErrorMessage),
+		RunID:              sql.StringPtr(result.RunID),
+		WorkspaceID:        sql.String(result.WorkspaceID),
+	})
+	return sql.Error(err)
+}
+
+func (db *pgdb) listResults(ctx context.Context, workspaceID string) ([]*Result, error) {
+	rows, err := db.Conn(ctx).FindAssessmentResultsByWorkspaceID(ctx, sql.String(workspaceID))
+	if err != nil {
+		return nil, sql.Error(err)
+	}
+	results := make([]*Result, len(rows))
+	for i, r := range rows {
+		results[i] = resultRow(r).toResult()
+	}
+	return results, nil
+}
+
+func (db *pgdb) listPendingResults(ctx context.Context) ([]*Result, error) {
+	rows, err := db.Conn(ctx).FindAssessmentResultsByStatus(ctx, sql.String(string(StatusPending)))
+	if err != nil {
+		return nil, sql.Error(err)
+	}
+	results := make([]*Result, len(rows))
+	for i, r := range rows {
+		results[i] = resultRow(r).toResult()
+	}
+	return results, nil
+}
+
+func (db *pgdb) getResult(ctx context.Context, resultID string) (*Result, error) {
+	row, err := db.Conn(ctx).FindAssessmentResultByID(ctx, sql.String(resultID))
+	if err != nil {
+		return nil, sql.Error(err)
+	}
+	return resultRow(row).toResult(), nil
+}
+
+func (db *pgdb) updateResult(ctx context.Context, result *Result) error {
+	_, err := db.Conn(ctx).UpdateAssessmentResult(ctx, pggen.UpdateAssessmentResultParams{
+		AssessmentResultID: sql.String(result.ID),
+		Status:             sql.String(string(result.Status)),
+		Drifted:            sql.Bool(result.Drifted),
+		DriftedResources:   result.DriftedResources,
+		ErrorMessage:       sql.String(result.ErrorMessage),
+		UpdatedAt:          sql.Timestamptz(result.UpdatedAt),
+	})
+	return sql.Error(err)
+}
+
+// listWorkspacesDue lists the IDs of workspaces with assessments enabled that
+// have neither a pending assessment nor an assessment created since the given
+// time.
+func (db *pgdb) listWorkspacesDue(ctx context.Context, assessedBefore time.Time) ([]string, error) {
+	rows, err := db.Conn(ctx).FindWorkspaceIDsDueAssessment(ctx, sql.Timestamptz(assessedBefore))
+	if err != nil {
+		return nil, sql.Error(err)
+	}
+	ids := make([]string, len(rows))
+	for i, r := range rows {
+		ids[i] = r.String
+	}
+	return ids, nil
+}
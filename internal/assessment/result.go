@@ -0,0 +1,85 @@
+// Package assessment provides health assessments of workspaces, regularly
+// running refresh-only plans to detect resources that have drifted from their
+// recorded state.
+package assessment
+
+import (
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/leg100/otf/internal"
+	"github.com/leg100/otf/internal/resource"
+	"github.com/leg100/otf/internal/run"
+)
+
+const (
+	StatusPending   Status = "pending"
+	StatusSucceeded Status = "succeeded"
+	StatusErrored   Status = "errored"
+)
+
+type (
+	// Result is the result of a health assessment of a workspace.
+	Result struct {
+		ID          string
+		CreatedAt   time.Time
+		UpdatedAt   time.Time
+		WorkspaceID string
+		// RunID is the ID of the refresh-only run performing the assessment.
+		// Nil if the run could not be created.
+		RunID  *string
+		Status Status
+		// Drifted is true if any resources have changed outside of terraform.
+		Drifted bool
+		// DriftedResources are the addresses of resources that have changed
+		// outside of terraform.
+		DriftedResources []string
+		// ErrorMessage explains why the assessment errored.
+		ErrorMessage string
+	}
+
+	// Status is the status of a health assessment.
+	Status string
+)
+
+func newResult(workspaceID string) *Result {
+	return &Result{
+		ID:          resource.NewID(resource.AssessmentResultKind),
+		CreatedAt:   internal.CurrentTimestamp(nil),
+		UpdatedAt:   internal.CurrentTimestamp(nil),
+		WorkspaceID: workspaceID,
+		Status:      StatusPending,
+	}
+}
+
+// Succeeded is true if the assessment completed successfully.
+func (r *Result) Succeeded() bool { return r.Status == StatusSucceeded }
+
+// succeed completes the assessment with the plan file produced by its run.
+func (r *Result) succeed(planFile *run.PlanFile) {
+	r.DriftedResources = planFile.DriftedResources()
+	r.Drifted = len(r.DriftedResources) > 0
+	r.Status = StatusSucceeded
+	r.UpdatedAt = internal.CurrentTimestamp(nil)
+}
+
+// fail errors the assessment with an explanatory message.
+func (r *Result) fail(msg string, args ...any) {
+	r.ErrorMessage = fmt.Sprintf(msg, args...)
+	r.Status = StatusErrored
+	r.UpdatedAt = internal.CurrentTimestamp(nil)
+}
+
+// LogValue implements slog.LogValuer.
+func (r *Result) LogValue() slog.Value {
+	attrs := []slog.Attr{
+		slog.String("id", r.ID),
+		slog.String("workspace_id", r.WorkspaceID),
+		slog.String("status", string(r.Status)),
+	}
+	if r.RunID != nil {
+		attrs = append(attrs, slog.String("run_id", *r.RunID))
+	}
+	return slog.GroupValue(attrs...)
+}
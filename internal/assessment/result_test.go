@@ -0,0 +1,47 @@
+package assessment
+
+import (
+	"testing"
+
+	"github.com/leg100/otf/internal/run"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResult(t *testing.T) {
+	t.Run("new", func(t *testing.T) {
+		result := newResult("ws-123")
+
+		assert.Equal(t, StatusPending, result.Status)
+		assert.False(t, result.Succeeded())
+	})
+
+	t.Run("succeed with drift", func(t *testing.T) {
+		result := newResult("ws-123")
+		result.succeed(&run.PlanFile{
+			ResourceDrift: []run.ResourceChange{
+				{Address: "aws_instance.web"},
+			},
+		})
+
+		assert.True(t, result.Succeeded())
+		assert.True(t, result.Drifted)
+		assert.Equal(t, []string{"aws_instance.web"}, result.DriftedResources)
+	})
+
+	t.Run("succeed without drift", func(t *testing.T) {
+		result := newResult("ws-123")
+		result.succeed(&run.PlanFile{})
+
+		assert.True(t, result.Succeeded())
+		assert.False(t, result.Drifted)
+	})
+
+	t.Run("fail", func(t *testing.T) {
+		result := newResult("ws-123")
+		result.fail("refresh-only run finished with status: %s", run.RunErrored)
+
+		assert.Equal(t, StatusErrored, result.Status)
+		assert.False(t, result.Succeeded())
+		assert.Equal(t, "refresh-only run finished with status: errored", result.ErrorMessage)
+	})
+}
@@ -0,0 +1,102 @@
+package assessment
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/leg100/otf/internal"
+)
+
+// SchedulerLockID guarantees only one scheduler on a cluster is running at any
+// time.
+const SchedulerLockID int64 = 5577006791947779419
+
+var (
+	defaultSchedulerInterval = time.Minute
+
+	// defaultAssessmentPeriod is the time between the health assessments of a
+	// workspace.
+	defaultAssessmentPeriod = 24 * time.Hour
+)
+
+type (
+	// Scheduler periodically starts health assessments of workspaces with
+	// assessments enabled, and completes assessments once their runs have
+	// finished.
+	//
+	// Only one scheduler should be running on an OTF cluster at any one time.
+	Scheduler struct {
+		logr.Logger
+
+		client schedulerClient
+		// frequency with which the scheduler checks for assessments to start
+		// or complete.
+		interval time.Duration
+		// time between assessments of a workspace.
+		period time.Duration
+	}
+
+	schedulerClient interface {
+		listWorkspacesDue(ctx context.Context, assessedBefore time.Time) ([]string, error)
+		listPendingResults(ctx context.Context) ([]*Result, error)
+		assess(ctx context.Context, workspaceID string) (*Result, error)
+		complete(ctx context.Context, result *Result) (bool, error)
+	}
+)
+
+// NewScheduler constructs a scheduler of health assessments.
+func (s *Service) NewScheduler(logger logr.Logger) *Scheduler {
+	return &Scheduler{
+		Logger:   logger.WithValues("component", "assessment-scheduler"),
+		client:   s,
+		interval: defaultSchedulerInterval,
+		period:   defaultAssessmentPeriod,
+	}
+}
+
+func (s *Scheduler) String() string { return "assessment-scheduler" }
+
+// Start the scheduler. Every interval pending assessments are completed if
+// their runs have finished, and assessments are started for workspaces that
+// are due one.
+//
+// Should be invoked in a go routine.
+func (s *Scheduler) Start(ctx context.Context) error {
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			if err := s.check(ctx); err != nil {
+				return err
+			}
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
+
+func (s *Scheduler) check(ctx context.Context) error {
+	pending, err := s.client.listPendingResults(ctx)
+	if err != nil {
+		return err
+	}
+	for _, result := range pending {
+		// carry on completing remaining assessments
+		if _, err := s.client.complete(ctx, result); err != nil {
+			s.Error(err, "completing health assessment", "result", result)
+		}
+	}
+	due, err := s.client.listWorkspacesDue(ctx, internal.CurrentTimestamp(nil).Add(-s.period))
+	if err != nil {
+		return err
+	}
+	for _, workspaceID := range due {
+		// carry on assessing remaining workspaces
+		if _, err := s.client.assess(ctx, workspaceID); err != nil {
+			s.Error(err, "starting health assessment", "workspace", workspaceID)
+		}
+	}
+	return nil
+}
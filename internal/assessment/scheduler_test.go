@@ -0,0 +1,59 @@
+package assessment
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeSchedulerClient struct {
+	due       []string
+	pending   []*Result
+	assessed  []string
+	completed []string
+}
+
+func (f *fakeSchedulerClient) listWorkspacesDue(context.Context, time.Time) ([]string, error) {
+	return f.due, nil
+}
+
+func (f *fakeSchedulerClient) listPendingResults(context.Context) ([]*Result, error) {
+	return f.pending, nil
+}
+
+func (f *fakeSchedulerClient) assess(_ context.Context, workspaceID string) (*Result, error) {
+	if workspaceID == "ws-broken" {
+		return nil, errors.New("something went wrong")
+	}
+	f.assessed = append(f.assessed, workspaceID)
+	return newResult(workspaceID), nil
+}
+
+func (f *fakeSchedulerClient) complete(_ context.Context, result *Result) (bool, error) {
+	f.completed = append(f.completed, result.ID)
+	return true, nil
+}
+
+func TestScheduler_check(t *testing.T) {
+	pending := newResult("ws-1")
+	client := &fakeSchedulerClient{
+		due:     []string{"ws-broken", "ws-2", "ws-3"},
+		pending: []*Result{pending},
+	}
+	scheduler := &Scheduler{
+		Logger: logr.Discard(),
+		client: client,
+		period: time.Hour,
+	}
+
+	require.NoError(t, scheduler.check(context.Background()))
+
+	assert.Equal(t, []string{pending.ID}, client.completed)
+	// an error assessing one workspace should not prevent assessing others
+	assert.Equal(t, []string{"ws-2", "ws-3"}, client.assessed)
+}
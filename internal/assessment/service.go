@@ -0,0 +1,204 @@
+package assessment
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/leg100/otf/internal"
+	"github.com/leg100/otf/internal/featureflag"
+	"github.com/leg100/otf/internal/logr"
+	"github.com/leg100/otf/internal/rbac"
+	"github.com/leg100/otf/internal/run"
+	"github.com/leg100/otf/internal/sql"
+	"github.com/leg100/otf/internal/tfeapi"
+	"github.com/leg100/otf/internal/workspace"
+)
+
+type (
+	Service struct {
+		logr.Logger
+
+		workspaceAuthorizer internal.Authorizer // authorize workspace actions
+
+		db         *pgdb
+		tfeapi     *tfe
+		runs       runClient
+		workspaces workspaceClient
+		flags      flagClient
+	}
+
+	Options struct {
+		*sql.DB
+		*tfeapi.Responder
+		logr.Logger
+
+		WorkspaceAuthorizer internal.Authorizer
+		RunService          *run.Service
+		WorkspaceService    *workspace.Service
+		FeatureFlagService  *featureflag.Service
+	}
+
+	runClient interface {
+		Create(ctx context.Context, workspaceID string, opts run.CreateOptions) (*run.Run, error)
+		Get(ctx context.Context, runID string) (*run.Run, error)
+		GetPlanFile(ctx context.Context, runID string, format run.PlanFormat) ([]byte, error)
+	}
+
+	workspaceClient interface {
+		Get(ctx context.Context, workspaceID string) (*workspace.Workspace, error)
+	}
+
+	flagClient interface {
+		Gate(ctx context.Context, flag featureflag.Flag, organization string) error
+	}
+)
+
+func NewService(opts Options) *Service {
+	svc := Service{
+		Logger:              opts.Logger,
+		workspaceAuthorizer: opts.WorkspaceAuthorizer,
+		db:                  &pgdb{opts.DB},
+		runs:                opts.RunService,
+		workspaces:          opts.WorkspaceService,
+		flags:               opts.FeatureFlagService,
+	}
+	svc.tfeapi = &tfe{
+		Service:   &svc,
+		Responder: opts.Responder,
+	}
+	return &svc
+}
+
+func (s *Service) AddHandlers(r *mux.Router) {
+	s.tfeapi.addHandlers(r)
+}
+
+// ListResults lists the results of a workspace's health assessments, most
+// recent first.
+func (s *Service) ListResults(ctx context.Context, workspaceID string) ([]*Result, error) {
+	subject, err := s.workspaceAuthorizer.CanAccess(ctx, rbac.ListAssessmentResultsAction, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.gate(ctx, workspaceID); err != nil {
+		return nil, err
+	}
+	results, err := s.db.listResults(ctx, workspaceID)
+	if err != nil {
+		s.Error(err, "listing assessment results", "workspace", workspaceID, "subject", subject)
+		return nil, err
+	}
+	s.V(9).Info("listed assessment results", "workspace", workspaceID, "count", len(results), "subject", subject)
+	return results, nil
+}
+
+func (s *Service) GetResult(ctx context.Context, resultID string) (*Result, error) {
+	result, err := s.db.getResult(ctx, resultID)
+	if err != nil {
+		s.Error(err, "retrieving assessment result", "id", resultID)
+		return nil, err
+	}
+	subject, err := s.workspaceAuthorizer.CanAccess(ctx, rbac.GetAssessmentResultAction, result.WorkspaceID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.gate(ctx, result.WorkspaceID); err != nil {
+		return nil, err
+	}
+	s.V(9).Info("retrieved assessment result", "result", result, "subject", subject)
+	return result, nil
+}
+
+// assess starts a health assessment of a workspace, creating a refresh-only
+// run. If the run cannot be created then the assessment is errored. If drift
+// detection is disabled for the workspace's organization then no assessment
+// is started and nil is returned.
+func (s *Service) assess(ctx context.Context, workspaceID string) (*Result, error) {
+	if err := s.gate(ctx, workspaceID); err != nil {
+		if errors.Is(err, featureflag.ErrDisabled) {
+			// drift detection is disabled for the workspace's organization
+			return nil, nil
+		}
+		return nil, err
+	}
+	result := newResult(workspaceID)
+	r, err := s.runs.Create(ctx, workspaceID, run.CreateOptions{
+		PlanOnly:    internal.Bool(true),
+		RefreshOnly: internal.Bool(true),
+		Source:      run.SourceAssessment,
+		Message:     internal.String("Health assessment"),
+	})
+	if err != nil {
+		result.fail("creating refresh-only run: %s", err.Error())
+	} else {
+		result.RunID = &r.ID
+	}
+	if err := s.db.createResult(ctx, result); err != nil {
+		s.Error(err, "creating assessment result", "result", result)
+		return nil, err
+	}
+	s.V(1).Info("started health assessment", "result", result)
+	return result, nil
+}
+
+// complete completes a pending health assessment once its run has finished,
+// determining whether resources have drifted. False is returned if the run is
+// yet to finish.
+func (s *Service) complete(ctx context.Context, result *Result) (bool, error) {
+	r, err := s.runs.Get(ctx, *result.RunID)
+	if err != nil {
+		return false, fmt.Errorf("retrieving run: %w", err)
+	}
+	if !r.Done() {
+		return false, nil
+	}
+	if r.Status == run.RunPlannedAndFinished || r.Status == run.RunPolicySoftFailed {
+		if planFile, err := s.getPlanFile(ctx, r.ID); err != nil {
+			result.fail("retrieving plan: %s", err.Error())
+		} else {
+			result.succeed(planFile)
+		}
+	} else {
+		result.fail("refresh-only run finished with status: %s", r.Status)
+	}
+	if err := s.db.updateResult(ctx, result); err != nil {
+		s.Error(err, "updating assessment result", "result", result)
+		return false, err
+	}
+	s.V(1).Info("completed health assessment", "result", result, "drifted", result.Drifted)
+	return true, nil
+}
+
+// gate returns featureflag.ErrDisabled if drift detection is disabled for the
+// organization of the workspace.
+func (s *Service) gate(ctx context.Context, workspaceID string) error {
+	ws, err := s.workspaces.Get(ctx, workspaceID)
+	if err != nil {
+		return fmt.Errorf("retrieving workspace: %w", err)
+	}
+	return s.flags.Gate(ctx, featureflag.DriftDetection, ws.Organization)
+}
+
+func (s *Service) getPlanFile(ctx context.Context, runID string) (*run.PlanFile, error) {
+	data, err := s.runs.GetPlanFile(ctx, runID, run.PlanFormatJSON)
+	if err != nil {
+		return nil, err
+	}
+	var planFile run.PlanFile
+	if err := json.Unmarshal(data, &planFile); err != nil {
+		return nil, err
+	}
+	return &planFile, nil
+}
+
+func (s *Service) listWorkspacesDue(ctx context.Context, assessedBefore time.Time) ([]string, error) {
+	return s.db.listWorkspacesDue(ctx, assessedBefore)
+}
+
+func (s *Service) listPendingResults(ctx context.Context) ([]*Result, error) {
+	return s.db.listPendingResults(ctx)
+}
@@ -0,0 +1,82 @@
+package assessment
+
+import (
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/leg100/otf/internal/http/decode"
+	"github.com/leg100/otf/internal/resource"
+	"github.com/leg100/otf/internal/tfeapi"
+	"github.com/leg100/otf/internal/tfeapi/types"
+)
+
+// tfe implements the TFE assessment results API:
+//
+// https://developer.hashicorp.com/terraform/cloud-docs/api-docs/assessment-results
+type tfe struct {
+	*Service
+	*tfeapi.Responder
+}
+
+func (a *tfe) addHandlers(r *mux.Router) {
+	r = r.PathPrefix(tfeapi.APIPrefixV2).Subrouter()
+
+	r.HandleFunc("/workspaces/{workspace_id}/assessment-results", a.listResults).Methods("GET")
+	r.HandleFunc("/assessment-results/{id}", a.getResult).Methods("GET")
+}
+
+func (a *tfe) listResults(w http.ResponseWriter, r *http.Request) {
+	var params struct {
+		WorkspaceID string `schema:"workspace_id,required"`
+		resource.PageOptions
+	}
+	if err := decode.All(&params, r); err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	results, err := a.ListResults(r.Context(), params.WorkspaceID)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	items := make([]*types.AssessmentResult, len(results))
+	for i, from := range results {
+		items[i] = a.convertResult(from)
+	}
+	page := resource.NewPage(items, params.PageOptions, nil)
+	a.RespondWithPage(w, r, page.Items, page.Pagination)
+}
+
+func (a *tfe) getResult(w http.ResponseWriter, r *http.Request) {
+	id, err := decode.Param("id", r)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	result, err := a.GetResult(r.Context(), id)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	a.Respond(w, r, a.convertResult(result), http.StatusOK)
+}
+
+func (a *tfe) convertResult(from *Result) *types.AssessmentResult {
+	to := &types.AssessmentResult{
+		ID:               from.ID,
+		Drifted:          from.Drifted,
+		Succeeded:        from.Succeeded(),
+		Status:           string(from.Status),
+		ResourcesDrifted: len(from.DriftedResources),
+		DriftedResources: from.DriftedResources,
+		CreatedAt:        from.CreatedAt,
+		Workspace:        &types.Workspace{ID: from.WorkspaceID},
+	}
+	if from.ErrorMessage != "" {
+		to.ErrorMsg = &from.ErrorMessage
+	}
+	if from.RunID != nil {
+		to.Run = &types.Run{ID: *from.RunID}
+	}
+	return to
+}
@@ -0,0 +1,86 @@
+package audit
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	otfapi "github.com/leg100/otf/internal/api"
+	"github.com/leg100/otf/internal/http/decode"
+	"github.com/leg100/otf/internal/resource"
+	"github.com/leg100/otf/internal/tfeapi"
+)
+
+type api struct {
+	*Service
+}
+
+func (a *api) addHandlers(r *mux.Router) {
+	r = r.PathPrefix(otfapi.DefaultBasePath).Subrouter()
+
+	r.HandleFunc("/organizations/{organization_name}/audit-events", a.list).Methods("GET")
+	r.HandleFunc("/organizations/{organization_name}/audit-settings", a.getSettings).Methods("GET")
+	r.HandleFunc("/organizations/{organization_name}/audit-settings", a.updateSettings).Methods("PUT")
+}
+
+func (a *api) list(w http.ResponseWriter, r *http.Request) {
+	var params struct {
+		Organization string `schema:"organization_name,required"`
+		ListOptions
+	}
+	if err := decode.All(&params, r); err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	page, err := a.List(r.Context(), params.Organization, params.ListOptions)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	a.respond(w, struct {
+		Events     []*Event             `json:"events"`
+		Pagination *resource.Pagination `json:"pagination"`
+	}{
+		Events:     page.Items,
+		Pagination: page.Pagination,
+	}, http.StatusOK)
+}
+
+func (a *api) getSettings(w http.ResponseWriter, r *http.Request) {
+	organization, err := decode.Param("organization_name", r)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	settings, err := a.GetSettings(r.Context(), organization)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	a.respond(w, settings, http.StatusOK)
+}
+
+func (a *api) updateSettings(w http.ResponseWriter, r *http.Request) {
+	organization, err := decode.Param("organization_name", r)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	var settings Settings
+	if err := json.NewDecoder(r.Body).Decode(&settings); err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	updated, err := a.UpdateSettings(r.Context(), organization, settings)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	a.respond(w, updated, http.StatusOK)
+}
+
+func (a *api) respond(w http.ResponseWriter, v any, code int) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	json.NewEncoder(w).Encode(v)
+}
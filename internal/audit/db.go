@@ -0,0 +1,123 @@
+package audit
+
+import (
+	"context"
+
+	"github.com/jackc/pgtype"
+	"github.com/jackc/pgx/v4"
+	"github.com/leg100/otf/internal"
+	"github.com/leg100/otf/internal/resource"
+	"github.com/leg100/otf/internal/sql"
+	"github.com/leg100/otf/internal/sql/pggen"
+)
+
+// pgdb is a database of audit events and settings on postgres
+type pgdb struct {
+	*sql.DB // provides access to generated SQL queries
+}
+
+type eventRow struct {
+	AuditEventID     pgtype.Text        `json:"audit_event_id"`
+	Time             pgtype.Timestamptz `json:"time"`
+	OrganizationName pgtype.Text        `json:"organization_name"`
+	Action           pgtype.Text        `json:"action"`
+	Subject          pgtype.Text        `json:"subject"`
+	TokenID          pgtype.Text        `json:"token_id"`
+	SourceIP         pgtype.Text        `json:"source_ip"`
+	ResourceID       pgtype.Text        `json:"resource_id"`
+	Justification    pgtype.Text        `json:"justification"`
+}
+
+func (r eventRow) toEvent() *Event {
+	event := &Event{
+		ID:           r.AuditEventID.String,
+		Time:         r.Time.Time.UTC(),
+		Organization: r.OrganizationName.String,
+		Action:       Action(r.Action.String),
+		Subject:      r.Subject.String,
+		ResourceID:   r.ResourceID.String,
+	}
+	if r.TokenID.Status == pgtype.Present {
+		event.TokenID = &r.TokenID.String
+	}
+	if r.SourceIP.Status == pgtype.Present {
+		event.SourceIP = &r.SourceIP.String
+	}
+	if r.Justification.Status == pgtype.Present {
+		event.Justification = &r.Justification.String
+	}
+	return event
+}
+
+func (db *pgdb) insert(ctx context.Context, event *Event) error {
+	_, err := db.Conn(ctx).InsertAuditEvent(ctx, pggen.InsertAuditEventParams{
+		AuditEventID:     sql.String(event.ID),
+		Time:             sql.Timestamptz(event.Time),
+		OrganizationName: sql.String(event.Organization),
+		Action:           sql.String(string(event.Action)),
+		Subject:          sql.String(event.Subject),
+		TokenID:          sql.StringPtr(event.TokenID),
+		SourceIP:         sql.StringPtr(event.SourceIP),
+		ResourceID:       sql.String(event.ResourceID),
+		Justification:    sql.StringPtr(event.Justification),
+	})
+	return sql.Error(err)
+}
+
+func (db *pgdb) list(ctx context.Context, organization string, opts ListOptions) (*resource.Page[*Event], error) {
+	q := db.Conn(ctx)
+	batch := &pgx.Batch{}
+
+	action := sql.NullString()
+	if opts.Action != nil {
+		action = sql.String(string(*opts.Action))
+	}
+	q.FindAuditEventsBatch(batch, pggen.FindAuditEventsParams{
+		OrganizationName: sql.String(organization),
+		Action:           action,
+		Limit:            opts.GetLimit(),
+		Offset:           opts.GetOffset(),
+	})
+	q.CountAuditEventsBatch(batch, sql.String(organization), action)
+
+	results := db.SendBatch(ctx, batch)
+	defer results.Close()
+
+	rows, err := q.FindAuditEventsScan(results)
+	if err != nil {
+		return nil, sql.Error(err)
+	}
+	count, err := q.CountAuditEventsScan(results)
+	if err != nil {
+		return nil, sql.Error(err)
+	}
+
+	items := make([]*Event, len(rows))
+	for i, r := range rows {
+		items[i] = eventRow(r).toEvent()
+	}
+	return resource.NewPage(items, opts.PageOptions, internal.Int64(count.Int)), nil
+}
+
+func (db *pgdb) getSettings(ctx context.Context, organization string) (*Settings, error) {
+	row, err := db.Conn(ctx).FindAuditSettings(ctx, sql.String(organization))
+	if err != nil {
+		return nil, sql.Error(err)
+	}
+	return &Settings{
+		RequireStateDownloadJustification: row.RequireStateDownloadJustification.Bool,
+	}, nil
+}
+
+func (db *pgdb) upsertSettings(ctx context.Context, organization string, settings Settings) error {
+	_, err := db.Conn(ctx).UpsertAuditSettings(ctx, sql.String(organization), sql.Bool(settings.RequireStateDownloadJustification))
+	return sql.Error(err)
+}
+
+func (db *pgdb) getOrganization(ctx context.Context, workspaceID string) (string, error) {
+	name, err := db.Conn(ctx).FindOrganizationNameByWorkspaceID(ctx, sql.String(workspaceID))
+	if err != nil {
+		return "", sql.Error(err)
+	}
+	return name.String, nil
+}
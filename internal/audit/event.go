@@ -0,0 +1,132 @@
+// Package audit records an audit trail of sensitive actions, such as
+// downloading state and configuration.
+package audit
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/leg100/otf/internal"
+	otfhttp "github.com/leg100/otf/internal/http"
+	"github.com/leg100/otf/internal/organization"
+	"github.com/leg100/otf/internal/resource"
+	"github.com/leg100/otf/internal/team"
+	"github.com/leg100/otf/internal/tokens"
+	"github.com/leg100/otf/internal/user"
+)
+
+const (
+	StateDownloadAction         Action = "state.download"
+	ConfigurationDownloadAction Action = "configuration.download"
+
+	// JustificationHeader is the HTTP header with which a client justifies
+	// downloading state.
+	JustificationHeader = "X-OTF-Justification"
+)
+
+// ErrJustificationRequired is returned when downloading state from an
+// organization that requires a justification, without providing one.
+var ErrJustificationRequired = errors.New("organization requires a justification for downloading state: provide one with the " + JustificationHeader + " header")
+
+type (
+	// Action is an audited action.
+	Action string
+
+	// Event is a record of a subject carrying out an audited action.
+	Event struct {
+		ID           string    `json:"id"`
+		Time         time.Time `json:"time"`
+		Organization string    `json:"organization"`
+		Action       Action    `json:"action"`
+		// Subject is the user, team, or other entity carrying out the action.
+		Subject string `json:"subject"`
+		// TokenID is the ID of the API token with which the subject
+		// authenticated, if any.
+		TokenID *string `json:"token_id,omitempty"`
+		// SourceIP is the IP address of the client, if the action was carried
+		// out via an HTTP request.
+		SourceIP *string `json:"source_ip,omitempty"`
+		// ResourceID is the ID of the resource acted upon, e.g. a state
+		// version ID.
+		ResourceID    string  `json:"resource_id"`
+		Justification *string `json:"justification,omitempty"`
+	}
+
+	// Settings are an organization's audit settings.
+	Settings struct {
+		// RequireStateDownloadJustification requires users and API tokens to
+		// justify downloading state.
+		RequireStateDownloadJustification bool `json:"require_state_download_justification"`
+	}
+
+	// RecordDownloadOptions are options for recording the download of state or
+	// configuration.
+	RecordDownloadOptions struct {
+		Action      Action
+		ResourceID  string
+		WorkspaceID string
+	}
+
+	// ListOptions are options for listing an organization's audit events.
+	ListOptions struct {
+		// Action filters events to those of the given action.
+		Action *Action `schema:"action"`
+
+		resource.PageOptions
+	}
+
+	justificationContextKey struct{}
+)
+
+func newEvent(ctx context.Context, subject internal.Subject, organization string, opts RecordDownloadOptions) *Event {
+	event := &Event{
+		ID:            resource.NewID(resource.AuditEventKind),
+		Time:          internal.CurrentTimestamp(nil),
+		Organization:  organization,
+		Action:        opts.Action,
+		Subject:       subject.String(),
+		ResourceID:    opts.ResourceID,
+		Justification: JustificationFromContext(ctx),
+	}
+	if id := tokens.TokenIDFromContext(ctx); id != "" {
+		event.TokenID = &id
+	}
+	if ip := otfhttp.ClientIPFromContext(ctx); ip != "" {
+		event.SourceIP = &ip
+	}
+	return event
+}
+
+// AddJustificationToContext adds a justification for downloading state to the
+// context. Clients send it to the server in the JustificationHeader.
+func AddJustificationToContext(ctx context.Context, justification string) context.Context {
+	return context.WithValue(ctx, justificationContextKey{}, justification)
+}
+
+// JustificationFromContext returns the justification for downloading state,
+// either added to the context with AddJustificationToContext, or provided by
+// the client in the JustificationHeader. Nil is returned if neither is found.
+func JustificationFromContext(ctx context.Context) *string {
+	if justification, ok := ctx.Value(justificationContextKey{}).(string); ok && justification != "" {
+		return &justification
+	}
+	if headers, err := otfhttp.HeadersFromContext(ctx); err == nil {
+		if justification := headers.Get(JustificationHeader); justification != "" {
+			return &justification
+		}
+	}
+	return nil
+}
+
+// requiresJustification determines whether the subject must justify
+// downloading state, if the organization requires it. Users and API tokens
+// must; subjects acting on behalf of runs, such as agents, need not.
+func requiresJustification(subject internal.Subject) bool {
+	switch subject.(type) {
+	case *user.User, *team.Team, *organization.OrganizationToken:
+		return true
+	default:
+		return false
+	}
+}
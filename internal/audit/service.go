@@ -0,0 +1,142 @@
+package audit
+
+import (
+	"context"
+	"errors"
+
+	"github.com/gorilla/mux"
+	"github.com/leg100/otf/internal"
+	"github.com/leg100/otf/internal/logr"
+	"github.com/leg100/otf/internal/organization"
+	"github.com/leg100/otf/internal/rbac"
+	"github.com/leg100/otf/internal/resource"
+	"github.com/leg100/otf/internal/sql"
+)
+
+type (
+	Service struct {
+		logr.Logger
+
+		organization internal.Authorizer
+
+		db  store
+		api *api
+	}
+
+	Options struct {
+		logr.Logger
+		*sql.DB
+	}
+
+	store interface {
+		insert(ctx context.Context, event *Event) error
+		list(ctx context.Context, organization string, opts ListOptions) (*resource.Page[*Event], error)
+		getSettings(ctx context.Context, organization string) (*Settings, error)
+		upsertSettings(ctx context.Context, organization string, settings Settings) error
+		getOrganization(ctx context.Context, workspaceID string) (string, error)
+	}
+)
+
+func NewService(opts Options) *Service {
+	svc := Service{
+		Logger:       opts.Logger,
+		organization: &organization.Authorizer{Logger: opts.Logger},
+		db:           &pgdb{opts.DB},
+	}
+	svc.api = &api{Service: &svc}
+	return &svc
+}
+
+func (s *Service) AddHandlers(r *mux.Router) {
+	s.api.addHandlers(r)
+}
+
+// RecordDownload records the download of state or configuration by the
+// subject in the context. If the download is of state, and the organization
+// requires a justification which the subject has not provided, then
+// ErrJustificationRequired is returned and nothing is recorded. The caller
+// is responsible for authorizing the download beforehand, and should refuse
+// the download if an error is returned.
+func (s *Service) RecordDownload(ctx context.Context, opts RecordDownloadOptions) error {
+	subject, err := internal.SubjectFromContext(ctx)
+	if err != nil {
+		return err
+	}
+	organization, err := s.db.getOrganization(ctx, opts.WorkspaceID)
+	if err != nil {
+		s.Error(err, "recording download", "resource_id", opts.ResourceID, "subject", subject)
+		return err
+	}
+	if opts.Action == StateDownloadAction && JustificationFromContext(ctx) == nil && requiresJustification(subject) {
+		settings, err := s.getSettings(ctx, organization)
+		if err != nil {
+			s.Error(err, "recording download", "resource_id", opts.ResourceID, "subject", subject)
+			return err
+		}
+		if settings.RequireStateDownloadJustification {
+			s.V(1).Info("rejected download without justification", "resource_id", opts.ResourceID, "subject", subject)
+			return ErrJustificationRequired
+		}
+	}
+	event := newEvent(ctx, subject, organization, opts)
+	if err := s.db.insert(ctx, event); err != nil {
+		s.Error(err, "recording download", "resource_id", opts.ResourceID, "subject", subject)
+		return err
+	}
+	s.V(1).Info("recorded download", "action", event.Action, "resource_id", event.ResourceID, "subject", subject)
+	return nil
+}
+
+// List lists an organization's audit events, most recent first.
+func (s *Service) List(ctx context.Context, organization string, opts ListOptions) (*resource.Page[*Event], error) {
+	subject, err := s.organization.CanAccess(ctx, rbac.ListAuditEventsAction, organization)
+	if err != nil {
+		return nil, err
+	}
+	page, err := s.db.list(ctx, organization, opts)
+	if err != nil {
+		s.Error(err, "listing audit events", "organization", organization, "subject", subject)
+		return nil, err
+	}
+	s.V(9).Info("listed audit events", "organization", organization, "count", len(page.Items), "subject", subject)
+	return page, nil
+}
+
+// GetSettings retrieves an organization's audit settings.
+func (s *Service) GetSettings(ctx context.Context, organization string) (*Settings, error) {
+	subject, err := s.organization.CanAccess(ctx, rbac.GetAuditSettingsAction, organization)
+	if err != nil {
+		return nil, err
+	}
+	settings, err := s.getSettings(ctx, organization)
+	if err != nil {
+		s.Error(err, "retrieving audit settings", "organization", organization, "subject", subject)
+		return nil, err
+	}
+	s.V(9).Info("retrieved audit settings", "organization", organization, "subject", subject)
+	return settings, nil
+}
+
+// UpdateSettings updates an organization's audit settings.
+func (s *Service) UpdateSettings(ctx context.Context, organization string, settings Settings) (*Settings, error) {
+	subject, err := s.organization.CanAccess(ctx, rbac.UpdateAuditSettingsAction, organization)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.db.upsertSettings(ctx, organization, settings); err != nil {
+		s.Error(err, "updating audit settings", "organization", organization, "subject", subject)
+		return nil, err
+	}
+	s.V(0).Info("updated audit settings", "organization", organization, "settings", settings, "subject", subject)
+	return &settings, nil
+}
+
+// getSettings retrieves an organization's audit settings, returning the
+// default settings if they have not been updated.
+func (s *Service) getSettings(ctx context.Context, organization string) (*Settings, error) {
+	settings, err := s.db.getSettings(ctx, organization)
+	if errors.Is(err, internal.ErrResourceNotFound) {
+		return &Settings{}, nil
+	}
+	return settings, err
+}
@@ -0,0 +1,128 @@
+package audit
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/leg100/otf/internal"
+	"github.com/leg100/otf/internal/user"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeStore struct {
+	settings *Settings
+	events   []*Event
+	store
+}
+
+func (f *fakeStore) getOrganization(context.Context, string) (string, error) {
+	return "acme-corp", nil
+}
+
+func (f *fakeStore) getSettings(context.Context, string) (*Settings, error) {
+	if f.settings == nil {
+		return nil, internal.ErrResourceNotFound
+	}
+	return f.settings, nil
+}
+
+func (f *fakeStore) insert(_ context.Context, event *Event) error {
+	f.events = append(f.events, event)
+	return nil
+}
+
+func TestService_RecordDownload(t *testing.T) {
+	bobby := user.NewUser("bobby")
+	stateDownload := RecordDownloadOptions{
+		Action:      StateDownloadAction,
+		ResourceID:  "sv-123",
+		WorkspaceID: "ws-123",
+	}
+
+	tests := []struct {
+		name          string
+		subject       internal.Subject
+		opts          RecordDownloadOptions
+		settings      *Settings
+		justification string
+		wantErr       error
+	}{
+		{
+			name:    "state download",
+			subject: bobby,
+			opts:    stateDownload,
+		},
+		{
+			name:     "state download without required justification",
+			subject:  bobby,
+			opts:     stateDownload,
+			settings: &Settings{RequireStateDownloadJustification: true},
+			wantErr:  ErrJustificationRequired,
+		},
+		{
+			name:          "state download with required justification",
+			subject:       bobby,
+			opts:          stateDownload,
+			settings:      &Settings{RequireStateDownloadJustification: true},
+			justification: "INC-123 investigating drift",
+		},
+		{
+			name:     "state download by run without justification",
+			subject:  &internal.Superuser{Username: "job"},
+			opts:     stateDownload,
+			settings: &Settings{RequireStateDownloadJustification: true},
+		},
+		{
+			name:    "configuration download without justification",
+			subject: bobby,
+			opts: RecordDownloadOptions{
+				Action:      ConfigurationDownloadAction,
+				ResourceID:  "cv-123",
+				WorkspaceID: "ws-123",
+			},
+			settings: &Settings{RequireStateDownloadJustification: true},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := &fakeStore{settings: tt.settings}
+			svc := &Service{Logger: logr.Discard(), db: db}
+			ctx := internal.AddSubjectToContext(context.Background(), tt.subject)
+			if tt.justification != "" {
+				ctx = AddJustificationToContext(ctx, tt.justification)
+			}
+
+			err := svc.RecordDownload(ctx, tt.opts)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.Empty(t, db.events)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, 1, len(db.events))
+			got := db.events[0]
+			assert.Equal(t, "acme-corp", got.Organization)
+			assert.Equal(t, tt.opts.Action, got.Action)
+			assert.Equal(t, tt.opts.ResourceID, got.ResourceID)
+			assert.Equal(t, tt.subject.String(), got.Subject)
+			if tt.justification != "" {
+				assert.Equal(t, &tt.justification, got.Justification)
+			} else {
+				assert.Nil(t, got.Justification)
+			}
+		})
+	}
+}
+
+func TestJustificationFromContext(t *testing.T) {
+	t.Run("none", func(t *testing.T) {
+		assert.Nil(t, JustificationFromContext(context.Background()))
+	})
+
+	t.Run("added to context", func(t *testing.T) {
+		ctx := AddJustificationToContext(context.Background(), "INC-123")
+		assert.Equal(t, "INC-123", *JustificationFromContext(ctx))
+	})
+}
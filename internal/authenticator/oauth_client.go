@@ -55,6 +55,9 @@ type (
 		Scopes              []string
 		Name                string
 		SkipTLSVerification bool
+		// SSO is true if the client logs users in via single sign-on, i.e.
+		// with the OIDC identity provider.
+		SSO bool
 	}
 )
 
@@ -162,7 +165,10 @@ func (a *OAuthClient) callbackHandler(w http.ResponseWriter, r *http.Request) {
 		html.Error(w, err.Error(), http.StatusInternalServerError, false)
 		return
 	}
-	err = a.sessions.StartSession(w, r, tokens.StartSessionOptions{Username: &username})
+	err = a.sessions.StartSession(w, r, tokens.StartSessionOptions{
+		Username: &username,
+		SSO:      a.SSO,
+	})
 	if err != nil {
 		html.Error(w, err.Error(), http.StatusInternalServerError, false)
 		return
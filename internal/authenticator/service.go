@@ -76,6 +76,7 @@ func NewAuthenticatorService(ctx context.Context, opts Options) (*service, error
 			ClientSecret:        opts.IDTokenHandlerConfig.ClientSecret,
 			Name:                opts.IDTokenHandlerConfig.Name,
 			SkipTLSVerification: opts.SkipTLSVerification,
+			SSO:                 true,
 		},
 	)
 	if err != nil {
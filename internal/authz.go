@@ -50,6 +50,72 @@ type WorkspacePermission struct {
 	Role   rbac.Role
 }
 
+// Decision is the outcome of evaluating whether a subject may carry out an
+// action, along with the reason for the outcome.
+type Decision struct {
+	Allowed bool   `json:"allowed"`
+	Reason  string `json:"reason"`
+	// Role is the name of the role permitting the action, if any.
+	Role string `json:"role,omitempty"`
+	// Team is the name of the team granting the role, if any.
+	Team string `json:"team,omitempty"`
+	// Scopes are the scopes of the token with which the subject
+	// authenticated, if the token restricts the subject to those scopes.
+	Scopes []string `json:"scopes,omitempty"`
+}
+
+// Explainer is a subject that can explain its authorization decisions.
+type Explainer interface {
+	ExplainSite(action rbac.Action) Decision
+	ExplainTeam(action rbac.Action, id string) Decision
+	ExplainOrganization(action rbac.Action, name string) Decision
+	ExplainWorkspace(action rbac.Action, policy WorkspacePolicy) Decision
+}
+
+// ExplainSite explains whether the subject may carry out the site-level
+// action. Subjects that are not an Explainer only report the outcome.
+func ExplainSite(subj Subject, action rbac.Action) Decision {
+	if e, ok := subj.(Explainer); ok {
+		return e.ExplainSite(action)
+	}
+	return decide(subj, subj.CanAccessSite(action))
+}
+
+// ExplainTeam explains whether the subject may carry out the action on the
+// team. Subjects that are not an Explainer only report the outcome.
+func ExplainTeam(subj Subject, action rbac.Action, id string) Decision {
+	if e, ok := subj.(Explainer); ok {
+		return e.ExplainTeam(action, id)
+	}
+	return decide(subj, subj.CanAccessTeam(action, id))
+}
+
+// ExplainOrganization explains whether the subject may carry out the action on
+// the organization. Subjects that are not an Explainer only report the
+// outcome.
+func ExplainOrganization(subj Subject, action rbac.Action, name string) Decision {
+	if e, ok := subj.(Explainer); ok {
+		return e.ExplainOrganization(action, name)
+	}
+	return decide(subj, subj.CanAccessOrganization(action, name))
+}
+
+// ExplainWorkspace explains whether the subject may carry out the action on
+// the workspace. Subjects that are not an Explainer only report the outcome.
+func ExplainWorkspace(subj Subject, action rbac.Action, policy WorkspacePolicy) Decision {
+	if e, ok := subj.(Explainer); ok {
+		return e.ExplainWorkspace(action, policy)
+	}
+	return decide(subj, subj.CanAccessWorkspace(action, policy))
+}
+
+func decide(subj Subject, allowed bool) Decision {
+	if allowed {
+		return Decision{Allowed: true, Reason: fmt.Sprintf("%s is permitted to carry out the action", subj)}
+	}
+	return Decision{Reason: fmt.Sprintf("%s is not permitted to carry out the action", subj)}
+}
+
 // AddSubjectToContext adds a subject to a context
 func AddSubjectToContext(ctx context.Context, subj Subject) context.Context {
 	return context.WithValue(ctx, subjectCtxKey, subj)
@@ -0,0 +1,94 @@
+package authz
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/leg100/otf/internal"
+	"github.com/leg100/otf/internal/http/decode"
+	"github.com/leg100/otf/internal/tfeapi"
+)
+
+// MaxBulkChecks is the maximum number of checks in a bulk request.
+const MaxBulkChecks = 100
+
+type (
+	api struct {
+		*Service
+	}
+
+	// BulkCheckOptions are options for checking several actions at once.
+	BulkCheckOptions struct {
+		Checks []Check `json:"checks"`
+	}
+
+	// BulkResult is the outcome of a check in a bulk request. If the check
+	// could not be carried out then Error is set.
+	BulkResult struct {
+		Result
+
+		Error string `json:"error,omitempty"`
+	}
+)
+
+func (a *api) addHandlers(r *mux.Router) {
+	r = r.PathPrefix(tfeapi.APIPrefixV2).Subrouter()
+
+	r.HandleFunc("/authz/check", a.check).Methods("GET")
+	r.HandleFunc("/authz/check", a.checkBulk).Methods("POST")
+}
+
+func (a *api) check(w http.ResponseWriter, r *http.Request) {
+	var params Check
+	if err := decode.Query(&params, r.URL.Query()); err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+
+	result, err := a.Check(r.Context(), params)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+
+	w.Header().Set("Content-type", "application/json")
+	json.NewEncoder(w).Encode(result)
+}
+
+func (a *api) checkBulk(w http.ResponseWriter, r *http.Request) {
+	var opts BulkCheckOptions
+	if err := json.NewDecoder(r.Body).Decode(&opts); err != nil {
+		tfeapi.Error(w, &internal.HTTPError{Code: http.StatusUnprocessableEntity, Message: err.Error()})
+		return
+	}
+	if len(opts.Checks) > MaxBulkChecks {
+		tfeapi.Error(w, &internal.InvalidParameterError{
+			Parameter: "checks",
+			Err:       fmt.Errorf("no more than %d checks permitted", MaxBulkChecks),
+		})
+		return
+	}
+
+	results := make([]BulkResult, len(opts.Checks))
+	for i, check := range opts.Checks {
+		result, err := a.Check(r.Context(), check)
+		if err != nil {
+			var invalid *internal.InvalidParameterError
+			if !errors.As(err, &invalid) && !errors.Is(err, internal.ErrResourceNotFound) {
+				tfeapi.Error(w, err)
+				return
+			}
+			results[i] = BulkResult{Result: Result{Check: check}, Error: err.Error()}
+			continue
+		}
+		results[i] = BulkResult{Result: *result}
+	}
+
+	w.Header().Set("Content-type", "application/json")
+	json.NewEncoder(w).Encode(struct {
+		Results []BulkResult `json:"results"`
+	}{results})
+}
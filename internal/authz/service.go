@@ -0,0 +1,124 @@
+// Package authz explains authorization decisions, helping users to debug why
+// they are or are not permitted to carry out actions.
+package authz
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gorilla/mux"
+	"github.com/leg100/otf/internal"
+	"github.com/leg100/otf/internal/logr"
+	"github.com/leg100/otf/internal/rbac"
+	"github.com/leg100/otf/internal/resource"
+)
+
+// SiteResource is the resource identifying the site, on which site-level
+// actions are carried out.
+const SiteResource = "site"
+
+type (
+	// Service checks whether the subject carrying out a request is permitted
+	// to carry out actions on resources, explaining each decision.
+	Service struct {
+		logr.Logger
+
+		policies map[resource.Kind]PolicyFunc
+		api      *api
+	}
+
+	Options struct {
+		logr.Logger
+	}
+
+	// PolicyFunc retrieves the policy of the workspace to which a resource
+	// belongs.
+	PolicyFunc func(ctx context.Context, id string) (internal.WorkspacePolicy, error)
+
+	// Check is a request to check whether the subject is permitted to carry
+	// out an action on a resource.
+	Check struct {
+		// Action is the name of the action, e.g. ApplyRunAction.
+		Action string `json:"action" schema:"action,required"`
+		// Resource is either the ID of a resource, the name of an
+		// organization, or SiteResource. Defaults to SiteResource.
+		Resource string `json:"resource" schema:"resource"`
+	}
+
+	// Result is the outcome of a check.
+	Result struct {
+		Check
+		internal.Decision
+
+		Subject string `json:"subject"`
+	}
+)
+
+func NewService(opts Options) *Service {
+	svc := &Service{
+		Logger:   opts.Logger,
+		policies: make(map[resource.Kind]PolicyFunc),
+	}
+	svc.api = &api{Service: svc}
+	return svc
+}
+
+func (s *Service) AddHandlers(r *mux.Router) {
+	s.api.addHandlers(r)
+}
+
+// Register a function for retrieving the workspace policy for resources of the
+// given kind.
+func (s *Service) Register(kind resource.Kind, fn PolicyFunc) {
+	s.policies[kind] = fn
+}
+
+// Check whether the subject in the context is permitted to carry out the
+// action on the resource.
+func (s *Service) Check(ctx context.Context, check Check) (*Result, error) {
+	subject, err := internal.SubjectFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	action, err := rbac.ParseAction(check.Action)
+	if err != nil {
+		return nil, &internal.InvalidParameterError{Parameter: "action", Err: err}
+	}
+	if check.Resource == "" {
+		check.Resource = SiteResource
+	}
+	decision, err := s.explain(ctx, subject, action, check.Resource)
+	if err != nil {
+		return nil, err
+	}
+
+	s.V(9).Info("checked authorization", "subject", subject, "action", action, "resource", check.Resource, "allowed", decision.Allowed)
+
+	return &Result{Check: check, Decision: decision, Subject: subject.String()}, nil
+}
+
+func (s *Service) explain(ctx context.Context, subject internal.Subject, action rbac.Action, ref string) (internal.Decision, error) {
+	if ref == SiteResource {
+		return internal.ExplainSite(subject, action), nil
+	}
+	id, err := resource.ParseID(ref)
+	if err != nil {
+		// not an ID so assume it is the name of an organization.
+		return internal.ExplainOrganization(subject, action, ref), nil
+	}
+	if id.Kind == resource.TeamKind {
+		return internal.ExplainTeam(subject, action, ref), nil
+	}
+	fn, ok := s.policies[id.Kind]
+	if !ok {
+		return internal.Decision{}, &internal.InvalidParameterError{
+			Parameter: "resource",
+			Err:       fmt.Errorf("unsupported kind of resource: %s", id.Kind),
+		}
+	}
+	policy, err := fn(ctx, ref)
+	if err != nil {
+		return internal.Decision{}, err
+	}
+	return internal.ExplainWorkspace(subject, action, policy), nil
+}
@@ -0,0 +1,125 @@
+package authz
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/leg100/otf/internal"
+	"github.com/leg100/otf/internal/logr"
+	"github.com/leg100/otf/internal/rbac"
+	"github.com/leg100/otf/internal/resource"
+	"github.com/leg100/otf/internal/team"
+	"github.com/leg100/otf/internal/user"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheck(t *testing.T) {
+	devs := &team.Team{ID: "team-devs", Name: "devs", Organization: "acme"}
+	subject := user.NewUser("bob", user.WithTeams(devs))
+	ctx := internal.AddSubjectToContext(context.Background(), subject)
+
+	svc := NewService(Options{Logger: logr.Discard()})
+	svc.Register(resource.WorkspaceKind, func(ctx context.Context, id string) (internal.WorkspacePolicy, error) {
+		if id != "ws-abc123" {
+			return internal.WorkspacePolicy{}, internal.ErrResourceNotFound
+		}
+		return internal.WorkspacePolicy{
+			Organization: "acme",
+			WorkspaceID:  id,
+			Permissions: []internal.WorkspacePermission{
+				{TeamID: "team-devs", Role: rbac.WorkspaceWriteRole},
+			},
+		}, nil
+	})
+
+	t.Run("workspace", func(t *testing.T) {
+		got, err := svc.Check(ctx, Check{Action: "ApplyRunAction", Resource: "ws-abc123"})
+		require.NoError(t, err)
+		assert.True(t, got.Allowed)
+		assert.Equal(t, "write", got.Role)
+		assert.Equal(t, "devs", got.Team)
+		assert.Equal(t, "bob", got.Subject)
+	})
+
+	t.Run("organization", func(t *testing.T) {
+		got, err := svc.Check(ctx, Check{Action: "CreateWorkspace", Resource: "acme"})
+		require.NoError(t, err)
+		assert.False(t, got.Allowed)
+	})
+
+	t.Run("team", func(t *testing.T) {
+		got, err := svc.Check(ctx, Check{Action: "GetTeamAction", Resource: "team-devs"})
+		require.NoError(t, err)
+		assert.True(t, got.Allowed)
+	})
+
+	t.Run("site", func(t *testing.T) {
+		got, err := svc.Check(ctx, Check{Action: "CreateBannerAction"})
+		require.NoError(t, err)
+		assert.False(t, got.Allowed)
+		assert.Equal(t, SiteResource, got.Resource)
+	})
+
+	t.Run("unknown action", func(t *testing.T) {
+		_, err := svc.Check(ctx, Check{Action: "FlyAction", Resource: "acme"})
+		var invalid *internal.InvalidParameterError
+		assert.True(t, errors.As(err, &invalid), "got error: %v", err)
+	})
+
+	t.Run("unsupported kind of resource", func(t *testing.T) {
+		_, err := svc.Check(ctx, Check{Action: "GetModuleAction", Resource: "mod-abc123"})
+		var invalid *internal.InvalidParameterError
+		assert.True(t, errors.As(err, &invalid), "got error: %v", err)
+	})
+
+	t.Run("api", func(t *testing.T) {
+		r := mux.NewRouter()
+		svc.AddHandlers(r)
+
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest("GET", "/api/v2/authz/check?action=ApplyRunAction&resource=ws-abc123", nil)
+		r.ServeHTTP(w, req.WithContext(ctx))
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		var got Result
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&got))
+		assert.True(t, got.Allowed)
+		assert.Equal(t, "ApplyRunAction", got.Action)
+
+		w = httptest.NewRecorder()
+		req = httptest.NewRequest("GET", "/api/v2/authz/check?action=ApplyRunAction&resource=ws-xyz789", nil)
+		r.ServeHTTP(w, req.WithContext(ctx))
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("bulk api", func(t *testing.T) {
+		r := mux.NewRouter()
+		svc.AddHandlers(r)
+
+		body, err := json.Marshal(BulkCheckOptions{Checks: []Check{
+			{Action: "ApplyRunAction", Resource: "ws-abc123"},
+			{Action: "ApplyRunAction", Resource: "ws-xyz789"},
+			{Action: "CreateBannerAction", Resource: "site"},
+		}})
+		require.NoError(t, err)
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest("POST", "/api/v2/authz/check", bytes.NewReader(body))
+		r.ServeHTTP(w, req.WithContext(ctx))
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		var got struct {
+			Results []BulkResult `json:"results"`
+		}
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&got))
+		require.Len(t, got.Results, 3)
+		assert.True(t, got.Results[0].Allowed)
+		assert.NotEmpty(t, got.Results[1].Error)
+		assert.Equal(t, "ws-xyz789", got.Results[1].Resource)
+		assert.False(t, got.Results[2].Allowed)
+	})
+}
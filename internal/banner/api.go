@@ -0,0 +1,120 @@
+package banner
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/gorilla/mux"
+	"github.com/leg100/otf/internal"
+	otfapi "github.com/leg100/otf/internal/api"
+	"github.com/leg100/otf/internal/http/decode"
+	"github.com/leg100/otf/internal/tfeapi"
+)
+
+type api struct {
+	*Service
+}
+
+// motdResponse is the body of a response to the terraform CLI's message of
+// the day request.
+type motdResponse struct {
+	Msg string `json:"msg"`
+}
+
+func (a *api) addHandlers(r *mux.Router) {
+	// message of the day, advertised to terraform via the service discovery
+	// document; the CLI requests it without authentication.
+	r.HandleFunc("/api/terraform/motd", a.motd).Methods("GET")
+
+	r = r.PathPrefix(otfapi.DefaultBasePath).Subrouter()
+
+	r.HandleFunc("/banners", a.active).Methods("GET")
+	r.HandleFunc("/admin/banners", a.list).Methods("GET")
+	r.HandleFunc("/admin/banners", a.create).Methods("POST")
+	r.HandleFunc("/admin/banners/{banner_id}", a.delete).Methods("DELETE")
+}
+
+func (a *api) active(w http.ResponseWriter, r *http.Request) {
+	var params struct {
+		Organization string `schema:"organization"`
+	}
+	if err := decode.Query(&params, r.URL.Query()); err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	banners, err := a.ListActive(r.Context(), params.Organization)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	a.respond(w, banners, http.StatusOK)
+}
+
+func (a *api) list(w http.ResponseWriter, r *http.Request) {
+	banners, err := a.List(r.Context())
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	a.respond(w, banners, http.StatusOK)
+}
+
+func (a *api) create(w http.ResponseWriter, r *http.Request) {
+	var opts CreateOptions
+	if err := json.NewDecoder(r.Body).Decode(&opts); err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	b, err := a.Create(r.Context(), opts)
+	if err != nil {
+		if isValidationError(err) {
+			err = &internal.HTTPError{Code: http.StatusUnprocessableEntity, Message: err.Error()}
+		}
+		tfeapi.Error(w, err)
+		return
+	}
+	a.respond(w, b, http.StatusCreated)
+}
+
+func (a *api) delete(w http.ResponseWriter, r *http.Request) {
+	bannerID, err := decode.Param("banner_id", r)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	if err := a.Delete(r.Context(), bannerID); err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// motd responds with the messages of active site-wide banners. Banners
+// targeting organizations are omitted because the request is unauthenticated.
+func (a *api) motd(w http.ResponseWriter, r *http.Request) {
+	banners, err := a.listActive(r.Context(), "")
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	msgs := make([]string, len(banners))
+	for i, b := range banners {
+		msgs[i] = b.Message
+	}
+	a.respond(w, motdResponse{Msg: strings.Join(msgs, "\n")}, http.StatusOK)
+}
+
+func (a *api) respond(w http.ResponseWriter, v any, code int) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	json.NewEncoder(w).Encode(v)
+}
+
+func isValidationError(err error) bool {
+	return errors.Is(err, ErrEmptyMessage) ||
+		errors.Is(err, ErrInvalidLevel) ||
+		errors.Is(err, ErrInvalidEndTime) ||
+		errors.Is(err, internal.ErrInvalidName)
+}
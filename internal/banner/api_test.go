@@ -0,0 +1,46 @@
+package banner
+
+import (
+	"context"
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/leg100/otf/internal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeStore struct {
+	banners []*Banner
+	store
+}
+
+func (f *fakeStore) list(context.Context) ([]*Banner, error) {
+	return f.banners, nil
+}
+
+func TestAPI_MOTD(t *testing.T) {
+	now := internal.CurrentTimestamp(nil)
+	api := &api{Service: &Service{
+		Logger: logr.Discard(),
+		db: &fakeStore{banners: []*Banner{
+			{Message: "upgrade tonight", Level: LevelInfo, StartsAt: now.Add(-time.Hour)},
+			{Message: "outage", Level: LevelCritical, StartsAt: now.Add(-time.Hour)},
+			{Message: "acme only", Level: LevelInfo, StartsAt: now.Add(-time.Hour), Organizations: []string{"acme"}},
+			{Message: "expired", Level: LevelInfo, StartsAt: now.Add(-2 * time.Hour), EndsAt: internal.Time(now.Add(-time.Hour))},
+			{Message: "upcoming", Level: LevelInfo, StartsAt: now.Add(time.Hour)},
+		}},
+	}}
+
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest("GET", "/api/terraform/motd", nil)
+	api.motd(w, r)
+
+	require.Equal(t, 200, w.Code, w.Body.String())
+	var got motdResponse
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&got))
+	assert.Equal(t, "outage\nupgrade tonight", got.Msg)
+}
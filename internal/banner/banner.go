@@ -0,0 +1,127 @@
+// Package banner provides announcement banners, managed by site admins and
+// displayed to users.
+package banner
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/leg100/otf/internal"
+	"github.com/leg100/otf/internal/resource"
+)
+
+const (
+	LevelInfo     Level = "info"
+	LevelWarning  Level = "warning"
+	LevelCritical Level = "critical"
+)
+
+var (
+	ErrEmptyMessage   = errors.New("banner message cannot be empty")
+	ErrInvalidLevel   = errors.New("banner level must be one of: info, warning, critical")
+	ErrInvalidEndTime = errors.New("banner end time must be after its start time")
+)
+
+type (
+	// Banner is an announcement displayed to users for a period of time.
+	Banner struct {
+		ID        string    `json:"id"`
+		CreatedAt time.Time `json:"created_at"`
+		Message   string    `json:"message"`
+		Level     Level     `json:"level"`
+		// StartsAt is when the banner is first displayed.
+		StartsAt time.Time `json:"starts_at"`
+		// EndsAt is when the banner stops being displayed. If nil then the
+		// banner is displayed until it is deleted.
+		EndsAt *time.Time `json:"ends_at,omitempty"`
+		// Organizations restricts the banner to the named organizations. If
+		// empty then the banner is site-wide.
+		Organizations []string `json:"organizations"`
+	}
+
+	// Level is the severity of a banner.
+	Level string
+
+	CreateOptions struct {
+		Message string `json:"message"`
+		// Level defaults to info.
+		Level Level `json:"level"`
+		// StartsAt defaults to now.
+		StartsAt      *time.Time `json:"starts_at"`
+		EndsAt        *time.Time `json:"ends_at"`
+		Organizations []string   `json:"organizations"`
+	}
+)
+
+func newBanner(opts CreateOptions) (*Banner, error) {
+	now := internal.CurrentTimestamp(nil)
+	b := Banner{
+		ID:            resource.NewID(resource.BannerKind),
+		CreatedAt:     now,
+		Message:       opts.Message,
+		Level:         LevelInfo,
+		StartsAt:      now,
+		Organizations: opts.Organizations,
+	}
+	if b.Message == "" {
+		return nil, ErrEmptyMessage
+	}
+	if opts.Level != "" {
+		switch opts.Level {
+		case LevelInfo, LevelWarning, LevelCritical:
+			b.Level = opts.Level
+		default:
+			return nil, ErrInvalidLevel
+		}
+	}
+	if opts.StartsAt != nil {
+		b.StartsAt = opts.StartsAt.UTC()
+	}
+	if opts.EndsAt != nil {
+		if !opts.EndsAt.After(b.StartsAt) {
+			return nil, ErrInvalidEndTime
+		}
+		b.EndsAt = internal.Time(opts.EndsAt.UTC())
+	}
+	for _, org := range b.Organizations {
+		if err := resource.ValidateName(&org); err != nil {
+			return nil, fmt.Errorf("invalid organization name: %w", err)
+		}
+	}
+	if b.Organizations == nil {
+		b.Organizations = []string{}
+	}
+	return &b, nil
+}
+
+// severity ranks levels so that more severe banners are displayed first.
+func (l Level) severity() int {
+	switch l {
+	case LevelCritical:
+		return 2
+	case LevelWarning:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// Active determines whether the banner should be displayed at the given time.
+func (b *Banner) Active(now time.Time) bool {
+	if now.Before(b.StartsAt) {
+		return false
+	}
+	return b.EndsAt == nil || now.Before(*b.EndsAt)
+}
+
+// Targets determines whether the banner should be displayed in the context of
+// the given organization. Site-wide banners target every organization, as
+// well as pages outside of an organization, for which organization is empty.
+func (b *Banner) Targets(organization string) bool {
+	if len(b.Organizations) == 0 {
+		return true
+	}
+	return slices.Contains(b.Organizations, organization)
+}
@@ -0,0 +1,110 @@
+package banner
+
+import (
+	"testing"
+	"time"
+
+	"github.com/leg100/otf/internal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewBanner(t *testing.T) {
+	now := internal.CurrentTimestamp(nil)
+
+	tests := []struct {
+		name string
+		opts CreateOptions
+		want func(*testing.T, *Banner)
+		err  error
+	}{
+		{
+			name: "defaults",
+			opts: CreateOptions{Message: "scheduled maintenance"},
+			want: func(t *testing.T, b *Banner) {
+				assert.Equal(t, LevelInfo, b.Level)
+				assert.False(t, b.StartsAt.Before(now))
+				assert.Nil(t, b.EndsAt)
+				assert.Equal(t, []string{}, b.Organizations)
+			},
+		},
+		{
+			name: "critical with time window and organizations",
+			opts: CreateOptions{
+				Message:       "database upgrade",
+				Level:         LevelCritical,
+				StartsAt:      internal.Time(now.Add(time.Hour)),
+				EndsAt:        internal.Time(now.Add(2 * time.Hour)),
+				Organizations: []string{"acme"},
+			},
+			want: func(t *testing.T, b *Banner) {
+				assert.Equal(t, LevelCritical, b.Level)
+				assert.Equal(t, now.Add(time.Hour), b.StartsAt)
+				assert.Equal(t, now.Add(2*time.Hour), *b.EndsAt)
+				assert.Equal(t, []string{"acme"}, b.Organizations)
+			},
+		},
+		{
+			name: "empty message",
+			opts: CreateOptions{},
+			err:  ErrEmptyMessage,
+		},
+		{
+			name: "invalid level",
+			opts: CreateOptions{Message: "hello", Level: "urgent"},
+			err:  ErrInvalidLevel,
+		},
+		{
+			name: "ends before it starts",
+			opts: CreateOptions{Message: "hello", EndsAt: internal.Time(now.Add(-time.Hour))},
+			err:  ErrInvalidEndTime,
+		},
+		{
+			name: "invalid organization name",
+			opts: CreateOptions{Message: "hello", Organizations: []string{"a/b"}},
+			err:  internal.ErrInvalidName,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := newBanner(tt.opts)
+			if tt.err != nil {
+				assert.ErrorIs(t, err, tt.err)
+				return
+			}
+			require.NoError(t, err)
+			tt.want(t, got)
+		})
+	}
+}
+
+func TestBanner_Active(t *testing.T) {
+	now := internal.CurrentTimestamp(nil)
+
+	tests := []struct {
+		name   string
+		banner Banner
+		want   bool
+	}{
+		{"started", Banner{StartsAt: now.Add(-time.Hour)}, true},
+		{"not yet started", Banner{StartsAt: now.Add(time.Hour)}, false},
+		{"not yet ended", Banner{StartsAt: now.Add(-time.Hour), EndsAt: internal.Time(now.Add(time.Hour))}, true},
+		{"ended", Banner{StartsAt: now.Add(-2 * time.Hour), EndsAt: internal.Time(now.Add(-time.Hour))}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.banner.Active(now))
+		})
+	}
+}
+
+func TestBanner_Targets(t *testing.T) {
+	siteWide := Banner{}
+	assert.True(t, siteWide.Targets(""))
+	assert.True(t, siteWide.Targets("acme"))
+
+	targeted := Banner{Organizations: []string{"acme"}}
+	assert.False(t, targeted.Targets(""))
+	assert.True(t, targeted.Targets("acme"))
+	assert.False(t, targeted.Targets("globex"))
+}
@@ -0,0 +1,78 @@
+package banner
+
+import (
+	"context"
+
+	"github.com/jackc/pgtype"
+	"github.com/leg100/otf/internal"
+	"github.com/leg100/otf/internal/sql"
+	"github.com/leg100/otf/internal/sql/pggen"
+)
+
+type (
+	// pgdb is a database of banners on postgres
+	pgdb struct {
+		*sql.DB // provides access to generated SQL queries
+	}
+
+	bannerRow struct {
+		BannerID      pgtype.Text        `json:"banner_id"`
+		CreatedAt     pgtype.Timestamptz `json:"created_at"`
+		Message       pgtype.Text        `json:"message"`
+		Level         pgtype.Text        `json:"level"`
+		StartsAt      pgtype.Timestamptz `json:"starts_at"`
+		EndsAt        pgtype.Timestamptz `json:"ends_at"`
+		Organizations []string           `json:"organizations"`
+	}
+)
+
+func (r bannerRow) toBanner() *Banner {
+	b := &Banner{
+		ID:            r.BannerID.String,
+		CreatedAt:     r.CreatedAt.Time.UTC(),
+		Message:       r.Message.String,
+		Level:         Level(r.Level.String),
+		StartsAt:      r.StartsAt.Time.UTC(),
+		Organizations: r.Organizations,
+	}
+	if r.EndsAt.Status == pgtype.Present {
+		b.EndsAt = internal.Time(r.EndsAt.Time.UTC())
+	}
+	return b
+}
+
+func (db *pgdb) create(ctx context.Context, b *Banner) error {
+	_, err := db.Conn(ctx).InsertBanner(ctx, pggen.InsertBannerParams{
+		BannerID:      sql.String(b.ID),
+		CreatedAt:     sql.Timestamptz(b.CreatedAt),
+		Message:       sql.String(b.Message),
+		Level:         sql.String(string(b.Level)),
+		StartsAt:      sql.Timestamptz(b.StartsAt),
+		EndsAt:        sql.TimestamptzPtr(b.EndsAt),
+		Organizations: b.Organizations,
+	})
+	if err != nil {
+		return sql.Error(err)
+	}
+	return nil
+}
+
+func (db *pgdb) list(ctx context.Context) ([]*Banner, error) {
+	rows, err := db.Conn(ctx).FindBanners(ctx)
+	if err != nil {
+		return nil, sql.Error(err)
+	}
+	banners := make([]*Banner, len(rows))
+	for i, r := range rows {
+		banners[i] = bannerRow(r).toBanner()
+	}
+	return banners, nil
+}
+
+func (db *pgdb) delete(ctx context.Context, bannerID string) error {
+	_, err := db.Conn(ctx).DeleteBannerByID(ctx, sql.String(bannerID))
+	if err != nil {
+		return sql.Error(err)
+	}
+	return nil
+}
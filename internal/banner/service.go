@@ -0,0 +1,139 @@
+package banner
+
+import (
+	"context"
+	"sort"
+
+	"github.com/gorilla/mux"
+	"github.com/leg100/otf/internal"
+	"github.com/leg100/otf/internal/http/html"
+	"github.com/leg100/otf/internal/logr"
+	"github.com/leg100/otf/internal/organization"
+	"github.com/leg100/otf/internal/rbac"
+	"github.com/leg100/otf/internal/sql"
+)
+
+type (
+	Service struct {
+		logr.Logger
+
+		site         internal.Authorizer
+		organization internal.Authorizer
+
+		db  store
+		api *api
+		web *webHandlers
+	}
+
+	Options struct {
+		logr.Logger
+		*sql.DB
+		html.Renderer
+	}
+
+	store interface {
+		create(ctx context.Context, b *Banner) error
+		list(ctx context.Context) ([]*Banner, error)
+		delete(ctx context.Context, bannerID string) error
+	}
+)
+
+func NewService(opts Options) *Service {
+	svc := Service{
+		Logger:       opts.Logger,
+		site:         &internal.SiteAuthorizer{Logger: opts.Logger},
+		organization: &organization.Authorizer{Logger: opts.Logger},
+		db:           &pgdb{opts.DB},
+	}
+	svc.api = &api{Service: &svc}
+	svc.web = &webHandlers{Renderer: opts.Renderer, Service: &svc}
+	return &svc
+}
+
+func (s *Service) AddHandlers(r *mux.Router) {
+	s.api.addHandlers(r)
+	s.web.addHandlers(r)
+}
+
+// Create creates a banner. Only a site admin can create a banner.
+func (s *Service) Create(ctx context.Context, opts CreateOptions) (*Banner, error) {
+	subject, err := s.site.CanAccess(ctx, rbac.CreateBannerAction, "")
+	if err != nil {
+		return nil, err
+	}
+	b, err := newBanner(opts)
+	if err != nil {
+		s.Error(err, "constructing banner", "subject", subject)
+		return nil, err
+	}
+	if err := s.db.create(ctx, b); err != nil {
+		s.Error(err, "creating banner", "subject", subject)
+		return nil, err
+	}
+	s.V(0).Info("created banner", "id", b.ID, "level", b.Level, "organizations", b.Organizations, "subject", subject)
+	return b, nil
+}
+
+// List lists all banners, including those that are not active, most
+// recently started first. Only a site admin can list all banners.
+func (s *Service) List(ctx context.Context) ([]*Banner, error) {
+	subject, err := s.site.CanAccess(ctx, rbac.ListBannersAction, "")
+	if err != nil {
+		return nil, err
+	}
+	banners, err := s.db.list(ctx)
+	if err != nil {
+		s.Error(err, "listing banners", "subject", subject)
+		return nil, err
+	}
+	s.V(9).Info("listed banners", "count", len(banners), "subject", subject)
+	return banners, nil
+}
+
+// Delete deletes a banner. Only a site admin can delete a banner.
+func (s *Service) Delete(ctx context.Context, bannerID string) error {
+	subject, err := s.site.CanAccess(ctx, rbac.DeleteBannerAction, "")
+	if err != nil {
+		return err
+	}
+	if err := s.db.delete(ctx, bannerID); err != nil {
+		s.Error(err, "deleting banner", "id", bannerID, "subject", subject)
+		return err
+	}
+	s.V(0).Info("deleted banner", "id", bannerID, "subject", subject)
+	return nil
+}
+
+// ListActive lists the banners to display in the context of the given
+// organization, or outside of any organization if organization is empty.
+// Banners are ordered by level, most severe first.
+func (s *Service) ListActive(ctx context.Context, organization string) ([]*Banner, error) {
+	if organization != "" {
+		if _, err := s.organization.CanAccess(ctx, rbac.GetOrganizationAction, organization); err != nil {
+			return nil, err
+		}
+	} else if _, err := internal.SubjectFromContext(ctx); err != nil {
+		return nil, err
+	}
+	return s.listActive(ctx, organization)
+}
+
+// listActive lists active banners without authorization.
+func (s *Service) listActive(ctx context.Context, organization string) ([]*Banner, error) {
+	banners, err := s.db.list(ctx)
+	if err != nil {
+		s.Error(err, "listing banners")
+		return nil, err
+	}
+	now := internal.CurrentTimestamp(nil)
+	var active []*Banner
+	for _, b := range banners {
+		if b.Active(now) && b.Targets(organization) {
+			active = append(active, b)
+		}
+	}
+	sort.SliceStable(active, func(i, j int) bool {
+		return active[i].Level.severity() > active[j].Level.severity()
+	})
+	return active, nil
+}
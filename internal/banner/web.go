@@ -0,0 +1,38 @@
+package banner
+
+import (
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/leg100/otf/internal/http/decode"
+	"github.com/leg100/otf/internal/http/html"
+)
+
+type webHandlers struct {
+	html.Renderer
+	*Service
+}
+
+func (h *webHandlers) addHandlers(r *mux.Router) {
+	r = html.UIRouter(r)
+
+	r.HandleFunc("/banners", h.listActive).Methods("GET")
+}
+
+// listActive renders the active banners for inclusion in every page.
+func (h *webHandlers) listActive(w http.ResponseWriter, r *http.Request) {
+	var params struct {
+		Organization string `schema:"organization"`
+	}
+	if err := decode.All(&params, r); err != nil {
+		h.Error(w, err.Error(), http.StatusUnprocessableEntity)
+		return
+	}
+
+	// ignore errors and instead render no banners
+	banners, _ := h.ListActive(r.Context(), params.Organization)
+
+	if err := h.RenderTemplate("banners.tmpl", w, banners); err != nil {
+		h.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
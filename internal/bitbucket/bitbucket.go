@@ -0,0 +1,10 @@
+// Package bitbucket provides bitbucket cloud related code
+package bitbucket
+
+const (
+	DefaultHostname string = "bitbucket.org"
+
+	// defaultAPIHostname is the hostname of the bitbucket cloud API, which
+	// differs from the hostname of the website.
+	defaultAPIHostname string = "api.bitbucket.org"
+)
@@ -0,0 +1,529 @@
+package bitbucket
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/leg100/otf/internal"
+	otfhttp "github.com/leg100/otf/internal/http"
+	"github.com/leg100/otf/internal/vcs"
+)
+
+// maxPageSize is the maximum number of items bitbucket returns in a page.
+const maxPageSize = 100
+
+type (
+	// Client is a client for the bitbucket cloud REST API:
+	//
+	// https://developer.atlassian.com/cloud/bitbucket/rest/
+	Client struct {
+		client *http.Client
+		apiURL *url.URL // base URL of the API
+		webURL *url.URL // base URL of the website, from which archives are downloaded
+		token  string
+	}
+
+	ClientOptions struct {
+		Hostname            string
+		SkipTLSVerification bool
+
+		// Token is either an access token, or an app password prefixed with
+		// the username and a colon, i.e. <username>:<app password>.
+		Token string
+	}
+
+	// page is a page of items returned by the bitbucket API.
+	page[T any] struct {
+		Values []T     `json:"values"`
+		Next   *string `json:"next"` // URL of next page; nil if last page
+	}
+
+	repository struct {
+		FullName   string `json:"full_name"`
+		Mainbranch struct {
+			Name string `json:"name"`
+		} `json:"mainbranch"`
+	}
+
+	commit struct {
+		Hash   string `json:"hash"`
+		Author struct {
+			User *user `json:"user"`
+		} `json:"author"`
+		Links struct {
+			HTML link `json:"html"`
+		} `json:"links"`
+	}
+
+	user struct {
+		Nickname string `json:"nickname"`
+		Links    struct {
+			HTML   link `json:"html"`
+			Avatar link `json:"avatar"`
+		} `json:"links"`
+	}
+
+	link struct {
+		Href string `json:"href"`
+	}
+
+	webhook struct {
+		UUID        string   `json:"uuid,omitempty"`
+		Description string   `json:"description"`
+		URL         string   `json:"url"`
+		Active      bool     `json:"active"`
+		Secret      string   `json:"secret,omitempty"`
+		Events      []string `json:"events"`
+	}
+
+	buildStatus struct {
+		Key         string `json:"key"`
+		Name        string `json:"name"`
+		State       string `json:"state"`
+		URL         string `json:"url"`
+		Description string `json:"description"`
+	}
+
+	diffstat struct {
+		Old *struct {
+			Path string `json:"path"`
+		} `json:"old"`
+		New *struct {
+			Path string `json:"path"`
+		} `json:"new"`
+	}
+
+	// apiError is an error returned by the bitbucket API.
+	apiError struct {
+		Error struct {
+			Message string `json:"message"`
+		} `json:"error"`
+	}
+)
+
+func NewClient(cfg ClientOptions) (*Client, error) {
+	if cfg.Hostname == "" {
+		cfg.Hostname = DefaultHostname
+	}
+	tripper := http.DefaultTransport
+	if cfg.SkipTLSVerification {
+		tripper = otfhttp.InsecureTransport
+	}
+	client := &Client{
+		// retry transient errors and honor rate limits, sharing the limit with
+		// other clients using the same credentials
+		client: &http.Client{Transport: vcs.NewTransport(tripper, cfg.Hostname, cfg.Token)},
+		webURL: &url.URL{Scheme: "https", Host: cfg.Hostname},
+		token:  cfg.Token,
+	}
+	// the API is served from a separate hostname for bitbucket.org; any
+	// other hostname, e.g. a test server, is assumed to serve both.
+	if cfg.Hostname == DefaultHostname {
+		client.apiURL = &url.URL{Scheme: "https", Host: defaultAPIHostname, Path: "/2.0/"}
+	} else {
+		client.apiURL = &url.URL{Scheme: "https", Host: cfg.Hostname, Path: "/2.0/"}
+	}
+	return client, nil
+}
+
+func NewTokenClient(opts vcs.NewTokenClientOptions) (vcs.Client, error) {
+	return NewClient(ClientOptions{
+		Hostname:            opts.Hostname,
+		Token:               opts.Token,
+		SkipTLSVerification: opts.SkipTLSVerification,
+	})
+}
+
+func (c *Client) GetRepository(ctx context.Context, identifier string) (vcs.Repository, error) {
+	p, err := repoPath(identifier)
+	if err != nil {
+		return vcs.Repository{}, err
+	}
+	var repo repository
+	if err := c.get(ctx, p, nil, &repo); err != nil {
+		return vcs.Repository{}, err
+	}
+	return vcs.Repository{
+		Path:          repo.FullName,
+		DefaultBranch: repo.Mainbranch.Name,
+	}, nil
+}
+
+// ListRepositories lists the first page of repositories that the user is a
+// member of, in order of those most recently updated.
+func (c *Client) ListRepositories(ctx context.Context, opts vcs.ListRepositoriesOptions) ([]string, error) {
+	q := url.Values{
+		"role": {"member"},
+		"sort": {"-updated_on"},
+	}
+	if opts.PageSize > 0 {
+		q.Set("pagelen", strconv.Itoa(min(opts.PageSize, maxPageSize)))
+	}
+	var result page[repository]
+	if err := c.get(ctx, "repositories", q, &result); err != nil {
+		return nil, err
+	}
+	repos := make([]string, len(result.Values))
+	for i, repo := range result.Values {
+		repos[i] = repo.FullName
+	}
+	return repos, nil
+}
+
+func (c *Client) ListTags(ctx context.Context, opts vcs.ListTagsOptions) ([]string, error) {
+	p, err := repoPath(opts.Repo)
+	if err != nil {
+		return nil, err
+	}
+	q := url.Values{"pagelen": {strconv.Itoa(maxPageSize)}}
+	if opts.Prefix != "" {
+		// the ~ operator matches tags *containing* the prefix, so results
+		// are further filtered below.
+		q.Set("q", fmt.Sprintf("name ~ %q", opts.Prefix))
+	}
+	refs, err := listAll[struct {
+		Name string `json:"name"`
+	}](ctx, c, path.Join(p, "refs/tags"), q)
+	if err != nil {
+		return nil, err
+	}
+	var tags []string
+	for _, ref := range refs {
+		if strings.HasPrefix(ref.Name, opts.Prefix) {
+			tags = append(tags, fmt.Sprintf("tags/%s", ref.Name))
+		}
+	}
+	return tags, nil
+}
+
+func (c *Client) GetRepoTarball(ctx context.Context, opts vcs.GetRepoTarballOptions) ([]byte, string, error) {
+	owner, name, found := strings.Cut(opts.Repo, "/")
+	if !found {
+		return nil, "", fmt.Errorf("malformed identifier: %s", opts.Repo)
+	}
+	var ref string
+	if opts.Ref != nil {
+		ref = *opts.Ref
+	} else {
+		repo, err := c.GetRepository(ctx, opts.Repo)
+		if err != nil {
+			return nil, "", err
+		}
+		ref = repo.DefaultBranch
+	}
+	// resolve ref to a commit SHA, ensuring the tarball and the SHA
+	// correspond to one another
+	commit, err := c.GetCommit(ctx, opts.Repo, ref)
+	if err != nil {
+		return nil, "", err
+	}
+
+	u := c.webURL.JoinPath(url.PathEscape(owner), url.PathEscape(name), "get", commit.SHA+".tar.gz")
+	req, err := c.newRequest(ctx, "GET", u.String(), nil)
+	if err != nil {
+		return nil, "", err
+	}
+	var buf bytes.Buffer
+	if err := c.do(req, &buf); err != nil {
+		return nil, "", err
+	}
+
+	// Bitbucket tarball contents are contained within a top-level directory
+	// formatted <owner>-<repo>-<short sha>. We want the tarball without this
+	// directory, so we re-tar the contents without the top-level directory.
+	untarpath, err := os.MkdirTemp("", fmt.Sprintf("bitbucket-%s-%s-*", owner, name))
+	if err != nil {
+		return nil, "", err
+	}
+	defer os.RemoveAll(untarpath)
+
+	if err := internal.Unpack(&buf, untarpath); err != nil {
+		return nil, "", err
+	}
+	contents, err := os.ReadDir(untarpath)
+	if err != nil {
+		return nil, "", err
+	}
+	if len(contents) != 1 {
+		return nil, "", fmt.Errorf("expected only one top-level directory; instead got %s", contents)
+	}
+	tarball, err := internal.Pack(path.Join(untarpath, contents[0].Name()))
+	if err != nil {
+		return nil, "", err
+	}
+	return tarball, commit.SHA, nil
+}
+
+func (c *Client) CreateWebhook(ctx context.Context, opts vcs.CreateWebhookOptions) (string, error) {
+	p, err := repoPath(opts.Repo)
+	if err != nil {
+		return "", err
+	}
+	var created webhook
+	if err := c.send(ctx, "POST", path.Join(p, "hooks"), newWebhook(opts), &created); err != nil {
+		return "", err
+	}
+	return created.UUID, nil
+}
+
+func (c *Client) UpdateWebhook(ctx context.Context, id string, opts vcs.UpdateWebhookOptions) error {
+	p, err := repoPath(opts.Repo)
+	if err != nil {
+		return err
+	}
+	return c.send(ctx, "PUT", path.Join(p, "hooks", url.PathEscape(id)), newWebhook(vcs.CreateWebhookOptions(opts)), nil)
+}
+
+func (c *Client) GetWebhook(ctx context.Context, opts vcs.GetWebhookOptions) (vcs.Webhook, error) {
+	p, err := repoPath(opts.Repo)
+	if err != nil {
+		return vcs.Webhook{}, err
+	}
+	var hook webhook
+	if err := c.get(ctx, path.Join(p, "hooks", url.PathEscape(opts.ID)), nil, &hook); err != nil {
+		return vcs.Webhook{}, err
+	}
+	var events []vcs.EventType
+	for _, event := range hook.Events {
+		switch event {
+		case "repo:push":
+			events = append(events, vcs.EventTypePush)
+		case "pullrequest:created", "pullrequest:updated":
+			if !slices.Contains(events, vcs.EventTypePull) {
+				events = append(events, vcs.EventTypePull)
+			}
+		}
+	}
+	return vcs.Webhook{
+		ID:       hook.UUID,
+		Repo:     opts.Repo,
+		Events:   events,
+		Endpoint: hook.URL,
+	}, nil
+}
+
+func (c *Client) DeleteWebhook(ctx context.Context, opts vcs.DeleteWebhookOptions) error {
+	p, err := repoPath(opts.Repo)
+	if err != nil {
+		return err
+	}
+	return c.send(ctx, "DELETE", path.Join(p, "hooks", url.PathEscape(opts.ID)), nil, nil)
+}
+
+func (c *Client) SetStatus(ctx context.Context, opts vcs.SetStatusOptions) error {
+	p, err := repoPath(opts.Repo)
+	if err != nil {
+		return err
+	}
+	var state string
+	switch opts.Status {
+	case vcs.PendingStatus, vcs.RunningStatus:
+		state = "INPROGRESS"
+	case vcs.SuccessStatus:
+		state = "SUCCESSFUL"
+	case vcs.ErrorStatus, vcs.FailureStatus:
+		state = "FAILED"
+	default:
+		return fmt.Errorf("invalid vcs status: %s", opts.Status)
+	}
+	name := fmt.Sprintf("otf/%s", opts.Workspace)
+	return c.send(ctx, "POST", path.Join(p, "commit", url.PathEscape(opts.Ref), "statuses/build"), &buildStatus{
+		// the key identifies the status, permitting it to be updated; it
+		// must be no longer than 40 characters.
+		Key:         name[:min(len(name), 40)],
+		Name:        name,
+		State:       state,
+		URL:         opts.TargetURL,
+		Description: opts.Description,
+	}, nil)
+}
+
+func (c *Client) ListPullRequestFiles(ctx context.Context, repo string, pull int) ([]string, error) {
+	p, err := repoPath(repo)
+	if err != nil {
+		return nil, err
+	}
+	diffs, err := listAll[diffstat](ctx, c, path.Join(p, "pullrequests", strconv.Itoa(pull), "diffstat"), nil)
+	if err != nil {
+		return nil, err
+	}
+	var changed []string
+	for _, diff := range diffs {
+		if diff.Old != nil {
+			changed = append(changed, diff.Old.Path)
+		}
+		if diff.New != nil {
+			changed = append(changed, diff.New.Path)
+		}
+	}
+	// remove duplicates
+	slices.Sort(changed)
+	return slices.Compact(changed), nil
+}
+
+func (c *Client) GetCommit(ctx context.Context, repo, ref string) (vcs.Commit, error) {
+	p, err := repoPath(repo)
+	if err != nil {
+		return vcs.Commit{}, err
+	}
+	var result commit
+	if err := c.get(ctx, path.Join(p, "commit", url.PathEscape(ref)), nil, &result); err != nil {
+		return vcs.Commit{}, err
+	}
+	to := vcs.Commit{
+		SHA: result.Hash,
+		URL: result.Links.HTML.Href,
+	}
+	// the author is only populated if the commit's author email is
+	// associated with a bitbucket user
+	if u := result.Author.User; u != nil {
+		to.Author = vcs.CommitAuthor{
+			Username:   u.Nickname,
+			ProfileURL: u.Links.HTML.Href,
+			AvatarURL:  u.Links.Avatar.Href,
+		}
+	}
+	return to, nil
+}
+
+// newWebhook constructs a bitbucket webhook from webhook options.
+func newWebhook(opts vcs.CreateWebhookOptions) *webhook {
+	hook := &webhook{
+		Description: "otf",
+		URL:         opts.Endpoint,
+		Active:      true,
+		Secret:      opts.Secret,
+	}
+	for _, event := range opts.Events {
+		switch event {
+		case vcs.EventTypePush:
+			hook.Events = append(hook.Events, "repo:push")
+		case vcs.EventTypePull:
+			hook.Events = append(hook.Events, "pullrequest:created", "pullrequest:updated")
+		}
+	}
+	return hook
+}
+
+// repoPath returns the API path for a repository identified by
+// <workspace>/<repo>.
+func repoPath(identifier string) (string, error) {
+	owner, name, found := strings.Cut(identifier, "/")
+	if !found || owner == "" || name == "" {
+		return "", fmt.Errorf("malformed identifier: %s", identifier)
+	}
+	return path.Join("repositories", url.PathEscape(owner), url.PathEscape(name)), nil
+}
+
+// listAll retrieves all items from a paginated API endpoint.
+func listAll[T any](ctx context.Context, c *Client, p string, q url.Values) ([]T, error) {
+	var (
+		items []T
+		u     = c.apiURL.JoinPath(p)
+	)
+	u.RawQuery = q.Encode()
+	next := u.String()
+	for next != "" {
+		req, err := c.newRequest(ctx, "GET", next, nil)
+		if err != nil {
+			return nil, err
+		}
+		var result page[T]
+		if err := c.do(req, &result); err != nil {
+			return nil, err
+		}
+		items = append(items, result.Values...)
+		next = ""
+		if result.Next != nil {
+			next = *result.Next
+		}
+	}
+	return items, nil
+}
+
+// get sends a GET request to an API path, decoding the JSON response into v.
+func (c *Client) get(ctx context.Context, p string, q url.Values, v any) error {
+	u := c.apiURL.JoinPath(p)
+	u.RawQuery = q.Encode()
+	req, err := c.newRequest(ctx, "GET", u.String(), nil)
+	if err != nil {
+		return err
+	}
+	return c.do(req, v)
+}
+
+// send sends a request with a JSON body to an API path, decoding the JSON
+// response, if any, into v.
+func (c *Client) send(ctx context.Context, method, p string, body, v any) error {
+	var r io.Reader
+	if body != nil {
+		encoded, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		r = bytes.NewReader(encoded)
+	}
+	req, err := c.newRequest(ctx, method, c.apiURL.JoinPath(p).String(), r)
+	if err != nil {
+		return err
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	return c.do(req, v)
+}
+
+func (c *Client) newRequest(ctx context.Context, method, u string, body io.Reader) (*http.Request, error) {
+	req, err := http.NewRequestWithContext(ctx, method, u, body)
+	if err != nil {
+		return nil, err
+	}
+	if username, password, found := strings.Cut(c.token, ":"); found {
+		req.SetBasicAuth(username, password)
+	} else if c.token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	}
+	req.Header.Set("Accept", "application/json")
+	return req, nil
+}
+
+// do sends the request, writing the response body to v if it is an
+// io.Writer, otherwise decoding the JSON response body into v. If v is nil
+// then the response body is discarded.
+func (c *Client) do(req *http.Request, v any) error {
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return internal.ErrResourceNotFound
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		var apiErr apiError
+		if err := json.NewDecoder(resp.Body).Decode(&apiErr); err == nil && apiErr.Error.Message != "" {
+			return fmt.Errorf("bitbucket: %s: %s", resp.Status, apiErr.Error.Message)
+		}
+		return fmt.Errorf("bitbucket: %s", resp.Status)
+	}
+	switch dst := v.(type) {
+	case nil:
+		return nil
+	case io.Writer:
+		_, err := io.Copy(dst, resp.Body)
+		return err
+	default:
+		return json.NewDecoder(resp.Body).Decode(v)
+	}
+}
@@ -0,0 +1,272 @@
+package bitbucket
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"path"
+	"testing"
+
+	"github.com/leg100/otf/internal"
+	"github.com/leg100/otf/internal/testutils"
+	"github.com/leg100/otf/internal/vcs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_GetRepository(t *testing.T) {
+	mux, client := setup(t, "my-token")
+
+	mux.HandleFunc("/2.0/repositories/acme/terraform", func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "GET", r.Method)
+		assert.Equal(t, "Bearer my-token", r.Header.Get("Authorization"))
+		fmt.Fprint(w, `{"full_name":"acme/terraform","mainbranch":{"name":"master"}}`)
+	})
+
+	got, err := client.GetRepository(context.Background(), "acme/terraform")
+	require.NoError(t, err)
+
+	assert.Equal(t, "acme/terraform", got.Path)
+	assert.Equal(t, "master", got.DefaultBranch)
+}
+
+func TestClient_GetRepository_NotFound(t *testing.T) {
+	_, client := setup(t, "my-token")
+
+	_, err := client.GetRepository(context.Background(), "acme/terraform")
+	assert.ErrorIs(t, err, internal.ErrResourceNotFound)
+}
+
+func TestClient_AppPassword(t *testing.T) {
+	mux, client := setup(t, "bobby:app-password")
+
+	mux.HandleFunc("/2.0/repositories/acme/terraform", func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "GET", r.Method)
+		username, password, ok := r.BasicAuth()
+		require.True(t, ok)
+		assert.Equal(t, "bobby", username)
+		assert.Equal(t, "app-password", password)
+		fmt.Fprint(w, `{"full_name":"acme/terraform"}`)
+	})
+
+	_, err := client.GetRepository(context.Background(), "acme/terraform")
+	require.NoError(t, err)
+}
+
+func TestClient_ListRepositories(t *testing.T) {
+	mux, client := setup(t, "my-token")
+
+	mux.HandleFunc("/2.0/repositories", func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "GET", r.Method)
+		assert.Equal(t, "member", r.URL.Query().Get("role"))
+		assert.Equal(t, "-updated_on", r.URL.Query().Get("sort"))
+		assert.Equal(t, "50", r.URL.Query().Get("pagelen"))
+		fmt.Fprint(w, `{"values":[{"full_name":"acme/terraform"},{"full_name":"acme/modules"}]}`)
+	})
+
+	got, err := client.ListRepositories(context.Background(), vcs.ListRepositoriesOptions{PageSize: 50})
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"acme/terraform", "acme/modules"}, got)
+}
+
+func TestClient_ListTags(t *testing.T) {
+	mux, client := setup(t, "my-token")
+
+	mux.HandleFunc("/2.0/repositories/acme/terraform/refs/tags", func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "GET", r.Method)
+		assert.Equal(t, `name ~ "v1"`, r.URL.Query().Get("q"))
+		if r.URL.Query().Get("page") == "2" {
+			fmt.Fprint(w, `{"values":[{"name":"v1.1.0"}]}`)
+			return
+		}
+		next := *r.URL
+		next.Scheme = "https"
+		next.Host = r.Host
+		q := next.Query()
+		q.Set("page", "2")
+		next.RawQuery = q.Encode()
+		fmt.Fprintf(w, `{"values":[{"name":"v1.0.0"},{"name":"prev1.0.0"}],"next":%q}`, next.String())
+	})
+
+	got, err := client.ListTags(context.Background(), vcs.ListTagsOptions{
+		Repo:   "acme/terraform",
+		Prefix: "v1",
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"tags/v1.0.0", "tags/v1.1.0"}, got)
+}
+
+func TestClient_GetRepoTarball(t *testing.T) {
+	mux, client := setup(t, "my-token")
+
+	mux.HandleFunc("/2.0/repositories/acme/terraform", func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "GET", r.Method)
+		fmt.Fprint(w, `{"full_name":"acme/terraform","mainbranch":{"name":"master"}}`)
+	})
+	mux.HandleFunc("/2.0/repositories/acme/terraform/commit/master", func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "GET", r.Method)
+		fmt.Fprint(w, `{"hash":"0335fb07bb0244b7a169ee89d15c7703e4aaf7de"}`)
+	})
+	mux.HandleFunc("/acme/terraform/get/0335fb07bb0244b7a169ee89d15c7703e4aaf7de.tar.gz", func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "GET", r.Method)
+		assert.Equal(t, "Bearer my-token", r.Header.Get("Authorization"))
+		w.Write(testutils.ReadFile(t, "../testdata/gitlab.tar.gz"))
+	})
+
+	got, ref, err := client.GetRepoTarball(context.Background(), vcs.GetRepoTarballOptions{
+		Repo: "acme/terraform",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "0335fb07bb0244b7a169ee89d15c7703e4aaf7de", ref)
+
+	dst := t.TempDir()
+	err = internal.Unpack(bytes.NewReader(got), dst)
+	require.NoError(t, err)
+	assert.FileExists(t, path.Join(dst, "afile"))
+	assert.FileExists(t, path.Join(dst, "bfile"))
+}
+
+func TestClient_Webhook(t *testing.T) {
+	mux, client := setup(t, "my-token")
+	ctx := context.Background()
+
+	var hook webhook
+	mux.HandleFunc("/2.0/repositories/acme/terraform/hooks", func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "POST", r.Method)
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&hook))
+		hook.UUID = "{hook-123}"
+		hook.Secret = ""
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(hook)
+	})
+	mux.HandleFunc("/2.0/repositories/acme/terraform/hooks/{hook-123}", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case "GET":
+			json.NewEncoder(w).Encode(hook)
+		case "DELETE":
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			t.Fatalf("unexpected method: %s", r.Method)
+		}
+	})
+
+	id, err := client.CreateWebhook(ctx, vcs.CreateWebhookOptions{
+		Repo:     "acme/terraform",
+		Secret:   "me-secret",
+		Endpoint: "https://otf.dev/webhooks/vcs/123",
+		Events:   []vcs.EventType{vcs.EventTypePush, vcs.EventTypePull},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "{hook-123}", id)
+	assert.True(t, hook.Active)
+	assert.Equal(t, []string{"repo:push", "pullrequest:created", "pullrequest:updated"}, hook.Events)
+
+	got, err := client.GetWebhook(ctx, vcs.GetWebhookOptions{Repo: "acme/terraform", ID: id})
+	require.NoError(t, err)
+	assert.Equal(t, vcs.Webhook{
+		ID:       "{hook-123}",
+		Repo:     "acme/terraform",
+		Events:   []vcs.EventType{vcs.EventTypePush, vcs.EventTypePull},
+		Endpoint: "https://otf.dev/webhooks/vcs/123",
+	}, got)
+
+	err = client.DeleteWebhook(ctx, vcs.DeleteWebhookOptions{Repo: "acme/terraform", ID: id})
+	require.NoError(t, err)
+}
+
+func TestClient_SetStatus(t *testing.T) {
+	mux, client := setup(t, "my-token")
+
+	var got buildStatus
+	mux.HandleFunc("/2.0/repositories/acme/terraform/commit/abc123/statuses/build", func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "POST", r.Method)
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&got))
+		w.WriteHeader(http.StatusCreated)
+	})
+
+	err := client.SetStatus(context.Background(), vcs.SetStatusOptions{
+		Workspace:   "dev",
+		Repo:        "acme/terraform",
+		Ref:         "abc123",
+		Status:      vcs.SuccessStatus,
+		TargetURL:   "https://otf.dev/runs/run-123",
+		Description: "planned: +1/~0/-0",
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, buildStatus{
+		Key:         "otf/dev",
+		Name:        "otf/dev",
+		State:       "SUCCESSFUL",
+		URL:         "https://otf.dev/runs/run-123",
+		Description: "planned: +1/~0/-0",
+	}, got)
+}
+
+func TestClient_ListPullRequestFiles(t *testing.T) {
+	mux, client := setup(t, "my-token")
+
+	mux.HandleFunc("/2.0/repositories/acme/terraform/pullrequests/1/diffstat", func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "GET", r.Method)
+		fmt.Fprint(w, `{"values":[
+			{"old":null,"new":{"path":"added.tf"}},
+			{"old":{"path":"main.tf"},"new":{"path":"main.tf"}},
+			{"old":{"path":"removed.tf"},"new":null}
+		]}`)
+	})
+
+	got, err := client.ListPullRequestFiles(context.Background(), "acme/terraform", 1)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"added.tf", "main.tf", "removed.tf"}, got)
+}
+
+func TestClient_GetCommit(t *testing.T) {
+	mux, client := setup(t, "my-token")
+
+	mux.HandleFunc("/2.0/repositories/acme/terraform/commit/master", func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "GET", r.Method)
+		fmt.Fprint(w, `{
+			"hash":"abc123",
+			"links":{"html":{"href":"https://bitbucket.org/acme/terraform/commits/abc123"}},
+			"author":{"user":{"nickname":"bobby","links":{"html":{"href":"https://bitbucket.org/bobby/"},"avatar":{"href":"https://avatars/bobby.png"}}}}
+		}`)
+	})
+
+	got, err := client.GetCommit(context.Background(), "acme/terraform", "master")
+	require.NoError(t, err)
+
+	assert.Equal(t, vcs.Commit{
+		SHA: "abc123",
+		URL: "https://bitbucket.org/acme/terraform/commits/abc123",
+		Author: vcs.CommitAuthor{
+			Username:   "bobby",
+			ProfileURL: "https://bitbucket.org/bobby/",
+			AvatarURL:  "https://avatars/bobby.png",
+		},
+	}, got)
+}
+
+func setup(t *testing.T, token string) (*http.ServeMux, *Client) {
+	mux := http.NewServeMux()
+	srv := httptest.NewTLSServer(mux)
+	t.Cleanup(srv.Close)
+
+	u, err := url.Parse(srv.URL)
+	require.NoError(t, err)
+
+	client, err := NewClient(ClientOptions{
+		Hostname:            u.Host,
+		SkipTLSVerification: true,
+		Token:               token,
+	})
+	require.NoError(t, err)
+
+	return mux, client
+}
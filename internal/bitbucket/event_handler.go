@@ -0,0 +1,188 @@
+package bitbucket
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/leg100/otf/internal/vcs"
+)
+
+type (
+	// pushEvent is the payload of a repo:push event:
+	//
+	// https://support.atlassian.com/bitbucket-cloud/docs/event-payloads/#Push
+	pushEvent struct {
+		Actor      user      `json:"actor"`
+		Repository eventRepo `json:"repository"`
+		Push       struct {
+			Changes []struct {
+				New    *pushRef `json:"new"`
+				Old    *pushRef `json:"old"`
+				Closed bool     `json:"closed"`
+			} `json:"changes"`
+		} `json:"push"`
+	}
+
+	pushRef struct {
+		Type   string `json:"type"` // branch or tag
+		Name   string `json:"name"`
+		Target struct {
+			Hash  string `json:"hash"`
+			Links struct {
+				HTML link `json:"html"`
+			} `json:"links"`
+		} `json:"target"`
+	}
+
+	// pullRequestEvent is the payload of pullrequest:* events:
+	//
+	// https://support.atlassian.com/bitbucket-cloud/docs/event-payloads/#Pull-request-events
+	pullRequestEvent struct {
+		Actor       user      `json:"actor"`
+		Repository  eventRepo `json:"repository"`
+		PullRequest struct {
+			ID     int    `json:"id"`
+			Title  string `json:"title"`
+			Source struct {
+				Branch struct {
+					Name string `json:"name"`
+				} `json:"branch"`
+				Commit struct {
+					Hash string `json:"hash"`
+				} `json:"commit"`
+			} `json:"source"`
+			Links struct {
+				HTML link `json:"html"`
+			} `json:"links"`
+		} `json:"pullrequest"`
+	}
+
+	eventRepo struct {
+		FullName string `json:"full_name"`
+		Links    struct {
+			HTML link `json:"html"`
+		} `json:"links"`
+	}
+)
+
+// HandleEvent converts a bitbucket cloud webhook event into an OTF event.
+// Push events do not include the repository's default branch nor the list of
+// changed files.
+func HandleEvent(r *http.Request, secret string) (*vcs.EventPayload, error) {
+	payload, err := io.ReadAll(r.Body)
+	if err != nil || len(payload) == 0 {
+		return nil, errors.New("error reading request body")
+	}
+	if err := validateSignature(r.Header.Get("X-Hub-Signature"), payload, secret); err != nil {
+		return nil, err
+	}
+
+	to := vcs.EventPayload{VCSKind: vcs.BitbucketKind, DeliveryID: r.Header.Get("X-Request-UUID")}
+	switch key := r.Header.Get("X-Event-Key"); key {
+	case "repo:push":
+		var event pushEvent
+		if err := json.Unmarshal(payload, &event); err != nil {
+			return nil, fmt.Errorf("parsing webhook: %w", err)
+		}
+		if len(event.Push.Changes) == 0 {
+			return nil, vcs.NewErrIgnoreEvent("push event contains no changes")
+		}
+		// a push of several refs results in several changes; only the first
+		// is handled.
+		change := event.Push.Changes[0]
+		ref := change.New
+		if change.Closed {
+			ref = change.Old
+		}
+		if ref == nil {
+			return nil, errors.New("push event missing ref")
+		}
+		switch ref.Type {
+		case "branch":
+			if change.Closed {
+				return nil, vcs.NewErrIgnoreEvent("branch deleted: %s", ref.Name)
+			}
+			to.Type = vcs.EventTypePush
+			to.Action = vcs.ActionCreated
+			to.Branch = ref.Name
+		case "tag":
+			to.Type = vcs.EventTypeTag
+			to.Tag = ref.Name
+			if change.Closed {
+				to.Action = vcs.ActionDeleted
+			} else {
+				to.Action = vcs.ActionCreated
+			}
+		default:
+			return nil, vcs.NewErrIgnoreEvent("unsupported ref type: %s", ref.Type)
+		}
+		if !change.Closed {
+			to.CommitSHA = ref.Target.Hash
+			to.CommitURL = ref.Target.Links.HTML.Href
+		}
+		to.RepoPath = event.Repository.FullName
+		setSender(&to, event.Actor)
+	case "pullrequest:created", "pullrequest:updated":
+		var event pullRequestEvent
+		if err := json.Unmarshal(payload, &event); err != nil {
+			return nil, fmt.Errorf("parsing webhook: %w", err)
+		}
+		to.Type = vcs.EventTypePull
+		if key == "pullrequest:created" {
+			to.Action = vcs.ActionCreated
+		} else {
+			to.Action = vcs.ActionUpdated
+		}
+		pr := event.PullRequest
+		to.Branch = pr.Source.Branch.Name
+		to.CommitSHA = pr.Source.Commit.Hash
+		// the commit link in the payload is an API link; construct a link to
+		// the commit on the website instead.
+		to.CommitURL = strings.TrimSuffix(event.Repository.Links.HTML.Href, "/") + "/commits/" + to.CommitSHA
+		to.PullRequestNumber = pr.ID
+		to.PullRequestURL = pr.Links.HTML.Href
+		to.PullRequestTitle = pr.Title
+		to.RepoPath = event.Repository.FullName
+		setSender(&to, event.Actor)
+	default:
+		return nil, vcs.NewErrIgnoreEvent("unsupported event type: %s", key)
+	}
+	if err := to.Validate(); err != nil {
+		return nil, fmt.Errorf("failed building OTF event: %w", err)
+	}
+	return &to, nil
+}
+
+// validateSignature validates the HMAC-SHA256 signature bitbucket sends for
+// webhooks configured with a secret.
+func validateSignature(header string, payload []byte, secret string) error {
+	sig, found := strings.CutPrefix(header, "sha256=")
+	if !found {
+		return errors.New("missing or malformed X-Hub-Signature header")
+	}
+	got, err := hex.DecodeString(sig)
+	if err != nil {
+		return fmt.Errorf("decoding signature: %w", err)
+	}
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(payload)
+	// constant-time comparison prevents the signature being guessed by
+	// timing responses.
+	if !hmac.Equal(got, mac.Sum(nil)) {
+		return errors.New("signature validation failed")
+	}
+	return nil
+}
+
+func setSender(to *vcs.EventPayload, actor user) {
+	to.SenderUsername = actor.Nickname
+	to.SenderAvatarURL = actor.Links.Avatar.Href
+	to.SenderHTMLURL = actor.Links.HTML.Href
+}
@@ -0,0 +1,151 @@
+package bitbucket
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/leg100/otf/internal/testutils"
+	"github.com/leg100/otf/internal/vcs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEventHandler(t *testing.T) {
+	sender := func(to vcs.EventPayload) *vcs.EventPayload {
+		to.VCSKind = vcs.BitbucketKind
+		to.DeliveryID = "delivery-123"
+		to.RepoPath = "leg100/otf-workspaces"
+		to.SenderUsername = "leg100"
+		to.SenderAvatarURL = "https://avatar-management.services.atlassian.com/leg100.png"
+		to.SenderHTMLURL = "https://bitbucket.org/%7B4c7e8f6b-0d7a-4c42-9d7a-1e0b3f3c2a10%7D/"
+		return &to
+	}
+
+	tests := []struct {
+		name     string
+		eventKey string
+		body     string
+		want     *vcs.EventPayload
+	}{
+		{
+			"push",
+			"repo:push",
+			"./testdata/push.json",
+			sender(vcs.EventPayload{
+				Type:      vcs.EventTypePush,
+				Action:    vcs.ActionCreated,
+				Branch:    "master",
+				CommitSHA: "42d7ac3b79a47c0e0e1c5fb4ebe3b9c2d8e04b8d",
+				CommitURL: "https://bitbucket.org/leg100/otf-workspaces/commits/42d7ac3b79a47c0e0e1c5fb4ebe3b9c2d8e04b8d",
+			}),
+		},
+		{
+			"tag created",
+			"repo:push",
+			"./testdata/tag_created.json",
+			sender(vcs.EventPayload{
+				Type:      vcs.EventTypeTag,
+				Action:    vcs.ActionCreated,
+				Tag:       "v1.0.0",
+				CommitSHA: "42d7ac3b79a47c0e0e1c5fb4ebe3b9c2d8e04b8d",
+				CommitURL: "https://bitbucket.org/leg100/otf-workspaces/commits/42d7ac3b79a47c0e0e1c5fb4ebe3b9c2d8e04b8d",
+			}),
+		},
+		{
+			"tag deleted",
+			"repo:push",
+			"./testdata/tag_deleted.json",
+			sender(vcs.EventPayload{
+				Type:   vcs.EventTypeTag,
+				Action: vcs.ActionDeleted,
+				Tag:    "v1.0.0",
+			}),
+		},
+		{
+			"pull request created",
+			"pullrequest:created",
+			"./testdata/pullrequest_created.json",
+			sender(vcs.EventPayload{
+				Type:              vcs.EventTypePull,
+				Action:            vcs.ActionCreated,
+				Branch:            "pr-1",
+				CommitSHA:         "eea3783a079c",
+				CommitURL:         "https://bitbucket.org/leg100/otf-workspaces/commits/eea3783a079c",
+				PullRequestNumber: 1,
+				PullRequestURL:    "https://bitbucket.org/leg100/otf-workspaces/pull-requests/1",
+				PullRequestTitle:  "pr-1",
+			}),
+		},
+		{
+			"pull request updated",
+			"pullrequest:updated",
+			"./testdata/pullrequest_created.json",
+			sender(vcs.EventPayload{
+				Type:              vcs.EventTypePull,
+				Action:            vcs.ActionUpdated,
+				Branch:            "pr-1",
+				CommitSHA:         "eea3783a079c",
+				CommitURL:         "https://bitbucket.org/leg100/otf-workspaces/commits/eea3783a079c",
+				PullRequestNumber: 1,
+				PullRequestURL:    "https://bitbucket.org/leg100/otf-workspaces/pull-requests/1",
+				PullRequestTitle:  "pr-1",
+			}),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			body := testutils.ReadFile(t, tt.body)
+			r := newTestEventRequest(body, tt.eventKey, sign(body, "secret"))
+
+			got, err := HandleEvent(r, "secret")
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.want, got)
+		})
+	}
+
+	t.Run("ignore unsupported event", func(t *testing.T) {
+		body := testutils.ReadFile(t, "./testdata/pullrequest_created.json")
+		r := newTestEventRequest(body, "pullrequest:fulfilled", sign(body, "secret"))
+
+		_, err := HandleEvent(r, "secret")
+		assert.ErrorAs(t, err, &vcs.ErrIgnoreEvent{})
+	})
+
+	t.Run("invalid signature", func(t *testing.T) {
+		body := testutils.ReadFile(t, "./testdata/push.json")
+		r := newTestEventRequest(body, "repo:push", sign(body, "wrong-secret"))
+
+		_, err := HandleEvent(r, "secret")
+		assert.Error(t, err)
+	})
+
+	t.Run("missing signature", func(t *testing.T) {
+		body := testutils.ReadFile(t, "./testdata/push.json")
+		r := newTestEventRequest(body, "repo:push", "")
+
+		_, err := HandleEvent(r, "secret")
+		assert.Error(t, err)
+	})
+}
+
+func newTestEventRequest(body []byte, eventKey, signature string) *http.Request {
+	r := httptest.NewRequest("POST", "/", bytes.NewReader(body))
+	r.Header.Set("X-Event-Key", eventKey)
+	r.Header.Set("X-Request-UUID", "delivery-123")
+	if signature != "" {
+		r.Header.Set("X-Hub-Signature", signature)
+	}
+	return r
+}
+
+func sign(body []byte, secret string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
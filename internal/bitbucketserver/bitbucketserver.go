@@ -0,0 +1,9 @@
+// Package bitbucketserver provides bitbucket server and bitbucket data center
+// related code
+package bitbucketserver
+
+import "errors"
+
+// ErrHostnameRequired is returned when no hostname is configured: unlike
+// other providers, bitbucket server is only ever self-hosted.
+var ErrHostnameRequired = errors.New("bitbucket server hostname not configured")
@@ -0,0 +1,505 @@
+package bitbucketserver
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"path"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/leg100/otf/internal"
+	otfhttp "github.com/leg100/otf/internal/http"
+	"github.com/leg100/otf/internal/vcs"
+)
+
+const (
+	// apiPath is the path of the core REST API.
+	apiPath = "rest/api/1.0"
+	// buildStatusPath is the path of the build status REST API, which is
+	// separate from the core API.
+	buildStatusPath = "rest/build-status/1.0"
+	// maxPageSize is the maximum number of items requested in a page.
+	maxPageSize = 100
+	// avatarSize is the size in pixels of avatars requested from the API.
+	avatarSize = 64
+)
+
+type (
+	// Client is a client for the bitbucket server and bitbucket data center
+	// REST API:
+	//
+	// https://developer.atlassian.com/server/bitbucket/rest/
+	Client struct {
+		client  *http.Client
+		baseURL *url.URL
+		token   string
+	}
+
+	ClientOptions struct {
+		Hostname            string
+		SkipTLSVerification bool
+
+		// Token is a personal, project, or repository HTTP access token.
+		Token string
+	}
+
+	// page is a page of items returned by the bitbucket server API.
+	page[T any] struct {
+		Values        []T  `json:"values"`
+		IsLastPage    bool `json:"isLastPage"`
+		NextPageStart int  `json:"nextPageStart"`
+	}
+
+	repository struct {
+		Slug    string `json:"slug"`
+		Project struct {
+			Key string `json:"key"`
+		} `json:"project"`
+	}
+
+	ref struct {
+		ID           string `json:"id"`
+		DisplayID    string `json:"displayId"`
+		LatestCommit string `json:"latestCommit"`
+	}
+
+	commit struct {
+		ID     string `json:"id"`
+		Author user   `json:"author"`
+	}
+
+	user struct {
+		Name      string `json:"name"`
+		Slug      string `json:"slug"`
+		AvatarURL string `json:"avatarUrl"`
+	}
+
+	webhook struct {
+		ID            int      `json:"id,omitempty"`
+		Name          string   `json:"name"`
+		URL           string   `json:"url"`
+		Active        bool     `json:"active"`
+		Events        []string `json:"events"`
+		Configuration struct {
+			Secret string `json:"secret,omitempty"`
+		} `json:"configuration"`
+	}
+
+	buildStatus struct {
+		Key         string `json:"key"`
+		Name        string `json:"name"`
+		State       string `json:"state"`
+		URL         string `json:"url"`
+		Description string `json:"description"`
+	}
+
+	change struct {
+		Path    *changePath `json:"path"`
+		SrcPath *changePath `json:"srcPath"` // only set if the file was moved
+	}
+
+	changePath struct {
+		ToString string `json:"toString"`
+	}
+
+	// apiError is an error returned by the bitbucket server API.
+	apiError struct {
+		Errors []struct {
+			Message string `json:"message"`
+		} `json:"errors"`
+	}
+)
+
+func NewClient(cfg ClientOptions) (*Client, error) {
+	if cfg.Hostname == "" {
+		return nil, ErrHostnameRequired
+	}
+	tripper := http.DefaultTransport
+	if cfg.SkipTLSVerification {
+		tripper = otfhttp.InsecureTransport
+	}
+	return &Client{
+		// retry transient errors and honor rate limits, sharing the limit with
+		// other clients using the same credentials
+		client:  &http.Client{Transport: vcs.NewTransport(tripper, cfg.Hostname, cfg.Token)},
+		baseURL: &url.URL{Scheme: "https", Host: cfg.Hostname, Path: "/"},
+		token:   cfg.Token,
+	}, nil
+}
+
+func NewTokenClient(opts vcs.NewTokenClientOptions) (vcs.Client, error) {
+	return NewClient(ClientOptions{
+		Hostname:            opts.Hostname,
+		Token:               opts.Token,
+		SkipTLSVerification: opts.SkipTLSVerification,
+	})
+}
+
+func (c *Client) GetRepository(ctx context.Context, identifier string) (vcs.Repository, error) {
+	p, err := repoPath(identifier)
+	if err != nil {
+		return vcs.Repository{}, err
+	}
+	var repo repository
+	if err := c.get(ctx, p, nil, &repo); err != nil {
+		return vcs.Repository{}, err
+	}
+	var defaultBranch ref
+	if err := c.get(ctx, path.Join(p, "branches/default"), nil, &defaultBranch); err != nil {
+		return vcs.Repository{}, err
+	}
+	return vcs.Repository{
+		Path:          repo.Project.Key + "/" + repo.Slug,
+		DefaultBranch: defaultBranch.DisplayID,
+	}, nil
+}
+
+// ListRepositories lists the first page of repositories that the user
+// administers. Administering a repository is necessary to create the webhooks
+// with which OTF receives events.
+func (c *Client) ListRepositories(ctx context.Context, opts vcs.ListRepositoriesOptions) ([]string, error) {
+	q := url.Values{"permission": {"REPO_ADMIN"}}
+	if opts.PageSize > 0 {
+		q.Set("limit", strconv.Itoa(min(opts.PageSize, maxPageSize)))
+	}
+	var result page[repository]
+	if err := c.get(ctx, path.Join(apiPath, "repos"), q, &result); err != nil {
+		return nil, err
+	}
+	repos := make([]string, len(result.Values))
+	for i, repo := range result.Values {
+		repos[i] = repo.Project.Key + "/" + repo.Slug
+	}
+	return repos, nil
+}
+
+func (c *Client) ListTags(ctx context.Context, opts vcs.ListTagsOptions) ([]string, error) {
+	p, err := repoPath(opts.Repo)
+	if err != nil {
+		return nil, err
+	}
+	q := url.Values{}
+	if opts.Prefix != "" {
+		// filterText matches tags *containing* the prefix, so results are
+		// further filtered below.
+		q.Set("filterText", opts.Prefix)
+	}
+	refs, err := listAll[ref](ctx, c, path.Join(p, "tags"), q)
+	if err != nil {
+		return nil, err
+	}
+	var tags []string
+	for _, ref := range refs {
+		if strings.HasPrefix(ref.DisplayID, opts.Prefix) {
+			tags = append(tags, fmt.Sprintf("tags/%s", ref.DisplayID))
+		}
+	}
+	return tags, nil
+}
+
+func (c *Client) GetRepoTarball(ctx context.Context, opts vcs.GetRepoTarballOptions) ([]byte, string, error) {
+	p, err := repoPath(opts.Repo)
+	if err != nil {
+		return nil, "", err
+	}
+	var ref string
+	if opts.Ref != nil {
+		ref = *opts.Ref
+	} else {
+		repo, err := c.GetRepository(ctx, opts.Repo)
+		if err != nil {
+			return nil, "", err
+		}
+		ref = repo.DefaultBranch
+	}
+	// resolve ref to a commit SHA, ensuring the tarball and the SHA
+	// correspond to one another
+	commit, err := c.GetCommit(ctx, opts.Repo, ref)
+	if err != nil {
+		return nil, "", err
+	}
+	// unlike other providers, the archive's contents are not contained
+	// within a top-level directory, so the tarball can be returned as-is.
+	q := url.Values{"at": {commit.SHA}, "format": {"tar.gz"}}
+	var buf bytes.Buffer
+	if err := c.get(ctx, path.Join(p, "archive"), q, &buf); err != nil {
+		return nil, "", err
+	}
+	return buf.Bytes(), commit.SHA, nil
+}
+
+func (c *Client) CreateWebhook(ctx context.Context, opts vcs.CreateWebhookOptions) (string, error) {
+	p, err := repoPath(opts.Repo)
+	if err != nil {
+		return "", err
+	}
+	var created webhook
+	if err := c.send(ctx, "POST", path.Join(p, "webhooks"), newWebhook(opts), &created); err != nil {
+		return "", err
+	}
+	return strconv.Itoa(created.ID), nil
+}
+
+func (c *Client) UpdateWebhook(ctx context.Context, id string, opts vcs.UpdateWebhookOptions) error {
+	p, err := repoPath(opts.Repo)
+	if err != nil {
+		return err
+	}
+	return c.send(ctx, "PUT", path.Join(p, "webhooks", url.PathEscape(id)), newWebhook(vcs.CreateWebhookOptions(opts)), nil)
+}
+
+func (c *Client) GetWebhook(ctx context.Context, opts vcs.GetWebhookOptions) (vcs.Webhook, error) {
+	p, err := repoPath(opts.Repo)
+	if err != nil {
+		return vcs.Webhook{}, err
+	}
+	var hook webhook
+	if err := c.get(ctx, path.Join(p, "webhooks", url.PathEscape(opts.ID)), nil, &hook); err != nil {
+		return vcs.Webhook{}, err
+	}
+	var events []vcs.EventType
+	for _, event := range hook.Events {
+		switch event {
+		case "repo:refs_changed":
+			events = append(events, vcs.EventTypePush)
+		case "pr:opened", "pr:from_ref_updated":
+			if !slices.Contains(events, vcs.EventTypePull) {
+				events = append(events, vcs.EventTypePull)
+			}
+		}
+	}
+	return vcs.Webhook{
+		ID:       strconv.Itoa(hook.ID),
+		Repo:     opts.Repo,
+		Events:   events,
+		Endpoint: hook.URL,
+	}, nil
+}
+
+func (c *Client) DeleteWebhook(ctx context.Context, opts vcs.DeleteWebhookOptions) error {
+	p, err := repoPath(opts.Repo)
+	if err != nil {
+		return err
+	}
+	return c.send(ctx, "DELETE", path.Join(p, "webhooks", url.PathEscape(opts.ID)), nil, nil)
+}
+
+// SetStatus sets a build status on a commit. Build statuses are associated
+// with a commit rather than a repository, and so are shown on any repository
+// containing the commit.
+func (c *Client) SetStatus(ctx context.Context, opts vcs.SetStatusOptions) error {
+	var state string
+	switch opts.Status {
+	case vcs.PendingStatus, vcs.RunningStatus:
+		state = "INPROGRESS"
+	case vcs.SuccessStatus:
+		state = "SUCCESSFUL"
+	case vcs.ErrorStatus, vcs.FailureStatus:
+		state = "FAILED"
+	default:
+		return fmt.Errorf("invalid vcs status: %s", opts.Status)
+	}
+	name := fmt.Sprintf("otf/%s", opts.Workspace)
+	return c.send(ctx, "POST", path.Join(buildStatusPath, "commits", url.PathEscape(opts.Ref)), &buildStatus{
+		// the key identifies the status, permitting it to be updated
+		Key:         name,
+		Name:        name,
+		State:       state,
+		URL:         opts.TargetURL,
+		Description: opts.Description,
+	}, nil)
+}
+
+func (c *Client) ListPullRequestFiles(ctx context.Context, repo string, pull int) ([]string, error) {
+	p, err := repoPath(repo)
+	if err != nil {
+		return nil, err
+	}
+	changes, err := listAll[change](ctx, c, path.Join(p, "pull-requests", strconv.Itoa(pull), "changes"), nil)
+	if err != nil {
+		return nil, err
+	}
+	var changed []string
+	for _, change := range changes {
+		if change.Path != nil {
+			changed = append(changed, change.Path.ToString)
+		}
+		if change.SrcPath != nil {
+			changed = append(changed, change.SrcPath.ToString)
+		}
+	}
+	// remove duplicates
+	slices.Sort(changed)
+	return slices.Compact(changed), nil
+}
+
+func (c *Client) GetCommit(ctx context.Context, repo, ref string) (vcs.Commit, error) {
+	p, err := repoPath(repo)
+	if err != nil {
+		return vcs.Commit{}, err
+	}
+	q := url.Values{"avatarSize": {strconv.Itoa(avatarSize)}}
+	var result commit
+	if err := c.get(ctx, path.Join(p, "commits", url.PathEscape(ref)), q, &result); err != nil {
+		return vcs.Commit{}, err
+	}
+	owner, name, _ := strings.Cut(repo, "/")
+	to := vcs.Commit{
+		SHA: result.ID,
+		URL: c.baseURL.JoinPath("projects", owner, "repos", name, "commits", result.ID).String(),
+	}
+	// the author slug is only populated if the commit's author email is
+	// associated with a bitbucket user
+	if u := result.Author; u.Slug != "" {
+		to.Author = vcs.CommitAuthor{
+			Username:   u.Slug,
+			ProfileURL: c.baseURL.JoinPath("users", u.Slug).String(),
+			AvatarURL:  c.absoluteURL(u.AvatarURL),
+		}
+	}
+	return to, nil
+}
+
+// absoluteURL resolves a URL returned by the API, which may be relative to the
+// base URL.
+func (c *Client) absoluteURL(s string) string {
+	if s == "" {
+		return ""
+	}
+	u, err := c.baseURL.Parse(s)
+	if err != nil {
+		return s
+	}
+	return u.String()
+}
+
+// newWebhook constructs a bitbucket server webhook from webhook options.
+func newWebhook(opts vcs.CreateWebhookOptions) *webhook {
+	hook := &webhook{
+		Name:   "otf",
+		URL:    opts.Endpoint,
+		Active: true,
+	}
+	hook.Configuration.Secret = opts.Secret
+	for _, event := range opts.Events {
+		switch event {
+		case vcs.EventTypePush:
+			// refs_changed is sent for both branches and tags
+			hook.Events = append(hook.Events, "repo:refs_changed")
+		case vcs.EventTypePull:
+			hook.Events = append(hook.Events, "pr:opened", "pr:from_ref_updated")
+		}
+	}
+	return hook
+}
+
+// repoPath returns the API path for a repository identified by
+// <project key>/<repo slug>.
+func repoPath(identifier string) (string, error) {
+	project, slug, found := strings.Cut(identifier, "/")
+	if !found || project == "" || slug == "" {
+		return "", fmt.Errorf("malformed identifier: %s", identifier)
+	}
+	return path.Join(apiPath, "projects", url.PathEscape(project), "repos", url.PathEscape(slug)), nil
+}
+
+// listAll retrieves all items from a paginated API endpoint.
+func listAll[T any](ctx context.Context, c *Client, p string, q url.Values) ([]T, error) {
+	if q == nil {
+		q = url.Values{}
+	}
+	q.Set("limit", strconv.Itoa(maxPageSize))
+	var items []T
+	for {
+		var result page[T]
+		if err := c.get(ctx, p, q, &result); err != nil {
+			return nil, err
+		}
+		items = append(items, result.Values...)
+		if result.IsLastPage || len(result.Values) == 0 {
+			return items, nil
+		}
+		q.Set("start", strconv.Itoa(result.NextPageStart))
+	}
+}
+
+// get sends a GET request to an API path, decoding the JSON response into v.
+func (c *Client) get(ctx context.Context, p string, q url.Values, v any) error {
+	u := c.baseURL.JoinPath(p)
+	u.RawQuery = q.Encode()
+	req, err := c.newRequest(ctx, "GET", u.String(), nil)
+	if err != nil {
+		return err
+	}
+	return c.do(req, v)
+}
+
+// send sends a request with a JSON body to an API path, decoding the JSON
+// response, if any, into v.
+func (c *Client) send(ctx context.Context, method, p string, body, v any) error {
+	var r io.Reader
+	if body != nil {
+		encoded, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		r = bytes.NewReader(encoded)
+	}
+	req, err := c.newRequest(ctx, method, c.baseURL.JoinPath(p).String(), r)
+	if err != nil {
+		return err
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	return c.do(req, v)
+}
+
+func (c *Client) newRequest(ctx context.Context, method, u string, body io.Reader) (*http.Request, error) {
+	req, err := http.NewRequestWithContext(ctx, method, u, body)
+	if err != nil {
+		return nil, err
+	}
+	if c.token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	}
+	req.Header.Set("Accept", "application/json")
+	return req, nil
+}
+
+// do sends the request, writing the response body to v if it is an
+// io.Writer, otherwise decoding the JSON response body into v. If v is nil
+// then the response body is discarded.
+func (c *Client) do(req *http.Request, v any) error {
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return internal.ErrResourceNotFound
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		var apiErr apiError
+		if err := json.NewDecoder(resp.Body).Decode(&apiErr); err == nil && len(apiErr.Errors) > 0 {
+			return fmt.Errorf("bitbucket server: %s: %s", resp.Status, apiErr.Errors[0].Message)
+		}
+		return fmt.Errorf("bitbucket server: %s", resp.Status)
+	}
+	switch dst := v.(type) {
+	case nil:
+		return nil
+	case io.Writer:
+		_, err := io.Copy(dst, resp.Body)
+		return err
+	default:
+		return json.NewDecoder(resp.Body).Decode(v)
+	}
+}
@@ -0,0 +1,256 @@
+package bitbucketserver
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"path"
+	"testing"
+
+	"github.com/leg100/otf/internal"
+	"github.com/leg100/otf/internal/testutils"
+	"github.com/leg100/otf/internal/vcs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_NoHostname(t *testing.T) {
+	_, err := NewClient(ClientOptions{Token: "my-token"})
+	assert.ErrorIs(t, err, ErrHostnameRequired)
+}
+
+func TestClient_GetRepository(t *testing.T) {
+	mux, client := setup(t, "my-token")
+
+	mux.HandleFunc("/rest/api/1.0/projects/OTF/repos/terraform", func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "GET", r.Method)
+		assert.Equal(t, "Bearer my-token", r.Header.Get("Authorization"))
+		fmt.Fprint(w, `{"slug":"terraform","project":{"key":"OTF"}}`)
+	})
+	mux.HandleFunc("/rest/api/1.0/projects/OTF/repos/terraform/branches/default", func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "GET", r.Method)
+		fmt.Fprint(w, `{"id":"refs/heads/master","displayId":"master"}`)
+	})
+
+	got, err := client.GetRepository(context.Background(), "OTF/terraform")
+	require.NoError(t, err)
+
+	assert.Equal(t, "OTF/terraform", got.Path)
+	assert.Equal(t, "master", got.DefaultBranch)
+}
+
+func TestClient_GetRepository_NotFound(t *testing.T) {
+	_, client := setup(t, "my-token")
+
+	_, err := client.GetRepository(context.Background(), "OTF/terraform")
+	assert.ErrorIs(t, err, internal.ErrResourceNotFound)
+}
+
+func TestClient_ListRepositories(t *testing.T) {
+	mux, client := setup(t, "my-token")
+
+	mux.HandleFunc("/rest/api/1.0/repos", func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "GET", r.Method)
+		assert.Equal(t, "REPO_ADMIN", r.URL.Query().Get("permission"))
+		assert.Equal(t, "50", r.URL.Query().Get("limit"))
+		fmt.Fprint(w, `{"values":[{"slug":"terraform","project":{"key":"OTF"}},{"slug":"modules","project":{"key":"~LEG100"}}],"isLastPage":true}`)
+	})
+
+	got, err := client.ListRepositories(context.Background(), vcs.ListRepositoriesOptions{PageSize: 50})
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"OTF/terraform", "~LEG100/modules"}, got)
+}
+
+func TestClient_ListTags(t *testing.T) {
+	mux, client := setup(t, "my-token")
+
+	mux.HandleFunc("/rest/api/1.0/projects/OTF/repos/terraform/tags", func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "GET", r.Method)
+		assert.Equal(t, "v1", r.URL.Query().Get("filterText"))
+		if r.URL.Query().Get("start") == "2" {
+			fmt.Fprint(w, `{"values":[{"displayId":"v1.1.0"}],"isLastPage":true}`)
+			return
+		}
+		fmt.Fprint(w, `{"values":[{"displayId":"v1.0.0"},{"displayId":"prev1.0.0"}],"isLastPage":false,"nextPageStart":2}`)
+	})
+
+	got, err := client.ListTags(context.Background(), vcs.ListTagsOptions{
+		Repo:   "OTF/terraform",
+		Prefix: "v1",
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"tags/v1.0.0", "tags/v1.1.0"}, got)
+}
+
+func TestClient_GetRepoTarball(t *testing.T) {
+	mux, client := setup(t, "my-token")
+
+	mux.HandleFunc("/rest/api/1.0/projects/OTF/repos/terraform/commits/v1.0.0", func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "GET", r.Method)
+		fmt.Fprint(w, `{"id":"0335fb07bb0244b7a169ee89d15c7703e4aaf7de"}`)
+	})
+	mux.HandleFunc("/rest/api/1.0/projects/OTF/repos/terraform/archive", func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "GET", r.Method)
+		assert.Equal(t, "0335fb07bb0244b7a169ee89d15c7703e4aaf7de", r.URL.Query().Get("at"))
+		assert.Equal(t, "tar.gz", r.URL.Query().Get("format"))
+		w.Write(testutils.ReadFile(t, "../testdata/unpack.tar.gz"))
+	})
+
+	got, ref, err := client.GetRepoTarball(context.Background(), vcs.GetRepoTarballOptions{
+		Repo: "OTF/terraform",
+		Ref:  internal.String("v1.0.0"),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "0335fb07bb0244b7a169ee89d15c7703e4aaf7de", ref)
+
+	dst := t.TempDir()
+	err = internal.Unpack(bytes.NewReader(got), dst)
+	require.NoError(t, err)
+	assert.FileExists(t, path.Join(dst, "file"))
+	assert.FileExists(t, path.Join(dst, "dir", "file"))
+}
+
+func TestClient_Webhook(t *testing.T) {
+	mux, client := setup(t, "my-token")
+	ctx := context.Background()
+
+	var hook webhook
+	mux.HandleFunc("/rest/api/1.0/projects/OTF/repos/terraform/webhooks", func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "POST", r.Method)
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&hook))
+		hook.ID = 123
+		hook.Configuration.Secret = ""
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(hook)
+	})
+	mux.HandleFunc("/rest/api/1.0/projects/OTF/repos/terraform/webhooks/123", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case "GET":
+			json.NewEncoder(w).Encode(hook)
+		case "DELETE":
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			t.Fatalf("unexpected method: %s", r.Method)
+		}
+	})
+
+	id, err := client.CreateWebhook(ctx, vcs.CreateWebhookOptions{
+		Repo:     "OTF/terraform",
+		Secret:   "me-secret",
+		Endpoint: "https://otf.dev/webhooks/vcs/123",
+		Events:   []vcs.EventType{vcs.EventTypePush, vcs.EventTypePull},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "123", id)
+	assert.True(t, hook.Active)
+	assert.Equal(t, []string{"repo:refs_changed", "pr:opened", "pr:from_ref_updated"}, hook.Events)
+
+	got, err := client.GetWebhook(ctx, vcs.GetWebhookOptions{Repo: "OTF/terraform", ID: id})
+	require.NoError(t, err)
+	assert.Equal(t, vcs.Webhook{
+		ID:       "123",
+		Repo:     "OTF/terraform",
+		Events:   []vcs.EventType{vcs.EventTypePush, vcs.EventTypePull},
+		Endpoint: "https://otf.dev/webhooks/vcs/123",
+	}, got)
+
+	err = client.DeleteWebhook(ctx, vcs.DeleteWebhookOptions{Repo: "OTF/terraform", ID: id})
+	require.NoError(t, err)
+}
+
+func TestClient_SetStatus(t *testing.T) {
+	mux, client := setup(t, "my-token")
+
+	var got buildStatus
+	mux.HandleFunc("/rest/build-status/1.0/commits/abc123", func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "POST", r.Method)
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&got))
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	err := client.SetStatus(context.Background(), vcs.SetStatusOptions{
+		Workspace:   "dev",
+		Repo:        "OTF/terraform",
+		Ref:         "abc123",
+		Status:      vcs.PendingStatus,
+		TargetURL:   "https://otf.dev/runs/run-123",
+		Description: "planning",
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, buildStatus{
+		Key:         "otf/dev",
+		Name:        "otf/dev",
+		State:       "INPROGRESS",
+		URL:         "https://otf.dev/runs/run-123",
+		Description: "planning",
+	}, got)
+}
+
+func TestClient_ListPullRequestFiles(t *testing.T) {
+	mux, client := setup(t, "my-token")
+
+	mux.HandleFunc("/rest/api/1.0/projects/OTF/repos/terraform/pull-requests/7/changes", func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "GET", r.Method)
+		fmt.Fprint(w, `{"values":[
+			{"path":{"toString":"added.tf"}},
+			{"path":{"toString":"main.tf"}},
+			{"path":{"toString":"modules/vpc.tf"},"srcPath":{"toString":"vpc.tf"}}
+		],"isLastPage":true}`)
+	})
+
+	got, err := client.ListPullRequestFiles(context.Background(), "OTF/terraform", 7)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"added.tf", "main.tf", "modules/vpc.tf", "vpc.tf"}, got)
+}
+
+func TestClient_GetCommit(t *testing.T) {
+	mux, client := setup(t, "my-token")
+
+	mux.HandleFunc("/rest/api/1.0/projects/OTF/repos/terraform/commits/master", func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "GET", r.Method)
+		fmt.Fprint(w, `{
+			"id":"abc123",
+			"author":{"name":"leg100","slug":"leg100","avatarUrl":"/users/leg100/avatar.png?s=64"}
+		}`)
+	})
+
+	got, err := client.GetCommit(context.Background(), "OTF/terraform", "master")
+	require.NoError(t, err)
+
+	base := client.baseURL.String()
+	assert.Equal(t, vcs.Commit{
+		SHA: "abc123",
+		URL: base + "projects/OTF/repos/terraform/commits/abc123",
+		Author: vcs.CommitAuthor{
+			Username:   "leg100",
+			ProfileURL: base + "users/leg100",
+			AvatarURL:  base + "users/leg100/avatar.png?s=64",
+		},
+	}, got)
+}
+
+func setup(t *testing.T, token string) (*http.ServeMux, *Client) {
+	mux := http.NewServeMux()
+	srv := httptest.NewTLSServer(mux)
+	t.Cleanup(srv.Close)
+
+	u, err := url.Parse(srv.URL)
+	require.NoError(t, err)
+
+	client, err := NewClient(ClientOptions{
+		Hostname:            u.Host,
+		SkipTLSVerification: true,
+		Token:               token,
+	})
+	require.NoError(t, err)
+
+	return mux, client
+}
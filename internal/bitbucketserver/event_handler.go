@@ -0,0 +1,210 @@
+package bitbucketserver
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/leg100/otf/internal/vcs"
+)
+
+type (
+	// refsChangedEvent is the payload of a repo:refs_changed event:
+	//
+	// https://confluence.atlassian.com/bitbucketserver/event-payload-938025882.html#Eventpayload-Push
+	refsChangedEvent struct {
+		Actor      eventUser `json:"actor"`
+		Repository eventRepo `json:"repository"`
+		Changes    []struct {
+			Ref struct {
+				DisplayID string `json:"displayId"`
+				Type      string `json:"type"` // BRANCH or TAG
+			} `json:"ref"`
+			ToHash string `json:"toHash"`
+			Type   string `json:"type"` // ADD, UPDATE, or DELETE
+		} `json:"changes"`
+	}
+
+	// pullRequestEvent is the payload of pr:* events:
+	//
+	// https://confluence.atlassian.com/bitbucketserver/event-payload-938025882.html#Eventpayload-Pullrequest
+	pullRequestEvent struct {
+		Actor       eventUser `json:"actor"`
+		PullRequest struct {
+			ID      int    `json:"id"`
+			Title   string `json:"title"`
+			FromRef struct {
+				DisplayID    string `json:"displayId"`
+				LatestCommit string `json:"latestCommit"`
+			} `json:"fromRef"`
+			ToRef struct {
+				Repository eventRepo `json:"repository"`
+			} `json:"toRef"`
+			Links struct {
+				Self []eventLink `json:"self"`
+			} `json:"links"`
+		} `json:"pullRequest"`
+	}
+
+	eventUser struct {
+		Slug string `json:"slug"`
+	}
+
+	eventRepo struct {
+		Slug    string `json:"slug"`
+		Project struct {
+			Key string `json:"key"`
+		} `json:"project"`
+		Links struct {
+			Self []eventLink `json:"self"`
+		} `json:"links"`
+	}
+
+	eventLink struct {
+		Href string `json:"href"`
+	}
+)
+
+// HandleEvent converts a bitbucket server webhook event into an OTF event.
+// Push events do not include the repository's default branch nor the list of
+// changed files.
+func HandleEvent(r *http.Request, secret string) (*vcs.EventPayload, error) {
+	payload, err := io.ReadAll(r.Body)
+	if err != nil || len(payload) == 0 {
+		return nil, errors.New("error reading request body")
+	}
+	if err := validateSignature(r.Header.Get("X-Hub-Signature"), payload, secret); err != nil {
+		return nil, err
+	}
+
+	to := vcs.EventPayload{VCSKind: vcs.BitbucketServerKind, DeliveryID: r.Header.Get("X-Request-Id")}
+	switch key := r.Header.Get("X-Event-Key"); key {
+	case "repo:refs_changed":
+		var event refsChangedEvent
+		if err := json.Unmarshal(payload, &event); err != nil {
+			return nil, fmt.Errorf("parsing webhook: %w", err)
+		}
+		if len(event.Changes) == 0 {
+			return nil, vcs.NewErrIgnoreEvent("push event contains no changes")
+		}
+		// a push of several refs results in several changes; only the first
+		// is handled.
+		change := event.Changes[0]
+		deleted := change.Type == "DELETE"
+		switch change.Ref.Type {
+		case "BRANCH":
+			if deleted {
+				return nil, vcs.NewErrIgnoreEvent("branch deleted: %s", change.Ref.DisplayID)
+			}
+			to.Type = vcs.EventTypePush
+			to.Action = vcs.ActionCreated
+			to.Branch = change.Ref.DisplayID
+		case "TAG":
+			to.Type = vcs.EventTypeTag
+			to.Tag = change.Ref.DisplayID
+			if deleted {
+				to.Action = vcs.ActionDeleted
+			} else {
+				to.Action = vcs.ActionCreated
+			}
+		default:
+			return nil, vcs.NewErrIgnoreEvent("unsupported ref type: %s", change.Ref.Type)
+		}
+		baseURL := event.Repository.baseURL()
+		if !deleted {
+			to.CommitSHA = change.ToHash
+			to.CommitURL = event.Repository.commitURL(baseURL, change.ToHash)
+		}
+		to.RepoPath = event.Repository.path()
+		setSender(&to, baseURL, event.Actor)
+	case "pr:opened", "pr:from_ref_updated":
+		var event pullRequestEvent
+		if err := json.Unmarshal(payload, &event); err != nil {
+			return nil, fmt.Errorf("parsing webhook: %w", err)
+		}
+		to.Type = vcs.EventTypePull
+		if key == "pr:opened" {
+			to.Action = vcs.ActionCreated
+		} else {
+			to.Action = vcs.ActionUpdated
+		}
+		pr := event.PullRequest
+		repo := pr.ToRef.Repository
+		baseURL := repo.baseURL()
+		to.Branch = pr.FromRef.DisplayID
+		to.CommitSHA = pr.FromRef.LatestCommit
+		to.CommitURL = repo.commitURL(baseURL, to.CommitSHA)
+		to.PullRequestNumber = pr.ID
+		if len(pr.Links.Self) > 0 {
+			to.PullRequestURL = pr.Links.Self[0].Href
+		}
+		to.PullRequestTitle = pr.Title
+		to.RepoPath = repo.path()
+		setSender(&to, baseURL, event.Actor)
+	case "diagnostics:ping":
+		return nil, vcs.NewErrIgnoreEvent("ping event")
+	default:
+		return nil, vcs.NewErrIgnoreEvent("unsupported event type: %s", key)
+	}
+	if err := to.Validate(); err != nil {
+		return nil, fmt.Errorf("failed building OTF event: %w", err)
+	}
+	return &to, nil
+}
+
+func (r eventRepo) path() string {
+	return r.Project.Key + "/" + r.Slug
+}
+
+// baseURL derives the base URL of the bitbucket server website from the
+// repository's link, e.g.
+// https://bitbucket.acme.com/projects/PROJ/repos/terraform/browse. Returns an
+// empty string if the repository has no link.
+func (r eventRepo) baseURL() string {
+	if len(r.Links.Self) == 0 {
+		return ""
+	}
+	base, _, _ := strings.Cut(r.Links.Self[0].Href, "/projects/")
+	return base
+}
+
+func (r eventRepo) commitURL(baseURL, sha string) string {
+	if baseURL == "" {
+		return ""
+	}
+	return fmt.Sprintf("%s/projects/%s/repos/%s/commits/%s", baseURL, r.Project.Key, r.Slug, sha)
+}
+
+// validateSignature validates the HMAC-SHA256 signature bitbucket server sends
+// for webhooks configured with a secret.
+func validateSignature(header string, payload []byte, secret string) error {
+	sig, found := strings.CutPrefix(header, "sha256=")
+	if !found {
+		return errors.New("missing or malformed X-Hub-Signature header")
+	}
+	got, err := hex.DecodeString(sig)
+	if err != nil {
+		return fmt.Errorf("decoding signature: %w", err)
+	}
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(payload)
+	// constant-time comparison prevents the signature being guessed by
+	// timing responses.
+	if !hmac.Equal(got, mac.Sum(nil)) {
+		return errors.New("signature validation failed")
+	}
+	return nil
+}
+
+func setSender(to *vcs.EventPayload, baseURL string, actor eventUser) {
+	to.SenderUsername = actor.Slug
+	if baseURL != "" && actor.Slug != "" {
+		to.SenderHTMLURL = baseURL + "/users/" + actor.Slug
+	}
+}
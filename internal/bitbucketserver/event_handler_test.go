@@ -0,0 +1,158 @@
+package bitbucketserver
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/leg100/otf/internal/testutils"
+	"github.com/leg100/otf/internal/vcs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEventHandler(t *testing.T) {
+	sender := func(to vcs.EventPayload) *vcs.EventPayload {
+		to.VCSKind = vcs.BitbucketServerKind
+		to.DeliveryID = "delivery-123"
+		to.RepoPath = "OTF/terraform"
+		to.SenderUsername = "leg100"
+		to.SenderHTMLURL = "https://bitbucket.acme.com/users/leg100"
+		return &to
+	}
+
+	tests := []struct {
+		name     string
+		eventKey string
+		body     string
+		want     *vcs.EventPayload
+	}{
+		{
+			"push",
+			"repo:refs_changed",
+			"./testdata/repo_refs_changed.json",
+			sender(vcs.EventPayload{
+				Type:      vcs.EventTypePush,
+				Action:    vcs.ActionCreated,
+				Branch:    "master",
+				CommitSHA: "178864a7d521b6f5e720b386b2c2b0ef8563e0dc",
+				CommitURL: "https://bitbucket.acme.com/projects/OTF/repos/terraform/commits/178864a7d521b6f5e720b386b2c2b0ef8563e0dc",
+			}),
+		},
+		{
+			"tag created",
+			"repo:refs_changed",
+			"./testdata/tag_created.json",
+			sender(vcs.EventPayload{
+				Type:      vcs.EventTypeTag,
+				Action:    vcs.ActionCreated,
+				Tag:       "v1.0.0",
+				CommitSHA: "178864a7d521b6f5e720b386b2c2b0ef8563e0dc",
+				CommitURL: "https://bitbucket.acme.com/projects/OTF/repos/terraform/commits/178864a7d521b6f5e720b386b2c2b0ef8563e0dc",
+			}),
+		},
+		{
+			"tag deleted",
+			"repo:refs_changed",
+			"./testdata/tag_deleted.json",
+			sender(vcs.EventPayload{
+				Type:   vcs.EventTypeTag,
+				Action: vcs.ActionDeleted,
+				Tag:    "v1.0.0",
+			}),
+		},
+		{
+			"pull request opened",
+			"pr:opened",
+			"./testdata/pr_opened.json",
+			sender(vcs.EventPayload{
+				Type:              vcs.EventTypePull,
+				Action:            vcs.ActionCreated,
+				Branch:            "add-vpc",
+				CommitSHA:         "ef8755f06ee4b28c96a847a95cb8ec8ed6ddd1ca",
+				CommitURL:         "https://bitbucket.acme.com/projects/OTF/repos/terraform/commits/ef8755f06ee4b28c96a847a95cb8ec8ed6ddd1ca",
+				PullRequestNumber: 7,
+				PullRequestURL:    "https://bitbucket.acme.com/projects/OTF/repos/terraform/pull-requests/7",
+				PullRequestTitle:  "add vpc",
+			}),
+		},
+		{
+			"pull request updated",
+			"pr:from_ref_updated",
+			"./testdata/pr_opened.json",
+			sender(vcs.EventPayload{
+				Type:              vcs.EventTypePull,
+				Action:            vcs.ActionUpdated,
+				Branch:            "add-vpc",
+				CommitSHA:         "ef8755f06ee4b28c96a847a95cb8ec8ed6ddd1ca",
+				CommitURL:         "https://bitbucket.acme.com/projects/OTF/repos/terraform/commits/ef8755f06ee4b28c96a847a95cb8ec8ed6ddd1ca",
+				PullRequestNumber: 7,
+				PullRequestURL:    "https://bitbucket.acme.com/projects/OTF/repos/terraform/pull-requests/7",
+				PullRequestTitle:  "add vpc",
+			}),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			body := testutils.ReadFile(t, tt.body)
+			r := newTestEventRequest(body, tt.eventKey, sign(body, "secret"))
+
+			got, err := HandleEvent(r, "secret")
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.want, got)
+		})
+	}
+
+	t.Run("ignore deleted branch", func(t *testing.T) {
+		body := testutils.ReadFile(t, "./testdata/branch_deleted.json")
+		r := newTestEventRequest(body, "repo:refs_changed", sign(body, "secret"))
+
+		_, err := HandleEvent(r, "secret")
+		assert.ErrorAs(t, err, &vcs.ErrIgnoreEvent{})
+	})
+
+	t.Run("ignore unsupported event", func(t *testing.T) {
+		body := testutils.ReadFile(t, "./testdata/pr_opened.json")
+		r := newTestEventRequest(body, "pr:merged", sign(body, "secret"))
+
+		_, err := HandleEvent(r, "secret")
+		assert.ErrorAs(t, err, &vcs.ErrIgnoreEvent{})
+	})
+
+	t.Run("invalid signature", func(t *testing.T) {
+		body := testutils.ReadFile(t, "./testdata/repo_refs_changed.json")
+		r := newTestEventRequest(body, "repo:refs_changed", sign(body, "wrong-secret"))
+
+		_, err := HandleEvent(r, "secret")
+		assert.Error(t, err)
+	})
+
+	t.Run("missing signature", func(t *testing.T) {
+		body := testutils.ReadFile(t, "./testdata/repo_refs_changed.json")
+		r := newTestEventRequest(body, "repo:refs_changed", "")
+
+		_, err := HandleEvent(r, "secret")
+		assert.Error(t, err)
+	})
+}
+
+func newTestEventRequest(body []byte, eventKey, signature string) *http.Request {
+	r := httptest.NewRequest("POST", "/", bytes.NewReader(body))
+	r.Header.Set("X-Event-Key", eventKey)
+	r.Header.Set("X-Request-Id", "delivery-123")
+	if signature != "" {
+		r.Header.Set("X-Hub-Signature", signature)
+	}
+	return r
+}
+
+func sign(body []byte, secret string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
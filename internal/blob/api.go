@@ -0,0 +1,171 @@
+package blob
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/leg100/otf/internal"
+	otfapi "github.com/leg100/otf/internal/api"
+	"github.com/leg100/otf/internal/http/decode"
+	"github.com/leg100/otf/internal/tfeapi"
+)
+
+type api struct {
+	*Service
+}
+
+func (a *api) addHandlers(r *mux.Router) {
+	signed := r.PathPrefix("/signed/{signature.expiry}").Subrouter()
+	signed.Use(internal.VerifySignedURL(a.Signer))
+
+	signed.HandleFunc("/blobs/{digest}", a.download).Methods("GET")
+	signed.HandleFunc("/blobs/{digest}/upload", a.upload).Methods("PUT")
+
+	r = r.PathPrefix(otfapi.DefaultBasePath).Subrouter()
+	r.HandleFunc("/admin/blob-migrations", a.startMigration).Methods("POST")
+	r.HandleFunc("/admin/blob-migrations", a.listMigrations).Methods("GET")
+	r.HandleFunc("/admin/blob-migrations/{migration_id}", a.getMigration).Methods("GET")
+	r.HandleFunc("/admin/blob-migrations/{migration_id}", a.updateMigration).Methods("PATCH")
+}
+
+// download streams a blob to the client.
+//
+// NOTE: unauthenticated - access granted only via signed URL
+func (a *api) download(w http.ResponseWriter, r *http.Request) {
+	digest, err := a.digestParam(r)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	blob, err := a.Get(r.Context(), digest)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	defer blob.Close()
+
+	w.Header().Set("Content-Type", "application/octet-stream")
+	w.Header().Set("ETag", fmt.Sprintf("%q", digest))
+	if _, err := io.Copy(w, blob); err != nil {
+		a.Error(err, "sending blob", "digest", digest)
+	}
+}
+
+// upload stores a blob sent by the client, which must match the digest in
+// the URL.
+//
+// NOTE: unauthenticated - access granted only via signed URL
+func (a *api) upload(w http.ResponseWriter, r *http.Request) {
+	digest, err := a.digestParam(r)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	body := r.Body
+	if a.maxSize > 0 {
+		body = http.MaxBytesReader(w, r.Body, a.maxSize)
+	}
+	if _, err := a.put(r.Context(), digest, body); err != nil {
+		var tooLarge *http.MaxBytesError
+		switch {
+		case errors.As(err, &tooLarge):
+			err = &internal.HTTPError{
+				Code:    http.StatusRequestEntityTooLarge,
+				Message: fmt.Sprintf("blob exceeds maximum size (%d bytes)", a.maxSize),
+			}
+		case errors.Is(err, ErrDigestMismatch):
+			err = &internal.HTTPError{Code: http.StatusUnprocessableEntity, Message: err.Error()}
+		}
+		tfeapi.Error(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusCreated)
+}
+
+func (a *api) digestParam(r *http.Request) (string, error) {
+	digest, err := decode.Param("digest", r)
+	if err != nil {
+		return "", err
+	}
+	if err := ValidDigest(digest); err != nil {
+		return "", &internal.HTTPError{Code: http.StatusBadRequest, Message: err.Error()}
+	}
+	return digest, nil
+}
+
+func (a *api) startMigration(w http.ResponseWriter, r *http.Request) {
+	// options are optional, so permit an empty body
+	var opts StartMigrationOptions
+	if err := json.NewDecoder(r.Body).Decode(&opts); err != nil && err != io.EOF {
+		tfeapi.Error(w, err)
+		return
+	}
+	m, err := a.StartMigration(r.Context(), opts)
+	if err != nil {
+		switch {
+		case errors.Is(err, ErrMigrationDestinationPostgres):
+			err = &internal.HTTPError{Code: http.StatusUnprocessableEntity, Message: err.Error()}
+		case errors.Is(err, ErrMigrationInProgress):
+			err = &internal.HTTPError{Code: http.StatusConflict, Message: err.Error()}
+		}
+		tfeapi.Error(w, err)
+		return
+	}
+	a.respond(w, m, http.StatusCreated)
+}
+
+func (a *api) listMigrations(w http.ResponseWriter, r *http.Request) {
+	migrations, err := a.ListMigrations(r.Context())
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	a.respond(w, migrations, http.StatusOK)
+}
+
+func (a *api) getMigration(w http.ResponseWriter, r *http.Request) {
+	id, err := decode.Param("migration_id", r)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	m, err := a.GetMigration(r.Context(), id)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	a.respond(w, m, http.StatusOK)
+}
+
+// updateMigration throttles, pauses, resumes or cancels a migration.
+func (a *api) updateMigration(w http.ResponseWriter, r *http.Request) {
+	id, err := decode.Param("migration_id", r)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	var opts UpdateMigrationOptions
+	if err := json.NewDecoder(r.Body).Decode(&opts); err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	m, err := a.UpdateMigration(r.Context(), id, opts)
+	if err != nil {
+		if errors.Is(err, ErrMigrationFinished) {
+			err = &internal.HTTPError{Code: http.StatusConflict, Message: err.Error()}
+		}
+		tfeapi.Error(w, err)
+		return
+	}
+	a.respond(w, m, http.StatusOK)
+}
+
+func (a *api) respond(w http.ResponseWriter, v any, code int) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	json.NewEncoder(w).Encode(v)
+}
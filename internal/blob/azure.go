@@ -0,0 +1,55 @@
+package blob
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azidentity"
+	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob"
+	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob/bloberror"
+	"github.com/leg100/otf/internal"
+)
+
+// azureBackend stores blobs in an Azure Blob Storage container. Credentials
+// are taken from the environment, e.g. a managed identity.
+type azureBackend struct {
+	client    *azblob.Client
+	container string
+	prefix    string
+}
+
+func newAzureBackend(cfg Config) (*azureBackend, error) {
+	cred, err := azidentity.NewDefaultAzureCredential(nil)
+	if err != nil {
+		return nil, fmt.Errorf("retrieving azure credentials: %w", err)
+	}
+	client, err := azblob.NewClient(cfg.AzureAccountURL, cred, nil)
+	if err != nil {
+		return nil, fmt.Errorf("constructing azure blob client: %w", err)
+	}
+	return &azureBackend{
+		client:    client,
+		container: cfg.Bucket,
+		prefix:    cfg.Prefix,
+	}, nil
+}
+
+func (b *azureBackend) Put(ctx context.Context, digest string, r io.Reader) error {
+	_, err := b.client.UploadStream(ctx, b.container, objectName(b.prefix, digest), r, nil)
+	if err != nil {
+		return fmt.Errorf("uploading blob to azure: %w", err)
+	}
+	return nil
+}
+
+func (b *azureBackend) Get(ctx context.Context, digest string) (io.ReadCloser, error) {
+	resp, err := b.client.DownloadStream(ctx, b.container, objectName(b.prefix, digest), nil)
+	if err != nil {
+		if bloberror.HasCode(err, bloberror.BlobNotFound) {
+			return nil, internal.ErrResourceNotFound
+		}
+		return nil, fmt.Errorf("retrieving blob from azure: %w", err)
+	}
+	return resp.Body, nil
+}
@@ -0,0 +1,104 @@
+package blob
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+)
+
+const (
+	PostgresBackend = "postgres"
+	S3Backend       = "s3"
+	GCSBackend      = "gcs"
+	AzureBackend    = "azure"
+)
+
+var ErrInvalidBackend = errors.New("invalid blob backend")
+
+type (
+	// Backend stores the content of blobs, addressed by their digest.
+	Backend interface {
+		// Put stores content read from r until EOF. Storing content under
+		// a digest that already exists is a no-op.
+		Put(ctx context.Context, digest string, r io.Reader) error
+		// Get retrieves the content of a blob, returning
+		// internal.ErrResourceNotFound if it does not exist. The caller is
+		// responsible for closing the returned reader.
+		Get(ctx context.Context, digest string) (io.ReadCloser, error)
+	}
+
+	// Config configures the backend in which blobs are stored.
+	Config struct {
+		// Backend is one of postgres, s3, gcs, or azure. Defaults to
+		// postgres.
+		Backend string
+		// Bucket in which blobs are stored. For Azure, this is the name of
+		// the container. Required for all but the postgres backend.
+		Bucket string
+		// Prefix is prepended to the name of each object stored in the
+		// bucket.
+		Prefix string
+
+		// S3Region is the AWS region of the bucket. If empty then the region
+		// is taken from the environment.
+		S3Region string
+		// S3Endpoint overrides the S3 endpoint, for use with S3-compatible
+		// services such as MinIO.
+		S3Endpoint string
+		// S3UsePathStyle addresses the bucket in the path of the URL rather
+		// than in the hostname.
+		S3UsePathStyle bool
+
+		// AzureAccountURL is the URL of the Azure storage account, e.g.
+		// https://myaccount.blob.core.windows.net
+		AzureAccountURL string
+	}
+
+	// uploadAssembler is implemented by a backend that can assemble an upload
+	// into a blob itself, without its content passing through otfd.
+	uploadAssembler interface {
+		assembleUpload(ctx context.Context, uploadID, digest string) error
+	}
+)
+
+// Valid validates the config.
+func (cfg Config) Valid() error {
+	switch cfg.Backend {
+	case "", PostgresBackend:
+		return nil
+	case S3Backend, GCSBackend:
+	case AzureBackend:
+		if cfg.AzureAccountURL == "" {
+			return errors.New("azure blob backend requires an account URL")
+		}
+	default:
+		return fmt.Errorf("%w: %s: must be one of %s, %s, %s, or %s", ErrInvalidBackend, cfg.Backend, PostgresBackend, S3Backend, GCSBackend, AzureBackend)
+	}
+	if cfg.Bucket == "" {
+		return fmt.Errorf("%s blob backend requires a bucket", cfg.Backend)
+	}
+	return nil
+}
+
+// NewBackend constructs the backend specified in the config. Nil is returned
+// for the postgres backend, which is provided by the service itself.
+func NewBackend(ctx context.Context, cfg Config) (Backend, error) {
+	switch cfg.Backend {
+	case S3Backend:
+		return newS3Backend(ctx, cfg)
+	case GCSBackend:
+		return newGCSBackend(ctx, cfg)
+	case AzureBackend:
+		return newAzureBackend(cfg)
+	default:
+		return nil, nil
+	}
+}
+
+// objectName returns the name of the object in which the blob with the given
+// digest is stored.
+func objectName(prefix, digest string) string {
+	return path.Join(prefix, "blobs", digest)
+}
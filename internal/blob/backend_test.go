@@ -0,0 +1,42 @@
+package blob
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConfig_Valid(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     Config
+		wantErr bool
+	}{
+		{"default", Config{}, false},
+		{"postgres", Config{Backend: PostgresBackend}, false},
+		{"s3", Config{Backend: S3Backend, Bucket: "otf"}, false},
+		{"s3 without bucket", Config{Backend: S3Backend}, true},
+		{"gcs", Config{Backend: GCSBackend, Bucket: "otf"}, false},
+		{"azure", Config{Backend: AzureBackend, Bucket: "otf", AzureAccountURL: "https://otf.blob.core.windows.net"}, false},
+		{"azure without account url", Config{Backend: AzureBackend, Bucket: "otf"}, true},
+		{"unknown backend", Config{Backend: "ftp", Bucket: "otf"}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.cfg.Valid()
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestObjectName(t *testing.T) {
+	digest := Digest([]byte("hello world"))
+
+	assert.Equal(t, "blobs/"+digest, objectName("", digest))
+	assert.Equal(t, "otf/blobs/"+digest, objectName("otf", digest))
+	assert.Equal(t, "otf/blobs/"+digest, objectName("otf/", digest))
+}
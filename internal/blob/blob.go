@@ -0,0 +1,39 @@
+// Package blob provides a content-addressed store for large artifacts, such as
+// configuration tarballs.
+package blob
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"regexp"
+)
+
+var (
+	// ErrDigestMismatch is returned when the content of a blob does not match
+	// the digest with which it is addressed.
+	ErrDigestMismatch = errors.New("blob content does not match digest")
+	// ErrInvalidDigest is returned when a digest is not a hex-encoded SHA-256
+	// hash.
+	ErrInvalidDigest = errors.New("invalid blob digest")
+	// ErrUploadOffsetMismatch is returned when a part of an upload is written
+	// at an offset other than the current size of the upload.
+	ErrUploadOffsetMismatch = errors.New("upload offset does not match size of upload")
+
+	digestRegex = regexp.MustCompile(`^[a-f0-9]{64}$`)
+)
+
+// Digest returns the address of a blob, the hex-encoded SHA-256 hash of its
+// content.
+func Digest(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// ValidDigest checks the digest is a hex-encoded SHA-256 hash.
+func ValidDigest(digest string) error {
+	if !digestRegex.MatchString(digest) {
+		return ErrInvalidDigest
+	}
+	return nil
+}
@@ -0,0 +1,306 @@
+package blob
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+
+	"github.com/jackc/pgtype"
+	"github.com/leg100/otf/internal"
+	"github.com/leg100/otf/internal/sql"
+	"github.com/leg100/otf/internal/sql/pggen"
+)
+
+type (
+	// pgdb is a database of blobs on postgres
+	pgdb struct {
+		*sql.DB // provides access to generated SQL queries
+	}
+
+	migrationRow struct {
+		MigrationID pgtype.Text        `json:"migration_id"`
+		CreatedAt   pgtype.Timestamptz `json:"created_at"`
+		UpdatedAt   pgtype.Timestamptz `json:"updated_at"`
+		Source      pgtype.Text        `json:"source"`
+		Destination pgtype.Text        `json:"destination"`
+		Status      pgtype.Text        `json:"status"`
+		BatchSize   pgtype.Int4        `json:"batch_size"`
+		RateLimit   pgtype.Int8        `json:"rate_limit"`
+		Total       pgtype.Int4        `json:"total"`
+		Copied      pgtype.Int4        `json:"copied"`
+		CopiedBytes pgtype.Int8        `json:"copied_bytes"`
+		Failed      pgtype.Int4        `json:"failed"`
+		LastDigest  pgtype.Text        `json:"last_digest"`
+		Error       pgtype.Text        `json:"error"`
+		CompletedAt pgtype.Timestamptz `json:"completed_at"`
+	}
+
+	// blobInfo describes a blob stored in postgres, without its content.
+	blobInfo struct {
+		digest string
+		size   int64
+	}
+)
+
+func (r migrationRow) toMigration() *Migration {
+	m := &Migration{
+		ID:          r.MigrationID.String,
+		CreatedAt:   r.CreatedAt.Time.UTC(),
+		UpdatedAt:   r.UpdatedAt.Time.UTC(),
+		Source:      r.Source.String,
+		Destination: r.Destination.String,
+		Status:      MigrationStatus(r.Status.String),
+		BatchSize:   int(r.BatchSize.Int),
+		RateLimit:   r.RateLimit.Int,
+		Total:       int(r.Total.Int),
+		Copied:      int(r.Copied.Int),
+		CopiedBytes: r.CopiedBytes.Int,
+		Failed:      int(r.Failed.Int),
+		LastDigest:  r.LastDigest.String,
+		Error:       r.Error.String,
+	}
+	if r.CompletedAt.Status == pgtype.Present {
+		m.CompletedAt = internal.Time(r.CompletedAt.Time.UTC())
+	}
+	return m
+}
+
+var (
+	_ Backend         = (*pgdb)(nil)
+	_ uploadAssembler = (*pgdb)(nil)
+)
+
+func (db *pgdb) Put(ctx context.Context, digest string, r io.Reader) error {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return err
+	}
+	_, err = db.Conn(ctx).InsertBlob(ctx, pggen.InsertBlobParams{
+		Digest:    sql.String(digest),
+		Data:      data,
+		Size:      sql.Int8(len(data)),
+		CreatedAt: sql.Timestamptz(internal.CurrentTimestamp(nil)),
+	})
+	if err != nil {
+		return sql.Error(err)
+	}
+	return nil
+}
+
+func (db *pgdb) Get(ctx context.Context, digest string) (io.ReadCloser, error) {
+	data, err := db.Conn(ctx).FindBlobByDigest(ctx, sql.String(digest))
+	if err != nil {
+		return nil, sql.Error(err)
+	}
+	return io.NopCloser(bytes.NewReader(data)), nil
+}
+
+// createUpload starts an upload, discarding any existing upload with the same
+// ID.
+func (db *pgdb) createUpload(ctx context.Context, uploadID string) error {
+	return db.Tx(ctx, func(ctx context.Context, q pggen.Querier) error {
+		if _, err := q.DeleteBlobUpload(ctx, sql.String(uploadID)); err != nil {
+			return sql.Error(err)
+		}
+		_, err := q.InsertBlobUpload(ctx, sql.String(uploadID), sql.Timestamptz(internal.CurrentTimestamp(nil)))
+		if err != nil {
+			return sql.Error(err)
+		}
+		return nil
+	})
+}
+
+// appendUploadPart appends a part to an upload at the given offset, returning
+// the new size of the upload. The offset must match the current size of the
+// upload.
+func (db *pgdb) appendUploadPart(ctx context.Context, uploadID string, offset int64, data []byte) (int64, error) {
+	var size int64
+	err := db.Tx(ctx, func(ctx context.Context, q pggen.Querier) error {
+		current, err := q.FindBlobUploadSizeForUpdate(ctx, sql.String(uploadID))
+		if err != nil {
+			return sql.Error(err)
+		}
+		if current.Int != offset {
+			size = current.Int
+			return ErrUploadOffsetMismatch
+		}
+		_, err = q.InsertBlobUploadPart(ctx, pggen.InsertBlobUploadPartParams{
+			UploadID:   sql.String(uploadID),
+			PartOffset: sql.Int8(int(offset)),
+			Data:       data,
+		})
+		if err != nil {
+			return sql.Error(err)
+		}
+		size = offset + int64(len(data))
+		if _, err := q.UpdateBlobUploadSize(ctx, sql.Int8(int(size)), sql.String(uploadID)); err != nil {
+			return sql.Error(err)
+		}
+		return nil
+	})
+	return size, err
+}
+
+func (db *pgdb) getUploadSize(ctx context.Context, uploadID string) (int64, error) {
+	size, err := db.Conn(ctx).FindBlobUploadSize(ctx, sql.String(uploadID))
+	if err != nil {
+		return 0, sql.Error(err)
+	}
+	return size.Int, nil
+}
+
+func (db *pgdb) getUploadPart(ctx context.Context, uploadID string, offset int64) ([]byte, error) {
+	data, err := db.Conn(ctx).FindBlobUploadPart(ctx, sql.String(uploadID), sql.Int8(int(offset)))
+	if err != nil {
+		return nil, sql.Error(err)
+	}
+	return data, nil
+}
+
+// assembleUpload assembles the parts of an upload into a blob and removes the
+// upload.
+func (db *pgdb) assembleUpload(ctx context.Context, uploadID, digest string) error {
+	return db.Tx(ctx, func(ctx context.Context, q pggen.Querier) error {
+		_, err := q.InsertBlobFromUpload(ctx, pggen.InsertBlobFromUploadParams{
+			Digest:    sql.String(digest),
+			CreatedAt: sql.Timestamptz(internal.CurrentTimestamp(nil)),
+			UploadID:  sql.String(uploadID),
+		})
+		if err != nil {
+			return sql.Error(err)
+		}
+		if _, err := q.DeleteBlobUpload(ctx, sql.String(uploadID)); err != nil {
+			return sql.Error(err)
+		}
+		return nil
+	})
+}
+
+func (db *pgdb) deleteUpload(ctx context.Context, uploadID string) error {
+	_, err := db.Conn(ctx).DeleteBlobUpload(ctx, sql.String(uploadID))
+	if err != nil {
+		return sql.Error(err)
+	}
+	return nil
+}
+
+func (db *pgdb) countBlobs(ctx context.Context) (int, error) {
+	count, err := db.Conn(ctx).CountBlobs(ctx)
+	if err != nil {
+		return 0, sql.Error(err)
+	}
+	return int(count.Int), nil
+}
+
+// listBlobs lists up to limit blobs with a digest greater than after, in
+// order of their digest.
+func (db *pgdb) listBlobs(ctx context.Context, after string, limit int) ([]blobInfo, error) {
+	rows, err := db.Conn(ctx).FindBlobDigestsAfter(ctx, pggen.FindBlobDigestsAfterParams{
+		After: sql.String(after),
+		Limit: sql.Int4(limit),
+	})
+	if err != nil {
+		return nil, sql.Error(err)
+	}
+	blobs := make([]blobInfo, len(rows))
+	for i, r := range rows {
+		blobs[i] = blobInfo{digest: r.Digest.String, size: r.Size.Int}
+	}
+	return blobs, nil
+}
+
+func (db *pgdb) createMigration(ctx context.Context, m *Migration) error {
+	_, err := db.Conn(ctx).InsertBlobMigration(ctx, pggen.InsertBlobMigrationParams{
+		MigrationID: sql.String(m.ID),
+		CreatedAt:   sql.Timestamptz(m.CreatedAt),
+		UpdatedAt:   sql.Timestamptz(m.UpdatedAt),
+		Source:      sql.String(m.Source),
+		Destination: sql.String(m.Destination),
+		Status:      sql.String(string(m.Status)),
+		BatchSize:   sql.Int4(m.BatchSize),
+		RateLimit:   sql.Int8(int(m.RateLimit)),
+		Total:       sql.Int4(m.Total),
+		Copied:      sql.Int4(m.Copied),
+		CopiedBytes: sql.Int8(int(m.CopiedBytes)),
+		Failed:      sql.Int4(m.Failed),
+		LastDigest:  sql.String(m.LastDigest),
+		Error:       sql.String(m.Error),
+		CompletedAt: sql.TimestamptzPtr(m.CompletedAt),
+	})
+	if err != nil {
+		err = sql.Error(err)
+		if errors.Is(err, internal.ErrResourceAlreadyExists) {
+			// only one migration may be in progress at a time
+			return ErrMigrationInProgress
+		}
+		return err
+	}
+	return nil
+}
+
+func (db *pgdb) getMigration(ctx context.Context, id string) (*Migration, error) {
+	row, err := db.Conn(ctx).FindBlobMigrationByID(ctx, sql.String(id))
+	if err != nil {
+		return nil, sql.Error(err)
+	}
+	return migrationRow(row).toMigration(), nil
+}
+
+func (db *pgdb) listMigrations(ctx context.Context) ([]*Migration, error) {
+	rows, err := db.Conn(ctx).FindBlobMigrations(ctx)
+	if err != nil {
+		return nil, sql.Error(err)
+	}
+	migrations := make([]*Migration, len(rows))
+	for i, r := range rows {
+		migrations[i] = migrationRow(r).toMigration()
+	}
+	return migrations, nil
+}
+
+func (db *pgdb) listMigrationsByStatus(ctx context.Context, status MigrationStatus) ([]*Migration, error) {
+	rows, err := db.Conn(ctx).FindBlobMigrationsByStatus(ctx, sql.String(string(status)))
+	if err != nil {
+		return nil, sql.Error(err)
+	}
+	migrations := make([]*Migration, len(rows))
+	for i, r := range rows {
+		migrations[i] = migrationRow(r).toMigration()
+	}
+	return migrations, nil
+}
+
+// updateMigration updates a migration within a transaction, so that progress
+// recorded by the migrator and changes made via the API are not lost.
+func (db *pgdb) updateMigration(ctx context.Context, id string, fn func(*Migration) error) (*Migration, error) {
+	var m *Migration
+	err := db.Tx(ctx, func(ctx context.Context, q pggen.Querier) error {
+		row, err := q.FindBlobMigrationByIDForUpdate(ctx, sql.String(id))
+		if err != nil {
+			return sql.Error(err)
+		}
+		m = migrationRow(row).toMigration()
+		if err := fn(m); err != nil {
+			return err
+		}
+		_, err = q.UpdateBlobMigration(ctx, pggen.UpdateBlobMigrationParams{
+			MigrationID: sql.String(m.ID),
+			UpdatedAt:   sql.Timestamptz(m.UpdatedAt),
+			Status:      sql.String(string(m.Status)),
+			BatchSize:   sql.Int4(m.BatchSize),
+			RateLimit:   sql.Int8(int(m.RateLimit)),
+			Copied:      sql.Int4(m.Copied),
+			CopiedBytes: sql.Int8(int(m.CopiedBytes)),
+			Failed:      sql.Int4(m.Failed),
+			LastDigest:  sql.String(m.LastDigest),
+			Error:       sql.String(m.Error),
+			CompletedAt: sql.TimestamptzPtr(m.CompletedAt),
+		})
+		if err != nil {
+			return sql.Error(err)
+		}
+		return nil
+	})
+	return m, err
+}
@@ -0,0 +1,52 @@
+package blob
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	"cloud.google.com/go/storage"
+	"github.com/leg100/otf/internal"
+)
+
+// gcsBackend stores blobs in a Google Cloud Storage bucket. Credentials are
+// taken from the environment, i.e. application default credentials.
+type gcsBackend struct {
+	bucket *storage.BucketHandle
+	prefix string
+}
+
+func newGCSBackend(ctx context.Context, cfg Config) (*gcsBackend, error) {
+	client, err := storage.NewClient(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("constructing gcs client: %w", err)
+	}
+	return &gcsBackend{
+		bucket: client.Bucket(cfg.Bucket),
+		prefix: cfg.Prefix,
+	}, nil
+}
+
+func (b *gcsBackend) Put(ctx context.Context, digest string, r io.Reader) error {
+	w := b.bucket.Object(objectName(b.prefix, digest)).NewWriter(ctx)
+	if _, err := io.Copy(w, r); err != nil {
+		w.Close()
+		return fmt.Errorf("uploading blob to gcs: %w", err)
+	}
+	if err := w.Close(); err != nil {
+		return fmt.Errorf("uploading blob to gcs: %w", err)
+	}
+	return nil
+}
+
+func (b *gcsBackend) Get(ctx context.Context, digest string) (io.ReadCloser, error) {
+	r, err := b.bucket.Object(objectName(b.prefix, digest)).NewReader(ctx)
+	if err != nil {
+		if errors.Is(err, storage.ErrObjectNotExist) {
+			return nil, internal.ErrResourceNotFound
+		}
+		return nil, fmt.Errorf("retrieving blob from gcs: %w", err)
+	}
+	return r, nil
+}
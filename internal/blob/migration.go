@@ -0,0 +1,192 @@
+package blob
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/leg100/otf/internal"
+	"github.com/leg100/otf/internal/resource"
+)
+
+const (
+	MigrationRunning   MigrationStatus = "running"
+	MigrationPaused    MigrationStatus = "paused"
+	MigrationCompleted MigrationStatus = "completed"
+	MigrationCanceled  MigrationStatus = "canceled"
+
+	// DefaultMigrationBatchSize is the number of blobs copied in each batch
+	// unless specified otherwise.
+	DefaultMigrationBatchSize = 100
+	// MaxMigrationBatchSize is the maximum number of blobs copied in each
+	// batch.
+	MaxMigrationBatchSize = 1000
+)
+
+var (
+	// ErrMigrationDestinationPostgres is returned when starting a migration
+	// without a backend other than postgres to which blobs can be copied.
+	ErrMigrationDestinationPostgres = errors.New("blobs are stored in postgres: configure another blob backend to migrate them to")
+	// ErrMigrationInProgress is returned when starting a migration while
+	// another is running or paused.
+	ErrMigrationInProgress = errors.New("a blob migration is already in progress")
+	// ErrMigrationFinished is returned when updating a migration that has
+	// completed or been canceled.
+	ErrMigrationFinished = errors.New("blob migration has finished")
+
+	ErrInvalidMigrationBatchSize = fmt.Errorf("batch size must be between 1 and %d", MaxMigrationBatchSize)
+	ErrInvalidMigrationRateLimit = errors.New("rate limit cannot be negative")
+	ErrInvalidMigrationStatus    = errors.New("status must be one of: running, paused, canceled")
+)
+
+type (
+	// Migration copies blobs from postgres to another backend, whilst otfd
+	// continues to serve blobs. Blobs are copied in batches, in order of
+	// their digest, and the digest of the last blob copied is recorded, so
+	// that a migration resumes where it left off.
+	Migration struct {
+		ID          string          `json:"id"`
+		CreatedAt   time.Time       `json:"created_at"`
+		UpdatedAt   time.Time       `json:"updated_at"`
+		Source      string          `json:"source"`
+		Destination string          `json:"destination"`
+		Status      MigrationStatus `json:"status"`
+		// BatchSize is the number of blobs copied in each batch.
+		BatchSize int `json:"batch_size"`
+		// RateLimit is the maximum number of bytes copied per second. Zero
+		// means no limit.
+		RateLimit int64 `json:"rate_limit"`
+		// Total is the number of blobs in the source when the migration
+		// started.
+		Total int `json:"total"`
+		// Copied is the number of blobs copied and verified.
+		Copied      int   `json:"copied"`
+		CopiedBytes int64 `json:"copied_bytes"`
+		// Failed is the number of blobs that failed verification, either
+		// because their content in the source or in the destination does not
+		// match their digest.
+		Failed int `json:"failed"`
+		// LastDigest is the digest of the last blob processed.
+		LastDigest string `json:"last_digest,omitempty"`
+		// Error is the most recent error encountered.
+		Error       string     `json:"error,omitempty"`
+		CompletedAt *time.Time `json:"completed_at,omitempty"`
+	}
+
+	MigrationStatus string
+
+	StartMigrationOptions struct {
+		BatchSize *int   `json:"batch_size,omitempty"`
+		RateLimit *int64 `json:"rate_limit,omitempty"`
+	}
+
+	// UpdateMigrationOptions throttle, pause, resume or cancel a migration.
+	UpdateMigrationOptions struct {
+		BatchSize *int             `json:"batch_size,omitempty"`
+		RateLimit *int64           `json:"rate_limit,omitempty"`
+		Status    *MigrationStatus `json:"status,omitempty"`
+	}
+
+	// batchResult is the outcome of copying a batch of blobs.
+	batchResult struct {
+		copied     int
+		bytes      int64
+		failed     int
+		lastDigest string
+		// err is the most recent verification failure
+		err error
+	}
+)
+
+func newMigration(destination string, total int, opts StartMigrationOptions) (*Migration, error) {
+	now := internal.CurrentTimestamp(nil)
+	m := &Migration{
+		ID:          resource.NewID(resource.BlobMigrationKind),
+		CreatedAt:   now,
+		UpdatedAt:   now,
+		Source:      PostgresBackend,
+		Destination: destination,
+		Status:      MigrationRunning,
+		BatchSize:   DefaultMigrationBatchSize,
+		Total:       total,
+	}
+	if err := m.throttle(opts.BatchSize, opts.RateLimit); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (m *Migration) LogValue() slog.Value {
+	attrs := []slog.Attr{
+		slog.String("id", m.ID),
+		slog.String("destination", m.Destination),
+		slog.String("status", string(m.Status)),
+		slog.Int("copied", m.Copied),
+		slog.Int("failed", m.Failed),
+		slog.Int("total", m.Total),
+	}
+	return slog.GroupValue(attrs...)
+}
+
+// Finished determines whether the migration has completed or been canceled.
+func (m *Migration) Finished() bool {
+	return m.Status == MigrationCompleted || m.Status == MigrationCanceled
+}
+
+func (m *Migration) update(opts UpdateMigrationOptions) error {
+	if m.Finished() {
+		return ErrMigrationFinished
+	}
+	if err := m.throttle(opts.BatchSize, opts.RateLimit); err != nil {
+		return err
+	}
+	if opts.Status != nil {
+		switch *opts.Status {
+		case MigrationRunning, MigrationPaused, MigrationCanceled:
+			m.Status = *opts.Status
+		default:
+			return &internal.InvalidParameterError{Parameter: "status", Err: ErrInvalidMigrationStatus}
+		}
+		if m.Status == MigrationCanceled {
+			m.CompletedAt = internal.Time(internal.CurrentTimestamp(nil))
+		}
+	}
+	m.UpdatedAt = internal.CurrentTimestamp(nil)
+	return nil
+}
+
+func (m *Migration) throttle(batchSize *int, rateLimit *int64) error {
+	if batchSize != nil {
+		if *batchSize < 1 || *batchSize > MaxMigrationBatchSize {
+			return &internal.InvalidParameterError{Parameter: "batch_size", Err: ErrInvalidMigrationBatchSize}
+		}
+		m.BatchSize = *batchSize
+	}
+	if rateLimit != nil {
+		if *rateLimit < 0 {
+			return &internal.InvalidParameterError{Parameter: "rate_limit", Err: ErrInvalidMigrationRateLimit}
+		}
+		m.RateLimit = *rateLimit
+	}
+	return nil
+}
+
+// record records the outcome of a batch. If the batch was the last then a
+// running migration is completed.
+func (m *Migration) record(result batchResult, last bool) {
+	m.Copied += result.copied
+	m.CopiedBytes += result.bytes
+	m.Failed += result.failed
+	if result.lastDigest != "" {
+		m.LastDigest = result.lastDigest
+	}
+	if result.err != nil {
+		m.Error = result.err.Error()
+	}
+	m.UpdatedAt = internal.CurrentTimestamp(nil)
+	if last && m.Status == MigrationRunning {
+		m.Status = MigrationCompleted
+		m.CompletedAt = internal.Time(m.UpdatedAt)
+	}
+}
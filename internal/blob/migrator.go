@@ -0,0 +1,213 @@
+package blob
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/go-logr/logr"
+	"golang.org/x/time/rate"
+)
+
+// MigratorLockID guarantees only one migrator on a cluster is running at any
+// time.
+const MigratorLockID int64 = 5577006791947779426
+
+var defaultMigratorInterval = 10 * time.Second
+
+// Migrator copies blobs from postgres to the configured backend for each
+// running migration, verifying each copy against its digest.
+//
+// Only one migrator should be running on an OTF cluster at any one time.
+type Migrator struct {
+	logr.Logger
+
+	db          migrationStore
+	source      Backend
+	destination Backend
+	// frequency with which the migrator checks for running migrations.
+	interval time.Duration
+	// limiter limits the rate at which bytes are copied.
+	limiter *rate.Limiter
+}
+
+// NewMigrator constructs a migrator of blobs.
+func (s *Service) NewMigrator(logger logr.Logger) *Migrator {
+	return &Migrator{
+		Logger:      logger.WithValues("component", "blob-migrator"),
+		db:          s.migrations,
+		source:      s.postgres,
+		destination: s.backend,
+		interval:    defaultMigratorInterval,
+		limiter:     rate.NewLimiter(rate.Inf, 0),
+	}
+}
+
+func (m *Migrator) String() string { return "blob-migrator" }
+
+// Start the migrator. Every interval running migrations are resumed.
+//
+// Should be invoked in a go routine.
+func (m *Migrator) Start(ctx context.Context) error {
+	ticker := time.NewTicker(m.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			if err := m.migrate(ctx); err != nil {
+				return err
+			}
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
+
+func (m *Migrator) migrate(ctx context.Context) error {
+	migrations, err := m.db.listMigrationsByStatus(ctx, MigrationRunning)
+	if err != nil {
+		return err
+	}
+	for _, mig := range migrations {
+		// record the error and retry the migration on the next interval.
+		if err := m.run(ctx, mig); err != nil {
+			m.Error(err, "migrating blobs", "migration", mig)
+			_, updateErr := m.db.updateMigration(ctx, mig.ID, func(mig *Migration) error {
+				mig.record(batchResult{err: err}, false)
+				return nil
+			})
+			if updateErr != nil {
+				m.Error(updateErr, "recording blob migration error", "migration", mig)
+			}
+		}
+	}
+	return nil
+}
+
+// run copies blobs in batches until the migration completes or is no longer
+// running. The migration is re-read after each batch, so that throttling,
+// pausing or canceling the migration takes effect from the next batch.
+func (m *Migrator) run(ctx context.Context, mig *Migration) error {
+	for mig.Status == MigrationRunning {
+		blobs, err := m.db.listBlobs(ctx, mig.LastDigest, mig.BatchSize)
+		if err != nil {
+			return err
+		}
+		result, err := m.copyBatch(ctx, mig.RateLimit, blobs)
+		// record progress even if the batch was interrupted, so that the
+		// migration resumes after the last blob processed.
+		last := err == nil && len(blobs) < mig.BatchSize
+		mig, err = m.recordBatch(ctx, mig.ID, result, last, err)
+		if err != nil {
+			return err
+		}
+		m.V(1).Info("copied batch of blobs", "migration", mig, "count", result.copied, "bytes", result.bytes)
+	}
+	if mig.Status == MigrationCompleted {
+		m.V(0).Info("completed blob migration", "migration", mig)
+	}
+	return nil
+}
+
+func (m *Migrator) recordBatch(ctx context.Context, id string, result batchResult, last bool, batchErr error) (*Migration, error) {
+	mig, err := m.db.updateMigration(ctx, id, func(mig *Migration) error {
+		mig.record(result, last)
+		return nil
+	})
+	if batchErr != nil {
+		return nil, batchErr
+	}
+	return mig, err
+}
+
+// copyBatch copies a batch of blobs, limiting the rate at which bytes are
+// copied. A blob that fails verification is counted and skipped; any other
+// error interrupts the batch.
+func (m *Migrator) copyBatch(ctx context.Context, rateLimit int64, blobs []blobInfo) (batchResult, error) {
+	m.setRate(rateLimit)
+
+	var result batchResult
+	for _, blob := range blobs {
+		if err := m.wait(ctx, blob.size); err != nil {
+			return result, err
+		}
+		n, err := m.copy(ctx, blob.digest)
+		switch {
+		case err == nil:
+			result.copied++
+			result.bytes += int64(n)
+		case errors.Is(err, ErrDigestMismatch):
+			m.Error(err, "verifying blob copy", "digest", blob.digest)
+			result.failed++
+			result.err = fmt.Errorf("blob %s: %w", blob.digest, err)
+		default:
+			return result, fmt.Errorf("copying blob %s: %w", blob.digest, err)
+		}
+		result.lastDigest = blob.digest
+	}
+	return result, nil
+}
+
+// copy copies a blob from the source to the destination, verifying its
+// content matches its digest both before and after it is copied. The number
+// of bytes copied is returned.
+func (m *Migrator) copy(ctx context.Context, digest string) (int, error) {
+	data, err := read(ctx, m.source, digest)
+	if err != nil {
+		return 0, err
+	}
+	if Digest(data) != digest {
+		return 0, fmt.Errorf("%w: in %s", ErrDigestMismatch, PostgresBackend)
+	}
+	if err := m.destination.Put(ctx, digest, bytes.NewReader(data)); err != nil {
+		return 0, err
+	}
+	copied, err := read(ctx, m.destination, digest)
+	if err != nil {
+		return 0, err
+	}
+	if Digest(copied) != digest {
+		return 0, fmt.Errorf("%w: in destination", ErrDigestMismatch)
+	}
+	return len(data), nil
+}
+
+// setRate sets the maximum number of bytes copied per second, permitting a
+// burst of up to one second's worth of bytes. Zero means no limit.
+func (m *Migrator) setRate(bytesPerSecond int64) {
+	if bytesPerSecond == 0 {
+		m.limiter.SetLimit(rate.Inf)
+		return
+	}
+	m.limiter.SetLimit(rate.Limit(bytesPerSecond))
+	m.limiter.SetBurst(int(bytesPerSecond))
+}
+
+// wait blocks until n bytes can be copied. A blob larger than the burst is
+// waited for in chunks.
+func (m *Migrator) wait(ctx context.Context, n int64) error {
+	if m.limiter.Limit() == rate.Inf {
+		return nil
+	}
+	burst := int64(m.limiter.Burst())
+	for n > 0 {
+		chunk := min(n, burst)
+		if err := m.limiter.WaitN(ctx, int(chunk)); err != nil {
+			return err
+		}
+		n -= chunk
+	}
+	return nil
+}
+
+func read(ctx context.Context, backend Backend, digest string) ([]byte, error) {
+	r, err := backend.Get(ctx, digest)
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+	return io.ReadAll(r)
+}
@@ -0,0 +1,461 @@
+package blob
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/gorilla/mux"
+	"github.com/leg100/otf/internal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/time/rate"
+)
+
+type (
+	fakeMigrationStore struct {
+		migrations map[string]*Migration
+		// source is the postgres backend from which blobs are listed
+		source *fakeBackend
+		// onUpdate, if non-nil, is called after a migration is updated
+		onUpdate func(*Migration)
+	}
+
+	// corruptingBackend stores content other than that it is given.
+	corruptingBackend struct {
+		*fakeBackend
+	}
+
+	// failingBackend fails to store any content.
+	failingBackend struct {
+		*fakeBackend
+	}
+)
+
+func (f *fakeMigrationStore) createMigration(ctx context.Context, m *Migration) error {
+	for _, existing := range f.migrations {
+		if !existing.Finished() {
+			return ErrMigrationInProgress
+		}
+	}
+	f.migrations[m.ID] = m
+	return nil
+}
+
+func (f *fakeMigrationStore) getMigration(ctx context.Context, id string) (*Migration, error) {
+	m, ok := f.migrations[id]
+	if !ok {
+		return nil, internal.ErrResourceNotFound
+	}
+	return m, nil
+}
+
+func (f *fakeMigrationStore) listMigrations(ctx context.Context) ([]*Migration, error) {
+	var migrations []*Migration
+	for _, m := range f.migrations {
+		migrations = append(migrations, m)
+	}
+	return migrations, nil
+}
+
+func (f *fakeMigrationStore) listMigrationsByStatus(ctx context.Context, status MigrationStatus) ([]*Migration, error) {
+	var migrations []*Migration
+	for _, m := range f.migrations {
+		if m.Status == status {
+			migrations = append(migrations, m)
+		}
+	}
+	return migrations, nil
+}
+
+func (f *fakeMigrationStore) updateMigration(ctx context.Context, id string, fn func(*Migration) error) (*Migration, error) {
+	m, ok := f.migrations[id]
+	if !ok {
+		return nil, internal.ErrResourceNotFound
+	}
+	updated := *m
+	if err := fn(&updated); err != nil {
+		return nil, err
+	}
+	f.migrations[id] = &updated
+	if f.onUpdate != nil {
+		f.onUpdate(&updated)
+	}
+	return &updated, nil
+}
+
+func (f *fakeMigrationStore) countBlobs(ctx context.Context) (int, error) {
+	return len(f.source.blobs), nil
+}
+
+func (f *fakeMigrationStore) listBlobs(ctx context.Context, after string, limit int) ([]blobInfo, error) {
+	var blobs []blobInfo
+	for digest, data := range f.source.blobs {
+		if digest > after {
+			blobs = append(blobs, blobInfo{digest: digest, size: int64(len(data))})
+		}
+	}
+	sort.Slice(blobs, func(i, j int) bool { return blobs[i].digest < blobs[j].digest })
+	if len(blobs) > limit {
+		blobs = blobs[:limit]
+	}
+	return blobs, nil
+}
+
+func (f *corruptingBackend) Put(ctx context.Context, digest string, r io.Reader) error {
+	return f.fakeBackend.Put(ctx, digest, strings.NewReader("corrupted"))
+}
+
+func (f *failingBackend) Put(ctx context.Context, digest string, r io.Reader) error {
+	return errors.New("bucket unavailable")
+}
+
+// newTestMigrationService constructs a service with n blobs in postgres
+// awaiting migration to the destination.
+func newTestMigrationService(t *testing.T, n int, destination Backend) (*Service, *fakeMigrationStore) {
+	source := &fakeBackend{blobs: make(map[string][]byte)}
+	for i := 0; i < n; i++ {
+		data := bytes.Repeat([]byte{byte(i)}, 10)
+		source.blobs[Digest(data)] = data
+	}
+	db := &fakeMigrationStore{migrations: make(map[string]*Migration), source: source}
+	svc := &Service{
+		Logger:      logr.Discard(),
+		site:        &internal.SiteAuthorizer{Logger: logr.Discard()},
+		migrations:  db,
+		backend:     destination,
+		postgres:    source,
+		backendName: S3Backend,
+	}
+	svc.api = &api{Service: svc}
+	return svc, db
+}
+
+func TestMigrator(t *testing.T) {
+	ctx := internal.AddSubjectToContext(context.Background(), &internal.Superuser{Username: "admin"})
+
+	t.Run("copy blobs in batches", func(t *testing.T) {
+		dst := &fakeBackend{blobs: make(map[string][]byte)}
+		svc, db := newTestMigrationService(t, 25, dst)
+		var batches int
+		db.onUpdate = func(*Migration) { batches++ }
+
+		m, err := svc.StartMigration(ctx, StartMigrationOptions{BatchSize: internal.Int(10)})
+		require.NoError(t, err)
+		assert.Equal(t, 25, m.Total)
+
+		err = svc.NewMigrator(logr.Discard()).migrate(ctx)
+		require.NoError(t, err)
+
+		got, err := svc.GetMigration(ctx, m.ID)
+		require.NoError(t, err)
+		assert.Equal(t, MigrationCompleted, got.Status)
+		assert.Equal(t, 25, got.Copied)
+		assert.Equal(t, int64(250), got.CopiedBytes)
+		assert.Equal(t, 0, got.Failed)
+		assert.NotNil(t, got.CompletedAt)
+		assert.Equal(t, 3, batches)
+		assert.Equal(t, db.source.blobs, dst.blobs)
+	})
+
+	t.Run("fail blob that does not match its digest in source", func(t *testing.T) {
+		dst := &fakeBackend{blobs: make(map[string][]byte)}
+		svc, db := newTestMigrationService(t, 3, dst)
+		corrupted := Digest([]byte("original"))
+		db.source.blobs[corrupted] = []byte("corrupted")
+
+		m, err := svc.StartMigration(ctx, StartMigrationOptions{})
+		require.NoError(t, err)
+
+		err = svc.NewMigrator(logr.Discard()).migrate(ctx)
+		require.NoError(t, err)
+
+		got, err := svc.GetMigration(ctx, m.ID)
+		require.NoError(t, err)
+		assert.Equal(t, MigrationCompleted, got.Status)
+		assert.Equal(t, 3, got.Copied)
+		assert.Equal(t, 1, got.Failed)
+		assert.Contains(t, got.Error, corrupted)
+		assert.NotContains(t, dst.blobs, corrupted)
+	})
+
+	t.Run("fail blob that does not match its digest in destination", func(t *testing.T) {
+		dst := &corruptingBackend{&fakeBackend{blobs: make(map[string][]byte)}}
+		svc, _ := newTestMigrationService(t, 3, dst)
+
+		m, err := svc.StartMigration(ctx, StartMigrationOptions{})
+		require.NoError(t, err)
+
+		err = svc.NewMigrator(logr.Discard()).migrate(ctx)
+		require.NoError(t, err)
+
+		got, err := svc.GetMigration(ctx, m.ID)
+		require.NoError(t, err)
+		assert.Equal(t, 0, got.Copied)
+		assert.Equal(t, 3, got.Failed)
+		assert.Contains(t, got.Error, ErrDigestMismatch.Error())
+	})
+
+	t.Run("resume after destination becomes available", func(t *testing.T) {
+		dst := &failingBackend{&fakeBackend{blobs: make(map[string][]byte)}}
+		svc, db := newTestMigrationService(t, 3, dst)
+
+		m, err := svc.StartMigration(ctx, StartMigrationOptions{})
+		require.NoError(t, err)
+
+		migrator := svc.NewMigrator(logr.Discard())
+		err = migrator.migrate(ctx)
+		require.NoError(t, err)
+
+		got, err := svc.GetMigration(ctx, m.ID)
+		require.NoError(t, err)
+		assert.Equal(t, MigrationRunning, got.Status)
+		assert.Equal(t, 0, got.Copied)
+		assert.Contains(t, got.Error, "bucket unavailable")
+
+		migrator.destination = dst.fakeBackend
+		err = migrator.migrate(ctx)
+		require.NoError(t, err)
+
+		got, err = svc.GetMigration(ctx, m.ID)
+		require.NoError(t, err)
+		assert.Equal(t, MigrationCompleted, got.Status)
+		assert.Equal(t, 3, got.Copied)
+		assert.Equal(t, db.source.blobs, dst.blobs)
+	})
+
+	t.Run("pause between batches", func(t *testing.T) {
+		dst := &fakeBackend{blobs: make(map[string][]byte)}
+		svc, db := newTestMigrationService(t, 25, dst)
+
+		m, err := svc.StartMigration(ctx, StartMigrationOptions{BatchSize: internal.Int(10)})
+		require.NoError(t, err)
+		// pause migration once the first batch is recorded
+		db.onUpdate = func(m *Migration) { m.Status = MigrationPaused }
+
+		err = svc.NewMigrator(logr.Discard()).migrate(ctx)
+		require.NoError(t, err)
+
+		got, err := svc.GetMigration(ctx, m.ID)
+		require.NoError(t, err)
+		assert.Equal(t, MigrationPaused, got.Status)
+		assert.Equal(t, 10, got.Copied)
+		assert.Len(t, dst.blobs, 10)
+
+		// resume migration, which continues where it left off
+		db.onUpdate = nil
+		_, err = svc.UpdateMigration(ctx, m.ID, UpdateMigrationOptions{Status: statusPtr(MigrationRunning)})
+		require.NoError(t, err)
+
+		err = svc.NewMigrator(logr.Discard()).migrate(ctx)
+		require.NoError(t, err)
+
+		got, err = svc.GetMigration(ctx, m.ID)
+		require.NoError(t, err)
+		assert.Equal(t, MigrationCompleted, got.Status)
+		assert.Equal(t, 25, got.Copied)
+		assert.Equal(t, db.source.blobs, dst.blobs)
+	})
+
+	t.Run("limit rate", func(t *testing.T) {
+		dst := &fakeBackend{blobs: make(map[string][]byte)}
+		svc, _ := newTestMigrationService(t, 15, dst)
+
+		// 150 bytes at 100 bytes per second, with a burst of 100 bytes,
+		// should take at least half a second.
+		m, err := svc.StartMigration(ctx, StartMigrationOptions{RateLimit: internal.Int64(100)})
+		require.NoError(t, err)
+
+		start := time.Now()
+		err = svc.NewMigrator(logr.Discard()).migrate(ctx)
+		require.NoError(t, err)
+		assert.GreaterOrEqual(t, time.Since(start), 400*time.Millisecond)
+
+		got, err := svc.GetMigration(ctx, m.ID)
+		require.NoError(t, err)
+		assert.Equal(t, MigrationCompleted, got.Status)
+		assert.Equal(t, 15, got.Copied)
+	})
+}
+
+func TestMigrator_wait(t *testing.T) {
+	m := &Migrator{limiter: rate.NewLimiter(rate.Inf, 0)}
+	m.setRate(1000)
+
+	// waiting for more bytes than the burst must not fail
+	err := m.wait(context.Background(), 1500)
+	require.NoError(t, err)
+}
+
+func TestService_StartMigration(t *testing.T) {
+	ctx := internal.AddSubjectToContext(context.Background(), &internal.Superuser{Username: "admin"})
+
+	t.Run("already in progress", func(t *testing.T) {
+		svc, _ := newTestMigrationService(t, 1, &fakeBackend{blobs: make(map[string][]byte)})
+
+		_, err := svc.StartMigration(ctx, StartMigrationOptions{})
+		require.NoError(t, err)
+
+		_, err = svc.StartMigration(ctx, StartMigrationOptions{})
+		assert.Equal(t, ErrMigrationInProgress, err)
+	})
+
+	t.Run("destination is postgres", func(t *testing.T) {
+		svc, _ := newTestMigrationService(t, 1, nil)
+		svc.backend, svc.postgres = svc.postgres, nil
+
+		_, err := svc.StartMigration(ctx, StartMigrationOptions{})
+		assert.Equal(t, ErrMigrationDestinationPostgres, err)
+	})
+
+	t.Run("invalid batch size", func(t *testing.T) {
+		svc, _ := newTestMigrationService(t, 1, &fakeBackend{blobs: make(map[string][]byte)})
+
+		_, err := svc.StartMigration(ctx, StartMigrationOptions{BatchSize: internal.Int(MaxMigrationBatchSize + 1)})
+		assert.ErrorIs(t, err, ErrInvalidMigrationBatchSize)
+	})
+
+	t.Run("unauthorized", func(t *testing.T) {
+		svc, _ := newTestMigrationService(t, 1, &fakeBackend{blobs: make(map[string][]byte)})
+
+		ctx := internal.AddSubjectToContext(context.Background(), &internal.Nobody{Username: "bobby"})
+		_, err := svc.StartMigration(ctx, StartMigrationOptions{})
+		assert.ErrorIs(t, err, internal.ErrAccessNotPermitted)
+	})
+}
+
+func TestMigration_update(t *testing.T) {
+	tests := []struct {
+		name    string
+		status  MigrationStatus
+		opts    UpdateMigrationOptions
+		want    func(t *testing.T, m *Migration)
+		wantErr error
+	}{
+		{
+			name:   "throttle",
+			status: MigrationRunning,
+			opts:   UpdateMigrationOptions{BatchSize: internal.Int(5), RateLimit: internal.Int64(1024)},
+			want: func(t *testing.T, m *Migration) {
+				assert.Equal(t, 5, m.BatchSize)
+				assert.Equal(t, int64(1024), m.RateLimit)
+			},
+		},
+		{
+			name:   "cancel",
+			status: MigrationPaused,
+			opts:   UpdateMigrationOptions{Status: statusPtr(MigrationCanceled)},
+			want: func(t *testing.T, m *Migration) {
+				assert.Equal(t, MigrationCanceled, m.Status)
+				assert.NotNil(t, m.CompletedAt)
+			},
+		},
+		{
+			name:    "negative rate limit",
+			status:  MigrationRunning,
+			opts:    UpdateMigrationOptions{RateLimit: internal.Int64(-1)},
+			wantErr: ErrInvalidMigrationRateLimit,
+		},
+		{
+			name:    "complete",
+			status:  MigrationRunning,
+			opts:    UpdateMigrationOptions{Status: statusPtr(MigrationCompleted)},
+			wantErr: ErrInvalidMigrationStatus,
+		},
+		{
+			name:    "finished",
+			status:  MigrationCompleted,
+			opts:    UpdateMigrationOptions{Status: statusPtr(MigrationRunning)},
+			wantErr: ErrMigrationFinished,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := &Migration{Status: tt.status, BatchSize: DefaultMigrationBatchSize}
+			err := m.update(tt.opts)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			tt.want(t, m)
+		})
+	}
+}
+
+func TestMigrationAPI(t *testing.T) {
+	svc, _ := newTestMigrationService(t, 3, &fakeBackend{blobs: make(map[string][]byte)})
+	router := mux.NewRouter()
+	svc.AddHandlers(router)
+
+	do := func(method, path, body string) *httptest.ResponseRecorder {
+		r := httptest.NewRequest(method, path, strings.NewReader(body))
+		r = r.WithContext(internal.AddSubjectToContext(r.Context(), &internal.Superuser{Username: "admin"}))
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, r)
+		return w
+	}
+
+	// start
+	w := do("POST", "/otfapi/admin/blob-migrations", `{"batch_size":2}`)
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+	var started Migration
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&started))
+	assert.Equal(t, MigrationRunning, started.Status)
+	assert.Equal(t, 2, started.BatchSize)
+	assert.Equal(t, 3, started.Total)
+
+	// cannot start another
+	w = do("POST", "/otfapi/admin/blob-migrations", "")
+	assert.Equal(t, http.StatusConflict, w.Code)
+
+	// throttle
+	w = do("PATCH", "/otfapi/admin/blob-migrations/"+started.ID, `{"rate_limit":1024,"status":"paused"}`)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+	// status
+	w = do("GET", "/otfapi/admin/blob-migrations/"+started.ID, "")
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	var got Migration
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&got))
+	assert.Equal(t, MigrationPaused, got.Status)
+	assert.Equal(t, int64(1024), got.RateLimit)
+
+	w = do("GET", "/otfapi/admin/blob-migrations", "")
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	var list []*Migration
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&list))
+	assert.Len(t, list, 1)
+
+	// invalid throttle
+	w = do("PATCH", "/otfapi/admin/blob-migrations/"+started.ID, `{"batch_size":0}`)
+	assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+}
+
+func TestService_GetFallsBackToPostgres(t *testing.T) {
+	ctx := context.Background()
+	svc, db := newTestMigrationService(t, 1, &fakeBackend{blobs: make(map[string][]byte)})
+
+	// blob not yet migrated is retrieved from postgres
+	for digest, want := range db.source.blobs {
+		blob, err := svc.Get(ctx, digest)
+		require.NoError(t, err)
+		got, err := io.ReadAll(blob)
+		require.NoError(t, err)
+		assert.Equal(t, want, got)
+	}
+
+	_, err := svc.Get(ctx, Digest([]byte("nonexistent")))
+	assert.Equal(t, internal.ErrResourceNotFound, err)
+}
+
+func statusPtr(s MigrationStatus) *MigrationStatus { return &s }
@@ -0,0 +1,77 @@
+package blob
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/feature/s3/manager"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+	"github.com/leg100/otf/internal"
+)
+
+// s3Backend stores blobs in an AWS S3 bucket, or in a bucket of an
+// S3-compatible service. Credentials are taken from the environment.
+type s3Backend struct {
+	client   *s3.Client
+	uploader *manager.Uploader
+	bucket   string
+	prefix   string
+}
+
+func newS3Backend(ctx context.Context, cfg Config) (*s3Backend, error) {
+	var opts []func(*config.LoadOptions) error
+	if cfg.S3Region != "" {
+		opts = append(opts, config.WithRegion(cfg.S3Region))
+	}
+	awsConfig, err := config.LoadDefaultConfig(ctx, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("loading aws config: %w", err)
+	}
+	client := s3.NewFromConfig(awsConfig, func(o *s3.Options) {
+		if cfg.S3Endpoint != "" {
+			o.BaseEndpoint = aws.String(cfg.S3Endpoint)
+		}
+		o.UsePathStyle = cfg.S3UsePathStyle
+	})
+	return &s3Backend{
+		client:   client,
+		uploader: manager.NewUploader(client),
+		bucket:   cfg.Bucket,
+		prefix:   cfg.Prefix,
+	}, nil
+}
+
+func (b *s3Backend) Put(ctx context.Context, digest string, r io.Reader) error {
+	// the uploader buffers content in parts, and uploads larger blobs in
+	// multiple parts, because the client requires the length of the content
+	// up front and to be able to re-read it should a request be retried.
+	_, err := b.uploader.Upload(ctx, &s3.PutObjectInput{
+		Bucket: aws.String(b.bucket),
+		Key:    aws.String(objectName(b.prefix, digest)),
+		Body:   r,
+	})
+	if err != nil {
+		return fmt.Errorf("uploading blob to s3: %w", err)
+	}
+	return nil
+}
+
+func (b *s3Backend) Get(ctx context.Context, digest string) (io.ReadCloser, error) {
+	out, err := b.client.GetObject(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(b.bucket),
+		Key:    aws.String(objectName(b.prefix, digest)),
+	})
+	if err != nil {
+		var notFound *types.NoSuchKey
+		if errors.As(err, &notFound) {
+			return nil, internal.ErrResourceNotFound
+		}
+		return nil, fmt.Errorf("retrieving blob from s3: %w", err)
+	}
+	return out.Body, nil
+}
@@ -0,0 +1,402 @@
+package blob
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/leg100/otf/internal"
+	"github.com/leg100/otf/internal/logr"
+	"github.com/leg100/otf/internal/rbac"
+	"github.com/leg100/otf/internal/sql"
+	"github.com/leg100/surl"
+)
+
+type (
+	// Service stores and retrieves blobs. Blobs are addressed by their digest
+	// and are immutable: storing the same content twice stores it only once.
+	//
+	// NOTE: the service performs no authorization of access to blobs. It is
+	// up to callers to authorize access to the artifacts they keep in the
+	// store, and access via HTTP is granted only via signed URLs. Only
+	// migrations are restricted, to site admins.
+	Service struct {
+		logr.Logger
+		*surl.Signer
+
+		site internal.Authorizer
+
+		db         store
+		migrations migrationStore
+		backend    Backend
+		// postgres is the source of blobs to be migrated to the backend. Nil
+		// if the backend is postgres.
+		postgres    Backend
+		backendName string
+		api         *api
+		maxSize     int64
+	}
+
+	Options struct {
+		logr.Logger
+		*sql.DB
+		*surl.Signer
+
+		// MaxSize is the maximum size in bytes of a blob uploaded via a
+		// signed URL.
+		MaxSize int64
+		// Backend stores the content of blobs. If nil then content is stored
+		// in postgres.
+		Backend Backend
+		// BackendName is the name of the backend, e.g. s3, recorded as the
+		// destination of migrations.
+		BackendName string
+	}
+
+	// store persists uploads in progress.
+	store interface {
+		createUpload(ctx context.Context, uploadID string) error
+		appendUploadPart(ctx context.Context, uploadID string, offset int64, data []byte) (int64, error)
+		getUploadSize(ctx context.Context, uploadID string) (int64, error)
+		getUploadPart(ctx context.Context, uploadID string, offset int64) ([]byte, error)
+		deleteUpload(ctx context.Context, uploadID string) error
+	}
+
+	// migrationStore persists migrations and lists the blobs to be migrated.
+	migrationStore interface {
+		createMigration(ctx context.Context, m *Migration) error
+		getMigration(ctx context.Context, id string) (*Migration, error)
+		listMigrations(ctx context.Context) ([]*Migration, error)
+		listMigrationsByStatus(ctx context.Context, status MigrationStatus) ([]*Migration, error)
+		updateMigration(ctx context.Context, id string, fn func(*Migration) error) (*Migration, error)
+		countBlobs(ctx context.Context) (int, error)
+		listBlobs(ctx context.Context, after string, limit int) ([]blobInfo, error)
+	}
+)
+
+// uploadPartSize is the maximum size of each part in which an upload is
+// stored, bounding the memory used to write or read an upload.
+var uploadPartSize = 1024 * 1024
+
+func NewService(opts Options) *Service {
+	db := &pgdb{opts.DB}
+	svc := Service{
+		Logger:      opts.Logger,
+		Signer:      opts.Signer,
+		site:        &internal.SiteAuthorizer{Logger: opts.Logger},
+		db:          db,
+		migrations:  db,
+		backend:     opts.Backend,
+		backendName: opts.BackendName,
+		maxSize:     opts.MaxSize,
+	}
+	if svc.backend == nil {
+		svc.backend = db
+	} else {
+		svc.postgres = db
+	}
+	svc.api = &api{Service: &svc}
+	return &svc
+}
+
+func (s *Service) AddHandlers(r *mux.Router) {
+	s.api.addHandlers(r)
+}
+
+// Put reads content from r until EOF and stores it as a blob, returning its
+// digest.
+func (s *Service) Put(ctx context.Context, r io.Reader) (string, error) {
+	return s.put(ctx, "", r)
+}
+
+// Get retrieves the content of the blob with the given digest. The caller is
+// responsible for closing the returned reader.
+func (s *Service) Get(ctx context.Context, digest string) (io.ReadCloser, error) {
+	if err := ValidDigest(digest); err != nil {
+		return nil, err
+	}
+	blob, err := s.backend.Get(ctx, digest)
+	if errors.Is(err, internal.ErrResourceNotFound) && s.postgres != nil {
+		// the blob may yet to have been migrated from postgres
+		blob, err = s.postgres.Get(ctx, digest)
+	}
+	if err != nil {
+		s.Error(err, "retrieving blob", "digest", digest)
+		return nil, err
+	}
+	s.V(9).Info("retrieved blob", "digest", digest)
+	return blob, nil
+}
+
+// SignedGetURL returns a signed URL from which the blob with the given digest
+// can be downloaded until the URL expires.
+func (s *Service) SignedGetURL(digest string, lifetime time.Duration) (string, error) {
+	if err := ValidDigest(digest); err != nil {
+		return "", err
+	}
+	return s.Sign(fmt.Sprintf("/blobs/%s", digest), lifetime)
+}
+
+// SignedPutURL returns a signed URL to which a blob with the given digest can
+// be uploaded until the URL expires. Only content matching the digest is
+// accepted.
+func (s *Service) SignedPutURL(digest string, lifetime time.Duration) (string, error) {
+	if err := ValidDigest(digest); err != nil {
+		return "", err
+	}
+	return s.Sign(fmt.Sprintf("/blobs/%s/upload", digest), lifetime)
+}
+
+// put stores content read from r. If want is non-empty then the content must
+// match the digest.
+func (s *Service) put(ctx context.Context, want string, r io.Reader) (string, error) {
+	h := sha256.New()
+	data, err := io.ReadAll(io.TeeReader(r, h))
+	if err != nil {
+		return "", err
+	}
+	digest := hex.EncodeToString(h.Sum(nil))
+	if want != "" && want != digest {
+		return "", ErrDigestMismatch
+	}
+	if err := s.backend.Put(ctx, digest, bytes.NewReader(data)); err != nil {
+		s.Error(err, "storing blob", "digest", digest)
+		return "", err
+	}
+	s.V(2).Info("stored blob", "digest", digest, "bytes", len(data))
+	return digest, nil
+}
+
+// WriteUpload reads content from r until EOF and appends it to the upload
+// with the given ID, starting at offset, returning the new size of the
+// upload. An offset of zero starts the upload afresh, discarding any content
+// previously written; any other offset must match the current size of the
+// upload, otherwise ErrUploadOffsetMismatch is returned along with the
+// current size.
+//
+// Content is written in parts, so that if reading from r fails then the
+// content written up until that point is retained and the returned size can
+// be used to resume the upload.
+func (s *Service) WriteUpload(ctx context.Context, uploadID string, offset int64, r io.Reader) (int64, error) {
+	if offset == 0 {
+		if err := s.db.createUpload(ctx, uploadID); err != nil {
+			s.Error(err, "starting upload", "upload_id", uploadID)
+			return 0, err
+		}
+	}
+	size := offset
+	buf := make([]byte, uploadPartSize)
+	for {
+		n, readErr := io.ReadFull(r, buf)
+		if n > 0 {
+			var err error
+			size, err = s.db.appendUploadPart(ctx, uploadID, size, buf[:n])
+			if err != nil {
+				s.Error(err, "writing upload", "upload_id", uploadID, "offset", offset)
+				return size, err
+			}
+		}
+		switch readErr {
+		case nil:
+			continue
+		case io.EOF, io.ErrUnexpectedEOF:
+			s.V(9).Info("wrote upload", "upload_id", uploadID, "offset", offset, "size", size)
+			return size, nil
+		default:
+			return size, readErr
+		}
+	}
+}
+
+// UploadSize returns the number of bytes written to the upload with the given
+// ID.
+func (s *Service) UploadSize(ctx context.Context, uploadID string) (int64, error) {
+	size, err := s.db.getUploadSize(ctx, uploadID)
+	if err != nil {
+		return 0, err
+	}
+	return size, nil
+}
+
+// OpenUpload returns a reader of the content written to the upload with the
+// given ID. Content is retrieved one part at a time.
+func (s *Service) OpenUpload(ctx context.Context, uploadID string) (io.Reader, error) {
+	size, err := s.db.getUploadSize(ctx, uploadID)
+	if err != nil {
+		return nil, err
+	}
+	return &uploadReader{ctx: ctx, db: s.db, uploadID: uploadID, size: size}, nil
+}
+
+// CompleteUpload stores the content of the upload with the given ID as a blob
+// and removes the upload, returning the digest of the blob. If want is
+// non-empty then the content must match the digest.
+func (s *Service) CompleteUpload(ctx context.Context, uploadID, want string) (string, error) {
+	r, err := s.OpenUpload(ctx, uploadID)
+	if err != nil {
+		return "", err
+	}
+	h := sha256.New()
+	size, err := io.Copy(h, r)
+	if err != nil {
+		return "", err
+	}
+	digest := hex.EncodeToString(h.Sum(nil))
+	if want != "" && want != digest {
+		return "", ErrDigestMismatch
+	}
+	if err := s.storeUpload(ctx, uploadID, digest); err != nil {
+		s.Error(err, "completing upload", "upload_id", uploadID, "digest", digest)
+		return "", err
+	}
+	s.V(2).Info("stored blob", "digest", digest, "bytes", size, "upload_id", uploadID)
+	return digest, nil
+}
+
+// storeUpload stores the content of an upload as the blob with the given
+// digest and removes the upload.
+func (s *Service) storeUpload(ctx context.Context, uploadID, digest string) error {
+	if assembler, ok := s.backend.(uploadAssembler); ok {
+		return assembler.assembleUpload(ctx, uploadID, digest)
+	}
+	r, err := s.OpenUpload(ctx, uploadID)
+	if err != nil {
+		return err
+	}
+	if err := s.backend.Put(ctx, digest, r); err != nil {
+		return err
+	}
+	return s.db.deleteUpload(ctx, uploadID)
+}
+
+// AbortUpload discards the upload with the given ID.
+func (s *Service) AbortUpload(ctx context.Context, uploadID string) error {
+	if err := s.db.deleteUpload(ctx, uploadID); err != nil {
+		s.Error(err, "aborting upload", "upload_id", uploadID)
+		return err
+	}
+	s.V(2).Info("aborted upload", "upload_id", uploadID)
+	return nil
+}
+
+// Migratable determines whether blobs can be migrated from postgres to
+// another backend.
+func (s *Service) Migratable() bool {
+	return s.postgres != nil
+}
+
+// StartMigration starts migrating blobs from postgres to the configured
+// backend. Only one migration may be in progress at a time. Only a site admin
+// can start a migration.
+func (s *Service) StartMigration(ctx context.Context, opts StartMigrationOptions) (*Migration, error) {
+	subject, err := s.site.CanAccess(ctx, rbac.StartBlobMigrationAction, "")
+	if err != nil {
+		return nil, err
+	}
+	if !s.Migratable() {
+		return nil, ErrMigrationDestinationPostgres
+	}
+	total, err := s.migrations.countBlobs(ctx)
+	if err != nil {
+		s.Error(err, "counting blobs to migrate", "subject", subject)
+		return nil, err
+	}
+	m, err := newMigration(s.backendName, total, opts)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.migrations.createMigration(ctx, m); err != nil {
+		s.Error(err, "starting blob migration", "subject", subject)
+		return nil, err
+	}
+	s.V(0).Info("started blob migration", "migration", m, "subject", subject)
+	return m, nil
+}
+
+// ListMigrations lists migrations, most recent first. Only a site admin can
+// list migrations.
+func (s *Service) ListMigrations(ctx context.Context) ([]*Migration, error) {
+	subject, err := s.site.CanAccess(ctx, rbac.ListBlobMigrationsAction, "")
+	if err != nil {
+		return nil, err
+	}
+	migrations, err := s.migrations.listMigrations(ctx)
+	if err != nil {
+		s.Error(err, "listing blob migrations", "subject", subject)
+		return nil, err
+	}
+	s.V(9).Info("listed blob migrations", "count", len(migrations), "subject", subject)
+	return migrations, nil
+}
+
+// GetMigration retrieves a migration, reporting its progress. Only a site
+// admin can retrieve a migration.
+func (s *Service) GetMigration(ctx context.Context, id string) (*Migration, error) {
+	subject, err := s.site.CanAccess(ctx, rbac.GetBlobMigrationAction, "")
+	if err != nil {
+		return nil, err
+	}
+	m, err := s.migrations.getMigration(ctx, id)
+	if err != nil {
+		s.Error(err, "retrieving blob migration", "id", id, "subject", subject)
+		return nil, err
+	}
+	s.V(9).Info("retrieved blob migration", "migration", m, "subject", subject)
+	return m, nil
+}
+
+// UpdateMigration throttles, pauses, resumes or cancels a migration. Changes
+// take effect from the next batch of blobs. Only a site admin can update a
+// migration.
+func (s *Service) UpdateMigration(ctx context.Context, id string, opts UpdateMigrationOptions) (*Migration, error) {
+	subject, err := s.site.CanAccess(ctx, rbac.UpdateBlobMigrationAction, "")
+	if err != nil {
+		return nil, err
+	}
+	m, err := s.migrations.updateMigration(ctx, id, func(m *Migration) error {
+		return m.update(opts)
+	})
+	if err != nil {
+		s.Error(err, "updating blob migration", "id", id, "subject", subject)
+		return nil, err
+	}
+	s.V(0).Info("updated blob migration", "migration", m, "subject", subject)
+	return m, nil
+}
+
+// uploadReader reads the parts of an upload in order.
+type uploadReader struct {
+	ctx      context.Context
+	db       store
+	uploadID string
+	size     int64
+
+	offset int64
+	part   []byte
+}
+
+func (r *uploadReader) Read(p []byte) (int, error) {
+	if len(r.part) == 0 {
+		if r.offset >= r.size {
+			return 0, io.EOF
+		}
+		part, err := r.db.getUploadPart(r.ctx, r.uploadID, r.offset)
+		if err != nil {
+			return 0, err
+		}
+		if len(part) == 0 {
+			return 0, io.ErrUnexpectedEOF
+		}
+		r.part = part
+		r.offset += int64(len(part))
+	}
+	n := copy(p, r.part)
+	r.part = r.part[n:]
+	return n, nil
+}
@@ -0,0 +1,255 @@
+package blob
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/gorilla/mux"
+	"github.com/leg100/otf/internal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeStore struct {
+	uploads map[string]*fakeUpload
+}
+
+type fakeBackend struct {
+	blobs map[string][]byte
+}
+
+type fakeUpload struct {
+	parts map[int64][]byte
+	size  int64
+}
+
+func (f *fakeBackend) Put(ctx context.Context, digest string, r io.Reader) error {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return err
+	}
+	f.blobs[digest] = data
+	return nil
+}
+
+func (f *fakeBackend) Get(ctx context.Context, digest string) (io.ReadCloser, error) {
+	data, ok := f.blobs[digest]
+	if !ok {
+		return nil, internal.ErrResourceNotFound
+	}
+	return io.NopCloser(bytes.NewReader(data)), nil
+}
+
+func (f *fakeStore) createUpload(ctx context.Context, uploadID string) error {
+	f.uploads[uploadID] = &fakeUpload{parts: make(map[int64][]byte)}
+	return nil
+}
+
+func (f *fakeStore) appendUploadPart(ctx context.Context, uploadID string, offset int64, data []byte) (int64, error) {
+	upload, ok := f.uploads[uploadID]
+	if !ok {
+		return 0, internal.ErrResourceNotFound
+	}
+	if offset != upload.size {
+		return upload.size, ErrUploadOffsetMismatch
+	}
+	upload.parts[offset] = append([]byte(nil), data...)
+	upload.size += int64(len(data))
+	return upload.size, nil
+}
+
+func (f *fakeStore) getUploadSize(ctx context.Context, uploadID string) (int64, error) {
+	upload, ok := f.uploads[uploadID]
+	if !ok {
+		return 0, internal.ErrResourceNotFound
+	}
+	return upload.size, nil
+}
+
+func (f *fakeStore) getUploadPart(ctx context.Context, uploadID string, offset int64) ([]byte, error) {
+	upload, ok := f.uploads[uploadID]
+	if !ok {
+		return nil, internal.ErrResourceNotFound
+	}
+	part, ok := upload.parts[offset]
+	if !ok {
+		return nil, internal.ErrResourceNotFound
+	}
+	return part, nil
+}
+
+func (f *fakeStore) deleteUpload(ctx context.Context, uploadID string) error {
+	delete(f.uploads, uploadID)
+	return nil
+}
+
+func newTestService(t *testing.T, maxSize int64) *Service {
+	svc := &Service{
+		Logger:  logr.Discard(),
+		Signer:  internal.NewSigner([]byte("abcdef123")),
+		db:      &fakeStore{uploads: make(map[string]*fakeUpload)},
+		backend: &fakeBackend{blobs: make(map[string][]byte)},
+		maxSize: maxSize,
+	}
+	svc.api = &api{Service: svc}
+	return svc
+}
+
+func TestService(t *testing.T) {
+	ctx := context.Background()
+	svc := newTestService(t, 0)
+
+	digest, err := svc.Put(ctx, bytes.NewBufferString("hello world"))
+	require.NoError(t, err)
+	assert.Equal(t, Digest([]byte("hello world")), digest)
+
+	blob, err := svc.Get(ctx, digest)
+	require.NoError(t, err)
+	got, err := io.ReadAll(blob)
+	require.NoError(t, err)
+	assert.Equal(t, "hello world", string(got))
+
+	_, err = svc.Get(ctx, "not-a-digest")
+	assert.Equal(t, ErrInvalidDigest, err)
+}
+
+func TestService_Upload(t *testing.T) {
+	ctx := context.Background()
+	svc := newTestService(t, 0)
+
+	// use small parts so that an upload spans several parts
+	defer func(size int) { uploadPartSize = size }(uploadPartSize)
+	uploadPartSize = 4
+
+	t.Run("resume upload", func(t *testing.T) {
+		size, err := svc.WriteUpload(ctx, "upload-1", 0, bytes.NewBufferString("hello "))
+		require.NoError(t, err)
+		assert.Equal(t, int64(6), size)
+
+		size, err = svc.WriteUpload(ctx, "upload-1", 6, bytes.NewBufferString("world"))
+		require.NoError(t, err)
+		assert.Equal(t, int64(11), size)
+
+		got, err := svc.UploadSize(ctx, "upload-1")
+		require.NoError(t, err)
+		assert.Equal(t, int64(11), got)
+
+		r, err := svc.OpenUpload(ctx, "upload-1")
+		require.NoError(t, err)
+		content, err := io.ReadAll(r)
+		require.NoError(t, err)
+		assert.Equal(t, "hello world", string(content))
+
+		digest, err := svc.CompleteUpload(ctx, "upload-1", "")
+		require.NoError(t, err)
+		assert.Equal(t, Digest([]byte("hello world")), digest)
+
+		blob, err := svc.Get(ctx, digest)
+		require.NoError(t, err)
+		content, err = io.ReadAll(blob)
+		require.NoError(t, err)
+		assert.Equal(t, "hello world", string(content))
+
+		_, err = svc.UploadSize(ctx, "upload-1")
+		assert.Equal(t, internal.ErrResourceNotFound, err)
+	})
+
+	t.Run("write at wrong offset", func(t *testing.T) {
+		_, err := svc.WriteUpload(ctx, "upload-2", 0, bytes.NewBufferString("hello "))
+		require.NoError(t, err)
+
+		size, err := svc.WriteUpload(ctx, "upload-2", 3, bytes.NewBufferString("world"))
+		assert.Equal(t, ErrUploadOffsetMismatch, err)
+		assert.Equal(t, int64(6), size)
+	})
+
+	t.Run("restart upload", func(t *testing.T) {
+		_, err := svc.WriteUpload(ctx, "upload-3", 0, bytes.NewBufferString("hello "))
+		require.NoError(t, err)
+
+		size, err := svc.WriteUpload(ctx, "upload-3", 0, bytes.NewBufferString("world"))
+		require.NoError(t, err)
+		assert.Equal(t, int64(5), size)
+	})
+
+	t.Run("complete upload not matching digest", func(t *testing.T) {
+		_, err := svc.WriteUpload(ctx, "upload-4", 0, bytes.NewBufferString("hello world"))
+		require.NoError(t, err)
+
+		_, err = svc.CompleteUpload(ctx, "upload-4", Digest([]byte("goodbye world")))
+		assert.Equal(t, ErrDigestMismatch, err)
+	})
+
+	t.Run("abort upload", func(t *testing.T) {
+		_, err := svc.WriteUpload(ctx, "upload-5", 0, bytes.NewBufferString("hello world"))
+		require.NoError(t, err)
+
+		err = svc.AbortUpload(ctx, "upload-5")
+		require.NoError(t, err)
+
+		_, err = svc.UploadSize(ctx, "upload-5")
+		assert.Equal(t, internal.ErrResourceNotFound, err)
+	})
+}
+
+func TestService_API(t *testing.T) {
+	svc := newTestService(t, 16)
+	r := mux.NewRouter()
+	svc.AddHandlers(r)
+
+	content := []byte("hello world")
+	digest := Digest(content)
+
+	upload := func(t *testing.T, digest string, body []byte) *httptest.ResponseRecorder {
+		url, err := svc.SignedPutURL(digest, time.Hour)
+		require.NoError(t, err)
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest("PUT", url, bytes.NewReader(body)))
+		return w
+	}
+
+	t.Run("upload", func(t *testing.T) {
+		w := upload(t, digest, content)
+		assert.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+	})
+
+	t.Run("upload content not matching digest", func(t *testing.T) {
+		w := upload(t, digest, []byte("goodbye world"))
+		assert.Equal(t, http.StatusUnprocessableEntity, w.Code, w.Body.String())
+	})
+
+	t.Run("upload exceeding max size", func(t *testing.T) {
+		big := bytes.Repeat([]byte("a"), 17)
+		w := upload(t, Digest(big), big)
+		assert.Equal(t, http.StatusRequestEntityTooLarge, w.Code, w.Body.String())
+	})
+
+	t.Run("download", func(t *testing.T) {
+		url, err := svc.SignedGetURL(digest, time.Hour)
+		require.NoError(t, err)
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest("GET", url, nil))
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		assert.Equal(t, content, w.Body.Bytes())
+	})
+
+	t.Run("download url cannot be used to upload", func(t *testing.T) {
+		url, err := svc.SignedGetURL(digest, time.Hour)
+		require.NoError(t, err)
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest("PUT", url, bytes.NewReader(content)))
+		assert.NotEqual(t, http.StatusCreated, w.Code)
+	})
+
+	t.Run("unsigned download", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest("GET", "/signed/abc.def/blobs/"+digest, nil))
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+	})
+}
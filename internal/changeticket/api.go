@@ -0,0 +1,57 @@
+package changeticket
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	otfapi "github.com/leg100/otf/internal/api"
+	"github.com/leg100/otf/internal/http/decode"
+	"github.com/leg100/otf/internal/tfeapi"
+)
+
+type api struct {
+	*Service
+}
+
+func (a *api) addHandlers(r *mux.Router) {
+	r = r.PathPrefix(otfapi.DefaultBasePath).Subrouter()
+
+	r.HandleFunc("/runs/{run_id}/change-ticket", a.get).Methods("GET")
+	r.HandleFunc("/change-tickets/{ticket_id}/callback", a.callback).Methods("PATCH")
+}
+
+func (a *api) get(w http.ResponseWriter, r *http.Request) {
+	runID, err := decode.Param("run_id", r)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	ticket, err := a.Get(r.Context(), runID)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(ticket)
+}
+
+func (a *api) callback(w http.ResponseWriter, r *http.Request) {
+	ticketID, err := decode.Param("ticket_id", r)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	var cb Callback
+	if err := json.NewDecoder(r.Body).Decode(&cb); err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	ticket, err := a.Callback(r.Context(), ticketID, cb)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(ticket)
+}
@@ -0,0 +1,99 @@
+package changeticket
+
+import (
+	"context"
+
+	"github.com/jackc/pgtype"
+	"github.com/leg100/otf/internal/sql"
+	"github.com/leg100/otf/internal/sql/pggen"
+)
+
+type (
+	// pgdb is a database of change tickets on postgres
+	pgdb struct {
+		*sql.DB // provides access to generated SQL queries
+	}
+
+	ticketRow struct {
+		ChangeTicketID pgtype.Text        `json:"change_ticket_id"`
+		RunID          pgtype.Text        `json:"run_id"`
+		Status         pgtype.Text        `json:"status"`
+		ExternalID     pgtype.Text        `json:"external_id"`
+		URL            pgtype.Text        `json:"url"`
+		Message        pgtype.Text        `json:"message"`
+		CreatedAt      pgtype.Timestamptz `json:"created_at"`
+		UpdatedAt      pgtype.Timestamptz `json:"updated_at"`
+	}
+)
+
+func (r ticketRow) toTicket() *Ticket {
+	return &Ticket{
+		ID:         r.ChangeTicketID.String,
+		CreatedAt:  r.CreatedAt.Time.UTC(),
+		UpdatedAt:  r.UpdatedAt.Time.UTC(),
+		RunID:      r.RunID.String,
+		Status:     Status(r.Status.String),
+		ExternalID: r.ExternalID.String,
+		URL:        r.URL.String,
+		Message:    r.Message.String,
+	}
+}
+
+// createTicket persists a ticket, returning false if the run already has a
+// ticket.
+func (db *pgdb) createTicket(ctx context.Context, ticket *Ticket) (bool, error) {
+	tag, err := db.Conn(ctx).InsertChangeTicket(ctx, pggen.InsertChangeTicketParams{
+		ChangeTicketID: sql.String(ticket.ID),
+		RunID:          sql.String(ticket.RunID),
+		Status:         sql.String(string(ticket.Status)),
+		ExternalID:     sql.String(ticket.ExternalID),
+		URL:            sql.String(ticket.URL),
+		Message:        sql.String(ticket.Message),
+		CreatedAt:      sql.Timestamptz(ticket.CreatedAt),
+		UpdatedAt:      sql.Timestamptz(ticket.UpdatedAt),
+	})
+	if err != nil {
+		return false, sql.Error(err)
+	}
+	return tag.RowsAffected() > 0, nil
+}
+
+func (db *pgdb) getTicket(ctx context.Context, ticketID string) (*Ticket, error) {
+	row, err := db.Conn(ctx).FindChangeTicketByID(ctx, sql.String(ticketID))
+	if err != nil {
+		return nil, sql.Error(err)
+	}
+	return ticketRow(row).toTicket(), nil
+}
+
+func (db *pgdb) getTicketByRunID(ctx context.Context, runID string) (*Ticket, error) {
+	row, err := db.Conn(ctx).FindChangeTicketByRunID(ctx, sql.String(runID))
+	if err != nil {
+		return nil, sql.Error(err)
+	}
+	return ticketRow(row).toTicket(), nil
+}
+
+func (db *pgdb) updateTicket(ctx context.Context, ticketID string, fn func(context.Context, *Ticket) error) (*Ticket, error) {
+	var ticket *Ticket
+	err := db.Tx(ctx, func(ctx context.Context, q pggen.Querier) error {
+		row, err := q.FindChangeTicketByIDForUpdate(ctx, sql.String(ticketID))
+		if err != nil {
+			return sql.Error(err)
+		}
+		ticket = ticketRow(row).toTicket()
+		if err := fn(ctx, ticket); err != nil {
+			return err
+		}
+		_, err = q.UpdateChangeTicket(ctx, pggen.UpdateChangeTicketParams{
+			ChangeTicketID: sql.String(ticket.ID),
+			Status:         sql.String(string(ticket.Status)),
+			ExternalID:     sql.String(ticket.ExternalID),
+			URL:            sql.String(ticket.URL),
+			Message:        sql.String(ticket.Message),
+			UpdatedAt:      sql.Timestamptz(ticket.UpdatedAt),
+		})
+		return sql.Error(err)
+	})
+	return ticket, err
+}
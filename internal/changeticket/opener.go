@@ -0,0 +1,66 @@
+package changeticket
+
+import (
+	"context"
+
+	"github.com/go-logr/logr"
+	"github.com/leg100/otf/internal/pubsub"
+	"github.com/leg100/otf/internal/run"
+)
+
+// OpenerLockID guarantees only one opener on a cluster is running at any
+// time.
+const OpenerLockID int64 = 5577006791947779422
+
+type (
+	// Opener opens change tickets for runs awaiting confirmation.
+	//
+	// Only one opener should be running on an OTF cluster at any one time.
+	Opener struct {
+		logr.Logger
+
+		runs   openerRunClient
+		client openerClient
+	}
+
+	openerRunClient interface {
+		Watch(context.Context) (<-chan pubsub.Event[*run.Run], func())
+	}
+
+	openerClient interface {
+		open(ctx context.Context, r *run.Run) error
+	}
+)
+
+// NewOpener constructs an opener of change tickets.
+func (s *Service) NewOpener(logger logr.Logger) *Opener {
+	return &Opener{
+		Logger: logger.WithValues("component", "change-ticket-opener"),
+		runs:   s.runs,
+		client: s,
+	}
+}
+
+func (o *Opener) String() string { return "change-ticket-opener" }
+
+// Start the opener. Should be invoked in a go routine.
+func (o *Opener) Start(ctx context.Context) error {
+	sub, unsub := o.runs.Watch(ctx)
+	defer unsub()
+
+	for event := range sub {
+		if event.Type != pubsub.UpdatedEvent {
+			continue
+		}
+		switch event.Payload.Status {
+		case run.RunPlanned, run.RunPolicyChecked:
+		default:
+			continue
+		}
+		// carry on opening tickets for subsequent runs
+		if err := o.client.open(ctx, event.Payload); err != nil {
+			o.Error(err, "opening change ticket", "run_id", event.Payload.ID)
+		}
+	}
+	return pubsub.ErrSubscriptionTerminated
+}
@@ -0,0 +1,58 @@
+package changeticket
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/leg100/otf/internal/pubsub"
+	"github.com/leg100/otf/internal/run"
+	"github.com/stretchr/testify/assert"
+)
+
+type (
+	fakeOpenerRunClient struct {
+		events []pubsub.Event[*run.Run]
+	}
+	fakeOpenerClient struct {
+		// IDs of runs for which tickets were opened
+		opened []string
+	}
+)
+
+func TestOpener(t *testing.T) {
+	runs := &fakeOpenerRunClient{
+		events: []pubsub.Event[*run.Run]{
+			{Type: pubsub.CreatedEvent, Payload: &run.Run{ID: "run-created", Status: run.RunPending}},
+			{Type: pubsub.UpdatedEvent, Payload: &run.Run{ID: "run-planning", Status: run.RunPlanning}},
+			{Type: pubsub.UpdatedEvent, Payload: &run.Run{ID: "run-planned", Status: run.RunPlanned}},
+			{Type: pubsub.UpdatedEvent, Payload: &run.Run{ID: "run-planned-and-finished", Status: run.RunPlannedAndFinished}},
+			{Type: pubsub.UpdatedEvent, Payload: &run.Run{ID: "run-applying", Status: run.RunApplying}},
+		},
+	}
+	client := &fakeOpenerClient{}
+	o := &Opener{
+		Logger: logr.Discard(),
+		runs:   runs,
+		client: client,
+	}
+
+	err := o.Start(context.Background())
+	assert.Equal(t, pubsub.ErrSubscriptionTerminated, err)
+
+	assert.Equal(t, []string{"run-planned"}, client.opened)
+}
+
+func (f *fakeOpenerRunClient) Watch(context.Context) (<-chan pubsub.Event[*run.Run], func()) {
+	ch := make(chan pubsub.Event[*run.Run], len(f.events))
+	for _, ev := range f.events {
+		ch <- ev
+	}
+	close(ch)
+	return ch, func() {}
+}
+
+func (f *fakeOpenerClient) open(ctx context.Context, r *run.Run) error {
+	f.opened = append(f.opened, r.ID)
+	return nil
+}
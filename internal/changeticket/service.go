@@ -0,0 +1,222 @@
+package changeticket
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/leg100/otf/internal"
+	otfapi "github.com/leg100/otf/internal/api"
+	"github.com/leg100/otf/internal/http/html/paths"
+	"github.com/leg100/otf/internal/logr"
+	"github.com/leg100/otf/internal/pubsub"
+	"github.com/leg100/otf/internal/rbac"
+	"github.com/leg100/otf/internal/resource"
+	"github.com/leg100/otf/internal/run"
+	"github.com/leg100/otf/internal/sql"
+	"github.com/leg100/otf/internal/tfeapi"
+	"github.com/leg100/otf/internal/tokens"
+	"github.com/leg100/otf/internal/workspace"
+)
+
+// ticketTokenExpiry is the lifetime of the token sent to the change management
+// system, within which a decision is expected to be made on the ticket.
+var ticketTokenExpiry = 14 * 24 * time.Hour
+
+type (
+	Service struct {
+		logr.Logger
+		*internal.HostnameService
+
+		runAuthorizer internal.Authorizer // authorize run actions
+
+		db         *pgdb
+		api        *api
+		runs       runClient
+		workspaces workspaceClient
+		tokens     *tokens.Service
+		webhook    *webhook
+	}
+
+	Options struct {
+		*sql.DB
+		*internal.HostnameService
+		logr.Logger
+
+		Config           Config
+		RunService       *run.Service
+		WorkspaceService *workspace.Service
+		TokensService    *tokens.Service
+	}
+
+	runClient interface {
+		Get(ctx context.Context, runID string) (*run.Run, error)
+		Apply(ctx context.Context, runID string) error
+		Watch(context.Context) (<-chan pubsub.Event[*run.Run], func())
+	}
+
+	workspaceClient interface {
+		Get(ctx context.Context, workspaceID string) (*workspace.Workspace, error)
+	}
+)
+
+func NewService(opts Options) *Service {
+	svc := Service{
+		Logger:          opts.Logger,
+		HostnameService: opts.HostnameService,
+		runAuthorizer:   opts.RunService,
+		db:              &pgdb{opts.DB},
+		runs:            opts.RunService,
+		workspaces:      opts.WorkspaceService,
+		tokens:          opts.TokensService,
+	}
+	svc.api = &api{Service: &svc}
+	if opts.Config.Enabled() {
+		svc.webhook = newWebhook(opts.Config)
+	}
+	// Register with auth middleware the token sent to the change management
+	// system and a means of retrieving the ticket corresponding to the token.
+	opts.TokensService.RegisterKind(TicketTokenKind, func(ctx context.Context, ticketID string) (internal.Subject, error) {
+		ticket, err := svc.db.getTicket(ctx, ticketID)
+		if err != nil {
+			return nil, fmt.Errorf("retrieving change ticket: %w", err)
+		}
+		run, err := svc.runs.Get(ctx, ticket.RunID)
+		if err != nil {
+			return nil, fmt.Errorf("retrieving run for change ticket: %w", err)
+		}
+		return &ticketSubject{
+			ticketID:     ticket.ID,
+			workspaceID:  run.WorkspaceID,
+			organization: run.Organization,
+		}, nil
+	})
+	return &svc
+}
+
+func (s *Service) AddHandlers(r *mux.Router) {
+	s.api.addHandlers(r)
+}
+
+// Enabled determines whether change tickets are opened for runs.
+func (s *Service) Enabled() bool { return s.webhook != nil }
+
+// Get retrieves the change ticket for a run.
+func (s *Service) Get(ctx context.Context, runID string) (*Ticket, error) {
+	subject, err := s.runAuthorizer.CanAccess(ctx, rbac.GetRunAction, runID)
+	if err != nil {
+		return nil, err
+	}
+	ticket, err := s.db.getTicketByRunID(ctx, runID)
+	if err != nil {
+		s.Error(err, "retrieving change ticket", "run_id", runID, "subject", subject)
+		return nil, err
+	}
+	s.V(9).Info("retrieved change ticket", "ticket", ticket, "subject", subject)
+	return ticket, nil
+}
+
+// Callback updates a change ticket with the status reported by the change
+// management system. Only the change management system, authenticated with
+// the token sent to it, may report the status. Once the ticket is approved the
+// run is applied.
+func (s *Service) Callback(ctx context.Context, ticketID string, cb Callback) (*Ticket, error) {
+	subject, err := ticketSubjectFromContext(ctx)
+	if err != nil || subject.ticketID != ticketID {
+		return nil, internal.ErrAccessNotPermitted
+	}
+	ticket, err := s.db.updateTicket(ctx, ticketID, func(ctx context.Context, ticket *Ticket) error {
+		if err := ticket.callback(cb); err != nil {
+			return err
+		}
+		if ticket.Status != TicketApproved {
+			return nil
+		}
+		// the ticket is only updated if the run is successfully applied,
+		// permitting the change management system to retry.
+		ctx = internal.AddSubjectToContext(ctx, &internal.Superuser{Username: "change-tickets"})
+		if err := s.runs.Apply(ctx, ticket.RunID); err != nil {
+			return fmt.Errorf("applying run: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		s.Error(err, "updating change ticket", "id", ticketID, "subject", subject)
+		return nil, err
+	}
+	s.V(0).Info("updated change ticket", "ticket", ticket, "subject", subject)
+	return ticket, nil
+}
+
+// open opens a change ticket for a run awaiting confirmation, sending a
+// request to the webhook adapter. A run has at most one ticket; if it already
+// has a ticket then nothing is done.
+func (s *Service) open(ctx context.Context, r *run.Run) error {
+	ticket := newTicket(r.ID)
+	created, err := s.db.createTicket(ctx, ticket)
+	if err != nil {
+		return err
+	}
+	if !created {
+		return nil
+	}
+	p, sendErr := s.newPayload(ctx, ticket, r)
+	if sendErr == nil {
+		sendErr = s.webhook.send(ctx, p)
+	}
+	if sendErr != nil {
+		_, err := s.db.updateTicket(ctx, ticket.ID, func(_ context.Context, t *Ticket) error {
+			t.fail(sendErr.Error())
+			return nil
+		})
+		if err != nil {
+			s.Error(err, "erroring change ticket", "ticket", ticket)
+		}
+		return fmt.Errorf("sending request to open change ticket: %w", sendErr)
+	}
+	s.V(0).Info("sent request to open change ticket", "ticket", ticket)
+	return nil
+}
+
+func (s *Service) newPayload(ctx context.Context, ticket *Ticket, r *run.Run) (*payload, error) {
+	ws, err := s.workspaces.Get(ctx, r.WorkspaceID)
+	if err != nil {
+		return nil, fmt.Errorf("retrieving workspace: %w", err)
+	}
+	expiry := internal.CurrentTimestamp(nil).Add(ticketTokenExpiry)
+	token, err := s.tokens.NewToken(tokens.NewTokenOptions{
+		Kind:    TicketTokenKind,
+		Subject: ticket.ID,
+		Expiry:  &expiry,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("creating access token: %w", err)
+	}
+	planID := resource.ConvertID(r.ID, resource.PlanKind)
+	p := &payload{
+		PayloadVersion:   payloadVersion,
+		TicketID:         ticket.ID,
+		AccessToken:      string(token),
+		CallbackURL:      s.URL(otfapi.DefaultBasePath + "/change-tickets/" + ticket.ID + "/callback"),
+		RunID:            r.ID,
+		RunAppURL:        s.URL(paths.Run(r.ID)),
+		RunMessage:       r.Message,
+		RunCreatedAt:     r.CreatedAt,
+		WorkspaceID:      ws.ID,
+		WorkspaceName:    ws.Name,
+		WorkspaceAppURL:  s.URL(paths.Workspace(ws.ID)),
+		OrganizationName: r.Organization,
+		PlanSummary:      r.Plan.ResourceReport,
+		PlanJSONAPIURL:   s.URL(tfeapi.APIPrefixV2 + "plans/" + planID + "/json-output"),
+	}
+	if r.CreatedBy != nil {
+		p.RunCreatedBy = *r.CreatedBy
+	}
+	if ia := r.IngressAttributes; ia != nil {
+		p.VCSBranch = ia.Branch
+		p.VCSCommitURL = ia.CommitURL
+		p.VCSPullRequestURL = ia.PullRequestURL
+	}
+	return p, nil
+}
@@ -0,0 +1,161 @@
+// Package changeticket provides change tickets, which gate the apply of a run
+// on the approval of a ticket opened in an external change management system,
+// e.g. ServiceNow or Jira.
+package changeticket
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/url"
+	"time"
+
+	"github.com/leg100/otf/internal"
+	"github.com/leg100/otf/internal/resource"
+)
+
+const (
+	// TicketPending is the status of a ticket that is yet to be opened by the
+	// change management system.
+	TicketPending Status = "pending"
+	// TicketOpened is the status of a ticket that has been opened and is
+	// awaiting a decision.
+	TicketOpened Status = "opened"
+	// TicketApproved is the status of a ticket that has been approved,
+	// whereupon the run is applied.
+	TicketApproved Status = "approved"
+	// TicketRejected is the status of a ticket that has been rejected. The run
+	// is left for a user to discard.
+	TicketRejected Status = "rejected"
+	// TicketErrored is the status of a ticket that could not be opened, e.g.
+	// the change management system could not be reached.
+	TicketErrored Status = "errored"
+)
+
+var (
+	ErrTicketAlreadyDone   = errors.New("change ticket has already been decided")
+	ErrInvalidTicketStatus = errors.New("status must be one of: opened, approved, rejected")
+)
+
+type (
+	// Ticket is a change ticket opened for a run awaiting confirmation.
+	Ticket struct {
+		ID        string    `json:"id"`
+		CreatedAt time.Time `json:"created_at"`
+		UpdatedAt time.Time `json:"updated_at"`
+		RunID     string    `json:"run_id"`
+		Status    Status    `json:"status"`
+		// ExternalID is the ID of the ticket in the change management
+		// system.
+		ExternalID string `json:"external_id"`
+		// URL of the ticket in the change management system.
+		URL     string `json:"url"`
+		Message string `json:"message"`
+	}
+
+	Status string
+
+	// Callback is the status of a ticket reported by the change management
+	// system.
+	Callback struct {
+		Status     Status  `json:"status"`
+		ExternalID *string `json:"external_id,omitempty"`
+		URL        *string `json:"url,omitempty"`
+		Message    *string `json:"message,omitempty"`
+	}
+
+	// Config configures the webhook adapter to which requests to open change
+	// tickets are sent.
+	Config struct {
+		// WebhookURL is the URL of the adapter. If empty then no tickets are
+		// opened.
+		WebhookURL string
+		// HMACKey, if non-empty, is used to sign requests sent to the
+		// adapter.
+		HMACKey string
+	}
+)
+
+// Enabled determines whether change tickets are enabled.
+func (cfg Config) Enabled() bool { return cfg.WebhookURL != "" }
+
+// Valid validates the config.
+func (cfg Config) Valid() error {
+	if !cfg.Enabled() {
+		return nil
+	}
+	u, err := url.Parse(cfg.WebhookURL)
+	if err != nil {
+		return &internal.InvalidParameterError{Parameter: "change-ticket-webhook-url", Err: err}
+	}
+	if u.Scheme != "http" && u.Scheme != "https" || u.Host == "" {
+		return &internal.InvalidParameterError{
+			Parameter: "change-ticket-webhook-url",
+			Err:       fmt.Errorf("must be an absolute http or https url: %s", cfg.WebhookURL),
+		}
+	}
+	return nil
+}
+
+func newTicket(runID string) *Ticket {
+	return &Ticket{
+		ID:        resource.NewID(resource.ChangeTicketKind),
+		CreatedAt: internal.CurrentTimestamp(nil),
+		UpdatedAt: internal.CurrentTimestamp(nil),
+		RunID:     runID,
+		Status:    TicketPending,
+	}
+}
+
+func (t *Ticket) LogValue() slog.Value {
+	attrs := []slog.Attr{
+		slog.String("id", t.ID),
+		slog.String("run_id", t.RunID),
+		slog.String("status", string(t.Status)),
+		slog.String("external_id", t.ExternalID),
+	}
+	return slog.GroupValue(attrs...)
+}
+
+// Done determines whether a decision has been made on the ticket, or whether
+// it could not be opened.
+func (t *Ticket) Done() bool {
+	switch t.Status {
+	case TicketApproved, TicketRejected, TicketErrored:
+		return true
+	default:
+		return false
+	}
+}
+
+// callback updates the ticket with that reported by the change management
+// system.
+func (t *Ticket) callback(cb Callback) error {
+	if t.Done() {
+		return ErrTicketAlreadyDone
+	}
+	switch cb.Status {
+	case TicketOpened, TicketApproved, TicketRejected:
+	default:
+		return &internal.InvalidParameterError{Parameter: "status", Err: ErrInvalidTicketStatus}
+	}
+	t.Status = cb.Status
+	if cb.ExternalID != nil {
+		t.ExternalID = *cb.ExternalID
+	}
+	if cb.URL != nil {
+		t.URL = *cb.URL
+	}
+	if cb.Message != nil {
+		t.Message = *cb.Message
+	}
+	t.UpdatedAt = internal.CurrentTimestamp(nil)
+	return nil
+}
+
+// fail marks the ticket as errored.
+func (t *Ticket) fail(msg string) {
+	t.Status = TicketErrored
+	t.Message = msg
+	t.UpdatedAt = internal.CurrentTimestamp(nil)
+}
@@ -0,0 +1,79 @@
+package changeticket
+
+import (
+	"testing"
+
+	"github.com/leg100/otf/internal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTicket_Callback(t *testing.T) {
+	t.Run("open then approve", func(t *testing.T) {
+		ticket := newTicket("run-123")
+
+		err := ticket.callback(Callback{
+			Status:     TicketOpened,
+			ExternalID: internal.String("CHG0031337"),
+			URL:        internal.String("https://acme.service-now.com/CHG0031337"),
+		})
+		require.NoError(t, err)
+		assert.Equal(t, TicketOpened, ticket.Status)
+		assert.Equal(t, "CHG0031337", ticket.ExternalID)
+		assert.False(t, ticket.Done())
+
+		err = ticket.callback(Callback{Status: TicketApproved, Message: internal.String("approved by CAB")})
+		require.NoError(t, err)
+		assert.Equal(t, TicketApproved, ticket.Status)
+		assert.Equal(t, "approved by CAB", ticket.Message)
+		// external ID is retained
+		assert.Equal(t, "CHG0031337", ticket.ExternalID)
+		assert.True(t, ticket.Done())
+	})
+
+	t.Run("decided ticket cannot be updated", func(t *testing.T) {
+		ticket := newTicket("run-123")
+		require.NoError(t, ticket.callback(Callback{Status: TicketRejected}))
+
+		err := ticket.callback(Callback{Status: TicketApproved})
+		assert.Equal(t, ErrTicketAlreadyDone, err)
+	})
+
+	t.Run("errored ticket cannot be updated", func(t *testing.T) {
+		ticket := newTicket("run-123")
+		ticket.fail("adapter unreachable")
+
+		err := ticket.callback(Callback{Status: TicketApproved})
+		assert.Equal(t, ErrTicketAlreadyDone, err)
+	})
+
+	t.Run("invalid status", func(t *testing.T) {
+		ticket := newTicket("run-123")
+
+		err := ticket.callback(Callback{Status: TicketErrored})
+		assert.ErrorIs(t, err, ErrInvalidTicketStatus)
+	})
+}
+
+func TestConfig_Valid(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     Config
+		wantErr bool
+	}{
+		{"disabled", Config{}, false},
+		{"valid url", Config{WebhookURL: "https://adapter.example.com/tickets"}, false},
+		{"relative url", Config{WebhookURL: "/tickets"}, true},
+		{"unsupported scheme", Config{WebhookURL: "ftp://adapter.example.com"}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.cfg.Valid()
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
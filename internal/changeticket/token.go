@@ -0,0 +1,70 @@
+package changeticket
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/leg100/otf/internal"
+	"github.com/leg100/otf/internal/rbac"
+	"github.com/leg100/otf/internal/tokens"
+)
+
+// TicketTokenKind is the kind of the access token sent to the change
+// management system, with which it retrieves details of the run and reports
+// the status of the ticket.
+const TicketTokenKind tokens.Kind = "change_ticket_token"
+
+// ticketSubject is the subject of a change ticket token, i.e. the change
+// management system acting on a ticket, for the purposes of authorization and
+// auditing.
+type ticketSubject struct {
+	ticketID     string
+	workspaceID  string
+	organization string
+}
+
+// ticketSubjectFromContext retrieves a change ticket subject from a context
+func ticketSubjectFromContext(ctx context.Context) (*ticketSubject, error) {
+	subj, err := internal.SubjectFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	ts, ok := subj.(*ticketSubject)
+	if !ok {
+		return nil, fmt.Errorf("subject found in context but it is not a change ticket")
+	}
+	return ts, nil
+}
+
+func (s *ticketSubject) String() string { return s.ticketID }
+
+func (s *ticketSubject) IsSiteAdmin() bool   { return false }
+func (s *ticketSubject) IsOwner(string) bool { return false }
+
+func (s *ticketSubject) Organizations() []string { return nil }
+
+func (*ticketSubject) CanAccessSite(action rbac.Action) bool {
+	return false
+}
+
+func (*ticketSubject) CanAccessTeam(rbac.Action, string) bool {
+	return false
+}
+
+func (*ticketSubject) CanAccessOrganization(action rbac.Action, name string) bool {
+	return false
+}
+
+func (s *ticketSubject) CanAccessWorkspace(action rbac.Action, policy internal.WorkspacePolicy) bool {
+	if policy.WorkspaceID != s.workspaceID {
+		return false
+	}
+	// the change management system may only retrieve details of the run and
+	// its workspace.
+	switch action {
+	case rbac.GetRunAction, rbac.GetPlanFileAction, rbac.GetWorkspaceAction:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,101 @@
+package changeticket
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha512"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/leg100/otf/internal/run"
+)
+
+const (
+	// signatureHeader is the header containing the HMAC signature of the
+	// request sent to the webhook adapter.
+	signatureHeader = "X-OTF-Change-Ticket-Signature"
+
+	// payloadVersion is the version of the request payload sent to the
+	// webhook adapter.
+	payloadVersion = 1
+)
+
+// webhookTimeout is the time the webhook adapter is given to respond.
+var webhookTimeout = 30 * time.Second
+
+type (
+	// webhook sends requests to open change tickets to an adapter, which
+	// translates them into tickets in a change management system.
+	webhook struct {
+		url     string
+		hmacKey string
+		client  *http.Client
+	}
+
+	// payload is the request sent to the webhook adapter.
+	payload struct {
+		PayloadVersion    int         `json:"payload_version"`
+		TicketID          string      `json:"ticket_id"`
+		AccessToken       string      `json:"access_token"`
+		CallbackURL       string      `json:"callback_url"`
+		RunID             string      `json:"run_id"`
+		RunAppURL         string      `json:"run_app_url"`
+		RunMessage        string      `json:"run_message"`
+		RunCreatedAt      time.Time   `json:"run_created_at"`
+		RunCreatedBy      string      `json:"run_created_by,omitempty"`
+		WorkspaceID       string      `json:"workspace_id"`
+		WorkspaceName     string      `json:"workspace_name"`
+		WorkspaceAppURL   string      `json:"workspace_app_url"`
+		OrganizationName  string      `json:"organization_name"`
+		PlanSummary       *run.Report `json:"plan_summary,omitempty"`
+		PlanJSONAPIURL    string      `json:"plan_json_api_url"`
+		VCSBranch         string      `json:"vcs_branch,omitempty"`
+		VCSCommitURL      string      `json:"vcs_commit_url,omitempty"`
+		VCSPullRequestURL string      `json:"vcs_pull_request_url,omitempty"`
+	}
+)
+
+func newWebhook(cfg Config) *webhook {
+	return &webhook{
+		url:     cfg.WebhookURL,
+		hmacKey: cfg.HMACKey,
+		client:  &http.Client{Timeout: webhookTimeout},
+	}
+}
+
+// send sends a request to open a ticket to the adapter.
+func (w *webhook) send(ctx context.Context, p *payload) error {
+	body, err := json.Marshal(p)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, "POST", w.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if w.hmacKey != "" {
+		req.Header.Set(signatureHeader, sign(body, w.hmacKey))
+	}
+	resp, err := w.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("webhook adapter responded with unexpected status: %s", resp.Status)
+	}
+	return nil
+}
+
+// sign returns the hex-encoded HMAC-SHA512 signature of the body using the
+// key.
+func sign(body []byte, key string) string {
+	mac := hmac.New(sha512.New, []byte(key))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,60 @@
+package changeticket
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/leg100/otf/internal/run"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWebhook(t *testing.T) {
+	t.Run("signed request", func(t *testing.T) {
+		var got payload
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			body, err := io.ReadAll(r.Body)
+			require.NoError(t, err)
+			assert.Equal(t, sign(body, "secret"), r.Header.Get(signatureHeader))
+			require.NoError(t, json.Unmarshal(body, &got))
+			w.WriteHeader(http.StatusAccepted)
+		}))
+		defer srv.Close()
+
+		wh := newWebhook(Config{WebhookURL: srv.URL, HMACKey: "secret"})
+		err := wh.send(context.Background(), &payload{
+			TicketID:    "ct-123",
+			PlanSummary: &run.Report{Additions: 2, Changes: 1},
+		})
+		require.NoError(t, err)
+
+		assert.Equal(t, "ct-123", got.TicketID)
+		assert.Equal(t, &run.Report{Additions: 2, Changes: 1}, got.PlanSummary)
+	})
+
+	t.Run("unsigned request", func(t *testing.T) {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Empty(t, r.Header.Get(signatureHeader))
+		}))
+		defer srv.Close()
+
+		wh := newWebhook(Config{WebhookURL: srv.URL})
+		err := wh.send(context.Background(), &payload{TicketID: "ct-123"})
+		require.NoError(t, err)
+	})
+
+	t.Run("adapter error", func(t *testing.T) {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusInternalServerError)
+		}))
+		defer srv.Close()
+
+		wh := newWebhook(Config{WebhookURL: srv.URL})
+		err := wh.send(context.Background(), &payload{TicketID: "ct-123"})
+		assert.Error(t, err)
+	})
+}
@@ -3,6 +3,7 @@ package cli
 
 import (
 	"context"
+	"fmt"
 	"io"
 	"os"
 
@@ -12,6 +13,7 @@ import (
 	"github.com/leg100/otf/internal/api"
 	"github.com/leg100/otf/internal/organization"
 	"github.com/leg100/otf/internal/run"
+	"github.com/leg100/otf/internal/search"
 	"github.com/leg100/otf/internal/state"
 	"github.com/leg100/otf/internal/team"
 	"github.com/leg100/otf/internal/user"
@@ -48,6 +50,11 @@ func (a *CLI) Run(ctx context.Context, args []string, out io.Writer) error {
 		PersistentPreRunE: a.newClient(&cfg),
 	}
 
+	// relay server warnings to the user
+	cfg.WarningHandler = func(msg string) {
+		fmt.Fprintf(cmd.ErrOrStderr(), "Warning: %s\n", msg)
+	}
+
 	cmd.PersistentFlags().StringVar(&cfg.Address, "address", api.DefaultAddress, "Address of OTF server")
 	cmd.PersistentFlags().StringVar(&cfg.Token, "token", "", "API authentication token")
 
@@ -62,6 +69,7 @@ func (a *CLI) Run(ctx context.Context, args []string, out io.Writer) error {
 	cmd.AddCommand(run.NewCommand(a.client))
 	cmd.AddCommand(state.NewCommand(a.client))
 	cmd.AddCommand(agent.NewAgentsCommand(a.client))
+	cmd.AddCommand(search.NewCommand(a.client))
 
 	if err := cmdutil.SetFlagsFromEnvVariables(cmd.Flags()); err != nil {
 		return errors.Wrap(err, "failed to populate config from environment vars")
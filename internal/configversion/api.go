@@ -1,9 +1,15 @@
 package configversion
 
 import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
 	"net/http"
+	"time"
 
 	"github.com/gorilla/mux"
+	"github.com/leg100/otf/internal"
 	otfapi "github.com/leg100/otf/internal/api"
 	"github.com/leg100/otf/internal/http/decode"
 	"github.com/leg100/otf/internal/tfeapi"
@@ -15,8 +21,18 @@ type api struct {
 }
 
 func (a *api) addHandlers(r *mux.Router) {
+	signed := r.PathPrefix("/signed/{signature.expiry}").Subrouter()
+	signed.Use(internal.VerifySignedURL(a.signer))
+	signed.HandleFunc("/configuration-versions/{id}/download", a.downloadSigned).Methods("GET")
+
 	r = r.PathPrefix(otfapi.DefaultBasePath).Subrouter()
 	r.HandleFunc("/configuration-versions/{id}/download", a.download).Methods("GET")
+	r.HandleFunc("/configuration-versions/{id}/files", a.listFiles).Methods("GET")
+	r.HandleFunc("/configuration-versions/{id}/metadata", a.getMetadata).Methods("GET")
+	r.HandleFunc("/configuration-versions/{id}/diff", a.diff).Methods("GET")
+	r.HandleFunc("/workspaces/{workspace_id}/configuration-versions/quota", a.getQuota).Methods("GET")
+	r.HandleFunc("/organizations/{organization_name}/configuration-versions/usage", a.getOrganizationUsage).Methods("GET")
+	r.HandleFunc("/organizations/{organization_name}/consumption", a.getOrganizationConsumption).Methods("GET")
 }
 
 func (a *api) download(w http.ResponseWriter, r *http.Request) {
@@ -25,10 +41,152 @@ func (a *api) download(w http.ResponseWriter, r *http.Request) {
 		tfeapi.Error(w, err)
 		return
 	}
-	resp, err := a.DownloadConfig(r.Context(), id)
+	config, err := a.DownloadConfig(r.Context(), id)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	cv, err := a.Get(r.Context(), id)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	serveConfig(w, r, config, cv.Digest)
+}
+
+// downloadSigned sends the configuration tarball.
+//
+// NOTE: unauthenticated - access granted only via signed URL
+func (a *api) downloadSigned(w http.ResponseWriter, r *http.Request) {
+	id, err := decode.Param("id", r)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	config, digest, err := a.downloadSignedConfig(r.Context(), id)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	serveConfig(w, r, config, digest)
+}
+
+// serveConfig sends the configuration tarball, honoring Range requests so that
+// an interrupted download can be resumed. The tarball's digest is sent as its
+// ETag, permitting the client to make the range conditional upon the tarball
+// being unchanged with If-Range.
+func serveConfig(w http.ResponseWriter, r *http.Request, config []byte, digest string) {
+	w.Header().Set("Content-Type", "application/octet-stream")
+	if digest != "" {
+		w.Header().Set("ETag", fmt.Sprintf("%q", digest))
+	}
+	http.ServeContent(w, r, "", time.Time{}, bytes.NewReader(config))
+}
+
+func (a *api) listFiles(w http.ResponseWriter, r *http.Request) {
+	id, err := decode.Param("id", r)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	files, err := a.ListFiles(r.Context(), id)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(files)
+}
+
+func (a *api) getMetadata(w http.ResponseWriter, r *http.Request) {
+	id, err := decode.Param("id", r)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	meta, err := a.GetMetadata(r.Context(), id)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(meta)
+}
+
+// diff sends a diff from the configuration version specified in the query to
+// the configuration version in the path.
+func (a *api) diff(w http.ResponseWriter, r *http.Request) {
+	var params struct {
+		ID   string `schema:"id,required"`
+		From string `schema:"from,required"`
+		DiffOptions
+	}
+	if err := decode.All(&params, r); err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	diff, err := a.Diff(r.Context(), params.From, params.ID, params.DiffOptions)
+	if errors.Is(err, ErrDiffAcrossWorkspaces) {
+		tfeapi.Error(w, &internal.HTTPError{
+			Code:    http.StatusUnprocessableEntity,
+			Message: err.Error(),
+		})
+		return
+	} else if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(diff)
+}
+
+// getQuota reports the limits placed upon uploading a configuration tarball
+// to a workspace, both in the response body and its headers.
+func (a *api) getQuota(w http.ResponseWriter, r *http.Request) {
+	workspaceID, err := decode.Param("workspace_id", r)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	quota, err := a.GetQuota(r.Context(), workspaceID)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	quota.SetHeaders(w.Header())
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(quota)
+}
+
+func (a *api) getOrganizationUsage(w http.ResponseWriter, r *http.Request) {
+	org, err := decode.Param("organization_name", r)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	usage, err := a.GetOrganizationUsage(r.Context(), org)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(usage)
+}
+
+func (a *api) getOrganizationConsumption(w http.ResponseWriter, r *http.Request) {
+	var params struct {
+		Organization string `schema:"organization_name,required"`
+		ConsumptionOptions
+	}
+	if err := decode.All(&params, r); err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	report, err := a.GetOrganizationConsumption(r.Context(), params.Organization, params.ConsumptionOptions)
 	if err != nil {
 		tfeapi.Error(w, err)
 		return
 	}
-	w.Write(resp)
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(report)
 }
@@ -16,18 +16,35 @@ type Client struct {
 	Service
 }
 
+// maxDownloadAttempts is the number of times a download is attempted, each
+// attempt resuming from where the previous attempt was interrupted.
+const maxDownloadAttempts = 3
+
 // DownloadConfig downloads a configuration version tarball.  Only configuration versions in the uploaded state may be downloaded.
+//
+// If the download is interrupted then it is resumed, requesting only the
+// remaining bytes.
 func (c *Client) DownloadConfig(ctx context.Context, cvID string) ([]byte, error) {
 	u := fmt.Sprintf("configuration-versions/%s/download", url.QueryEscape(cvID))
-	req, err := c.NewRequest("GET", u, nil)
-	if err != nil {
-		return nil, err
-	}
 
 	var buf bytes.Buffer
-	if err := c.Do(ctx, req, &buf); err != nil {
-		return nil, err
+	for attempt := 1; ; attempt++ {
+		req, err := c.NewRequest("GET", u, nil)
+		if err != nil {
+			return nil, err
+		}
+		received := buf.Len()
+		if received > 0 {
+			req.Header.Set("Range", fmt.Sprintf("bytes=%d-", received))
+		}
+		err = c.Do(ctx, req, &buf)
+		if err == nil {
+			return buf.Bytes(), nil
+		}
+		// only resume a download that made progress before it was
+		// interrupted
+		if buf.Len() == received || attempt == maxDownloadAttempts || ctx.Err() != nil {
+			return nil, err
+		}
 	}
-
-	return buf.Bytes(), nil
 }
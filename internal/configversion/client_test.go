@@ -0,0 +1,48 @@
+package configversion
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	otfapi "github.com/leg100/otf/internal/api"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_DownloadConfig(t *testing.T) {
+	const config = "hello world"
+
+	var ranges []string
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ranges = append(ranges, r.Header.Get("Range"))
+		if len(ranges) == 1 {
+			// send only part of the body before dropping the connection
+			w.Header().Set("Content-Length", "11")
+			w.Write([]byte(config[:5]))
+			w.(http.Flusher).Flush()
+			conn, _, err := w.(http.Hijacker).Hijack()
+			require.NoError(t, err)
+			conn.Close()
+			return
+		}
+		http.ServeContent(w, r, "", time.Time{}, strings.NewReader(config))
+	}))
+	t.Cleanup(srv.Close)
+
+	client, err := otfapi.NewClient(otfapi.Config{
+		Address:   srv.URL,
+		Token:     "token",
+		Transport: srv.Client().Transport,
+	})
+	require.NoError(t, err)
+
+	got, err := (&Client{Client: client}).DownloadConfig(context.Background(), "cv-123")
+	require.NoError(t, err)
+
+	assert.Equal(t, []byte(config), got)
+	assert.Equal(t, []string{"", "bytes=5-"}, ranges)
+}
@@ -28,9 +28,9 @@ func (u *cvUploader) SetErrored(ctx context.Context) error {
 	return nil
 }
 
-func (u *cvUploader) Upload(ctx context.Context, config []byte) (ConfigurationStatus, error) {
+func (u *cvUploader) Upload(ctx context.Context, digest string) (ConfigurationStatus, error) {
 	// TODO: add status timestamp
-	_, err := u.q.UpdateConfigurationVersionConfigByID(ctx, config, sql.String(u.id))
+	_, err := u.q.UpdateConfigurationVersionConfigByID(ctx, sql.String(digest), sql.String(u.id))
 	if err != nil {
 		return ConfigurationErrored, err
 	}
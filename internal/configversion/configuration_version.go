@@ -34,6 +34,11 @@ type (
 		StatusTimestamps  []ConfigurationVersionStatusTimestamp
 		WorkspaceID       string
 		IngressAttributes *IngressAttributes
+		// Digest is the SHA-256 digest of the uploaded config tarball, by
+		// which it is addressed in the blob store. Configuration versions
+		// with identical tarballs share the same digest and storage. Empty
+		// until a tarball is uploaded.
+		Digest string
 	}
 
 	// CreateOptions represents the options for creating a
@@ -56,9 +61,10 @@ type (
 
 	// ConfigUploader uploads a config
 	ConfigUploader interface {
-		// Upload uploads the config tarball and returns a status indicating success
-		// or failure.
-		Upload(ctx context.Context, config []byte) (ConfigurationStatus, error)
+		// Upload associates the config tarball, identified by its blob digest,
+		// with the configuration version and returns a status indicating
+		// success or failure.
+		Upload(ctx context.Context, digest string) (ConfigurationStatus, error)
 		// SetErrored sets the config version status to 'errored' in the store.
 		SetErrored(ctx context.Context) error
 	}
@@ -110,7 +116,7 @@ type (
 // NewConfigurationVersion creates a ConfigurationVersion object from scratch
 func NewConfigurationVersion(workspaceID string, opts CreateOptions) (*ConfigurationVersion, error) {
 	cv := ConfigurationVersion{
-		ID:            internal.NewID("cv"),
+		ID:            resource.NewID(resource.ConfigVersionKind),
 		CreatedAt:     internal.CurrentTimestamp(nil),
 		AutoQueueRuns: DefaultAutoQueueRuns,
 		Source:        DefaultSource,
@@ -151,14 +157,16 @@ func (cv *ConfigurationVersion) AddStatusTimestamp(status ConfigurationStatus, t
 	})
 }
 
-// Upload saves the config to the db and updates status accordingly.
-func (cv *ConfigurationVersion) Upload(ctx context.Context, config []byte, uploader ConfigUploader) error {
+// Upload associates the config, identified by its blob digest, with the
+// configuration version and updates status accordingly.
+func (cv *ConfigurationVersion) Upload(ctx context.Context, digest string, uploader ConfigUploader) error {
 	// upload config and set status depending on success
-	status, err := uploader.Upload(ctx, config)
+	status, err := uploader.Upload(ctx, digest)
 	if err != nil {
 		return err
 	}
 	cv.Status = status
+	cv.Digest = digest
 
 	return nil
 }
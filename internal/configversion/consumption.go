@@ -0,0 +1,234 @@
+package configversion
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/leg100/otf/internal/rbac"
+	"github.com/leg100/otf/internal/sql"
+	"github.com/zclconf/go-cty/cty"
+)
+
+const (
+	// ModuleDependency is a module called from a module block.
+	ModuleDependency DependencyKind = "module"
+	// ProviderDependency is a provider selected in a dependency lock file.
+	ProviderDependency DependencyKind = "provider"
+
+	lockFilename = ".terraform.lock.hcl"
+)
+
+var (
+	moduleSchema = &hcl.BodySchema{
+		Blocks: []hcl.BlockHeaderSchema{{Type: "module", LabelNames: []string{"name"}}},
+	}
+	providerLockSchema = &hcl.BodySchema{
+		Blocks: []hcl.BlockHeaderSchema{{Type: "provider", LabelNames: []string{"source"}}},
+	}
+	sourceVersionSchema = &hcl.BodySchema{
+		Attributes: []hcl.AttributeSchema{{Name: "source"}, {Name: "version"}},
+	}
+)
+
+type (
+	DependencyKind string
+
+	// Dependency is a module or provider upon which a configuration depends.
+	Dependency struct {
+		Kind DependencyKind `json:"kind"`
+		// Source is the module source address, or the fully qualified provider
+		// address.
+		Source string `json:"source"`
+		// Version is the module version constraint, or the locked provider
+		// version. Empty if a module is called without a version constraint.
+		Version string `json:"version"`
+	}
+
+	// Consumption reports which workspaces in an organization depend upon
+	// which modules and providers. Only the latest configuration version of
+	// each workspace is considered.
+	Consumption struct {
+		Organization string                   `json:"organization"`
+		Modules      []*DependencyConsumption `json:"modules"`
+		Providers    []*DependencyConsumption `json:"providers"`
+	}
+
+	// DependencyConsumption lists the versions of a module or provider in use.
+	DependencyConsumption struct {
+		Source   string                `json:"source"`
+		Versions []*VersionConsumption `json:"versions"`
+	}
+
+	// VersionConsumption lists the workspaces using a version of a module or
+	// provider.
+	VersionConsumption struct {
+		Version    string                 `json:"version"`
+		Workspaces []ConsumptionWorkspace `json:"workspaces"`
+	}
+
+	ConsumptionWorkspace struct {
+		ID   string `json:"id"`
+		Name string `json:"name"`
+	}
+
+	// ConsumptionOptions filter the consumption report.
+	ConsumptionOptions struct {
+		// Only report on dependencies of this kind
+		Kind *DependencyKind `schema:"kind"`
+		// Only report on dependencies with this source
+		Source *string `schema:"source"`
+	}
+)
+
+// parseDependencies parses the modules and providers upon which the
+// configuration in a tarball depends. Modules are parsed from module blocks in
+// all terraform files, and providers from all dependency lock files. Local
+// modules are skipped.
+func parseDependencies(tarball io.Reader) ([]Dependency, error) {
+	gr, err := gzip.NewReader(tarball)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decompress archive: %w", err)
+	}
+	tr := tar.NewReader(gr)
+
+	var deps []Dependency
+	for {
+		header, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		} else if err != nil {
+			return nil, fmt.Errorf("failed to untar archive: %w", err)
+		}
+		if header.Typeflag != tar.TypeReg {
+			continue
+		}
+		var schema *hcl.BodySchema
+		switch {
+		case path.Base(header.Name) == lockFilename:
+			schema = providerLockSchema
+		case path.Ext(header.Name) == ".tf":
+			schema = moduleSchema
+		default:
+			continue
+		}
+		contents, err := io.ReadAll(tr)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", header.Name, err)
+		}
+		file, diags := hclsyntax.ParseConfig(contents, header.Name, hcl.InitialPos)
+		if diags.HasErrors() {
+			// invalid configuration is reported by terraform, so just skip it
+			continue
+		}
+		content, _, _ := file.Body.PartialContent(schema)
+		for _, block := range content.Blocks {
+			attrs, _, _ := block.Body.PartialContent(sourceVersionSchema)
+			switch block.Type {
+			case "module":
+				source := stringAttribute(attrs.Attributes["source"])
+				if source == "" || strings.HasPrefix(source, "./") || strings.HasPrefix(source, "../") {
+					continue
+				}
+				deps = append(deps, Dependency{
+					Kind:    ModuleDependency,
+					Source:  source,
+					Version: stringAttribute(attrs.Attributes["version"]),
+				})
+			case "provider":
+				deps = append(deps, Dependency{
+					Kind:    ProviderDependency,
+					Source:  block.Labels[0],
+					Version: stringAttribute(attrs.Attributes["version"]),
+				})
+			}
+		}
+	}
+	// remove duplicates, e.g. the same module called more than once
+	slices.SortFunc(deps, func(a, b Dependency) int {
+		if c := strings.Compare(string(a.Kind), string(b.Kind)); c != 0 {
+			return c
+		}
+		if c := strings.Compare(a.Source, b.Source); c != 0 {
+			return c
+		}
+		return strings.Compare(a.Version, b.Version)
+	})
+	return slices.Compact(deps), nil
+}
+
+// stringAttribute returns the value of an attribute if it is a literal string,
+// otherwise an empty string is returned.
+func stringAttribute(attr *hcl.Attribute) string {
+	if attr == nil {
+		return ""
+	}
+	v, diags := attr.Expr.Value(nil)
+	if diags.HasErrors() || v.Type() != cty.String || !v.IsKnown() || v.IsNull() {
+		return ""
+	}
+	return v.AsString()
+}
+
+// GetOrganizationConsumption reports which workspaces in an organization
+// depend upon which modules and providers, and which versions thereof.
+func (s *Service) GetOrganizationConsumption(ctx context.Context, organization string, opts ConsumptionOptions) (*Consumption, error) {
+	subject, err := s.organization.CanAccess(ctx, rbac.GetConsumptionReportAction, organization)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := s.db.Conn(ctx).FindDependencyConsumptionByOrganization(ctx, sql.String(organization))
+	if err != nil {
+		s.Error(err, "retrieving dependency consumption", "organization", organization, "subject", subject)
+		return nil, sql.Error(err)
+	}
+	report := &Consumption{
+		Organization: organization,
+		Modules:      []*DependencyConsumption{},
+		Providers:    []*DependencyConsumption{},
+	}
+	// rows are ordered by kind, source, version, and workspace name
+	var (
+		dep     *DependencyConsumption
+		depKind DependencyKind
+		version *VersionConsumption
+	)
+	for _, row := range rows {
+		kind := DependencyKind(row.Kind.String)
+		if opts.Kind != nil && *opts.Kind != kind {
+			continue
+		}
+		if opts.Source != nil && *opts.Source != row.Source.String {
+			continue
+		}
+		if dep == nil || depKind != kind || dep.Source != row.Source.String {
+			dep = &DependencyConsumption{Source: row.Source.String}
+			depKind = kind
+			version = nil
+			switch kind {
+			case ModuleDependency:
+				report.Modules = append(report.Modules, dep)
+			case ProviderDependency:
+				report.Providers = append(report.Providers, dep)
+			}
+		}
+		if version == nil || version.Version != row.Version.String {
+			version = &VersionConsumption{Version: row.Version.String}
+			dep.Versions = append(dep.Versions, version)
+		}
+		version.Workspaces = append(version.Workspaces, ConsumptionWorkspace{
+			ID:   row.WorkspaceID.String,
+			Name: row.WorkspaceName.String,
+		})
+	}
+	s.V(9).Info("retrieved dependency consumption", "organization", organization, "modules", len(report.Modules), "providers", len(report.Providers), "subject", subject)
+	return report, nil
+}
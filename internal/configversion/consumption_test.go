@@ -0,0 +1,71 @@
+package configversion
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/leg100/otf/internal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseDependencies(t *testing.T) {
+	dir := t.TempDir()
+	writeFile := func(name, contents string) {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+		require.NoError(t, os.WriteFile(path, []byte(contents), 0o644))
+	}
+	writeFile("main.tf", `
+module "vpc" {
+  source  = "terraform-aws-modules/vpc/aws"
+  version = "~> 5.0"
+}
+
+module "local" {
+  source = "./modules/local"
+}
+
+module "git" {
+  source = "git::https://example.com/vpc.git?ref=v1.2.0"
+}
+`)
+	writeFile("other.tf", `
+module "vpc_again" {
+  source  = "terraform-aws-modules/vpc/aws"
+  version = "~> 5.0"
+}
+`)
+	writeFile("modules/local/main.tf", `
+module "nested" {
+  source  = "otf.example.com/acme/s3/aws"
+  version = "1.0.0"
+}
+`)
+	writeFile(".terraform.lock.hcl", `
+provider "registry.terraform.io/hashicorp/aws" {
+  version     = "5.26.0"
+  constraints = "~> 5.0"
+  hashes = [
+    "h1:abc",
+  ]
+}
+`)
+	writeFile("invalid.tf", `module "broken" {`)
+	writeFile("README.md", `module "not_terraform" {}`)
+
+	tarball, err := internal.Pack(dir)
+	require.NoError(t, err)
+
+	got, err := parseDependencies(bytes.NewReader(tarball))
+	require.NoError(t, err)
+
+	assert.Equal(t, []Dependency{
+		{Kind: ModuleDependency, Source: "git::https://example.com/vpc.git?ref=v1.2.0"},
+		{Kind: ModuleDependency, Source: "otf.example.com/acme/s3/aws", Version: "1.0.0"},
+		{Kind: ModuleDependency, Source: "terraform-aws-modules/vpc/aws", Version: "~> 5.0"},
+		{Kind: ProviderDependency, Source: "registry.terraform.io/hashicorp/aws", Version: "5.26.0"},
+	}, got)
+}
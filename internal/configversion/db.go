@@ -2,6 +2,7 @@ package configversion
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 
 	"github.com/jackc/pgtype"
@@ -16,7 +17,9 @@ type pgdb struct {
 	*sql.DB // provides access to generated SQL queries
 }
 
-func (db *pgdb) CreateConfigurationVersion(ctx context.Context, cv *ConfigurationVersion) error {
+// CreateConfigurationVersion persists a configuration version along with the
+// subject that created it, as a record of its provenance.
+func (db *pgdb) CreateConfigurationVersion(ctx context.Context, cv *ConfigurationVersion, createdBy string) error {
 	return db.Tx(ctx, func(ctx context.Context, q pggen.Querier) error {
 		_, err := q.InsertConfigurationVersion(ctx, pggen.InsertConfigurationVersionParams{
 			ID:            sql.String(cv.ID),
@@ -30,6 +33,10 @@ func (db *pgdb) CreateConfigurationVersion(ctx context.Context, cv *Configuratio
 		if err != nil {
 			return err
 		}
+		_, err = q.InsertConfigurationVersionCreator(ctx, sql.String(cv.ID), sql.String(createdBy))
+		if err != nil {
+			return err
+		}
 
 		if cv.IngressAttributes != nil {
 			ia := cv.IngressAttributes
@@ -125,12 +132,17 @@ func (db *pgdb) GetConfigurationVersion(ctx context.Context, opts ConfigurationV
 	}
 }
 
-func (db *pgdb) GetConfig(ctx context.Context, id string) ([]byte, error) {
-	cfg, err := db.Conn(ctx).DownloadConfigurationVersion(ctx, sql.String(id))
+// GetConfigDigest retrieves the blob digest of a configuration version's
+// config tarball.
+func (db *pgdb) GetConfigDigest(ctx context.Context, id string) (string, error) {
+	digest, err := db.Conn(ctx).DownloadConfigurationVersion(ctx, sql.String(id))
 	if err != nil {
-		return nil, sql.Error(err)
+		return "", sql.Error(err)
 	}
-	return cfg, nil
+	if digest.Status != pgtype.Present {
+		return "", internal.ErrResourceNotFound
+	}
+	return digest.String, nil
 }
 
 func (db *pgdb) DeleteConfigurationVersion(ctx context.Context, id string) error {
@@ -141,6 +153,120 @@ func (db *pgdb) DeleteConfigurationVersion(ctx context.Context, id string) error
 	return nil
 }
 
+func (db *pgdb) createDependencies(ctx context.Context, id string, deps []Dependency) error {
+	return db.Tx(ctx, func(ctx context.Context, q pggen.Querier) error {
+		for _, dep := range deps {
+			_, err := q.InsertConfigurationVersionDependency(ctx, pggen.InsertConfigurationVersionDependencyParams{
+				ConfigurationVersionID: sql.String(id),
+				Kind:                   sql.String(string(dep.Kind)),
+				Source:                 sql.String(dep.Source),
+				Version:                sql.String(dep.Version),
+			})
+			if err != nil {
+				return sql.Error(err)
+			}
+		}
+		return nil
+	})
+}
+
+// getWorkingDirectory retrieves the working directory of the workspace to
+// which a configuration version belongs.
+func (db *pgdb) getWorkingDirectory(ctx context.Context, id string) (string, error) {
+	dir, err := db.Conn(ctx).FindConfigurationVersionWorkingDirectory(ctx, sql.String(id))
+	if err != nil {
+		return "", sql.Error(err)
+	}
+	return dir.String, nil
+}
+
+func (db *pgdb) createMetadata(ctx context.Context, id string, meta *Metadata) error {
+	return db.Tx(ctx, func(ctx context.Context, q pggen.Querier) error {
+		for _, v := range meta.Variables {
+			defaultValue := sql.NullString()
+			if v.Default != nil {
+				defaultValue = sql.String(string(v.Default))
+			}
+			_, err := q.InsertConfigurationVersionVariable(ctx, pggen.InsertConfigurationVersionVariableParams{
+				ConfigurationVersionID: sql.String(id),
+				Name:                   sql.String(v.Name),
+				Type:                   sql.String(v.Type),
+				Description:            sql.String(v.Description),
+				DefaultValue:           defaultValue,
+				Required:               sql.Bool(v.Required),
+				Sensitive:              sql.Bool(v.Sensitive),
+			})
+			if err != nil {
+				return sql.Error(err)
+			}
+		}
+		for _, o := range meta.Outputs {
+			_, err := q.InsertConfigurationVersionOutput(ctx, pggen.InsertConfigurationVersionOutputParams{
+				ConfigurationVersionID: sql.String(id),
+				Name:                   sql.String(o.Name),
+				Description:            sql.String(o.Description),
+				Sensitive:              sql.Bool(o.Sensitive),
+			})
+			if err != nil {
+				return sql.Error(err)
+			}
+		}
+		return nil
+	})
+}
+
+func (db *pgdb) getMetadata(ctx context.Context, id string) (*Metadata, error) {
+	// check configuration version exists
+	if _, err := db.Conn(ctx).FindConfigurationVersionByID(ctx, sql.String(id)); err != nil {
+		return nil, sql.Error(err)
+	}
+	meta := &Metadata{
+		Variables:    []Variable{},
+		Outputs:      []Output{},
+		Dependencies: []Dependency{},
+	}
+	variables, err := db.Conn(ctx).FindConfigurationVersionVariables(ctx, sql.String(id))
+	if err != nil {
+		return nil, sql.Error(err)
+	}
+	for _, row := range variables {
+		v := Variable{
+			Name:        row.Name.String,
+			Type:        row.Type.String,
+			Description: row.Description.String,
+			Required:    row.Required.Bool,
+			Sensitive:   row.Sensitive.Bool,
+		}
+		if row.DefaultValue.Status == pgtype.Present {
+			v.Default = json.RawMessage(row.DefaultValue.String)
+		}
+		meta.Variables = append(meta.Variables, v)
+	}
+	outputs, err := db.Conn(ctx).FindConfigurationVersionOutputs(ctx, sql.String(id))
+	if err != nil {
+		return nil, sql.Error(err)
+	}
+	for _, row := range outputs {
+		meta.Outputs = append(meta.Outputs, Output{
+			Name:        row.Name.String,
+			Description: row.Description.String,
+			Sensitive:   row.Sensitive.Bool,
+		})
+	}
+	deps, err := db.Conn(ctx).FindConfigurationVersionDependencies(ctx, sql.String(id))
+	if err != nil {
+		return nil, sql.Error(err)
+	}
+	for _, row := range deps {
+		meta.Dependencies = append(meta.Dependencies, Dependency{
+			Kind:    DependencyKind(row.Kind.String),
+			Source:  row.Source.String,
+			Version: row.Version.String,
+		})
+	}
+	return meta, nil
+}
+
 func (db *pgdb) insertCVStatusTimestamp(ctx context.Context, cv *ConfigurationVersion) error {
 	sts, err := cv.StatusTimestamp(cv.Status)
 	if err != nil {
@@ -163,6 +289,7 @@ type pgRow struct {
 	Speculative                          pgtype.Bool                                  `json:"speculative"`
 	Status                               pgtype.Text                                  `json:"status"`
 	WorkspaceID                          pgtype.Text                                  `json:"workspace_id"`
+	ConfigDigest                         pgtype.Text                                  `json:"config_digest"`
 	ConfigurationVersionStatusTimestamps []pggen.ConfigurationVersionStatusTimestamps `json:"configuration_version_status_timestamps"`
 	IngressAttributes                    *pggen.IngressAttributes                     `json:"ingress_attributes"`
 }
@@ -177,6 +304,7 @@ func (result pgRow) toConfigVersion() *ConfigurationVersion {
 		Status:           ConfigurationStatus(result.Status.String),
 		StatusTimestamps: unmarshalStatusTimestampRows(result.ConfigurationVersionStatusTimestamps),
 		WorkspaceID:      result.WorkspaceID.String,
+		Digest:           result.ConfigDigest.String,
 	}
 	if result.IngressAttributes != nil {
 		cv.IngressAttributes = NewIngressFromRow(result.IngressAttributes)
@@ -0,0 +1,174 @@
+package configversion
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/pmezard/go-difflib/difflib"
+)
+
+const (
+	FileAdded   FileDiffStatus = "added"
+	FileRemoved FileDiffStatus = "removed"
+	FileChanged FileDiffStatus = "changed"
+
+	// maxDiffFileSize is the maximum size of a file for which a unified
+	// diff is produced. Producing a diff is quadratic in the worst case so
+	// larger files are only reported as changed.
+	maxDiffFileSize = 1024 * 1024
+	// diffContextLines is the number of unchanged lines surrounding each
+	// change in a unified diff.
+	diffContextLines = 3
+)
+
+// ErrDiffAcrossWorkspaces is returned when diffing configuration versions
+// belonging to different workspaces.
+var ErrDiffAcrossWorkspaces = errors.New("cannot diff configuration versions belonging to different workspaces")
+
+type (
+	// Diff is a file-level diff between two configuration versions.
+	Diff struct {
+		// ID of the configuration version diffed from.
+		From string `json:"from"`
+		// ID of the configuration version diffed to.
+		To string `json:"to"`
+		// Files that have been added, removed, or changed, sorted by path.
+		// Unchanged files are omitted.
+		Files []FileDiff `json:"files"`
+	}
+
+	// FileDiff describes how a file differs between two configuration
+	// versions.
+	FileDiff struct {
+		// Path of the file relative to the root of the configuration.
+		Path   string         `json:"path"`
+		Status FileDiffStatus `json:"status"`
+		// Binary is true if either version of the file is not text, in
+		// which case no unified diff is produced.
+		Binary bool `json:"binary"`
+		// Unified diff of the file's contents. Only populated if requested,
+		// and omitted for binary files and files larger than 1MiB.
+		UnifiedDiff string `json:"unified_diff,omitempty"`
+	}
+
+	// FileDiffStatus is the way in which a file differs.
+	FileDiffStatus string
+
+	DiffOptions struct {
+		// Unified requests a unified diff of each text file.
+		Unified bool `schema:"unified"`
+	}
+)
+
+// diffTarballs diffs the files in two gzipped tarballs.
+func diffTarballs(from, to io.Reader, unified bool) ([]FileDiff, error) {
+	a, err := readTarball(from)
+	if err != nil {
+		return nil, err
+	}
+	b, err := readTarball(to)
+	if err != nil {
+		return nil, err
+	}
+	diffs := []FileDiff{}
+	for path, contents := range a {
+		if _, ok := b[path]; !ok {
+			diffs = append(diffs, newFileDiff(path, FileRemoved, contents, nil, unified))
+		}
+	}
+	for path, contents := range b {
+		previous, ok := a[path]
+		if !ok {
+			diffs = append(diffs, newFileDiff(path, FileAdded, nil, contents, unified))
+		} else if !bytes.Equal(previous, contents) {
+			diffs = append(diffs, newFileDiff(path, FileChanged, previous, contents, unified))
+		}
+	}
+	sort.Slice(diffs, func(i, j int) bool {
+		return diffs[i].Path < diffs[j].Path
+	})
+	return diffs, nil
+}
+
+func newFileDiff(path string, status FileDiffStatus, from, to []byte, unified bool) FileDiff {
+	diff := FileDiff{
+		Path:   path,
+		Status: status,
+		Binary: !isText(from) || !isText(to),
+	}
+	if !unified || diff.Binary || len(from) > maxDiffFileSize || len(to) > maxDiffFileSize {
+		return diff
+	}
+	fromFile, toFile := "a/"+path, "b/"+path
+	switch status {
+	case FileAdded:
+		fromFile = "/dev/null"
+	case FileRemoved:
+		toFile = "/dev/null"
+	}
+	// an error can only be returned when writing the diff, which, being
+	// written to a string, cannot fail.
+	diff.UnifiedDiff, _ = difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        splitLines(string(from)),
+		B:        splitLines(string(to)),
+		FromFile: fromFile,
+		ToFile:   toFile,
+		Context:  diffContextLines,
+	})
+	return diff
+}
+
+// splitLines splits contents into lines, each retaining its newline, which is
+// added to an unterminated last line.
+func splitLines(contents string) []string {
+	lines := strings.SplitAfter(contents, "\n")
+	if last := len(lines) - 1; lines[last] == "" {
+		lines = lines[:last]
+	} else {
+		lines[last] += "\n"
+	}
+	return lines
+}
+
+// readTarball reads the contents of the regular files in a gzipped tarball,
+// keyed by path.
+func readTarball(config io.Reader) (map[string][]byte, error) {
+	gr, err := gzip.NewReader(config)
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to decompress archive: %w", ErrInvalidConfig, err)
+	}
+	tr := tar.NewReader(gr)
+
+	files := make(map[string][]byte)
+	for {
+		header, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		} else if err != nil {
+			return nil, fmt.Errorf("%w: failed to untar archive: %w", ErrInvalidConfig, err)
+		}
+		if header.Typeflag != tar.TypeReg {
+			continue
+		}
+		contents, err := io.ReadAll(tr)
+		if err != nil {
+			return nil, fmt.Errorf("%w: failed to read %s: %w", ErrInvalidConfig, header.Name, err)
+		}
+		files[path.Clean(header.Name)] = contents
+	}
+	return files, nil
+}
+
+// isText determines whether contents are text, i.e. valid UTF-8 without any
+// null bytes.
+func isText(contents []byte) bool {
+	return utf8.Valid(contents) && bytes.IndexByte(contents, 0) == -1
+}
@@ -0,0 +1,86 @@
+package configversion
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiffTarballs(t *testing.T) {
+	from := newTestTarballWithFiles(t, map[string]string{
+		"main.tf":      "resource \"null_resource\" \"a\" {}\n",
+		"outputs.tf":   "output \"a\" {}\n",
+		"unchanged.tf": "# unchanged\n",
+		"logo.png":     "\x89PNG\x00",
+	})
+	to := newTestTarballWithFiles(t, map[string]string{
+		"main.tf":      "resource \"null_resource\" \"b\" {}\n",
+		"variables.tf": "variable \"b\" {}\n",
+		"unchanged.tf": "# unchanged\n",
+		"logo.png":     "\x89PNG\x00\x01",
+	})
+
+	t.Run("file-level", func(t *testing.T) {
+		got, err := diffTarballs(bytes.NewReader(from), bytes.NewReader(to), false)
+		require.NoError(t, err)
+
+		assert.Equal(t, []FileDiff{
+			{Path: "logo.png", Status: FileChanged, Binary: true},
+			{Path: "main.tf", Status: FileChanged},
+			{Path: "outputs.tf", Status: FileRemoved},
+			{Path: "variables.tf", Status: FileAdded},
+		}, got)
+	})
+
+	t.Run("unified", func(t *testing.T) {
+		got, err := diffTarballs(bytes.NewReader(from), bytes.NewReader(to), true)
+		require.NoError(t, err)
+		require.Equal(t, 4, len(got))
+
+		assert.Empty(t, got[0].UnifiedDiff, "binary file")
+		assert.Equal(t, `--- a/main.tf
++++ b/main.tf
+@@ -1 +1 @@
+-resource "null_resource" "a" {}
++resource "null_resource" "b" {}
+`, got[1].UnifiedDiff)
+		assert.Equal(t, `--- a/outputs.tf
++++ /dev/null
+@@ -1 +0,0 @@
+-output "a" {}
+`, got[2].UnifiedDiff)
+		assert.Equal(t, `--- /dev/null
++++ b/variables.tf
+@@ -0,0 +1 @@
++variable "b" {}
+`, got[3].UnifiedDiff)
+	})
+
+	t.Run("invalid tarball", func(t *testing.T) {
+		_, err := diffTarballs(bytes.NewReader([]byte("not a tarball")), bytes.NewReader(to), false)
+		assert.ErrorIs(t, err, ErrInvalidConfig)
+	})
+}
+
+func newTestTarballWithFiles(t *testing.T, files map[string]string) []byte {
+	var buf bytes.Buffer
+	gw := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gw)
+	for path, contents := range files {
+		require.NoError(t, tw.WriteHeader(&tar.Header{
+			Name:     path,
+			Typeflag: tar.TypeReg,
+			Mode:     0o644,
+			Size:     int64(len(contents)),
+		}))
+		_, err := tw.Write([]byte(contents))
+		require.NoError(t, err)
+	}
+	require.NoError(t, tw.Close())
+	require.NoError(t, gw.Close())
+	return buf.Bytes()
+}
@@ -0,0 +1,117 @@
+package configversion
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/hashicorp/terraform-config-inspect/tfconfig"
+	"github.com/leg100/otf/internal"
+	"github.com/leg100/otf/internal/rbac"
+)
+
+type (
+	// Metadata describes the inputs and outputs of the root module of a
+	// configuration, along with the modules and providers upon which it
+	// depends.
+	Metadata struct {
+		Variables    []Variable   `json:"variables"`
+		Outputs      []Output     `json:"outputs"`
+		Dependencies []Dependency `json:"dependencies"`
+	}
+
+	// Variable is an input variable declared in the root module.
+	Variable struct {
+		Name string `json:"name"`
+		// Type is the type constraint, e.g. list(string); empty if the
+		// variable accepts any type.
+		Type        string `json:"type"`
+		Description string `json:"description"`
+		// Default is the JSON encoded default value; nil if the variable has
+		// no default.
+		Default json.RawMessage `json:"default"`
+		// Required is true if a value must be provided for the variable.
+		Required  bool `json:"required"`
+		Sensitive bool `json:"sensitive"`
+	}
+
+	// Output is an output value declared in the root module.
+	Output struct {
+		Name        string `json:"name"`
+		Description string `json:"description"`
+		Sensitive   bool   `json:"sensitive"`
+	}
+)
+
+// parseMetadata parses the variables and outputs of the root module found in
+// the given working directory of a configuration tarball.
+func parseMetadata(tarball io.Reader, workingDir string) (*Metadata, error) {
+	dir, err := os.MkdirTemp("", "otf-config-")
+	if err != nil {
+		return nil, fmt.Errorf("creating temporary directory: %w", err)
+	}
+	defer os.RemoveAll(dir)
+
+	if err := internal.Unpack(tarball, dir); err != nil {
+		return nil, fmt.Errorf("extracting tarball: %w", err)
+	}
+	root := filepath.Join(dir, filepath.Clean(filepath.Join("/", workingDir)))
+	if !tfconfig.IsModuleDir(root) {
+		return nil, fmt.Errorf("no terraform configuration found in %q", workingDir)
+	}
+	mod, diags := tfconfig.LoadModule(root)
+	if diags.HasErrors() {
+		return nil, fmt.Errorf("parsing HCL: %w", diags.Err())
+	}
+
+	meta := &Metadata{
+		Variables: make([]Variable, 0, len(mod.Variables)),
+		Outputs:   make([]Output, 0, len(mod.Outputs)),
+	}
+	for _, v := range mod.Variables {
+		variable := Variable{
+			Name:        v.Name,
+			Type:        v.Type,
+			Description: v.Description,
+			Required:    v.Required,
+			Sensitive:   v.Sensitive,
+		}
+		if !v.Required {
+			if variable.Default, err = json.Marshal(v.Default); err != nil {
+				return nil, fmt.Errorf("encoding default value of variable %s: %w", v.Name, err)
+			}
+		}
+		meta.Variables = append(meta.Variables, variable)
+	}
+	for _, o := range mod.Outputs {
+		meta.Outputs = append(meta.Outputs, Output{
+			Name:        o.Name,
+			Description: o.Description,
+			Sensitive:   o.Sensitive,
+		})
+	}
+	sort.Slice(meta.Variables, func(i, j int) bool { return meta.Variables[i].Name < meta.Variables[j].Name })
+	sort.Slice(meta.Outputs, func(i, j int) bool { return meta.Outputs[i].Name < meta.Outputs[j].Name })
+	return meta, nil
+}
+
+// GetMetadata retrieves the variables, outputs, and dependencies parsed from
+// a configuration version when it was uploaded.
+func (s *Service) GetMetadata(ctx context.Context, cvID string) (*Metadata, error) {
+	subject, err := s.canAccess(ctx, rbac.GetConfigurationVersionAction, cvID)
+	if err != nil {
+		return nil, err
+	}
+
+	meta, err := s.db.getMetadata(ctx, cvID)
+	if err != nil {
+		s.Error(err, "retrieving configuration metadata", "id", cvID, "subject", subject)
+		return nil, err
+	}
+	s.V(9).Info("retrieved configuration metadata", "id", cvID, "variables", len(meta.Variables), "outputs", len(meta.Outputs), "subject", subject)
+	return meta, nil
+}
@@ -0,0 +1,93 @@
+package configversion
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/leg100/otf/internal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseMetadata(t *testing.T) {
+	dir := t.TempDir()
+	writeFile := func(name, contents string) {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+		require.NoError(t, os.WriteFile(path, []byte(contents), 0o644))
+	}
+	writeFile("main.tf", `
+variable "name" {
+  type        = string
+  description = "name of the bucket"
+}
+
+variable "tags" {
+  type    = map(string)
+  default = { env = "dev" }
+}
+
+variable "password" {
+  sensitive = true
+  default   = null
+}
+
+output "arn" {
+  description = "ARN of the bucket"
+  value       = "arn"
+}
+`)
+	writeFile("outputs.tf", `
+output "secret" {
+  sensitive = true
+  value     = var.password
+}
+`)
+	writeFile("envs/prod/main.tf", `
+variable "region" {
+  default = "eu-west-2"
+}
+`)
+	tarball, err := internal.Pack(dir)
+	require.NoError(t, err)
+
+	t.Run("root", func(t *testing.T) {
+		got, err := parseMetadata(bytes.NewReader(tarball), "")
+		require.NoError(t, err)
+
+		assert.Equal(t, []Variable{
+			{Name: "name", Type: "string", Description: "name of the bucket", Required: true},
+			{Name: "password", Default: json.RawMessage(`null`), Sensitive: true},
+			{Name: "tags", Type: "map(string)", Default: json.RawMessage(`{"env":"dev"}`)},
+		}, got.Variables)
+		assert.Equal(t, []Output{
+			{Name: "arn", Description: "ARN of the bucket"},
+			{Name: "secret", Sensitive: true},
+		}, got.Outputs)
+	})
+
+	t.Run("working directory", func(t *testing.T) {
+		got, err := parseMetadata(bytes.NewReader(tarball), "envs/prod")
+		require.NoError(t, err)
+
+		assert.Equal(t, []Variable{
+			{Name: "region", Default: json.RawMessage(`"eu-west-2"`)},
+		}, got.Variables)
+		assert.Equal(t, []Output{}, got.Outputs)
+	})
+
+	t.Run("working directory cannot escape configuration", func(t *testing.T) {
+		got, err := parseMetadata(bytes.NewReader(tarball), "../../envs/prod")
+		require.NoError(t, err)
+
+		assert.Equal(t, "region", got.Variables[0].Name)
+	})
+
+	t.Run("missing working directory", func(t *testing.T) {
+		_, err := parseMetadata(bytes.NewReader(tarball), "envs/staging")
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,100 @@
+package configversion
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/leg100/otf/internal/rbac"
+	"github.com/leg100/otf/internal/sql"
+)
+
+// ErrStorageQuotaExceeded is returned when uploading a configuration tarball
+// would take its organization over its storage quota.
+var ErrStorageQuotaExceeded = errors.New("configuration storage quota exceeded")
+
+// Headers reporting the limits placed upon uploading a configuration tarball.
+const (
+	maxUploadSizeHeader    = "X-OTF-Max-Upload-Size"
+	storageQuotaHeader     = "X-OTF-Storage-Quota"
+	storageUsedHeader      = "X-OTF-Storage-Used"
+	storageRemainingHeader = "X-OTF-Storage-Remaining"
+)
+
+// Quota describes the limits placed upon uploading a configuration tarball to
+// a workspace, permitting a client to check whether an upload would be
+// accepted before attempting it.
+type Quota struct {
+	// MaxUploadSize is the maximum size in bytes of a tarball that would be
+	// accepted, being the lesser of the maximum configuration size and the
+	// remaining storage quota.
+	MaxUploadSize int64 `json:"max_upload_size"`
+	// StorageQuota is the maximum total size in bytes of the configuration
+	// tarballs in the workspace's organization. Nil means there is no quota.
+	StorageQuota *int64 `json:"storage_quota"`
+	// StorageUsed is the total size in bytes of the configuration tarballs
+	// in the workspace's organization.
+	StorageUsed int64 `json:"storage_used"`
+	// StorageRemaining is the number of bytes remaining before the quota is
+	// reached. Nil means there is no quota.
+	StorageRemaining *int64 `json:"storage_remaining"`
+}
+
+func newQuota(maxSize, quota, used int64) *Quota {
+	q := &Quota{MaxUploadSize: maxSize, StorageUsed: used}
+	if quota > 0 {
+		remaining := max(quota-used, 0)
+		q.StorageQuota = &quota
+		q.StorageRemaining = &remaining
+		q.MaxUploadSize = min(maxSize, remaining)
+	}
+	return q
+}
+
+// SetHeaders adds the quota to the headers of a response.
+func (q *Quota) SetHeaders(h http.Header) {
+	h.Set(maxUploadSizeHeader, strconv.FormatInt(q.MaxUploadSize, 10))
+	h.Set(storageUsedHeader, strconv.FormatInt(q.StorageUsed, 10))
+	if q.StorageQuota != nil {
+		h.Set(storageQuotaHeader, strconv.FormatInt(*q.StorageQuota, 10))
+		h.Set(storageRemainingHeader, strconv.FormatInt(*q.StorageRemaining, 10))
+	}
+}
+
+// GetQuota retrieves the limits placed upon uploading a configuration tarball
+// to a workspace.
+func (s *Service) GetQuota(ctx context.Context, workspaceID string) (*Quota, error) {
+	subject, err := s.workspace.CanAccess(ctx, rbac.CreateConfigurationVersionAction, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+	quota, err := s.getQuota(ctx, workspaceID)
+	if err != nil {
+		s.Error(err, "retrieving configuration upload quota", "workspace", workspaceID, "subject", subject)
+		return nil, err
+	}
+	s.V(9).Info("retrieved configuration upload quota", "workspace", workspaceID, "subject", subject)
+	return quota, nil
+}
+
+// UploadQuota retrieves the limits placed upon uploading the tarball of a
+// configuration version.
+//
+// NOTE: unauthenticated - access granted only via signed URL
+func (s *Service) UploadQuota(ctx context.Context, cvID string) (*Quota, error) {
+	cv, err := s.db.GetConfigurationVersion(ctx, ConfigurationVersionGetOptions{ID: &cvID})
+	if err != nil {
+		s.Error(err, "retrieving configuration upload quota", "id", cvID)
+		return nil, err
+	}
+	return s.getQuota(ctx, cv.WorkspaceID)
+}
+
+func (s *Service) getQuota(ctx context.Context, workspaceID string) (*Quota, error) {
+	used, err := s.db.Conn(ctx).FindConfigurationVersionStorageUsed(ctx, sql.String(workspaceID))
+	if err != nil {
+		return nil, sql.Error(err)
+	}
+	return newQuota(s.maxSize, s.storageQuota, used.Int), nil
+}
@@ -0,0 +1,85 @@
+package configversion
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/leg100/otf/internal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestQuota(t *testing.T) {
+	tests := []struct {
+		name                 string
+		maxSize, quota, used int64
+		want                 *Quota
+	}{
+		{
+			name:    "no quota",
+			maxSize: 100,
+			used:    1000,
+			want:    &Quota{MaxUploadSize: 100, StorageUsed: 1000},
+		},
+		{
+			name:    "max size within quota",
+			maxSize: 100,
+			quota:   1000,
+			used:    500,
+			want: &Quota{
+				MaxUploadSize:    100,
+				StorageQuota:     internal.Int64(1000),
+				StorageUsed:      500,
+				StorageRemaining: internal.Int64(500),
+			},
+		},
+		{
+			name:    "max size limited by quota",
+			maxSize: 100,
+			quota:   1000,
+			used:    950,
+			want: &Quota{
+				MaxUploadSize:    50,
+				StorageQuota:     internal.Int64(1000),
+				StorageUsed:      950,
+				StorageRemaining: internal.Int64(50),
+			},
+		},
+		{
+			name:    "quota exceeded",
+			maxSize: 100,
+			quota:   1000,
+			used:    1200,
+			want: &Quota{
+				MaxUploadSize:    0,
+				StorageQuota:     internal.Int64(1000),
+				StorageUsed:      1200,
+				StorageRemaining: internal.Int64(0),
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, newQuota(tt.maxSize, tt.quota, tt.used))
+		})
+	}
+
+	t.Run("headers", func(t *testing.T) {
+		h := make(http.Header)
+		newQuota(100, 1000, 950).SetHeaders(h)
+
+		assert.Equal(t, "50", h.Get("X-OTF-Max-Upload-Size"))
+		assert.Equal(t, "1000", h.Get("X-OTF-Storage-Quota"))
+		assert.Equal(t, "950", h.Get("X-OTF-Storage-Used"))
+		assert.Equal(t, "50", h.Get("X-OTF-Storage-Remaining"))
+	})
+
+	t.Run("headers without quota", func(t *testing.T) {
+		h := make(http.Header)
+		newQuota(100, 0, 950).SetHeaders(h)
+
+		assert.Equal(t, "100", h.Get("X-OTF-Max-Upload-Size"))
+		assert.Equal(t, "950", h.Get("X-OTF-Storage-Used"))
+		assert.NotContains(t, h, "X-Otf-Storage-Quota")
+		assert.NotContains(t, h, "X-Otf-Storage-Remaining")
+	})
+}
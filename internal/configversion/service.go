@@ -2,10 +2,15 @@ package configversion
 
 import (
 	"context"
+	"io"
+	"time"
 
 	"github.com/go-logr/logr"
 	"github.com/gorilla/mux"
 	"github.com/leg100/otf/internal"
+	"github.com/leg100/otf/internal/audit"
+	"github.com/leg100/otf/internal/blob"
+	"github.com/leg100/otf/internal/organization"
 	"github.com/leg100/otf/internal/rbac"
 	"github.com/leg100/otf/internal/resource"
 	"github.com/leg100/otf/internal/sql"
@@ -14,34 +19,137 @@ import (
 )
 
 type (
+	// ConfigurationVersionService provides all capabilities for managing
+	// configuration versions. Consumers should prefer to depend upon only
+	// those capabilities they need.
 	ConfigurationVersionService interface {
-		// By WorkspaceID
-		Create(context.Context, string, CreateOptions) (*ConfigurationVersion, error)
-		List(context.Context, string, ListOptions) (*resource.Page[*ConfigurationVersion], error)
-		GetLatest(context.Context, string) (*ConfigurationVersion, error)
+		Creator
+		Getter
+		Lister
+		Deleter
+		Uploader
+		ResumableUploader
+		Downloader
+		QuotaGetter
+	}
+
+	// Creator creates configuration versions.
+	Creator interface {
+		// Create creates a configuration version for the workspace with the
+		// given ID.
+		Create(ctx context.Context, workspaceID string, opts CreateOptions) (*ConfigurationVersion, error)
+	}
+
+	// Getter retrieves configuration versions.
+	Getter interface {
+		Get(ctx context.Context, cvID string) (*ConfigurationVersion, error)
+		// GetLatest retrieves the latest configuration version for the
+		// workspace with the given ID.
+		GetLatest(ctx context.Context, workspaceID string) (*ConfigurationVersion, error)
+	}
+
+	// Lister lists configuration versions.
+	Lister interface {
+		// List lists the configuration versions for the workspace with the
+		// given ID.
+		List(ctx context.Context, workspaceID string, opts ListOptions) (*resource.Page[*ConfigurationVersion], error)
+	}
+
+	// Deleter deletes configuration versions.
+	Deleter interface {
+		Delete(ctx context.Context, cvID string) error
+	}
+
+	// Uploader uploads configuration tarballs.
+	Uploader interface {
+		Upload(ctx context.Context, cvID string, config []byte) error
+	}
 
-		// By ConfigurationVersionID
-		Get(context.Context, string) (*ConfigurationVersion, error)
-		Delete(context.Context, string) error
-		Upload(context.Context, string, []byte) error
-		Download(context.Context, string) ([]byte, error)
+	// ResumableUploader uploads configuration tarballs in parts, so that a
+	// tarball need not be held in memory, and so that an interrupted upload
+	// can be resumed.
+	ResumableUploader interface {
+		// UploadPart reads part of a tarball from r and writes it at the
+		// given offset, returning the number of bytes uploaded so far. An
+		// offset of zero restarts the upload.
+		UploadPart(ctx context.Context, cvID string, offset int64, r io.Reader) (int64, error)
+		// UploadedSize returns the number of bytes uploaded so far.
+		UploadedSize(ctx context.Context, cvID string) (int64, error)
+		// CompleteUpload validates the uploaded tarball and associates it
+		// with the configuration version.
+		CompleteUpload(ctx context.Context, cvID string) error
+	}
+
+	// QuotaGetter retrieves the limits placed upon uploading configuration
+	// tarballs.
+	QuotaGetter interface {
+		// GetQuota retrieves the limits for the workspace with the given
+		// ID.
+		GetQuota(ctx context.Context, workspaceID string) (*Quota, error)
+		// UploadQuota retrieves the limits for uploading the tarball of the
+		// configuration version with the given ID.
+		UploadQuota(ctx context.Context, cvID string) (*Quota, error)
+	}
+
+	// Downloader downloads configuration tarballs.
+	Downloader interface {
+		Download(ctx context.Context, cvID string) ([]byte, error)
+		// SignedDownloadURL returns a signed URL from which the tarball can
+		// be downloaded until the URL expires.
+		SignedDownloadURL(ctx context.Context, cvID string, lifetime time.Duration) (string, error)
 	}
 
 	Service struct {
 		logr.Logger
 
-		workspace internal.Authorizer
+		workspace    internal.Authorizer
+		organization internal.Authorizer
+
+		db     *pgdb
+		blobs  blobClient
+		audit  auditor
+		cache  internal.Cache
+		signer *surl.Signer
+		api    *api
+		limits tarballLimits
+		// maximum size of a configuration tarball
+		maxSize int64
+		// maximum total size of an organization's configuration tarballs;
+		// zero means unlimited.
+		storageQuota int64
+	}
+
+	// blobClient stores and retrieves configuration tarballs.
+	blobClient interface {
+		Get(ctx context.Context, digest string) (io.ReadCloser, error)
+		WriteUpload(ctx context.Context, uploadID string, offset int64, r io.Reader) (int64, error)
+		UploadSize(ctx context.Context, uploadID string) (int64, error)
+		OpenUpload(ctx context.Context, uploadID string) (io.Reader, error)
+		CompleteUpload(ctx context.Context, uploadID, want string) (string, error)
+		AbortUpload(ctx context.Context, uploadID string) error
+	}
 
-		db    *pgdb
-		cache internal.Cache
-		api   *api
+	// auditor records downloads of configuration tarballs.
+	auditor interface {
+		RecordDownload(ctx context.Context, opts audit.RecordDownloadOptions) error
 	}
 
 	Options struct {
 		logr.Logger
 
 		WorkspaceAuthorizer internal.Authorizer
+		BlobService         *blob.Service
+		AuditService        *audit.Service
 		MaxConfigSize       int64
+		// MaxConfigUnpackedSize is the maximum size of a configuration once
+		// decompressed.
+		MaxConfigUnpackedSize int64
+		// MaxConfigFileSize is the maximum size of any one file in a
+		// decompressed configuration.
+		MaxConfigFileSize int64
+		// StorageQuota is the maximum total size of the configuration
+		// tarballs in an organization. Zero means unlimited.
+		StorageQuota int64
 
 		internal.Cache
 		*sql.DB
@@ -50,15 +158,27 @@ type (
 	}
 )
 
+var _ ConfigurationVersionService = (*Service)(nil)
+
 func NewService(opts Options) *Service {
 	svc := Service{
 		Logger: opts.Logger,
 	}
 
 	svc.workspace = opts.WorkspaceAuthorizer
+	svc.organization = &organization.Authorizer{Logger: opts.Logger}
 
 	svc.db = &pgdb{opts.DB}
+	svc.blobs = opts.BlobService
+	svc.audit = opts.AuditService
 	svc.cache = opts.Cache
+	svc.signer = opts.Signer
+	svc.maxSize = opts.MaxConfigSize
+	svc.storageQuota = opts.StorageQuota
+	svc.limits = tarballLimits{
+		unpackedSize: opts.MaxConfigUnpackedSize,
+		fileSize:     opts.MaxConfigFileSize,
+	}
 	svc.api = &api{
 		Service:   &svc,
 		Responder: opts.Responder,
@@ -82,7 +202,7 @@ func (s *Service) Create(ctx context.Context, workspaceID string, opts CreateOpt
 		s.Error(err, "constructing configuration version", "id", cv.ID, "subject", subject)
 		return nil, err
 	}
-	if err := s.db.CreateConfigurationVersion(ctx, cv); err != nil {
+	if err := s.db.CreateConfigurationVersion(ctx, cv, subject.String()); err != nil {
 		s.Error(err, "creating configuration version", "id", cv.ID, "subject", subject)
 		return nil, err
 	}
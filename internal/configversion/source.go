@@ -5,6 +5,9 @@ const (
 	SourceGithub    Source = "github"
 	SourceGitlab    Source = "gitlab"
 	SourceTerraform Source = "terraform+cloud"
+	// SourceVCS is the source of configuration versions created from a
+	// repository of a vcs kind without a dedicated source.
+	SourceVCS Source = "vcs"
 
 	DefaultSource = SourceAPI
 )
@@ -0,0 +1,135 @@
+package configversion
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+)
+
+const (
+	// Default maximum size of a configuration once decompressed is 100mb.
+	DefaultConfigUnpackedMaxSize int64 = 1024 * 1024 * 100
+	// Default maximum size of an individual file in a configuration is 50mb.
+	DefaultConfigFileMaxSize int64 = 1024 * 1024 * 50
+)
+
+// ErrInvalidConfig is returned when an uploaded configuration is not a valid
+// tarball.
+var ErrInvalidConfig = errors.New("invalid configuration tarball")
+
+// File is a file within a configuration tarball.
+type File struct {
+	// Path of the file relative to the root of the configuration.
+	Path string `json:"path"`
+	// Size of the file in bytes.
+	Size int64 `json:"size"`
+}
+
+// tarballLimits are limits on the decompressed contents of a configuration
+// tarball.
+type tarballLimits struct {
+	// maximum size of all files
+	unpackedSize int64
+	// maximum size of any one file
+	fileSize int64
+}
+
+// validateTarball checks the config is a well-formed gzipped tarball, that
+// its decompressed contents are within the limits, and that none of its
+// entries would be unpacked outside of the destination directory.
+func validateTarball(config io.Reader, limits tarballLimits) error {
+	gr, err := gzip.NewReader(config)
+	if err != nil {
+		return fmt.Errorf("%w: failed to decompress archive: %w", ErrInvalidConfig, err)
+	}
+	tr := tar.NewReader(gr)
+
+	var total int64
+	for {
+		header, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		} else if err != nil {
+			return fmt.Errorf("%w: failed to untar archive: %w", ErrInvalidConfig, err)
+		}
+		if err := validateTarballEntry(header); err != nil {
+			return fmt.Errorf("%w: %s: %w", ErrInvalidConfig, header.Name, err)
+		}
+		if header.Typeflag != tar.TypeReg {
+			continue
+		}
+		// read the contents rather than trust the size in the header, to
+		// verify the stream is intact too.
+		n, err := io.Copy(io.Discard, io.LimitReader(tr, limits.fileSize+1))
+		if err != nil {
+			return fmt.Errorf("%w: failed to read %s: %w", ErrInvalidConfig, header.Name, err)
+		}
+		if n > limits.fileSize {
+			return fmt.Errorf("%w: %s exceeds maximum file size (%d bytes)", ErrInvalidConfig, header.Name, limits.fileSize)
+		}
+		total += n
+		if total > limits.unpackedSize {
+			return fmt.Errorf("%w: exceeds maximum unpacked size (%d bytes)", ErrInvalidConfig, limits.unpackedSize)
+		}
+	}
+	return nil
+}
+
+// listTarball lists the regular files in a gzipped tarball, in the order in
+// which they appear.
+func listTarball(config io.Reader) ([]File, error) {
+	gr, err := gzip.NewReader(config)
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to decompress archive: %w", ErrInvalidConfig, err)
+	}
+	tr := tar.NewReader(gr)
+
+	files := []File{}
+	for {
+		header, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		} else if err != nil {
+			return nil, fmt.Errorf("%w: failed to untar archive: %w", ErrInvalidConfig, err)
+		}
+		if header.Typeflag != tar.TypeReg {
+			continue
+		}
+		files = append(files, File{Path: path.Clean(header.Name), Size: header.Size})
+	}
+	return files, nil
+}
+
+// validateTarballEntry rejects entries with paths, or symbolic or hard links,
+// that escape the directory into which the tarball is unpacked.
+func validateTarballEntry(header *tar.Header) error {
+	if escapes(header.Name) {
+		return errors.New("path traverses outside of configuration")
+	}
+	switch header.Typeflag {
+	case tar.TypeSymlink:
+		// symlink targets are relative to the directory containing the link
+		if path.IsAbs(header.Linkname) || escapes(path.Join(path.Dir(header.Name), header.Linkname)) {
+			return errors.New("symbolic link points outside of configuration")
+		}
+	case tar.TypeLink:
+		if escapes(header.Linkname) {
+			return errors.New("hard link points outside of configuration")
+		}
+	}
+	return nil
+}
+
+// escapes determines whether the path, relative to the destination directory,
+// resolves to a location outside of the directory.
+func escapes(name string) bool {
+	if name == "" || path.IsAbs(name) {
+		return true
+	}
+	cleaned := path.Clean(name)
+	return cleaned == ".." || strings.HasPrefix(cleaned, "../")
+}
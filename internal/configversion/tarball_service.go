@@ -1,9 +1,14 @@
 package configversion
 
 import (
+	"bytes"
 	"context"
 	"fmt"
+	"io"
+	"time"
 
+	"github.com/leg100/otf/internal"
+	"github.com/leg100/otf/internal/audit"
 	"github.com/leg100/otf/internal/rbac"
 )
 
@@ -12,42 +17,327 @@ func cacheKey(cvID string) string {
 	return fmt.Sprintf("%s.tar.gz", cvID)
 }
 
-// UploadConfig saves a configuration tarball to the db
+// UploadConfig saves a configuration tarball to the blob store and associates
+// it with the configuration version.
 //
 // NOTE: unauthenticated - access granted only via signed URL
 func (s *Service) UploadConfig(ctx context.Context, cvID string, config []byte) error {
-	err := s.db.UploadConfigurationVersion(ctx, cvID, func(cv *ConfigurationVersion, uploader ConfigUploader) error {
-		return cv.Upload(ctx, config, uploader)
+	if _, err := s.UploadPart(ctx, cvID, 0, bytes.NewReader(config)); err != nil {
+		return err
+	}
+	if err := s.CompleteUpload(ctx, cvID); err != nil {
+		return err
+	}
+	if err := s.cache.Set(cacheKey(cvID), config); err != nil {
+		s.Error(err, "caching configuration version tarball")
+	}
+	return nil
+}
+
+// UploadPart reads part of a configuration tarball from r and writes it at the
+// given offset, returning the number of bytes uploaded so far. An offset of
+// zero restarts the upload. The upload is only associated with the
+// configuration version once it is completed with CompleteUpload.
+//
+// NOTE: unauthenticated - access granted only via signed URL
+func (s *Service) UploadPart(ctx context.Context, cvID string, offset int64, r io.Reader) (int64, error) {
+	// the configuration version ID doubles as the upload ID
+	size, err := s.blobs.WriteUpload(ctx, cvID, offset, r)
+	if err != nil {
+		s.Error(err, "uploading configuration part", "id", cvID, "offset", offset)
+		return size, err
+	}
+	s.V(9).Info("uploaded configuration part", "id", cvID, "offset", offset, "size", size)
+	return size, nil
+}
+
+// UploadedSize returns the number of bytes of a configuration tarball
+// uploaded so far.
+//
+// NOTE: unauthenticated - access granted only via signed URL
+func (s *Service) UploadedSize(ctx context.Context, cvID string) (int64, error) {
+	return s.blobs.UploadSize(ctx, cvID)
+}
+
+// CompleteUpload validates the uploaded configuration tarball, saves it to the
+// blob store and associates it with the configuration version.
+//
+// NOTE: unauthenticated - access granted only via signed URL
+func (s *Service) CompleteUpload(ctx context.Context, cvID string) error {
+	// the tarball is read from the store rather than held in memory, once to
+	// validate it, and once more each to parse its dependencies and its
+	// metadata.
+	tarball, err := s.blobs.OpenUpload(ctx, cvID)
+	if err != nil {
+		s.Error(err, "opening configuration upload", "id", cvID)
+		return err
+	}
+	quota, err := s.UploadQuota(ctx, cvID)
+	if err != nil {
+		return err
+	}
+	size, err := s.blobs.UploadSize(ctx, cvID)
+	if err != nil {
+		s.Error(err, "retrieving configuration upload size", "id", cvID)
+		return err
+	}
+	err = validateTarball(tarball, s.limits)
+	if err == nil && quota.StorageQuota != nil && size > *quota.StorageRemaining {
+		err = fmt.Errorf("%w: %d bytes remaining", ErrStorageQuotaExceeded, *quota.StorageRemaining)
+	}
+	if err != nil {
+		s.Error(err, "validating configuration", "id", cvID)
+		// mark the configuration version as errored so that it is not used
+		// for a run.
+		seterr := s.db.UploadConfigurationVersion(ctx, cvID, func(cv *ConfigurationVersion, uploader ConfigUploader) error {
+			return uploader.SetErrored(ctx)
+		})
+		if seterr != nil {
+			s.Error(seterr, "setting configuration version status to errored", "id", cvID)
+		}
+		if err := s.blobs.AbortUpload(ctx, cvID); err != nil {
+			s.Error(err, "discarding configuration upload", "id", cvID)
+		}
+		return &internal.InvalidParameterError{Parameter: "configuration", Err: err}
+	}
+	// record modules and providers the configuration depends upon, for the
+	// consumption report; failure to do so is not fatal.
+	var deps []Dependency
+	if tarball, err := s.blobs.OpenUpload(ctx, cvID); err != nil {
+		s.Error(err, "opening configuration upload", "id", cvID)
+	} else if deps, err = parseDependencies(tarball); err != nil {
+		s.Error(err, "parsing configuration dependencies", "id", cvID)
+	}
+	// record the variables and outputs of the root module, to describe the
+	// configuration's inputs and outputs; failure to do so is not fatal.
+	meta, err := s.parseMetadata(ctx, cvID)
+	if err != nil {
+		s.Error(err, "parsing configuration metadata", "id", cvID)
+	}
+	digest, err := s.blobs.CompleteUpload(ctx, cvID, "")
+	if err != nil {
+		s.Error(err, "storing configuration", "id", cvID)
+		return err
+	}
+	err = s.db.UploadConfigurationVersion(ctx, cvID, func(cv *ConfigurationVersion, uploader ConfigUploader) error {
+		return cv.Upload(ctx, digest, uploader)
 	})
 	if err != nil {
 		s.Error(err, "uploading configuration")
 		return err
 	}
-	if err := s.cache.Set(cacheKey(cvID), config); err != nil {
-		s.Error(err, "caching configuration version tarball")
+	if deps != nil {
+		if err := s.db.createDependencies(ctx, cvID, deps); err != nil {
+			s.Error(err, "recording configuration dependencies", "id", cvID)
+		}
+	}
+	if meta != nil {
+		if err := s.db.createMetadata(ctx, cvID, meta); err != nil {
+			s.Error(err, "recording configuration metadata", "id", cvID)
+		}
 	}
-	s.V(2).Info("uploaded configuration", "id", cvID, "bytes", len(config))
+	s.V(2).Info("uploaded configuration", "id", cvID, "digest", digest)
 	return nil
 }
 
-// DownloadConfig retrieves a tarball from the db
+// parseMetadata parses the metadata of the root module of an uploaded
+// configuration, which is found in the working directory of the workspace.
+func (s *Service) parseMetadata(ctx context.Context, cvID string) (*Metadata, error) {
+	workingDir, err := s.db.getWorkingDirectory(ctx, cvID)
+	if err != nil {
+		return nil, err
+	}
+	tarball, err := s.blobs.OpenUpload(ctx, cvID)
+	if err != nil {
+		return nil, err
+	}
+	return parseMetadata(tarball, workingDir)
+}
+
+// DownloadConfig retrieves a tarball from the blob store, recording the
+// download in the audit trail.
 func (s *Service) DownloadConfig(ctx context.Context, cvID string) ([]byte, error) {
-	subject, err := s.canAccess(ctx, rbac.DownloadConfigurationVersionAction, cvID)
+	subject, err := s.authorizeDownload(ctx, cvID)
 	if err != nil {
 		return nil, err
 	}
+	config, err := s.getConfig(ctx, cvID)
+	if err != nil {
+		s.Error(err, "downloading configuration", "id", cvID, "subject", subject)
+		return nil, err
+	}
+	s.V(9).Info("downloaded configuration", "id", cvID, "bytes", len(config), "subject", subject)
+	return config, nil
+}
+
+// downloadSignedConfig retrieves a tarball and its digest on behalf of a
+// client presenting a signed URL. The download was authorized and recorded in
+// the audit trail when the URL was signed, on behalf of the subject to whom
+// the URL was issued.
+//
+// NOTE: unauthenticated - access granted only via signed URL
+func (s *Service) downloadSignedConfig(ctx context.Context, cvID string) ([]byte, string, error) {
+	digest, err := s.db.GetConfigDigest(ctx, cvID)
+	if err != nil {
+		return nil, "", err
+	}
+	config, err := s.getConfig(ctx, cvID)
+	if err != nil {
+		s.Error(err, "downloading configuration via signed URL", "id", cvID)
+		return nil, "", err
+	}
+	s.V(9).Info("downloaded configuration via signed URL", "id", cvID, "bytes", len(config))
+	return config, digest, nil
+}
 
+// authorizeDownload authorizes the subject in the context to download a
+// tarball and records the download in the audit trail, along with the
+// subject's token and IP address.
+func (s *Service) authorizeDownload(ctx context.Context, cvID string) (internal.Subject, error) {
+	// refuse an unauthenticated caller before disclosing whether the
+	// configuration version exists.
+	if _, err := internal.SubjectFromContext(ctx); err != nil {
+		return nil, err
+	}
+	cv, err := s.db.GetConfigurationVersion(ctx, ConfigurationVersionGetOptions{ID: &cvID})
+	if err != nil {
+		return nil, err
+	}
+	subject, err := s.workspace.CanAccess(ctx, rbac.DownloadConfigurationVersionAction, cv.WorkspaceID)
+	if err != nil {
+		return nil, err
+	}
+	err = s.audit.RecordDownload(ctx, audit.RecordDownloadOptions{
+		Action:      audit.ConfigurationDownloadAction,
+		ResourceID:  cvID,
+		WorkspaceID: cv.WorkspaceID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return subject, nil
+}
+
+// ListFiles lists the files in the configuration tarball.
+func (s *Service) ListFiles(ctx context.Context, cvID string) ([]File, error) {
+	subject, err := s.canAccess(ctx, rbac.GetConfigurationVersionAction, cvID)
+	if err != nil {
+		return nil, err
+	}
+
+	config, err := s.getConfig(ctx, cvID)
+	if err != nil {
+		s.Error(err, "listing configuration files", "id", cvID, "subject", subject)
+		return nil, err
+	}
+	files, err := listTarball(bytes.NewReader(config))
+	if err != nil {
+		s.Error(err, "listing configuration files", "id", cvID, "subject", subject)
+		return nil, err
+	}
+	s.V(9).Info("listed configuration files", "id", cvID, "files", len(files), "subject", subject)
+	return files, nil
+}
+
+// Diff produces a file-level diff from one configuration version to another
+// of the same workspace. Requesting unified diffs discloses the contents of
+// the configurations, in which case the subject needs permission to download
+// them, and the downloads are recorded in the audit log.
+func (s *Service) Diff(ctx context.Context, fromID, toID string, opts DiffOptions) (*Diff, error) {
+	from, err := s.db.GetConfigurationVersion(ctx, ConfigurationVersionGetOptions{ID: &fromID})
+	if err != nil {
+		return nil, err
+	}
+	to, err := s.db.GetConfigurationVersion(ctx, ConfigurationVersionGetOptions{ID: &toID})
+	if err != nil {
+		return nil, err
+	}
+	action := rbac.GetConfigurationVersionAction
+	if opts.Unified {
+		action = rbac.DownloadConfigurationVersionAction
+	}
+	subject, err := s.workspace.CanAccess(ctx, action, to.WorkspaceID)
+	if err != nil {
+		return nil, err
+	}
+	if from.WorkspaceID != to.WorkspaceID {
+		return nil, ErrDiffAcrossWorkspaces
+	}
+	if opts.Unified {
+		for _, cvID := range []string{fromID, toID} {
+			err := s.audit.RecordDownload(ctx, audit.RecordDownloadOptions{
+				Action:      audit.ConfigurationDownloadAction,
+				ResourceID:  cvID,
+				WorkspaceID: to.WorkspaceID,
+			})
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	fromConfig, err := s.getConfig(ctx, fromID)
+	if err != nil {
+		s.Error(err, "diffing configuration versions", "from", fromID, "to", toID, "subject", subject)
+		return nil, err
+	}
+	toConfig, err := s.getConfig(ctx, toID)
+	if err != nil {
+		s.Error(err, "diffing configuration versions", "from", fromID, "to", toID, "subject", subject)
+		return nil, err
+	}
+	files, err := diffTarballs(bytes.NewReader(fromConfig), bytes.NewReader(toConfig), opts.Unified)
+	if err != nil {
+		s.Error(err, "diffing configuration versions", "from", fromID, "to", toID, "subject", subject)
+		return nil, err
+	}
+	s.V(9).Info("diffed configuration versions", "from", fromID, "to", toID, "files", len(files), "subject", subject)
+	return &Diff{From: fromID, To: toID, Files: files}, nil
+}
+
+// getConfig retrieves a tarball, from the cache if possible.
+func (s *Service) getConfig(ctx context.Context, cvID string) ([]byte, error) {
 	if config, err := s.cache.Get(cacheKey(cvID)); err == nil {
 		return config, nil
 	}
-	config, err := s.db.GetConfig(ctx, cvID)
+	config, err := s.readConfig(ctx, cvID)
 	if err != nil {
-		s.Error(err, "downloading configuration", "id", cvID, "subject", subject)
 		return nil, err
 	}
 	if err := s.cache.Set(cacheKey(cvID), config); err != nil {
 		s.Error(err, "caching configuration version tarball")
 	}
-	s.V(9).Info("downloaded configuration", "id", cvID, "bytes", len(config), "subject", subject)
 	return config, nil
 }
+
+// SignedDownloadURL returns a signed URL from which the configuration
+// tarball can be downloaded until the URL expires. The download is recorded in
+// the audit trail now, on behalf of the subject in the context, because the
+// client presenting the URL is not authenticated.
+func (s *Service) SignedDownloadURL(ctx context.Context, cvID string, lifetime time.Duration) (string, error) {
+	subject, err := s.authorizeDownload(ctx, cvID)
+	if err != nil {
+		return "", err
+	}
+	url, err := s.signer.Sign(fmt.Sprintf("/configuration-versions/%s/download", cvID), lifetime)
+	if err != nil {
+		s.Error(err, "signing configuration download URL", "id", cvID, "subject", subject)
+		return "", err
+	}
+	s.V(9).Info("signed configuration download URL", "id", cvID, "subject", subject)
+	return url, nil
+}
+
+func (s *Service) readConfig(ctx context.Context, cvID string) ([]byte, error) {
+	digest, err := s.db.GetConfigDigest(ctx, cvID)
+	if err != nil {
+		return nil, err
+	}
+	blob, err := s.blobs.Get(ctx, digest)
+	if err != nil {
+		return nil, err
+	}
+	defer blob.Close()
+
+	return io.ReadAll(blob)
+}
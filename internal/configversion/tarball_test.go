@@ -0,0 +1,125 @@
+package configversion
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateTarball(t *testing.T) {
+	limits := tarballLimits{unpackedSize: 100, fileSize: 60}
+
+	tests := []struct {
+		name    string
+		tarball []byte
+		wantErr bool
+	}{
+		{
+			name: "valid",
+			tarball: newTestTarball(t,
+				&tar.Header{Name: "modules/", Typeflag: tar.TypeDir},
+				&tar.Header{Name: "main.tf", Typeflag: tar.TypeReg, Size: 50},
+				&tar.Header{Name: "modules/vpc.tf", Typeflag: tar.TypeReg, Size: 50},
+				&tar.Header{Name: "modules/link.tf", Typeflag: tar.TypeSymlink, Linkname: "../main.tf"},
+			),
+		},
+		{
+			name:    "not gzipped",
+			tarball: []byte("not a tarball"),
+			wantErr: true,
+		},
+		{
+			name:    "truncated",
+			tarball: newTestTarball(t, &tar.Header{Name: "main.tf", Typeflag: tar.TypeReg, Size: 50})[:20],
+			wantErr: true,
+		},
+		{
+			name:    "file too large",
+			tarball: newTestTarball(t, &tar.Header{Name: "main.tf", Typeflag: tar.TypeReg, Size: 61}),
+			wantErr: true,
+		},
+		{
+			name: "unpacked size too large",
+			tarball: newTestTarball(t,
+				&tar.Header{Name: "a.tf", Typeflag: tar.TypeReg, Size: 60},
+				&tar.Header{Name: "b.tf", Typeflag: tar.TypeReg, Size: 41},
+			),
+			wantErr: true,
+		},
+		{
+			name:    "path traversal",
+			tarball: newTestTarball(t, &tar.Header{Name: "modules/../../main.tf", Typeflag: tar.TypeReg}),
+			wantErr: true,
+		},
+		{
+			name:    "absolute path",
+			tarball: newTestTarball(t, &tar.Header{Name: "/etc/passwd", Typeflag: tar.TypeReg}),
+			wantErr: true,
+		},
+		{
+			name:    "symlink outside of configuration",
+			tarball: newTestTarball(t, &tar.Header{Name: "modules/link", Typeflag: tar.TypeSymlink, Linkname: "../../etc"}),
+			wantErr: true,
+		},
+		{
+			name:    "absolute symlink",
+			tarball: newTestTarball(t, &tar.Header{Name: "link", Typeflag: tar.TypeSymlink, Linkname: "/etc/passwd"}),
+			wantErr: true,
+		},
+		{
+			name:    "hard link outside of configuration",
+			tarball: newTestTarball(t, &tar.Header{Name: "link", Typeflag: tar.TypeLink, Linkname: "../passwd"}),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateTarball(bytes.NewReader(tt.tarball), limits)
+			if tt.wantErr {
+				assert.ErrorIs(t, err, ErrInvalidConfig)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+// newTestTarball constructs a gzipped tarball containing the given entries,
+// with regular files filled to the size in their header.
+func TestListTarball(t *testing.T) {
+	tarball := newTestTarball(t,
+		&tar.Header{Name: "modules/", Typeflag: tar.TypeDir},
+		&tar.Header{Name: "./main.tf", Typeflag: tar.TypeReg, Size: 50},
+		&tar.Header{Name: "modules/vpc.tf", Typeflag: tar.TypeReg, Size: 20},
+		&tar.Header{Name: "modules/link.tf", Typeflag: tar.TypeSymlink, Linkname: "../main.tf"},
+	)
+
+	got, err := listTarball(bytes.NewReader(tarball))
+	require.NoError(t, err)
+
+	assert.Equal(t, []File{
+		{Path: "main.tf", Size: 50},
+		{Path: "modules/vpc.tf", Size: 20},
+	}, got)
+}
+
+func newTestTarball(t *testing.T, headers ...*tar.Header) []byte {
+	var buf bytes.Buffer
+	gw := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gw)
+	for _, hdr := range headers {
+		hdr.Mode = 0o644
+		require.NoError(t, tw.WriteHeader(hdr))
+		if hdr.Typeflag == tar.TypeReg {
+			_, err := tw.Write(bytes.Repeat([]byte("x"), int(hdr.Size)))
+			require.NoError(t, err)
+		}
+	}
+	require.NoError(t, tw.Close())
+	require.NoError(t, gw.Close())
+	return buf.Bytes()
+}
@@ -0,0 +1,114 @@
+package configversion
+
+import (
+	"context"
+
+	"github.com/leg100/otf/internal/rbac"
+	"github.com/leg100/otf/internal/sql"
+)
+
+const (
+	// Categories by which usage is broken down.
+	UsageCategoryAPI = "api"
+	UsageCategoryCLI = "cli"
+	UsageCategoryVCS = "vcs"
+)
+
+type (
+	// Usage summarises the storage consumed by configuration versions.
+	Usage struct {
+		// Count is the number of configuration versions
+		Count int64 `json:"count"`
+		// Bytes is the total size of uploaded configuration tarballs
+		Bytes int64 `json:"bytes"`
+		// Sources breaks down usage by the category of source from which
+		// configuration versions originate, i.e. api, cli, or vcs.
+		Sources map[string]SourceUsage `json:"sources"`
+	}
+
+	// SourceUsage summarises the storage consumed by configuration versions
+	// originating from a category of source.
+	SourceUsage struct {
+		Count int64 `json:"count"`
+		Bytes int64 `json:"bytes"`
+	}
+
+	// WorkspaceUsage is the storage consumed by a workspace's configuration
+	// versions.
+	WorkspaceUsage struct {
+		WorkspaceID   string `json:"workspace_id"`
+		WorkspaceName string `json:"workspace_name"`
+		Usage
+	}
+
+	// OrganizationUsage is the storage consumed by an organization's
+	// configuration versions, in total and per workspace.
+	OrganizationUsage struct {
+		Organization string `json:"organization"`
+		Usage
+		Workspaces []*WorkspaceUsage `json:"workspaces"`
+	}
+)
+
+// usageCategory maps a source to its category.
+func usageCategory(src Source) string {
+	switch src {
+	case SourceAPI:
+		return UsageCategoryAPI
+	case SourceTerraform:
+		return UsageCategoryCLI
+	case SourceGithub, SourceGitlab, SourceVCS:
+		return UsageCategoryVCS
+	default:
+		return string(src)
+	}
+}
+
+func (u *Usage) add(src Source, count, bytes int64) {
+	u.Count += count
+	u.Bytes += bytes
+	if u.Sources == nil {
+		u.Sources = make(map[string]SourceUsage)
+	}
+	category := usageCategory(src)
+	su := u.Sources[category]
+	su.Count += count
+	su.Bytes += bytes
+	u.Sources[category] = su
+}
+
+// GetOrganizationUsage reports the number and size of configuration versions
+// in an organization, in total and per workspace. Workspaces without any
+// configuration versions are omitted.
+func (s *Service) GetOrganizationUsage(ctx context.Context, organization string) (*OrganizationUsage, error) {
+	subject, err := s.organization.CanAccess(ctx, rbac.GetConfigurationVersionUsageAction, organization)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := s.db.Conn(ctx).FindConfigurationVersionUsageByOrganization(ctx, sql.String(organization))
+	if err != nil {
+		s.Error(err, "retrieving configuration version usage", "organization", organization, "subject", subject)
+		return nil, sql.Error(err)
+	}
+	usage := &OrganizationUsage{
+		Organization: organization,
+		Usage:        Usage{Sources: make(map[string]SourceUsage)},
+		Workspaces:   []*WorkspaceUsage{},
+	}
+	// rows are ordered by workspace
+	var current *WorkspaceUsage
+	for _, row := range rows {
+		if current == nil || current.WorkspaceID != row.WorkspaceID.String {
+			current = &WorkspaceUsage{
+				WorkspaceID:   row.WorkspaceID.String,
+				WorkspaceName: row.WorkspaceName.String,
+			}
+			usage.Workspaces = append(usage.Workspaces, current)
+		}
+		src := Source(row.Source.String)
+		current.add(src, row.Count.Int, row.Bytes.Int)
+		usage.add(src, row.Count.Int, row.Bytes.Int)
+	}
+	s.V(9).Info("retrieved configuration version usage", "organization", organization, "workspaces", len(usage.Workspaces), "subject", subject)
+	return usage, nil
+}
@@ -44,6 +44,7 @@ func TestDiscovery(t *testing.T) {
 	require.Equal(t, []interface{}{float64(10000), float64(10010)}, res["login.v1"].(map[string]interface{})["ports"])
 	require.Equal(t, tfeapi.ModuleV1Prefix, res["modules.v1"])
 	require.Equal(t, "/api/terraform/motd", res["motd.v1"])
+	require.Equal(t, tfeapi.ProviderV1Prefix, res["providers.v1"])
 	require.Equal(t, tfeapi.APIPrefixV2, res["state.v2"])
 	require.Equal(t, tfeapi.APIPrefixV2, res["tfe.v2"])
 	require.Equal(t, tfeapi.APIPrefixV2, res["tfe.v2.1"])
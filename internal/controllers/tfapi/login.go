@@ -21,10 +21,12 @@ import (
 	"encoding/json"
 	"net/http"
 	"net/url"
+	"sort"
 
 	"github.com/leg100/otf/internal"
 	"github.com/leg100/otf/internal/http/decode"
 	"github.com/leg100/otf/internal/http/html"
+	"github.com/leg100/otf/internal/tokens"
 	"github.com/leg100/otf/internal/user"
 )
 
@@ -46,6 +48,13 @@ type (
 		CodeChallenge       string `json:"code_challenge"`
 		CodeChallengeMethod string `json:"code_challenge_method"`
 		Username            string `json:"username"`
+		// Organization and Scopes optionally restrict the token issued in
+		// exchange for the code.
+		Organization string   `json:"organization,omitempty"`
+		Scopes       []string `json:"scopes,omitempty"`
+		// SSO is true if the user authenticated via single sign-on, in which
+		// case the token issued in exchange for the code is marked likewise.
+		SSO bool `json:"sso,omitempty"`
 	}
 )
 
@@ -58,7 +67,9 @@ func (s *TerraformAPIService) Auth(w http.ResponseWriter, r *http.Request) {
 		ResponseType        string `schema:"response_type"`
 		State               string `schema:"state"`
 
-		Consented bool `schema:"consented"`
+		Consented    bool     `schema:"consented"`
+		Organization string   `schema:"organization"`
+		Scopes       []string `schema:"scopes"`
 	}
 	if err := decode.All(&params, r); err != nil {
 		http.Error(w, err.Error(), http.StatusUnprocessableEntity)
@@ -89,26 +100,39 @@ func (s *TerraformAPIService) Auth(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if r.Method == "GET" {
-		s.renderer.Render("consent.tmpl", w, html.NewSitePage(r, "consent"))
+	u, err := user.UserFromContext(r.Context())
+	if err != nil {
+		tr.Error(ErrServerError, err.Error())
 		return
 	}
 
-	if !params.Consented {
-		tr.Error(ErrAccessDenied, "user denied consent")
+	if r.Method == "GET" {
+		organizations := u.Organizations()
+		sort.Strings(organizations)
+		s.renderer.Render("consent.tmpl", w, struct {
+			html.SitePage
+			Organizations []string
+			Scopes        []string
+		}{
+			SitePage:      html.NewSitePage(r, "consent"),
+			Organizations: organizations,
+			Scopes:        user.TokenScopes(),
+		})
 		return
 	}
 
-	user, err := user.UserFromContext(r.Context())
-	if err != nil {
-		tr.Error(ErrServerError, err.Error())
+	if !params.Consented {
+		tr.Error(ErrAccessDenied, "user denied consent")
 		return
 	}
 
 	marshaled, err := json.Marshal(&authcode{
 		CodeChallenge:       params.CodeChallenge,
 		CodeChallengeMethod: params.CodeChallengeMethod,
-		Username:            user.Username,
+		Username:            u.Username,
+		Organization:        params.Organization,
+		Scopes:              params.Scopes,
+		SSO:                 tokens.IsSSO(r.Context()),
 	})
 	if err != nil {
 		tr.Error(ErrServerError, err.Error())
@@ -188,9 +212,17 @@ func (s *TerraformAPIService) Token(w http.ResponseWriter, r *http.Request) {
 
 	// Create API token for user and include in response
 	userCtx := internal.AddSubjectToContext(r.Context(), &user.User{Username: code.Username})
-	_, token, err := s.tok.CreateToken(userCtx, user.CreateUserTokenOptions{
+	if code.SSO {
+		userCtx = tokens.AddSSOToContext(userCtx)
+	}
+	opts := user.CreateUserTokenOptions{
 		Description: "terraform login",
-	})
+		Scopes:      code.Scopes,
+	}
+	if code.Organization != "" {
+		opts.Organization = &code.Organization
+	}
+	_, token, err := s.tok.CreateToken(userCtx, opts)
 	if err != nil {
 		tr.Error(ErrInvalidRequest, err.Error())
 		return
@@ -21,26 +21,35 @@ import (
 	"encoding/base64"
 	"encoding/json"
 	"net/http/httptest"
+	"net/url"
 	"testing"
 
 	"github.com/leg100/otf/internal"
 	"github.com/leg100/otf/internal/testutils"
+	"github.com/leg100/otf/internal/tokens"
 	"github.com/leg100/otf/internal/user"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
 type (
-	creator struct{}
+	creator struct {
+		opts user.CreateUserTokenOptions
+		// claims are the SSO claims the token would be issued with
+		claims map[string]string
+	}
 )
 
 func (c *creator) CreateToken(ctx context.Context, opts user.CreateUserTokenOptions) (*user.UserToken, []byte, error) {
+	c.opts = opts
+	c.claims = tokens.SSOClaims(ctx)
 	return nil, nil, nil
 }
 
 func TestLogin(t *testing.T) {
 	secret := testutils.NewSecret(t)
-	srv := NewTerraformAPIService(secret, &creator{}, testutils.NewRenderer(t))
+	tokenCreator := &creator{}
+	srv := NewTerraformAPIService(secret, tokenCreator, testutils.NewRenderer(t))
 
 	t.Run("AuthHandler", func(t *testing.T) {
 		q := "/?"
@@ -113,4 +122,115 @@ func TestLogin(t *testing.T) {
 		require.NoError(t, err)
 
 	})
+
+	t.Run("restricted token", func(t *testing.T) {
+		verifier := "myverifier"
+		hash := sha256.Sum256([]byte(verifier))
+		challenge := base64.RawURLEncoding.EncodeToString(hash[:])
+
+		q := "/?"
+		q += "redirect_uri=https://localhost:10000"
+		q += "&client_id=terraform"
+		q += "&response_type=code"
+		q += "&consented=true"
+		q += "&code_challenge=" + challenge
+		q += "&code_challenge_method=S256"
+		q += "&organization=acme-corp"
+		q += "&scopes=plan"
+
+		r := httptest.NewRequest("POST", q, nil)
+		r = r.WithContext(internal.AddSubjectToContext(r.Context(), &user.User{Username: "bobby"}))
+		w := httptest.NewRecorder()
+		srv.Auth(w, r)
+
+		require.Equal(t, 302, w.Code)
+		redirect, err := w.Result().Location()
+		require.NoError(t, err)
+		require.Empty(t, redirect.Query().Get("error"))
+
+		q = "/?"
+		q += "redirect_uri=https://localhost:10000"
+		q += "&client_id=terraform"
+		q += "&grant_type=authorization_code"
+		q += "&code=" + url.QueryEscape(redirect.Query().Get("code"))
+		q += "&code_verifier=" + verifier
+
+		r = httptest.NewRequest("POST", q, nil)
+		w = httptest.NewRecorder()
+		srv.Token(w, r)
+
+		require.Equal(t, 200, w.Code, w.Body.String())
+		assert.Equal(t, "acme-corp", *tokenCreator.opts.Organization)
+		assert.Equal(t, []string{"plan"}, tokenCreator.opts.Scopes)
+	})
+
+	t.Run("single sign-on", func(t *testing.T) {
+		verifier := "myverifier"
+		hash := sha256.Sum256([]byte(verifier))
+		challenge := base64.RawURLEncoding.EncodeToString(hash[:])
+
+		q := "/?"
+		q += "redirect_uri=https://localhost:10000"
+		q += "&client_id=terraform"
+		q += "&response_type=code"
+		q += "&consented=true"
+		q += "&code_challenge=" + challenge
+		q += "&code_challenge_method=S256"
+
+		// user logged into the web app via single sign-on
+		r := httptest.NewRequest("POST", q, nil)
+		ctx := internal.AddSubjectToContext(r.Context(), &user.User{Username: "bobby"})
+		r = r.WithContext(tokens.AddSSOToContext(ctx))
+		w := httptest.NewRecorder()
+		srv.Auth(w, r)
+
+		require.Equal(t, 302, w.Code)
+		redirect, err := w.Result().Location()
+		require.NoError(t, err)
+		require.Empty(t, redirect.Query().Get("error"))
+
+		// terraform exchanges the code without any credentials
+		q = "/?"
+		q += "redirect_uri=https://localhost:10000"
+		q += "&client_id=terraform"
+		q += "&grant_type=authorization_code"
+		q += "&code=" + url.QueryEscape(redirect.Query().Get("code"))
+		q += "&code_verifier=" + verifier
+
+		r = httptest.NewRequest("POST", q, nil)
+		w = httptest.NewRecorder()
+		srv.Token(w, r)
+
+		require.Equal(t, 200, w.Code, w.Body.String())
+		assert.Equal(t, map[string]string{"sso": "true"}, tokenCreator.claims)
+	})
+
+	t.Run("without single sign-on", func(t *testing.T) {
+		verifier := "myverifier"
+		hash := sha256.Sum256([]byte(verifier))
+		challenge := base64.RawURLEncoding.EncodeToString(hash[:])
+
+		mashaled, err := json.Marshal(&authcode{
+			CodeChallenge:       challenge,
+			CodeChallengeMethod: "S256",
+			Username:            "bobby",
+		})
+		require.NoError(t, err)
+		code, err := internal.Encrypt(mashaled, []byte(secret))
+		require.NoError(t, err)
+
+		q := "/?"
+		q += "redirect_uri=https://localhost:10000"
+		q += "&client_id=terraform"
+		q += "&grant_type=authorization_code"
+		q += "&code=" + url.QueryEscape(code)
+		q += "&code_verifier=" + verifier
+
+		r := httptest.NewRequest("POST", q, nil)
+		w := httptest.NewRecorder()
+		srv.Token(w, r)
+
+		require.Equal(t, 200, w.Code, w.Body.String())
+		assert.Nil(t, tokenCreator.claims)
+	})
 }
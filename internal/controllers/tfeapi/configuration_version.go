@@ -17,13 +17,16 @@ package tfeapi
 
 import (
 	"context"
+	"errors"
 	"fmt"
-	"io"
 	"net/http"
 	"reflect"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/leg100/otf/internal"
+	"github.com/leg100/otf/internal/blob"
 	"github.com/leg100/otf/internal/configversion"
 	ihttp "github.com/leg100/otf/internal/http"
 	"github.com/leg100/otf/internal/http/decode"
@@ -38,7 +41,7 @@ func (s *TerraformEnterpriseAPIService) getConfigurationVersion(r *http.Request)
 		return nil, err
 	}
 
-	cv, err := s.cv.Get(r.Context(), id)
+	cv, err := s.cvGetter.Get(r.Context(), id)
 	if err != nil {
 		return nil, err
 	}
@@ -60,7 +63,7 @@ func (s *TerraformEnterpriseAPIService) listConfigurationVersions(r *http.Reques
 		PageOptions: p.PageOptions,
 	}
 
-	page, err := s.cv.List(r.Context(), p.WorkspaceID, opts)
+	page, err := s.cvLister.List(r.Context(), p.WorkspaceID, opts)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -73,6 +76,8 @@ func (s *TerraformEnterpriseAPIService) listConfigurationVersions(r *http.Reques
 	return items, page.Pagination, nil
 }
 
+// downloadConfigurationVersion redirects the client to a signed URL from which
+// the configuration tarball can be downloaded.
 func (s *TerraformEnterpriseAPIService) downloadConfigurationVersion(w http.ResponseWriter, r *http.Request) {
 	id, err := decode.Param("id", r)
 	if err != nil {
@@ -80,13 +85,13 @@ func (s *TerraformEnterpriseAPIService) downloadConfigurationVersion(w http.Resp
 		return
 	}
 
-	buf, err := s.cv.Download(r.Context(), id)
+	url, err := s.cvDownloader.SignedDownloadURL(r.Context(), id, time.Hour)
 	if err != nil {
 		tfeapi.Error(w, err)
 		return
 	}
 
-	w.Write(buf)
+	http.Redirect(w, r, ihttp.Absolute(r, url), http.StatusFound)
 }
 
 func (s *TerraformEnterpriseAPIService) createConfigurationVersion(r *http.Request) (*types.ConfigurationVersion, error) {
@@ -110,7 +115,7 @@ func (s *TerraformEnterpriseAPIService) createConfigurationVersion(r *http.Reque
 		Source:        source,
 	}
 
-	cv, err := s.cv.Create(r.Context(), workspaceID, opts)
+	cv, err := s.cvCreator.Create(r.Context(), workspaceID, opts)
 	if err != nil {
 		return nil, err
 	}
@@ -135,28 +140,158 @@ func (s *TerraformEnterpriseAPIService) signConfigurationVersionUploadURL(r *htt
 	return ihttp.Absolute(r, url), nil
 }
 
+// UploadConfigurationVersion streams a configuration tarball to the store.
+//
+// The tarball is either uploaded in its entirety in a single request, or it is
+// uploaded in chunks, each request specifying the chunk's position in a
+// Content-Range header, e.g. "bytes 0-1048575/4194304". Until the last chunk is
+// received, 308 is returned along with a Range header specifying the bytes
+// received so far, e.g. "bytes=0-1048575". An interrupted upload can be resumed
+// by first querying the bytes received with "bytes */4194304", and then
+// uploading the remainder.
 func (s *TerraformEnterpriseAPIService) UploadConfigurationVersion(w http.ResponseWriter, r *http.Request) {
 	id, err := decode.Param("id", r)
 	if err != nil {
 		tfeapi.Error(w, err)
 		return
 	}
-
-	buf, err := io.ReadAll(io.LimitReader(r.Body, s.maxUploadSize+1))
+	// report the upload limits, permitting clients to fail fast before
+	// uploading any further parts.
+	quota, err := s.cvQuota.UploadQuota(r.Context(), id)
 	if err != nil {
 		tfeapi.Error(w, err)
 		return
-	} else if int64(len(buf)) > s.maxUploadSize {
-		tfeapi.Error(w, &internal.HTTPError{
-			Code:    422,
-			Message: fmt.Sprintf("configuration version exceeds maximum size (%d bytes)", s.maxUploadSize),
-		})
+	}
+	quota.SetHeaders(w.Header())
+	maxUploadSize := quota.MaxUploadSize
+	tooBig := &internal.HTTPError{
+		Code:    422,
+		Message: fmt.Sprintf("configuration version exceeds maximum size (%d bytes)", maxUploadSize),
+	}
+	if quota.StorageQuota != nil && maxUploadSize == *quota.StorageRemaining {
+		tooBig.Message = fmt.Sprintf("configuration version exceeds remaining storage quota (%d bytes)", maxUploadSize)
 	}
 
-	if err := s.cv.Upload(r.Context(), id, buf); err != nil {
-		tfeapi.Error(w, err)
+	header := r.Header.Get("Content-Range")
+	if header == "" {
+		body := http.MaxBytesReader(w, r.Body, maxUploadSize)
+		if _, err := s.cvUploader.UploadPart(r.Context(), id, 0, body); err != nil {
+			var maxBytesError *http.MaxBytesError
+			if errors.As(err, &maxBytesError) {
+				err = tooBig
+			}
+			tfeapi.Error(w, err)
+			return
+		}
+		if err := s.cvUploader.CompleteUpload(r.Context(), id); err != nil {
+			tfeapi.Error(w, err)
+			return
+		}
+		return
+	}
+
+	cr, err := parseContentRange(header)
+	if err != nil {
+		tfeapi.Error(w, &internal.HTTPError{Code: http.StatusBadRequest, Message: err.Error()})
+		return
+	}
+	if cr.total > maxUploadSize || cr.end >= maxUploadSize {
+		tfeapi.Error(w, tooBig)
+		return
+	}
+	var size int64
+	if cr.start < 0 {
+		// client is querying the bytes received so far
+		size, err = s.cvUploader.UploadedSize(r.Context(), id)
+		if errors.Is(err, internal.ErrResourceNotFound) {
+			size, err = 0, nil
+		}
+		if err != nil {
+			tfeapi.Error(w, err)
+			return
+		}
+	} else {
+		body := http.MaxBytesReader(w, r.Body, cr.end-cr.start+1)
+		size, err = s.cvUploader.UploadPart(r.Context(), id, cr.start, body)
+		if err != nil {
+			var maxBytesError *http.MaxBytesError
+			switch {
+			case errors.Is(err, blob.ErrUploadOffsetMismatch):
+				setReceivedRange(w, size)
+				err = &internal.HTTPError{Code: http.StatusRequestedRangeNotSatisfiable, Message: err.Error()}
+			case errors.As(err, &maxBytesError):
+				err = &internal.HTTPError{Code: http.StatusBadRequest, Message: "request body exceeds content range"}
+			}
+			tfeapi.Error(w, err)
+			return
+		}
+	}
+	if cr.total >= 0 && size == cr.total {
+		if err := s.cvUploader.CompleteUpload(r.Context(), id); err != nil {
+			tfeapi.Error(w, err)
+			return
+		}
 		return
 	}
+	setReceivedRange(w, size)
+	w.WriteHeader(http.StatusPermanentRedirect)
+}
+
+// contentRange is a parsed Content-Range header. Unknown values are -1.
+type contentRange struct {
+	start, end, total int64
+}
+
+// parseContentRange parses a Content-Range header of the form "bytes
+// start-end/total", where total may be "*" if unknown, or of the form "bytes
+// */total".
+func parseContentRange(header string) (contentRange, error) {
+	invalid := fmt.Errorf("invalid content range: %q", header)
+
+	spec, ok := strings.CutPrefix(header, "bytes ")
+	if !ok {
+		return contentRange{}, invalid
+	}
+	rng, total, ok := strings.Cut(spec, "/")
+	if !ok {
+		return contentRange{}, invalid
+	}
+	cr := contentRange{start: -1, end: -1, total: -1}
+	if total != "*" {
+		n, err := strconv.ParseInt(total, 10, 64)
+		if err != nil || n < 0 {
+			return contentRange{}, invalid
+		}
+		cr.total = n
+	}
+	if rng == "*" {
+		if cr.total < 0 {
+			return contentRange{}, invalid
+		}
+		return cr, nil
+	}
+	start, end, ok := strings.Cut(rng, "-")
+	if !ok {
+		return contentRange{}, invalid
+	}
+	var err error
+	if cr.start, err = strconv.ParseInt(start, 10, 64); err != nil || cr.start < 0 {
+		return contentRange{}, invalid
+	}
+	if cr.end, err = strconv.ParseInt(end, 10, 64); err != nil || cr.end < cr.start {
+		return contentRange{}, invalid
+	}
+	if cr.total >= 0 && cr.end >= cr.total {
+		return contentRange{}, invalid
+	}
+	return cr, nil
+}
+
+// setReceivedRange informs the client of the bytes received so far.
+func setReceivedRange(w http.ResponseWriter, size int64) {
+	if size > 0 {
+		w.Header().Set("Range", fmt.Sprintf("bytes=0-%d", size-1))
+	}
 }
 
 func (s *TerraformEnterpriseAPIService) includeByConfigurationVersionIDField(ctx context.Context, v any) ([]any, error) {
@@ -173,7 +308,7 @@ func (s *TerraformEnterpriseAPIService) includeByConfigurationVersionIDField(ctx
 	if id.Kind() != reflect.String {
 		return nil, nil
 	}
-	cv, err := s.cv.Get(ctx, id.String())
+	cv, err := s.cvGetter.Get(ctx, id.String())
 	if err != nil {
 		return nil, err
 	}
@@ -190,21 +325,55 @@ func (s *TerraformEnterpriseAPIService) includeByConfigurationVersionIngressAttr
 	}
 	// the tfe CV does not by default include ingress attributes, whereas the
 	// otf CV *does*, so we need to fetch it.
-	cv, err := s.cv.Get(ctx, tfeCV.ID)
+	cv, err := s.cvGetter.Get(ctx, tfeCV.ID)
 	if err != nil {
 		return nil, err
 	}
-	return []any{&types.IngressAttributes{
-		ID:        internal.ConvertID(cv.ID, "ia"),
-		CommitSHA: cv.IngressAttributes.CommitSHA,
-		CommitURL: cv.IngressAttributes.CommitURL,
-	}}, nil
+	return []any{convertIngressAttributes(cv.ID, cv.IngressAttributes)}, nil
+}
+
+// getIngressAttributes retrieves the attributes of the commit, and of the pull
+// request if any, from which a configuration version was created.
+func (s *TerraformEnterpriseAPIService) getIngressAttributes(r *http.Request) (*types.IngressAttributes, error) {
+	id, err := decode.Param("id", r)
+	if err != nil {
+		return nil, err
+	}
+
+	cv, err := s.cvGetter.Get(r.Context(), id)
+	if err != nil {
+		return nil, err
+	}
+	if cv.IngressAttributes == nil {
+		return nil, internal.ErrResourceNotFound
+	}
+	return convertIngressAttributes(cv.ID, cv.IngressAttributes), nil
+}
+
+func convertIngressAttributes(cvID string, from *configversion.IngressAttributes) *types.IngressAttributes {
+	return &types.IngressAttributes{
+		ID:                resource.ConvertID(cvID, resource.IngressAttributesKind),
+		Branch:            from.Branch,
+		CommitSHA:         from.CommitSHA,
+		CommitURL:         from.CommitURL,
+		Identifier:        from.Repo,
+		IsPullRequest:     from.IsPullRequest,
+		OnDefaultBranch:   from.OnDefaultBranch,
+		PullRequestNumber: from.PullRequestNumber,
+		PullRequestURL:    from.PullRequestURL,
+		PullRequestTitle:  from.PullRequestTitle,
+		Tag:               from.Tag,
+		SenderUsername:    from.SenderUsername,
+		SenderAvatarURL:   from.SenderAvatarURL,
+		SenderHTMLURL:     from.SenderHTMLURL,
+	}
 }
 
 func convertConfigurationVersion(from *configversion.ConfigurationVersion, url string) *types.ConfigurationVersion {
 	to := &types.ConfigurationVersion{
 		ID:               from.ID,
 		AutoQueueRuns:    from.AutoQueueRuns,
+		Digest:           from.Digest,
 		Speculative:      from.Speculative,
 		Source:           string(from.Source),
 		Status:           string(from.Status),
@@ -213,7 +382,7 @@ func convertConfigurationVersion(from *configversion.ConfigurationVersion, url s
 	}
 	if from.IngressAttributes != nil {
 		to.IngressAttributes = &types.IngressAttributes{
-			ID: internal.ConvertID(from.ID, "ia"),
+			ID: resource.ConvertID(from.ID, resource.IngressAttributesKind),
 		}
 	}
 	for _, ts := range from.StatusTimestamps {
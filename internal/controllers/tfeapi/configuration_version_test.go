@@ -20,26 +20,120 @@ import (
 	"crypto/rand"
 	"io"
 	"net/http/httptest"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/leg100/otf/internal"
+	"github.com/leg100/otf/internal/blob"
 	"github.com/leg100/otf/internal/configversion"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 type fakeCVSvc struct {
-	*configversion.Service
+	cv        *configversion.ConfigurationVersion
+	uploaded  []byte
+	completed bool
+	quota     configversion.Quota
 }
 
-func (f *fakeCVSvc) Upload(ctx context.Context, cvID string, config []byte) error {
+func (f *fakeCVSvc) Get(ctx context.Context, cvID string) (*configversion.ConfigurationVersion, error) {
+	return f.cv, nil
+}
+
+func (f *fakeCVSvc) GetLatest(ctx context.Context, workspaceID string) (*configversion.ConfigurationVersion, error) {
+	return f.cv, nil
+}
+
+func (f *fakeCVSvc) UploadPart(ctx context.Context, cvID string, offset int64, r io.Reader) (int64, error) {
+	if offset == 0 {
+		f.uploaded = nil
+	} else if offset != int64(len(f.uploaded)) {
+		return int64(len(f.uploaded)), blob.ErrUploadOffsetMismatch
+	}
+	part, err := io.ReadAll(r)
+	f.uploaded = append(f.uploaded, part...)
+	return int64(len(f.uploaded)), err
+}
+
+func (f *fakeCVSvc) UploadedSize(ctx context.Context, cvID string) (int64, error) {
+	return int64(len(f.uploaded)), nil
+}
+
+func (f *fakeCVSvc) CompleteUpload(ctx context.Context, cvID string) error {
+	f.completed = true
 	return nil
 }
 
+func (f *fakeCVSvc) GetQuota(ctx context.Context, workspaceID string) (*configversion.Quota, error) {
+	return &f.quota, nil
+}
+
+func (f *fakeCVSvc) UploadQuota(ctx context.Context, cvID string) (*configversion.Quota, error) {
+	return &f.quota, nil
+}
+
+func (f *fakeCVSvc) Download(ctx context.Context, cvID string) ([]byte, error) {
+	return f.uploaded, nil
+}
+
+func (f *fakeCVSvc) SignedDownloadURL(ctx context.Context, cvID string, lifetime time.Duration) (string, error) {
+	return "/signed/123/configuration-versions/" + cvID + "/download", nil
+}
+
 func TestConfigurationVersion(t *testing.T) {
+	t.Run("DownloadConfigurationVersion", func(t *testing.T) {
+		svc := TerraformEnterpriseAPIService{cvDownloader: &fakeCVSvc{}}
+
+		req := httptest.NewRequest("GET", "/api/v2/configuration-versions/cv-1/download?id=cv-1", nil)
+		w := httptest.NewRecorder()
+		svc.downloadConfigurationVersion(w, req)
+		assert.Equal(t, 302, w.Code, w.Body.String())
+		assert.Equal(t, "http://example.com/signed/123/configuration-versions/cv-1/download", w.Header().Get("Location"))
+	})
+
+	t.Run("GetIngressAttributes", func(t *testing.T) {
+		svc := TerraformEnterpriseAPIService{cvGetter: &fakeCVSvc{
+			cv: &configversion.ConfigurationVersion{
+				ID: "cv-1",
+				IngressAttributes: &configversion.IngressAttributes{
+					CommitSHA:         "abc123",
+					Repo:              "leg100/otf",
+					IsPullRequest:     true,
+					PullRequestNumber: 13,
+					PullRequestURL:    "https://github.com/leg100/otf/pull/13",
+				},
+			},
+		}}
+
+		req := httptest.NewRequest("GET", "/api/v2/configuration-versions/cv-1/ingress-attributes?id=cv-1", nil)
+		got, err := svc.getIngressAttributes(req)
+		require.NoError(t, err)
+		assert.Equal(t, "ia-1", got.ID)
+		assert.Equal(t, "abc123", got.CommitSHA)
+		assert.Equal(t, "leg100/otf", got.Identifier)
+		assert.True(t, got.IsPullRequest)
+		assert.Equal(t, 13, got.PullRequestNumber)
+		assert.Equal(t, "https://github.com/leg100/otf/pull/13", got.PullRequestURL)
+	})
+
+	t.Run("GetIngressAttributesNotFound", func(t *testing.T) {
+		svc := TerraformEnterpriseAPIService{cvGetter: &fakeCVSvc{
+			cv: &configversion.ConfigurationVersion{ID: "cv-1"},
+		}}
+
+		req := httptest.NewRequest("GET", "/api/v2/configuration-versions/cv-1/ingress-attributes?id=cv-1", nil)
+		_, err := svc.getIngressAttributes(req)
+		assert.ErrorIs(t, err, internal.ErrResourceNotFound)
+	})
+
 	t.Run("UploadConfigurationVersion", func(t *testing.T) {
 		const maxUploadSize = 100
+		fake := &fakeCVSvc{quota: configversion.Quota{MaxUploadSize: maxUploadSize}}
 		svc := TerraformEnterpriseAPIService{
-			cv:            &fakeCVSvc{},
-			maxUploadSize: maxUploadSize,
+			cvUploader: fake,
+			cvQuota:    fake,
 		}
 
 		t.Run("WithSmallPayload", func(t *testing.T) {
@@ -57,5 +151,115 @@ func TestConfigurationVersion(t *testing.T) {
 			svc.UploadConfigurationVersion(w, req)
 			assert.Equal(t, 422, w.Code)
 		})
+
+		t.Run("ReportsQuota", func(t *testing.T) {
+			reader := io.LimitReader(rand.Reader, 10)
+			req := httptest.NewRequest("PUT", "/configuration-versions/cv-1/upload?id=cv-1", reader)
+			w := httptest.NewRecorder()
+			svc.UploadConfigurationVersion(w, req)
+			assert.Equal(t, 200, w.Code)
+			assert.Equal(t, "100", w.Header().Get("X-OTF-Max-Upload-Size"))
+			assert.Equal(t, "0", w.Header().Get("X-OTF-Storage-Used"))
+			assert.Empty(t, w.Header().Get("X-OTF-Storage-Quota"))
+		})
+	})
+
+	t.Run("UploadConfigurationVersionExceedingQuota", func(t *testing.T) {
+		quota, remaining := int64(1000), int64(50)
+		fake := &fakeCVSvc{quota: configversion.Quota{
+			MaxUploadSize:    remaining,
+			StorageQuota:     &quota,
+			StorageUsed:      quota - remaining,
+			StorageRemaining: &remaining,
+		}}
+		svc := TerraformEnterpriseAPIService{
+			cvUploader: fake,
+			cvQuota:    fake,
+		}
+
+		reader := io.LimitReader(rand.Reader, remaining+1)
+		req := httptest.NewRequest("PUT", "/configuration-versions/cv-1/upload?id=cv-1", reader)
+		w := httptest.NewRecorder()
+		svc.UploadConfigurationVersion(w, req)
+		assert.Equal(t, 422, w.Code)
+		assert.Contains(t, w.Body.String(), "remaining storage quota")
+		assert.Equal(t, "1000", w.Header().Get("X-OTF-Storage-Quota"))
+		assert.Equal(t, "50", w.Header().Get("X-OTF-Storage-Remaining"))
+		assert.False(t, fake.completed)
 	})
+
+	t.Run("ResumableUploadConfigurationVersion", func(t *testing.T) {
+		const maxUploadSize = 100
+		fake := &fakeCVSvc{quota: configversion.Quota{MaxUploadSize: maxUploadSize}}
+		svc := TerraformEnterpriseAPIService{
+			cvUploader: fake,
+			cvQuota:    fake,
+		}
+		upload := func(t *testing.T, contentRange, body string) *httptest.ResponseRecorder {
+			req := httptest.NewRequest("PUT", "/configuration-versions/cv-1/upload?id=cv-1", strings.NewReader(body))
+			req.Header.Set("Content-Range", contentRange)
+			w := httptest.NewRecorder()
+			svc.UploadConfigurationVersion(w, req)
+			return w
+		}
+
+		w := upload(t, "bytes 0-4/11", "hello")
+		assert.Equal(t, 308, w.Code, w.Body.String())
+		assert.Equal(t, "bytes=0-4", w.Header().Get("Range"))
+		assert.False(t, fake.completed)
+
+		// query bytes received after interruption
+		w = upload(t, "bytes */11", "")
+		assert.Equal(t, 308, w.Code, w.Body.String())
+		assert.Equal(t, "bytes=0-4", w.Header().Get("Range"))
+
+		// chunk not continuing from bytes received
+		w = upload(t, "bytes 3-10/11", "lo world")
+		assert.Equal(t, 416, w.Code, w.Body.String())
+		assert.Equal(t, "bytes=0-4", w.Header().Get("Range"))
+
+		// final chunk
+		w = upload(t, "bytes 5-10/11", " world")
+		assert.Equal(t, 200, w.Code, w.Body.String())
+		assert.True(t, fake.completed)
+		assert.Equal(t, "hello world", string(fake.uploaded))
+
+		t.Run("exceeding max size", func(t *testing.T) {
+			w := upload(t, "bytes 0-4/101", "hello")
+			assert.Equal(t, 422, w.Code, w.Body.String())
+		})
+
+		t.Run("invalid content range", func(t *testing.T) {
+			w := upload(t, "bytes 5-4/11", "hello")
+			assert.Equal(t, 400, w.Code, w.Body.String())
+		})
+	})
+}
+
+func TestParseContentRange(t *testing.T) {
+	tests := []struct {
+		header string
+		want   contentRange
+		err    bool
+	}{
+		{"bytes 0-99/200", contentRange{0, 99, 200}, false},
+		{"bytes 100-199/*", contentRange{100, 199, -1}, false},
+		{"bytes */200", contentRange{-1, -1, 200}, false},
+		{"bytes */*", contentRange{}, true},
+		{"bytes 0-200/200", contentRange{}, true},
+		{"bytes 10-5/200", contentRange{}, true},
+		{"bytes=0-99", contentRange{}, true},
+		{"items 0-99/200", contentRange{}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.header, func(t *testing.T) {
+			got, err := parseContentRange(tt.header)
+			if tt.err {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
 }
@@ -0,0 +1,262 @@
+// Copyright (C) 2024 Francois Saint-Jacques
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package tfeapi
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/leg100/otf/internal"
+	ihttp "github.com/leg100/otf/internal/http"
+	"github.com/leg100/otf/internal/http/decode"
+	"github.com/leg100/otf/internal/resource"
+	"github.com/leg100/otf/internal/run"
+	"github.com/leg100/otf/internal/state"
+	"github.com/leg100/otf/internal/tfeapi"
+)
+
+const runBundleManifestFilename = "manifest.json"
+
+type (
+	// runBundleManifest describes a run and the artifacts packaged alongside
+	// it in a run bundle.
+	runBundleManifest struct {
+		RunID                  string                `json:"run_id"`
+		Organization           string                `json:"organization"`
+		WorkspaceID            string                `json:"workspace_id"`
+		ConfigurationVersionID string                `json:"configuration_version_id"`
+		Status                 run.Status            `json:"status"`
+		CreatedAt              time.Time             `json:"created_at"`
+		StatusTimestamps       []run.StatusTimestamp `json:"status_timestamps"`
+		// StateBefore references the state version that was current when the
+		// run was created.
+		StateBefore *runBundleStateReference `json:"state_before,omitempty"`
+		// StateAfter references the state version created by the run, if
+		// any.
+		StateAfter *runBundleStateReference `json:"state_after,omitempty"`
+		Files      []string                 `json:"files"`
+	}
+
+	runBundleStateReference struct {
+		ID        string    `json:"id"`
+		Serial    int64     `json:"serial"`
+		CreatedAt time.Time `json:"created_at"`
+	}
+
+	runBundleFile struct {
+		name string
+		data []byte
+	}
+)
+
+// getRunBundle checks the caller has access to the run and then redirects
+// them to a pre-signed URL from which the run bundle can be downloaded.
+func (s *TerraformEnterpriseAPIService) getRunBundle(w http.ResponseWriter, r *http.Request) {
+	id, err := decode.Param("id", r)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+
+	if _, err := s.run.Get(r.Context(), id); err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+
+	url, err := s.signer.Sign(fmt.Sprintf("/runs/%s/bundle", id), time.Hour)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+
+	http.Redirect(w, r, ihttp.Absolute(r, url), http.StatusFound)
+}
+
+// downloadRunBundle streams a gzipped tarball containing the run's artifacts.
+//
+// NOTE: unauthenticated - access granted only via signed URL
+func (s *TerraformEnterpriseAPIService) downloadRunBundle(w http.ResponseWriter, r *http.Request) {
+	id, err := decode.Param("id", r)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+
+	ctx := internal.AddSubjectToContext(r.Context(), &internal.Superuser{Username: "run-bundler"})
+
+	// retrieve the artifacts first so that errors can be reported to the
+	// client before any of the body is sent.
+	files, err := s.runBundleFiles(ctx, id)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/gzip")
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="%s.tar.gz"`, id))
+	if err := writeRunBundle(w, files); err != nil {
+		// the response header has been written, so abort the response,
+		// leaving the client with a truncated tarball rather than an
+		// apparently complete one.
+		panic(http.ErrAbortHandler)
+	}
+}
+
+// runBundleFiles retrieves the files comprising the run bundle: a manifest,
+// the JSON plan, the logs for each phase, and the configuration version
+// tarball. Artifacts that do not exist yet are omitted.
+func (s *TerraformEnterpriseAPIService) runBundleFiles(ctx context.Context, runID string) ([]runBundleFile, error) {
+	rn, err := s.run.Get(ctx, runID)
+	if err != nil {
+		return nil, err
+	}
+
+	var files []runBundleFile
+
+	plan, err := s.run.GetPlanFile(ctx, runID, run.PlanFormatJSON)
+	if err != nil && !errors.Is(err, internal.ErrResourceNotFound) {
+		return nil, fmt.Errorf("retrieving plan: %w", err)
+	}
+	if len(plan) > 0 {
+		files = append(files, runBundleFile{name: "plan.json", data: plan})
+	}
+
+	for _, phase := range []internal.PhaseType{internal.PlanPhase, internal.ApplyPhase} {
+		chunk, err := s.logs.GetChunk(ctx, internal.GetChunkOptions{RunID: runID, Phase: phase})
+		if err != nil && !errors.Is(err, internal.ErrResourceNotFound) {
+			return nil, fmt.Errorf("retrieving %s logs: %w", phase, err)
+		}
+		// remove ASCII markers
+		if chunk.IsStart() {
+			chunk.Data = chunk.Data[1:]
+		}
+		if chunk.IsEnd() {
+			chunk.Data = chunk.Data[:len(chunk.Data)-1]
+		}
+		if len(chunk.Data) > 0 {
+			files = append(files, runBundleFile{name: fmt.Sprintf("logs/%s.log", phase), data: chunk.Data})
+		}
+	}
+
+	config, err := s.cvDownloader.Download(ctx, rn.ConfigurationVersionID)
+	if err != nil && !errors.Is(err, internal.ErrResourceNotFound) {
+		return nil, fmt.Errorf("retrieving configuration: %w", err)
+	}
+	if len(config) > 0 {
+		files = append(files, runBundleFile{name: "config.tar.gz", data: config})
+	}
+
+	manifest := runBundleManifest{
+		RunID:                  rn.ID,
+		Organization:           rn.Organization,
+		WorkspaceID:            rn.WorkspaceID,
+		ConfigurationVersionID: rn.ConfigurationVersionID,
+		Status:                 rn.Status,
+		CreatedAt:              rn.CreatedAt,
+		StatusTimestamps:       rn.StatusTimestamps,
+		Files:                  make([]string, len(files)),
+	}
+	for i, f := range files {
+		manifest.Files[i] = f.name
+	}
+	manifest.StateBefore, manifest.StateAfter, err = s.runStateReferences(ctx, rn)
+	if err != nil {
+		return nil, err
+	}
+	encoded, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append([]runBundleFile{{name: runBundleManifestFilename, data: encoded}}, files...), nil
+}
+
+// writeRunBundle writes the files to w as a gzipped tarball.
+func writeRunBundle(w io.Writer, files []runBundleFile) error {
+	gw := gzip.NewWriter(w)
+	tw := tar.NewWriter(gw)
+	now := internal.CurrentTimestamp(nil)
+	for _, f := range files {
+		hdr := &tar.Header{
+			Name:    f.name,
+			Mode:    0o644,
+			Size:    int64(len(f.data)),
+			ModTime: now,
+		}
+		if err := tw.WriteHeader(hdr); err != nil {
+			return err
+		}
+		if _, err := tw.Write(f.data); err != nil {
+			return err
+		}
+	}
+	if err := tw.Close(); err != nil {
+		return err
+	}
+	return gw.Close()
+}
+
+// runStateReferences determines the state version that was current when the
+// run was created, and the state version created by the run, if any. The
+// state versions created by the run are those recorded against the run when
+// they were created, and the most recent of them is the state after the run.
+// The state before the run is the most recent state version preceding them
+// or, if the run created none, the most recent state version created before
+// the run.
+func (s *TerraformEnterpriseAPIService) runStateReferences(ctx context.Context, rn *run.Run) (before, after *runBundleStateReference, err error) {
+	provenance, err := s.run.GetProvenance(ctx, rn.ID, false)
+	if err != nil {
+		return nil, nil, fmt.Errorf("retrieving run provenance: %w", err)
+	}
+	createdByRun := make(map[string]bool, len(provenance.StateVersions))
+	for _, sv := range provenance.StateVersions {
+		createdByRun[sv.ID] = true
+	}
+	// provenance state versions are ordered by serial
+	if n := len(provenance.StateVersions); n > 0 {
+		last := provenance.StateVersions[n-1]
+		after = &runBundleStateReference{ID: last.ID, Serial: last.Serial, CreatedAt: last.CreatedAt}
+	}
+
+	versions, err := resource.ListAll(func(opts resource.PageOptions) (*resource.Page[*state.Version], error) {
+		return s.state.List(ctx, rn.WorkspaceID, opts)
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("listing state versions: %w", err)
+	}
+	// versions are ordered newest first
+	for _, sv := range versions {
+		if createdByRun[sv.ID] {
+			continue
+		}
+		if len(provenance.StateVersions) > 0 {
+			if sv.Serial >= provenance.StateVersions[0].Serial {
+				continue
+			}
+		} else if sv.CreatedAt.After(rn.CreatedAt) {
+			continue
+		}
+		before = &runBundleStateReference{ID: sv.ID, Serial: sv.Serial, CreatedAt: sv.CreatedAt}
+		break
+	}
+	return before, after, nil
+}
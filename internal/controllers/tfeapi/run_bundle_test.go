@@ -0,0 +1,157 @@
+// Copyright (C) 2024 Francois Saint-Jacques
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package tfeapi
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/leg100/otf/internal"
+	"github.com/leg100/otf/internal/logs"
+	"github.com/leg100/otf/internal/resource"
+	"github.com/leg100/otf/internal/run"
+	"github.com/leg100/otf/internal/state"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type (
+	fakeRunSvc struct {
+		*run.Service
+		run        *run.Run
+		plan       []byte
+		provenance *run.Provenance
+	}
+
+	fakeLogsSvc struct {
+		*logs.Service
+		logs map[internal.PhaseType][]byte
+	}
+
+	fakeStateSvc struct {
+		*state.Service
+		versions []*state.Version
+	}
+
+	fakeBundleCVSvc struct {
+		config []byte
+	}
+)
+
+func (f *fakeRunSvc) Get(context.Context, string) (*run.Run, error) {
+	return f.run, nil
+}
+
+func (f *fakeRunSvc) GetPlanFile(context.Context, string, run.PlanFormat) ([]byte, error) {
+	return f.plan, nil
+}
+
+func (f *fakeRunSvc) GetProvenance(context.Context, string, bool) (*run.Provenance, error) {
+	return f.provenance, nil
+}
+
+func (f *fakeLogsSvc) GetChunk(_ context.Context, opts internal.GetChunkOptions) (internal.Chunk, error) {
+	return internal.Chunk{RunID: opts.RunID, Phase: opts.Phase, Data: f.logs[opts.Phase]}, nil
+}
+
+func (f *fakeStateSvc) List(_ context.Context, _ string, opts resource.PageOptions) (*resource.Page[*state.Version], error) {
+	return resource.NewPage(f.versions, opts, nil), nil
+}
+
+func (f *fakeBundleCVSvc) Download(context.Context, string) ([]byte, error) {
+	return f.config, nil
+}
+
+func (f *fakeBundleCVSvc) SignedDownloadURL(context.Context, string, time.Duration) (string, error) {
+	return "", nil
+}
+
+func TestRunBundle(t *testing.T) {
+	created := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	rn := &run.Run{
+		ID:                     "run-123",
+		Organization:           "acme",
+		WorkspaceID:            "ws-123",
+		ConfigurationVersionID: "cv-123",
+		CreatedAt:              created,
+		Status:                 run.RunApplied,
+		StatusTimestamps: []run.StatusTimestamp{
+			{Status: run.RunPending, Timestamp: created},
+			{Status: run.RunApplied, Timestamp: created.Add(time.Minute)},
+		},
+	}
+	svc := TerraformEnterpriseAPIService{
+		run: &fakeRunSvc{
+			run:  rn,
+			plan: []byte(`{"format_version":"1.0"}`),
+			provenance: &run.Provenance{
+				StateVersions: []run.StateVersionProvenance{
+					{ID: "sv-2", Serial: 2, CreatedAt: created.Add(15 * time.Second)},
+				},
+			},
+		},
+		logs: &fakeLogsSvc{logs: map[internal.PhaseType][]byte{
+			internal.PlanPhase: append(append([]byte{internal.STX}, "plan output"...), internal.ETX),
+		}},
+		cvDownloader: &fakeBundleCVSvc{config: []byte("tarball")},
+		state: &fakeStateSvc{versions: []*state.Version{
+			// created whilst the run was in progress but not by the run
+			{ID: "sv-3", Serial: 3, CreatedAt: created.Add(30 * time.Second)},
+			{ID: "sv-2", Serial: 2, CreatedAt: created.Add(15 * time.Second)},
+			{ID: "sv-1", Serial: 1, CreatedAt: created.Add(-time.Hour)},
+		}},
+	}
+
+	files, err := svc.runBundleFiles(context.Background(), rn.ID)
+	require.NoError(t, err)
+	buf := new(bytes.Buffer)
+	require.NoError(t, writeRunBundle(buf, files))
+
+	gr, err := gzip.NewReader(buf)
+	require.NoError(t, err)
+	tr := tar.NewReader(gr)
+	got := make(map[string][]byte)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+		contents, err := io.ReadAll(tr)
+		require.NoError(t, err)
+		got[hdr.Name] = contents
+	}
+
+	assert.Equal(t, `{"format_version":"1.0"}`, string(got["plan.json"]))
+	assert.Equal(t, "plan output", string(got["logs/plan.log"]))
+	assert.Equal(t, "tarball", string(got["config.tar.gz"]))
+	assert.NotContains(t, got, "logs/apply.log")
+
+	var manifest runBundleManifest
+	require.NoError(t, json.Unmarshal(got[runBundleManifestFilename], &manifest))
+	assert.Equal(t, "run-123", manifest.RunID)
+	assert.Equal(t, []string{"plan.json", "logs/plan.log", "config.tar.gz"}, manifest.Files)
+	require.NotNil(t, manifest.StateBefore)
+	assert.Equal(t, "sv-1", manifest.StateBefore.ID)
+	require.NotNil(t, manifest.StateAfter)
+	assert.Equal(t, "sv-2", manifest.StateAfter.ID)
+}
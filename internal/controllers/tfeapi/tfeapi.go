@@ -22,45 +22,68 @@ import (
 	"github.com/gorilla/mux"
 	"github.com/leg100/otf/internal"
 	"github.com/leg100/otf/internal/configversion"
+	"github.com/leg100/otf/internal/logs"
 	"github.com/leg100/otf/internal/organization"
 	"github.com/leg100/otf/internal/resource"
+	"github.com/leg100/otf/internal/run"
+	"github.com/leg100/otf/internal/state"
 	"github.com/leg100/otf/internal/tfeapi"
 	"github.com/leg100/surl"
 )
 
 type (
 	TerraformEnterpriseAPIService struct {
-		cv  ConfigurationVersionService
-		org OrganizationService
+		// configuration version capabilities
+		cvCreator    configversion.Creator
+		cvGetter     configversion.Getter
+		cvLister     configversion.Lister
+		cvUploader   configversion.ResumableUploader
+		cvDownloader configversion.Downloader
+		cvQuota      configversion.QuotaGetter
+
+		org   OrganizationService
+		run   RunService
+		logs  LogsService
+		state StateService
 
 		responder *tfeapi.Responder
 		signer    *surl.Signer
-
-		maxUploadSize int64
 	}
 
 	Options struct {
 		ConfigurationVersionService
 		OrganizationService
+		RunService
+		LogsService
+		StateService
 
 		*tfeapi.Responder
 		*surl.Signer
-
-		MaxUploadSize int64
 	}
 
 	ConfigurationVersionService = configversion.ConfigurationVersionService
 	OrganizationService         = organization.OrganizationService
+	RunService                  = run.RunService
+	LogsService                 = logs.LogsService
+	StateService                = state.StateService
 )
 
 func NewTerraformEnterpriseAPIService(opts Options) *TerraformEnterpriseAPIService {
 	return &TerraformEnterpriseAPIService{
-		cv:  opts.ConfigurationVersionService,
-		org: opts.OrganizationService,
-
-		responder:     opts.Responder,
-		signer:        opts.Signer,
-		maxUploadSize: opts.MaxUploadSize,
+		cvCreator:    opts.ConfigurationVersionService,
+		cvGetter:     opts.ConfigurationVersionService,
+		cvLister:     opts.ConfigurationVersionService,
+		cvUploader:   opts.ConfigurationVersionService,
+		cvDownloader: opts.ConfigurationVersionService,
+		cvQuota:      opts.ConfigurationVersionService,
+
+		org:   opts.OrganizationService,
+		run:   opts.RunService,
+		logs:  opts.LogsService,
+		state: opts.StateService,
+
+		responder: opts.Responder,
+		signer:    opts.Signer,
 	}
 }
 
@@ -85,6 +108,7 @@ func (s *TerraformEnterpriseAPIService) AddHandlers(r *mux.Router) {
 	r.HandleFunc("/workspaces/{workspace_id}/configuration-versions", hp(rsp, s.listConfigurationVersions)).Methods("GET")
 	r.HandleFunc("/configuration-versions/{id}", h(rsp, s.getConfigurationVersion)).Methods("GET")
 	r.HandleFunc("/configuration-versions/{id}/download", s.downloadConfigurationVersion).Methods("GET")
+	r.HandleFunc("/configuration-versions/{id}/ingress-attributes", h(rsp, s.getIngressAttributes)).Methods("GET")
 	// Upload is *not* rooted at /api/v2
 	signed.HandleFunc("/configuration-versions/{id}/upload", s.UploadConfigurationVersion).Methods("PUT")
 	rsp.Register(tfeapi.IncludeConfig, s.includeByConfigurationVersionIDField)
@@ -101,6 +125,11 @@ func (s *TerraformEnterpriseAPIService) AddHandlers(r *mux.Router) {
 	r.HandleFunc("/organizations/{name}/authentication-token", h(rsp, s.getOrganizationToken)).Methods("GET")
 	r.HandleFunc("/organizations/{name}/authentication-token", he(rsp, s.deleteOrganizationToken)).Methods("DELETE")
 	rsp.Register(tfeapi.IncludeOrganization, s.includeByOrganizationField)
+
+	// Run bundles
+	r.HandleFunc("/runs/{id}/bundle", s.getRunBundle).Methods("GET")
+	// Download is *not* rooted at /api/v2
+	signed.HandleFunc("/runs/{id}/bundle", s.downloadRunBundle).Methods("GET")
 }
 
 func addTFEApiVersionHeaderHandler(next http.Handler) http.Handler {
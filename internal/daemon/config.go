@@ -2,13 +2,24 @@ package daemon
 
 import (
 	"errors"
+	"fmt"
+	"slices"
+	"time"
 
 	"github.com/leg100/otf/internal"
 	"github.com/leg100/otf/internal/agent"
 	"github.com/leg100/otf/internal/authenticator"
+	"github.com/leg100/otf/internal/blob"
+	"github.com/leg100/otf/internal/changeticket"
 	"github.com/leg100/otf/internal/configversion"
+	"github.com/leg100/otf/internal/email"
+	"github.com/leg100/otf/internal/featureflag"
 	"github.com/leg100/otf/internal/inmem"
+	"github.com/leg100/otf/internal/orgexport"
+	"github.com/leg100/otf/internal/orgmetrics"
+	"github.com/leg100/otf/internal/resourcechange"
 	"github.com/leg100/otf/internal/tokens"
+	"github.com/leg100/otf/internal/vcs"
 )
 
 var ErrInvalidSecretLength = errors.New("secret must be 16 bytes in size")
@@ -24,14 +35,29 @@ type Config struct {
 	GitlabHostname               string
 	GitlabClientID               string
 	GitlabClientSecret           string
+	GitlabWebhookGroups          []string
+	BitbucketHostname            string
+	BitbucketServerHostname      string
+	GiteaHostname                string
 	OIDC                         authenticator.OIDCConfig
+	Email                        email.Config
+	OrganizationMetrics          orgmetrics.Config
+	Blob                         blob.Config
+	FeatureFlags                 featureflag.Config
+	ChangeTickets                changeticket.Config
+	ResourceChanges              resourcechange.Config
+	OrganizationExport           orgexport.Config
 	Secret                       []byte // 16-byte secret for signing URLs and encrypting payloads
 	SiteToken                    string
+	RecoveryToken                string
 	Host                         string
 	WebhookHost                  string
 	Address                      string
 	Database                     string
 	MaxConfigSize                int64
+	MaxConfigUnpackedSize        int64
+	MaxConfigFileSize            int64
+	MaxConfigStorage             int64
 	SSL                          bool
 	CertFile, KeyFile            string
 	EnableRequestLogging         bool
@@ -40,8 +66,29 @@ type Config struct {
 	RestrictOrganizationCreation bool
 	SiteAdmins                   []string
 	SkipTLSVerification          bool
+	// URLs of external services that annotate runs
+	RunAnnotationWebhooks []string
+	// number of days for which deleted workspaces can be restored
+	WorkspaceRecoveryDays int
+	// number of days for which renamed workspaces can be found using their
+	// former names
+	WorkspaceRenameGraceDays int
+	// maximum permitted difference between the time a webhook delivery is
+	// sent and received
+	WebhookClockSkew time.Duration
+	// kinds of vcs providers whose webhook deliveries must carry a Date
+	// header
+	WebhookRequireDate []string
+	// maximum number of vcs events per minute processed for each repository
+	WebhookRateLimit int
+	// maximum number of concurrent requests made to each vcs provider
+	VCSMaxConcurrentRequests int
+	// period following a cancelation before a run can be force canceled
+	ForceCancelCoolOff time.Duration
 	// skip checks for latest terraform version
 	DisableLatestChecker *bool
+	// path to the opa binary with which OPA policies are evaluated
+	OPABinary string
 
 	tokens.GoogleIAPConfig
 }
@@ -58,6 +105,12 @@ func ApplyDefaults(cfg *Config) {
 	if cfg.MaxConfigSize == 0 {
 		cfg.MaxConfigSize = configversion.DefaultConfigMaxSize
 	}
+	if cfg.MaxConfigUnpackedSize == 0 {
+		cfg.MaxConfigUnpackedSize = configversion.DefaultConfigUnpackedMaxSize
+	}
+	if cfg.MaxConfigFileSize == 0 {
+		cfg.MaxConfigFileSize = configversion.DefaultConfigFileMaxSize
+	}
 }
 
 func (cfg *Config) Valid() error {
@@ -67,5 +120,34 @@ func (cfg *Config) Valid() error {
 	if len(cfg.Secret) != 16 {
 		return ErrInvalidSecretLength
 	}
+	if cfg.Email.Enabled() && cfg.Email.From == "" {
+		return &internal.MissingParameterError{Parameter: "smtp-from"}
+	}
+	if err := cfg.OrganizationMetrics.Valid(); err != nil {
+		return err
+	}
+	if err := cfg.Blob.Valid(); err != nil {
+		return err
+	}
+	if err := cfg.FeatureFlags.Valid(); err != nil {
+		return err
+	}
+	if err := cfg.ChangeTickets.Valid(); err != nil {
+		return err
+	}
+	if err := cfg.ResourceChanges.Valid(); err != nil {
+		return err
+	}
+	if err := cfg.OrganizationExport.Valid(); err != nil {
+		return err
+	}
+	for _, kind := range cfg.WebhookRequireDate {
+		if !slices.Contains(vcs.Kinds, vcs.Kind(kind)) {
+			return &internal.InvalidParameterError{
+				Parameter: "webhook-require-date",
+				Err:       fmt.Errorf("unknown kind of vcs provider: %s", kind),
+			}
+		}
+	}
 	return nil
 }
@@ -5,6 +5,9 @@ import (
 	"context"
 	"fmt"
 	"net"
+	nethttp "net/http"
+	"os/exec"
+	"slices"
 	"time"
 
 	"github.com/go-logr/logr"
@@ -12,12 +15,23 @@ import (
 	"github.com/leg100/otf/internal"
 	"github.com/leg100/otf/internal/agent"
 	"github.com/leg100/otf/internal/api"
+	"github.com/leg100/otf/internal/assessment"
+	"github.com/leg100/otf/internal/audit"
 	"github.com/leg100/otf/internal/authenticator"
+	"github.com/leg100/otf/internal/authz"
+	"github.com/leg100/otf/internal/banner"
+	"github.com/leg100/otf/internal/bitbucket"
+	"github.com/leg100/otf/internal/bitbucketserver"
+	"github.com/leg100/otf/internal/blob"
+	"github.com/leg100/otf/internal/changeticket"
 	"github.com/leg100/otf/internal/configversion"
 	"github.com/leg100/otf/internal/connections"
 	"github.com/leg100/otf/internal/controllers/tfapi"
 	"github.com/leg100/otf/internal/controllers/tfeapi"
+	"github.com/leg100/otf/internal/email"
+	"github.com/leg100/otf/internal/featureflag"
 	"github.com/leg100/otf/internal/ghapphandler"
+	"github.com/leg100/otf/internal/gitea"
 	"github.com/leg100/otf/internal/github"
 	"github.com/leg100/otf/internal/gitlab"
 	"github.com/leg100/otf/internal/http"
@@ -27,11 +41,26 @@ import (
 	"github.com/leg100/otf/internal/module"
 	"github.com/leg100/otf/internal/notifications"
 	"github.com/leg100/otf/internal/organization"
+	"github.com/leg100/otf/internal/orgexport"
+	"github.com/leg100/otf/internal/orgimport"
+	"github.com/leg100/otf/internal/orgmetrics"
+	"github.com/leg100/otf/internal/policy"
+	"github.com/leg100/otf/internal/registryprovider"
 	"github.com/leg100/otf/internal/releases"
 	"github.com/leg100/otf/internal/repohooks"
+	"github.com/leg100/otf/internal/repoimport"
+	"github.com/leg100/otf/internal/resolver"
+	"github.com/leg100/otf/internal/resource"
+	"github.com/leg100/otf/internal/resourcechange"
 	"github.com/leg100/otf/internal/run"
+	"github.com/leg100/otf/internal/runannotation"
+	"github.com/leg100/otf/internal/runtask"
+	"github.com/leg100/otf/internal/runtrigger"
 	"github.com/leg100/otf/internal/scheduler"
+	"github.com/leg100/otf/internal/search"
 	"github.com/leg100/otf/internal/sql"
+	"github.com/leg100/otf/internal/sshkey"
+	"github.com/leg100/otf/internal/sso"
 	"github.com/leg100/otf/internal/state"
 	"github.com/leg100/otf/internal/team"
 	tfeutils "github.com/leg100/otf/internal/tfeapi"
@@ -39,8 +68,10 @@ import (
 	"github.com/leg100/otf/internal/user"
 	"github.com/leg100/otf/internal/variable"
 	"github.com/leg100/otf/internal/vcs"
+	"github.com/leg100/otf/internal/vcsevent"
 	"github.com/leg100/otf/internal/vcsprovider"
 	"github.com/leg100/otf/internal/workspace"
+	"github.com/leg100/otf/internal/workspacetemplate"
 	"golang.org/x/sync/errgroup"
 )
 
@@ -51,24 +82,45 @@ type (
 
 		*sql.DB
 
-		Organizations *organization.Service
-		Runs          *run.Service
-		Workspaces    *workspace.Service
-		Variables     *variable.Service
-		Notifications *notifications.Service
-		Logs          *logs.Service
-		State         *state.Service
-		Configs       *configversion.Service
-		Modules       *module.Service
-		VCSProviders  *vcsprovider.Service
-		Tokens        *tokens.Service
-		Teams         *team.Service
-		Users         *user.Service
-		GithubApp     *github.Service
-		RepoHooks     *repohooks.Service
-		Agents        *agent.Service
-		Connections   *connections.Service
-		System        *internal.HostnameService
+		Organizations   *organization.Service
+		Runs            *run.Service
+		Workspaces      *workspace.Service
+		Variables       *variable.Service
+		SSHKeys         *sshkey.Service
+		PolicySets      *policy.Service
+		Notifications   *notifications.Service
+		Emails          *email.Service
+		RunTriggers     *runtrigger.Service
+		RunTasks        *runtask.Service
+		Assessments     *assessment.Service
+		OrgMetrics      *orgmetrics.Service
+		Annotations     *runannotation.Service
+		ChangeTickets   *changeticket.Service
+		ResourceChanges *resourcechange.Service
+		OrgExports      *orgexport.Service
+		OrgImports      *orgimport.Service
+		Search          *search.Service
+		Banners         *banner.Service
+		FeatureFlags    *featureflag.Service
+		SSO             *sso.Service
+		Audit           *audit.Service
+		Logs            *logs.Service
+		State           *state.Service
+		Configs         *configversion.Service
+		Blobs           *blob.Service
+		Modules         *module.Service
+		Providers       *registryprovider.Service
+		VCSProviders    *vcsprovider.Service
+		Tokens          *tokens.Service
+		Teams           *team.Service
+		Users           *user.Service
+		GithubApp       *github.Service
+		RepoHooks       *repohooks.Service
+		VCSEvents       *vcsevent.Service
+		Templates       *workspacetemplate.Service
+		Agents          *agent.Service
+		Connections     *connections.Service
+		System          *internal.HostnameService
 
 		handlers []internal.Handlers
 		listener *sql.Listener
@@ -149,19 +201,34 @@ func New(ctx context.Context, logger logr.Logger, cfg Config) (*Daemon, error) {
 		OrganizationService: orgService,
 		TokensService:       tokensService,
 	})
+	emailService := email.NewService(email.Options{
+		Logger: logger,
+		DB:     db,
+		Config: cfg.Email,
+	})
 	userService := user.NewService(user.Options{
-		Logger:        logger,
-		DB:            db,
-		Renderer:      renderer,
-		Responder:     responder,
-		TokensService: tokensService,
-		SiteToken:     cfg.SiteToken,
-		TeamService:   teamService,
+		Logger:          logger,
+		DB:              db,
+		Renderer:        renderer,
+		Responder:       responder,
+		TokensService:   tokensService,
+		SiteToken:       cfg.SiteToken,
+		TeamService:     teamService,
+		EmailService:    emailService,
+		HostnameService: hostnameService,
 	})
 	// promote nominated users to site admin
 	if err := userService.SetSiteAdmins(ctx, cfg.SiteAdmins...); err != nil {
 		return nil, err
 	}
+	// permit bootstrapping or recovering access via a one-time recovery token
+	if err := userService.InitRecoveryToken(ctx, cfg.RecoveryToken); err != nil {
+		return nil, err
+	}
+
+	// cap concurrent requests to vcs providers; must be set before any vcs
+	// clients are constructed
+	vcs.SetMaxConcurrentRequests(cfg.VCSMaxConcurrentRequests)
 
 	githubAppService := github.NewService(github.Options{
 		Logger:              logger,
@@ -172,20 +239,39 @@ func New(ctx context.Context, logger logr.Logger, cfg Config) (*Daemon, error) {
 		SkipTLSVerification: cfg.SkipTLSVerification,
 	})
 
-	vcsEventBroker := &vcs.Broker{}
+	vcsEventBroker := &vcs.Broker{
+		Logger:    logger.WithValues("component", "vcs-event-broker"),
+		RateLimit: cfg.WebhookRateLimit,
+	}
+	vcsEventService := vcsevent.NewService(vcsevent.Options{
+		Logger: logger,
+		DB:     db,
+		Broker: vcsEventBroker,
+	})
+	vcsEventBroker.Deliveries = vcsEventService
+	vcsEventBroker.DeadLetters = vcsEventService
+	vcsEventBroker.Queue = vcsEventService
 
 	vcsProviderService := vcsprovider.NewService(vcsprovider.Options{
-		Logger:              logger,
-		DB:                  db,
-		Renderer:            renderer,
-		Responder:           responder,
-		HostnameService:     hostnameService,
-		GithubAppService:    githubAppService,
-		GithubHostname:      cfg.GithubHostname,
-		GitlabHostname:      cfg.GitlabHostname,
-		SkipTLSVerification: cfg.SkipTLSVerification,
-		Subscriber:          vcsEventBroker,
+		Logger:                  logger,
+		DB:                      db,
+		Renderer:                renderer,
+		Responder:               responder,
+		HostnameService:         hostnameService,
+		GithubAppService:        githubAppService,
+		GithubHostname:          cfg.GithubHostname,
+		GitlabHostname:          cfg.GitlabHostname,
+		BitbucketHostname:       cfg.BitbucketHostname,
+		BitbucketServerHostname: cfg.BitbucketServerHostname,
+		GiteaHostname:           cfg.GiteaHostname,
+		SkipTLSVerification:     cfg.SkipTLSVerification,
+		Subscriber:              vcsEventBroker,
+		Cache:                   cache,
 	})
+	webhookRequireDate := make([]vcs.Kind, len(cfg.WebhookRequireDate))
+	for i, kind := range cfg.WebhookRequireDate {
+		webhookRequireDate[i] = vcs.Kind(kind)
+	}
 	repoService := repohooks.NewService(ctx, repohooks.Options{
 		Logger:              logger,
 		DB:                  db,
@@ -194,9 +280,15 @@ func New(ctx context.Context, logger logr.Logger, cfg Config) (*Daemon, error) {
 		VCSProviderService:  vcsProviderService,
 		GithubAppService:    githubAppService,
 		VCSEventBroker:      vcsEventBroker,
+		WebhookClockSkew:    cfg.WebhookClockSkew,
+		WebhookRequireDate:  webhookRequireDate,
+		GitlabWebhookGroups: cfg.GitlabWebhookGroups,
 	})
 	repoService.RegisterCloudHandler(vcs.GithubKind, github.HandleEvent)
 	repoService.RegisterCloudHandler(vcs.GitlabKind, gitlab.HandleEvent)
+	repoService.RegisterCloudHandler(vcs.BitbucketKind, bitbucket.HandleEvent)
+	repoService.RegisterCloudHandler(vcs.BitbucketServerKind, bitbucketserver.HandleEvent)
+	repoService.RegisterCloudHandler(vcs.GiteaKind, gitea.HandleEvent)
 
 	connectionService := connections.NewService(ctx, connections.Options{
 		Logger:             logger,
@@ -221,15 +313,43 @@ func New(ctx context.Context, logger logr.Logger, cfg Config) (*Daemon, error) {
 		TeamService:         teamService,
 		OrganizationService: orgService,
 		VCSProviderService:  vcsProviderService,
+		RecoveryDays:        cfg.WorkspaceRecoveryDays,
+		RenameGraceDays:     cfg.WorkspaceRenameGraceDays,
 	})
+	blobBackend, err := blob.NewBackend(ctx, cfg.Blob)
+	if err != nil {
+		return nil, fmt.Errorf("setting up blob backend: %w", err)
+	}
+	if blobBackend != nil {
+		logger.Info("storing blobs in bucket", "backend", cfg.Blob.Backend, "bucket", cfg.Blob.Bucket)
+	}
+	blobService := blob.NewService(blob.Options{
+		Logger:      logger,
+		DB:          db,
+		Signer:      signer,
+		MaxSize:     cfg.MaxConfigSize,
+		Backend:     blobBackend,
+		BackendName: cfg.Blob.Backend,
+	})
+
+	auditService := audit.NewService(audit.Options{
+		Logger: logger,
+		DB:     db,
+	})
+
 	configService := configversion.NewService(configversion.Options{
-		Logger:              logger,
-		DB:                  db,
-		WorkspaceAuthorizer: workspaceService,
-		Responder:           responder,
-		Cache:               cache,
-		Signer:              signer,
-		MaxConfigSize:       cfg.MaxConfigSize,
+		Logger:                logger,
+		DB:                    db,
+		WorkspaceAuthorizer:   workspaceService,
+		BlobService:           blobService,
+		AuditService:          auditService,
+		Responder:             responder,
+		Cache:                 cache,
+		Signer:                signer,
+		MaxConfigSize:         cfg.MaxConfigSize,
+		MaxConfigUnpackedSize: cfg.MaxConfigUnpackedSize,
+		MaxConfigFileSize:     cfg.MaxConfigFileSize,
+		StorageQuota:          cfg.MaxConfigStorage,
 	})
 
 	runService := run.NewService(run.Options{
@@ -248,19 +368,23 @@ func New(ctx context.Context, logger logr.Logger, cfg Config) (*Daemon, error) {
 		Signer:               signer,
 		ReleasesService:      releasesService,
 		TokensService:        tokensService,
+		Secret:               cfg.Secret,
+		ForceCancelCoolOff:   cfg.ForceCancelCoolOff,
 	})
 	logsService := logs.NewService(logs.Options{
-		Logger:        logger,
-		DB:            db,
-		RunAuthorizer: runService,
-		Cache:         cache,
-		Listener:      listener,
-		Verifier:      signer,
+		Logger:              logger,
+		DB:                  db,
+		RunAuthorizer:       runService,
+		WorkspaceAuthorizer: workspaceService,
+		Cache:               cache,
+		Listener:            listener,
+		Verifier:            signer,
 	})
 	moduleService := module.NewService(module.Options{
 		Logger:             logger,
 		DB:                 db,
 		Renderer:           renderer,
+		Responder:          responder,
 		HostnameService:    hostnameService,
 		VCSProviderService: vcsProviderService,
 		Signer:             signer,
@@ -268,10 +392,17 @@ func New(ctx context.Context, logger logr.Logger, cfg Config) (*Daemon, error) {
 		RepohookService:    repoService,
 		VCSEventSubscriber: vcsEventBroker,
 	})
+	providerService := registryprovider.NewService(registryprovider.Options{
+		Logger:    logger,
+		DB:        db,
+		Responder: responder,
+		Signer:    signer,
+	})
 	stateService := state.NewService(state.Options{
 		Logger:           logger,
 		DB:               db,
 		WorkspaceService: workspaceService,
+		AuditService:     auditService,
 		Cache:            cache,
 		Renderer:         renderer,
 		Responder:        responder,
@@ -286,6 +417,29 @@ func New(ctx context.Context, logger logr.Logger, cfg Config) (*Daemon, error) {
 		WorkspaceService:    workspaceService,
 		RunClient:           runService,
 	})
+	sshKeyService := sshkey.NewService(sshkey.Options{
+		Logger:              logger,
+		DB:                  db,
+		Responder:           responder,
+		Secret:              cfg.Secret,
+		WorkspaceAuthorizer: workspaceService,
+		WorkspaceService:    workspaceService,
+	})
+	policyService := policy.NewService(policy.Options{
+		Logger:              logger,
+		DB:                  db,
+		Responder:           responder,
+		Signer:              signer,
+		MaxUploadSize:       cfg.MaxConfigSize,
+		WorkspaceAuthorizer: workspaceService,
+		WorkspaceService:    workspaceService,
+		RunService:          runService,
+	})
+	if opaPath, err := exec.LookPath(cfg.OPABinary); err != nil {
+		logger.V(0).Info("opa binary not found: OPA policy sets will not be evaluated", "opa_binary", cfg.OPABinary)
+	} else {
+		policyService.RegisterEvaluator(policy.OPA, policy.NewOPAEvaluator(opaPath))
+	}
 
 	agentService := agent.NewService(agent.ServiceOptions{
 		Logger:           logger,
@@ -295,6 +449,8 @@ func New(ctx context.Context, logger logr.Logger, cfg Config) (*Daemon, error) {
 		RunService:       runService,
 		WorkspaceService: workspaceService,
 		TokensService:    tokensService,
+		LogsService:      logsService,
+		StateService:     stateService,
 		Listener:         listener,
 	})
 
@@ -304,6 +460,7 @@ func New(ctx context.Context, logger logr.Logger, cfg Config) (*Daemon, error) {
 		agent.ServerDaemonOptions{
 			WorkspaceService:            workspaceService,
 			VariableService:             variableService,
+			SSHKeyService:               sshKeyService,
 			StateService:                stateService,
 			ConfigurationVersionService: configService,
 			RunService:                  runService,
@@ -357,16 +514,159 @@ func New(ctx context.Context, logger logr.Logger, cfg Config) (*Daemon, error) {
 		DB:                  db,
 		Listener:            listener,
 		Responder:           responder,
+		HostnameService:     hostnameService,
 		WorkspaceAuthorizer: workspaceService,
+		WorkspaceService:    workspaceService,
+		EmailService:        emailService,
+	})
+
+	runTaskService := runtask.NewService(runtask.Options{
+		Logger:              logger,
+		DB:                  db,
+		Responder:           responder,
+		HostnameService:     hostnameService,
+		WorkspaceAuthorizer: workspaceService,
+		WorkspaceService:    workspaceService,
+		RunService:          runService,
+		TokensService:       tokensService,
+	})
+
+	featureFlagService := featureflag.NewService(featureflag.Options{
+		Logger: logger,
+		DB:     db,
+		Config: cfg.FeatureFlags,
+	})
+
+	ssoService := sso.NewService(sso.Options{
+		Logger:        logger,
+		DB:            db,
+		Listener:      listener,
+		TokensService: tokensService,
+	})
+
+	assessmentService := assessment.NewService(assessment.Options{
+		Logger:              logger,
+		DB:                  db,
+		Responder:           responder,
+		WorkspaceAuthorizer: workspaceService,
+		RunService:          runService,
+		WorkspaceService:    workspaceService,
+		FeatureFlagService:  featureFlagService,
+	})
+
+	orgMetricsService := orgmetrics.NewService(orgmetrics.Options{
+		Logger:     logger,
+		Config:     cfg.OrganizationMetrics,
+		RunService: runService,
+	})
+
+	annotationService := runannotation.NewService(runannotation.Options{
+		Logger:     logger,
+		DB:         db,
+		RunService: runService,
+		Webhooks:   cfg.RunAnnotationWebhooks,
+	})
+
+	changeTicketService := changeticket.NewService(changeticket.Options{
+		Logger:           logger,
+		DB:               db,
+		HostnameService:  hostnameService,
+		Config:           cfg.ChangeTickets,
+		RunService:       runService,
+		WorkspaceService: workspaceService,
+		TokensService:    tokensService,
+	})
+
+	resourceChangeService := resourcechange.NewService(resourcechange.Options{
+		Logger:      logger,
+		DB:          db,
+		Listener:    listener,
+		Config:      cfg.ResourceChanges,
+		RunService:  runService,
+		LogsService: logsService,
+	})
+
+	orgExportSink, err := orgexport.NewSink(ctx, cfg.OrganizationExport)
+	if err != nil {
+		return nil, fmt.Errorf("setting up organization export sink: %w", err)
+	}
+	orgExportService := orgexport.NewService(orgexport.Options{
+		Logger: logger,
+		DB:     db,
+		Sink:   orgExportSink,
+	})
+
+	runTriggerService := runtrigger.NewService(runtrigger.Options{
+		Logger:              logger,
+		DB:                  db,
+		Responder:           responder,
+		WorkspaceAuthorizer: workspaceService,
+		WorkspaceService:    workspaceService,
+	})
+
+	orgImportService := orgimport.NewService(orgimport.Options{
+		Logger:           logger,
+		DB:               db,
+		Responder:        responder,
+		WorkspaceService: workspaceService,
+		VariableService:  variableService,
+		StateService:     stateService,
+		ModuleService:    moduleService,
+		TeamService:      teamService,
+		UserService:      userService,
+		MaxModuleSize:    cfg.MaxConfigSize,
+	})
+	repoImportService := repoimport.NewService(repoimport.Options{
+		Logger:             logger,
+		Responder:          responder,
+		WorkspaceService:   workspaceService,
+		VCSProviderService: vcsProviderService,
+		RunTriggerService:  runTriggerService,
+	})
+	workspaceTemplateService := workspacetemplate.NewService(workspacetemplate.Options{
+		Logger:              logger,
+		DB:                  db,
+		Responder:           responder,
+		WorkspaceService:    workspaceService,
+		VariableService:     variableService,
+		NotificationService: notificationService,
+	})
+
+	searchService := search.NewService(search.Options{
+		Logger:    logger,
+		DB:        db,
+		Responder: responder,
+	})
+
+	resolverService := resolver.NewService(resolver.Options{
+		Logger: logger,
+	})
+	resolverService.Register(resource.OrganizationKind, orgService.ResolveAlias)
+	resolverService.Register(resource.WorkspaceKind, workspaceService.ResolveAlias)
+	resolverService.Register(resource.TeamKind, teamService.ResolveAlias)
+	resolverService.Register(resource.UserKind, userService.ResolveAlias)
+
+	authzService := authz.NewService(authz.Options{
+		Logger: logger,
+	})
+	authzService.Register(resource.WorkspaceKind, workspaceService.GetPolicy)
+	authzService.Register(resource.RunKind, runService.GetPolicy)
+
+	bannerService := banner.NewService(banner.Options{
+		Logger:   logger,
+		DB:       db,
+		Renderer: renderer,
 	})
 
 	tfapi := tfapi.NewTerraformAPIService(cfg.Secret, userService, renderer)
 	tfeapi := tfeapi.NewTerraformEnterpriseAPIService(tfeapi.Options{
 		ConfigurationVersionService: configService,
 		OrganizationService:         orgService,
+		RunService:                  runService,
+		LogsService:                 logsService,
+		StateService:                stateService,
 		Responder:                   responder,
 		Signer:                      signer,
-		MaxUploadSize:               cfg.MaxConfigSize,
 	})
 
 	handlers := []internal.Handlers{
@@ -378,50 +678,96 @@ func New(ctx context.Context, logger logr.Logger, cfg Config) (*Daemon, error) {
 		stateService,
 		orgService,
 		variableService,
+		sshKeyService,
+		policyService,
 		vcsProviderService,
 		moduleService,
+		providerService,
 		runService,
 		logsService,
 		repoService,
+		vcsEventService,
 		authenticatorService,
 		configService,
+		blobService,
 		notificationService,
+		runTriggerService,
+		runTaskService,
+		assessmentService,
+		orgMetricsService,
+		annotationService,
+		changeTicketService,
+		resourceChangeService,
+		orgExportService,
+		bannerService,
+		featureFlagService,
+		ssoService,
+		auditService,
 		githubAppService,
 		agentService,
+		orgImportService,
+		repoImportService,
+		workspaceTemplateService,
+		searchService,
+		resolverService,
+		authzService,
 		&ghapphandler.Handler{
 			Logger:       logger,
 			Publisher:    vcsEventBroker,
 			GithubApps:   githubAppService,
 			VCSProviders: vcsProviderService,
+			ClockSkew:    cfg.WebhookClockSkew,
+			RequireDate:  slices.Contains(webhookRequireDate, vcs.GithubKind),
 		},
 		&api.Handlers{},
 	}
 
 	return &Daemon{
-		Config:        cfg,
-		Logger:        logger,
-		handlers:      handlers,
-		Organizations: orgService,
-		System:        hostnameService,
-		Runs:          runService,
-		Workspaces:    workspaceService,
-		Variables:     variableService,
-		Notifications: notificationService,
-		Logs:          logsService,
-		State:         stateService,
-		Configs:       configService,
-		Modules:       moduleService,
-		VCSProviders:  vcsProviderService,
-		Tokens:        tokensService,
-		Teams:         teamService,
-		Users:         userService,
-		RepoHooks:     repoService,
-		GithubApp:     githubAppService,
-		Connections:   connectionService,
-		Agents:        agentService,
-		DB:            db,
-		agent:         agentDaemon,
-		listener:      listener,
+		Config:          cfg,
+		Logger:          logger,
+		handlers:        handlers,
+		Organizations:   orgService,
+		System:          hostnameService,
+		Runs:            runService,
+		Workspaces:      workspaceService,
+		Variables:       variableService,
+		SSHKeys:         sshKeyService,
+		PolicySets:      policyService,
+		Notifications:   notificationService,
+		Emails:          emailService,
+		RunTriggers:     runTriggerService,
+		RunTasks:        runTaskService,
+		Assessments:     assessmentService,
+		OrgMetrics:      orgMetricsService,
+		Annotations:     annotationService,
+		ChangeTickets:   changeTicketService,
+		ResourceChanges: resourceChangeService,
+		OrgExports:      orgExportService,
+		OrgImports:      orgImportService,
+		Search:          searchService,
+		Banners:         bannerService,
+		FeatureFlags:    featureFlagService,
+		SSO:             ssoService,
+		Audit:           auditService,
+		Logs:            logsService,
+		State:           stateService,
+		Configs:         configService,
+		Blobs:           blobService,
+		Modules:         moduleService,
+		Providers:       providerService,
+		VCSProviders:    vcsProviderService,
+		Tokens:          tokensService,
+		Teams:           teamService,
+		Users:           userService,
+		RepoHooks:       repoService,
+		VCSEvents:       vcsEventService,
+		Templates:       workspaceTemplateService,
+		GithubApp:       githubAppService,
+		Connections:     connectionService,
+		Agents:          agentService,
+		DB:              db,
+		agent:           agentDaemon,
+		listener:        listener,
 	}, nil
 }
 
@@ -435,15 +781,7 @@ func (d *Daemon) Start(ctx context.Context, started chan struct{}) error {
 	defer d.DB.Close()
 
 	// Construct web server and start listening on port
-	server, err := http.NewServer(d.Logger, http.ServerConfig{
-		SSL:                  d.SSL,
-		CertFile:             d.CertFile,
-		KeyFile:              d.KeyFile,
-		EnableRequestLogging: d.EnableRequestLogging,
-		DevMode:              d.DevMode,
-		Middleware:           []mux.MiddlewareFunc{d.Tokens.Middleware()},
-		Handlers:             d.handlers,
-	})
+	server, err := http.NewServer(d.Logger, d.serverConfig())
 	if err != nil {
 		return fmt.Errorf("setting up http server: %w", err)
 	}
@@ -460,6 +798,64 @@ func (d *Daemon) Start(ctx context.Context, started chan struct{}) error {
 		d.System.SetHostname(internal.NormalizeAddress(listenAddress))
 	}
 
+	if err := d.startSubsystems(ctx, g); err != nil {
+		return err
+	}
+
+	// Run HTTP/JSON-API server and web app
+	g.Go(func() error {
+		if err := server.Start(ctx, ln); err != nil {
+			return fmt.Errorf("http server terminated: %w", err)
+		}
+		return nil
+	})
+
+	// Inform the caller the daemon has started
+	close(started)
+
+	// Block until error or Ctrl-C received.
+	return g.Wait()
+}
+
+// Handler returns the http handler serving the API and web app, for use by a
+// caller that runs its own http server rather than calling Start.
+func (d *Daemon) Handler() (nethttp.Handler, error) {
+	return http.NewHandler(d.Logger, d.serverConfig())
+}
+
+// StartSubsystems starts the daemon's background subsystems, without an http
+// server, and blocks until ctx is cancelled or an error is returned. The
+// started channel is closed once the subsystems have started.
+func (d *Daemon) StartSubsystems(ctx context.Context, started chan struct{}) error {
+	g, ctx := errgroup.WithContext(ctx)
+
+	// close all db connections upon exit
+	defer d.DB.Close()
+
+	if err := d.startSubsystems(ctx, g); err != nil {
+		return err
+	}
+
+	close(started)
+
+	return g.Wait()
+}
+
+func (d *Daemon) serverConfig() http.ServerConfig {
+	return http.ServerConfig{
+		SSL:                  d.SSL,
+		CertFile:             d.CertFile,
+		KeyFile:              d.KeyFile,
+		EnableRequestLogging: d.EnableRequestLogging,
+		DevMode:              d.DevMode,
+		Middleware:           []mux.MiddlewareFunc{d.Tokens.Middleware()},
+		Handlers:             d.handlers,
+	}
+}
+
+// startSubsystems starts the subsystems in the errgroup and waits for those
+// that other components depend upon to be ready.
+func (d *Daemon) startSubsystems(ctx context.Context, g *errgroup.Group) error {
 	d.V(0).Info("set system hostname", "hostname", d.System.Hostname())
 	d.V(0).Info("set webhook hostname", "webhook_hostname", d.System.WebhookHostname())
 
@@ -474,6 +870,13 @@ func (d *Daemon) Start(ctx context.Context, started chan struct{}) error {
 			Logger: d.Logger,
 			System: d.Logs,
 		},
+		{
+			// every node records metrics so that each can serve an
+			// organization's metrics
+			Name:   "organization-metrics",
+			Logger: d.Logger,
+			System: d.OrgMetrics,
+		},
 		{
 			Name:      "reporter",
 			Logger:    d.Logger,
@@ -501,9 +904,62 @@ func (d *Daemon) Start(ctx context.Context, started chan struct{}) error {
 				WorkspaceClient:    d.Workspaces,
 				RunClient:          d.Runs,
 				NotificationClient: d.Notifications,
+				EmailService:       d.Emails,
 				DB:                 d.DB,
 			}),
 		},
+		{
+			Name:      "run-trigger-propagator",
+			Logger:    d.Logger,
+			Exclusive: true,
+			DB:        d.DB,
+			LockID:    internal.Int64(runtrigger.LockID),
+			System: runtrigger.NewPropagator(runtrigger.PropagatorOptions{
+				Logger:    d.Logger,
+				RunClient: d.Runs,
+				DB:        d.DB,
+			}),
+		},
+		{
+			Name:      "run-task-dispatcher",
+			Logger:    d.Logger,
+			Exclusive: true,
+			DB:        d.DB,
+			LockID:    internal.Int64(runtask.DispatcherLockID),
+			System:    d.RunTasks.NewDispatcher(d.Logger),
+		},
+		{
+			Name:      "policy-checker",
+			Logger:    d.Logger,
+			Exclusive: true,
+			DB:        d.DB,
+			LockID:    internal.Int64(policy.CheckerLockID),
+			System:    d.PolicySets.NewChecker(d.Logger),
+		},
+		{
+			Name:      "assessment-scheduler",
+			Logger:    d.Logger,
+			Exclusive: true,
+			DB:        d.DB,
+			LockID:    internal.Int64(assessment.SchedulerLockID),
+			System:    d.Assessments.NewScheduler(d.Logger),
+		},
+		{
+			Name:      "run-annotator",
+			Logger:    d.Logger,
+			Exclusive: true,
+			DB:        d.DB,
+			LockID:    internal.Int64(runannotation.AnnotatorLockID),
+			System:    d.Annotations.NewAnnotator(d.Logger),
+		},
+		{
+			Name:      "resource-change-recorder",
+			Logger:    d.Logger,
+			Exclusive: true,
+			DB:        d.DB,
+			LockID:    internal.Int64(resourcechange.RecorderLockID),
+			System:    d.ResourceChanges.NewRecorder(d.Logger),
+		},
 		{
 			Name:      "job-allocator",
 			Logger:    d.Logger,
@@ -520,12 +976,107 @@ func (d *Daemon) Start(ctx context.Context, started chan struct{}) error {
 			LockID:    internal.Int64(agent.ManagerLockID),
 			System:    d.Agents.NewManager(),
 		},
+		{
+			Name:      "workspace-reaper",
+			Logger:    d.Logger,
+			Exclusive: true,
+			DB:        d.DB,
+			LockID:    internal.Int64(workspace.ReaperLockID),
+			System:    d.Workspaces.NewReaper(d.Logger),
+		},
+		{
+			Name:      "module-syncer",
+			Logger:    d.Logger,
+			Exclusive: true,
+			DB:        d.DB,
+			LockID:    internal.Int64(module.SyncerLockID),
+			System:    d.Modules.NewSyncer(d.Logger),
+		},
+		{
+			Name:      "vcs-event-pruner",
+			Logger:    d.Logger,
+			Exclusive: true,
+			DB:        d.DB,
+			LockID:    internal.Int64(vcsevent.PrunerLockID),
+			System:    d.VCSEvents.NewPruner(d.Logger),
+		},
+		{
+			Name:      "vcs-event-resumer",
+			Logger:    d.Logger,
+			Exclusive: true,
+			DB:        d.DB,
+			LockID:    internal.Int64(vcsevent.ResumerLockID),
+			System:    d.VCSEvents.NewResumer(d.Logger),
+		},
 		{
 			Name:   "agent-daemon",
 			Logger: d.Logger,
 			DB:     d.DB,
 			System: d.agent,
 		},
+		{
+			// every node caches enforcements of single sign-on to check
+			// when authenticating requests
+			Name:   "sso-enforcement-cache",
+			Logger: d.Logger,
+			System: d.SSO,
+		},
+		{
+			Name:   "organization-importer",
+			Logger: d.Logger,
+			DB:     d.DB,
+			System: d.OrgImports,
+		},
+	}
+	if d.Emails.Enabled() {
+		subsystems = append(subsystems, &Subsystem{
+			Name:      "mailer",
+			Logger:    d.Logger,
+			Exclusive: true,
+			DB:        d.DB,
+			LockID:    internal.Int64(email.LockID),
+			System:    d.Emails.NewMailer(d.Logger),
+		})
+	}
+	if d.ChangeTickets.Enabled() {
+		subsystems = append(subsystems, &Subsystem{
+			Name:      "change-ticket-opener",
+			Logger:    d.Logger,
+			Exclusive: true,
+			DB:        d.DB,
+			LockID:    internal.Int64(changeticket.OpenerLockID),
+			System:    d.ChangeTickets.NewOpener(d.Logger),
+		})
+	}
+	if d.ResourceChanges.Enabled() {
+		subsystems = append(subsystems, &Subsystem{
+			Name:      "resource-change-exporter",
+			Logger:    d.Logger,
+			Exclusive: true,
+			DB:        d.DB,
+			LockID:    internal.Int64(resourcechange.ExporterLockID),
+			System:    d.ResourceChanges.NewExporter(d.Logger),
+		})
+	}
+	if d.OrgExports.Enabled() {
+		subsystems = append(subsystems, &Subsystem{
+			Name:      "organization-exporter",
+			Logger:    d.Logger,
+			Exclusive: true,
+			DB:        d.DB,
+			LockID:    internal.Int64(orgexport.ExporterLockID),
+			System:    d.OrgExports.NewExporter(d.Logger),
+		})
+	}
+	if d.Blobs.Migratable() {
+		subsystems = append(subsystems, &Subsystem{
+			Name:      "blob-migrator",
+			Logger:    d.Logger,
+			Exclusive: true,
+			DB:        d.DB,
+			LockID:    internal.Int64(blob.MigratorLockID),
+			System:    d.Blobs.NewMigrator(d.Logger),
+		})
 	}
 	if !d.DisableScheduler {
 		subsystems = append(subsystems, &Subsystem{
@@ -562,17 +1113,5 @@ func (d *Daemon) Start(ctx context.Context, started chan struct{}) error {
 	case <-d.agent.Registered():
 	}
 
-	// Run HTTP/JSON-API server and web app
-	g.Go(func() error {
-		if err := server.Start(ctx, ln); err != nil {
-			return fmt.Errorf("http server terminated: %w", err)
-		}
-		return nil
-	})
-
-	// Inform the caller the daemon has started
-	close(started)
-
-	// Block until error or Ctrl-C received.
-	return g.Wait()
+	return nil
 }
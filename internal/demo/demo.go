@@ -0,0 +1,48 @@
+// Package demo runs otfd in demo mode, lowering the barrier to evaluating otf:
+// otfd uses an ephemeral database seeded with sample data, and prints a URL
+// for logging in as site admin.
+package demo
+
+import (
+	"crypto/rand"
+	"net/url"
+
+	"github.com/leg100/otf/internal"
+	"github.com/leg100/otf/internal/daemon"
+	"github.com/leg100/otf/internal/http/html/paths"
+)
+
+// Configure configures the daemon to use the ephemeral database, generating a
+// secret and a site token unless they have already been set.
+func Configure(cfg *daemon.Config, db *Postgres) error {
+	cfg.Database = db.ConnString()
+	if cfg.Secret == nil {
+		cfg.Secret = make([]byte, 16)
+		if _, err := rand.Read(cfg.Secret); err != nil {
+			return err
+		}
+	}
+	if cfg.SiteToken == "" {
+		token, err := internal.GenerateToken()
+		if err != nil {
+			return err
+		}
+		cfg.SiteToken = token
+	}
+	return nil
+}
+
+// LoginURL returns a URL for logging into the started daemon as the site
+// admin, with the site token pre-filled.
+func LoginURL(d *daemon.Daemon) string {
+	u := url.URL{
+		Scheme:   "http",
+		Host:     d.System.Hostname(),
+		Path:     paths.AdminLogin(),
+		RawQuery: url.Values{"token": {d.SiteToken}}.Encode(),
+	}
+	if d.SSL {
+		u.Scheme = "https"
+	}
+	return u.String()
+}
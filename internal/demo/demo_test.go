@@ -0,0 +1,38 @@
+package demo
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPack(t *testing.T) {
+	tarball, err := pack("sample/module")
+	require.NoError(t, err)
+
+	gr, err := gzip.NewReader(bytes.NewReader(tarball))
+	require.NoError(t, err)
+	tr := tar.NewReader(gr)
+
+	var got []string
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+		got = append(got, hdr.Name)
+	}
+	assert.Equal(t, []string{"README.md", "main.tf"}, got)
+}
+
+func TestPostgres_ConnString(t *testing.T) {
+	p := &Postgres{dir: "/tmp/otf-demo-123"}
+
+	assert.Equal(t, "postgres:///postgres?host=%2Ftmp%2Fotf-demo-123&user=postgres", p.ConnString())
+}
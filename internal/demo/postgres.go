@@ -0,0 +1,163 @@
+package demo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"time"
+
+	"github.com/jackc/pgx/v4"
+	"github.com/leg100/otf/internal/logr"
+)
+
+const (
+	// postgresUser is the superuser created in the ephemeral database.
+	postgresUser = "postgres"
+	// postgresStartTimeout is how long to wait for the ephemeral database to
+	// accept connections.
+	postgresStartTimeout = 30 * time.Second
+	// postgresStopTimeout is how long to wait for the ephemeral database to
+	// shut down before killing it.
+	postgresStopTimeout = 10 * time.Second
+)
+
+// ErrPostgresNotFound is returned when the postgres binaries required to run
+// an ephemeral database cannot be found.
+var ErrPostgresNotFound = errors.New("demo mode requires postgres to be installed: initdb and postgres binaries not found")
+
+// Postgres is an ephemeral postgres server, running as a child process and
+// storing its data in a temporary directory that is removed when the server
+// is stopped. The server only listens on a unix socket in that directory.
+type Postgres struct {
+	logr.Logger
+
+	dir  string
+	cmd  *exec.Cmd
+	done chan error
+}
+
+// StartPostgres initializes and starts an ephemeral postgres server, and
+// blocks until it is accepting connections.
+func StartPostgres(ctx context.Context, logger logr.Logger) (*Postgres, error) {
+	initdb, postgres, err := findPostgres()
+	if err != nil {
+		return nil, err
+	}
+	dir, err := os.MkdirTemp("", "otf-demo-")
+	if err != nil {
+		return nil, err
+	}
+	data := filepath.Join(dir, "data")
+	out, err := exec.CommandContext(ctx, initdb, "-D", data, "-U", postgresUser, "-A", "trust", "-E", "UTF8").CombinedOutput()
+	if err != nil {
+		os.RemoveAll(dir)
+		return nil, fmt.Errorf("initializing demo database: %w: %s", err, out)
+	}
+	p := &Postgres{
+		Logger: logger.WithValues("component", "demo-database"),
+		dir:    dir,
+		// listen only on a unix socket in the temporary directory, so as not
+		// to clash with any other postgres server on the host. Durability is
+		// of no concern for an ephemeral database.
+		cmd:  exec.Command(postgres, "-D", data, "-k", dir, "-c", "listen_addresses=", "-c", "fsync=off"),
+		done: make(chan error, 1),
+	}
+	if err := p.cmd.Start(); err != nil {
+		os.RemoveAll(dir)
+		return nil, fmt.Errorf("starting demo database: %w", err)
+	}
+	go func() {
+		p.done <- p.cmd.Wait()
+	}()
+	if err := p.wait(ctx); err != nil {
+		p.Stop()
+		return nil, err
+	}
+	p.V(0).Info("started demo database", "dir", dir)
+	return p, nil
+}
+
+// ConnString returns the connection string for the server.
+func (p *Postgres) ConnString() string {
+	q := url.Values{}
+	q.Set("host", p.dir)
+	q.Set("user", postgresUser)
+	return (&url.URL{Scheme: "postgres", Path: "/postgres", RawQuery: q.Encode()}).String()
+}
+
+// Stop shuts down the server and removes its data.
+func (p *Postgres) Stop() error {
+	defer os.RemoveAll(p.dir)
+
+	// interrupt requests a fast shutdown, which disconnects clients rather
+	// than waiting for them to disconnect.
+	if err := p.cmd.Process.Signal(os.Interrupt); err != nil && !errors.Is(err, os.ErrProcessDone) {
+		return err
+	}
+	select {
+	case <-p.done:
+	case <-time.After(postgresStopTimeout):
+		if err := p.cmd.Process.Kill(); err != nil && !errors.Is(err, os.ErrProcessDone) {
+			return err
+		}
+		<-p.done
+	}
+	p.V(0).Info("stopped demo database")
+	return nil
+}
+
+// wait blocks until the server is accepting connections.
+func (p *Postgres) wait(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, postgresStartTimeout)
+	defer cancel()
+
+	for {
+		conn, err := pgx.Connect(ctx, p.ConnString())
+		if err == nil {
+			return conn.Close(ctx)
+		}
+		select {
+		case err := <-p.done:
+			// put it back for Stop to receive
+			p.done <- err
+			return fmt.Errorf("demo database exited unexpectedly: %w", err)
+		case <-ctx.Done():
+			return fmt.Errorf("waiting for demo database to start: %w", err)
+		case <-time.After(100 * time.Millisecond):
+		}
+	}
+}
+
+// findPostgres finds the initdb and postgres binaries, looking first in the
+// path and then in the directories in which they're installed on debian based
+// distributions, which are not in the path by default.
+func findPostgres() (initdb string, postgres string, err error) {
+	initdb, err = exec.LookPath("initdb")
+	if err == nil {
+		postgres, err = exec.LookPath("postgres")
+		if err == nil {
+			return initdb, postgres, nil
+		}
+	}
+	dirs, _ := filepath.Glob("/usr/lib/postgresql/*/bin")
+	// prefer the most recent version
+	slices.Reverse(dirs)
+	for _, dir := range dirs {
+		initdb = filepath.Join(dir, "initdb")
+		postgres = filepath.Join(dir, "postgres")
+		if isExecutable(initdb) && isExecutable(postgres) {
+			return initdb, postgres, nil
+		}
+	}
+	return "", "", ErrPostgresNotFound
+}
+
+func isExecutable(path string) bool {
+	info, err := os.Stat(path)
+	return err == nil && !info.IsDir() && info.Mode()&0o111 != 0
+}
@@ -0,0 +1,18 @@
+resource "random_pet" "server" {
+  count = var.servers
+}
+
+resource "null_resource" "deployment" {
+  triggers = {
+    servers = join(",", random_pet.server[*].id)
+  }
+}
+
+variable "servers" {
+  type    = number
+  default = 3
+}
+
+output "servers" {
+  value = random_pet.server[*].id
+}
@@ -0,0 +1,14 @@
+resource "random_pet" "name" {
+  prefix = var.prefix
+}
+
+variable "prefix" {
+  type        = string
+  description = "Prefix of the generated name."
+  default     = "demo"
+}
+
+output "name" {
+  value       = random_pet.name.id
+  description = "Generated name."
+}
@@ -0,0 +1,166 @@
+package demo
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"embed"
+	"io/fs"
+
+	"github.com/leg100/otf/internal"
+	"github.com/leg100/otf/internal/configversion"
+	"github.com/leg100/otf/internal/daemon"
+	"github.com/leg100/otf/internal/module"
+	"github.com/leg100/otf/internal/organization"
+	"github.com/leg100/otf/internal/run"
+	"github.com/leg100/otf/internal/workspace"
+)
+
+const (
+	// Organization is the name of the seeded organization.
+	Organization = "demo"
+	// Workspace is the name of the seeded workspace.
+	Workspace = "servers"
+)
+
+//go:embed sample
+var sample embed.FS
+
+// history is the seeded run history of the workspace, oldest first.
+var history = []run.SeedOptions{
+	{
+		CreateOptions: run.CreateOptions{Message: internal.String("Initial deployment")},
+		Changes:       run.Report{Additions: 4},
+		Apply:         true,
+	},
+	{
+		CreateOptions: run.CreateOptions{Message: internal.String("Scale out to five servers")},
+		Changes:       run.Report{Additions: 2, Changes: 1},
+		Apply:         true,
+	},
+	{
+		CreateOptions: run.CreateOptions{Message: internal.String("Rename servers")},
+		Errored:       true,
+	},
+	{
+		CreateOptions: run.CreateOptions{Message: internal.String("Replace deployment")},
+		Changes:       run.Report{Additions: 1, Destructions: 1},
+		Apply:         true,
+		Errored:       true,
+	},
+	{
+		CreateOptions: run.CreateOptions{Message: internal.String("Check for drift")},
+	},
+}
+
+// Seed populates a new installation with an organization, a workspace with a
+// run history, and a module in the organization's registry. The context must
+// carry a subject with site admin permissions.
+func Seed(ctx context.Context, d *daemon.Daemon) error {
+	org, err := d.Organizations.Create(ctx, organization.CreateOptions{
+		Name: internal.String(Organization),
+	})
+	if err != nil {
+		return err
+	}
+	if err := seedWorkspace(ctx, d, org.Name); err != nil {
+		return err
+	}
+	return seedModule(ctx, d, org.Name)
+}
+
+func seedWorkspace(ctx context.Context, d *daemon.Daemon, organization string) error {
+	ws, err := d.Workspaces.Create(ctx, workspace.CreateOptions{
+		Name:         internal.String(Workspace),
+		Organization: &organization,
+	})
+	if err != nil {
+		return err
+	}
+	cv, err := d.Configs.Create(ctx, ws.ID, configversion.CreateOptions{})
+	if err != nil {
+		return err
+	}
+	config, err := pack("sample/config")
+	if err != nil {
+		return err
+	}
+	if err := d.Configs.UploadConfig(ctx, cv.ID, config); err != nil {
+		return err
+	}
+	var latest *run.Run
+	for _, opts := range history {
+		opts.ConfigurationVersionID = &cv.ID
+		latest, err = d.Runs.Seed(ctx, ws.ID, opts)
+		if err != nil {
+			return err
+		}
+	}
+	_, err = d.Workspaces.SetCurrentRun(ctx, ws.ID, latest.ID)
+	return err
+}
+
+func seedModule(ctx context.Context, d *daemon.Daemon, organization string) error {
+	mod, err := d.Modules.CreateModule(ctx, module.CreateOptions{
+		Name:         "pet",
+		Provider:     "random",
+		Organization: organization,
+	})
+	if err != nil {
+		return err
+	}
+	version, err := d.Modules.CreateVersion(ctx, module.CreateModuleVersionOptions{
+		ModuleID: mod.ID,
+		Version:  "1.0.0",
+	})
+	if err != nil {
+		return err
+	}
+	tarball, err := pack("sample/module")
+	if err != nil {
+		return err
+	}
+	return d.Modules.UploadVersion(ctx, version.ID, tarball)
+}
+
+// pack packs a directory of the embedded sample files into a tarball
+// (.tar.gz).
+func pack(dir string) ([]byte, error) {
+	root, err := fs.Sub(sample, dir)
+	if err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	gw := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gw)
+	err = fs.WalkDir(root, ".", func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		contents, err := fs.ReadFile(root, path)
+		if err != nil {
+			return err
+		}
+		err = tw.WriteHeader(&tar.Header{
+			Name: path,
+			Mode: 0o644,
+			Size: int64(len(contents)),
+		})
+		if err != nil {
+			return err
+		}
+		_, err = tw.Write(contents)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := tw.Close(); err != nil {
+		return nil, err
+	}
+	if err := gw.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,79 @@
+package email
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgtype"
+	"github.com/leg100/otf/internal/sql"
+	"github.com/leg100/otf/internal/sql/pggen"
+)
+
+// pgdb is the email message queue on postgres
+type pgdb struct {
+	*sql.DB // provides access to generated SQL queries
+}
+
+func (db *pgdb) insert(ctx context.Context, msg *Message) error {
+	params := pggen.InsertEmailMessageParams{
+		EmailMessageID: sql.String(msg.ID),
+		CreatedAt:      sql.Timestamptz(msg.CreatedAt),
+		Recipients:     msg.To,
+		Subject:        sql.String(msg.Subject),
+		Body:           sql.String(msg.Body),
+		DigestKey:      sql.NullString(),
+		Attempts:       sql.Int4(msg.Attempts),
+		NextAttemptAt:  sql.Timestamptz(msg.NextAttemptAt),
+	}
+	if msg.DigestKey != nil {
+		params.DigestKey = sql.String(*msg.DigestKey)
+	}
+	_, err := db.Conn(ctx).InsertEmailMessage(ctx, params)
+	return sql.Error(err)
+}
+
+// listDue lists messages due for delivery, oldest first.
+func (db *pgdb) listDue(ctx context.Context, now time.Time) ([]*Message, error) {
+	rows, err := db.Conn(ctx).FindDueEmailMessages(ctx, sql.Timestamptz(now))
+	if err != nil {
+		return nil, sql.Error(err)
+	}
+	messages := make([]*Message, len(rows))
+	for i, r := range rows {
+		messages[i] = &Message{
+			ID:            r.EmailMessageID.String,
+			CreatedAt:     r.CreatedAt.Time.UTC(),
+			To:            r.Recipients,
+			Subject:       r.Subject.String,
+			Body:          r.Body.String,
+			Attempts:      int(r.Attempts.Int),
+			NextAttemptAt: r.NextAttemptAt.Time.UTC(),
+		}
+		if r.DigestKey.Status == pgtype.Present {
+			messages[i].DigestKey = &r.DigestKey.String
+		}
+		if r.LastError.Status == pgtype.Present {
+			messages[i].LastError = &r.LastError.String
+		}
+	}
+	return messages, nil
+}
+
+func (db *pgdb) updateAttempt(ctx context.Context, msg *Message) error {
+	params := pggen.UpdateEmailMessageAttemptParams{
+		EmailMessageID: sql.String(msg.ID),
+		Attempts:       sql.Int4(msg.Attempts),
+		NextAttemptAt:  sql.Timestamptz(msg.NextAttemptAt),
+		LastError:      sql.NullString(),
+	}
+	if msg.LastError != nil {
+		params.LastError = sql.String(*msg.LastError)
+	}
+	_, err := db.Conn(ctx).UpdateEmailMessageAttempt(ctx, params)
+	return sql.Error(err)
+}
+
+func (db *pgdb) delete(ctx context.Context, id string) error {
+	_, err := db.Conn(ctx).DeleteEmailMessage(ctx, sql.String(id))
+	return sql.Error(err)
+}
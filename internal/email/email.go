@@ -0,0 +1,110 @@
+// Package email sends email via SMTP. Messages are queued in the database and
+// delivered by the mailer, which retries failed deliveries.
+package email
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	"io/fs"
+	"path"
+	"strings"
+	"text/template"
+	"time"
+
+	"log/slog"
+)
+
+const (
+	// DefaultPort is the default port of the SMTP server.
+	DefaultPort = 587
+	// DefaultDigestInterval is the default period over which notifications
+	// are collected into a single digest email.
+	DefaultDigestInterval = 5 * time.Minute
+)
+
+var (
+	//go:embed templates
+	content embed.FS
+
+	// templates keyed by name, each defining a subject and a body.
+	templates = make(map[string]*template.Template)
+)
+
+func init() {
+	paths, err := fs.Glob(content, "templates/*.tmpl")
+	if err != nil {
+		panic(err.Error())
+	}
+	for _, p := range paths {
+		name := strings.TrimSuffix(path.Base(p), ".tmpl")
+		templates[name] = template.Must(template.ParseFS(content, p))
+	}
+}
+
+type (
+	// Config configures the sending of email.
+	Config struct {
+		// Hostname of the SMTP server. If empty then no email is sent.
+		Host string
+		// Port of the SMTP server.
+		Port int
+		// Username and password for authenticating with the SMTP server. If
+		// the username is empty then no authentication is performed.
+		Username string
+		Password string
+		// From is the address from which email is sent.
+		From string
+		// DigestInterval is the period over which notifications are
+		// collected into a single digest email.
+		DigestInterval time.Duration
+	}
+
+	// Message is an email queued for delivery.
+	Message struct {
+		ID        string
+		CreatedAt time.Time
+		To        []string
+		Subject   string
+		Body      string
+		// DigestKey is non-nil if the message is to be delivered in a digest
+		// along with other messages with the same key.
+		DigestKey *string
+		// Number of failed attempts to deliver the message.
+		Attempts int
+		// Time after which the next attempt to deliver the message is made.
+		NextAttemptAt time.Time
+		// Error from the last failed attempt to deliver the message.
+		LastError *string
+	}
+)
+
+// Enabled determines whether sending email is enabled.
+func (c Config) Enabled() bool { return c.Host != "" }
+
+func (m *Message) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.String("id", m.ID),
+		slog.Any("to", m.To),
+		slog.String("subject", m.Subject),
+		slog.Int("attempts", m.Attempts),
+	)
+}
+
+// render renders the subject and body of the named template.
+func render(name string, data any) (subject, body string, err error) {
+	tmpl, ok := templates[name]
+	if !ok {
+		return "", "", fmt.Errorf("unknown email template: %s", name)
+	}
+	var buf bytes.Buffer
+	if err := tmpl.ExecuteTemplate(&buf, "subject", data); err != nil {
+		return "", "", err
+	}
+	subject = strings.TrimSpace(buf.String())
+	buf.Reset()
+	if err := tmpl.ExecuteTemplate(&buf, "body", data); err != nil {
+		return "", "", err
+	}
+	return subject, strings.TrimSpace(buf.String()), nil
+}
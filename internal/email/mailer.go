@@ -0,0 +1,152 @@
+package email
+
+import (
+	"context"
+	"time"
+
+	"github.com/leg100/otf/internal"
+	"github.com/leg100/otf/internal/logr"
+)
+
+const (
+	// LockID guarantees only one mailer on a cluster is running at any time.
+	LockID int64 = 5577006791947779416
+
+	// maxAttempts is the number of attempts made to deliver a message before
+	// it is discarded.
+	maxAttempts = 5
+)
+
+var (
+	defaultMailerInterval = 10 * time.Second
+	// delay before first retry, doubling with each subsequent retry.
+	defaultRetryDelay = time.Minute
+)
+
+type (
+	// Mailer delivers queued email, retrying failed deliveries.
+	//
+	// Only one mailer should be running on an OTF cluster at any one time.
+	Mailer struct {
+		logr.Logger
+
+		db     mailerDB
+		sender sender
+		// frequency with which the mailer checks for messages to deliver.
+		interval time.Duration
+		// delay before first retry
+		retryDelay time.Duration
+	}
+
+	mailerDB interface {
+		listDue(ctx context.Context, now time.Time) ([]*Message, error)
+		updateAttempt(ctx context.Context, msg *Message) error
+		delete(ctx context.Context, id string) error
+	}
+)
+
+// NewMailer constructs a mailer that delivers queued email via the configured
+// SMTP server.
+func (s *Service) NewMailer(logger logr.Logger) *Mailer {
+	return &Mailer{
+		Logger:     logger.WithValues("component", "mailer"),
+		db:         s.db,
+		sender:     newSMTPSender(s.config),
+		interval:   defaultMailerInterval,
+		retryDelay: defaultRetryDelay,
+	}
+}
+
+func (m *Mailer) String() string { return "mailer" }
+
+// Start the mailer. Every interval messages due for delivery are delivered.
+//
+// Should be invoked in a go routine.
+func (m *Mailer) Start(ctx context.Context) error {
+	ticker := time.NewTicker(m.interval)
+	defer ticker.Stop()
+	for {
+		if err := m.deliver(ctx); err != nil {
+			return err
+		}
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
+
+// deliver delivers messages due for delivery. Messages sharing a digest key
+// are delivered together in a single email.
+func (m *Mailer) deliver(ctx context.Context) error {
+	due, err := m.db.listDue(ctx, internal.CurrentTimestamp(nil))
+	if err != nil {
+		return err
+	}
+	var (
+		batches [][]*Message
+		digests = make(map[string]int) // digest key -> index of batch
+	)
+	for _, msg := range due {
+		if msg.DigestKey == nil {
+			batches = append(batches, []*Message{msg})
+			continue
+		}
+		if i, ok := digests[*msg.DigestKey]; ok {
+			batches[i] = append(batches[i], msg)
+			continue
+		}
+		digests[*msg.DigestKey] = len(batches)
+		batches = append(batches, []*Message{msg})
+	}
+	for _, batch := range batches {
+		if err := m.deliverBatch(ctx, batch); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// deliverBatch delivers a batch of messages in a single email. A batch of
+// more than one message is delivered as a digest. The messages are deleted
+// upon successful delivery, otherwise they are scheduled for another attempt,
+// or discarded if they have exhausted their attempts.
+func (m *Mailer) deliverBatch(ctx context.Context, batch []*Message) error {
+	// the latest message's recipients take precedence
+	to := batch[len(batch)-1].To
+	subject, body := batch[0].Subject, batch[0].Body
+	if len(batch) > 1 {
+		var err error
+		subject, body, err = render("digest", batch)
+		if err != nil {
+			return err
+		}
+	}
+	sendErr := m.sender.send(to, subject, body)
+	for _, msg := range batch {
+		if sendErr == nil {
+			if err := m.db.delete(ctx, msg.ID); err != nil {
+				return err
+			}
+			m.V(1).Info("delivered email", "message", msg)
+			continue
+		}
+		msg.Attempts++
+		if msg.Attempts >= maxAttempts {
+			if err := m.db.delete(ctx, msg.ID); err != nil {
+				return err
+			}
+			m.Error(sendErr, "discarding undeliverable email", "message", msg)
+			continue
+		}
+		lastError := sendErr.Error()
+		msg.LastError = &lastError
+		msg.NextAttemptAt = internal.CurrentTimestamp(nil).Add(m.retryDelay << (msg.Attempts - 1))
+		if err := m.db.updateAttempt(ctx, msg); err != nil {
+			return err
+		}
+		m.Error(sendErr, "delivering email; will retry", "message", msg, "next_attempt", msg.NextAttemptAt)
+	}
+	return nil
+}
@@ -0,0 +1,130 @@
+package email
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/leg100/otf/internal/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMailer(t *testing.T) {
+	ctx := context.Background()
+	key := "nc-123"
+
+	t.Run("deliver digest", func(t *testing.T) {
+		db := &fakeMailerDB{due: []*Message{
+			{ID: "email-1", To: []string{"bob@example.com"}, Subject: "invited", Body: "welcome"},
+			{ID: "email-2", To: []string{"alice@example.com"}, Subject: "run applied", Body: "body-2", DigestKey: &key},
+			{ID: "email-3", To: []string{"alice@example.com"}, Subject: "run errored", Body: "body-3", DigestKey: &key},
+		}}
+		sender := &fakeSender{}
+		m := &Mailer{Logger: logr.Discard(), db: db, sender: sender}
+
+		require.NoError(t, m.deliver(ctx))
+
+		require.Equal(t, 2, len(sender.sent))
+		assert.Equal(t, "invited", sender.sent[0].subject)
+		assert.Equal(t, "2 notifications from OTF", sender.sent[1].subject)
+		assert.Contains(t, sender.sent[1].body, "run applied")
+		assert.Contains(t, sender.sent[1].body, "run errored")
+		assert.Equal(t, []string{"email-1", "email-2", "email-3"}, db.deleted)
+	})
+
+	t.Run("retry failed delivery", func(t *testing.T) {
+		db := &fakeMailerDB{due: []*Message{
+			{ID: "email-1", To: []string{"bob@example.com"}, Subject: "invited", Attempts: 1},
+		}}
+		m := &Mailer{
+			Logger:     logr.Discard(),
+			db:         db,
+			sender:     &fakeSender{err: errors.New("connection refused")},
+			retryDelay: time.Minute,
+		}
+
+		require.NoError(t, m.deliver(ctx))
+
+		require.Equal(t, 1, len(db.updated))
+		assert.Equal(t, 2, db.updated[0].Attempts)
+		assert.Equal(t, "connection refused", *db.updated[0].LastError)
+		// second retry is delayed by twice the retry delay
+		assert.WithinDuration(t, time.Now().Add(2*time.Minute), db.updated[0].NextAttemptAt, time.Second)
+		assert.Empty(t, db.deleted)
+	})
+
+	t.Run("discard undeliverable message", func(t *testing.T) {
+		db := &fakeMailerDB{due: []*Message{
+			{ID: "email-1", To: []string{"bob@example.com"}, Subject: "invited", Attempts: maxAttempts - 1},
+		}}
+		m := &Mailer{
+			Logger: logr.Discard(),
+			db:     db,
+			sender: &fakeSender{err: errors.New("connection refused")},
+		}
+
+		require.NoError(t, m.deliver(ctx))
+
+		assert.Empty(t, db.updated)
+		assert.Equal(t, []string{"email-1"}, db.deleted)
+	})
+}
+
+func TestRender(t *testing.T) {
+	subject, body, err := render("invitation", struct {
+		Organization string
+		InvitedBy    string
+		URL          string
+	}{
+		Organization: "acme",
+		InvitedBy:    "bob",
+		URL:          "https://otf.example.com/app/organizations/acme",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "You have been invited to join acme on OTF", subject)
+	assert.Contains(t, body, "bob has added you to the organization acme")
+	assert.Contains(t, body, "https://otf.example.com/app/organizations/acme")
+
+	_, _, err = render("does-not-exist", nil)
+	assert.Error(t, err)
+}
+
+type (
+	fakeMailerDB struct {
+		due     []*Message
+		updated []*Message
+		deleted []string
+	}
+	fakeSender struct {
+		sent []fakeSentEmail
+		err  error
+	}
+	fakeSentEmail struct {
+		to            []string
+		subject, body string
+	}
+)
+
+func (f *fakeMailerDB) listDue(context.Context, time.Time) ([]*Message, error) {
+	return f.due, nil
+}
+
+func (f *fakeMailerDB) updateAttempt(_ context.Context, msg *Message) error {
+	f.updated = append(f.updated, msg)
+	return nil
+}
+
+func (f *fakeMailerDB) delete(_ context.Context, id string) error {
+	f.deleted = append(f.deleted, id)
+	return nil
+}
+
+func (f *fakeSender) send(to []string, subject, body string) error {
+	if f.err != nil {
+		return f.err
+	}
+	f.sent = append(f.sent, fakeSentEmail{to: to, subject: subject, body: body})
+	return nil
+}
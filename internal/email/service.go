@@ -0,0 +1,89 @@
+package email
+
+import (
+	"context"
+
+	"github.com/leg100/otf/internal"
+	"github.com/leg100/otf/internal/logr"
+	"github.com/leg100/otf/internal/resource"
+	"github.com/leg100/otf/internal/sql"
+)
+
+type (
+	// Service queues email for delivery.
+	Service struct {
+		logr.Logger
+
+		config Config
+		db     *pgdb
+	}
+
+	Options struct {
+		Config
+
+		*sql.DB
+		logr.Logger
+	}
+)
+
+func NewService(opts Options) *Service {
+	if opts.Port == 0 {
+		opts.Port = DefaultPort
+	}
+	if opts.DigestInterval == 0 {
+		opts.DigestInterval = DefaultDigestInterval
+	}
+	return &Service{
+		Logger: opts.Logger,
+		config: opts.Config,
+		db:     &pgdb{opts.DB},
+	}
+}
+
+// Enabled determines whether sending email is enabled.
+func (s *Service) Enabled() bool { return s.config.Enabled() }
+
+// Send renders the named template and queues the resulting message for
+// delivery to the recipients. If email is disabled then the message is
+// discarded.
+func (s *Service) Send(ctx context.Context, to []string, tmpl string, data any) error {
+	return s.send(ctx, nil, to, tmpl, data)
+}
+
+// SendDigest is like Send but delays delivery of the message until the digest
+// interval has elapsed, whereupon it is delivered in a single email along with
+// any other messages queued with the same digest key.
+func (s *Service) SendDigest(ctx context.Context, key string, to []string, tmpl string, data any) error {
+	return s.send(ctx, &key, to, tmpl, data)
+}
+
+func (s *Service) send(ctx context.Context, digestKey *string, to []string, tmpl string, data any) error {
+	if !s.Enabled() {
+		s.V(9).Info("email disabled; discarding message", "to", to, "template", tmpl)
+		return nil
+	}
+	subject, body, err := render(tmpl, data)
+	if err != nil {
+		s.Error(err, "rendering email", "template", tmpl)
+		return err
+	}
+	now := internal.CurrentTimestamp(nil)
+	msg := &Message{
+		ID:            resource.NewID(resource.EmailMessageKind),
+		CreatedAt:     now,
+		To:            to,
+		Subject:       subject,
+		Body:          body,
+		DigestKey:     digestKey,
+		NextAttemptAt: now,
+	}
+	if digestKey != nil {
+		msg.NextAttemptAt = now.Add(s.config.DigestInterval)
+	}
+	if err := s.db.insert(ctx, msg); err != nil {
+		s.Error(err, "queuing email", "message", msg)
+		return err
+	}
+	s.V(1).Info("queued email", "message", msg)
+	return nil
+}
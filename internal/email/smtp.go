@@ -0,0 +1,55 @@
+package email
+
+import (
+	"bytes"
+	"fmt"
+	"net"
+	"net/smtp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type (
+	// sender sends an email.
+	sender interface {
+		send(to []string, subject, body string) error
+	}
+
+	// smtpSender sends email via an SMTP server.
+	smtpSender struct {
+		addr string
+		from string
+		auth smtp.Auth
+	}
+)
+
+func newSMTPSender(cfg Config) *smtpSender {
+	s := &smtpSender{
+		addr: net.JoinHostPort(cfg.Host, strconv.Itoa(cfg.Port)),
+		from: cfg.From,
+	}
+	if cfg.Username != "" {
+		s.auth = smtp.PlainAuth("", cfg.Username, cfg.Password, cfg.Host)
+	}
+	return s
+}
+
+func (s *smtpSender) send(to []string, subject, body string) error {
+	return smtp.SendMail(s.addr, s.auth, s.from, to, formatMessage(s.from, to, subject, body))
+}
+
+// formatMessage formats a plain text message in accordance with RFC 5322.
+func formatMessage(from string, to []string, subject, body string) []byte {
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "From: %s\r\n", from)
+	fmt.Fprintf(&buf, "To: %s\r\n", strings.Join(to, ", "))
+	fmt.Fprintf(&buf, "Subject: %s\r\n", subject)
+	fmt.Fprintf(&buf, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	buf.WriteString("MIME-Version: 1.0\r\n")
+	buf.WriteString("Content-Type: text/plain; charset=UTF-8\r\n")
+	buf.WriteString("\r\n")
+	buf.WriteString(strings.ReplaceAll(body, "\n", "\r\n"))
+	buf.WriteString("\r\n")
+	return buf.Bytes()
+}
@@ -71,11 +71,20 @@ type (
 		*pgconn.PgError
 	}
 
-	InvalidParameterError string
+	// InvalidParameterError occurs when the caller has provided a parameter
+	// with an invalid value
+	InvalidParameterError struct {
+		Parameter string
+		Err       error
+	}
 )
 
-func (e InvalidParameterError) Error() string {
-	return string(e)
+func (e *InvalidParameterError) Error() string {
+	return fmt.Sprintf("invalid value for parameter %s: %s", e.Parameter, e.Err)
+}
+
+func (e *InvalidParameterError) Unwrap() error {
+	return e.Err
 }
 
 func (e *HTTPError) Error() string {
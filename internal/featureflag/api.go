@@ -0,0 +1,85 @@
+package featureflag
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/leg100/otf/internal"
+	otfapi "github.com/leg100/otf/internal/api"
+	"github.com/leg100/otf/internal/http/decode"
+	"github.com/leg100/otf/internal/tfeapi"
+)
+
+type api struct {
+	*Service
+}
+
+func (a *api) addHandlers(r *mux.Router) {
+	r = r.PathPrefix(otfapi.DefaultBasePath).Subrouter()
+
+	r.HandleFunc("/admin/feature-flags", a.list).Methods("GET")
+	r.HandleFunc("/admin/feature-flags/{name}", a.set).Methods("PUT")
+	r.HandleFunc("/admin/feature-flags/{name}", a.unset).Methods("DELETE")
+}
+
+func (a *api) list(w http.ResponseWriter, r *http.Request) {
+	var params struct {
+		Organization string `schema:"organization"`
+	}
+	if err := decode.Query(&params, r.URL.Query()); err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	states, err := a.List(r.Context(), params.Organization)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	a.respond(w, states, http.StatusOK)
+}
+
+func (a *api) set(w http.ResponseWriter, r *http.Request) {
+	name, err := decode.Param("name", r)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	var opts SetOptions
+	if err := json.NewDecoder(r.Body).Decode(&opts); err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	setting, err := a.Set(r.Context(), Flag(name), opts)
+	if err != nil {
+		if errors.Is(err, ErrUnknownFlag) {
+			err = &internal.HTTPError{Code: http.StatusNotFound, Message: err.Error()}
+		}
+		tfeapi.Error(w, err)
+		return
+	}
+	a.respond(w, setting, http.StatusOK)
+}
+
+func (a *api) unset(w http.ResponseWriter, r *http.Request) {
+	var params struct {
+		Name         string  `schema:"name,required"`
+		Organization *string `schema:"organization"`
+	}
+	if err := decode.All(&params, r); err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	if err := a.Unset(r.Context(), Flag(params.Name), params.Organization); err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (a *api) respond(w http.ResponseWriter, v any, code int) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	json.NewEncoder(w).Encode(v)
+}
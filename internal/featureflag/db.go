@@ -0,0 +1,49 @@
+package featureflag
+
+import (
+	"context"
+
+	"github.com/jackc/pgtype"
+	"github.com/leg100/otf/internal"
+	"github.com/leg100/otf/internal/sql"
+	"github.com/leg100/otf/internal/sql/pggen"
+)
+
+// pgdb is a database of feature flag settings on postgres
+type pgdb struct {
+	*sql.DB // provides access to generated SQL queries
+}
+
+func (db *pgdb) set(ctx context.Context, setting Setting) error {
+	_, err := db.Conn(ctx).UpsertFeatureFlag(ctx, pggen.UpsertFeatureFlagParams{
+		Name:             sql.String(string(setting.Flag)),
+		OrganizationName: sql.StringPtr(setting.Organization),
+		Enabled:          sql.Bool(setting.Enabled),
+		UpdatedAt:        sql.Timestamptz(setting.UpdatedAt),
+	})
+	return sql.Error(err)
+}
+
+func (db *pgdb) list(ctx context.Context) ([]Setting, error) {
+	rows, err := db.Conn(ctx).FindFeatureFlags(ctx)
+	if err != nil {
+		return nil, sql.Error(err)
+	}
+	settings := make([]Setting, len(rows))
+	for i, r := range rows {
+		settings[i] = Setting{
+			Flag:      Flag(r.Name.String),
+			Enabled:   r.Enabled.Bool,
+			UpdatedAt: r.UpdatedAt.Time.UTC(),
+		}
+		if r.OrganizationName.Status == pgtype.Present {
+			settings[i].Organization = internal.String(r.OrganizationName.String)
+		}
+	}
+	return settings, nil
+}
+
+func (db *pgdb) delete(ctx context.Context, flag Flag, organization *string) error {
+	_, err := db.Conn(ctx).DeleteFeatureFlag(ctx, sql.String(string(flag)), sql.StringPtr(organization))
+	return sql.Error(err)
+}
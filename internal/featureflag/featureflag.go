@@ -0,0 +1,156 @@
+// Package featureflag provides flags that enable or disable experimental
+// features, either for the whole installation or for individual
+// organizations.
+package featureflag
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/leg100/otf/internal"
+)
+
+const (
+	// DriftDetection enables health assessments, which detect drift between
+	// a workspace's state and its real infrastructure.
+	DriftDetection Flag = "drift-detection"
+)
+
+const (
+	SourceDefault      Source = "default"
+	SourceInstallation Source = "installation"
+	SourceOrganization Source = "organization"
+	SourceOverride     Source = "override"
+)
+
+var (
+	ErrUnknownFlag = errors.New("unknown feature flag")
+	// ErrDisabled is returned when a feature is used that is disabled. It
+	// also wraps internal.ErrResourceNotFound, so that the endpoints of a
+	// disabled feature respond as if they do not exist.
+	ErrDisabled = fmt.Errorf("feature is disabled: %w", internal.ErrResourceNotFound)
+
+	// definitions of all known flags
+	definitions = []Definition{
+		{
+			Name:        DriftDetection,
+			Description: "Health assessments detecting drift of workspace resources.",
+			Default:     true,
+		},
+	}
+)
+
+type (
+	// Flag is the name of a feature flag.
+	Flag string
+
+	// Definition defines a feature flag.
+	Definition struct {
+		Name        Flag   `json:"name"`
+		Description string `json:"description"`
+		// Default determines whether the feature is enabled when the flag is
+		// neither set nor overridden.
+		Default bool `json:"default"`
+	}
+
+	// Setting enables or disables a feature, for the whole installation or
+	// for an organization.
+	Setting struct {
+		Flag Flag `json:"flag"`
+		// Organization is the organization to which the setting applies. If
+		// nil then it applies to the whole installation.
+		Organization *string   `json:"organization,omitempty"`
+		Enabled      bool      `json:"enabled"`
+		UpdatedAt    time.Time `json:"updated_at"`
+	}
+
+	// SetOptions are options for setting a flag.
+	SetOptions struct {
+		Enabled bool `json:"enabled"`
+		// Organization restricts the setting to an organization. If nil then
+		// the setting applies to the whole installation.
+		Organization *string `json:"organization"`
+	}
+
+	// State is the evaluated state of a flag.
+	State struct {
+		Definition
+		Enabled bool `json:"enabled"`
+		// Source is whatever determined whether the flag is enabled.
+		Source Source `json:"source"`
+	}
+
+	// Source is the origin of the state of a flag.
+	Source string
+
+	// Config configures feature flags.
+	Config struct {
+		// Overrides enable or disable flags for the whole installation,
+		// taking precedence over flags set via the API. Each key is the name
+		// of a flag and each value is either true or false.
+		Overrides map[string]string
+	}
+)
+
+// Valid validates the config.
+func (cfg Config) Valid() error {
+	_, err := cfg.overrides()
+	return err
+}
+
+func (cfg Config) overrides() (map[Flag]bool, error) {
+	overrides := make(map[Flag]bool, len(cfg.Overrides))
+	for name, value := range cfg.Overrides {
+		if _, err := lookup(Flag(name)); err != nil {
+			return nil, err
+		}
+		enabled, err := strconv.ParseBool(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid value for feature flag %s: %s", name, value)
+		}
+		overrides[Flag(name)] = enabled
+	}
+	return overrides, nil
+}
+
+// Definitions returns the definitions of all known flags.
+func Definitions() []Definition {
+	return definitions
+}
+
+func lookup(name Flag) (Definition, error) {
+	for _, def := range definitions {
+		if def.Name == name {
+			return def, nil
+		}
+	}
+	return Definition{}, fmt.Errorf("%w: %s", ErrUnknownFlag, name)
+}
+
+// evaluate determines the state of a flag for an organization, or for the
+// installation if organization is empty. An override takes precedence,
+// followed by a setting for the organization, a setting for the
+// installation, and finally the flag's default.
+func evaluate(def Definition, settings []Setting, overrides map[Flag]bool, organization string) State {
+	state := State{Definition: def, Enabled: def.Default, Source: SourceDefault}
+	if enabled, ok := overrides[def.Name]; ok {
+		state.Enabled, state.Source = enabled, SourceOverride
+		return state
+	}
+	for _, setting := range settings {
+		if setting.Flag != def.Name {
+			continue
+		}
+		switch {
+		case setting.Organization == nil:
+			if state.Source == SourceDefault {
+				state.Enabled, state.Source = setting.Enabled, SourceInstallation
+			}
+		case organization != "" && *setting.Organization == organization:
+			state.Enabled, state.Source = setting.Enabled, SourceOrganization
+		}
+	}
+	return state
+}
@@ -0,0 +1,110 @@
+package featureflag
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/leg100/otf/internal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeStore struct {
+	settings []Setting
+	store
+}
+
+func (f *fakeStore) list(context.Context) ([]Setting, error) {
+	return f.settings, nil
+}
+
+func TestEvaluate(t *testing.T) {
+	def := Definition{Name: "experiment", Default: false}
+
+	tests := []struct {
+		name         string
+		settings     []Setting
+		overrides    map[Flag]bool
+		organization string
+		want         State
+	}{
+		{
+			name: "default",
+			want: State{Definition: def, Enabled: false, Source: SourceDefault},
+		},
+		{
+			name:     "installation setting",
+			settings: []Setting{{Flag: "experiment", Enabled: true}},
+			want:     State{Definition: def, Enabled: true, Source: SourceInstallation},
+		},
+		{
+			name: "organization setting takes precedence over installation setting",
+			settings: []Setting{
+				{Flag: "experiment", Enabled: true},
+				{Flag: "experiment", Organization: internal.String("acme"), Enabled: false},
+			},
+			organization: "acme",
+			want:         State{Definition: def, Enabled: false, Source: SourceOrganization},
+		},
+		{
+			name: "setting for another organization",
+			settings: []Setting{
+				{Flag: "experiment", Organization: internal.String("initech"), Enabled: true},
+			},
+			organization: "acme",
+			want:         State{Definition: def, Enabled: false, Source: SourceDefault},
+		},
+		{
+			name: "organization setting ignored for installation",
+			settings: []Setting{
+				{Flag: "experiment", Organization: internal.String("acme"), Enabled: true},
+			},
+			want: State{Definition: def, Enabled: false, Source: SourceDefault},
+		},
+		{
+			name: "override takes precedence",
+			settings: []Setting{
+				{Flag: "experiment", Organization: internal.String("acme"), Enabled: true},
+			},
+			overrides:    map[Flag]bool{"experiment": false},
+			organization: "acme",
+			want:         State{Definition: def, Enabled: false, Source: SourceOverride},
+		},
+		{
+			name:     "setting for another flag",
+			settings: []Setting{{Flag: "another", Enabled: true}},
+			want:     State{Definition: def, Enabled: false, Source: SourceDefault},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := evaluate(def, tt.settings, tt.overrides, tt.organization)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestConfig_Valid(t *testing.T) {
+	assert.NoError(t, Config{Overrides: map[string]string{"drift-detection": "false"}}.Valid())
+	assert.ErrorIs(t, Config{Overrides: map[string]string{"stacks": "true"}}.Valid(), ErrUnknownFlag)
+	assert.Error(t, Config{Overrides: map[string]string{"drift-detection": "maybe"}}.Valid())
+}
+
+func TestService_Gate(t *testing.T) {
+	svc := &Service{
+		Logger: logr.Discard(),
+		db: &fakeStore{settings: []Setting{
+			{Flag: DriftDetection, Organization: internal.String("acme"), Enabled: false},
+		}},
+	}
+
+	err := svc.Gate(context.Background(), DriftDetection, "acme")
+	assert.ErrorIs(t, err, ErrDisabled)
+	assert.ErrorIs(t, err, internal.ErrResourceNotFound)
+
+	require.NoError(t, svc.Gate(context.Background(), DriftDetection, "initech"))
+
+	_, err = svc.Enabled(context.Background(), "stacks", "acme")
+	assert.ErrorIs(t, err, ErrUnknownFlag)
+}
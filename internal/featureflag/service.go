@@ -0,0 +1,144 @@
+package featureflag
+
+import (
+	"context"
+
+	"github.com/gorilla/mux"
+	"github.com/leg100/otf/internal"
+	"github.com/leg100/otf/internal/logr"
+	"github.com/leg100/otf/internal/rbac"
+	"github.com/leg100/otf/internal/sql"
+)
+
+type (
+	Service struct {
+		logr.Logger
+
+		site internal.Authorizer
+
+		db        store
+		overrides map[Flag]bool
+		api       *api
+	}
+
+	Options struct {
+		Config
+		logr.Logger
+		*sql.DB
+	}
+
+	store interface {
+		set(ctx context.Context, setting Setting) error
+		list(ctx context.Context) ([]Setting, error)
+		delete(ctx context.Context, flag Flag, organization *string) error
+	}
+)
+
+// NewService constructs the feature flag service. The config is expected to
+// have been validated.
+func NewService(opts Options) *Service {
+	overrides, _ := opts.Config.overrides()
+	svc := Service{
+		Logger:    opts.Logger,
+		site:      &internal.SiteAuthorizer{Logger: opts.Logger},
+		db:        &pgdb{opts.DB},
+		overrides: overrides,
+	}
+	svc.api = &api{Service: &svc}
+	return &svc
+}
+
+func (s *Service) AddHandlers(r *mux.Router) {
+	s.api.addHandlers(r)
+}
+
+// List lists the state of every flag for an organization, or for the whole
+// installation if organization is empty. Only a site admin can list flags.
+func (s *Service) List(ctx context.Context, organization string) ([]State, error) {
+	subject, err := s.site.CanAccess(ctx, rbac.ListFeatureFlagsAction, "")
+	if err != nil {
+		return nil, err
+	}
+	settings, err := s.db.list(ctx)
+	if err != nil {
+		s.Error(err, "listing feature flags", "subject", subject)
+		return nil, err
+	}
+	states := make([]State, len(definitions))
+	for i, def := range definitions {
+		states[i] = evaluate(def, settings, s.overrides, organization)
+	}
+	s.V(9).Info("listed feature flags", "organization", organization, "subject", subject)
+	return states, nil
+}
+
+// Set enables or disables a flag, for an organization or for the whole
+// installation. Only a site admin can set a flag.
+func (s *Service) Set(ctx context.Context, flag Flag, opts SetOptions) (*Setting, error) {
+	subject, err := s.site.CanAccess(ctx, rbac.UpdateFeatureFlagAction, "")
+	if err != nil {
+		return nil, err
+	}
+	if _, err := lookup(flag); err != nil {
+		return nil, err
+	}
+	setting := Setting{
+		Flag:         flag,
+		Organization: opts.Organization,
+		Enabled:      opts.Enabled,
+		UpdatedAt:    internal.CurrentTimestamp(nil),
+	}
+	if err := s.db.set(ctx, setting); err != nil {
+		s.Error(err, "setting feature flag", "flag", flag, "organization", opts.Organization, "subject", subject)
+		return nil, err
+	}
+	s.V(0).Info("set feature flag", "flag", flag, "enabled", opts.Enabled, "organization", opts.Organization, "subject", subject)
+	return &setting, nil
+}
+
+// Unset removes the setting of a flag for an organization or for the whole
+// installation, reverting it to its inherited state. Only a site admin can
+// unset a flag.
+func (s *Service) Unset(ctx context.Context, flag Flag, organization *string) error {
+	subject, err := s.site.CanAccess(ctx, rbac.UpdateFeatureFlagAction, "")
+	if err != nil {
+		return err
+	}
+	if err := s.db.delete(ctx, flag, organization); err != nil {
+		s.Error(err, "unsetting feature flag", "flag", flag, "organization", organization, "subject", subject)
+		return err
+	}
+	s.V(0).Info("unset feature flag", "flag", flag, "organization", organization, "subject", subject)
+	return nil
+}
+
+// Enabled determines whether a feature is enabled for an organization, or
+// for the whole installation if organization is empty.
+func (s *Service) Enabled(ctx context.Context, flag Flag, organization string) (bool, error) {
+	def, err := lookup(flag)
+	if err != nil {
+		return false, err
+	}
+	if enabled, ok := s.overrides[flag]; ok {
+		return enabled, nil
+	}
+	settings, err := s.db.list(ctx)
+	if err != nil {
+		s.Error(err, "listing feature flags")
+		return false, err
+	}
+	return evaluate(def, settings, s.overrides, organization).Enabled, nil
+}
+
+// Gate returns ErrDisabled if a feature is disabled for an organization, or
+// for the whole installation if organization is empty.
+func (s *Service) Gate(ctx context.Context, flag Flag, organization string) error {
+	enabled, err := s.Enabled(ctx, flag, organization)
+	if err != nil {
+		return err
+	}
+	if !enabled {
+		return ErrDisabled
+	}
+	return nil
+}
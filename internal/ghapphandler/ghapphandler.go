@@ -4,6 +4,7 @@ package ghapphandler
 import (
 	"errors"
 	"net/http"
+	"time"
 
 	"github.com/gorilla/mux"
 	"github.com/leg100/otf/internal"
@@ -19,6 +20,11 @@ type Handler struct {
 
 	VCSProviders *vcsprovider.Service
 	GithubApps   *github.Service
+	// ClockSkew is the maximum permitted difference between the time an
+	// event is sent and received.
+	ClockSkew time.Duration
+	// RequireDate rejects events without a Date header.
+	RequireDate bool
 }
 
 func (h *Handler) AddHandlers(r *mux.Router) {
@@ -37,6 +43,12 @@ func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	}
 	h.V(2).Info("received vcs event", "github_app", app)
 
+	if err := vcs.ValidateDeliveryTime(r, h.ClockSkew, time.Now(), h.RequireDate); err != nil {
+		h.Error(err, "handling vcs event", "github_app", app)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
 	// use github-specific handler to unmarshal event
 	payload, err := github.HandleEvent(r, app.WebhookSecret)
 	// either ignore the event, return an error, or publish the event onwards
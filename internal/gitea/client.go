@@ -0,0 +1,484 @@
+package gitea
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/leg100/otf/internal"
+	otfhttp "github.com/leg100/otf/internal/http"
+	"github.com/leg100/otf/internal/vcs"
+)
+
+const (
+	// apiPath is the path of the REST API.
+	apiPath = "api/v1"
+	// maxPageSize is the maximum number of items requested in a page; gitea
+	// caps page sizes at 50 by default.
+	maxPageSize = 50
+)
+
+type (
+	// Client is a client for the gitea REST API, which is also provided by
+	// forgejo:
+	//
+	// https://docs.gitea.com/api/1.20/
+	Client struct {
+		client  *http.Client
+		baseURL *url.URL
+		token   string
+	}
+
+	ClientOptions struct {
+		Hostname            string
+		SkipTLSVerification bool
+
+		// Token is a personal access token.
+		Token string
+	}
+
+	repository struct {
+		FullName      string `json:"full_name"`
+		DefaultBranch string `json:"default_branch"`
+		Permissions   struct {
+			Admin bool `json:"admin"`
+		} `json:"permissions"`
+	}
+
+	tag struct {
+		Name string `json:"name"`
+	}
+
+	commit struct {
+		SHA     string `json:"sha"`
+		HTMLURL string `json:"html_url"`
+		// Author is nil if the commit's author email is not associated with
+		// a gitea user.
+		Author *user `json:"author"`
+	}
+
+	user struct {
+		Login     string `json:"login"`
+		AvatarURL string `json:"avatar_url"`
+		HTMLURL   string `json:"html_url"`
+	}
+
+	webhook struct {
+		ID     int64             `json:"id,omitempty"`
+		Type   string            `json:"type,omitempty"`
+		Config map[string]string `json:"config"`
+		Events []string          `json:"events"`
+		Active bool              `json:"active"`
+	}
+
+	commitStatus struct {
+		State       string `json:"state"`
+		TargetURL   string `json:"target_url"`
+		Description string `json:"description"`
+		Context     string `json:"context"`
+	}
+
+	changedFile struct {
+		Filename         string `json:"filename"`
+		PreviousFilename string `json:"previous_filename"` // only set if the file was renamed
+	}
+
+	// apiError is an error returned by the gitea API.
+	apiError struct {
+		Message string `json:"message"`
+	}
+)
+
+func NewClient(cfg ClientOptions) (*Client, error) {
+	if cfg.Hostname == "" {
+		return nil, ErrHostnameRequired
+	}
+	tripper := http.DefaultTransport
+	if cfg.SkipTLSVerification {
+		tripper = otfhttp.InsecureTransport
+	}
+	return &Client{
+		// retry transient errors and honor rate limits, sharing the limit with
+		// other clients using the same credentials
+		client:  &http.Client{Transport: vcs.NewTransport(tripper, cfg.Hostname, cfg.Token)},
+		baseURL: &url.URL{Scheme: "https", Host: cfg.Hostname, Path: "/"},
+		token:   cfg.Token,
+	}, nil
+}
+
+func NewTokenClient(opts vcs.NewTokenClientOptions) (vcs.Client, error) {
+	return NewClient(ClientOptions{
+		Hostname:            opts.Hostname,
+		Token:               opts.Token,
+		SkipTLSVerification: opts.SkipTLSVerification,
+	})
+}
+
+func (c *Client) GetRepository(ctx context.Context, identifier string) (vcs.Repository, error) {
+	p, err := repoPath(identifier)
+	if err != nil {
+		return vcs.Repository{}, err
+	}
+	var repo repository
+	if err := c.get(ctx, p, nil, &repo); err != nil {
+		return vcs.Repository{}, err
+	}
+	return vcs.Repository{
+		Path:          repo.FullName,
+		DefaultBranch: repo.DefaultBranch,
+	}, nil
+}
+
+// ListRepositories lists the first page of repositories that the user
+// administers. Administering a repository is necessary to create the webhooks
+// with which OTF receives events.
+func (c *Client) ListRepositories(ctx context.Context, opts vcs.ListRepositoriesOptions) ([]string, error) {
+	q := url.Values{}
+	if opts.PageSize > 0 {
+		q.Set("limit", strconv.Itoa(min(opts.PageSize, maxPageSize)))
+	}
+	var result []repository
+	if err := c.get(ctx, path.Join(apiPath, "user/repos"), q, &result); err != nil {
+		return nil, err
+	}
+	var repos []string
+	for _, repo := range result {
+		if repo.Permissions.Admin {
+			repos = append(repos, repo.FullName)
+		}
+	}
+	return repos, nil
+}
+
+func (c *Client) ListTags(ctx context.Context, opts vcs.ListTagsOptions) ([]string, error) {
+	p, err := repoPath(opts.Repo)
+	if err != nil {
+		return nil, err
+	}
+	results, err := listAll[tag](ctx, c, path.Join(p, "tags"))
+	if err != nil {
+		return nil, err
+	}
+	var tags []string
+	for _, tag := range results {
+		if strings.HasPrefix(tag.Name, opts.Prefix) {
+			tags = append(tags, fmt.Sprintf("tags/%s", tag.Name))
+		}
+	}
+	return tags, nil
+}
+
+func (c *Client) GetRepoTarball(ctx context.Context, opts vcs.GetRepoTarballOptions) ([]byte, string, error) {
+	p, err := repoPath(opts.Repo)
+	if err != nil {
+		return nil, "", err
+	}
+	var ref string
+	if opts.Ref != nil {
+		ref = *opts.Ref
+	} else {
+		repo, err := c.GetRepository(ctx, opts.Repo)
+		if err != nil {
+			return nil, "", err
+		}
+		ref = repo.DefaultBranch
+	}
+	// resolve ref to a commit SHA, ensuring the tarball and the SHA
+	// correspond to one another
+	commit, err := c.GetCommit(ctx, opts.Repo, ref)
+	if err != nil {
+		return nil, "", err
+	}
+	var buf bytes.Buffer
+	if err := c.get(ctx, path.Join(p, "archive", commit.SHA+".tar.gz"), nil, &buf); err != nil {
+		return nil, "", err
+	}
+
+	// Gitea tarball contents are contained within a top-level directory
+	// named after the repo. We want the tarball without this directory, so we
+	// re-tar the contents without the top-level directory.
+	owner, name, _ := strings.Cut(opts.Repo, "/")
+	untarpath, err := os.MkdirTemp("", fmt.Sprintf("gitea-%s-%s-*", owner, name))
+	if err != nil {
+		return nil, "", err
+	}
+	defer os.RemoveAll(untarpath)
+
+	if err := internal.Unpack(&buf, untarpath); err != nil {
+		return nil, "", err
+	}
+	contents, err := os.ReadDir(untarpath)
+	if err != nil {
+		return nil, "", err
+	}
+	if len(contents) != 1 {
+		return nil, "", fmt.Errorf("expected only one top-level directory; instead got %s", contents)
+	}
+	tarball, err := internal.Pack(path.Join(untarpath, contents[0].Name()))
+	if err != nil {
+		return nil, "", err
+	}
+	return tarball, commit.SHA, nil
+}
+
+func (c *Client) CreateWebhook(ctx context.Context, opts vcs.CreateWebhookOptions) (string, error) {
+	p, err := repoPath(opts.Repo)
+	if err != nil {
+		return "", err
+	}
+	hook := newWebhook(opts)
+	hook.Type = "gitea"
+	var created webhook
+	if err := c.send(ctx, "POST", path.Join(p, "hooks"), hook, &created); err != nil {
+		return "", err
+	}
+	return strconv.FormatInt(created.ID, 10), nil
+}
+
+func (c *Client) UpdateWebhook(ctx context.Context, id string, opts vcs.UpdateWebhookOptions) error {
+	p, err := repoPath(opts.Repo)
+	if err != nil {
+		return err
+	}
+	return c.send(ctx, "PATCH", path.Join(p, "hooks", url.PathEscape(id)), newWebhook(vcs.CreateWebhookOptions(opts)), nil)
+}
+
+func (c *Client) GetWebhook(ctx context.Context, opts vcs.GetWebhookOptions) (vcs.Webhook, error) {
+	p, err := repoPath(opts.Repo)
+	if err != nil {
+		return vcs.Webhook{}, err
+	}
+	var hook webhook
+	if err := c.get(ctx, path.Join(p, "hooks", url.PathEscape(opts.ID)), nil, &hook); err != nil {
+		return vcs.Webhook{}, err
+	}
+	var events []vcs.EventType
+	for _, event := range hook.Events {
+		switch event {
+		case "push":
+			events = append(events, vcs.EventTypePush)
+		case "pull_request":
+			events = append(events, vcs.EventTypePull)
+		}
+	}
+	return vcs.Webhook{
+		ID:       strconv.FormatInt(hook.ID, 10),
+		Repo:     opts.Repo,
+		Events:   events,
+		Endpoint: hook.Config["url"],
+	}, nil
+}
+
+func (c *Client) DeleteWebhook(ctx context.Context, opts vcs.DeleteWebhookOptions) error {
+	p, err := repoPath(opts.Repo)
+	if err != nil {
+		return err
+	}
+	return c.send(ctx, "DELETE", path.Join(p, "hooks", url.PathEscape(opts.ID)), nil, nil)
+}
+
+func (c *Client) SetStatus(ctx context.Context, opts vcs.SetStatusOptions) error {
+	p, err := repoPath(opts.Repo)
+	if err != nil {
+		return err
+	}
+	var state string
+	switch opts.Status {
+	case vcs.PendingStatus, vcs.RunningStatus:
+		state = "pending"
+	case vcs.SuccessStatus:
+		state = "success"
+	case vcs.ErrorStatus:
+		state = "error"
+	case vcs.FailureStatus:
+		state = "failure"
+	default:
+		return fmt.Errorf("invalid vcs status: %s", opts.Status)
+	}
+	return c.send(ctx, "POST", path.Join(p, "statuses", url.PathEscape(opts.Ref)), &commitStatus{
+		State:       state,
+		TargetURL:   opts.TargetURL,
+		Description: opts.Description,
+		Context:     fmt.Sprintf("otf/%s", opts.Workspace),
+	}, nil)
+}
+
+func (c *Client) ListPullRequestFiles(ctx context.Context, repo string, pull int) ([]string, error) {
+	p, err := repoPath(repo)
+	if err != nil {
+		return nil, err
+	}
+	files, err := listAll[changedFile](ctx, c, path.Join(p, "pulls", strconv.Itoa(pull), "files"))
+	if err != nil {
+		return nil, err
+	}
+	var changed []string
+	for _, file := range files {
+		changed = append(changed, file.Filename)
+		if file.PreviousFilename != "" {
+			changed = append(changed, file.PreviousFilename)
+		}
+	}
+	// remove duplicates
+	slices.Sort(changed)
+	return slices.Compact(changed), nil
+}
+
+func (c *Client) GetCommit(ctx context.Context, repo, ref string) (vcs.Commit, error) {
+	p, err := repoPath(repo)
+	if err != nil {
+		return vcs.Commit{}, err
+	}
+	// the endpoint accepts any git ref as well as a commit SHA
+	var result commit
+	if err := c.get(ctx, path.Join(p, "git/commits", url.PathEscape(ref)), nil, &result); err != nil {
+		return vcs.Commit{}, err
+	}
+	to := vcs.Commit{
+		SHA: result.SHA,
+		URL: result.HTMLURL,
+	}
+	if u := result.Author; u != nil {
+		to.Author = vcs.CommitAuthor{
+			Username:   u.Login,
+			ProfileURL: u.HTMLURL,
+			AvatarURL:  u.AvatarURL,
+		}
+	}
+	return to, nil
+}
+
+// newWebhook constructs a gitea webhook from webhook options.
+func newWebhook(opts vcs.CreateWebhookOptions) *webhook {
+	hook := &webhook{
+		Config: map[string]string{
+			"url":          opts.Endpoint,
+			"content_type": "json",
+			"secret":       opts.Secret,
+		},
+		Active: true,
+	}
+	for _, event := range opts.Events {
+		switch event {
+		case vcs.EventTypePush:
+			// push events are sent for both branches and tags, but not
+			// when a tag is deleted, for which a delete event is sent.
+			hook.Events = append(hook.Events, "push", "delete")
+		case vcs.EventTypePull:
+			hook.Events = append(hook.Events, "pull_request")
+		}
+	}
+	return hook
+}
+
+// repoPath returns the API path for a repository identified by
+// <owner>/<repo>.
+func repoPath(identifier string) (string, error) {
+	owner, name, found := strings.Cut(identifier, "/")
+	if !found || owner == "" || name == "" {
+		return "", fmt.Errorf("malformed identifier: %s", identifier)
+	}
+	return path.Join(apiPath, "repos", url.PathEscape(owner), url.PathEscape(name)), nil
+}
+
+// listAll retrieves all items from a paginated API endpoint.
+func listAll[T any](ctx context.Context, c *Client, p string) ([]T, error) {
+	q := url.Values{"limit": {strconv.Itoa(maxPageSize)}}
+	var items []T
+	for page := 1; ; page++ {
+		q.Set("page", strconv.Itoa(page))
+		var result []T
+		if err := c.get(ctx, p, q, &result); err != nil {
+			return nil, err
+		}
+		items = append(items, result...)
+		// a page that is not full is the last page
+		if len(result) < maxPageSize {
+			return items, nil
+		}
+	}
+}
+
+// get sends a GET request to an API path, decoding the JSON response into v.
+func (c *Client) get(ctx context.Context, p string, q url.Values, v any) error {
+	u := c.baseURL.JoinPath(p)
+	u.RawQuery = q.Encode()
+	req, err := c.newRequest(ctx, "GET", u.String(), nil)
+	if err != nil {
+		return err
+	}
+	return c.do(req, v)
+}
+
+// send sends a request with a JSON body to an API path, decoding the JSON
+// response, if any, into v.
+func (c *Client) send(ctx context.Context, method, p string, body, v any) error {
+	var r io.Reader
+	if body != nil {
+		encoded, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		r = bytes.NewReader(encoded)
+	}
+	req, err := c.newRequest(ctx, method, c.baseURL.JoinPath(p).String(), r)
+	if err != nil {
+		return err
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	return c.do(req, v)
+}
+
+func (c *Client) newRequest(ctx context.Context, method, u string, body io.Reader) (*http.Request, error) {
+	req, err := http.NewRequestWithContext(ctx, method, u, body)
+	if err != nil {
+		return nil, err
+	}
+	if c.token != "" {
+		req.Header.Set("Authorization", "token "+c.token)
+	}
+	req.Header.Set("Accept", "application/json")
+	return req, nil
+}
+
+// do sends the request, writing the response body to v if it is an
+// io.Writer, otherwise decoding the JSON response body into v. If v is nil
+// then the response body is discarded.
+func (c *Client) do(req *http.Request, v any) error {
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return internal.ErrResourceNotFound
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		var apiErr apiError
+		if err := json.NewDecoder(resp.Body).Decode(&apiErr); err == nil && apiErr.Message != "" {
+			return fmt.Errorf("gitea: %s: %s", resp.Status, apiErr.Message)
+		}
+		return fmt.Errorf("gitea: %s", resp.Status)
+	}
+	switch dst := v.(type) {
+	case nil:
+		return nil
+	case io.Writer:
+		_, err := io.Copy(dst, resp.Body)
+		return err
+	default:
+		return json.NewDecoder(resp.Body).Decode(v)
+	}
+}
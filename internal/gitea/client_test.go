@@ -0,0 +1,275 @@
+package gitea
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"path"
+	"testing"
+
+	"github.com/leg100/otf/internal"
+	"github.com/leg100/otf/internal/testutils"
+	"github.com/leg100/otf/internal/vcs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_NoHostname(t *testing.T) {
+	_, err := NewClient(ClientOptions{Token: "my-token"})
+	assert.ErrorIs(t, err, ErrHostnameRequired)
+}
+
+func TestClient_GetRepository(t *testing.T) {
+	mux, client := setup(t, "my-token")
+
+	mux.HandleFunc("/api/v1/repos/acme/terraform", func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "GET", r.Method)
+		assert.Equal(t, "token my-token", r.Header.Get("Authorization"))
+		fmt.Fprint(w, `{"full_name":"acme/terraform","default_branch":"main"}`)
+	})
+
+	got, err := client.GetRepository(context.Background(), "acme/terraform")
+	require.NoError(t, err)
+
+	assert.Equal(t, "acme/terraform", got.Path)
+	assert.Equal(t, "main", got.DefaultBranch)
+}
+
+func TestClient_GetRepository_NotFound(t *testing.T) {
+	_, client := setup(t, "my-token")
+
+	_, err := client.GetRepository(context.Background(), "acme/terraform")
+	assert.ErrorIs(t, err, internal.ErrResourceNotFound)
+}
+
+func TestClient_ListRepositories(t *testing.T) {
+	mux, client := setup(t, "my-token")
+
+	mux.HandleFunc("/api/v1/user/repos", func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "GET", r.Method)
+		assert.Equal(t, "20", r.URL.Query().Get("limit"))
+		fmt.Fprint(w, `[
+			{"full_name":"acme/terraform","permissions":{"admin":true}},
+			{"full_name":"acme/readonly","permissions":{"admin":false}},
+			{"full_name":"leg100/modules","permissions":{"admin":true}}
+		]`)
+	})
+
+	got, err := client.ListRepositories(context.Background(), vcs.ListRepositoriesOptions{PageSize: 20})
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"acme/terraform", "leg100/modules"}, got)
+}
+
+func TestClient_ListTags(t *testing.T) {
+	mux, client := setup(t, "my-token")
+
+	mux.HandleFunc("/api/v1/repos/acme/terraform/tags", func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "GET", r.Method)
+		if r.URL.Query().Get("page") == "2" {
+			fmt.Fprint(w, `[{"name":"v1.1.0"}]`)
+			return
+		}
+		// return a full page to force retrieval of the next page
+		tags := make([]tag, maxPageSize)
+		for i := range tags {
+			tags[i] = tag{Name: fmt.Sprintf("v0.%d.0", i)}
+		}
+		tags[0].Name = "v1.0.0"
+		json.NewEncoder(w).Encode(tags)
+	})
+
+	got, err := client.ListTags(context.Background(), vcs.ListTagsOptions{
+		Repo:   "acme/terraform",
+		Prefix: "v1",
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"tags/v1.0.0", "tags/v1.1.0"}, got)
+}
+
+func TestClient_GetRepoTarball(t *testing.T) {
+	mux, client := setup(t, "my-token")
+
+	mux.HandleFunc("/api/v1/repos/acme/terraform/git/commits/v1.0.0", func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "GET", r.Method)
+		fmt.Fprint(w, `{"sha":"0335fb07bb0244b7a169ee89d15c7703e4aaf7de"}`)
+	})
+	mux.HandleFunc("/api/v1/repos/acme/terraform/archive/0335fb07bb0244b7a169ee89d15c7703e4aaf7de.tar.gz", func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "GET", r.Method)
+		w.Write(testutils.ReadFile(t, "../testdata/gitlab.tar.gz"))
+	})
+
+	got, ref, err := client.GetRepoTarball(context.Background(), vcs.GetRepoTarballOptions{
+		Repo: "acme/terraform",
+		Ref:  internal.String("v1.0.0"),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "0335fb07bb0244b7a169ee89d15c7703e4aaf7de", ref)
+
+	dst := t.TempDir()
+	err = internal.Unpack(bytes.NewReader(got), dst)
+	require.NoError(t, err)
+	assert.FileExists(t, path.Join(dst, "afile"))
+	assert.FileExists(t, path.Join(dst, "bfile"))
+}
+
+func TestClient_Webhook(t *testing.T) {
+	mux, client := setup(t, "my-token")
+	ctx := context.Background()
+
+	var hook webhook
+	mux.HandleFunc("/api/v1/repos/acme/terraform/hooks", func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "POST", r.Method)
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&hook))
+		hook.ID = 123
+		delete(hook.Config, "secret")
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(hook)
+	})
+	mux.HandleFunc("/api/v1/repos/acme/terraform/hooks/123", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case "GET":
+			json.NewEncoder(w).Encode(hook)
+		case "PATCH":
+			var update webhook
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&update))
+			assert.Equal(t, "new-secret", update.Config["secret"])
+			json.NewEncoder(w).Encode(hook)
+		case "DELETE":
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			t.Fatalf("unexpected method: %s", r.Method)
+		}
+	})
+
+	id, err := client.CreateWebhook(ctx, vcs.CreateWebhookOptions{
+		Repo:     "acme/terraform",
+		Secret:   "me-secret",
+		Endpoint: "https://otf.dev/webhooks/vcs/123",
+		Events:   []vcs.EventType{vcs.EventTypePush, vcs.EventTypePull},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "123", id)
+	assert.Equal(t, "gitea", hook.Type)
+	assert.True(t, hook.Active)
+	assert.Equal(t, "json", hook.Config["content_type"])
+	assert.Equal(t, []string{"push", "delete", "pull_request"}, hook.Events)
+
+	got, err := client.GetWebhook(ctx, vcs.GetWebhookOptions{Repo: "acme/terraform", ID: id})
+	require.NoError(t, err)
+	assert.Equal(t, vcs.Webhook{
+		ID:       "123",
+		Repo:     "acme/terraform",
+		Events:   []vcs.EventType{vcs.EventTypePush, vcs.EventTypePull},
+		Endpoint: "https://otf.dev/webhooks/vcs/123",
+	}, got)
+
+	err = client.UpdateWebhook(ctx, id, vcs.UpdateWebhookOptions{
+		Repo:     "acme/terraform",
+		Secret:   "new-secret",
+		Endpoint: "https://otf.dev/webhooks/vcs/123",
+		Events:   []vcs.EventType{vcs.EventTypePush, vcs.EventTypePull},
+	})
+	require.NoError(t, err)
+
+	err = client.DeleteWebhook(ctx, vcs.DeleteWebhookOptions{Repo: "acme/terraform", ID: id})
+	require.NoError(t, err)
+}
+
+func TestClient_SetStatus(t *testing.T) {
+	mux, client := setup(t, "my-token")
+
+	mux.HandleFunc("/api/v1/repos/acme/terraform/statuses/abc123", func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "POST", r.Method)
+		var got commitStatus
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&got))
+		assert.Equal(t, commitStatus{
+			State:       "failure",
+			TargetURL:   "https://otf.dev/runs/run-123",
+			Description: "planned: +1/~0/-0",
+			Context:     "otf/dev",
+		}, got)
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprint(w, `{}`)
+	})
+
+	err := client.SetStatus(context.Background(), vcs.SetStatusOptions{
+		Workspace:   "dev",
+		Repo:        "acme/terraform",
+		Ref:         "abc123",
+		Status:      vcs.FailureStatus,
+		TargetURL:   "https://otf.dev/runs/run-123",
+		Description: "planned: +1/~0/-0",
+	})
+	require.NoError(t, err)
+}
+
+func TestClient_ListPullRequestFiles(t *testing.T) {
+	mux, client := setup(t, "my-token")
+
+	mux.HandleFunc("/api/v1/repos/acme/terraform/pulls/7/files", func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "GET", r.Method)
+		fmt.Fprint(w, `[
+			{"filename":"main.tf","status":"changed"},
+			{"filename":"vpc.tf","previous_filename":"network.tf","status":"renamed"}
+		]`)
+	})
+
+	got, err := client.ListPullRequestFiles(context.Background(), "acme/terraform", 7)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"main.tf", "network.tf", "vpc.tf"}, got)
+}
+
+func TestClient_GetCommit(t *testing.T) {
+	mux, client := setup(t, "my-token")
+
+	mux.HandleFunc("/api/v1/repos/acme/terraform/git/commits/main", func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "GET", r.Method)
+		fmt.Fprint(w, `{
+			"sha":"abc123",
+			"html_url":"https://gitea.acme.com/acme/terraform/commit/abc123",
+			"author":{
+				"login":"leg100",
+				"avatar_url":"https://gitea.acme.com/avatars/leg100",
+				"html_url":"https://gitea.acme.com/leg100"
+			}
+		}`)
+	})
+
+	got, err := client.GetCommit(context.Background(), "acme/terraform", "main")
+	require.NoError(t, err)
+
+	assert.Equal(t, vcs.Commit{
+		SHA: "abc123",
+		URL: "https://gitea.acme.com/acme/terraform/commit/abc123",
+		Author: vcs.CommitAuthor{
+			Username:   "leg100",
+			ProfileURL: "https://gitea.acme.com/leg100",
+			AvatarURL:  "https://gitea.acme.com/avatars/leg100",
+		},
+	}, got)
+}
+
+func setup(t *testing.T, token string) (*http.ServeMux, *Client) {
+	mux := http.NewServeMux()
+	srv := httptest.NewTLSServer(mux)
+	t.Cleanup(srv.Close)
+
+	u, err := url.Parse(srv.URL)
+	require.NoError(t, err)
+
+	client, err := NewClient(ClientOptions{
+		Hostname:            u.Host,
+		SkipTLSVerification: true,
+		Token:               token,
+	})
+	require.NoError(t, err)
+
+	return mux, client
+}
@@ -0,0 +1,207 @@
+package gitea
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/leg100/otf/internal/vcs"
+)
+
+// nullSHA is the SHA of the "after" commit of a push that deletes a ref.
+const nullSHA = "0000000000000000000000000000000000000000"
+
+type (
+	// pushEvent is the payload of a push event:
+	//
+	// https://docs.gitea.com/usage/webhooks#event-information
+	pushEvent struct {
+		Ref        string       `json:"ref"`
+		After      string       `json:"after"`
+		Commits    []pushCommit `json:"commits"`
+		HeadCommit *pushCommit  `json:"head_commit"`
+		Repository eventRepo    `json:"repository"`
+		Sender     user         `json:"sender"`
+	}
+
+	pushCommit struct {
+		ID       string   `json:"id"`
+		URL      string   `json:"url"`
+		Added    []string `json:"added"`
+		Removed  []string `json:"removed"`
+		Modified []string `json:"modified"`
+	}
+
+	// deleteEvent is the payload of a delete event, sent when a branch or
+	// tag is deleted.
+	deleteEvent struct {
+		Ref        string    `json:"ref"`
+		RefType    string    `json:"ref_type"` // branch or tag
+		Repository eventRepo `json:"repository"`
+		Sender     user      `json:"sender"`
+	}
+
+	// pullRequestEvent is the payload of a pull_request event.
+	pullRequestEvent struct {
+		Action      string `json:"action"`
+		Number      int    `json:"number"`
+		PullRequest struct {
+			HTMLURL string `json:"html_url"`
+			Title   string `json:"title"`
+			Merged  bool   `json:"merged"`
+			Head    struct {
+				Ref string `json:"ref"`
+				SHA string `json:"sha"`
+			} `json:"head"`
+		} `json:"pull_request"`
+		Repository eventRepo `json:"repository"`
+		Sender     user      `json:"sender"`
+	}
+
+	eventRepo struct {
+		FullName      string `json:"full_name"`
+		HTMLURL       string `json:"html_url"`
+		DefaultBranch string `json:"default_branch"`
+	}
+)
+
+// HandleEvent converts a gitea or forgejo webhook event into an OTF event.
+func HandleEvent(r *http.Request, secret string) (*vcs.EventPayload, error) {
+	payload, err := io.ReadAll(r.Body)
+	if err != nil || len(payload) == 0 {
+		return nil, errors.New("error reading request body")
+	}
+	// forgejo sends gitea headers too, for compatibility
+	if err := validateSignature(r.Header.Get("X-Gitea-Signature"), payload, secret); err != nil {
+		return nil, err
+	}
+
+	to := vcs.EventPayload{VCSKind: vcs.GiteaKind, DeliveryID: r.Header.Get("X-Gitea-Delivery")}
+	switch kind := r.Header.Get("X-Gitea-Event"); kind {
+	case "push":
+		var event pushEvent
+		if err := json.Unmarshal(payload, &event); err != nil {
+			return nil, fmt.Errorf("parsing webhook: %w", err)
+		}
+		if event.After == nullSHA {
+			// deletions are handled by delete events
+			return nil, vcs.NewErrIgnoreEvent("ref deleted: %s", event.Ref)
+		}
+		if tag, found := strings.CutPrefix(event.Ref, "refs/tags/"); found {
+			to.Type = vcs.EventTypeTag
+			to.Tag = tag
+		} else if branch, found := strings.CutPrefix(event.Ref, "refs/heads/"); found {
+			to.Type = vcs.EventTypePush
+			to.Branch = branch
+		} else {
+			return nil, fmt.Errorf("malformed ref: %s", event.Ref)
+		}
+		// pushes of both branches and tags are always a create
+		to.Action = vcs.ActionCreated
+		to.CommitSHA = event.After
+		if event.HeadCommit != nil {
+			to.CommitURL = event.HeadCommit.URL
+		} else {
+			to.CommitURL = event.Repository.HTMLURL + "/commit/" + event.After
+		}
+		// populate event with list of changed file paths
+		for _, c := range event.Commits {
+			to.Paths = append(to.Paths, c.Added...)
+			to.Paths = append(to.Paths, c.Modified...)
+			to.Paths = append(to.Paths, c.Removed...)
+		}
+		// remove duplicate file paths
+		slices.Sort(to.Paths)
+		to.Paths = slices.Compact(to.Paths)
+		setRepo(&to, event.Repository)
+		setSender(&to, event.Sender)
+	case "delete":
+		var event deleteEvent
+		if err := json.Unmarshal(payload, &event); err != nil {
+			return nil, fmt.Errorf("parsing webhook: %w", err)
+		}
+		if event.RefType != "tag" {
+			return nil, vcs.NewErrIgnoreEvent("%s deleted: %s", event.RefType, event.Ref)
+		}
+		to.Type = vcs.EventTypeTag
+		to.Action = vcs.ActionDeleted
+		to.Tag = strings.TrimPrefix(event.Ref, "refs/tags/")
+		setRepo(&to, event.Repository)
+		setSender(&to, event.Sender)
+	case "pull_request":
+		var event pullRequestEvent
+		if err := json.Unmarshal(payload, &event); err != nil {
+			return nil, fmt.Errorf("parsing webhook: %w", err)
+		}
+		to.Type = vcs.EventTypePull
+		switch event.Action {
+		case "opened":
+			to.Action = vcs.ActionCreated
+		case "closed":
+			if event.PullRequest.Merged {
+				to.Action = vcs.ActionMerged
+			} else {
+				to.Action = vcs.ActionDeleted
+			}
+		case "synchronized":
+			to.Action = vcs.ActionUpdated
+		default:
+			// ignore other pull request events
+			return nil, vcs.NewErrIgnoreEvent("unsupported action: %s", event.Action)
+		}
+		to.Branch = event.PullRequest.Head.Ref
+		to.CommitSHA = event.PullRequest.Head.SHA
+		// commit-url isn't provided in a pull-request event so one is
+		// constructed instead
+		to.CommitURL = event.Repository.HTMLURL + "/commit/" + to.CommitSHA
+		to.PullRequestNumber = event.Number
+		to.PullRequestURL = event.PullRequest.HTMLURL
+		to.PullRequestTitle = event.PullRequest.Title
+		setRepo(&to, event.Repository)
+		setSender(&to, event.Sender)
+	default:
+		return nil, vcs.NewErrIgnoreEvent("unsupported event type: %s", kind)
+	}
+	if err := to.Validate(); err != nil {
+		return nil, fmt.Errorf("failed building OTF event: %w", err)
+	}
+	return &to, nil
+}
+
+// validateSignature validates the hex-encoded HMAC-SHA256 signature gitea
+// sends for webhooks configured with a secret.
+func validateSignature(header string, payload []byte, secret string) error {
+	if header == "" {
+		return errors.New("missing X-Gitea-Signature header")
+	}
+	got, err := hex.DecodeString(header)
+	if err != nil {
+		return fmt.Errorf("decoding signature: %w", err)
+	}
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(payload)
+	// constant-time comparison prevents the signature being guessed by
+	// timing responses.
+	if !hmac.Equal(got, mac.Sum(nil)) {
+		return errors.New("signature validation failed")
+	}
+	return nil
+}
+
+func setRepo(to *vcs.EventPayload, repo eventRepo) {
+	to.RepoPath = repo.FullName
+	to.DefaultBranch = repo.DefaultBranch
+}
+
+func setSender(to *vcs.EventPayload, sender user) {
+	to.SenderUsername = sender.Login
+	to.SenderAvatarURL = sender.AvatarURL
+	to.SenderHTMLURL = sender.HTMLURL
+}
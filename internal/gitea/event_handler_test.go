@@ -0,0 +1,161 @@
+package gitea
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/leg100/otf/internal/testutils"
+	"github.com/leg100/otf/internal/vcs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEventHandler(t *testing.T) {
+	sender := func(to vcs.EventPayload) *vcs.EventPayload {
+		to.VCSKind = vcs.GiteaKind
+		to.DeliveryID = "delivery-123"
+		to.RepoPath = "acme/terraform"
+		to.DefaultBranch = "main"
+		to.SenderUsername = "leg100"
+		to.SenderAvatarURL = "https://gitea.acme.com/avatars/leg100"
+		to.SenderHTMLURL = "https://gitea.acme.com/leg100"
+		return &to
+	}
+	pull := func(action vcs.Action) *vcs.EventPayload {
+		return sender(vcs.EventPayload{
+			Type:              vcs.EventTypePull,
+			Action:            action,
+			Branch:            "add-vpc",
+			CommitSHA:         "ef8755f06ee4b28c96a847a95cb8ec8ed6ddd1ca",
+			CommitURL:         "https://gitea.acme.com/acme/terraform/commit/ef8755f06ee4b28c96a847a95cb8ec8ed6ddd1ca",
+			PullRequestNumber: 7,
+			PullRequestURL:    "https://gitea.acme.com/acme/terraform/pulls/7",
+			PullRequestTitle:  "add vpc",
+		})
+	}
+
+	tests := []struct {
+		name  string
+		event string
+		body  string
+		want  *vcs.EventPayload
+	}{
+		{
+			"push",
+			"push",
+			"./testdata/push.json",
+			sender(vcs.EventPayload{
+				Type:      vcs.EventTypePush,
+				Action:    vcs.ActionCreated,
+				Branch:    "main",
+				CommitSHA: "bffeb74224043ba2feb48d137756c8a9331c449a",
+				CommitURL: "https://gitea.acme.com/acme/terraform/commit/bffeb74224043ba2feb48d137756c8a9331c449a",
+				Paths:     []string{"main.tf", "network.tf", "vpc.tf"},
+			}),
+		},
+		{
+			"tag created",
+			"push",
+			"./testdata/tag_created.json",
+			sender(vcs.EventPayload{
+				Type:      vcs.EventTypeTag,
+				Action:    vcs.ActionCreated,
+				Tag:       "v1.0.0",
+				CommitSHA: "bffeb74224043ba2feb48d137756c8a9331c449a",
+				CommitURL: "https://gitea.acme.com/acme/terraform/commit/bffeb74224043ba2feb48d137756c8a9331c449a",
+			}),
+		},
+		{
+			"tag deleted",
+			"delete",
+			"./testdata/tag_deleted.json",
+			sender(vcs.EventPayload{
+				Type:   vcs.EventTypeTag,
+				Action: vcs.ActionDeleted,
+				Tag:    "v1.0.0",
+			}),
+		},
+		{
+			"pull request opened",
+			"pull_request",
+			"./testdata/pull_request_opened.json",
+			pull(vcs.ActionCreated),
+		},
+		{
+			"pull request updated",
+			"pull_request",
+			"./testdata/pull_request_synchronized.json",
+			pull(vcs.ActionUpdated),
+		},
+		{
+			"pull request merged",
+			"pull_request",
+			"./testdata/pull_request_merged.json",
+			pull(vcs.ActionMerged),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			body := testutils.ReadFile(t, tt.body)
+			r := newTestEventRequest(body, tt.event, sign(body, "secret"))
+
+			got, err := HandleEvent(r, "secret")
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.want, got)
+		})
+	}
+
+	t.Run("ignore deleted branch push", func(t *testing.T) {
+		body := testutils.ReadFile(t, "./testdata/branch_deleted.json")
+		r := newTestEventRequest(body, "push", sign(body, "secret"))
+
+		_, err := HandleEvent(r, "secret")
+		assert.ErrorAs(t, err, &vcs.ErrIgnoreEvent{})
+	})
+
+	t.Run("ignore unsupported event", func(t *testing.T) {
+		body := testutils.ReadFile(t, "./testdata/push.json")
+		r := newTestEventRequest(body, "issues", sign(body, "secret"))
+
+		_, err := HandleEvent(r, "secret")
+		assert.ErrorAs(t, err, &vcs.ErrIgnoreEvent{})
+	})
+
+	t.Run("invalid signature", func(t *testing.T) {
+		body := testutils.ReadFile(t, "./testdata/push.json")
+		r := newTestEventRequest(body, "push", sign(body, "wrong-secret"))
+
+		_, err := HandleEvent(r, "secret")
+		assert.Error(t, err)
+	})
+
+	t.Run("missing signature", func(t *testing.T) {
+		body := testutils.ReadFile(t, "./testdata/push.json")
+		r := newTestEventRequest(body, "push", "")
+
+		_, err := HandleEvent(r, "secret")
+		assert.Error(t, err)
+	})
+}
+
+func newTestEventRequest(body []byte, event, signature string) *http.Request {
+	r := httptest.NewRequest("POST", "/", bytes.NewReader(body))
+	r.Header.Set("X-Gitea-Event", event)
+	r.Header.Set("X-Gitea-Delivery", "delivery-123")
+	if signature != "" {
+		r.Header.Set("X-Gitea-Signature", signature)
+	}
+	return r
+}
+
+func sign(body []byte, secret string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
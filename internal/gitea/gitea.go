@@ -0,0 +1,8 @@
+// Package gitea provides gitea and forgejo related code
+package gitea
+
+import "errors"
+
+// ErrHostnameRequired is returned when no hostname is configured: unlike
+// other providers, gitea is only ever self-hosted.
+var ErrHostnameRequired = errors.New("gitea hostname not configured")
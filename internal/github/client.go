@@ -82,6 +82,9 @@ func NewClient(cfg ClientOptions) (*Client, error) {
 	if cfg.SkipTLSVerification {
 		tripper = otfhttp.InsecureTransport
 	}
+	// retry transient errors and honor rate limits, sharing the limit with
+	// other clients using the same credentials
+	tripper = vcs.NewTransport(tripper, cfg.Hostname, rateLimitKey(cfg))
 	switch {
 	case cfg.AppCredentials != nil:
 		tripper, err = ghinstallation.NewAppsTransport(tripper, cfg.AppCredentials.ID, []byte(cfg.AppCredentials.PrivateKey))
@@ -127,6 +130,24 @@ func NewClient(cfg ClientOptions) (*Client, error) {
 	return &Client{client: client, iat: iat}, nil
 }
 
+// rateLimitKey returns a key identifying the credentials with which the rate
+// limit is associated.
+func rateLimitKey(cfg ClientOptions) string {
+	switch {
+	case cfg.AppCredentials != nil:
+		return fmt.Sprintf("app:%d", cfg.AppCredentials.ID)
+	case cfg.InstallCredentials != nil:
+		return fmt.Sprintf("install:%d", cfg.InstallCredentials.ID)
+	case cfg.PersonalToken != nil:
+		return *cfg.PersonalToken
+	case cfg.OAuthToken != nil:
+		return cfg.OAuthToken.AccessToken
+	default:
+		// anonymous
+		return ""
+	}
+}
+
 func NewTokenClient(opts vcs.NewTokenClientOptions) (vcs.Client, error) {
 	return NewClient(ClientOptions{
 		Hostname:            opts.Hostname,
@@ -3,8 +3,18 @@ package github
 import (
 	"bytes"
 	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"fmt"
+	"net/http"
 	"os"
 	"path"
+	"strings"
 	"testing"
 
 	"github.com/leg100/otf/internal"
@@ -77,6 +87,70 @@ func TestCreateWebhook(t *testing.T) {
 	require.NoError(t, err)
 }
 
+// TestListRepositories_Installation demonstrates a client authenticating as
+// a github app installation, exchanging a JWT for an installation access
+// token, and listing the repositories the installation has access to.
+func TestListRepositories_Installation(t *testing.T) {
+	ctx := context.Background()
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	privateKey := pem.EncodeToMemory(&pem.Block{
+		Type:  "RSA PRIVATE KEY",
+		Bytes: x509.MarshalPKCS1PrivateKey(key),
+	})
+
+	_, u := NewTestServer(t,
+		WithHandler("/api/v3/app/installations/42/access_tokens", func(w http.ResponseWriter, r *http.Request) {
+			// app authenticates with a JWT signed with its private key
+			bearer, found := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+			require.True(t, found)
+			parts := strings.Split(bearer, ".")
+			require.Len(t, parts, 3)
+			digest := sha256.Sum256([]byte(parts[0] + "." + parts[1]))
+			sig, err := base64.RawURLEncoding.DecodeString(parts[2])
+			require.NoError(t, err)
+			require.NoError(t, rsa.VerifyPKCS1v15(&key.PublicKey, crypto.SHA256, digest[:], sig))
+			claims, err := base64.RawURLEncoding.DecodeString(parts[1])
+			require.NoError(t, err)
+			assert.Contains(t, string(claims), `"iss":"123"`)
+
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusCreated)
+			w.Write([]byte(`{"token":"install-token","expires_at":"2099-01-01T00:00:00Z"}`))
+		}),
+		WithHandler("/api/v3/installation/repositories", func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "token install-token", r.Header.Get("Authorization"))
+
+			w.Header().Set("Content-Type", "application/json")
+			if r.URL.Query().Get("page") == "2" {
+				w.Write([]byte(`{"total_count":3,"repositories":[{"full_name":"acme/c","pushed_at":"2023-01-02T00:00:00Z"}]}`))
+				return
+			}
+			w.Header().Set("Link", fmt.Sprintf(`<https://%s/api/v3/installation/repositories?page=2>; rel="next"`, r.Host))
+			w.Write([]byte(`{"total_count":3,"repositories":[{"full_name":"acme/a","pushed_at":"2023-01-01T00:00:00Z"},{"full_name":"acme/b","pushed_at":"2023-01-03T00:00:00Z"}]}`))
+		}),
+	)
+	client, err := NewClient(ClientOptions{
+		Hostname:            u.Host,
+		SkipTLSVerification: true,
+		InstallCredentials: &InstallCredentials{
+			ID: 42,
+			AppCredentials: AppCredentials{
+				ID:         123,
+				PrivateKey: string(privateKey),
+			},
+		},
+	})
+	require.NoError(t, err)
+
+	got, err := client.ListRepositories(ctx, vcs.ListRepositoriesOptions{PageSize: 2})
+	require.NoError(t, err)
+
+	// repos are sorted by most recently pushed to
+	assert.Equal(t, []string{"acme/b", "acme/c", "acme/a"}, got)
+}
+
 // newTestServerClient creates a github server for testing purposes and
 // returns a client configured to access the server.
 func newTestServerClient(t *testing.T, opts ...TestServerOption) *Client {
@@ -1,6 +1,10 @@
 package github
 
 import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
 	"errors"
 	"net/http/httptest"
 	"os"
@@ -162,3 +166,41 @@ func TestEventHandler(t *testing.T) {
 		})
 	}
 }
+
+func TestEventHandler_Signature(t *testing.T) {
+	body, err := os.ReadFile("./testdata/github_push.json")
+	require.NoError(t, err)
+
+	sign := func(secret string) string {
+		mac := hmac.New(sha256.New, []byte(secret))
+		mac.Write(body)
+		return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+	}
+
+	tests := []struct {
+		name      string
+		signature string
+		wantErr   bool
+	}{
+		{"valid signature", sign("secret"), false},
+		{"signed with different secret", sign("different-secret"), true},
+		{"missing signature", "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest("POST", "/", bytes.NewReader(body))
+			r.Header.Add("Content-type", "application/json")
+			r.Header.Add(github.EventTypeHeader, "push")
+			if tt.signature != "" {
+				r.Header.Add(github.SHA256SignatureHeader, tt.signature)
+			}
+			got, err := HandleEvent(r, "secret")
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, vcs.EventTypePush, got.Type)
+		})
+	}
+}
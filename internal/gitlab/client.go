@@ -44,11 +44,25 @@ func NewClient(cfg ClientOptions) (*Client, error) {
 			),
 		}
 	)
+	tripper := http.DefaultTransport
 	if cfg.SkipTLSVerification {
-		options = append(options, gitlab.WithHTTPClient(
-			&http.Client{Transport: otfhttp.InsecureTransport},
-		))
+		tripper = otfhttp.InsecureTransport
 	}
+	// retry transient errors and honor rate limits, sharing the limit with
+	// other clients using the same credentials
+	var credentials string
+	if cfg.OAuthToken != nil {
+		credentials = cfg.OAuthToken.AccessToken
+	} else if cfg.PersonalToken != nil {
+		credentials = *cfg.PersonalToken
+	}
+	options = append(options,
+		gitlab.WithHTTPClient(&http.Client{
+			Transport: vcs.NewTransport(tripper, cfg.Hostname, credentials),
+		}),
+		// retries are instead performed by the transport above
+		gitlab.WithoutRetries(),
+	)
 	if cfg.OAuthToken != nil {
 		client, err = gitlab.NewOAuthClient(cfg.OAuthToken.AccessToken, options...)
 	} else if cfg.PersonalToken != nil {
@@ -1,6 +1,7 @@
 package gitlab
 
 import (
+	"crypto/subtle"
 	"errors"
 	"fmt"
 	"io"
@@ -14,8 +15,16 @@ import (
 	"github.com/xanzy/go-gitlab"
 )
 
+// nullSHA is the SHA of the "after" commit of a push that deletes a branch.
+const nullSHA = "0000000000000000000000000000000000000000"
+
+// HandleEvent converts a gitlab webhook event into an OTF event. The request
+// is authenticated using the secret token gitlab sends in the X-Gitlab-Token
+// header.
 func HandleEvent(r *http.Request, secret string) (*vcs.EventPayload, error) {
-	if token := r.Header.Get("X-Gitlab-Token"); token != secret {
+	// constant-time comparison prevents the secret being guessed by timing
+	// responses.
+	if token := r.Header.Get("X-Gitlab-Token"); subtle.ConstantTimeCompare([]byte(token), []byte(secret)) != 1 {
 		return nil, errors.New("token validation failed")
 	}
 	var origin *url.URL
@@ -38,7 +47,7 @@ func HandleEvent(r *http.Request, secret string) (*vcs.EventPayload, error) {
 	}
 
 	// convert gitlab event to an OTF event
-	to := vcs.EventPayload{VCSKind: vcs.GitlabKind}
+	to := vcs.EventPayload{VCSKind: vcs.GitlabKind, DeliveryID: r.Header.Get("X-Gitlab-Event-UUID")}
 	switch event := rawEvent.(type) {
 	case *gitlab.PushEvent:
 		to.Type = vcs.EventTypePush
@@ -46,6 +55,9 @@ func HandleEvent(r *http.Request, secret string) (*vcs.EventPayload, error) {
 		if !found {
 			return nil, fmt.Errorf("malformed ref: %s", event.Ref)
 		}
+		if event.After == nullSHA {
+			return nil, vcs.NewErrIgnoreEvent("branch deleted: %s", branch)
+		}
 		to.Action = vcs.ActionCreated
 		to.Branch = branch
 		to.CommitSHA = event.After
@@ -72,6 +84,10 @@ func HandleEvent(r *http.Request, secret string) (*vcs.EventPayload, error) {
 			to.Action = vcs.ActionCreated
 		case "update":
 			to.Action = vcs.ActionUpdated
+		case "merge":
+			to.Action = vcs.ActionMerged
+		case "close":
+			to.Action = vcs.ActionDeleted
 		default:
 			return nil, vcs.NewErrIgnoreEvent("unsupported action: %s", event.ObjectAttributes.Action)
 		}
@@ -1,10 +1,13 @@
 package gitlab
 
 import (
+	"bytes"
+	"net/http"
 	"net/http/httptest"
 	"os"
 	"testing"
 
+	"github.com/leg100/otf/internal/testutils"
 	"github.com/leg100/otf/internal/vcs"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -60,6 +63,48 @@ func TestEventHandler(t *testing.T) {
 				SenderHTMLURL:     "https://github.com/leg100",
 			},
 		},
+		{
+			"merge merge request",
+			"Merge Request Hook",
+			"./testdata/merge_merged.json",
+			&vcs.EventPayload{
+				VCSKind:           vcs.GitlabKind,
+				Type:              vcs.EventTypePull,
+				Action:            vcs.ActionMerged,
+				RepoPath:          "leg100/otf-workspaces",
+				Branch:            "pr-1",
+				DefaultBranch:     "master",
+				CommitSHA:         "eea3783a079cd610b748e406610e78c7ce2f34e6",
+				CommitURL:         "https://gitlab.com/leg100/otf-workspaces/-/commit/eea3783a079cd610b748e406610e78c7ce2f34e6",
+				PullRequestNumber: 1,
+				PullRequestURL:    "https://gitlab.com/leg100/otf-workspaces/-/merge_requests/1",
+				PullRequestTitle:  "Pr 1",
+				SenderUsername:    "leg100",
+				SenderAvatarURL:   "https://secure.gravatar.com/avatar/de3ca65d31c67b63a795b88c677bba5d?s=80&d=identicon",
+				SenderHTMLURL:     "https://github.com/leg100",
+			},
+		},
+		{
+			"close merge request",
+			"Merge Request Hook",
+			"./testdata/merge_closed.json",
+			&vcs.EventPayload{
+				VCSKind:           vcs.GitlabKind,
+				Type:              vcs.EventTypePull,
+				Action:            vcs.ActionDeleted,
+				RepoPath:          "leg100/otf-workspaces",
+				Branch:            "pr-1",
+				DefaultBranch:     "master",
+				CommitSHA:         "eea3783a079cd610b748e406610e78c7ce2f34e6",
+				CommitURL:         "https://gitlab.com/leg100/otf-workspaces/-/commit/eea3783a079cd610b748e406610e78c7ce2f34e6",
+				PullRequestNumber: 1,
+				PullRequestURL:    "https://gitlab.com/leg100/otf-workspaces/-/merge_requests/1",
+				PullRequestTitle:  "Pr 1",
+				SenderUsername:    "leg100",
+				SenderAvatarURL:   "https://secure.gravatar.com/avatar/de3ca65d31c67b63a795b88c677bba5d?s=80&d=identicon",
+				SenderHTMLURL:     "https://github.com/leg100",
+			},
+		},
 		{
 			"update merge request",
 			"Merge Request Hook",
@@ -133,4 +178,53 @@ func TestEventHandler(t *testing.T) {
 			assert.Equal(t, tt.want, got)
 		})
 	}
+
+	t.Run("ignore deleted branch", func(t *testing.T) {
+		r := newTestEventRequest(t, "Push Hook", "./testdata/branch_deleted.json", "")
+
+		_, err := HandleEvent(r, "")
+		assert.ErrorAs(t, err, &vcs.ErrIgnoreEvent{})
+	})
+
+	t.Run("ignore unsupported merge request action", func(t *testing.T) {
+		body := bytes.Replace(testutils.ReadFile(t, "./testdata/merge_opened.json"), []byte(`"action": "open"`), []byte(`"action": "approved"`), 1)
+		r := httptest.NewRequest("POST", "/", bytes.NewReader(body))
+		r.Header.Add("X-Gitlab-Event", "Merge Request Hook")
+		r.Header.Add("X-Gitlab-Instance", "https://github.com")
+
+		_, err := HandleEvent(r, "")
+		assert.ErrorAs(t, err, &vcs.ErrIgnoreEvent{})
+	})
+
+	t.Run("valid token", func(t *testing.T) {
+		r := newTestEventRequest(t, "Push Hook", "./testdata/push.json", "secret")
+
+		_, err := HandleEvent(r, "secret")
+		assert.NoError(t, err)
+	})
+
+	t.Run("invalid token", func(t *testing.T) {
+		r := newTestEventRequest(t, "Push Hook", "./testdata/push.json", "wrong-secret")
+
+		_, err := HandleEvent(r, "secret")
+		assert.Error(t, err)
+	})
+
+	t.Run("missing token", func(t *testing.T) {
+		r := newTestEventRequest(t, "Push Hook", "./testdata/push.json", "")
+
+		_, err := HandleEvent(r, "secret")
+		assert.Error(t, err)
+	})
+}
+
+func newTestEventRequest(t *testing.T, event, path, token string) *http.Request {
+	r := httptest.NewRequest("POST", "/", bytes.NewReader(testutils.ReadFile(t, path)))
+	r.Header.Add("Content-type", "application/json")
+	r.Header.Add("X-Gitlab-Event", event)
+	r.Header.Add("X-Gitlab-Instance", "https://github.com")
+	if token != "" {
+		r.Header.Add("X-Gitlab-Token", token)
+	}
+	return r
 }
@@ -0,0 +1,126 @@
+package gitlab
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+
+	"github.com/leg100/otf/internal"
+	"github.com/leg100/otf/internal/vcs"
+	"github.com/xanzy/go-gitlab"
+)
+
+type (
+	// GroupWebhookOptions are options for creating or updating a group-level
+	// webhook, which receives events from every project in the group and its
+	// subgroups.
+	GroupWebhookOptions struct {
+		Group    string // group identifier, i.e. its full path
+		Secret   string // secret token sent with each event
+		Endpoint string // otf's URL that receives events
+		Events   []vcs.EventType
+	}
+
+	// GetGroupWebhookOptions are options for retrieving or deleting a
+	// group-level webhook.
+	GetGroupWebhookOptions struct {
+		Group string // group identifier, i.e. its full path
+		ID    string // gitlab's webhook ID
+	}
+)
+
+// CreateGroupWebhook creates a webhook on a group, returning gitlab's ID for
+// the webhook. A single group webhook receives events from every project in
+// the group and its subgroups, in place of a webhook on each project.
+//
+// NOTE: group webhooks require a paid gitlab tier.
+func (g *Client) CreateGroupWebhook(ctx context.Context, opts GroupWebhookOptions) (string, error) {
+	addOpts := &gitlab.AddGroupHookOptions{
+		EnableSSLVerification: internal.Bool(true),
+		PushEvents:            internal.Bool(true),
+		Token:                 internal.String(opts.Secret),
+		URL:                   internal.String(opts.Endpoint),
+	}
+	for _, event := range opts.Events {
+		switch event {
+		case vcs.EventTypePush:
+			addOpts.PushEvents = internal.Bool(true)
+		case vcs.EventTypePull:
+			addOpts.MergeRequestsEvents = internal.Bool(true)
+		}
+	}
+
+	hook, _, err := g.client.Groups.AddGroupHook(opts.Group, addOpts, gitlab.WithContext(ctx))
+	if err != nil {
+		return "", err
+	}
+	return strconv.Itoa(hook.ID), nil
+}
+
+// UpdateGroupWebhook updates a webhook on a group.
+func (g *Client) UpdateGroupWebhook(ctx context.Context, id string, opts GroupWebhookOptions) error {
+	intID, err := strconv.Atoi(id)
+	if err != nil {
+		return err
+	}
+
+	editOpts := &gitlab.EditGroupHookOptions{
+		EnableSSLVerification: internal.Bool(true),
+		Token:                 internal.String(opts.Secret),
+		URL:                   internal.String(opts.Endpoint),
+	}
+	for _, event := range opts.Events {
+		switch event {
+		case vcs.EventTypePush:
+			editOpts.PushEvents = internal.Bool(true)
+		case vcs.EventTypePull:
+			editOpts.MergeRequestsEvents = internal.Bool(true)
+		}
+	}
+
+	_, _, err = g.client.Groups.EditGroupHook(opts.Group, intID, editOpts, gitlab.WithContext(ctx))
+	return err
+}
+
+// GetGroupWebhook retrieves a webhook on a group. The webhook's Repo is set to
+// the group.
+func (g *Client) GetGroupWebhook(ctx context.Context, opts GetGroupWebhookOptions) (vcs.Webhook, error) {
+	id, err := strconv.Atoi(opts.ID)
+	if err != nil {
+		return vcs.Webhook{}, err
+	}
+
+	hook, resp, err := g.client.Groups.GetGroupHook(opts.Group, id, gitlab.WithContext(ctx))
+	if err != nil {
+		if resp != nil && resp.StatusCode == http.StatusNotFound {
+			return vcs.Webhook{}, internal.ErrResourceNotFound
+		}
+		return vcs.Webhook{}, err
+	}
+
+	var events []vcs.EventType
+	if hook.PushEvents {
+		events = append(events, vcs.EventTypePush)
+	}
+	if hook.MergeRequestsEvents {
+		events = append(events, vcs.EventTypePull)
+	}
+
+	return vcs.Webhook{
+		ID:       strconv.Itoa(id),
+		Repo:     opts.Group,
+		Events:   events,
+		Endpoint: hook.URL,
+	}, nil
+}
+
+// DeleteGroupWebhook deletes a webhook on a group.
+func (g *Client) DeleteGroupWebhook(ctx context.Context, opts GetGroupWebhookOptions) error {
+	id, err := strconv.Atoi(opts.ID)
+	if err != nil {
+		return err
+	}
+
+	_, err = g.client.Groups.DeleteGroupHook(opts.Group, id, gitlab.WithContext(ctx))
+	return err
+}
@@ -0,0 +1,86 @@
+package gitlab
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/leg100/otf/internal"
+	"github.com/leg100/otf/internal/vcs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_GroupWebhook(t *testing.T) {
+	mux, client := setup(t)
+	ctx := context.Background()
+
+	type groupHook struct {
+		ID                  int    `json:"id"`
+		URL                 string `json:"url"`
+		Token               string `json:"token,omitempty"`
+		PushEvents          bool   `json:"push_events"`
+		MergeRequestsEvents bool   `json:"merge_requests_events"`
+	}
+	var hook groupHook
+	mux.HandleFunc("/api/v4/groups/acme/infra/hooks", func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "POST", r.Method)
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&hook))
+		hook.ID = 123
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(hook)
+	})
+	mux.HandleFunc("/api/v4/groups/acme/infra/hooks/123", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case "GET":
+			json.NewEncoder(w).Encode(hook)
+		case "PUT":
+			var update groupHook
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&update))
+			assert.Equal(t, "new-secret", update.Token)
+			json.NewEncoder(w).Encode(hook)
+		case "DELETE":
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			t.Fatalf("unexpected method: %s", r.Method)
+		}
+	})
+
+	id, err := client.CreateGroupWebhook(ctx, GroupWebhookOptions{
+		Group:    "acme/infra",
+		Secret:   "me-secret",
+		Endpoint: "https://otf.dev/webhooks/vcs/123",
+		Events:   []vcs.EventType{vcs.EventTypePush, vcs.EventTypePull},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "123", id)
+	assert.Equal(t, "me-secret", hook.Token)
+	assert.True(t, hook.PushEvents)
+	assert.True(t, hook.MergeRequestsEvents)
+
+	got, err := client.GetGroupWebhook(ctx, GetGroupWebhookOptions{Group: "acme/infra", ID: id})
+	require.NoError(t, err)
+	assert.Equal(t, vcs.Webhook{
+		ID:       "123",
+		Repo:     "acme/infra",
+		Events:   []vcs.EventType{vcs.EventTypePush, vcs.EventTypePull},
+		Endpoint: "https://otf.dev/webhooks/vcs/123",
+	}, got)
+
+	err = client.UpdateGroupWebhook(ctx, id, GroupWebhookOptions{
+		Group:    "acme/infra",
+		Secret:   "new-secret",
+		Endpoint: "https://otf.dev/webhooks/vcs/123",
+		Events:   []vcs.EventType{vcs.EventTypePush, vcs.EventTypePull},
+	})
+	require.NoError(t, err)
+
+	err = client.DeleteGroupWebhook(ctx, GetGroupWebhookOptions{Group: "acme/infra", ID: id})
+	require.NoError(t, err)
+
+	t.Run("not found", func(t *testing.T) {
+		_, err := client.GetGroupWebhook(ctx, GetGroupWebhookOptions{Group: "acme/infra", ID: "456"})
+		assert.ErrorIs(t, err, internal.ErrResourceNotFound)
+	})
+}
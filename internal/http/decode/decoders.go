@@ -5,6 +5,8 @@ import (
 	"errors"
 	"net/http"
 	"net/url"
+	"reflect"
+	"time"
 
 	"github.com/gorilla/mux"
 	"github.com/gorilla/schema"
@@ -19,6 +21,7 @@ func init() {
 	// Don't error if there are keys in the source map that are not present in
 	// the destination struct.
 	decoder.IgnoreUnknownKeys(true)
+	decoder.RegisterConverter(time.Time{}, convertTime)
 }
 
 func RegisterConverter(v any, fn schema.Converter) {
@@ -93,13 +96,14 @@ func Param(name string, r *http.Request) (string, error) {
 }
 
 func decode(dst interface{}, src map[string][]string) error {
-	if err := decoder.Decode(dst, src); err != nil {
-		var emptyField schema.EmptyFieldError
-		if errors.As(err, &emptyField) {
-			return &internal.MissingParameterError{Parameter: emptyField.Key}
+	if err := decoder.Decode(dst, expandNestedKeys(src)); err != nil {
+		var multi schema.MultiError
+		if errors.As(err, &multi) {
+			return fromMultiError(multi)
 		}
 		return err
 	}
+	splitCommaSeparated(reflect.ValueOf(dst))
 	return nil
 }
 
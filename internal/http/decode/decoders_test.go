@@ -0,0 +1,108 @@
+package decode
+
+import (
+	"errors"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/leg100/otf/internal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testColor string
+
+func init() {
+	RegisterEnum[testColor]("red", "green")
+}
+
+func TestQuery(t *testing.T) {
+	type (
+		workspaceFilter struct {
+			Name string   `schema:"name"`
+			Tags []string `schema:"tags"`
+		}
+		filter struct {
+			Workspace workspaceFilter `schema:"workspace"`
+		}
+		params struct {
+			Filter     filter      `schema:"filter"`
+			PageNumber int         `schema:"page[number]"`
+			Since      *time.Time  `schema:"since"`
+			Color      testColor   `schema:"color"`
+			Colors     []testColor `schema:"colors"`
+			Include    []string    `schema:"include"`
+		}
+	)
+
+	t.Run("decode", func(t *testing.T) {
+		var got params
+		err := Query(&got, url.Values{
+			"filter[workspace][name]": {"dev"},
+			"filter[workspace][tags]": {"foo,bar", "baz"},
+			"page[number]":            {"2"},
+			"since":                   {"2023-11-24T10:00:00Z"},
+			"color":                   {"red"},
+			"colors":                  {"red,green"},
+			"include":                 {"workspace,created_by"},
+		})
+		require.NoError(t, err)
+
+		want := params{
+			Filter: filter{Workspace: workspaceFilter{
+				Name: "dev",
+				Tags: []string{"foo", "bar", "baz"},
+			}},
+			PageNumber: 2,
+			Since:      internal.Time(time.Date(2023, 11, 24, 10, 0, 0, 0, time.UTC)),
+			Color:      "red",
+			Colors:     []testColor{"red", "green"},
+			Include:    []string{"workspace", "created_by"},
+		}
+		assert.Equal(t, want, got)
+	})
+
+	t.Run("date", func(t *testing.T) {
+		var got params
+		err := Query(&got, url.Values{"since": {"2023-11-24"}})
+		require.NoError(t, err)
+		assert.Equal(t, time.Date(2023, 11, 24, 0, 0, 0, 0, time.UTC), *got.Since)
+	})
+
+	tests := []struct {
+		name      string
+		query     url.Values
+		parameter string
+		want      string
+	}{
+		{"invalid enum", url.Values{"color": {"blue"}}, "color", "invalid value for parameter color: must be one of: red, green"},
+		{"invalid enum in list", url.Values{"colors": {"red,blue"}}, "colors", "invalid value for parameter colors: must be one of: red, green"},
+		{"invalid time", url.Values{"since": {"yesterday"}}, "since", "invalid value for parameter since: must be an RFC3339 timestamp or a date of the form YYYY-MM-DD"},
+		{"invalid integer", url.Values{"page[number]": {"two"}}, "page[number]", "invalid value for parameter page[number]: must be of type int"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got params
+			err := Query(&got, tt.query)
+			var invalid *internal.InvalidParameterError
+			require.True(t, errors.As(err, &invalid), "got error: %v", err)
+			assert.Equal(t, tt.parameter, invalid.Parameter)
+			assert.Equal(t, tt.want, err.Error())
+		})
+	}
+
+	t.Run("missing nested parameter", func(t *testing.T) {
+		var got struct {
+			Filter struct {
+				Organization struct {
+					Name string `schema:"name,required"`
+				} `schema:"organization"`
+			} `schema:"filter"`
+		}
+		err := Query(&got, url.Values{})
+		var missing *internal.MissingParameterError
+		require.True(t, errors.As(err, &missing), "got error: %v", err)
+		assert.Equal(t, "filter[organization][name]", missing.Parameter)
+	})
+}
@@ -0,0 +1,173 @@
+package decode
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/gorilla/schema"
+	"github.com/leg100/otf/internal"
+)
+
+var (
+	// nestedKey matches a key using nested bracket syntax, e.g.
+	// filter[workspace][tags]
+	nestedKey = regexp.MustCompile(`^([^\[\].]+)((?:\[[^\[\].]+\])+)$`)
+
+	// enums maps a type to its permitted values
+	enums = make(map[reflect.Type][]string)
+
+	timeType = reflect.TypeOf(time.Time{})
+)
+
+// RegisterEnum registers the permitted values of a string type. Decoding a
+// parameter into a field of the type fails with an error naming the parameter
+// if its value is not one of the permitted values. An empty value is decoded
+// as the zero value.
+func RegisterEnum[T ~string](values ...T) {
+	permitted := make([]string, len(values))
+	for i, v := range values {
+		permitted[i] = string(v)
+	}
+	var zero T
+	enums[reflect.TypeOf(zero)] = permitted
+	decoder.RegisterConverter(zero, func(s string) reflect.Value {
+		if s != "" && !slices.Contains(permitted, s) {
+			return reflect.Value{}
+		}
+		return reflect.ValueOf(T(s))
+	})
+}
+
+// convertTime converts either an RFC3339 timestamp or a date into a time.Time.
+func convertTime(s string) reflect.Value {
+	for _, layout := range []string{time.RFC3339, time.DateOnly} {
+		if t, err := time.Parse(layout, s); err == nil {
+			return reflect.ValueOf(t)
+		}
+	}
+	return reflect.Value{}
+}
+
+// expandNestedKeys adds an alias in dotted notation for each key using nested
+// bracket syntax, e.g. filter.workspace.tags for filter[workspace][tags],
+// permitting the parameter to be decoded into a field of a nested struct. The
+// original key is retained for fields whose tag uses bracket syntax, e.g.
+// `schema:"page[number]"`.
+func expandNestedKeys(src map[string][]string) map[string][]string {
+	expanded := make(map[string][]string, len(src))
+	for k, v := range src {
+		expanded[k] = v
+		if dotted, ok := dottedKey(k); ok {
+			if _, exists := src[dotted]; !exists {
+				expanded[dotted] = v
+			}
+		}
+	}
+	return expanded
+}
+
+func dottedKey(key string) (string, bool) {
+	matches := nestedKey.FindStringSubmatch(key)
+	if matches == nil {
+		return "", false
+	}
+	nested := strings.Split(strings.Trim(matches[2], "[]"), "][")
+	return matches[1] + "." + strings.Join(nested, "."), true
+}
+
+// bracketedKey converts a key in dotted notation back into nested bracket
+// syntax, the form in which clients provide parameters.
+func bracketedKey(key string) string {
+	parts := strings.Split(key, ".")
+	if len(parts) == 1 || strings.ContainsAny(key, "[]") {
+		return key
+	}
+	return parts[0] + "[" + strings.Join(parts[1:], "][") + "]"
+}
+
+// fromMultiError converts the errors reported by the schema decoder into
+// errors naming the offending parameter. Where there is more than one such
+// error, the error for the parameter first in alphabetical order is returned.
+func fromMultiError(multi schema.MultiError) error {
+	keys := make([]string, 0, len(multi))
+	for k := range multi {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		switch err := multi[k].(type) {
+		case schema.EmptyFieldError:
+			return &internal.MissingParameterError{Parameter: bracketedKey(err.Key)}
+		case schema.ConversionError:
+			return &internal.InvalidParameterError{
+				Parameter: bracketedKey(err.Key),
+				Err:       conversionReason(err),
+			}
+		}
+	}
+	return multi
+}
+
+func conversionReason(err schema.ConversionError) error {
+	if err.Err != nil {
+		return err.Err
+	}
+	typ := err.Type
+	for typ.Kind() == reflect.Pointer || typ.Kind() == reflect.Slice {
+		typ = typ.Elem()
+	}
+	if values, ok := enums[typ]; ok {
+		return fmt.Errorf("must be one of: %s", strings.Join(values, ", "))
+	}
+	if typ == timeType {
+		return errors.New("must be an RFC3339 timestamp or a date of the form YYYY-MM-DD")
+	}
+	return fmt.Errorf("must be of type %s", typ.Kind())
+}
+
+// splitCommaSeparated splits comma-separated values decoded into string
+// slices, permitting a list to be provided either as repeated parameters, e.g.
+// include=a&include=b, or as a single parameter, e.g. include=a,b. The schema
+// decoder already splits values decoded into slices of other types.
+func splitCommaSeparated(v reflect.Value) {
+	switch v.Kind() {
+	case reflect.Pointer:
+		if !v.IsNil() {
+			splitCommaSeparated(v.Elem())
+		}
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			field := v.Type().Field(i)
+			if !field.IsExported() || field.Tag.Get("schema") == "-" {
+				continue
+			}
+			splitCommaSeparated(v.Field(i))
+		}
+	case reflect.Slice:
+		if v.Type().Elem().Kind() != reflect.String {
+			return
+		}
+		var commas bool
+		for i := 0; i < v.Len(); i++ {
+			commas = commas || strings.Contains(v.Index(i).String(), ",")
+		}
+		if !commas {
+			return
+		}
+		split := reflect.MakeSlice(v.Type(), 0, v.Len())
+		for i := 0; i < v.Len(); i++ {
+			for _, s := range strings.Split(v.Index(i).String(), ",") {
+				if s = strings.TrimSpace(s); s != "" {
+					split = reflect.Append(split, reflect.ValueOf(s).Convert(v.Type().Elem()))
+				}
+			}
+		}
+		v.Set(split)
+	}
+}
@@ -27,6 +27,8 @@ func init() {
 
 	funcmap["createTokenPath"] = CreateToken
 
+	funcmap["bannersPath"] = Banners
+
 	funcmap["githubAppsPath"] = GithubApps
 	funcmap["createGithubAppPath"] = CreateGithubApp
 	funcmap["newGithubAppPath"] = NewGithubApp
@@ -81,6 +83,8 @@ func init() {
 	funcmap["cancelRunPath"] = CancelRun
 	funcmap["forceCancelRunPath"] = ForceCancelRun
 	funcmap["retryRunPath"] = RetryRun
+	funcmap["retryApplyRunPath"] = RetryApplyRun
+	funcmap["replanRunPath"] = ReplanRun
 	funcmap["tailRunPath"] = TailRun
 	funcmap["widgetRunPath"] = WidgetRun
 
@@ -142,6 +142,10 @@ var specs = []controllerSpec{
 		controllerType: singlePath,
 		path:           "/profile/tokens/create",
 	},
+	{
+		Name:           "banners",
+		controllerType: singlePath,
+	},
 	{
 		Name:           "github_app",
 		controllerType: resourcePath,
@@ -233,6 +237,12 @@ var specs = []controllerSpec{
 							{
 								name: "retry",
 							},
+							{
+								name: "retry-apply",
+							},
+							{
+								name: "replan",
+							},
 							{
 								name: "tail",
 							},
@@ -52,6 +52,14 @@ func RetryRun(run string) string {
 	return fmt.Sprintf("/app/runs/%s/retry", run)
 }
 
+func RetryApplyRun(run string) string {
+	return fmt.Sprintf("/app/runs/%s/retry-apply", run)
+}
+
+func ReplanRun(run string) string {
+	return fmt.Sprintf("/app/runs/%s/replan", run)
+}
+
 func TailRun(run string) string {
 	return fmt.Sprintf("/app/runs/%s/tail", run)
 }
@@ -24,6 +24,7 @@ const (
 	// before shutdown.
 	shutdownTimeout     = 1 * time.Second
 	headersKey      key = "headers"
+	clientIPKey     key = "client-ip"
 )
 
 var (
@@ -71,6 +72,21 @@ func NewServer(logger logr.Logger, cfg ServerConfig) (*Server, error) {
 		}
 	}
 
+	handler, err := NewHandler(logger, cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Server{
+		Logger:       logger,
+		ServerConfig: cfg,
+		server:       &http.Server{Handler: handler},
+	}, nil
+}
+
+// NewHandler constructs the http handler for OTF, serving the API, the web
+// app, and static files. SSL settings in the config are ignored.
+func NewHandler(logger logr.Logger, cfg ServerConfig) (http.Handler, error) {
 	r := mux.NewRouter()
 
 	// Catch panics and return 500s
@@ -98,14 +114,22 @@ func NewServer(logger logr.Logger, cfg ServerConfig) (*Server, error) {
 	// Subrouter for service routes
 	svcRouter := r.NewRoute().Subrouter()
 
-	// this middleware adds http headers from the request to the context
+	// this middleware adds http headers and the client's IP address from the
+	// request to the context
 	r.Use(func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			ctx := context.WithValue(r.Context(), headersKey, r.Header)
+			if ip, err := GetClientIP(r); err == nil {
+				ctx = context.WithValue(ctx, clientIPKey, ip)
+			}
 			next.ServeHTTP(w, r.WithContext(ctx))
 		})
 	})
 
+	// this middleware relays warnings to the client, e.g. warning of
+	// deprecated behaviour
+	r.Use(warningsMiddleware)
+
 	// Subject service routes to provided middleware, verifying tokens,
 	// sessions.
 	svcRouter.Use(cfg.Middleware...)
@@ -129,11 +153,7 @@ func NewServer(logger logr.Logger, cfg ServerConfig) (*Server, error) {
 		})
 	}
 
-	return &Server{
-		Logger:       logger,
-		ServerConfig: cfg,
-		server:       &http.Server{Handler: r},
-	}, nil
+	return r, nil
 }
 
 // Start starts serving http traffic on the given listener and waits until the server exits due to
@@ -178,3 +198,10 @@ func HeadersFromContext(ctx context.Context) (http.Header, error) {
 	}
 	return headers, nil
 }
+
+// ClientIPFromContext returns the IP address of the client making the request
+// in the context, or an empty string if not known.
+func ClientIPFromContext(ctx context.Context) string {
+	ip, _ := ctx.Value(clientIPKey).(string)
+	return ip
+}
@@ -0,0 +1,87 @@
+package http
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/felixge/httpsnoop"
+	"github.com/leg100/otf/internal"
+)
+
+const (
+	// WarningHeader is the HTTP header in which warnings are relayed to
+	// clients.
+	WarningHeader = "Warning"
+
+	// warnCodePrefix prefixes a warning header value with the warn-code
+	// for a miscellaneous persistent warning, and an anonymous warn-agent,
+	// as per RFC 7234.
+	warnCodePrefix = `299 - `
+)
+
+var warningEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`)
+
+// FormatWarning formats a warning message as a warning header value.
+func FormatWarning(msg string) string {
+	return warnCodePrefix + `"` + warningEscaper.Replace(msg) + `"`
+}
+
+// ParseWarning parses a warning message from a warning header value. False is
+// returned if the value is not a warning formatted by FormatWarning.
+func ParseWarning(value string) (string, bool) {
+	quoted, ok := strings.CutPrefix(value, warnCodePrefix)
+	if !ok || len(quoted) < 2 || quoted[0] != '"' || quoted[len(quoted)-1] != '"' {
+		return "", false
+	}
+	quoted = quoted[1 : len(quoted)-1]
+
+	var (
+		msg     strings.Builder
+		escaped bool
+	)
+	for _, c := range quoted {
+		if c == '\\' && !escaped {
+			escaped = true
+			continue
+		}
+		escaped = false
+		msg.WriteRune(c)
+	}
+	return msg.String(), true
+}
+
+// warningsMiddleware adds a collector of warnings to the request context, and
+// relays any warnings collected whilst handling the request to the client in
+// warning headers.
+func warningsMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := internal.AddWarningsToContext(r.Context())
+
+		// headers must be added before the response status is written
+		var written bool
+		addHeaders := func() {
+			if written {
+				return
+			}
+			written = true
+			for _, msg := range internal.WarningsFromContext(ctx) {
+				w.Header().Add(WarningHeader, FormatWarning(msg))
+			}
+		}
+		w = httpsnoop.Wrap(w, httpsnoop.Hooks{
+			WriteHeader: func(next httpsnoop.WriteHeaderFunc) httpsnoop.WriteHeaderFunc {
+				return func(code int) {
+					addHeaders()
+					next(code)
+				}
+			},
+			Write: func(next httpsnoop.WriteFunc) httpsnoop.WriteFunc {
+				return func(b []byte) (int, error) {
+					addHeaders()
+					return next(b)
+				}
+			},
+		})
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
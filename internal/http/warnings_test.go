@@ -0,0 +1,41 @@
+package http
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/leg100/otf/internal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWarnings(t *testing.T) {
+	t.Run("format and parse", func(t *testing.T) {
+		msg := `token "ci" expires in 1 day \ renew it`
+
+		got, ok := ParseWarning(FormatWarning(msg))
+		assert.True(t, ok)
+		assert.Equal(t, msg, got)
+	})
+
+	t.Run("parse invalid warning", func(t *testing.T) {
+		_, ok := ParseWarning(`110 anderson/1.3.37 "Response is stale"`)
+		assert.False(t, ok)
+	})
+
+	t.Run("middleware", func(t *testing.T) {
+		handler := warningsMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			internal.AddWarning(r.Context(), "warning %d", 1)
+			internal.AddWarning(r.Context(), "warning %d", 2)
+			internal.AddWarning(r.Context(), "warning %d", 1)
+			w.WriteHeader(http.StatusNoContent)
+		}))
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, httptest.NewRequest("GET", "/", nil))
+
+		assert.Equal(t, []string{
+			`299 - "warning 1"`,
+			`299 - "warning 2"`,
+		}, w.Header().Values(WarningHeader))
+	})
+}
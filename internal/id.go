@@ -3,7 +3,6 @@ package internal
 import (
 	"reflect"
 	"regexp"
-	"strings"
 )
 
 // ReStringID is a regular expression used to validate common string ID patterns.
@@ -39,14 +38,3 @@ func NewID(rtype string) string {
 func ValidStringID(v *string) bool {
 	return v != nil && ReStringID.MatchString(*v)
 }
-
-// ConvertID converts an ID for use with a different resource, e.g. convert
-// run-123 to plan-123.
-func ConvertID(id, resource string) string {
-	parts := strings.Split(id, "-")
-	// if ID not in expected form then just return it unchanged without error
-	if len(parts) != 2 {
-		return id
-	}
-	return resource + "-" + parts[1]
-}
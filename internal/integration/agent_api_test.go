@@ -0,0 +1,85 @@
+package integration
+
+import (
+	"testing"
+
+	tfe "github.com/hashicorp/go-tfe"
+	"github.com/leg100/otf/internal"
+	agentpkg "github.com/leg100/otf/internal/agent"
+	"github.com/leg100/otf/internal/workspace"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestIntegration_AgentAPI tests the agents API endpoints, and the assignment
+// of workspaces to agent pools via the API.
+func TestIntegration_AgentAPI(t *testing.T) {
+	integrationTest(t)
+
+	daemon, org, ctx := setup(t, nil)
+	_, token := daemon.createToken(t, ctx, nil)
+
+	tfeClient, err := tfe.NewClient(&tfe.Config{
+		Address:           "https://" + daemon.System.Hostname(),
+		Token:             string(token),
+		RetryServerErrors: true,
+	})
+	require.NoError(t, err)
+
+	pool, err := daemon.Agents.CreateAgentPool(ctx, agentpkg.CreateAgentPoolOptions{
+		Name:         "pool-1",
+		Organization: org.Name,
+	})
+	require.NoError(t, err)
+
+	// start agent up, which registers itself with the pool
+	agent, shutdown := daemon.startAgent(t, ctx, org.Name, pool.ID, "", agentpkg.Config{})
+	defer shutdown()
+
+	t.Run("list agents", func(t *testing.T) {
+		got, err := tfeClient.Agents.List(ctx, pool.ID, nil)
+		require.NoError(t, err)
+
+		require.Equal(t, 1, len(got.Items))
+		assert.Equal(t, agent.ID, got.Items[0].ID)
+		assert.Equal(t, "idle", got.Items[0].Status)
+	})
+
+	t.Run("get agent", func(t *testing.T) {
+		got, err := tfeClient.Agents.Read(ctx, agent.ID)
+		require.NoError(t, err)
+
+		assert.Equal(t, agent.ID, got.ID)
+	})
+
+	t.Run("get pool includes agent count", func(t *testing.T) {
+		got, err := tfeClient.AgentPools.Read(ctx, pool.ID)
+		require.NoError(t, err)
+
+		assert.Equal(t, 1, got.AgentCount)
+	})
+
+	t.Run("assign workspace to pool", func(t *testing.T) {
+		ws, err := daemon.Workspaces.Create(ctx, workspace.CreateOptions{
+			Name:         internal.String("ws-1"),
+			Organization: internal.String(org.Name),
+		})
+		require.NoError(t, err)
+
+		_, err = tfeClient.AgentPools.Update(ctx, pool.ID, tfe.AgentPoolUpdateOptions{
+			OrganizationScoped: tfe.Bool(false),
+			AllowedWorkspaces:  []*tfe.Workspace{{ID: ws.ID}},
+		})
+		require.NoError(t, err)
+
+		got, err := tfeClient.Workspaces.UpdateByID(ctx, ws.ID, tfe.WorkspaceUpdateOptions{
+			ExecutionMode: tfe.String("agent"),
+			AgentPoolID:   tfe.String(pool.ID),
+		})
+		require.NoError(t, err)
+
+		assert.Equal(t, "agent", got.ExecutionMode)
+		require.NotNil(t, got.AgentPool)
+		assert.Equal(t, pool.ID, got.AgentPool.ID)
+	})
+}
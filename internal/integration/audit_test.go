@@ -0,0 +1,68 @@
+package integration
+
+import (
+	"testing"
+	"time"
+
+	"github.com/leg100/otf/internal"
+	"github.com/leg100/otf/internal/audit"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIntegration_Audit(t *testing.T) {
+	integrationTest(t)
+
+	svc, org, ctx := setup(t, nil)
+	ws := svc.createWorkspace(t, ctx, org)
+	sv := svc.createStateVersion(t, ctx, ws)
+
+	t.Run("record state download", func(t *testing.T) {
+		_, err := svc.State.Download(ctx, sv.ID)
+		require.NoError(t, err)
+
+		action := audit.StateDownloadAction
+		got, err := svc.Audit.List(ctx, org.Name, audit.ListOptions{Action: &action})
+		require.NoError(t, err)
+		require.Equal(t, 1, len(got.Items))
+		assert.Equal(t, sv.ID, got.Items[0].ResourceID)
+		assert.Nil(t, got.Items[0].Justification)
+	})
+
+	t.Run("record configuration download via signed URL", func(t *testing.T) {
+		cv := svc.createAndUploadConfigurationVersion(t, ctx, ws, nil)
+
+		_, err := svc.Configs.SignedDownloadURL(ctx, cv.ID, time.Minute)
+		require.NoError(t, err)
+
+		// recorded on behalf of the subject to whom the URL was issued
+		action := audit.ConfigurationDownloadAction
+		got, err := svc.Audit.List(ctx, org.Name, audit.ListOptions{Action: &action})
+		require.NoError(t, err)
+		require.Equal(t, 1, len(got.Items))
+		assert.Equal(t, cv.ID, got.Items[0].ResourceID)
+		subject, err := internal.SubjectFromContext(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, subject.String(), got.Items[0].Subject)
+	})
+
+	t.Run("require justification", func(t *testing.T) {
+		_, err := svc.Audit.UpdateSettings(ctx, org.Name, audit.Settings{
+			RequireStateDownloadJustification: true,
+		})
+		require.NoError(t, err)
+
+		_, err = svc.State.Download(ctx, sv.ID)
+		assert.ErrorIs(t, err, audit.ErrJustificationRequired)
+
+		_, err = svc.State.Download(audit.AddJustificationToContext(ctx, "INC-123"), sv.ID)
+		require.NoError(t, err)
+
+		action := audit.StateDownloadAction
+		got, err := svc.Audit.List(ctx, org.Name, audit.ListOptions{Action: &action})
+		require.NoError(t, err)
+		require.Equal(t, 2, len(got.Items))
+		// most recent first
+		assert.Equal(t, "INC-123", *got.Items[0].Justification)
+	})
+}
@@ -0,0 +1,70 @@
+package integration
+
+import (
+	"testing"
+	"time"
+
+	"github.com/leg100/otf/internal"
+	"github.com/leg100/otf/internal/banner"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIntegration_Banners(t *testing.T) {
+	integrationTest(t)
+
+	svc, org, ctx := setup(t, nil)
+
+	siteWide, err := svc.Banners.Create(adminCtx, banner.CreateOptions{
+		Message: "upgrade tonight",
+	})
+	require.NoError(t, err)
+	targeted, err := svc.Banners.Create(adminCtx, banner.CreateOptions{
+		Message:       "your organization is over quota",
+		Level:         banner.LevelCritical,
+		Organizations: []string{org.Name},
+	})
+	require.NoError(t, err)
+	_, err = svc.Banners.Create(adminCtx, banner.CreateOptions{
+		Message:  "upcoming",
+		StartsAt: internal.Time(time.Now().Add(time.Hour)),
+	})
+	require.NoError(t, err)
+
+	t.Run("list all", func(t *testing.T) {
+		got, err := svc.Banners.List(adminCtx)
+		require.NoError(t, err)
+		assert.Len(t, got, 3)
+	})
+
+	t.Run("list active for organization", func(t *testing.T) {
+		got, err := svc.Banners.ListActive(ctx, org.Name)
+		require.NoError(t, err)
+		if assert.Len(t, got, 2) {
+			assert.Equal(t, targeted.ID, got[0].ID)
+			assert.Equal(t, siteWide.ID, got[1].ID)
+		}
+	})
+
+	t.Run("list active outside of organization", func(t *testing.T) {
+		got, err := svc.Banners.ListActive(ctx, "")
+		require.NoError(t, err)
+		if assert.Len(t, got, 1) {
+			assert.Equal(t, siteWide.ID, got[0].ID)
+		}
+	})
+
+	t.Run("non-admin cannot create banner", func(t *testing.T) {
+		_, err := svc.Banners.Create(ctx, banner.CreateOptions{Message: "hello"})
+		assert.ErrorIs(t, err, internal.ErrAccessNotPermitted)
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		err := svc.Banners.Delete(adminCtx, siteWide.ID)
+		require.NoError(t, err)
+
+		got, err := svc.Banners.ListActive(ctx, "")
+		require.NoError(t, err)
+		assert.Len(t, got, 0)
+	})
+}
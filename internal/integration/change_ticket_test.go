@@ -0,0 +1,122 @@
+package integration
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha512"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/leg100/otf/internal/changeticket"
+	"github.com/leg100/otf/internal/daemon"
+	"github.com/leg100/otf/internal/run"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestIntegration_ChangeTicket demonstrates a run awaiting confirmation being
+// applied once its change ticket is approved.
+func TestIntegration_ChangeTicket(t *testing.T) {
+	integrationTest(t)
+
+	// adapter is a change ticket adapter that approves each ticket it is
+	// asked to open, after verifying the request's signature.
+	adapter := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+
+		mac := hmac.New(sha512.New, []byte("secret"))
+		mac.Write(body)
+		assert.Equal(t, hex.EncodeToString(mac.Sum(nil)), r.Header.Get("X-OTF-Change-Ticket-Signature"))
+
+		var payload struct {
+			AccessToken string      `json:"access_token"`
+			CallbackURL string      `json:"callback_url"`
+			PlanSummary *run.Report `json:"plan_summary"`
+		}
+		require.NoError(t, json.Unmarshal(body, &payload))
+		assert.NotNil(t, payload.PlanSummary)
+		w.WriteHeader(http.StatusOK)
+
+		// approve ticket
+		go func() {
+			callback := []byte(`{"status":"approved","external_id":"CHG0031337","message":"approved by CAB"}`)
+			req, err := http.NewRequest("PATCH", payload.CallbackURL, bytes.NewReader(callback))
+			require.NoError(t, err)
+			req.Header.Set("Authorization", "Bearer "+payload.AccessToken)
+			req.Header.Set("Content-Type", "application/json")
+			resp, err := http.DefaultClient.Do(req)
+			require.NoError(t, err)
+			resp.Body.Close()
+			assert.Equal(t, http.StatusOK, resp.StatusCode)
+		}()
+	}))
+	t.Cleanup(adapter.Close)
+
+	daemon, org, ctx := setup(t, &config{Config: daemon.Config{
+		ChangeTickets: changeticket.Config{
+			WebhookURL: adapter.URL,
+			HMACKey:    "secret",
+		},
+	}})
+	ws := daemon.createWorkspace(t, ctx, org)
+
+	sub, unsub := daemon.Runs.Watch(ctx)
+	defer unsub()
+
+	cv := daemon.createAndUploadConfigurationVersion(t, ctx, ws, nil)
+	r := daemon.createRun(t, ctx, ws, cv)
+
+	// wait for run to be applied
+	func() {
+		for event := range sub {
+			if got := event.Payload; got.ID == r.ID {
+				if got.Status == run.RunApplied {
+					return
+				}
+				require.False(t, got.Done(), "run unexpectedly finished with status %s", got.Status)
+			}
+		}
+		t.Fatal("run events stream closed unexpectedly")
+	}()
+
+	ticket, err := daemon.ChangeTickets.Get(ctx, r.ID)
+	require.NoError(t, err)
+	assert.Equal(t, changeticket.TicketApproved, ticket.Status)
+	assert.Equal(t, "CHG0031337", ticket.ExternalID)
+	assert.Equal(t, "approved by CAB", ticket.Message)
+}
+
+// TestIntegration_ChangeTicketErrored demonstrates a run awaiting
+// confirmation when its change ticket cannot be opened.
+func TestIntegration_ChangeTicketErrored(t *testing.T) {
+	integrationTest(t)
+
+	adapter := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	t.Cleanup(adapter.Close)
+
+	daemon, org, ctx := setup(t, &config{Config: daemon.Config{
+		ChangeTickets: changeticket.Config{WebhookURL: adapter.URL},
+	}})
+	ws := daemon.createWorkspace(t, ctx, org)
+
+	cv := daemon.createAndUploadConfigurationVersion(t, ctx, ws, nil)
+	r := daemon.createRun(t, ctx, ws, cv)
+
+	assert.Eventually(t, func() bool {
+		ticket, err := daemon.ChangeTickets.Get(ctx, r.ID)
+		return err == nil && ticket.Status == changeticket.TicketErrored
+	}, time.Minute, time.Second)
+
+	// run still awaits confirmation by a user
+	got, err := daemon.Runs.Get(ctx, r.ID)
+	require.NoError(t, err)
+	assert.Equal(t, run.RunPlanned, got.Status)
+}
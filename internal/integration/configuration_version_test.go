@@ -1,12 +1,18 @@
 package integration
 
 import (
+	"bytes"
+	"fmt"
 	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/leg100/otf/internal"
+	"github.com/leg100/otf/internal/blob"
 	"github.com/leg100/otf/internal/configversion"
+	"github.com/leg100/otf/internal/daemon"
 	"github.com/leg100/otf/internal/resource"
+	"github.com/leg100/otf/internal/workspace"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -35,12 +41,98 @@ func TestConfigurationVersion(t *testing.T) {
 		require.NoError(t, err)
 
 		assert.Equal(t, configversion.ConfigurationUploaded, got.Status)
+		assert.Equal(t, blob.Digest(tarball), got.Digest)
 
 		t.Run("download config", func(t *testing.T) {
 			gotConfig, err := svc.Configs.DownloadConfig(ctx, cv.ID)
 			require.NoError(t, err)
 			assert.Equal(t, tarball, gotConfig)
 		})
+
+		t.Run("list files", func(t *testing.T) {
+			files, err := svc.Configs.ListFiles(ctx, cv.ID)
+			require.NoError(t, err)
+			assert.Equal(t, []configversion.File{{Path: "tardata/afile", Size: 0}}, files)
+		})
+
+		t.Run("upload identical config", func(t *testing.T) {
+			cv2 := svc.createConfigurationVersion(t, ctx, nil, nil)
+			err = svc.Configs.UploadConfig(ctx, cv2.ID, tarball)
+			require.NoError(t, err)
+
+			got2, err := svc.Configs.Get(ctx, cv2.ID)
+			require.NoError(t, err)
+			assert.Equal(t, got.Digest, got2.Digest)
+		})
+	})
+
+	t.Run("resume config upload", func(t *testing.T) {
+		svc, _, ctx := setup(t, nil)
+		cv := svc.createConfigurationVersion(t, ctx, nil, nil)
+		tarball, err := os.ReadFile("./testdata/tarball.tar.gz")
+		require.NoError(t, err)
+		half := int64(len(tarball) / 2)
+
+		size, err := svc.Configs.UploadPart(ctx, cv.ID, 0, bytes.NewReader(tarball[:half]))
+		require.NoError(t, err)
+		assert.Equal(t, half, size)
+
+		size, err = svc.Configs.UploadedSize(ctx, cv.ID)
+		require.NoError(t, err)
+		assert.Equal(t, half, size)
+
+		size, err = svc.Configs.UploadPart(ctx, cv.ID, size, bytes.NewReader(tarball[half:]))
+		require.NoError(t, err)
+		assert.Equal(t, int64(len(tarball)), size)
+
+		err = svc.Configs.CompleteUpload(ctx, cv.ID)
+		require.NoError(t, err)
+
+		gotConfig, err := svc.Configs.DownloadConfig(ctx, cv.ID)
+		require.NoError(t, err)
+		assert.Equal(t, tarball, gotConfig)
+	})
+
+	t.Run("storage quota", func(t *testing.T) {
+		// root.tar.gz is 246 bytes, so only one upload fits within the quota
+		cfg := &config{Config: daemon.Config{MaxConfigSize: 1024, MaxConfigStorage: 400}}
+		svc, _, ctx := setup(t, cfg)
+		ws := svc.createWorkspace(t, ctx, nil)
+
+		got, err := svc.Configs.GetQuota(ctx, ws.ID)
+		require.NoError(t, err)
+		assert.Equal(t, int64(400), got.MaxUploadSize)
+		assert.Equal(t, int64(0), got.StorageUsed)
+
+		svc.createAndUploadConfigurationVersion(t, ctx, ws, nil)
+
+		got, err = svc.Configs.GetQuota(ctx, ws.ID)
+		require.NoError(t, err)
+		assert.Equal(t, int64(154), got.MaxUploadSize)
+		assert.Equal(t, int64(246), got.StorageUsed)
+		assert.Equal(t, int64(154), *got.StorageRemaining)
+
+		tarball, err := os.ReadFile("./testdata/root.tar.gz")
+		require.NoError(t, err)
+		cv := svc.createConfigurationVersion(t, ctx, ws, nil)
+		err = svc.Configs.UploadConfig(ctx, cv.ID, tarball)
+		assert.ErrorIs(t, err, configversion.ErrStorageQuotaExceeded)
+
+		cv, err = svc.Configs.Get(ctx, cv.ID)
+		require.NoError(t, err)
+		assert.Equal(t, configversion.ConfigurationErrored, cv.Status)
+	})
+
+	t.Run("upload invalid config", func(t *testing.T) {
+		svc, _, ctx := setup(t, nil)
+		cv := svc.createConfigurationVersion(t, ctx, nil, nil)
+
+		err := svc.Configs.UploadConfig(ctx, cv.ID, []byte("not a tarball"))
+		assert.ErrorIs(t, err, configversion.ErrInvalidConfig)
+
+		got, err := svc.Configs.Get(ctx, cv.ID)
+		require.NoError(t, err)
+		assert.Equal(t, configversion.ConfigurationErrored, got.Status)
 	})
 
 	t.Run("get", func(t *testing.T) {
@@ -121,4 +213,117 @@ func TestConfigurationVersion(t *testing.T) {
 			})
 		}
 	})
+
+	t.Run("organization usage", func(t *testing.T) {
+		svc, org, ctx := setup(t, nil)
+		ws1 := svc.createWorkspace(t, ctx, org)
+		ws2 := svc.createWorkspace(t, ctx, org)
+		svc.createAndUploadConfigurationVersion(t, ctx, ws1, nil)
+		svc.createAndUploadConfigurationVersion(t, ctx, ws1, &configversion.CreateOptions{
+			Source: configversion.SourceTerraform,
+		})
+		// config not yet uploaded
+		svc.createConfigurationVersion(t, ctx, ws2, nil)
+		// workspace without any configs should be omitted
+		svc.createWorkspace(t, ctx, org)
+
+		tarball, err := os.ReadFile("./testdata/root.tar.gz")
+		require.NoError(t, err)
+		size := int64(len(tarball))
+
+		got, err := svc.Configs.GetOrganizationUsage(ctx, org.Name)
+		require.NoError(t, err)
+
+		assert.Equal(t, int64(3), got.Count)
+		assert.Equal(t, 2*size, got.Bytes)
+		assert.Equal(t, map[string]configversion.SourceUsage{
+			configversion.UsageCategoryAPI: {Count: 2, Bytes: size},
+			configversion.UsageCategoryCLI: {Count: 1, Bytes: size},
+		}, got.Sources)
+		assert.Equal(t, 2, len(got.Workspaces))
+	})
+
+	t.Run("organization consumption", func(t *testing.T) {
+		svc, org, ctx := setup(t, nil)
+
+		// uploadConfig uploads a config calling the vpc module with the given
+		// version
+		uploadConfig := func(t *testing.T, ws *workspace.Workspace, version string) {
+			dir := t.TempDir()
+			err := os.WriteFile(filepath.Join(dir, "main.tf"), []byte(fmt.Sprintf(`
+module "vpc" {
+  source  = "terraform-aws-modules/vpc/aws"
+  version = "%s"
+}
+`, version)), 0o644)
+			require.NoError(t, err)
+			tarball, err := internal.Pack(dir)
+			require.NoError(t, err)
+
+			cv := svc.createConfigurationVersion(t, ctx, ws, nil)
+			err = svc.Configs.UploadConfig(ctx, cv.ID, tarball)
+			require.NoError(t, err)
+		}
+		ws1 := svc.createWorkspace(t, ctx, org)
+		ws2 := svc.createWorkspace(t, ctx, org)
+		ws3 := svc.createWorkspace(t, ctx, org)
+		uploadConfig(t, ws1, "4.0.0")
+		uploadConfig(t, ws2, "4.0.0")
+		uploadConfig(t, ws3, "4.0.0")
+		// only the latest config of a workspace is considered
+		uploadConfig(t, ws3, "5.0.0")
+
+		got, err := svc.Configs.GetOrganizationConsumption(ctx, org.Name, configversion.ConsumptionOptions{})
+		require.NoError(t, err)
+
+		assert.Equal(t, 0, len(got.Providers))
+		require.Equal(t, 1, len(got.Modules))
+		assert.Equal(t, "terraform-aws-modules/vpc/aws", got.Modules[0].Source)
+		require.Equal(t, 2, len(got.Modules[0].Versions))
+		assert.Equal(t, "4.0.0", got.Modules[0].Versions[0].Version)
+		assert.Equal(t, 2, len(got.Modules[0].Versions[0].Workspaces))
+		assert.Equal(t, "5.0.0", got.Modules[0].Versions[1].Version)
+		assert.Equal(t, []configversion.ConsumptionWorkspace{
+			{ID: ws3.ID, Name: ws3.Name},
+		}, got.Modules[0].Versions[1].Workspaces)
+	})
+
+	t.Run("metadata", func(t *testing.T) {
+		svc, _, ctx := setup(t, nil)
+
+		dir := t.TempDir()
+		err := os.WriteFile(filepath.Join(dir, "main.tf"), []byte(`
+module "vpc" {
+  source  = "terraform-aws-modules/vpc/aws"
+  version = "5.0.0"
+}
+
+variable "region" {
+  type    = string
+  default = "eu-west-2"
+}
+
+output "vpc_id" {
+  value = module.vpc.vpc_id
+}
+`), 0o644)
+		require.NoError(t, err)
+		tarball, err := internal.Pack(dir)
+		require.NoError(t, err)
+
+		cv := svc.createConfigurationVersion(t, ctx, nil, nil)
+		err = svc.Configs.UploadConfig(ctx, cv.ID, tarball)
+		require.NoError(t, err)
+
+		got, err := svc.Configs.GetMetadata(ctx, cv.ID)
+		require.NoError(t, err)
+
+		assert.Equal(t, []configversion.Variable{
+			{Name: "region", Type: "string", Default: []byte(`"eu-west-2"`)},
+		}, got.Variables)
+		assert.Equal(t, []configversion.Output{{Name: "vpc_id"}}, got.Outputs)
+		assert.Equal(t, []configversion.Dependency{
+			{Kind: configversion.ModuleDependency, Source: "terraform-aws-modules/vpc/aws", Version: "5.0.0"},
+		}, got.Dependencies)
+	})
 }
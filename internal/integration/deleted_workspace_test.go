@@ -0,0 +1,125 @@
+package integration
+
+import (
+	"testing"
+
+	"github.com/leg100/otf/internal"
+	"github.com/leg100/otf/internal/daemon"
+	"github.com/leg100/otf/internal/resource"
+	"github.com/leg100/otf/internal/workspace"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIntegration_DeletedWorkspace(t *testing.T) {
+	integrationTest(t)
+
+	cfg := &config{Config: daemon.Config{WorkspaceRecoveryDays: 7}}
+
+	t.Run("restore workspace with state", func(t *testing.T) {
+		svc, org, ctx := setup(t, cfg)
+		ws := svc.createWorkspace(t, ctx, org)
+		sv := svc.createStateVersion(t, ctx, ws)
+
+		_, err := svc.Workspaces.Delete(ctx, ws.ID)
+		require.NoError(t, err)
+
+		_, err = svc.Workspaces.Get(ctx, ws.ID)
+		assert.ErrorIs(t, err, internal.ErrResourceNotFound)
+
+		deleted, err := svc.Workspaces.ListDeleted(ctx, org.Name, resource.PageOptions{})
+		require.NoError(t, err)
+		require.Len(t, deleted.Items, 1)
+		assert.Equal(t, ws.ID, deleted.Items[0].ID)
+		assert.Equal(t, ws.Name, deleted.Items[0].Name)
+
+		restored, err := svc.Workspaces.Restore(ctx, ws.ID)
+		require.NoError(t, err)
+		assert.Equal(t, ws.ID, restored.ID)
+		assert.Equal(t, ws.Name, restored.Name)
+
+		// restored workspace should have its state back
+		current := svc.getCurrentState(t, ctx, ws.ID)
+		assert.Equal(t, sv.ID, current.ID)
+		assert.Equal(t, sv.State, current.State)
+		assert.Equal(t, len(sv.Outputs), len(current.Outputs))
+
+		// and should no longer be listed as deleted
+		deleted, err = svc.Workspaces.ListDeleted(ctx, org.Name, resource.PageOptions{})
+		require.NoError(t, err)
+		assert.Len(t, deleted.Items, 0)
+	})
+
+	t.Run("restore workspace with tags", func(t *testing.T) {
+		svc, org, ctx := setup(t, cfg)
+		ws, err := svc.Workspaces.Create(ctx, workspace.CreateOptions{
+			Name:         internal.String("tagged"),
+			Organization: &org.Name,
+			Tags:         []workspace.TagSpec{{Name: "foo"}, {Name: "bar"}},
+		})
+		require.NoError(t, err)
+
+		_, err = svc.Workspaces.Delete(ctx, ws.ID)
+		require.NoError(t, err)
+
+		restored, err := svc.Workspaces.Restore(ctx, ws.ID)
+		require.NoError(t, err)
+		assert.ElementsMatch(t, []string{"foo", "bar"}, restored.Tags)
+	})
+
+	t.Run("cannot restore when name is taken", func(t *testing.T) {
+		svc, org, ctx := setup(t, cfg)
+		ws := svc.createWorkspace(t, ctx, org)
+
+		_, err := svc.Workspaces.Delete(ctx, ws.ID)
+		require.NoError(t, err)
+
+		_, err = svc.Workspaces.Create(ctx, workspace.CreateOptions{
+			Name:         &ws.Name,
+			Organization: &org.Name,
+		})
+		require.NoError(t, err)
+
+		_, err = svc.Workspaces.Restore(ctx, ws.ID)
+		assert.ErrorIs(t, err, internal.ErrResourceAlreadyExists)
+	})
+
+	t.Run("purge", func(t *testing.T) {
+		svc, org, ctx := setup(t, cfg)
+		ws := svc.createWorkspace(t, ctx, org)
+		svc.createStateVersion(t, ctx, ws)
+
+		_, err := svc.Workspaces.Delete(ctx, ws.ID)
+		require.NoError(t, err)
+
+		err = svc.Workspaces.Purge(ctx, ws.ID)
+		require.NoError(t, err)
+
+		_, err = svc.Workspaces.Restore(ctx, ws.ID)
+		assert.ErrorIs(t, err, internal.ErrResourceNotFound)
+	})
+
+	t.Run("non-owner cannot restore", func(t *testing.T) {
+		svc, org, ctx := setup(t, cfg)
+		ws := svc.createWorkspace(t, ctx, org)
+
+		_, err := svc.Workspaces.Delete(ctx, ws.ID)
+		require.NoError(t, err)
+
+		userCtx := internal.AddSubjectToContext(ctx, svc.createUser(t))
+		_, err = svc.Workspaces.Restore(userCtx, ws.ID)
+		assert.ErrorIs(t, err, internal.ErrAccessNotPermitted)
+	})
+
+	t.Run("recovery disabled", func(t *testing.T) {
+		svc, org, ctx := setup(t, nil)
+		ws := svc.createWorkspace(t, ctx, org)
+
+		_, err := svc.Workspaces.Delete(ctx, ws.ID)
+		require.NoError(t, err)
+
+		deleted, err := svc.Workspaces.ListDeleted(ctx, org.Name, resource.PageOptions{})
+		require.NoError(t, err)
+		assert.Len(t, deleted.Items, 0)
+	})
+}
@@ -0,0 +1,48 @@
+package integration
+
+import (
+	"testing"
+
+	"github.com/leg100/otf/internal/demo"
+	"github.com/leg100/otf/internal/module"
+	"github.com/leg100/otf/internal/run"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIntegration_Demo(t *testing.T) {
+	integrationTest(t)
+
+	svc, _, _ := setup(t, nil)
+
+	err := demo.Seed(adminCtx, svc.Daemon)
+	require.NoError(t, err)
+
+	ws, err := svc.Workspaces.GetByName(adminCtx, demo.Organization, demo.Workspace)
+	require.NoError(t, err)
+
+	runs, err := svc.Runs.List(adminCtx, run.ListOptions{WorkspaceID: &ws.ID})
+	require.NoError(t, err)
+	require.Equal(t, 5, len(runs.Items))
+	// newest first
+	assert.Equal(t, run.RunPlannedAndFinished, runs.Items[0].Status)
+	assert.Equal(t, run.RunErrored, runs.Items[1].Status)
+	assert.Equal(t, run.RunErrored, runs.Items[2].Status)
+	assert.Equal(t, run.RunApplied, runs.Items[3].Status)
+	assert.Equal(t, run.RunApplied, runs.Items[4].Status)
+	assert.Equal(t, &run.Report{Additions: 4}, runs.Items[4].Apply.ResourceReport)
+
+	// the latest run is the workspace's current run
+	require.NotNil(t, ws.LatestRun)
+	assert.Equal(t, runs.Items[0].ID, ws.LatestRun.ID)
+
+	mod, err := svc.Modules.GetModule(adminCtx, module.GetModuleOptions{
+		Organization: demo.Organization,
+		Name:         "pet",
+		Provider:     "random",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, module.ModuleStatusSetupComplete, mod.Status)
+	require.NotNil(t, mod.Latest())
+	assert.Equal(t, "1.0.0", mod.Latest().Version)
+}
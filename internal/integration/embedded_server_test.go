@@ -0,0 +1,52 @@
+package integration
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/leg100/otf"
+	"github.com/leg100/otf/internal"
+	"github.com/leg100/otf/internal/logr"
+	"github.com/leg100/otf/internal/sql"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestEmbeddedServer demonstrates embedding otf within a Go program, serving
+// its handler on an http server of the program's choosing.
+func TestEmbeddedServer(t *testing.T) {
+	integrationTest(t)
+
+	cfg := otf.Config{}
+	otf.ApplyDefaults(&cfg)
+	cfg.Database = sql.NewTestDB(t)
+	cfg.Secret = sharedSecret
+	cfg.DisableLatestChecker = internal.Bool(true)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	server, err := otf.NewServer(ctx, logr.Discard(), cfg)
+	require.NoError(t, err)
+
+	srv := httptest.NewServer(server.Handler())
+	t.Cleanup(srv.Close)
+	server.SetHostname(srv.Listener.Addr().String())
+
+	done := make(chan error)
+	started := make(chan struct{})
+	go func() {
+		done <- server.Start(ctx, started)
+	}()
+	<-started
+
+	resp, err := http.Get(srv.URL + "/healthz")
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	cancel()
+	<-done
+}
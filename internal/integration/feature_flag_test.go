@@ -0,0 +1,52 @@
+package integration
+
+import (
+	"testing"
+
+	"github.com/leg100/otf/internal"
+	"github.com/leg100/otf/internal/featureflag"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIntegration_FeatureFlags(t *testing.T) {
+	integrationTest(t)
+
+	svc, org, ctx := setup(t, nil)
+	ws := svc.createWorkspace(t, ctx, org)
+
+	t.Run("enabled by default", func(t *testing.T) {
+		_, err := svc.Assessments.ListResults(ctx, ws.ID)
+		require.NoError(t, err)
+	})
+
+	t.Run("disable for organization", func(t *testing.T) {
+		_, err := svc.FeatureFlags.Set(adminCtx, featureflag.DriftDetection, featureflag.SetOptions{
+			Enabled:      false,
+			Organization: &org.Name,
+		})
+		require.NoError(t, err)
+
+		states, err := svc.FeatureFlags.List(adminCtx, org.Name)
+		require.NoError(t, err)
+		require.Len(t, states, 1)
+		assert.False(t, states[0].Enabled)
+		assert.Equal(t, featureflag.SourceOrganization, states[0].Source)
+
+		_, err = svc.Assessments.ListResults(ctx, ws.ID)
+		assert.ErrorIs(t, err, featureflag.ErrDisabled)
+	})
+
+	t.Run("unset for organization", func(t *testing.T) {
+		err := svc.FeatureFlags.Unset(adminCtx, featureflag.DriftDetection, &org.Name)
+		require.NoError(t, err)
+
+		_, err = svc.Assessments.ListResults(ctx, ws.ID)
+		require.NoError(t, err)
+	})
+
+	t.Run("non-admin cannot set flag", func(t *testing.T) {
+		_, err := svc.FeatureFlags.Set(ctx, featureflag.DriftDetection, featureflag.SetOptions{})
+		assert.ErrorIs(t, err, internal.ErrAccessNotPermitted)
+	})
+}
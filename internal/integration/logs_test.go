@@ -6,6 +6,7 @@ import (
 
 	"github.com/leg100/otf/internal"
 	"github.com/leg100/otf/internal/daemon"
+	"github.com/leg100/otf/internal/logs"
 	"github.com/leg100/otf/internal/sql"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -119,6 +120,48 @@ func TestLogs(t *testing.T) {
 			})
 		}
 	})
+
+	t.Run("search", func(t *testing.T) {
+		svc, _, ctx := setup(t, &config{Config: daemon.Config{
+			DisableScheduler: true, // don't start runs
+		}})
+		ws := svc.createWorkspace(t, ctx, nil)
+		cv := svc.createConfigurationVersion(t, ctx, ws, nil)
+		older := svc.createRun(t, ctx, ws, cv)
+		newer := svc.createRun(t, ctx, ws, cv)
+
+		put := func(runID string, offset int, data string) {
+			err := svc.Logs.PutChunk(ctx, internal.PutChunkOptions{
+				RunID:  runID,
+				Phase:  internal.PlanPhase,
+				Data:   []byte(data),
+				Offset: offset,
+			})
+			require.NoError(t, err)
+		}
+		put(older.ID, 0, "\x02Error: first")
+		put(older.ID, 13, " occurrence\x03")
+		put(newer.ID, 0, "\x02ok\nError: second occurrence\x03")
+
+		got, err := svc.Logs.SearchLogs(ctx, ws.ID, logs.SearchOptions{Query: "Error: \\w+ occurrence", Regex: true})
+		require.NoError(t, err)
+
+		assert.Equal(t, 2, got.RunsSearched)
+		if assert.Len(t, got.Matches, 2) {
+			assert.Equal(t, newer.ID, got.Matches[0].RunID)
+			assert.Equal(t, 2, got.Matches[0].Line)
+			assert.Equal(t, older.ID, got.Matches[1].RunID)
+			assert.Equal(t, "Error: first occurrence", got.Matches[1].Text)
+		}
+
+		t.Run("only most recent runs", func(t *testing.T) {
+			got, err := svc.Logs.SearchLogs(ctx, ws.ID, logs.SearchOptions{Query: "Error:", Runs: 1})
+			require.NoError(t, err)
+
+			assert.Equal(t, 1, got.RunsSearched)
+			assert.Len(t, got.Matches, 1)
+		})
+	})
 }
 
 // TestClusterLogs tests the relaying of logs across a cluster of otfd nodes.
@@ -0,0 +1,89 @@
+package integration
+
+import (
+	"testing"
+
+	tfe "github.com/hashicorp/go-tfe"
+	"github.com/leg100/otf/internal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestIntegration_OAuthAPI tests configuring VCS providers via the OAuth
+// client and OAuth token endpoints.
+func TestIntegration_OAuthAPI(t *testing.T) {
+	integrationTest(t)
+
+	daemon, org, ctx := setup(t, nil)
+	_, token := daemon.createToken(t, ctx, nil)
+
+	client, err := tfe.NewClient(&tfe.Config{
+		Address: "https://" + daemon.System.Hostname(),
+		Token:   string(token),
+	})
+	require.NoError(t, err)
+
+	oauth, err := client.OAuthClients.Create(ctx, org.Name, tfe.OAuthClientCreateOptions{
+		Name:            internal.String("gitlab"),
+		OAuthToken:      internal.String("my-token"),
+		APIURL:          internal.String("https://gitlab.com/api/v4"),
+		HTTPURL:         internal.String("https://gitlab.com"),
+		ServiceProvider: tfe.ServiceProvider(tfe.ServiceProviderGitlab),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, tfe.ServiceProviderGitlab, oauth.ServiceProvider)
+
+	t.Run("unsupported service provider", func(t *testing.T) {
+		_, err := client.OAuthClients.Create(ctx, org.Name, tfe.OAuthClientCreateOptions{
+			OAuthToken:      internal.String("my-token"),
+			APIURL:          internal.String("https://dev.azure.com"),
+			HTTPURL:         internal.String("https://dev.azure.com"),
+			ServiceProvider: tfe.ServiceProvider(tfe.ServiceProviderAzureDevOpsServices),
+		})
+		assert.Error(t, err)
+	})
+
+	t.Run("bitbucket server hostname not configured", func(t *testing.T) {
+		_, err := client.OAuthClients.Create(ctx, org.Name, tfe.OAuthClientCreateOptions{
+			OAuthToken:      internal.String("my-token"),
+			APIURL:          internal.String("https://bitbucket.acme.com"),
+			HTTPURL:         internal.String("https://bitbucket.acme.com"),
+			ServiceProvider: tfe.ServiceProvider(tfe.ServiceProviderBitbucketServer),
+		})
+		assert.Error(t, err)
+	})
+
+	t.Run("update", func(t *testing.T) {
+		updated, err := client.OAuthClients.Update(ctx, oauth.ID, tfe.OAuthClientUpdateOptions{
+			OAuthToken: internal.String("new-token"),
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "gitlab", *updated.Name)
+
+		provider, err := daemon.VCSProviders.Get(ctx, oauth.ID)
+		require.NoError(t, err)
+		assert.Equal(t, "new-token", *provider.Token)
+	})
+
+	t.Run("list tokens", func(t *testing.T) {
+		got, err := client.OAuthTokens.List(ctx, org.Name, nil)
+		require.NoError(t, err)
+		if assert.Len(t, got.Items, 1) {
+			assert.Equal(t, oauth.ID, got.Items[0].ID)
+		}
+	})
+
+	t.Run("get token", func(t *testing.T) {
+		got, err := client.OAuthTokens.Read(ctx, oauth.ID)
+		require.NoError(t, err)
+		assert.Equal(t, oauth.ID, got.OAuthClient.ID)
+	})
+
+	t.Run("delete token", func(t *testing.T) {
+		err := client.OAuthTokens.Delete(ctx, oauth.ID)
+		require.NoError(t, err)
+
+		_, err = daemon.VCSProviders.Get(ctx, oauth.ID)
+		assert.ErrorIs(t, err, internal.ErrResourceNotFound)
+	})
+}
@@ -0,0 +1,84 @@
+package integration
+
+import (
+	"testing"
+
+	"github.com/leg100/otf/internal/user"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIntegration_OrganizationMembership(t *testing.T) {
+	integrationTest(t)
+
+	t.Run("create", func(t *testing.T) {
+		svc, org, ctx := setup(t, nil)
+		team := svc.createTeam(t, ctx, org)
+
+		membership, err := svc.Users.CreateOrganizationMembership(ctx, org.Name, user.CreateOrganizationMembershipOptions{
+			Username: "bob@example.com",
+			TeamIDs:  []string{team.ID},
+		})
+		require.NoError(t, err)
+
+		// user should have been created and added to team
+		bob := svc.getUser(t, adminCtx, "bob@example.com")
+		assert.True(t, bob.IsTeamMember(team.ID))
+
+		t.Run("already a member", func(t *testing.T) {
+			got, err := svc.Users.CreateOrganizationMembership(ctx, org.Name, user.CreateOrganizationMembershipOptions{
+				Username: "bob@example.com",
+			})
+			require.NoError(t, err)
+			assert.Equal(t, membership.ID, got.ID)
+		})
+	})
+
+	t.Run("added to team", func(t *testing.T) {
+		svc, org, ctx := setup(t, nil)
+		team := svc.createTeam(t, ctx, org)
+		bob := svc.createUser(t)
+
+		err := svc.Users.AddTeamMembership(ctx, team.ID, []string{bob.Username})
+		require.NoError(t, err)
+
+		memberships, err := svc.Users.ListOrganizationMemberships(ctx, org.Name)
+		require.NoError(t, err)
+
+		usernames := make([]string, len(memberships))
+		for i, m := range memberships {
+			usernames[i] = m.Username
+		}
+		assert.Contains(t, usernames, bob.Username)
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		svc, org, ctx := setup(t, nil)
+		team := svc.createTeam(t, ctx, org)
+		bob := svc.createUser(t)
+
+		membership, err := svc.Users.CreateOrganizationMembership(ctx, org.Name, user.CreateOrganizationMembershipOptions{
+			Username: bob.Username,
+			TeamIDs:  []string{team.ID},
+		})
+		require.NoError(t, err)
+
+		err = svc.Users.DeleteOrganizationMembership(ctx, membership.ID)
+		require.NoError(t, err)
+
+		// user should have been removed from team
+		bob = svc.getUser(t, adminCtx, bob.Username)
+		assert.False(t, bob.IsTeamMember(team.ID))
+	})
+
+	t.Run("cannot delete only owner", func(t *testing.T) {
+		svc, org, ctx := setup(t, nil)
+
+		memberships, err := svc.Users.ListOrganizationMemberships(ctx, org.Name)
+		require.NoError(t, err)
+		require.Equal(t, 1, len(memberships))
+
+		err = svc.Users.DeleteOrganizationMembership(ctx, memberships[0].ID)
+		assert.Equal(t, user.ErrCannotDeleteOnlyOwner, err)
+	})
+}
@@ -55,3 +55,54 @@ func TestIntegration_OrganizationTokens(t *testing.T) {
 	})
 	require.Equal(t, internal.ErrUnauthorized, err)
 }
+
+// TestIntegration_NamedOrganizationTokens demonstrates rotating named
+// organization tokens without interruption.
+func TestIntegration_NamedOrganizationTokens(t *testing.T) {
+	integrationTest(t)
+
+	daemon, org, ctx := setup(t, nil)
+	daemon.createWorkspace(t, ctx, org)
+
+	listWorkspaces := func(t *testing.T, token []byte) error {
+		apiClient, err := api.NewClient(api.Config{
+			Address: daemon.System.Hostname(),
+			Token:   string(token),
+		})
+		require.NoError(t, err)
+		wsClient := &workspace.Client{Client: apiClient}
+		_, err = wsClient.List(ctx, workspace.ListOptions{
+			Organization: internal.String(org.Name),
+		})
+		return err
+	}
+
+	old, oldToken, err := daemon.Organizations.CreateToken(ctx, organization.CreateOrganizationTokenOptions{
+		Organization: org.Name,
+		Description:  "ci",
+	})
+	require.NoError(t, err)
+	require.NoError(t, listWorkspaces(t, oldToken))
+
+	// create replacement token; both tokens should be valid
+	_, newToken, err := daemon.Organizations.CreateToken(ctx, organization.CreateOrganizationTokenOptions{
+		Organization: org.Name,
+		Description:  "ci (rotated)",
+	})
+	require.NoError(t, err)
+	require.NoError(t, listWorkspaces(t, oldToken))
+	require.NoError(t, listWorkspaces(t, newToken))
+
+	tokens, err := daemon.Organizations.ListTokens(ctx, org.Name)
+	require.NoError(t, err)
+	require.Equal(t, 2, len(tokens))
+	assert.Equal(t, "ci", tokens[0].Description)
+	assert.NotNil(t, tokens[0].LastUsedAt)
+	assert.NotEmpty(t, tokens[0].CreatedBy)
+
+	// retire old token
+	err = daemon.Organizations.DeleteTokenByID(ctx, old.ID)
+	require.NoError(t, err)
+	require.Equal(t, internal.ErrUnauthorized, listWorkspaces(t, oldToken))
+	require.NoError(t, listWorkspaces(t, newToken))
+}
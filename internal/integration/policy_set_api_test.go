@@ -0,0 +1,139 @@
+package integration
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	tfe "github.com/hashicorp/go-tfe"
+	"github.com/leg100/otf/internal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestIntegration_PolicySetAPI tests the policy sets API endpoints using the
+// go-tfe client.
+func TestIntegration_PolicySetAPI(t *testing.T) {
+	integrationTest(t)
+
+	daemon, org, ctx := setup(t, nil)
+	ws := daemon.createWorkspace(t, ctx, org)
+	_, token := daemon.createToken(t, ctx, nil)
+
+	tfeClient, err := tfe.NewClient(&tfe.Config{
+		Address:           "https://" + daemon.System.Hostname(),
+		Token:             string(token),
+		RetryServerErrors: true,
+	})
+	require.NoError(t, err)
+
+	set, err := tfeClient.PolicySets.Create(ctx, org.Name, tfe.PolicySetCreateOptions{
+		Name:       internal.String("security"),
+		Kind:       tfe.OPA,
+		Workspaces: []*tfe.Workspace{{ID: ws.ID}},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "security", set.Name)
+	assert.Equal(t, tfe.OPA, set.Kind)
+	assert.Equal(t, 1, set.WorkspaceCount)
+
+	t.Run("list", func(t *testing.T) {
+		got, err := tfeClient.PolicySets.List(ctx, org.Name, &tfe.PolicySetListOptions{
+			Search: "sec",
+		})
+		require.NoError(t, err)
+		require.Equal(t, 1, len(got.Items))
+		assert.Equal(t, set.ID, got.Items[0].ID)
+	})
+
+	t.Run("update", func(t *testing.T) {
+		got, err := tfeClient.PolicySets.Update(ctx, set.ID, tfe.PolicySetUpdateOptions{
+			Description: internal.String("security policies"),
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "security policies", got.Description)
+	})
+
+	t.Run("vcs repo not supported", func(t *testing.T) {
+		_, err := tfeClient.PolicySets.Create(ctx, org.Name, tfe.PolicySetCreateOptions{
+			Name:    internal.String("vcs"),
+			VCSRepo: &tfe.VCSRepoOptions{Identifier: internal.String("acme/policies")},
+		})
+		assert.Error(t, err)
+	})
+
+	t.Run("remove and add workspaces", func(t *testing.T) {
+		err := tfeClient.PolicySets.RemoveWorkspaces(ctx, set.ID, tfe.PolicySetRemoveWorkspacesOptions{
+			Workspaces: []*tfe.Workspace{{ID: ws.ID}},
+		})
+		require.NoError(t, err)
+
+		sets, err := daemon.PolicySets.ListWorkspacePolicySets(ctx, ws.ID)
+		require.NoError(t, err)
+		assert.Equal(t, 0, len(sets))
+
+		err = tfeClient.PolicySets.AddWorkspaces(ctx, set.ID, tfe.PolicySetAddWorkspacesOptions{
+			Workspaces: []*tfe.Workspace{{ID: ws.ID}},
+		})
+		require.NoError(t, err)
+
+		sets, err = daemon.PolicySets.ListWorkspacePolicySets(ctx, ws.ID)
+		require.NoError(t, err)
+		assert.Equal(t, 1, len(sets))
+	})
+
+	t.Run("parameters", func(t *testing.T) {
+		param, err := tfeClient.PolicySetParameters.Create(ctx, set.ID, tfe.PolicySetParameterCreateOptions{
+			Key:       internal.String("token"),
+			Value:     internal.String("secret"),
+			Category:  tfe.Category(tfe.CategoryPolicySet),
+			Sensitive: internal.Bool(true),
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "token", param.Key)
+		// sensitive values are not returned
+		assert.Equal(t, "", param.Value)
+
+		got, err := tfeClient.PolicySetParameters.List(ctx, set.ID, nil)
+		require.NoError(t, err)
+		assert.Equal(t, 1, len(got.Items))
+
+		err = tfeClient.PolicySetParameters.Delete(ctx, set.ID, param.ID)
+		require.NoError(t, err)
+	})
+
+	t.Run("upload version", func(t *testing.T) {
+		dir := t.TempDir()
+		err := os.WriteFile(filepath.Join(dir, "main.rego"), []byte("package terraform"), 0o644)
+		require.NoError(t, err)
+
+		version, err := tfeClient.PolicySetVersions.Create(ctx, set.ID)
+		require.NoError(t, err)
+		assert.Equal(t, tfe.PolicySetVersionPending, version.Status)
+
+		err = tfeClient.PolicySetVersions.Upload(ctx, *version, dir)
+		require.NoError(t, err)
+
+		version, err = tfeClient.PolicySetVersions.Read(ctx, version.ID)
+		require.NoError(t, err)
+		assert.Equal(t, tfe.PolicySetVersionReady, version.Status)
+
+		got, err := tfeClient.PolicySets.Read(ctx, set.ID)
+		require.NoError(t, err)
+		assert.Equal(t, 1, got.PolicyCount)
+		require.NotNil(t, got.CurrentVersion)
+		assert.Equal(t, version.ID, got.CurrentVersion.ID)
+
+		bundle, err := daemon.PolicySets.DownloadCurrentVersion(ctx, set.ID)
+		require.NoError(t, err)
+		assert.NotEmpty(t, bundle)
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		err := tfeClient.PolicySets.Delete(ctx, set.ID)
+		require.NoError(t, err)
+
+		_, err = tfeClient.PolicySets.Read(ctx, set.ID)
+		assert.Equal(t, tfe.ErrResourceNotFound, err)
+	})
+}
@@ -0,0 +1,94 @@
+package integration
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/leg100/otf/internal"
+	"github.com/leg100/otf/internal/rbac"
+	"github.com/leg100/otf/internal/run"
+	"github.com/leg100/otf/internal/workspace"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestIntegration_ProtectionRules demonstrates a run that destroys a protected
+// resource being held for confirmation despite auto-apply, and only being
+// applied once a workspace admin overrides the protection rules.
+func TestIntegration_ProtectionRules(t *testing.T) {
+	integrationTest(t)
+
+	daemon, org, ctx := setup(t, nil)
+	ws, err := daemon.Workspaces.Create(ctx, workspace.CreateOptions{
+		Name:         internal.String(t.Name()),
+		Organization: internal.String(org.Name),
+		AutoApply:    internal.Bool(true),
+	})
+	require.NoError(t, err)
+
+	_, err = daemon.Runs.SetProtectionRules(ctx, ws.ID, run.ProtectionRules{
+		ProtectedResources: []string{"random_pet.*"},
+	})
+	require.NoError(t, err)
+
+	// engineer has write permissions on the workspace, but is not permitted to
+	// override protection rules.
+	engineer, engineerCtx := daemon.createUserCtx(t)
+	team := daemon.createTeam(t, ctx, org)
+	err = daemon.Users.AddTeamMembership(ctx, team.ID, []string{engineer.Username})
+	require.NoError(t, err)
+	err = daemon.Workspaces.SetPermission(ctx, ws.ID, team.ID, rbac.WorkspaceWriteRole)
+	require.NoError(t, err)
+
+	sub, unsub := daemon.Runs.Watch(ctx)
+	defer unsub()
+
+	root := t.TempDir()
+	createRun := func(t *testing.T, config string, wantStatus run.Status) *run.Run {
+		cv := daemon.createConfigurationVersion(t, ctx, ws, nil)
+		err := os.WriteFile(filepath.Join(root, "main.tf"), []byte(config), 0o777)
+		require.NoError(t, err)
+		tarball, err := internal.Pack(root)
+		require.NoError(t, err)
+		err = daemon.Configs.UploadConfig(ctx, cv.ID, tarball)
+		require.NoError(t, err)
+
+		created := daemon.createRun(t, ctx, ws, cv)
+		for event := range sub {
+			if r := event.Payload; r.ID == created.ID {
+				if r.Status == wantStatus {
+					return r
+				}
+				require.False(t, r.Done(), "run unexpectedly finished with status %s", r.Status)
+			}
+		}
+		t.Fatal("run events stream closed unexpectedly")
+		return nil
+	}
+
+	// creating a protected resource is permitted
+	createRun(t, `resource "random_pet" "cat" { prefix = "mr-" }`, run.RunApplied)
+
+	// replacing a protected resource violates the rules and the run awaits
+	// confirmation despite auto-apply
+	replace := createRun(t, `resource "random_pet" "cat" { prefix = "sir-" }`, run.RunPlanned)
+
+	check, err := daemon.Runs.GetProtectionCheck(ctx, replace.ID)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"plan destroys protected resource random_pet.cat (matches random_pet.*)"}, check.Violations)
+	assert.Nil(t, check.Override)
+
+	err = daemon.Runs.Apply(engineerCtx, replace.ID)
+	assert.True(t, errors.Is(err, run.ErrProtectionRulesViolated), "got error: %v", err)
+
+	// owner overrides the rules
+	err = daemon.Runs.Apply(ctx, replace.ID)
+	require.NoError(t, err)
+
+	check, err = daemon.Runs.GetProtectionCheck(ctx, replace.ID)
+	require.NoError(t, err)
+	require.NotNil(t, check.Override)
+	assert.Equal(t, check.Violations, check.Override.Violations)
+}
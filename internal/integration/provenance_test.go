@@ -0,0 +1,59 @@
+package integration
+
+import (
+	"os"
+	"testing"
+
+	"github.com/leg100/otf/internal"
+	"github.com/leg100/otf/internal/agent"
+	"github.com/leg100/otf/internal/state"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestIntegration_Provenance demonstrates retrieving the provenance of a run,
+// linking the run to its configuration version and creator, and to the state
+// version created by the run's apply phase.
+func TestIntegration_Provenance(t *testing.T) {
+	integrationTest(t)
+
+	daemon, org, ctx := setup(t, nil)
+	user := userFromContext(t, ctx)
+	ws := daemon.createWorkspace(t, ctx, org)
+	cv := daemon.createConfigurationVersion(t, ctx, ws, nil)
+	run := daemon.createRun(t, ctx, ws, cv)
+
+	// create state version on behalf of the run's apply job
+	jobCtx := internal.AddSubjectToContext(ctx, &agent.Job{
+		Spec:         agent.JobSpec{RunID: run.ID, Phase: internal.ApplyPhase},
+		Organization: org.Name,
+		WorkspaceID:  ws.ID,
+	})
+	file, err := os.ReadFile("./testdata/terraform.tfstate")
+	require.NoError(t, err)
+	sv, err := daemon.State.Create(jobCtx, state.CreateStateVersionOptions{
+		State:       file,
+		WorkspaceID: internal.String(ws.ID),
+		Serial:      internal.Int64(9),
+	})
+	require.NoError(t, err)
+
+	// state versions not created by the run are excluded
+	daemon.createStateVersion(t, ctx, ws)
+
+	got, err := daemon.Runs.GetProvenance(ctx, run.ID, false)
+	require.NoError(t, err)
+
+	assert.Equal(t, run.ID, got.RunID)
+	assert.Equal(t, ws.ID, got.WorkspaceID)
+	assert.Equal(t, cv.ID, got.ConfigurationVersion.ID)
+	assert.Equal(t, user.Username, got.ConfigurationVersion.CreatedBy)
+	if assert.Equal(t, 1, len(got.StateVersions)) {
+		assert.Equal(t, sv.ID, got.StateVersions[0].ID)
+	}
+	assert.Empty(t, got.Signature)
+
+	signed, err := daemon.Runs.GetProvenance(ctx, run.ID, true)
+	require.NoError(t, err)
+	assert.NotEmpty(t, signed.Signature)
+}
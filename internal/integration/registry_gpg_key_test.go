@@ -0,0 +1,91 @@
+package integration
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/ProtonMail/go-crypto/openpgp"
+	"github.com/ProtonMail/go-crypto/openpgp/armor"
+	tfe "github.com/hashicorp/go-tfe"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestIntegration_RegistryGPGKeyAPI tests managing GPG keys via the TFE API
+// using the go-tfe client.
+func TestIntegration_RegistryGPGKeyAPI(t *testing.T) {
+	integrationTest(t)
+
+	daemon, org, ctx := setup(t, nil)
+	_, token := daemon.createToken(t, ctx, nil)
+
+	tfeClient, err := tfe.NewClient(&tfe.Config{
+		Address:           "https://" + daemon.System.Hostname(),
+		Token:             string(token),
+		RetryServerErrors: true,
+	})
+	require.NoError(t, err)
+
+	entity, asciiArmor := newGPGKey(t)
+
+	key, err := tfeClient.GPGKeys.Create(ctx, tfe.PrivateRegistry, tfe.GPGKeyCreateOptions{
+		Namespace:  org.Name,
+		AsciiArmor: asciiArmor,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, entity.PrimaryKey.KeyIdString(), key.KeyID)
+	assert.Equal(t, org.Name, key.Namespace)
+
+	keyID := tfe.GPGKeyID{
+		RegistryName: tfe.PrivateRegistry,
+		Namespace:    org.Name,
+		KeyID:        key.KeyID,
+	}
+
+	t.Run("read", func(t *testing.T) {
+		got, err := tfeClient.GPGKeys.Read(ctx, keyID)
+		require.NoError(t, err)
+		assert.Equal(t, asciiArmor, got.AsciiArmor)
+	})
+
+	t.Run("list", func(t *testing.T) {
+		got, err := tfeClient.GPGKeys.ListPrivate(ctx, tfe.GPGKeyListOptions{
+			Namespaces: []string{org.Name},
+		})
+		require.NoError(t, err)
+		require.Equal(t, 1, len(got.Items))
+		assert.Equal(t, key.KeyID, got.Items[0].KeyID)
+	})
+
+	t.Run("create duplicate", func(t *testing.T) {
+		_, err := tfeClient.GPGKeys.Create(ctx, tfe.PrivateRegistry, tfe.GPGKeyCreateOptions{
+			Namespace:  org.Name,
+			AsciiArmor: asciiArmor,
+		})
+		assert.Error(t, err)
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		err := tfeClient.GPGKeys.Delete(ctx, keyID)
+		require.NoError(t, err)
+
+		_, err = tfeClient.GPGKeys.Read(ctx, keyID)
+		assert.ErrorIs(t, err, tfe.ErrResourceNotFound)
+	})
+}
+
+// newGPGKey generates a GPG key, returning the key along with its ASCII
+// armored public key.
+func newGPGKey(t *testing.T) (*openpgp.Entity, string) {
+	t.Helper()
+
+	entity, err := openpgp.NewEntity("otf", "", "otf@example.com", nil)
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	w, err := armor.Encode(&buf, openpgp.PublicKeyType, nil)
+	require.NoError(t, err)
+	require.NoError(t, entity.Serialize(w))
+	require.NoError(t, w.Close())
+	return entity, buf.String()
+}
@@ -0,0 +1,91 @@
+package integration
+
+import (
+	"testing"
+
+	tfe "github.com/hashicorp/go-tfe"
+	"github.com/leg100/otf/internal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestIntegration_RegistryModuleAPI tests publishing a module via the TFE API
+// using the go-tfe client.
+func TestIntegration_RegistryModuleAPI(t *testing.T) {
+	integrationTest(t)
+
+	daemon, org, ctx := setup(t, nil)
+	_, token := daemon.createToken(t, ctx, nil)
+
+	tfeClient, err := tfe.NewClient(&tfe.Config{
+		Address:           "https://" + daemon.System.Hostname(),
+		Token:             string(token),
+		RetryServerErrors: true,
+	})
+	require.NoError(t, err)
+
+	mod, err := tfeClient.RegistryModules.Create(ctx, org.Name, tfe.RegistryModuleCreateOptions{
+		Name:     internal.String("vpc"),
+		Provider: internal.String("aws"),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, tfe.RegistryModuleStatusPending, mod.Status)
+
+	id := tfe.RegistryModuleID{
+		Organization: org.Name,
+		Name:         "vpc",
+		Provider:     "aws",
+		Namespace:    org.Name,
+		RegistryName: tfe.PrivateRegistry,
+	}
+
+	modver, err := tfeClient.RegistryModules.CreateVersion(ctx, id, tfe.RegistryModuleCreateVersionOptions{
+		Version: internal.String("1.0.0"),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "1.0.0", modver.Version)
+	require.NotNil(t, modver.Links["upload"])
+
+	err = tfeClient.RegistryModules.Upload(ctx, *modver, "./testdata/root")
+	require.NoError(t, err)
+
+	t.Run("read", func(t *testing.T) {
+		got, err := tfeClient.RegistryModules.Read(ctx, id)
+		require.NoError(t, err)
+		assert.Equal(t, mod.ID, got.ID)
+		assert.Equal(t, tfe.RegistryModuleStatusSetupComplete, got.Status)
+		require.Equal(t, 1, len(got.VersionStatuses))
+		assert.Equal(t, tfe.RegistryModuleVersionStatusOk, got.VersionStatuses[0].Status)
+	})
+
+	t.Run("list", func(t *testing.T) {
+		got, err := tfeClient.RegistryModules.List(ctx, org.Name, nil)
+		require.NoError(t, err)
+		require.Equal(t, 1, len(got.Items))
+		assert.Equal(t, mod.ID, got.Items[0].ID)
+	})
+
+	t.Run("duplicate version", func(t *testing.T) {
+		_, err := tfeClient.RegistryModules.CreateVersion(ctx, id, tfe.RegistryModuleCreateVersionOptions{
+			Version: internal.String("1.0.0"),
+		})
+		assert.Error(t, err)
+	})
+
+	t.Run("delete version", func(t *testing.T) {
+		err := tfeClient.RegistryModules.DeleteVersion(ctx, id, "1.0.0")
+		require.NoError(t, err)
+
+		got, err := tfeClient.RegistryModules.Read(ctx, id)
+		require.NoError(t, err)
+		assert.Equal(t, 0, len(got.VersionStatuses))
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		err := tfeClient.RegistryModules.Delete(ctx, org.Name, "vpc")
+		require.NoError(t, err)
+
+		_, err = tfeClient.RegistryModules.Read(ctx, id)
+		assert.Equal(t, tfe.ErrResourceNotFound, err)
+	})
+}
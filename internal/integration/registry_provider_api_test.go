@@ -0,0 +1,165 @@
+package integration
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"testing"
+
+	tfe "github.com/hashicorp/go-tfe"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestIntegration_RegistryProviderAPI tests publishing a provider via the TFE
+// API using the go-tfe client.
+func TestIntegration_RegistryProviderAPI(t *testing.T) {
+	integrationTest(t)
+
+	daemon, org, ctx := setup(t, nil)
+	_, token := daemon.createToken(t, ctx, nil)
+
+	tfeClient, err := tfe.NewClient(&tfe.Config{
+		Address:           "https://" + daemon.System.Hostname(),
+		Token:             string(token),
+		RetryServerErrors: true,
+	})
+	require.NoError(t, err)
+
+	prov, err := tfeClient.RegistryProviders.Create(ctx, org.Name, tfe.RegistryProviderCreateOptions{
+		Name:         "aws",
+		Namespace:    org.Name,
+		RegistryName: tfe.PrivateRegistry,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "aws", prov.Name)
+
+	provID := tfe.RegistryProviderID{
+		OrganizationName: org.Name,
+		RegistryName:     tfe.PrivateRegistry,
+		Namespace:        org.Name,
+		Name:             "aws",
+	}
+
+	ver, err := tfeClient.RegistryProviderVersions.Create(ctx, provID, tfe.RegistryProviderVersionCreateOptions{
+		Version:   "1.0.0",
+		KeyID:     "32966F3FB5AC1129",
+		Protocols: []string{"5.0"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "1.0.0", ver.Version)
+	assert.False(t, ver.ShasumsUploaded)
+
+	shasumsURL, err := ver.ShasumsUploadURL()
+	require.NoError(t, err)
+	putSignedURL(t, shasumsURL, []byte("shasums"), http.StatusOK)
+
+	verID := tfe.RegistryProviderVersionID{RegistryProviderID: provID, Version: "1.0.0"}
+
+	binary := []byte("provider binary")
+	sum := sha256.Sum256(binary)
+	platform, err := tfeClient.RegistryProviderPlatforms.Create(ctx, verID, tfe.RegistryProviderPlatformCreateOptions{
+		OS:       "linux",
+		Arch:     "amd64",
+		Shasum:   hex.EncodeToString(sum[:]),
+		Filename: "terraform-provider-aws_1.0.0_linux_amd64.zip",
+	})
+	require.NoError(t, err)
+	uploadURL, ok := platform.Links["provider-binary-upload"].(string)
+	require.True(t, ok)
+
+	// binary must match the shasum of the platform
+	putSignedURL(t, uploadURL, []byte("not the binary"), http.StatusUnprocessableEntity)
+	putSignedURL(t, uploadURL, binary, http.StatusOK)
+
+	t.Run("read version", func(t *testing.T) {
+		got, err := tfeClient.RegistryProviderVersions.Read(ctx, verID)
+		require.NoError(t, err)
+		assert.True(t, got.ShasumsUploaded)
+		assert.False(t, got.ShasumsSigUploaded)
+
+		downloadURL, err := got.ShasumsDownloadURL()
+		require.NoError(t, err)
+		assert.Equal(t, []byte("shasums"), getSignedURL(t, downloadURL))
+	})
+
+	t.Run("read platform", func(t *testing.T) {
+		got, err := tfeClient.RegistryProviderPlatforms.Read(ctx, tfe.RegistryProviderPlatformID{
+			RegistryProviderVersionID: verID,
+			OS:                        "linux",
+			Arch:                      "amd64",
+		})
+		require.NoError(t, err)
+		assert.True(t, got.ProviderBinaryUploaded)
+
+		downloadURL, ok := got.Links["provider-binary-download"].(string)
+		require.True(t, ok)
+		assert.Equal(t, binary, getSignedURL(t, downloadURL))
+	})
+
+	t.Run("list", func(t *testing.T) {
+		got, err := tfeClient.RegistryProviders.List(ctx, org.Name, nil)
+		require.NoError(t, err)
+		require.Equal(t, 1, len(got.Items))
+		assert.Equal(t, prov.ID, got.Items[0].ID)
+	})
+
+	t.Run("duplicate version", func(t *testing.T) {
+		_, err := tfeClient.RegistryProviderVersions.Create(ctx, provID, tfe.RegistryProviderVersionCreateOptions{
+			Version:   "1.0.0",
+			KeyID:     "32966F3FB5AC1129",
+			Protocols: []string{"5.0"},
+		})
+		assert.Error(t, err)
+	})
+
+	t.Run("delete version", func(t *testing.T) {
+		err := tfeClient.RegistryProviderVersions.Delete(ctx, verID)
+		require.NoError(t, err)
+
+		_, err = tfeClient.RegistryProviderVersions.Read(ctx, verID)
+		assert.Equal(t, tfe.ErrResourceNotFound, err)
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		err := tfeClient.RegistryProviders.Delete(ctx, provID)
+		require.NoError(t, err)
+
+		_, err = tfeClient.RegistryProviders.Read(ctx, provID, nil)
+		assert.Equal(t, tfe.ErrResourceNotFound, err)
+	})
+
+	t.Run("invalid name", func(t *testing.T) {
+		_, err := tfeClient.RegistryProviders.Create(ctx, org.Name, tfe.RegistryProviderCreateOptions{
+			Name:         "Not_Valid",
+			Namespace:    org.Name,
+			RegistryName: tfe.PrivateRegistry,
+		})
+		assert.Error(t, err)
+	})
+}
+
+func putSignedURL(t *testing.T, url string, body []byte, want int) {
+	t.Helper()
+
+	r, err := http.NewRequest("PUT", url, bytes.NewReader(body))
+	require.NoError(t, err)
+	resp, err := http.DefaultClient.Do(r)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, want, resp.StatusCode)
+}
+
+func getSignedURL(t *testing.T, url string) []byte {
+	t.Helper()
+
+	resp, err := http.Get(url)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	return body
+}
@@ -0,0 +1,142 @@
+package integration
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	tfe "github.com/hashicorp/go-tfe"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestIntegration_RegistryProviderProtocol tests retrieving a published
+// provider using the provider registry protocol.
+func TestIntegration_RegistryProviderProtocol(t *testing.T) {
+	integrationTest(t)
+
+	daemon, org, ctx := setup(t, nil)
+	_, token := daemon.createToken(t, ctx, nil)
+
+	tfeClient, err := tfe.NewClient(&tfe.Config{
+		Address:           "https://" + daemon.System.Hostname(),
+		Token:             string(token),
+		RetryServerErrors: true,
+	})
+	require.NoError(t, err)
+
+	// add the key with which the version's SHA256SUMS file is signed
+	entity, asciiArmor := newGPGKey(t)
+	_, err = tfeClient.GPGKeys.Create(ctx, tfe.PrivateRegistry, tfe.GPGKeyCreateOptions{
+		Namespace:  org.Name,
+		AsciiArmor: asciiArmor,
+	})
+	require.NoError(t, err)
+
+	// publish version 1.0.0 of the provider for linux/amd64
+	_, err = tfeClient.RegistryProviders.Create(ctx, org.Name, tfe.RegistryProviderCreateOptions{
+		Name:         "aws",
+		Namespace:    org.Name,
+		RegistryName: tfe.PrivateRegistry,
+	})
+	require.NoError(t, err)
+	provID := tfe.RegistryProviderID{
+		OrganizationName: org.Name,
+		RegistryName:     tfe.PrivateRegistry,
+		Namespace:        org.Name,
+		Name:             "aws",
+	}
+	ver, err := tfeClient.RegistryProviderVersions.Create(ctx, provID, tfe.RegistryProviderVersionCreateOptions{
+		Version:   "1.0.0",
+		KeyID:     entity.PrimaryKey.KeyIdString(),
+		Protocols: []string{"5.0"},
+	})
+	require.NoError(t, err)
+	shasumsURL, err := ver.ShasumsUploadURL()
+	require.NoError(t, err)
+	putSignedURL(t, shasumsURL, []byte("shasums"), http.StatusOK)
+	shasumsSigURL, err := ver.ShasumsSigUploadURL()
+	require.NoError(t, err)
+	putSignedURL(t, shasumsSigURL, []byte("signature"), http.StatusOK)
+
+	binary := []byte("provider binary")
+	sum := sha256.Sum256(binary)
+	platform, err := tfeClient.RegistryProviderPlatforms.Create(ctx, tfe.RegistryProviderVersionID{
+		RegistryProviderID: provID,
+		Version:            "1.0.0",
+	}, tfe.RegistryProviderPlatformCreateOptions{
+		OS:       "linux",
+		Arch:     "amd64",
+		Shasum:   hex.EncodeToString(sum[:]),
+		Filename: "terraform-provider-aws_1.0.0_linux_amd64.zip",
+	})
+	require.NoError(t, err)
+	putSignedURL(t, platform.Links["provider-binary-upload"].(string), binary, http.StatusOK)
+
+	// retrieve a path using the provider registry protocol
+	get := func(t *testing.T, path string, v any) int {
+		t.Helper()
+
+		r, err := http.NewRequest("GET", "https://"+daemon.System.Hostname()+"/v1/providers/"+path, nil)
+		require.NoError(t, err)
+		r.Header.Add("Authorization", "Bearer "+string(token))
+		resp, err := http.DefaultClient.Do(r)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		if resp.StatusCode == http.StatusOK {
+			require.NoError(t, json.NewDecoder(resp.Body).Decode(v))
+		}
+		return resp.StatusCode
+	}
+
+	t.Run("list available versions", func(t *testing.T) {
+		var got struct {
+			Versions []struct {
+				Version   string
+				Protocols []string
+				Platforms []struct {
+					OS   string
+					Arch string
+				}
+			}
+		}
+		require.Equal(t, http.StatusOK, get(t, org.Name+"/aws/versions", &got))
+		require.Equal(t, 1, len(got.Versions))
+		assert.Equal(t, "1.0.0", got.Versions[0].Version)
+		assert.Equal(t, []string{"5.0"}, got.Versions[0].Protocols)
+		require.Equal(t, 1, len(got.Versions[0].Platforms))
+		assert.Equal(t, "linux", got.Versions[0].Platforms[0].OS)
+		assert.Equal(t, "amd64", got.Versions[0].Platforms[0].Arch)
+	})
+
+	t.Run("find package", func(t *testing.T) {
+		var got struct {
+			Filename            string
+			DownloadURL         string `json:"download_url"`
+			ShasumsURL          string `json:"shasums_url"`
+			ShasumsSignatureURL string `json:"shasums_signature_url"`
+			Shasum              string
+			SigningKeys         struct {
+				GPGPublicKeys []struct {
+					KeyID      string `json:"key_id"`
+					ASCIIArmor string `json:"ascii_armor"`
+				} `json:"gpg_public_keys"`
+			} `json:"signing_keys"`
+		}
+		require.Equal(t, http.StatusOK, get(t, org.Name+"/aws/1.0.0/download/linux/amd64", &got))
+		assert.Equal(t, "terraform-provider-aws_1.0.0_linux_amd64.zip", got.Filename)
+		assert.Equal(t, hex.EncodeToString(sum[:]), got.Shasum)
+		assert.Equal(t, binary, getSignedURL(t, got.DownloadURL))
+		assert.Equal(t, []byte("shasums"), getSignedURL(t, got.ShasumsURL))
+		assert.Equal(t, []byte("signature"), getSignedURL(t, got.ShasumsSignatureURL))
+		require.Equal(t, 1, len(got.SigningKeys.GPGPublicKeys))
+		assert.Equal(t, entity.PrimaryKey.KeyIdString(), got.SigningKeys.GPGPublicKeys[0].KeyID)
+		assert.Equal(t, asciiArmor, got.SigningKeys.GPGPublicKeys[0].ASCIIArmor)
+	})
+
+	t.Run("find missing package", func(t *testing.T) {
+		assert.Equal(t, http.StatusNotFound, get(t, org.Name+"/aws/1.0.0/download/darwin/arm64", nil))
+	})
+}
@@ -0,0 +1,94 @@
+package integration
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/leg100/otf/internal"
+	"github.com/leg100/otf/internal/daemon"
+	"github.com/leg100/otf/internal/workspace"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIntegration_RenamedWorkspace(t *testing.T) {
+	integrationTest(t)
+
+	cfg := &config{Config: daemon.Config{WorkspaceRenameGraceDays: 7}}
+
+	rename := func(t *testing.T, svc *testDaemon, ctx context.Context, ws *workspace.Workspace, name string) {
+		_, err := svc.Workspaces.Update(ctx, ws.ID, workspace.UpdateOptions{
+			Name: internal.String(name),
+		})
+		require.NoError(t, err)
+	}
+
+	t.Run("get by former name", func(t *testing.T) {
+		svc, _, ctx := setup(t, cfg)
+		ws := svc.createWorkspace(t, ctx, nil)
+		rename(t, svc, ctx, ws, "renamed")
+
+		_, err := svc.Workspaces.GetByName(ctx, ws.Organization, ws.Name)
+		var renamed *workspace.RenamedError
+		require.ErrorAs(t, err, &renamed)
+		assert.Equal(t, ws.ID, renamed.WorkspaceID)
+		assert.Equal(t, "renamed", renamed.Name)
+		assert.ErrorIs(t, err, internal.ErrResourceNotFound)
+	})
+
+	t.Run("former name reused", func(t *testing.T) {
+		svc, org, ctx := setup(t, cfg)
+		ws := svc.createWorkspace(t, ctx, org)
+		rename(t, svc, ctx, ws, "renamed")
+
+		want, err := svc.Workspaces.Create(ctx, workspace.CreateOptions{
+			Name:         internal.String(ws.Name),
+			Organization: internal.String(org.Name),
+		})
+		require.NoError(t, err)
+
+		got, err := svc.Workspaces.GetByName(ctx, org.Name, ws.Name)
+		require.NoError(t, err)
+		assert.Equal(t, want.ID, got.ID)
+	})
+
+	t.Run("redirect API request", func(t *testing.T) {
+		svc, _, ctx := setup(t, cfg)
+		ws := svc.createWorkspace(t, ctx, nil)
+		rename(t, svc, ctx, ws, "renamed")
+		_, token := svc.createToken(t, ctx, nil)
+
+		u := fmt.Sprintf("https://%s/api/v2/organizations/%s/workspaces/%s", svc.System.Hostname(), ws.Organization, ws.Name)
+		r, err := http.NewRequest("GET", u, nil)
+		require.NoError(t, err)
+		r.Header.Add("Authorization", "Bearer "+string(token))
+
+		// don't follow redirects
+		client := &http.Client{
+			CheckRedirect: func(*http.Request, []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		}
+		resp, err := client.Do(r)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusMovedPermanently, resp.StatusCode)
+		want := fmt.Sprintf("/api/v2/organizations/%s/workspaces/renamed", ws.Organization)
+		assert.Equal(t, want, resp.Header.Get("Location"))
+	})
+
+	t.Run("grace period disabled", func(t *testing.T) {
+		svc, _, ctx := setup(t, nil)
+		ws := svc.createWorkspace(t, ctx, nil)
+		rename(t, svc, ctx, ws, "renamed")
+
+		_, err := svc.Workspaces.GetByName(ctx, ws.Organization, ws.Name)
+		var renamed *workspace.RenamedError
+		assert.False(t, errors.As(err, &renamed))
+		assert.ErrorIs(t, err, internal.ErrResourceNotFound)
+	})
+}
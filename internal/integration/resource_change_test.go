@@ -0,0 +1,74 @@
+package integration
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/leg100/otf/internal/daemon"
+	"github.com/leg100/otf/internal/resourcechange"
+	"github.com/leg100/otf/internal/run"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestIntegration_ResourceChanges demonstrates the changes made to resources
+// by an apply being recorded and sent to a webhook.
+func TestIntegration_ResourceChanges(t *testing.T) {
+	integrationTest(t)
+
+	// cmdb receives resource changes sent to the webhook
+	received := make(chan *resourcechange.Change, 1)
+	cmdb := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var change resourcechange.Change
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&change))
+		received <- &change
+	}))
+	t.Cleanup(cmdb.Close)
+
+	daemon, org, ctx := setup(t, &config{Config: daemon.Config{
+		ResourceChanges: resourcechange.Config{WebhookURL: cmdb.URL},
+	}})
+
+	sub, unsub := daemon.Runs.Watch(ctx)
+	defer unsub()
+
+	ws := daemon.createWorkspace(t, ctx, org)
+	cv := daemon.createAndUploadConfigurationVersion(t, ctx, ws, nil)
+	r := daemon.createRun(t, ctx, ws, cv)
+applied:
+	for event := range sub {
+		if event.Payload.ID != r.ID {
+			continue
+		}
+		switch event.Payload.Status {
+		case run.RunApplied:
+			break applied
+		case run.RunPlanned:
+			err := daemon.Runs.Apply(ctx, r.ID)
+			require.NoError(t, err)
+		case run.RunErrored:
+			t.Fatal("run unexpectedly finished with an error")
+		}
+	}
+
+	select {
+	case got := <-received:
+		assert.Equal(t, r.ID, got.RunID)
+		assert.Equal(t, resourcechange.ActionCreated, got.Action)
+		assert.Equal(t, "null_resource.test", got.Address)
+		assert.Equal(t, "null_resource", got.Type)
+		assert.Equal(t, "registry.terraform.io/hashicorp/null", got.Provider)
+		assert.Equal(t, ws.Name, got.WorkspaceName)
+	case <-time.After(time.Minute):
+		t.Fatal("timed out waiting for resource change to be sent to webhook")
+	}
+
+	changes, err := daemon.ResourceChanges.List(ctx, r.ID)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(changes))
+	assert.Equal(t, "null_resource.test", changes[0].Address)
+	assert.NotEmpty(t, changes[0].RemoteID)
+}
@@ -1,11 +1,13 @@
 package integration
 
 import (
+	"encoding/json"
 	"testing"
 
 	tfe "github.com/hashicorp/go-tfe"
 	"github.com/leg100/otf/internal"
 	"github.com/leg100/otf/internal/github"
+	"github.com/leg100/otf/internal/resource"
 	"github.com/leg100/otf/internal/run"
 	"github.com/leg100/otf/internal/testutils"
 	"github.com/leg100/otf/internal/vcs"
@@ -72,6 +74,15 @@ func TestIntegration_RunAPI(t *testing.T) {
 				require.NoError(t, err)
 
 				assert.Equal(t, 2, planned.Plan.ResourceReport.Additions)
+
+				// retrieve plan in JSON format, which redirects to a signed
+				// URL
+				planID := resource.ConvertID(created.ID, resource.PlanKind)
+				planJSON, err := tfeClient.Plans.ReadJSONOutput(ctx, planID)
+				require.NoError(t, err)
+				var planFile run.PlanFile
+				require.NoError(t, json.Unmarshal(planJSON, &planFile))
+				assert.Equal(t, 2, len(planFile.ResourceChanges))
 				return // success
 			}
 		}
@@ -0,0 +1,170 @@
+package integration
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha512"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	tfe "github.com/hashicorp/go-tfe"
+	"github.com/leg100/otf/internal"
+	"github.com/leg100/otf/internal/pubsub"
+	"github.com/leg100/otf/internal/run"
+	"github.com/leg100/otf/internal/runtask"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestIntegration_RunTask demonstrates a run waiting on a run task to report
+// its result before proceeding.
+func TestIntegration_RunTask(t *testing.T) {
+	integrationTest(t)
+
+	// taskServer is a run task server that reports the given status for each
+	// request it receives, after verifying the request's signature.
+	taskServer := func(t *testing.T, hmacKey string, status runtask.ResultStatus) string {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			body, err := io.ReadAll(r.Body)
+			require.NoError(t, err)
+
+			mac := hmac.New(sha512.New, []byte(hmacKey))
+			mac.Write(body)
+			assert.Equal(t, hex.EncodeToString(mac.Sum(nil)), r.Header.Get("X-TFC-Task-Signature"))
+
+			var payload struct {
+				AccessToken           string `json:"access_token"`
+				Stage                 string `json:"stage"`
+				TaskResultCallbackURL string `json:"task_result_callback_url"`
+			}
+			require.NoError(t, json.Unmarshal(body, &payload))
+			assert.Equal(t, "pre_plan", payload.Stage)
+			w.WriteHeader(http.StatusOK)
+
+			// report result
+			go func() {
+				callback := []byte(`{"data":{"type":"task-results","attributes":{"status":"` + string(status) + `","message":"scanned"}}}`)
+				req, err := http.NewRequest("PATCH", payload.TaskResultCallbackURL, bytes.NewReader(callback))
+				require.NoError(t, err)
+				req.Header.Set("Authorization", "Bearer "+payload.AccessToken)
+				req.Header.Set("Content-Type", "application/vnd.api+json")
+				resp, err := http.DefaultClient.Do(req)
+				require.NoError(t, err)
+				resp.Body.Close()
+				assert.Equal(t, http.StatusOK, resp.StatusCode)
+			}()
+		}))
+		t.Cleanup(srv.Close)
+		return srv.URL
+	}
+
+	// waitFor waits for the run to reach the status.
+	waitFor := func(t *testing.T, sub <-chan pubsub.Event[*run.Run], runID string, status run.Status) {
+		for event := range sub {
+			if r := event.Payload; r.ID == runID {
+				if r.Status == status {
+					return
+				}
+				require.False(t, r.Done(), "run unexpectedly finished with status %s", r.Status)
+			}
+		}
+		t.Fatal("run events stream closed unexpectedly")
+	}
+
+	tests := []struct {
+		name   string
+		status runtask.ResultStatus
+		want   run.Status
+	}{
+		{"passed", runtask.ResultPassed, run.RunPlanned},
+		{"failed", runtask.ResultFailed, run.RunErrored},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			daemon, org, ctx := setup(t, nil)
+			ws := daemon.createWorkspace(t, ctx, org)
+
+			task, err := daemon.RunTasks.CreateTask(ctx, runtask.CreateTaskOptions{
+				Organization: org.Name,
+				Name:         "scanner",
+				URL:          taskServer(t, "secret", tt.status),
+				Category:     runtask.TaskCategory,
+				HMACKey:      internal.String("secret"),
+			})
+			require.NoError(t, err)
+			stage := run.PrePlanStage
+			_, err = daemon.RunTasks.CreateWorkspaceTask(ctx, ws.ID, runtask.CreateWorkspaceTaskOptions{
+				TaskID:           task.ID,
+				EnforcementLevel: runtask.MandatoryEnforcement,
+				Stage:            &stage,
+			})
+			require.NoError(t, err)
+
+			sub, unsub := daemon.Runs.Watch(ctx)
+			defer unsub()
+
+			cv := daemon.createAndUploadConfigurationVersion(t, ctx, ws, nil)
+			r := daemon.createRun(t, ctx, ws, cv)
+			waitFor(t, sub, r.ID, run.RunPrePlanRunning)
+			waitFor(t, sub, r.ID, tt.want)
+
+			stages, err := daemon.RunTasks.ListStages(ctx, r.ID)
+			require.NoError(t, err)
+			require.Equal(t, 1, len(stages))
+			require.Equal(t, 1, len(stages[0].Results))
+			assert.Equal(t, tt.status, stages[0].Results[0].Status)
+			assert.Equal(t, "scanned", stages[0].Results[0].Message)
+		})
+	}
+}
+
+// TestIntegration_RunTaskAPI tests managing run tasks via the TFE API.
+func TestIntegration_RunTaskAPI(t *testing.T) {
+	integrationTest(t)
+
+	daemon, org, ctx := setup(t, nil)
+	ws := daemon.createWorkspace(t, ctx, org)
+	_, token := daemon.createToken(t, ctx, nil)
+
+	client, err := tfe.NewClient(&tfe.Config{
+		Address:           "https://" + daemon.System.Hostname(),
+		Token:             string(token),
+		RetryServerErrors: true,
+	})
+	require.NoError(t, err)
+
+	task, err := client.RunTasks.Create(ctx, org.Name, tfe.RunTaskCreateOptions{
+		Name:     "scanner",
+		URL:      "https://scanner.example.com",
+		Category: "task",
+		HMACKey:  internal.String("secret"),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "scanner", task.Name)
+	assert.Nil(t, task.HMACKey, "hmac key should not be returned")
+
+	list, err := client.RunTasks.List(ctx, org.Name, nil)
+	require.NoError(t, err)
+	assert.Equal(t, 1, len(list.Items))
+
+	wt, err := client.WorkspaceRunTasks.Create(ctx, ws.ID, tfe.WorkspaceRunTaskCreateOptions{
+		EnforcementLevel: tfe.Mandatory,
+		RunTask:          task,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, tfe.PostPlan, wt.Stage)
+
+	got, err := client.WorkspaceRunTasks.Read(ctx, ws.ID, wt.ID)
+	require.NoError(t, err)
+	assert.Equal(t, task.ID, got.RunTask.ID)
+
+	err = client.RunTasks.Delete(ctx, task.ID)
+	require.NoError(t, err)
+
+	_, err = client.WorkspaceRunTasks.Read(ctx, ws.ID, wt.ID)
+	assert.Equal(t, tfe.ErrResourceNotFound, err)
+}
@@ -101,6 +101,21 @@ func TestRun(t *testing.T) {
 		assert.Equal(t, user.Username, *got.CreatedBy)
 	})
 
+	t.Run("get policy", func(t *testing.T) {
+		svc, _, ctx := setup(t, &config{Config: daemon.Config{DisableScheduler: true}})
+		run := svc.createRun(t, ctx, nil, nil)
+
+		policy, err := svc.Runs.GetPolicy(ctx, run.ID)
+		require.NoError(t, err)
+		assert.Equal(t, run.WorkspaceID, policy.WorkspaceID)
+
+		// a user without access to the run's workspace should not be able to
+		// determine whether the run exists.
+		_, otherCtx := svc.createUserCtx(t)
+		_, err = svc.Runs.GetPolicy(otherCtx, run.ID)
+		assert.ErrorIs(t, err, internal.ErrResourceNotFound)
+	})
+
 	t.Run("list", func(t *testing.T) {
 		svc, _, ctx := setup(t, &config{Config: daemon.Config{DisableScheduler: true}})
 
@@ -0,0 +1,125 @@
+package integration
+
+import (
+	"testing"
+
+	"github.com/leg100/otf/internal"
+	"github.com/leg100/otf/internal/run"
+	"github.com/leg100/otf/internal/runtrigger"
+	"github.com/leg100/otf/internal/workspace"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIntegration_RunTrigger(t *testing.T) {
+	integrationTest(t)
+
+	t.Run("create", func(t *testing.T) {
+		svc, org, ctx := setup(t, nil)
+		upstream := svc.createWorkspace(t, ctx, org)
+		downstream := svc.createWorkspace(t, ctx, org)
+
+		rt, err := svc.RunTriggers.Create(ctx, downstream.ID, upstream.ID)
+		require.NoError(t, err)
+		assert.Equal(t, downstream.Name, rt.WorkspaceName)
+		assert.Equal(t, upstream.Name, rt.SourceableName)
+
+		t.Run("duplicate", func(t *testing.T) {
+			_, err := svc.RunTriggers.Create(ctx, downstream.ID, upstream.ID)
+			assert.Equal(t, internal.ErrResourceAlreadyExists, err)
+		})
+	})
+
+	t.Run("same workspace", func(t *testing.T) {
+		svc, org, ctx := setup(t, nil)
+		ws := svc.createWorkspace(t, ctx, org)
+
+		_, err := svc.RunTriggers.Create(ctx, ws.ID, ws.ID)
+		assert.Equal(t, runtrigger.ErrSameWorkspace, err)
+	})
+
+	t.Run("different organization", func(t *testing.T) {
+		svc, org, ctx := setup(t, nil)
+		ws := svc.createWorkspace(t, ctx, org)
+		other := svc.createWorkspace(t, ctx, nil)
+
+		_, err := svc.RunTriggers.Create(ctx, ws.ID, other.ID)
+		assert.Equal(t, runtrigger.ErrDifferentOrganization, err)
+	})
+
+	t.Run("list", func(t *testing.T) {
+		svc, org, ctx := setup(t, nil)
+		upstream := svc.createWorkspace(t, ctx, org)
+		downstream1 := svc.createWorkspace(t, ctx, org)
+		downstream2 := svc.createWorkspace(t, ctx, org)
+
+		rt1, err := svc.RunTriggers.Create(ctx, downstream1.ID, upstream.ID)
+		require.NoError(t, err)
+		rt2, err := svc.RunTriggers.Create(ctx, downstream2.ID, upstream.ID)
+		require.NoError(t, err)
+
+		got, err := svc.RunTriggers.List(ctx, upstream.ID, runtrigger.Outbound)
+		require.NoError(t, err)
+		assert.Equal(t, 2, len(got))
+		assert.Contains(t, got, rt1)
+		assert.Contains(t, got, rt2)
+
+		got, err = svc.RunTriggers.List(ctx, downstream1.ID, runtrigger.Inbound)
+		require.NoError(t, err)
+		assert.Equal(t, []*runtrigger.RunTrigger{rt1}, got)
+	})
+
+	t.Run("get", func(t *testing.T) {
+		svc, org, ctx := setup(t, nil)
+		upstream := svc.createWorkspace(t, ctx, org)
+		downstream := svc.createWorkspace(t, ctx, org)
+		want, err := svc.RunTriggers.Create(ctx, downstream.ID, upstream.ID)
+		require.NoError(t, err)
+
+		got, err := svc.RunTriggers.Get(ctx, want.ID)
+		require.NoError(t, err)
+		assert.Equal(t, want, got)
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		svc, org, ctx := setup(t, nil)
+		upstream := svc.createWorkspace(t, ctx, org)
+		downstream := svc.createWorkspace(t, ctx, org)
+		rt, err := svc.RunTriggers.Create(ctx, downstream.ID, upstream.ID)
+		require.NoError(t, err)
+
+		err = svc.RunTriggers.Delete(ctx, rt.ID)
+		require.NoError(t, err)
+
+		_, err = svc.RunTriggers.Get(ctx, rt.ID)
+		assert.Equal(t, internal.ErrResourceNotFound, err)
+	})
+
+	t.Run("queue run following apply", func(t *testing.T) {
+		svc, org, ctx := setup(t, nil)
+		upstream, err := svc.Workspaces.Create(ctx, workspace.CreateOptions{
+			Name:         internal.String("upstream"),
+			Organization: internal.String(org.Name),
+			AutoApply:    internal.Bool(true),
+		})
+		require.NoError(t, err)
+		downstream := svc.createWorkspace(t, ctx, org)
+		svc.createAndUploadConfigurationVersion(t, ctx, downstream, nil)
+
+		_, err = svc.RunTriggers.Create(ctx, downstream.ID, upstream.ID)
+		require.NoError(t, err)
+
+		sub, unsub := svc.Runs.Watch(ctx)
+		defer unsub()
+
+		cv := svc.createAndUploadConfigurationVersion(t, ctx, upstream, nil)
+		svc.createRun(t, ctx, upstream, cv)
+
+		for event := range sub {
+			if event.Payload.WorkspaceID == downstream.ID {
+				assert.Equal(t, run.SourceRunTrigger, event.Payload.Source)
+				break
+			}
+		}
+	})
+}
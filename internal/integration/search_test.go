@@ -0,0 +1,111 @@
+package integration
+
+import (
+	"testing"
+
+	"github.com/leg100/otf/internal"
+	"github.com/leg100/otf/internal/configversion"
+	"github.com/leg100/otf/internal/rbac"
+	"github.com/leg100/otf/internal/run"
+	"github.com/leg100/otf/internal/search"
+	"github.com/leg100/otf/internal/workspace"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestIntegration_Search demonstrates searching workspaces and runs.
+func TestIntegration_Search(t *testing.T) {
+	integrationTest(t)
+
+	daemon, org, ctx := setup(t, nil)
+
+	networking, err := daemon.Workspaces.Create(ctx, workspace.CreateOptions{
+		Name:         internal.String("networking-prod"),
+		Description:  internal.String("VPCs and subnets"),
+		Organization: internal.String(org.Name),
+		Tags:         []workspace.TagSpec{{Name: "platform"}},
+	})
+	require.NoError(t, err)
+	billing, err := daemon.Workspaces.Create(ctx, workspace.CreateOptions{
+		Name:         internal.String("billing-prod"),
+		Organization: internal.String(org.Name),
+	})
+	require.NoError(t, err)
+
+	cv := daemon.createConfigurationVersion(t, ctx, billing, &configversion.CreateOptions{
+		IngressAttributes: &configversion.IngressAttributes{
+			CommitSHA: "0123456789abcdef0123456789abcdef01234567",
+		},
+	})
+	billingRun, err := daemon.Runs.Create(ctx, billing.ID, run.CreateOptions{
+		ConfigurationVersionID: internal.String(cv.ID),
+		Message:                internal.String("Widen the private subnet"),
+	})
+	require.NoError(t, err)
+
+	searchIDs := func(t *testing.T, query string, kinds ...search.Kind) []string {
+		t.Helper()
+
+		page, err := daemon.Search.Search(ctx, org.Name, search.SearchOptions{
+			Query: query,
+			Kinds: kinds,
+		})
+		require.NoError(t, err)
+		var got []string
+		for _, r := range page.Items {
+			got = append(got, r.ID)
+		}
+		return got
+	}
+
+	t.Run("workspace name prefix", func(t *testing.T) {
+		assert.Equal(t, []string{networking.ID}, searchIDs(t, "netw"))
+	})
+
+	t.Run("workspace description", func(t *testing.T) {
+		assert.Equal(t, []string{networking.ID}, searchIDs(t, "vpcs"))
+	})
+
+	t.Run("workspace tag", func(t *testing.T) {
+		assert.Equal(t, []string{networking.ID}, searchIDs(t, "plat"))
+	})
+
+	t.Run("all words must match", func(t *testing.T) {
+		assert.Equal(t, []string{billing.ID}, searchIDs(t, "billing prod"))
+	})
+
+	t.Run("run message", func(t *testing.T) {
+		assert.Equal(t, []string{billingRun.ID}, searchIDs(t, "private subnet", search.RunKind))
+	})
+
+	t.Run("run commit sha", func(t *testing.T) {
+		assert.Equal(t, []string{billingRun.ID}, searchIDs(t, "0123456"))
+	})
+
+	t.Run("workspaces and runs", func(t *testing.T) {
+		assert.ElementsMatch(t, []string{networking.ID, billingRun.ID}, searchIDs(t, "subnet"))
+	})
+
+	t.Run("no results", func(t *testing.T) {
+		assert.Empty(t, searchIDs(t, "storage"))
+	})
+
+	t.Run("restricted to permitted workspaces", func(t *testing.T) {
+		engineer, engineerCtx := daemon.createUserCtx(t)
+		team := daemon.createTeam(t, ctx, org)
+		err := daemon.Users.AddTeamMembership(ctx, team.ID, []string{engineer.Username})
+		require.NoError(t, err)
+		err = daemon.Workspaces.SetPermission(ctx, billing.ID, team.ID, rbac.WorkspaceReadRole)
+		require.NoError(t, err)
+
+		page, err := daemon.Search.Search(engineerCtx, org.Name, search.SearchOptions{Query: "prod"})
+		require.NoError(t, err)
+		require.Len(t, page.Items, 1)
+		assert.Equal(t, billing.ID, page.Items[0].ID)
+	})
+
+	t.Run("cli", func(t *testing.T) {
+		out := daemon.otfcli(t, ctx, "search", "netw", "--organization", org.Name)
+		assert.Equal(t, networking.ID+" networking-prod\n", out)
+	})
+}
@@ -0,0 +1,114 @@
+package integration
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"testing"
+
+	"github.com/leg100/otf/internal"
+	"github.com/leg100/otf/internal/sshkey"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIntegration_SSHKey(t *testing.T) {
+	integrationTest(t)
+
+	newKey := func(t *testing.T) *string {
+		_, priv, err := ed25519.GenerateKey(rand.Reader)
+		require.NoError(t, err)
+		der, err := x509.MarshalPKCS8PrivateKey(priv)
+		require.NoError(t, err)
+		return internal.String(string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})))
+	}
+
+	t.Run("create", func(t *testing.T) {
+		svc, org, ctx := setup(t, nil)
+
+		key, err := svc.SSHKeys.Create(ctx, org.Name, sshkey.CreateOptions{
+			Name:  internal.String("deploy"),
+			Value: newKey(t),
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "deploy", key.Name)
+
+		t.Run("duplicate name", func(t *testing.T) {
+			_, err := svc.SSHKeys.Create(ctx, org.Name, sshkey.CreateOptions{
+				Name:  internal.String("deploy"),
+				Value: newKey(t),
+			})
+			assert.Equal(t, internal.ErrResourceAlreadyExists, err)
+		})
+	})
+
+	t.Run("invalid key", func(t *testing.T) {
+		svc, org, ctx := setup(t, nil)
+
+		_, err := svc.SSHKeys.Create(ctx, org.Name, sshkey.CreateOptions{
+			Name:  internal.String("deploy"),
+			Value: internal.String("not-a-key"),
+		})
+		assert.ErrorIs(t, err, sshkey.ErrInvalidPrivateKey)
+	})
+
+	t.Run("list, update and delete", func(t *testing.T) {
+		svc, org, ctx := setup(t, nil)
+		key1, err := svc.SSHKeys.Create(ctx, org.Name, sshkey.CreateOptions{Name: internal.String("key-1"), Value: newKey(t)})
+		require.NoError(t, err)
+		key2, err := svc.SSHKeys.Create(ctx, org.Name, sshkey.CreateOptions{Name: internal.String("key-2"), Value: newKey(t)})
+		require.NoError(t, err)
+
+		got, err := svc.SSHKeys.List(ctx, org.Name)
+		require.NoError(t, err)
+		if assert.Len(t, got, 2) {
+			assert.Contains(t, got, key1)
+			assert.Contains(t, got, key2)
+		}
+
+		updated, err := svc.SSHKeys.Update(ctx, key1.ID, sshkey.UpdateOptions{Name: internal.String("renamed")})
+		require.NoError(t, err)
+		assert.Equal(t, "renamed", updated.Name)
+
+		err = svc.SSHKeys.Delete(ctx, key2.ID)
+		require.NoError(t, err)
+
+		_, err = svc.SSHKeys.Get(ctx, key2.ID)
+		assert.Equal(t, internal.ErrResourceNotFound, err)
+	})
+
+	t.Run("assign to workspace", func(t *testing.T) {
+		svc, org, ctx := setup(t, nil)
+		ws := svc.createWorkspace(t, ctx, org)
+		value := newKey(t)
+		key, err := svc.SSHKeys.Create(ctx, org.Name, sshkey.CreateOptions{Name: internal.String("deploy"), Value: value})
+		require.NoError(t, err)
+
+		_, err = svc.SSHKeys.GetWorkspaceKey(ctx, ws.ID)
+		assert.Equal(t, internal.ErrResourceNotFound, err)
+
+		err = svc.SSHKeys.AssignToWorkspace(ctx, ws.ID, key.ID)
+		require.NoError(t, err)
+
+		got, err := svc.SSHKeys.GetWorkspaceKey(ctx, ws.ID)
+		require.NoError(t, err)
+		assert.Equal(t, *value, string(got))
+
+		err = svc.SSHKeys.UnassignFromWorkspace(ctx, ws.ID)
+		require.NoError(t, err)
+
+		_, err = svc.SSHKeys.GetWorkspaceKey(ctx, ws.ID)
+		assert.Equal(t, internal.ErrResourceNotFound, err)
+	})
+
+	t.Run("assign key from different organization", func(t *testing.T) {
+		svc, org, ctx := setup(t, nil)
+		ws := svc.createWorkspace(t, ctx, nil)
+		key, err := svc.SSHKeys.Create(ctx, org.Name, sshkey.CreateOptions{Name: internal.String("deploy"), Value: newKey(t)})
+		require.NoError(t, err)
+
+		err = svc.SSHKeys.AssignToWorkspace(ctx, ws.ID, key.ID)
+		assert.Equal(t, sshkey.ErrDifferentOrganization, err)
+	})
+}
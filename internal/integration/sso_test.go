@@ -0,0 +1,62 @@
+package integration
+
+import (
+	"testing"
+
+	"github.com/leg100/otf/internal"
+	"github.com/leg100/otf/internal/sso"
+	otfuser "github.com/leg100/otf/internal/user"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIntegration_SSOEnforcement(t *testing.T) {
+	integrationTest(t)
+
+	svc, org, ctx := setup(t, nil)
+	team := svc.createTeam(t, ctx, org)
+	member := svc.createUser(t, otfuser.WithTeams(team))
+	breakGlass := svc.createUser(t, otfuser.WithTeams(team))
+	outsider := svc.createUser(t)
+
+	t.Run("not enforced", func(t *testing.T) {
+		_, err := svc.SSO.Get(ctx, org.Name)
+		assert.ErrorIs(t, err, internal.ErrResourceNotFound)
+
+		err = svc.SSO.EnforceSSO(ctx, svc.getUser(t, adminCtx, member.Username))
+		assert.NoError(t, err)
+	})
+
+	t.Run("owner cannot lock themselves out", func(t *testing.T) {
+		_, err := svc.SSO.Enforce(ctx, org.Name, sso.EnforceOptions{})
+		assert.ErrorIs(t, err, sso.ErrLockout)
+	})
+
+	t.Run("enforce", func(t *testing.T) {
+		_, err := svc.SSO.Enforce(adminCtx, org.Name, sso.EnforceOptions{
+			ExemptUsernames: []string{breakGlass.Username},
+		})
+		require.NoError(t, err)
+
+		got, err := svc.SSO.Get(ctx, org.Name)
+		require.NoError(t, err)
+		assert.Equal(t, []string{breakGlass.Username}, got.ExemptUsernames)
+
+		err = svc.SSO.EnforceSSO(ctx, svc.getUser(t, adminCtx, member.Username))
+		assert.ErrorIs(t, err, sso.ErrSSORequired)
+
+		err = svc.SSO.EnforceSSO(ctx, svc.getUser(t, adminCtx, breakGlass.Username))
+		assert.NoError(t, err)
+
+		err = svc.SSO.EnforceSSO(ctx, svc.getUser(t, adminCtx, outsider.Username))
+		assert.NoError(t, err)
+	})
+
+	t.Run("unenforce", func(t *testing.T) {
+		err := svc.SSO.Unenforce(adminCtx, org.Name)
+		require.NoError(t, err)
+
+		err = svc.SSO.EnforceSSO(ctx, svc.getUser(t, adminCtx, member.Username))
+		assert.NoError(t, err)
+	})
+}
@@ -0,0 +1,57 @@
+package integration
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/leg100/otf/internal/run"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestIntegration_StalePlan demonstrates a plan being rejected for apply
+// because the workspace state changed after the run was planned, and the run
+// then being replanned.
+func TestIntegration_StalePlan(t *testing.T) {
+	integrationTest(t)
+
+	daemon, _, ctx := setup(t, nil)
+	ws := daemon.createWorkspace(t, ctx, nil)
+
+	sub, unsub := daemon.Runs.Watch(ctx)
+	defer unsub()
+
+	waitPlanned := func(t *testing.T, runID string) {
+		for event := range sub {
+			if r := event.Payload; r.ID == runID {
+				if r.Status == run.RunPlanned {
+					return
+				}
+				require.False(t, r.Done(), "run unexpectedly finished with status %s", r.Status)
+			}
+		}
+		t.Fatal("run events stream closed unexpectedly")
+	}
+
+	cv := daemon.createAndUploadConfigurationVersion(t, ctx, ws, nil)
+	stale := daemon.createRun(t, ctx, ws, cv)
+	waitPlanned(t, stale.ID)
+
+	// state is written after the run is planned
+	daemon.createStateVersion(t, ctx, ws)
+
+	err := daemon.Runs.Apply(ctx, stale.ID)
+	assert.True(t, errors.Is(err, run.ErrStalePlan), "got error: %v", err)
+
+	replanned, err := daemon.Runs.Replan(ctx, stale.ID)
+	require.NoError(t, err)
+	assert.Equal(t, cv.ID, replanned.ConfigurationVersionID)
+
+	got, err := daemon.Runs.Get(ctx, stale.ID)
+	require.NoError(t, err)
+	assert.Equal(t, run.RunDiscarded, got.Status)
+
+	waitPlanned(t, replanned.ID)
+	err = daemon.Runs.Apply(ctx, replanned.ID)
+	require.NoError(t, err)
+}
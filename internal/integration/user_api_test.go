@@ -0,0 +1,70 @@
+package integration
+
+import (
+	"testing"
+
+	tfe "github.com/hashicorp/go-tfe"
+	"github.com/leg100/otf/internal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestIntegration_UserAPI tests the account and user API endpoints using the
+// go-tfe client.
+func TestIntegration_UserAPI(t *testing.T) {
+	integrationTest(t)
+
+	daemon, org, ctx := setup(t, nil)
+	user := userFromContext(t, ctx)
+	_, token := daemon.createToken(t, ctx, nil)
+
+	tfeClient, err := tfe.NewClient(&tfe.Config{
+		Address:           "https://" + daemon.System.Hostname(),
+		Token:             string(token),
+		RetryServerErrors: true,
+	})
+	require.NoError(t, err)
+
+	t.Run("read current user", func(t *testing.T) {
+		got, err := tfeClient.Users.ReadCurrent(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, user.ID, got.ID)
+		assert.Equal(t, user.Username, got.Username)
+	})
+
+	t.Run("update current user", func(t *testing.T) {
+		got, err := tfeClient.Users.UpdateCurrent(ctx, tfe.UserUpdateOptions{
+			Username: internal.String(user.Username),
+		})
+		require.NoError(t, err)
+		assert.Equal(t, user.ID, got.ID)
+	})
+
+	t.Run("cannot change username", func(t *testing.T) {
+		_, err := tfeClient.Users.UpdateCurrent(ctx, tfe.UserUpdateOptions{
+			Username: internal.String("new-name"),
+		})
+		assert.Error(t, err)
+	})
+
+	t.Run("read user in same organization", func(t *testing.T) {
+		colleague := daemon.createUser(t)
+		err := daemon.Users.AddTeamMembership(ctx, daemon.createTeam(t, ctx, org).ID, []string{colleague.Username})
+		require.NoError(t, err)
+
+		req, err := tfeClient.NewRequest("GET", "users/"+colleague.ID, nil)
+		require.NoError(t, err)
+		var got tfe.User
+		require.NoError(t, req.Do(ctx, &got))
+		assert.Equal(t, colleague.Username, got.Username)
+	})
+
+	t.Run("cannot read user in other organization", func(t *testing.T) {
+		stranger := daemon.createUser(t)
+
+		req, err := tfeClient.NewRequest("GET", "users/"+stranger.ID, nil)
+		require.NoError(t, err)
+		var got tfe.User
+		assert.Error(t, req.Do(ctx, &got))
+	})
+}
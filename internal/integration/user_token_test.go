@@ -4,6 +4,7 @@ import (
 	"context"
 	"testing"
 
+	tfe "github.com/hashicorp/go-tfe"
 	"github.com/leg100/otf/internal"
 	"github.com/leg100/otf/internal/user"
 	"github.com/stretchr/testify/assert"
@@ -46,4 +47,69 @@ func TestUserToken(t *testing.T) {
 		err := svc.Users.DeleteToken(ctx, token.ID)
 		require.NoError(t, err)
 	})
+
+	t.Run("restricted to organization", func(t *testing.T) {
+		svc, org, ctx := setup(t, nil)
+		other := svc.createOrganization(t, ctx)
+		_, token, err := svc.Users.CreateToken(ctx, user.CreateUserTokenOptions{
+			Description:  "acme ci",
+			Organization: &org.Name,
+		})
+		require.NoError(t, err)
+
+		client, err := tfe.NewClient(&tfe.Config{
+			Address:           "https://" + svc.System.Hostname(),
+			Token:             string(token),
+			RetryServerErrors: true,
+		})
+		require.NoError(t, err)
+
+		_, err = client.Organizations.Read(ctx, org.Name)
+		assert.NoError(t, err)
+
+		_, err = client.Organizations.Read(ctx, other.Name)
+		assert.Error(t, err)
+	})
+
+	t.Run("restricted to scopes", func(t *testing.T) {
+		svc, org, ctx := setup(t, nil)
+		ws := svc.createWorkspace(t, ctx, org)
+		_, token, err := svc.Users.CreateToken(ctx, user.CreateUserTokenOptions{
+			Description: "read-only",
+			Scopes:      []string{"read"},
+		})
+		require.NoError(t, err)
+
+		client, err := tfe.NewClient(&tfe.Config{
+			Address:           "https://" + svc.System.Hostname(),
+			Token:             string(token),
+			RetryServerErrors: true,
+		})
+		require.NoError(t, err)
+
+		_, err = client.Workspaces.ReadByID(ctx, ws.ID)
+		assert.NoError(t, err)
+
+		_, err = client.Workspaces.Lock(ctx, ws.ID, tfe.WorkspaceLockOptions{})
+		assert.Error(t, err)
+	})
+
+	t.Run("cannot restrict to organization of which user is not a member", func(t *testing.T) {
+		svc, _, _ := setup(t, nil)
+		ctx := internal.AddSubjectToContext(ctx, svc.createUser(t))
+		_, _, err := svc.Users.CreateToken(ctx, user.CreateUserTokenOptions{
+			Description:  "lorem ipsum...",
+			Organization: internal.String("acme-corp"),
+		})
+		assert.ErrorIs(t, err, internal.ErrAccessNotPermitted)
+	})
+
+	t.Run("unknown scope", func(t *testing.T) {
+		svc, _, ctx := setup(t, nil)
+		_, _, err := svc.Users.CreateToken(ctx, user.CreateUserTokenOptions{
+			Description: "lorem ipsum...",
+			Scopes:      []string{"superpowers"},
+		})
+		assert.ErrorIs(t, err, user.ErrUnknownTokenScope)
+	})
 }
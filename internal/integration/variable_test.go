@@ -69,4 +69,51 @@ func TestVariable(t *testing.T) {
 		require.NoError(t, err)
 		assert.Equal(t, want, got.Variable)
 	})
+
+	t.Run("import and export", func(t *testing.T) {
+		svc, _, ctx := setup(t, nil)
+		ws := svc.createWorkspace(t, ctx, nil)
+		_, err := svc.Variables.CreateWorkspaceVariable(ctx, ws.ID, variable.CreateVariableOptions{
+			Key:      internal.String("foo"),
+			Value:    internal.String("bar"),
+			Category: variable.VariableCategoryPtr(variable.CategoryTerraform),
+		})
+		require.NoError(t, err)
+
+		tfvars := []byte("foo = \"baz\"\nlist = [1, 2]\n")
+
+		// dry run should report changes without persisting them
+		result, err := svc.Variables.ImportWorkspaceVariables(ctx, ws.ID, variable.ImportOptions{
+			Format: variable.FormatTFVars,
+			Data:   tfvars,
+			DryRun: true,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"list"}, result.Created)
+		assert.Equal(t, []string{"foo"}, result.Updated)
+
+		got, err := svc.Variables.ListWorkspaceVariables(ctx, ws.ID)
+		require.NoError(t, err)
+		if assert.Len(t, got, 1) {
+			assert.Equal(t, "bar", got[0].Value)
+		}
+
+		_, err = svc.Variables.ImportWorkspaceVariables(ctx, ws.ID, variable.ImportOptions{
+			Format: variable.FormatTFVars,
+			Data:   tfvars,
+		})
+		require.NoError(t, err)
+
+		exported, err := svc.Variables.ExportWorkspaceVariables(ctx, ws.ID, variable.FormatTFVars)
+		require.NoError(t, err)
+		assert.Equal(t, "foo = \"baz\"\nlist = [1, 2]\n", string(exported))
+
+		t.Run("invalid import is rejected", func(t *testing.T) {
+			_, err := svc.Variables.ImportWorkspaceVariables(ctx, ws.ID, variable.ImportOptions{
+				Format: variable.FormatDotEnv,
+				Data:   []byte("FOO=bar\nFOO=baz\n"),
+			})
+			assert.ErrorIs(t, err, variable.ErrInvalidImport)
+		})
+	})
 }
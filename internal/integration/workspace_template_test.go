@@ -0,0 +1,79 @@
+package integration
+
+import (
+	"testing"
+
+	"github.com/leg100/otf/internal"
+	"github.com/leg100/otf/internal/rbac"
+	"github.com/leg100/otf/internal/workspacetemplate"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIntegration_WorkspaceTemplate(t *testing.T) {
+	integrationTest(t)
+
+	svc, org, ctx := setup(t, nil)
+	tmpl := svc.createWorkspace(t, ctx, org)
+	v := svc.createVariable(t, ctx, tmpl)
+	team := svc.createTeam(t, ctx, org)
+	err := svc.Workspaces.SetPermission(ctx, tmpl.ID, team.ID, rbac.WorkspaceWriteRole)
+	require.NoError(t, err)
+	nc := svc.createNotificationConfig(t, ctx, tmpl)
+
+	t.Run("not a template", func(t *testing.T) {
+		_, err := svc.Templates.CreateFromTemplate(ctx, tmpl.ID, workspacetemplate.CreateOptions{Name: "dev"})
+		assert.ErrorIs(t, err, workspacetemplate.ErrNotTemplate)
+	})
+
+	err = svc.Templates.MarkTemplate(ctx, tmpl.ID)
+	require.NoError(t, err)
+
+	t.Run("list", func(t *testing.T) {
+		got, err := svc.Templates.ListTemplates(ctx, org.Name)
+		require.NoError(t, err)
+
+		require.Len(t, got, 1)
+		assert.Equal(t, tmpl.ID, got[0].WorkspaceID)
+		assert.Equal(t, tmpl.Name, got[0].Name)
+	})
+
+	t.Run("create from template", func(t *testing.T) {
+		ws, err := svc.Templates.CreateFromTemplate(ctx, tmpl.ID, workspacetemplate.CreateOptions{
+			Name:      "dev",
+			Variables: []workspacetemplate.VariableOverride{{Key: v.Key, Value: "overridden"}},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "dev", ws.Name)
+		assert.Equal(t, tmpl.TerraformVersion, ws.TerraformVersion)
+
+		vars, err := svc.Variables.ListWorkspaceVariables(ctx, ws.ID)
+		require.NoError(t, err)
+		require.Len(t, vars, 1)
+		assert.Equal(t, v.Key, vars[0].Key)
+		assert.Equal(t, "overridden", vars[0].Value)
+
+		policy, err := svc.Workspaces.GetPolicy(ctx, ws.ID)
+		require.NoError(t, err)
+		assert.Contains(t, policy.Permissions, internal.WorkspacePermission{TeamID: team.ID, Role: rbac.WorkspaceWriteRole})
+
+		configs, err := svc.Notifications.List(ctx, ws.ID)
+		require.NoError(t, err)
+		require.Len(t, configs, 1)
+		assert.Equal(t, nc.Name, configs[0].Name)
+	})
+
+	t.Run("name already taken", func(t *testing.T) {
+		_, err := svc.Templates.CreateFromTemplate(ctx, tmpl.ID, workspacetemplate.CreateOptions{Name: tmpl.Name})
+		assert.Error(t, err)
+	})
+
+	t.Run("unmark", func(t *testing.T) {
+		err := svc.Templates.UnmarkTemplate(ctx, tmpl.ID)
+		require.NoError(t, err)
+
+		got, err := svc.Templates.ListTemplates(ctx, org.Name)
+		require.NoError(t, err)
+		assert.Empty(t, got)
+	})
+}
@@ -2,10 +2,13 @@ package logs
 
 import (
 	"bytes"
+	"encoding/json"
 	"io"
 	"net/http"
 
 	otfapi "github.com/leg100/otf/internal/api"
+	"github.com/leg100/otf/internal/resource"
+	"github.com/leg100/otf/internal/tfeapi"
 
 	"github.com/gorilla/mux"
 	"github.com/leg100/otf/internal"
@@ -24,9 +27,16 @@ func (a *api) addHandlers(r *mux.Router) {
 	signed.Use(internal.VerifySignedURL(a.Verifier))
 	signed.HandleFunc("/runs/{run_id}/logs/{phase}", a.getLogs).Methods("GET")
 
+	// client is typically terraform-cli or other TFC-compatible tooling
+	tfe := r.PathPrefix(tfeapi.APIPrefixV2).Subrouter()
+	tfe.HandleFunc("/plans/{plan_id}/logs", a.getPlanLogs).Methods("GET")
+	tfe.HandleFunc("/applies/{apply_id}/logs", a.getApplyLogs).Methods("GET")
+
 	// client is typically otf-agent
 	r = r.PathPrefix(otfapi.DefaultBasePath).Subrouter()
 	r.HandleFunc("/runs/{run_id}/logs/{phase}", a.putLogs).Methods("PUT")
+	r.HandleFunc("/runs/{run_id}/logs/{phase}", a.appendLogs).Methods("POST")
+	r.HandleFunc("/workspaces/{workspace_id}/logs/search", a.searchLogs).Methods("GET")
 }
 
 func (a *api) getLogs(w http.ResponseWriter, r *http.Request) {
@@ -46,6 +56,70 @@ func (a *api) getLogs(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// getPlanLogs reads a chunk of a plan's logs, using the limit and offset
+// query parameters.
+func (a *api) getPlanLogs(w http.ResponseWriter, r *http.Request) {
+	a.readLogs(w, r, "plan_id", internal.PlanPhase)
+}
+
+// getApplyLogs reads a chunk of an apply's logs, using the limit and offset
+// query parameters.
+func (a *api) getApplyLogs(w http.ResponseWriter, r *http.Request) {
+	a.readLogs(w, r, "apply_id", internal.ApplyPhase)
+}
+
+func (a *api) readLogs(w http.ResponseWriter, r *http.Request, param string, phase internal.PhaseType) {
+	var params struct {
+		Limit  int `schema:"limit"`
+		Offset int `schema:"offset"`
+	}
+	if err := decode.All(&params, r); err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	id, err := decode.Param(param, r)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	// otf's plan and apply IDs are simply the corresponding run ID
+	chunk, err := a.svc.ReadChunk(r.Context(), internal.GetChunkOptions{
+		RunID:  resource.ConvertID(id, resource.RunKind),
+		Phase:  phase,
+		Limit:  params.Limit,
+		Offset: params.Offset,
+	})
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "text/plain")
+	if _, err := w.Write(chunk.Data); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
+func (a *api) appendLogs(w http.ResponseWriter, r *http.Request) {
+	var params struct {
+		RunID string             `schema:"run_id,required"`
+		Phase internal.PhaseType `schema:"phase,required"`
+	}
+	if err := decode.All(&params, r); err != nil {
+		http.Error(w, err.Error(), http.StatusUnprocessableEntity)
+		return
+	}
+	buf := new(bytes.Buffer)
+	if _, err := io.Copy(buf, r.Body); err != nil {
+		http.Error(w, err.Error(), http.StatusUnprocessableEntity)
+		return
+	}
+	if err := a.svc.AppendChunk(r.Context(), params.RunID, params.Phase, buf.Bytes()); err != nil {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+}
+
 func (a *api) putLogs(w http.ResponseWriter, r *http.Request) {
 	var opts internal.PutChunkOptions
 	if err := decode.All(&opts, r); err != nil {
@@ -63,3 +137,21 @@ func (a *api) putLogs(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 }
+
+func (a *api) searchLogs(w http.ResponseWriter, r *http.Request) {
+	var params struct {
+		WorkspaceID string `schema:"workspace_id,required"`
+		SearchOptions
+	}
+	if err := decode.All(&params, r); err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	result, err := a.svc.SearchLogs(r.Context(), params.WorkspaceID, params.SearchOptions)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(result)
+}
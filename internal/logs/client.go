@@ -33,3 +33,13 @@ func (c *Client) PutChunk(ctx context.Context, opts internal.PutChunkOptions) er
 
 	return nil
 }
+
+// AppendChunk appends data to the logs of a run phase.
+func (c *Client) AppendChunk(ctx context.Context, runID string, phase internal.PhaseType, data []byte) error {
+	u := fmt.Sprintf("runs/%s/logs/%s", url.QueryEscape(runID), url.QueryEscape(string(phase)))
+	req, err := c.NewRequest("POST", u, data)
+	if err != nil {
+		return err
+	}
+	return c.Do(ctx, req, nil)
+}
@@ -0,0 +1,188 @@
+package logs
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/leg100/otf/internal"
+	"github.com/leg100/otf/internal/http/html/paths"
+	"github.com/leg100/otf/internal/rbac"
+	"github.com/leg100/otf/internal/sql"
+)
+
+const (
+	// defaultSearchRuns is the number of most recent runs searched by default.
+	defaultSearchRuns = 20
+	// maxSearchRuns is the maximum number of runs that can be searched at
+	// once.
+	maxSearchRuns = 100
+	// maxSearchMatches is the maximum number of matching lines returned.
+	maxSearchMatches = 1000
+)
+
+type (
+	// SearchOptions are options for searching the logs of a workspace's runs.
+	SearchOptions struct {
+		// Query is the string to search for.
+		Query string `schema:"q,required"`
+		// Regex treats the query as a regular expression, using RE2 syntax.
+		Regex bool `schema:"regex"`
+		// IgnoreCase matches the query case-insensitively.
+		IgnoreCase bool `schema:"ignore_case"`
+		// Runs is the number of most recent runs to search. Defaults to 20
+		// and cannot exceed 100.
+		Runs int `schema:"runs"`
+	}
+
+	// SearchResult is the result of searching a workspace's run logs.
+	SearchResult struct {
+		// Matches are the matching log lines, from the newest run to the
+		// oldest, and in order of appearance within a run's logs.
+		Matches []*LogMatch `json:"matches"`
+		// RunsSearched is the number of runs with logs that were searched.
+		RunsSearched int `json:"runs_searched"`
+		// Truncated is true if there were more matches than were returned.
+		Truncated bool `json:"truncated"`
+	}
+
+	// LogMatch is a line of a run's logs matching a search.
+	LogMatch struct {
+		RunID string `json:"run_id"`
+		// RunURL is the path to the run's page in the web UI.
+		RunURL    string             `json:"run_url"`
+		Phase     internal.PhaseType `json:"phase"`
+		CreatedAt time.Time          `json:"created_at"`
+		// Line is the line number of the match in the phase's logs, starting
+		// at 1.
+		Line int `json:"line"`
+		// Text is the line, stripped of ANSI escape codes.
+		Text string `json:"text"`
+		// Highlights are the positions of the matches within the line.
+		Highlights []Highlight `json:"highlights"`
+	}
+
+	// Highlight is the position of a match within a line, as byte offsets
+	// into the line, with the end exclusive.
+	Highlight struct {
+		Start int `json:"start"`
+		End   int `json:"end"`
+	}
+
+	// runLogs are the logs of a phase of a run.
+	runLogs struct {
+		runID     string
+		createdAt time.Time
+		phase     internal.PhaseType
+		data      []byte
+	}
+)
+
+func (opts SearchOptions) compile() (*regexp.Regexp, error) {
+	if opts.Query == "" {
+		return nil, &internal.MissingParameterError{Parameter: "q"}
+	}
+	expr := opts.Query
+	if !opts.Regex {
+		expr = regexp.QuoteMeta(expr)
+	}
+	if opts.IgnoreCase {
+		expr = "(?i)" + expr
+	}
+	re, err := regexp.Compile(expr)
+	if err != nil {
+		return nil, &internal.InvalidParameterError{Parameter: "q", Err: err}
+	}
+	return re, nil
+}
+
+// SearchLogs searches the logs of the most recent runs of a workspace.
+func (s *Service) SearchLogs(ctx context.Context, workspaceID string, opts SearchOptions) (*SearchResult, error) {
+	subject, err := s.workspace.CanAccess(ctx, rbac.TailLogsAction, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+	re, err := opts.compile()
+	if err != nil {
+		return nil, err
+	}
+	runs := opts.Runs
+	if runs <= 0 {
+		runs = defaultSearchRuns
+	} else if runs > maxSearchRuns {
+		runs = maxSearchRuns
+	}
+	logs, err := s.db.getRecentLogs(ctx, workspaceID, runs)
+	if err != nil {
+		s.Error(err, "searching logs", "workspace", workspaceID, "query", opts.Query, "subject", subject)
+		return nil, err
+	}
+	result := searchLogs(re, logs, maxSearchMatches)
+	s.V(9).Info("searched logs", "workspace", workspaceID, "query", opts.Query, "runs", result.RunsSearched, "matches", len(result.Matches), "subject", subject)
+	return result, nil
+}
+
+// searchLogs searches logs line by line, returning up to limit matching lines.
+func searchLogs(re *regexp.Regexp, logs []runLogs, limit int) *SearchResult {
+	result := &SearchResult{Matches: []*LogMatch{}}
+	searched := make(map[string]bool)
+logs:
+	for _, l := range logs {
+		searched[l.runID] = true
+		// remove markers delimiting the beginning and end of logs
+		data := bytes.TrimPrefix(l.data, []byte{internal.STX})
+		data = bytes.TrimSuffix(data, []byte{internal.ETX})
+
+		scanner := bufio.NewScanner(bytes.NewReader(data))
+		scanner.Buffer(nil, 1024*1024)
+		for n := 1; scanner.Scan(); n++ {
+			line := internal.StripAnsi(strings.TrimSuffix(scanner.Text(), "\r"))
+			var highlights []Highlight
+			for _, idx := range re.FindAllStringIndex(line, -1) {
+				// skip empty matches, e.g. from a regex such as a*
+				if idx[0] == idx[1] {
+					continue
+				}
+				highlights = append(highlights, Highlight{Start: idx[0], End: idx[1]})
+			}
+			if len(highlights) == 0 {
+				continue
+			}
+			if len(result.Matches) == limit {
+				result.Truncated = true
+				break logs
+			}
+			result.Matches = append(result.Matches, &LogMatch{
+				RunID:      l.runID,
+				RunURL:     paths.Run(l.runID),
+				Phase:      l.phase,
+				CreatedAt:  l.createdAt,
+				Line:       n,
+				Text:       line,
+				Highlights: highlights,
+			})
+		}
+	}
+	result.RunsSearched = len(searched)
+	return result
+}
+
+func (db *pgdb) getRecentLogs(ctx context.Context, workspaceID string, runs int) ([]runLogs, error) {
+	rows, err := db.Conn(ctx).FindRecentRunLogs(ctx, sql.String(workspaceID), sql.Int4(runs))
+	if err != nil {
+		return nil, sql.Error(err)
+	}
+	logs := make([]runLogs, len(rows))
+	for i, row := range rows {
+		logs[i] = runLogs{
+			runID:     row.RunID.String,
+			createdAt: row.CreatedAt.Time.UTC(),
+			phase:     internal.PhaseType(row.Phase.String),
+			data:      row.Logs,
+		}
+	}
+	return logs, nil
+}
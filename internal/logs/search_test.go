@@ -0,0 +1,123 @@
+package logs
+
+import (
+	"testing"
+	"time"
+
+	"github.com/leg100/otf/internal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSearchLogs(t *testing.T) {
+	created := time.Date(2023, 12, 30, 12, 0, 0, 0, time.UTC)
+	logs := []runLogs{
+		{
+			runID:     "run-new",
+			createdAt: created,
+			phase:     internal.PlanPhase,
+			data:      []byte("\x02Initializing...\n\x1b[31mError:\x1b[0m Invalid provider\r\nerror: again\x03"),
+		},
+		{
+			runID:     "run-new",
+			createdAt: created,
+			phase:     internal.ApplyPhase,
+			data:      []byte("\x02Apply complete!\x03"),
+		},
+		{
+			runID:     "run-old",
+			createdAt: created.Add(-time.Hour),
+			phase:     internal.PlanPhase,
+			data:      []byte("\x02Error: Error: duplicate\x03"),
+		},
+	}
+
+	t.Run("substring", func(t *testing.T) {
+		re, err := SearchOptions{Query: "Error:"}.compile()
+		require.NoError(t, err)
+
+		got := searchLogs(re, logs, 100)
+
+		assert.Equal(t, 2, got.RunsSearched)
+		assert.False(t, got.Truncated)
+		assert.Equal(t, []*LogMatch{
+			{
+				RunID:      "run-new",
+				RunURL:     "/app/runs/run-new",
+				Phase:      internal.PlanPhase,
+				CreatedAt:  created,
+				Line:       2,
+				Text:       "Error: Invalid provider",
+				Highlights: []Highlight{{Start: 0, End: 6}},
+			},
+			{
+				RunID:      "run-old",
+				RunURL:     "/app/runs/run-old",
+				Phase:      internal.PlanPhase,
+				CreatedAt:  created.Add(-time.Hour),
+				Line:       1,
+				Text:       "Error: Error: duplicate",
+				Highlights: []Highlight{{Start: 0, End: 6}, {Start: 7, End: 13}},
+			},
+		}, got.Matches)
+	})
+
+	t.Run("ignore case", func(t *testing.T) {
+		re, err := SearchOptions{Query: "error:", IgnoreCase: true}.compile()
+		require.NoError(t, err)
+
+		got := searchLogs(re, logs, 100)
+
+		assert.Len(t, got.Matches, 3)
+		assert.Equal(t, 3, got.Matches[1].Line)
+	})
+
+	t.Run("query is not a regex by default", func(t *testing.T) {
+		re, err := SearchOptions{Query: "complete!."}.compile()
+		require.NoError(t, err)
+
+		got := searchLogs(re, logs, 100)
+
+		assert.Empty(t, got.Matches)
+	})
+
+	t.Run("regex", func(t *testing.T) {
+		re, err := SearchOptions{Query: `^Apply \w+!$`, Regex: true}.compile()
+		require.NoError(t, err)
+
+		got := searchLogs(re, logs, 100)
+
+		require.Len(t, got.Matches, 1)
+		assert.Equal(t, internal.ApplyPhase, got.Matches[0].Phase)
+		assert.Equal(t, []Highlight{{Start: 0, End: 15}}, got.Matches[0].Highlights)
+	})
+
+	t.Run("ignore empty matches", func(t *testing.T) {
+		re, err := SearchOptions{Query: `x*`, Regex: true}.compile()
+		require.NoError(t, err)
+
+		got := searchLogs(re, logs, 100)
+
+		assert.Empty(t, got.Matches)
+	})
+
+	t.Run("truncated", func(t *testing.T) {
+		re, err := SearchOptions{Query: "Error:"}.compile()
+		require.NoError(t, err)
+
+		got := searchLogs(re, logs, 1)
+
+		assert.Len(t, got.Matches, 1)
+		assert.True(t, got.Truncated)
+	})
+
+	t.Run("invalid regex", func(t *testing.T) {
+		_, err := SearchOptions{Query: "(", Regex: true}.compile()
+		assert.Error(t, err)
+	})
+
+	t.Run("missing query", func(t *testing.T) {
+		_, err := SearchOptions{}.compile()
+		assert.Error(t, err)
+	})
+}
@@ -12,10 +12,17 @@ import (
 )
 
 type (
+	LogsService interface {
+		GetChunk(context.Context, internal.GetChunkOptions) (internal.Chunk, error)
+	}
+
 	Service struct {
 		logr.Logger
 
-		run internal.Authorizer
+		run       internal.Authorizer
+		workspace internal.Authorizer
+
+		db *pgdb
 
 		api    *api
 		web    *webHandlers
@@ -37,15 +44,18 @@ type (
 		*sql.Listener
 		internal.Verifier
 
-		RunAuthorizer internal.Authorizer
+		RunAuthorizer       internal.Authorizer
+		WorkspaceAuthorizer internal.Authorizer
 	}
 )
 
 func NewService(opts Options) *Service {
 	db := &pgdb{opts.DB}
 	svc := Service{
-		Logger: opts.Logger,
-		run:    opts.RunAuthorizer,
+		Logger:    opts.Logger,
+		run:       opts.RunAuthorizer,
+		workspace: opts.WorkspaceAuthorizer,
+		db:        db,
 	}
 	svc.api = &api{
 		Verifier: opts.Verifier,
@@ -97,6 +107,51 @@ func (s *Service) GetChunk(ctx context.Context, opts internal.GetChunkOptions) (
 	return logs, nil
 }
 
+// ReadChunk reads a chunk of logs for a phase on behalf of an authenticated
+// caller.
+func (s *Service) ReadChunk(ctx context.Context, opts internal.GetChunkOptions) (internal.Chunk, error) {
+	subject, err := s.run.CanAccess(ctx, rbac.TailLogsAction, opts.RunID)
+	if err != nil {
+		return internal.Chunk{}, err
+	}
+	logs, err := s.chunkproxy.get(ctx, opts)
+	if err != nil {
+		s.Error(err, "reading logs", "id", opts.RunID, "phase", opts.Phase, "offset", opts.Offset, "subject", subject)
+		return internal.Chunk{}, err
+	}
+	s.V(9).Info("read logs", "id", opts.RunID, "phase", opts.Phase, "offset", opts.Offset, "subject", subject)
+	return logs, nil
+}
+
+// AppendChunk appends data to the logs for a phase, writing it at the offset
+// immediately following existing logs. Only one writer should append to the
+// logs of a phase at any one time.
+func (s *Service) AppendChunk(ctx context.Context, runID string, phase internal.PhaseType, data []byte) error {
+	_, err := s.run.CanAccess(ctx, rbac.PutChunkAction, runID)
+	if err != nil {
+		return err
+	}
+
+	existing, err := s.chunkproxy.get(ctx, internal.GetChunkOptions{RunID: runID, Phase: phase})
+	if err != nil {
+		s.Error(err, "appending logs", "id", runID, "phase", phase)
+		return err
+	}
+	opts := internal.PutChunkOptions{
+		RunID:  runID,
+		Phase:  phase,
+		Data:   data,
+		Offset: existing.NextOffset(),
+	}
+	if err := s.chunkproxy.put(ctx, opts); err != nil {
+		s.Error(err, "appending logs", "id", runID, "phase", phase, "offset", opts.Offset)
+		return err
+	}
+	s.V(3).Info("appended logs", "id", runID, "phase", phase, "offset", opts.Offset)
+
+	return nil
+}
+
 // PutChunk writes a chunk of logs for a phase
 func (s *Service) PutChunk(ctx context.Context, opts internal.PutChunkOptions) error {
 	_, err := s.run.CanAccess(ctx, rbac.PutChunkAction, opts.RunID)
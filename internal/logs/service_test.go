@@ -184,3 +184,28 @@ func TestTail(t *testing.T) {
 		assert.Equal(t, want, <-stream)
 	})
 }
+
+func TestAppendChunk(t *testing.T) {
+	ctx := context.Background()
+	proxy := &fakeTailProxy{chunk: internal.Chunk{
+		RunID: "run-123",
+		Phase: internal.PlanPhase,
+		Data:  []byte("\x02hello"),
+	}}
+	svc := &Service{
+		chunkproxy: proxy,
+		Logger:     logr.Discard(),
+		run:        &fakeAuthorizer{},
+	}
+
+	err := svc.AppendChunk(ctx, "run-123", internal.PlanPhase, []byte(" world\x03"))
+	require.NoError(t, err)
+
+	want := internal.PutChunkOptions{
+		RunID:  "run-123",
+		Phase:  internal.PlanPhase,
+		Data:   []byte(" world\x03"),
+		Offset: 6,
+	}
+	assert.Equal(t, []internal.PutChunkOptions{want}, proxy.written)
+}
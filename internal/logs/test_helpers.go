@@ -22,6 +22,8 @@ type (
 	fakeTailProxy struct {
 		// fake chunk to return
 		chunk internal.Chunk
+		// chunks written
+		written []internal.PutChunkOptions
 		chunkproxy
 	}
 
@@ -57,6 +59,11 @@ func (f *fakeTailProxy) get(ctx context.Context, opts internal.GetChunkOptions)
 	return f.chunk, nil
 }
 
+func (f *fakeTailProxy) put(ctx context.Context, opts internal.PutChunkOptions) error {
+	f.written = append(f.written, opts)
+	return nil
+}
+
 func (f *fakeAuthorizer) CanAccess(context.Context, rbac.Action, string) (internal.Subject, error) {
 	return &internal.Superuser{}, nil
 }
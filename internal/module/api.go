@@ -2,6 +2,7 @@ package module
 
 import (
 	"encoding/json"
+	"io"
 	"net/http"
 	"strings"
 	"time"
@@ -24,6 +25,7 @@ func (h *api) addHandlers(r *mux.Router) {
 	signed := r.PathPrefix("/signed/{signature.expiry}").Subrouter()
 	signed.Use(internal.VerifySignedURL(h.Signer))
 	signed.HandleFunc("/modules/download/{module_version_id}.tar.gz", h.downloadModuleVersion).Methods("GET")
+	signed.HandleFunc("/modules/upload/{module_version_id}.tar.gz", h.uploadModuleVersion).Methods("PUT")
 
 	// authenticated module api routes
 	//
@@ -145,3 +147,22 @@ func (h *api) downloadModuleVersion(w http.ResponseWriter, r *http.Request) {
 
 	w.Write(tarball)
 }
+
+func (h *api) uploadModuleVersion(w http.ResponseWriter, r *http.Request) {
+	id, err := decode.Param("module_version_id", r)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+
+	tarball, err := io.ReadAll(r.Body)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+
+	if err := h.svc.uploadSignedVersion(r.Context(), id, tarball); err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+}
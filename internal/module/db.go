@@ -191,3 +191,15 @@ func (v byVersion) Swap(i, j int) { v[i], v[j] = v[j], v[i] }
 func (v byVersion) Less(i, j int) bool {
 	return semver.Compare(v[i].Version.String, v[j].Version.String) < 0
 }
+
+func (db *pgdb) listConnectedModuleIDs(ctx context.Context) ([]string, error) {
+	rows, err := db.Conn(ctx).FindConnectedModuleIDs(ctx)
+	if err != nil {
+		return nil, sql.Error(err)
+	}
+	ids := make([]string, len(rows))
+	for i, r := range rows {
+		ids[i] = r.String
+	}
+	return ids, nil
+}
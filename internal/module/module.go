@@ -99,7 +99,7 @@ type (
 
 func newModule(opts CreateOptions) *Module {
 	return &Module{
-		ID:           internal.NewID("mod"),
+		ID:           resource.NewID(resource.ModuleKind),
 		CreatedAt:    internal.CurrentTimestamp(nil),
 		UpdatedAt:    internal.CurrentTimestamp(nil),
 		Name:         opts.Name,
@@ -111,7 +111,7 @@ func newModule(opts CreateOptions) *Module {
 
 func newModuleVersion(opts CreateModuleVersionOptions) *ModuleVersion {
 	return &ModuleVersion{
-		ID:        internal.NewID("modver"),
+		ID:        resource.NewID(resource.ModuleVersionKind),
 		CreatedAt: internal.CurrentTimestamp(nil),
 		UpdatedAt: internal.CurrentTimestamp(nil),
 		ModuleID:  opts.ModuleID,
@@ -2,6 +2,7 @@ package module
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strings"
 
@@ -22,7 +23,7 @@ type (
 	}
 )
 
-func (p *publisher) handle(event vcs.Event) {
+func (p *publisher) handle(event vcs.Event) error {
 	logger := p.Logger.WithValues(
 		"sha", event.CommitSHA,
 		"type", event.Type,
@@ -33,7 +34,9 @@ func (p *publisher) handle(event vcs.Event) {
 
 	if err := p.handleWithError(logger, event); err != nil {
 		p.Error(err, "handling event")
+		return err
 	}
+	return nil
 }
 
 // handlerWithError publishes a module version in response to a vcs event.
@@ -57,7 +60,11 @@ func (p *publisher) handleWithError(logger logr.Logger, event vcs.Event) error {
 	// TODO: we're only retrieving *one* module, but can not *multiple* modules
 	// be connected to a repo?
 	module, err := p.modules.GetModuleByConnection(ctx, event.VCSProviderID, event.RepoPath)
-	if err != nil {
+	if errors.Is(err, internal.ErrResourceNotFound) {
+		// repo is not connected to a module
+		logger.V(4).Info("ignoring vcs event: no connected module found")
+		return nil
+	} else if err != nil {
 		return err
 	}
 	if module.Connection == nil {
@@ -2,8 +2,6 @@ package module
 
 import (
 	"context"
-	"fmt"
-	"strings"
 
 	"github.com/go-logr/logr"
 	"github.com/gorilla/mux"
@@ -13,9 +11,9 @@ import (
 	"github.com/leg100/otf/internal/organization"
 	"github.com/leg100/otf/internal/rbac"
 	"github.com/leg100/otf/internal/repohooks"
-	"github.com/leg100/otf/internal/semver"
 	"github.com/leg100/otf/internal/sql"
 	"github.com/leg100/otf/internal/sql/pggen"
+	"github.com/leg100/otf/internal/tfeapi"
 	"github.com/leg100/otf/internal/vcs"
 	"github.com/leg100/otf/internal/vcsprovider"
 	"github.com/leg100/surl"
@@ -31,6 +29,7 @@ type (
 		organization internal.Authorizer
 
 		api          *api
+		tfeapi       *tfe
 		web          *webHandlers
 		vcsproviders *vcsprovider.Service
 		connections  *connections.Service
@@ -42,6 +41,7 @@ type (
 		*sql.DB
 		*internal.HostnameService
 		*surl.Signer
+		*tfeapi.Responder
 		html.Renderer
 
 		RepohookService    *repohooks.Service
@@ -63,6 +63,12 @@ func NewService(opts Options) *Service {
 		svc:    &svc,
 		Signer: opts.Signer,
 	}
+	svc.tfeapi = &tfe{
+		Service:   &svc,
+		Responder: opts.Responder,
+		Signer:    opts.Signer,
+		system:    opts.HostnameService,
+	}
 	svc.web = &webHandlers{
 		Renderer:     opts.Renderer,
 		client:       &svc,
@@ -75,13 +81,14 @@ func NewService(opts Options) *Service {
 		modules:      &svc,
 	}
 	// Subscribe module publisher to incoming vcs events
-	opts.VCSEventSubscriber.Subscribe(publisher.handle)
+	opts.VCSEventSubscriber.Subscribe("module-publisher", publisher.handle)
 
 	return &svc
 }
 
 func (s *Service) AddHandlers(r *mux.Router) {
 	s.api.addHandlers(r)
+	s.tfeapi.addHandlers(r)
 	s.web.addHandlers(r)
 }
 
@@ -158,22 +165,20 @@ func (s *Service) publishModule(ctx context.Context, organization string, opts P
 		return s.updateModuleStatus(ctx, mod, ModuleStatusNoVersionTags)
 	}
 	for _, tag := range tags {
-		// tags/<version> -> <version>
-		_, version, found := strings.Cut(tag, "/")
-		if !found {
-			return nil, fmt.Errorf("malformed git ref: %s", tag)
+		version, err := parseVersionTag(tag)
+		if err != nil {
+			return nil, err
 		}
 		// skip tags that are not semantic versions
-		if !semver.IsValid(version) {
+		if version == "" {
 			continue
 		}
-		err := s.PublishVersion(ctx, PublishVersionOptions{
+		err = s.PublishVersion(ctx, PublishVersionOptions{
 			ModuleID: mod.ID,
-			// strip off v prefix if it has one
-			Version: strings.TrimPrefix(version, "v"),
-			Ref:     tag,
-			Repo:    opts.Repo,
-			Client:  client,
+			Version:  version,
+			Ref:      tag,
+			Repo:     opts.Repo,
+			Client:   client,
 		})
 		if err != nil {
 			return nil, err
@@ -333,6 +338,44 @@ func (s *Service) CreateVersion(ctx context.Context, opts CreateModuleVersionOpt
 	return modver, nil
 }
 
+// UploadVersion uploads the tarball for a module version that is not sourced
+// from a VCS repository, e.g. one imported from another registry.
+func (s *Service) UploadVersion(ctx context.Context, versionID string, tarball []byte) error {
+	module, err := s.db.getModuleByVersionID(ctx, versionID)
+	if err != nil {
+		return err
+	}
+	if _, err := s.organization.CanAccess(ctx, rbac.CreateModuleVersionAction, module.Organization); err != nil {
+		return err
+	}
+	return s.uploadVersionAndSetup(ctx, module, versionID, tarball)
+}
+
+// uploadSignedVersion uploads the tarball for a module version, and should be
+// accessed via signed URL.
+func (s *Service) uploadSignedVersion(ctx context.Context, versionID string, tarball []byte) error {
+	module, err := s.db.getModuleByVersionID(ctx, versionID)
+	if err != nil {
+		s.Error(err, "uploading module version", "module_version_id", versionID)
+		return err
+	}
+	return s.uploadVersionAndSetup(ctx, module, versionID, tarball)
+}
+
+func (s *Service) uploadVersionAndSetup(ctx context.Context, module *Module, versionID string, tarball []byte) error {
+	if err := s.uploadVersion(ctx, versionID, tarball); err != nil {
+		return err
+	}
+	// a module without a VCS connection is set up as soon as it has a
+	// version.
+	if module.Status != ModuleStatusSetupComplete {
+		if _, err := s.updateModuleStatus(ctx, module, ModuleStatusSetupComplete); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func (s *Service) GetModuleInfo(ctx context.Context, versionID string) (*TerraformModule, error) {
 	tarball, err := s.db.getTarball(ctx, versionID)
 	if err != nil {
@@ -408,11 +451,10 @@ func (s *Service) downloadVersion(ctx context.Context, versionID string) ([]byte
 	return tarball, nil
 }
 
-//lint:ignore U1000 to be used later
-func (s *Service) deleteVersion(ctx context.Context, versionID string) (*Module, error) {
-	module, err := s.db.getModuleByID(ctx, versionID)
+func (s *Service) DeleteVersion(ctx context.Context, versionID string) (*Module, error) {
+	module, err := s.db.getModuleByVersionID(ctx, versionID)
 	if err != nil {
-		s.Error(err, "retrieving module", "id", versionID)
+		s.Error(err, "retrieving module", "module_version_id", versionID)
 		return nil, err
 	}
 
@@ -422,10 +464,10 @@ func (s *Service) deleteVersion(ctx context.Context, versionID string) (*Module,
 	}
 
 	if err = s.db.deleteModuleVersion(ctx, versionID); err != nil {
-		s.Error(err, "deleting module", "subject", subject, "module", module)
+		s.Error(err, "deleting module version", "subject", subject, "module_version_id", versionID)
 		return nil, err
 	}
-	s.V(2).Info("deleted module", "subject", subject, "module", module)
+	s.V(0).Info("deleted module version", "subject", subject, "module_version_id", versionID)
 
 	// return module w/o deleted version
 	return s.db.getModuleByID(ctx, module.ID)
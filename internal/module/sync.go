@@ -0,0 +1,159 @@
+package module
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/leg100/otf/internal/rbac"
+	"github.com/leg100/otf/internal/semver"
+	"github.com/leg100/otf/internal/vcs"
+)
+
+// SyncerLockID guarantees only one syncer on a cluster is running at any
+// time.
+const SyncerLockID int64 = 5577006791947779417
+
+var defaultSyncerInterval = time.Hour
+
+type (
+	// Syncer periodically publishes versions for any semantic version tags
+	// in the repositories of connected modules that have yet to be published,
+	// e.g. tags pushed while the repository's webhook could not be delivered.
+	//
+	// Only one syncer should be running on an OTF cluster at any one time.
+	Syncer struct {
+		logr.Logger
+
+		client syncerClient
+		// frequency with which the syncer syncs modules.
+		interval time.Duration
+	}
+
+	syncerClient interface {
+		listConnectedModuleIDs(ctx context.Context) ([]string, error)
+		SyncModule(ctx context.Context, moduleID string) (*Module, error)
+	}
+)
+
+// NewSyncer constructs a syncer of connected modules.
+func (s *Service) NewSyncer(logger logr.Logger) *Syncer {
+	return &Syncer{
+		Logger:   logger.WithValues("component", "module-syncer"),
+		client:   s,
+		interval: defaultSyncerInterval,
+	}
+}
+
+func (s *Syncer) String() string { return "module-syncer" }
+
+// Start the syncer. Every interval connected modules are synced with their
+// repositories.
+//
+// Should be invoked in a go routine.
+func (s *Syncer) Start(ctx context.Context) error {
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			if err := s.sync(ctx); err != nil {
+				return err
+			}
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
+
+func (s *Syncer) sync(ctx context.Context) error {
+	ids, err := s.client.listConnectedModuleIDs(ctx)
+	if err != nil {
+		return err
+	}
+	for _, id := range ids {
+		// carry on syncing remaining modules
+		if _, err := s.client.SyncModule(ctx, id); err != nil {
+			s.Error(err, "syncing module", "module", id)
+		}
+	}
+	return nil
+}
+
+// SyncModule publishes a version for each semantic version tag in a connected
+// module's repository that has yet to be published.
+func (s *Service) SyncModule(ctx context.Context, moduleID string) (*Module, error) {
+	mod, err := s.db.getModuleByID(ctx, moduleID)
+	if err != nil {
+		return nil, err
+	}
+	subject, err := s.organization.CanAccess(ctx, rbac.CreateModuleVersionAction, mod.Organization)
+	if err != nil {
+		return nil, err
+	}
+	if mod.Connection == nil {
+		return nil, fmt.Errorf("module is not connected to a repo: %s", mod.ID)
+	}
+	client, err := s.vcsproviders.GetVCSClient(ctx, mod.Connection.VCSProviderID)
+	if err != nil {
+		return nil, err
+	}
+	tags, err := client.ListTags(ctx, vcs.ListTagsOptions{
+		Repo: string(mod.Connection.Repo),
+	})
+	if err != nil {
+		s.Error(err, "listing repository tags", "module", mod, "subject", subject)
+		return nil, err
+	}
+	var published int
+	for _, tag := range tags {
+		version, err := parseVersionTag(tag)
+		if err != nil {
+			return nil, err
+		}
+		if version == "" || mod.Version(version) != nil {
+			// skip tags that are not semantic versions or already published
+			continue
+		}
+		err = s.PublishVersion(ctx, PublishVersionOptions{
+			ModuleID: mod.ID,
+			Version:  version,
+			Ref:      tag,
+			Repo:     Repo(mod.Connection.Repo),
+			Client:   client,
+		})
+		if err != nil {
+			s.Error(err, "publishing module version", "module", mod, "version", version, "subject", subject)
+			return nil, err
+		}
+		published++
+	}
+	if published > 0 {
+		s.V(0).Info("synced module", "module", mod, "published", published, "subject", subject)
+	} else {
+		s.V(9).Info("synced module", "module", mod, "published", published, "subject", subject)
+	}
+	return s.db.getModuleByID(ctx, mod.ID)
+}
+
+// parseVersionTag parses the version from a git tag ref, e.g. tags/v1.0.0
+// -> 1.0.0. An empty version is returned if the tag is not a semantic
+// version.
+func parseVersionTag(tag string) (string, error) {
+	// tags/<version> -> <version>
+	_, version, found := strings.Cut(tag, "/")
+	if !found {
+		return "", fmt.Errorf("malformed git ref: %s", tag)
+	}
+	if !semver.IsValid(version) {
+		return "", nil
+	}
+	// strip off v prefix if it has one
+	return strings.TrimPrefix(version, "v"), nil
+}
+
+func (s *Service) listConnectedModuleIDs(ctx context.Context) ([]string, error) {
+	return s.db.listConnectedModuleIDs(ctx)
+}
@@ -0,0 +1,32 @@
+package module
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseVersionTag(t *testing.T) {
+	tests := []struct {
+		name string
+		tag  string
+		want string
+	}{
+		{"with v prefix", "tags/v1.0.0", "1.0.0"},
+		{"without v prefix", "tags/0.10.3", "0.10.3"},
+		{"not semver", "tags/release", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseVersionTag(tt.tag)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+
+	t.Run("malformed ref", func(t *testing.T) {
+		_, err := parseVersionTag("v1.0.0")
+		assert.Error(t, err)
+	})
+}
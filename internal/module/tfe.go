@@ -0,0 +1,401 @@
+package module
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/leg100/otf/internal"
+	ihttp "github.com/leg100/otf/internal/http"
+	"github.com/leg100/otf/internal/http/decode"
+	"github.com/leg100/otf/internal/rbac"
+	"github.com/leg100/otf/internal/resource"
+	"github.com/leg100/otf/internal/semver"
+	"github.com/leg100/otf/internal/tfeapi"
+	"github.com/leg100/otf/internal/tfeapi/types"
+	"github.com/leg100/surl"
+)
+
+type (
+	// tfe implements the TFE private module registry API:
+	//
+	// https://developer.hashicorp.com/terraform/cloud-docs/api-docs/private-registry/modules
+	tfe struct {
+		*Service
+		*tfeapi.Responder
+		*surl.Signer
+
+		system *internal.HostnameService
+	}
+
+	// registryModuleParams identifies a registry module in the path of a
+	// request.
+	registryModuleParams struct {
+		Organization string             `schema:"organization_name,required"`
+		RegistryName types.RegistryName `schema:"registry_name"`
+		Namespace    string             `schema:"namespace"`
+		Name         string             `schema:"name,required"`
+		Provider     string             `schema:"provider,required"`
+	}
+)
+
+func (a *tfe) addHandlers(r *mux.Router) {
+	r = r.PathPrefix(tfeapi.APIPrefixV2).Subrouter()
+
+	r.HandleFunc("/organizations/{organization_name}/registry-modules", a.listModules).Methods("GET")
+	r.HandleFunc("/organizations/{organization_name}/registry-modules", a.createModule).Methods("POST")
+	r.HandleFunc("/organizations/{organization_name}/registry-modules/vcs", a.createModuleWithVCS).Methods("POST")
+	r.HandleFunc("/organizations/{organization_name}/registry-modules/{registry_name}/{namespace}/{name}", a.deleteModulesByName).Methods("DELETE")
+	r.HandleFunc("/organizations/{organization_name}/registry-modules/{registry_name}/{namespace}/{name}/{provider}", a.getModule).Methods("GET")
+	r.HandleFunc("/organizations/{organization_name}/registry-modules/{registry_name}/{namespace}/{name}/{provider}", a.deleteModule).Methods("DELETE")
+	r.HandleFunc("/organizations/{organization_name}/registry-modules/{registry_name}/{namespace}/{name}/{provider}/versions", a.createVersion).Methods("POST")
+	r.HandleFunc("/organizations/{organization_name}/registry-modules/{registry_name}/{namespace}/{name}/{provider}/{version}", a.deleteVersion).Methods("DELETE")
+
+	// deprecated endpoints still used by some clients
+	r.HandleFunc("/registry-modules", a.createModuleWithVCS).Methods("POST")
+	r.HandleFunc("/registry-modules/{organization_name}/{name}/{provider}/versions", a.createVersion).Methods("POST")
+	r.HandleFunc("/registry-modules/actions/delete/{organization_name}/{name}", a.deleteModulesByName).Methods("POST")
+	r.HandleFunc("/registry-modules/actions/delete/{organization_name}/{name}/{provider}", a.deleteModule).Methods("POST")
+	r.HandleFunc("/registry-modules/actions/delete/{organization_name}/{name}/{provider}/{version}", a.deleteVersion).Methods("POST")
+}
+
+func (a *tfe) listModules(w http.ResponseWriter, r *http.Request) {
+	var params struct {
+		Organization string `schema:"organization_name,required"`
+		resource.PageOptions
+	}
+	if err := decode.All(&params, r); err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+
+	modules, err := a.ListModules(r.Context(), ListModulesOptions{Organization: params.Organization})
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+
+	items := make([]*types.RegistryModule, len(modules))
+	for i, from := range modules {
+		items[i] = a.convertModule(r.Context(), from)
+	}
+	page := resource.NewPage(items, params.PageOptions, nil)
+	a.RespondWithPage(w, r, page.Items, page.Pagination)
+}
+
+func (a *tfe) createModule(w http.ResponseWriter, r *http.Request) {
+	org, err := decode.Param("organization_name", r)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	var params types.RegistryModuleCreateOptions
+	if err := tfeapi.Unmarshal(r.Body, &params); err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	if params.Name == nil {
+		tfeapi.Error(w, &internal.MissingParameterError{Parameter: "name"})
+		return
+	}
+	if params.Provider == nil {
+		tfeapi.Error(w, &internal.MissingParameterError{Parameter: "provider"})
+		return
+	}
+	if err := validateRegistry(org, params.RegistryName, params.Namespace); err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+
+	mod, err := a.CreateModule(r.Context(), CreateOptions{
+		Name:         *params.Name,
+		Provider:     *params.Provider,
+		Organization: org,
+	})
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+
+	a.Respond(w, r, a.convertModule(r.Context(), mod), http.StatusCreated)
+}
+
+func (a *tfe) createModuleWithVCS(w http.ResponseWriter, r *http.Request) {
+	var params types.RegistryModuleCreateWithVCSConnectionOptions
+	if err := tfeapi.Unmarshal(r.Body, &params); err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	if params.VCSRepo == nil || params.VCSRepo.Identifier == nil {
+		tfeapi.Error(w, &internal.MissingParameterError{Parameter: "vcs-repo.identifier"})
+		return
+	}
+	// OTF only supports connecting via a VCS provider, which is presented to
+	// clients as an OAuth token.
+	if params.VCSRepo.OAuthTokenID == nil {
+		tfeapi.Error(w, &internal.MissingParameterError{Parameter: "vcs-repo.oauth-token-id"})
+		return
+	}
+
+	repo := Repo(*params.VCSRepo.Identifier)
+	if _, _, err := repo.Split(); err != nil {
+		tfeapi.Error(w, &internal.InvalidParameterError{Parameter: "vcs-repo.identifier", Err: err})
+		return
+	}
+	// the module belongs to the organization of the VCS provider, which must
+	// be the organization in the path, if specified.
+	if org, ok := mux.Vars(r)["organization_name"]; ok {
+		vcsprov, err := a.vcsproviders.Get(r.Context(), *params.VCSRepo.OAuthTokenID)
+		if err != nil {
+			tfeapi.Error(w, err)
+			return
+		}
+		if vcsprov.Organization != org {
+			tfeapi.Error(w, &internal.InvalidParameterError{
+				Parameter: "vcs-repo.oauth-token-id",
+				Err:       fmt.Errorf("belongs to a different organization: %s", vcsprov.Organization),
+			})
+			return
+		}
+	}
+
+	mod, err := a.PublishModule(r.Context(), PublishOptions{
+		Repo:          repo,
+		VCSProviderID: *params.VCSRepo.OAuthTokenID,
+	})
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+
+	a.Respond(w, r, a.convertModule(r.Context(), mod), http.StatusCreated)
+}
+
+func (a *tfe) getModule(w http.ResponseWriter, r *http.Request) {
+	mod, err := a.getModuleFromPath(r)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+
+	a.Respond(w, r, a.convertModule(r.Context(), mod), http.StatusOK)
+}
+
+func (a *tfe) deleteModule(w http.ResponseWriter, r *http.Request) {
+	mod, err := a.getModuleFromPath(r)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+
+	if _, err := a.DeleteModule(r.Context(), mod.ID); err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// deleteModulesByName deletes a module along with all its providers.
+func (a *tfe) deleteModulesByName(w http.ResponseWriter, r *http.Request) {
+	var params struct {
+		Organization string             `schema:"organization_name,required"`
+		RegistryName types.RegistryName `schema:"registry_name"`
+		Namespace    string             `schema:"namespace"`
+		Name         string             `schema:"name,required"`
+	}
+	if err := decode.Route(&params, r); err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	if err := validateRegistry(params.Organization, params.RegistryName, params.Namespace); err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+
+	modules, err := a.ListModules(r.Context(), ListModulesOptions{Organization: params.Organization})
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	var deleted int
+	for _, mod := range modules {
+		if mod.Name != params.Name {
+			continue
+		}
+		if _, err := a.DeleteModule(r.Context(), mod.ID); err != nil {
+			tfeapi.Error(w, err)
+			return
+		}
+		deleted++
+	}
+	if deleted == 0 {
+		tfeapi.Error(w, internal.ErrResourceNotFound)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (a *tfe) createVersion(w http.ResponseWriter, r *http.Request) {
+	mod, err := a.getModuleFromPath(r)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	var params types.RegistryModuleCreateVersionOptions
+	if err := tfeapi.Unmarshal(r.Body, &params); err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	if params.Version == nil {
+		tfeapi.Error(w, &internal.MissingParameterError{Parameter: "version"})
+		return
+	}
+	// versions are stored without a v prefix
+	version := strings.TrimPrefix(*params.Version, "v")
+	if !semver.IsValid("v" + version) {
+		tfeapi.Error(w, &internal.InvalidParameterError{
+			Parameter: "version",
+			Err:       fmt.Errorf("not a semantic version: %s", *params.Version),
+		})
+		return
+	}
+	if mod.Version(version) != nil {
+		tfeapi.Error(w, internal.ErrResourceAlreadyExists)
+		return
+	}
+
+	modver, err := a.CreateVersion(r.Context(), CreateModuleVersionOptions{
+		ModuleID: mod.ID,
+		Version:  version,
+	})
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+
+	// the tarball is uploaded to a signed URL, returned in the upload link
+	upload, err := a.Sign(fmt.Sprintf("/modules/upload/%s.tar.gz", modver.ID), time.Hour)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	to := a.convertVersion(mod, modver)
+	a.RespondWithLinks(w, r, to, http.StatusCreated, map[string]string{
+		"upload": ihttp.Absolute(r, upload),
+	})
+}
+
+func (a *tfe) deleteVersion(w http.ResponseWriter, r *http.Request) {
+	mod, err := a.getModuleFromPath(r)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	version, err := decode.Param("version", r)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	modver := mod.Version(strings.TrimPrefix(version, "v"))
+	if modver == nil {
+		tfeapi.Error(w, internal.ErrResourceNotFound)
+		return
+	}
+
+	if _, err := a.DeleteVersion(r.Context(), modver.ID); err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// getModuleFromPath retrieves the module identified in the path of the
+// request.
+func (a *tfe) getModuleFromPath(r *http.Request) (*Module, error) {
+	var params registryModuleParams
+	if err := decode.Route(&params, r); err != nil {
+		return nil, err
+	}
+	if err := validateRegistry(params.Organization, params.RegistryName, params.Namespace); err != nil {
+		return nil, err
+	}
+	return a.GetModule(r.Context(), GetModuleOptions{
+		Organization: params.Organization,
+		Name:         params.Name,
+		Provider:     params.Provider,
+	})
+}
+
+// validateRegistry checks the registry name and namespace are those of the
+// organization's private registry, the only kind of registry supported by
+// OTF. Either may be omitted.
+func validateRegistry(organization string, registry types.RegistryName, namespace string) error {
+	if registry != "" && registry != types.PrivateRegistry {
+		return &internal.InvalidParameterError{
+			Parameter: "registry-name",
+			Err:       fmt.Errorf("only the %s registry is supported", types.PrivateRegistry),
+		}
+	}
+	if namespace != "" && namespace != organization {
+		return &internal.InvalidParameterError{
+			Parameter: "namespace",
+			Err:       errors.New("must be the name of the organization"),
+		}
+	}
+	return nil
+}
+
+func (a *tfe) convertModule(ctx context.Context, from *Module) *types.RegistryModule {
+	to := &types.RegistryModule{
+		ID:              from.ID,
+		Name:            from.Name,
+		Provider:        from.Provider,
+		RegistryName:    types.PrivateRegistry,
+		Namespace:       from.Organization,
+		Permissions:     &types.RegistryModulePermissions{},
+		Status:          string(from.Status),
+		VersionStatuses: []types.RegistryModuleVersionStatuses{},
+		CreatedAt:       from.CreatedAt,
+		UpdatedAt:       from.UpdatedAt,
+		Organization:    &types.Organization{Name: from.Organization},
+	}
+	if subject, err := internal.SubjectFromContext(ctx); err == nil {
+		to.Permissions.CanDelete = subject.CanAccessOrganization(rbac.DeleteModuleAction, from.Organization)
+	}
+	if from.Connection != nil {
+		to.VCSRepo = &types.VCSRepo{
+			Identifier:        from.Connection.Repo,
+			DisplayIdentifier: from.Connection.Repo,
+			OAuthTokenID:      from.Connection.VCSProviderID,
+		}
+	}
+	for _, modver := range from.Versions {
+		to.VersionStatuses = append(to.VersionStatuses, types.RegistryModuleVersionStatuses{
+			Version: modver.Version,
+			Status:  string(modver.Status),
+			Error:   modver.StatusError,
+		})
+	}
+	return to
+}
+
+func (a *tfe) convertVersion(mod *Module, from *ModuleVersion) *types.RegistryModuleVersion {
+	return &types.RegistryModuleVersion{
+		ID:        from.ID,
+		Source:    strings.Join([]string{a.system.Hostname(), mod.Organization, mod.Name, mod.Provider}, "/"),
+		Status:    string(from.Status),
+		Version:   from.Version,
+		CreatedAt: from.CreatedAt,
+		UpdatedAt: from.UpdatedAt,
+		RegistryModule: &types.RegistryModule{
+			ID: mod.ID,
+		},
+	}
+}
@@ -0,0 +1,37 @@
+package module
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/leg100/otf/internal"
+	"github.com/leg100/otf/internal/tfeapi/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateRegistry(t *testing.T) {
+	tests := []struct {
+		name      string
+		registry  types.RegistryName
+		namespace string
+		parameter string
+	}{
+		{"omitted", "", "", ""},
+		{"private", types.PrivateRegistry, "acme", ""},
+		{"public", "public", "acme", "registry-name"},
+		{"other namespace", types.PrivateRegistry, "hashicorp", "namespace"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateRegistry("acme", tt.registry, tt.namespace)
+			if tt.parameter == "" {
+				assert.NoError(t, err)
+				return
+			}
+			var invalid *internal.InvalidParameterError
+			if assert.True(t, errors.As(err, &invalid)) {
+				assert.Equal(t, tt.parameter, invalid.Parameter)
+			}
+		})
+	}
+}
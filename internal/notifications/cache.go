@@ -12,10 +12,11 @@ type (
 	// (ii) allows re-use of clients whilst ensuring they are closed when no
 	// longer in use.
 	//
-	// A client is maintained per unique url.
+	// A client is maintained per unique url, and one client for all email
+	// configs.
 	cache struct {
 		mu      sync.Mutex
-		clients map[string]*clientEntry // keyed by url
+		clients map[string]*clientEntry // keyed by config's client key
 		configs map[string]*Config      // keyed by config ID
 
 		clientFactory // constructs new clients
@@ -53,10 +54,6 @@ func newCache(ctx context.Context, db cacheDB, f clientFactory) (*cache, error)
 
 // add a config to the cache and either create a client or re-use existing one.
 func (c *cache) add(cfg *Config) error {
-	if cfg.DestinationType == DestinationEmail {
-		// email type is unimplemented
-		return nil
-	}
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
@@ -64,10 +61,10 @@ func (c *cache) add(cfg *Config) error {
 		// this should never happen
 		return errors.New("config already added")
 	}
-	if ent, ok := c.clients[*cfg.URL]; ok {
+	if ent, ok := c.clients[cfg.clientKey()]; ok {
 		// re-use existing client
 		ent.count++
-		c.clients[*cfg.URL] = ent
+		c.clients[cfg.clientKey()] = ent
 		c.configs[cfg.ID] = cfg
 		configsMetric.Inc()
 		return nil
@@ -77,7 +74,7 @@ func (c *cache) add(cfg *Config) error {
 	if err != nil {
 		return err
 	}
-	c.clients[*cfg.URL] = &clientEntry{client: client, count: 1}
+	c.clients[cfg.clientKey()] = &clientEntry{client: client, count: 1}
 	clientsMetric.Inc()
 	c.configs[cfg.ID] = cfg
 	configsMetric.Inc()
@@ -93,7 +90,7 @@ func (c *cache) remove(id string) error {
 		// this should never happen
 		return errors.New("config not found")
 	}
-	ent, ok := c.clients[*cfg.URL]
+	ent, ok := c.clients[cfg.clientKey()]
 	if !ok {
 		// this should never happen
 		return errors.New("client not found")
@@ -102,10 +99,10 @@ func (c *cache) remove(id string) error {
 	if ent.count == 0 {
 		// no more configs reference this client so close and delete
 		ent.Close()
-		delete(c.clients, *cfg.URL)
+		delete(c.clients, cfg.clientKey())
 		clientsMetric.Dec()
 	} else {
-		c.clients[*cfg.URL] = ent
+		c.clients[cfg.clientKey()] = ent
 	}
 	delete(c.configs, cfg.ID)
 	configsMetric.Dec()
@@ -16,7 +16,9 @@ type (
 		newClient(*Config) (client, error)
 	}
 
-	defaultFactory struct{}
+	defaultFactory struct {
+		emails emailSender
+	}
 )
 
 func (f *defaultFactory) newClient(cfg *Config) (client, error) {
@@ -27,6 +29,8 @@ func (f *defaultFactory) newClient(cfg *Config) (client, error) {
 		return newSlackClient(cfg)
 	case DestinationGCPPubSub:
 		return newPubSubClient(cfg)
+	case DestinationEmail:
+		return &emailClient{emails: f.emails}, nil
 	default:
 		return nil, ErrUnsupportedDestination
 	}
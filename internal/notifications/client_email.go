@@ -0,0 +1,64 @@
+package notifications
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var (
+	ErrEmailDisabled    = errors.New("sending email is disabled")
+	ErrNoEmailAddresses = errors.New("no email addresses specified")
+)
+
+var _ client = (*emailClient)(nil)
+
+type (
+	// emailClient sends notifications by email. Run notifications are
+	// collected into a digest per config.
+	emailClient struct {
+		emails emailSender
+	}
+
+	emailSender interface {
+		Enabled() bool
+		Send(ctx context.Context, to []string, tmpl string, data any) error
+		SendDigest(ctx context.Context, key string, to []string, tmpl string, data any) error
+	}
+
+	// emailNotification populates the run notification email template.
+	emailNotification struct {
+		Title        string
+		Organization string
+		Workspace    string
+		URL          string
+	}
+)
+
+func (c *emailClient) Publish(ctx context.Context, n *notification) error {
+	msg := emailNotification{
+		Organization: n.workspace.Organization,
+		Workspace:    n.workspace.Name,
+	}
+	if n.run == nil {
+		// verification notifications do not relate to a run, and are sent
+		// immediately.
+		if !c.emails.Enabled() {
+			return ErrEmailDisabled
+		}
+		if len(n.config.EmailAddresses) == 0 {
+			return ErrNoEmailAddresses
+		}
+		msg.Title = fmt.Sprintf("Verification of notification configuration %s", n.config.Name)
+		return c.emails.Send(ctx, n.config.EmailAddresses, "run_notification", msg)
+	}
+	if len(n.config.EmailAddresses) == 0 {
+		return nil
+	}
+	msg.Title = fmt.Sprintf("Run %s %s", n.run.ID, strings.ReplaceAll(string(n.run.Status), "_", " "))
+	msg.URL = n.runURL()
+	return c.emails.SendDigest(ctx, n.config.ID, n.config.EmailAddresses, "run_notification", msg)
+}
+
+func (c *emailClient) Close() {}
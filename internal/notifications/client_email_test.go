@@ -0,0 +1,91 @@
+package notifications
+
+import (
+	"context"
+	"testing"
+
+	"github.com/leg100/otf/internal/run"
+	"github.com/leg100/otf/internal/workspace"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEmailClient(t *testing.T) {
+	ctx := context.Background()
+	ws := &workspace.Workspace{ID: "ws-123", Name: "dev", Organization: "acme"}
+	cfg := &Config{
+		ID:              "nc-123",
+		Name:            "ops",
+		DestinationType: DestinationEmail,
+		EmailAddresses:  []string{"ops@example.com"},
+	}
+
+	t.Run("run notification is sent in digest", func(t *testing.T) {
+		emails := &fakeEmailSender{enabled: true}
+		client := &emailClient{emails: emails}
+
+		err := client.Publish(ctx, &notification{
+			workspace: ws,
+			run:       &run.Run{ID: "run-123", Status: run.RunPlannedAndFinished},
+			trigger:   TriggerCompleted,
+			config:    cfg,
+			hostname:  "otf.example.com",
+		})
+		require.NoError(t, err)
+
+		require.Equal(t, 1, len(emails.sent))
+		assert.Equal(t, "nc-123", emails.sent[0].digestKey)
+		assert.Equal(t, []string{"ops@example.com"}, emails.sent[0].to)
+		assert.Equal(t, "Run run-123 planned and finished", emails.sent[0].data.(emailNotification).Title)
+	})
+
+	t.Run("verification is sent immediately", func(t *testing.T) {
+		emails := &fakeEmailSender{enabled: true}
+		client := &emailClient{emails: emails}
+
+		err := client.Publish(ctx, &notification{
+			workspace: ws,
+			trigger:   TriggerVerification,
+			config:    cfg,
+		})
+		require.NoError(t, err)
+
+		require.Equal(t, 1, len(emails.sent))
+		assert.Empty(t, emails.sent[0].digestKey)
+	})
+
+	t.Run("verification fails when email disabled", func(t *testing.T) {
+		client := &emailClient{emails: &fakeEmailSender{}}
+
+		err := client.Publish(ctx, &notification{
+			workspace: ws,
+			trigger:   TriggerVerification,
+			config:    cfg,
+		})
+		assert.Equal(t, ErrEmailDisabled, err)
+	})
+}
+
+type (
+	fakeEmailSender struct {
+		enabled bool
+		sent    []fakeSentEmail
+	}
+	fakeSentEmail struct {
+		digestKey string
+		to        []string
+		data      any
+	}
+)
+
+func (f *fakeEmailSender) Enabled() bool { return f.enabled }
+
+func (f *fakeEmailSender) Send(_ context.Context, to []string, _ string, data any) error {
+	f.sent = append(f.sent, fakeSentEmail{to: to, data: data})
+	return nil
+}
+
+func (f *fakeEmailSender) SendDigest(_ context.Context, key string, to []string, _ string, data any) error {
+	f.sent = append(f.sent, fakeSentEmail{digestKey: key, to: to, data: data})
+	return nil
+}
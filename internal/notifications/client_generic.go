@@ -4,6 +4,8 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"fmt"
+	"io"
 	"net/http"
 	"time"
 
@@ -41,6 +43,13 @@ type (
 		client *http.Client
 		url    string
 	}
+
+	// deliveryError is returned when a destination responds to a notification
+	// with an unsuccessful status code.
+	deliveryError struct {
+		code int
+		body string
+	}
 )
 
 func newGenericClient(cfg *Config) (*genericClient, error) {
@@ -59,7 +68,12 @@ func (c *genericClient) Publish(ctx context.Context, n *notification) error {
 	if err != nil {
 		return err
 	}
-	req, err := http.NewRequest("POST", c.url, bytes.NewReader(data))
+	return c.post(ctx, data)
+}
+
+// post sends JSON-encoded data to the destination url.
+func (c *genericClient) post(ctx context.Context, data []byte) error {
+	req, err := http.NewRequestWithContext(ctx, "POST", c.url, bytes.NewReader(data))
 	if err != nil {
 		return err
 	}
@@ -68,10 +82,19 @@ func (c *genericClient) Publish(ctx context.Context, n *notification) error {
 	if err != nil {
 		return err
 	}
-	resp.Body.Close()
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return &deliveryError{code: resp.StatusCode, body: string(body)}
+	}
 	return nil
 }
 
 func (c *genericClient) Close() {
 	c.client.CloseIdleConnections()
 }
+
+func (e *deliveryError) Error() string {
+	return fmt.Sprintf("destination responded with status code %d", e.code)
+}
@@ -0,0 +1,61 @@
+package notifications
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/leg100/otf/internal"
+	"github.com/leg100/otf/internal/workspace"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenericClient_Publish_Verification(t *testing.T) {
+	ctx := context.Background()
+	ws := &workspace.Workspace{ID: "ws-123", Name: "dev", Organization: "acme"}
+
+	t.Run("successful", func(t *testing.T) {
+		got := make(chan *GenericPayload, 1)
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			var payload GenericPayload
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&payload))
+			got <- &payload
+		}))
+		t.Cleanup(srv.Close)
+
+		cfg := &Config{ID: "nc-123", Name: "my-config", URL: internal.String(srv.URL)}
+		client, err := newGenericClient(cfg)
+		require.NoError(t, err)
+
+		err = client.Publish(ctx, &notification{workspace: ws, config: cfg, trigger: TriggerVerification})
+		require.NoError(t, err)
+
+		payload := <-got
+		assert.Equal(t, "nc-123", payload.NotificationConfigurationID)
+		assert.Equal(t, "ws-123", payload.WorkspaceID)
+		assert.Equal(t, "", payload.RunID)
+		if assert.Equal(t, 1, len(payload.Notifications)) {
+			assert.Equal(t, TriggerVerification, payload.Notifications[0].Trigger)
+		}
+	})
+
+	t.Run("unsuccessful", func(t *testing.T) {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			http.Error(w, "bad token", http.StatusForbidden)
+		}))
+		t.Cleanup(srv.Close)
+
+		cfg := &Config{ID: "nc-123", Name: "my-config", URL: internal.String(srv.URL)}
+		client, err := newGenericClient(cfg)
+		require.NoError(t, err)
+
+		err = client.Publish(ctx, &notification{workspace: ws, config: cfg, trigger: TriggerVerification})
+		var derr *deliveryError
+		require.ErrorAs(t, err, &derr)
+		assert.Equal(t, http.StatusForbidden, derr.code)
+		assert.Equal(t, "bad token\n", derr.body)
+	})
+}
@@ -1,11 +1,9 @@
 package notifications
 
 import (
-	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
-	"net/http"
 	"strings"
 )
 
@@ -35,8 +33,20 @@ func newSlackClient(cfg *Config) (*slackClient, error) {
 }
 
 func (c *slackClient) Publish(ctx context.Context, n *notification) error {
-	data, err := json.Marshal(slackMessage{
-		Blocks: []slackBlock{
+	var blocks []slackBlock
+	if n.run == nil {
+		// verification notifications do not relate to a run
+		blocks = []slackBlock{
+			{
+				Type: "section",
+				Text: &slackBlock{
+					Type: "mrkdwn",
+					Text: fmt.Sprintf("Verification of notification configuration *%s* for %s/%s", n.config.Name, n.workspace.Organization, n.workspace.Name),
+				},
+			},
+		}
+	} else {
+		blocks = []slackBlock{
 			{
 				Type: "section",
 				Text: &slackBlock{
@@ -51,20 +61,11 @@ func (c *slackClient) Publish(ctx context.Context, n *notification) error {
 					Text: fmt.Sprintf("*run %s*", strings.ReplaceAll(string(n.run.Status), "_", " ")),
 				},
 			},
-		},
-	})
-	if err != nil {
-		return err
-	}
-	req, err := http.NewRequest("POST", c.url, bytes.NewReader(data))
-	if err != nil {
-		return err
+		}
 	}
-	req.Header.Set("Content-type", "application/json")
-	resp, err := c.client.Do(req)
+	data, err := json.Marshal(slackMessage{Blocks: blocks})
 	if err != nil {
 		return err
 	}
-	resp.Body.Close()
-	return nil
+	return c.post(ctx, data)
 }
@@ -3,6 +3,7 @@ package notifications
 import (
 	"errors"
 	"fmt"
+	"net/mail"
 	"net/url"
 	"time"
 
@@ -11,6 +12,7 @@ import (
 	"slices"
 
 	"github.com/leg100/otf/internal"
+	"github.com/leg100/otf/internal/resource"
 	"github.com/leg100/otf/internal/run"
 )
 
@@ -18,9 +20,8 @@ const (
 	DestinationGeneric   Destination = "generic"
 	DestinationSlack     Destination = "slack"
 	DestinationGCPPubSub Destination = "gcppubsub"
-	// Email type is only accepted in order to pass the `go-tfe` API tests,
-	// which create configs with this type. It otherwise is entirely
-	// unfunctional; no emails are sent.
+	// DestinationEmail sends notifications by email to the email addresses
+	// of the config, collected into digests.
 	DestinationEmail Destination = "email"
 
 	TriggerCreated        Trigger = "run:created"
@@ -29,6 +30,10 @@ const (
 	TriggerApplying       Trigger = "run:applying"
 	TriggerCompleted      Trigger = "run:completed"
 	TriggerErrored        Trigger = "run:errored"
+
+	// TriggerVerification is the trigger for a test notification sent to
+	// verify a config. It cannot be subscribed to.
+	TriggerVerification Trigger = "verification"
 )
 
 var (
@@ -50,6 +55,9 @@ type (
 		Triggers        []Trigger
 		URL             *string
 		WorkspaceID     string
+		// EmailAddresses are the recipients of notifications for the email
+		// destination type.
+		EmailAddresses []string
 	}
 
 	// Trigger is the event triggering a notification
@@ -76,6 +84,10 @@ type (
 
 		// Optional: The url of the notification configuration
 		URL *string
+
+		// Optional: The email addresses to which notifications are sent for
+		// the email destination type.
+		EmailAddresses []string
 	}
 
 	// UpdateConfigOptions represents the options for
@@ -95,6 +107,10 @@ type (
 
 		// Optional: The url of the notification configuration
 		URL *string
+
+		// Optional: The email addresses to which notifications are sent for
+		// the email destination type.
+		EmailAddresses []string
 	}
 )
 
@@ -119,6 +135,9 @@ func NewConfig(workspaceID string, opts CreateConfigOptions) (*Config, error) {
 	if err := validTriggers(opts.Triggers); err != nil {
 		return nil, err
 	}
+	if err := validEmailAddresses(opts.EmailAddresses); err != nil {
+		return nil, err
+	}
 	if opts.Enabled == nil {
 		return nil, &internal.MissingParameterError{Parameter: "enabled"}
 	}
@@ -130,7 +149,7 @@ func NewConfig(workspaceID string, opts CreateConfigOptions) (*Config, error) {
 	}
 
 	return &Config{
-		ID:              internal.NewID("nc"),
+		ID:              resource.NewID(resource.NotificationConfigurationKind),
 		CreatedAt:       internal.CurrentTimestamp(nil),
 		UpdatedAt:       internal.CurrentTimestamp(nil),
 		Name:            *opts.Name,
@@ -139,6 +158,7 @@ func NewConfig(workspaceID string, opts CreateConfigOptions) (*Config, error) {
 		DestinationType: opts.DestinationType,
 		URL:             opts.URL,
 		WorkspaceID:     workspaceID,
+		EmailAddresses:  opts.EmailAddresses,
 	}, nil
 }
 
@@ -172,9 +192,25 @@ func (c *Config) update(opts UpdateConfigOptions) error {
 	if opts.URL != nil {
 		c.URL = opts.URL
 	}
+	if err := validEmailAddresses(opts.EmailAddresses); err != nil {
+		return err
+	}
+	if opts.EmailAddresses != nil {
+		c.EmailAddresses = opts.EmailAddresses
+	}
 	return nil
 }
 
+// clientKey identifies the client that sends the config's notifications.
+// Configs with the same URL share a client, and all email configs share a
+// client.
+func (c *Config) clientKey() string {
+	if c.DestinationType == DestinationEmail {
+		return string(DestinationEmail)
+	}
+	return *c.URL
+}
+
 // matchTrigger determines whether the config has a trigger that matches the
 // given run state
 func (c *Config) matchTrigger(r *run.Run) (Trigger, bool) {
@@ -183,7 +219,7 @@ func (c *Config) matchTrigger(r *run.Run) (Trigger, bool) {
 		return TriggerCreated, c.hasTrigger(TriggerCreated)
 	case run.RunPlanning:
 		return TriggerPlanning, c.hasTrigger(TriggerPlanning)
-	case run.RunPlanned:
+	case run.RunPlanned, run.RunPolicyChecked, run.RunPolicyOverride:
 		return TriggerNeedsAttention, c.hasTrigger(TriggerNeedsAttention)
 	case run.RunApplying:
 		return TriggerApplying, c.hasTrigger(TriggerApplying)
@@ -215,3 +251,12 @@ func validTriggers(triggers []Trigger) error {
 	}
 	return nil
 }
+
+func validEmailAddresses(addresses []string) error {
+	for _, addr := range addresses {
+		if _, err := mail.ParseAddress(addr); err != nil {
+			return &internal.InvalidParameterError{Parameter: "email-addresses", Err: err}
+		}
+	}
+	return nil
+}
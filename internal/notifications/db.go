@@ -25,6 +25,7 @@ type (
 		DestinationType             pgtype.Text        `json:"destination_type"`
 		WorkspaceID                 pgtype.Text        `json:"workspace_id"`
 		Enabled                     pgtype.Bool        `json:"enabled"`
+		EmailAddresses              []string           `json:"email_addresses"`
 	}
 )
 
@@ -37,6 +38,7 @@ func (r pgresult) toNotificationConfiguration() *Config {
 		Enabled:         r.Enabled.Bool,
 		DestinationType: Destination(r.DestinationType.String),
 		WorkspaceID:     r.WorkspaceID.String,
+		EmailAddresses:  r.EmailAddresses,
 	}
 	for _, t := range r.Triggers {
 		nc.Triggers = append(nc.Triggers, Trigger(t))
@@ -57,6 +59,7 @@ func (db *pgdb) create(ctx context.Context, nc *Config) error {
 		DestinationType:             sql.String(string(nc.DestinationType)),
 		URL:                         sql.NullString(),
 		WorkspaceID:                 sql.String(nc.WorkspaceID),
+		EmailAddresses:              nc.EmailAddresses,
 	}
 	for _, t := range nc.Triggers {
 		params.Triggers = append(params.Triggers, string(t))
@@ -85,6 +88,7 @@ func (db *pgdb) update(ctx context.Context, id string, updateFunc func(*Config)
 			Name:                        sql.String(nc.Name),
 			URL:                         sql.NullString(),
 			NotificationConfigurationID: sql.String(nc.ID),
+			EmailAddresses:              nc.EmailAddresses,
 		}
 		for _, t := range nc.Triggers {
 			params.Triggers = append(params.Triggers, string(t))
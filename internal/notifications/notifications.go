@@ -24,38 +24,51 @@ type notification struct {
 
 func (n *notification) LogValue() slog.Value {
 	attrs := []slog.Attr{
-		slog.String("run", n.run.ID),
 		slog.String("workspace_id", n.workspace.ID),
 		slog.String("trigger", string(n.trigger)),
 		slog.String("destination", string(n.config.DestinationType)),
 	}
+	if n.run != nil {
+		attrs = append(attrs, slog.String("run", n.run.ID))
+	}
 	return slog.GroupValue(attrs...)
 }
 
 // genericPayload converts a notification into a format suitable for the generic
 // and GCP-pubsub destination types.
 func (n *notification) genericPayload() (*GenericPayload, error) {
-	runUpdatedAt, err := n.run.StatusTimestamp(n.run.Status)
-	if err != nil {
-		return nil, err
-	}
-	return &GenericPayload{
+	payload := &GenericPayload{
 		PayloadVersion:              1,
-		NotificationConfigurationID: "",
-		RunURL:                      n.runURL(),
-		RunID:                       n.run.ID,
-		RunCreatedAt:                n.run.CreatedAt,
+		NotificationConfigurationID: n.config.ID,
 		WorkspaceID:                 n.workspace.ID,
 		WorkspaceName:               n.workspace.Name,
 		OrganizationName:            n.workspace.Organization,
-		Notifications: []genericNotificationPayload{
+	}
+	if n.run == nil {
+		// verification notifications do not relate to a run
+		payload.Notifications = []genericNotificationPayload{
 			{
-				Trigger:      n.trigger,
-				RunStatus:    n.run.Status,
-				RunUpdatedAt: runUpdatedAt,
+				Message: "Verification of " + n.config.Name,
+				Trigger: n.trigger,
 			},
+		}
+		return payload, nil
+	}
+	runUpdatedAt, err := n.run.StatusTimestamp(n.run.Status)
+	if err != nil {
+		return nil, err
+	}
+	payload.RunURL = n.runURL()
+	payload.RunID = n.run.ID
+	payload.RunCreatedAt = n.run.CreatedAt
+	payload.Notifications = []genericNotificationPayload{
+		{
+			Trigger:      n.trigger,
+			RunStatus:    n.run.Status,
+			RunUpdatedAt: runUpdatedAt,
 		},
-	}, nil
+	}
+	return payload, nil
 }
 
 func (n *notification) runURL() string {
@@ -27,13 +27,15 @@ type (
 		system        notifierHostnameClient
 
 		*cache
-		db *pgdb
+		db     *pgdb
+		emails emailSender
 	}
 
 	NotifierOptions struct {
 		RunClient          notifierRunClient
 		WorkspaceClient    notifierWorkspaceClient
 		NotificationClient notifierNotificationClient
+		EmailService       emailSender
 
 		logr.Logger
 		*internal.HostnameService
@@ -65,6 +67,7 @@ func NewNotifier(opts NotifierOptions) *Notifier {
 		runs:          opts.RunClient,
 		notifications: opts.NotificationClient,
 		db:            &pgdb{opts.DB},
+		emails:        opts.EmailService,
 	}
 }
 
@@ -77,7 +80,7 @@ func (s *Notifier) Start(ctx context.Context) error {
 	defer unsubConfigs()
 
 	// populate cache with existing notification configs
-	cache, err := newCache(ctx, s.db, &defaultFactory{})
+	cache, err := newCache(ctx, s.db, &defaultFactory{emails: s.emails})
 	if err != nil {
 		return err
 	}
@@ -161,10 +164,10 @@ func (s *Notifier) handleRun(ctx context.Context, r *run.Run) error {
 				return err
 			}
 		}
-		client, ok := s.clients[*cfg.URL]
+		client, ok := s.clients[cfg.clientKey()]
 		if !ok {
 			// should never happen
-			return fmt.Errorf("client not found for url: %s", *cfg.URL)
+			return fmt.Errorf("client not found for config: %s", cfg.ID)
 		}
 		msg := &notification{
 			run:       r,
@@ -17,18 +17,25 @@ type (
 		logr.Logger
 
 		workspaceAuthorizer internal.Authorizer // authorize workspaces actions
+		workspaces          notifierWorkspaceClient
+		system              notifierHostnameClient
 		db                  *pgdb
 		api                 *tfe
 		broker              *pubsub.Broker[*Config]
+
+		clientFactory // constructs clients for verifying configs
 	}
 
 	Options struct {
 		*sql.DB
 		*sql.Listener
 		*tfeapi.Responder
+		*internal.HostnameService
 		logr.Logger
 
 		WorkspaceAuthorizer internal.Authorizer
+		WorkspaceService    notifierWorkspaceClient
+		EmailService        emailSender
 	}
 )
 
@@ -36,7 +43,10 @@ func NewService(opts Options) *Service {
 	svc := Service{
 		Logger:              opts.Logger,
 		workspaceAuthorizer: opts.WorkspaceAuthorizer,
+		workspaces:          opts.WorkspaceService,
+		system:              opts.HostnameService,
 		db:                  &pgdb{opts.DB},
+		clientFactory:       &defaultFactory{emails: opts.EmailService},
 	}
 	svc.api = &tfe{
 		Service:   &svc,
@@ -2,6 +2,7 @@ package notifications
 
 import (
 	"net/http"
+	"strconv"
 
 	"github.com/gorilla/mux"
 	"github.com/leg100/otf/internal"
@@ -22,7 +23,7 @@ func (a *tfe) addHandlers(r *mux.Router) {
 	r.HandleFunc("/workspaces/{workspace_id}/notification-configurations", a.listNotifications).Methods("GET")
 	r.HandleFunc("/notification-configurations/{id}", a.getNotification).Methods("GET")
 	r.HandleFunc("/notification-configurations/{id}", a.updateNotification).Methods("PATCH")
-	r.HandleFunc("/notification-configurations/{id}", a.verifyNotification).Methods("POST")
+	r.HandleFunc("/notification-configurations/{id}/actions/verify", a.verifyNotification).Methods("POST")
 	r.HandleFunc("/notification-configurations/{id}", a.deleteNotification).Methods("DELETE")
 }
 
@@ -47,6 +48,7 @@ func (a *tfe) createNotification(w http.ResponseWriter, r *http.Request) {
 		Enabled:         params.Enabled,
 		Name:            params.Name,
 		URL:             params.URL,
+		EmailAddresses:  params.EmailAddresses,
 	}
 	for _, t := range params.Triggers {
 		opts.Triggers = append(opts.Triggers, Trigger(t))
@@ -111,9 +113,10 @@ func (a *tfe) updateNotification(w http.ResponseWriter, r *http.Request) {
 	}
 
 	opts := UpdateConfigOptions{
-		Enabled: params.Enabled,
-		Name:    params.Name,
-		URL:     params.URL,
+		Enabled:        params.Enabled,
+		Name:           params.Name,
+		URL:            params.URL,
+		EmailAddresses: params.EmailAddresses,
 	}
 	for _, t := range params.Triggers {
 		opts.Triggers = append(opts.Triggers, Trigger(t))
@@ -128,7 +131,33 @@ func (a *tfe) updateNotification(w http.ResponseWriter, r *http.Request) {
 	a.Respond(w, r, a.convert(updated), http.StatusOK)
 }
 
-func (a *tfe) verifyNotification(w http.ResponseWriter, r *http.Request) {}
+func (a *tfe) verifyNotification(w http.ResponseWriter, r *http.Request) {
+	id, err := decode.Param("id", r)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+
+	nc, resp, err := a.Verify(r.Context(), id)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+
+	to := a.convert(nc)
+	to.DeliveryResponses = []*types.DeliveryResponse{
+		{
+			URL:        resp.URL,
+			SentAt:     resp.SentAt,
+			Successful: strconv.FormatBool(resp.Successful),
+			Body:       resp.Body,
+		},
+	}
+	if resp.Code != 0 {
+		to.DeliveryResponses[0].Code = strconv.Itoa(resp.Code)
+	}
+	a.Respond(w, r, to, http.StatusOK)
+}
 
 func (a *tfe) deleteNotification(w http.ResponseWriter, r *http.Request) {
 	id, err := decode.Param("id", r)
@@ -156,6 +185,7 @@ func (a *tfe) convert(from *Config) *types.NotificationConfiguration {
 		Subscribable: &types.Workspace{
 			ID: from.WorkspaceID,
 		},
+		EmailAddresses: from.EmailAddresses,
 	}
 	if from.URL != nil {
 		to.URL = *from.URL
@@ -0,0 +1,72 @@
+package notifications
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/leg100/otf/internal"
+	"github.com/leg100/otf/internal/rbac"
+)
+
+// DeliveryResponse is the outcome of sending a notification to a destination.
+type DeliveryResponse struct {
+	URL        string
+	SentAt     time.Time
+	Successful bool
+	// Code is the status code returned by the destination, if any.
+	Code int
+	// Body is the body of an unsuccessful response, or the error that
+	// prevented the notification from being sent.
+	Body string
+}
+
+// Verify sends a test notification to the destination of the config, allowing
+// the user to check the destination is reachable and correctly configured.
+func (s *Service) Verify(ctx context.Context, id string) (*Config, *DeliveryResponse, error) {
+	nc, err := s.db.get(ctx, id)
+	if err != nil {
+		s.Error(err, "retrieving notification config", "id", id)
+		return nil, nil, err
+	}
+	subject, err := s.workspaceAuthorizer.CanAccess(ctx, rbac.UpdateNotificationConfigurationAction, nc.WorkspaceID)
+	if err != nil {
+		return nil, nil, err
+	}
+	ws, err := s.workspaces.Get(ctx, nc.WorkspaceID)
+	if err != nil {
+		return nil, nil, err
+	}
+	client, err := s.newClient(nc)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer client.Close()
+
+	resp := &DeliveryResponse{
+		SentAt: internal.CurrentTimestamp(nil),
+	}
+	if nc.URL != nil {
+		resp.URL = *nc.URL
+	}
+	err = client.Publish(ctx, &notification{
+		workspace: ws,
+		trigger:   TriggerVerification,
+		config:    nc,
+		hostname:  s.system.Hostname(),
+	})
+	if err != nil {
+		var derr *deliveryError
+		if errors.As(err, &derr) {
+			resp.Code = derr.code
+			resp.Body = derr.body
+		} else {
+			resp.Body = err.Error()
+		}
+		s.Error(err, "verifying notification config", "config", nc, "subject", subject)
+	} else {
+		resp.Successful = true
+		s.V(1).Info("verified notification config", "config", nc, "subject", subject)
+	}
+	return nc, resp, nil
+}
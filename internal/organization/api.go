@@ -3,6 +3,7 @@ package organization
 import (
 	"encoding/json"
 	"net/http"
+	"time"
 
 	otfapi "github.com/leg100/otf/internal/api"
 
@@ -21,6 +22,10 @@ func (a *api) addHandlers(r *mux.Router) {
 
 	r.HandleFunc("/organizations", a.createOrganization).Methods("POST")
 	r.HandleFunc("/organizations/{name}", a.deleteOrganization).Methods("DELETE")
+
+	r.HandleFunc("/organizations/{name}/tokens", a.listTokens).Methods("GET")
+	r.HandleFunc("/organizations/{name}/tokens", a.createToken).Methods("POST")
+	r.HandleFunc("/organization-tokens/{token_id}", a.deleteToken).Methods("DELETE")
 }
 
 func (a *api) createOrganization(w http.ResponseWriter, r *http.Request) {
@@ -49,3 +54,65 @@ func (a *api) deleteOrganization(w http.ResponseWriter, r *http.Request) {
 	}
 	w.WriteHeader(http.StatusNoContent)
 }
+
+func (a *api) listTokens(w http.ResponseWriter, r *http.Request) {
+	name, err := decode.Param("name", r)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	tokens, err := a.ListTokens(r.Context(), name)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(tokens)
+}
+
+func (a *api) createToken(w http.ResponseWriter, r *http.Request) {
+	name, err := decode.Param("name", r)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	var params struct {
+		Description string     `json:"description"`
+		Expiry      *time.Time `json:"expiry"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&params); err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	ot, token, err := a.CreateToken(r.Context(), CreateOrganizationTokenOptions{
+		Organization: name,
+		Description:  params.Description,
+		Expiry:       params.Expiry,
+	})
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(struct {
+		*OrganizationToken
+		Token string `json:"token"`
+	}{
+		OrganizationToken: ot,
+		Token:             string(token),
+	})
+}
+
+func (a *api) deleteToken(w http.ResponseWriter, r *http.Request) {
+	tokenID, err := decode.Param("token_id", r)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	if err := a.DeleteTokenByID(r.Context(), tokenID); err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -2,6 +2,7 @@ package organization
 
 import (
 	"context"
+	"time"
 
 	"github.com/jackc/pgtype"
 	"github.com/jackc/pgx/v4"
@@ -184,6 +185,9 @@ type tokenRow struct {
 	CreatedAt           pgtype.Timestamptz `json:"created_at"`
 	OrganizationName    pgtype.Text        `json:"organization_name"`
 	Expiry              pgtype.Timestamptz `json:"expiry"`
+	Description         pgtype.Text        `json:"description"`
+	CreatedBy           pgtype.Text        `json:"created_by"`
+	LastUsedAt          pgtype.Timestamptz `json:"last_used_at"`
 }
 
 func (result tokenRow) toToken() *OrganizationToken {
@@ -191,21 +195,40 @@ func (result tokenRow) toToken() *OrganizationToken {
 		ID:           result.OrganizationTokenID.String,
 		CreatedAt:    result.CreatedAt.Time.UTC(),
 		Organization: result.OrganizationName.String,
+		Description:  result.Description.String,
+		CreatedBy:    result.CreatedBy.String,
 	}
 	if result.Expiry.Status == pgtype.Present {
 		ot.Expiry = internal.Time(result.Expiry.Time.UTC())
 	}
+	if result.LastUsedAt.Status == pgtype.Present {
+		ot.LastUsedAt = internal.Time(result.LastUsedAt.Time.UTC())
+	}
 	return ot
 }
 
-func (db *pgdb) upsertOrganizationToken(ctx context.Context, token *OrganizationToken) error {
-	_, err := db.Conn(ctx).UpsertOrganizationToken(ctx, pggen.UpsertOrganizationTokenParams{
+// createOrganizationToken persists an organization token. An unnamed token
+// replaces any existing unnamed token.
+func (db *pgdb) createOrganizationToken(ctx context.Context, token *OrganizationToken) error {
+	if token.Description == "" {
+		_, err := db.Conn(ctx).UpsertOrganizationToken(ctx, pggen.UpsertOrganizationTokenParams{
+			OrganizationTokenID: sql.String(token.ID),
+			OrganizationName:    sql.String(token.Organization),
+			CreatedAt:           sql.Timestamptz(token.CreatedAt),
+			Expiry:              sql.TimestamptzPtr(token.Expiry),
+			CreatedBy:           sql.String(token.CreatedBy),
+		})
+		return err
+	}
+	_, err := db.Conn(ctx).InsertOrganizationToken(ctx, pggen.InsertOrganizationTokenParams{
 		OrganizationTokenID: sql.String(token.ID),
 		OrganizationName:    sql.String(token.Organization),
 		CreatedAt:           sql.Timestamptz(token.CreatedAt),
 		Expiry:              sql.TimestamptzPtr(token.Expiry),
+		Description:         sql.String(token.Description),
+		CreatedBy:           sql.String(token.CreatedBy),
 	})
-	return err
+	return sql.Error(err)
 }
 
 func (db *pgdb) getOrganizationTokenByName(ctx context.Context, organization string) (*OrganizationToken, error) {
@@ -233,15 +256,15 @@ func (db *pgdb) getOrganizationTokenByID(ctx context.Context, tokenID string) (*
 	if err != nil {
 		return nil, sql.Error(err)
 	}
-	ot := &OrganizationToken{
-		ID:           result.OrganizationTokenID.String,
-		CreatedAt:    result.CreatedAt.Time.UTC(),
-		Organization: result.OrganizationName.String,
-	}
-	if result.Expiry.Status == pgtype.Present {
-		ot.Expiry = internal.Time(result.Expiry.Time.UTC())
+	return tokenRow(result).toToken(), nil
+}
+
+func (db *pgdb) updateOrganizationTokenLastUsedAt(ctx context.Context, tokenID string, lastUsedAt time.Time) error {
+	_, err := db.Conn(ctx).UpdateOrganizationTokenLastUsedAt(ctx, sql.Timestamptz(lastUsedAt), sql.String(tokenID))
+	if err != nil {
+		return sql.Error(err)
 	}
-	return ot, nil
+	return nil
 }
 
 func (db *pgdb) deleteOrganizationToken(ctx context.Context, organization string) error {
@@ -251,3 +274,11 @@ func (db *pgdb) deleteOrganizationToken(ctx context.Context, organization string
 	}
 	return nil
 }
+
+func (db *pgdb) deleteOrganizationTokenByID(ctx context.Context, tokenID string) error {
+	_, err := db.Conn(ctx).DeleteOrganizationTokenByID(ctx, sql.String(tokenID))
+	if err != nil {
+		return sql.Error(err)
+	}
+	return nil
+}
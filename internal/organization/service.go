@@ -98,7 +98,7 @@ func NewService(opts Options) *Service {
 	// Register with auth middleware the organization token and a means of
 	// retrieving organization corresponding to token.
 	opts.TokensService.RegisterKind(OrganizationTokenKind, func(ctx context.Context, tokenID string) (internal.Subject, error) {
-		return svc.getOrganizationTokenByID(ctx, tokenID)
+		return svc.authenticateOrganizationToken(ctx, tokenID)
 	})
 	return &svc
 }
@@ -205,6 +205,15 @@ func (s *Service) Get(ctx context.Context, name string) (*Organization, error) {
 	return org, nil
 }
 
+// ResolveAlias retrieves the ID of an organization by its alias, its name.
+func (s *Service) ResolveAlias(ctx context.Context, name string) (string, error) {
+	org, err := s.Get(ctx, name)
+	if err != nil {
+		return "", err
+	}
+	return org.ID, nil
+}
+
 func (s *Service) Delete(ctx context.Context, name string) error {
 	subject, err := s.CanAccess(ctx, rbac.DeleteOrganizationAction, name)
 	if err != nil {
@@ -261,10 +270,12 @@ func (s *Service) restrictOrganizationCreation(ctx context.Context) (internal.Su
 	return subject, nil
 }
 
-// CreateToken creates an organization token. If an organization
-// token already exists it is replaced.
+// CreateToken creates an organization token. If no description is provided
+// then the organization's unnamed token is created, replacing any existing
+// unnamed token; otherwise a named token is created alongside any existing
+// tokens, permitting tokens to be rotated without interruption.
 func (s *Service) CreateToken(ctx context.Context, opts CreateOrganizationTokenOptions) (*OrganizationToken, []byte, error) {
-	_, err := s.CanAccess(ctx, rbac.CreateOrganizationTokenAction, opts.Organization)
+	subject, err := s.CanAccess(ctx, rbac.CreateOrganizationTokenAction, opts.Organization)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -274,13 +285,14 @@ func (s *Service) CreateToken(ctx context.Context, opts CreateOrganizationTokenO
 		s.Error(err, "constructing organization token", "organization", opts.Organization)
 		return nil, nil, err
 	}
+	ot.CreatedBy = subject.String()
 
-	if err := s.db.upsertOrganizationToken(ctx, ot); err != nil {
+	if err := s.db.createOrganizationToken(ctx, ot); err != nil {
 		s.Error(err, "creating organization token", "organization", opts.Organization)
 		return nil, nil, err
 	}
 
-	s.V(0).Info("created organization token", "organization", opts.Organization)
+	s.V(0).Info("created organization token", "organization", opts.Organization, "id", ot.ID, "description", ot.Description, "subject", subject)
 
 	return ot, token, nil
 }
@@ -305,7 +317,33 @@ func (s *Service) getOrganizationTokenByID(ctx context.Context, tokenID string)
 	return ot, nil
 }
 
+// authenticateOrganizationToken retrieves the organization token with the
+// given ID for the purposes of authenticating a request, recording that the
+// token has been used.
+func (s *Service) authenticateOrganizationToken(ctx context.Context, tokenID string) (*OrganizationToken, error) {
+	ot, err := s.getOrganizationTokenByID(ctx, tokenID)
+	if err != nil {
+		return nil, err
+	}
+	now := internal.CurrentTimestamp(nil)
+	if ot.dueLastUsedUpdate(now) {
+		// failure to record use of the token is not fatal
+		if err := s.db.updateOrganizationTokenLastUsedAt(ctx, tokenID, now); err != nil {
+			s.Error(err, "recording use of organization token", "token_id", tokenID)
+		} else {
+			ot.LastUsedAt = &now
+		}
+	}
+	return ot, nil
+}
+
+// ListTokens lists an organization's tokens, ordered by creation time.
 func (s *Service) ListTokens(ctx context.Context, organization string) ([]*OrganizationToken, error) {
+	_, err := s.CanAccess(ctx, rbac.CreateOrganizationTokenAction, organization)
+	if err != nil {
+		return nil, err
+	}
+
 	tokens, err := s.db.listOrganizationTokens(ctx, organization)
 	if err != nil {
 		s.Error(err, "listing organization tokens", "organization", organization)
@@ -315,6 +353,7 @@ func (s *Service) ListTokens(ctx context.Context, organization string) ([]*Organ
 	return tokens, nil
 }
 
+// DeleteToken deletes an organization's unnamed token.
 func (s *Service) DeleteToken(ctx context.Context, organization string) error {
 	_, err := s.CanAccess(ctx, rbac.CreateOrganizationTokenAction, organization)
 	if err != nil {
@@ -330,3 +369,26 @@ func (s *Service) DeleteToken(ctx context.Context, organization string) error {
 
 	return nil
 }
+
+// DeleteTokenByID deletes an organization token, named or unnamed, with the
+// given ID.
+func (s *Service) DeleteTokenByID(ctx context.Context, tokenID string) error {
+	ot, err := s.db.getOrganizationTokenByID(ctx, tokenID)
+	if err != nil {
+		s.Error(err, "retrieving organization token", "token_id", tokenID)
+		return err
+	}
+	subject, err := s.CanAccess(ctx, rbac.CreateOrganizationTokenAction, ot.Organization)
+	if err != nil {
+		return err
+	}
+
+	if err := s.db.deleteOrganizationTokenByID(ctx, tokenID); err != nil {
+		s.Error(err, "deleting organization token", "token_id", tokenID, "subject", subject)
+		return err
+	}
+
+	s.V(0).Info("deleted organization token", "organization", ot.Organization, "token_id", tokenID, "subject", subject)
+
+	return nil
+}
@@ -5,20 +5,31 @@ import (
 
 	"github.com/leg100/otf/internal"
 	"github.com/leg100/otf/internal/rbac"
+	"github.com/leg100/otf/internal/resource"
 	"github.com/leg100/otf/internal/tokens"
 )
 
 const OrganizationTokenKind tokens.Kind = "organization_token"
 
 type (
-	// OrganizationToken provides information about an API token for an organization
+	// OrganizationToken provides information about an API token for an
+	// organization. An organization has at most one unnamed token, and any
+	// number of named tokens, i.e. tokens with a description.
 	OrganizationToken struct {
-		ID        string
-		CreatedAt time.Time
+		ID        string    `json:"id"`
+		CreatedAt time.Time `json:"created_at"`
 		// Token belongs to an organization
-		Organization string
+		Organization string `json:"organization"`
 		// Optional expiry.
-		Expiry *time.Time
+		Expiry *time.Time `json:"expiry,omitempty"`
+		// Optional human-readable description, e.g. the integration that uses
+		// the token.
+		Description string `json:"description,omitempty"`
+		// CreatedBy identifies the subject that created the token.
+		CreatedBy string `json:"created_by,omitempty"`
+		// LastUsedAt is when the token was last used to authenticate, to
+		// the nearest minute. Nil if it has never been used.
+		LastUsedAt *time.Time `json:"last_used_at,omitempty"`
 	}
 
 	// CreateOrganizationTokenOptions are options for creating an organization token via the service
@@ -26,6 +37,10 @@ type (
 	CreateOrganizationTokenOptions struct {
 		Organization string `schema:"organization_name,required"`
 		Expiry       *time.Time
+		// Description names the token. If empty, the organization's unnamed
+		// token is created, replacing any existing unnamed token. Otherwise a
+		// new token is created alongside existing tokens.
+		Description string `schema:"description"`
 	}
 
 	// tokenFactory constructs organization tokens
@@ -36,10 +51,11 @@ type (
 
 func (f *tokenFactory) NewOrganizationToken(opts CreateOrganizationTokenOptions) (*OrganizationToken, []byte, error) {
 	ot := OrganizationToken{
-		ID:           internal.NewID("ot"),
+		ID:           resource.NewID(resource.OrganizationTokenKind),
 		CreatedAt:    internal.CurrentTimestamp(nil),
 		Organization: opts.Organization,
 		Expiry:       opts.Expiry,
+		Description:  opts.Description,
 	}
 	token, err := f.tokens.NewToken(tokens.NewTokenOptions{
 		Subject: ot.ID,
@@ -52,6 +68,16 @@ func (f *tokenFactory) NewOrganizationToken(opts CreateOrganizationTokenOptions)
 	return &ot, token, nil
 }
 
+// lastUsedInterval is the minimum interval between recording the use of an
+// organization token, to avoid writing to the database on every request.
+const lastUsedInterval = time.Minute
+
+// dueLastUsedUpdate determines whether the time the token was last used
+// should be updated.
+func (u *OrganizationToken) dueLastUsedUpdate(now time.Time) bool {
+	return u.LastUsedAt == nil || now.Sub(*u.LastUsedAt) >= lastUsedInterval
+}
+
 func (u *OrganizationToken) CanAccessSite(action rbac.Action) bool {
 	// only be used for organization-scoped resources.
 	return false
@@ -69,7 +95,7 @@ func (u *OrganizationToken) CanAccessOrganization(action rbac.Action, org string
 	// can perform most actions in an organization, so it is easier to first refuse
 	// access to those actions it CANNOT perform.
 	switch action {
-	case rbac.GetRunAction, rbac.ListRunsAction, rbac.ApplyRunAction, rbac.CreateRunAction, rbac.DiscardRunAction, rbac.CancelRunAction, rbac.ForceCancelRunAction, rbac.EnqueuePlanAction, rbac.PutChunkAction, rbac.TailLogsAction, rbac.CreateStateVersionAction, rbac.RollbackStateVersionAction:
+	case rbac.GetRunAction, rbac.ListRunsAction, rbac.ApplyRunAction, rbac.CreateRunAction, rbac.DiscardRunAction, rbac.CancelRunAction, rbac.ForceCancelRunAction, rbac.EnqueuePlanAction, rbac.EnqueueApplyAction, rbac.PutChunkAction, rbac.TailLogsAction, rbac.CreateStateVersionAction, rbac.RollbackStateVersionAction:
 		return false
 	}
 	return true
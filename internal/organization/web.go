@@ -34,6 +34,7 @@ type (
 		CreateToken(ctx context.Context, opts CreateOrganizationTokenOptions) (*OrganizationToken, []byte, error)
 		ListTokens(ctx context.Context, organization string) ([]*OrganizationToken, error)
 		DeleteToken(ctx context.Context, organization string) error
+		DeleteTokenByID(ctx context.Context, tokenID string) error
 	}
 
 	// OrganizationPage contains data shared by all organization-based pages.
@@ -230,7 +231,7 @@ func (a *web) delete(w http.ResponseWriter, r *http.Request) {
 
 func (a *web) createOrganizationToken(w http.ResponseWriter, r *http.Request) {
 	var opts CreateOrganizationTokenOptions
-	if err := decode.Route(&opts, r); err != nil {
+	if err := decode.All(&opts, r); err != nil {
 		a.Error(w, err.Error(), http.StatusUnprocessableEntity)
 		return
 	}
@@ -253,35 +254,56 @@ func (a *web) organizationToken(w http.ResponseWriter, r *http.Request) {
 		a.Error(w, err.Error(), http.StatusUnprocessableEntity)
 		return
 	}
-	// ListOrganizationTokens should only ever return either 0 or 1 token
 	tokens, err := a.svc.ListTokens(r.Context(), org)
 	if err != nil {
 		a.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
-	var token *OrganizationToken
-	if len(tokens) > 0 {
-		token = tokens[0]
+	// separate the unnamed token, of which there is at most one, from the
+	// named tokens.
+	var (
+		token *OrganizationToken
+		named []*OrganizationToken
+	)
+	for _, ot := range tokens {
+		if ot.Description == "" {
+			token = ot
+		} else {
+			named = append(named, ot)
+		}
 	}
 	a.Render("organization_token.tmpl", w, struct {
 		OrganizationPage
-		Token *OrganizationToken
+		Token       *OrganizationToken
+		NamedTokens []*OrganizationToken
 	}{
 		OrganizationPage: NewPage(r, org, org),
 		Token:            token,
+		NamedTokens:      named,
 	})
 }
 
 func (a *web) deleteOrganizationToken(w http.ResponseWriter, r *http.Request) {
-	organization, err := decode.Param("organization_name", r)
-	if err != nil {
+	var params struct {
+		Organization string `schema:"organization_name,required"`
+		// TokenID is the ID of a named token to delete; if empty then the
+		// unnamed token is deleted.
+		TokenID string `schema:"token_id"`
+	}
+	if err := decode.All(&params, r); err != nil {
 		a.Error(w, err.Error(), http.StatusUnprocessableEntity)
 		return
 	}
-	if err := a.svc.DeleteToken(r.Context(), organization); err != nil {
+	var err error
+	if params.TokenID != "" {
+		err = a.svc.DeleteTokenByID(r.Context(), params.TokenID)
+	} else {
+		err = a.svc.DeleteToken(r.Context(), params.Organization)
+	}
+	if err != nil {
 		a.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
 	html.FlashSuccess(w, "Deleted organization token")
-	http.Redirect(w, r, paths.OrganizationToken(organization), http.StatusFound)
+	http.Redirect(w, r, paths.OrganizationToken(params.Organization), http.StatusFound)
 }
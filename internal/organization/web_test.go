@@ -6,6 +6,7 @@ import (
 	"net/url"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/antchfx/htmlquery"
 	"github.com/google/uuid"
@@ -155,9 +156,65 @@ func TestWeb_DeleteHandler(t *testing.T) {
 	testutils.AssertRedirect(t, w, paths.Organizations())
 }
 
+func TestWeb_OrganizationTokens(t *testing.T) {
+	lastUsed := time.Now().Add(-time.Hour)
+	fakeService := &fakeWebService{
+		tokens: []*OrganizationToken{
+			{ID: "ot-unnamed", Organization: "acme-corp", CreatedAt: time.Now()},
+			{ID: "ot-ci", Organization: "acme-corp", CreatedAt: time.Now(), Description: "ci", CreatedBy: "bobby", LastUsedAt: &lastUsed},
+			{ID: "ot-ci-new", Organization: "acme-corp", CreatedAt: time.Now(), Description: "ci (rotated)"},
+		},
+	}
+	svc := &web{
+		svc:      fakeService,
+		Renderer: testutils.NewRenderer(t),
+	}
+
+	t.Run("list", func(t *testing.T) {
+		r := httptest.NewRequest("GET", "/?organization_name=acme-corp", nil)
+		w := httptest.NewRecorder()
+		svc.organizationToken(w, r)
+		require.Equal(t, 200, w.Code, w.Body.String())
+
+		doc, err := htmlquery.Parse(w.Body)
+		require.NoError(t, err)
+		assert.NotNil(t, htmlquery.FindOne(doc, `//button[text()='regenerate']`))
+		assert.NotNil(t, htmlquery.FindOne(doc, `//div[@id='item-ot-ci']//span[text()='ci']`))
+		assert.NotNil(t, htmlquery.FindOne(doc, `//div[@id='item-ot-ci']//span[text()='created by bobby']`))
+		assert.NotNil(t, htmlquery.FindOne(doc, `//div[@id='item-ot-ci-new']//span[text()='never used']`))
+	})
+
+	t.Run("create named token", func(t *testing.T) {
+		form := strings.NewReader(url.Values{
+			"description": {"deploy"},
+		}.Encode())
+		r := httptest.NewRequest("POST", "/?organization_name=acme-corp", form)
+		r.Header.Add("Content-Type", "application/x-www-form-urlencoded")
+		w := httptest.NewRecorder()
+		svc.createOrganizationToken(w, r)
+		testutils.AssertRedirect(t, w, paths.OrganizationToken("acme-corp"))
+		assert.Equal(t, "deploy", fakeService.created.Description)
+	})
+
+	t.Run("delete named token", func(t *testing.T) {
+		form := strings.NewReader(url.Values{
+			"token_id": {"ot-ci"},
+		}.Encode())
+		r := httptest.NewRequest("POST", "/?organization_name=acme-corp", form)
+		r.Header.Add("Content-Type", "application/x-www-form-urlencoded")
+		w := httptest.NewRecorder()
+		svc.deleteOrganizationToken(w, r)
+		testutils.AssertRedirect(t, w, paths.OrganizationToken("acme-corp"))
+		assert.Equal(t, "ot-ci", fakeService.deleted)
+	})
+}
+
 type (
 	fakeWebService struct {
-		orgs []*Organization
+		orgs    []*Organization
+		tokens  []*OrganizationToken
+		created CreateOrganizationTokenOptions
+		deleted string
 
 		webService
 	}
@@ -179,6 +236,20 @@ func (f *fakeWebService) Delete(context.Context, string) error {
 	return nil
 }
 
+func (f *fakeWebService) CreateToken(ctx context.Context, opts CreateOrganizationTokenOptions) (*OrganizationToken, []byte, error) {
+	f.created = opts
+	return &OrganizationToken{ID: "ot-new", Organization: opts.Organization, Description: opts.Description}, []byte("token"), nil
+}
+
+func (f *fakeWebService) ListTokens(context.Context, string) ([]*OrganizationToken, error) {
+	return f.tokens, nil
+}
+
+func (f *fakeWebService) DeleteTokenByID(ctx context.Context, tokenID string) error {
+	f.deleted = tokenID
+	return nil
+}
+
 func (s *unprivilegedSubject) CanAccessSite(_ rbac.Action) bool {
 	return false
 }
@@ -0,0 +1,80 @@
+package orgexport
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	otfapi "github.com/leg100/otf/internal/api"
+	"github.com/leg100/otf/internal/http/decode"
+	"github.com/leg100/otf/internal/tfeapi"
+)
+
+type api struct {
+	*Service
+}
+
+func (a *api) addHandlers(r *mux.Router) {
+	r = r.PathPrefix(otfapi.DefaultBasePath).Subrouter()
+
+	r.HandleFunc("/admin/export-streams", a.list).Methods("GET")
+	r.HandleFunc("/admin/export-streams/{organization_name}", a.enable).Methods("POST")
+	r.HandleFunc("/admin/export-streams/{organization_name}", a.get).Methods("GET")
+	r.HandleFunc("/admin/export-streams/{organization_name}", a.disable).Methods("DELETE")
+}
+
+func (a *api) list(w http.ResponseWriter, r *http.Request) {
+	streams, err := a.List(r.Context())
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	a.respond(w, streams, http.StatusOK)
+}
+
+func (a *api) enable(w http.ResponseWriter, r *http.Request) {
+	organization, err := decode.Param("organization_name", r)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	stream, err := a.Enable(r.Context(), organization)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	a.respond(w, stream, http.StatusCreated)
+}
+
+func (a *api) get(w http.ResponseWriter, r *http.Request) {
+	organization, err := decode.Param("organization_name", r)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	stream, err := a.Get(r.Context(), organization)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	a.respond(w, stream, http.StatusOK)
+}
+
+func (a *api) disable(w http.ResponseWriter, r *http.Request) {
+	organization, err := decode.Param("organization_name", r)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	if err := a.Disable(r.Context(), organization); err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (a *api) respond(w http.ResponseWriter, v any, code int) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	json.NewEncoder(w).Encode(v)
+}
@@ -0,0 +1,135 @@
+package orgexport
+
+import (
+	"context"
+
+	"github.com/jackc/pgtype"
+	"github.com/leg100/otf/internal"
+	"github.com/leg100/otf/internal/sql"
+	"github.com/leg100/otf/internal/sql/pggen"
+)
+
+// pgdb is a database of export streams and the changes pending export on
+// postgres
+type pgdb struct {
+	*sql.DB // provides access to generated SQL queries
+}
+
+type streamRow struct {
+	OrganizationName pgtype.Text        `json:"organization_name"`
+	CreatedAt        pgtype.Timestamptz `json:"created_at"`
+	ExportedSequence pgtype.Int8        `json:"exported_sequence"`
+	ExportedAt       pgtype.Timestamptz `json:"exported_at"`
+	LastError        pgtype.Text        `json:"last_error"`
+	Pending          pgtype.Int8        `json:"pending"`
+}
+
+func (r streamRow) toStream() *Stream {
+	stream := &Stream{
+		Organization:     r.OrganizationName.String,
+		CreatedAt:        r.CreatedAt.Time.UTC(),
+		ExportedSequence: r.ExportedSequence.Int,
+		Pending:          int(r.Pending.Int),
+	}
+	if r.ExportedAt.Status == pgtype.Present {
+		stream.ExportedAt = internal.Time(r.ExportedAt.Time.UTC())
+	}
+	if r.LastError.Status == pgtype.Present {
+		stream.LastError = &r.LastError.String
+	}
+	return stream
+}
+
+// createStream creates a stream along with a snapshot of the organization,
+// so that the stream begins with the state of the organization at the time
+// the stream was created.
+func (db *pgdb) createStream(ctx context.Context, stream *Stream) error {
+	return db.Tx(ctx, func(ctx context.Context, q pggen.Querier) error {
+		_, err := q.InsertOrganizationExportStream(ctx, pggen.InsertOrganizationExportStreamParams{
+			OrganizationName: sql.String(stream.Organization),
+			CreatedAt:        sql.Timestamptz(stream.CreatedAt),
+		})
+		if err != nil {
+			return sql.Error(err)
+		}
+		if _, err := q.SnapshotOrganization(ctx, sql.String(stream.Organization)); err != nil {
+			return sql.Error(err)
+		}
+		return nil
+	})
+}
+
+func (db *pgdb) getStream(ctx context.Context, organization string) (*Stream, error) {
+	row, err := db.Conn(ctx).FindOrganizationExportStream(ctx, sql.String(organization))
+	if err != nil {
+		return nil, sql.Error(err)
+	}
+	return streamRow(row).toStream(), nil
+}
+
+func (db *pgdb) listStreams(ctx context.Context) ([]*Stream, error) {
+	rows, err := db.Conn(ctx).FindOrganizationExportStreams(ctx)
+	if err != nil {
+		return nil, sql.Error(err)
+	}
+	streams := make([]*Stream, len(rows))
+	for i, r := range rows {
+		streams[i] = streamRow(r).toStream()
+	}
+	return streams, nil
+}
+
+func (db *pgdb) updateStream(ctx context.Context, stream *Stream) error {
+	_, err := db.Conn(ctx).UpdateOrganizationExportStream(ctx, pggen.UpdateOrganizationExportStreamParams{
+		ExportedSequence: sql.Int8(int(stream.ExportedSequence)),
+		ExportedAt:       sql.TimestamptzPtr(stream.ExportedAt),
+		LastError:        sql.StringPtr(stream.LastError),
+		OrganizationName: sql.String(stream.Organization),
+	})
+	return sql.Error(err)
+}
+
+// deleteStream deletes a stream along with any changes pending export.
+func (db *pgdb) deleteStream(ctx context.Context, organization string) error {
+	_, err := db.Conn(ctx).DeleteOrganizationExportStream(ctx, sql.String(organization))
+	return sql.Error(err)
+}
+
+// listChanges lists up to limit changes pending export for an organization,
+// in sequence order.
+func (db *pgdb) listChanges(ctx context.Context, organization string, limit int) ([]*Change, error) {
+	rows, err := db.Conn(ctx).FindOrganizationChanges(ctx, pggen.FindOrganizationChangesParams{
+		OrganizationName: sql.String(organization),
+		Limit:            sql.Int8(limit),
+	})
+	if err != nil {
+		return nil, sql.Error(err)
+	}
+	changes := make([]*Change, len(rows))
+	for i, r := range rows {
+		changes[i] = &Change{
+			Sequence:     r.Sequence.Int,
+			Organization: r.OrganizationName.String,
+			Table:        r.TableName.String,
+			Action:       Action(r.Action.String),
+			Data:         r.Data,
+			CreatedAt:    r.CreatedAt.Time.UTC(),
+		}
+	}
+	return changes, nil
+}
+
+// markExported removes exported changes from the log and updates their
+// stream accordingly.
+func (db *pgdb) markExported(ctx context.Context, stream *Stream, changes []*Change) error {
+	sequences := make([]int64, len(changes))
+	for i, change := range changes {
+		sequences[i] = change.Sequence
+	}
+	return db.Tx(ctx, func(ctx context.Context, q pggen.Querier) error {
+		if _, err := q.DeleteOrganizationChanges(ctx, sequences); err != nil {
+			return sql.Error(err)
+		}
+		return db.updateStream(ctx, stream)
+	})
+}
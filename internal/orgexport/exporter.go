@@ -0,0 +1,113 @@
+package orgexport
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-logr/logr"
+)
+
+// ExporterLockID guarantees only one exporter on a cluster is running at any
+// time.
+const ExporterLockID int64 = 5577006791947779425
+
+var (
+	defaultExporterInterval = 10 * time.Second
+	// defaultBatchSize is the maximum number of changes written to the sink
+	// at a time.
+	defaultBatchSize = 1000
+)
+
+// Exporter exports the changes made to organizations with an export stream to
+// the sink.
+//
+// Only one exporter should be running on an OTF cluster at any one time.
+type Exporter struct {
+	logr.Logger
+
+	db   store
+	sink Sink
+	// frequency with which the exporter checks for changes to export.
+	interval time.Duration
+	// maximum number of changes written to the sink at a time.
+	batchSize int
+}
+
+// NewExporter constructs an exporter of organization changes.
+func (s *Service) NewExporter(logger logr.Logger) *Exporter {
+	return &Exporter{
+		Logger:    logger.WithValues("component", "organization-exporter"),
+		db:        s.db,
+		sink:      s.sink,
+		interval:  defaultExporterInterval,
+		batchSize: defaultBatchSize,
+	}
+}
+
+func (e *Exporter) String() string { return "organization-exporter" }
+
+// Start the exporter. Every interval pending changes are exported.
+//
+// Should be invoked in a go routine.
+func (e *Exporter) Start(ctx context.Context) error {
+	ticker := time.NewTicker(e.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			if err := e.export(ctx); err != nil {
+				return err
+			}
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
+
+func (e *Exporter) export(ctx context.Context) error {
+	streams, err := e.db.listStreams(ctx)
+	if err != nil {
+		return err
+	}
+	for _, stream := range streams {
+		if stream.Pending == 0 {
+			continue
+		}
+		// carry on exporting other streams; the failed stream's changes are
+		// retried on the next interval.
+		if err := e.exportStream(ctx, stream); err != nil {
+			e.Error(err, "exporting organization changes", "stream", stream)
+		}
+	}
+	return nil
+}
+
+// exportStream exports a stream's pending changes in batches, in sequence
+// order. A batch is removed from the log only once it has been written to
+// the sink, so a batch is written at least once.
+func (e *Exporter) exportStream(ctx context.Context, stream *Stream) error {
+	for {
+		changes, err := e.db.listChanges(ctx, stream.Organization, e.batchSize)
+		if err != nil {
+			return err
+		}
+		if len(changes) == 0 {
+			return nil
+		}
+		if err := e.sink.Write(ctx, stream.Organization, changes); err != nil {
+			stream.failed(err)
+			if updateErr := e.db.updateStream(ctx, stream); updateErr != nil {
+				e.Error(updateErr, "recording organization export error", "stream", stream)
+			}
+			return err
+		}
+		stream.exported(changes)
+		if err := e.db.markExported(ctx, stream, changes); err != nil {
+			return err
+		}
+		e.V(1).Info("exported organization changes", "stream", stream, "count", len(changes))
+		if len(changes) < e.batchSize {
+			return nil
+		}
+	}
+}
@@ -0,0 +1,104 @@
+package orgexport
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExporter(t *testing.T) {
+	ctx := context.Background()
+
+	newChanges := func(sequences ...int64) []*Change {
+		changes := make([]*Change, len(sequences))
+		for i, seq := range sequences {
+			changes[i] = &Change{Sequence: seq, Organization: "acme", Table: "workspaces", Action: InsertAction}
+		}
+		return changes
+	}
+
+	t.Run("export in batches", func(t *testing.T) {
+		db := &fakeStore{
+			streams: []*Stream{{Organization: "acme", Pending: 5}},
+			changes: newChanges(1, 2, 4, 3, 5),
+		}
+		sink := &fakeSink{}
+		exporter := &Exporter{Logger: logr.Discard(), db: db, sink: sink, batchSize: 2}
+
+		require.NoError(t, exporter.export(ctx))
+
+		require.Len(t, sink.batches, 3)
+		assert.Equal(t, newChanges(1, 2), sink.batches[0])
+		assert.Equal(t, newChanges(4, 3), sink.batches[1])
+		assert.Equal(t, newChanges(5), sink.batches[2])
+		assert.Empty(t, db.changes)
+		assert.Equal(t, int64(5), db.streams[0].ExportedSequence)
+		assert.NotNil(t, db.streams[0].ExportedAt)
+	})
+
+	t.Run("skip stream without pending changes", func(t *testing.T) {
+		db := &fakeStore{streams: []*Stream{{Organization: "acme"}}}
+		sink := &fakeSink{}
+		exporter := &Exporter{Logger: logr.Discard(), db: db, sink: sink, batchSize: 2}
+
+		require.NoError(t, exporter.export(ctx))
+
+		assert.Empty(t, sink.batches)
+	})
+
+	t.Run("retain changes the sink fails to accept", func(t *testing.T) {
+		db := &fakeStore{
+			streams: []*Stream{{Organization: "acme", Pending: 1}},
+			changes: newChanges(1),
+		}
+		sink := &fakeSink{err: errors.New("bucket not found")}
+		exporter := &Exporter{Logger: logr.Discard(), db: db, sink: sink, batchSize: 2}
+
+		require.NoError(t, exporter.export(ctx))
+
+		assert.Equal(t, newChanges(1), db.changes)
+		assert.Equal(t, int64(0), db.streams[0].ExportedSequence)
+		if assert.NotNil(t, db.streams[0].LastError) {
+			assert.Equal(t, "bucket not found", *db.streams[0].LastError)
+		}
+	})
+}
+
+type fakeStore struct {
+	streams []*Stream
+	changes []*Change
+
+	store
+}
+
+func (f *fakeStore) listStreams(context.Context) ([]*Stream, error) {
+	return f.streams, nil
+}
+
+func (f *fakeStore) updateStream(context.Context, *Stream) error { return nil }
+
+func (f *fakeStore) listChanges(_ context.Context, _ string, limit int) ([]*Change, error) {
+	return f.changes[:min(limit, len(f.changes))], nil
+}
+
+func (f *fakeStore) markExported(_ context.Context, _ *Stream, exported []*Change) error {
+	f.changes = f.changes[len(exported):]
+	return nil
+}
+
+type fakeSink struct {
+	batches [][]*Change
+	err     error
+}
+
+func (f *fakeSink) Write(_ context.Context, _ string, changes []*Change) error {
+	if f.err != nil {
+		return f.err
+	}
+	f.batches = append(f.batches, changes)
+	return nil
+}
@@ -0,0 +1,92 @@
+package orgexport
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// kafkaContentType is the content type of records produced via the v2 API of
+// a Kafka REST proxy.
+const kafkaContentType = "application/vnd.kafka.json.v2+json"
+
+// kafkaTimeout is the time the REST proxy is given to respond.
+var kafkaTimeout = 30 * time.Second
+
+type (
+	// kafkaSink produces changes to a Kafka topic via a Kafka REST proxy.
+	// Each change is keyed by its organization, so that the changes made to
+	// an organization are produced to the same partition, preserving their
+	// order.
+	kafkaSink struct {
+		url    string
+		client *http.Client
+	}
+
+	kafkaRecords struct {
+		Records []kafkaRecord `json:"records"`
+	}
+
+	kafkaRecord struct {
+		Key   string  `json:"key"`
+		Value *Change `json:"value"`
+	}
+
+	kafkaResponse struct {
+		Offsets []struct {
+			ErrorCode *int    `json:"error_code"`
+			Error     *string `json:"error"`
+		} `json:"offsets"`
+	}
+)
+
+func newKafkaSink(cfg Config) *kafkaSink {
+	return &kafkaSink{
+		url:    cfg.KafkaRESTURL + "/topics/" + url.PathEscape(cfg.KafkaTopic),
+		client: &http.Client{Timeout: kafkaTimeout},
+	}
+}
+
+func (s *kafkaSink) Write(ctx context.Context, organization string, changes []*Change) error {
+	records := kafkaRecords{Records: make([]kafkaRecord, len(changes))}
+	for i, change := range changes {
+		records.Records[i] = kafkaRecord{Key: organization, Value: change}
+	}
+	body, err := json.Marshal(records)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, "POST", s.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", kafkaContentType)
+	req.Header.Set("Accept", "application/vnd.kafka.v2+json")
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("kafka rest proxy responded with unexpected status: %s", resp.Status)
+	}
+	// the proxy reports whether each record was produced successfully.
+	var produced kafkaResponse
+	if err := json.NewDecoder(resp.Body).Decode(&produced); err != nil {
+		return fmt.Errorf("decoding kafka rest proxy response: %w", err)
+	}
+	for i, offset := range produced.Offsets {
+		if offset.ErrorCode != nil {
+			msg := ""
+			if offset.Error != nil {
+				msg = *offset.Error
+			}
+			return fmt.Errorf("producing record %d to kafka: error code %d: %s", i, *offset.ErrorCode, msg)
+		}
+	}
+	return nil
+}
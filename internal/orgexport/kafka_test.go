@@ -0,0 +1,62 @@
+package orgexport
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestKafkaSink(t *testing.T) {
+	changes := []*Change{
+		{Sequence: 1, Organization: "acme", Table: "workspaces", Action: SnapshotAction, Data: json.RawMessage(`{"name":"dev"}`)},
+		{Sequence: 2, Organization: "acme", Table: "workspaces", Action: UpdateAction, Data: json.RawMessage(`{"name":"prod"}`)},
+	}
+
+	t.Run("produce records", func(t *testing.T) {
+		var got kafkaRecords
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "/topics/otf-changes", r.URL.Path)
+			assert.Equal(t, kafkaContentType, r.Header.Get("Content-Type"))
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&got))
+			w.Write([]byte(`{"offsets":[{"partition":0,"offset":10},{"partition":0,"offset":11}]}`))
+		}))
+		defer srv.Close()
+
+		sink := newKafkaSink(Config{KafkaRESTURL: srv.URL, KafkaTopic: "otf-changes"})
+		err := sink.Write(context.Background(), "acme", changes)
+		require.NoError(t, err)
+
+		require.Len(t, got.Records, 2)
+		assert.Equal(t, "acme", got.Records[0].Key)
+		assert.Equal(t, int64(1), got.Records[0].Value.Sequence)
+		assert.Equal(t, UpdateAction, got.Records[1].Value.Action)
+		assert.JSONEq(t, `{"name":"prod"}`, string(got.Records[1].Value.Data))
+	})
+
+	t.Run("record not produced", func(t *testing.T) {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte(`{"offsets":[{"partition":0,"offset":10},{"error_code":50003,"error":"leader not available"}]}`))
+		}))
+		defer srv.Close()
+
+		sink := newKafkaSink(Config{KafkaRESTURL: srv.URL, KafkaTopic: "otf-changes"})
+		err := sink.Write(context.Background(), "acme", changes)
+		assert.EqualError(t, err, "producing record 1 to kafka: error code 50003: leader not available")
+	})
+
+	t.Run("unexpected status", func(t *testing.T) {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusNotFound)
+		}))
+		defer srv.Close()
+
+		sink := newKafkaSink(Config{KafkaRESTURL: srv.URL, KafkaTopic: "otf-changes"})
+		err := sink.Write(context.Background(), "acme", changes)
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,70 @@
+package orgexport
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"path"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+)
+
+// s3Sink stores each batch of changes as an object of newline-delimited JSON
+// in an AWS S3 bucket, or in a bucket of an S3-compatible service.
+// Credentials are taken from the environment.
+type s3Sink struct {
+	client *s3.Client
+	bucket string
+	prefix string
+}
+
+func newS3Sink(ctx context.Context, cfg Config) (*s3Sink, error) {
+	var opts []func(*config.LoadOptions) error
+	if cfg.S3Region != "" {
+		opts = append(opts, config.WithRegion(cfg.S3Region))
+	}
+	awsConfig, err := config.LoadDefaultConfig(ctx, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("loading aws config: %w", err)
+	}
+	client := s3.NewFromConfig(awsConfig, func(o *s3.Options) {
+		if cfg.S3Endpoint != "" {
+			o.BaseEndpoint = aws.String(cfg.S3Endpoint)
+		}
+		o.UsePathStyle = cfg.S3UsePathStyle
+	})
+	return &s3Sink{
+		client: client,
+		bucket: cfg.S3Bucket,
+		prefix: cfg.S3Prefix,
+	}, nil
+}
+
+func (s *s3Sink) Write(ctx context.Context, organization string, changes []*Change) error {
+	body, err := marshalLines(changes)
+	if err != nil {
+		return err
+	}
+	_, err = s.client.PutObject(ctx, &s3.PutObjectInput{
+		Bucket:      aws.String(s.bucket),
+		Key:         aws.String(batchObjectName(s.prefix, organization, changes)),
+		Body:        bytes.NewReader(body),
+		ContentType: aws.String("application/x-ndjson"),
+	})
+	if err != nil {
+		return fmt.Errorf("writing changes to s3: %w", err)
+	}
+	return nil
+}
+
+// batchObjectName returns the name of the object in which a batch of changes
+// is stored. The name is derived from the sequence numbers of the first and
+// last changes, zero-padded so that objects are listed in sequence order, and
+// so that writing the same batch again overwrites the same object.
+func batchObjectName(prefix, organization string, changes []*Change) string {
+	first := changes[0].Sequence
+	last := changes[len(changes)-1].Sequence
+	return path.Join(prefix, organization, "changes", fmt.Sprintf("%020d-%020d.jsonl", first, last))
+}
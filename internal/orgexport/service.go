@@ -0,0 +1,132 @@
+package orgexport
+
+import (
+	"context"
+
+	"github.com/gorilla/mux"
+	"github.com/leg100/otf/internal"
+	"github.com/leg100/otf/internal/logr"
+	"github.com/leg100/otf/internal/rbac"
+	"github.com/leg100/otf/internal/sql"
+)
+
+type (
+	Service struct {
+		logr.Logger
+
+		site internal.Authorizer
+
+		db   store
+		sink Sink
+		api  *api
+	}
+
+	Options struct {
+		logr.Logger
+		*sql.DB
+
+		// Sink to which changes are exported. If nil then streams cannot be
+		// enabled.
+		Sink Sink
+	}
+
+	store interface {
+		createStream(ctx context.Context, stream *Stream) error
+		getStream(ctx context.Context, organization string) (*Stream, error)
+		listStreams(ctx context.Context) ([]*Stream, error)
+		updateStream(ctx context.Context, stream *Stream) error
+		deleteStream(ctx context.Context, organization string) error
+		listChanges(ctx context.Context, organization string, limit int) ([]*Change, error)
+		markExported(ctx context.Context, stream *Stream, changes []*Change) error
+	}
+)
+
+func NewService(opts Options) *Service {
+	svc := Service{
+		Logger: opts.Logger,
+		site:   &internal.SiteAuthorizer{Logger: opts.Logger},
+		db:     &pgdb{opts.DB},
+		sink:   opts.Sink,
+	}
+	svc.api = &api{Service: &svc}
+	return &svc
+}
+
+func (s *Service) AddHandlers(r *mux.Router) {
+	s.api.addHandlers(r)
+}
+
+// Enabled determines whether a sink is configured to which changes are
+// exported.
+func (s *Service) Enabled() bool { return s.sink != nil }
+
+// Enable enables an export stream for an organization. The stream begins with
+// a snapshot of the organization, followed by each subsequent change made to
+// the organization. Only a site admin can enable a stream.
+func (s *Service) Enable(ctx context.Context, organization string) (*Stream, error) {
+	subject, err := s.site.CanAccess(ctx, rbac.EnableOrganizationExportStreamAction, "")
+	if err != nil {
+		return nil, err
+	}
+	if !s.Enabled() {
+		return nil, ErrNotConfigured
+	}
+	stream := newStream(organization)
+	if err := s.db.createStream(ctx, stream); err != nil {
+		s.Error(err, "enabling organization export stream", "organization", organization, "subject", subject)
+		return nil, err
+	}
+	// retrieve stream to report the number of changes in the snapshot.
+	stream, err = s.db.getStream(ctx, organization)
+	if err != nil {
+		s.Error(err, "retrieving organization export stream", "organization", organization, "subject", subject)
+		return nil, err
+	}
+	s.V(0).Info("enabled organization export stream", "stream", stream, "subject", subject)
+	return stream, nil
+}
+
+// Get retrieves an organization's export stream.
+func (s *Service) Get(ctx context.Context, organization string) (*Stream, error) {
+	subject, err := s.site.CanAccess(ctx, rbac.GetOrganizationExportStreamAction, "")
+	if err != nil {
+		return nil, err
+	}
+	stream, err := s.db.getStream(ctx, organization)
+	if err != nil {
+		s.Error(err, "retrieving organization export stream", "organization", organization, "subject", subject)
+		return nil, err
+	}
+	s.V(9).Info("retrieved organization export stream", "stream", stream, "subject", subject)
+	return stream, nil
+}
+
+// List lists the export streams of all organizations.
+func (s *Service) List(ctx context.Context) ([]*Stream, error) {
+	subject, err := s.site.CanAccess(ctx, rbac.ListOrganizationExportStreamsAction, "")
+	if err != nil {
+		return nil, err
+	}
+	streams, err := s.db.listStreams(ctx)
+	if err != nil {
+		s.Error(err, "listing organization export streams", "subject", subject)
+		return nil, err
+	}
+	s.V(9).Info("listed organization export streams", "count", len(streams), "subject", subject)
+	return streams, nil
+}
+
+// Disable disables an organization's export stream. Any changes yet to be
+// exported are discarded.
+func (s *Service) Disable(ctx context.Context, organization string) error {
+	subject, err := s.site.CanAccess(ctx, rbac.DisableOrganizationExportStreamAction, "")
+	if err != nil {
+		return err
+	}
+	if err := s.db.deleteStream(ctx, organization); err != nil {
+		s.Error(err, "disabling organization export stream", "organization", organization, "subject", subject)
+		return err
+	}
+	s.V(0).Info("disabled organization export stream", "organization", organization, "subject", subject)
+	return nil
+}
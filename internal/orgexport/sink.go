@@ -0,0 +1,106 @@
+package orgexport
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+)
+
+const (
+	S3Sink    = "s3"
+	KafkaSink = "kafka"
+)
+
+var ErrInvalidSink = errors.New("invalid organization export sink")
+
+type (
+	// Sink receives the changes made to organizations.
+	Sink interface {
+		// Write writes a batch of changes made to an organization, in the
+		// order in which they were made. A batch may be written more than
+		// once should a previous attempt have failed part way through.
+		Write(ctx context.Context, organization string, changes []*Change) error
+	}
+
+	// Config configures the sink to which changes are exported.
+	Config struct {
+		// Sink is one of s3 or kafka. If empty then changes are not
+		// exported.
+		Sink string
+
+		// S3Bucket is the bucket in which batches of changes are stored.
+		S3Bucket string
+		// S3Prefix is prepended to the name of each object stored in the
+		// bucket.
+		S3Prefix string
+		// S3Region is the AWS region of the bucket. If empty then the region
+		// is taken from the environment.
+		S3Region string
+		// S3Endpoint overrides the S3 endpoint, for use with S3-compatible
+		// services such as MinIO.
+		S3Endpoint string
+		// S3UsePathStyle addresses the bucket in the path of the URL rather
+		// than in the hostname.
+		S3UsePathStyle bool
+
+		// KafkaRESTURL is the URL of a Kafka REST proxy, through which
+		// changes are produced to a topic.
+		KafkaRESTURL string
+		// KafkaTopic is the topic to which changes are produced.
+		KafkaTopic string
+	}
+)
+
+// Enabled determines whether changes are exported to a sink.
+func (cfg Config) Enabled() bool { return cfg.Sink != "" }
+
+// Valid validates the config.
+func (cfg Config) Valid() error {
+	switch cfg.Sink {
+	case "":
+		return nil
+	case S3Sink:
+		if cfg.S3Bucket == "" {
+			return errors.New("s3 organization export sink requires a bucket")
+		}
+	case KafkaSink:
+		u, err := url.Parse(cfg.KafkaRESTURL)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return fmt.Errorf("kafka organization export sink requires an absolute http or https REST proxy url: %q", cfg.KafkaRESTURL)
+		}
+		if cfg.KafkaTopic == "" {
+			return errors.New("kafka organization export sink requires a topic")
+		}
+	default:
+		return fmt.Errorf("%w: %s: must be one of %s or %s", ErrInvalidSink, cfg.Sink, S3Sink, KafkaSink)
+	}
+	return nil
+}
+
+// NewSink constructs the sink specified in the config. Nil is returned if no
+// sink is specified.
+func NewSink(ctx context.Context, cfg Config) (Sink, error) {
+	switch cfg.Sink {
+	case S3Sink:
+		return newS3Sink(ctx, cfg)
+	case KafkaSink:
+		return newKafkaSink(cfg), nil
+	default:
+		return nil, nil
+	}
+}
+
+// marshalLines marshals changes into newline-delimited JSON.
+func marshalLines(changes []*Change) ([]byte, error) {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	for _, change := range changes {
+		if err := enc.Encode(change); err != nil {
+			return nil, err
+		}
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,93 @@
+// Package orgexport streams the changes made to an organization to an external
+// sink, from which the organization can be recovered to a point in time, or
+// replicated to a standby installation.
+package orgexport
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/leg100/otf/internal"
+)
+
+const (
+	// Changes recorded by database triggers, named after the operation that
+	// made the change.
+	InsertAction Action = "INSERT"
+	UpdateAction Action = "UPDATE"
+	DeleteAction Action = "DELETE"
+	// SnapshotAction is a change recording the state of a row at the time
+	// the stream was enabled.
+	SnapshotAction Action = "SNAPSHOT"
+)
+
+// ErrNotConfigured is returned when a stream is enabled but no sink is
+// configured. It wraps internal.ErrResourceNotFound, so that the endpoints
+// respond as if they do not exist.
+var ErrNotConfigured = fmt.Errorf("organization export sink is not configured: %w", internal.ErrResourceNotFound)
+
+type (
+	// Stream streams the changes made to an organization to the sink.
+	Stream struct {
+		Organization string    `json:"organization"`
+		CreatedAt    time.Time `json:"created_at"`
+		// ExportedSequence is the highest sequence number of the changes
+		// exported so far.
+		ExportedSequence int64 `json:"exported_sequence"`
+		// ExportedAt is the time at which changes were last exported, if
+		// they have been.
+		ExportedAt *time.Time `json:"exported_at,omitempty"`
+		// Pending is the number of changes yet to be exported.
+		Pending int `json:"pending"`
+		// LastError is the error that occurred the last time changes were
+		// exported, if any.
+		LastError *string `json:"last_error,omitempty"`
+	}
+
+	// Change is a change made to a row belonging to an organization.
+	Change struct {
+		// Sequence orders changes; a change made after another has a higher
+		// sequence number.
+		Sequence     int64           `json:"sequence"`
+		Organization string          `json:"organization"`
+		Table        string          `json:"table"`
+		Action       Action          `json:"action"`
+		Data         json.RawMessage `json:"data"`
+		CreatedAt    time.Time       `json:"created_at"`
+	}
+
+	Action string
+)
+
+func newStream(organization string) *Stream {
+	return &Stream{
+		Organization: organization,
+		CreatedAt:    internal.CurrentTimestamp(nil),
+	}
+}
+
+// exported updates the stream upon exporting changes.
+func (s *Stream) exported(changes []*Change) {
+	for _, change := range changes {
+		// changes are exported in the order they are committed, which is not
+		// necessarily the order of their sequence numbers.
+		s.ExportedSequence = max(s.ExportedSequence, change.Sequence)
+	}
+	s.ExportedAt = internal.Time(internal.CurrentTimestamp(nil))
+	s.LastError = nil
+}
+
+// failed updates the stream upon failing to export changes.
+func (s *Stream) failed(err error) {
+	s.LastError = internal.String(err.Error())
+}
+
+func (s *Stream) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.String("organization", s.Organization),
+		slog.Int64("exported_sequence", s.ExportedSequence),
+		slog.Int("pending", s.Pending),
+	)
+}
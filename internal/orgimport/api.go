@@ -0,0 +1,98 @@
+package orgimport
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/leg100/otf/internal"
+	otfapi "github.com/leg100/otf/internal/api"
+	"github.com/leg100/otf/internal/http/decode"
+	"github.com/leg100/otf/internal/tfeapi"
+)
+
+type api struct {
+	*Service
+	*tfeapi.Responder
+}
+
+func (a *api) addHandlers(r *mux.Router) {
+	r = r.PathPrefix(otfapi.DefaultBasePath).Subrouter()
+
+	r.HandleFunc("/organizations/{organization_name}/imports", a.createImport).Methods("POST")
+	r.HandleFunc("/organizations/{organization_name}/imports", a.listImports).Methods("GET")
+	r.HandleFunc("/organization-imports/{import_id}", a.getImport).Methods("GET")
+	r.HandleFunc("/organization-imports/{import_id}/resume", a.resumeImport).Methods("POST")
+}
+
+func (a *api) createImport(w http.ResponseWriter, r *http.Request) {
+	org, err := decode.Param("organization_name", r)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	var opts CreateOptions
+	if err := json.NewDecoder(r.Body).Decode(&opts); err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	opts.Organization = org
+
+	imp, err := a.Create(r.Context(), opts)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	a.Respond(w, r, imp, http.StatusAccepted)
+}
+
+func (a *api) listImports(w http.ResponseWriter, r *http.Request) {
+	org, err := decode.Param("organization_name", r)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	imports, err := a.List(r.Context(), org)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	a.Respond(w, r, imports, http.StatusOK)
+}
+
+func (a *api) getImport(w http.ResponseWriter, r *http.Request) {
+	id, err := decode.Param("import_id", r)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	imp, err := a.Get(r.Context(), id)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	a.Respond(w, r, imp, http.StatusOK)
+}
+
+func (a *api) resumeImport(w http.ResponseWriter, r *http.Request) {
+	id, err := decode.Param("import_id", r)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	var opts ResumeOptions
+	if err := json.NewDecoder(r.Body).Decode(&opts); err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	imp, err := a.Resume(r.Context(), id, opts)
+	if errors.Is(err, ErrImportInProgress) || errors.Is(err, ErrImportCompleted) {
+		tfeapi.Error(w, &internal.HTTPError{Code: http.StatusConflict, Message: err.Error()})
+		return
+	} else if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	a.Respond(w, r, imp, http.StatusAccepted)
+}
@@ -0,0 +1,165 @@
+package orgimport
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgtype"
+	"github.com/leg100/otf/internal"
+	"github.com/leg100/otf/internal/sql"
+	"github.com/leg100/otf/internal/sql/pggen"
+)
+
+type (
+	// pgdb is an organization import database on postgres
+	pgdb struct {
+		*sql.DB // provides access to generated SQL queries
+	}
+
+	pgresult struct {
+		OrganizationImportID pgtype.Text        `json:"organization_import_id"`
+		CreatedAt            pgtype.Timestamptz `json:"created_at"`
+		UpdatedAt            pgtype.Timestamptz `json:"updated_at"`
+		OrganizationName     pgtype.Text        `json:"organization_name"`
+		SourceAddress        pgtype.Text        `json:"source_address"`
+		SourceOrganization   pgtype.Text        `json:"source_organization"`
+		Status               pgtype.Text        `json:"status"`
+		Error                pgtype.Text        `json:"error"`
+	}
+)
+
+func (r pgresult) toImport() *Import {
+	return &Import{
+		ID:                 r.OrganizationImportID.String,
+		CreatedAt:          r.CreatedAt.Time.UTC(),
+		UpdatedAt:          r.UpdatedAt.Time.UTC(),
+		Organization:       r.OrganizationName.String,
+		SourceAddress:      r.SourceAddress.String,
+		SourceOrganization: r.SourceOrganization.String,
+		Status:             Status(r.Status.String),
+		Error:              r.Error.String,
+		Progress:           make(map[ItemKind]int),
+	}
+}
+
+func (db *pgdb) create(ctx context.Context, imp *Import) error {
+	_, err := db.Conn(ctx).InsertOrganizationImport(ctx, pggen.InsertOrganizationImportParams{
+		OrganizationImportID: sql.String(imp.ID),
+		CreatedAt:            sql.Timestamptz(imp.CreatedAt),
+		UpdatedAt:            sql.Timestamptz(imp.UpdatedAt),
+		OrganizationName:     sql.String(imp.Organization),
+		SourceAddress:        sql.String(imp.SourceAddress),
+		SourceOrganization:   sql.String(imp.SourceOrganization),
+		Status:               sql.String(string(imp.Status)),
+		Error:                sql.NullString(),
+	})
+	return sql.Error(err)
+}
+
+func (db *pgdb) updateStatus(ctx context.Context, id string, status Status, errMsg string) error {
+	params := pggen.UpdateOrganizationImportStatusParams{
+		OrganizationImportID: sql.String(id),
+		Status:               sql.String(string(status)),
+		Error:                sql.NullString(),
+		UpdatedAt:            sql.Timestamptz(internal.CurrentTimestamp(nil)),
+	}
+	if errMsg != "" {
+		params.Error = sql.String(errMsg)
+	}
+	_, err := db.Conn(ctx).UpdateOrganizationImportStatus(ctx, params)
+	return sql.Error(err)
+}
+
+// get retrieves an import along with its progress
+func (db *pgdb) get(ctx context.Context, id string) (*Import, error) {
+	row, err := db.Conn(ctx).FindOrganizationImport(ctx, sql.String(id))
+	if err != nil {
+		return nil, sql.Error(err)
+	}
+	imp := pgresult(row).toImport()
+	if err := db.addProgress(ctx, imp); err != nil {
+		return nil, err
+	}
+	return imp, nil
+}
+
+func (db *pgdb) list(ctx context.Context, organization string) ([]*Import, error) {
+	rows, err := db.Conn(ctx).FindOrganizationImportsByOrganization(ctx, sql.String(organization))
+	if err != nil {
+		return nil, sql.Error(err)
+	}
+	imports := make([]*Import, len(rows))
+	for i, r := range rows {
+		imports[i] = pgresult(r).toImport()
+		if err := db.addProgress(ctx, imports[i]); err != nil {
+			return nil, err
+		}
+	}
+	return imports, nil
+}
+
+// addProgress populates the import's progress with the number of items of
+// each kind imported thus far.
+func (db *pgdb) addProgress(ctx context.Context, imp *Import) error {
+	items, err := db.listItems(ctx, imp.ID)
+	if err != nil {
+		return err
+	}
+	for _, i := range items {
+		imp.Progress[i.Kind]++
+	}
+	return nil
+}
+
+func (db *pgdb) createItem(ctx context.Context, importID string, i item) error {
+	_, err := db.Conn(ctx).InsertOrganizationImportItem(ctx, pggen.InsertOrganizationImportItemParams{
+		OrganizationImportID: sql.String(importID),
+		Kind:                 sql.String(string(i.Kind)),
+		SourceID:             sql.String(i.SourceID),
+		TargetID:             sql.String(i.TargetID),
+	})
+	return sql.Error(err)
+}
+
+func (db *pgdb) listItems(ctx context.Context, importID string) ([]item, error) {
+	rows, err := db.Conn(ctx).FindOrganizationImportItems(ctx, sql.String(importID))
+	if err != nil {
+		return nil, sql.Error(err)
+	}
+	items := make([]item, len(rows))
+	for i, r := range rows {
+		items[i] = item{
+			Kind:     ItemKind(r.Kind.String),
+			SourceID: r.SourceID.String,
+			TargetID: r.TargetID.String,
+		}
+	}
+	return items, nil
+}
+
+// touch records that the imports are still running.
+func (db *pgdb) touch(ctx context.Context, ids []string) error {
+	_, err := db.Conn(ctx).TouchOrganizationImports(ctx, pggen.TouchOrganizationImportsParams{
+		UpdatedAt:             sql.Timestamptz(internal.CurrentTimestamp(nil)),
+		OrganizationImportIDs: ids,
+	})
+	return sql.Error(err)
+}
+
+// errorInterrupted marks as errored those running imports that have not been
+// updated since the cutoff, returning their IDs.
+func (db *pgdb) errorInterrupted(ctx context.Context, cutoff time.Time, errMsg string) ([]string, error) {
+	rows, err := db.Conn(ctx).UpdateInterruptedOrganizationImports(ctx, pggen.UpdateInterruptedOrganizationImportsParams{
+		Error:     sql.String(errMsg),
+		UpdatedAt: sql.Timestamptz(internal.CurrentTimestamp(nil)),
+		Cutoff:    sql.Timestamptz(cutoff),
+	})
+	if err != nil {
+		return nil, sql.Error(err)
+	}
+	ids := make([]string, len(rows))
+	for i, r := range rows {
+		ids[i] = r.String
+	}
+	return ids, nil
+}
@@ -0,0 +1,128 @@
+// Package orgimport imports an organization from Terraform Cloud or Terraform
+// Enterprise.
+package orgimport
+
+import (
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/leg100/otf/internal"
+	"github.com/leg100/otf/internal/resource"
+)
+
+const (
+	StatusRunning   Status = "running"
+	StatusCompleted Status = "completed"
+	StatusErrored   Status = "errored"
+
+	TeamKind           ItemKind = "team"
+	WorkspaceKind      ItemKind = "workspace"
+	VariableKind       ItemKind = "variable"
+	StateVersionKind   ItemKind = "state_version"
+	TeamAccessKind     ItemKind = "team_access"
+	TeamMembershipKind ItemKind = "team_membership"
+	ModuleKind         ItemKind = "module"
+	ModuleVersionKind  ItemKind = "module_version"
+
+	// DefaultAddress is the address of Terraform Cloud
+	DefaultAddress = "https://app.terraform.io"
+)
+
+var (
+	ErrImportInProgress = errors.New("import is already in progress")
+	ErrImportCompleted  = errors.New("import has already completed")
+)
+
+type (
+	// Import is an import of an organization from Terraform Cloud, or
+	// Terraform Enterprise, into an OTF organization. Each resource imported
+	// is recorded, which permits an import that fails part-way through to be
+	// resumed without duplicating resources.
+	Import struct {
+		ID        string    `jsonapi:"primary,organization-imports"`
+		CreatedAt time.Time `jsonapi:"attribute" json:"created-at"`
+		UpdatedAt time.Time `jsonapi:"attribute" json:"updated-at"`
+		// Name of OTF organization into which resources are imported.
+		Organization string `jsonapi:"attribute" json:"organization"`
+		// Address of the TFC/TFE host from which resources are imported.
+		SourceAddress string `jsonapi:"attribute" json:"source-address"`
+		// Name of the TFC/TFE organization from which resources are imported.
+		SourceOrganization string `jsonapi:"attribute" json:"source-organization"`
+		Status             Status `jsonapi:"attribute" json:"status"`
+		// Error is the reason the import errored, if it errored.
+		Error string `jsonapi:"attribute" json:"error,omitempty"`
+		// Progress is the number of resources of each kind imported thus far.
+		Progress map[ItemKind]int `jsonapi:"attribute" json:"progress"`
+	}
+
+	// Status is the status of an import
+	Status string
+
+	// ItemKind is a kind of resource that is imported.
+	ItemKind string
+
+	// item is a resource that has been imported.
+	item struct {
+		Kind ItemKind
+		// ID of resource on the source.
+		SourceID string
+		// ID of resource created in OTF.
+		TargetID string
+	}
+
+	CreateOptions struct {
+		// Name of OTF organization into which resources are imported.
+		// Required.
+		Organization string `json:"-"`
+		// Address of the TFC/TFE host. Defaults to Terraform Cloud.
+		Address string `json:"address"`
+		// Name of the TFC/TFE organization from which resources are imported.
+		// Required.
+		SourceOrganization string `json:"source_organization"`
+		// API token with which to authenticate to TFC/TFE. It is used only
+		// for the duration of the import and is not persisted. Required.
+		Token string `json:"token"`
+	}
+
+	ResumeOptions struct {
+		// API token with which to authenticate to TFC/TFE. Required.
+		Token string `json:"token"`
+	}
+)
+
+func newImport(opts CreateOptions) (*Import, error) {
+	if opts.Organization == "" {
+		return nil, &internal.MissingParameterError{Parameter: "organization"}
+	}
+	if opts.SourceOrganization == "" {
+		return nil, &internal.MissingParameterError{Parameter: "source_organization"}
+	}
+	if opts.Token == "" {
+		return nil, &internal.MissingParameterError{Parameter: "token"}
+	}
+	if opts.Address == "" {
+		opts.Address = DefaultAddress
+	}
+	now := internal.CurrentTimestamp(nil)
+	return &Import{
+		ID:                 resource.NewID(resource.OrganizationImportKind),
+		CreatedAt:          now,
+		UpdatedAt:          now,
+		Organization:       opts.Organization,
+		SourceAddress:      opts.Address,
+		SourceOrganization: opts.SourceOrganization,
+		Status:             StatusRunning,
+		Progress:           make(map[ItemKind]int),
+	}, nil
+}
+
+func (i *Import) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.String("id", i.ID),
+		slog.String("organization", i.Organization),
+		slog.String("source_address", i.SourceAddress),
+		slog.String("source_organization", i.SourceOrganization),
+		slog.String("status", string(i.Status)),
+	)
+}
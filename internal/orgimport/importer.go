@@ -0,0 +1,429 @@
+package orgimport
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/hashicorp/go-tfe"
+	"github.com/leg100/otf/internal"
+	"github.com/leg100/otf/internal/logr"
+	"github.com/leg100/otf/internal/module"
+	"github.com/leg100/otf/internal/rbac"
+	"github.com/leg100/otf/internal/semver"
+	"github.com/leg100/otf/internal/state"
+	"github.com/leg100/otf/internal/team"
+	"github.com/leg100/otf/internal/variable"
+	"github.com/leg100/otf/internal/workspace"
+)
+
+type (
+	// importer imports resources from a source into an OTF organization.
+	importer struct {
+		logr.Logger
+
+		src          source
+		organization string
+		// record persists an imported item
+		record func(context.Context, item) error
+		// imported items, keyed by kind and then source ID, mapping to
+		// target ID.
+		imported map[ItemKind]map[string]string
+
+		services
+	}
+
+	services struct {
+		Workspaces WorkspaceService
+		Variables  VariableService
+		States     StateService
+		Modules    ModuleService
+		Teams      TeamService
+		Users      UserService
+	}
+)
+
+func newImporter(logger logr.Logger, src source, imp *Import, items []item, svcs services, record func(context.Context, item) error) *importer {
+	imported := make(map[ItemKind]map[string]string)
+	for _, i := range items {
+		if imported[i.Kind] == nil {
+			imported[i.Kind] = make(map[string]string)
+		}
+		imported[i.Kind][i.SourceID] = i.TargetID
+	}
+	return &importer{
+		Logger:       logger,
+		src:          src,
+		organization: imp.Organization,
+		record:       record,
+		imported:     imported,
+		services:     svcs,
+	}
+}
+
+// run imports teams, then workspaces along with their variables, state and
+// team access, and lastly registry modules. Items that have already been
+// imported are skipped.
+func (i *importer) run(ctx context.Context) error {
+	if err := i.importTeams(ctx); err != nil {
+		return fmt.Errorf("importing teams: %w", err)
+	}
+	if err := i.importWorkspaces(ctx); err != nil {
+		return fmt.Errorf("importing workspaces: %w", err)
+	}
+	if err := i.importModules(ctx); err != nil {
+		return fmt.Errorf("importing modules: %w", err)
+	}
+	return nil
+}
+
+// lookup returns the target ID of an already imported item.
+func (i *importer) lookup(kind ItemKind, sourceID string) (string, bool) {
+	targetID, ok := i.imported[kind][sourceID]
+	return targetID, ok
+}
+
+func (i *importer) add(ctx context.Context, kind ItemKind, sourceID, targetID string) error {
+	if err := i.record(ctx, item{Kind: kind, SourceID: sourceID, TargetID: targetID}); err != nil {
+		return err
+	}
+	if i.imported[kind] == nil {
+		i.imported[kind] = make(map[string]string)
+	}
+	i.imported[kind][sourceID] = targetID
+	return nil
+}
+
+func (i *importer) importTeams(ctx context.Context) error {
+	teams, err := i.src.listTeams(ctx)
+	if err != nil {
+		return err
+	}
+	for _, from := range teams {
+		teamID, ok := i.lookup(TeamKind, from.ID)
+		if !ok {
+			teamID, err = i.importTeam(ctx, from)
+			if err != nil {
+				return err
+			}
+		}
+		if err := i.importTeamMembers(ctx, from, teamID); err != nil {
+			return fmt.Errorf("%s: %w", from.Name, err)
+		}
+	}
+	return nil
+}
+
+func (i *importer) importTeam(ctx context.Context, from *tfe.Team) (string, error) {
+	// the team may already exist, either because it was created by a
+	// previous attempt that failed before it could be recorded, or
+	// because it is the owners team, which every organization has.
+	to, err := i.Teams.Get(ctx, i.organization, from.Name)
+	if errors.Is(err, internal.ErrResourceNotFound) {
+		opts := team.CreateTeamOptions{Name: &from.Name}
+		if access := from.OrganizationAccess; access != nil {
+			opts.OrganizationAccessOptions = team.OrganizationAccessOptions{
+				ManageWorkspaces: &access.ManageWorkspaces,
+				ManageVCS:        &access.ManageVCSSettings,
+				ManageModules:    &access.ManageModules,
+			}
+		}
+		to, err = i.Teams.Create(ctx, i.organization, opts)
+	}
+	if err != nil {
+		return "", fmt.Errorf("%s: %w", from.Name, err)
+	}
+	if err := i.add(ctx, TeamKind, from.ID, to.ID); err != nil {
+		return "", err
+	}
+	i.V(1).Info("imported team", "name", from.Name)
+	return to.ID, nil
+}
+
+// importTeamMembers adds the members of the source team to the team, creating
+// users that do not exist. Users that are already members are skipped.
+func (i *importer) importTeamMembers(ctx context.Context, from *tfe.Team, teamID string) error {
+	if _, ok := i.lookup(TeamMembershipKind, from.ID); ok {
+		return nil
+	}
+	existing, err := i.Users.ListTeamUsers(ctx, teamID)
+	if err != nil {
+		return err
+	}
+	members := make(map[string]bool, len(existing))
+	for _, u := range existing {
+		members[u.Username] = true
+	}
+	var usernames []string
+	for _, u := range from.Users {
+		if !members[u.Username] {
+			usernames = append(usernames, u.Username)
+		}
+	}
+	if len(usernames) > 0 {
+		if err := i.Users.AddTeamMembership(ctx, teamID, usernames); err != nil {
+			return err
+		}
+	}
+	if err := i.add(ctx, TeamMembershipKind, from.ID, teamID); err != nil {
+		return err
+	}
+	i.V(1).Info("imported team members", "name", from.Name, "count", len(usernames))
+	return nil
+}
+
+func (i *importer) importWorkspaces(ctx context.Context) error {
+	workspaces, err := i.src.listWorkspaces(ctx)
+	if err != nil {
+		return err
+	}
+	for _, from := range workspaces {
+		workspaceID, ok := i.lookup(WorkspaceKind, from.ID)
+		if !ok {
+			to, err := i.Workspaces.GetByName(ctx, i.organization, from.Name)
+			if errors.Is(err, internal.ErrResourceNotFound) {
+				to, err = i.Workspaces.Create(ctx, workspaceCreateOptions(i.organization, from))
+			}
+			if err != nil {
+				return fmt.Errorf("%s: %w", from.Name, err)
+			}
+			if err := i.add(ctx, WorkspaceKind, from.ID, to.ID); err != nil {
+				return err
+			}
+			workspaceID = to.ID
+			i.V(1).Info("imported workspace", "name", from.Name)
+		}
+		if err := i.importVariables(ctx, from, workspaceID); err != nil {
+			return fmt.Errorf("%s: importing variables: %w", from.Name, err)
+		}
+		if err := i.importStateVersions(ctx, from, workspaceID); err != nil {
+			return fmt.Errorf("%s: importing state: %w", from.Name, err)
+		}
+		if err := i.importTeamAccess(ctx, from, workspaceID); err != nil {
+			return fmt.Errorf("%s: importing team access: %w", from.Name, err)
+		}
+	}
+	return nil
+}
+
+// importVariables imports workspace variables. Sensitive variables are
+// skipped because their values cannot be retrieved from the source.
+func (i *importer) importVariables(ctx context.Context, from *tfe.Workspace, workspaceID string) error {
+	variables, err := i.src.listVariables(ctx, from.ID)
+	if err != nil {
+		return err
+	}
+	existing, err := i.Variables.ListWorkspaceVariables(ctx, workspaceID)
+	if err != nil {
+		return err
+	}
+	for _, v := range variables {
+		if v.Sensitive {
+			continue
+		}
+		if _, ok := i.lookup(VariableKind, v.ID); ok {
+			continue
+		}
+		category := variable.VariableCategory(v.Category)
+		var to *variable.Variable
+		for _, e := range existing {
+			if e.Key == v.Key && e.Category == category {
+				to = e
+				break
+			}
+		}
+		if to == nil {
+			to, err = i.Variables.CreateWorkspaceVariable(ctx, workspaceID, variable.CreateVariableOptions{
+				Key:         &v.Key,
+				Value:       &v.Value,
+				Description: &v.Description,
+				Category:    &category,
+				HCL:         &v.HCL,
+			})
+			if err != nil {
+				return fmt.Errorf("%s: %w", v.Key, err)
+			}
+		}
+		if err := i.add(ctx, VariableKind, v.ID, to.ID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// importStateVersions imports state versions in the order in which they were
+// created, so that the most recent becomes the current state version.
+func (i *importer) importStateVersions(ctx context.Context, from *tfe.Workspace, workspaceID string) error {
+	versions, err := i.src.listStateVersions(ctx, from.Name)
+	if err != nil {
+		return err
+	}
+	for _, sv := range versions {
+		if _, ok := i.lookup(StateVersionKind, sv.ID); ok {
+			continue
+		}
+		contents, err := i.src.downloadStateVersion(ctx, sv)
+		if err != nil {
+			return fmt.Errorf("downloading state version %s: %w", sv.ID, err)
+		}
+		serial := sv.Serial
+		// creating a state version with the same serial and state as the
+		// current state version is permitted, which means an attempt that
+		// failed before the state version could be recorded can be resumed.
+		to, err := i.States.Create(ctx, state.CreateStateVersionOptions{
+			WorkspaceID: &workspaceID,
+			Serial:      &serial,
+			State:       contents,
+		})
+		if err != nil {
+			return fmt.Errorf("creating state version with serial %d: %w", serial, err)
+		}
+		if err := i.add(ctx, StateVersionKind, sv.ID, to.ID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// importTeamAccess grants teams the same access to the workspace as on the
+// source. Custom access cannot be mapped onto an OTF role and is skipped.
+func (i *importer) importTeamAccess(ctx context.Context, from *tfe.Workspace, workspaceID string) error {
+	accesses, err := i.src.listTeamAccess(ctx, from.ID)
+	if err != nil {
+		return err
+	}
+	for _, access := range accesses {
+		if _, ok := i.lookup(TeamAccessKind, access.ID); ok {
+			continue
+		}
+		if access.Team == nil {
+			continue
+		}
+		teamID, ok := i.lookup(TeamKind, access.Team.ID)
+		if !ok {
+			continue
+		}
+		role, err := rbac.WorkspaceRoleFromString(string(access.Access))
+		if err != nil {
+			i.V(1).Info("skipping team access", "workspace", from.Name, "access", access.Access)
+			continue
+		}
+		if err := i.Workspaces.SetPermission(ctx, workspaceID, teamID, role); err != nil {
+			return err
+		}
+		if err := i.add(ctx, TeamAccessKind, access.ID, teamID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// importModules imports private registry modules along with each of their
+// versions. Modules are imported without a VCS connection.
+func (i *importer) importModules(ctx context.Context) error {
+	modules, err := i.src.listModules(ctx)
+	if err != nil {
+		return err
+	}
+	for _, from := range modules {
+		if from.RegistryName != tfe.PrivateRegistry {
+			continue
+		}
+		mod, err := i.Modules.GetModule(ctx, module.GetModuleOptions{
+			Name:         from.Name,
+			Provider:     from.Provider,
+			Organization: i.organization,
+		})
+		if errors.Is(err, internal.ErrResourceNotFound) {
+			mod, err = i.Modules.CreateModule(ctx, module.CreateOptions{
+				Name:         from.Name,
+				Provider:     from.Provider,
+				Organization: i.organization,
+			})
+		}
+		if err != nil {
+			return fmt.Errorf("%s/%s: %w", from.Name, from.Provider, err)
+		}
+		if _, ok := i.lookup(ModuleKind, from.ID); !ok {
+			if err := i.add(ctx, ModuleKind, from.ID, mod.ID); err != nil {
+				return err
+			}
+		}
+		for _, status := range from.VersionStatuses {
+			if status.Status != tfe.RegistryModuleVersionStatusOk {
+				continue
+			}
+			sourceID := from.ID + "/" + status.Version
+			if _, ok := i.lookup(ModuleVersionKind, sourceID); ok {
+				continue
+			}
+			if err := i.importModuleVersion(ctx, from, mod, status.Version, sourceID); err != nil {
+				return fmt.Errorf("%s/%s version %s: %w", from.Name, from.Provider, status.Version, err)
+			}
+		}
+		i.V(1).Info("imported module", "name", from.Name, "provider", from.Provider)
+	}
+	return nil
+}
+
+func (i *importer) importModuleVersion(ctx context.Context, from *tfe.RegistryModule, mod *module.Module, version, sourceID string) error {
+	tarball, err := i.src.downloadModuleVersion(ctx, from, version)
+	if err != nil {
+		return err
+	}
+	// a version created by a previous attempt is re-used
+	var modver *module.ModuleVersion
+	for _, existing := range mod.Versions {
+		if existing.Version == version {
+			modver = &existing
+			break
+		}
+	}
+	if modver == nil {
+		modver, err = i.Modules.CreateVersion(ctx, module.CreateModuleVersionOptions{
+			ModuleID: mod.ID,
+			Version:  version,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	if err := i.Modules.UploadVersion(ctx, modver.ID, tarball); err != nil {
+		return err
+	}
+	return i.add(ctx, ModuleVersionKind, sourceID, modver.ID)
+}
+
+// workspaceCreateOptions maps a TFC/TFE workspace onto options for creating an
+// OTF workspace. VCS connections and agent pools are specific to the source
+// and are not imported.
+func workspaceCreateOptions(organization string, from *tfe.Workspace) workspace.CreateOptions {
+	opts := workspace.CreateOptions{
+		Name:                       &from.Name,
+		Organization:               &organization,
+		AllowDestroyPlan:           &from.AllowDestroyPlan,
+		AutoApply:                  &from.AutoApply,
+		Description:                &from.Description,
+		GlobalRemoteState:          &from.GlobalRemoteState,
+		QueueAllRuns:               &from.QueueAllRuns,
+		SpeculativeEnabled:         &from.SpeculativeEnabled,
+		StructuredRunOutputEnabled: &from.StructuredRunOutputEnabled,
+		WorkingDirectory:           &from.WorkingDirectory,
+	}
+	if from.ExecutionMode == string(workspace.LocalExecutionMode) {
+		mode := workspace.LocalExecutionMode
+		opts.ExecutionMode = &mode
+	}
+	// only use the source's terraform version if OTF supports it, otherwise
+	// leave it to default.
+	if v := from.TerraformVersion; semver.IsValid(v) && semver.Compare(v, workspace.MinTerraformVersion) >= 0 {
+		opts.TerraformVersion = &from.TerraformVersion
+	}
+	if len(from.TriggerPatterns) > 0 {
+		opts.TriggerPatterns = from.TriggerPatterns
+	}
+	for _, name := range from.TagNames {
+		opts.Tags = append(opts.Tags, workspace.TagSpec{Name: name})
+	}
+	return opts
+}
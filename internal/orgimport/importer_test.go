@@ -0,0 +1,259 @@
+package orgimport
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/go-tfe"
+	"github.com/leg100/otf/internal"
+	"github.com/leg100/otf/internal/logr"
+	"github.com/leg100/otf/internal/module"
+	"github.com/leg100/otf/internal/rbac"
+	"github.com/leg100/otf/internal/state"
+	"github.com/leg100/otf/internal/team"
+	"github.com/leg100/otf/internal/user"
+	"github.com/leg100/otf/internal/variable"
+	"github.com/leg100/otf/internal/workspace"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestImporter(t *testing.T) {
+	ctx := context.Background()
+	src := &fakeSource{
+		teams: []*tfe.Team{
+			{
+				ID:                 "team-src-1",
+				Name:               "devs",
+				OrganizationAccess: &tfe.OrganizationAccess{ManageWorkspaces: true},
+				Users:              []*tfe.User{{Username: "alice"}, {Username: "bob"}},
+			},
+		},
+		workspaces: []*tfe.Workspace{
+			{ID: "ws-src-1", Name: "dev", TerraformVersion: "1.5.7", ExecutionMode: "local", TagNames: []string{"foo"}},
+		},
+		variables: []*tfe.Variable{
+			{ID: "var-src-1", Key: "region", Value: "eu-west-1", Category: tfe.CategoryTerraform},
+			{ID: "var-src-2", Key: "secret", Category: tfe.CategoryEnv, Sensitive: true},
+		},
+		teamAccess: []*tfe.TeamAccess{
+			{ID: "tws-src-1", Access: tfe.AccessWrite, Team: &tfe.Team{ID: "team-src-1"}},
+			{ID: "tws-src-2", Access: tfe.AccessCustom, Team: &tfe.Team{ID: "team-src-1"}},
+		},
+		stateVersions: []*tfe.StateVersion{
+			{ID: "sv-src-1", Serial: 1},
+			{ID: "sv-src-2", Serial: 2},
+		},
+		modules: []*tfe.RegistryModule{
+			{
+				ID:              "mod-src-1",
+				Name:            "vpc",
+				Provider:        "aws",
+				RegistryName:    tfe.PrivateRegistry,
+				VersionStatuses: []tfe.RegistryModuleVersionStatuses{{Version: "1.0.0", Status: tfe.RegistryModuleVersionStatusOk}},
+			},
+			{ID: "mod-src-2", Name: "public", Provider: "aws", RegistryName: tfe.PublicRegistry},
+		},
+	}
+	// alice is already a member of the team
+	svcs := &fakeServices{members: []string{"alice"}}
+	var recorded []item
+	record := func(_ context.Context, i item) error {
+		recorded = append(recorded, i)
+		return nil
+	}
+	imp := &Import{Organization: "acme"}
+
+	err := newImporter(logr.Discard(), src, imp, nil, svcs.services(), record).run(ctx)
+	require.NoError(t, err)
+
+	// team
+	require.Equal(t, 1, len(svcs.teams))
+	assert.True(t, *svcs.teams[0].ManageWorkspaces)
+	// only the user that is not already a member is added to the team
+	assert.Equal(t, []string{"alice", "bob"}, svcs.members)
+	// workspace
+	require.Equal(t, 1, len(svcs.workspaces))
+	assert.Equal(t, "1.5.7", *svcs.workspaces[0].TerraformVersion)
+	assert.Equal(t, workspace.LocalExecutionMode, *svcs.workspaces[0].ExecutionMode)
+	assert.Equal(t, []workspace.TagSpec{{Name: "foo"}}, svcs.workspaces[0].Tags)
+	// only the non-sensitive variable is imported
+	require.Equal(t, 1, len(svcs.variables))
+	assert.Equal(t, "region", *svcs.variables[0].Key)
+	// state versions are imported oldest first
+	require.Equal(t, 2, len(svcs.stateVersions))
+	assert.Equal(t, int64(1), *svcs.stateVersions[0].Serial)
+	assert.Equal(t, int64(2), *svcs.stateVersions[1].Serial)
+	// only the team access that maps onto a role is imported
+	assert.Equal(t, []rbac.Role{rbac.WorkspaceWriteRole}, svcs.permissions)
+	// only the private module is imported
+	require.Equal(t, 1, len(svcs.modules))
+	assert.Equal(t, []string{"1.0.0"}, svcs.moduleVersions)
+
+	assert.Equal(t, 9, len(recorded))
+
+	t.Run("resume", func(t *testing.T) {
+		// resuming with every item already imported should import nothing
+		// more.
+		err := newImporter(logr.Discard(), src, imp, recorded, svcs.services(), record).run(ctx)
+		require.NoError(t, err)
+
+		assert.Equal(t, 1, len(svcs.teams))
+		assert.Equal(t, 1, len(svcs.workspaces))
+		assert.Equal(t, 1, len(svcs.variables))
+		assert.Equal(t, 2, len(svcs.stateVersions))
+		assert.Equal(t, 1, len(svcs.permissions))
+		assert.Equal(t, 1, len(svcs.modules))
+		assert.Equal(t, 1, len(svcs.moduleVersions))
+		assert.Equal(t, 2, len(svcs.members))
+		assert.Equal(t, 9, len(recorded))
+	})
+}
+
+type (
+	fakeSource struct {
+		teams         []*tfe.Team
+		workspaces    []*tfe.Workspace
+		variables     []*tfe.Variable
+		teamAccess    []*tfe.TeamAccess
+		stateVersions []*tfe.StateVersion
+		modules       []*tfe.RegistryModule
+	}
+
+	fakeServices struct {
+		teams          []team.CreateTeamOptions
+		workspaces     []workspace.CreateOptions
+		variables      []variable.CreateVariableOptions
+		stateVersions  []state.CreateStateVersionOptions
+		permissions    []rbac.Role
+		modules        []module.CreateOptions
+		moduleVersions []string
+		members        []string
+	}
+)
+
+func (f *fakeSource) listTeams(context.Context) ([]*tfe.Team, error) {
+	return f.teams, nil
+}
+
+func (f *fakeSource) listWorkspaces(context.Context) ([]*tfe.Workspace, error) {
+	return f.workspaces, nil
+}
+
+func (f *fakeSource) listVariables(context.Context, string) ([]*tfe.Variable, error) {
+	return f.variables, nil
+}
+
+func (f *fakeSource) listTeamAccess(context.Context, string) ([]*tfe.TeamAccess, error) {
+	return f.teamAccess, nil
+}
+
+func (f *fakeSource) listStateVersions(context.Context, string) ([]*tfe.StateVersion, error) {
+	return f.stateVersions, nil
+}
+
+func (f *fakeSource) downloadStateVersion(context.Context, *tfe.StateVersion) ([]byte, error) {
+	return []byte(`{}`), nil
+}
+
+func (f *fakeSource) listModules(context.Context) ([]*tfe.RegistryModule, error) {
+	return f.modules, nil
+}
+
+func (f *fakeSource) downloadModuleVersion(context.Context, *tfe.RegistryModule, string) ([]byte, error) {
+	return []byte("tarball"), nil
+}
+
+func (f *fakeServices) services() services {
+	return services{
+		Workspaces: &fakeWorkspaceService{f},
+		Variables:  &fakeVariableService{f},
+		States:     &fakeStateService{f},
+		Modules:    &fakeModuleService{f},
+		Teams:      &fakeTeamService{f},
+		Users:      &fakeUserService{f},
+	}
+}
+
+type fakeWorkspaceService struct{ *fakeServices }
+
+func (f *fakeWorkspaceService) Create(_ context.Context, opts workspace.CreateOptions) (*workspace.Workspace, error) {
+	f.workspaces = append(f.workspaces, opts)
+	return &workspace.Workspace{ID: "ws-1"}, nil
+}
+
+func (f *fakeWorkspaceService) GetByName(context.Context, string, string) (*workspace.Workspace, error) {
+	return nil, internal.ErrResourceNotFound
+}
+
+func (f *fakeWorkspaceService) SetPermission(_ context.Context, _, _ string, role rbac.Role) error {
+	f.permissions = append(f.permissions, role)
+	return nil
+}
+
+type fakeVariableService struct{ *fakeServices }
+
+func (f *fakeVariableService) CreateWorkspaceVariable(_ context.Context, _ string, opts variable.CreateVariableOptions) (*variable.Variable, error) {
+	f.variables = append(f.variables, opts)
+	return &variable.Variable{ID: "var-1"}, nil
+}
+
+func (f *fakeVariableService) ListWorkspaceVariables(context.Context, string) ([]*variable.Variable, error) {
+	return nil, nil
+}
+
+type fakeStateService struct{ *fakeServices }
+
+func (f *fakeStateService) Create(_ context.Context, opts state.CreateStateVersionOptions) (*state.Version, error) {
+	f.stateVersions = append(f.stateVersions, opts)
+	return &state.Version{ID: "sv-1"}, nil
+}
+
+type fakeModuleService struct{ *fakeServices }
+
+func (f *fakeModuleService) CreateModule(_ context.Context, opts module.CreateOptions) (*module.Module, error) {
+	f.modules = append(f.modules, opts)
+	return &module.Module{ID: "mod-1"}, nil
+}
+
+func (f *fakeModuleService) GetModule(context.Context, module.GetModuleOptions) (*module.Module, error) {
+	if len(f.modules) > 0 {
+		return &module.Module{ID: "mod-1"}, nil
+	}
+	return nil, internal.ErrResourceNotFound
+}
+
+func (f *fakeModuleService) CreateVersion(_ context.Context, opts module.CreateModuleVersionOptions) (*module.ModuleVersion, error) {
+	f.moduleVersions = append(f.moduleVersions, opts.Version)
+	return &module.ModuleVersion{ID: "modver-1", Version: opts.Version}, nil
+}
+
+func (f *fakeModuleService) UploadVersion(context.Context, string, []byte) error {
+	return nil
+}
+
+type fakeTeamService struct{ *fakeServices }
+
+func (f *fakeTeamService) Create(_ context.Context, _ string, opts team.CreateTeamOptions) (*team.Team, error) {
+	f.teams = append(f.teams, opts)
+	return &team.Team{ID: "team-1"}, nil
+}
+
+func (f *fakeTeamService) Get(context.Context, string, string) (*team.Team, error) {
+	return nil, internal.ErrResourceNotFound
+}
+
+type fakeUserService struct{ *fakeServices }
+
+func (f *fakeUserService) AddTeamMembership(_ context.Context, _ string, usernames []string) error {
+	f.members = append(f.members, usernames...)
+	return nil
+}
+
+func (f *fakeUserService) ListTeamUsers(context.Context, string) ([]*user.User, error) {
+	users := make([]*user.User, len(f.members))
+	for i, username := range f.members {
+		users[i] = &user.User{Username: username}
+	}
+	return users, nil
+}
@@ -0,0 +1,335 @@
+package orgimport
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/leg100/otf/internal"
+	"github.com/leg100/otf/internal/logr"
+	"github.com/leg100/otf/internal/module"
+	"github.com/leg100/otf/internal/organization"
+	"github.com/leg100/otf/internal/rbac"
+	"github.com/leg100/otf/internal/sql"
+	"github.com/leg100/otf/internal/state"
+	"github.com/leg100/otf/internal/team"
+	"github.com/leg100/otf/internal/tfeapi"
+	"github.com/leg100/otf/internal/user"
+	"github.com/leg100/otf/internal/variable"
+	"github.com/leg100/otf/internal/workspace"
+)
+
+const (
+	// heartbeatInterval is the frequency with which the importer records
+	// that its imports are still running.
+	heartbeatInterval = time.Minute
+	// interruptedAfter is the period after which a running import that has
+	// not been updated is deemed to have been interrupted, e.g. by a restart.
+	interruptedAfter = 3 * heartbeatInterval
+)
+
+var (
+	errNotStarted  = errors.New("organization importer has not started")
+	errInterrupted = errors.New("import was interrupted: resume the import to continue")
+)
+
+type (
+	Service struct {
+		logr.Logger
+
+		organization internal.Authorizer
+		db           *pgdb
+		api          *api
+		services
+
+		// newSource constructs a source from which to import
+		newSource func(address, token, organization string) (source, error)
+
+		mu sync.Mutex
+		// IDs of imports in progress
+		running map[string]struct{}
+		// ctx is the context from which imports in progress are derived. It
+		// is set when the importer is started and is canceled when otfd
+		// shuts down.
+		ctx context.Context
+	}
+
+	Options struct {
+		logr.Logger
+		*sql.DB
+		*tfeapi.Responder
+
+		WorkspaceService WorkspaceService
+		VariableService  VariableService
+		StateService     StateService
+		ModuleService    ModuleService
+		TeamService      TeamService
+		UserService      UserService
+		// MaxModuleSize is the maximum permitted size in bytes of a module
+		// version tarball.
+		MaxModuleSize int64
+	}
+
+	WorkspaceService interface {
+		Create(ctx context.Context, opts workspace.CreateOptions) (*workspace.Workspace, error)
+		GetByName(ctx context.Context, organization, workspace string) (*workspace.Workspace, error)
+		SetPermission(ctx context.Context, workspaceID, teamID string, role rbac.Role) error
+	}
+
+	VariableService interface {
+		CreateWorkspaceVariable(ctx context.Context, workspaceID string, opts variable.CreateVariableOptions) (*variable.Variable, error)
+		ListWorkspaceVariables(ctx context.Context, workspaceID string) ([]*variable.Variable, error)
+	}
+
+	StateService interface {
+		Create(ctx context.Context, opts state.CreateStateVersionOptions) (*state.Version, error)
+	}
+
+	ModuleService interface {
+		CreateModule(ctx context.Context, opts module.CreateOptions) (*module.Module, error)
+		GetModule(ctx context.Context, opts module.GetModuleOptions) (*module.Module, error)
+		CreateVersion(ctx context.Context, opts module.CreateModuleVersionOptions) (*module.ModuleVersion, error)
+		UploadVersion(ctx context.Context, versionID string, tarball []byte) error
+	}
+
+	TeamService interface {
+		Create(ctx context.Context, organization string, opts team.CreateTeamOptions) (*team.Team, error)
+		Get(ctx context.Context, organization, team string) (*team.Team, error)
+	}
+
+	UserService interface {
+		AddTeamMembership(ctx context.Context, teamID string, usernames []string) error
+		ListTeamUsers(ctx context.Context, teamID string) ([]*user.User, error)
+	}
+)
+
+func NewService(opts Options) *Service {
+	svc := &Service{
+		Logger:       opts.Logger,
+		organization: &organization.Authorizer{Logger: opts.Logger},
+		db:           &pgdb{opts.DB},
+		services: services{
+			Workspaces: opts.WorkspaceService,
+			Variables:  opts.VariableService,
+			States:     opts.StateService,
+			Modules:    opts.ModuleService,
+			Teams:      opts.TeamService,
+			Users:      opts.UserService,
+		},
+		newSource: func(address, token, organization string) (source, error) {
+			return newTFESource(address, token, organization, opts.MaxModuleSize)
+		},
+		running: make(map[string]struct{}),
+	}
+	svc.api = &api{
+		Service:   svc,
+		Responder: opts.Responder,
+	}
+	return svc
+}
+
+func (s *Service) AddHandlers(r *mux.Router) {
+	s.api.addHandlers(r)
+}
+
+func (s *Service) String() string { return "organization-importer" }
+
+// Start the importer. Imports are run in the background on this node until
+// ctx is canceled. Every heartbeat interval the importer records that its
+// imports are still running, and marks as errored any running import, on any
+// node, that has not been updated recently, i.e. one that was interrupted by a
+// restart. An interrupted import can be resumed.
+//
+// Should be invoked in a go routine.
+func (s *Service) Start(ctx context.Context) error {
+	s.mu.Lock()
+	s.ctx = ctx
+	s.mu.Unlock()
+
+	ticker := time.NewTicker(heartbeatInterval)
+	defer ticker.Stop()
+	for {
+		if err := s.reconcile(ctx); err != nil {
+			return err
+		}
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
+
+func (s *Service) reconcile(ctx context.Context) error {
+	s.mu.Lock()
+	ids := make([]string, 0, len(s.running))
+	for id := range s.running {
+		ids = append(ids, id)
+	}
+	s.mu.Unlock()
+
+	if len(ids) > 0 {
+		if err := s.db.touch(ctx, ids); err != nil {
+			return err
+		}
+	}
+	cutoff := internal.CurrentTimestamp(nil).Add(-interruptedAfter)
+	interrupted, err := s.db.errorInterrupted(ctx, cutoff, errInterrupted.Error())
+	if err != nil {
+		return err
+	}
+	for _, id := range interrupted {
+		s.V(0).Info("marked interrupted organization import as errored", "id", id)
+	}
+	return nil
+}
+
+// Create starts importing resources from a TFC/TFE organization into an OTF
+// organization. The import proceeds in the background; its progress can be
+// tracked by retrieving the import.
+func (s *Service) Create(ctx context.Context, opts CreateOptions) (*Import, error) {
+	subject, err := s.organization.CanAccess(ctx, rbac.CreateOrganizationImportAction, opts.Organization)
+	if err != nil {
+		return nil, err
+	}
+	imp, err := newImport(opts)
+	if err != nil {
+		return nil, err
+	}
+	src, err := s.newSource(imp.SourceAddress, opts.Token, imp.SourceOrganization)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.db.create(ctx, imp); err != nil {
+		s.Error(err, "creating organization import", "import", imp, "subject", subject)
+		return nil, err
+	}
+	if err := s.start(imp, src, nil); err != nil {
+		if err := s.db.updateStatus(ctx, imp.ID, StatusErrored, err.Error()); err != nil {
+			s.Error(err, "updating organization import status", "import", imp)
+		}
+		return nil, err
+	}
+	s.V(0).Info("started organization import", "import", imp, "subject", subject)
+	return imp, nil
+}
+
+// Resume resumes an import that has errored, including one that was
+// interrupted by a restart, skipping resources that have already been
+// imported.
+func (s *Service) Resume(ctx context.Context, importID string, opts ResumeOptions) (*Import, error) {
+	imp, err := s.db.get(ctx, importID)
+	if err != nil {
+		return nil, err
+	}
+	subject, err := s.organization.CanAccess(ctx, rbac.CreateOrganizationImportAction, imp.Organization)
+	if err != nil {
+		return nil, err
+	}
+	switch imp.Status {
+	case StatusCompleted:
+		return nil, ErrImportCompleted
+	case StatusRunning:
+		return nil, ErrImportInProgress
+	}
+	if opts.Token == "" {
+		return nil, &internal.MissingParameterError{Parameter: "token"}
+	}
+	src, err := s.newSource(imp.SourceAddress, opts.Token, imp.SourceOrganization)
+	if err != nil {
+		return nil, err
+	}
+	items, err := s.db.listItems(ctx, importID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.db.updateStatus(ctx, importID, StatusRunning, ""); err != nil {
+		return nil, err
+	}
+	if err := s.start(imp, src, items); err != nil {
+		return nil, err
+	}
+	imp.Status = StatusRunning
+	imp.Error = ""
+	s.V(0).Info("resumed organization import", "import", imp, "subject", subject)
+	return imp, nil
+}
+
+func (s *Service) Get(ctx context.Context, importID string) (*Import, error) {
+	imp, err := s.db.get(ctx, importID)
+	if err != nil {
+		s.Error(err, "retrieving organization import", "id", importID)
+		return nil, err
+	}
+	subject, err := s.organization.CanAccess(ctx, rbac.GetOrganizationImportAction, imp.Organization)
+	if err != nil {
+		return nil, err
+	}
+	s.V(9).Info("retrieved organization import", "import", imp, "subject", subject)
+	return imp, nil
+}
+
+func (s *Service) List(ctx context.Context, organization string) ([]*Import, error) {
+	subject, err := s.organization.CanAccess(ctx, rbac.ListOrganizationImportsAction, organization)
+	if err != nil {
+		return nil, err
+	}
+	imports, err := s.db.list(ctx, organization)
+	if err != nil {
+		s.Error(err, "listing organization imports", "organization", organization, "subject", subject)
+		return nil, err
+	}
+	s.V(9).Info("listed organization imports", "organization", organization, "count", len(imports), "subject", subject)
+	return imports, nil
+}
+
+// start runs the import in the background. An error is returned if the
+// import is already running or if the importer has not started.
+func (s *Service) start(imp *Import, src source, items []item) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.ctx == nil {
+		return errNotStarted
+	}
+	if _, ok := s.running[imp.ID]; ok {
+		return ErrImportInProgress
+	}
+	s.running[imp.ID] = struct{}{}
+
+	// the import outlives the request that started it, so it is derived
+	// from the importer's context, acting with the importer's privileges,
+	// and it is canceled when the importer is stopped.
+	ctx := s.ctx
+	go func() {
+		defer func() {
+			s.mu.Lock()
+			delete(s.running, imp.ID)
+			s.mu.Unlock()
+		}()
+		record := func(ctx context.Context, i item) error {
+			return s.db.createItem(ctx, imp.ID, i)
+		}
+		importer := newImporter(s.WithValues("import", imp.ID), src, imp, items, s.services, record)
+		err := importer.run(ctx)
+		if err != nil && ctx.Err() != nil {
+			err = errInterrupted
+		}
+		// record the outcome even if the importer has been stopped
+		ctx := context.WithoutCancel(ctx)
+		if err != nil {
+			s.Error(err, "importing organization", "import", imp)
+			if err := s.db.updateStatus(ctx, imp.ID, StatusErrored, err.Error()); err != nil {
+				s.Error(err, "updating organization import status", "import", imp)
+			}
+			return
+		}
+		if err := s.db.updateStatus(ctx, imp.ID, StatusCompleted, ""); err != nil {
+			s.Error(err, "updating organization import status", "import", imp)
+			return
+		}
+		s.V(0).Info("completed organization import", "import", imp)
+	}()
+	return nil
+}
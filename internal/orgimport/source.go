@@ -0,0 +1,216 @@
+package orgimport
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"slices"
+
+	"github.com/hashicorp/go-tfe"
+)
+
+type (
+	// source is a TFC/TFE organization from which resources are imported.
+	source interface {
+		listTeams(ctx context.Context) ([]*tfe.Team, error)
+		listWorkspaces(ctx context.Context) ([]*tfe.Workspace, error)
+		listVariables(ctx context.Context, workspaceID string) ([]*tfe.Variable, error)
+		listTeamAccess(ctx context.Context, workspaceID string) ([]*tfe.TeamAccess, error)
+		// listStateVersions lists a workspace's state versions, oldest first.
+		listStateVersions(ctx context.Context, workspaceName string) ([]*tfe.StateVersion, error)
+		downloadStateVersion(ctx context.Context, sv *tfe.StateVersion) ([]byte, error)
+		listModules(ctx context.Context) ([]*tfe.RegistryModule, error)
+		downloadModuleVersion(ctx context.Context, mod *tfe.RegistryModule, version string) ([]byte, error)
+	}
+
+	// tfeSource is a source accessed via the TFC/TFE API.
+	tfeSource struct {
+		*tfe.Client
+
+		address      string
+		token        string
+		organization string
+		// maximum permitted size in bytes of a module version tarball
+		maxModuleSize int64
+	}
+)
+
+func newTFESource(address, token, organization string, maxModuleSize int64) (*tfeSource, error) {
+	client, err := tfe.NewClient(&tfe.Config{
+		Address: address,
+		Token:   token,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("constructing client: %w", err)
+	}
+	return &tfeSource{
+		Client:        client,
+		address:       address,
+		token:         token,
+		organization:  organization,
+		maxModuleSize: maxModuleSize,
+	}, nil
+}
+
+func (s *tfeSource) listTeams(ctx context.Context) ([]*tfe.Team, error) {
+	return listAll(func(opts tfe.ListOptions) ([]*tfe.Team, *tfe.Pagination, error) {
+		list, err := s.Teams.List(ctx, s.organization, &tfe.TeamListOptions{
+			ListOptions: opts,
+			Include:     []tfe.TeamIncludeOpt{tfe.TeamUsers},
+		})
+		if err != nil {
+			return nil, nil, err
+		}
+		return list.Items, list.Pagination, nil
+	})
+}
+
+func (s *tfeSource) listWorkspaces(ctx context.Context) ([]*tfe.Workspace, error) {
+	return listAll(func(opts tfe.ListOptions) ([]*tfe.Workspace, *tfe.Pagination, error) {
+		list, err := s.Workspaces.List(ctx, s.organization, &tfe.WorkspaceListOptions{ListOptions: opts})
+		if err != nil {
+			return nil, nil, err
+		}
+		return list.Items, list.Pagination, nil
+	})
+}
+
+func (s *tfeSource) listVariables(ctx context.Context, workspaceID string) ([]*tfe.Variable, error) {
+	return listAll(func(opts tfe.ListOptions) ([]*tfe.Variable, *tfe.Pagination, error) {
+		list, err := s.Variables.List(ctx, workspaceID, &tfe.VariableListOptions{ListOptions: opts})
+		if err != nil {
+			return nil, nil, err
+		}
+		return list.Items, list.Pagination, nil
+	})
+}
+
+func (s *tfeSource) listTeamAccess(ctx context.Context, workspaceID string) ([]*tfe.TeamAccess, error) {
+	return listAll(func(opts tfe.ListOptions) ([]*tfe.TeamAccess, *tfe.Pagination, error) {
+		list, err := s.TeamAccess.List(ctx, &tfe.TeamAccessListOptions{ListOptions: opts, WorkspaceID: workspaceID})
+		if err != nil {
+			return nil, nil, err
+		}
+		return list.Items, list.Pagination, nil
+	})
+}
+
+func (s *tfeSource) listStateVersions(ctx context.Context, workspaceName string) ([]*tfe.StateVersion, error) {
+	versions, err := listAll(func(opts tfe.ListOptions) ([]*tfe.StateVersion, *tfe.Pagination, error) {
+		list, err := s.StateVersions.List(ctx, &tfe.StateVersionListOptions{
+			ListOptions:  opts,
+			Organization: s.organization,
+			Workspace:    workspaceName,
+		})
+		if err != nil {
+			return nil, nil, err
+		}
+		return list.Items, list.Pagination, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	// API lists newest first
+	slices.Reverse(versions)
+	return versions, nil
+}
+
+func (s *tfeSource) downloadStateVersion(ctx context.Context, sv *tfe.StateVersion) ([]byte, error) {
+	return s.StateVersions.Download(ctx, sv.DownloadURL)
+}
+
+func (s *tfeSource) listModules(ctx context.Context) ([]*tfe.RegistryModule, error) {
+	return listAll(func(opts tfe.ListOptions) ([]*tfe.RegistryModule, *tfe.Pagination, error) {
+		list, err := s.RegistryModules.List(ctx, s.organization, &tfe.RegistryModuleListOptions{ListOptions: opts})
+		if err != nil {
+			return nil, nil, err
+		}
+		return list.Items, list.Pagination, nil
+	})
+}
+
+// downloadModuleVersion downloads a module version tarball using the module
+// registry protocol:
+//
+// https://developer.hashicorp.com/terraform/internals/module-registry-protocol#download-source-code-for-a-specific-module-version
+//
+// A tarball larger than the maximum module size is rejected.
+func (s *tfeSource) downloadModuleVersion(ctx context.Context, mod *tfe.RegistryModule, version string) ([]byte, error) {
+	base, err := url.Parse(s.address)
+	if err != nil {
+		return nil, err
+	}
+	u := base.JoinPath("/api/registry/v1/modules", mod.Namespace, mod.Name, mod.Provider, version, "download")
+	resp, err := s.get(ctx, u.String(), true)
+	if err != nil {
+		return nil, err
+	}
+	resp.Body.Close()
+	getter := resp.Header.Get("X-Terraform-Get")
+	if getter == "" {
+		return nil, fmt.Errorf("registry did not return a download location for %s/%s version %s", mod.Name, mod.Provider, version)
+	}
+	location, err := u.Parse(getter)
+	if err != nil {
+		return nil, fmt.Errorf("parsing module download location: %w", err)
+	}
+	if location.Scheme != "http" && location.Scheme != "https" {
+		return nil, fmt.Errorf("unsupported module download location: %s", getter)
+	}
+	// only send the token to the source host and not to, say, a storage
+	// bucket serving a pre-signed URL.
+	resp, err = s.get(ctx, location.String(), location.Host == base.Host)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	// read one byte more than the maximum to detect a tarball that exceeds it
+	tarball, err := io.ReadAll(io.LimitReader(resp.Body, s.maxModuleSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(tarball)) > s.maxModuleSize {
+		return nil, fmt.Errorf("%s/%s version %s exceeds maximum module size (%d bytes)", mod.Name, mod.Provider, version, s.maxModuleSize)
+	}
+	return tarball, nil
+}
+
+func (s *tfeSource) get(ctx context.Context, u string, authenticate bool) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", u, nil)
+	if err != nil {
+		return nil, err
+	}
+	if authenticate {
+		req.Header.Set("Authorization", "Bearer "+s.token)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		resp.Body.Close()
+		return nil, fmt.Errorf("GET %s: %s", u, resp.Status)
+	}
+	return resp, nil
+}
+
+// listAll retrieves every page of results from a TFC/TFE list endpoint.
+func listAll[T any](fn func(tfe.ListOptions) ([]T, *tfe.Pagination, error)) ([]T, error) {
+	var (
+		opts = tfe.ListOptions{PageNumber: 1, PageSize: 100}
+		all  []T
+	)
+	for {
+		items, pagination, err := fn(opts)
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, items...)
+		if pagination == nil || pagination.NextPage == 0 {
+			return all, nil
+		}
+		opts.PageNumber = pagination.NextPage
+	}
+}
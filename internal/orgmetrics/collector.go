@@ -0,0 +1,306 @@
+// Package orgmetrics provides per-organization metrics, permitting tenants to
+// monitor their own usage of OTF.
+package orgmetrics
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/leg100/otf/internal/run"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	OrganizationLabel = "organization"
+	WorkspaceLabel    = "workspace"
+
+	// OverflowWorkspace is the workspace label value given to the series of
+	// workspaces in an organization that has reached its series cap.
+	OverflowWorkspace = "__overflow__"
+
+	DefaultMaxSeries = 1000
+)
+
+var (
+	DefaultLabels = []string{OrganizationLabel, WorkspaceLabel}
+
+	ErrInvalidLabel = errors.New("invalid metrics label")
+
+	// durationBuckets are the upper bounds, in seconds, of the buckets into
+	// which the durations of run stages are observed.
+	durationBuckets = []float64{1, 5, 15, 30, 60, 120, 300, 600, 1800, 3600, 7200}
+)
+
+type (
+	// Config configures organization metrics.
+	Config struct {
+		// Labels to include in metrics: organization and/or workspace.
+		Labels []string
+		// MaxSeries caps the number of series per organization. Once the cap
+		// is reached, further workspaces are aggregated into a single
+		// overflow series. Zero means no cap.
+		MaxSeries int
+	}
+
+	// Collector collects metrics on finished runs, by organization, workspace
+	// and status, along with the time they spent in each stage. It implements
+	// prometheus.Collector.
+	Collector struct {
+		includeOrganization bool
+		includeWorkspace    bool
+		maxSeries           int
+
+		desc         *prometheus.Desc
+		durationDesc *prometheus.Desc
+
+		mu sync.Mutex
+		// counts of finished runs, keyed by organization
+		runs map[string]map[series]float64
+		// durations of the stages of finished runs, keyed by organization
+		durations map[string]map[stageSeries]*histogram
+	}
+
+	series struct {
+		workspace string
+		status    run.Status
+	}
+
+	stageSeries struct {
+		workspace string
+		stage     string
+	}
+
+	// histogram accumulates observations into durationBuckets.
+	histogram struct {
+		count   uint64
+		sum     float64
+		buckets map[float64]uint64
+	}
+
+	// labelValues uniquely identifies a metric, taking into account which
+	// labels are included.
+	labelValues struct {
+		organization string
+		series
+	}
+
+	// stageLabelValues uniquely identifies a stage duration metric, taking
+	// into account which labels are included.
+	stageLabelValues struct {
+		organization string
+		stageSeries
+	}
+)
+
+// Valid validates the config.
+func (cfg Config) Valid() error {
+	for _, label := range cfg.Labels {
+		if label != OrganizationLabel && label != WorkspaceLabel {
+			return fmt.Errorf("%w: %s: must be one of %s or %s", ErrInvalidLabel, label, OrganizationLabel, WorkspaceLabel)
+		}
+	}
+	if cfg.MaxSeries < 0 {
+		return errors.New("max series cannot be negative")
+	}
+	return nil
+}
+
+func NewCollector(cfg Config) *Collector {
+	c := &Collector{
+		includeOrganization: slices.Contains(cfg.Labels, OrganizationLabel),
+		includeWorkspace:    slices.Contains(cfg.Labels, WorkspaceLabel),
+		maxSeries:           cfg.MaxSeries,
+		runs:                make(map[string]map[series]float64),
+		durations:           make(map[string]map[stageSeries]*histogram),
+	}
+	var labels []string
+	if c.includeOrganization {
+		labels = append(labels, "organization")
+	}
+	if c.includeWorkspace {
+		labels = append(labels, "workspace_id")
+	}
+	c.desc = prometheus.NewDesc(
+		"otf_organization_runs_total",
+		"Total number of finished runs.",
+		append(slices.Clone(labels), "status"),
+		nil,
+	)
+	c.durationDesc = prometheus.NewDesc(
+		"otf_organization_run_stage_duration_seconds",
+		"Time finished runs spent in each stage: queued, waiting_for_agent, plan, waiting_for_confirmation, and apply.",
+		append(slices.Clone(labels), "stage"),
+		nil,
+	)
+	return c
+}
+
+// record a finished run.
+func (c *Collector) record(r *run.Run) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	s := series{status: r.Status}
+	if c.includeWorkspace {
+		s.workspace = r.WorkspaceID
+	}
+	counts, ok := c.runs[r.Organization]
+	if !ok {
+		counts = make(map[series]float64)
+		c.runs[r.Organization] = counts
+	}
+	if _, ok := counts[s]; !ok && c.maxSeries > 0 && len(counts) >= c.maxSeries {
+		s.workspace = OverflowWorkspace
+	}
+	counts[s]++
+
+	histograms, ok := c.durations[r.Organization]
+	if !ok {
+		histograms = make(map[stageSeries]*histogram)
+		c.durations[r.Organization] = histograms
+	}
+	timings := r.Timings(time.Now())
+	for _, stage := range []struct {
+		name     string
+		duration time.Duration
+	}{
+		{"queued", timings.Queued},
+		{"waiting_for_agent", timings.WaitingForAgent},
+		{"plan", timings.Plan},
+		{"waiting_for_confirmation", timings.WaitingForConfirmation},
+		{"apply", timings.Apply},
+	} {
+		// skip stages the run did not enter
+		if stage.duration == 0 {
+			continue
+		}
+		ss := stageSeries{workspace: s.workspace, stage: stage.name}
+		h, ok := histograms[ss]
+		if !ok {
+			h = newHistogram()
+			histograms[ss] = h
+		}
+		h.observe(stage.duration.Seconds())
+	}
+}
+
+func newHistogram() *histogram {
+	h := &histogram{buckets: make(map[float64]uint64, len(durationBuckets))}
+	for _, upper := range durationBuckets {
+		h.buckets[upper] = 0
+	}
+	return h
+}
+
+func (h *histogram) observe(v float64) {
+	h.count++
+	h.sum += v
+	for _, upper := range durationBuckets {
+		if v <= upper {
+			h.buckets[upper]++
+		}
+	}
+}
+
+// add merges another histogram into this one.
+func (h *histogram) add(other *histogram) {
+	h.count += other.count
+	h.sum += other.sum
+	for upper, count := range other.buckets {
+		h.buckets[upper] += count
+	}
+}
+
+// Describe implements prometheus.Collector
+func (c *Collector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.desc
+	ch <- c.durationDesc
+}
+
+// Collect implements prometheus.Collector
+func (c *Collector) Collect(ch chan<- prometheus.Metric) {
+	c.collect(ch, func(string) bool { return true })
+}
+
+// forOrganization returns a collector of the metrics belonging only to the
+// given organization.
+func (c *Collector) forOrganization(organization string) prometheus.Collector {
+	return &organizationCollector{Collector: c, organization: organization}
+}
+
+func (c *Collector) collect(ch chan<- prometheus.Metric, include func(organization string) bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	// aggregate counts across excluded labels
+	aggregated := make(map[labelValues]float64)
+	for org, counts := range c.runs {
+		if !include(org) {
+			continue
+		}
+		for s, count := range counts {
+			var lv labelValues
+			if c.includeOrganization {
+				lv.organization = org
+			}
+			lv.series = s
+			aggregated[lv] += count
+		}
+	}
+	for lv, count := range aggregated {
+		var values []string
+		if c.includeOrganization {
+			values = append(values, lv.organization)
+		}
+		if c.includeWorkspace {
+			values = append(values, lv.workspace)
+		}
+		values = append(values, string(lv.status))
+		ch <- prometheus.MustNewConstMetric(c.desc, prometheus.CounterValue, count, values...)
+	}
+
+	// aggregate histograms across excluded labels
+	aggregatedDurations := make(map[stageLabelValues]*histogram)
+	for org, histograms := range c.durations {
+		if !include(org) {
+			continue
+		}
+		for ss, h := range histograms {
+			var lv stageLabelValues
+			if c.includeOrganization {
+				lv.organization = org
+			}
+			lv.stageSeries = ss
+			agg, ok := aggregatedDurations[lv]
+			if !ok {
+				agg = newHistogram()
+				aggregatedDurations[lv] = agg
+			}
+			agg.add(h)
+		}
+	}
+	for lv, h := range aggregatedDurations {
+		var values []string
+		if c.includeOrganization {
+			values = append(values, lv.organization)
+		}
+		if c.includeWorkspace {
+			values = append(values, lv.workspace)
+		}
+		values = append(values, lv.stage)
+		ch <- prometheus.MustNewConstHistogram(c.durationDesc, h.count, h.sum, h.buckets, values...)
+	}
+}
+
+type organizationCollector struct {
+	*Collector
+	organization string
+}
+
+// Collect implements prometheus.Collector
+func (c *organizationCollector) Collect(ch chan<- prometheus.Metric) {
+	c.collect(ch, func(org string) bool { return org == c.organization })
+}
@@ -0,0 +1,210 @@
+package orgmetrics
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/leg100/otf/internal/run"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const metricHeader = `
+# HELP otf_organization_runs_total Total number of finished runs.
+# TYPE otf_organization_runs_total counter
+`
+
+func TestCollector(t *testing.T) {
+	runs := []*run.Run{
+		{Organization: "acme", WorkspaceID: "ws-1", Status: run.RunApplied},
+		{Organization: "acme", WorkspaceID: "ws-1", Status: run.RunApplied},
+		{Organization: "acme", WorkspaceID: "ws-2", Status: run.RunErrored},
+		{Organization: "initech", WorkspaceID: "ws-3", Status: run.RunApplied},
+	}
+
+	tests := []struct {
+		name string
+		cfg  Config
+		want string
+	}{
+		{
+			name: "all labels",
+			cfg:  Config{Labels: DefaultLabels},
+			want: `
+otf_organization_runs_total{organization="acme",status="applied",workspace_id="ws-1"} 2
+otf_organization_runs_total{organization="acme",status="errored",workspace_id="ws-2"} 1
+otf_organization_runs_total{organization="initech",status="applied",workspace_id="ws-3"} 1
+`,
+		},
+		{
+			name: "organization label only",
+			cfg:  Config{Labels: []string{OrganizationLabel}},
+			want: `
+otf_organization_runs_total{organization="acme",status="applied"} 2
+otf_organization_runs_total{organization="acme",status="errored"} 1
+otf_organization_runs_total{organization="initech",status="applied"} 1
+`,
+		},
+		{
+			name: "no labels",
+			cfg:  Config{},
+			want: `
+otf_organization_runs_total{status="applied"} 3
+otf_organization_runs_total{status="errored"} 1
+`,
+		},
+		{
+			name: "series cap",
+			cfg:  Config{Labels: DefaultLabels, MaxSeries: 1},
+			want: `
+otf_organization_runs_total{organization="acme",status="applied",workspace_id="ws-1"} 2
+otf_organization_runs_total{organization="acme",status="errored",workspace_id="__overflow__"} 1
+otf_organization_runs_total{organization="initech",status="applied",workspace_id="ws-3"} 1
+`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := NewCollector(tt.cfg)
+			for _, r := range runs {
+				c.record(r)
+			}
+			err := testutil.CollectAndCompare(c, strings.NewReader(metricHeader+tt.want))
+			assert.NoError(t, err)
+		})
+	}
+
+	t.Run("for organization", func(t *testing.T) {
+		c := NewCollector(Config{Labels: DefaultLabels})
+		for _, r := range runs {
+			c.record(r)
+		}
+		want := `
+otf_organization_runs_total{organization="initech",status="applied",workspace_id="ws-3"} 1
+`
+		err := testutil.CollectAndCompare(c.forOrganization("initech"), strings.NewReader(metricHeader+want))
+		assert.NoError(t, err)
+	})
+}
+
+func TestCollector_StageDurations(t *testing.T) {
+	start := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	at := func(seconds int) time.Time {
+		return start.Add(time.Duration(seconds) * time.Second)
+	}
+	runs := []*run.Run{
+		{
+			Organization: "acme",
+			WorkspaceID:  "ws-1",
+			Status:       run.RunApplied,
+			StatusTimestamps: []run.StatusTimestamp{
+				{Status: run.RunPending, Timestamp: at(0)},
+				{Status: run.RunPlanQueued, Timestamp: at(2)},
+				{Status: run.RunPlanning, Timestamp: at(12)},
+				{Status: run.RunPlanned, Timestamp: at(40)},
+				{Status: run.RunApplyQueued, Timestamp: at(100)},
+				{Status: run.RunApplying, Timestamp: at(110)},
+				{Status: run.RunApplied, Timestamp: at(400)},
+			},
+		},
+		{
+			Organization: "acme",
+			WorkspaceID:  "ws-1",
+			Status:       run.RunPlannedAndFinished,
+			StatusTimestamps: []run.StatusTimestamp{
+				{Status: run.RunPending, Timestamp: at(0)},
+				{Status: run.RunPlanQueued, Timestamp: at(20)},
+				{Status: run.RunPlanning, Timestamp: at(22)},
+				{Status: run.RunPlannedAndFinished, Timestamp: at(30)},
+			},
+		},
+	}
+	c := NewCollector(Config{Labels: []string{OrganizationLabel}})
+	for _, r := range runs {
+		c.record(r)
+	}
+
+	want := `
+# HELP otf_organization_run_stage_duration_seconds Time finished runs spent in each stage: queued, waiting_for_agent, plan, waiting_for_confirmation, and apply.
+# TYPE otf_organization_run_stage_duration_seconds histogram
+otf_organization_run_stage_duration_seconds_bucket{organization="acme",stage="apply",le="1"} 0
+otf_organization_run_stage_duration_seconds_bucket{organization="acme",stage="apply",le="5"} 0
+otf_organization_run_stage_duration_seconds_bucket{organization="acme",stage="apply",le="15"} 0
+otf_organization_run_stage_duration_seconds_bucket{organization="acme",stage="apply",le="30"} 0
+otf_organization_run_stage_duration_seconds_bucket{organization="acme",stage="apply",le="60"} 0
+otf_organization_run_stage_duration_seconds_bucket{organization="acme",stage="apply",le="120"} 0
+otf_organization_run_stage_duration_seconds_bucket{organization="acme",stage="apply",le="300"} 1
+otf_organization_run_stage_duration_seconds_bucket{organization="acme",stage="apply",le="600"} 1
+otf_organization_run_stage_duration_seconds_bucket{organization="acme",stage="apply",le="1800"} 1
+otf_organization_run_stage_duration_seconds_bucket{organization="acme",stage="apply",le="3600"} 1
+otf_organization_run_stage_duration_seconds_bucket{organization="acme",stage="apply",le="7200"} 1
+otf_organization_run_stage_duration_seconds_bucket{organization="acme",stage="apply",le="+Inf"} 1
+otf_organization_run_stage_duration_seconds_sum{organization="acme",stage="apply"} 290
+otf_organization_run_stage_duration_seconds_count{organization="acme",stage="apply"} 1
+otf_organization_run_stage_duration_seconds_bucket{organization="acme",stage="plan",le="1"} 0
+otf_organization_run_stage_duration_seconds_bucket{organization="acme",stage="plan",le="5"} 0
+otf_organization_run_stage_duration_seconds_bucket{organization="acme",stage="plan",le="15"} 1
+otf_organization_run_stage_duration_seconds_bucket{organization="acme",stage="plan",le="30"} 2
+otf_organization_run_stage_duration_seconds_bucket{organization="acme",stage="plan",le="60"} 2
+otf_organization_run_stage_duration_seconds_bucket{organization="acme",stage="plan",le="120"} 2
+otf_organization_run_stage_duration_seconds_bucket{organization="acme",stage="plan",le="300"} 2
+otf_organization_run_stage_duration_seconds_bucket{organization="acme",stage="plan",le="600"} 2
+otf_organization_run_stage_duration_seconds_bucket{organization="acme",stage="plan",le="1800"} 2
+otf_organization_run_stage_duration_seconds_bucket{organization="acme",stage="plan",le="3600"} 2
+otf_organization_run_stage_duration_seconds_bucket{organization="acme",stage="plan",le="7200"} 2
+otf_organization_run_stage_duration_seconds_bucket{organization="acme",stage="plan",le="+Inf"} 2
+otf_organization_run_stage_duration_seconds_sum{organization="acme",stage="plan"} 36
+otf_organization_run_stage_duration_seconds_count{organization="acme",stage="plan"} 2
+otf_organization_run_stage_duration_seconds_bucket{organization="acme",stage="queued",le="1"} 0
+otf_organization_run_stage_duration_seconds_bucket{organization="acme",stage="queued",le="5"} 1
+otf_organization_run_stage_duration_seconds_bucket{organization="acme",stage="queued",le="15"} 1
+otf_organization_run_stage_duration_seconds_bucket{organization="acme",stage="queued",le="30"} 2
+otf_organization_run_stage_duration_seconds_bucket{organization="acme",stage="queued",le="60"} 2
+otf_organization_run_stage_duration_seconds_bucket{organization="acme",stage="queued",le="120"} 2
+otf_organization_run_stage_duration_seconds_bucket{organization="acme",stage="queued",le="300"} 2
+otf_organization_run_stage_duration_seconds_bucket{organization="acme",stage="queued",le="600"} 2
+otf_organization_run_stage_duration_seconds_bucket{organization="acme",stage="queued",le="1800"} 2
+otf_organization_run_stage_duration_seconds_bucket{organization="acme",stage="queued",le="3600"} 2
+otf_organization_run_stage_duration_seconds_bucket{organization="acme",stage="queued",le="7200"} 2
+otf_organization_run_stage_duration_seconds_bucket{organization="acme",stage="queued",le="+Inf"} 2
+otf_organization_run_stage_duration_seconds_sum{organization="acme",stage="queued"} 22
+otf_organization_run_stage_duration_seconds_count{organization="acme",stage="queued"} 2
+otf_organization_run_stage_duration_seconds_bucket{organization="acme",stage="waiting_for_agent",le="1"} 0
+otf_organization_run_stage_duration_seconds_bucket{organization="acme",stage="waiting_for_agent",le="5"} 1
+otf_organization_run_stage_duration_seconds_bucket{organization="acme",stage="waiting_for_agent",le="15"} 1
+otf_organization_run_stage_duration_seconds_bucket{organization="acme",stage="waiting_for_agent",le="30"} 2
+otf_organization_run_stage_duration_seconds_bucket{organization="acme",stage="waiting_for_agent",le="60"} 2
+otf_organization_run_stage_duration_seconds_bucket{organization="acme",stage="waiting_for_agent",le="120"} 2
+otf_organization_run_stage_duration_seconds_bucket{organization="acme",stage="waiting_for_agent",le="300"} 2
+otf_organization_run_stage_duration_seconds_bucket{organization="acme",stage="waiting_for_agent",le="600"} 2
+otf_organization_run_stage_duration_seconds_bucket{organization="acme",stage="waiting_for_agent",le="1800"} 2
+otf_organization_run_stage_duration_seconds_bucket{organization="acme",stage="waiting_for_agent",le="3600"} 2
+otf_organization_run_stage_duration_seconds_bucket{organization="acme",stage="waiting_for_agent",le="7200"} 2
+otf_organization_run_stage_duration_seconds_bucket{organization="acme",stage="waiting_for_agent",le="+Inf"} 2
+otf_organization_run_stage_duration_seconds_sum{organization="acme",stage="waiting_for_agent"} 22
+otf_organization_run_stage_duration_seconds_count{organization="acme",stage="waiting_for_agent"} 2
+otf_organization_run_stage_duration_seconds_bucket{organization="acme",stage="waiting_for_confirmation",le="1"} 0
+otf_organization_run_stage_duration_seconds_bucket{organization="acme",stage="waiting_for_confirmation",le="5"} 0
+otf_organization_run_stage_duration_seconds_bucket{organization="acme",stage="waiting_for_confirmation",le="15"} 0
+otf_organization_run_stage_duration_seconds_bucket{organization="acme",stage="waiting_for_confirmation",le="30"} 0
+otf_organization_run_stage_duration_seconds_bucket{organization="acme",stage="waiting_for_confirmation",le="60"} 1
+otf_organization_run_stage_duration_seconds_bucket{organization="acme",stage="waiting_for_confirmation",le="120"} 1
+otf_organization_run_stage_duration_seconds_bucket{organization="acme",stage="waiting_for_confirmation",le="300"} 1
+otf_organization_run_stage_duration_seconds_bucket{organization="acme",stage="waiting_for_confirmation",le="600"} 1
+otf_organization_run_stage_duration_seconds_bucket{organization="acme",stage="waiting_for_confirmation",le="1800"} 1
+otf_organization_run_stage_duration_seconds_bucket{organization="acme",stage="waiting_for_confirmation",le="3600"} 1
+otf_organization_run_stage_duration_seconds_bucket{organization="acme",stage="waiting_for_confirmation",le="7200"} 1
+otf_organization_run_stage_duration_seconds_bucket{organization="acme",stage="waiting_for_confirmation",le="+Inf"} 1
+otf_organization_run_stage_duration_seconds_sum{organization="acme",stage="waiting_for_confirmation"} 60
+otf_organization_run_stage_duration_seconds_count{organization="acme",stage="waiting_for_confirmation"} 1
+`
+	err := testutil.CollectAndCompare(c, strings.NewReader(want), "otf_organization_run_stage_duration_seconds")
+	assert.NoError(t, err)
+}
+
+func TestConfig_Valid(t *testing.T) {
+	require.NoError(t, Config{Labels: DefaultLabels}.Valid())
+	assert.ErrorIs(t, Config{Labels: []string{"run"}}.Valid(), ErrInvalidLabel)
+}
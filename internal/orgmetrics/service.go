@@ -0,0 +1,104 @@
+package orgmetrics
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/leg100/otf/internal"
+	otfapi "github.com/leg100/otf/internal/api"
+	"github.com/leg100/otf/internal/http/decode"
+	"github.com/leg100/otf/internal/logr"
+	"github.com/leg100/otf/internal/organization"
+	"github.com/leg100/otf/internal/pubsub"
+	"github.com/leg100/otf/internal/rbac"
+	"github.com/leg100/otf/internal/run"
+	"github.com/leg100/otf/internal/tfeapi"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+type (
+	Service struct {
+		logr.Logger
+
+		organization internal.Authorizer
+
+		collector *Collector
+		runs      runClient
+	}
+
+	Options struct {
+		Config
+		logr.Logger
+
+		RunService *run.Service
+	}
+
+	runClient interface {
+		Watch(context.Context) (<-chan pubsub.Event[*run.Run], func())
+	}
+)
+
+func NewService(opts Options) *Service {
+	svc := Service{
+		Logger:       opts.Logger,
+		organization: &organization.Authorizer{Logger: opts.Logger},
+		collector:    NewCollector(opts.Config),
+		runs:         opts.RunService,
+	}
+	// Expose metrics for all organizations on the site-wide metrics endpoint.
+	// Only one collector can be registered per process, so subsequent
+	// collectors, i.e. when running several daemons in tests, are skipped.
+	if err := prometheus.Register(svc.collector); err != nil {
+		var are prometheus.AlreadyRegisteredError
+		if !errors.As(err, &are) {
+			panic(err)
+		}
+	}
+	return &svc
+}
+
+func (s *Service) AddHandlers(r *mux.Router) {
+	r = r.PathPrefix(otfapi.DefaultBasePath).Subrouter()
+
+	r.HandleFunc("/organizations/{organization_name}/metrics", s.getMetrics).Methods("GET")
+}
+
+// Start records metrics for runs as they finish. Should be invoked in a go
+// routine.
+func (s *Service) Start(ctx context.Context) error {
+	sub, unsub := s.runs.Watch(ctx)
+	defer unsub()
+
+	for event := range sub {
+		if event.Type != pubsub.UpdatedEvent {
+			continue
+		}
+		if event.Payload.Done() {
+			s.collector.record(event.Payload)
+		}
+	}
+	return pubsub.ErrSubscriptionTerminated
+}
+
+// getMetrics serves the metrics of an organization in the prometheus
+// exposition format.
+func (s *Service) getMetrics(w http.ResponseWriter, r *http.Request) {
+	org, err := decode.Param("organization_name", r)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	subject, err := s.organization.CanAccess(r.Context(), rbac.GetOrganizationMetricsAction, org)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	s.V(9).Info("retrieved organization metrics", "organization", org, "subject", subject)
+
+	registry := prometheus.NewRegistry()
+	registry.MustRegister(s.collector.forOrganization(org))
+	promhttp.HandlerFor(registry, promhttp.HandlerOpts{}).ServeHTTP(w, r)
+}
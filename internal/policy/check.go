@@ -0,0 +1,187 @@
+package policy
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/leg100/otf/internal"
+	"github.com/leg100/otf/internal/resource"
+	"github.com/leg100/otf/internal/run"
+)
+
+const (
+	CheckQueued     CheckStatus = "queued"
+	CheckPassed     CheckStatus = "passed"
+	CheckSoftFailed CheckStatus = "soft_failed"
+	CheckHardFailed CheckStatus = "hard_failed"
+	CheckOverridden CheckStatus = "overridden"
+	CheckErrored    CheckStatus = "errored"
+	CheckCanceled   CheckStatus = "canceled"
+
+	// AdvisoryEnforcement policies are reported when they fail but do not
+	// prevent a run from being applied.
+	AdvisoryEnforcement EnforcementLevel = "advisory"
+	// SoftMandatoryEnforcement policies prevent a run from being applied
+	// when they fail, unless the policy set is overridable and the failure is
+	// overridden.
+	SoftMandatoryEnforcement EnforcementLevel = "soft-mandatory"
+	// MandatoryEnforcement policies prevent a run from being applied when
+	// they fail.
+	MandatoryEnforcement EnforcementLevel = "mandatory"
+)
+
+var ErrCheckNotOverridable = errors.New("only a soft failed policy check can be overridden")
+
+type (
+	// Check is the evaluation of the policy sets enforced against a
+	// workspace, checking the plan of one of its runs.
+	Check struct {
+		ID           string
+		CreatedAt    time.Time
+		UpdatedAt    time.Time
+		RunID        string
+		Status       CheckStatus
+		Result       CheckResult
+		ErrorMessage string
+		// CompletedAt is the time at which the check completed, if it has.
+		CompletedAt *time.Time
+		// OverriddenAt is the time at which the check was overridden, if it
+		// has been.
+		OverriddenAt *time.Time
+	}
+
+	CheckStatus string
+
+	// CheckResult tallies the outcomes of the policies evaluated by a check.
+	CheckResult struct {
+		Passed         int
+		AdvisoryFailed int
+		SoftFailed     int
+		HardFailed     int
+		Outcomes       []PolicyOutcome
+	}
+
+	// PolicyOutcome is the outcome of evaluating a policy.
+	PolicyOutcome struct {
+		PolicySetName    string           `json:"policy_set_name"`
+		PolicyName       string           `json:"policy_name"`
+		EnforcementLevel EnforcementLevel `json:"enforcement_level"`
+		Passed           bool             `json:"passed"`
+		// Violations are messages from a failed policy explaining why it
+		// failed.
+		Violations []string `json:"violations,omitempty"`
+	}
+
+	// EnforcementLevel determines the consequences of a policy failing.
+	EnforcementLevel string
+
+	// Evaluator evaluates the policies in a policy set written in a
+	// particular policy language.
+	Evaluator interface {
+		Evaluate(ctx context.Context, input EvaluationInput) ([]PolicyOutcome, error)
+	}
+
+	// EvaluationInput is the input to an evaluator.
+	EvaluationInput struct {
+		Set *PolicySet
+		// Bundle is the gzipped tarball of policies in the current version
+		// of the policy set.
+		Bundle     []byte
+		Parameters []*Parameter
+		// Document is the document against which the policies are evaluated,
+		// comprising the plan alongside metadata about the run.
+		Document json.RawMessage
+	}
+)
+
+func newCheck(runID string) *Check {
+	check := &Check{
+		ID:        resource.NewID(resource.PolicyCheckKind),
+		CreatedAt: internal.CurrentTimestamp(nil),
+		RunID:     runID,
+		Status:    CheckQueued,
+	}
+	check.UpdatedAt = check.CreatedAt
+	return check
+}
+
+// TotalFailed is the number of policies that failed.
+func (r CheckResult) TotalFailed() int {
+	return r.AdvisoryFailed + r.SoftFailed + r.HardFailed
+}
+
+// add adds the outcomes of evaluating the policies in a policy set. Failures
+// of soft-mandatory policies in a policy set that is not overridable are
+// counted as hard failures.
+func (r *CheckResult) add(set *PolicySet, outcomes []PolicyOutcome) {
+	for _, outcome := range outcomes {
+		outcome.PolicySetName = set.Name
+		r.Outcomes = append(r.Outcomes, outcome)
+		switch {
+		case outcome.Passed:
+			r.Passed++
+		case outcome.EnforcementLevel == AdvisoryEnforcement:
+			r.AdvisoryFailed++
+		case outcome.EnforcementLevel == SoftMandatoryEnforcement && set.Overridable:
+			r.SoftFailed++
+		default:
+			r.HardFailed++
+		}
+	}
+}
+
+// complete completes the check with the result of evaluating its policy sets,
+// returning the outcome for its run.
+func (c *Check) complete(result CheckResult) run.PolicyCheckOutcome {
+	c.Result = result
+	switch {
+	case result.HardFailed > 0:
+		c.setStatus(CheckHardFailed)
+		return run.PolicyCheckHardFailed
+	case result.SoftFailed > 0:
+		c.setStatus(CheckSoftFailed)
+		return run.PolicyCheckSoftFailed
+	default:
+		c.setStatus(CheckPassed)
+		return run.PolicyCheckPassed
+	}
+}
+
+// fail completes the check with an error, e.g. a policy could not be
+// evaluated. An errored check fails its run.
+func (c *Check) fail(err error) run.PolicyCheckOutcome {
+	c.ErrorMessage = err.Error()
+	c.setStatus(CheckErrored)
+	return run.PolicyCheckHardFailed
+}
+
+func (c *Check) override() error {
+	if c.Status != CheckSoftFailed {
+		return ErrCheckNotOverridable
+	}
+	c.Status = CheckOverridden
+	c.UpdatedAt = internal.CurrentTimestamp(nil)
+	c.OverriddenAt = internal.Time(c.UpdatedAt)
+	return nil
+}
+
+func (c *Check) cancel() {
+	c.setStatus(CheckCanceled)
+}
+
+func (c *Check) setStatus(status CheckStatus) {
+	c.Status = status
+	c.UpdatedAt = internal.CurrentTimestamp(nil)
+	c.CompletedAt = internal.Time(c.UpdatedAt)
+}
+
+func (c *Check) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.String("id", c.ID),
+		slog.String("run_id", c.RunID),
+		slog.String("status", string(c.Status)),
+	)
+}
@@ -0,0 +1,74 @@
+package policy
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/leg100/otf/internal/run"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheck(t *testing.T) {
+	t.Run("passed", func(t *testing.T) {
+		check := newCheck("run-123")
+
+		outcome := check.complete(CheckResult{Passed: 2, AdvisoryFailed: 1})
+		assert.Equal(t, run.PolicyCheckPassed, outcome)
+		assert.Equal(t, CheckPassed, check.Status)
+		assert.NotNil(t, check.CompletedAt)
+	})
+
+	t.Run("soft failed and overridden", func(t *testing.T) {
+		check := newCheck("run-123")
+
+		outcome := check.complete(CheckResult{Passed: 1, SoftFailed: 1})
+		assert.Equal(t, run.PolicyCheckSoftFailed, outcome)
+		assert.Equal(t, CheckSoftFailed, check.Status)
+
+		require.NoError(t, check.override())
+		assert.Equal(t, CheckOverridden, check.Status)
+		assert.NotNil(t, check.OverriddenAt)
+	})
+
+	t.Run("hard failed", func(t *testing.T) {
+		check := newCheck("run-123")
+
+		outcome := check.complete(CheckResult{SoftFailed: 1, HardFailed: 1})
+		assert.Equal(t, run.PolicyCheckHardFailed, outcome)
+		assert.Equal(t, CheckHardFailed, check.Status)
+		assert.ErrorIs(t, check.override(), ErrCheckNotOverridable)
+	})
+
+	t.Run("errored", func(t *testing.T) {
+		check := newCheck("run-123")
+
+		outcome := check.fail(errors.New("invalid policy"))
+		assert.Equal(t, run.PolicyCheckHardFailed, outcome)
+		assert.Equal(t, CheckErrored, check.Status)
+		assert.Equal(t, "invalid policy", check.ErrorMessage)
+	})
+}
+
+func TestCheckResult_Add(t *testing.T) {
+	var result CheckResult
+	result.add(&PolicySet{Name: "overridable", Overridable: true}, []PolicyOutcome{
+		{PolicyName: "a", EnforcementLevel: MandatoryEnforcement, Passed: true},
+		{PolicyName: "b", EnforcementLevel: SoftMandatoryEnforcement},
+	})
+	result.add(&PolicySet{Name: "strict"}, []PolicyOutcome{
+		{PolicyName: "c", EnforcementLevel: AdvisoryEnforcement},
+		{PolicyName: "d", EnforcementLevel: SoftMandatoryEnforcement},
+		{PolicyName: "e", EnforcementLevel: MandatoryEnforcement},
+	})
+
+	assert.Equal(t, 1, result.Passed)
+	assert.Equal(t, 1, result.AdvisoryFailed)
+	assert.Equal(t, 1, result.SoftFailed)
+	assert.Equal(t, 2, result.HardFailed)
+	assert.Equal(t, 4, result.TotalFailed())
+	if assert.Len(t, result.Outcomes, 5) {
+		assert.Equal(t, "overridable", result.Outcomes[0].PolicySetName)
+		assert.Equal(t, "strict", result.Outcomes[4].PolicySetName)
+	}
+}
@@ -0,0 +1,70 @@
+package policy
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-logr/logr"
+)
+
+// CheckerLockID guarantees only one checker on a cluster is running at any
+// time.
+const CheckerLockID int64 = 3916589616287113937
+
+var defaultCheckerInterval = 5 * time.Second
+
+// Checker evaluates queued policy checks.
+//
+// Only one checker should be running on an OTF cluster at any one time.
+type Checker struct {
+	logr.Logger
+
+	svc *Service
+	// frequency with which the checker checks for queued checks.
+	interval time.Duration
+}
+
+// NewChecker constructs a checker of policies.
+func (s *Service) NewChecker(logger logr.Logger) *Checker {
+	return &Checker{
+		Logger:   logger.WithValues("component", "policy-checker"),
+		svc:      s,
+		interval: defaultCheckerInterval,
+	}
+}
+
+func (c *Checker) String() string { return "policy-checker" }
+
+// Start the checker. Every interval queued checks are evaluated.
+//
+// Should be invoked in a go routine.
+func (c *Checker) Start(ctx context.Context) error {
+	ticker := time.NewTicker(c.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			if err := c.check(ctx); err != nil {
+				return err
+			}
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
+
+func (c *Checker) check(ctx context.Context) error {
+	checks, err := c.svc.db.listQueuedChecks(ctx)
+	if err != nil {
+		return err
+	}
+	for _, check := range checks {
+		evaluated, err := c.svc.evaluateCheck(ctx, check.ID)
+		if err != nil {
+			c.Error(err, "evaluating policy check", "check", check)
+			continue
+		}
+		c.V(1).Info("evaluated policy check", "check", evaluated)
+	}
+	return nil
+}
@@ -0,0 +1,479 @@
+package policy
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/jackc/pgtype"
+	"github.com/leg100/otf/internal"
+	"github.com/leg100/otf/internal/sql"
+	"github.com/leg100/otf/internal/sql/pggen"
+)
+
+type (
+	// pgdb is a database of policy sets on postgres
+	pgdb struct {
+		*sql.DB // provides access to generated SQL queries
+	}
+
+	// pgresult is the result of a database query for a policy set.
+	pgresult struct {
+		PolicySetID      pgtype.Text        `json:"policy_set_id"`
+		CreatedAt        pgtype.Timestamptz `json:"created_at"`
+		UpdatedAt        pgtype.Timestamptz `json:"updated_at"`
+		Name             pgtype.Text        `json:"name"`
+		Description      pgtype.Text        `json:"description"`
+		Kind             pgtype.Text        `json:"kind"`
+		Overridable      pgtype.Bool        `json:"overridable"`
+		Global           pgtype.Bool        `json:"global"`
+		OrganizationName pgtype.Text        `json:"organization_name"`
+		WorkspaceIds     []string           `json:"workspace_ids"`
+		NewestVersionID  pgtype.Text        `json:"newest_version_id"`
+		CurrentVersionID pgtype.Text        `json:"current_version_id"`
+		PolicyCount      pgtype.Int4        `json:"policy_count"`
+	}
+
+	parameterResult struct {
+		PolicySetParameterID pgtype.Text `json:"policy_set_parameter_id"`
+		Key                  pgtype.Text `json:"key"`
+		Value                pgtype.Text `json:"value"`
+		Sensitive            pgtype.Bool `json:"sensitive"`
+		PolicySetID          pgtype.Text `json:"policy_set_id"`
+	}
+
+	versionResult struct {
+		PolicySetVersionID pgtype.Text        `json:"policy_set_version_id"`
+		CreatedAt          pgtype.Timestamptz `json:"created_at"`
+		UpdatedAt          pgtype.Timestamptz `json:"updated_at"`
+		Status             pgtype.Text        `json:"status"`
+		ErrorMessage       pgtype.Text        `json:"error_message"`
+		PolicyCount        pgtype.Int4        `json:"policy_count"`
+		PolicySetID        pgtype.Text        `json:"policy_set_id"`
+	}
+
+	checkResult struct {
+		PolicyCheckID  pgtype.Text        `json:"policy_check_id"`
+		CreatedAt      pgtype.Timestamptz `json:"created_at"`
+		UpdatedAt      pgtype.Timestamptz `json:"updated_at"`
+		Status         pgtype.Text        `json:"status"`
+		Passed         pgtype.Int4        `json:"passed"`
+		AdvisoryFailed pgtype.Int4        `json:"advisory_failed"`
+		SoftFailed     pgtype.Int4        `json:"soft_failed"`
+		HardFailed     pgtype.Int4        `json:"hard_failed"`
+		ErrorMessage   pgtype.Text        `json:"error_message"`
+		CompletedAt    pgtype.Timestamptz `json:"completed_at"`
+		OverriddenAt   pgtype.Timestamptz `json:"overridden_at"`
+		RunID          pgtype.Text        `json:"run_id"`
+		Outcomes       []byte             `json:"outcomes"`
+	}
+)
+
+func (r pgresult) toPolicySet() *PolicySet {
+	set := &PolicySet{
+		ID:           r.PolicySetID.String,
+		CreatedAt:    r.CreatedAt.Time.UTC(),
+		UpdatedAt:    r.UpdatedAt.Time.UTC(),
+		Name:         r.Name.String,
+		Description:  r.Description.String,
+		Kind:         Kind(r.Kind.String),
+		Organization: r.OrganizationName.String,
+		Overridable:  r.Overridable.Bool,
+		Global:       r.Global.Bool,
+		Workspaces:   r.WorkspaceIds,
+		PolicyCount:  int(r.PolicyCount.Int),
+	}
+	if r.NewestVersionID.Status == pgtype.Present {
+		set.NewestVersionID = &r.NewestVersionID.String
+	}
+	if r.CurrentVersionID.Status == pgtype.Present {
+		set.CurrentVersionID = &r.CurrentVersionID.String
+	}
+	return set
+}
+
+func (r parameterResult) toParameter() *Parameter {
+	return &Parameter{
+		ID:          r.PolicySetParameterID.String,
+		Key:         r.Key.String,
+		Value:       r.Value.String,
+		Sensitive:   r.Sensitive.Bool,
+		PolicySetID: r.PolicySetID.String,
+	}
+}
+
+func (r versionResult) toVersion() *Version {
+	return &Version{
+		ID:           r.PolicySetVersionID.String,
+		CreatedAt:    r.CreatedAt.Time.UTC(),
+		UpdatedAt:    r.UpdatedAt.Time.UTC(),
+		Status:       VersionStatus(r.Status.String),
+		ErrorMessage: r.ErrorMessage.String,
+		PolicyCount:  int(r.PolicyCount.Int),
+		PolicySetID:  r.PolicySetID.String,
+	}
+}
+
+func (r checkResult) toCheck() (*Check, error) {
+	check := &Check{
+		ID:        r.PolicyCheckID.String,
+		CreatedAt: r.CreatedAt.Time.UTC(),
+		UpdatedAt: r.UpdatedAt.Time.UTC(),
+		RunID:     r.RunID.String,
+		Status:    CheckStatus(r.Status.String),
+		Result: CheckResult{
+			Passed:         int(r.Passed.Int),
+			AdvisoryFailed: int(r.AdvisoryFailed.Int),
+			SoftFailed:     int(r.SoftFailed.Int),
+			HardFailed:     int(r.HardFailed.Int),
+		},
+		ErrorMessage: r.ErrorMessage.String,
+	}
+	if r.CompletedAt.Status == pgtype.Present {
+		check.CompletedAt = internal.Time(r.CompletedAt.Time.UTC())
+	}
+	if r.OverriddenAt.Status == pgtype.Present {
+		check.OverriddenAt = internal.Time(r.OverriddenAt.Time.UTC())
+	}
+	if err := json.Unmarshal(r.Outcomes, &check.Result.Outcomes); err != nil {
+		return nil, err
+	}
+	return check, nil
+}
+
+func (db *pgdb) create(ctx context.Context, set *PolicySet) error {
+	return db.Tx(ctx, func(ctx context.Context, q pggen.Querier) error {
+		_, err := q.InsertPolicySet(ctx, pggen.InsertPolicySetParams{
+			PolicySetID:      sql.String(set.ID),
+			CreatedAt:        sql.Timestamptz(set.CreatedAt),
+			UpdatedAt:        sql.Timestamptz(set.UpdatedAt),
+			Name:             sql.String(set.Name),
+			Description:      sql.String(set.Description),
+			Kind:             sql.String(string(set.Kind)),
+			Overridable:      sql.Bool(set.Overridable),
+			Global:           sql.Bool(set.Global),
+			OrganizationName: sql.String(set.Organization),
+		})
+		if err != nil {
+			return sql.Error(err)
+		}
+		for _, workspaceID := range set.Workspaces {
+			if _, err := q.InsertPolicySetWorkspace(ctx, sql.String(set.ID), sql.String(workspaceID)); err != nil {
+				return sql.Error(err)
+			}
+		}
+		return nil
+	})
+}
+
+func (db *pgdb) update(ctx context.Context, id string, updateFunc func(*PolicySet) error) (*PolicySet, error) {
+	var set *PolicySet
+	err := db.Tx(ctx, func(ctx context.Context, q pggen.Querier) error {
+		row, err := q.FindPolicySetByIDForUpdate(ctx, sql.String(id))
+		if err != nil {
+			return sql.Error(err)
+		}
+		set = pgresult(row).toPolicySet()
+		if err := updateFunc(set); err != nil {
+			return err
+		}
+		_, err = q.UpdatePolicySetByID(ctx, pggen.UpdatePolicySetByIDParams{
+			PolicySetID: sql.String(set.ID),
+			UpdatedAt:   sql.Timestamptz(set.UpdatedAt),
+			Name:        sql.String(set.Name),
+			Description: sql.String(set.Description),
+			Overridable: sql.Bool(set.Overridable),
+			Global:      sql.Bool(set.Global),
+		})
+		return sql.Error(err)
+	})
+	return set, err
+}
+
+func (db *pgdb) list(ctx context.Context, organization string, opts ListOptions) ([]*PolicySet, error) {
+	params := pggen.FindPolicySetsByOrganizationParams{
+		OrganizationName: sql.String(organization),
+		NameSubstring:    sql.StringPtr(opts.Search),
+		Kind:             sql.NullString(),
+	}
+	if opts.Kind != nil {
+		params.Kind = sql.String(string(*opts.Kind))
+	}
+	rows, err := db.Conn(ctx).FindPolicySetsByOrganization(ctx, params)
+	if err != nil {
+		return nil, sql.Error(err)
+	}
+	sets := make([]*PolicySet, len(rows))
+	for i, r := range rows {
+		sets[i] = pgresult(r).toPolicySet()
+	}
+	return sets, nil
+}
+
+func (db *pgdb) listByWorkspace(ctx context.Context, workspaceID string) ([]*PolicySet, error) {
+	rows, err := db.Conn(ctx).FindPolicySetsByWorkspaceID(ctx, sql.String(workspaceID))
+	if err != nil {
+		return nil, sql.Error(err)
+	}
+	sets := make([]*PolicySet, len(rows))
+	for i, r := range rows {
+		sets[i] = pgresult(r).toPolicySet()
+	}
+	return sets, nil
+}
+
+func (db *pgdb) get(ctx context.Context, id string) (*PolicySet, error) {
+	row, err := db.Conn(ctx).FindPolicySetByID(ctx, sql.String(id))
+	if err != nil {
+		return nil, sql.Error(err)
+	}
+	return pgresult(row).toPolicySet(), nil
+}
+
+func (db *pgdb) delete(ctx context.Context, id string) error {
+	_, err := db.Conn(ctx).DeletePolicySetByID(ctx, sql.String(id))
+	return sql.Error(err)
+}
+
+func (db *pgdb) addWorkspaces(ctx context.Context, id string, workspaceIDs []string) error {
+	return db.Tx(ctx, func(ctx context.Context, q pggen.Querier) error {
+		for _, workspaceID := range workspaceIDs {
+			if _, err := q.InsertPolicySetWorkspace(ctx, sql.String(id), sql.String(workspaceID)); err != nil {
+				return sql.Error(err)
+			}
+		}
+		return nil
+	})
+}
+
+func (db *pgdb) removeWorkspaces(ctx context.Context, id string, workspaceIDs []string) error {
+	return db.Tx(ctx, func(ctx context.Context, q pggen.Querier) error {
+		for _, workspaceID := range workspaceIDs {
+			if _, err := q.DeletePolicySetWorkspace(ctx, sql.String(id), sql.String(workspaceID)); err != nil {
+				return sql.Error(err)
+			}
+		}
+		return nil
+	})
+}
+
+func (db *pgdb) createParameter(ctx context.Context, p *Parameter) error {
+	_, err := db.Conn(ctx).InsertPolicySetParameter(ctx, pggen.InsertPolicySetParameterParams{
+		PolicySetParameterID: sql.String(p.ID),
+		Key:                  sql.String(p.Key),
+		Value:                sql.String(p.Value),
+		Sensitive:            sql.Bool(p.Sensitive),
+		PolicySetID:          sql.String(p.PolicySetID),
+	})
+	return sql.Error(err)
+}
+
+func (db *pgdb) updateParameter(ctx context.Context, id string, updateFunc func(*Parameter) error) (*Parameter, error) {
+	var p *Parameter
+	err := db.Tx(ctx, func(ctx context.Context, q pggen.Querier) error {
+		row, err := q.FindPolicySetParameterByIDForUpdate(ctx, sql.String(id))
+		if err != nil {
+			return sql.Error(err)
+		}
+		p = parameterResult(row).toParameter()
+		if err := updateFunc(p); err != nil {
+			return err
+		}
+		_, err = q.UpdatePolicySetParameterByID(ctx, pggen.UpdatePolicySetParameterByIDParams{
+			PolicySetParameterID: sql.String(p.ID),
+			Key:                  sql.String(p.Key),
+			Value:                sql.String(p.Value),
+			Sensitive:            sql.Bool(p.Sensitive),
+		})
+		return sql.Error(err)
+	})
+	return p, err
+}
+
+func (db *pgdb) listParameters(ctx context.Context, policySetID string) ([]*Parameter, error) {
+	rows, err := db.Conn(ctx).FindPolicySetParameters(ctx, sql.String(policySetID))
+	if err != nil {
+		return nil, sql.Error(err)
+	}
+	params := make([]*Parameter, len(rows))
+	for i, r := range rows {
+		params[i] = parameterResult(r).toParameter()
+	}
+	return params, nil
+}
+
+func (db *pgdb) getParameter(ctx context.Context, id string) (*Parameter, error) {
+	row, err := db.Conn(ctx).FindPolicySetParameterByID(ctx, sql.String(id))
+	if err != nil {
+		return nil, sql.Error(err)
+	}
+	return parameterResult(row).toParameter(), nil
+}
+
+func (db *pgdb) deleteParameter(ctx context.Context, id string) error {
+	_, err := db.Conn(ctx).DeletePolicySetParameterByID(ctx, sql.String(id))
+	return sql.Error(err)
+}
+
+func (db *pgdb) createVersion(ctx context.Context, v *Version) error {
+	_, err := db.Conn(ctx).InsertPolicySetVersion(ctx, pggen.InsertPolicySetVersionParams{
+		PolicySetVersionID: sql.String(v.ID),
+		CreatedAt:          sql.Timestamptz(v.CreatedAt),
+		UpdatedAt:          sql.Timestamptz(v.UpdatedAt),
+		Status:             sql.String(string(v.Status)),
+		ErrorMessage:       sql.String(v.ErrorMessage),
+		PolicyCount:        sql.Int4(v.PolicyCount),
+		PolicySetID:        sql.String(v.PolicySetID),
+	})
+	return sql.Error(err)
+}
+
+func (db *pgdb) getVersion(ctx context.Context, id string) (*Version, error) {
+	row, err := db.Conn(ctx).FindPolicySetVersionByID(ctx, sql.String(id))
+	if err != nil {
+		return nil, sql.Error(err)
+	}
+	return versionResult(row).toVersion(), nil
+}
+
+// uploadVersion uploads a bundle of policies to a version, saving the bundle
+// only if the version is deemed ready.
+func (db *pgdb) uploadVersion(ctx context.Context, id string, bundle []byte, uploadFunc func(*Version) error) (*Version, error) {
+	var v *Version
+	err := db.Tx(ctx, func(ctx context.Context, q pggen.Querier) error {
+		row, err := q.FindPolicySetVersionByIDForUpdate(ctx, sql.String(id))
+		if err != nil {
+			return sql.Error(err)
+		}
+		v = versionResult(row).toVersion()
+		if err := uploadFunc(v); err != nil {
+			return err
+		}
+		params := pggen.UpdatePolicySetVersionByIDParams{
+			PolicySetVersionID: sql.String(v.ID),
+			UpdatedAt:          sql.Timestamptz(v.UpdatedAt),
+			Status:             sql.String(string(v.Status)),
+			ErrorMessage:       sql.String(v.ErrorMessage),
+			PolicyCount:        sql.Int4(v.PolicyCount),
+		}
+		if v.Status == VersionReady {
+			params.Bundle = bundle
+		}
+		_, err = q.UpdatePolicySetVersionByID(ctx, params)
+		return sql.Error(err)
+	})
+	return v, err
+}
+
+func (db *pgdb) downloadVersion(ctx context.Context, id string) ([]byte, error) {
+	bundle, err := db.Conn(ctx).DownloadPolicySetVersion(ctx, sql.String(id))
+	if err != nil {
+		return nil, sql.Error(err)
+	}
+	return bundle, nil
+}
+
+func (db *pgdb) createCheck(ctx context.Context, c *Check) error {
+	outcomes, err := marshalOutcomes(c.Result.Outcomes)
+	if err != nil {
+		return err
+	}
+	_, err = db.Conn(ctx).InsertPolicyCheck(ctx, pggen.InsertPolicyCheckParams{
+		PolicyCheckID:  sql.String(c.ID),
+		CreatedAt:      sql.Timestamptz(c.CreatedAt),
+		UpdatedAt:      sql.Timestamptz(c.UpdatedAt),
+		Status:         sql.String(string(c.Status)),
+		Passed:         sql.Int4(c.Result.Passed),
+		AdvisoryFailed: sql.Int4(c.Result.AdvisoryFailed),
+		SoftFailed:     sql.Int4(c.Result.SoftFailed),
+		HardFailed:     sql.Int4(c.Result.HardFailed),
+		ErrorMessage:   sql.String(c.ErrorMessage),
+		Outcomes:       outcomes,
+		RunID:          sql.String(c.RunID),
+	})
+	return sql.Error(err)
+}
+
+// updateCheck updates a policy check, invoking fn within the same transaction
+// in which the check is locked for update.
+func (db *pgdb) updateCheck(ctx context.Context, id string, fn func(context.Context, *Check) error) (*Check, error) {
+	var c *Check
+	err := db.Tx(ctx, func(ctx context.Context, q pggen.Querier) error {
+		row, err := q.FindPolicyCheckByIDForUpdate(ctx, sql.String(id))
+		if err != nil {
+			return sql.Error(err)
+		}
+		c, err = checkResult(row).toCheck()
+		if err != nil {
+			return err
+		}
+		if err := fn(ctx, c); err != nil {
+			return err
+		}
+		outcomes, err := marshalOutcomes(c.Result.Outcomes)
+		if err != nil {
+			return err
+		}
+		_, err = q.UpdatePolicyCheck(ctx, pggen.UpdatePolicyCheckParams{
+			PolicyCheckID:  sql.String(c.ID),
+			Status:         sql.String(string(c.Status)),
+			Passed:         sql.Int4(c.Result.Passed),
+			AdvisoryFailed: sql.Int4(c.Result.AdvisoryFailed),
+			SoftFailed:     sql.Int4(c.Result.SoftFailed),
+			HardFailed:     sql.Int4(c.Result.HardFailed),
+			ErrorMessage:   sql.String(c.ErrorMessage),
+			Outcomes:       outcomes,
+			CompletedAt:    sql.TimestamptzPtr(c.CompletedAt),
+			OverriddenAt:   sql.TimestamptzPtr(c.OverriddenAt),
+			UpdatedAt:      sql.Timestamptz(c.UpdatedAt),
+		})
+		return sql.Error(err)
+	})
+	return c, err
+}
+
+func (db *pgdb) listChecks(ctx context.Context, runID string) ([]*Check, error) {
+	rows, err := db.Conn(ctx).FindPolicyChecksByRunID(ctx, sql.String(runID))
+	if err != nil {
+		return nil, sql.Error(err)
+	}
+	checks := make([]*Check, len(rows))
+	for i, r := range rows {
+		checks[i], err = checkResult(r).toCheck()
+		if err != nil {
+			return nil, err
+		}
+	}
+	return checks, nil
+}
+
+func (db *pgdb) listQueuedChecks(ctx context.Context) ([]*Check, error) {
+	rows, err := db.Conn(ctx).FindQueuedPolicyChecks(ctx)
+	if err != nil {
+		return nil, sql.Error(err)
+	}
+	checks := make([]*Check, len(rows))
+	for i, r := range rows {
+		checks[i], err = checkResult(r).toCheck()
+		if err != nil {
+			return nil, err
+		}
+	}
+	return checks, nil
+}
+
+func (db *pgdb) getCheck(ctx context.Context, id string) (*Check, error) {
+	row, err := db.Conn(ctx).FindPolicyCheckByID(ctx, sql.String(id))
+	if err != nil {
+		return nil, sql.Error(err)
+	}
+	return checkResult(row).toCheck()
+}
+
+// marshalOutcomes marshals policy outcomes for persisting, marshaling nil
+// outcomes as an empty list.
+func marshalOutcomes(outcomes []PolicyOutcome) ([]byte, error) {
+	if outcomes == nil {
+		outcomes = []PolicyOutcome{}
+	}
+	return json.Marshal(outcomes)
+}
@@ -0,0 +1,208 @@
+package policy
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+
+	"github.com/hashicorp/hcl/v2/hclsimple"
+	"github.com/leg100/otf/internal"
+)
+
+// opaConfigFile is the file in a bundle of OPA policies that declares the
+// policies to evaluate, e.g.:
+//
+//	policy "deny-public-buckets" {
+//	  query             = "data.terraform.policies.public_buckets.deny"
+//	  enforcement_level = "mandatory"
+//	}
+const opaConfigFile = "policies.hcl"
+
+var ErrOPAConfigNotFound = fmt.Errorf("policy bundle does not contain %s", opaConfigFile)
+
+type (
+	// OPAEvaluator evaluates Open Policy Agent policies, written in Rego,
+	// using the opa binary.
+	//
+	// Each policy declared in a bundle's policies.hcl has a query, which is
+	// evaluated against the plan. The query should evaluate to a set of
+	// violation messages; the policy fails if the set is non-empty.
+	OPAEvaluator struct {
+		// Path to the opa binary
+		binary string
+		// exec executes the opa binary, returning its output.
+		exec func(ctx context.Context, name string, args ...string) ([]byte, error)
+	}
+
+	opaConfig struct {
+		Policies []opaPolicy `hcl:"policy,block"`
+	}
+
+	opaPolicy struct {
+		Name             string  `hcl:"name,label"`
+		Query            string  `hcl:"query"`
+		EnforcementLevel *string `hcl:"enforcement_level,optional"`
+		Description      *string `hcl:"description,optional"`
+	}
+
+	// opaOutput is the output of `opa eval --format json`.
+	opaOutput struct {
+		Result []struct {
+			Expressions []struct {
+				Value json.RawMessage `json:"value"`
+			} `json:"expressions"`
+		} `json:"result"`
+	}
+)
+
+// NewOPAEvaluator constructs an evaluator of OPA policies using the opa binary
+// at the given path.
+func NewOPAEvaluator(binary string) *OPAEvaluator {
+	return &OPAEvaluator{binary: binary, exec: execOPA}
+}
+
+// Evaluate evaluates the policies in the bundle against the document. Policy
+// set parameters are available to policies as data.parameters.
+func (e *OPAEvaluator) Evaluate(ctx context.Context, input EvaluationInput) ([]PolicyOutcome, error) {
+	dir, err := os.MkdirTemp("", "otf-policies-")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(dir)
+
+	bundleDir := filepath.Join(dir, "bundle")
+	if err := internal.Unpack(bytes.NewReader(input.Bundle), bundleDir); err != nil {
+		return nil, fmt.Errorf("unpacking policy bundle: %w", err)
+	}
+	config, err := loadOPAConfig(bundleDir)
+	if err != nil {
+		return nil, err
+	}
+	// write parameters and the document to files from which opa loads them.
+	dataDir := filepath.Join(dir, "data")
+	if err := writeOPAParameters(dataDir, input.Parameters); err != nil {
+		return nil, err
+	}
+	inputPath := filepath.Join(dir, "input.json")
+	if err := os.WriteFile(inputPath, input.Document, 0o600); err != nil {
+		return nil, err
+	}
+
+	outcomes := make([]PolicyOutcome, len(config.Policies))
+	for i, policy := range config.Policies {
+		level, err := policy.enforcementLevel()
+		if err != nil {
+			return nil, err
+		}
+		out, err := e.exec(ctx, e.binary, "eval",
+			"--format", "json",
+			"--bundle", bundleDir,
+			"--data", dataDir,
+			"--input", inputPath,
+			policy.Query,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("evaluating policy %s: %w", policy.Name, err)
+		}
+		violations, err := parseOPAViolations(out)
+		if err != nil {
+			return nil, fmt.Errorf("evaluating policy %s: %w", policy.Name, err)
+		}
+		outcomes[i] = PolicyOutcome{
+			PolicyName:       policy.Name,
+			EnforcementLevel: level,
+			Passed:           len(violations) == 0,
+			Violations:       violations,
+		}
+	}
+	return outcomes, nil
+}
+
+func (p opaPolicy) enforcementLevel() (EnforcementLevel, error) {
+	if p.EnforcementLevel == nil {
+		return AdvisoryEnforcement, nil
+	}
+	switch level := EnforcementLevel(*p.EnforcementLevel); level {
+	case AdvisoryEnforcement, SoftMandatoryEnforcement, MandatoryEnforcement:
+		return level, nil
+	default:
+		return "", fmt.Errorf("policy %s: invalid enforcement level: %s", p.Name, level)
+	}
+}
+
+func loadOPAConfig(bundleDir string) (*opaConfig, error) {
+	path := filepath.Join(bundleDir, opaConfigFile)
+	src, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, ErrOPAConfigNotFound
+	} else if err != nil {
+		return nil, err
+	}
+	var config opaConfig
+	if err := hclsimple.Decode(opaConfigFile, src, nil, &config); err != nil {
+		return nil, err
+	}
+	return &config, nil
+}
+
+// writeOPAParameters writes parameters to a data file in the directory,
+// which opa loads into data.parameters.
+func writeOPAParameters(dir string, params []*Parameter) error {
+	values := make(map[string]string, len(params))
+	for _, p := range params {
+		values[p.Key] = p.Value
+	}
+	data, err := json.Marshal(values)
+	if err != nil {
+		return err
+	}
+	paramsDir := filepath.Join(dir, "parameters")
+	if err := os.MkdirAll(paramsDir, 0o700); err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(paramsDir, "data.json"), data, 0o600)
+}
+
+// parseOPAViolations parses the output of evaluating a policy's query,
+// returning the violation messages. An undefined query has no violations.
+// Messages that are not strings are converted to JSON.
+func parseOPAViolations(out []byte) ([]string, error) {
+	var output opaOutput
+	if err := json.Unmarshal(out, &output); err != nil {
+		return nil, fmt.Errorf("parsing opa output: %w", err)
+	}
+	var violations []string
+	for _, result := range output.Result {
+		for _, expr := range result.Expressions {
+			var values []json.RawMessage
+			if err := json.Unmarshal(expr.Value, &values); err != nil {
+				return nil, errors.New("query must evaluate to a set or array of violations")
+			}
+			for _, v := range values {
+				var msg string
+				if err := json.Unmarshal(v, &msg); err != nil {
+					msg = string(v)
+				}
+				violations = append(violations, msg)
+			}
+		}
+	}
+	return violations, nil
+}
+
+func execOPA(ctx context.Context, name string, args ...string) ([]byte, error) {
+	var stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, name, args...)
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		// opa reports evaluation errors on stdout and other errors on stderr
+		return nil, fmt.Errorf("%w: %s%s", err, bytes.TrimSpace(out), bytes.TrimSpace(stderr.Bytes()))
+	}
+	return out, nil
+}
@@ -0,0 +1,130 @@
+package policy
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/leg100/otf/internal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOPAEvaluator(t *testing.T) {
+	ctx := context.Background()
+
+	newBundle := func(t *testing.T, config string) []byte {
+		dir := t.TempDir()
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "main.rego"), []byte("package terraform"), 0o644))
+		if config != "" {
+			require.NoError(t, os.WriteFile(filepath.Join(dir, opaConfigFile), []byte(config), 0o644))
+		}
+		bundle, err := internal.Pack(dir)
+		require.NoError(t, err)
+		return bundle
+	}
+
+	// newEvaluator returns an evaluator that responds to each query with the
+	// given output.
+	newEvaluator := func(outputs map[string]string) *OPAEvaluator {
+		return &OPAEvaluator{
+			binary: "opa",
+			exec: func(_ context.Context, _ string, args ...string) ([]byte, error) {
+				query := args[len(args)-1]
+				return []byte(outputs[query]), nil
+			},
+		}
+	}
+
+	t.Run("enforcement levels and violations", func(t *testing.T) {
+		bundle := newBundle(t, `
+policy "tags" {
+  query = "data.terraform.tags.deny"
+}
+
+policy "regions" {
+  query             = "data.terraform.regions.deny"
+  enforcement_level = "soft-mandatory"
+}
+
+policy "public" {
+  query             = "data.terraform.public.deny"
+  enforcement_level = "mandatory"
+}
+`)
+		evaluator := newEvaluator(map[string]string{
+			"data.terraform.tags.deny":    `{"result":[{"expressions":[{"value":["bucket is untagged"]}]}]}`,
+			"data.terraform.regions.deny": `{"result":[{"expressions":[{"value":[]}]}]}`,
+			"data.terraform.public.deny":  `{"result":[{"expressions":[{"value":["bucket is public",{"address":"aws_s3_bucket.b"}]}]}]}`,
+		})
+
+		got, err := evaluator.Evaluate(ctx, EvaluationInput{Bundle: bundle, Document: json.RawMessage(`{}`)})
+		require.NoError(t, err)
+
+		assert.Equal(t, []PolicyOutcome{
+			{
+				PolicyName:       "tags",
+				EnforcementLevel: AdvisoryEnforcement,
+				Violations:       []string{"bucket is untagged"},
+			},
+			{
+				PolicyName:       "regions",
+				EnforcementLevel: SoftMandatoryEnforcement,
+				Passed:           true,
+			},
+			{
+				PolicyName:       "public",
+				EnforcementLevel: MandatoryEnforcement,
+				Violations:       []string{"bucket is public", `{"address":"aws_s3_bucket.b"}`},
+			},
+		}, got)
+	})
+
+	t.Run("undefined query passes", func(t *testing.T) {
+		bundle := newBundle(t, `
+policy "tags" {
+  query = "data.terraform.tags.deny"
+}
+`)
+		evaluator := newEvaluator(map[string]string{"data.terraform.tags.deny": `{}`})
+
+		got, err := evaluator.Evaluate(ctx, EvaluationInput{Bundle: bundle, Document: json.RawMessage(`{}`)})
+		require.NoError(t, err)
+		require.Len(t, got, 1)
+		assert.True(t, got[0].Passed)
+	})
+
+	t.Run("invalid enforcement level", func(t *testing.T) {
+		bundle := newBundle(t, `
+policy "tags" {
+  query             = "data.terraform.tags.deny"
+  enforcement_level = "hard-mandatory"
+}
+`)
+		_, err := newEvaluator(nil).Evaluate(ctx, EvaluationInput{Bundle: bundle, Document: json.RawMessage(`{}`)})
+		assert.EqualError(t, err, "policy tags: invalid enforcement level: hard-mandatory")
+	})
+
+	t.Run("query not evaluating to violations", func(t *testing.T) {
+		bundle := newBundle(t, `
+policy "tags" {
+  query = "data.terraform.tags.allow"
+}
+`)
+		evaluator := newEvaluator(map[string]string{
+			"data.terraform.tags.allow": `{"result":[{"expressions":[{"value":true}]}]}`,
+		})
+
+		_, err := evaluator.Evaluate(ctx, EvaluationInput{Bundle: bundle, Document: json.RawMessage(`{}`)})
+		assert.EqualError(t, err, "evaluating policy tags: query must evaluate to a set or array of violations")
+	})
+
+	t.Run("missing config", func(t *testing.T) {
+		bundle := newBundle(t, "")
+
+		_, err := newEvaluator(nil).Evaluate(ctx, EvaluationInput{Bundle: bundle, Document: json.RawMessage(`{}`)})
+		assert.ErrorIs(t, err, ErrOPAConfigNotFound)
+	})
+}
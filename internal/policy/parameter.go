@@ -0,0 +1,102 @@
+package policy
+
+import (
+	"errors"
+	"log/slog"
+	"strings"
+
+	"github.com/leg100/otf/internal/resource"
+)
+
+// ErrParameterKeyRequired is returned when a parameter is created without a
+// key.
+var ErrParameterKeyRequired = errors.New("parameter key is required")
+
+type (
+	// Parameter is a key-value pair passed to the policies in a policy set
+	// when they are evaluated.
+	Parameter struct {
+		ID          string
+		Key         string
+		Value       string
+		Sensitive   bool
+		PolicySetID string
+	}
+
+	CreateParameterOptions struct {
+		Key       *string
+		Value     *string
+		Sensitive *bool
+	}
+
+	UpdateParameterOptions struct {
+		Key       *string
+		Value     *string
+		Sensitive *bool
+	}
+)
+
+func newParameter(policySetID string, opts CreateParameterOptions) (*Parameter, error) {
+	if opts.Key == nil {
+		return nil, ErrParameterKeyRequired
+	}
+	p := &Parameter{
+		ID:          resource.NewID(resource.PolicySetParameterKind),
+		PolicySetID: policySetID,
+	}
+	if err := p.setKey(*opts.Key); err != nil {
+		return nil, err
+	}
+	if opts.Value != nil {
+		p.Value = *opts.Value
+	}
+	if opts.Sensitive != nil {
+		p.Sensitive = *opts.Sensitive
+	}
+	return p, nil
+}
+
+func (p *Parameter) update(opts UpdateParameterOptions) error {
+	if opts.Key != nil {
+		if p.Sensitive {
+			return errors.New("changing the key of a sensitive parameter is not allowed")
+		}
+		if err := p.setKey(*opts.Key); err != nil {
+			return err
+		}
+	}
+	if opts.Value != nil {
+		p.Value = *opts.Value
+	}
+	if opts.Sensitive != nil {
+		if p.Sensitive && !*opts.Sensitive {
+			return errors.New("cannot change a sensitive parameter to a non-sensitive parameter")
+		}
+		p.Sensitive = *opts.Sensitive
+	}
+	return nil
+}
+
+func (p *Parameter) setKey(key string) error {
+	key = strings.TrimSpace(key)
+	if key == "" {
+		return ErrParameterKeyRequired
+	}
+	p.Key = key
+	return nil
+}
+
+func (p *Parameter) LogValue() slog.Value {
+	attrs := []slog.Attr{
+		slog.String("id", p.ID),
+		slog.String("key", p.Key),
+		slog.String("policy_set_id", p.PolicySetID),
+		slog.Bool("sensitive", p.Sensitive),
+	}
+	if p.Sensitive {
+		attrs = append(attrs, slog.String("value", "*****"))
+	} else {
+		attrs = append(attrs, slog.String("value", p.Value))
+	}
+	return slog.GroupValue(attrs...)
+}
@@ -0,0 +1,172 @@
+// Package policy manages policy sets, which are collections of policies
+// enforced against the runs of workspaces.
+package policy
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/leg100/otf/internal"
+	"github.com/leg100/otf/internal/resource"
+)
+
+const (
+	// OPA policy sets contain Open Policy Agent policies written in Rego.
+	OPA Kind = "opa"
+	// Sentinel policy sets contain Sentinel policies. Sentinel policy sets can
+	// be managed but OTF does not evaluate them.
+	Sentinel Kind = "sentinel"
+
+	DefaultKind = OPA
+)
+
+var (
+	ErrInvalidKind         = errors.New("kind must be either opa or sentinel")
+	ErrVCSNotSupported     = errors.New("policy sets sourced from a VCS repository are not supported")
+	ErrWorkspacesAndGlobal = errors.New("a global policy set cannot be assigned workspaces")
+)
+
+type (
+	// Kind is the policy language of a policy set.
+	Kind string
+
+	// PolicySet is a versioned collection of policies belonging to an
+	// organization, which is enforced against either all of the
+	// organization's workspaces (global) or a selection of them.
+	PolicySet struct {
+		ID           string
+		CreatedAt    time.Time
+		UpdatedAt    time.Time
+		Name         string
+		Description  string
+		Kind         Kind
+		Organization string
+		// Overridable permits users to override the policy set when it fails
+		// during a run.
+		Overridable bool
+		// Global policy sets are enforced against all workspaces in the
+		// organization.
+		Global bool
+		// Workspaces against which the policy set is enforced; empty if global.
+		Workspaces []string // workspace IDs
+
+		// NewestVersionID is the ID of the most recently created version, if
+		// any.
+		NewestVersionID *string
+		// CurrentVersionID is the ID of the most recently created version that
+		// is ready to be evaluated, if any.
+		CurrentVersionID *string
+		// PolicyCount is the number of policies in the current version.
+		PolicyCount int
+	}
+
+	CreateOptions struct {
+		Name        *string
+		Description *string
+		Kind        *Kind
+		Overridable *bool
+		Global      *bool
+		Workspaces  []string // workspace IDs
+	}
+
+	UpdateOptions struct {
+		Name        *string
+		Description *string
+		Overridable *bool
+		Global      *bool
+	}
+
+	ListOptions struct {
+		// Filter policy sets by those with a name containing this substring.
+		Search *string
+		// Filter policy sets by kind
+		Kind *Kind
+	}
+)
+
+func newPolicySet(organization string, opts CreateOptions) (*PolicySet, error) {
+	if opts.Name == nil {
+		return nil, internal.ErrRequiredName
+	}
+	set := &PolicySet{
+		ID:           resource.NewID(resource.PolicySetKind),
+		CreatedAt:    internal.CurrentTimestamp(nil),
+		Organization: organization,
+		Kind:         DefaultKind,
+		Workspaces:   opts.Workspaces,
+	}
+	set.UpdatedAt = set.CreatedAt
+	if err := set.setName(*opts.Name); err != nil {
+		return nil, err
+	}
+	if opts.Kind != nil {
+		if err := set.setKind(*opts.Kind); err != nil {
+			return nil, err
+		}
+	}
+	if opts.Description != nil {
+		set.Description = *opts.Description
+	}
+	if opts.Overridable != nil {
+		set.Overridable = *opts.Overridable
+	}
+	if opts.Global != nil {
+		set.Global = *opts.Global
+	}
+	if set.Global && len(set.Workspaces) > 0 {
+		return nil, ErrWorkspacesAndGlobal
+	}
+	return set, nil
+}
+
+func (s *PolicySet) update(opts UpdateOptions) error {
+	if opts.Global != nil && *opts.Global && len(s.Workspaces) > 0 {
+		return ErrWorkspacesAndGlobal
+	}
+	if opts.Name != nil {
+		if err := s.setName(*opts.Name); err != nil {
+			return err
+		}
+	}
+	if opts.Description != nil {
+		s.Description = *opts.Description
+	}
+	if opts.Overridable != nil {
+		s.Overridable = *opts.Overridable
+	}
+	if opts.Global != nil {
+		s.Global = *opts.Global
+	}
+	s.UpdatedAt = internal.CurrentTimestamp(nil)
+	return nil
+}
+
+func (s *PolicySet) setName(name string) error {
+	if !internal.ReStringID.MatchString(name) {
+		return internal.ErrInvalidName
+	}
+	s.Name = name
+	return nil
+}
+
+func (s *PolicySet) setKind(kind Kind) error {
+	switch kind {
+	case OPA, Sentinel:
+		s.Kind = kind
+		return nil
+	default:
+		return fmt.Errorf("%w: %s", ErrInvalidKind, kind)
+	}
+}
+
+func (s *PolicySet) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.String("id", s.ID),
+		slog.String("name", s.Name),
+		slog.String("organization", s.Organization),
+		slog.String("kind", string(s.Kind)),
+		slog.Bool("global", s.Global),
+	)
+}
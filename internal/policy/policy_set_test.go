@@ -0,0 +1,73 @@
+package policy
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/leg100/otf/internal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewPolicySet(t *testing.T) {
+	sentinel := Sentinel
+	invalid := Kind("cedar")
+
+	tests := []struct {
+		name     string
+		opts     CreateOptions
+		wantKind Kind
+		want     error
+	}{
+		{"default kind", CreateOptions{Name: internal.String("security")}, OPA, nil},
+		{"sentinel", CreateOptions{Name: internal.String("security"), Kind: &sentinel}, Sentinel, nil},
+		{"missing name", CreateOptions{}, "", internal.ErrRequiredName},
+		{"invalid name", CreateOptions{Name: internal.String("my policies")}, "", internal.ErrInvalidName},
+		{"invalid kind", CreateOptions{Name: internal.String("security"), Kind: &invalid}, "", ErrInvalidKind},
+		{"global with workspaces", CreateOptions{Name: internal.String("security"), Global: internal.Bool(true), Workspaces: []string{"ws-123"}}, "", ErrWorkspacesAndGlobal},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := newPolicySet("acme", tt.opts)
+			if tt.want != nil {
+				assert.True(t, errors.Is(err, tt.want), "got error: %v", err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, "security", got.Name)
+			assert.Equal(t, "acme", got.Organization)
+			assert.Equal(t, tt.wantKind, got.Kind)
+		})
+	}
+}
+
+func TestPolicySet_Update(t *testing.T) {
+	set, err := newPolicySet("acme", CreateOptions{
+		Name:       internal.String("security"),
+		Workspaces: []string{"ws-123"},
+	})
+	require.NoError(t, err)
+
+	err = set.update(UpdateOptions{Global: internal.Bool(true)})
+	assert.Equal(t, ErrWorkspacesAndGlobal, err)
+
+	err = set.update(UpdateOptions{Description: internal.String("security policies"), Overridable: internal.Bool(true)})
+	require.NoError(t, err)
+	assert.Equal(t, "security policies", set.Description)
+	assert.True(t, set.Overridable)
+}
+
+func TestParameter_Update(t *testing.T) {
+	param, err := newParameter("polset-123", CreateParameterOptions{
+		Key:       internal.String("region"),
+		Value:     internal.String("eu-west-1"),
+		Sensitive: internal.Bool(true),
+	})
+	require.NoError(t, err)
+
+	assert.Error(t, param.update(UpdateParameterOptions{Key: internal.String("zone")}))
+	assert.Error(t, param.update(UpdateParameterOptions{Sensitive: internal.Bool(false)}))
+
+	require.NoError(t, param.update(UpdateParameterOptions{Value: internal.String("us-east-1")}))
+	assert.Equal(t, "us-east-1", param.Value)
+}
@@ -0,0 +1,584 @@
+package policy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/gorilla/mux"
+	"github.com/leg100/otf/internal"
+	"github.com/leg100/otf/internal/logr"
+	"github.com/leg100/otf/internal/organization"
+	"github.com/leg100/otf/internal/rbac"
+	"github.com/leg100/otf/internal/run"
+	"github.com/leg100/otf/internal/sql"
+	"github.com/leg100/otf/internal/tfeapi"
+	"github.com/leg100/otf/internal/workspace"
+	"github.com/leg100/surl"
+)
+
+var ErrDifferentOrganization = errors.New("workspace belongs to a different organization to the policy set")
+
+type (
+	Service struct {
+		logr.Logger
+
+		organization        internal.Authorizer
+		workspaceAuthorizer internal.Authorizer
+		runAuthorizer       internal.Authorizer
+		workspaces          workspaceClient
+		runs                runClient
+		db                  *pgdb
+		tfeapi              *tfe
+		evaluators          map[Kind]Evaluator
+	}
+
+	Options struct {
+		*sql.DB
+		*tfeapi.Responder
+		*surl.Signer
+		logr.Logger
+
+		// Maximum permitted size of an uploaded bundle of policies
+		MaxUploadSize int64
+
+		WorkspaceAuthorizer internal.Authorizer
+		WorkspaceService    workspaceClient
+		RunService          *run.Service
+	}
+
+	workspaceClient interface {
+		Get(ctx context.Context, workspaceID string) (*workspace.Workspace, error)
+	}
+
+	runClient interface {
+		Get(ctx context.Context, runID string) (*run.Run, error)
+		SummarizePlan(ctx context.Context, runID string) (*run.PlanSummary, error)
+		CompletePolicyCheck(ctx context.Context, runID string, outcome run.PolicyCheckOutcome) (*run.Run, error)
+		OverridePolicyCheck(ctx context.Context, runID string) (*run.Run, error)
+	}
+)
+
+func NewService(opts Options) *Service {
+	svc := Service{
+		Logger:              opts.Logger,
+		organization:        &organization.Authorizer{Logger: opts.Logger},
+		workspaceAuthorizer: opts.WorkspaceAuthorizer,
+		runAuthorizer:       opts.RunService,
+		workspaces:          opts.WorkspaceService,
+		runs:                opts.RunService,
+		db:                  &pgdb{DB: opts.DB},
+		evaluators:          make(map[Kind]Evaluator),
+	}
+	svc.tfeapi = &tfe{
+		Service:       &svc,
+		Responder:     opts.Responder,
+		Signer:        opts.Signer,
+		maxUploadSize: opts.MaxUploadSize,
+	}
+	// Check policies against a run's plan before it can be applied.
+	opts.RunService.OnPolicyCheck(svc.startCheck)
+	return &svc
+}
+
+// RegisterEvaluator registers an evaluator of policy sets of the given kind.
+// Only policy sets for which an evaluator is registered are checked against
+// runs. Not safe for concurrent use; evaluators should be registered on
+// startup.
+func (s *Service) RegisterEvaluator(kind Kind, evaluator Evaluator) {
+	s.evaluators[kind] = evaluator
+}
+
+func (s *Service) AddHandlers(r *mux.Router) {
+	s.tfeapi.addHandlers(r)
+}
+
+func (s *Service) Create(ctx context.Context, organization string, opts CreateOptions) (*PolicySet, error) {
+	subject, err := s.organization.CanAccess(ctx, rbac.CreatePolicySetAction, organization)
+	if err != nil {
+		return nil, err
+	}
+	set, err := newPolicySet(organization, opts)
+	if err != nil {
+		s.Error(err, "constructing policy set", "organization", organization, "subject", subject)
+		return nil, err
+	}
+	if err := s.checkWorkspaces(ctx, organization, set.Workspaces); err != nil {
+		return nil, err
+	}
+	if err := s.db.create(ctx, set); err != nil {
+		s.Error(err, "creating policy set", "set", set, "subject", subject)
+		return nil, err
+	}
+	s.V(1).Info("created policy set", "set", set, "subject", subject)
+	return set, nil
+}
+
+func (s *Service) Update(ctx context.Context, id string, opts UpdateOptions) (*PolicySet, error) {
+	set, err := s.db.get(ctx, id)
+	if err != nil {
+		s.Error(err, "retrieving policy set", "id", id)
+		return nil, err
+	}
+	subject, err := s.organization.CanAccess(ctx, rbac.UpdatePolicySetAction, set.Organization)
+	if err != nil {
+		return nil, err
+	}
+	set, err = s.db.update(ctx, id, func(set *PolicySet) error {
+		return set.update(opts)
+	})
+	if err != nil {
+		s.Error(err, "updating policy set", "id", id, "subject", subject)
+		return nil, err
+	}
+	s.V(1).Info("updated policy set", "set", set, "subject", subject)
+	return set, nil
+}
+
+func (s *Service) List(ctx context.Context, organization string, opts ListOptions) ([]*PolicySet, error) {
+	subject, err := s.organization.CanAccess(ctx, rbac.ListPolicySetsAction, organization)
+	if err != nil {
+		return nil, err
+	}
+	sets, err := s.db.list(ctx, organization, opts)
+	if err != nil {
+		s.Error(err, "listing policy sets", "organization", organization, "subject", subject)
+		return nil, err
+	}
+	s.V(9).Info("listed policy sets", "organization", organization, "total", len(sets), "subject", subject)
+	return sets, nil
+}
+
+// ListWorkspacePolicySets lists the policy sets enforced against a workspace:
+// global policy sets in the workspace's organization, and policy sets to
+// which the workspace has been added.
+func (s *Service) ListWorkspacePolicySets(ctx context.Context, workspaceID string) ([]*PolicySet, error) {
+	subject, err := s.workspaceAuthorizer.CanAccess(ctx, rbac.ListWorkspacePolicySetsAction, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+	sets, err := s.db.listByWorkspace(ctx, workspaceID)
+	if err != nil {
+		s.Error(err, "listing workspace policy sets", "workspace_id", workspaceID, "subject", subject)
+		return nil, err
+	}
+	s.V(9).Info("listed workspace policy sets", "workspace_id", workspaceID, "total", len(sets), "subject", subject)
+	return sets, nil
+}
+
+func (s *Service) Get(ctx context.Context, id string) (*PolicySet, error) {
+	set, err := s.db.get(ctx, id)
+	if err != nil {
+		s.Error(err, "retrieving policy set", "id", id)
+		return nil, err
+	}
+	subject, err := s.organization.CanAccess(ctx, rbac.GetPolicySetAction, set.Organization)
+	if err != nil {
+		return nil, err
+	}
+	s.V(9).Info("retrieved policy set", "set", set, "subject", subject)
+	return set, nil
+}
+
+func (s *Service) Delete(ctx context.Context, id string) error {
+	set, err := s.db.get(ctx, id)
+	if err != nil {
+		s.Error(err, "retrieving policy set", "id", id)
+		return err
+	}
+	subject, err := s.organization.CanAccess(ctx, rbac.DeletePolicySetAction, set.Organization)
+	if err != nil {
+		return err
+	}
+	if err := s.db.delete(ctx, id); err != nil {
+		s.Error(err, "deleting policy set", "set", set, "subject", subject)
+		return err
+	}
+	s.V(1).Info("deleted policy set", "set", set, "subject", subject)
+	return nil
+}
+
+// AddWorkspaces adds workspaces to a policy set, enforcing the policy set
+// against their runs.
+func (s *Service) AddWorkspaces(ctx context.Context, id string, workspaceIDs []string) error {
+	set, err := s.db.get(ctx, id)
+	if err != nil {
+		s.Error(err, "retrieving policy set", "id", id)
+		return err
+	}
+	subject, err := s.organization.CanAccess(ctx, rbac.UpdatePolicySetAction, set.Organization)
+	if err != nil {
+		return err
+	}
+	if set.Global {
+		return ErrWorkspacesAndGlobal
+	}
+	if err := s.checkWorkspaces(ctx, set.Organization, workspaceIDs); err != nil {
+		return err
+	}
+	if err := s.db.addWorkspaces(ctx, id, workspaceIDs); err != nil {
+		s.Error(err, "adding workspaces to policy set", "set", set, "workspaces", workspaceIDs, "subject", subject)
+		return err
+	}
+	s.V(1).Info("added workspaces to policy set", "set", set, "workspaces", workspaceIDs, "subject", subject)
+	return nil
+}
+
+// RemoveWorkspaces removes workspaces from a policy set.
+func (s *Service) RemoveWorkspaces(ctx context.Context, id string, workspaceIDs []string) error {
+	set, err := s.db.get(ctx, id)
+	if err != nil {
+		s.Error(err, "retrieving policy set", "id", id)
+		return err
+	}
+	subject, err := s.organization.CanAccess(ctx, rbac.UpdatePolicySetAction, set.Organization)
+	if err != nil {
+		return err
+	}
+	if err := s.db.removeWorkspaces(ctx, id, workspaceIDs); err != nil {
+		s.Error(err, "removing workspaces from policy set", "set", set, "workspaces", workspaceIDs, "subject", subject)
+		return err
+	}
+	s.V(1).Info("removed workspaces from policy set", "set", set, "workspaces", workspaceIDs, "subject", subject)
+	return nil
+}
+
+// checkWorkspaces checks the workspaces belong to the organization.
+func (s *Service) checkWorkspaces(ctx context.Context, organization string, workspaceIDs []string) error {
+	for _, workspaceID := range workspaceIDs {
+		ws, err := s.workspaces.Get(ctx, workspaceID)
+		if err != nil {
+			return fmt.Errorf("retrieving workspace: %w", err)
+		}
+		if ws.Organization != organization {
+			return ErrDifferentOrganization
+		}
+	}
+	return nil
+}
+
+func (s *Service) CreateParameter(ctx context.Context, policySetID string, opts CreateParameterOptions) (*Parameter, error) {
+	set, subject, err := s.canAccessPolicySet(ctx, rbac.UpdatePolicySetAction, policySetID)
+	if err != nil {
+		return nil, err
+	}
+	param, err := newParameter(set.ID, opts)
+	if err != nil {
+		s.Error(err, "constructing policy set parameter", "set", set, "subject", subject)
+		return nil, err
+	}
+	if err := s.db.createParameter(ctx, param); err != nil {
+		s.Error(err, "creating policy set parameter", "set", set, "parameter", param, "subject", subject)
+		return nil, err
+	}
+	s.V(1).Info("created policy set parameter", "set", set, "parameter", param, "subject", subject)
+	return param, nil
+}
+
+func (s *Service) UpdateParameter(ctx context.Context, parameterID string, opts UpdateParameterOptions) (*Parameter, error) {
+	param, err := s.db.getParameter(ctx, parameterID)
+	if err != nil {
+		s.Error(err, "retrieving policy set parameter", "id", parameterID)
+		return nil, err
+	}
+	set, subject, err := s.canAccessPolicySet(ctx, rbac.UpdatePolicySetAction, param.PolicySetID)
+	if err != nil {
+		return nil, err
+	}
+	param, err = s.db.updateParameter(ctx, parameterID, func(param *Parameter) error {
+		return param.update(opts)
+	})
+	if err != nil {
+		s.Error(err, "updating policy set parameter", "set", set, "id", parameterID, "subject", subject)
+		return nil, err
+	}
+	s.V(1).Info("updated policy set parameter", "set", set, "parameter", param, "subject", subject)
+	return param, nil
+}
+
+func (s *Service) ListParameters(ctx context.Context, policySetID string) ([]*Parameter, error) {
+	set, subject, err := s.canAccessPolicySet(ctx, rbac.GetPolicySetAction, policySetID)
+	if err != nil {
+		return nil, err
+	}
+	params, err := s.db.listParameters(ctx, policySetID)
+	if err != nil {
+		s.Error(err, "listing policy set parameters", "set", set, "subject", subject)
+		return nil, err
+	}
+	s.V(9).Info("listed policy set parameters", "set", set, "total", len(params), "subject", subject)
+	return params, nil
+}
+
+func (s *Service) GetParameter(ctx context.Context, parameterID string) (*Parameter, error) {
+	param, err := s.db.getParameter(ctx, parameterID)
+	if err != nil {
+		s.Error(err, "retrieving policy set parameter", "id", parameterID)
+		return nil, err
+	}
+	set, subject, err := s.canAccessPolicySet(ctx, rbac.GetPolicySetAction, param.PolicySetID)
+	if err != nil {
+		return nil, err
+	}
+	s.V(9).Info("retrieved policy set parameter", "set", set, "parameter", param, "subject", subject)
+	return param, nil
+}
+
+func (s *Service) DeleteParameter(ctx context.Context, parameterID string) (*Parameter, error) {
+	param, err := s.db.getParameter(ctx, parameterID)
+	if err != nil {
+		s.Error(err, "retrieving policy set parameter", "id", parameterID)
+		return nil, err
+	}
+	set, subject, err := s.canAccessPolicySet(ctx, rbac.UpdatePolicySetAction, param.PolicySetID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.db.deleteParameter(ctx, parameterID); err != nil {
+		s.Error(err, "deleting policy set parameter", "set", set, "parameter", param, "subject", subject)
+		return nil, err
+	}
+	s.V(1).Info("deleted policy set parameter", "set", set, "parameter", param, "subject", subject)
+	return param, nil
+}
+
+// CreateVersion creates a new version of a policy set, to which a bundle of
+// policies is then uploaded.
+func (s *Service) CreateVersion(ctx context.Context, policySetID string) (*Version, error) {
+	set, subject, err := s.canAccessPolicySet(ctx, rbac.UpdatePolicySetAction, policySetID)
+	if err != nil {
+		return nil, err
+	}
+	version := newVersion(set.ID)
+	if err := s.db.createVersion(ctx, version); err != nil {
+		s.Error(err, "creating policy set version", "set", set, "subject", subject)
+		return nil, err
+	}
+	s.V(1).Info("created policy set version", "set", set, "version", version, "subject", subject)
+	return version, nil
+}
+
+func (s *Service) GetVersion(ctx context.Context, versionID string) (*Version, error) {
+	version, err := s.db.getVersion(ctx, versionID)
+	if err != nil {
+		s.Error(err, "retrieving policy set version", "id", versionID)
+		return nil, err
+	}
+	set, subject, err := s.canAccessPolicySet(ctx, rbac.GetPolicySetAction, version.PolicySetID)
+	if err != nil {
+		return nil, err
+	}
+	s.V(9).Info("retrieved policy set version", "set", set, "version", version, "subject", subject)
+	return version, nil
+}
+
+// UploadVersion uploads a bundle of policies, a gzipped tarball, to a policy
+// set version.
+//
+// NOTE: unauthenticated - access granted only via signed URL
+func (s *Service) UploadVersion(ctx context.Context, versionID string, bundle []byte) error {
+	version, err := s.db.getVersion(ctx, versionID)
+	if err != nil {
+		s.Error(err, "retrieving policy set version", "id", versionID)
+		return err
+	}
+	set, err := s.db.get(ctx, version.PolicySetID)
+	if err != nil {
+		s.Error(err, "retrieving policy set", "id", version.PolicySetID)
+		return err
+	}
+	version, err = s.db.uploadVersion(ctx, versionID, bundle, func(version *Version) error {
+		return version.upload(set.Kind, bundle)
+	})
+	if err != nil {
+		s.Error(err, "uploading policy set version", "set", set, "id", versionID)
+		return err
+	}
+	s.V(1).Info("uploaded policy set version", "set", set, "version", version)
+	return nil
+}
+
+// DownloadCurrentVersion downloads the bundle of policies for the current
+// version of a policy set. Returns internal.ErrResourceNotFound if the policy
+// set has no current version.
+func (s *Service) DownloadCurrentVersion(ctx context.Context, policySetID string) ([]byte, error) {
+	set, subject, err := s.canAccessPolicySet(ctx, rbac.GetPolicySetAction, policySetID)
+	if err != nil {
+		return nil, err
+	}
+	if set.CurrentVersionID == nil {
+		return nil, internal.ErrResourceNotFound
+	}
+	bundle, err := s.db.downloadVersion(ctx, *set.CurrentVersionID)
+	if err != nil {
+		s.Error(err, "downloading policy set version", "set", set, "subject", subject)
+		return nil, err
+	}
+	s.V(9).Info("downloaded policy set version", "set", set, "version_id", *set.CurrentVersionID, "subject", subject)
+	return bundle, nil
+}
+
+func (s *Service) canAccessPolicySet(ctx context.Context, action rbac.Action, policySetID string) (*PolicySet, internal.Subject, error) {
+	set, err := s.db.get(ctx, policySetID)
+	if err != nil {
+		s.Error(err, "retrieving policy set", "id", policySetID)
+		return nil, nil, err
+	}
+	subject, err := s.organization.CanAccess(ctx, action, set.Organization)
+	if err != nil {
+		return nil, nil, err
+	}
+	return set, subject, nil
+}
+
+// ListChecks lists the policy checks of a run.
+func (s *Service) ListChecks(ctx context.Context, runID string) ([]*Check, error) {
+	subject, err := s.runAuthorizer.CanAccess(ctx, rbac.GetRunAction, runID)
+	if err != nil {
+		return nil, err
+	}
+	checks, err := s.db.listChecks(ctx, runID)
+	if err != nil {
+		s.Error(err, "listing policy checks", "run_id", runID, "subject", subject)
+		return nil, err
+	}
+	s.V(9).Info("listed policy checks", "run_id", runID, "count", len(checks), "subject", subject)
+	return checks, nil
+}
+
+func (s *Service) GetCheck(ctx context.Context, checkID string) (*Check, error) {
+	check, err := s.db.getCheck(ctx, checkID)
+	if err != nil {
+		s.Error(err, "retrieving policy check", "id", checkID)
+		return nil, err
+	}
+	subject, err := s.runAuthorizer.CanAccess(ctx, rbac.GetRunAction, check.RunID)
+	if err != nil {
+		return nil, err
+	}
+	s.V(9).Info("retrieved policy check", "check", check, "subject", subject)
+	return check, nil
+}
+
+// OverrideCheck overrides a soft failed policy check, permitting its run to
+// be applied.
+func (s *Service) OverrideCheck(ctx context.Context, checkID string) (*Check, error) {
+	check, err := s.db.updateCheck(ctx, checkID, func(ctx context.Context, check *Check) error {
+		if err := check.override(); err != nil {
+			return err
+		}
+		// the run service checks the subject is permitted to override the
+		// check
+		_, err := s.runs.OverridePolicyCheck(ctx, check.RunID)
+		return err
+	})
+	if err != nil {
+		s.Error(err, "overriding policy check", "id", checkID)
+		return nil, err
+	}
+	s.V(0).Info("overrode policy check", "check", check)
+	return check, nil
+}
+
+// startCheck queues a check of the policy sets enforced against a run's
+// workspace, returning true if there are any policy sets to evaluate.
+func (s *Service) startCheck(ctx context.Context, r *run.Run) (bool, error) {
+	sets, err := s.listEvaluableSets(ctx, r.WorkspaceID)
+	if err != nil {
+		return false, err
+	}
+	if len(sets) == 0 {
+		return false, nil
+	}
+	check := newCheck(r.ID)
+	if err := s.db.createCheck(ctx, check); err != nil {
+		return false, err
+	}
+	s.V(0).Info("queued policy check", "check", check, "policy_sets", len(sets))
+	return true, nil
+}
+
+// evaluateCheck evaluates the policy sets of a queued check, informing the
+// run of the outcome.
+func (s *Service) evaluateCheck(ctx context.Context, checkID string) (*Check, error) {
+	ctx = internal.AddSubjectToContext(ctx, &internal.Superuser{Username: "policy-checker"})
+	return s.db.updateCheck(ctx, checkID, func(ctx context.Context, check *Check) error {
+		if check.Status != CheckQueued {
+			return nil
+		}
+		result, err := s.evaluate(ctx, check.RunID)
+		var outcome run.PolicyCheckOutcome
+		if err != nil {
+			s.Error(err, "evaluating policy check", "check", check)
+			outcome = check.fail(err)
+		} else {
+			outcome = check.complete(result)
+		}
+		_, err = s.runs.CompletePolicyCheck(ctx, check.RunID, outcome)
+		if errors.Is(err, run.ErrInvalidRunStateTransition) {
+			// run has moved on, e.g. it has been canceled
+			check.cancel()
+			return nil
+		}
+		return err
+	})
+}
+
+// evaluate evaluates the policy sets enforced against a run's workspace
+// against the run's plan.
+func (s *Service) evaluate(ctx context.Context, runID string) (CheckResult, error) {
+	var result CheckResult
+	r, err := s.runs.Get(ctx, runID)
+	if err != nil {
+		return result, fmt.Errorf("retrieving run: %w", err)
+	}
+	sets, err := s.listEvaluableSets(ctx, r.WorkspaceID)
+	if err != nil {
+		return result, err
+	}
+	summary, err := s.runs.SummarizePlan(ctx, runID)
+	if err != nil {
+		return result, fmt.Errorf("summarizing plan: %w", err)
+	}
+	for _, set := range sets {
+		bundle, err := s.db.downloadVersion(ctx, *set.CurrentVersionID)
+		if err != nil {
+			return result, fmt.Errorf("downloading policy set %s: %w", set.Name, err)
+		}
+		params, err := s.db.listParameters(ctx, set.ID)
+		if err != nil {
+			return result, fmt.Errorf("retrieving policy set %s parameters: %w", set.Name, err)
+		}
+		outcomes, err := s.evaluators[set.Kind].Evaluate(ctx, EvaluationInput{
+			Set:        set,
+			Bundle:     bundle,
+			Parameters: params,
+			Document:   summary.PolicyInput,
+		})
+		if err != nil {
+			return result, fmt.Errorf("evaluating policy set %s: %w", set.Name, err)
+		}
+		result.add(set, outcomes)
+	}
+	return result, nil
+}
+
+// listEvaluableSets lists the policy sets enforced against a workspace that
+// can be evaluated, i.e. those with policies and an evaluator for their kind.
+func (s *Service) listEvaluableSets(ctx context.Context, workspaceID string) ([]*PolicySet, error) {
+	sets, err := s.db.listByWorkspace(ctx, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+	var evaluable []*PolicySet
+	for _, set := range sets {
+		if set.CurrentVersionID == nil {
+			continue
+		}
+		if _, ok := s.evaluators[set.Kind]; !ok {
+			continue
+		}
+		evaluable = append(evaluable, set)
+	}
+	return evaluable, nil
+}
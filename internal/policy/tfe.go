@@ -0,0 +1,603 @@
+package policy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/leg100/otf/internal"
+	otfhttp "github.com/leg100/otf/internal/http"
+	"github.com/leg100/otf/internal/http/decode"
+	"github.com/leg100/otf/internal/rbac"
+	"github.com/leg100/otf/internal/resource"
+	"github.com/leg100/otf/internal/run"
+	"github.com/leg100/otf/internal/tfeapi"
+	"github.com/leg100/otf/internal/tfeapi/types"
+	"github.com/leg100/surl"
+)
+
+type tfe struct {
+	*Service
+	*tfeapi.Responder
+	*surl.Signer
+
+	maxUploadSize int64
+}
+
+// Implements TFC policy sets API:
+//
+// https://developer.hashicorp.com/terraform/cloud-docs/api-docs/policy-sets
+func (a *tfe) addHandlers(r *mux.Router) {
+	api := r.PathPrefix(tfeapi.APIPrefixV2).Subrouter()
+
+	api.HandleFunc("/organizations/{organization_name}/policy-sets", a.createPolicySet).Methods("POST")
+	api.HandleFunc("/organizations/{organization_name}/policy-sets", a.listPolicySets).Methods("GET")
+	api.HandleFunc("/policy-sets/{policy_set_id}", a.getPolicySet).Methods("GET")
+	api.HandleFunc("/policy-sets/{policy_set_id}", a.updatePolicySet).Methods("PATCH")
+	api.HandleFunc("/policy-sets/{policy_set_id}", a.deletePolicySet).Methods("DELETE")
+	api.HandleFunc("/policy-sets/{policy_set_id}/relationships/workspaces", a.addWorkspaces).Methods("POST")
+	api.HandleFunc("/policy-sets/{policy_set_id}/relationships/workspaces", a.removeWorkspaces).Methods("DELETE")
+
+	api.HandleFunc("/policy-sets/{policy_set_id}/parameters", a.createParameter).Methods("POST")
+	api.HandleFunc("/policy-sets/{policy_set_id}/parameters", a.listParameters).Methods("GET")
+	api.HandleFunc("/policy-sets/{policy_set_id}/parameters/{parameter_id}", a.getParameter).Methods("GET")
+	api.HandleFunc("/policy-sets/{policy_set_id}/parameters/{parameter_id}", a.updateParameter).Methods("PATCH")
+	api.HandleFunc("/policy-sets/{policy_set_id}/parameters/{parameter_id}", a.deleteParameter).Methods("DELETE")
+
+	api.HandleFunc("/policy-sets/{policy_set_id}/versions", a.createVersion).Methods("POST")
+	api.HandleFunc("/policy-set-versions/{version_id}", a.getVersion).Methods("GET")
+
+	api.HandleFunc("/runs/{run_id}/policy-checks", a.listChecks).Methods("GET")
+	api.HandleFunc("/policy-checks/{policy_check_id}", a.getCheck).Methods("GET")
+	api.HandleFunc("/policy-checks/{policy_check_id}/actions/override", a.overrideCheck).Methods("POST")
+
+	// Upload is *not* rooted at /api/v2
+	signed := r.PathPrefix("/signed/{signature.expiry}").Subrouter()
+	signed.Use(internal.VerifySignedURL(a.Signer))
+	signed.HandleFunc("/policy-set-versions/{version_id}/upload", a.uploadVersion).Methods("PUT")
+}
+
+func (a *tfe) createPolicySet(w http.ResponseWriter, r *http.Request) {
+	org, err := decode.Param("organization_name", r)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	var params types.PolicySetCreateOptions
+	if err := tfeapi.Unmarshal(r.Body, &params); err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	if params.VCSRepo != nil {
+		tfeapi.Error(w, &internal.HTTPError{Code: http.StatusUnprocessableEntity, Message: ErrVCSNotSupported.Error()})
+		return
+	}
+
+	opts := CreateOptions{
+		Name:        params.Name,
+		Description: params.Description,
+		Overridable: params.Overridable,
+		Global:      params.Global,
+	}
+	if params.Kind != nil {
+		opts.Kind = (*Kind)(params.Kind)
+	}
+	for _, ws := range params.Workspaces {
+		opts.Workspaces = append(opts.Workspaces, ws.ID)
+	}
+	set, err := a.Create(r.Context(), org, opts)
+	if err != nil {
+		a.error(w, err)
+		return
+	}
+
+	a.Respond(w, r, a.toPolicySet(set), http.StatusCreated)
+}
+
+func (a *tfe) listPolicySets(w http.ResponseWriter, r *http.Request) {
+	org, err := decode.Param("organization_name", r)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	var params types.PolicySetListOptions
+	if err := decode.All(&params, r); err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+
+	opts := ListOptions{Search: params.Search}
+	if params.Kind != nil {
+		opts.Kind = (*Kind)(params.Kind)
+	}
+	sets, err := a.List(r.Context(), org, opts)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+
+	// client expects a page, whereas List returns full result set, so
+	// convert to page first
+	page := resource.NewPage(sets, resource.PageOptions(params.ListOptions), nil)
+
+	// convert items
+	items := make([]*types.PolicySet, len(page.Items))
+	for i, from := range page.Items {
+		items[i] = a.toPolicySet(from)
+	}
+	a.RespondWithPage(w, r, items, page.Pagination)
+}
+
+func (a *tfe) getPolicySet(w http.ResponseWriter, r *http.Request) {
+	id, err := decode.Param("policy_set_id", r)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+
+	set, err := a.Get(r.Context(), id)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+
+	a.Respond(w, r, a.toPolicySet(set), http.StatusOK)
+}
+
+func (a *tfe) updatePolicySet(w http.ResponseWriter, r *http.Request) {
+	id, err := decode.Param("policy_set_id", r)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	var params types.PolicySetUpdateOptions
+	if err := tfeapi.Unmarshal(r.Body, &params); err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	if params.VCSRepo != nil {
+		tfeapi.Error(w, &internal.HTTPError{Code: http.StatusUnprocessableEntity, Message: ErrVCSNotSupported.Error()})
+		return
+	}
+
+	set, err := a.Update(r.Context(), id, UpdateOptions{
+		Name:        params.Name,
+		Description: params.Description,
+		Overridable: params.Overridable,
+		Global:      params.Global,
+	})
+	if err != nil {
+		a.error(w, err)
+		return
+	}
+
+	a.Respond(w, r, a.toPolicySet(set), http.StatusOK)
+}
+
+func (a *tfe) deletePolicySet(w http.ResponseWriter, r *http.Request) {
+	id, err := decode.Param("policy_set_id", r)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+
+	if err := a.Delete(r.Context(), id); err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (a *tfe) addWorkspaces(w http.ResponseWriter, r *http.Request) {
+	id, err := decode.Param("policy_set_id", r)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	var params []*types.Workspace
+	if err := tfeapi.Unmarshal(r.Body, &params); err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	workspaceIDs := make([]string, len(params))
+	for i, ws := range params {
+		workspaceIDs[i] = ws.ID
+	}
+
+	if err := a.AddWorkspaces(r.Context(), id, workspaceIDs); err != nil {
+		a.error(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (a *tfe) removeWorkspaces(w http.ResponseWriter, r *http.Request) {
+	id, err := decode.Param("policy_set_id", r)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	var params []*types.Workspace
+	if err := tfeapi.Unmarshal(r.Body, &params); err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	workspaceIDs := make([]string, len(params))
+	for i, ws := range params {
+		workspaceIDs[i] = ws.ID
+	}
+
+	if err := a.RemoveWorkspaces(r.Context(), id, workspaceIDs); err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (a *tfe) createParameter(w http.ResponseWriter, r *http.Request) {
+	id, err := decode.Param("policy_set_id", r)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	var params types.PolicySetParameterCreateOptions
+	if err := tfeapi.Unmarshal(r.Body, &params); err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+
+	param, err := a.CreateParameter(r.Context(), id, CreateParameterOptions{
+		Key:       params.Key,
+		Value:     params.Value,
+		Sensitive: params.Sensitive,
+	})
+	if err != nil {
+		a.error(w, err)
+		return
+	}
+
+	a.Respond(w, r, a.toParameter(param), http.StatusCreated)
+}
+
+func (a *tfe) listParameters(w http.ResponseWriter, r *http.Request) {
+	var params struct {
+		PolicySetID string `schema:"policy_set_id,required"`
+		resource.PageOptions
+	}
+	if err := decode.All(&params, r); err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+
+	parameters, err := a.ListParameters(r.Context(), params.PolicySetID)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+
+	page := resource.NewPage(parameters, params.PageOptions, nil)
+
+	// convert items
+	items := make([]*types.PolicySetParameter, len(page.Items))
+	for i, from := range page.Items {
+		items[i] = a.toParameter(from)
+	}
+	a.RespondWithPage(w, r, items, page.Pagination)
+}
+
+func (a *tfe) getParameter(w http.ResponseWriter, r *http.Request) {
+	id, err := decode.Param("parameter_id", r)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+
+	param, err := a.GetParameter(r.Context(), id)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+
+	a.Respond(w, r, a.toParameter(param), http.StatusOK)
+}
+
+func (a *tfe) updateParameter(w http.ResponseWriter, r *http.Request) {
+	id, err := decode.Param("parameter_id", r)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	var params types.PolicySetParameterUpdateOptions
+	if err := tfeapi.Unmarshal(r.Body, &params); err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+
+	param, err := a.UpdateParameter(r.Context(), id, UpdateParameterOptions{
+		Key:       params.Key,
+		Value:     params.Value,
+		Sensitive: params.Sensitive,
+	})
+	if err != nil {
+		a.error(w, err)
+		return
+	}
+
+	a.Respond(w, r, a.toParameter(param), http.StatusOK)
+}
+
+func (a *tfe) deleteParameter(w http.ResponseWriter, r *http.Request) {
+	id, err := decode.Param("parameter_id", r)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+
+	if _, err := a.DeleteParameter(r.Context(), id); err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (a *tfe) createVersion(w http.ResponseWriter, r *http.Request) {
+	id, err := decode.Param("policy_set_id", r)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+
+	version, err := a.CreateVersion(r.Context(), id)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+
+	// the client retrieves the URL to which it uploads the policies from the
+	// upload link.
+	uploadURL, err := a.Sign(fmt.Sprintf("/policy-set-versions/%s/upload", version.ID), time.Hour)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	a.RespondWithLinks(w, r, a.toVersion(version), http.StatusCreated, map[string]string{
+		"upload": otfhttp.Absolute(r, uploadURL),
+	})
+}
+
+func (a *tfe) getVersion(w http.ResponseWriter, r *http.Request) {
+	id, err := decode.Param("version_id", r)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+
+	version, err := a.GetVersion(r.Context(), id)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+
+	a.Respond(w, r, a.toVersion(version), http.StatusOK)
+}
+
+func (a *tfe) uploadVersion(w http.ResponseWriter, r *http.Request) {
+	id, err := decode.Param("version_id", r)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+
+	buf, err := io.ReadAll(io.LimitReader(r.Body, a.maxUploadSize+1))
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	} else if int64(len(buf)) > a.maxUploadSize {
+		tfeapi.Error(w, &internal.HTTPError{
+			Code:    http.StatusUnprocessableEntity,
+			Message: fmt.Sprintf("policy set version exceeds maximum size (%d bytes)", a.maxUploadSize),
+		})
+		return
+	}
+
+	if err := a.UploadVersion(r.Context(), id, buf); err != nil {
+		a.error(w, err)
+		return
+	}
+}
+
+func (a *tfe) listChecks(w http.ResponseWriter, r *http.Request) {
+	var params struct {
+		RunID string `schema:"run_id,required"`
+		resource.PageOptions
+	}
+	if err := decode.All(&params, r); err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+
+	checks, err := a.ListChecks(r.Context(), params.RunID)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+
+	page := resource.NewPage(checks, params.PageOptions, nil)
+
+	// convert items
+	items := make([]*types.PolicyCheck, len(page.Items))
+	for i, from := range page.Items {
+		items[i] = a.toCheck(r.Context(), from)
+	}
+	a.RespondWithPage(w, r, items, page.Pagination)
+}
+
+func (a *tfe) getCheck(w http.ResponseWriter, r *http.Request) {
+	id, err := decode.Param("policy_check_id", r)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+
+	check, err := a.GetCheck(r.Context(), id)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+
+	a.Respond(w, r, a.toCheck(r.Context(), check), http.StatusOK)
+}
+
+func (a *tfe) overrideCheck(w http.ResponseWriter, r *http.Request) {
+	id, err := decode.Param("policy_check_id", r)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+
+	check, err := a.OverrideCheck(r.Context(), id)
+	if err != nil {
+		a.error(w, err)
+		return
+	}
+
+	a.Respond(w, r, a.toCheck(r.Context(), check), http.StatusOK)
+}
+
+// error responds with an error, responding with 422 if the error is the fault
+// of invalid input.
+func (a *tfe) error(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, internal.ErrRequiredName),
+		errors.Is(err, internal.ErrInvalidName),
+		errors.Is(err, ErrInvalidKind),
+		errors.Is(err, ErrWorkspacesAndGlobal),
+		errors.Is(err, ErrDifferentOrganization),
+		errors.Is(err, ErrParameterKeyRequired),
+		errors.Is(err, ErrVersionAlreadyUploaded):
+		tfeapi.Error(w, &internal.HTTPError{Code: http.StatusUnprocessableEntity, Message: err.Error()})
+	case errors.Is(err, ErrCheckNotOverridable),
+		errors.Is(err, run.ErrPolicyCheckNotOverridable):
+		tfeapi.Error(w, &internal.HTTPError{Code: http.StatusConflict, Message: err.Error()})
+	default:
+		tfeapi.Error(w, err)
+	}
+}
+
+func (a *tfe) toPolicySet(from *PolicySet) *types.PolicySet {
+	to := &types.PolicySet{
+		ID:             from.ID,
+		Name:           from.Name,
+		Description:    from.Description,
+		Kind:           string(from.Kind),
+		Overridable:    &from.Overridable,
+		Global:         from.Global,
+		PolicyCount:    from.PolicyCount,
+		WorkspaceCount: len(from.Workspaces),
+		CreatedAt:      from.CreatedAt,
+		UpdatedAt:      from.UpdatedAt,
+		Organization:   &types.Organization{Name: from.Organization},
+		Workspaces:     make([]*types.Workspace, len(from.Workspaces)),
+	}
+	for i, workspaceID := range from.Workspaces {
+		to.Workspaces[i] = &types.Workspace{ID: workspaceID}
+	}
+	if from.NewestVersionID != nil {
+		to.NewestVersion = &types.PolicySetVersion{ID: *from.NewestVersionID}
+	}
+	if from.CurrentVersionID != nil {
+		to.CurrentVersion = &types.PolicySetVersion{ID: *from.CurrentVersionID}
+	}
+	return to
+}
+
+func (a *tfe) toParameter(from *Parameter) *types.PolicySetParameter {
+	to := &types.PolicySetParameter{
+		ID:        from.ID,
+		Key:       from.Key,
+		Category:  "policy-set",
+		Sensitive: from.Sensitive,
+		PolicySet: &types.PolicySet{ID: from.PolicySetID},
+	}
+	// sensitive values are write-only
+	if !from.Sensitive {
+		to.Value = from.Value
+	}
+	return to
+}
+
+func (a *tfe) toVersion(from *Version) *types.PolicySetVersion {
+	return &types.PolicySetVersion{
+		ID:           from.ID,
+		Source:       "tfe-api",
+		Status:       string(from.Status),
+		ErrorMessage: from.ErrorMessage,
+		CreatedAt:    from.CreatedAt,
+		UpdatedAt:    from.UpdatedAt,
+		PolicySet:    &types.PolicySet{ID: from.PolicySetID},
+	}
+}
+
+func (a *tfe) toCheck(ctx context.Context, from *Check) *types.PolicyCheck {
+	// a check can only be overridden by a subject permitted to do so
+	_, err := a.runAuthorizer.CanAccess(ctx, rbac.OverridePolicyCheckAction, from.RunID)
+	canOverride := err == nil
+	to := &types.PolicyCheck{
+		ID:     from.ID,
+		Scope:  "organization",
+		Status: string(from.Status),
+		Actions: &types.PolicyActions{
+			IsOverridable: from.Status == CheckSoftFailed,
+		},
+		Permissions: &types.PolicyPermissions{
+			CanOverride: canOverride,
+		},
+		Result: &types.PolicyResult{
+			AdvisoryFailed: from.Result.AdvisoryFailed,
+			HardFailed:     from.Result.HardFailed,
+			Passed:         from.Result.Passed,
+			Result:         from.Status == CheckPassed || from.Status == CheckOverridden,
+			SoftFailed:     from.Result.SoftFailed,
+			TotalFailed:    from.Result.TotalFailed(),
+		},
+		StatusTimestamps: &types.PolicyStatusTimestamps{
+			QueuedAt:     &from.CreatedAt,
+			OverriddenAt: from.OverriddenAt,
+		},
+		Run: &types.Run{ID: from.RunID},
+	}
+	for _, outcome := range from.Result.Outcomes {
+		status := "failed"
+		if outcome.Passed {
+			status = "passed"
+		}
+		to.Result.Outcomes = append(to.Result.Outcomes, &types.PolicyOutcome{
+			PolicySetName:    outcome.PolicySetName,
+			PolicyName:       outcome.PolicyName,
+			EnforcementLevel: string(outcome.EnforcementLevel),
+			Status:           status,
+			Violations:       outcome.Violations,
+		})
+	}
+	switch from.Status {
+	case CheckPassed:
+		to.StatusTimestamps.PassedAt = from.CompletedAt
+	case CheckSoftFailed, CheckOverridden:
+		to.StatusTimestamps.SoftFailedAt = from.CompletedAt
+	case CheckHardFailed:
+		to.StatusTimestamps.HardFailedAt = from.CompletedAt
+	case CheckErrored:
+		to.StatusTimestamps.ErroredAt = from.CompletedAt
+	case CheckCanceled:
+		to.StatusTimestamps.CanceledAt = from.CompletedAt
+	}
+	return to
+}
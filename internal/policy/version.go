@@ -0,0 +1,120 @@
+package policy
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/leg100/otf/internal"
+	"github.com/leg100/otf/internal/resource"
+)
+
+const (
+	VersionPending VersionStatus = "pending"
+	VersionReady   VersionStatus = "ready"
+	VersionErrored VersionStatus = "errored"
+)
+
+// ErrVersionAlreadyUploaded is returned when policies are uploaded to a
+// version that is no longer pending.
+var ErrVersionAlreadyUploaded = errors.New("policies have already been uploaded to this policy set version")
+
+type (
+	VersionStatus string
+
+	// Version is a version of the policies in a policy set. A version is
+	// created in a pending state, and once a bundle of policies is uploaded it
+	// becomes ready, or errored if the bundle is invalid.
+	Version struct {
+		ID           string
+		CreatedAt    time.Time
+		UpdatedAt    time.Time
+		Status       VersionStatus
+		ErrorMessage string
+		PolicyCount  int
+		PolicySetID  string
+	}
+)
+
+func newVersion(policySetID string) *Version {
+	v := &Version{
+		ID:          resource.NewID(resource.PolicySetVersionKind),
+		CreatedAt:   internal.CurrentTimestamp(nil),
+		Status:      VersionPending,
+		PolicySetID: policySetID,
+	}
+	v.UpdatedAt = v.CreatedAt
+	return v
+}
+
+// upload processes an uploaded bundle of policies, a gzipped tarball, updating
+// the status of the version accordingly. An invalid bundle errors the version
+// rather than returning an error.
+func (v *Version) upload(kind Kind, bundle []byte) error {
+	if v.Status != VersionPending {
+		return ErrVersionAlreadyUploaded
+	}
+	v.UpdatedAt = internal.CurrentTimestamp(nil)
+	count, err := countPolicies(kind, bundle)
+	if err != nil {
+		v.Status = VersionErrored
+		v.ErrorMessage = err.Error()
+		return nil
+	}
+	v.Status = VersionReady
+	v.PolicyCount = count
+	return nil
+}
+
+func (v *Version) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.String("id", v.ID),
+		slog.String("policy_set_id", v.PolicySetID),
+		slog.String("status", string(v.Status)),
+	)
+}
+
+// countPolicies counts the policies of the given kind in a bundle. Rego test
+// files are not counted.
+func countPolicies(kind Kind, bundle []byte) (int, error) {
+	gr, err := gzip.NewReader(bytes.NewReader(bundle))
+	if err != nil {
+		return 0, fmt.Errorf("decompressing policy bundle: %w", err)
+	}
+	tr := tar.NewReader(gr)
+
+	var count int
+	for {
+		header, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		} else if err != nil {
+			return 0, fmt.Errorf("extracting policy bundle: %w", err)
+		}
+		if header.Typeflag != tar.TypeReg {
+			continue
+		}
+		name := path.Base(header.Name)
+		switch kind {
+		case OPA:
+			if path.Ext(name) == ".rego" && !strings.HasSuffix(name, "_test.rego") {
+				count++
+			}
+		case Sentinel:
+			if path.Ext(name) == ".sentinel" {
+				count++
+			}
+		}
+	}
+	if count == 0 {
+		return 0, fmt.Errorf("policy bundle contains no %s policies", kind)
+	}
+	return count, nil
+}
@@ -0,0 +1,67 @@
+package policy
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/leg100/otf/internal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVersion_Upload(t *testing.T) {
+	newBundle := func(t *testing.T, files ...string) []byte {
+		dir := t.TempDir()
+		for _, name := range files {
+			path := filepath.Join(dir, name)
+			require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+			require.NoError(t, os.WriteFile(path, []byte("package terraform"), 0o644))
+		}
+		bundle, err := internal.Pack(dir)
+		require.NoError(t, err)
+		return bundle
+	}
+
+	t.Run("opa", func(t *testing.T) {
+		v := newVersion("polset-123")
+		bundle := newBundle(t, "main.rego", "main_test.rego", "lib/tags.rego", "README.md")
+
+		require.NoError(t, v.upload(OPA, bundle))
+		assert.Equal(t, VersionReady, v.Status)
+		assert.Equal(t, 2, v.PolicyCount)
+	})
+
+	t.Run("sentinel", func(t *testing.T) {
+		v := newVersion("polset-123")
+		bundle := newBundle(t, "restrict.sentinel", "sentinel.hcl")
+
+		require.NoError(t, v.upload(Sentinel, bundle))
+		assert.Equal(t, VersionReady, v.Status)
+		assert.Equal(t, 1, v.PolicyCount)
+	})
+
+	t.Run("no policies", func(t *testing.T) {
+		v := newVersion("polset-123")
+		bundle := newBundle(t, "restrict.sentinel")
+
+		require.NoError(t, v.upload(OPA, bundle))
+		assert.Equal(t, VersionErrored, v.Status)
+		assert.Equal(t, "policy bundle contains no opa policies", v.ErrorMessage)
+	})
+
+	t.Run("invalid bundle", func(t *testing.T) {
+		v := newVersion("polset-123")
+
+		require.NoError(t, v.upload(OPA, []byte("not a tarball")))
+		assert.Equal(t, VersionErrored, v.Status)
+	})
+
+	t.Run("already uploaded", func(t *testing.T) {
+		v := newVersion("polset-123")
+		bundle := newBundle(t, "main.rego")
+
+		require.NoError(t, v.upload(OPA, bundle))
+		assert.Equal(t, ErrVersionAlreadyUploaded, v.upload(OPA, bundle))
+	})
+}
@@ -1,5 +1,10 @@
 package rbac
 
+import (
+	"fmt"
+	"strings"
+)
+
 // Action identifies an action a subject carries out on a resource for
 // authorization purposes.
 type Action int
@@ -18,6 +23,13 @@ const (
 	ListVCSProvidersAction
 	DeleteVCSProviderAction
 
+	CreateSSHKeyAction
+	UpdateSSHKeyAction
+	ListSSHKeysAction
+	GetSSHKeyAction
+	DeleteSSHKeyAction
+	GetWorkspaceSSHKeyAction
+
 	CreateAgentPoolAction
 	UpdateAgentPoolAction
 	ListAgentPoolsAction
@@ -35,6 +47,10 @@ const (
 	CreateOrganizationTokenAction
 	DeleteOrganizationTokenAction
 
+	CreateOrganizationImportAction
+	ListOrganizationImportsAction
+	GetOrganizationImportAction
+
 	CreateRunTokenAction
 
 	CreateTeamTokenAction
@@ -49,6 +65,19 @@ const (
 	DeleteModuleAction
 	DeleteModuleVersionAction
 
+	CreateRegistryProviderAction
+	ListRegistryProvidersAction
+	GetRegistryProviderAction
+	DeleteRegistryProviderAction
+	CreateRegistryProviderVersionAction
+	DeleteRegistryProviderVersionAction
+
+	CreateGPGKeyAction
+	ListGPGKeysAction
+	GetGPGKeyAction
+	UpdateGPGKeyAction
+	DeleteGPGKeyAction
+
 	CreateWorkspaceVariableAction
 	UpdateWorkspaceVariableAction
 	ListWorkspaceVariablesAction
@@ -72,15 +101,25 @@ const (
 	ApplyVariableSetToWorkspacesAction
 	DeleteVariableSetFromWorkspacesAction
 
+	CreatePolicySetAction
+	UpdatePolicySetAction
+	ListPolicySetsAction
+	GetPolicySetAction
+	DeletePolicySetAction
+	ListWorkspacePolicySetsAction
+
 	GetRunAction
 	ListRunsAction
 	ApplyRunAction
+	OverrideProtectionRulesAction
+	OverridePolicyCheckAction
 	CreateRunAction
 	DiscardRunAction
 	DeleteRunAction
 	CancelRunAction
 	ForceCancelRunAction
 	EnqueuePlanAction
+	EnqueueApplyAction
 	PutChunkAction
 	TailLogsAction
 
@@ -97,6 +136,9 @@ const (
 	SetWorkspacePermissionAction
 	UnsetWorkspacePermissionAction
 	UpdateWorkspaceAction
+	ListDeletedWorkspacesAction
+	RestoreWorkspaceAction
+	PurgeWorkspaceAction
 
 	ListTagsAction
 	DeleteTagsAction
@@ -123,6 +165,8 @@ const (
 	GetConfigurationVersionAction
 	DownloadConfigurationVersionAction
 	DeleteConfigurationVersionAction
+	GetConfigurationVersionUsageAction
+	GetConsumptionReportAction
 
 	CreateUserAction
 	ListUsersAction
@@ -137,12 +181,65 @@ const (
 	AddTeamMembershipAction
 	RemoveTeamMembershipAction
 
+	CreateOrganizationMembershipAction
+	ListOrganizationMembershipsAction
+	GetOrganizationMembershipAction
+	DeleteOrganizationMembershipAction
+
 	CreateNotificationConfigurationAction
 	UpdateNotificationConfigurationAction
 	ListNotificationConfigurationsAction
 	GetNotificationConfigurationAction
 	DeleteNotificationConfigurationAction
 
+	CreateRunTriggerAction
+	ListRunTriggersAction
+	GetRunTriggerAction
+	DeleteRunTriggerAction
+
+	CreateRunTaskAction
+	ListRunTasksAction
+	GetRunTaskAction
+	UpdateRunTaskAction
+	DeleteRunTaskAction
+	GetRunTaskSettingsAction
+	UpdateRunTaskSettingsAction
+	CreateWorkspaceRunTaskAction
+	ListWorkspaceRunTasksAction
+	GetWorkspaceRunTaskAction
+	UpdateWorkspaceRunTaskAction
+	DeleteWorkspaceRunTaskAction
+
+	ListAssessmentResultsAction
+	GetAssessmentResultAction
+
+	GetOrganizationMetricsAction
+
+	ListRunAnnotationsAction
+	ListResourceChangesAction
+	DebugRunVariablesAction
+	CreateBannerAction
+	ListBannersAction
+	DeleteBannerAction
+	ListVCSEventDeadLettersAction
+	RedriveVCSEventDeadLetterAction
+	DeleteVCSEventDeadLetterAction
+	ListFeatureFlagsAction
+	UpdateFeatureFlagAction
+	StartBlobMigrationAction
+	ListBlobMigrationsAction
+	GetBlobMigrationAction
+	UpdateBlobMigrationAction
+	GetSSOEnforcementAction
+	UpdateSSOEnforcementAction
+	ListAuditEventsAction
+	GetAuditSettingsAction
+	UpdateAuditSettingsAction
+	EnableOrganizationExportStreamAction
+	GetOrganizationExportStreamAction
+	ListOrganizationExportStreamsAction
+	DisableOrganizationExportStreamAction
+
 	CreateGithubAppAction
 	UpdateGithubAppAction
 	GetGithubAppAction
@@ -151,3 +248,16 @@ const (
 	CreateGithubAppInstallAction
 	DeleteGithubAppInstallAction
 )
+
+// ParseAction parses the name of an action, e.g. ApplyRunAction. The Action
+// suffix may be omitted, e.g. ApplyRun.
+func ParseAction(name string) (Action, error) {
+	name = strings.TrimSuffix(name, "Action")
+	for i := 0; i < len(_Action_index)-1; i++ {
+		action := Action(i)
+		if strings.TrimSuffix(action.String(), "Action") == name {
+			return action, nil
+		}
+	}
+	return 0, fmt.Errorf("unknown action: %s", name)
+}
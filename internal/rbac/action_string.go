@@ -19,124 +19,206 @@ func _() {
 	_ = x[GetVCSProviderAction-8]
 	_ = x[ListVCSProvidersAction-9]
 	_ = x[DeleteVCSProviderAction-10]
-	_ = x[CreateAgentPoolAction-11]
-	_ = x[UpdateAgentPoolAction-12]
-	_ = x[ListAgentPoolsAction-13]
-	_ = x[GetAgentPoolAction-14]
-	_ = x[DeleteAgentPoolAction-15]
-	_ = x[CreateAgentTokenAction-16]
-	_ = x[ListAgentTokensAction-17]
-	_ = x[GetAgentTokenAction-18]
-	_ = x[DeleteAgentTokenAction-19]
-	_ = x[ListAgentsAction-20]
-	_ = x[WatchAgentsAction-21]
-	_ = x[CreateOrganizationTokenAction-22]
-	_ = x[DeleteOrganizationTokenAction-23]
-	_ = x[CreateRunTokenAction-24]
-	_ = x[CreateTeamTokenAction-25]
-	_ = x[GetTeamTokenAction-26]
-	_ = x[DeleteTeamTokenAction-27]
-	_ = x[CreateModuleAction-28]
-	_ = x[CreateModuleVersionAction-29]
-	_ = x[UpdateModuleAction-30]
-	_ = x[ListModulesAction-31]
-	_ = x[GetModuleAction-32]
-	_ = x[DeleteModuleAction-33]
-	_ = x[DeleteModuleVersionAction-34]
-	_ = x[CreateWorkspaceVariableAction-35]
-	_ = x[UpdateWorkspaceVariableAction-36]
-	_ = x[ListWorkspaceVariablesAction-37]
-	_ = x[GetWorkspaceVariableAction-38]
-	_ = x[DeleteWorkspaceVariableAction-39]
-	_ = x[CreateVariableSetAction-40]
-	_ = x[UpdateVariableSetAction-41]
-	_ = x[ListVariableSetsAction-42]
-	_ = x[GetVariableSetAction-43]
-	_ = x[DeleteVariableSetAction-44]
-	_ = x[CreateVariableSetVariableAction-45]
-	_ = x[UpdateVariableSetVariableAction-46]
-	_ = x[GetVariableSetVariableAction-47]
-	_ = x[DeleteVariableSetVariableAction-48]
-	_ = x[AddVariableToSetAction-49]
-	_ = x[RemoveVariableFromSetAction-50]
-	_ = x[ApplyVariableSetToWorkspacesAction-51]
-	_ = x[DeleteVariableSetFromWorkspacesAction-52]
-	_ = x[GetRunAction-53]
-	_ = x[ListRunsAction-54]
-	_ = x[ApplyRunAction-55]
-	_ = x[CreateRunAction-56]
-	_ = x[DiscardRunAction-57]
-	_ = x[DeleteRunAction-58]
-	_ = x[CancelRunAction-59]
-	_ = x[ForceCancelRunAction-60]
-	_ = x[EnqueuePlanAction-61]
-	_ = x[PutChunkAction-62]
-	_ = x[TailLogsAction-63]
-	_ = x[GetPlanFileAction-64]
-	_ = x[UploadPlanFileAction-65]
-	_ = x[GetLockFileAction-66]
-	_ = x[UploadLockFileAction-67]
-	_ = x[ListWorkspacesAction-68]
-	_ = x[GetWorkspaceAction-69]
-	_ = x[CreateWorkspaceAction-70]
-	_ = x[DeleteWorkspaceAction-71]
-	_ = x[SetWorkspacePermissionAction-72]
-	_ = x[UnsetWorkspacePermissionAction-73]
-	_ = x[UpdateWorkspaceAction-74]
-	_ = x[ListTagsAction-75]
-	_ = x[DeleteTagsAction-76]
-	_ = x[TagWorkspacesAction-77]
-	_ = x[AddTagsAction-78]
-	_ = x[RemoveTagsAction-79]
-	_ = x[ListWorkspaceTags-80]
-	_ = x[LockWorkspaceAction-81]
-	_ = x[UnlockWorkspaceAction-82]
-	_ = x[ForceUnlockWorkspaceAction-83]
-	_ = x[CreateStateVersionAction-84]
-	_ = x[ListStateVersionsAction-85]
-	_ = x[GetStateVersionAction-86]
-	_ = x[DeleteStateVersionAction-87]
-	_ = x[RollbackStateVersionAction-88]
-	_ = x[UploadStateAction-89]
-	_ = x[DownloadStateAction-90]
-	_ = x[GetStateVersionOutputAction-91]
-	_ = x[CreateConfigurationVersionAction-92]
-	_ = x[ListConfigurationVersionsAction-93]
-	_ = x[GetConfigurationVersionAction-94]
-	_ = x[DownloadConfigurationVersionAction-95]
-	_ = x[DeleteConfigurationVersionAction-96]
-	_ = x[CreateUserAction-97]
-	_ = x[ListUsersAction-98]
-	_ = x[GetUserAction-99]
-	_ = x[DeleteUserAction-100]
-	_ = x[CreateTeamAction-101]
-	_ = x[UpdateTeamAction-102]
-	_ = x[GetTeamAction-103]
-	_ = x[ListTeamsAction-104]
-	_ = x[DeleteTeamAction-105]
-	_ = x[AddTeamMembershipAction-106]
-	_ = x[RemoveTeamMembershipAction-107]
-	_ = x[CreateNotificationConfigurationAction-108]
-	_ = x[UpdateNotificationConfigurationAction-109]
-	_ = x[ListNotificationConfigurationsAction-110]
-	_ = x[GetNotificationConfigurationAction-111]
-	_ = x[DeleteNotificationConfigurationAction-112]
-	_ = x[CreateGithubAppAction-113]
-	_ = x[UpdateGithubAppAction-114]
-	_ = x[GetGithubAppAction-115]
-	_ = x[ListGithubAppsAction-116]
-	_ = x[DeleteGithubAppAction-117]
-	_ = x[CreateGithubAppInstallAction-118]
-	_ = x[DeleteGithubAppInstallAction-119]
+	_ = x[CreateSSHKeyAction-11]
+	_ = x[UpdateSSHKeyAction-12]
+	_ = x[ListSSHKeysAction-13]
+	_ = x[GetSSHKeyAction-14]
+	_ = x[DeleteSSHKeyAction-15]
+	_ = x[GetWorkspaceSSHKeyAction-16]
+	_ = x[CreateAgentPoolAction-17]
+	_ = x[UpdateAgentPoolAction-18]
+	_ = x[ListAgentPoolsAction-19]
+	_ = x[GetAgentPoolAction-20]
+	_ = x[DeleteAgentPoolAction-21]
+	_ = x[CreateAgentTokenAction-22]
+	_ = x[ListAgentTokensAction-23]
+	_ = x[GetAgentTokenAction-24]
+	_ = x[DeleteAgentTokenAction-25]
+	_ = x[ListAgentsAction-26]
+	_ = x[WatchAgentsAction-27]
+	_ = x[CreateOrganizationTokenAction-28]
+	_ = x[DeleteOrganizationTokenAction-29]
+	_ = x[CreateOrganizationImportAction-30]
+	_ = x[ListOrganizationImportsAction-31]
+	_ = x[GetOrganizationImportAction-32]
+	_ = x[CreateRunTokenAction-33]
+	_ = x[CreateTeamTokenAction-34]
+	_ = x[GetTeamTokenAction-35]
+	_ = x[DeleteTeamTokenAction-36]
+	_ = x[CreateModuleAction-37]
+	_ = x[CreateModuleVersionAction-38]
+	_ = x[UpdateModuleAction-39]
+	_ = x[ListModulesAction-40]
+	_ = x[GetModuleAction-41]
+	_ = x[DeleteModuleAction-42]
+	_ = x[DeleteModuleVersionAction-43]
+	_ = x[CreateRegistryProviderAction-44]
+	_ = x[ListRegistryProvidersAction-45]
+	_ = x[GetRegistryProviderAction-46]
+	_ = x[DeleteRegistryProviderAction-47]
+	_ = x[CreateRegistryProviderVersionAction-48]
+	_ = x[DeleteRegistryProviderVersionAction-49]
+	_ = x[CreateGPGKeyAction-50]
+	_ = x[ListGPGKeysAction-51]
+	_ = x[GetGPGKeyAction-52]
+	_ = x[UpdateGPGKeyAction-53]
+	_ = x[DeleteGPGKeyAction-54]
+	_ = x[CreateWorkspaceVariableAction-55]
+	_ = x[UpdateWorkspaceVariableAction-56]
+	_ = x[ListWorkspaceVariablesAction-57]
+	_ = x[GetWorkspaceVariableAction-58]
+	_ = x[DeleteWorkspaceVariableAction-59]
+	_ = x[CreateVariableSetAction-60]
+	_ = x[UpdateVariableSetAction-61]
+	_ = x[ListVariableSetsAction-62]
+	_ = x[GetVariableSetAction-63]
+	_ = x[DeleteVariableSetAction-64]
+	_ = x[CreateVariableSetVariableAction-65]
+	_ = x[UpdateVariableSetVariableAction-66]
+	_ = x[GetVariableSetVariableAction-67]
+	_ = x[DeleteVariableSetVariableAction-68]
+	_ = x[AddVariableToSetAction-69]
+	_ = x[RemoveVariableFromSetAction-70]
+	_ = x[ApplyVariableSetToWorkspacesAction-71]
+	_ = x[DeleteVariableSetFromWorkspacesAction-72]
+	_ = x[CreatePolicySetAction-73]
+	_ = x[UpdatePolicySetAction-74]
+	_ = x[ListPolicySetsAction-75]
+	_ = x[GetPolicySetAction-76]
+	_ = x[DeletePolicySetAction-77]
+	_ = x[ListWorkspacePolicySetsAction-78]
+	_ = x[GetRunAction-79]
+	_ = x[ListRunsAction-80]
+	_ = x[ApplyRunAction-81]
+	_ = x[OverrideProtectionRulesAction-82]
+	_ = x[OverridePolicyCheckAction-83]
+	_ = x[CreateRunAction-84]
+	_ = x[DiscardRunAction-85]
+	_ = x[DeleteRunAction-86]
+	_ = x[CancelRunAction-87]
+	_ = x[ForceCancelRunAction-88]
+	_ = x[EnqueuePlanAction-89]
+	_ = x[EnqueueApplyAction-90]
+	_ = x[PutChunkAction-91]
+	_ = x[TailLogsAction-92]
+	_ = x[GetPlanFileAction-93]
+	_ = x[UploadPlanFileAction-94]
+	_ = x[GetLockFileAction-95]
+	_ = x[UploadLockFileAction-96]
+	_ = x[ListWorkspacesAction-97]
+	_ = x[GetWorkspaceAction-98]
+	_ = x[CreateWorkspaceAction-99]
+	_ = x[DeleteWorkspaceAction-100]
+	_ = x[SetWorkspacePermissionAction-101]
+	_ = x[UnsetWorkspacePermissionAction-102]
+	_ = x[UpdateWorkspaceAction-103]
+	_ = x[ListDeletedWorkspacesAction-104]
+	_ = x[RestoreWorkspaceAction-105]
+	_ = x[PurgeWorkspaceAction-106]
+	_ = x[ListTagsAction-107]
+	_ = x[DeleteTagsAction-108]
+	_ = x[TagWorkspacesAction-109]
+	_ = x[AddTagsAction-110]
+	_ = x[RemoveTagsAction-111]
+	_ = x[ListWorkspaceTags-112]
+	_ = x[LockWorkspaceAction-113]
+	_ = x[UnlockWorkspaceAction-114]
+	_ = x[ForceUnlockWorkspaceAction-115]
+	_ = x[CreateStateVersionAction-116]
+	_ = x[ListStateVersionsAction-117]
+	_ = x[GetStateVersionAction-118]
+	_ = x[DeleteStateVersionAction-119]
+	_ = x[RollbackStateVersionAction-120]
+	_ = x[UploadStateAction-121]
+	_ = x[DownloadStateAction-122]
+	_ = x[GetStateVersionOutputAction-123]
+	_ = x[CreateConfigurationVersionAction-124]
+	_ = x[ListConfigurationVersionsAction-125]
+	_ = x[GetConfigurationVersionAction-126]
+	_ = x[DownloadConfigurationVersionAction-127]
+	_ = x[DeleteConfigurationVersionAction-128]
+	_ = x[GetConfigurationVersionUsageAction-129]
+	_ = x[GetConsumptionReportAction-130]
+	_ = x[CreateUserAction-131]
+	_ = x[ListUsersAction-132]
+	_ = x[GetUserAction-133]
+	_ = x[DeleteUserAction-134]
+	_ = x[CreateTeamAction-135]
+	_ = x[UpdateTeamAction-136]
+	_ = x[GetTeamAction-137]
+	_ = x[ListTeamsAction-138]
+	_ = x[DeleteTeamAction-139]
+	_ = x[AddTeamMembershipAction-140]
+	_ = x[RemoveTeamMembershipAction-141]
+	_ = x[CreateOrganizationMembershipAction-142]
+	_ = x[ListOrganizationMembershipsAction-143]
+	_ = x[GetOrganizationMembershipAction-144]
+	_ = x[DeleteOrganizationMembershipAction-145]
+	_ = x[CreateNotificationConfigurationAction-146]
+	_ = x[UpdateNotificationConfigurationAction-147]
+	_ = x[ListNotificationConfigurationsAction-148]
+	_ = x[GetNotificationConfigurationAction-149]
+	_ = x[DeleteNotificationConfigurationAction-150]
+	_ = x[CreateRunTriggerAction-151]
+	_ = x[ListRunTriggersAction-152]
+	_ = x[GetRunTriggerAction-153]
+	_ = x[DeleteRunTriggerAction-154]
+	_ = x[CreateRunTaskAction-155]
+	_ = x[ListRunTasksAction-156]
+	_ = x[GetRunTaskAction-157]
+	_ = x[UpdateRunTaskAction-158]
+	_ = x[DeleteRunTaskAction-159]
+	_ = x[GetRunTaskSettingsAction-160]
+	_ = x[UpdateRunTaskSettingsAction-161]
+	_ = x[CreateWorkspaceRunTaskAction-162]
+	_ = x[ListWorkspaceRunTasksAction-163]
+	_ = x[GetWorkspaceRunTaskAction-164]
+	_ = x[UpdateWorkspaceRunTaskAction-165]
+	_ = x[DeleteWorkspaceRunTaskAction-166]
+	_ = x[ListAssessmentResultsAction-167]
+	_ = x[GetAssessmentResultAction-168]
+	_ = x[GetOrganizationMetricsAction-169]
+	_ = x[ListRunAnnotationsAction-170]
+	_ = x[ListResourceChangesAction-171]
+	_ = x[DebugRunVariablesAction-172]
+	_ = x[CreateBannerAction-173]
+	_ = x[ListBannersAction-174]
+	_ = x[DeleteBannerAction-175]
+	_ = x[ListVCSEventDeadLettersAction-176]
+	_ = x[RedriveVCSEventDeadLetterAction-177]
+	_ = x[DeleteVCSEventDeadLetterAction-178]
+	_ = x[ListFeatureFlagsAction-179]
+	_ = x[UpdateFeatureFlagAction-180]
+	_ = x[StartBlobMigrationAction-181]
+	_ = x[ListBlobMigrationsAction-182]
+	_ = x[GetBlobMigrationAction-183]
+	_ = x[UpdateBlobMigrationAction-184]
+	_ = x[GetSSOEnforcementAction-185]
+	_ = x[UpdateSSOEnforcementAction-186]
+	_ = x[ListAuditEventsAction-187]
+	_ = x[GetAuditSettingsAction-188]
+	_ = x[UpdateAuditSettingsAction-189]
+	_ = x[EnableOrganizationExportStreamAction-190]
+	_ = x[GetOrganizationExportStreamAction-191]
+	_ = x[ListOrganizationExportStreamsAction-192]
+	_ = x[DisableOrganizationExportStreamAction-193]
+	_ = x[CreateGithubAppAction-194]
+	_ = x[UpdateGithubAppAction-195]
+	_ = x[GetGithubAppAction-196]
+	_ = x[ListGithubAppsAction-197]
+	_ = x[DeleteGithubAppAction-198]
+	_ = x[CreateGithubAppInstallAction-199]
+	_ = x[DeleteGithubAppInstallAction-200]
 }
 
-const _Action_name = "WatchActionCreateOrganizationActionUpdateOrganizationActionGetOrganizationActionListOrganizationsActionGetEntitlementsActionDeleteOrganizationActionCreateVCSProviderActionGetVCSProviderActionListVCSProvidersActionDeleteVCSProviderActionCreateAgentPoolActionUpdateAgentPoolActionListAgentPoolsActionGetAgentPoolActionDeleteAgentPoolActionCreateAgentTokenActionListAgentTokensActionGetAgentTokenActionDeleteAgentTokenActionListAgentsActionWatchAgentsActionCreateOrganizationTokenActionDeleteOrganizationTokenActionCreateRunTokenActionCreateTeamTokenActionGetTeamTokenActionDeleteTeamTokenActionCreateModuleActionCreateModuleVersionActionUpdateModuleActionListModulesActionGetModuleActionDeleteModuleActionDeleteModuleVersionActionCreateWorkspaceVariableActionUpdateWorkspaceVariableActionListWorkspaceVariablesActionGetWorkspaceVariableActionDeleteWorkspaceVariableActionCreateVariableSetActionUpdateVariableSetActionListVariableSetsActionGetVariableSetActionDeleteVariableSetActionCreateVariableSetVariableActionUpdateVariableSetVariableActionGetVariableSetVariableActionDeleteVariableSetVariableActionAddVariableToSetActionRemoveVariableFromSetActionApplyVariableSetToWorkspacesActionDeleteVariableSetFromWorkspacesActionGetRunActionListRunsActionApplyRunActionCreateRunActionDiscardRunActionDeleteRunActionCancelRunActionForceCancelRunActionEnqueuePlanActionPutChunkActionTailLogsActionGetPlanFileActionUploadPlanFileActionGetLockFileActionUploadLockFileActionListWorkspacesActionGetWorkspaceActionCreateWorkspaceActionDeleteWorkspaceActionSetWorkspacePermissionActionUnsetWorkspacePermissionActionUpdateWorkspaceActionListTagsActionDeleteTagsActionTagWorkspacesActionAddTagsActionRemoveTagsActionListWorkspaceTagsLockWorkspaceActionUnlockWorkspaceActionForceUnlockWorkspaceActionCreateStateVersionActionListStateVersionsActionGetStateVersionActionDeleteStateVersionActionRollbackStateVersionActionUploadStateActionDownloadStateActionGetStateVersionOutputActionCreateConfigurationVersionActionListConfigurationVersionsActionGetConfigurationVersionActionDownloadConfigurationVersionActionDeleteConfigurationVersionActionCreateUserActionListUsersActionGetUserActionDeleteUserActionCreateTeamActionUpdateTeamActionGetTeamActionListTeamsActionDeleteTeamActionAddTeamMembershipActionRemoveTeamMembershipActionCreateNotificationConfigurationActionUpdateNotificationConfigurationActionListNotificationConfigurationsActionGetNotificationConfigurationActionDeleteNotificationConfigurationActionCreateGithubAppActionUpdateGithubAppActionGetGithubAppActionListGithubAppsActionDeleteGithubAppActionCreateGithubAppInstallActionDeleteGithubAppInstallAction"
+const _Action_name = "WatchActionCreateOrganizationActionUpdateOrganizationActionGetOrganizationActionListOrganizationsActionGetEntitlementsActionDeleteOrganizationActionCreateVCSProviderActionGetVCSProviderActionListVCSProvidersActionDeleteVCSProviderActionCreateSSHKeyActionUpdateSSHKeyActionListSSHKeysActionGetSSHKeyActionDeleteSSHKeyActionGetWorkspaceSSHKeyActionCreateAgentPoolActionUpdateAgentPoolActionListAgentPoolsActionGetAgentPoolActionDeleteAgentPoolActionCreateAgentTokenActionListAgentTokensActionGetAgentTokenActionDeleteAgentTokenActionListAgentsActionWatchAgentsActionCreateOrganizationTokenActionDeleteOrganizationTokenActionCreateOrganizationImportActionListOrganizationImportsActionGetOrganizationImportActionCreateRunTokenActionCreateTeamTokenActionGetTeamTokenActionDeleteTeamTokenActionCreateModuleActionCreateModuleVersionActionUpdateModuleActionListModulesActionGetModuleActionDeleteModuleActionDeleteModuleVersionActionCreateRegistryProviderActionListRegistryProvidersActionGetRegistryProviderActionDeleteRegistryProviderActionCreateRegistryProviderVersionActionDeleteRegistryProviderVersionActionCreateGPGKeyActionListGPGKeysActionGetGPGKeyActionUpdateGPGKeyActionDeleteGPGKeyActionCreateWorkspaceVariableActionUpdateWorkspaceVariableActionListWorkspaceVariablesActionGetWorkspaceVariableActionDeleteWorkspaceVariableActionCreateVariableSetActionUpdateVariableSetActionListVariableSetsActionGetVariableSetActionDeleteVariableSetActionCreateVariableSetVariableActionUpdateVariableSetVariableActionGetVariableSetVariableActionDeleteVariableSetVariableActionAddVariableToSetActionRemoveVariableFromSetActionApplyVariableSetToWorkspacesActionDeleteVariableSetFromWorkspacesActionCreatePolicySetActionUpdatePolicySetActionListPolicySetsActionGetPolicySetActionDeletePolicySetActionListWorkspacePolicySetsActionGetRunActionListRunsActionApplyRunActionOverrideProtectionRulesActionOverridePolicyCheckActionCreateRunActionDiscardRunActionDeleteRunActionCancelRunActionForceCancelRunActionEnqueuePlanActionEnqueueApplyActionPutChunkActionTailLogsActionGetPlanFileActionUploadPlanFileActionGetLockFileActionUploadLockFileActionListWorkspacesActionGetWorkspaceActionCreateWorkspaceActionDeleteWorkspaceActionSetWorkspacePermissionActionUnsetWorkspacePermissionActionUpdateWorkspaceActionListDeletedWorkspacesActionRestoreWorkspaceActionPurgeWorkspaceActionListTagsActionDeleteTagsActionTagWorkspacesActionAddTagsActionRemoveTagsActionListWorkspaceTagsLockWorkspaceActionUnlockWorkspaceActionForceUnlockWorkspaceActionCreateStateVersionActionListStateVersionsActionGetStateVersionActionDeleteStateVersionActionRollbackStateVersionActionUploadStateActionDownloadStateActionGetStateVersionOutputActionCreateConfigurationVersionActionListConfigurationVersionsActionGetConfigurationVersionActionDownloadConfigurationVersionActionDeleteConfigurationVersionActionGetConfigurationVersionUsageActionGetConsumptionReportActionCreateUserActionListUsersActionGetUserActionDeleteUserActionCreateTeamActionUpdateTeamActionGetTeamActionListTeamsActionDeleteTeamActionAddTeamMembershipActionRemoveTeamMembershipActionCreateOrganizationMembershipActionListOrganizationMembershipsActionGetOrganizationMembershipActionDeleteOrganizationMembershipActionCreateNotificationConfigurationActionUpdateNotificationConfigurationActionListNotificationConfigurationsActionGetNotificationConfigurationActionDeleteNotificationConfigurationActionCreateRunTriggerActionListRunTriggersActionGetRunTriggerActionDeleteRunTriggerActionCreateRunTaskActionListRunTasksActionGetRunTaskActionUpdateRunTaskActionDeleteRunTaskActionGetRunTaskSettingsActionUpdateRunTaskSettingsActionCreateWorkspaceRunTaskActionListWorkspaceRunTasksActionGetWorkspaceRunTaskActionUpdateWorkspaceRunTaskActionDeleteWorkspaceRunTaskActionListAssessmentResultsActionGetAssessmentResultActionGetOrganizationMetricsActionListRunAnnotationsActionListResourceChangesActionDebugRunVariablesActionCreateBannerActionListBannersActionDeleteBannerActionListVCSEventDeadLettersActionRedriveVCSEventDeadLetterActionDeleteVCSEventDeadLetterActionListFeatureFlagsActionUpdateFeatureFlagActionStartBlobMigrationActionListBlobMigrationsActionGetBlobMigrationActionUpdateBlobMigrationActionGetSSOEnforcementActionUpdateSSOEnforcementActionListAuditEventsActionGetAuditSettingsActionUpdateAuditSettingsActionEnableOrganizationExportStreamActionGetOrganizationExportStreamActionListOrganizationExportStreamsActionDisableOrganizationExportStreamActionCreateGithubAppActionUpdateGithubAppActionGetGithubAppActionListGithubAppsActionDeleteGithubAppActionCreateGithubAppInstallActionDeleteGithubAppInstallAction"
 
-var _Action_index = [...]uint16{0, 11, 35, 59, 80, 103, 124, 148, 171, 191, 213, 236, 257, 278, 298, 316, 337, 359, 380, 399, 421, 437, 454, 483, 512, 532, 553, 571, 592, 610, 635, 653, 670, 685, 703, 728, 757, 786, 814, 840, 869, 892, 915, 937, 957, 980, 1011, 1042, 1070, 1101, 1123, 1150, 1184, 1221, 1233, 1247, 1261, 1276, 1292, 1307, 1322, 1342, 1359, 1373, 1387, 1404, 1424, 1441, 1461, 1481, 1499, 1520, 1541, 1569, 1599, 1620, 1634, 1650, 1669, 1682, 1698, 1715, 1734, 1755, 1781, 1805, 1828, 1849, 1873, 1899, 1916, 1935, 1962, 1994, 2025, 2054, 2088, 2120, 2136, 2151, 2164, 2180, 2196, 2212, 2225, 2240, 2256, 2279, 2305, 2342, 2379, 2415, 2449, 2486, 2507, 2528, 2546, 2566, 2587, 2615, 2643}
+var _Action_index = [...]uint16{0, 11, 35, 59, 80, 103, 124, 148, 171, 191, 213, 236, 254, 272, 289, 304, 322, 346, 367, 388, 408, 426, 447, 469, 490, 509, 531, 547, 564, 593, 622, 652, 681, 708, 728, 749, 767, 788, 806, 831, 849, 866, 881, 899, 924, 952, 979, 1004, 1032, 1067, 1102, 1120, 1137, 1152, 1170, 1188, 1217, 1246, 1274, 1300, 1329, 1352, 1375, 1397, 1417, 1440, 1471, 1502, 1530, 1561, 1583, 1610, 1644, 1681, 1702, 1723, 1743, 1761, 1782, 1811, 1823, 1837, 1851, 1880, 1905, 1920, 1936, 1951, 1966, 1986, 2003, 2021, 2035, 2049, 2066, 2086, 2103, 2123, 2143, 2161, 2182, 2203, 2231, 2261, 2282, 2309, 2331, 2351, 2365, 2381, 2400, 2413, 2429, 2446, 2465, 2486, 2512, 2536, 2559, 2580, 2604, 2630, 2647, 2666, 2693, 2725, 2756, 2785, 2819, 2851, 2885, 2911, 2927, 2942, 2955, 2971, 2987, 3003, 3016, 3031, 3047, 3070, 3096, 3130, 3163, 3194, 3228, 3265, 3302, 3338, 3372, 3409, 3431, 3452, 3471, 3493, 3512, 3530, 3546, 3565, 3584, 3608, 3635, 3663, 3690, 3715, 3743, 3771, 3798, 3823, 3851, 3875, 3900, 3923, 3941, 3958, 3976, 4005, 4036, 4066, 4088, 4111, 4135, 4159, 4181, 4206, 4229, 4255, 4276, 4298, 4323, 4359, 4392, 4427, 4464, 4485, 4506, 4524, 4544, 4565, 4593, 4621}
 
 func (i Action) String() string {
-	if i < 0 || i >= Action(len(_Action_index)-1) {
+	idx := int(i) - 0
+	if i < 0 || idx >= len(_Action_index)-1 {
 		return "Action(" + strconv.FormatInt(int64(i), 10) + ")"
 	}
-	return _Action_name[_Action_index[i]:_Action_index[i+1]]
+	return _Action_name[_Action_index[idx]:_Action_index[idx+1]]
 }
@@ -0,0 +1,25 @@
+package rbac
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseAction(t *testing.T) {
+	got, err := ParseAction("ApplyRunAction")
+	require.NoError(t, err)
+	assert.Equal(t, ApplyRunAction, got)
+
+	got, err = ParseAction("ApplyRun")
+	require.NoError(t, err)
+	assert.Equal(t, ApplyRunAction, got)
+
+	got, err = ParseAction("WatchAction")
+	require.NoError(t, err)
+	assert.Equal(t, WatchAction, got)
+
+	_, err = ParseAction("DoSomethingAction")
+	assert.Error(t, err)
+}
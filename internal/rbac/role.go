@@ -9,21 +9,32 @@ var (
 	OrganizationMinPermissions = Role{
 		name: "minimum",
 		permissions: map[Action]bool{
-			GetOrganizationAction:  true,
-			GetEntitlementsAction:  true,
-			ListModulesAction:      true,
-			GetModuleAction:        true,
-			GetTeamAction:          true,
-			ListTeamsAction:        true,
-			GetUserAction:          true,
-			ListUsersAction:        true,
-			ListTagsAction:         true,
-			ListVCSProvidersAction: true,
-			GetVCSProviderAction:   true,
-			ListVariableSetsAction: true,
-			GetVariableSetAction:   true,
-			WatchAgentsAction:      true,
-			ListAgentsAction:       true,
+			GetOrganizationAction:             true,
+			GetEntitlementsAction:             true,
+			ListModulesAction:                 true,
+			GetModuleAction:                   true,
+			ListRegistryProvidersAction:       true,
+			GetRegistryProviderAction:         true,
+			ListGPGKeysAction:                 true,
+			GetGPGKeyAction:                   true,
+			GetTeamAction:                     true,
+			ListTeamsAction:                   true,
+			GetUserAction:                     true,
+			ListUsersAction:                   true,
+			ListOrganizationMembershipsAction: true,
+			GetOrganizationMembershipAction:   true,
+			ListTagsAction:                    true,
+			ListVCSProvidersAction:            true,
+			GetVCSProviderAction:              true,
+			ListVariableSetsAction:            true,
+			GetVariableSetAction:              true,
+			WatchAgentsAction:                 true,
+			ListAgentsAction:                  true,
+			ListPolicySetsAction:              true,
+			GetPolicySetAction:                true,
+			ListRunTasksAction:                true,
+			GetRunTaskAction:                  true,
+			GetRunTaskSettingsAction:          true,
 		},
 	}
 
@@ -48,6 +59,15 @@ var (
 			TailLogsAction:                       true,
 			ListNotificationConfigurationsAction: true,
 			GetNotificationConfigurationAction:   true,
+			ListRunTriggersAction:                true,
+			GetRunTriggerAction:                  true,
+			ListWorkspacePolicySetsAction:        true,
+			ListWorkspaceRunTasksAction:          true,
+			GetWorkspaceRunTaskAction:            true,
+			ListAssessmentResultsAction:          true,
+			GetAssessmentResultAction:            true,
+			ListRunAnnotationsAction:             true,
+			ListResourceChangesAction:            true,
 		},
 	}
 
@@ -74,6 +94,7 @@ var (
 			CreateWorkspaceVariableAction:         true,
 			UpdateWorkspaceVariableAction:         true,
 			DeleteWorkspaceVariableAction:         true,
+			DebugRunVariablesAction:               true,
 			CreateNotificationConfigurationAction: true,
 			UpdateNotificationConfigurationAction: true,
 			DeleteNotificationConfigurationAction: true,
@@ -91,6 +112,12 @@ var (
 			DeleteWorkspaceAction:          true,
 			ForceUnlockWorkspaceAction:     true,
 			UpdateWorkspaceAction:          true,
+			CreateRunTriggerAction:         true,
+			DeleteRunTriggerAction:         true,
+			OverrideProtectionRulesAction:  true,
+			CreateWorkspaceRunTaskAction:   true,
+			UpdateWorkspaceRunTaskAction:   true,
+			DeleteWorkspaceRunTaskAction:   true,
 		},
 		inherits: &WorkspaceWriteRole,
 	}
@@ -110,24 +137,60 @@ var (
 	}
 
 	// VCSManagerRole is scoped to an organization and permits management of VCS
-	// providers.
+	// providers and SSH keys.
 	VCSManagerRole = Role{
 		name: "vcs-manager",
 		permissions: map[Action]bool{
 			CreateVCSProviderAction: true,
 			DeleteVCSProviderAction: true,
+			CreateSSHKeyAction:      true,
+			UpdateSSHKeyAction:      true,
+			ListSSHKeysAction:       true,
+			GetSSHKeyAction:         true,
+			DeleteSSHKeyAction:      true,
 		},
 	}
 
 	// RegistryManagerRole is scoped to an organization and permits management
-	// of registry of modules and providers
+	// of registry of modules and providers, and reporting on their consumption
 	RegistryManagerRole = Role{
 		name: "registry-manager",
 		permissions: map[Action]bool{
-			CreateModuleAction:        true,
-			CreateModuleVersionAction: true,
-			UpdateModuleAction:        true,
-			DeleteModuleAction:        true,
+			CreateModuleAction:         true,
+			CreateModuleVersionAction:  true,
+			UpdateModuleAction:         true,
+			DeleteModuleAction:         true,
+			DeleteModuleVersionAction:  true,
+			GetConsumptionReportAction: true,
+
+			CreateRegistryProviderAction:        true,
+			DeleteRegistryProviderAction:        true,
+			CreateRegistryProviderVersionAction: true,
+			DeleteRegistryProviderVersionAction: true,
+
+			CreateGPGKeyAction: true,
+			UpdateGPGKeyAction: true,
+			DeleteGPGKeyAction: true,
+		},
+	}
+
+	// PolicyManagerRole is scoped to an organization and permits management of
+	// policy sets.
+	PolicyManagerRole = Role{
+		name: "policy-manager",
+		permissions: map[Action]bool{
+			CreatePolicySetAction: true,
+			UpdatePolicySetAction: true,
+			DeletePolicySetAction: true,
+		},
+	}
+
+	// PolicyOverrideRole is scoped to an organization and permits overriding
+	// soft-mandatory policy check failures.
+	PolicyOverrideRole = Role{
+		name: "policy-override",
+		permissions: map[Action]bool{
+			OverridePolicyCheckAction: true,
 		},
 	}
 )
@@ -0,0 +1,262 @@
+package registryprovider
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/leg100/otf/internal"
+	ihttp "github.com/leg100/otf/internal/http"
+	"github.com/leg100/otf/internal/http/decode"
+	"github.com/leg100/otf/internal/tfeapi"
+	"github.com/leg100/surl"
+)
+
+// api handles the signed URLs for uploading and downloading the assets of
+// provider versions and platforms.
+type api struct {
+	*surl.Signer
+
+	svc *Service
+}
+
+func shasumsPath(versionID string) string {
+	return "/registry-providers/versions/" + versionID + "/shasums"
+}
+
+func shasumsSigPath(versionID string) string {
+	return "/registry-providers/versions/" + versionID + "/shasums.sig"
+}
+
+func binaryPath(platformID string) string {
+	return "/registry-providers/platforms/" + platformID + "/binary"
+}
+
+func (h *api) addHandlers(r *mux.Router) {
+	// signed routes
+	signed := r.PathPrefix("/signed/{signature.expiry}").Subrouter()
+	signed.Use(internal.VerifySignedURL(h.Signer))
+
+	signed.HandleFunc("/registry-providers/versions/{id}/shasums", h.upload(h.svc.uploadShasums)).Methods("PUT")
+	signed.HandleFunc("/registry-providers/versions/{id}/shasums", h.download(h.svc.downloadShasums)).Methods("GET")
+	signed.HandleFunc("/registry-providers/versions/{id}/shasums.sig", h.upload(h.svc.uploadShasumsSig)).Methods("PUT")
+	signed.HandleFunc("/registry-providers/versions/{id}/shasums.sig", h.download(h.svc.downloadShasumsSig)).Methods("GET")
+	signed.HandleFunc("/registry-providers/platforms/{id}/binary", h.upload(h.svc.uploadBinary)).Methods("PUT")
+	signed.HandleFunc("/registry-providers/platforms/{id}/binary", h.download(h.svc.downloadBinary)).Methods("GET")
+
+	// authenticated provider api routes
+	//
+	// Implements the Provider Registry Protocol:
+	//
+	// https://developer.hashicorp.com/terraform/internals/provider-registry-protocol
+	r = r.PathPrefix(tfeapi.ProviderV1Prefix).Subrouter()
+
+	r.HandleFunc("/{namespace}/{type}/versions", h.listAvailableVersions).Methods("GET")
+	r.HandleFunc("/{namespace}/{type}/{version}/download/{os}/{arch}", h.findPackage).Methods("GET")
+}
+
+type (
+	listAvailableVersionsResponse struct {
+		Versions []availableVersion `json:"versions"`
+	}
+	availableVersion struct {
+		Version   string              `json:"version"`
+		Protocols []string            `json:"protocols"`
+		Platforms []availablePlatform `json:"platforms"`
+	}
+	availablePlatform struct {
+		OS   string `json:"os"`
+		Arch string `json:"arch"`
+	}
+
+	findPackageResponse struct {
+		Protocols           []string    `json:"protocols"`
+		OS                  string      `json:"os"`
+		Arch                string      `json:"arch"`
+		Filename            string      `json:"filename"`
+		DownloadURL         string      `json:"download_url"`
+		ShasumsURL          string      `json:"shasums_url"`
+		ShasumsSignatureURL string      `json:"shasums_signature_url"`
+		Shasum              string      `json:"shasum"`
+		SigningKeys         signingKeys `json:"signing_keys"`
+	}
+	signingKeys struct {
+		GPGPublicKeys []gpgPublicKey `json:"gpg_public_keys"`
+	}
+	gpgPublicKey struct {
+		KeyID      string `json:"key_id"`
+		ASCIIArmor string `json:"ascii_armor"`
+	}
+)
+
+// List Available Versions
+//
+// https://developer.hashicorp.com/terraform/internals/provider-registry-protocol#list-available-versions
+func (h *api) listAvailableVersions(w http.ResponseWriter, r *http.Request) {
+	var params struct {
+		Namespace string `schema:"namespace,required"`
+		Type      string `schema:"type,required"`
+	}
+	if err := decode.Route(&params, r); err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	spec := ProviderSpec{Organization: params.Namespace, Name: params.Type}
+
+	versions, err := h.svc.ListVersions(r.Context(), spec)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	response := listAvailableVersionsResponse{Versions: []availableVersion{}}
+	for _, v := range versions {
+		// only versions whose shasums have been uploaded can be installed
+		if !v.ShasumsUploaded || !v.ShasumsSigUploaded {
+			continue
+		}
+		platforms, err := h.svc.ListPlatforms(r.Context(), spec, v.Version)
+		if err != nil {
+			tfeapi.Error(w, err)
+			return
+		}
+		available := availableVersion{
+			Version:   v.Version,
+			Protocols: v.Protocols,
+			Platforms: []availablePlatform{},
+		}
+		for _, p := range platforms {
+			if p.BinaryUploaded {
+				available.Platforms = append(available.Platforms, availablePlatform{OS: p.OS, Arch: p.Arch})
+			}
+		}
+		response.Versions = append(response.Versions, available)
+	}
+
+	w.Header().Set("Content-type", "application/json")
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+// Find a Provider Package
+//
+// https://developer.hashicorp.com/terraform/internals/provider-registry-protocol#find-a-provider-package
+func (h *api) findPackage(w http.ResponseWriter, r *http.Request) {
+	var params struct {
+		Namespace string `schema:"namespace,required"`
+		Type      string `schema:"type,required"`
+		Version   string `schema:"version,required"`
+		OS        string `schema:"os,required"`
+		Arch      string `schema:"arch,required"`
+	}
+	if err := decode.Route(&params, r); err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	spec := ProviderSpec{Organization: params.Namespace, Name: params.Type}
+
+	v, err := h.svc.GetVersion(r.Context(), spec, params.Version)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	p, err := h.svc.GetPlatform(r.Context(), spec, params.Version, params.OS, params.Arch)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	if !v.ShasumsUploaded || !v.ShasumsSigUploaded || !p.BinaryUploaded {
+		tfeapi.Error(w, internal.ErrResourceNotFound)
+		return
+	}
+
+	response := findPackageResponse{
+		Protocols: v.Protocols,
+		OS:        p.OS,
+		Arch:      p.Arch,
+		Filename:  p.Filename,
+		Shasum:    p.Shasum,
+		SigningKeys: signingKeys{
+			GPGPublicKeys: []gpgPublicKey{},
+		},
+	}
+	key, err := h.svc.getSigningKey(r.Context(), spec.Organization, v)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	if key != nil {
+		response.SigningKeys.GPGPublicKeys = append(response.SigningKeys.GPGPublicKeys, gpgPublicKey{
+			KeyID:      key.KeyID,
+			ASCIIArmor: key.ASCIIArmor,
+		})
+	}
+	if response.DownloadURL, err = h.signedURL(r, binaryPath(p.ID)); err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	if response.ShasumsURL, err = h.signedURL(r, shasumsPath(v.ID)); err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	if response.ShasumsSignatureURL, err = h.signedURL(r, shasumsSigPath(v.ID)); err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+
+	w.Header().Set("Content-type", "application/json")
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+func (h *api) upload(fn func(ctx context.Context, id string, content []byte) error) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, err := decode.Param("id", r)
+		if err != nil {
+			tfeapi.Error(w, err)
+			return
+		}
+
+		content, err := io.ReadAll(r.Body)
+		if err != nil {
+			tfeapi.Error(w, err)
+			return
+		}
+
+		if err := fn(r.Context(), id, content); err != nil {
+			tfeapi.Error(w, err)
+			return
+		}
+	}
+}
+
+func (h *api) download(fn func(ctx context.Context, id string) ([]byte, error)) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, err := decode.Param("id", r)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusUnprocessableEntity)
+			return
+		}
+
+		content, err := fn(r.Context(), id)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		}
+
+		w.Write(content)
+	}
+}
+
+// signedURL returns an absolute signed URL for downloading an asset.
+func (h *api) signedURL(r *http.Request, path string) (string, error) {
+	signed, err := h.Sign(path, time.Hour)
+	if err != nil {
+		return "", err
+	}
+	return ihttp.Absolute(r, signed), nil
+}
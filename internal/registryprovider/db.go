@@ -0,0 +1,344 @@
+package registryprovider
+
+import (
+	"context"
+
+	"github.com/jackc/pgtype"
+	"github.com/leg100/otf/internal"
+	"github.com/leg100/otf/internal/sql"
+	"github.com/leg100/otf/internal/sql/pggen"
+)
+
+type (
+	// pgdb is a database of registry providers on postgres
+	pgdb struct {
+		*sql.DB // provides access to generated SQL queries
+	}
+
+	providerRow struct {
+		RegistryProviderID pgtype.Text        `json:"registry_provider_id"`
+		CreatedAt          pgtype.Timestamptz `json:"created_at"`
+		UpdatedAt          pgtype.Timestamptz `json:"updated_at"`
+		Name               pgtype.Text        `json:"name"`
+		OrganizationName   pgtype.Text        `json:"organization_name"`
+	}
+
+	versionRow struct {
+		RegistryProviderVersionID pgtype.Text        `json:"registry_provider_version_id"`
+		CreatedAt                 pgtype.Timestamptz `json:"created_at"`
+		UpdatedAt                 pgtype.Timestamptz `json:"updated_at"`
+		Version                   pgtype.Text        `json:"version"`
+		KeyID                     pgtype.Text        `json:"key_id"`
+		Protocols                 []string           `json:"protocols"`
+		ShasumsUploaded           pgtype.Bool        `json:"shasums_uploaded"`
+		ShasumsSigUploaded        pgtype.Bool        `json:"shasums_sig_uploaded"`
+		RegistryProviderID        pgtype.Text        `json:"registry_provider_id"`
+	}
+
+	platformRow struct {
+		RegistryProviderPlatformID pgtype.Text        `json:"registry_provider_platform_id"`
+		CreatedAt                  pgtype.Timestamptz `json:"created_at"`
+		UpdatedAt                  pgtype.Timestamptz `json:"updated_at"`
+		Os                         pgtype.Text        `json:"os"`
+		Arch                       pgtype.Text        `json:"arch"`
+		Filename                   pgtype.Text        `json:"filename"`
+		Shasum                     pgtype.Text        `json:"shasum"`
+		BinaryUploaded             pgtype.Bool        `json:"binary_uploaded"`
+		RegistryProviderVersionID  pgtype.Text        `json:"registry_provider_version_id"`
+	}
+
+	gpgKeyRow struct {
+		RegistryGpgKeyID pgtype.Text        `json:"registry_gpg_key_id"`
+		CreatedAt        pgtype.Timestamptz `json:"created_at"`
+		UpdatedAt        pgtype.Timestamptz `json:"updated_at"`
+		KeyID            pgtype.Text        `json:"key_id"`
+		AsciiArmor       pgtype.Text        `json:"ascii_armor"`
+		OrganizationName pgtype.Text        `json:"organization_name"`
+	}
+)
+
+func (r providerRow) toProvider() *Provider {
+	return &Provider{
+		ID:           r.RegistryProviderID.String,
+		CreatedAt:    r.CreatedAt.Time.UTC(),
+		UpdatedAt:    r.UpdatedAt.Time.UTC(),
+		Name:         r.Name.String,
+		Organization: r.OrganizationName.String,
+	}
+}
+
+func (r versionRow) toVersion() *Version {
+	return &Version{
+		ID:                 r.RegistryProviderVersionID.String,
+		CreatedAt:          r.CreatedAt.Time.UTC(),
+		UpdatedAt:          r.UpdatedAt.Time.UTC(),
+		ProviderID:         r.RegistryProviderID.String,
+		Version:            r.Version.String,
+		KeyID:              r.KeyID.String,
+		Protocols:          r.Protocols,
+		ShasumsUploaded:    r.ShasumsUploaded.Bool,
+		ShasumsSigUploaded: r.ShasumsSigUploaded.Bool,
+	}
+}
+
+func (r platformRow) toPlatform() *Platform {
+	return &Platform{
+		ID:             r.RegistryProviderPlatformID.String,
+		CreatedAt:      r.CreatedAt.Time.UTC(),
+		UpdatedAt:      r.UpdatedAt.Time.UTC(),
+		VersionID:      r.RegistryProviderVersionID.String,
+		OS:             r.Os.String,
+		Arch:           r.Arch.String,
+		Filename:       r.Filename.String,
+		Shasum:         r.Shasum.String,
+		BinaryUploaded: r.BinaryUploaded.Bool,
+	}
+}
+
+func (r gpgKeyRow) toGPGKey() *GPGKey {
+	return &GPGKey{
+		ID:           r.RegistryGpgKeyID.String,
+		CreatedAt:    r.CreatedAt.Time.UTC(),
+		UpdatedAt:    r.UpdatedAt.Time.UTC(),
+		Organization: r.OrganizationName.String,
+		KeyID:        r.KeyID.String,
+		ASCIIArmor:   r.AsciiArmor.String,
+	}
+}
+
+func (db *pgdb) createProvider(ctx context.Context, prov *Provider) error {
+	_, err := db.Conn(ctx).InsertRegistryProvider(ctx, pggen.InsertRegistryProviderParams{
+		RegistryProviderID: sql.String(prov.ID),
+		CreatedAt:          sql.Timestamptz(prov.CreatedAt),
+		UpdatedAt:          sql.Timestamptz(prov.UpdatedAt),
+		Name:               sql.String(prov.Name),
+		OrganizationName:   sql.String(prov.Organization),
+	})
+	return sql.Error(err)
+}
+
+func (db *pgdb) listProviders(ctx context.Context, organization string) ([]*Provider, error) {
+	rows, err := db.Conn(ctx).FindRegistryProvidersByOrganization(ctx, sql.String(organization))
+	if err != nil {
+		return nil, sql.Error(err)
+	}
+	providers := make([]*Provider, len(rows))
+	for i, r := range rows {
+		providers[i] = providerRow(r).toProvider()
+	}
+	return providers, nil
+}
+
+func (db *pgdb) getProvider(ctx context.Context, spec ProviderSpec) (*Provider, error) {
+	row, err := db.Conn(ctx).FindRegistryProviderByName(ctx, sql.String(spec.Organization), sql.String(spec.Name))
+	if err != nil {
+		return nil, sql.Error(err)
+	}
+	return providerRow(row).toProvider(), nil
+}
+
+func (db *pgdb) deleteProvider(ctx context.Context, providerID string) error {
+	_, err := db.Conn(ctx).DeleteRegistryProviderByID(ctx, sql.String(providerID))
+	return sql.Error(err)
+}
+
+func (db *pgdb) createVersion(ctx context.Context, v *Version) error {
+	_, err := db.Conn(ctx).InsertRegistryProviderVersion(ctx, pggen.InsertRegistryProviderVersionParams{
+		RegistryProviderVersionID: sql.String(v.ID),
+		CreatedAt:                 sql.Timestamptz(v.CreatedAt),
+		UpdatedAt:                 sql.Timestamptz(v.UpdatedAt),
+		Version:                   sql.String(v.Version),
+		KeyID:                     sql.String(v.KeyID),
+		Protocols:                 v.Protocols,
+		RegistryProviderID:        sql.String(v.ProviderID),
+	})
+	return sql.Error(err)
+}
+
+func (db *pgdb) listVersions(ctx context.Context, providerID string) ([]*Version, error) {
+	rows, err := db.Conn(ctx).FindRegistryProviderVersionsByProviderID(ctx, sql.String(providerID))
+	if err != nil {
+		return nil, sql.Error(err)
+	}
+	versions := make([]*Version, len(rows))
+	for i, r := range rows {
+		versions[i] = versionRow(r).toVersion()
+	}
+	return versions, nil
+}
+
+func (db *pgdb) getVersion(ctx context.Context, providerID, version string) (*Version, error) {
+	row, err := db.Conn(ctx).FindRegistryProviderVersion(ctx, sql.String(providerID), sql.String(version))
+	if err != nil {
+		return nil, sql.Error(err)
+	}
+	return versionRow(row).toVersion(), nil
+}
+
+func (db *pgdb) deleteVersion(ctx context.Context, versionID string) error {
+	_, err := db.Conn(ctx).DeleteRegistryProviderVersionByID(ctx, sql.String(versionID))
+	return sql.Error(err)
+}
+
+func (db *pgdb) uploadShasums(ctx context.Context, versionID string, shasums []byte) error {
+	result, err := db.Conn(ctx).UpdateRegistryProviderVersionShasums(ctx, pggen.UpdateRegistryProviderVersionShasumsParams{
+		Shasums:                   shasums,
+		UpdatedAt:                 sql.Timestamptz(internal.CurrentTimestamp(nil)),
+		RegistryProviderVersionID: sql.String(versionID),
+	})
+	if err != nil {
+		return sql.Error(err)
+	}
+	if result.RowsAffected() == 0 {
+		return internal.ErrResourceNotFound
+	}
+	return nil
+}
+
+func (db *pgdb) uploadShasumsSig(ctx context.Context, versionID string, sig []byte) error {
+	result, err := db.Conn(ctx).UpdateRegistryProviderVersionShasumsSig(ctx, pggen.UpdateRegistryProviderVersionShasumsSigParams{
+		ShasumsSig:                sig,
+		UpdatedAt:                 sql.Timestamptz(internal.CurrentTimestamp(nil)),
+		RegistryProviderVersionID: sql.String(versionID),
+	})
+	if err != nil {
+		return sql.Error(err)
+	}
+	if result.RowsAffected() == 0 {
+		return internal.ErrResourceNotFound
+	}
+	return nil
+}
+
+func (db *pgdb) getShasums(ctx context.Context, versionID string) ([]byte, error) {
+	shasums, err := db.Conn(ctx).FindRegistryProviderVersionShasums(ctx, sql.String(versionID))
+	if err != nil {
+		return nil, sql.Error(err)
+	}
+	return shasums, nil
+}
+
+func (db *pgdb) getShasumsSig(ctx context.Context, versionID string) ([]byte, error) {
+	sig, err := db.Conn(ctx).FindRegistryProviderVersionShasumsSig(ctx, sql.String(versionID))
+	if err != nil {
+		return nil, sql.Error(err)
+	}
+	return sig, nil
+}
+
+func (db *pgdb) createPlatform(ctx context.Context, p *Platform) error {
+	_, err := db.Conn(ctx).InsertRegistryProviderPlatform(ctx, pggen.InsertRegistryProviderPlatformParams{
+		RegistryProviderPlatformID: sql.String(p.ID),
+		CreatedAt:                  sql.Timestamptz(p.CreatedAt),
+		UpdatedAt:                  sql.Timestamptz(p.UpdatedAt),
+		Os:                         sql.String(p.OS),
+		Arch:                       sql.String(p.Arch),
+		Filename:                   sql.String(p.Filename),
+		Shasum:                     sql.String(p.Shasum),
+		RegistryProviderVersionID:  sql.String(p.VersionID),
+	})
+	return sql.Error(err)
+}
+
+func (db *pgdb) listPlatforms(ctx context.Context, versionID string) ([]*Platform, error) {
+	rows, err := db.Conn(ctx).FindRegistryProviderPlatformsByVersionID(ctx, sql.String(versionID))
+	if err != nil {
+		return nil, sql.Error(err)
+	}
+	platforms := make([]*Platform, len(rows))
+	for i, r := range rows {
+		platforms[i] = platformRow(r).toPlatform()
+	}
+	return platforms, nil
+}
+
+func (db *pgdb) getPlatform(ctx context.Context, versionID, os, arch string) (*Platform, error) {
+	row, err := db.Conn(ctx).FindRegistryProviderPlatform(ctx, pggen.FindRegistryProviderPlatformParams{
+		RegistryProviderVersionID: sql.String(versionID),
+		Os:                        sql.String(os),
+		Arch:                      sql.String(arch),
+	})
+	if err != nil {
+		return nil, sql.Error(err)
+	}
+	return platformRow(row).toPlatform(), nil
+}
+
+func (db *pgdb) getPlatformByID(ctx context.Context, platformID string) (*Platform, error) {
+	row, err := db.Conn(ctx).FindRegistryProviderPlatformByID(ctx, sql.String(platformID))
+	if err != nil {
+		return nil, sql.Error(err)
+	}
+	return platformRow(row).toPlatform(), nil
+}
+
+func (db *pgdb) deletePlatform(ctx context.Context, platformID string) error {
+	_, err := db.Conn(ctx).DeleteRegistryProviderPlatformByID(ctx, sql.String(platformID))
+	return sql.Error(err)
+}
+
+func (db *pgdb) uploadBinary(ctx context.Context, platformID string, binary []byte) error {
+	_, err := db.Conn(ctx).UpdateRegistryProviderPlatformBinary(ctx, pggen.UpdateRegistryProviderPlatformBinaryParams{
+		Data:                       binary,
+		UpdatedAt:                  sql.Timestamptz(internal.CurrentTimestamp(nil)),
+		RegistryProviderPlatformID: sql.String(platformID),
+	})
+	return sql.Error(err)
+}
+
+func (db *pgdb) getBinary(ctx context.Context, platformID string) ([]byte, error) {
+	binary, err := db.Conn(ctx).FindRegistryProviderPlatformBinary(ctx, sql.String(platformID))
+	if err != nil {
+		return nil, sql.Error(err)
+	}
+	return binary, nil
+}
+
+func (db *pgdb) createGPGKey(ctx context.Context, key *GPGKey) error {
+	_, err := db.Conn(ctx).InsertRegistryGPGKey(ctx, pggen.InsertRegistryGPGKeyParams{
+		RegistryGpgKeyID: sql.String(key.ID),
+		CreatedAt:        sql.Timestamptz(key.CreatedAt),
+		UpdatedAt:        sql.Timestamptz(key.UpdatedAt),
+		KeyID:            sql.String(key.KeyID),
+		AsciiArmor:       sql.String(key.ASCIIArmor),
+		OrganizationName: sql.String(key.Organization),
+	})
+	return sql.Error(err)
+}
+
+func (db *pgdb) listGPGKeys(ctx context.Context, organization string) ([]*GPGKey, error) {
+	rows, err := db.Conn(ctx).FindRegistryGPGKeysByOrganization(ctx, sql.String(organization))
+	if err != nil {
+		return nil, sql.Error(err)
+	}
+	keys := make([]*GPGKey, len(rows))
+	for i, r := range rows {
+		keys[i] = gpgKeyRow(r).toGPGKey()
+	}
+	return keys, nil
+}
+
+func (db *pgdb) getGPGKey(ctx context.Context, organization, keyID string) (*GPGKey, error) {
+	row, err := db.Conn(ctx).FindRegistryGPGKey(ctx, sql.String(organization), sql.String(keyID))
+	if err != nil {
+		return nil, sql.Error(err)
+	}
+	return gpgKeyRow(row).toGPGKey(), nil
+}
+
+func (db *pgdb) updateGPGKeyOrganization(ctx context.Context, id, organization string) (*GPGKey, error) {
+	row, err := db.Conn(ctx).UpdateRegistryGPGKeyOrganization(ctx, pggen.UpdateRegistryGPGKeyOrganizationParams{
+		NewOrganizationName: sql.String(organization),
+		UpdatedAt:           sql.Timestamptz(internal.CurrentTimestamp(nil)),
+		RegistryGpgKeyID:    sql.String(id),
+	})
+	if err != nil {
+		return nil, sql.Error(err)
+	}
+	return gpgKeyRow(row).toGPGKey(), nil
+}
+
+func (db *pgdb) deleteGPGKey(ctx context.Context, id string) error {
+	_, err := db.Conn(ctx).DeleteRegistryGPGKeyByID(ctx, sql.String(id))
+	return sql.Error(err)
+}
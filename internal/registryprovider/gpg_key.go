@@ -0,0 +1,68 @@
+package registryprovider
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/ProtonMail/go-crypto/openpgp"
+	"github.com/leg100/otf/internal"
+	"github.com/leg100/otf/internal/resource"
+)
+
+var ErrPrivateGPGKey = errors.New("must be a public key and not a private key")
+
+// GPGKey is a public GPG key with which the SHA256SUMS files of an
+// organization's provider versions are signed, permitting terraform to verify
+// the provider binaries it downloads.
+type GPGKey struct {
+	ID           string
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+	Organization string
+	// ID of the key: the last 16 hex characters of its fingerprint,
+	// upper-cased.
+	KeyID      string
+	ASCIIArmor string
+}
+
+func newGPGKey(organization, asciiArmor string) (*GPGKey, error) {
+	keyID, err := parseGPGKeyID(asciiArmor)
+	if err != nil {
+		return nil, &internal.InvalidParameterError{Parameter: "ascii-armor", Err: err}
+	}
+	now := internal.CurrentTimestamp(nil)
+	return &GPGKey{
+		ID:           resource.NewID(resource.GPGKeyKind),
+		CreatedAt:    now,
+		UpdatedAt:    now,
+		Organization: organization,
+		KeyID:        keyID,
+		ASCIIArmor:   asciiArmor,
+	}, nil
+}
+
+// parseGPGKeyID parses an ASCII armored public key and returns its key ID.
+func parseGPGKeyID(asciiArmor string) (string, error) {
+	entities, err := openpgp.ReadArmoredKeyRing(strings.NewReader(asciiArmor))
+	if err != nil {
+		return "", fmt.Errorf("parsing ascii armored key: %w", err)
+	}
+	if len(entities) != 1 {
+		return "", fmt.Errorf("expected one key but found %d", len(entities))
+	}
+	if entities[0].PrivateKey != nil {
+		return "", ErrPrivateGPGKey
+	}
+	return entities[0].PrimaryKey.KeyIdString(), nil
+}
+
+func (k *GPGKey) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.String("id", k.ID),
+		slog.String("organization", k.Organization),
+		slog.String("key_id", k.KeyID),
+	)
+}
@@ -0,0 +1,45 @@
+package registryprovider
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/ProtonMail/go-crypto/openpgp"
+	"github.com/ProtonMail/go-crypto/openpgp/armor"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewGPGKey(t *testing.T) {
+	entity, err := openpgp.NewEntity("otf", "", "otf@example.com", nil)
+	require.NoError(t, err)
+
+	t.Run("public key", func(t *testing.T) {
+		var buf bytes.Buffer
+		w, err := armor.Encode(&buf, openpgp.PublicKeyType, nil)
+		require.NoError(t, err)
+		require.NoError(t, entity.Serialize(w))
+		require.NoError(t, w.Close())
+
+		got, err := newGPGKey("acme", buf.String())
+		require.NoError(t, err)
+		assert.Equal(t, entity.PrimaryKey.KeyIdString(), got.KeyID)
+		assert.Equal(t, "acme", got.Organization)
+	})
+
+	t.Run("private key", func(t *testing.T) {
+		var buf bytes.Buffer
+		w, err := armor.Encode(&buf, openpgp.PrivateKeyType, nil)
+		require.NoError(t, err)
+		require.NoError(t, entity.SerializePrivate(w, nil))
+		require.NoError(t, w.Close())
+
+		_, err = newGPGKey("acme", buf.String())
+		assert.ErrorIs(t, err, ErrPrivateGPGKey)
+	})
+
+	t.Run("malformed", func(t *testing.T) {
+		_, err := newGPGKey("acme", "not a key")
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,200 @@
+// Package registryprovider provides a private registry of terraform providers.
+package registryprovider
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/leg100/otf/internal"
+	"github.com/leg100/otf/internal/resource"
+	"github.com/leg100/otf/internal/semver"
+)
+
+var (
+	ErrShasumMismatch = errors.New("SHA256 hash of uploaded binary does not match the shasum of the platform")
+
+	// provider names must be lowercase alphanumerics with optional hyphens,
+	// as per the provider type name in a terraform configuration.
+	nameRegex     = regexp.MustCompile(`^[a-z0-9]+(-[a-z0-9]+)*$`)
+	protocolRegex = regexp.MustCompile(`^[0-9]+\.[0-9]+$`)
+	shasumRegex   = regexp.MustCompile(`^[a-f0-9]{64}$`)
+)
+
+type (
+	// Provider is a terraform provider in an organization's private registry.
+	Provider struct {
+		ID           string
+		CreatedAt    time.Time
+		UpdatedAt    time.Time
+		Name         string
+		Organization string
+	}
+
+	// Version is a version of a provider. A version's binaries are
+	// published for one or more platforms.
+	Version struct {
+		ID         string
+		CreatedAt  time.Time
+		UpdatedAt  time.Time
+		ProviderID string
+		Version    string
+		// ID of the GPG key with which the SHA256SUMS file is signed.
+		KeyID string
+		// Terraform plugin protocols supported by the version.
+		Protocols []string
+
+		ShasumsUploaded    bool
+		ShasumsSigUploaded bool
+	}
+
+	// Platform is an OS and architecture for which a version of a provider is
+	// built.
+	Platform struct {
+		ID        string
+		CreatedAt time.Time
+		UpdatedAt time.Time
+		VersionID string
+		OS        string
+		Arch      string
+		Filename  string
+		// SHA256 hash of the provider binary.
+		Shasum string
+
+		BinaryUploaded bool
+	}
+
+	// ProviderSpec identifies a provider in an organization's registry.
+	ProviderSpec struct {
+		Organization string
+		Name         string
+	}
+
+	CreateVersionOptions struct {
+		Version   string
+		KeyID     string
+		Protocols []string
+	}
+
+	CreatePlatformOptions struct {
+		OS       string
+		Arch     string
+		Shasum   string
+		Filename string
+	}
+)
+
+func newProvider(spec ProviderSpec) (*Provider, error) {
+	if !nameRegex.MatchString(spec.Name) {
+		return nil, &internal.InvalidParameterError{
+			Parameter: "name",
+			Err:       fmt.Errorf("must consist of lowercase letters, numbers, and hyphens: %s", spec.Name),
+		}
+	}
+	now := internal.CurrentTimestamp(nil)
+	return &Provider{
+		ID:           resource.NewID(resource.ProviderKind),
+		CreatedAt:    now,
+		UpdatedAt:    now,
+		Name:         spec.Name,
+		Organization: spec.Organization,
+	}, nil
+}
+
+func newVersion(providerID string, opts CreateVersionOptions) (*Version, error) {
+	// versions are stored without a v prefix
+	version := strings.TrimPrefix(opts.Version, "v")
+	if !semver.IsValid("v" + version) {
+		return nil, &internal.InvalidParameterError{
+			Parameter: "version",
+			Err:       fmt.Errorf("not a semantic version: %s", opts.Version),
+		}
+	}
+	if opts.KeyID == "" {
+		return nil, &internal.MissingParameterError{Parameter: "key-id"}
+	}
+	if len(opts.Protocols) == 0 {
+		return nil, &internal.MissingParameterError{Parameter: "protocols"}
+	}
+	for _, p := range opts.Protocols {
+		if !protocolRegex.MatchString(p) {
+			return nil, &internal.InvalidParameterError{
+				Parameter: "protocols",
+				Err:       fmt.Errorf("must be of the form <major>.<minor>: %s", p),
+			}
+		}
+	}
+	now := internal.CurrentTimestamp(nil)
+	return &Version{
+		ID:         resource.NewID(resource.ProviderVersionKind),
+		CreatedAt:  now,
+		UpdatedAt:  now,
+		ProviderID: providerID,
+		Version:    version,
+		KeyID:      opts.KeyID,
+		Protocols:  opts.Protocols,
+	}, nil
+}
+
+func newPlatform(versionID string, opts CreatePlatformOptions) (*Platform, error) {
+	if opts.OS == "" {
+		return nil, &internal.MissingParameterError{Parameter: "os"}
+	}
+	if opts.Arch == "" {
+		return nil, &internal.MissingParameterError{Parameter: "arch"}
+	}
+	if opts.Filename == "" {
+		return nil, &internal.MissingParameterError{Parameter: "filename"}
+	}
+	if !shasumRegex.MatchString(opts.Shasum) {
+		return nil, &internal.InvalidParameterError{
+			Parameter: "shasum",
+			Err:       fmt.Errorf("must be a hex-encoded SHA256 hash: %s", opts.Shasum),
+		}
+	}
+	now := internal.CurrentTimestamp(nil)
+	return &Platform{
+		ID:        resource.NewID(resource.ProviderPlatformKind),
+		CreatedAt: now,
+		UpdatedAt: now,
+		VersionID: versionID,
+		OS:        opts.OS,
+		Arch:      opts.Arch,
+		Filename:  opts.Filename,
+		Shasum:    opts.Shasum,
+	}, nil
+}
+
+func (p *Provider) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.String("id", p.ID),
+		slog.String("organization", p.Organization),
+		slog.String("name", p.Name),
+	)
+}
+
+func (v *Version) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.String("id", v.ID),
+		slog.String("version", v.Version),
+	)
+}
+
+func (p *Platform) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.String("id", p.ID),
+		slog.String("os", p.OS),
+		slog.String("arch", p.Arch),
+	)
+}
+
+// sortVersions sorts versions, latest version first.
+func sortVersions(versions []*Version) {
+	sort.Slice(versions, func(i, j int) bool {
+		return semver.Compare(versions[i].Version, versions[j].Version) > 0
+	})
+}
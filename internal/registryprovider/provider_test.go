@@ -0,0 +1,88 @@
+package registryprovider
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewProvider(t *testing.T) {
+	tests := []struct {
+		name    string
+		wantErr bool
+	}{
+		{"aws", false},
+		{"my-provider", false},
+		{"My_Provider", true},
+		{"-aws", true},
+		{"", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := newProvider(ProviderSpec{Organization: "acme", Name: tt.name})
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestNewVersion(t *testing.T) {
+	t.Run("strip v prefix", func(t *testing.T) {
+		got, err := newVersion("prov-123", CreateVersionOptions{
+			Version:   "v1.0.0",
+			KeyID:     "32966F3FB5AC1129",
+			Protocols: []string{"5.0"},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "1.0.0", got.Version)
+	})
+
+	tests := []struct {
+		name string
+		opts CreateVersionOptions
+	}{
+		{"invalid version", CreateVersionOptions{Version: "latest", KeyID: "abc", Protocols: []string{"5.0"}}},
+		{"missing key id", CreateVersionOptions{Version: "1.0.0", Protocols: []string{"5.0"}}},
+		{"missing protocols", CreateVersionOptions{Version: "1.0.0", KeyID: "abc"}},
+		{"invalid protocol", CreateVersionOptions{Version: "1.0.0", KeyID: "abc", Protocols: []string{"5"}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := newVersion("prov-123", tt.opts)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestNewPlatform(t *testing.T) {
+	shasum := strings.Repeat("a", 64)
+
+	_, err := newPlatform("provver-123", CreatePlatformOptions{
+		OS: "linux", Arch: "amd64", Filename: "provider.zip", Shasum: shasum,
+	})
+	assert.NoError(t, err)
+
+	_, err = newPlatform("provver-123", CreatePlatformOptions{
+		OS: "linux", Arch: "amd64", Filename: "provider.zip", Shasum: "abc",
+	})
+	assert.Error(t, err)
+
+	_, err = newPlatform("provver-123", CreatePlatformOptions{
+		Arch: "amd64", Filename: "provider.zip", Shasum: shasum,
+	})
+	assert.Error(t, err)
+}
+
+func TestSortVersions(t *testing.T) {
+	versions := []*Version{{Version: "1.2.0"}, {Version: "1.10.0"}, {Version: "0.9.0"}}
+	sortVersions(versions)
+
+	assert.Equal(t, "1.10.0", versions[0].Version)
+	assert.Equal(t, "1.2.0", versions[1].Version)
+	assert.Equal(t, "0.9.0", versions[2].Version)
+}
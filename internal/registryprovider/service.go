@@ -0,0 +1,471 @@
+package registryprovider
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"strings"
+
+	"github.com/gorilla/mux"
+	"github.com/leg100/otf/internal"
+	"github.com/leg100/otf/internal/logr"
+	"github.com/leg100/otf/internal/organization"
+	"github.com/leg100/otf/internal/rbac"
+	"github.com/leg100/otf/internal/sql"
+	"github.com/leg100/otf/internal/tfeapi"
+	"github.com/leg100/surl"
+)
+
+type (
+	Service struct {
+		logr.Logger
+
+		organization internal.Authorizer
+		db           *pgdb
+		tfeapi       *tfe
+		api          *api
+	}
+
+	Options struct {
+		*sql.DB
+		*tfeapi.Responder
+		*surl.Signer
+		logr.Logger
+	}
+)
+
+func NewService(opts Options) *Service {
+	svc := Service{
+		Logger:       opts.Logger,
+		organization: &organization.Authorizer{Logger: opts.Logger},
+		db:           &pgdb{opts.DB},
+	}
+	svc.api = &api{
+		svc:    &svc,
+		Signer: opts.Signer,
+	}
+	svc.tfeapi = &tfe{
+		Service:   &svc,
+		Responder: opts.Responder,
+		Signer:    opts.Signer,
+	}
+	return &svc
+}
+
+func (s *Service) AddHandlers(r *mux.Router) {
+	s.api.addHandlers(r)
+	s.tfeapi.addHandlers(r)
+}
+
+func (s *Service) CreateProvider(ctx context.Context, spec ProviderSpec) (*Provider, error) {
+	subject, err := s.organization.CanAccess(ctx, rbac.CreateRegistryProviderAction, spec.Organization)
+	if err != nil {
+		return nil, err
+	}
+	prov, err := newProvider(spec)
+	if err != nil {
+		s.Error(err, "constructing registry provider", "organization", spec.Organization, "subject", subject)
+		return nil, err
+	}
+	if err := s.db.createProvider(ctx, prov); err != nil {
+		s.Error(err, "creating registry provider", "provider", prov, "subject", subject)
+		return nil, err
+	}
+	s.V(0).Info("created registry provider", "provider", prov, "subject", subject)
+	return prov, nil
+}
+
+func (s *Service) ListProviders(ctx context.Context, organization string) ([]*Provider, error) {
+	subject, err := s.organization.CanAccess(ctx, rbac.ListRegistryProvidersAction, organization)
+	if err != nil {
+		return nil, err
+	}
+	providers, err := s.db.listProviders(ctx, organization)
+	if err != nil {
+		s.Error(err, "listing registry providers", "organization", organization, "subject", subject)
+		return nil, err
+	}
+	s.V(9).Info("listed registry providers", "organization", organization, "count", len(providers), "subject", subject)
+	return providers, nil
+}
+
+func (s *Service) GetProvider(ctx context.Context, spec ProviderSpec) (*Provider, error) {
+	subject, err := s.organization.CanAccess(ctx, rbac.GetRegistryProviderAction, spec.Organization)
+	if err != nil {
+		return nil, err
+	}
+	prov, err := s.db.getProvider(ctx, spec)
+	if err != nil {
+		s.Error(err, "retrieving registry provider", "organization", spec.Organization, "name", spec.Name, "subject", subject)
+		return nil, err
+	}
+	s.V(9).Info("retrieved registry provider", "provider", prov, "subject", subject)
+	return prov, nil
+}
+
+// DeleteProvider deletes a provider along with its versions and their
+// platforms.
+func (s *Service) DeleteProvider(ctx context.Context, spec ProviderSpec) error {
+	subject, err := s.organization.CanAccess(ctx, rbac.DeleteRegistryProviderAction, spec.Organization)
+	if err != nil {
+		return err
+	}
+	prov, err := s.db.getProvider(ctx, spec)
+	if err != nil {
+		s.Error(err, "retrieving registry provider", "organization", spec.Organization, "name", spec.Name, "subject", subject)
+		return err
+	}
+	if err := s.db.deleteProvider(ctx, prov.ID); err != nil {
+		s.Error(err, "deleting registry provider", "provider", prov, "subject", subject)
+		return err
+	}
+	s.V(0).Info("deleted registry provider", "provider", prov, "subject", subject)
+	return nil
+}
+
+func (s *Service) CreateVersion(ctx context.Context, spec ProviderSpec, opts CreateVersionOptions) (*Version, error) {
+	subject, err := s.organization.CanAccess(ctx, rbac.CreateRegistryProviderVersionAction, spec.Organization)
+	if err != nil {
+		return nil, err
+	}
+	prov, err := s.db.getProvider(ctx, spec)
+	if err != nil {
+		s.Error(err, "retrieving registry provider", "organization", spec.Organization, "name", spec.Name, "subject", subject)
+		return nil, err
+	}
+	v, err := newVersion(prov.ID, opts)
+	if err != nil {
+		s.Error(err, "constructing registry provider version", "provider", prov, "subject", subject)
+		return nil, err
+	}
+	if err := s.db.createVersion(ctx, v); err != nil {
+		s.Error(err, "creating registry provider version", "provider", prov, "version", v, "subject", subject)
+		return nil, err
+	}
+	s.V(0).Info("created registry provider version", "provider", prov, "version", v, "subject", subject)
+	return v, nil
+}
+
+// ListVersions lists the versions of a provider, latest version first.
+func (s *Service) ListVersions(ctx context.Context, spec ProviderSpec) ([]*Version, error) {
+	prov, err := s.GetProvider(ctx, spec)
+	if err != nil {
+		return nil, err
+	}
+	versions, err := s.db.listVersions(ctx, prov.ID)
+	if err != nil {
+		s.Error(err, "listing registry provider versions", "provider", prov)
+		return nil, err
+	}
+	sortVersions(versions)
+	s.V(9).Info("listed registry provider versions", "provider", prov, "count", len(versions))
+	return versions, nil
+}
+
+func (s *Service) GetVersion(ctx context.Context, spec ProviderSpec, version string) (*Version, error) {
+	prov, err := s.GetProvider(ctx, spec)
+	if err != nil {
+		return nil, err
+	}
+	return s.getVersion(ctx, prov, version)
+}
+
+// DeleteVersion deletes a version of a provider along with its platforms.
+func (s *Service) DeleteVersion(ctx context.Context, spec ProviderSpec, version string) error {
+	subject, err := s.organization.CanAccess(ctx, rbac.DeleteRegistryProviderVersionAction, spec.Organization)
+	if err != nil {
+		return err
+	}
+	prov, err := s.db.getProvider(ctx, spec)
+	if err != nil {
+		s.Error(err, "retrieving registry provider", "organization", spec.Organization, "name", spec.Name, "subject", subject)
+		return err
+	}
+	v, err := s.getVersion(ctx, prov, version)
+	if err != nil {
+		return err
+	}
+	if err := s.db.deleteVersion(ctx, v.ID); err != nil {
+		s.Error(err, "deleting registry provider version", "provider", prov, "version", v, "subject", subject)
+		return err
+	}
+	s.V(0).Info("deleted registry provider version", "provider", prov, "version", v, "subject", subject)
+	return nil
+}
+
+func (s *Service) CreatePlatform(ctx context.Context, spec ProviderSpec, version string, opts CreatePlatformOptions) (*Platform, error) {
+	subject, err := s.organization.CanAccess(ctx, rbac.CreateRegistryProviderVersionAction, spec.Organization)
+	if err != nil {
+		return nil, err
+	}
+	prov, err := s.db.getProvider(ctx, spec)
+	if err != nil {
+		s.Error(err, "retrieving registry provider", "organization", spec.Organization, "name", spec.Name, "subject", subject)
+		return nil, err
+	}
+	v, err := s.getVersion(ctx, prov, version)
+	if err != nil {
+		return nil, err
+	}
+	p, err := newPlatform(v.ID, opts)
+	if err != nil {
+		s.Error(err, "constructing registry provider platform", "provider", prov, "version", v, "subject", subject)
+		return nil, err
+	}
+	if err := s.db.createPlatform(ctx, p); err != nil {
+		s.Error(err, "creating registry provider platform", "provider", prov, "version", v, "platform", p, "subject", subject)
+		return nil, err
+	}
+	s.V(0).Info("created registry provider platform", "provider", prov, "version", v, "platform", p, "subject", subject)
+	return p, nil
+}
+
+func (s *Service) ListPlatforms(ctx context.Context, spec ProviderSpec, version string) ([]*Platform, error) {
+	v, err := s.GetVersion(ctx, spec, version)
+	if err != nil {
+		return nil, err
+	}
+	platforms, err := s.db.listPlatforms(ctx, v.ID)
+	if err != nil {
+		s.Error(err, "listing registry provider platforms", "version", v)
+		return nil, err
+	}
+	s.V(9).Info("listed registry provider platforms", "version", v, "count", len(platforms))
+	return platforms, nil
+}
+
+func (s *Service) GetPlatform(ctx context.Context, spec ProviderSpec, version, os, arch string) (*Platform, error) {
+	v, err := s.GetVersion(ctx, spec, version)
+	if err != nil {
+		return nil, err
+	}
+	p, err := s.db.getPlatform(ctx, v.ID, os, arch)
+	if err != nil {
+		s.Error(err, "retrieving registry provider platform", "version", v, "os", os, "arch", arch)
+		return nil, err
+	}
+	s.V(9).Info("retrieved registry provider platform", "version", v, "platform", p)
+	return p, nil
+}
+
+func (s *Service) DeletePlatform(ctx context.Context, spec ProviderSpec, version, os, arch string) error {
+	subject, err := s.organization.CanAccess(ctx, rbac.DeleteRegistryProviderVersionAction, spec.Organization)
+	if err != nil {
+		return err
+	}
+	prov, err := s.db.getProvider(ctx, spec)
+	if err != nil {
+		s.Error(err, "retrieving registry provider", "organization", spec.Organization, "name", spec.Name, "subject", subject)
+		return err
+	}
+	v, err := s.getVersion(ctx, prov, version)
+	if err != nil {
+		return err
+	}
+	p, err := s.db.getPlatform(ctx, v.ID, os, arch)
+	if err != nil {
+		s.Error(err, "retrieving registry provider platform", "version", v, "os", os, "arch", arch, "subject", subject)
+		return err
+	}
+	if err := s.db.deletePlatform(ctx, p.ID); err != nil {
+		s.Error(err, "deleting registry provider platform", "version", v, "platform", p, "subject", subject)
+		return err
+	}
+	s.V(0).Info("deleted registry provider platform", "provider", prov, "version", v, "platform", p, "subject", subject)
+	return nil
+}
+
+// CreateGPGKey adds an ASCII armored public GPG key to an organization's
+// registry.
+func (s *Service) CreateGPGKey(ctx context.Context, organization, asciiArmor string) (*GPGKey, error) {
+	subject, err := s.organization.CanAccess(ctx, rbac.CreateGPGKeyAction, organization)
+	if err != nil {
+		return nil, err
+	}
+	key, err := newGPGKey(organization, asciiArmor)
+	if err != nil {
+		s.Error(err, "constructing registry gpg key", "organization", organization, "subject", subject)
+		return nil, err
+	}
+	if err := s.db.createGPGKey(ctx, key); err != nil {
+		s.Error(err, "creating registry gpg key", "key", key, "subject", subject)
+		return nil, err
+	}
+	s.V(0).Info("created registry gpg key", "key", key, "subject", subject)
+	return key, nil
+}
+
+// ListGPGKeys lists the GPG keys of one or more organizations.
+func (s *Service) ListGPGKeys(ctx context.Context, organizations ...string) ([]*GPGKey, error) {
+	var keys []*GPGKey
+	for _, organization := range organizations {
+		subject, err := s.organization.CanAccess(ctx, rbac.ListGPGKeysAction, organization)
+		if err != nil {
+			return nil, err
+		}
+		orgKeys, err := s.db.listGPGKeys(ctx, organization)
+		if err != nil {
+			s.Error(err, "listing registry gpg keys", "organization", organization, "subject", subject)
+			return nil, err
+		}
+		s.V(9).Info("listed registry gpg keys", "organization", organization, "count", len(orgKeys), "subject", subject)
+		keys = append(keys, orgKeys...)
+	}
+	return keys, nil
+}
+
+func (s *Service) GetGPGKey(ctx context.Context, organization, keyID string) (*GPGKey, error) {
+	subject, err := s.organization.CanAccess(ctx, rbac.GetGPGKeyAction, organization)
+	if err != nil {
+		return nil, err
+	}
+	key, err := s.db.getGPGKey(ctx, organization, strings.ToUpper(keyID))
+	if err != nil {
+		s.Error(err, "retrieving registry gpg key", "organization", organization, "key_id", keyID, "subject", subject)
+		return nil, err
+	}
+	s.V(9).Info("retrieved registry gpg key", "key", key, "subject", subject)
+	return key, nil
+}
+
+// MoveGPGKey moves a GPG key from one organization to another. The subject
+// must be permitted to manage GPG keys in both organizations.
+func (s *Service) MoveGPGKey(ctx context.Context, organization, keyID, to string) (*GPGKey, error) {
+	subject, err := s.organization.CanAccess(ctx, rbac.UpdateGPGKeyAction, organization)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := s.organization.CanAccess(ctx, rbac.UpdateGPGKeyAction, to); err != nil {
+		return nil, err
+	}
+	key, err := s.db.getGPGKey(ctx, organization, strings.ToUpper(keyID))
+	if err != nil {
+		s.Error(err, "retrieving registry gpg key", "organization", organization, "key_id", keyID, "subject", subject)
+		return nil, err
+	}
+	moved, err := s.db.updateGPGKeyOrganization(ctx, key.ID, to)
+	if err != nil {
+		s.Error(err, "moving registry gpg key", "key", key, "to", to, "subject", subject)
+		return nil, err
+	}
+	s.V(0).Info("moved registry gpg key", "key", moved, "from", organization, "subject", subject)
+	return moved, nil
+}
+
+func (s *Service) DeleteGPGKey(ctx context.Context, organization, keyID string) error {
+	subject, err := s.organization.CanAccess(ctx, rbac.DeleteGPGKeyAction, organization)
+	if err != nil {
+		return err
+	}
+	key, err := s.db.getGPGKey(ctx, organization, strings.ToUpper(keyID))
+	if err != nil {
+		s.Error(err, "retrieving registry gpg key", "organization", organization, "key_id", keyID, "subject", subject)
+		return err
+	}
+	if err := s.db.deleteGPGKey(ctx, key.ID); err != nil {
+		s.Error(err, "deleting registry gpg key", "key", key, "subject", subject)
+		return err
+	}
+	s.V(0).Info("deleted registry gpg key", "key", key, "subject", subject)
+	return nil
+}
+
+// getSigningKey retrieves the GPG key with which a version's SHA256SUMS file
+// is signed. Returns nil if no such key has been added to the organization's
+// registry. The caller is expected to have been authorized to retrieve the
+// version.
+func (s *Service) getSigningKey(ctx context.Context, organization string, v *Version) (*GPGKey, error) {
+	key, err := s.db.getGPGKey(ctx, organization, strings.ToUpper(v.KeyID))
+	if errors.Is(err, internal.ErrResourceNotFound) {
+		return nil, nil
+	} else if err != nil {
+		s.Error(err, "retrieving registry gpg key", "organization", organization, "key_id", v.KeyID)
+		return nil, err
+	}
+	return key, nil
+}
+
+func (s *Service) getVersion(ctx context.Context, prov *Provider, version string) (*Version, error) {
+	v, err := s.db.getVersion(ctx, prov.ID, strings.TrimPrefix(version, "v"))
+	if err != nil {
+		s.Error(err, "retrieving registry provider version", "provider", prov, "version", version)
+		return nil, err
+	}
+	return v, nil
+}
+
+// uploadShasums uploads the SHA256SUMS file for a version. The caller is
+// expected to have been authorized via a signed URL.
+func (s *Service) uploadShasums(ctx context.Context, versionID string, shasums []byte) error {
+	if err := s.db.uploadShasums(ctx, versionID, shasums); err != nil {
+		s.Error(err, "uploading registry provider shasums", "version", versionID)
+		return err
+	}
+	s.V(0).Info("uploaded registry provider shasums", "version", versionID)
+	return nil
+}
+
+// uploadShasumsSig uploads the signature of the SHA256SUMS file for a
+// version. The caller is expected to have been authorized via a signed URL.
+func (s *Service) uploadShasumsSig(ctx context.Context, versionID string, sig []byte) error {
+	if err := s.db.uploadShasumsSig(ctx, versionID, sig); err != nil {
+		s.Error(err, "uploading registry provider shasums signature", "version", versionID)
+		return err
+	}
+	s.V(0).Info("uploaded registry provider shasums signature", "version", versionID)
+	return nil
+}
+
+// uploadBinary uploads the provider binary for a platform, checking its hash
+// matches the shasum of the platform. The caller is expected to have been
+// authorized via a signed URL.
+func (s *Service) uploadBinary(ctx context.Context, platformID string, binary []byte) error {
+	p, err := s.db.getPlatformByID(ctx, platformID)
+	if err != nil {
+		s.Error(err, "retrieving registry provider platform", "platform", platformID)
+		return err
+	}
+	sum := sha256.Sum256(binary)
+	if hex.EncodeToString(sum[:]) != p.Shasum {
+		err := &internal.InvalidParameterError{Parameter: "binary", Err: ErrShasumMismatch}
+		s.Error(err, "uploading registry provider binary", "platform", p)
+		return err
+	}
+	if err := s.db.uploadBinary(ctx, platformID, binary); err != nil {
+		s.Error(err, "uploading registry provider binary", "platform", p)
+		return err
+	}
+	s.V(0).Info("uploaded registry provider binary", "platform", p, "bytes", len(binary))
+	return nil
+}
+
+func (s *Service) downloadShasums(ctx context.Context, versionID string) ([]byte, error) {
+	shasums, err := s.db.getShasums(ctx, versionID)
+	if err != nil {
+		s.Error(err, "downloading registry provider shasums", "version", versionID)
+		return nil, err
+	}
+	s.V(9).Info("downloaded registry provider shasums", "version", versionID)
+	return shasums, nil
+}
+
+func (s *Service) downloadShasumsSig(ctx context.Context, versionID string) ([]byte, error) {
+	sig, err := s.db.getShasumsSig(ctx, versionID)
+	if err != nil {
+		s.Error(err, "downloading registry provider shasums signature", "version", versionID)
+		return nil, err
+	}
+	s.V(9).Info("downloaded registry provider shasums signature", "version", versionID)
+	return sig, nil
+}
+
+func (s *Service) downloadBinary(ctx context.Context, platformID string) ([]byte, error) {
+	binary, err := s.db.getBinary(ctx, platformID)
+	if err != nil {
+		s.Error(err, "downloading registry provider binary", "platform", platformID)
+		return nil, err
+	}
+	s.V(9).Info("downloaded registry provider binary", "platform", platformID)
+	return binary, nil
+}
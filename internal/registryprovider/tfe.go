@@ -0,0 +1,659 @@
+package registryprovider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/leg100/otf/internal"
+	ihttp "github.com/leg100/otf/internal/http"
+	"github.com/leg100/otf/internal/http/decode"
+	"github.com/leg100/otf/internal/rbac"
+	"github.com/leg100/otf/internal/resource"
+	"github.com/leg100/otf/internal/tfeapi"
+	"github.com/leg100/otf/internal/tfeapi/types"
+	"github.com/leg100/surl"
+)
+
+type (
+	// tfe implements the TFE private provider registry API:
+	//
+	// https://developer.hashicorp.com/terraform/cloud-docs/api-docs/private-registry/providers
+	tfe struct {
+		*Service
+		*tfeapi.Responder
+		*surl.Signer
+	}
+
+	// providerParams identifies a registry provider in the path of a request.
+	providerParams struct {
+		Organization string             `schema:"organization_name,required"`
+		RegistryName types.RegistryName `schema:"registry_name,required"`
+		Namespace    string             `schema:"namespace,required"`
+		Name         string             `schema:"name,required"`
+	}
+)
+
+func (a *tfe) addHandlers(r *mux.Router) {
+	// GPG keys API:
+	//
+	// https://developer.hashicorp.com/terraform/cloud-docs/api-docs/private-registry/gpg-keys
+	registry := r.PathPrefix(tfeapi.RegistryAPIPrefix + "{registry_name}/v2").Subrouter()
+	registry.HandleFunc("/gpg-keys", a.listGPGKeys).Methods("GET")
+	registry.HandleFunc("/gpg-keys", a.createGPGKey).Methods("POST")
+	registry.HandleFunc("/gpg-keys/{namespace}/{key_id}", a.getGPGKey).Methods("GET")
+	registry.HandleFunc("/gpg-keys/{namespace}/{key_id}", a.updateGPGKey).Methods("PATCH")
+	registry.HandleFunc("/gpg-keys/{namespace}/{key_id}", a.deleteGPGKey).Methods("DELETE")
+
+	r = r.PathPrefix(tfeapi.APIPrefixV2).Subrouter()
+
+	r.HandleFunc("/organizations/{organization_name}/registry-providers", a.listProviders).Methods("GET")
+	r.HandleFunc("/organizations/{organization_name}/registry-providers", a.createProvider).Methods("POST")
+
+	provider := r.PathPrefix("/organizations/{organization_name}/registry-providers/{registry_name}/{namespace}/{name}").Subrouter()
+	provider.HandleFunc("", a.getProvider).Methods("GET")
+	provider.HandleFunc("", a.deleteProvider).Methods("DELETE")
+	provider.HandleFunc("/versions", a.listVersions).Methods("GET")
+	provider.HandleFunc("/versions", a.createVersion).Methods("POST")
+	provider.HandleFunc("/versions/{version}", a.getVersion).Methods("GET")
+	provider.HandleFunc("/versions/{version}", a.deleteVersion).Methods("DELETE")
+	provider.HandleFunc("/versions/{version}/platforms", a.listPlatforms).Methods("GET")
+	provider.HandleFunc("/versions/{version}/platforms", a.createPlatform).Methods("POST")
+	provider.HandleFunc("/versions/{version}/platforms/{os}/{arch}", a.getPlatform).Methods("GET")
+	provider.HandleFunc("/versions/{version}/platforms/{os}/{arch}", a.deletePlatform).Methods("DELETE")
+}
+
+func (a *tfe) listProviders(w http.ResponseWriter, r *http.Request) {
+	var params struct {
+		Organization string `schema:"organization_name,required"`
+		resource.PageOptions
+	}
+	if err := decode.All(&params, r); err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+
+	providers, err := a.ListProviders(r.Context(), params.Organization)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+
+	items := make([]*types.RegistryProvider, len(providers))
+	for i, from := range providers {
+		items[i] = a.convertProvider(r.Context(), from)
+	}
+	page := resource.NewPage(items, params.PageOptions, nil)
+	a.RespondWithPage(w, r, page.Items, page.Pagination)
+}
+
+func (a *tfe) createProvider(w http.ResponseWriter, r *http.Request) {
+	org, err := decode.Param("organization_name", r)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	var params types.RegistryProviderCreateOptions
+	if err := tfeapi.Unmarshal(r.Body, &params); err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	if params.Name == nil {
+		tfeapi.Error(w, &internal.MissingParameterError{Parameter: "name"})
+		return
+	}
+	if err := validateRegistry(org, params.RegistryName, params.Namespace); err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+
+	prov, err := a.CreateProvider(r.Context(), ProviderSpec{
+		Organization: org,
+		Name:         *params.Name,
+	})
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+
+	a.Respond(w, r, a.convertProvider(r.Context(), prov), http.StatusCreated)
+}
+
+func (a *tfe) getProvider(w http.ResponseWriter, r *http.Request) {
+	spec, err := providerSpecFromPath(r)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+
+	prov, err := a.GetProvider(r.Context(), spec)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+
+	a.Respond(w, r, a.convertProvider(r.Context(), prov), http.StatusOK)
+}
+
+func (a *tfe) deleteProvider(w http.ResponseWriter, r *http.Request) {
+	spec, err := providerSpecFromPath(r)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+
+	if err := a.DeleteProvider(r.Context(), spec); err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (a *tfe) listVersions(w http.ResponseWriter, r *http.Request) {
+	spec, err := providerSpecFromPath(r)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	var params resource.PageOptions
+	if err := decode.Query(&params, r.URL.Query()); err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+
+	versions, err := a.ListVersions(r.Context(), spec)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+
+	items := make([]*types.RegistryProviderVersion, len(versions))
+	for i, from := range versions {
+		items[i] = a.convertVersion(r.Context(), spec, from)
+	}
+	page := resource.NewPage(items, params, nil)
+	a.RespondWithPage(w, r, page.Items, page.Pagination)
+}
+
+func (a *tfe) createVersion(w http.ResponseWriter, r *http.Request) {
+	spec, err := providerSpecFromPath(r)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	var params types.RegistryProviderVersionCreateOptions
+	if err := tfeapi.Unmarshal(r.Body, &params); err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+
+	v, err := a.CreateVersion(r.Context(), spec, CreateVersionOptions{
+		Version:   params.Version,
+		KeyID:     params.KeyID,
+		Protocols: params.Protocols,
+	})
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+
+	a.respondWithVersion(w, r, spec, v, http.StatusCreated)
+}
+
+func (a *tfe) getVersion(w http.ResponseWriter, r *http.Request) {
+	spec, err := providerSpecFromPath(r)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	version, err := decode.Param("version", r)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+
+	v, err := a.GetVersion(r.Context(), spec, version)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+
+	a.respondWithVersion(w, r, spec, v, http.StatusOK)
+}
+
+func (a *tfe) deleteVersion(w http.ResponseWriter, r *http.Request) {
+	spec, err := providerSpecFromPath(r)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	version, err := decode.Param("version", r)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+
+	if err := a.DeleteVersion(r.Context(), spec, version); err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (a *tfe) listPlatforms(w http.ResponseWriter, r *http.Request) {
+	spec, err := providerSpecFromPath(r)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	var params struct {
+		Version string `schema:"version,required"`
+		resource.PageOptions
+	}
+	if err := decode.All(&params, r); err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+
+	platforms, err := a.ListPlatforms(r.Context(), spec, params.Version)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+
+	items := make([]*types.RegistryProviderPlatform, len(platforms))
+	for i, from := range platforms {
+		items[i] = a.convertPlatform(r.Context(), spec, from)
+	}
+	page := resource.NewPage(items, params.PageOptions, nil)
+	a.RespondWithPage(w, r, page.Items, page.Pagination)
+}
+
+func (a *tfe) createPlatform(w http.ResponseWriter, r *http.Request) {
+	spec, err := providerSpecFromPath(r)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	version, err := decode.Param("version", r)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	var params types.RegistryProviderPlatformCreateOptions
+	if err := tfeapi.Unmarshal(r.Body, &params); err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+
+	p, err := a.CreatePlatform(r.Context(), spec, version, CreatePlatformOptions{
+		OS:       params.OS,
+		Arch:     params.Arch,
+		Shasum:   params.Shasum,
+		Filename: params.Filename,
+	})
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+
+	a.respondWithPlatform(w, r, spec, p, http.StatusCreated)
+}
+
+func (a *tfe) getPlatform(w http.ResponseWriter, r *http.Request) {
+	spec, err := providerSpecFromPath(r)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	var params struct {
+		Version string `schema:"version,required"`
+		OS      string `schema:"os,required"`
+		Arch    string `schema:"arch,required"`
+	}
+	if err := decode.Route(&params, r); err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+
+	p, err := a.GetPlatform(r.Context(), spec, params.Version, params.OS, params.Arch)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+
+	a.respondWithPlatform(w, r, spec, p, http.StatusOK)
+}
+
+func (a *tfe) deletePlatform(w http.ResponseWriter, r *http.Request) {
+	spec, err := providerSpecFromPath(r)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	var params struct {
+		Version string `schema:"version,required"`
+		OS      string `schema:"os,required"`
+		Arch    string `schema:"arch,required"`
+	}
+	if err := decode.Route(&params, r); err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+
+	if err := a.DeletePlatform(r.Context(), spec, params.Version, params.OS, params.Arch); err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (a *tfe) listGPGKeys(w http.ResponseWriter, r *http.Request) {
+	var params struct {
+		RegistryName types.RegistryName `schema:"registry_name,required"`
+		Namespaces   []string           `schema:"filter[namespace],required"`
+		resource.PageOptions
+	}
+	if err := decode.All(&params, r); err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	if err := validateRegistryName(params.RegistryName); err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	// namespaces may be specified either as a comma-separated list or by
+	// repeating the filter parameter.
+	var namespaces []string
+	for _, ns := range params.Namespaces {
+		namespaces = append(namespaces, strings.Split(ns, ",")...)
+	}
+
+	keys, err := a.ListGPGKeys(r.Context(), namespaces...)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+
+	items := make([]*types.GPGKey, len(keys))
+	for i, from := range keys {
+		items[i] = a.convertGPGKey(from)
+	}
+	page := resource.NewPage(items, params.PageOptions, nil)
+	a.RespondWithPage(w, r, page.Items, page.Pagination)
+}
+
+func (a *tfe) createGPGKey(w http.ResponseWriter, r *http.Request) {
+	registry, err := decode.Param("registry_name", r)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	if err := validateRegistryName(types.RegistryName(registry)); err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	var params types.GPGKeyCreateOptions
+	if err := tfeapi.Unmarshal(r.Body, &params); err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	if params.Namespace == "" {
+		tfeapi.Error(w, &internal.MissingParameterError{Parameter: "namespace"})
+		return
+	}
+	if params.AsciiArmor == "" {
+		tfeapi.Error(w, &internal.MissingParameterError{Parameter: "ascii-armor"})
+		return
+	}
+
+	key, err := a.CreateGPGKey(r.Context(), params.Namespace, params.AsciiArmor)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+
+	a.Respond(w, r, a.convertGPGKey(key), http.StatusCreated)
+}
+
+func (a *tfe) getGPGKey(w http.ResponseWriter, r *http.Request) {
+	params, err := gpgKeyParamsFromPath(r)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+
+	key, err := a.GetGPGKey(r.Context(), params.Namespace, params.KeyID)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+
+	a.Respond(w, r, a.convertGPGKey(key), http.StatusOK)
+}
+
+func (a *tfe) updateGPGKey(w http.ResponseWriter, r *http.Request) {
+	params, err := gpgKeyParamsFromPath(r)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	var opts types.GPGKeyUpdateOptions
+	if err := tfeapi.Unmarshal(r.Body, &opts); err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	if opts.Namespace == "" {
+		tfeapi.Error(w, &internal.MissingParameterError{Parameter: "namespace"})
+		return
+	}
+
+	key, err := a.MoveGPGKey(r.Context(), params.Namespace, params.KeyID, opts.Namespace)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+
+	a.Respond(w, r, a.convertGPGKey(key), http.StatusOK)
+}
+
+func (a *tfe) deleteGPGKey(w http.ResponseWriter, r *http.Request) {
+	params, err := gpgKeyParamsFromPath(r)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+
+	if err := a.DeleteGPGKey(r.Context(), params.Namespace, params.KeyID); err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// respondWithVersion responds with a version along with links for uploading
+// its SHA256SUMS file and signature, and for downloading them once uploaded.
+func (a *tfe) respondWithVersion(w http.ResponseWriter, r *http.Request, spec ProviderSpec, v *Version, status int) {
+	links := make(map[string]string)
+	for name, path := range map[string]string{
+		"shasums-upload":     shasumsPath(v.ID),
+		"shasums-sig-upload": shasumsSigPath(v.ID),
+	} {
+		if err := a.addSignedLink(r, links, name, path); err != nil {
+			tfeapi.Error(w, err)
+			return
+		}
+	}
+	if v.ShasumsUploaded {
+		if err := a.addSignedLink(r, links, "shasums-download", shasumsPath(v.ID)); err != nil {
+			tfeapi.Error(w, err)
+			return
+		}
+	}
+	if v.ShasumsSigUploaded {
+		if err := a.addSignedLink(r, links, "shasums-sig-download", shasumsSigPath(v.ID)); err != nil {
+			tfeapi.Error(w, err)
+			return
+		}
+	}
+	a.RespondWithLinks(w, r, a.convertVersion(r.Context(), spec, v), status, links)
+}
+
+// respondWithPlatform responds with a platform along with a link for
+// uploading its provider binary, and for downloading it once uploaded.
+func (a *tfe) respondWithPlatform(w http.ResponseWriter, r *http.Request, spec ProviderSpec, p *Platform, status int) {
+	links := make(map[string]string)
+	if err := a.addSignedLink(r, links, "provider-binary-upload", binaryPath(p.ID)); err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	if p.BinaryUploaded {
+		if err := a.addSignedLink(r, links, "provider-binary-download", binaryPath(p.ID)); err != nil {
+			tfeapi.Error(w, err)
+			return
+		}
+	}
+	a.RespondWithLinks(w, r, a.convertPlatform(r.Context(), spec, p), status, links)
+}
+
+func (a *tfe) addSignedLink(r *http.Request, links map[string]string, name, path string) error {
+	signed, err := a.Sign(path, time.Hour)
+	if err != nil {
+		return err
+	}
+	links[name] = ihttp.Absolute(r, signed)
+	return nil
+}
+
+// gpgKeyParams identifies a GPG key in the path of a request.
+type gpgKeyParams struct {
+	RegistryName types.RegistryName `schema:"registry_name,required"`
+	Namespace    string             `schema:"namespace,required"`
+	KeyID        string             `schema:"key_id,required"`
+}
+
+func gpgKeyParamsFromPath(r *http.Request) (gpgKeyParams, error) {
+	var params gpgKeyParams
+	if err := decode.Route(&params, r); err != nil {
+		return gpgKeyParams{}, err
+	}
+	if err := validateRegistryName(params.RegistryName); err != nil {
+		return gpgKeyParams{}, err
+	}
+	return params, nil
+}
+
+// providerSpecFromPath retrieves the spec of the provider identified in the
+// path of the request.
+func providerSpecFromPath(r *http.Request) (ProviderSpec, error) {
+	var params providerParams
+	if err := decode.Route(&params, r); err != nil {
+		return ProviderSpec{}, err
+	}
+	if err := validateRegistry(params.Organization, params.RegistryName, params.Namespace); err != nil {
+		return ProviderSpec{}, err
+	}
+	return ProviderSpec{Organization: params.Organization, Name: params.Name}, nil
+}
+
+// validateRegistry checks the registry name and namespace are those of the
+// organization's private registry, the only kind of registry supported by
+// OTF.
+func validateRegistry(organization string, registry types.RegistryName, namespace string) error {
+	if err := validateRegistryName(registry); err != nil {
+		return err
+	}
+	if namespace != organization {
+		return &internal.InvalidParameterError{
+			Parameter: "namespace",
+			Err:       errors.New("must be the name of the organization"),
+		}
+	}
+	return nil
+}
+
+// validateRegistryName checks the registry name is that of the private
+// registry, the only kind of registry supported by OTF.
+func validateRegistryName(registry types.RegistryName) error {
+	if registry != types.PrivateRegistry {
+		return &internal.InvalidParameterError{
+			Parameter: "registry-name",
+			Err:       fmt.Errorf("only the %s registry is supported", types.PrivateRegistry),
+		}
+	}
+	return nil
+}
+
+func (a *tfe) convertProvider(ctx context.Context, from *Provider) *types.RegistryProvider {
+	to := &types.RegistryProvider{
+		ID:           from.ID,
+		Name:         from.Name,
+		Namespace:    from.Organization,
+		RegistryName: types.PrivateRegistry,
+		Permissions:  &types.RegistryProviderPermissions{},
+		CreatedAt:    from.CreatedAt,
+		UpdatedAt:    from.UpdatedAt,
+		Organization: &types.Organization{Name: from.Organization},
+	}
+	if subject, err := internal.SubjectFromContext(ctx); err == nil {
+		to.Permissions.CanDelete = subject.CanAccessOrganization(rbac.DeleteRegistryProviderAction, from.Organization)
+	}
+	return to
+}
+
+func (a *tfe) convertVersion(ctx context.Context, spec ProviderSpec, from *Version) *types.RegistryProviderVersion {
+	to := &types.RegistryProviderVersion{
+		ID:                 from.ID,
+		Version:            from.Version,
+		KeyID:              from.KeyID,
+		Protocols:          from.Protocols,
+		Permissions:        &types.RegistryProviderVersionPermissions{},
+		ShasumsUploaded:    from.ShasumsUploaded,
+		ShasumsSigUploaded: from.ShasumsSigUploaded,
+		CreatedAt:          from.CreatedAt,
+		UpdatedAt:          from.UpdatedAt,
+		RegistryProvider:   &types.RegistryProvider{ID: from.ProviderID},
+	}
+	if subject, err := internal.SubjectFromContext(ctx); err == nil {
+		to.Permissions.CanDelete = subject.CanAccessOrganization(rbac.DeleteRegistryProviderVersionAction, spec.Organization)
+		to.Permissions.CanUploadAsset = subject.CanAccessOrganization(rbac.CreateRegistryProviderVersionAction, spec.Organization)
+	}
+	return to
+}
+
+func (a *tfe) convertPlatform(ctx context.Context, spec ProviderSpec, from *Platform) *types.RegistryProviderPlatform {
+	to := &types.RegistryProviderPlatform{
+		ID:                      from.ID,
+		OS:                      from.OS,
+		Arch:                    from.Arch,
+		Filename:                from.Filename,
+		Shasum:                  from.Shasum,
+		Permissions:             &types.RegistryProviderPlatformPermissions{},
+		ProviderBinaryUploaded:  from.BinaryUploaded,
+		RegistryProviderVersion: &types.RegistryProviderVersion{ID: from.VersionID},
+	}
+	if subject, err := internal.SubjectFromContext(ctx); err == nil {
+		to.Permissions.CanDelete = subject.CanAccessOrganization(rbac.DeleteRegistryProviderVersionAction, spec.Organization)
+		to.Permissions.CanUploadAsset = subject.CanAccessOrganization(rbac.CreateRegistryProviderVersionAction, spec.Organization)
+	}
+	return to
+}
+
+func (a *tfe) convertGPGKey(from *GPGKey) *types.GPGKey {
+	return &types.GPGKey{
+		ID:         from.ID,
+		AsciiArmor: from.ASCIIArmor,
+		CreatedAt:  from.CreatedAt,
+		KeyID:      from.KeyID,
+		Namespace:  from.Organization,
+		UpdatedAt:  from.UpdatedAt,
+	}
+}
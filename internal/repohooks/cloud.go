@@ -0,0 +1,118 @@
+package repohooks
+
+import (
+	"context"
+	"errors"
+
+	"github.com/leg100/otf/internal/gitlab"
+	"github.com/leg100/otf/internal/vcs"
+)
+
+// ErrGroupHooksNotSupported is returned when a group hook is synchronised
+// using a vcs client that does not support hooks on groups.
+var ErrGroupHooksNotSupported = errors.New("vcs provider does not support group webhooks")
+
+var _ groupClient = (*gitlab.Client)(nil)
+
+type (
+	// cloudHooks manages a hook on the vcs provider, either on a repo or on a
+	// group.
+	cloudHooks interface {
+		create(ctx context.Context, hook *hook) (string, error)
+		get(ctx context.Context, hook *hook) (vcs.Webhook, error)
+		update(ctx context.Context, cloudID string, hook *hook) error
+		delete(ctx context.Context, hook *hook) error
+	}
+
+	// groupClient is a vcs client that supports hooks on groups, which
+	// receive events for every repo in the group and its subgroups.
+	groupClient interface {
+		CreateGroupWebhook(ctx context.Context, opts gitlab.GroupWebhookOptions) (string, error)
+		UpdateGroupWebhook(ctx context.Context, id string, opts gitlab.GroupWebhookOptions) error
+		GetGroupWebhook(ctx context.Context, opts gitlab.GetGroupWebhookOptions) (vcs.Webhook, error)
+		DeleteGroupWebhook(ctx context.Context, opts gitlab.GetGroupWebhookOptions) error
+	}
+
+	repoHooks struct {
+		vcs.Client
+	}
+
+	groupHooks struct {
+		groupClient
+	}
+)
+
+func newCloudHooks(client vcs.Client, hook *hook) (cloudHooks, error) {
+	if !hook.group {
+		return repoHooks{client}, nil
+	}
+	gclient, ok := client.(groupClient)
+	if !ok {
+		return nil, ErrGroupHooksNotSupported
+	}
+	return groupHooks{gclient}, nil
+}
+
+func (c repoHooks) create(ctx context.Context, hook *hook) (string, error) {
+	return c.CreateWebhook(ctx, vcs.CreateWebhookOptions{
+		Repo:     hook.repoPath,
+		Secret:   hook.secret,
+		Events:   defaultEvents,
+		Endpoint: hook.endpoint,
+	})
+}
+
+func (c repoHooks) get(ctx context.Context, hook *hook) (vcs.Webhook, error) {
+	return c.GetWebhook(ctx, vcs.GetWebhookOptions{
+		Repo: hook.repoPath,
+		ID:   *hook.cloudID,
+	})
+}
+
+func (c repoHooks) update(ctx context.Context, cloudID string, hook *hook) error {
+	return c.UpdateWebhook(ctx, cloudID, vcs.UpdateWebhookOptions{
+		Repo:     hook.repoPath,
+		Secret:   hook.secret,
+		Events:   defaultEvents,
+		Endpoint: hook.endpoint,
+	})
+}
+
+func (c repoHooks) delete(ctx context.Context, hook *hook) error {
+	return c.DeleteWebhook(ctx, vcs.DeleteWebhookOptions{
+		Repo: hook.repoPath,
+		ID:   *hook.cloudID,
+	})
+}
+
+func (c groupHooks) create(ctx context.Context, hook *hook) (string, error) {
+	return c.CreateGroupWebhook(ctx, gitlab.GroupWebhookOptions{
+		Group:    hook.repoPath,
+		Secret:   hook.secret,
+		Events:   defaultEvents,
+		Endpoint: hook.endpoint,
+	})
+}
+
+func (c groupHooks) get(ctx context.Context, hook *hook) (vcs.Webhook, error) {
+	return c.GetGroupWebhook(ctx, gitlab.GetGroupWebhookOptions{
+		Group: hook.repoPath,
+		ID:    *hook.cloudID,
+	})
+}
+
+func (c groupHooks) update(ctx context.Context, cloudID string, hook *hook) error {
+	return c.UpdateGroupWebhook(ctx, cloudID, gitlab.GroupWebhookOptions{
+		Group:    hook.repoPath,
+		Secret:   hook.secret,
+		Events:   defaultEvents,
+		Endpoint: hook.endpoint,
+	})
+}
+
+func (c groupHooks) delete(ctx context.Context, hook *hook) error {
+	return c.DeleteGroupWebhook(ctx, gitlab.GetGroupWebhookOptions{
+		Group: hook.repoPath,
+		ID:    *hook.cloudID,
+	})
+}
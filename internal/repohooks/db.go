@@ -24,6 +24,7 @@ type (
 		VCSProviderID pgtype.Text `json:"vcs_provider_id"`
 		Secret        pgtype.Text `json:"secret"`
 		RepoPath      pgtype.Text `json:"repo_path"`
+		GroupHook     pgtype.Bool `json:"group_hook"`
 		VCSKind       pgtype.Text `json:"vcs_kind"`
 	}
 )
@@ -48,6 +49,7 @@ func (db *db) getOrCreateHook(ctx context.Context, hook *hook) (*hook, error) {
 		RepoPath:      sql.String(hook.repoPath),
 		VCSID:         sql.StringPtr(hook.cloudID),
 		VCSProviderID: sql.String(hook.vcsProviderID),
+		GroupHook:     sql.Bool(hook.group),
 	})
 	if err != nil {
 		return nil, fmt.Errorf("inserting webhook into db: %w", sql.Error(err))
@@ -124,6 +126,7 @@ func (db *db) fromRow(row hookRow) (*hook, error) {
 		secret:          internal.String(row.Secret.String),
 		repoPath:        row.RepoPath.String,
 		cloud:           vcs.Kind(row.VCSKind.String),
+		group:           row.GroupHook.Bool,
 		HostnameService: db.HostnameService,
 	}
 	if row.VCSID.Status == pgtype.Present {
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"net/http"
 	"path"
+	"time"
 
 	"github.com/go-logr/logr"
 	"github.com/google/uuid"
@@ -26,6 +27,11 @@ type (
 		vcs.Publisher
 
 		cloudHandlers *internal.SafeMap[vcs.Kind, EventUnmarshaler]
+		// maximum permitted difference between the time an event is sent and
+		// received
+		clockSkew time.Duration
+		// kinds of providers whose deliveries must carry a Date header
+		requireDate map[vcs.Kind]bool
 
 		handlerDB
 	}
@@ -42,13 +48,19 @@ type (
 	}
 )
 
-func newHandler(logger logr.Logger, publisher vcs.Publisher, db handlerDB) *handlers {
-	return &handlers{
+func newHandler(logger logr.Logger, publisher vcs.Publisher, db handlerDB, clockSkew time.Duration, requireDate []vcs.Kind) *handlers {
+	h := &handlers{
 		Logger:        logger,
 		Publisher:     publisher,
 		handlerDB:     db,
+		clockSkew:     clockSkew,
+		requireDate:   make(map[vcs.Kind]bool, len(requireDate)),
 		cloudHandlers: internal.NewSafeMap[vcs.Kind, EventUnmarshaler](),
 	}
+	for _, kind := range requireDate {
+		h.requireDate[kind] = true
+	}
+	return h
 }
 
 func (h *handlers) AddHandlers(r *mux.Router) {
@@ -77,6 +89,11 @@ func (h *handlers) repohookHandler(w http.ResponseWriter, r *http.Request) {
 		http.Error(w, "no event unmarshaler found for event", http.StatusNotFound)
 		return
 	}
+	if err := vcs.ValidateDeliveryTime(r, h.clockSkew, time.Now(), h.requireDate[hook.cloud]); err != nil {
+		h.Error(err, "handling vcs event", "repohook_id", opts.ID, "repo", hook.repoPath, "cloud", hook.cloud)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
 	// handle event
 	payload, err := cloudHandler(r, hook.secret)
 	// either ignore the event, return an error, or publish the event onwards
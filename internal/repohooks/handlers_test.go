@@ -29,6 +29,8 @@ func Test_repohookHandler(t *testing.T) {
 		&fakeHandlerDB{
 			hook: hook,
 		},
+		vcs.DefaultWebhookClockSkew,
+		nil,
 	)
 	handler.cloudHandlers.Set(vcs.GithubKind, func(*http.Request, string) (*vcs.EventPayload, error) {
 		return &vcs.EventPayload{}, nil
@@ -46,6 +48,28 @@ func Test_repohookHandler(t *testing.T) {
 		EventPayload: vcs.EventPayload{RepoPath: hook.repoPath},
 	}
 	assert.Equal(t, want, broker.got)
+
+	t.Run("missing required date header", func(t *testing.T) {
+		broker := &fakeBroker{}
+		handler := newHandler(
+			logr.Discard(),
+			broker,
+			&fakeHandlerDB{
+				hook: hook,
+			},
+			vcs.DefaultWebhookClockSkew,
+			[]vcs.Kind{vcs.GithubKind},
+		)
+		handler.cloudHandlers.Set(vcs.GithubKind, func(*http.Request, string) (*vcs.EventPayload, error) {
+			return &vcs.EventPayload{}, nil
+		})
+
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest("POST", "/?webhook_id=158c758a-7090-11ed-a843-d398c839c7ad", nil)
+		handler.repohookHandler(w, r)
+		assert.Equal(t, 400, w.Code, "response body: %s", w.Body.String())
+		assert.Equal(t, vcs.Event{}, broker.got)
+	})
 }
 
 type (
@@ -4,6 +4,7 @@ package repohooks
 import (
 	"log/slog"
 	"path"
+	"strings"
 
 	"github.com/google/uuid"
 	"github.com/leg100/otf/internal"
@@ -24,9 +25,10 @@ type (
 		vcsProviderID string
 
 		secret   string   // secret token
-		repoPath string   // repo identifier: <repo_owner>/<repo_name>
+		repoPath string   // repo identifier: <repo_owner>/<repo_name>, or group path if group is true
 		cloud    vcs.Kind // origin of events
 		endpoint string   // OTF URL that receives events
+		group    bool     // hook is on a group, covering every repo in the group
 	}
 
 	newRepohookOptions struct {
@@ -36,6 +38,7 @@ type (
 		repoPath      string
 		cloud         vcs.Kind
 		cloudID       *string // cloud's webhook id
+		group         bool
 
 		// for building endpoint URL
 		*internal.HostnameService
@@ -48,6 +51,7 @@ func newRepohook(opts newRepohookOptions) (*hook, error) {
 		cloud:         opts.cloud,
 		cloudID:       opts.cloudID,
 		vcsProviderID: opts.vcsProviderID,
+		group:         opts.group,
 	}
 	if opts.id != nil {
 		hook.id = *opts.id
@@ -74,9 +78,47 @@ func (h *hook) LogValue() slog.Value {
 		slog.String("vcs_kind", string(h.cloud)),
 		slog.String("repo", h.repoPath),
 		slog.String("endpoint", h.endpoint),
+		slog.Bool("group", h.group),
 	}
 	if h.cloudID != nil {
 		attrs = append(attrs, slog.String("vcs_id", *h.cloudID))
 	}
 	return slog.GroupValue(attrs...)
 }
+
+// webhookGroup returns the group from groups that contains the repo, if any.
+// If more than one group contains the repo then the outermost group is
+// returned, because its hook receives events for the repos in its subgroups
+// too.
+func webhookGroup(groups []string, repoPath string) (string, bool) {
+	var found string
+	for _, group := range groups {
+		group = strings.Trim(group, "/")
+		if group == "" || !inGroup(group, repoPath) {
+			continue
+		}
+		if found == "" || len(group) < len(found) {
+			found = group
+		}
+	}
+	return found, found != ""
+}
+
+// inGroup determines whether the repo is in the group or one of its subgroups.
+func inGroup(group, repoPath string) bool {
+	return strings.HasPrefix(repoPath, group+"/")
+}
+
+// replacedRepohooks returns the hooks on repos that are covered by the group
+// hook.
+func replacedRepohooks(group *hook, hooks []*hook) (replaced []*hook) {
+	for _, h := range hooks {
+		if h.group || h.vcsProviderID != group.vcsProviderID {
+			continue
+		}
+		if inGroup(group.repoPath, h.repoPath) {
+			replaced = append(replaced, h)
+		}
+	}
+	return replaced
+}
@@ -42,3 +42,39 @@ func Test_newHook(t *testing.T) {
 		})
 	}
 }
+
+func Test_webhookGroup(t *testing.T) {
+	tests := []struct {
+		name   string
+		groups []string
+		repo   string
+		want   string
+		found  bool
+	}{
+		{"no groups", nil, "acme/terraform", "", false},
+		{"in group", []string{"acme"}, "acme/terraform", "acme", true},
+		{"in subgroup", []string{"acme"}, "acme/infra/terraform", "acme", true},
+		{"not in group", []string{"acme"}, "widgets/terraform", "", false},
+		{"group with same prefix", []string{"acme"}, "acme-corp/terraform", "", false},
+		{"outermost group", []string{"acme/infra", "acme"}, "acme/infra/terraform", "acme", true},
+		{"trailing slash", []string{"acme/"}, "acme/terraform", "acme", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, found := webhookGroup(tt.groups, tt.repo)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.found, found)
+		})
+	}
+}
+
+func Test_replacedRepohooks(t *testing.T) {
+	group := &hook{vcsProviderID: "vcs-1", repoPath: "acme", group: true}
+	inGroup := &hook{vcsProviderID: "vcs-1", repoPath: "acme/terraform"}
+	inSubgroup := &hook{vcsProviderID: "vcs-1", repoPath: "acme/infra/terraform"}
+	otherProvider := &hook{vcsProviderID: "vcs-2", repoPath: "acme/terraform"}
+	otherGroup := &hook{vcsProviderID: "vcs-1", repoPath: "widgets/terraform"}
+
+	got := replacedRepohooks(group, []*hook{group, inGroup, inSubgroup, otherProvider, otherGroup})
+	assert.Equal(t, []*hook{inGroup, inSubgroup}, got)
+}
@@ -3,6 +3,7 @@ package repohooks
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/go-logr/logr"
 	"github.com/google/uuid"
@@ -24,6 +25,7 @@ type (
 		*synchroniser // synchronise hooks
 
 		vcsproviders *vcsprovider.Service
+		gitlabGroups []string
 	}
 
 	Options struct {
@@ -33,6 +35,16 @@ type (
 		VCSProviderService  *vcsprovider.Service
 		GithubAppService    *github.Service
 		VCSEventBroker      *vcs.Broker
+		// WebhookClockSkew is the maximum permitted difference between the
+		// time a webhook delivery is sent and received.
+		WebhookClockSkew time.Duration
+		// WebhookRequireDate are the kinds of providers whose webhook
+		// deliveries are rejected if they lack a Date header.
+		WebhookRequireDate []vcs.Kind
+		// GitlabWebhookGroups are gitlab groups for which a single webhook is
+		// created on the group in place of a webhook on each repo in the
+		// group.
+		GitlabWebhookGroups []string
 
 		*sql.DB
 		*internal.HostnameService
@@ -49,11 +61,14 @@ func NewService(ctx context.Context, opts Options) *Service {
 	svc := &Service{
 		Logger:       opts.Logger,
 		vcsproviders: opts.VCSProviderService,
+		gitlabGroups: opts.GitlabWebhookGroups,
 		db:           db,
 		handlers: newHandler(
 			opts.Logger,
 			opts.VCSEventBroker,
 			db,
+			opts.WebhookClockSkew,
+			opts.WebhookRequireDate,
 		),
 		synchroniser: &synchroniser{Logger: opts.Logger, syncdb: db},
 	}
@@ -85,12 +100,20 @@ func (s *Service) CreateRepohook(ctx context.Context, opts CreateRepohookOptions
 	if err != nil {
 		return uuid.UUID{}, fmt.Errorf("checking repository exists: %w", err)
 	}
-	hook, err := newRepohook(newRepohookOptions{
+	hookOpts := newRepohookOptions{
 		repoPath:        opts.RepoPath,
 		cloud:           vcsProvider.Kind,
 		vcsProviderID:   vcsProvider.ID,
 		HostnameService: s.HostnameService,
-	})
+	}
+	if vcsProvider.Kind == vcs.GitlabKind {
+		// a single hook on the group receives events for the repo
+		if group, ok := webhookGroup(s.gitlabGroups, opts.RepoPath); ok {
+			hookOpts.repoPath = group
+			hookOpts.group = true
+		}
+	}
+	hook, err := newRepohook(hookOpts)
 	if err != nil {
 		return uuid.UUID{}, fmt.Errorf("constructing webhook: %w", err)
 	}
@@ -104,6 +127,9 @@ func (s *Service) CreateRepohook(ctx context.Context, opts CreateRepohookOptions
 		if err := s.sync(ctx, client, hook); err != nil {
 			return fmt.Errorf("synchronising webhook: %w", err)
 		}
+		if hook.group {
+			return s.deleteReplacedRepohooks(ctx, hook)
+		}
 		return nil
 	})
 	if err != nil {
@@ -165,6 +191,21 @@ func (s *Service) deleteProviderRepohooks(ctx context.Context, provider *vcsprov
 	return nil
 }
 
+// deleteReplacedRepohooks deletes the hooks on repos that are replaced by a
+// group hook, so that events are not received twice.
+func (s *Service) deleteReplacedRepohooks(ctx context.Context, group *hook) error {
+	hooks, err := s.db.listHooks(ctx)
+	if err != nil {
+		return err
+	}
+	for _, h := range replacedRepohooks(group, hooks) {
+		if err := s.deleteRepohook(ctx, h); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func (s *Service) deleteRepohook(ctx context.Context, repohook *hook) error {
 	if err := s.db.deleteHook(ctx, repohook.id); err != nil {
 		return fmt.Errorf("deleting webhook from db: %w", err)
@@ -173,10 +214,10 @@ func (s *Service) deleteRepohook(ctx context.Context, repohook *hook) error {
 	if err != nil {
 		return fmt.Errorf("retrieving vcs client from db: %w", err)
 	}
-	err = client.DeleteWebhook(ctx, vcs.DeleteWebhookOptions{
-		Repo: repohook.repoPath,
-		ID:   *repohook.cloudID,
-	})
+	cloud, err := newCloudHooks(client, repohook)
+	if err == nil {
+		err = cloud.delete(ctx, repohook)
+	}
 	if err != nil {
 		s.Error(err, "deleting webhook", "repo", repohook.repoPath, "cloud", repohook.cloud)
 	} else {
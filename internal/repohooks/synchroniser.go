@@ -25,13 +25,12 @@ type (
 
 // sync should be called from within a tx to avoid inconsistent results.
 func (s *synchroniser) sync(ctx context.Context, client vcs.Client, hook *hook) error {
+	cloud, err := newCloudHooks(client, hook)
+	if err != nil {
+		return err
+	}
 	createAndSync := func() error {
-		cloudID, err := client.CreateWebhook(ctx, vcs.CreateWebhookOptions{
-			Repo:     hook.repoPath,
-			Secret:   hook.secret,
-			Events:   defaultEvents,
-			Endpoint: hook.endpoint,
-		})
+		cloudID, err := cloud.create(ctx, hook)
 		if err != nil {
 			return err
 		}
@@ -44,10 +43,7 @@ func (s *synchroniser) sync(ctx context.Context, client vcs.Client, hook *hook)
 	if hook.cloudID == nil {
 		return createAndSync()
 	}
-	cloudHook, err := client.GetWebhook(ctx, vcs.GetWebhookOptions{
-		Repo: hook.repoPath,
-		ID:   *hook.cloudID,
-	})
+	cloudHook, err := cloud.get(ctx, hook)
 	if errors.Is(err, internal.ErrResourceNotFound) {
 		return createAndSync()
 	} else if err != nil {
@@ -55,13 +51,7 @@ func (s *synchroniser) sync(ctx context.Context, client vcs.Client, hook *hook)
 	}
 	// hook is present on the vcs repo, but we update it anyway just to ensure
 	// its configuration is consistent with what we have in the DB
-	err = client.UpdateWebhook(ctx, cloudHook.ID, vcs.UpdateWebhookOptions{
-		Repo:     hook.repoPath,
-		Secret:   hook.secret,
-		Events:   defaultEvents,
-		Endpoint: hook.endpoint,
-	})
-	if err != nil {
+	if err := cloud.update(ctx, cloudHook.ID, hook); err != nil {
 		return err
 	}
 	s.Info("updated webhook", "webhook", hook)
@@ -73,3 +73,35 @@ func TestSynchroniser(t *testing.T) {
 		})
 	}
 }
+
+func TestSynchroniser_Group(t *testing.T) {
+	t.Run("new hook", func(t *testing.T) {
+		client := &fakeGroupClient{hook: vcs.Webhook{ID: "123"}}
+		got := &hook{repoPath: "acme/infra", group: true, endpoint: "fake-host.org/xyz"}
+		db := &fakeDB{hook: got}
+		synchr := &synchroniser{Logger: logr.Discard(), syncdb: db}
+
+		require.NoError(t, synchr.sync(context.Background(), client, got))
+		assert.Equal(t, "acme/infra", client.gotGroup)
+		assert.Equal(t, internal.String("123"), got.cloudID)
+	})
+
+	t.Run("existing hook", func(t *testing.T) {
+		client := &fakeGroupClient{hook: vcs.Webhook{ID: "123"}}
+		got := &hook{repoPath: "acme/infra", group: true, cloudID: internal.String("123")}
+		db := &fakeDB{hook: got}
+		synchr := &synchroniser{Logger: logr.Discard(), syncdb: db}
+
+		require.NoError(t, synchr.sync(context.Background(), client, got))
+		assert.True(t, client.gotUpdate)
+		assert.Equal(t, "acme/infra", client.gotGroup)
+	})
+
+	t.Run("group hooks not supported", func(t *testing.T) {
+		got := &hook{repoPath: "acme/infra", group: true}
+		synchr := &synchroniser{Logger: logr.Discard(), syncdb: &fakeDB{hook: got}}
+
+		err := synchr.sync(context.Background(), &fakeCloudClient{}, got)
+		assert.ErrorIs(t, err, ErrGroupHooksNotSupported)
+	})
+}
@@ -5,6 +5,7 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/leg100/otf/internal"
+	"github.com/leg100/otf/internal/gitlab"
 	"github.com/leg100/otf/internal/vcs"
 )
 
@@ -41,3 +42,33 @@ func (f *fakeDB) updateHookCloudID(ctx context.Context, id uuid.UUID, cloudID st
 	f.hook.cloudID = &cloudID
 	return nil
 }
+
+type fakeGroupClient struct {
+	hook      vcs.Webhook // seed cloud with group hook
+	gotGroup  string
+	gotUpdate bool
+
+	vcs.Client
+}
+
+func (f *fakeGroupClient) CreateGroupWebhook(ctx context.Context, opts gitlab.GroupWebhookOptions) (string, error) {
+	f.gotGroup = opts.Group
+	return f.hook.ID, nil
+}
+
+func (f *fakeGroupClient) UpdateGroupWebhook(ctx context.Context, id string, opts gitlab.GroupWebhookOptions) error {
+	f.gotGroup = opts.Group
+	f.gotUpdate = true
+	return nil
+}
+
+func (f *fakeGroupClient) GetGroupWebhook(ctx context.Context, opts gitlab.GetGroupWebhookOptions) (vcs.Webhook, error) {
+	if f.hook.ID == opts.ID {
+		return f.hook, nil
+	}
+	return vcs.Webhook{}, internal.ErrResourceNotFound
+}
+
+func (f *fakeGroupClient) DeleteGroupWebhook(context.Context, gitlab.GetGroupWebhookOptions) error {
+	return nil
+}
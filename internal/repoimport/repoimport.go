@@ -0,0 +1,89 @@
+// Package repoimport imports workspaces from repositories laid out for use
+// with atlantis or terragrunt.
+package repoimport
+
+import (
+	"errors"
+	"slices"
+
+	"github.com/leg100/otf/internal"
+)
+
+const (
+	AtlantisLayout   Layout = "atlantis"
+	TerragruntLayout Layout = "terragrunt"
+)
+
+var ErrNoLayoutFound = errors.New("neither an atlantis.yaml nor terragrunt.hcl files found in repository")
+
+type (
+	// Layout is the layout of the repository from which workspaces are
+	// imported.
+	Layout string
+
+	// ImportOptions are options for importing workspaces from a repository.
+	ImportOptions struct {
+		Organization  string  `json:"-"`
+		VCSProviderID string  `json:"vcs_provider_id"`
+		Repo          string  `json:"repo"`
+		Branch        *string `json:"branch,omitempty"`
+		// DryRun proposes workspaces without creating them.
+		DryRun bool `json:"dry_run"`
+	}
+
+	// Result is the result of importing workspaces from a repository.
+	Result struct {
+		Layout    Layout      `json:"layout"`
+		DryRun    bool        `json:"dry_run"`
+		Proposals []*Proposal `json:"workspaces"`
+	}
+
+	// Proposal is a workspace proposed for a project or module found in the
+	// repository.
+	Proposal struct {
+		Name             string   `json:"name"`
+		WorkingDirectory string   `json:"working_directory"`
+		TerraformVersion string   `json:"terraform_version,omitempty"`
+		TriggerPatterns  []string `json:"trigger_patterns"`
+		// Dependencies are the working directories of the projects or
+		// modules upon which this workspace depends.
+		Dependencies []string `json:"dependencies"`
+		// RunTriggerSources are the names of the workspaces proposed for
+		// dependencies. A run trigger is created for each, queuing a run on
+		// this workspace whenever an apply completes in the source
+		// workspace.
+		RunTriggerSources []string `json:"run_trigger_sources"`
+		// Exists is true if a workspace with the same name already exists,
+		// in which case it is left untouched.
+		Exists bool `json:"exists"`
+		// WorkspaceID is the ID of the workspace, set if it exists or has
+		// been created.
+		WorkspaceID string `json:"workspace_id,omitempty"`
+	}
+)
+
+func (opts ImportOptions) validate() error {
+	if opts.VCSProviderID == "" {
+		return &internal.MissingParameterError{Parameter: "vcs_provider_id"}
+	}
+	if opts.Repo == "" {
+		return &internal.MissingParameterError{Parameter: "repo"}
+	}
+	return nil
+}
+
+// addDependency adds the directory of a dependency along with the name of the
+// workspace proposed for the dependency, which becomes the source of a run
+// trigger. If no workspace is proposed for the dependency then source is empty
+// and runs are instead triggered on changes to files within the directory.
+func (p *Proposal) addDependency(dir, source string) {
+	if dir == p.WorkingDirectory || slices.Contains(p.Dependencies, dir) {
+		return
+	}
+	p.Dependencies = append(p.Dependencies, dir)
+	if source != "" {
+		p.RunTriggerSources = append(p.RunTriggerSources, source)
+	} else {
+		p.TriggerPatterns = append(p.TriggerPatterns, "/"+dir+"/**")
+	}
+}
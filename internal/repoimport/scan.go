@@ -0,0 +1,255 @@
+package repoimport
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclparse"
+	"github.com/zclconf/go-cty/cty"
+	"gopkg.in/yaml.v3"
+)
+
+var (
+	atlantisConfigFilenames = []string{"atlantis.yaml", "atlantis.yml"}
+
+	// atlantisDefaultWhenModified is the set of patterns atlantis uses to
+	// determine whether a project is to be planned when none are configured.
+	atlantisDefaultWhenModified = []string{"**/*.tf*", "**/terragrunt.hcl", "**/.terraform.lock.hcl"}
+
+	// invalidNameChars matches characters not permitted in a workspace name
+	invalidNameChars = regexp.MustCompile(`[^a-zA-Z0-9\-_]+`)
+)
+
+const terragruntConfigFilename = "terragrunt.hcl"
+
+type (
+	atlantisConfig struct {
+		Projects []atlantisProject `yaml:"projects"`
+	}
+
+	atlantisProject struct {
+		Name             string   `yaml:"name"`
+		Dir              string   `yaml:"dir"`
+		Workspace        string   `yaml:"workspace"`
+		TerraformVersion string   `yaml:"terraform_version"`
+		DependsOn        []string `yaml:"depends_on"`
+		Autoplan         *struct {
+			WhenModified []string `yaml:"when_modified"`
+		} `yaml:"autoplan"`
+	}
+)
+
+// scan scans a repository checked out to root, returning a proposed workspace
+// for each atlantis project or terragrunt module found. An atlantis.yaml
+// takes precedence over terragrunt configuration.
+func scan(root string) (Layout, []*Proposal, error) {
+	for _, fname := range atlantisConfigFilenames {
+		contents, err := os.ReadFile(filepath.Join(root, fname))
+		if errors.Is(err, fs.ErrNotExist) {
+			continue
+		} else if err != nil {
+			return "", nil, err
+		}
+		proposals, err := scanAtlantis(contents)
+		if err != nil {
+			return "", nil, fmt.Errorf("parsing %s: %w", fname, err)
+		}
+		return AtlantisLayout, proposals, nil
+	}
+	proposals, err := scanTerragrunt(root)
+	if err != nil {
+		return "", nil, err
+	}
+	if len(proposals) == 0 {
+		return "", nil, ErrNoLayoutFound
+	}
+	return TerragruntLayout, proposals, nil
+}
+
+func scanAtlantis(contents []byte) ([]*Proposal, error) {
+	var cfg atlantisConfig
+	if err := yaml.Unmarshal(contents, &cfg); err != nil {
+		return nil, err
+	}
+	// map project names to directories, for resolving dependencies
+	dirs := make(map[string]string, len(cfg.Projects))
+	for _, p := range cfg.Projects {
+		if p.Name != "" {
+			dirs[p.Name] = cleanDir(p.Dir)
+		}
+	}
+	proposals := make([]*Proposal, 0, len(cfg.Projects))
+	for _, p := range cfg.Projects {
+		dir := cleanDir(p.Dir)
+		name := p.Name
+		if name == "" {
+			name = dir
+			// a directory may be used by several projects, each with a
+			// different terraform workspace
+			if p.Workspace != "" && p.Workspace != "default" {
+				name += "-" + p.Workspace
+			}
+		}
+		proposal := &Proposal{
+			Name:             workspaceName(name),
+			WorkingDirectory: dir,
+			TerraformVersion: strings.TrimPrefix(p.TerraformVersion, "v"),
+		}
+		whenModified := atlantisDefaultWhenModified
+		if p.Autoplan != nil && len(p.Autoplan.WhenModified) > 0 {
+			whenModified = p.Autoplan.WhenModified
+		}
+		for _, patt := range whenModified {
+			proposal.TriggerPatterns = append(proposal.TriggerPatterns, path.Join("/", dir, patt))
+		}
+		for _, dep := range p.DependsOn {
+			depDir, ok := dirs[dep]
+			if !ok {
+				return nil, fmt.Errorf("project %s depends on unknown project %s", name, dep)
+			}
+			proposal.addDependency(depDir, workspaceName(dep))
+		}
+		proposals = append(proposals, proposal)
+	}
+	return proposals, nil
+}
+
+// scanTerragrunt walks the directory tree, proposing a workspace for each
+// directory containing a terragrunt.hcl file. A terragrunt.hcl file at the
+// root is assumed to be the parent configuration included by the others
+// and is not proposed unless it is the only one.
+func scanTerragrunt(root string) ([]*Proposal, error) {
+	var (
+		proposals []*Proposal
+		// directories of each proposal's dependencies
+		deps = make(map[*Proposal][]string)
+	)
+	err := filepath.WalkDir(root, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			// skip hidden directories, e.g. .git and .terragrunt-cache
+			if p != root && strings.HasPrefix(d.Name(), ".") {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if d.Name() != terragruntConfigFilename {
+			return nil
+		}
+		rel, err := filepath.Rel(root, filepath.Dir(p))
+		if err != nil {
+			return err
+		}
+		dir := cleanDir(filepath.ToSlash(rel))
+		depPaths, err := terragruntDependencies(p)
+		if err != nil {
+			return err
+		}
+		name := dir
+		if name == "" {
+			name = "root"
+		}
+		proposal := &Proposal{
+			Name:             workspaceName(name),
+			WorkingDirectory: dir,
+			TriggerPatterns:  []string{path.Join("/", dir, "**")},
+		}
+		for _, dep := range depPaths {
+			deps[proposal] = append(deps[proposal], cleanDir(path.Join(dir, dep)))
+		}
+		proposals = append(proposals, proposal)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(proposals) > 1 {
+		proposals = slices.DeleteFunc(proposals, func(p *Proposal) bool {
+			return p.WorkingDirectory == ""
+		})
+	}
+	// resolve dependencies now that the workspaces for all modules are known
+	byDir := make(map[string]*Proposal, len(proposals))
+	for _, p := range proposals {
+		byDir[p.WorkingDirectory] = p
+	}
+	for _, p := range proposals {
+		for _, dir := range deps[p] {
+			var source string
+			if dep, ok := byDir[dir]; ok {
+				source = dep.Name
+			}
+			p.addDependency(dir, source)
+		}
+	}
+	return proposals, nil
+}
+
+// terragruntDependencies parses a terragrunt.hcl file, returning the paths of
+// the modules it depends upon, via either dependency blocks or a dependencies
+// block. Paths are relative to the directory containing the file. Paths that
+// are not literal strings, e.g. those using terragrunt functions, are
+// skipped.
+func terragruntDependencies(fname string) ([]string, error) {
+	f, diags := hclparse.NewParser().ParseHCLFile(fname)
+	if diags.HasErrors() {
+		return nil, fmt.Errorf("parsing %s: %s", fname, diags.Error())
+	}
+	content, _, diags := f.Body.PartialContent(&hcl.BodySchema{
+		Blocks: []hcl.BlockHeaderSchema{
+			{Type: "dependency", LabelNames: []string{"name"}},
+			{Type: "dependencies"},
+		},
+	})
+	if diags.HasErrors() {
+		return nil, fmt.Errorf("parsing %s: %s", fname, diags.Error())
+	}
+	var paths []string
+	for _, block := range content.Blocks {
+		attrs, _ := block.Body.JustAttributes()
+		switch block.Type {
+		case "dependency":
+			if attr, ok := attrs["config_path"]; ok {
+				if v, diags := attr.Expr.Value(nil); !diags.HasErrors() && v.Type() == cty.String && v.IsKnown() && !v.IsNull() {
+					paths = append(paths, v.AsString())
+				}
+			}
+		case "dependencies":
+			if attr, ok := attrs["paths"]; ok {
+				v, diags := attr.Expr.Value(nil)
+				if diags.HasErrors() || !v.IsKnown() || v.IsNull() || !v.CanIterateElements() {
+					continue
+				}
+				for it := v.ElementIterator(); it.Next(); {
+					_, elem := it.Element()
+					if elem.Type() == cty.String && elem.IsKnown() && !elem.IsNull() {
+						paths = append(paths, elem.AsString())
+					}
+				}
+			}
+		}
+	}
+	return paths, nil
+}
+
+// cleanDir normalizes a directory path relative to the root of the
+// repository, returning an empty string for the root itself.
+func cleanDir(dir string) string {
+	dir = strings.Trim(path.Clean("/"+dir), "/")
+	return dir
+}
+
+// workspaceName derives a valid workspace name from s.
+func workspaceName(s string) string {
+	return strings.Trim(invalidNameChars.ReplaceAllString(s, "-"), "-")
+}
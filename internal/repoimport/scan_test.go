@@ -0,0 +1,80 @@
+package repoimport
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScan(t *testing.T) {
+	t.Run("atlantis", func(t *testing.T) {
+		layout, got, err := scan("./testdata/atlantis")
+		require.NoError(t, err)
+
+		assert.Equal(t, AtlantisLayout, layout)
+		want := []*Proposal{
+			{
+				Name:             "network",
+				WorkingDirectory: "envs/prod/network",
+				TerraformVersion: "1.5.7",
+				TriggerPatterns: []string{
+					"/envs/prod/network/**/*.tf*",
+					"/envs/prod/network/**/terragrunt.hcl",
+					"/envs/prod/network/**/.terraform.lock.hcl",
+				},
+			},
+			{
+				Name:             "app",
+				WorkingDirectory: "envs/prod/app",
+				TriggerPatterns: []string{
+					"/envs/prod/app/*.tf",
+					"/envs/prod/modules/**/*.tf",
+				},
+				Dependencies:      []string{"envs/prod/network"},
+				RunTriggerSources: []string{"network"},
+			},
+			{
+				Name:             "envs-staging-blue",
+				WorkingDirectory: "envs/staging",
+				TriggerPatterns: []string{
+					"/envs/staging/**/*.tf*",
+					"/envs/staging/**/terragrunt.hcl",
+					"/envs/staging/**/.terraform.lock.hcl",
+				},
+			},
+		}
+		assert.Equal(t, want, got)
+	})
+
+	t.Run("terragrunt", func(t *testing.T) {
+		layout, got, err := scan("./testdata/terragrunt")
+		require.NoError(t, err)
+
+		assert.Equal(t, TerragruntLayout, layout)
+		want := []*Proposal{
+			{
+				Name:             "prod-app",
+				WorkingDirectory: "prod/app",
+				TriggerPatterns: []string{
+					"/prod/app/**",
+					// no workspace is proposed for shared/dns
+					"/shared/dns/**",
+				},
+				Dependencies:      []string{"prod/vpc", "shared/dns"},
+				RunTriggerSources: []string{"prod-vpc"},
+			},
+			{
+				Name:             "prod-vpc",
+				WorkingDirectory: "prod/vpc",
+				TriggerPatterns:  []string{"/prod/vpc/**"},
+			},
+		}
+		assert.Equal(t, want, got)
+	})
+
+	t.Run("no layout found", func(t *testing.T) {
+		_, _, err := scan(t.TempDir())
+		assert.Equal(t, ErrNoLayoutFound, err)
+	})
+}
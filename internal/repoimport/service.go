@@ -0,0 +1,191 @@
+package repoimport
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/gorilla/mux"
+	"github.com/leg100/otf/internal"
+	"github.com/leg100/otf/internal/logr"
+	"github.com/leg100/otf/internal/organization"
+	"github.com/leg100/otf/internal/rbac"
+	"github.com/leg100/otf/internal/runtrigger"
+	"github.com/leg100/otf/internal/tfeapi"
+	"github.com/leg100/otf/internal/vcs"
+	"github.com/leg100/otf/internal/vcsprovider"
+	"github.com/leg100/otf/internal/workspace"
+)
+
+type (
+	Service struct {
+		logr.Logger
+
+		organization internal.Authorizer
+		api          *api
+
+		workspaces   WorkspaceService
+		vcsproviders VCSProviderService
+		runtriggers  RunTriggerService
+	}
+
+	Options struct {
+		logr.Logger
+		*tfeapi.Responder
+
+		WorkspaceService   WorkspaceService
+		VCSProviderService VCSProviderService
+		RunTriggerService  RunTriggerService
+	}
+
+	WorkspaceService interface {
+		Create(ctx context.Context, opts workspace.CreateOptions) (*workspace.Workspace, error)
+		GetByName(ctx context.Context, organization, workspace string) (*workspace.Workspace, error)
+	}
+
+	VCSProviderService interface {
+		Get(ctx context.Context, providerID string) (*vcsprovider.VCSProvider, error)
+		GetVCSClient(ctx context.Context, providerID string) (vcs.Client, error)
+	}
+
+	RunTriggerService interface {
+		Create(ctx context.Context, workspaceID, sourceableID string) (*runtrigger.RunTrigger, error)
+	}
+)
+
+func NewService(opts Options) *Service {
+	svc := &Service{
+		Logger:       opts.Logger,
+		organization: &organization.Authorizer{Logger: opts.Logger},
+		workspaces:   opts.WorkspaceService,
+		vcsproviders: opts.VCSProviderService,
+		runtriggers:  opts.RunTriggerService,
+	}
+	svc.api = &api{
+		Service:   svc,
+		Responder: opts.Responder,
+	}
+	return svc
+}
+
+func (s *Service) AddHandlers(r *mux.Router) {
+	s.api.addHandlers(r)
+}
+
+// Import scans a repository for an atlantis.yaml or terragrunt configuration
+// and proposes a workspace for each project or module found, creating those
+// that don't already exist unless it is a dry run.
+func (s *Service) Import(ctx context.Context, opts ImportOptions) (*Result, error) {
+	subject, err := s.organization.CanAccess(ctx, rbac.CreateWorkspaceAction, opts.Organization)
+	if err != nil {
+		return nil, err
+	}
+	if err := opts.validate(); err != nil {
+		return nil, err
+	}
+	provider, err := s.vcsproviders.Get(ctx, opts.VCSProviderID)
+	if err != nil {
+		return nil, err
+	}
+	if provider.Organization != opts.Organization {
+		return nil, internal.ErrResourceNotFound
+	}
+	layout, proposals, err := s.scanRepo(ctx, opts)
+	if err != nil {
+		s.Error(err, "scanning repository", "repo", opts.Repo, "subject", subject)
+		return nil, err
+	}
+	for _, p := range proposals {
+		ws, err := s.workspaces.GetByName(ctx, opts.Organization, p.Name)
+		if err == nil {
+			p.Exists = true
+			p.WorkspaceID = ws.ID
+			continue
+		} else if !errors.Is(err, internal.ErrResourceNotFound) {
+			return nil, err
+		}
+		if opts.DryRun {
+			continue
+		}
+		ws, err = s.workspaces.Create(ctx, p.createOptions(opts))
+		if err != nil {
+			return nil, fmt.Errorf("creating workspace %s: %w", p.Name, err)
+		}
+		p.WorkspaceID = ws.ID
+	}
+	if !opts.DryRun {
+		if err := s.createRunTriggers(ctx, proposals); err != nil {
+			return nil, err
+		}
+	}
+	s.V(0).Info("imported workspaces from repository", "repo", opts.Repo, "layout", layout, "dry_run", opts.DryRun, "count", len(proposals), "subject", subject)
+	return &Result{Layout: layout, DryRun: opts.DryRun, Proposals: proposals}, nil
+}
+
+// createRunTriggers creates a run trigger on each workspace created for a
+// proposal, for each of the proposal's run trigger sources. Workspaces that
+// already existed are left untouched.
+func (s *Service) createRunTriggers(ctx context.Context, proposals []*Proposal) error {
+	ids := make(map[string]string, len(proposals))
+	for _, p := range proposals {
+		ids[p.Name] = p.WorkspaceID
+	}
+	for _, p := range proposals {
+		if p.Exists {
+			continue
+		}
+		for _, source := range p.RunTriggerSources {
+			if _, err := s.runtriggers.Create(ctx, p.WorkspaceID, ids[source]); err != nil {
+				return fmt.Errorf("creating run trigger from %s to %s: %w", source, p.Name, err)
+			}
+		}
+	}
+	return nil
+}
+
+// scanRepo downloads and unpacks the repository and scans its contents.
+func (s *Service) scanRepo(ctx context.Context, opts ImportOptions) (Layout, []*Proposal, error) {
+	client, err := s.vcsproviders.GetVCSClient(ctx, opts.VCSProviderID)
+	if err != nil {
+		return "", nil, err
+	}
+	tarball, _, err := client.GetRepoTarball(ctx, vcs.GetRepoTarballOptions{
+		Repo: opts.Repo,
+		Ref:  opts.Branch,
+	})
+	if err != nil {
+		return "", nil, fmt.Errorf("retrieving repository tarball: %w", err)
+	}
+	root, err := os.MkdirTemp("", "otf-repoimport-*")
+	if err != nil {
+		return "", nil, err
+	}
+	defer os.RemoveAll(root)
+
+	if err := internal.Unpack(bytes.NewReader(tarball), root); err != nil {
+		return "", nil, err
+	}
+	return scan(root)
+}
+
+func (p *Proposal) createOptions(opts ImportOptions) workspace.CreateOptions {
+	createOpts := workspace.CreateOptions{
+		Name:            internal.String(p.Name),
+		Organization:    internal.String(opts.Organization),
+		TriggerPatterns: p.TriggerPatterns,
+		ConnectOptions: &workspace.ConnectOptions{
+			RepoPath:      internal.String(opts.Repo),
+			VCSProviderID: internal.String(opts.VCSProviderID),
+			Branch:        opts.Branch,
+		},
+	}
+	if p.WorkingDirectory != "" {
+		createOpts.WorkingDirectory = internal.String(p.WorkingDirectory)
+	}
+	if p.TerraformVersion != "" {
+		createOpts.TerraformVersion = internal.String(p.TerraformVersion)
+	}
+	return createOpts
+}
@@ -0,0 +1,134 @@
+package repoimport
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/leg100/otf/internal"
+	"github.com/leg100/otf/internal/organization"
+	"github.com/leg100/otf/internal/runtrigger"
+	"github.com/leg100/otf/internal/vcs"
+	"github.com/leg100/otf/internal/vcsprovider"
+	"github.com/leg100/otf/internal/workspace"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type (
+	fakeWorkspaceService struct {
+		existing map[string]string // workspace name to ID
+		created  []string
+	}
+
+	fakeVCSProviderService struct {
+		tarball []byte
+	}
+
+	fakeVCSClient struct {
+		tarball []byte
+		vcs.Client
+	}
+
+	fakeRunTriggerService struct {
+		created [][2]string // workspace ID and source workspace ID
+	}
+)
+
+func (f *fakeWorkspaceService) Create(ctx context.Context, opts workspace.CreateOptions) (*workspace.Workspace, error) {
+	f.created = append(f.created, *opts.Name)
+	return &workspace.Workspace{ID: "ws-" + *opts.Name, Name: *opts.Name}, nil
+}
+
+func (f *fakeWorkspaceService) GetByName(ctx context.Context, organization, name string) (*workspace.Workspace, error) {
+	if id, ok := f.existing[name]; ok {
+		return &workspace.Workspace{ID: id, Name: name}, nil
+	}
+	return nil, internal.ErrResourceNotFound
+}
+
+func (f *fakeVCSProviderService) Get(ctx context.Context, providerID string) (*vcsprovider.VCSProvider, error) {
+	return &vcsprovider.VCSProvider{ID: providerID, Organization: "acme"}, nil
+}
+
+func (f *fakeVCSProviderService) GetVCSClient(ctx context.Context, providerID string) (vcs.Client, error) {
+	return &fakeVCSClient{tarball: f.tarball}, nil
+}
+
+func (f *fakeVCSClient) GetRepoTarball(context.Context, vcs.GetRepoTarballOptions) ([]byte, string, error) {
+	return f.tarball, "", nil
+}
+
+func (f *fakeRunTriggerService) Create(ctx context.Context, workspaceID, sourceableID string) (*runtrigger.RunTrigger, error) {
+	f.created = append(f.created, [2]string{workspaceID, sourceableID})
+	return &runtrigger.RunTrigger{}, nil
+}
+
+func TestService_Import(t *testing.T) {
+	tarball, err := internal.Pack("./testdata/terragrunt")
+	require.NoError(t, err)
+
+	ctx := internal.AddSubjectToContext(context.Background(), &internal.Superuser{Username: "bob"})
+	opts := ImportOptions{
+		Organization:  "acme",
+		VCSProviderID: "vcs-123",
+		Repo:          "acme/infra",
+	}
+
+	newService := func(ws *fakeWorkspaceService, rt *fakeRunTriggerService) *Service {
+		return &Service{
+			Logger:       logr.Discard(),
+			organization: &organization.Authorizer{Logger: logr.Discard()},
+			workspaces:   ws,
+			vcsproviders: &fakeVCSProviderService{tarball: tarball},
+			runtriggers:  rt,
+		}
+	}
+
+	t.Run("create run triggers for dependencies", func(t *testing.T) {
+		ws := &fakeWorkspaceService{}
+		rt := &fakeRunTriggerService{}
+
+		_, err := newService(ws, rt).Import(ctx, opts)
+		require.NoError(t, err)
+
+		assert.Equal(t, []string{"prod-app", "prod-vpc"}, ws.created)
+		assert.Equal(t, [][2]string{{"ws-prod-app", "ws-prod-vpc"}}, rt.created)
+	})
+
+	t.Run("dependency on existing workspace", func(t *testing.T) {
+		ws := &fakeWorkspaceService{existing: map[string]string{"prod-vpc": "ws-existing"}}
+		rt := &fakeRunTriggerService{}
+
+		_, err := newService(ws, rt).Import(ctx, opts)
+		require.NoError(t, err)
+
+		assert.Equal(t, []string{"prod-app"}, ws.created)
+		assert.Equal(t, [][2]string{{"ws-prod-app", "ws-existing"}}, rt.created)
+	})
+
+	t.Run("existing workspace left untouched", func(t *testing.T) {
+		ws := &fakeWorkspaceService{existing: map[string]string{"prod-app": "ws-existing"}}
+		rt := &fakeRunTriggerService{}
+
+		_, err := newService(ws, rt).Import(ctx, opts)
+		require.NoError(t, err)
+
+		assert.Equal(t, []string{"prod-vpc"}, ws.created)
+		assert.Empty(t, rt.created)
+	})
+
+	t.Run("dry run", func(t *testing.T) {
+		ws := &fakeWorkspaceService{}
+		rt := &fakeRunTriggerService{}
+
+		dryRun := opts
+		dryRun.DryRun = true
+		got, err := newService(ws, rt).Import(ctx, dryRun)
+		require.NoError(t, err)
+
+		assert.Empty(t, ws.created)
+		assert.Empty(t, rt.created)
+		assert.Equal(t, []string{"prod-vpc"}, got.Proposals[0].RunTriggerSources)
+	})
+}
@@ -0,0 +1,15 @@
+include "root" {
+  path = find_in_parent_folders()
+}
+
+dependency "vpc" {
+  config_path = "../vpc"
+}
+
+dependencies {
+  paths = ["../vpc", "../../shared/dns"]
+}
+
+inputs = {
+  vpc_id = dependency.vpc.outputs.vpc_id
+}
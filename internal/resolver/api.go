@@ -0,0 +1,53 @@
+package resolver
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	otfapi "github.com/leg100/otf/internal/api"
+	"github.com/leg100/otf/internal/http/decode"
+	"github.com/leg100/otf/internal/tfeapi"
+)
+
+type (
+	api struct {
+		*Service
+	}
+
+	// Resolution is the result of resolving a reference to a resource.
+	Resolution struct {
+		ID string `json:"id"`
+	}
+)
+
+func (a *api) addHandlers(r *mux.Router) {
+	r = r.PathPrefix(otfapi.DefaultBasePath).Subrouter()
+
+	r.HandleFunc("/resolve/{kind}", a.resolve).Methods("GET")
+}
+
+func (a *api) resolve(w http.ResponseWriter, r *http.Request) {
+	var params struct {
+		Kind string `schema:"kind,required"`
+		Ref  string `schema:"ref,required"`
+	}
+	if err := decode.All(&params, r); err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	kind, err := parseKind(params.Kind)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+
+	id, err := a.Resolve(r.Context(), kind, params.Ref)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+
+	w.Header().Set("Content-type", "application/json")
+	json.NewEncoder(w).Encode(&Resolution{ID: id})
+}
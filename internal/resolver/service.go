@@ -0,0 +1,89 @@
+// Package resolver resolves references to resources into resource IDs.
+package resolver
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/gorilla/mux"
+	"github.com/leg100/otf/internal"
+	"github.com/leg100/otf/internal/logr"
+	"github.com/leg100/otf/internal/resource"
+)
+
+var errUnknownKind = errors.New("unknown kind of resource")
+
+type (
+	// Service resolves references to resources. A reference is either the
+	// ID of a resource or an external alias that uniquely identifies it, e.g.
+	// <organization>/<name> for a workspace.
+	Service struct {
+		logr.Logger
+
+		resolvers map[resource.Kind]AliasFunc
+		api       *api
+	}
+
+	Options struct {
+		logr.Logger
+	}
+
+	// AliasFunc looks up a resource by its alias, returning its ID.
+	AliasFunc func(ctx context.Context, alias string) (string, error)
+)
+
+func NewService(opts Options) *Service {
+	svc := &Service{
+		Logger:    opts.Logger,
+		resolvers: make(map[resource.Kind]AliasFunc),
+	}
+	svc.api = &api{Service: svc}
+	return svc
+}
+
+func (s *Service) AddHandlers(r *mux.Router) {
+	s.api.addHandlers(r)
+}
+
+// Register a function for looking up resources of the given kind by their
+// alias.
+func (s *Service) Register(kind resource.Kind, fn AliasFunc) {
+	s.resolvers[kind] = fn
+}
+
+// Resolve a reference to a resource of the given kind into its ID. If the
+// reference is already an ID then it is returned as-is, otherwise it is looked
+// up as an alias.
+func (s *Service) Resolve(ctx context.Context, kind resource.Kind, ref string) (string, error) {
+	if resource.ValidateID(ref, kind) == nil {
+		return ref, nil
+	}
+	fn, ok := s.resolvers[kind]
+	if !ok {
+		return "", &internal.InvalidParameterError{
+			Parameter: "ref",
+			Err:       fmt.Errorf("not a valid ID for resource kind %s: %s", kind, ref),
+		}
+	}
+	id, err := fn(ctx, ref)
+	if err != nil {
+		return "", err
+	}
+
+	s.V(9).Info("resolved alias", "kind", kind, "alias", ref, "id", id)
+
+	return id, nil
+}
+
+// parseKind parses the kind of a resource, checking it is known.
+func parseKind(s string) (resource.Kind, error) {
+	kind := resource.Kind(s)
+	if !resource.IsKind(kind) {
+		return "", &internal.InvalidParameterError{
+			Parameter: "kind",
+			Err:       fmt.Errorf("%w: %s", errUnknownKind, s),
+		}
+	}
+	return kind, nil
+}
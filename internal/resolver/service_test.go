@@ -0,0 +1,67 @@
+package resolver
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/leg100/otf/internal"
+	"github.com/leg100/otf/internal/logr"
+	"github.com/leg100/otf/internal/resource"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResolve(t *testing.T) {
+	ctx := context.Background()
+	svc := NewService(Options{Logger: logr.Discard()})
+	svc.Register(resource.WorkspaceKind, func(ctx context.Context, alias string) (string, error) {
+		if alias != "acme/dev" {
+			return "", internal.ErrResourceNotFound
+		}
+		return "ws-abc123", nil
+	})
+
+	t.Run("id", func(t *testing.T) {
+		got, err := svc.Resolve(ctx, resource.WorkspaceKind, "ws-xyz789")
+		require.NoError(t, err)
+		assert.Equal(t, "ws-xyz789", got)
+	})
+
+	t.Run("alias", func(t *testing.T) {
+		got, err := svc.Resolve(ctx, resource.WorkspaceKind, "acme/dev")
+		require.NoError(t, err)
+		assert.Equal(t, "ws-abc123", got)
+	})
+
+	t.Run("unknown alias", func(t *testing.T) {
+		_, err := svc.Resolve(ctx, resource.WorkspaceKind, "acme/prod")
+		assert.Equal(t, internal.ErrResourceNotFound, err)
+	})
+
+	t.Run("kind without aliases", func(t *testing.T) {
+		_, err := svc.Resolve(ctx, resource.RunKind, "acme/dev")
+		var invalid *internal.InvalidParameterError
+		assert.True(t, errors.As(err, &invalid), "got error: %v", err)
+	})
+
+	t.Run("api", func(t *testing.T) {
+		r := mux.NewRouter()
+		svc.AddHandlers(r)
+
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest("GET", "/otfapi/resolve/ws?ref=acme/dev", nil))
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		var got Resolution
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&got))
+		assert.Equal(t, "ws-abc123", got.ID)
+
+		w = httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest("GET", "/otfapi/resolve/foo?ref=acme/dev", nil))
+		assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+	})
+}
@@ -0,0 +1,198 @@
+package resource
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/leg100/otf/internal"
+)
+
+// ErrInvalidID is returned when parsing a string that is not a valid resource
+// ID.
+var ErrInvalidID = errors.New("invalid resource ID")
+
+// Kind is a kind of resource, e.g. a workspace. Its string value is the prefix
+// of the IDs of resources of that kind, e.g. "ws" for workspaces.
+type Kind string
+
+const (
+	AgentKind                     Kind = "agent"
+	AgentPoolKind                 Kind = "apool"
+	AgentTokenKind                Kind = "at"
+	ApplyKind                     Kind = "apply"
+	AssessmentResultKind          Kind = "asmtres"
+	AuditEventKind                Kind = "audit"
+	BannerKind                    Kind = "banner"
+	BlobMigrationKind             Kind = "bmig"
+	ChangeTicketKind              Kind = "ct"
+	ConfigVersionKind             Kind = "cv"
+	CostEstimateKind              Kind = "ce"
+	EmailMessageKind              Kind = "email"
+	GPGKeyKind                    Kind = "gpgkey"
+	IngressAttributesKind         Kind = "ia"
+	ModuleKind                    Kind = "mod"
+	ModuleVersionKind             Kind = "modver"
+	NotificationConfigurationKind Kind = "nc"
+	OrganizationImportKind        Kind = "orgimport"
+	OrganizationKind              Kind = "org"
+	OrganizationMembershipKind    Kind = "ou"
+	OrganizationTokenKind         Kind = "ot"
+	PlanKind                      Kind = "plan"
+	PolicyCheckKind               Kind = "polchk"
+	PolicySetKind                 Kind = "polset"
+	PolicySetParameterKind        Kind = "polvar"
+	PolicySetVersionKind          Kind = "polsetver"
+	ProviderKind                  Kind = "prov"
+	ProviderPlatformKind          Kind = "provpltfrm"
+	ProviderVersionKind           Kind = "provver"
+	ResourceChangeKind            Kind = "rc"
+	RunKind                       Kind = "run"
+	RunTriggerKind                Kind = "rt"
+	RunTaskKind                   Kind = "task"
+	SSHKeyKind                    Kind = "sshkey"
+	StateVersionKind              Kind = "sv"
+	StateVersionOutputKind        Kind = "wsout"
+	TagKind                       Kind = "tag"
+	TaskResultKind                Kind = "taskrs"
+	TaskStageKind                 Kind = "ts"
+	TeamKind                      Kind = "team"
+	TeamTokenKind                 Kind = "tt"
+	UserKind                      Kind = "user"
+	UserTokenKind                 Kind = "ut"
+	VariableKind                  Kind = "var"
+	VariableSetKind               Kind = "varset"
+	VCSEventDeadLetterKind        Kind = "vcsdl"
+	VCSEventQueueKind             Kind = "vcsq"
+	VCSProviderKind               Kind = "vcs"
+	WorkspaceKind                 Kind = "ws"
+	WorkspaceRunTaskKind          Kind = "wstask"
+)
+
+// kinds is the registry of kinds of resource, ensuring each prefix identifies
+// only one kind.
+var kinds = map[Kind]bool{
+	AgentKind:                     true,
+	AgentPoolKind:                 true,
+	AgentTokenKind:                true,
+	ApplyKind:                     true,
+	AssessmentResultKind:          true,
+	AuditEventKind:                true,
+	BannerKind:                    true,
+	ConfigVersionKind:             true,
+	CostEstimateKind:              true,
+	EmailMessageKind:              true,
+	GPGKeyKind:                    true,
+	IngressAttributesKind:         true,
+	ModuleKind:                    true,
+	ModuleVersionKind:             true,
+	NotificationConfigurationKind: true,
+	OrganizationImportKind:        true,
+	OrganizationKind:              true,
+	OrganizationMembershipKind:    true,
+	OrganizationTokenKind:         true,
+	PlanKind:                      true,
+	PolicyCheckKind:               true,
+	PolicySetKind:                 true,
+	PolicySetParameterKind:        true,
+	PolicySetVersionKind:          true,
+	ProviderKind:                  true,
+	ProviderPlatformKind:          true,
+	ProviderVersionKind:           true,
+	RunKind:                       true,
+	RunTriggerKind:                true,
+	RunTaskKind:                   true,
+	SSHKeyKind:                    true,
+	StateVersionKind:              true,
+	StateVersionOutputKind:        true,
+	TagKind:                       true,
+	TaskResultKind:                true,
+	TaskStageKind:                 true,
+	TeamKind:                      true,
+	TeamTokenKind:                 true,
+	UserKind:                      true,
+	UserTokenKind:                 true,
+	VariableKind:                  true,
+	VariableSetKind:               true,
+	VCSProviderKind:               true,
+	WorkspaceKind:                 true,
+	WorkspaceRunTaskKind:          true,
+}
+
+// IsKind determines whether the kind of resource is known.
+func IsKind(kind Kind) bool {
+	return kinds[kind]
+}
+
+// ID is a resource ID, composed of the kind of resource, a hyphen, and a
+// suffix unique to the resource, e.g. ws-3bXkT9yfGm2yvhdZ.
+type ID struct {
+	Kind   Kind
+	suffix string
+}
+
+// NewID constructs a new ID for a resource of the given kind.
+func NewID(kind Kind) string {
+	return internal.NewID(string(kind))
+}
+
+// ParseID parses a string into a resource ID, checking that its prefix is a
+// known kind of resource.
+func ParseID(s string) (ID, error) {
+	prefix, suffix, found := strings.Cut(s, "-")
+	if !found || suffix == "" || strings.Contains(suffix, "-") || !internal.ReStringID.MatchString(suffix) {
+		return ID{}, fmt.Errorf("%w: %s", ErrInvalidID, s)
+	}
+	if !kinds[Kind(prefix)] {
+		return ID{}, fmt.Errorf("%w: unknown kind of resource: %s", ErrInvalidID, prefix)
+	}
+	return ID{Kind: Kind(prefix), suffix: suffix}, nil
+}
+
+// ValidateID checks that the string is a valid ID for a resource of the given
+// kind.
+func ValidateID(s string, kind Kind) error {
+	id, err := ParseID(s)
+	if err != nil {
+		return err
+	}
+	if id.Kind != kind {
+		return fmt.Errorf("%w: expected ID for %s resource: %s", ErrInvalidID, kind, s)
+	}
+	return nil
+}
+
+func (id ID) String() string {
+	return string(id.Kind) + "-" + id.suffix
+}
+
+// Convert converts the ID for use with a resource of a different kind that has
+// a one-to-one relationship with the resource, e.g. convert run-123 to
+// plan-123.
+func (id ID) Convert(kind Kind) ID {
+	return ID{Kind: kind, suffix: id.suffix}
+}
+
+// ConvertID converts an ID string for use with a resource of a different kind,
+// e.g. convert run-123 to plan-123. If the ID is not in the expected form then
+// it is returned unchanged.
+func ConvertID(s string, kind Kind) string {
+	_, suffix, found := strings.Cut(s, "-")
+	if !found || strings.Contains(suffix, "-") {
+		return s
+	}
+	return string(kind) + "-" + suffix
+}
+
+// ParseAlias parses an alias of the form <organization>/<name>, identifying a
+// resource belonging to an organization, e.g. a workspace.
+func ParseAlias(alias string) (organization, name string, err error) {
+	organization, name, found := strings.Cut(alias, "/")
+	if !found || organization == "" || name == "" || strings.Contains(name, "/") {
+		return "", "", &internal.InvalidParameterError{
+			Parameter: "ref",
+			Err:       fmt.Errorf("alias must be of the form <organization>/<name>: %s", alias),
+		}
+	}
+	return organization, name, nil
+}
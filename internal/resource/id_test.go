@@ -0,0 +1,50 @@
+package resource
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/leg100/otf/internal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseID(t *testing.T) {
+	id := NewID(WorkspaceKind)
+	got, err := ParseID(id)
+	require.NoError(t, err)
+	assert.Equal(t, WorkspaceKind, got.Kind)
+	assert.Equal(t, id, got.String())
+
+	for _, invalid := range []string{"", "ws", "ws-", "-abc", "foo-abc", "ws-abc-def", "ws-a/b"} {
+		t.Run(invalid, func(t *testing.T) {
+			_, err := ParseID(invalid)
+			assert.True(t, errors.Is(err, ErrInvalidID), "got error: %v", err)
+		})
+	}
+}
+
+func TestValidateID(t *testing.T) {
+	assert.NoError(t, ValidateID("run-abc123", RunKind))
+	assert.True(t, errors.Is(ValidateID("run-abc123", WorkspaceKind), ErrInvalidID))
+}
+
+func TestConvertID(t *testing.T) {
+	assert.Equal(t, "plan-abc123", ConvertID("run-abc123", PlanKind))
+	assert.Equal(t, "abc123", ConvertID("abc123", PlanKind))
+}
+
+func TestParseAlias(t *testing.T) {
+	organization, name, err := ParseAlias("acme/dev")
+	require.NoError(t, err)
+	assert.Equal(t, "acme", organization)
+	assert.Equal(t, "dev", name)
+
+	for _, invalid := range []string{"acme", "acme/", "/dev", "acme/dev/prod"} {
+		t.Run(invalid, func(t *testing.T) {
+			_, _, err := ParseAlias(invalid)
+			var want *internal.InvalidParameterError
+			assert.True(t, errors.As(err, &want), "got error: %v", err)
+		})
+	}
+}
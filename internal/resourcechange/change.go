@@ -0,0 +1,172 @@
+// Package resourcechange records the changes an apply makes to individual
+// resources, publishing them as events so that external systems such as
+// CMDBs and asset inventories can stay in sync with applied infrastructure.
+package resourcechange
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/leg100/otf/internal"
+	"github.com/leg100/otf/internal/resource"
+	"github.com/leg100/otf/internal/run"
+)
+
+const (
+	ActionCreated Action = "created"
+	ActionUpdated Action = "updated"
+	ActionDeleted Action = "deleted"
+)
+
+// applyLineRegex matches a line in the apply output reporting a change made to
+// a resource, e.g.:
+//
+//	random_pet.pet: Creation complete after 0s [id=hopeful-cat]
+//	module.vpc.aws_subnet.private[0]: Modifications complete after 2s [id=subnet-123]
+//	aws_instance.web (deposed object 1a2b3c4d): Destruction complete after 30s
+var applyLineRegex = regexp.MustCompile(`(?m)^(.+?)(?: \(deposed object [0-9a-f]+\))?: (Creation|Modifications|Destruction) complete after [^\s\[]+(?: \[id=(.*)\])?\r?$`)
+
+var applyLineActions = map[string]Action{
+	"Creation":      ActionCreated,
+	"Modifications": ActionUpdated,
+	"Destruction":   ActionDeleted,
+}
+
+type (
+	// Change is a change made to a resource by an apply.
+	Change struct {
+		ID        string    `jsonapi:"primary,resource-changes" json:"id"`
+		CreatedAt time.Time `jsonapi:"attribute" json:"created_at"`
+		RunID     string    `jsonapi:"attribute" json:"run_id"`
+		Action    Action    `jsonapi:"attribute" json:"action"`
+		// Address is the resource instance address, e.g.
+		// module.vpc.aws_subnet.private[0]
+		Address string `jsonapi:"attribute" json:"address"`
+		// Module is the address of the module containing the resource;
+		// empty for the root module.
+		Module string `jsonapi:"attribute" json:"module"`
+		// Mode is either managed or data.
+		Mode string `jsonapi:"attribute" json:"mode"`
+		Type string `jsonapi:"attribute" json:"type"`
+		Name string `jsonapi:"attribute" json:"name"`
+		// Provider is the fully qualified provider name, e.g.
+		// registry.terraform.io/hashicorp/aws
+		Provider string `jsonapi:"attribute" json:"provider"`
+		// RemoteID is the ID of the object as reported by the provider; empty
+		// for deleted resources.
+		RemoteID string `jsonapi:"attribute" json:"remote_id"`
+
+		WorkspaceID   string `jsonapi:"attribute" json:"workspace_id"`
+		WorkspaceName string `jsonapi:"attribute" json:"workspace_name"`
+		Organization  string `jsonapi:"attribute" json:"organization"`
+	}
+
+	// Action is the action performed on a resource.
+	Action string
+
+	// Config configures the webhook to which resource change events are
+	// exported.
+	Config struct {
+		// WebhookURL is the URL to which events are sent. If empty then
+		// events are not exported.
+		WebhookURL string
+		// HMACKey, if non-empty, is used to sign events sent to the webhook.
+		HMACKey string
+	}
+
+	// planFile is the subset of the JSON plan needed to describe changed
+	// resources.
+	planFile struct {
+		ResourceChanges []planResourceChange `json:"resource_changes"`
+	}
+
+	planResourceChange struct {
+		Address       string `json:"address"`
+		ModuleAddress string `json:"module_address"`
+		Mode          string `json:"mode"`
+		Type          string `json:"type"`
+		Name          string `json:"name"`
+		ProviderName  string `json:"provider_name"`
+	}
+)
+
+// Enabled determines whether events are exported to a webhook.
+func (cfg Config) Enabled() bool { return cfg.WebhookURL != "" }
+
+// Valid validates the config.
+func (cfg Config) Valid() error {
+	if !cfg.Enabled() {
+		return nil
+	}
+	u, err := url.Parse(cfg.WebhookURL)
+	if err != nil {
+		return &internal.InvalidParameterError{Parameter: "resource-change-webhook-url", Err: err}
+	}
+	if u.Scheme != "http" && u.Scheme != "https" || u.Host == "" {
+		return &internal.InvalidParameterError{
+			Parameter: "resource-change-webhook-url",
+			Err:       fmt.Errorf("must be an absolute http or https url: %s", cfg.WebhookURL),
+		}
+	}
+	return nil
+}
+
+// parseApplyOutput parses the changes made to resources from the output of
+// an apply, describing each resource using the run's JSON plan. Replacing a
+// resource results in both a deleted and a created change.
+func parseApplyOutput(r *run.Run, output, planJSON []byte) ([]*Change, error) {
+	var plan planFile
+	if err := json.Unmarshal(planJSON, &plan); err != nil {
+		return nil, fmt.Errorf("parsing plan: %w", err)
+	}
+	resources := make(map[string]planResourceChange, len(plan.ResourceChanges))
+	for _, rc := range plan.ResourceChanges {
+		resources[rc.Address] = rc
+	}
+	// remove colors and the markers delimiting the phase's logs
+	text := internal.StripAnsi(string(output))
+	text = strings.Trim(text, string([]byte{internal.STX, internal.ETX}))
+
+	var changes []*Change
+	for _, m := range applyLineRegex.FindAllStringSubmatch(text, -1) {
+		address := m[1]
+		change := &Change{
+			ID:           resource.NewID(resource.ResourceChangeKind),
+			CreatedAt:    internal.CurrentTimestamp(nil),
+			RunID:        r.ID,
+			Action:       applyLineActions[m[2]],
+			Address:      address,
+			RemoteID:     m[3],
+			WorkspaceID:  r.WorkspaceID,
+			Organization: r.Organization,
+			Mode:         "managed",
+		}
+		if rc, ok := resources[address]; ok {
+			change.Module = rc.ModuleAddress
+			change.Mode = rc.Mode
+			change.Type = rc.Type
+			change.Name = rc.Name
+			change.Provider = rc.ProviderName
+		}
+		changes = append(changes, change)
+	}
+	return changes, nil
+}
+
+func (c *Change) String() string {
+	return strings.Join([]string{string(c.Action), c.Address}, " ")
+}
+
+func (c *Change) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.String("id", c.ID),
+		slog.String("run_id", c.RunID),
+		slog.String("action", string(c.Action)),
+		slog.String("address", c.Address),
+	)
+}
@@ -0,0 +1,83 @@
+package resourcechange
+
+import (
+	"testing"
+
+	"github.com/leg100/otf/internal"
+	"github.com/leg100/otf/internal/run"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseApplyOutput(t *testing.T) {
+	r := &run.Run{ID: "run-123", WorkspaceID: "ws-123", Organization: "acme"}
+	plan := []byte(`{
+  "resource_changes": [
+    {
+      "address": "random_pet.pet",
+      "mode": "managed",
+      "type": "random_pet",
+      "name": "pet",
+      "provider_name": "registry.terraform.io/hashicorp/random"
+    },
+    {
+      "address": "module.vpc.aws_subnet.private[0]",
+      "module_address": "module.vpc",
+      "mode": "managed",
+      "type": "aws_subnet",
+      "name": "private",
+      "provider_name": "registry.terraform.io/hashicorp/aws"
+    }
+  ]
+}`)
+	output := string([]byte{internal.STX}) +
+		"\x1b[0m\x1b[1mrandom_pet.pet: Destroying... [id=old-cat]\x1b[0m\n" +
+		"\x1b[0m\x1b[1mrandom_pet.pet: Destruction complete after 0s\x1b[0m\n" +
+		"\x1b[0m\x1b[1mrandom_pet.pet: Creating...\x1b[0m\n" +
+		"\x1b[0m\x1b[1mrandom_pet.pet: Creation complete after 0s [id=hopeful-cat]\x1b[0m\n" +
+		"module.vpc.aws_subnet.private[0]: Modifications complete after 2s [id=subnet-123]\r\n" +
+		"aws_instance.web (deposed object 1a2b3c4d): Destruction complete after 1m30s\n" +
+		"\n" +
+		"Apply complete! Resources: 1 added, 1 changed, 2 destroyed.\n" +
+		string([]byte{internal.ETX})
+
+	changes, err := parseApplyOutput(r, []byte(output), plan)
+	require.NoError(t, err)
+	require.Equal(t, 4, len(changes))
+
+	for _, c := range changes {
+		assert.Equal(t, "run-123", c.RunID)
+		assert.Equal(t, "ws-123", c.WorkspaceID)
+		assert.Equal(t, "acme", c.Organization)
+		assert.Equal(t, "managed", c.Mode)
+	}
+
+	assert.Equal(t, ActionDeleted, changes[0].Action)
+	assert.Equal(t, "random_pet.pet", changes[0].Address)
+	assert.Equal(t, "", changes[0].RemoteID)
+
+	assert.Equal(t, ActionCreated, changes[1].Action)
+	assert.Equal(t, "random_pet.pet", changes[1].Address)
+	assert.Equal(t, "hopeful-cat", changes[1].RemoteID)
+	assert.Equal(t, "random_pet", changes[1].Type)
+	assert.Equal(t, "pet", changes[1].Name)
+	assert.Equal(t, "", changes[1].Module)
+	assert.Equal(t, "registry.terraform.io/hashicorp/random", changes[1].Provider)
+
+	assert.Equal(t, ActionUpdated, changes[2].Action)
+	assert.Equal(t, "module.vpc.aws_subnet.private[0]", changes[2].Address)
+	assert.Equal(t, "subnet-123", changes[2].RemoteID)
+	assert.Equal(t, "module.vpc", changes[2].Module)
+	assert.Equal(t, "registry.terraform.io/hashicorp/aws", changes[2].Provider)
+
+	// resource missing from the plan is described by its address alone
+	assert.Equal(t, ActionDeleted, changes[3].Action)
+	assert.Equal(t, "aws_instance.web", changes[3].Address)
+	assert.Equal(t, "", changes[3].Type)
+}
+
+func TestConfig_Valid(t *testing.T) {
+	assert.NoError(t, Config{}.Valid())
+	assert.NoError(t, Config{WebhookURL: "https://cmdb.example.com/otf"}.Valid())
+	assert.Error(t, Config{WebhookURL: "cmdb.example.com"}.Valid())
+}
@@ -0,0 +1,98 @@
+package resourcechange
+
+import (
+	"context"
+
+	"github.com/jackc/pgtype"
+	"github.com/leg100/otf/internal/sql"
+	"github.com/leg100/otf/internal/sql/pggen"
+)
+
+type (
+	// pgdb is a database of resource changes on postgres
+	pgdb struct {
+		*sql.DB // provides access to generated SQL queries
+	}
+
+	changeRow struct {
+		ResourceChangeID pgtype.Text        `json:"resource_change_id"`
+		RunID            pgtype.Text        `json:"run_id"`
+		Address          pgtype.Text        `json:"address"`
+		Module           pgtype.Text        `json:"module"`
+		Mode             pgtype.Text        `json:"mode"`
+		Type             pgtype.Text        `json:"type"`
+		Name             pgtype.Text        `json:"name"`
+		Provider         pgtype.Text        `json:"provider"`
+		Action           pgtype.Text        `json:"action"`
+		RemoteID         pgtype.Text        `json:"remote_id"`
+		CreatedAt        pgtype.Timestamptz `json:"created_at"`
+		WorkspaceID      pgtype.Text        `json:"workspace_id"`
+		WorkspaceName    pgtype.Text        `json:"workspace_name"`
+		OrganizationName pgtype.Text        `json:"organization_name"`
+	}
+)
+
+func (r changeRow) toChange() *Change {
+	return &Change{
+		ID:            r.ResourceChangeID.String,
+		RunID:         r.RunID.String,
+		Address:       r.Address.String,
+		Module:        r.Module.String,
+		Mode:          r.Mode.String,
+		Type:          r.Type.String,
+		Name:          r.Name.String,
+		Provider:      r.Provider.String,
+		Action:        Action(r.Action.String),
+		RemoteID:      r.RemoteID.String,
+		CreatedAt:     r.CreatedAt.Time.UTC(),
+		WorkspaceID:   r.WorkspaceID.String,
+		WorkspaceName: r.WorkspaceName.String,
+		Organization:  r.OrganizationName.String,
+	}
+}
+
+// insertChanges persists changes, skipping those that have already been
+// recorded for the run.
+func (db *pgdb) insertChanges(ctx context.Context, changes []*Change) error {
+	return db.Tx(ctx, func(ctx context.Context, q pggen.Querier) error {
+		for _, c := range changes {
+			_, err := q.InsertResourceChange(ctx, pggen.InsertResourceChangeParams{
+				ResourceChangeID: sql.String(c.ID),
+				RunID:            sql.String(c.RunID),
+				Address:          sql.String(c.Address),
+				Module:           sql.String(c.Module),
+				Mode:             sql.String(c.Mode),
+				Type:             sql.String(c.Type),
+				Name:             sql.String(c.Name),
+				Provider:         sql.String(c.Provider),
+				Action:           sql.String(string(c.Action)),
+				RemoteID:         sql.String(c.RemoteID),
+				CreatedAt:        sql.Timestamptz(c.CreatedAt),
+			})
+			if err != nil {
+				return sql.Error(err)
+			}
+		}
+		return nil
+	})
+}
+
+func (db *pgdb) getChange(ctx context.Context, changeID string) (*Change, error) {
+	row, err := db.Conn(ctx).FindResourceChangeByID(ctx, sql.String(changeID))
+	if err != nil {
+		return nil, sql.Error(err)
+	}
+	return changeRow(row).toChange(), nil
+}
+
+func (db *pgdb) listChanges(ctx context.Context, runID string) ([]*Change, error) {
+	rows, err := db.Conn(ctx).FindResourceChangesByRunID(ctx, sql.String(runID))
+	if err != nil {
+		return nil, sql.Error(err)
+	}
+	changes := make([]*Change, len(rows))
+	for i, r := range rows {
+		changes[i] = changeRow(r).toChange()
+	}
+	return changes, nil
+}
@@ -0,0 +1,60 @@
+package resourcechange
+
+import (
+	"context"
+
+	"github.com/go-logr/logr"
+	"github.com/leg100/otf/internal/pubsub"
+)
+
+// ExporterLockID guarantees only one exporter on a cluster is running at any
+// time.
+const ExporterLockID int64 = 5577006791947779424
+
+type (
+	// Exporter sends resource changes to a webhook as they are recorded.
+	//
+	// Only one exporter should be running on an OTF cluster at any one time.
+	Exporter struct {
+		logr.Logger
+
+		changes exporterChangeClient
+		client  exporterClient
+	}
+
+	exporterChangeClient interface {
+		Watch(context.Context) (<-chan pubsub.Event[*Change], func())
+	}
+
+	exporterClient interface {
+		export(ctx context.Context, change *Change) error
+	}
+)
+
+// NewExporter constructs an exporter of resource changes.
+func (s *Service) NewExporter(logger logr.Logger) *Exporter {
+	return &Exporter{
+		Logger:  logger.WithValues("component", "resource-change-exporter"),
+		changes: s,
+		client:  s,
+	}
+}
+
+func (e *Exporter) String() string { return "resource-change-exporter" }
+
+// Start the exporter. Should be invoked in a go routine.
+func (e *Exporter) Start(ctx context.Context) error {
+	sub, unsub := e.changes.Watch(ctx)
+	defer unsub()
+
+	for event := range sub {
+		if event.Type != pubsub.CreatedEvent {
+			continue
+		}
+		// carry on exporting subsequent changes
+		if err := e.client.export(ctx, event.Payload); err != nil {
+			e.Error(err, "exporting resource change", "change", event.Payload)
+		}
+	}
+	return pubsub.ErrSubscriptionTerminated
+}
@@ -0,0 +1,69 @@
+package resourcechange
+
+import (
+	"context"
+
+	"github.com/go-logr/logr"
+	"github.com/leg100/otf/internal/pubsub"
+	"github.com/leg100/otf/internal/run"
+)
+
+// RecorderLockID guarantees only one recorder on a cluster is running at any
+// time.
+const RecorderLockID int64 = 5577006791947779423
+
+type (
+	// Recorder records the changes made to resources by runs once they have
+	// been applied.
+	//
+	// Only one recorder should be running on an OTF cluster at any one time.
+	Recorder struct {
+		logr.Logger
+
+		runs   recorderRunClient
+		client recorderClient
+	}
+
+	recorderRunClient interface {
+		Watch(context.Context) (<-chan pubsub.Event[*run.Run], func())
+	}
+
+	recorderClient interface {
+		record(ctx context.Context, r *run.Run) error
+	}
+)
+
+// NewRecorder constructs a recorder of resource changes.
+func (s *Service) NewRecorder(logger logr.Logger) *Recorder {
+	return &Recorder{
+		Logger: logger.WithValues("component", "resource-change-recorder"),
+		runs:   s.runs,
+		client: s,
+	}
+}
+
+func (r *Recorder) String() string { return "resource-change-recorder" }
+
+// Start the recorder. Should be invoked in a go routine.
+func (r *Recorder) Start(ctx context.Context) error {
+	sub, unsub := r.runs.Watch(ctx)
+	defer unsub()
+
+	for event := range sub {
+		if event.Type != pubsub.UpdatedEvent || !event.Payload.Done() {
+			continue
+		}
+		// an apply that errored or was canceled may nonetheless have changed
+		// some resources.
+		switch event.Payload.Apply.Status {
+		case run.PhaseFinished, run.PhaseErrored, run.PhaseCanceled:
+		default:
+			continue
+		}
+		// carry on recording subsequent runs
+		if err := r.client.record(ctx, event.Payload); err != nil {
+			r.Error(err, "recording resource changes", "run_id", event.Payload.ID)
+		}
+	}
+	return pubsub.ErrSubscriptionTerminated
+}
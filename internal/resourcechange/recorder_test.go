@@ -0,0 +1,59 @@
+package resourcechange
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/leg100/otf/internal/pubsub"
+	"github.com/leg100/otf/internal/run"
+	"github.com/stretchr/testify/assert"
+)
+
+type (
+	fakeRecorderRunClient struct {
+		events []pubsub.Event[*run.Run]
+	}
+	fakeRecorderClient struct {
+		// IDs of recorded runs
+		recorded []string
+	}
+)
+
+func TestRecorder(t *testing.T) {
+	runs := &fakeRecorderRunClient{
+		events: []pubsub.Event[*run.Run]{
+			{Type: pubsub.CreatedEvent, Payload: &run.Run{ID: "run-created", Status: run.RunPending}},
+			{Type: pubsub.UpdatedEvent, Payload: &run.Run{ID: "run-applying", Status: run.RunApplying, Apply: run.Phase{Status: run.PhaseRunning}}},
+			{Type: pubsub.UpdatedEvent, Payload: &run.Run{ID: "run-applied", Status: run.RunApplied, Apply: run.Phase{Status: run.PhaseFinished}}},
+			{Type: pubsub.UpdatedEvent, Payload: &run.Run{ID: "run-apply-errored", Status: run.RunErrored, Apply: run.Phase{Status: run.PhaseErrored}}},
+			{Type: pubsub.UpdatedEvent, Payload: &run.Run{ID: "run-plan-errored", Status: run.RunErrored, Apply: run.Phase{Status: run.PhaseUnreachable}}},
+			{Type: pubsub.UpdatedEvent, Payload: &run.Run{ID: "run-discarded", Status: run.RunDiscarded, Apply: run.Phase{Status: run.PhaseUnreachable}}},
+		},
+	}
+	client := &fakeRecorderClient{}
+	r := &Recorder{
+		Logger: logr.Discard(),
+		runs:   runs,
+		client: client,
+	}
+
+	err := r.Start(context.Background())
+	assert.Equal(t, pubsub.ErrSubscriptionTerminated, err)
+
+	assert.Equal(t, []string{"run-applied", "run-apply-errored"}, client.recorded)
+}
+
+func (f *fakeRecorderRunClient) Watch(context.Context) (<-chan pubsub.Event[*run.Run], func()) {
+	ch := make(chan pubsub.Event[*run.Run], len(f.events))
+	for _, ev := range f.events {
+		ch <- ev
+	}
+	close(ch)
+	return ch, func() {}
+}
+
+func (f *fakeRecorderClient) record(ctx context.Context, r *run.Run) error {
+	f.recorded = append(f.recorded, r.ID)
+	return nil
+}
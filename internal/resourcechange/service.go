@@ -0,0 +1,137 @@
+package resourcechange
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gorilla/mux"
+	"github.com/leg100/otf/internal"
+	"github.com/leg100/otf/internal/logr"
+	"github.com/leg100/otf/internal/logs"
+	"github.com/leg100/otf/internal/pubsub"
+	"github.com/leg100/otf/internal/rbac"
+	"github.com/leg100/otf/internal/run"
+	"github.com/leg100/otf/internal/sql"
+)
+
+type (
+	Service struct {
+		logr.Logger
+
+		runAuthorizer internal.Authorizer // authorize run actions
+
+		db      *pgdb
+		api     *api
+		runs    runClient
+		logs    logsClient
+		broker  *pubsub.Broker[*Change]
+		webhook *webhook
+	}
+
+	Options struct {
+		*sql.DB
+		*sql.Listener
+		logr.Logger
+
+		Config      Config
+		RunService  *run.Service
+		LogsService *logs.Service
+	}
+
+	runClient interface {
+		GetPlanFile(ctx context.Context, runID string, format run.PlanFormat) ([]byte, error)
+		Watch(context.Context) (<-chan pubsub.Event[*run.Run], func())
+	}
+
+	logsClient interface {
+		GetChunk(ctx context.Context, opts internal.GetChunkOptions) (internal.Chunk, error)
+	}
+)
+
+func NewService(opts Options) *Service {
+	svc := Service{
+		Logger:        opts.Logger,
+		runAuthorizer: opts.RunService,
+		db:            &pgdb{opts.DB},
+		runs:          opts.RunService,
+		logs:          opts.LogsService,
+	}
+	svc.api = &api{Service: &svc}
+	if opts.Config.Enabled() {
+		svc.webhook = newWebhook(opts.Config)
+	}
+	svc.broker = pubsub.NewBroker(
+		opts.Logger,
+		opts.Listener,
+		"resource_changes",
+		func(ctx context.Context, id string, action sql.Action) (*Change, error) {
+			if action == sql.DeleteAction {
+				return &Change{ID: id}, nil
+			}
+			return svc.db.getChange(ctx, id)
+		},
+	)
+	return &svc
+}
+
+func (s *Service) AddHandlers(r *mux.Router) {
+	s.api.addHandlers(r)
+}
+
+// Enabled determines whether resource changes are exported to a webhook.
+func (s *Service) Enabled() bool { return s.webhook != nil }
+
+// Watch provides access to a stream of resource change events.
+func (s *Service) Watch(ctx context.Context) (<-chan pubsub.Event[*Change], func()) {
+	return s.broker.Subscribe(ctx)
+}
+
+// List lists the changes made to resources by a run's apply, in the order in
+// which they were made.
+func (s *Service) List(ctx context.Context, runID string) ([]*Change, error) {
+	subject, err := s.runAuthorizer.CanAccess(ctx, rbac.ListResourceChangesAction, runID)
+	if err != nil {
+		return nil, err
+	}
+	changes, err := s.db.listChanges(ctx, runID)
+	if err != nil {
+		s.Error(err, "listing resource changes", "run_id", runID, "subject", subject)
+		return nil, err
+	}
+	s.V(9).Info("listed resource changes", "run_id", runID, "count", len(changes), "subject", subject)
+	return changes, nil
+}
+
+// record parses the changes made to resources from the output of a run's
+// apply and persists them, which in turn publishes an event for each change.
+func (s *Service) record(ctx context.Context, r *run.Run) error {
+	chunk, err := s.logs.GetChunk(ctx, internal.GetChunkOptions{
+		RunID: r.ID,
+		Phase: internal.ApplyPhase,
+	})
+	if err != nil {
+		return fmt.Errorf("retrieving apply logs: %w", err)
+	}
+	planJSON, err := s.runs.GetPlanFile(ctx, r.ID, run.PlanFormatJSON)
+	if err != nil {
+		return fmt.Errorf("retrieving plan: %w", err)
+	}
+	changes, err := parseApplyOutput(r, chunk.Data, planJSON)
+	if err != nil {
+		return err
+	}
+	if len(changes) == 0 {
+		return nil
+	}
+	if err := s.db.insertChanges(ctx, changes); err != nil {
+		s.Error(err, "recording resource changes", "run_id", r.ID)
+		return err
+	}
+	s.V(1).Info("recorded resource changes", "run_id", r.ID, "count", len(changes))
+	return nil
+}
+
+// export sends a resource change to the webhook.
+func (s *Service) export(ctx context.Context, change *Change) error {
+	return s.webhook.send(ctx, change)
+}
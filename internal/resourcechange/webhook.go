@@ -0,0 +1,82 @@
+package resourcechange
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha512"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+const (
+	// signatureHeader is the header containing the HMAC signature of the
+	// request sent to the webhook.
+	signatureHeader = "X-OTF-Resource-Change-Signature"
+
+	// payloadVersion is the version of the request payload sent to the
+	// webhook.
+	payloadVersion = 1
+)
+
+// webhookTimeout is the time the webhook is given to respond.
+var webhookTimeout = 30 * time.Second
+
+type (
+	// webhook sends resource changes to an external service, such as a CMDB.
+	webhook struct {
+		url     string
+		hmacKey string
+		client  *http.Client
+	}
+
+	// payload is the request sent to the webhook.
+	payload struct {
+		PayloadVersion int `json:"payload_version"`
+		*Change
+	}
+)
+
+func newWebhook(cfg Config) *webhook {
+	return &webhook{
+		url:     cfg.WebhookURL,
+		hmacKey: cfg.HMACKey,
+		client:  &http.Client{Timeout: webhookTimeout},
+	}
+}
+
+// send sends a resource change to the webhook.
+func (w *webhook) send(ctx context.Context, change *Change) error {
+	body, err := json.Marshal(payload{PayloadVersion: payloadVersion, Change: change})
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, "POST", w.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if w.hmacKey != "" {
+		req.Header.Set(signatureHeader, sign(body, w.hmacKey))
+	}
+	resp, err := w.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("webhook responded with unexpected status: %s", resp.Status)
+	}
+	return nil
+}
+
+// sign returns the hex-encoded HMAC-SHA512 signature of the body using the
+// key.
+func sign(body []byte, key string) string {
+	mac := hmac.New(sha512.New, []byte(key))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,62 @@
+package resourcechange
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWebhook(t *testing.T) {
+	t.Run("signed request", func(t *testing.T) {
+		var got map[string]any
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			body, err := io.ReadAll(r.Body)
+			require.NoError(t, err)
+			assert.Equal(t, sign(body, "secret"), r.Header.Get(signatureHeader))
+			require.NoError(t, json.Unmarshal(body, &got))
+			w.WriteHeader(http.StatusAccepted)
+		}))
+		defer srv.Close()
+
+		wh := newWebhook(Config{WebhookURL: srv.URL, HMACKey: "secret"})
+		err := wh.send(context.Background(), &Change{
+			ID:      "rc-123",
+			Action:  ActionCreated,
+			Address: "random_pet.pet",
+		})
+		require.NoError(t, err)
+
+		assert.Equal(t, float64(payloadVersion), got["payload_version"])
+		assert.Equal(t, "rc-123", got["id"])
+		assert.Equal(t, "created", got["action"])
+		assert.Equal(t, "random_pet.pet", got["address"])
+	})
+
+	t.Run("unsigned request", func(t *testing.T) {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Empty(t, r.Header.Get(signatureHeader))
+		}))
+		defer srv.Close()
+
+		wh := newWebhook(Config{WebhookURL: srv.URL})
+		err := wh.send(context.Background(), &Change{ID: "rc-123"})
+		require.NoError(t, err)
+	})
+
+	t.Run("service error", func(t *testing.T) {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusInternalServerError)
+		}))
+		defer srv.Close()
+
+		wh := newWebhook(Config{WebhookURL: srv.URL})
+		err := wh.send(context.Background(), &Change{ID: "rc-123"})
+		assert.Error(t, err)
+	})
+}
@@ -2,11 +2,14 @@ package run
 
 import (
 	"bytes"
+	"encoding/json"
+	"errors"
 	"io"
 	"net/http"
 
 	"github.com/go-logr/logr"
 	"github.com/gorilla/mux"
+	"github.com/leg100/otf/internal"
 	otfapi "github.com/leg100/otf/internal/api"
 	"github.com/leg100/otf/internal/http/decode"
 	"github.com/leg100/otf/internal/tfeapi"
@@ -26,6 +29,17 @@ func (a *api) addHandlers(r *mux.Router) {
 	r.HandleFunc("/runs/{id}/planfile", a.uploadPlanFile).Methods("PUT")
 	r.HandleFunc("/runs/{id}/lockfile", a.getLockFile).Methods("GET")
 	r.HandleFunc("/runs/{id}/lockfile", a.uploadLockFile).Methods("PUT")
+	r.HandleFunc("/runs/{id}/diagnostics", a.listDiagnostics).Methods("GET")
+	r.HandleFunc("/runs/{id}/apply-failures", a.listApplyFailures).Methods("GET")
+	r.HandleFunc("/runs/{id}/protection-check", a.getProtectionCheck).Methods("GET")
+	r.HandleFunc("/runs/{id}/actions/replan", a.replan).Methods("POST")
+	r.HandleFunc("/runs/{id}/actions/retry-apply", a.retryApply).Methods("POST")
+	r.HandleFunc("/runs/{id}/provenance", a.getProvenance).Methods("GET")
+	r.HandleFunc("/runs/{id}/timings", a.getTimings).Methods("GET")
+
+	// workspace protection rules
+	r.HandleFunc("/workspaces/{workspace_id}/protection-rules", a.getProtectionRules).Methods("GET")
+	r.HandleFunc("/workspaces/{workspace_id}/protection-rules", a.setProtectionRules).Methods("PUT")
 }
 
 func (a *api) list(w http.ResponseWriter, r *http.Request) {
@@ -56,6 +70,37 @@ func (a *api) get(w http.ResponseWriter, r *http.Request) {
 	a.Respond(w, r, run, http.StatusOK)
 }
 
+func (a *api) replan(w http.ResponseWriter, r *http.Request) {
+	id, err := decode.Param("id", r)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	run, err := a.Replan(r.Context(), id)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	a.Respond(w, r, run, http.StatusCreated)
+}
+
+func (a *api) retryApply(w http.ResponseWriter, r *http.Request) {
+	id, err := decode.Param("id", r)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	run, err := a.RetryApply(r.Context(), id)
+	if err != nil {
+		if errors.Is(err, ErrRunRetryApplyNotAllowed) || errors.Is(err, ErrStalePlan) || errors.Is(err, ErrProtectionRulesViolated) {
+			err = &internal.HTTPError{Code: http.StatusConflict, Message: err.Error()}
+		}
+		tfeapi.Error(w, err)
+		return
+	}
+	a.Respond(w, r, run, http.StatusAccepted)
+}
+
 func (a *api) getPlanFile(w http.ResponseWriter, r *http.Request) {
 	id, err := decode.Param("id", r)
 	if err != nil {
@@ -135,3 +180,116 @@ func (a *api) uploadLockFile(w http.ResponseWriter, r *http.Request) {
 	}
 	w.WriteHeader(http.StatusAccepted)
 }
+
+func (a *api) listDiagnostics(w http.ResponseWriter, r *http.Request) {
+	id, err := decode.Param("id", r)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	diags, err := a.ListDiagnostics(r.Context(), id)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(diags)
+}
+
+func (a *api) listApplyFailures(w http.ResponseWriter, r *http.Request) {
+	id, err := decode.Param("id", r)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	failures, err := a.ListApplyFailures(r.Context(), id)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(failures)
+}
+
+func (a *api) getProtectionCheck(w http.ResponseWriter, r *http.Request) {
+	id, err := decode.Param("id", r)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	check, err := a.GetProtectionCheck(r.Context(), id)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(check)
+}
+
+func (a *api) getProvenance(w http.ResponseWriter, r *http.Request) {
+	var params struct {
+		ID     string `schema:"id,required"`
+		Signed bool   `schema:"signed"`
+	}
+	if err := decode.All(&params, r); err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	provenance, err := a.GetProvenance(r.Context(), params.ID, params.Signed)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(provenance)
+}
+
+func (a *api) getTimings(w http.ResponseWriter, r *http.Request) {
+	id, err := decode.Param("id", r)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	run, err := a.Get(r.Context(), id)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(run.Timings(internal.CurrentTimestamp(nil)))
+}
+
+func (a *api) getProtectionRules(w http.ResponseWriter, r *http.Request) {
+	workspaceID, err := decode.Param("workspace_id", r)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	rules, err := a.GetProtectionRules(r.Context(), workspaceID)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(rules)
+}
+
+func (a *api) setProtectionRules(w http.ResponseWriter, r *http.Request) {
+	workspaceID, err := decode.Param("workspace_id", r)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	var params ProtectionRules
+	if err := json.NewDecoder(r.Body).Decode(&params); err != nil {
+		tfeapi.Error(w, &internal.HTTPError{Code: http.StatusUnprocessableEntity, Message: err.Error()})
+		return
+	}
+	rules, err := a.SetProtectionRules(r.Context(), workspaceID, params)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(rules)
+}
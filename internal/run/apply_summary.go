@@ -0,0 +1,47 @@
+package run
+
+import (
+	"context"
+
+	"github.com/leg100/otf/internal/rbac"
+)
+
+type (
+	// ApplySummary is a structured summary of an apply, derived from the
+	// events terraform emits when run with the -json flag.
+	ApplySummary struct {
+		// Resources tallies the changes made to resources. Unlike the summary
+		// terraform prints upon completion, it includes the changes made by an
+		// apply that failed part way through.
+		Resources Report `json:"resources"`
+		// Failures are the resources terraform failed to change.
+		Failures []ApplyFailure `json:"failures,omitempty"`
+	}
+
+	// ApplyFailure is a resource that terraform failed to change during an
+	// apply.
+	ApplyFailure struct {
+		// Address of the resource, e.g. module.vpc.aws_subnet.private[0]
+		Address string `json:"address"`
+		// Action terraform was carrying out on the resource, e.g. create
+		Action string `json:"action"`
+		// Summary of the error reported for the resource, if any.
+		Summary string `json:"summary,omitempty"`
+	}
+)
+
+// ListApplyFailures lists the resources terraform failed to change during the
+// run's apply.
+func (s *Service) ListApplyFailures(ctx context.Context, runID string) ([]ApplyFailure, error) {
+	subject, err := s.CanAccess(ctx, rbac.GetRunAction, runID)
+	if err != nil {
+		return nil, err
+	}
+	failures, err := s.db.listApplyFailures(ctx, runID)
+	if err != nil {
+		s.Error(err, "listing apply failures", "id", runID, "subject", subject)
+		return nil, err
+	}
+	s.V(9).Info("listed apply failures", "id", runID, "count", len(failures), "subject", subject)
+	return failures, nil
+}
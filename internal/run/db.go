@@ -2,6 +2,7 @@ package run
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"sort"
 	"strconv"
@@ -47,6 +48,7 @@ type (
 		CreatedBy              pgtype.Text                   `json:"created_by"`
 		TerraformVersion       pgtype.Text                   `json:"terraform_version"`
 		AllowEmptyApply        pgtype.Bool                   `json:"allow_empty_apply"`
+		Message                pgtype.Text                   `json:"message"`
 		ExecutionMode          pgtype.Text                   `json:"execution_mode"`
 		Latest                 pgtype.Bool                   `json:"latest"`
 		OrganizationName       pgtype.Text                   `json:"organization_name"`
@@ -74,6 +76,7 @@ func (result pgresult) toRun() *Run {
 		AutoApply:              result.AutoApply.Bool,
 		PlanOnly:               result.PlanOnly.Bool,
 		AllowEmptyApply:        result.AllowEmptyApply.Bool,
+		Message:                result.Message.String,
 		TerraformVersion:       result.TerraformVersion.String,
 		ExecutionMode:          workspace.ExecutionMode(result.ExecutionMode.String),
 		Latest:                 result.Latest.Bool,
@@ -166,6 +169,7 @@ func (db *pgdb) CreateRun(ctx context.Context, run *Run) error {
 			AutoApply:              sql.Bool(run.AutoApply),
 			PlanOnly:               sql.Bool(run.PlanOnly),
 			AllowEmptyApply:        sql.Bool(run.AllowEmptyApply),
+			Message:                sql.String(run.Message),
 			TerraformVersion:       sql.String(run.TerraformVersion),
 			ConfigurationVersionID: sql.String(run.ConfigurationVersionID),
 			WorkspaceID:            sql.String(run.WorkspaceID),
@@ -449,3 +453,263 @@ func (db *pgdb) insertPhaseStatusTimestamp(ctx context.Context, phase Phase) err
 	})
 	return err
 }
+
+// diagnosticRow is the result of a database query for a run diagnostic.
+type diagnosticRow struct {
+	RunID    pgtype.Text `json:"run_id"`
+	Phase    pgtype.Text `json:"phase"`
+	Position pgtype.Int4 `json:"position"`
+	Severity pgtype.Text `json:"severity"`
+	Summary  pgtype.Text `json:"summary"`
+	Detail   pgtype.Text `json:"detail"`
+	Address  pgtype.Text `json:"address"`
+	Filename pgtype.Text `json:"filename"`
+	Line     pgtype.Int4 `json:"line"`
+}
+
+func (r diagnosticRow) toDiagnostic() Diagnostic {
+	diag := Diagnostic{
+		Phase:    internal.PhaseType(r.Phase.String),
+		Severity: DiagnosticSeverity(r.Severity.String),
+		Summary:  r.Summary.String,
+		Detail:   r.Detail.String,
+		Address:  r.Address.String,
+	}
+	if r.Filename.Status == pgtype.Present {
+		diag.Range = &DiagnosticRange{
+			Filename: r.Filename.String,
+			Line:     int(r.Line.Int),
+		}
+	}
+	return diag
+}
+
+func (db *pgdb) createDiagnostics(ctx context.Context, runID string, phase internal.PhaseType, diags []Diagnostic) error {
+	for i, diag := range diags {
+		params := pggen.InsertRunDiagnosticParams{
+			RunID:    sql.String(runID),
+			Phase:    sql.String(string(phase)),
+			Position: sql.Int4(i),
+			Severity: sql.String(string(diag.Severity)),
+			Summary:  sql.String(diag.Summary),
+			Detail:   sql.NullString(),
+			Address:  sql.NullString(),
+			Filename: sql.NullString(),
+			Line:     pgtype.Int4{Status: pgtype.Null},
+		}
+		if diag.Detail != "" {
+			params.Detail = sql.String(diag.Detail)
+		}
+		if diag.Address != "" {
+			params.Address = sql.String(diag.Address)
+		}
+		if diag.Range != nil {
+			params.Filename = sql.String(diag.Range.Filename)
+			params.Line = sql.Int4(diag.Range.Line)
+		}
+		if _, err := db.Conn(ctx).InsertRunDiagnostic(ctx, params); err != nil {
+			return sql.Error(err)
+		}
+	}
+	return nil
+}
+
+func (db *pgdb) listDiagnostics(ctx context.Context, runID string) ([]Diagnostic, error) {
+	rows, err := db.Conn(ctx).FindRunDiagnostics(ctx, sql.String(runID))
+	if err != nil {
+		return nil, sql.Error(err)
+	}
+	diags := make([]Diagnostic, len(rows))
+	for i, r := range rows {
+		diags[i] = diagnosticRow(r).toDiagnostic()
+	}
+	return diags, nil
+}
+
+func (db *pgdb) createApplyFailures(ctx context.Context, runID string, failures []ApplyFailure) error {
+	for i, failure := range failures {
+		params := pggen.InsertRunApplyFailureParams{
+			RunID:    sql.String(runID),
+			Position: sql.Int4(i),
+			Address:  sql.String(failure.Address),
+			Action:   sql.String(failure.Action),
+			Summary:  sql.NullString(),
+		}
+		if failure.Summary != "" {
+			params.Summary = sql.String(failure.Summary)
+		}
+		if _, err := db.Conn(ctx).InsertRunApplyFailure(ctx, params); err != nil {
+			return sql.Error(err)
+		}
+	}
+	return nil
+}
+
+func (db *pgdb) listApplyFailures(ctx context.Context, runID string) ([]ApplyFailure, error) {
+	rows, err := db.Conn(ctx).FindRunApplyFailures(ctx, sql.String(runID))
+	if err != nil {
+		return nil, sql.Error(err)
+	}
+	failures := make([]ApplyFailure, len(rows))
+	for i, r := range rows {
+		failures[i] = ApplyFailure{
+			Address: r.Address.String,
+			Action:  r.Action.String,
+			Summary: r.Summary.String,
+		}
+	}
+	return failures, nil
+}
+
+func (db *pgdb) setProtectionRules(ctx context.Context, workspaceID string, rules *ProtectionRules) error {
+	_, err := db.Conn(ctx).UpsertWorkspaceProtectionRules(ctx, pggen.UpsertWorkspaceProtectionRulesParams{
+		WorkspaceID:        sql.String(workspaceID),
+		PreventDestroyRuns: sql.Bool(rules.PreventDestroyRuns),
+		ProtectedResources: rules.ProtectedResources,
+	})
+	return sql.Error(err)
+}
+
+// getProtectionRules retrieves a workspace's protection rules. A workspace
+// without rules is returned empty rules.
+func (db *pgdb) getProtectionRules(ctx context.Context, workspaceID string) (*ProtectionRules, error) {
+	row, err := db.Conn(ctx).FindWorkspaceProtectionRules(ctx, sql.String(workspaceID))
+	if err != nil {
+		err = sql.Error(err)
+		if errors.Is(err, internal.ErrResourceNotFound) {
+			return &ProtectionRules{ProtectedResources: []string{}}, nil
+		}
+		return nil, err
+	}
+	return &ProtectionRules{
+		PreventDestroyRuns: row.PreventDestroyRuns.Bool,
+		ProtectedResources: row.ProtectedResources,
+	}, nil
+}
+
+func (db *pgdb) createProtectionViolations(ctx context.Context, runID string, violations []string) error {
+	for i, violation := range violations {
+		_, err := db.Conn(ctx).InsertRunProtectionViolation(ctx, pggen.InsertRunProtectionViolationParams{
+			RunID:     sql.String(runID),
+			Position:  sql.Int4(i),
+			Violation: sql.String(violation),
+		})
+		if err != nil {
+			return sql.Error(err)
+		}
+	}
+	return nil
+}
+
+func (db *pgdb) createProtectionOverride(ctx context.Context, runID string, override ProtectionOverride) error {
+	_, err := db.Conn(ctx).InsertRunProtectionOverride(ctx, pggen.InsertRunProtectionOverrideParams{
+		RunID:      sql.String(runID),
+		Username:   sql.String(override.Username),
+		CreatedAt:  sql.Timestamptz(override.CreatedAt),
+		Violations: override.Violations,
+	})
+	return sql.Error(err)
+}
+
+func (db *pgdb) getProtectionCheck(ctx context.Context, runID string) (*ProtectionCheck, error) {
+	rows, err := db.Conn(ctx).FindRunProtectionViolations(ctx, sql.String(runID))
+	if err != nil {
+		return nil, sql.Error(err)
+	}
+	check := ProtectionCheck{Violations: make([]string, len(rows))}
+	for i, r := range rows {
+		check.Violations[i] = r.Violation.String
+	}
+	override, err := db.Conn(ctx).FindRunProtectionOverride(ctx, sql.String(runID))
+	if err != nil {
+		err = sql.Error(err)
+		if errors.Is(err, internal.ErrResourceNotFound) {
+			return &check, nil
+		}
+		return nil, err
+	}
+	check.Override = &ProtectionOverride{
+		Username:   override.Username.String,
+		CreatedAt:  override.CreatedAt.Time.UTC(),
+		Violations: override.Violations,
+	}
+	return &check, nil
+}
+
+// setPlanState records the serial of the workspace's current state at the
+// time the run is planned. The serial is nil if the workspace has no state.
+func (db *pgdb) setPlanState(ctx context.Context, runID, workspaceID string) error {
+	serial, err := db.getCurrentStateSerial(ctx, workspaceID)
+	if err != nil {
+		return err
+	}
+	_, err = db.Conn(ctx).UpsertRunPlanState(ctx, sql.String(runID), sql.Int4Ptr(serial))
+	return sql.Error(err)
+}
+
+// getPlanState retrieves the serial of the workspace's state at the time the
+// run was planned. ErrResourceNotFound is returned if no serial was recorded.
+func (db *pgdb) getPlanState(ctx context.Context, runID string) (*int, error) {
+	row, err := db.Conn(ctx).FindRunPlanState(ctx, sql.String(runID))
+	if err != nil {
+		return nil, sql.Error(err)
+	}
+	if row.StateSerial.Status != pgtype.Present {
+		return nil, nil
+	}
+	serial := int(row.StateSerial.Int)
+	return &serial, nil
+}
+
+// deleteLogs deletes the logs for a run phase.
+func (db *pgdb) deleteLogs(ctx context.Context, runID string, phase internal.PhaseType) error {
+	_, err := db.Conn(ctx).DeleteLogs(ctx, sql.String(runID), sql.String(string(phase)))
+	return sql.Error(err)
+}
+
+// getCurrentStateSerial retrieves the serial of the workspace's current state,
+// or nil if the workspace has no state.
+func (db *pgdb) getCurrentStateSerial(ctx context.Context, workspaceID string) (*int, error) {
+	result, err := db.Conn(ctx).FindWorkspaceCurrentStateSerial(ctx, sql.String(workspaceID))
+	if err != nil {
+		err = sql.Error(err)
+		if errors.Is(err, internal.ErrResourceNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	serial := int(result.Int)
+	return &serial, nil
+}
+
+// getProvenanceConfigurationVersion retrieves the provenance of a
+// configuration version.
+func (db *pgdb) getProvenanceConfigurationVersion(ctx context.Context, cvID string) (*ConfigurationVersionProvenance, error) {
+	row, err := db.Conn(ctx).FindRunProvenanceConfigurationVersion(ctx, sql.String(cvID))
+	if err != nil {
+		return nil, sql.Error(err)
+	}
+	return &ConfigurationVersionProvenance{
+		ID:        row.ConfigurationVersionID.String,
+		Source:    row.Source.String,
+		CreatedAt: row.CreatedAt.Time.UTC(),
+		CreatedBy: row.CreatedBy.String,
+	}, nil
+}
+
+// listProvenanceStateVersions lists the state versions created by a run.
+func (db *pgdb) listProvenanceStateVersions(ctx context.Context, runID string) ([]StateVersionProvenance, error) {
+	rows, err := db.Conn(ctx).FindRunProvenanceStateVersions(ctx, sql.String(runID))
+	if err != nil {
+		return nil, sql.Error(err)
+	}
+	versions := make([]StateVersionProvenance, len(rows))
+	for i, r := range rows {
+		versions[i] = StateVersionProvenance{
+			ID:        r.StateVersionID.String,
+			Serial:    int64(r.Serial.Int),
+			CreatedAt: r.CreatedAt.Time.UTC(),
+		}
+	}
+	return versions, nil
+}
@@ -0,0 +1,53 @@
+package run
+
+import (
+	"context"
+
+	"github.com/leg100/otf/internal"
+	"github.com/leg100/otf/internal/rbac"
+)
+
+const (
+	DiagnosticError   DiagnosticSeverity = "error"
+	DiagnosticWarning DiagnosticSeverity = "warning"
+)
+
+type (
+	// Diagnostic is an error or warning reported by terraform during a phase,
+	// along with the location in the configuration and the resource to which
+	// it relates, where known.
+	Diagnostic struct {
+		Phase    internal.PhaseType `json:"phase"`
+		Severity DiagnosticSeverity `json:"severity"`
+		Summary  string             `json:"summary"`
+		Detail   string             `json:"detail,omitempty"`
+		// Address of the resource to which the diagnostic relates
+		Address string           `json:"address,omitempty"`
+		Range   *DiagnosticRange `json:"range,omitempty"`
+	}
+
+	DiagnosticSeverity string
+
+	// DiagnosticRange is the location in the configuration to which a
+	// diagnostic relates.
+	DiagnosticRange struct {
+		Filename string `json:"filename"`
+		Line     int    `json:"line"`
+	}
+)
+
+// ListDiagnostics lists the diagnostics reported by terraform for a run,
+// those of the plan phase first.
+func (s *Service) ListDiagnostics(ctx context.Context, runID string) ([]Diagnostic, error) {
+	subject, err := s.CanAccess(ctx, rbac.GetRunAction, runID)
+	if err != nil {
+		return nil, err
+	}
+	diags, err := s.db.listDiagnostics(ctx, runID)
+	if err != nil {
+		s.Error(err, "listing run diagnostics", "id", runID, "subject", subject)
+		return nil, err
+	}
+	s.V(9).Info("listed run diagnostics", "id", runID, "count", len(diags), "subject", subject)
+	return diags, nil
+}
@@ -1,11 +1,17 @@
 package run
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+
+	"github.com/leg100/otf/internal"
+)
 
 var (
 	ErrRunDiscardNotAllowed     = errors.New("run was not paused for confirmation or priority; discard not allowed")
 	ErrRunCancelNotAllowed      = errors.New("run was not planning or applying; cancel not allowed")
-	ErrRunForceCancelNotAllowed = errors.New("run was not planning or applying, has not been canceled non-forcefully, or the cool-off period has not yet passed")
+	ErrRunForceCancelNotAllowed = fmt.Errorf("run was not planning or applying, has not been canceled non-forcefully, or the cool-off period has not yet passed: %w", internal.ErrConflict)
+	ErrRunRetryApplyNotAllowed  = errors.New("retry apply not allowed")
 	//
 	ErrPhaseAlreadyStarted = errors.New("phase already started")
 )
@@ -44,6 +44,11 @@ type (
 	// PhaseFinishOptions report the status of a phase upon finishing.
 	PhaseFinishOptions struct {
 		Errored bool `json:"errored,omitempty"`
+		// Diagnostics reported by terraform during the phase.
+		Diagnostics []Diagnostic `json:"diagnostics,omitempty"`
+		// ApplySummary summarizes the apply phase. Only set by agents that run
+		// the apply with terraform's -json flag.
+		ApplySummary *ApplySummary `json:"apply_summary,omitempty"`
 	}
 
 	PhaseStatusTimestamp struct {
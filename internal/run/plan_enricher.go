@@ -0,0 +1,202 @@
+package run
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+)
+
+type (
+	// PlanEnricher computes run-level data from the plan produced by a run,
+	// adding it to the plan summary. Enrichers should only compute data and
+	// leave persisting it to their callers.
+	PlanEnricher func(ctx context.Context, plan *EnrichablePlan, summary *PlanSummary) error
+
+	// EnrichablePlan is the input to a plan enricher.
+	EnrichablePlan struct {
+		Run *Run
+		// JSON is the plan file in JSON format, as produced by terraform.
+		JSON []byte
+		// File is the parsed plan file.
+		File *PlanFile
+	}
+
+	// PlanSummary is run-level data computed from a plan by the registered
+	// plan enrichers.
+	PlanSummary struct {
+		// ResourceReport tallies planned changes to resources.
+		ResourceReport Report `json:"resource_report"`
+		// OutputReport tallies planned changes to outputs.
+		OutputReport Report `json:"output_report"`
+		// Resources lists the resources the plan proposes to change.
+		Resources []ResourceChange `json:"resources"`
+		// Drift classifies resources that have changed outside of terraform.
+		Drift DriftSummary `json:"drift"`
+		// PolicyInput is the document against which policies are evaluated.
+		PolicyInput json.RawMessage `json:"policy_input"`
+		// CostInput lists the resources for which costs are to be estimated.
+		CostInput []CostResource `json:"cost_input"`
+		// Extensions holds data computed by enrichers registered outside of
+		// this package, keyed by enricher name.
+		Extensions map[string]any `json:"extensions,omitempty"`
+	}
+
+	// DriftSummary classifies resources that have changed outside of
+	// terraform.
+	DriftSummary struct {
+		// Modified are the addresses of resources that have been modified
+		// outside of terraform.
+		Modified []string `json:"modified"`
+		// Deleted are the addresses of resources that have been deleted
+		// outside of terraform.
+		Deleted []string `json:"deleted"`
+	}
+
+	// CostResource is a resource for which costs are to be estimated.
+	CostResource struct {
+		Address      string         `json:"address"`
+		Type         string         `json:"type"`
+		ProviderName string         `json:"provider_name"`
+		Actions      []ChangeAction `json:"actions"`
+	}
+
+	planEnricher struct {
+		name string
+		fn   PlanEnricher
+	}
+
+	// policyInput is the document against which policies are evaluated,
+	// comprising the plan alongside metadata about the run.
+	policyInput struct {
+		Run  policyInputRun  `json:"run"`
+		Plan json.RawMessage `json:"plan"`
+	}
+
+	policyInputRun struct {
+		ID          string `json:"id"`
+		WorkspaceID string `json:"workspace_id"`
+		IsDestroy   bool   `json:"is_destroy"`
+		RefreshOnly bool   `json:"refresh_only"`
+		Source      Source `json:"source"`
+	}
+)
+
+// Drifted is true if any resources have changed outside of terraform.
+func (d DriftSummary) Drifted() bool {
+	return len(d.Modified) > 0 || len(d.Deleted) > 0
+}
+
+// RegisterPlanEnricher adds an enricher to the registry of plan enrichers,
+// which are invoked in order of registration whenever a run's plan is
+// summarized. Registering an enricher with the name of an existing enricher
+// replaces the existing enricher. Not safe for concurrent use; enrichers
+// should be registered on startup.
+func (s *Service) RegisterPlanEnricher(name string, fn PlanEnricher) {
+	for i, e := range s.planEnrichers {
+		if e.name == name {
+			s.planEnrichers[i].fn = fn
+			return
+		}
+	}
+	s.planEnrichers = append(s.planEnrichers, planEnricher{name: name, fn: fn})
+}
+
+// SummarizePlan invokes the registered plan enrichers on the JSON plan file
+// of the run, returning their combined summary.
+func (s *Service) SummarizePlan(ctx context.Context, runID string) (*PlanSummary, error) {
+	run, err := s.db.GetRun(ctx, runID)
+	if err != nil {
+		return nil, err
+	}
+	planJSON, err := s.GetPlanFile(ctx, runID, PlanFormatJSON)
+	if err != nil {
+		return nil, err
+	}
+	return s.summarizePlan(ctx, run, planJSON)
+}
+
+func (s *Service) summarizePlan(ctx context.Context, run *Run, planJSON []byte) (*PlanSummary, error) {
+	var file PlanFile
+	if err := json.Unmarshal(planJSON, &file); err != nil {
+		return nil, err
+	}
+	plan := &EnrichablePlan{Run: run, JSON: planJSON, File: &file}
+
+	summary := &PlanSummary{Extensions: make(map[string]any)}
+	for _, e := range s.planEnrichers {
+		if err := e.fn(ctx, plan, summary); err != nil {
+			return nil, fmt.Errorf("enriching plan with %s: %w", e.name, err)
+		}
+	}
+	return summary, nil
+}
+
+// registerDefaultPlanEnrichers registers the enrichers computing the data
+// that OTF itself relies upon.
+func (s *Service) registerDefaultPlanEnrichers() {
+	s.RegisterPlanEnricher("change-counts", enrichChangeCounts)
+	s.RegisterPlanEnricher("resource-list", enrichResourceList)
+	s.RegisterPlanEnricher("drift-classification", enrichDriftClassification)
+	s.RegisterPlanEnricher("policy-input", enrichPolicyInput)
+	s.RegisterPlanEnricher("cost-input", enrichCostInput)
+}
+
+func enrichChangeCounts(_ context.Context, plan *EnrichablePlan, summary *PlanSummary) error {
+	summary.ResourceReport, summary.OutputReport = plan.File.Summarize()
+	return nil
+}
+
+func enrichResourceList(_ context.Context, plan *EnrichablePlan, summary *PlanSummary) error {
+	for _, rc := range plan.File.ResourceChanges {
+		if rc.Change.IsChange() {
+			summary.Resources = append(summary.Resources, rc)
+		}
+	}
+	return nil
+}
+
+func enrichDriftClassification(_ context.Context, plan *EnrichablePlan, summary *PlanSummary) error {
+	for _, rc := range plan.File.ResourceDrift {
+		if slices.Contains(rc.Change.Actions, DeleteAction) {
+			summary.Drift.Deleted = append(summary.Drift.Deleted, rc.Address)
+		} else {
+			summary.Drift.Modified = append(summary.Drift.Modified, rc.Address)
+		}
+	}
+	return nil
+}
+
+func enrichPolicyInput(_ context.Context, plan *EnrichablePlan, summary *PlanSummary) error {
+	input, err := json.Marshal(policyInput{
+		Run: policyInputRun{
+			ID:          plan.Run.ID,
+			WorkspaceID: plan.Run.WorkspaceID,
+			IsDestroy:   plan.Run.IsDestroy,
+			RefreshOnly: plan.Run.RefreshOnly,
+			Source:      plan.Run.Source,
+		},
+		Plan: plan.JSON,
+	})
+	if err != nil {
+		return err
+	}
+	summary.PolicyInput = input
+	return nil
+}
+
+func enrichCostInput(_ context.Context, plan *EnrichablePlan, summary *PlanSummary) error {
+	for _, rc := range plan.File.ResourceChanges {
+		// only managed resources incur costs; data sources are read-only.
+		if rc.Mode == DataResourceMode || !rc.Change.IsChange() {
+			continue
+		}
+		summary.CostInput = append(summary.CostInput, CostResource{
+			Address:      rc.Address,
+			Type:         rc.Type,
+			ProviderName: rc.ProviderName,
+			Actions:      rc.Change.Actions,
+		})
+	}
+	return nil
+}
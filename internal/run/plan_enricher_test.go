@@ -0,0 +1,102 @@
+package run
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSummarizePlan(t *testing.T) {
+	ctx := context.Background()
+	planJSON, err := os.ReadFile("testdata/plan.json")
+	require.NoError(t, err)
+	run := &Run{ID: "run-123", WorkspaceID: "ws-123"}
+
+	t.Run("default enrichers", func(t *testing.T) {
+		svc := &Service{}
+		svc.registerDefaultPlanEnrichers()
+
+		got, err := svc.summarizePlan(ctx, run, planJSON)
+		require.NoError(t, err)
+
+		assert.Equal(t, Report{Additions: 2}, got.ResourceReport)
+		assert.Equal(t, Report{Additions: 1}, got.OutputReport)
+		assert.Len(t, got.Resources, 2)
+		assert.False(t, got.Drift.Drifted())
+		assert.Equal(t, []CostResource{
+			{
+				Address:      "module.random.random_id.test",
+				Type:         "random_id",
+				ProviderName: "registry.terraform.io/hashicorp/random",
+				Actions:      []ChangeAction{CreateAction},
+			},
+			{
+				Address:      "null_resource.example",
+				Type:         "null_resource",
+				ProviderName: "registry.terraform.io/hashicorp/null",
+				Actions:      []ChangeAction{CreateAction},
+			},
+		}, got.CostInput)
+
+		var input policyInput
+		require.NoError(t, json.Unmarshal(got.PolicyInput, &input))
+		assert.Equal(t, "run-123", input.Run.ID)
+		assert.JSONEq(t, string(planJSON), string(input.Plan))
+	})
+
+	t.Run("drift classification", func(t *testing.T) {
+		plan := &EnrichablePlan{File: &PlanFile{
+			ResourceDrift: []ResourceChange{
+				{Address: "a", Change: Change{Actions: []ChangeAction{UpdateAction}}},
+				{Address: "b", Change: Change{Actions: []ChangeAction{DeleteAction}}},
+			},
+		}}
+		var summary PlanSummary
+		require.NoError(t, enrichDriftClassification(ctx, plan, &summary))
+
+		assert.True(t, summary.Drift.Drifted())
+		assert.Equal(t, []string{"a"}, summary.Drift.Modified)
+		assert.Equal(t, []string{"b"}, summary.Drift.Deleted)
+	})
+
+	t.Run("custom enricher", func(t *testing.T) {
+		svc := &Service{}
+		svc.RegisterPlanEnricher("custom", func(_ context.Context, plan *EnrichablePlan, summary *PlanSummary) error {
+			summary.Extensions["custom"] = len(plan.File.ResourceChanges)
+			return nil
+		})
+
+		got, err := svc.summarizePlan(ctx, run, planJSON)
+		require.NoError(t, err)
+		assert.Equal(t, 2, got.Extensions["custom"])
+	})
+
+	t.Run("replace enricher", func(t *testing.T) {
+		svc := &Service{}
+		svc.RegisterPlanEnricher("custom", func(context.Context, *EnrichablePlan, *PlanSummary) error {
+			return errors.New("should have been replaced")
+		})
+		svc.RegisterPlanEnricher("custom", func(context.Context, *EnrichablePlan, *PlanSummary) error {
+			return nil
+		})
+
+		_, err := svc.summarizePlan(ctx, run, planJSON)
+		require.NoError(t, err)
+		assert.Len(t, svc.planEnrichers, 1)
+	})
+
+	t.Run("failing enricher", func(t *testing.T) {
+		svc := &Service{}
+		svc.RegisterPlanEnricher("broken", func(context.Context, *EnrichablePlan, *PlanSummary) error {
+			return errors.New("boom")
+		})
+
+		_, err := svc.summarizePlan(ctx, run, planJSON)
+		assert.EqualError(t, err, "enriching plan with broken: boom")
+	})
+}
@@ -1,13 +1,18 @@
 package run
 
 import (
-	"encoding/json"
+	"slices"
 )
 
 const (
 	CreateAction ChangeAction = "create"
 	UpdateAction ChangeAction = "update"
 	DeleteAction ChangeAction = "delete"
+	NoOpAction   ChangeAction = "no-op"
+	ReadAction   ChangeAction = "read"
+
+	ManagedResourceMode ResourceMode = "managed"
+	DataResourceMode    ResourceMode = "data"
 )
 
 type (
@@ -15,6 +20,9 @@ type (
 	PlanFile struct {
 		ResourceChanges []ResourceChange  `json:"resource_changes"`
 		OutputChanges   map[string]Change `json:"output_changes"`
+		// ResourceDrift lists changes made to resources outside of terraform
+		// that were detected during the refresh.
+		ResourceDrift []ResourceChange `json:"resource_drift"`
 	}
 
 	// PlanFileOptions are options for the plan file API
@@ -24,7 +32,11 @@ type (
 
 	// ResourceChange represents a proposed change to a resource in a plan file
 	ResourceChange struct {
-		Change Change
+		Address      string
+		Mode         ResourceMode `json:",omitempty"`
+		Type         string       `json:",omitempty"`
+		ProviderName string       `json:"provider_name,omitempty"`
+		Change       Change
 	}
 
 	// Change represents the type of change being made
@@ -33,8 +45,23 @@ type (
 	}
 
 	ChangeAction string
+
+	// ResourceMode distinguishes managed resources from data sources.
+	ResourceMode string
 )
 
+// IsChange is true if the change modifies the resource, i.e. it is not a no-op
+// nor a read of a data source.
+func (c Change) IsChange() bool {
+	for _, action := range c.Actions {
+		switch action {
+		case CreateAction, UpdateAction, DeleteAction:
+			return true
+		}
+	}
+	return false
+}
+
 // Summarize provides a tally of the types of changes proposed in the plan file.
 func (pf *PlanFile) Summarize() (resource, output Report) {
 	for _, rc := range pf.ResourceChanges {
@@ -65,15 +92,22 @@ func (pf *PlanFile) Summarize() (resource, output Report) {
 	return
 }
 
-// CompilePlanReports compiles reports of planned changes from a JSON
-// representation of a plan file: one report for planned *resources*, and
-// another for planned *outputs*.
-func CompilePlanReports(planJSON []byte) (resources Report, outputs Report, err error) {
-	planFile := PlanFile{}
-	if err := json.Unmarshal(planJSON, &planFile); err != nil {
-		return Report{}, Report{}, err
+// DestroyedResources returns the addresses of resources the plan file proposes
+// to destroy, including those that are to be replaced.
+func (pf *PlanFile) DestroyedResources() (addresses []string) {
+	for _, rc := range pf.ResourceChanges {
+		if slices.Contains(rc.Change.Actions, DeleteAction) {
+			addresses = append(addresses, rc.Address)
+		}
 	}
+	return
+}
 
-	resources, outputs = planFile.Summarize()
-	return resources, outputs, nil
+// DriftedResources returns the addresses of resources that have changed
+// outside of terraform.
+func (pf *PlanFile) DriftedResources() (addresses []string) {
+	for _, rc := range pf.ResourceDrift {
+		addresses = append(addresses, rc.Address)
+	}
+	return
 }
@@ -19,6 +19,10 @@ func TestPlanFile(t *testing.T) {
 	want := PlanFile{
 		ResourceChanges: []ResourceChange{
 			{
+				Address:      "module.random.random_id.test",
+				Mode:         ManagedResourceMode,
+				Type:         "random_id",
+				ProviderName: "registry.terraform.io/hashicorp/random",
 				Change: Change{
 					Actions: []ChangeAction{
 						CreateAction,
@@ -26,6 +30,10 @@ func TestPlanFile(t *testing.T) {
 				},
 			},
 			{
+				Address:      "null_resource.example",
+				Mode:         ManagedResourceMode,
+				Type:         "null_resource",
+				ProviderName: "registry.terraform.io/hashicorp/null",
 				Change: Change{
 					Actions: []ChangeAction{
 						CreateAction,
@@ -61,3 +69,27 @@ func TestPlanFile_Changes(t *testing.T) {
 	assert.Equal(t, 0, outputReport.Changes)
 	assert.Equal(t, 0, outputReport.Destructions)
 }
+
+func TestPlanFile_DestroyedResources(t *testing.T) {
+	file := PlanFile{
+		ResourceChanges: []ResourceChange{
+			{Address: "aws_instance.web", Change: Change{Actions: []ChangeAction{CreateAction}}},
+			{Address: "aws_db_instance.main", Change: Change{Actions: []ChangeAction{DeleteAction}}},
+			{Address: "aws_s3_bucket.logs", Change: Change{Actions: []ChangeAction{DeleteAction, CreateAction}}},
+		},
+	}
+	assert.Equal(t, []string{"aws_db_instance.main", "aws_s3_bucket.logs"}, file.DestroyedResources())
+}
+
+func TestPlanFile_DriftedResources(t *testing.T) {
+	data := []byte(`{
+		"resource_drift": [
+			{"address": "aws_instance.web", "change": {"actions": ["update"]}},
+			{"address": "aws_s3_bucket.logs", "change": {"actions": ["delete"]}}
+		]
+	}`)
+	file := PlanFile{}
+	require.NoError(t, json.Unmarshal(data, &file))
+
+	assert.Equal(t, []string{"aws_instance.web", "aws_s3_bucket.logs"}, file.DriftedResources())
+}
@@ -0,0 +1,82 @@
+package run
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/leg100/otf/internal"
+	"github.com/leg100/otf/internal/sql/pggen"
+)
+
+// ErrStalePlan is returned when applying a run whose workspace state has
+// changed since the run was planned, i.e. another run, or some other
+// operation, has since written state.
+var ErrStalePlan = errors.New("workspace state has changed since the run was planned; replan the run")
+
+// checkPlanState returns ErrStalePlan if the workspace's current state is not
+// the state the run was planned against. Runs planned before their state was
+// recorded are not checked.
+func (s *Service) checkPlanState(ctx context.Context, run *Run) error {
+	planned, err := s.db.getPlanState(ctx, run.ID)
+	if err != nil {
+		if errors.Is(err, internal.ErrResourceNotFound) {
+			return nil
+		}
+		return err
+	}
+	current, err := s.db.getCurrentStateSerial(ctx, run.WorkspaceID)
+	if err != nil {
+		return err
+	}
+	if serialString(planned) == serialString(current) {
+		return nil
+	}
+	return fmt.Errorf("%w: serial %s when planned, now %s", ErrStalePlan, serialString(planned), serialString(current))
+}
+
+func serialString(serial *int) string {
+	if serial == nil {
+		return "none"
+	}
+	return fmt.Sprint(*serial)
+}
+
+// Replan discards a run, if it can be discarded, and creates a new run with
+// the same configuration and options, which is planned against the
+// workspace's current state.
+func (s *Service) Replan(ctx context.Context, runID string) (replanned *Run, err error) {
+	err = s.db.Tx(ctx, func(ctx context.Context, q pggen.Querier) error {
+		run, err := s.Get(ctx, runID)
+		if err != nil {
+			return err
+		}
+		if run.Discardable() {
+			if err := s.Discard(ctx, runID); err != nil {
+				return err
+			}
+		}
+		replanned, err = s.Create(ctx, run.WorkspaceID, CreateOptions{
+			ConfigurationVersionID: &run.ConfigurationVersionID,
+			IsDestroy:              &run.IsDestroy,
+			Refresh:                &run.Refresh,
+			RefreshOnly:            &run.RefreshOnly,
+			Message:                &run.Message,
+			TargetAddrs:            run.TargetAddrs,
+			ReplaceAddrs:           run.ReplaceAddrs,
+			AutoApply:              &run.AutoApply,
+			AllowEmptyApply:        &run.AllowEmptyApply,
+			TerraformVersion:       &run.TerraformVersion,
+			PlanOnly:               &run.PlanOnly,
+			Variables:              run.Variables,
+			Source:                 run.Source,
+		})
+		return err
+	})
+	if err != nil {
+		s.Error(err, "replanning run", "id", runID)
+		return nil, err
+	}
+	s.V(0).Info("replanned run", "id", runID, "replanned_id", replanned.ID)
+	return replanned, nil
+}
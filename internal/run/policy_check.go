@@ -0,0 +1,188 @@
+package run
+
+import (
+	"context"
+	"errors"
+
+	"github.com/leg100/otf/internal/rbac"
+	"github.com/leg100/otf/internal/sql/pggen"
+)
+
+const (
+	// PolicyCheckPassed is the outcome of a check in which no mandatory
+	// policies failed.
+	PolicyCheckPassed PolicyCheckOutcome = "passed"
+	// PolicyCheckSoftFailed is the outcome of a check in which mandatory
+	// policies failed but each failure can be overridden.
+	PolicyCheckSoftFailed PolicyCheckOutcome = "soft_failed"
+	// PolicyCheckHardFailed is the outcome of a check in which mandatory
+	// policies failed that cannot be overridden, or the check errored.
+	PolicyCheckHardFailed PolicyCheckOutcome = "hard_failed"
+)
+
+// ErrPolicyCheckNotOverridable is returned when overriding the policy check of
+// a run that is not awaiting an override.
+var ErrPolicyCheckNotOverridable = errors.New("run is not awaiting a policy check override")
+
+// PolicyCheckOutcome is the outcome of checking a run's plan against policies.
+type PolicyCheckOutcome string
+
+// startPolicyCheck updates the run to reflect policies being checked against
+// its plan. The run waits until the check completes.
+func (r *Run) startPolicyCheck() error {
+	switch r.Status {
+	case RunPlanning:
+		r.Plan.UpdateStatus(PhaseFinished)
+	case RunPostPlanRunning:
+	default:
+		return ErrInvalidRunStateTransition
+	}
+	r.updateStatus(RunPolicyChecking, nil)
+	return nil
+}
+
+// completePolicyCheck updates the run to reflect the outcome of its policy
+// check. A run that passed proceeds to await confirmation; a run that soft
+// failed awaits an override, unless it cannot be applied, in which case it is
+// finished; and a run that hard failed is errored. If an apply should be
+// automatically enqueued then autoapply is set to true.
+func (r *Run) completePolicyCheck(outcome PolicyCheckOutcome) (autoapply bool, err error) {
+	if r.Status != RunPolicyChecking {
+		return false, ErrInvalidRunStateTransition
+	}
+	switch outcome {
+	case PolicyCheckPassed:
+		return r.finishPolicyCheck(), nil
+	case PolicyCheckSoftFailed:
+		if !r.HasChanges() || r.PlanOnly {
+			r.updateStatus(RunPolicySoftFailed, nil)
+			r.Apply.UpdateStatus(PhaseUnreachable)
+			return false, nil
+		}
+		r.updateStatus(RunPolicyOverride, nil)
+		return false, nil
+	case PolicyCheckHardFailed:
+		r.updateStatus(RunErrored, nil)
+		r.Apply.UpdateStatus(PhaseUnreachable)
+		return false, nil
+	default:
+		return false, errors.New("unknown policy check outcome")
+	}
+}
+
+// overridePolicyCheck overrides the soft failure of the run's policy check,
+// permitting the run to proceed as if the check passed.
+func (r *Run) overridePolicyCheck() (autoapply bool, err error) {
+	if r.Status != RunPolicyOverride {
+		return false, ErrPolicyCheckNotOverridable
+	}
+	return r.finishPolicyCheck(), nil
+}
+
+func (r *Run) finishPolicyCheck() (autoapply bool) {
+	r.updateStatus(RunPolicyChecked, nil)
+	if !r.HasChanges() || r.PlanOnly {
+		r.updateStatus(RunPlannedAndFinished, nil)
+		r.Apply.UpdateStatus(PhaseUnreachable)
+		return false
+	}
+	return r.AutoApply
+}
+
+// OnPolicyCheck registers a hook that is invoked when a run's plan has
+// finished. The hook returns true if it has started checking policies, in
+// which case the run waits until CompletePolicyCheck is invoked.
+func (s *Service) OnPolicyCheck(hook func(context.Context, *Run) (bool, error)) {
+	s.policyCheckHooks = append(s.policyCheckHooks, hook)
+}
+
+// startPolicyCheck invokes the policy check hooks for the run, and if any hook
+// has started checking policies then the run is updated to wait for the check
+// to complete. Returns true if a check has been started.
+func (s *Service) startPolicyCheck(ctx context.Context, run *Run) (bool, error) {
+	var started bool
+	for _, hook := range s.policyCheckHooks {
+		ok, err := hook(ctx, run)
+		if err != nil {
+			return false, err
+		}
+		started = started || ok
+	}
+	if !started {
+		return false, nil
+	}
+	return true, run.startPolicyCheck()
+}
+
+// CompletePolicyCheck completes the policy check of a run with the given
+// outcome.
+//
+// NOTE: this is an internal action, invoked by the policy service only.
+func (s *Service) CompletePolicyCheck(ctx context.Context, runID string, outcome PolicyCheckOutcome) (*Run, error) {
+	var run *Run
+	err := s.db.Tx(ctx, func(ctx context.Context, q pggen.Querier) error {
+		subject, err := s.CanAccess(ctx, rbac.EnqueuePlanAction, runID)
+		if err != nil {
+			return err
+		}
+		var autoapply bool
+		run, err = s.db.UpdateStatus(ctx, runID, func(run *Run) (err error) {
+			autoapply, err = run.completePolicyCheck(outcome)
+			return err
+		})
+		if err != nil {
+			return err
+		}
+		s.V(0).Info("completed policy check", "id", runID, "outcome", outcome, "run_status", run.Status, "subject", subject)
+		if autoapply {
+			return s.autoApply(ctx, runID)
+		}
+		return nil
+	})
+	if err != nil {
+		s.Error(err, "completing policy check", "id", runID, "outcome", outcome)
+		return nil, err
+	}
+	return run, nil
+}
+
+// OverridePolicyCheck overrides the soft failure of a run's policy check,
+// permitting the run to be applied.
+func (s *Service) OverridePolicyCheck(ctx context.Context, runID string) (*Run, error) {
+	var run *Run
+	err := s.db.Tx(ctx, func(ctx context.Context, q pggen.Querier) error {
+		subject, err := s.CanAccess(ctx, rbac.OverridePolicyCheckAction, runID)
+		if err != nil {
+			return err
+		}
+		var autoapply bool
+		run, err = s.db.UpdateStatus(ctx, runID, func(run *Run) (err error) {
+			autoapply, err = run.overridePolicyCheck()
+			return err
+		})
+		if err != nil {
+			return err
+		}
+		s.V(0).Info("overrode policy check", "id", runID, "run_status", run.Status, "subject", subject)
+		if autoapply {
+			return s.autoApply(ctx, runID)
+		}
+		return nil
+	})
+	if err != nil {
+		s.Error(err, "overriding policy check", "id", runID)
+		return nil, err
+	}
+	return run, nil
+}
+
+// autoApply applies a run that is to be automatically applied, leaving the
+// run for a user to apply or discard should the run not be applyable.
+func (s *Service) autoApply(ctx context.Context, runID string) error {
+	err := s.Apply(ctx, runID)
+	if errors.Is(err, ErrStalePlan) || errors.Is(err, ErrProtectionRulesViolated) {
+		s.V(0).Info("not auto-applying run", "id", runID, "reason", err.Error())
+		return nil
+	}
+	return err
+}
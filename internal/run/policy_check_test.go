@@ -0,0 +1,119 @@
+package run
+
+import (
+	"context"
+	"testing"
+
+	"github.com/leg100/otf/internal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRun_PolicyCheck(t *testing.T) {
+	ctx := context.Background()
+
+	// newPlannedRun returns a run whose plan has finished with changes.
+	newPlannedRun := func(opts CreateOptions) *Run {
+		run := newTestRun(ctx, opts)
+		run.Status = RunPlanning
+		run.Plan.Status = PhaseRunning
+		run.Plan.ResourceReport = &Report{Additions: 1}
+		return run
+	}
+
+	t.Run("passed", func(t *testing.T) {
+		run := newPlannedRun(CreateOptions{})
+
+		require.NoError(t, run.startPolicyCheck())
+		require.Equal(t, RunPolicyChecking, run.Status)
+		require.Equal(t, PhaseFinished, run.Plan.Status)
+
+		autoapply, err := run.completePolicyCheck(PolicyCheckPassed)
+		require.NoError(t, err)
+		assert.False(t, autoapply)
+		assert.Equal(t, RunPolicyChecked, run.Status)
+		assert.True(t, run.Confirmable())
+	})
+
+	t.Run("passed with auto-apply", func(t *testing.T) {
+		run := newPlannedRun(CreateOptions{AutoApply: internal.Bool(true)})
+
+		require.NoError(t, run.startPolicyCheck())
+		autoapply, err := run.completePolicyCheck(PolicyCheckPassed)
+		require.NoError(t, err)
+		assert.True(t, autoapply)
+	})
+
+	t.Run("passed without changes", func(t *testing.T) {
+		run := newPlannedRun(CreateOptions{})
+		run.Plan.ResourceReport = &Report{}
+
+		require.NoError(t, run.startPolicyCheck())
+		_, err := run.completePolicyCheck(PolicyCheckPassed)
+		require.NoError(t, err)
+		assert.Equal(t, RunPlannedAndFinished, run.Status)
+		assert.Equal(t, PhaseUnreachable, run.Apply.Status)
+	})
+
+	t.Run("soft failed and overridden", func(t *testing.T) {
+		run := newPlannedRun(CreateOptions{})
+
+		require.NoError(t, run.startPolicyCheck())
+		_, err := run.completePolicyCheck(PolicyCheckSoftFailed)
+		require.NoError(t, err)
+		require.Equal(t, RunPolicyOverride, run.Status)
+		require.False(t, run.Confirmable())
+
+		_, err = run.overridePolicyCheck()
+		require.NoError(t, err)
+		assert.Equal(t, RunPolicyChecked, run.Status)
+	})
+
+	t.Run("soft failed plan-only run", func(t *testing.T) {
+		run := newPlannedRun(CreateOptions{PlanOnly: internal.Bool(true)})
+
+		require.NoError(t, run.startPolicyCheck())
+		_, err := run.completePolicyCheck(PolicyCheckSoftFailed)
+		require.NoError(t, err)
+		assert.Equal(t, RunPolicySoftFailed, run.Status)
+		assert.True(t, run.Done())
+	})
+
+	t.Run("hard failed", func(t *testing.T) {
+		run := newPlannedRun(CreateOptions{})
+
+		require.NoError(t, run.startPolicyCheck())
+		_, err := run.completePolicyCheck(PolicyCheckHardFailed)
+		require.NoError(t, err)
+		assert.Equal(t, RunErrored, run.Status)
+		assert.Equal(t, PhaseUnreachable, run.Apply.Status)
+	})
+
+	t.Run("after post-plan tasks", func(t *testing.T) {
+		run := newPlannedRun(CreateOptions{})
+
+		require.NoError(t, run.startTaskStage(PostPlanStage))
+		require.NoError(t, run.startPolicyCheck())
+		assert.Equal(t, RunPolicyChecking, run.Status)
+	})
+
+	t.Run("cannot override passed check", func(t *testing.T) {
+		run := newPlannedRun(CreateOptions{})
+
+		require.NoError(t, run.startPolicyCheck())
+		_, err := run.completePolicyCheck(PolicyCheckPassed)
+		require.NoError(t, err)
+
+		_, err = run.overridePolicyCheck()
+		assert.ErrorIs(t, err, ErrPolicyCheckNotOverridable)
+	})
+
+	t.Run("canceled run cannot complete check", func(t *testing.T) {
+		run := newPlannedRun(CreateOptions{})
+
+		require.NoError(t, run.startPolicyCheck())
+		require.NoError(t, run.Cancel(true))
+		_, err := run.completePolicyCheck(PolicyCheckPassed)
+		assert.ErrorIs(t, err, ErrInvalidRunStateTransition)
+	})
+}
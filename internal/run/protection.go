@@ -0,0 +1,194 @@
+package run
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/gobwas/glob"
+	"github.com/leg100/otf/internal"
+	"github.com/leg100/otf/internal/rbac"
+)
+
+// ErrProtectionRulesViolated is returned when applying a run that violates its
+// workspace's protection rules without permission to override the rules.
+var ErrProtectionRulesViolated = errors.New("run violates workspace protection rules")
+
+type (
+	// ProtectionRules protect a workspace's resources from destruction. They
+	// are enforced between the plan and the apply of a run: a run whose plan
+	// violates the rules is not applied unless a user with permission to do so
+	// overrides the rules.
+	ProtectionRules struct {
+		// PreventDestroyRuns disallows applying destroy runs.
+		PreventDestroyRuns bool `json:"prevent_destroy_runs"`
+		// ProtectedResources are glob patterns matched against resource
+		// addresses, e.g. aws_db_instance.* or module.database.**. A plan
+		// that destroys, or replaces, a matching resource violates the rules.
+		ProtectedResources []string `json:"protected_resources"`
+	}
+
+	// ProtectionOverride is an audit record of a user overriding the
+	// protection rules in order to apply a run.
+	ProtectionOverride struct {
+		Username   string    `json:"username"`
+		CreatedAt  time.Time `json:"created_at"`
+		Violations []string  `json:"violations"`
+	}
+
+	// ProtectionCheck is the outcome of checking a run's plan against its
+	// workspace's protection rules.
+	ProtectionCheck struct {
+		Violations []string            `json:"violations"`
+		Override   *ProtectionOverride `json:"override,omitempty"`
+	}
+)
+
+// newProtectionRules validates and normalizes protection rules. Empty patterns
+// are ignored.
+func newProtectionRules(rules ProtectionRules) (*ProtectionRules, error) {
+	patterns := make([]string, 0, len(rules.ProtectedResources))
+	for _, pattern := range rules.ProtectedResources {
+		pattern = strings.TrimSpace(pattern)
+		if pattern == "" {
+			continue
+		}
+		if _, err := glob.Compile(pattern, '.'); err != nil {
+			return nil, fmt.Errorf("invalid protected resource pattern %q: %w", pattern, err)
+		}
+		patterns = append(patterns, pattern)
+	}
+	return &ProtectionRules{
+		PreventDestroyRuns: rules.PreventDestroyRuns,
+		ProtectedResources: patterns,
+	}, nil
+}
+
+func (r *ProtectionRules) empty() bool {
+	return !r.PreventDestroyRuns && len(r.ProtectedResources) == 0
+}
+
+// violations returns a description of each violation of the rules by the run
+// and its plan.
+func (r *ProtectionRules) violations(run *Run, plan *PlanFile) (violations []string) {
+	if r.PreventDestroyRuns && run.IsDestroy {
+		violations = append(violations, "destroy runs are not permitted")
+	}
+	for _, addr := range plan.DestroyedResources() {
+		for _, pattern := range r.ProtectedResources {
+			// patterns are validated upon creation
+			if glob.MustCompile(pattern, '.').Match(addr) {
+				violations = append(violations, fmt.Sprintf("plan destroys protected resource %s (matches %s)", addr, pattern))
+				break
+			}
+		}
+	}
+	return
+}
+
+// SetProtectionRules replaces the protection rules for a workspace.
+func (s *Service) SetProtectionRules(ctx context.Context, workspaceID string, rules ProtectionRules) (*ProtectionRules, error) {
+	subject, err := s.workspaceAuthorizer.CanAccess(ctx, rbac.UpdateWorkspaceAction, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+	updated, err := newProtectionRules(rules)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.db.setProtectionRules(ctx, workspaceID, updated); err != nil {
+		s.Error(err, "setting workspace protection rules", "workspace_id", workspaceID, "subject", subject)
+		return nil, err
+	}
+	s.V(0).Info("set workspace protection rules", "workspace_id", workspaceID, "rules", updated, "subject", subject)
+	return updated, nil
+}
+
+// GetProtectionRules retrieves the protection rules for a workspace.
+func (s *Service) GetProtectionRules(ctx context.Context, workspaceID string) (*ProtectionRules, error) {
+	subject, err := s.workspaceAuthorizer.CanAccess(ctx, rbac.GetWorkspaceAction, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+	rules, err := s.db.getProtectionRules(ctx, workspaceID)
+	if err != nil {
+		s.Error(err, "retrieving workspace protection rules", "workspace_id", workspaceID, "subject", subject)
+		return nil, err
+	}
+	s.V(9).Info("retrieved workspace protection rules", "workspace_id", workspaceID, "subject", subject)
+	return rules, nil
+}
+
+// GetProtectionCheck retrieves the outcome of checking a run against its
+// workspace's protection rules, along with any override of the rules.
+func (s *Service) GetProtectionCheck(ctx context.Context, runID string) (*ProtectionCheck, error) {
+	subject, err := s.CanAccess(ctx, rbac.GetRunAction, runID)
+	if err != nil {
+		return nil, err
+	}
+	check, err := s.db.getProtectionCheck(ctx, runID)
+	if err != nil {
+		s.Error(err, "retrieving run protection check", "id", runID, "subject", subject)
+		return nil, err
+	}
+	s.V(9).Info("retrieved run protection check", "id", runID, "subject", subject)
+	return check, nil
+}
+
+// checkProtectionRules checks a planned run against its workspace's
+// protection rules, returning any violations.
+func (s *Service) checkProtectionRules(ctx context.Context, runID string) ([]string, error) {
+	run, err := s.db.GetRun(ctx, runID)
+	if err != nil {
+		return nil, err
+	}
+	rules, err := s.db.getProtectionRules(ctx, run.WorkspaceID)
+	if err != nil {
+		return nil, err
+	}
+	if rules.empty() {
+		return nil, nil
+	}
+	planJSON, err := s.GetPlanFile(ctx, runID, PlanFormatJSON)
+	if err != nil {
+		return nil, err
+	}
+	var plan PlanFile
+	if err := json.Unmarshal(planJSON, &plan); err != nil {
+		return nil, err
+	}
+	return rules.violations(run, &plan), nil
+}
+
+// enforceProtectionRules permits a run to be applied only if it violates no
+// protection rules, or if the subject has permission to override the rules, in
+// which case the override is recorded.
+func (s *Service) enforceProtectionRules(ctx context.Context, runID string) error {
+	check, err := s.db.getProtectionCheck(ctx, runID)
+	if err != nil {
+		return err
+	}
+	if len(check.Violations) == 0 {
+		return nil
+	}
+	subject, err := s.CanAccess(ctx, rbac.OverrideProtectionRulesAction, runID)
+	if errors.Is(err, internal.ErrAccessNotPermitted) {
+		return fmt.Errorf("%w: %s", ErrProtectionRulesViolated, strings.Join(check.Violations, "; "))
+	} else if err != nil {
+		return err
+	}
+	override := ProtectionOverride{
+		Username:   subject.String(),
+		CreatedAt:  internal.CurrentTimestamp(nil),
+		Violations: check.Violations,
+	}
+	if err := s.db.createProtectionOverride(ctx, runID, override); err != nil {
+		return err
+	}
+	s.V(0).Info("overrode workspace protection rules", "id", runID, "violations", check.Violations, "subject", subject)
+	internal.AddWarning(ctx, "workspace protection rules overridden: %s", strings.Join(check.Violations, "; "))
+	return nil
+}
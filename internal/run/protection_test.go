@@ -0,0 +1,72 @@
+package run
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewProtectionRules(t *testing.T) {
+	got, err := newProtectionRules(ProtectionRules{
+		ProtectedResources: []string{" aws_db_instance.* ", "", "module.database.**"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"aws_db_instance.*", "module.database.**"}, got.ProtectedResources)
+
+	_, err = newProtectionRules(ProtectionRules{ProtectedResources: []string{"aws_db_instance.[main"}})
+	assert.Error(t, err)
+}
+
+func TestProtectionRules_Violations(t *testing.T) {
+	plan := &PlanFile{
+		ResourceChanges: []ResourceChange{
+			{Address: "aws_instance.web", Change: Change{Actions: []ChangeAction{DeleteAction}}},
+			{Address: "aws_db_instance.main", Change: Change{Actions: []ChangeAction{DeleteAction, CreateAction}}},
+			{Address: "module.database.aws_db_instance.replica", Change: Change{Actions: []ChangeAction{DeleteAction}}},
+			{Address: "aws_db_instance.standby", Change: Change{Actions: []ChangeAction{UpdateAction}}},
+		},
+	}
+
+	tests := []struct {
+		name  string
+		rules ProtectionRules
+		run   *Run
+		want  []string
+	}{
+		{
+			name: "no rules",
+			run:  &Run{IsDestroy: true},
+		},
+		{
+			name:  "destroy run prevented",
+			rules: ProtectionRules{PreventDestroyRuns: true},
+			run:   &Run{IsDestroy: true},
+			want:  []string{"destroy runs are not permitted"},
+		},
+		{
+			name:  "destroy run not prevented",
+			rules: ProtectionRules{PreventDestroyRuns: true},
+			run:   &Run{},
+		},
+		{
+			name:  "protected resources",
+			rules: ProtectionRules{ProtectedResources: []string{"aws_db_instance.*", "module.database.**"}},
+			run:   &Run{},
+			want: []string{
+				"plan destroys protected resource aws_db_instance.main (matches aws_db_instance.*)",
+				"plan destroys protected resource module.database.aws_db_instance.replica (matches module.database.**)",
+			},
+		},
+		{
+			name:  "wildcard does not match across modules",
+			rules: ProtectionRules{ProtectedResources: []string{"*.replica", "module.*"}},
+			run:   &Run{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.rules.violations(tt.run, plan))
+		})
+	}
+}
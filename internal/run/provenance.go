@@ -0,0 +1,135 @@
+package run
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/leg100/otf/internal/rbac"
+	"github.com/lestrrat-go/jwx/v2/jwa"
+	"github.com/lestrrat-go/jwx/v2/jwk"
+	"github.com/lestrrat-go/jwx/v2/jws"
+)
+
+type (
+	// Provenance links a run to the configuration it was created from, and
+	// to the state versions it produced, for auditing the origin of changes
+	// to infrastructure.
+	Provenance struct {
+		RunID                string                         `json:"run_id"`
+		WorkspaceID          string                         `json:"workspace_id"`
+		Organization         string                         `json:"organization"`
+		CreatedAt            time.Time                      `json:"created_at"`
+		CreatedBy            string                         `json:"created_by,omitempty"`
+		ConfigurationVersion ConfigurationVersionProvenance `json:"configuration_version"`
+		StateVersions        []StateVersionProvenance       `json:"state_versions"`
+		// Signature is a JWS, signed with the server secret, of the
+		// provenance without its signature. Only populated if requested.
+		Signature string `json:"signature,omitempty"`
+	}
+
+	// ConfigurationVersionProvenance identifies the origin of a
+	// configuration version: either the commit from which it was created or
+	// the identity of the subject that uploaded it.
+	ConfigurationVersionProvenance struct {
+		ID        string    `json:"id"`
+		Source    string    `json:"source"`
+		CreatedAt time.Time `json:"created_at"`
+		// CreatedBy is the identity of the subject that created the
+		// configuration version; empty for configuration versions created
+		// before their creator was recorded.
+		CreatedBy string `json:"created_by,omitempty"`
+
+		// Commit attributes, populated if created from a VCS event.
+		Repo      string `json:"repo,omitempty"`
+		Branch    string `json:"branch,omitempty"`
+		Tag       string `json:"tag,omitempty"`
+		CommitSHA string `json:"commit_sha,omitempty"`
+		CommitURL string `json:"commit_url,omitempty"`
+	}
+
+	// StateVersionProvenance identifies a state version produced by a run.
+	StateVersionProvenance struct {
+		ID        string    `json:"id"`
+		Serial    int64     `json:"serial"`
+		CreatedAt time.Time `json:"created_at"`
+	}
+)
+
+// GetProvenance retrieves the provenance of a run. If signed is true then the
+// provenance is signed with the server secret.
+func (s *Service) GetProvenance(ctx context.Context, runID string, signed bool) (*Provenance, error) {
+	subject, err := s.CanAccess(ctx, rbac.GetRunAction, runID)
+	if err != nil {
+		return nil, err
+	}
+	provenance, err := s.getProvenance(ctx, runID)
+	if err != nil {
+		s.Error(err, "retrieving run provenance", "id", runID, "subject", subject)
+		return nil, err
+	}
+	if signed {
+		provenance.Signature, err = signProvenance(provenance, s.secret)
+		if err != nil {
+			s.Error(err, "signing run provenance", "id", runID, "subject", subject)
+			return nil, err
+		}
+	}
+	s.V(9).Info("retrieved run provenance", "id", runID, "subject", subject)
+	return provenance, nil
+}
+
+func (s *Service) getProvenance(ctx context.Context, runID string) (*Provenance, error) {
+	run, err := s.db.GetRun(ctx, runID)
+	if err != nil {
+		return nil, err
+	}
+	cv, err := s.db.getProvenanceConfigurationVersion(ctx, run.ConfigurationVersionID)
+	if err != nil {
+		return nil, err
+	}
+	if ia := run.IngressAttributes; ia != nil {
+		cv.Repo = ia.Repo
+		cv.Branch = ia.Branch
+		cv.Tag = ia.Tag
+		cv.CommitSHA = ia.CommitSHA
+		cv.CommitURL = ia.CommitURL
+	}
+	svs, err := s.db.listProvenanceStateVersions(ctx, runID)
+	if err != nil {
+		return nil, err
+	}
+	provenance := Provenance{
+		RunID:                run.ID,
+		WorkspaceID:          run.WorkspaceID,
+		Organization:         run.Organization,
+		CreatedAt:            run.CreatedAt,
+		ConfigurationVersion: *cv,
+		StateVersions:        svs,
+	}
+	if run.CreatedBy != nil {
+		provenance.CreatedBy = *run.CreatedBy
+	}
+	return &provenance, nil
+}
+
+// signProvenance returns a compact JWS of the provenance, signed using HS256
+// with the given secret.
+func signProvenance(provenance *Provenance, secret []byte) (string, error) {
+	unsigned := *provenance
+	unsigned.Signature = ""
+	payload, err := json.Marshal(&unsigned)
+	if err != nil {
+		return "", err
+	}
+	key, err := jwk.FromRaw(secret)
+	if err != nil {
+		return "", fmt.Errorf("constructing signing key: %w", err)
+	}
+	signed, err := jws.Sign(payload, jws.WithKey(jwa.HS256, key))
+	if err != nil {
+		return "", err
+	}
+	return string(signed), nil
+}
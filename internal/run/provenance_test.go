@@ -0,0 +1,46 @@
+package run
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/lestrrat-go/jwx/v2/jwa"
+	"github.com/lestrrat-go/jwx/v2/jwk"
+	"github.com/lestrrat-go/jwx/v2/jws"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSignProvenance(t *testing.T) {
+	secret := []byte("abcdef0123456789")
+	provenance := &Provenance{
+		RunID:        "run-123",
+		WorkspaceID:  "ws-123",
+		Organization: "acme",
+		ConfigurationVersion: ConfigurationVersionProvenance{
+			ID:        "cv-123",
+			Source:    "github",
+			CommitSHA: "0123abcd",
+		},
+		StateVersions: []StateVersionProvenance{{ID: "sv-123", Serial: 1}},
+	}
+
+	signature, err := signProvenance(provenance, secret)
+	require.NoError(t, err)
+
+	key, err := jwk.FromRaw(secret)
+	require.NoError(t, err)
+	payload, err := jws.Verify([]byte(signature), jws.WithKey(jwa.HS256, key))
+	require.NoError(t, err)
+
+	var got Provenance
+	require.NoError(t, json.Unmarshal(payload, &got))
+	assert.Equal(t, *provenance, got)
+
+	t.Run("wrong secret", func(t *testing.T) {
+		key, err := jwk.FromRaw([]byte("0123456789abcdef"))
+		require.NoError(t, err)
+		_, err = jws.Verify([]byte(signature), jws.WithKey(jwa.HS256, key))
+		assert.Error(t, err)
+	})
+}
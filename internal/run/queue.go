@@ -0,0 +1,129 @@
+package run
+
+import (
+	"context"
+	"slices"
+
+	"github.com/leg100/otf/internal/sql"
+)
+
+// isQueued determines whether the run is waiting in its workspace's queue.
+// Speculative runs are never queued: they are scheduled as soon as they are
+// created, concurrently with other runs.
+func (r *Run) isQueued() bool {
+	return r.Status == RunPending && !r.PlanOnly
+}
+
+// setQueuePositions sets the position of queued runs in their workspace's
+// queue. The position is the number of unfinished runs ahead of the run,
+// including the workspace's current run; zero means the run is next to be
+// scheduled. listQueue lists the IDs of the unfinished non-speculative runs
+// of a workspace, in the order in which they are scheduled, and is called at
+// most once per workspace.
+func setQueuePositions(runs []*Run, listQueue func(workspaceID string) ([]string, error)) error {
+	queues := make(map[string][]string)
+	for _, run := range runs {
+		if !run.isQueued() {
+			continue
+		}
+		queue, ok := queues[run.WorkspaceID]
+		if !ok {
+			var err error
+			queue, err = listQueue(run.WorkspaceID)
+			if err != nil {
+				return err
+			}
+			queues[run.WorkspaceID] = queue
+		}
+		// the run may have been scheduled in the meantime, in which case
+		// it is no longer in the queue.
+		if i := slices.Index(queue, run.ID); i > 0 {
+			run.PositionInQueue = i
+		}
+	}
+	return nil
+}
+
+// groupQueue is the queue of runs in a serialization group that are applying
+// or waiting to apply.
+type groupQueue struct {
+	group string
+	// IDs of runs in the order in which they apply, beginning with those
+	// applying.
+	runIDs []string
+}
+
+// setGroupPositions sets the serialization group and the position in the
+// group's queue of confirmed runs waiting to apply. The position is the number
+// of runs ahead of the run that are applying or waiting to apply. listQueue
+// lists the queue of the workspace's serialization group, returning nil if the
+// workspace does not belong to a group, and is called at most once per
+// workspace.
+func setGroupPositions(runs []*Run, listQueue func(workspaceID string) (*groupQueue, error)) error {
+	queues := make(map[string]*groupQueue)
+	for _, run := range runs {
+		if run.Status != RunConfirmed {
+			continue
+		}
+		queue, ok := queues[run.WorkspaceID]
+		if !ok {
+			var err error
+			queue, err = listQueue(run.WorkspaceID)
+			if err != nil {
+				return err
+			}
+			queues[run.WorkspaceID] = queue
+		}
+		if queue == nil {
+			// the workspace has since been removed from its group
+			continue
+		}
+		run.SerializationGroup = &queue.group
+		if i := slices.Index(queue.runIDs, run.ID); i > 0 {
+			run.PositionInGroup = i
+		}
+	}
+	return nil
+}
+
+func (s *Service) setQueuePositions(ctx context.Context, runs ...*Run) error {
+	err := setQueuePositions(runs, func(workspaceID string) ([]string, error) {
+		return s.db.listQueuedRunIDs(ctx, workspaceID)
+	})
+	if err != nil {
+		return err
+	}
+	return setGroupPositions(runs, func(workspaceID string) (*groupQueue, error) {
+		return s.db.listGroupQueue(ctx, workspaceID)
+	})
+}
+
+func (db *pgdb) listQueuedRunIDs(ctx context.Context, workspaceID string) ([]string, error) {
+	rows, err := db.Conn(ctx).FindQueuedRunIDsByWorkspaceID(ctx, sql.String(workspaceID))
+	if err != nil {
+		return nil, sql.Error(err)
+	}
+	ids := make([]string, len(rows))
+	for i, r := range rows {
+		ids[i] = r.String
+	}
+	return ids, nil
+}
+
+func (db *pgdb) listGroupQueue(ctx context.Context, workspaceID string) (*groupQueue, error) {
+	rows, err := db.Conn(ctx).FindSerializationGroupQueueByWorkspaceID(ctx, sql.String(workspaceID))
+	if err != nil {
+		return nil, sql.Error(err)
+	}
+	if len(rows) == 0 {
+		return nil, nil
+	}
+	queue := groupQueue{
+		group:  rows[0].SerializationGroup.String,
+		runIDs: make([]string, len(rows)),
+	}
+	for i, r := range rows {
+		queue.runIDs[i] = r.RunID.String
+	}
+	return &queue, nil
+}
@@ -0,0 +1,63 @@
+package run
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSetQueuePositions(t *testing.T) {
+	var (
+		current     = &Run{ID: "run-1", WorkspaceID: "ws-1", Status: RunPlanning}
+		second      = &Run{ID: "run-2", WorkspaceID: "ws-1", Status: RunPending}
+		third       = &Run{ID: "run-3", WorkspaceID: "ws-1", Status: RunPending}
+		speculative = &Run{ID: "run-4", WorkspaceID: "ws-1", Status: RunPending, PlanOnly: true}
+		next        = &Run{ID: "run-5", WorkspaceID: "ws-2", Status: RunPending}
+		queues      = map[string][]string{
+			"ws-1": {"run-1", "run-2", "run-3"},
+			"ws-2": {"run-5"},
+		}
+		calls = make(map[string]int)
+	)
+	err := setQueuePositions([]*Run{current, second, third, speculative, next}, func(workspaceID string) ([]string, error) {
+		calls[workspaceID]++
+		return queues[workspaceID], nil
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, 0, current.PositionInQueue)
+	assert.Equal(t, 1, second.PositionInQueue)
+	assert.Equal(t, 2, third.PositionInQueue)
+	assert.Equal(t, 0, speculative.PositionInQueue)
+	assert.Equal(t, 0, next.PositionInQueue)
+
+	// queue is retrieved once per workspace
+	assert.Equal(t, map[string]int{"ws-1": 1, "ws-2": 1}, calls)
+}
+
+func TestSetGroupPositions(t *testing.T) {
+	var (
+		applying  = &Run{ID: "run-1", WorkspaceID: "ws-1", Status: RunApplying}
+		next      = &Run{ID: "run-2", WorkspaceID: "ws-2", Status: RunConfirmed}
+		last      = &Run{ID: "run-3", WorkspaceID: "ws-1", Status: RunConfirmed}
+		ungrouped = &Run{ID: "run-4", WorkspaceID: "ws-3", Status: RunConfirmed}
+		queues    = map[string]*groupQueue{
+			"ws-1": {group: "vpc-prod", runIDs: []string{"run-1", "run-2", "run-3"}},
+			"ws-2": {group: "vpc-prod", runIDs: []string{"run-1", "run-2", "run-3"}},
+		}
+	)
+	err := setGroupPositions([]*Run{applying, next, last, ungrouped}, func(workspaceID string) (*groupQueue, error) {
+		return queues[workspaceID], nil
+	})
+	require.NoError(t, err)
+
+	assert.Nil(t, applying.SerializationGroup)
+	if assert.NotNil(t, next.SerializationGroup) {
+		assert.Equal(t, "vpc-prod", *next.SerializationGroup)
+	}
+	assert.Equal(t, 1, next.PositionInGroup)
+	assert.Equal(t, 2, last.PositionInGroup)
+	assert.Nil(t, ungrouped.SerializationGroup)
+	assert.Equal(t, 0, ungrouped.PositionInGroup)
+}
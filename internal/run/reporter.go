@@ -65,8 +65,8 @@ func (r *Reporter) Start(ctx context.Context) error {
 }
 
 func (r *Reporter) handleRun(ctx context.Context, run *Run) error {
-	// Skip runs triggered via the UI or API
-	if run.Source == SourceUI || run.Source == SourceAPI {
+	// Skip runs triggered via the UI or API, and health assessments
+	if run.Source == SourceUI || run.Source == SourceAPI || run.Source == SourceAssessment {
 		return nil
 	}
 
@@ -101,8 +101,14 @@ func (r *Reporter) handleRun(ctx context.Context, run *Run) error {
 	switch run.Status {
 	case RunPending, RunPlanQueued, RunApplyQueued:
 		status = vcs.PendingStatus
-	case RunPlanning, RunApplying, RunPlanned, RunConfirmed:
+	case RunPrePlanRunning, RunPlanning, RunPostPlanRunning, RunPolicyChecking, RunPolicyChecked, RunApplying, RunPlanned, RunConfirmed:
 		status = vcs.RunningStatus
+	case RunPolicyOverride:
+		status = vcs.PendingStatus
+		description = "policy check soft failed; awaiting override"
+	case RunPolicySoftFailed:
+		status = vcs.FailureStatus
+		description = "policy check soft failed"
 	case RunPlannedAndFinished:
 		status = vcs.SuccessStatus
 		if run.Plan.ResourceReport != nil {
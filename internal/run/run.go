@@ -28,6 +28,10 @@ const (
 	// defaultRefresh specifies that the state be refreshed prior to running a
 	// plan
 	defaultRefresh = true
+
+	// DeprecatedTerraformVersion is the version of terraform prior to which
+	// runs are warned that their version of terraform is deprecated.
+	DeprecatedTerraformVersion = "1.0.0"
 )
 
 var ErrInvalidRunStateTransition = errors.New("invalid run state transition")
@@ -50,6 +54,8 @@ type (
 		RefreshOnly            bool                    `jsonapi:"attribute" json:"refresh_only"`
 		ReplaceAddrs           []string                `jsonapi:"attribute" json:"replace_addrs"`
 		PositionInQueue        int                     `jsonapi:"attribute" json:"position_in_queue"`
+		SerializationGroup     *string                 `jsonapi:"attribute" json:"serialization_group"`
+		PositionInGroup        int                     `jsonapi:"attribute" json:"position_in_group"`
 		TargetAddrs            []string                `jsonapi:"attribute" json:"target_addrs"`
 		TerraformVersion       string                  `jsonapi:"attribute" json:"terraform_version"`
 		AllowEmptyApply        bool                    `jsonapi:"attribute" json:"allow_empty_apply"`
@@ -152,7 +158,7 @@ type (
 // newRun creates a new run with defaults.
 func newRun(ctx context.Context, org *organization.Organization, cv *configversion.ConfigurationVersion, ws *workspace.Workspace, opts CreateOptions) *Run {
 	run := Run{
-		ID:                     internal.NewID("run"),
+		ID:                     resource.NewID(resource.RunKind),
 		CreatedAt:              internal.CurrentTimestamp(opts.now),
 		Refresh:                defaultRefresh,
 		Organization:           ws.Organization,
@@ -270,9 +276,9 @@ func (r *Run) PeriodReport(now time.Time) (report PeriodReport) {
 // Phase returns the current phase.
 func (r *Run) Phase() internal.PhaseType {
 	switch r.Status {
-	case RunPending:
+	case RunPending, RunPrePlanRunning:
 		return internal.PendingPhase
-	case RunPlanQueued, RunPlanning, RunPlanned:
+	case RunPlanQueued, RunPlanning, RunPostPlanRunning, RunPlanned:
 		return internal.PlanPhase
 	case RunApplyQueued, RunApplying, RunApplied:
 		return internal.ApplyPhase
@@ -313,24 +319,25 @@ func (r *Run) InProgress() bool {
 // The isUser arg should be set to true if a user is directly instigating the
 // cancelation; otherwise it should be set to false, i.e. the job service has
 // canceled a job and is now canceling the corresponding run.
-//
-// The force arg when set to true forceably cancels the run. This is only
-// allowed when an attempt has already been made to cancel the run
-// non-forceably. The force arg is only respected when isUser is true.
-func (r *Run) Cancel(isUser, force bool) error {
-	if force {
-		if isUser {
-			if !r.ForceCancelable() {
-				return ErrRunForceCancelNotAllowed
-			}
-		} else {
-			// only a user can forceably cancel a run.
-			return ErrRunForceCancelNotAllowed
-		}
+func (r *Run) Cancel(isUser bool) error {
+	return r.cancel(isUser, false)
+}
+
+// ForceCancel forceably cancels the run. This is only allowed when an attempt
+// has already been made to cancel the run non-forceably and the cool-off period
+// since that attempt has elapsed. The process executing the run is sent a kill
+// signal.
+func (r *Run) ForceCancel(coolOff time.Duration) error {
+	if !r.ForceCancelable(coolOff) {
+		return ErrRunForceCancelNotAllowed
 	}
+	return r.cancel(true, true)
+}
+
+func (r *Run) cancel(isUser, force bool) error {
 	var signal bool
 	switch r.Status {
-	case RunPending:
+	case RunPending, RunPrePlanRunning:
 		r.Plan.UpdateStatus(PhaseUnreachable)
 		r.Apply.UpdateStatus(PhaseUnreachable)
 	case RunPlanQueued:
@@ -345,7 +352,7 @@ func (r *Run) Cancel(isUser, force bool) error {
 			r.Plan.UpdateStatus(PhaseCanceled)
 			r.Apply.UpdateStatus(PhaseUnreachable)
 		}
-	case RunPlanned:
+	case RunPlanned, RunPostPlanRunning, RunPolicyChecking, RunPolicyChecked, RunPolicyOverride, RunConfirmed:
 		r.Apply.UpdateStatus(PhaseUnreachable)
 	case RunApplying:
 		if isUser && !force {
@@ -379,16 +386,17 @@ func (r *Run) Cancelable() bool {
 		return false
 	}
 	switch r.Status {
-	case RunPending, RunPlanQueued, RunPlanning, RunApplyQueued, RunApplying:
+	case RunPending, RunPrePlanRunning, RunPlanQueued, RunPlanning, RunPostPlanRunning, RunPolicyChecking, RunConfirmed, RunApplyQueued, RunApplying:
 		return true
 	default:
 		return false
 	}
 }
 
-// ForceCancelable determines whether run can be forceably cancelled.
-func (r *Run) ForceCancelable() bool {
-	availableAt := r.ForceCancelAvailableAt()
+// ForceCancelable determines whether run can be forceably cancelled, given
+// the cool-off period that must elapse following a non-forceable cancelation.
+func (r *Run) ForceCancelable(coolOff time.Duration) bool {
+	availableAt := r.ForceCancelAvailableAt(coolOff)
 	if availableAt == nil || time.Now().Before(*availableAt) {
 		return false
 	}
@@ -399,25 +407,20 @@ func (r *Run) ForceCancelable() bool {
 // forceably cancel the run. It only possible to do so when an attempt has
 // previously been made to cancel the run non-forceably and a cool-off period
 // has elapsed.
-func (r *Run) ForceCancelAvailableAt() *time.Time {
+func (r *Run) ForceCancelAvailableAt(coolOff time.Duration) *time.Time {
 	if r.Done() || r.CancelSignaledAt == nil {
 		// cannot force cancel a run that is already complete or when no attempt
 		// has previously been made to cancel run.
 		return nil
 	}
-	return r.cancelCoolOff()
-}
-
-const forceCancelCoolOff = time.Second * 10
-
-func (r *Run) cancelCoolOff() *time.Time {
-	if r.CancelSignaledAt == nil {
-		return nil
-	}
-	cooledOff := r.CancelSignaledAt.Add(forceCancelCoolOff)
+	cooledOff := r.CancelSignaledAt.Add(coolOff)
 	return &cooledOff
 }
 
+// DefaultForceCancelCoolOff is the default period that must elapse following
+// a non-forceable cancelation before a run can be forceably canceled.
+const DefaultForceCancelCoolOff = time.Second * 10
+
 // StartedAt returns the time the run was created.
 func (r *Run) StartedAt() time.Time {
 	return r.CreatedAt
@@ -427,7 +430,7 @@ func (r *Run) StartedAt() time.Time {
 // discarded, etc.
 func (r *Run) Done() bool {
 	switch r.Status {
-	case RunApplied, RunPlannedAndFinished, RunDiscarded, RunCanceled, RunForceCanceled, RunErrored:
+	case RunApplied, RunPlannedAndFinished, RunPolicySoftFailed, RunDiscarded, RunCanceled, RunForceCanceled, RunErrored:
 		return true
 	default:
 		return false
@@ -463,7 +466,7 @@ func (r *Run) CanAccessWorkspace(action rbac.Action, policy *internal.WorkspaceP
 
 func (r *Run) EnqueueApply() error {
 	switch r.Status {
-	case RunPlanned, RunCostEstimated:
+	case RunPlanned, RunCostEstimated, RunPolicyChecked, RunConfirmed:
 		// applyable statuses
 	default:
 		return fmt.Errorf("cannot apply run with status %s", r.Status)
@@ -473,6 +476,39 @@ func (r *Run) EnqueueApply() error {
 	return nil
 }
 
+// Confirm updates the run to reflect its apply having been confirmed, or its
+// errored apply having been retried, but not yet enqueued: the run waits for
+// the scheduler to enqueue the apply once no other run in its workspace's
+// serialization group is applying.
+func (r *Run) Confirm() error {
+	switch r.Status {
+	case RunPlanned, RunCostEstimated, RunPolicyChecked:
+		// applyable statuses
+	default:
+		if !r.RetryApplyable() {
+			return fmt.Errorf("cannot confirm run with status %s", r.Status)
+		}
+	}
+	r.updateStatus(RunConfirmed, nil)
+	return nil
+}
+
+// RetryApply re-enqueues the apply of a run whose apply errored, applying the
+// same plan once more.
+func (r *Run) RetryApply() error {
+	if !r.RetryApplyable() {
+		return fmt.Errorf("%w: run's apply did not error", ErrRunRetryApplyNotAllowed)
+	}
+	r.updateStatus(RunApplyQueued, nil)
+	r.Apply.UpdateStatus(PhaseQueued)
+	return nil
+}
+
+// RetryApplyable determines whether the run's apply can be retried.
+func (r *Run) RetryApplyable() bool {
+	return r.Status == RunErrored && r.Apply.Status == PhaseErrored
+}
+
 func (r *Run) StatusTimestamp(status Status) (time.Time, error) {
 	for _, rst := range r.StatusTimestamps {
 		if rst.Status == status {
@@ -518,22 +554,8 @@ func (r *Run) Finish(phase internal.PhaseType, opts PhaseFinishOptions) (autoapp
 			r.Apply.UpdateStatus(PhaseUnreachable)
 			return false, nil
 		}
-		// Enter RunCostEstimated state if cost estimation is enabled. OTF does
-		// not support cost estimation but enter this state only in order to
-		// satisfy the go-tfe tests.
-		if r.CostEstimationEnabled {
-			r.updateStatus(RunCostEstimated, nil)
-		} else {
-			r.updateStatus(RunPlanned, nil)
-		}
 		r.Plan.UpdateStatus(PhaseFinished)
-
-		if !r.HasChanges() || r.PlanOnly {
-			r.updateStatus(RunPlannedAndFinished, nil)
-			r.Apply.UpdateStatus(PhaseUnreachable)
-			return false, nil
-		}
-		return r.AutoApply, nil
+		return r.finishPlan(), nil
 	case internal.ApplyPhase:
 		if r.Status != RunApplying {
 			return false, ErrInvalidRunStateTransition
@@ -551,6 +573,25 @@ func (r *Run) Finish(phase internal.PhaseType, opts PhaseFinishOptions) (autoapp
 	}
 }
 
+// finishPlan updates the status of a run whose plan has finished
+// successfully, returning true if an apply should be automatically enqueued.
+func (r *Run) finishPlan() (autoapply bool) {
+	// Enter RunCostEstimated state if cost estimation is enabled. OTF does
+	// not support cost estimation but enter this state only in order to
+	// satisfy the go-tfe tests.
+	if r.CostEstimationEnabled {
+		r.updateStatus(RunCostEstimated, nil)
+	} else {
+		r.updateStatus(RunPlanned, nil)
+	}
+	if !r.HasChanges() || r.PlanOnly {
+		r.updateStatus(RunPlannedAndFinished, nil)
+		r.Apply.UpdateStatus(PhaseUnreachable)
+		return false
+	}
+	return r.AutoApply
+}
+
 func (r *Run) updateStatus(status Status, now *time.Time) *Run {
 	r.Status = status
 	r.StatusTimestamps = append(r.StatusTimestamps, StatusTimestamp{
@@ -563,7 +604,7 @@ func (r *Run) updateStatus(status Status, now *time.Time) *Run {
 // Discardable determines whether run can be discarded.
 func (r *Run) Discardable() bool {
 	switch r.Status {
-	case RunPending, RunPlanned, RunCostEstimated:
+	case RunPending, RunPlanned, RunCostEstimated, RunPolicyChecked, RunPolicyOverride:
 		return true
 	default:
 		return false
@@ -573,7 +614,7 @@ func (r *Run) Discardable() bool {
 // Confirmable determines whether run can be confirmed.
 func (r *Run) Confirmable() bool {
 	switch r.Status {
-	case RunPlanned:
+	case RunPlanned, RunPolicyChecked:
 		return true
 	default:
 		return false
@@ -148,6 +148,22 @@ func TestRun_States(t *testing.T) {
 		require.Equal(t, PhaseQueued, run.Apply.Status)
 	})
 
+	t.Run("confirm and then enqueue apply", func(t *testing.T) {
+		run := newTestRun(ctx, CreateOptions{})
+		run.Status = RunPlanned
+
+		require.NoError(t, run.Confirm())
+
+		require.Equal(t, RunConfirmed, run.Status)
+		require.Equal(t, PhasePending, run.Apply.Status)
+		assert.True(t, run.Cancelable())
+
+		require.NoError(t, run.EnqueueApply())
+
+		require.Equal(t, RunApplyQueued, run.Status)
+		require.Equal(t, PhaseQueued, run.Apply.Status)
+	})
+
 	t.Run("start apply", func(t *testing.T) {
 		run := newTestRun(ctx, CreateOptions{})
 		run.Status = RunApplyQueued
@@ -182,7 +198,7 @@ func TestRun_States(t *testing.T) {
 
 	t.Run("cancel pending run", func(t *testing.T) {
 		run := newTestRun(ctx, CreateOptions{})
-		err := run.Cancel(true, false)
+		err := run.Cancel(true)
 		require.NoError(t, err)
 		// no signal should be sent
 		assert.Zero(t, run.CancelSignaledAt)
@@ -193,16 +209,42 @@ func TestRun_States(t *testing.T) {
 	t.Run("cancel planning run should indicate signal be sent", func(t *testing.T) {
 		run := newTestRun(ctx, CreateOptions{})
 		run.Status = RunPlanning
-		err := run.Cancel(true, false)
+		err := run.Cancel(true)
 		require.NoError(t, err)
 		assert.NotZero(t, run.CancelSignaledAt)
 		assert.Equal(t, RunPlanning, run.Status)
 	})
 
+	t.Run("retry errored apply", func(t *testing.T) {
+		run := newTestRun(ctx, CreateOptions{})
+		run.Status = RunApplying
+		run.Apply.Status = PhaseRunning
+
+		_, err := run.Finish(internal.ApplyPhase, PhaseFinishOptions{Errored: true})
+		require.NoError(t, err)
+		require.True(t, run.RetryApplyable())
+
+		require.NoError(t, run.RetryApply())
+
+		assert.Equal(t, RunApplyQueued, run.Status)
+		assert.Equal(t, PhaseQueued, run.Apply.Status)
+	})
+
+	t.Run("cannot retry apply of a run whose plan errored", func(t *testing.T) {
+		run := newTestRun(ctx, CreateOptions{})
+		run.Status = RunPlanning
+
+		_, err := run.Finish(internal.PlanPhase, PhaseFinishOptions{Errored: true})
+		require.NoError(t, err)
+
+		assert.False(t, run.RetryApplyable())
+		assert.ErrorIs(t, run.RetryApply(), ErrRunRetryApplyNotAllowed)
+	})
+
 	t.Run("when non-user cancels a planning run, it should be placed into canceled state", func(t *testing.T) {
 		run := newTestRun(ctx, CreateOptions{})
 		run.Status = RunPlanning
-		err := run.Cancel(false, false)
+		err := run.Cancel(false)
 		require.NoError(t, err)
 		assert.Equal(t, PhaseCanceled, run.Plan.Status)
 		assert.Equal(t, PhaseUnreachable, run.Apply.Status)
@@ -212,16 +254,16 @@ func TestRun_States(t *testing.T) {
 	t.Run("user cannot cancel a run twice", func(t *testing.T) {
 		run := newTestRun(ctx, CreateOptions{})
 		run.Status = RunPlanning
-		err := run.Cancel(true, false)
+		err := run.Cancel(true)
 		require.NoError(t, err)
-		err = run.Cancel(true, false)
+		err = run.Cancel(true)
 		assert.Equal(t, ErrRunCancelNotAllowed, err)
 	})
 
 	t.Run("cannot force cancel a run when no previous attempt has been made to cancel run gracefully", func(t *testing.T) {
 		run := newTestRun(ctx, CreateOptions{})
 		run.Status = RunPlanning
-		err := run.Cancel(true, true)
+		err := run.ForceCancel(DefaultForceCancelCoolOff)
 		assert.Equal(t, ErrRunForceCancelNotAllowed, err)
 	})
 
@@ -231,16 +273,20 @@ func TestRun_States(t *testing.T) {
 		// gracefully canceled 11 seconds ago
 		run.CancelSignaledAt = internal.Time(time.Now().Add(-11 * time.Second))
 		// force cancel now
-		err := run.Cancel(true, true)
+		err := run.ForceCancel(DefaultForceCancelCoolOff)
 		require.NoError(t, err)
 		assert.Equal(t, RunForceCanceled, run.Status)
 	})
 
-	t.Run("non-user cannot force cancel a run", func(t *testing.T) {
+	t.Run("cannot force cancel a run before cool off period has elapsed", func(t *testing.T) {
 		run := newTestRun(ctx, CreateOptions{})
 		run.Status = RunPlanning
-		err := run.Cancel(false, true)
-		assert.Equal(t, ErrRunForceCancelNotAllowed, err)
+		// gracefully canceled 11 seconds ago
+		run.CancelSignaledAt = internal.Time(time.Now().Add(-11 * time.Second))
+		// force cancel now with a cool off period of a minute
+		err := run.ForceCancel(time.Minute)
+		assert.ErrorIs(t, err, internal.ErrConflict)
+		assert.Equal(t, RunPlanning, run.Status)
 	})
 }
 
@@ -334,6 +380,76 @@ func TestRun_StatusReport(t *testing.T) {
 	}
 }
 
+func TestRun_Timings(t *testing.T) {
+	var (
+		now = internal.CurrentTimestamp(nil)
+		ago = func(seconds int) time.Time {
+			return now.Add(time.Duration(seconds) * -time.Second)
+		}
+		createRun = func(created time.Time) *Run {
+			return newTestRun(context.Background(), CreateOptions{now: &created})
+		}
+	)
+
+	tests := []struct {
+		name string
+		run  *Run
+		want Timings
+	}{
+		{
+			"planning",
+			createRun(ago(4)).
+				updateStatus(RunPlanQueued, internal.Time(ago(3))).
+				updateStatus(RunPlanning, internal.Time(ago(2))),
+			Timings{
+				Queued:          time.Second,
+				WaitingForAgent: time.Second,
+				Plan:            2 * time.Second,
+			},
+		},
+		{
+			"applied",
+			createRun(ago(20)).
+				updateStatus(RunPlanQueued, internal.Time(ago(19))).
+				updateStatus(RunPlanning, internal.Time(ago(17))).
+				updateStatus(RunPlanned, internal.Time(ago(14))).
+				updateStatus(RunApplyQueued, internal.Time(ago(10))).
+				updateStatus(RunApplying, internal.Time(ago(5))).
+				updateStatus(RunApplied, &now),
+			Timings{
+				Queued:                 time.Second,
+				WaitingForAgent:        7 * time.Second,
+				Plan:                   3 * time.Second,
+				WaitingForConfirmation: 4 * time.Second,
+				Apply:                  5 * time.Second,
+			},
+		},
+		{
+			"retried apply",
+			createRun(ago(10)).
+				updateStatus(RunPlanQueued, internal.Time(ago(10))).
+				updateStatus(RunPlanning, internal.Time(ago(10))).
+				updateStatus(RunPlanned, internal.Time(ago(9))).
+				updateStatus(RunApplyQueued, internal.Time(ago(9))).
+				updateStatus(RunApplying, internal.Time(ago(8))).
+				updateStatus(RunErrored, internal.Time(ago(6))).
+				updateStatus(RunApplyQueued, internal.Time(ago(4))).
+				updateStatus(RunApplying, internal.Time(ago(3))).
+				updateStatus(RunApplied, &now),
+			Timings{
+				WaitingForAgent: 2 * time.Second,
+				Plan:            time.Second,
+				Apply:           5 * time.Second,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.run.Timings(now))
+		})
+	}
+}
+
 func newTestRun(ctx context.Context, opts CreateOptions) *Run {
 	return newRun(ctx, &organization.Organization{}, &configversion.ConfigurationVersion{}, &workspace.Workspace{}, opts)
 }
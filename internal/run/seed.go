@@ -0,0 +1,98 @@
+package run
+
+import (
+	"context"
+
+	"github.com/leg100/otf/internal"
+	"github.com/leg100/otf/internal/rbac"
+	"github.com/leg100/otf/internal/sql/pggen"
+)
+
+// SeedOptions are options for seeding a run that has already finished.
+type SeedOptions struct {
+	CreateOptions
+	// Changes are the resource changes reported by the plan, and by the
+	// apply, if any.
+	Changes Report
+	// Apply the plan. Ignored if the plan has no changes.
+	Apply bool
+	// Errored errors the apply if Apply is true; otherwise it errors the
+	// plan.
+	Errored bool
+}
+
+// Seed creates a run that has already finished, for populating a demo
+// installation with a run history. The run is neither scheduled nor handled
+// by an agent, and terraform is not executed.
+func (s *Service) Seed(ctx context.Context, workspaceID string, opts SeedOptions) (*Run, error) {
+	subject, err := s.workspaceAuthorizer.CanAccess(ctx, rbac.CreateRunAction, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+	run, err := s.NewRun(ctx, workspaceID, opts.CreateOptions)
+	if err != nil {
+		s.Error(err, "constructing new run", "subject", subject)
+		return nil, err
+	}
+	err = s.db.Tx(ctx, func(ctx context.Context, q pggen.Querier) error {
+		if err := s.db.CreateRun(ctx, run); err != nil {
+			return err
+		}
+		if err := s.seedPhase(ctx, run.ID, internal.PlanPhase, opts.Errored && !opts.Apply, opts.Changes); err != nil {
+			return err
+		}
+		if !opts.Apply {
+			return nil
+		}
+		planned, err := s.db.UpdateStatus(ctx, run.ID, func(run *Run) error {
+			if run.Status == RunPlanned {
+				return run.EnqueueApply()
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		if planned.Status == RunApplyQueued {
+			return s.seedPhase(ctx, run.ID, internal.ApplyPhase, opts.Errored, opts.Changes)
+		}
+		return nil
+	})
+	if err != nil {
+		s.Error(err, "seeding run", "id", run.ID, "workspace_id", workspaceID, "subject", subject)
+		return nil, err
+	}
+	s.V(1).Info("seeded run", "id", run.ID, "workspace_id", workspaceID, "subject", subject)
+	return s.db.GetRun(ctx, run.ID)
+}
+
+// seedPhase starts and finishes a phase of a seeded run, recording the changes
+// the phase reports unless it errored.
+func (s *Service) seedPhase(ctx context.Context, runID string, phase internal.PhaseType, errored bool, changes Report) error {
+	if _, err := s.db.UpdateStatus(ctx, runID, func(run *Run) error {
+		if phase == internal.PlanPhase {
+			if err := run.EnqueuePlan(); err != nil {
+				return err
+			}
+		}
+		return run.Start()
+	}); err != nil {
+		return err
+	}
+	if !errored {
+		var err error
+		if phase == internal.PlanPhase {
+			err = s.db.CreatePlanReport(ctx, runID, changes, Report{})
+		} else {
+			err = s.db.CreateApplyReport(ctx, runID, changes)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	_, err := s.db.UpdateStatus(ctx, runID, func(run *Run) error {
+		_, err := run.Finish(phase, PhaseFinishOptions{Errored: errored})
+		return err
+	})
+	return err
+}
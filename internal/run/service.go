@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/go-logr/logr"
 	"github.com/gorilla/mux"
@@ -15,6 +16,7 @@ import (
 	"github.com/leg100/otf/internal/rbac"
 	"github.com/leg100/otf/internal/releases"
 	"github.com/leg100/otf/internal/resource"
+	"github.com/leg100/otf/internal/semver"
 	"github.com/leg100/otf/internal/sql"
 	"github.com/leg100/otf/internal/sql/pggen"
 	"github.com/leg100/otf/internal/tfeapi"
@@ -31,6 +33,13 @@ type (
 	ConfigurationVersionService configversion.Service
 	VCSProviderService          vcsprovider.Service
 
+	RunService interface {
+		// By RunID
+		Get(context.Context, string) (*Run, error)
+		GetPlanFile(context.Context, string, PlanFormat) ([]byte, error)
+		GetProvenance(ctx context.Context, runID string, signed bool) (*Provenance, error)
+	}
+
 	Service struct {
 		logr.Logger
 
@@ -50,7 +59,12 @@ type (
 		afterForceCancelHooks  []func(context.Context, *Run) error
 		afterEnqueuePlanHooks  []func(context.Context, *Run) error
 		afterEnqueueApplyHooks []func(context.Context, *Run) error
+		taskStageHooks         []func(context.Context, *Run, TaskStage) (bool, error)
+		policyCheckHooks       []func(context.Context, *Run) (bool, error)
+		planEnrichers          []planEnricher
 		broker                 pubsub.SubscriptionService[*Run]
+		secret                 []byte // for signing provenance
+		forceCancelCoolOff     time.Duration
 
 		*factory
 	}
@@ -66,6 +80,12 @@ type (
 		VCSProviderService   *vcsprovider.Service
 		TokensService        *tokens.Service
 
+		// Secret for signing run provenance
+		Secret []byte
+		// Period that must elapse following a cancelation before a run can be
+		// forceably canceled. Defaults to DefaultForceCancelCoolOff.
+		ForceCancelCoolOff time.Duration
+
 		logr.Logger
 		internal.Cache
 		*sql.DB
@@ -87,7 +107,13 @@ func NewService(opts Options) *Service {
 		organization:        &organization.Authorizer{Logger: opts.Logger},
 		workspaceAuthorizer: opts.WorkspaceAuthorizer,
 		authorizer:          &authorizer{db, opts.WorkspaceAuthorizer},
+		secret:              opts.Secret,
+		forceCancelCoolOff:  opts.ForceCancelCoolOff,
 	}
+	if svc.forceCancelCoolOff == 0 {
+		svc.forceCancelCoolOff = DefaultForceCancelCoolOff
+	}
+	svc.registerDefaultPlanEnrichers()
 	svc.factory = &factory{
 		organizations: opts.OrganizationService,
 		workspaces:    opts.WorkspaceService,
@@ -96,10 +122,11 @@ func NewService(opts Options) *Service {
 		releases:      opts.ReleasesService,
 	}
 	svc.web = &webHandlers{
-		Renderer:   opts.Renderer,
-		logger:     opts.Logger,
-		runs:       &svc,
-		workspaces: opts.WorkspaceService,
+		Renderer:           opts.Renderer,
+		logger:             opts.Logger,
+		runs:               &svc,
+		workspaces:         opts.WorkspaceService,
+		forceCancelCoolOff: svc.forceCancelCoolOff,
 	}
 	svc.tfeapi = &tfe{
 		Service:    &svc,
@@ -136,7 +163,7 @@ func NewService(opts Options) *Service {
 	opts.Responder.Register(tfeapi.IncludeCurrentRun, svc.tfeapi.includeCurrentRun)
 
 	// Subscribe run spawner to incoming vcs events
-	opts.VCSEventSubscriber.Subscribe(spawner.handle)
+	opts.VCSEventSubscriber.Subscribe("run-spawner", spawner.handle)
 
 	// After a workspace is created, if auto-queue-runs is set, then create a
 	// run as well.
@@ -169,6 +196,10 @@ func (s *Service) Create(ctx context.Context, workspaceID string, opts CreateOpt
 	}
 	s.V(1).Info("created run", "id", run.ID, "workspace_id", run.WorkspaceID, "subject", subject)
 
+	if semver.Compare(run.TerraformVersion, DeprecatedTerraformVersion) < 0 {
+		internal.AddWarning(ctx, "workspace uses terraform %s: versions older than %s are deprecated and support will be removed in a future release", run.TerraformVersion, DeprecatedTerraformVersion)
+	}
+
 	return run, nil
 }
 
@@ -184,11 +215,29 @@ func (s *Service) Get(ctx context.Context, runID string) (*Run, error) {
 		s.Error(err, "retrieving run", "id", runID, "subject", subject)
 		return nil, err
 	}
+	if err := s.setQueuePositions(ctx, run); err != nil {
+		s.Error(err, "retrieving run queue position", "id", runID, "subject", subject)
+		return nil, err
+	}
 	s.V(9).Info("retrieved run", "id", runID, "subject", subject)
 
 	return run, nil
 }
 
+// GetPolicy retrieves the policy of the workspace to which the run belongs.
+// The subject must be permitted to retrieve the run; otherwise, so as not to
+// reveal the existence of the run, ErrResourceNotFound is returned.
+func (s *Service) GetPolicy(ctx context.Context, runID string) (internal.WorkspacePolicy, error) {
+	run, err := s.db.GetRun(ctx, runID)
+	if err != nil {
+		return internal.WorkspacePolicy{}, internal.ErrResourceNotFound
+	}
+	if _, err := s.workspaceAuthorizer.CanAccess(ctx, rbac.GetRunAction, run.WorkspaceID); err != nil {
+		return internal.WorkspacePolicy{}, internal.ErrResourceNotFound
+	}
+	return s.workspaces.GetPolicy(ctx, run.WorkspaceID)
+}
+
 // List retrieves multiple runs. Use opts to filter and paginate the
 // list.
 func (s *Service) List(ctx context.Context, opts ListOptions) (*resource.Page[*Run], error) {
@@ -222,6 +271,10 @@ func (s *Service) List(ctx context.Context, opts ListOptions) (*resource.Page[*R
 		s.Error(err, "listing runs", "subject", subject)
 		return nil, err
 	}
+	if err := s.setQueuePositions(ctx, page.Items...); err != nil {
+		s.Error(err, "retrieving run queue positions", "subject", subject)
+		return nil, err
+	}
 
 	s.V(9).Info("listed runs", "count", len(page.Items), "subject", subject)
 
@@ -238,12 +291,23 @@ func (s *Service) EnqueuePlan(ctx context.Context, runID string) (run *Run, err
 			return err
 		}
 		run, err = s.db.UpdateStatus(ctx, runID, func(run *Run) error {
+			if run.Status == RunPending {
+				// run pre-plan tasks, if any, before enqueuing the plan
+				if started, err := s.startTaskStage(ctx, run, PrePlanStage); err != nil || started {
+					return err
+				}
+			}
 			return run.EnqueuePlan()
 		})
 		if err != nil {
 			s.Error(err, "enqueuing plan", "id", runID, "subject", subject)
 			return err
 		}
+		if run.Status == RunPrePlanRunning {
+			// plan is enqueued once the tasks complete
+			s.V(0).Info("started pre-plan tasks", "id", runID, "subject", subject)
+			return nil
+		}
 		s.V(0).Info("enqueued plan", "id", runID, "subject", subject)
 		// invoke AfterEnqueuePlan hooks
 		for _, hook := range s.afterEnqueuePlanHooks {
@@ -282,8 +346,20 @@ func (s *Service) Delete(ctx context.Context, runID string) error {
 
 // StartPhase starts a run phase.
 func (s *Service) StartPhase(ctx context.Context, runID string, phase internal.PhaseType, _ PhaseStartOptions) (*Run, error) {
-	run, err := s.db.UpdateStatus(ctx, runID, func(run *Run) error {
-		return run.Start()
+	var run *Run
+	err := s.db.Tx(ctx, func(ctx context.Context, q pggen.Querier) (err error) {
+		run, err = s.db.UpdateStatus(ctx, runID, func(run *Run) error {
+			return run.Start()
+		})
+		if err != nil {
+			return err
+		}
+		if phase == internal.PlanPhase {
+			// record the state the run is planned against, so that the
+			// plan is not applied should the state change in the meantime.
+			return s.db.setPlanState(ctx, runID, run.WorkspaceID)
+		}
+		return nil
 	})
 	if err != nil {
 		// only log error if not an phase already started error - this occurs when
@@ -303,8 +379,20 @@ func (s *Service) StartPhase(ctx context.Context, runID string, phase internal.P
 // FinishPhase finishes a phase. Creates a report of changes before updating the status of
 // the run.
 func (s *Service) FinishPhase(ctx context.Context, runID string, phase internal.PhaseType, opts PhaseFinishOptions) (*Run, error) {
-	var resourceReport, outputReport Report
-	if !opts.Errored {
+	var (
+		resourceReport, outputReport Report
+		violations                   []string
+	)
+	switch {
+	case phase == internal.ApplyPhase && opts.ApplySummary != nil:
+		// the agent summarized the apply, which accounts for changes made even
+		// if the apply failed, so there is no need to parse its output.
+		resourceReport = opts.ApplySummary.Resources
+		if err := s.db.CreateApplyReport(ctx, runID, resourceReport); err != nil {
+			s.Error(err, "creating report", "id", runID, "phase", phase)
+			opts.Errored = true
+		}
+	case !opts.Errored:
 		var err error
 		resourceReport, outputReport, err = s.createReports(ctx, runID, phase)
 		if err != nil {
@@ -312,18 +400,60 @@ func (s *Service) FinishPhase(ctx context.Context, runID string, phase internal.
 			opts.Errored = true
 		}
 	}
+	if !opts.Errored && phase == internal.PlanPhase {
+		var err error
+		violations, err = s.checkProtectionRules(ctx, runID)
+		if err != nil {
+			s.Error(err, "checking protection rules", "id", runID)
+			opts.Errored = true
+		}
+	}
 	var run *Run
 	err := s.db.Tx(ctx, func(ctx context.Context, q pggen.Querier) (err error) {
 		var autoapply bool
 		run, err = s.db.UpdateStatus(ctx, runID, func(run *Run) (err error) {
+			if phase == internal.PlanPhase && !opts.Errored && run.Status == RunPlanning {
+				// run post-plan tasks, if any, before finishing the plan
+				if started, err := s.startTaskStage(ctx, run, PostPlanStage); err != nil || started {
+					return err
+				}
+				// check policies, if any, before finishing the plan
+				if started, err := s.startPolicyCheck(ctx, run); err != nil || started {
+					return err
+				}
+			}
 			autoapply, err = run.Finish(phase, opts)
 			return err
 		})
 		if err != nil {
 			return err
 		}
+		if err := s.db.createDiagnostics(ctx, runID, phase, opts.Diagnostics); err != nil {
+			return err
+		}
+		if phase == internal.ApplyPhase && opts.ApplySummary != nil {
+			if err := s.db.createApplyFailures(ctx, runID, opts.ApplySummary.Failures); err != nil {
+				return err
+			}
+		}
+		if err := s.db.createProtectionViolations(ctx, runID, violations); err != nil {
+			return err
+		}
+		if len(violations) > 0 {
+			// a run violating protection rules must be applied by a user
+			// permitted to override the rules.
+			s.V(0).Info("run violates workspace protection rules", "id", runID, "violations", violations)
+			return nil
+		}
 		if autoapply {
-			return s.Apply(ctx, runID)
+			err := s.Apply(ctx, runID)
+			if errors.Is(err, ErrStalePlan) {
+				// leave a stale plan for a user to replan or discard
+				// rather than fail the plan.
+				s.V(0).Info("not auto-applying stale plan", "id", runID)
+				return nil
+			}
+			return err
 		}
 		return nil
 	})
@@ -390,13 +520,33 @@ func (s *Service) Apply(ctx context.Context, runID string) error {
 			return err
 		}
 		run, err := s.db.UpdateStatus(ctx, runID, func(run *Run) error {
+			// the apply of a run in a serialization group is held until the
+			// scheduler enqueues it.
+			if serialized, err := s.serialized(ctx, run); err != nil {
+				return err
+			} else if serialized {
+				return run.Confirm()
+			}
 			return run.EnqueueApply()
 		})
 		if err != nil {
 			s.Error(err, "enqueuing apply", "id", runID, "subject", subject)
 			return err
 		}
+		// an error rolls back the enqueued apply
+		if err := s.checkPlanState(ctx, run); err != nil {
+			s.Error(err, "enqueuing apply", "id", runID, "subject", subject)
+			return err
+		}
+		if err := s.enforceProtectionRules(ctx, runID); err != nil {
+			s.Error(err, "enqueuing apply", "id", runID, "subject", subject)
+			return err
+		}
 
+		if run.Status == RunConfirmed {
+			s.V(0).Info("confirmed apply", "id", runID, "subject", subject)
+			return nil
+		}
 		s.V(0).Info("enqueued apply", "id", runID, "subject", subject)
 		// invoke AfterEnqueueApply hooks
 		for _, hook := range s.afterEnqueueApplyHooks {
@@ -408,6 +558,126 @@ func (s *Service) Apply(ctx context.Context, runID string) error {
 	})
 }
 
+// EnqueueApply enqueues the apply of a confirmed run. The scheduler invokes
+// this once no other run in the run's serialization group is applying.
+func (s *Service) EnqueueApply(ctx context.Context, runID string) (run *Run, err error) {
+	err = s.db.Tx(ctx, func(ctx context.Context, q pggen.Querier) error {
+		subject, err := s.CanAccess(ctx, rbac.EnqueueApplyAction, runID)
+		if err != nil {
+			return err
+		}
+		run, err = s.db.UpdateStatus(ctx, runID, func(run *Run) error {
+			if run.Status != RunConfirmed {
+				// the run has since been canceled
+				return fmt.Errorf("%w: run is not confirmed", ErrInvalidRunStateTransition)
+			}
+			return run.EnqueueApply()
+		})
+		if err != nil {
+			s.Error(err, "enqueuing apply", "id", runID, "subject", subject)
+			return err
+		}
+		s.V(0).Info("enqueued apply", "id", runID, "subject", subject)
+		for _, hook := range s.afterEnqueueApplyHooks {
+			if err := hook(ctx, run); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	return
+}
+
+// serialized determines whether the run's workspace belongs to a
+// serialization group, in which case the run's apply is held until the
+// scheduler enqueues it.
+func (s *Service) serialized(ctx context.Context, run *Run) (bool, error) {
+	ws, err := s.workspaces.Get(ctx, run.WorkspaceID)
+	if err != nil {
+		return false, err
+	}
+	return ws.SerializationGroup != nil, nil
+}
+
+// RetryApply re-enqueues the apply of a run whose apply errored, e.g. because
+// of a transient failure, applying the same plan once more. The retry is only
+// permitted if the failed apply did not write any state, and the run is still
+// the workspace's latest run. Otherwise ErrStalePlan or
+// ErrRunRetryApplyNotAllowed is returned and the run should instead be
+// replanned.
+func (s *Service) RetryApply(ctx context.Context, runID string) (run *Run, err error) {
+	subject, err := s.CanAccess(ctx, rbac.ApplyRunAction, runID)
+	if err != nil {
+		return nil, err
+	}
+	err = s.db.Tx(ctx, func(ctx context.Context, q pggen.Querier) error {
+		run, err = s.db.UpdateStatus(ctx, runID, func(run *Run) error {
+			if serialized, err := s.serialized(ctx, run); err != nil {
+				return err
+			} else if serialized {
+				if !run.RetryApplyable() {
+					return fmt.Errorf("%w: run's apply did not error", ErrRunRetryApplyNotAllowed)
+				}
+				return run.Confirm()
+			}
+			return run.RetryApply()
+		})
+		if err != nil {
+			return err
+		}
+		if !run.Latest {
+			return fmt.Errorf("%w: run is no longer the workspace's latest run", ErrRunRetryApplyNotAllowed)
+		}
+		// without a record of the state the run was planned against it cannot
+		// be determined whether the failed apply wrote state.
+		if _, err := s.db.getPlanState(ctx, runID); errors.Is(err, internal.ErrResourceNotFound) {
+			return fmt.Errorf("%w: state at time of plan is unknown; replan the run", ErrRunRetryApplyNotAllowed)
+		} else if err != nil {
+			return err
+		}
+		// a failed apply that wrote state renders the plan stale
+		if err := s.checkPlanState(ctx, run); err != nil {
+			return err
+		}
+		if err := s.enforceProtectionRules(ctx, runID); err != nil {
+			return err
+		}
+		// the run relinquished its lock on the workspace when the apply
+		// errored; re-acquire it, provided neither another run nor a user has
+		// since locked the workspace.
+		ws, err := s.workspaces.Get(ctx, run.WorkspaceID)
+		if err != nil {
+			return err
+		}
+		if ws.Locked() {
+			return fmt.Errorf("%w: workspace is locked", ErrRunRetryApplyNotAllowed)
+		}
+		if _, err := s.workspaces.Lock(ctx, run.WorkspaceID, &run.ID); err != nil {
+			return err
+		}
+		// discard the logs of the failed apply
+		if err := s.db.deleteLogs(ctx, runID, internal.ApplyPhase); err != nil {
+			return err
+		}
+		if run.Status == RunConfirmed {
+			// the scheduler enqueues the apply
+			return nil
+		}
+		for _, hook := range s.afterEnqueueApplyHooks {
+			if err := hook(ctx, run); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		s.Error(err, "retrying apply", "id", runID, "subject", subject)
+		return nil, err
+	}
+	s.V(0).Info("retrying apply", "id", runID, "subject", subject)
+	return run, nil
+}
+
 func (s *Service) AfterEnqueueApply(hook func(context.Context, *Run) error) {
 	// add hook to list of hooks to be triggered after apply is enqueued
 	s.afterEnqueueApplyHooks = append(s.afterEnqueueApplyHooks, hook)
@@ -442,7 +712,7 @@ func (s *Service) Cancel(ctx context.Context, runID string) error {
 		_, isUser := subject.(*user.User)
 
 		run, err := s.db.UpdateStatus(ctx, runID, func(run *Run) (err error) {
-			return run.Cancel(isUser, false)
+			return run.Cancel(isUser)
 		})
 		if err != nil {
 			s.Error(err, "canceling run", "id", runID, "subject", subject)
@@ -476,7 +746,7 @@ func (s *Service) ForceCancel(ctx context.Context, runID string) error {
 			return err
 		}
 		run, err := s.db.UpdateStatus(ctx, runID, func(run *Run) (err error) {
-			return run.Cancel(true, true)
+			return run.ForceCancel(s.forceCancelCoolOff)
 		})
 		if err != nil {
 			s.Error(err, "force canceling run", "id", runID, "subject", subject)
@@ -561,18 +831,14 @@ func (s *Service) createReports(ctx context.Context, runID string, phase interna
 }
 
 func (s *Service) createPlanReports(ctx context.Context, runID string) (resources Report, outputs Report, err error) {
-	plan, err := s.GetPlanFile(ctx, runID, PlanFormatJSON)
-	if err != nil {
-		return Report{}, Report{}, err
-	}
-	resourceReport, outputReport, err := CompilePlanReports(plan)
+	summary, err := s.SummarizePlan(ctx, runID)
 	if err != nil {
 		return Report{}, Report{}, err
 	}
-	if err := s.db.CreatePlanReport(ctx, runID, resourceReport, outputReport); err != nil {
+	if err := s.db.CreatePlanReport(ctx, runID, summary.ResourceReport, summary.OutputReport); err != nil {
 		return Report{}, Report{}, err
 	}
-	return resourceReport, outputReport, nil
+	return summary.ResourceReport, summary.OutputReport, nil
 }
 
 func (s *Service) createApplyReport(ctx context.Context, runID string) (Report, error) {
@@ -6,6 +6,15 @@ const (
 	SourceTerraform Source = "terraform+cloud"
 	SourceGithub    Source = "github"
 	SourceGitlab    Source = "gitlab"
+	// SourceVCS is the source of runs spawned by an event from a vcs kind
+	// without a dedicated source.
+	SourceVCS Source = "vcs"
+	// SourceRunTrigger is the source of runs queued by a run trigger
+	// following an apply in another workspace.
+	SourceRunTrigger Source = "tfe-run-trigger"
+	// SourceAssessment is the source of refresh-only runs queued by a health
+	// assessment.
+	SourceAssessment Source = "assessment"
 )
 
 // Source represents a source type of a run.
@@ -44,7 +44,7 @@ type (
 	}
 )
 
-func (s *Spawner) handle(event vcs.Event) {
+func (s *Spawner) handle(event vcs.Event) error {
 	// TODO: vcs.Event should implement slog.LogValue
 	logger := s.Logger.WithValues(
 		"sha", event.CommitSHA,
@@ -56,7 +56,9 @@ func (s *Spawner) handle(event vcs.Event) {
 
 	if err := s.handleWithError(logger, event); err != nil {
 		s.Error(err, "handling event")
+		return err
 	}
+	return nil
 }
 
 func (s *Spawner) handleWithError(logger logr.Logger, event vcs.Event) error {
@@ -82,6 +84,20 @@ func (s *Spawner) handleWithError(logger logr.Logger, event vcs.Event) error {
 		return nil
 	}
 
+	// some providers, e.g. bitbucket, don't include the repository's default
+	// branch in push events, in which case it is retrieved from the provider.
+	if event.Type == vcs.EventTypePush && event.DefaultBranch == "" {
+		client, err := s.vcs.GetVCSClient(ctx, event.VCSProviderID)
+		if err != nil {
+			return err
+		}
+		repo, err := client.GetRepository(ctx, event.RepoPath)
+		if err != nil {
+			return fmt.Errorf("retrieving repository default branch: %w", err)
+		}
+		event.DefaultBranch = repo.DefaultBranch
+	}
+
 	// filter out workspaces based on info contained in the event
 	n := 0
 	for _, ws := range workspaces {
@@ -125,6 +141,13 @@ func (s *Spawner) handleWithError(logger logr.Logger, event vcs.Event) error {
 					continue
 				}
 			}
+			// filter workspaces with ignore patterns that match all of the
+			// files in the event
+			if ws.IgnorePatterns != nil {
+				if globMatchAll(event.Paths, ws.IgnorePatterns) {
+					continue
+				}
+			}
 		}
 		workspaces[n] = ws
 		n++
@@ -153,10 +176,10 @@ func (s *Spawner) handleWithError(logger logr.Logger, event vcs.Event) error {
 	// call is necsssary to retrieve the list of changed files
 	if event.Type == vcs.EventTypePull {
 		// only perform API call if at least one workspace has file triggers
-		// enabled.
+		// or ignore patterns enabled.
 		var listFiles bool
 		for _, ws := range workspaces {
-			if ws.TriggerPatterns != nil {
+			if ws.TriggerPatterns != nil || ws.IgnorePatterns != nil {
 				listFiles = true
 				break
 			}
@@ -172,6 +195,10 @@ func (s *Spawner) handleWithError(logger logr.Logger, event vcs.Event) error {
 					// skip workspace
 					continue
 				}
+				if ws.IgnorePatterns != nil && globMatchAll(paths, ws.IgnorePatterns) {
+					// skip workspace
+					continue
+				}
 				workspaces[n] = ws
 				n++
 			}
@@ -212,23 +239,33 @@ func (s *Spawner) handleWithError(logger logr.Logger, event vcs.Event) error {
 		case vcs.GitlabKind:
 			cvOpts.Source = configversion.SourceGitlab
 			runOpts.Source = SourceGitlab
+		default:
+			cvOpts.Source = configversion.SourceVCS
+			runOpts.Source = SourceVCS
 		}
-		cv, err := s.configs.Create(ctx, ws.ID, cvOpts)
-		if err != nil {
-			return err
-		}
-		if err := s.configs.UploadConfig(ctx, cv.ID, tarball); err != nil {
-			return err
-		}
-		runOpts.ConfigurationVersionID = internal.String(cv.ID)
-		_, err = s.runs.Create(ctx, ws.ID, runOpts)
-		if err != nil {
-			return err
+		// a failure to spawn a run for a workspace is logged rather than
+		// returned: returning an error would see the event handled again,
+		// spawning duplicate runs for the other workspaces.
+		if err := s.spawn(ctx, ws.ID, tarball, cvOpts, runOpts); err != nil {
+			logger.Error(err, "spawning run", "workspace", ws.ID)
 		}
 	}
 	return nil
 }
 
+func (s *Spawner) spawn(ctx context.Context, workspaceID string, tarball []byte, cvOpts configversion.CreateOptions, runOpts CreateOptions) error {
+	cv, err := s.configs.Create(ctx, workspaceID, cvOpts)
+	if err != nil {
+		return err
+	}
+	if err := s.configs.UploadConfig(ctx, cv.ID, tarball); err != nil {
+		return err
+	}
+	runOpts.ConfigurationVersionID = internal.String(cv.ID)
+	_, err = s.runs.Create(ctx, workspaceID, runOpts)
+	return err
+}
+
 // globMatch returns true if any of the paths match any of the glob patterns.
 func globMatch(paths []string, patterns []string) bool {
 	if len(paths) == 0 || len(patterns) == 0 {
@@ -245,3 +282,17 @@ func globMatch(paths []string, patterns []string) bool {
 	}
 	return false
 }
+
+// globMatchAll returns true if every path matches at least one of the
+// patterns.
+func globMatchAll(paths []string, patterns []string) bool {
+	if len(paths) == 0 || len(patterns) == 0 {
+		return false
+	}
+	for _, path := range paths {
+		if !globMatch([]string{path}, patterns) {
+			return false
+		}
+	}
+	return true
+}
@@ -190,6 +190,67 @@ func TestSpawner(t *testing.T) {
 			pullFiles: []string{"README.md", ".gitignore"},
 			spawn:     false,
 		},
+		{
+			name: "skip run for push event for workspace with ignore patterns matching all files",
+			ws: &workspace.Workspace{
+				IgnorePatterns: []string{"*.md", "/docs/**"},
+				Connection:     &workspace.Connection{},
+			},
+			event: vcs.Event{
+				EventPayload: vcs.EventPayload{
+					Type:   vcs.EventTypePush,
+					Action: vcs.ActionCreated,
+					Paths:  []string{"README.md", "/docs/index.html"},
+				},
+			},
+			spawn: false,
+		},
+		{
+			name: "spawn run for push event for workspace with ignore patterns not matching all files",
+			ws: &workspace.Workspace{
+				IgnorePatterns: []string{"*.md", "/docs/**"},
+				Connection:     &workspace.Connection{},
+			},
+			event: vcs.Event{
+				EventPayload: vcs.EventPayload{
+					Type:   vcs.EventTypePush,
+					Action: vcs.ActionCreated,
+					Paths:  []string{"README.md", "/foo/bar.tf"},
+				},
+			},
+			spawn: true,
+		},
+		{
+			name: "skip run for push event for workspace with matching trigger pattern but ignored files",
+			ws: &workspace.Workspace{
+				TriggerPatterns: []string{"/foo/**"},
+				IgnorePatterns:  []string{"*.md"},
+				Connection:      &workspace.Connection{},
+			},
+			event: vcs.Event{
+				EventPayload: vcs.EventPayload{
+					Type:   vcs.EventTypePush,
+					Action: vcs.ActionCreated,
+					Paths:  []string{"/foo/README.md"},
+				},
+			},
+			spawn: false,
+		},
+		{
+			name: "skip run for pull event for workspace with ignore patterns matching all files",
+			ws: &workspace.Workspace{
+				IgnorePatterns: []string{"*.md"},
+				Connection:     &workspace.Connection{},
+			},
+			event: vcs.Event{
+				EventPayload: vcs.EventPayload{
+					Type:   vcs.EventTypePull,
+					Action: vcs.ActionUpdated,
+				},
+			},
+			pullFiles: []string{"README.md", "CHANGELOG.md"},
+			spawn:     false,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -212,28 +273,139 @@ func TestSpawner(t *testing.T) {
 	}
 }
 
+func TestSpawner_Source(t *testing.T) {
+	tests := []struct {
+		name     string
+		kind     vcs.Kind
+		cvSource configversion.Source
+		source   Source
+	}{
+		{"github", vcs.GithubKind, configversion.SourceGithub, SourceGithub},
+		{"gitlab", vcs.GitlabKind, configversion.SourceGitlab, SourceGitlab},
+		{"other", "", configversion.SourceVCS, SourceVCS},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			configs := &fakeSpawnerConfigClient{}
+			runClient := &fakeSpawnerRunClient{}
+			spawner := Spawner{
+				configs: configs,
+				workspaces: &workspace.FakeService{
+					Workspaces: []*workspace.Workspace{{Connection: &workspace.Connection{Repo: "leg100/otf"}}},
+				},
+				runs: runClient,
+				vcs:  &fakeSpawnerVCSProviderClient{},
+			}
+			err := spawner.handleWithError(logr.Discard(), vcs.Event{
+				EventPayload: vcs.EventPayload{
+					VCSKind:       tt.kind,
+					Type:          vcs.EventTypePush,
+					Action:        vcs.ActionCreated,
+					Branch:        "main",
+					DefaultBranch: "main",
+					CommitSHA:     "abc123",
+					CommitURL:     "https://example.com/leg100/otf/commit/abc123",
+				},
+			})
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.cvSource, configs.opts.Source)
+			assert.Equal(t, tt.source, runClient.source)
+			if assert.NotNil(t, configs.opts.IngressAttributes) {
+				assert.Equal(t, "abc123", configs.opts.IngressAttributes.CommitSHA)
+				assert.Equal(t, "https://example.com/leg100/otf/commit/abc123", configs.opts.IngressAttributes.CommitURL)
+				assert.Equal(t, "leg100/otf", configs.opts.IngressAttributes.Repo)
+				assert.True(t, configs.opts.IngressAttributes.OnDefaultBranch)
+			}
+		})
+	}
+}
+
+// TestSpawner_DefaultBranch tests retrieving the default branch from the
+// provider for push events that don't include it.
+func TestSpawner_DefaultBranch(t *testing.T) {
+	tests := []struct {
+		name   string
+		branch string
+		spawn  bool
+	}{
+		{"push to default branch", "main", true},
+		{"push to non-default branch", "dev", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			configs := &fakeSpawnerConfigClient{}
+			runClient := &fakeSpawnerRunClient{}
+			spawner := Spawner{
+				configs: configs,
+				workspaces: &workspace.FakeService{
+					Workspaces: []*workspace.Workspace{{Connection: &workspace.Connection{Repo: "leg100/otf"}}},
+				},
+				runs: runClient,
+				vcs:  &fakeSpawnerVCSProviderClient{defaultBranch: "main"},
+			}
+			err := spawner.handleWithError(logr.Discard(), vcs.Event{
+				EventPayload: vcs.EventPayload{
+					VCSKind:  vcs.BitbucketKind,
+					RepoPath: "leg100/otf",
+					Type:     vcs.EventTypePush,
+					Action:   vcs.ActionCreated,
+					Branch:   tt.branch,
+				},
+			})
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.spawn, runClient.spawned)
+			if tt.spawn {
+				assert.True(t, configs.opts.IngressAttributes.OnDefaultBranch)
+			}
+		})
+	}
+}
+
+type fakeSpawnerConfigClient struct {
+	configversion.FakeService
+	// options with which config version was created
+	opts configversion.CreateOptions
+}
+
+func (f *fakeSpawnerConfigClient) Create(_ context.Context, _ string, opts configversion.CreateOptions) (*configversion.ConfigurationVersion, error) {
+	f.opts = opts
+	return &configversion.ConfigurationVersion{ID: "created"}, nil
+}
+
 type fakeSpawnerRunClient struct {
 	// whether a run was spawned
 	spawned bool
+	// source of spawned run
+	source Source
 }
 
-func (f *fakeSpawnerRunClient) Create(context.Context, string, CreateOptions) (*Run, error) {
+func (f *fakeSpawnerRunClient) Create(_ context.Context, _ string, opts CreateOptions) (*Run, error) {
 	f.spawned = true
+	f.source = opts.Source
 	return nil, nil
 }
 
 type fakeSpawnerVCSProviderClient struct {
 	// list of file paths to return from stubbed ListPullRequestFiles()
 	pullFiles []string
+	// default branch to return from stubbed GetRepository()
+	defaultBranch string
 }
 
 func (f *fakeSpawnerVCSProviderClient) GetVCSClient(context.Context, string) (vcs.Client, error) {
-	return &fakeSpawnerCloudClient{pullFiles: f.pullFiles}, nil
+	return &fakeSpawnerCloudClient{pullFiles: f.pullFiles, defaultBranch: f.defaultBranch}, nil
 }
 
 type fakeSpawnerCloudClient struct {
 	vcs.Client
-	pullFiles []string
+	pullFiles     []string
+	defaultBranch string
+}
+
+func (f *fakeSpawnerCloudClient) GetRepository(_ context.Context, repo string) (vcs.Repository, error) {
+	return vcs.Repository{Path: repo, DefaultBranch: f.defaultBranch}, nil
 }
 
 func (f *fakeSpawnerCloudClient) GetRepoTarball(context.Context, vcs.GetRepoTarballOptions) ([]byte, string, error) {
@@ -20,6 +20,12 @@ const (
 	RunPlanned            Status = "planned"
 	RunPlannedAndFinished Status = "planned_and_finished"
 	RunPlanning           Status = "planning"
+	RunPrePlanRunning     Status = "pre_plan_running"
+	RunPostPlanRunning    Status = "post_plan_running"
+	RunPolicyChecking     Status = "policy_checking"
+	RunPolicyChecked      Status = "policy_checked"
+	RunPolicyOverride     Status = "policy_override"
+	RunPolicySoftFailed   Status = "policy_soft_failed"
 
 	// OTF doesn't support cost estimation but go-tfe API tests expect this
 	// status so it is included expressly to pass the tests.
@@ -53,6 +59,11 @@ var (
 		RunPlanQueued,
 		RunPlanned,
 		RunPlanning,
+		RunPrePlanRunning,
+		RunPostPlanRunning,
+		RunPolicyChecking,
+		RunPolicyChecked,
+		RunPolicyOverride,
 	}
 	IncompleteRun = append(ActiveRun, RunPending)
 )
@@ -0,0 +1,150 @@
+package run
+
+import (
+	"context"
+	"errors"
+
+	"github.com/leg100/otf/internal/rbac"
+	"github.com/leg100/otf/internal/sql/pggen"
+)
+
+// TaskStage is a stage of a run at which run tasks are executed.
+type TaskStage string
+
+const (
+	// PrePlanStage is the stage after a run is scheduled but before its plan
+	// is enqueued.
+	PrePlanStage TaskStage = "pre_plan"
+	// PostPlanStage is the stage after a run's plan has finished but before
+	// it can be applied.
+	PostPlanStage TaskStage = "post_plan"
+)
+
+// startTaskStage updates the run to reflect run tasks having started at the
+// given stage. The run waits at the stage until the tasks complete.
+func (r *Run) startTaskStage(stage TaskStage) error {
+	switch stage {
+	case PrePlanStage:
+		if r.Status != RunPending {
+			return ErrInvalidRunStateTransition
+		}
+		r.updateStatus(RunPrePlanRunning, nil)
+	case PostPlanStage:
+		if r.Status != RunPlanning {
+			return ErrInvalidRunStateTransition
+		}
+		r.updateStatus(RunPostPlanRunning, nil)
+		r.Plan.UpdateStatus(PhaseFinished)
+	default:
+		return errors.New("unknown task stage")
+	}
+	return nil
+}
+
+// completeTaskStage updates the run to reflect the run tasks at the given
+// stage having completed. If the tasks passed then the run proceeds to the
+// next stage; otherwise the run is errored. If the post-plan stage has
+// completed and an apply should be automatically enqueued then autoapply is
+// set to true.
+func (r *Run) completeTaskStage(stage TaskStage, passed bool) (autoapply bool, err error) {
+	switch stage {
+	case PrePlanStage:
+		if r.Status != RunPrePlanRunning {
+			return false, ErrInvalidRunStateTransition
+		}
+		if !passed {
+			r.updateStatus(RunErrored, nil)
+			r.Plan.UpdateStatus(PhaseUnreachable)
+			r.Apply.UpdateStatus(PhaseUnreachable)
+			return false, nil
+		}
+		r.updateStatus(RunPlanQueued, nil)
+		r.Plan.UpdateStatus(PhaseQueued)
+		return false, nil
+	case PostPlanStage:
+		if r.Status != RunPostPlanRunning {
+			return false, ErrInvalidRunStateTransition
+		}
+		if !passed {
+			r.updateStatus(RunErrored, nil)
+			r.Apply.UpdateStatus(PhaseUnreachable)
+			return false, nil
+		}
+		return r.finishPlan(), nil
+	default:
+		return false, errors.New("unknown task stage")
+	}
+}
+
+// OnTaskStage registers a hook that is invoked when a run reaches a stage at
+// which run tasks are executed. The hook returns true if it has started tasks,
+// in which case the run waits at the stage until CompleteTaskStage is invoked.
+func (s *Service) OnTaskStage(hook func(context.Context, *Run, TaskStage) (bool, error)) {
+	s.taskStageHooks = append(s.taskStageHooks, hook)
+}
+
+// startTaskStage invokes the task stage hooks for the run, and if any hook has
+// started tasks then the run is updated to wait at the stage. Returns true if
+// tasks have been started.
+func (s *Service) startTaskStage(ctx context.Context, run *Run, stage TaskStage) (bool, error) {
+	var started bool
+	for _, hook := range s.taskStageHooks {
+		ok, err := hook(ctx, run, stage)
+		if err != nil {
+			return false, err
+		}
+		started = started || ok
+	}
+	if !started {
+		return false, nil
+	}
+	return true, run.startTaskStage(stage)
+}
+
+// CompleteTaskStage completes the run tasks stage of a run. If the tasks
+// passed then the run proceeds: a plan is enqueued following the pre-plan
+// stage, and the plan is finished following the post-plan stage. Otherwise the
+// run is errored.
+//
+// NOTE: this is an internal action, invoked by the run tasks service only.
+func (s *Service) CompleteTaskStage(ctx context.Context, runID string, stage TaskStage, passed bool) (*Run, error) {
+	var run *Run
+	err := s.db.Tx(ctx, func(ctx context.Context, q pggen.Querier) error {
+		subject, err := s.CanAccess(ctx, rbac.EnqueuePlanAction, runID)
+		if err != nil {
+			return err
+		}
+		var autoapply bool
+		run, err = s.db.UpdateStatus(ctx, runID, func(run *Run) (err error) {
+			if stage == PostPlanStage && passed && run.Status == RunPostPlanRunning {
+				// check policies, if any, before finishing the plan
+				if started, err := s.startPolicyCheck(ctx, run); err != nil || started {
+					return err
+				}
+			}
+			autoapply, err = run.completeTaskStage(stage, passed)
+			return err
+		})
+		if err != nil {
+			return err
+		}
+		s.V(0).Info("completed task stage", "id", runID, "stage", stage, "passed", passed, "run_status", run.Status, "subject", subject)
+		if run.Status == RunPlanQueued {
+			// invoke AfterEnqueuePlan hooks
+			for _, hook := range s.afterEnqueuePlanHooks {
+				if err := hook(ctx, run); err != nil {
+					return err
+				}
+			}
+		}
+		if autoapply {
+			return s.autoApply(ctx, runID)
+		}
+		return nil
+	})
+	if err != nil {
+		s.Error(err, "completing task stage", "id", runID, "stage", stage)
+		return nil, err
+	}
+	return run, nil
+}
@@ -0,0 +1,70 @@
+package run
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRun_TaskStages(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("pre-plan tasks passed", func(t *testing.T) {
+		run := newTestRun(ctx, CreateOptions{})
+
+		require.NoError(t, run.startTaskStage(PrePlanStage))
+		require.Equal(t, RunPrePlanRunning, run.Status)
+
+		_, err := run.completeTaskStage(PrePlanStage, true)
+		require.NoError(t, err)
+		assert.Equal(t, RunPlanQueued, run.Status)
+		assert.Equal(t, PhaseQueued, run.Plan.Status)
+	})
+
+	t.Run("pre-plan tasks failed", func(t *testing.T) {
+		run := newTestRun(ctx, CreateOptions{})
+
+		require.NoError(t, run.startTaskStage(PrePlanStage))
+		_, err := run.completeTaskStage(PrePlanStage, false)
+		require.NoError(t, err)
+		assert.Equal(t, RunErrored, run.Status)
+		assert.Equal(t, PhaseUnreachable, run.Plan.Status)
+	})
+
+	t.Run("post-plan tasks passed", func(t *testing.T) {
+		run := newTestRun(ctx, CreateOptions{})
+		run.Status = RunPlanning
+		run.Plan.Status = PhaseRunning
+
+		require.NoError(t, run.startTaskStage(PostPlanStage))
+		require.Equal(t, RunPostPlanRunning, run.Status)
+		require.Equal(t, PhaseFinished, run.Plan.Status)
+
+		_, err := run.completeTaskStage(PostPlanStage, true)
+		require.NoError(t, err)
+		assert.Equal(t, RunPlannedAndFinished, run.Status)
+	})
+
+	t.Run("post-plan tasks failed", func(t *testing.T) {
+		run := newTestRun(ctx, CreateOptions{})
+		run.Status = RunPlanning
+		run.Plan.Status = PhaseRunning
+
+		require.NoError(t, run.startTaskStage(PostPlanStage))
+		_, err := run.completeTaskStage(PostPlanStage, false)
+		require.NoError(t, err)
+		assert.Equal(t, RunErrored, run.Status)
+		assert.Equal(t, PhaseUnreachable, run.Apply.Status)
+	})
+
+	t.Run("canceled run cannot complete stage", func(t *testing.T) {
+		run := newTestRun(ctx, CreateOptions{})
+
+		require.NoError(t, run.startTaskStage(PrePlanStage))
+		require.NoError(t, run.Cancel(true))
+		_, err := run.completeTaskStage(PrePlanStage, true)
+		assert.ErrorIs(t, err, ErrInvalidRunStateTransition)
+	})
+}
@@ -80,6 +80,18 @@ func (f *fakeWebServices) getLogs(context.Context, string, internal.PhaseType) (
 
 func (f *fakeWebServices) Cancel(context.Context, string) error { return nil }
 
+func (f *fakeWebServices) ListDiagnostics(context.Context, string) ([]Diagnostic, error) {
+	return nil, nil
+}
+
+func (f *fakeWebServices) ListApplyFailures(context.Context, string) ([]ApplyFailure, error) {
+	return nil, nil
+}
+
+func (f *fakeWebServices) GetProtectionCheck(context.Context, string) (*ProtectionCheck, error) {
+	return &ProtectionCheck{}, nil
+}
+
 func (f *fakeWebServices) Get(ctx context.Context, runID string) (*Run, error) {
 	return f.runs[0], nil
 }
@@ -87,3 +99,15 @@ func (f *fakeWebServices) Get(ctx context.Context, runID string) (*Run, error) {
 func (f *fakeWebServices) Apply(ctx context.Context, runID string) error {
 	return nil
 }
+
+func (f *fakeWebServices) checkPlanState(context.Context, *Run) error {
+	return nil
+}
+
+func (f *fakeWebServices) Replan(ctx context.Context, runID string) (*Run, error) {
+	return f.runs[0], nil
+}
+
+func (f *fakeWebServices) RetryApply(ctx context.Context, runID string) (*Run, error) {
+	return f.runs[0], nil
+}
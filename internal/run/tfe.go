@@ -2,6 +2,7 @@ package run
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/http"
 	"slices"
@@ -16,17 +17,23 @@ import (
 	"github.com/leg100/otf/internal/tfeapi"
 	"github.com/leg100/otf/internal/tfeapi/types"
 	"github.com/leg100/otf/internal/workspace"
+	"github.com/leg100/surl"
 )
 
 type tfe struct {
 	*Service
-	internal.Signer
+	*surl.Signer
 	*tfeapi.Responder
 
 	workspaces *workspace.Service
 }
 
 func (a *tfe) addHandlers(r *mux.Router) {
+	// Plan JSON download is *not* rooted at /api/v2
+	signed := r.PathPrefix("/signed/{signature.expiry}").Subrouter()
+	signed.Use(internal.VerifySignedURL(a.Signer))
+	signed.HandleFunc("/plans/{plan_id}/json-output", a.downloadPlanJSON).Methods("GET")
+
 	r = r.PathPrefix(tfeapi.APIPrefixV2).Subrouter()
 
 	// Run routes
@@ -43,6 +50,7 @@ func (a *tfe) addHandlers(r *mux.Router) {
 	// Plan routes
 	r.HandleFunc("/plans/{plan_id}", a.getPlan).Methods("GET")
 	r.HandleFunc("/plans/{plan_id}/json-output", a.getPlanJSON).Methods("GET")
+	r.HandleFunc("/runs/{id}/plan/json-output", a.getRunPlanJSON).Methods("GET")
 
 	// Apply routes
 	r.HandleFunc("/applies/{apply_id}", a.getApply).Methods("GET")
@@ -164,17 +172,20 @@ func (a *tfe) listRunsWithOptions(w http.ResponseWriter, r *http.Request, opts L
 		return
 	}
 
-	// convert items
-	items := make([]*types.Run, len(page.Items))
-	for i, from := range page.Items {
-		to, err := a.toRun(from, r.Context())
-		if err != nil {
-			tfeapi.Error(w, err)
-			return
+	// convert and write items one at a time, bounding memory for large
+	// listings
+	a.RespondWithStream(w, r, func(emit func(any) error) (*resource.Pagination, error) {
+		for _, from := range page.Items {
+			to, err := a.toRun(from, r.Context())
+			if err != nil {
+				return nil, err
+			}
+			if err := emit(to); err != nil {
+				return nil, err
+			}
 		}
-		items[i] = to
-	}
-	a.RespondWithPage(w, r, items, page.Pagination)
+		return page.Pagination, nil
+	})
 }
 
 func (a *tfe) applyRun(w http.ResponseWriter, r *http.Request) {
@@ -185,6 +196,9 @@ func (a *tfe) applyRun(w http.ResponseWriter, r *http.Request) {
 	}
 
 	if err := a.Apply(r.Context(), id); err != nil {
+		if errors.Is(err, ErrProtectionRulesViolated) || errors.Is(err, ErrStalePlan) {
+			err = &internal.HTTPError{Code: http.StatusConflict, Message: err.Error()}
+		}
 		tfeapi.Error(w, err)
 		return
 	}
@@ -248,7 +262,7 @@ func (a *tfe) getPlan(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// otf's plan IDs are simply the corresponding run ID
-	run, err := a.Get(r.Context(), internal.ConvertID(id, "run"))
+	run, err := a.Get(r.Context(), resource.ConvertID(id, resource.RunKind))
 	if err != nil {
 		tfeapi.Error(w, err)
 		return
@@ -263,7 +277,9 @@ func (a *tfe) getPlan(w http.ResponseWriter, r *http.Request) {
 	a.Respond(w, r, plan, http.StatusOK)
 }
 
-// getPlanJSON retrieves a plan object's plan file in JSON format.
+// getPlanJSON checks the caller has access to the plan and then redirects
+// them to a signed, expiring URL from which the plan file in JSON format can
+// be downloaded.
 //
 // https://www.terraform.io/cloud-docs/api-docs/plans#retrieve-the-json-execution-plan
 func (a *tfe) getPlanJSON(w http.ResponseWriter, r *http.Request) {
@@ -272,13 +288,55 @@ func (a *tfe) getPlanJSON(w http.ResponseWriter, r *http.Request) {
 		tfeapi.Error(w, err)
 		return
 	}
-
 	// otf's plan IDs are simply the corresponding run ID
-	json, err := a.GetPlanFile(r.Context(), internal.ConvertID(id, "run"), PlanFormatJSON)
+	a.redirectToPlanJSON(w, r, resource.ConvertID(id, resource.RunKind))
+}
+
+// getRunPlanJSON is the same as getPlanJSON but is addressed by run ID
+// instead.
+//
+// https://developer.hashicorp.com/terraform/cloud-docs/api-docs/plans#retrieve-the-json-execution-plan
+func (a *tfe) getRunPlanJSON(w http.ResponseWriter, r *http.Request) {
+	id, err := decode.Param("id", r)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	a.redirectToPlanJSON(w, r, id)
+}
+
+func (a *tfe) redirectToPlanJSON(w http.ResponseWriter, r *http.Request, runID string) {
+	if _, err := a.CanAccess(r.Context(), rbac.GetPlanFileAction, runID); err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	planID := resource.ConvertID(runID, resource.PlanKind)
+	url, err := a.Sign(fmt.Sprintf("/plans/%s/json-output", planID), time.Hour)
 	if err != nil {
 		tfeapi.Error(w, err)
 		return
 	}
+	http.Redirect(w, r, otfhttp.Absolute(r, url), http.StatusTemporaryRedirect)
+}
+
+// downloadPlanJSON sends the plan file in JSON format.
+//
+// NOTE: unauthenticated - access granted only via signed URL
+func (a *tfe) downloadPlanJSON(w http.ResponseWriter, r *http.Request) {
+	id, err := decode.Param("plan_id", r)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+
+	ctx := internal.AddSubjectToContext(r.Context(), &internal.Superuser{Username: "plan-downloader"})
+
+	json, err := a.GetPlanFile(ctx, resource.ConvertID(id, resource.RunKind), PlanFormatJSON)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
 	if _, err := w.Write(json); err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
@@ -293,7 +351,7 @@ func (a *tfe) getApply(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// otf's apply IDs are simply the corresponding run ID
-	run, err := a.Get(r.Context(), internal.ConvertID(id, "run"))
+	run, err := a.Get(r.Context(), resource.ConvertID(id, resource.RunKind))
 	if err != nil {
 		tfeapi.Error(w, err)
 		return
@@ -376,6 +434,12 @@ func (a *tfe) toRun(from *Run, ctx context.Context) (*types.Run, error) {
 			timestamps.PlannedAt = &rst.Timestamp
 		case RunPlannedAndFinished:
 			timestamps.PlannedAndFinishedAt = &rst.Timestamp
+		case RunPolicyChecked:
+			timestamps.PolicyCheckedAt = &rst.Timestamp
+		case RunPolicySoftFailed:
+			timestamps.PolicySoftFailedAt = &rst.Timestamp
+		case RunConfirmed:
+			timestamps.ConfirmedAt = &rst.Timestamp
 		case RunApplyQueued:
 			timestamps.ApplyQueuedAt = &rst.Timestamp
 		case RunApplying:
@@ -401,27 +465,29 @@ func (a *tfe) toRun(from *Run, ctx context.Context) (*types.Run, error) {
 			IsForceCancelable: from.CancelSignaledAt != nil,
 			IsDiscardable:     from.Discardable(),
 		},
-		AllowEmptyApply:  from.AllowEmptyApply,
-		AutoApply:        from.AutoApply,
-		CreatedAt:        from.CreatedAt,
-		ExecutionMode:    string(from.ExecutionMode),
-		HasChanges:       from.Plan.HasChanges(),
-		IsDestroy:        from.IsDestroy,
-		Message:          from.Message,
-		Permissions:      perms,
-		PlanOnly:         from.PlanOnly,
-		PositionInQueue:  0,
-		Refresh:          from.Refresh,
-		RefreshOnly:      from.RefreshOnly,
-		ReplaceAddrs:     from.ReplaceAddrs,
-		Source:           string(from.Source),
-		Status:           string(from.Status),
-		StatusTimestamps: &timestamps,
-		TargetAddrs:      from.TargetAddrs,
-		TerraformVersion: from.TerraformVersion,
+		AllowEmptyApply:    from.AllowEmptyApply,
+		AutoApply:          from.AutoApply,
+		CreatedAt:          from.CreatedAt,
+		ExecutionMode:      string(from.ExecutionMode),
+		HasChanges:         from.Plan.HasChanges(),
+		IsDestroy:          from.IsDestroy,
+		Message:            from.Message,
+		Permissions:        perms,
+		PlanOnly:           from.PlanOnly,
+		PositionInQueue:    from.PositionInQueue,
+		PositionInGroup:    from.PositionInGroup,
+		Refresh:            from.Refresh,
+		RefreshOnly:        from.RefreshOnly,
+		ReplaceAddrs:       from.ReplaceAddrs,
+		SerializationGroup: from.SerializationGroup,
+		Source:             string(from.Source),
+		Status:             string(from.Status),
+		StatusTimestamps:   &timestamps,
+		TargetAddrs:        from.TargetAddrs,
+		TerraformVersion:   from.TerraformVersion,
 		// Relations
-		Plan:  &types.Plan{ID: internal.ConvertID(from.ID, "plan")},
-		Apply: &types.Apply{ID: internal.ConvertID(from.ID, "apply")},
+		Plan:  &types.Plan{ID: resource.ConvertID(from.ID, resource.PlanKind)},
+		Apply: &types.Apply{ID: resource.ConvertID(from.ID, resource.ApplyKind)},
 		// TODO: populate with real user.
 		CreatedBy: &types.User{
 			ID:       "user-123",
@@ -436,8 +502,31 @@ func (a *tfe) toRun(from *Run, ctx context.Context) (*types.Run, error) {
 	for i, from := range from.Variables {
 		to.Variables[i] = types.RunVariable{Key: from.Key, Value: from.Value}
 	}
+	if from.Status == RunErrored {
+		// only errored runs have diagnostics
+		diags, err := a.db.listDiagnostics(ctx, from.ID)
+		if err != nil {
+			return nil, err
+		}
+		to.Diagnostics = make([]types.RunDiagnostic, len(diags))
+		for i, from := range diags {
+			to.Diagnostics[i] = types.RunDiagnostic{
+				Phase:    string(from.Phase),
+				Severity: string(from.Severity),
+				Summary:  from.Summary,
+				Detail:   from.Detail,
+				Address:  from.Address,
+			}
+			if from.Range != nil {
+				to.Diagnostics[i].Range = &types.RunDiagnosticRange{
+					Filename: from.Range.Filename,
+					Line:     from.Range.Line,
+				}
+			}
+		}
+	}
 	if from.CostEstimationEnabled {
-		to.CostEstimate = &types.CostEstimate{ID: internal.ConvertID(from.ID, "ce")}
+		to.CostEstimate = &types.CostEstimate{ID: resource.ConvertID(from.ID, resource.CostEstimateKind)}
 	}
 	//
 	// go-tfe integration tests expect this parameter to be set even if a run
@@ -448,11 +537,11 @@ func (a *tfe) toRun(from *Run, ctx context.Context) (*types.Run, error) {
 	// circumstances.
 	if timestamps.CanceledAt != nil {
 		// run successfully canceled
-		cooledOff := timestamps.CanceledAt.Add(forceCancelCoolOff)
+		cooledOff := timestamps.CanceledAt.Add(a.forceCancelCoolOff)
 		to.ForceCancelAvailableAt = &cooledOff
 	} else if from.CancelSignaledAt != nil {
 		// run not successfully canceled yet
-		cooledOff := from.CancelSignaledAt.Add(forceCancelCoolOff)
+		cooledOff := from.CancelSignaledAt.Add(a.forceCancelCoolOff)
 		to.ForceCancelAvailableAt = &cooledOff
 	}
 	return to, nil
@@ -465,7 +554,7 @@ func (a *tfe) toPlan(plan Phase, r *http.Request) (*types.Plan, error) {
 	}
 
 	return &types.Plan{
-		ID:               internal.ConvertID(plan.RunID, "plan"),
+		ID:               resource.ConvertID(plan.RunID, resource.PlanKind),
 		HasChanges:       plan.HasChanges(),
 		LogReadURL:       logURL,
 		ResourceReport:   a.toResourceReport(plan.ResourceReport),
@@ -481,7 +570,7 @@ func (a *tfe) toApply(apply Phase, r *http.Request) (*types.Apply, error) {
 	}
 
 	return &types.Apply{
-		ID:               internal.ConvertID(apply.RunID, "apply"),
+		ID:               resource.ConvertID(apply.RunID, resource.ApplyKind),
 		LogReadURL:       logURL,
 		ResourceReport:   a.toResourceReport(apply.ResourceReport),
 		Status:           string(apply.Status),
@@ -0,0 +1,53 @@
+package run
+
+import "time"
+
+// Timings are the durations a run has spent in each stage of its lifecycle. A
+// stage the run is yet to enter, or has skipped, has a zero duration. Stages
+// entered more than once, e.g. a retried apply, are summed.
+type Timings struct {
+	// Queued is the time spent pending, waiting for earlier runs on the
+	// workspace to finish.
+	Queued time.Duration `json:"queued"`
+	// WaitingForAgent is the time spent with a plan or apply enqueued,
+	// waiting for an agent to start it.
+	WaitingForAgent time.Duration `json:"waiting_for_agent"`
+	// Plan is the time spent planning.
+	Plan time.Duration `json:"plan"`
+	// WaitingForConfirmation is the time spent waiting for a planned run to
+	// be confirmed.
+	WaitingForConfirmation time.Duration `json:"waiting_for_confirmation"`
+	// Apply is the time spent applying.
+	Apply time.Duration `json:"apply"`
+}
+
+// Timings reports the durations the run has spent in each stage thus far. If
+// the run is still in progress then the current stage is measured up until
+// now.
+func (r *Run) Timings(now time.Time) (timings Timings) {
+	for i, current := range r.StatusTimestamps {
+		var end time.Time
+		if i+1 < len(r.StatusTimestamps) {
+			end = r.StatusTimestamps[i+1].Timestamp
+		} else if r.Done() {
+			// completed statuses are an instant not a period of time.
+			break
+		} else {
+			end = now
+		}
+		duration := end.Sub(current.Timestamp)
+		switch current.Status {
+		case RunPending:
+			timings.Queued += duration
+		case RunPlanQueued, RunApplyQueued:
+			timings.WaitingForAgent += duration
+		case RunPlanning:
+			timings.Plan += duration
+		case RunPlanned, RunCostEstimated:
+			timings.WaitingForConfirmation += duration
+		case RunApplying:
+			timings.Apply += duration
+		}
+	}
+	return timings
+}
@@ -3,7 +3,9 @@ package run
 import (
 	"bytes"
 	"context"
+	"errors"
 	"net/http"
+	"time"
 
 	"github.com/go-logr/logr"
 	"github.com/gorilla/mux"
@@ -24,6 +26,17 @@ type (
 		logger     logr.Logger
 		runs       webRunClient
 		workspaces webWorkspaceClient
+
+		// period following a cancelation before a run can be force canceled
+		forceCancelCoolOff time.Duration
+	}
+
+	// runItem is a run rendered as a widget.
+	runItem struct {
+		*Run
+		// ForceCancelable is true if the run can be forceably canceled, i.e.
+		// the cool-off period following its cancelation has elapsed.
+		ForceCancelable bool
 	}
 
 	webRunClient interface {
@@ -35,8 +48,14 @@ type (
 		ForceCancel(ctx context.Context, runID string) error
 		Apply(ctx context.Context, runID string) error
 		Discard(ctx context.Context, runID string) error
+		ListDiagnostics(ctx context.Context, runID string) ([]Diagnostic, error)
+		ListApplyFailures(ctx context.Context, runID string) ([]ApplyFailure, error)
+		GetProtectionCheck(ctx context.Context, runID string) (*ProtectionCheck, error)
+		Replan(ctx context.Context, runID string) (*Run, error)
+		RetryApply(ctx context.Context, runID string) (*Run, error)
 
 		getLogs(ctx context.Context, runID string, phase internal.PhaseType) ([]byte, error)
+		checkPlanState(ctx context.Context, run *Run) error
 		watchWithOptions(ctx context.Context, opts WatchOptions) (<-chan pubsub.Event[*Run], error)
 	}
 
@@ -59,6 +78,8 @@ func (h *webHandlers) addHandlers(r *mux.Router) {
 	r.HandleFunc("/runs/{run_id}/apply", h.apply).Methods("POST")
 	r.HandleFunc("/runs/{run_id}/discard", h.discard).Methods("POST")
 	r.HandleFunc("/runs/{run_id}/retry", h.retry).Methods("POST")
+	r.HandleFunc("/runs/{run_id}/retry-apply", h.retryApply).Methods("POST")
+	r.HandleFunc("/runs/{run_id}/replan", h.replan).Methods("POST")
 	r.HandleFunc("/workspaces/{workspace_id}/watch", h.watch).Methods("GET")
 
 	// this handles the link the terraform CLI shows during a plan/apply.
@@ -126,13 +147,17 @@ func (h *webHandlers) list(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	items := make([]runItem, len(runs.Items))
+	for i, run := range runs.Items {
+		items[i] = h.newRunItem(run)
+	}
 	response := struct {
 		workspace.WorkspacePage
-		*resource.Page[*Run]
+		*resource.Page[runItem]
 		CanUpdateWorkspace bool
 	}{
 		WorkspacePage:      workspace.NewPage(r, "runs", ws),
-		Page:               runs,
+		Page:               &resource.Page[runItem]{Items: items, Pagination: runs.Pagination},
 		CanUpdateWorkspace: user.CanAccessWorkspace(rbac.UpdateWorkspaceAction, policy),
 	}
 
@@ -176,16 +201,56 @@ func (h *webHandlers) get(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	diagnostics, err := h.runs.ListDiagnostics(r.Context(), run.ID)
+	if err != nil {
+		h.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	applyFailures, err := h.runs.ListApplyFailures(r.Context(), run.ID)
+	if err != nil {
+		h.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	protection, err := h.runs.GetProtectionCheck(r.Context(), run.ID)
+	if err != nil {
+		h.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	// a plan awaiting confirmation is stale if the workspace state has
+	// changed since it was planned.
+	var stalePlan error
+	if run.Confirmable() {
+		if err := h.runs.checkPlanState(r.Context(), run); errors.Is(err, ErrStalePlan) {
+			stalePlan = err
+		} else if err != nil {
+			h.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+	}
+
 	h.Render("run_get.tmpl", w, struct {
 		workspace.WorkspacePage
-		Run       *Run
-		PlanLogs  internal.Chunk
-		ApplyLogs internal.Chunk
+		Run           *Run
+		RunItem       runItem
+		PlanLogs      internal.Chunk
+		ApplyLogs     internal.Chunk
+		Diagnostics   []Diagnostic
+		ApplyFailures []ApplyFailure
+		Protection    *ProtectionCheck
+		StalePlan     error
 	}{
 		WorkspacePage: workspace.NewPage(r, run.ID, ws),
 		Run:           run,
+		RunItem:       h.newRunItem(run),
 		PlanLogs:      internal.Chunk{Data: planLogs},
 		ApplyLogs:     internal.Chunk{Data: applyLogs},
+		Diagnostics:   diagnostics,
+		ApplyFailures: applyFailures,
+		Protection:    protection,
+		StalePlan:     stalePlan,
 	})
 }
 
@@ -204,11 +269,18 @@ func (h *webHandlers) getWidget(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if err := h.RenderTemplate("run_item.tmpl", w, run); err != nil {
+	if err := h.RenderTemplate("run_item.tmpl", w, h.newRunItem(run)); err != nil {
 		h.Error(w, err.Error(), http.StatusInternalServerError)
 	}
 }
 
+func (h *webHandlers) newRunItem(run *Run) runItem {
+	return runItem{
+		Run:             run,
+		ForceCancelable: run.ForceCancelable(h.forceCancelCoolOff),
+	}
+}
+
 func (h *webHandlers) delete(w http.ResponseWriter, r *http.Request) {
 	runID, err := decode.Param("run_id", r)
 	if err != nil {
@@ -251,7 +323,12 @@ func (h *webHandlers) forceCancel(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if err := h.runs.ForceCancel(r.Context(), runID); err != nil {
+	err = h.runs.ForceCancel(r.Context(), runID)
+	if errors.Is(err, ErrRunForceCancelNotAllowed) {
+		html.FlashError(w, err.Error())
+		http.Redirect(w, r, paths.Run(runID), http.StatusFound)
+		return
+	} else if err != nil {
 		h.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
@@ -267,13 +344,50 @@ func (h *webHandlers) apply(w http.ResponseWriter, r *http.Request) {
 	}
 
 	err = h.runs.Apply(r.Context(), runID)
-	if err != nil {
+	if errors.Is(err, ErrStalePlan) {
+		html.FlashError(w, err.Error())
+		http.Redirect(w, r, paths.Run(runID), http.StatusFound)
+		return
+	} else if err != nil {
 		h.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
 	http.Redirect(w, r, paths.Run(runID)+"#apply", http.StatusFound)
 }
 
+// replan discards a stale run and creates a new run in its place.
+func (h *webHandlers) replan(w http.ResponseWriter, r *http.Request) {
+	runID, err := decode.Param("run_id", r)
+	if err != nil {
+		h.Error(w, err.Error(), http.StatusUnprocessableEntity)
+		return
+	}
+
+	run, err := h.runs.Replan(r.Context(), runID)
+	if err != nil {
+		html.FlashError(w, err.Error())
+		http.Redirect(w, r, paths.Run(runID), http.StatusFound)
+		return
+	}
+
+	http.Redirect(w, r, paths.Run(run.ID), http.StatusFound)
+}
+
+func (h *webHandlers) retryApply(w http.ResponseWriter, r *http.Request) {
+	runID, err := decode.Param("run_id", r)
+	if err != nil {
+		h.Error(w, err.Error(), http.StatusUnprocessableEntity)
+		return
+	}
+
+	if _, err := h.runs.RetryApply(r.Context(), runID); err != nil {
+		html.FlashError(w, err.Error())
+		http.Redirect(w, r, paths.Run(runID), http.StatusFound)
+		return
+	}
+	http.Redirect(w, r, paths.Run(runID)+"#apply", http.StatusFound)
+}
+
 func (h *webHandlers) discard(w http.ResponseWriter, r *http.Request) {
 	runID, err := decode.Param("run_id", r)
 	if err != nil {
@@ -372,7 +486,7 @@ func (h *webHandlers) watch(w http.ResponseWriter, r *http.Request) {
 			// render HTML snippet and send as payload in SSE events
 			//
 			itemHTML := new(bytes.Buffer)
-			if err := h.RenderTemplate("run_item.tmpl", itemHTML, event.Payload); err != nil {
+			if err := h.RenderTemplate("run_item.tmpl", itemHTML, h.newRunItem(event.Payload)); err != nil {
 				h.logger.Error(err, "rendering template for run item")
 				continue
 			}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/leg100/otf/internal"
 	"github.com/leg100/otf/internal/http/html/paths"
@@ -66,6 +67,36 @@ func TestWeb_GetHandler(t *testing.T) {
 	assert.Equal(t, 200, w.Code, "output: %s", w.Body.String())
 }
 
+func TestWebHandlers_GetWidget_ForceCancel(t *testing.T) {
+	tests := []struct {
+		name             string
+		cancelSignaledAt time.Time
+		want             bool // want force cancel button
+	}{
+		{"cool-off period elapsed", time.Now().Add(-time.Minute), true},
+		{"within cool-off period", time.Now(), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			run := (&Run{ID: "run-123", WorkspaceID: "ws-1"}).updateStatus(RunPlanning, nil)
+			run.CancelSignaledAt = &tt.cancelSignaledAt
+			h := newTestWebHandlers(t, withRuns(run))
+			h.forceCancelCoolOff = DefaultForceCancelCoolOff
+
+			r := httptest.NewRequest("GET", "/?run_id=run-123", nil)
+			w := httptest.NewRecorder()
+			h.getWidget(w, r)
+			assert.Equal(t, 200, w.Code, "output: %s", w.Body.String())
+			if tt.want {
+				assert.Contains(t, w.Body.String(), "force cancel")
+			} else {
+				assert.NotContains(t, w.Body.String(), "force cancel")
+				assert.Contains(t, w.Body.String(), "cancelling...")
+			}
+		})
+	}
+}
+
 func TestRuns_CancelHandler(t *testing.T) {
 	h := newTestWebHandlers(t, withRuns(&Run{ID: "run-1"}))
 
@@ -75,6 +106,24 @@ func TestRuns_CancelHandler(t *testing.T) {
 	testutils.AssertRedirect(t, w, paths.Run("run-1"))
 }
 
+func TestWebHandlers_Replan(t *testing.T) {
+	h := newTestWebHandlers(t, withRuns(&Run{ID: "run-2"}))
+
+	r := httptest.NewRequest("POST", "/?run_id=run-1", nil)
+	w := httptest.NewRecorder()
+	h.replan(w, r)
+	testutils.AssertRedirect(t, w, paths.Run("run-2"))
+}
+
+func TestWebHandlers_RetryApply(t *testing.T) {
+	h := newTestWebHandlers(t, withRuns(&Run{ID: "run-1"}))
+
+	r := httptest.NewRequest("POST", "/?run_id=run-1", nil)
+	w := httptest.NewRecorder()
+	h.retryApply(w, r)
+	testutils.AssertRedirect(t, w, paths.Run("run-1"))
+}
+
 func TestWebHandlers_CreateRun_Connected(t *testing.T) {
 	h := newTestWebHandlers(t,
 		withRuns(&Run{ID: "run-1"}),
@@ -0,0 +1,39 @@
+// Package runannotation provides annotations of runs: arbitrary key-value
+// pairs computed by plugins once a run has been planned, e.g. estimated
+// carbon, or resource counts by type.
+package runannotation
+
+import (
+	"context"
+	"time"
+
+	"github.com/leg100/otf/internal/run"
+)
+
+type (
+	// Annotation is a key-value pair attached to a run by a plugin.
+	Annotation struct {
+		RunID     string    `json:"run_id"`
+		Key       string    `json:"key"`
+		Value     string    `json:"value"`
+		Plugin    string    `json:"plugin"`
+		CreatedAt time.Time `json:"created_at"`
+	}
+
+	// Plugin computes annotations for a run once it has been planned. It is
+	// provided the run and its plan in JSON format, which can be parsed into a
+	// run.PlanFile. A returned annotation replaces any existing annotation on
+	// the run with the same key.
+	Plugin interface {
+		Annotate(ctx context.Context, r *run.Run, planJSON []byte) (map[string]string, error)
+	}
+
+	// PluginFunc is an adapter to allow the use of an ordinary function as a
+	// plugin.
+	PluginFunc func(ctx context.Context, r *run.Run, planJSON []byte) (map[string]string, error)
+)
+
+// Annotate calls f(ctx, r, planJSON).
+func (f PluginFunc) Annotate(ctx context.Context, r *run.Run, planJSON []byte) (map[string]string, error) {
+	return f(ctx, r, planJSON)
+}
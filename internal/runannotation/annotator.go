@@ -0,0 +1,91 @@
+package runannotation
+
+import (
+	"context"
+	"sync"
+
+	"github.com/go-logr/logr"
+	"github.com/leg100/otf/internal/pubsub"
+	"github.com/leg100/otf/internal/run"
+)
+
+// AnnotatorLockID guarantees only one annotator on a cluster is running at any
+// time.
+const AnnotatorLockID int64 = 5577006791947779420
+
+// defaultAnnotatorConcurrency is the maximum number of runs annotated at any
+// one time.
+const defaultAnnotatorConcurrency = 10
+
+type (
+	// Annotator invokes plugins to annotate runs once they have been planned.
+	//
+	// Only one annotator should be running on an OTF cluster at any one time.
+	Annotator struct {
+		logr.Logger
+
+		runs   annotatorRunClient
+		client annotatorClient
+		// maximum number of runs annotated at any one time
+		concurrency int
+	}
+
+	annotatorRunClient interface {
+		Watch(context.Context) (<-chan pubsub.Event[*run.Run], func())
+	}
+
+	annotatorClient interface {
+		annotate(ctx context.Context, r *run.Run) error
+	}
+)
+
+// NewAnnotator constructs an annotator of runs.
+func (s *Service) NewAnnotator(logger logr.Logger) *Annotator {
+	return &Annotator{
+		Logger:      logger.WithValues("component", "run-annotator"),
+		runs:        s.runs,
+		client:      s,
+		concurrency: defaultAnnotatorConcurrency,
+	}
+}
+
+func (a *Annotator) String() string { return "run-annotator" }
+
+// Start the annotator. Runs are annotated in the background, so that a slow
+// plugin does not hold up the annotation of subsequent runs, but no more than
+// the configured number of runs are annotated at any one time: once that limit
+// is reached, further runs wait for an annotation to finish.
+//
+// Should be invoked in a go routine.
+func (a *Annotator) Start(ctx context.Context) error {
+	sub, unsub := a.runs.Watch(ctx)
+	defer unsub()
+
+	var wg sync.WaitGroup
+	defer wg.Wait()
+
+	sem := make(chan struct{}, max(a.concurrency, 1))
+	for event := range sub {
+		if event.Type != pubsub.UpdatedEvent {
+			continue
+		}
+		switch event.Payload.Status {
+		case run.RunPlanned, run.RunPlannedAndFinished, run.RunPolicyChecked, run.RunPolicyOverride, run.RunPolicySoftFailed:
+		default:
+			continue
+		}
+		sem <- struct{}{}
+		wg.Add(1)
+		go func(r *run.Run) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			// carry on annotating subsequent runs
+			if err := a.client.annotate(ctx, r); err != nil {
+				a.Error(err, "annotating run", "run_id", r.ID)
+			}
+		}(event.Payload)
+	}
+	return pubsub.ErrSubscriptionTerminated
+}
@@ -0,0 +1,145 @@
+package runannotation
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/leg100/otf/internal/pubsub"
+	"github.com/leg100/otf/internal/run"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type (
+	fakeAnnotatorRunClient struct {
+		events []pubsub.Event[*run.Run]
+	}
+	fakeAnnotatorClient struct {
+		// IDs of annotated runs
+		annotated []string
+		// block, if non-nil, is received from before a run is annotated
+		block chan struct{}
+		// number of runs being annotated, and the maximum number at any one
+		// time
+		inflight, maxInflight int
+		mu                    sync.Mutex
+	}
+)
+
+func TestAnnotator(t *testing.T) {
+	runs := &fakeAnnotatorRunClient{
+		events: []pubsub.Event[*run.Run]{
+			{Type: pubsub.CreatedEvent, Payload: &run.Run{ID: "run-created", Status: run.RunPending}},
+			{Type: pubsub.UpdatedEvent, Payload: &run.Run{ID: "run-planning", Status: run.RunPlanning}},
+			{Type: pubsub.UpdatedEvent, Payload: &run.Run{ID: "run-planned", Status: run.RunPlanned}},
+			{Type: pubsub.UpdatedEvent, Payload: &run.Run{ID: "run-planned-and-finished", Status: run.RunPlannedAndFinished}},
+			{Type: pubsub.UpdatedEvent, Payload: &run.Run{ID: "run-errored", Status: run.RunErrored}},
+		},
+	}
+	client := &fakeAnnotatorClient{}
+	a := &Annotator{
+		Logger:      logr.Discard(),
+		runs:        runs,
+		client:      client,
+		concurrency: defaultAnnotatorConcurrency,
+	}
+
+	err := a.Start(context.Background())
+	assert.Equal(t, pubsub.ErrSubscriptionTerminated, err)
+
+	assert.ElementsMatch(t, []string{"run-planned", "run-planned-and-finished"}, client.annotated)
+}
+
+func TestAnnotator_Concurrency(t *testing.T) {
+	runs := &fakeAnnotatorRunClient{}
+	for _, id := range []string{"run-1", "run-2", "run-3", "run-4", "run-5"} {
+		runs.events = append(runs.events, pubsub.Event[*run.Run]{
+			Type:    pubsub.UpdatedEvent,
+			Payload: &run.Run{ID: id, Status: run.RunPlanned},
+		})
+	}
+	client := &fakeAnnotatorClient{block: make(chan struct{})}
+	a := &Annotator{
+		Logger:      logr.Discard(),
+		runs:        runs,
+		client:      client,
+		concurrency: 2,
+	}
+
+	done := make(chan error)
+	go func() { done <- a.Start(context.Background()) }()
+	// each time the limit is reached, unblock one annotation, making room for
+	// the next.
+	for range runs.events {
+		require.Eventually(t, func() bool {
+			client.mu.Lock()
+			defer client.mu.Unlock()
+			return client.inflight == min(2, len(runs.events)-len(client.annotated))
+		}, time.Second, time.Millisecond)
+		client.block <- struct{}{}
+	}
+	assert.Equal(t, pubsub.ErrSubscriptionTerminated, <-done)
+
+	assert.Equal(t, 5, len(client.annotated))
+	assert.Equal(t, 2, client.maxInflight)
+}
+
+func TestService_annotate_Timeout(t *testing.T) {
+	defer func(d time.Duration) { pluginTimeout = d }(pluginTimeout)
+	pluginTimeout = 10 * time.Millisecond
+
+	var got error
+	svc := &Service{
+		Logger: logr.Discard(),
+		runs:   &fakeRunClient{},
+		plugins: map[string]Plugin{
+			"slow": PluginFunc(func(ctx context.Context, _ *run.Run, _ []byte) (map[string]string, error) {
+				<-ctx.Done()
+				got = ctx.Err()
+				return nil, got
+			}),
+		},
+	}
+
+	// the plugin that times out is skipped and there is nothing to persist.
+	err := svc.annotate(context.Background(), &run.Run{ID: "run-123"})
+	assert.NoError(t, err)
+	assert.Equal(t, context.DeadlineExceeded, got)
+}
+
+func (f *fakeAnnotatorRunClient) Watch(context.Context) (<-chan pubsub.Event[*run.Run], func()) {
+	ch := make(chan pubsub.Event[*run.Run], len(f.events))
+	for _, ev := range f.events {
+		ch <- ev
+	}
+	close(ch)
+	return ch, func() {}
+}
+
+func (f *fakeAnnotatorClient) annotate(ctx context.Context, r *run.Run) error {
+	f.mu.Lock()
+	f.inflight++
+	f.maxInflight = max(f.maxInflight, f.inflight)
+	f.mu.Unlock()
+
+	if f.block != nil {
+		<-f.block
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.inflight--
+	f.annotated = append(f.annotated, r.ID)
+	return nil
+}
+
+type fakeRunClient struct {
+	runClient
+}
+
+func (f *fakeRunClient) GetPlanFile(context.Context, string, run.PlanFormat) ([]byte, error) {
+	return []byte(`{}`), nil
+}
@@ -0,0 +1,64 @@
+package runannotation
+
+import (
+	"context"
+
+	"github.com/jackc/pgtype"
+	"github.com/leg100/otf/internal/sql"
+	"github.com/leg100/otf/internal/sql/pggen"
+)
+
+type (
+	// pgdb is a database of run annotations on postgres
+	pgdb struct {
+		*sql.DB // provides access to generated SQL queries
+	}
+
+	annotationRow struct {
+		RunID     pgtype.Text        `json:"run_id"`
+		Key       pgtype.Text        `json:"key"`
+		Value     pgtype.Text        `json:"value"`
+		Plugin    pgtype.Text        `json:"plugin"`
+		CreatedAt pgtype.Timestamptz `json:"created_at"`
+	}
+)
+
+func (r annotationRow) toAnnotation() *Annotation {
+	return &Annotation{
+		RunID:     r.RunID.String,
+		Key:       r.Key.String,
+		Value:     r.Value.String,
+		Plugin:    r.Plugin.String,
+		CreatedAt: r.CreatedAt.Time.UTC(),
+	}
+}
+
+func (db *pgdb) upsertAnnotations(ctx context.Context, annotations []*Annotation) error {
+	return db.Tx(ctx, func(ctx context.Context, q pggen.Querier) error {
+		for _, a := range annotations {
+			_, err := q.UpsertRunAnnotation(ctx, pggen.UpsertRunAnnotationParams{
+				RunID:     sql.String(a.RunID),
+				Key:       sql.String(a.Key),
+				Value:     sql.String(a.Value),
+				Plugin:    sql.String(a.Plugin),
+				CreatedAt: sql.Timestamptz(a.CreatedAt),
+			})
+			if err != nil {
+				return sql.Error(err)
+			}
+		}
+		return nil
+	})
+}
+
+func (db *pgdb) listAnnotations(ctx context.Context, runID string) ([]*Annotation, error) {
+	rows, err := db.Conn(ctx).FindRunAnnotationsByRunID(ctx, sql.String(runID))
+	if err != nil {
+		return nil, sql.Error(err)
+	}
+	annotations := make([]*Annotation, len(rows))
+	for i, r := range rows {
+		annotations[i] = annotationRow(r).toAnnotation()
+	}
+	return annotations, nil
+}
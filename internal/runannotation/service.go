@@ -0,0 +1,151 @@
+package runannotation
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/leg100/otf/internal"
+	"github.com/leg100/otf/internal/logr"
+	"github.com/leg100/otf/internal/pubsub"
+	"github.com/leg100/otf/internal/rbac"
+	"github.com/leg100/otf/internal/run"
+	"github.com/leg100/otf/internal/sql"
+)
+
+// pluginTimeout is the time a plugin is given to annotate a run.
+var pluginTimeout = 30 * time.Second
+
+type (
+	Service struct {
+		logr.Logger
+
+		runAuthorizer internal.Authorizer // authorize run actions
+
+		db      *pgdb
+		api     *api
+		runs    runClient
+		plugins map[string]Plugin
+	}
+
+	Options struct {
+		*sql.DB
+		logr.Logger
+
+		RunService *run.Service
+		// Webhooks are URLs of external services to which runs are sent to
+		// be annotated.
+		Webhooks []string
+	}
+
+	runClient interface {
+		GetPlanFile(ctx context.Context, runID string, format run.PlanFormat) ([]byte, error)
+		Watch(context.Context) (<-chan pubsub.Event[*run.Run], func())
+	}
+)
+
+func NewService(opts Options) *Service {
+	svc := Service{
+		Logger:        opts.Logger,
+		runAuthorizer: opts.RunService,
+		db:            &pgdb{opts.DB},
+		runs:          opts.RunService,
+		plugins:       make(map[string]Plugin),
+	}
+	svc.api = &api{Service: &svc}
+	for _, url := range opts.Webhooks {
+		svc.RegisterPlugin(url, NewWebhookPlugin(url))
+	}
+	return &svc
+}
+
+func (s *Service) AddHandlers(r *mux.Router) {
+	s.api.addHandlers(r)
+}
+
+// RegisterPlugin registers a plugin with a unique name, to be invoked for each
+// run once it has been planned. Registering a plugin with the same name as an
+// existing plugin replaces the latter.
+func (s *Service) RegisterPlugin(name string, plugin Plugin) {
+	s.plugins[name] = plugin
+}
+
+// List lists a run's annotations, ordered by key.
+func (s *Service) List(ctx context.Context, runID string) ([]*Annotation, error) {
+	subject, err := s.runAuthorizer.CanAccess(ctx, rbac.ListRunAnnotationsAction, runID)
+	if err != nil {
+		return nil, err
+	}
+	annotations, err := s.db.listAnnotations(ctx, runID)
+	if err != nil {
+		s.Error(err, "listing run annotations", "run_id", runID, "subject", subject)
+		return nil, err
+	}
+	s.V(9).Info("listed run annotations", "run_id", runID, "count", len(annotations), "subject", subject)
+	return annotations, nil
+}
+
+// annotate invokes each plugin to annotate a planned run, persisting the
+// resulting annotations. A plugin that fails, or that does not respond within
+// pluginTimeout, does not prevent other plugins from annotating the run.
+func (s *Service) annotate(ctx context.Context, r *run.Run) error {
+	if len(s.plugins) == 0 {
+		return nil
+	}
+	planJSON, err := s.runs.GetPlanFile(ctx, r.ID, run.PlanFormatJSON)
+	if err != nil {
+		return fmt.Errorf("retrieving plan: %w", err)
+	}
+	// invoke plugins in a deterministic order, so that if several plugins
+	// return the same key then the same plugin always wins.
+	names := make([]string, 0, len(s.plugins))
+	for name := range s.plugins {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	annotations := make(map[string]*Annotation)
+	for _, name := range names {
+		kvs, err := s.invoke(ctx, s.plugins[name], r, planJSON)
+		if err != nil {
+			s.Error(err, "annotating run", "run_id", r.ID, "plugin", name)
+			continue
+		}
+		for k, v := range kvs {
+			if k == "" {
+				continue
+			}
+			annotations[k] = &Annotation{
+				RunID:     r.ID,
+				Key:       k,
+				Value:     v,
+				Plugin:    name,
+				CreatedAt: internal.CurrentTimestamp(nil),
+			}
+		}
+	}
+	if len(annotations) == 0 {
+		return nil
+	}
+	rows := make([]*Annotation, 0, len(annotations))
+	for _, a := range annotations {
+		rows = append(rows, a)
+	}
+	if err := s.db.upsertAnnotations(ctx, rows); err != nil {
+		s.Error(err, "persisting run annotations", "run_id", r.ID)
+		return err
+	}
+	s.V(1).Info("annotated run", "run_id", r.ID, "count", len(rows))
+	return nil
+}
+
+// invoke invokes the plugin, canceling its context should it not respond
+// within pluginTimeout.
+func (s *Service) invoke(ctx context.Context, plugin Plugin, r *run.Run, planJSON []byte) (map[string]string, error) {
+	ctx, cancel := context.WithTimeout(ctx, pluginTimeout)
+	defer cancel()
+
+	return plugin.Annotate(ctx, r, planJSON)
+}
@@ -0,0 +1,77 @@
+package runannotation
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/leg100/otf/internal/run"
+)
+
+// webhookTimeout is the time a webhook is given to respond.
+var webhookTimeout = 30 * time.Second
+
+type (
+	// WebhookPlugin is a plugin that delegates computing annotations to an
+	// external service, sending it the run and its plan.
+	WebhookPlugin struct {
+		URL string
+
+		client *http.Client
+	}
+
+	// webhookRequest is the request body sent to the external service.
+	webhookRequest struct {
+		RunID            string          `json:"run_id"`
+		WorkspaceID      string          `json:"workspace_id"`
+		OrganizationName string          `json:"organization_name"`
+		Plan             json.RawMessage `json:"plan"`
+	}
+
+	// webhookResponse is the response body expected from the external
+	// service.
+	webhookResponse struct {
+		Annotations map[string]string `json:"annotations"`
+	}
+)
+
+func NewWebhookPlugin(url string) *WebhookPlugin {
+	return &WebhookPlugin{
+		URL:    url,
+		client: &http.Client{Timeout: webhookTimeout},
+	}
+}
+
+func (p *WebhookPlugin) Annotate(ctx context.Context, r *run.Run, planJSON []byte) (map[string]string, error) {
+	body, err := json.Marshal(webhookRequest{
+		RunID:            r.ID,
+		WorkspaceID:      r.WorkspaceID,
+		OrganizationName: r.Organization,
+		Plan:             planJSON,
+	})
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequestWithContext(ctx, "POST", p.URL, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("webhook returned non-200 status: %s", resp.Status)
+	}
+	var annotations webhookResponse
+	if err := json.NewDecoder(resp.Body).Decode(&annotations); err != nil {
+		return nil, fmt.Errorf("decoding webhook response: %w", err)
+	}
+	return annotations.Annotations, nil
+}
@@ -0,0 +1,47 @@
+package runannotation
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/leg100/otf/internal/run"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWebhookPlugin(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req webhookRequest
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		assert.Equal(t, "run-123", req.RunID)
+		assert.Equal(t, "ws-123", req.WorkspaceID)
+		assert.Equal(t, "acme", req.OrganizationName)
+		assert.JSONEq(t, `{"resource_changes":[]}`, string(req.Plan))
+
+		json.NewEncoder(w).Encode(webhookResponse{
+			Annotations: map[string]string{"carbon": "12kg"},
+		})
+	}))
+	t.Cleanup(srv.Close)
+
+	got, err := NewWebhookPlugin(srv.URL).Annotate(
+		context.Background(),
+		&run.Run{ID: "run-123", WorkspaceID: "ws-123", Organization: "acme"},
+		[]byte(`{"resource_changes":[]}`),
+	)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"carbon": "12kg"}, got)
+
+	t.Run("non-200 response", func(t *testing.T) {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusInternalServerError)
+		}))
+		t.Cleanup(srv.Close)
+
+		_, err := NewWebhookPlugin(srv.URL).Annotate(context.Background(), &run.Run{}, []byte(`{}`))
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,95 @@
+package runtask
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/gorilla/mux"
+	otfapi "github.com/leg100/otf/internal/api"
+	"github.com/leg100/otf/internal/http/decode"
+	"github.com/leg100/otf/internal/tfeapi"
+)
+
+type (
+	// api provides endpoints specific to OTF, i.e. not part of the TFE API.
+	api struct {
+		*Service
+	}
+
+	// settingsParams are an organization's run task settings as sent and
+	// received by the API. Timeouts are in seconds.
+	settingsParams struct {
+		Concurrency     *int           `json:"concurrency,omitempty"`
+		PrePlanTimeout  *int           `json:"pre_plan_timeout,omitempty"`
+		PostPlanTimeout *int           `json:"post_plan_timeout,omitempty"`
+		TimeoutPolicy   *TimeoutPolicy `json:"timeout_policy,omitempty"`
+	}
+)
+
+func (a *api) addHandlers(r *mux.Router) {
+	r = r.PathPrefix(otfapi.DefaultBasePath).Subrouter()
+
+	r.HandleFunc("/organizations/{organization_name}/run-task-settings", a.getSettings).Methods("GET")
+	r.HandleFunc("/organizations/{organization_name}/run-task-settings", a.updateSettings).Methods("PATCH")
+}
+
+func (a *api) getSettings(w http.ResponseWriter, r *http.Request) {
+	org, err := decode.Param("organization_name", r)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	settings, err := a.GetSettings(r.Context(), org)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(toSettingsParams(settings))
+}
+
+func (a *api) updateSettings(w http.ResponseWriter, r *http.Request) {
+	org, err := decode.Param("organization_name", r)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	var params settingsParams
+	if err := json.NewDecoder(r.Body).Decode(&params); err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	opts := UpdateSettingsOptions{
+		Concurrency:   params.Concurrency,
+		TimeoutPolicy: params.TimeoutPolicy,
+	}
+	if params.PrePlanTimeout != nil {
+		timeout := time.Duration(*params.PrePlanTimeout) * time.Second
+		opts.PrePlanTimeout = &timeout
+	}
+	if params.PostPlanTimeout != nil {
+		timeout := time.Duration(*params.PostPlanTimeout) * time.Second
+		opts.PostPlanTimeout = &timeout
+	}
+	settings, err := a.UpdateSettings(r.Context(), org, opts)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(toSettingsParams(settings))
+}
+
+func toSettingsParams(settings *Settings) settingsParams {
+	var (
+		prePlanTimeout  = int(settings.PrePlanTimeout.Seconds())
+		postPlanTimeout = int(settings.PostPlanTimeout.Seconds())
+	)
+	return settingsParams{
+		Concurrency:     &settings.Concurrency,
+		PrePlanTimeout:  &prePlanTimeout,
+		PostPlanTimeout: &postPlanTimeout,
+		TimeoutPolicy:   &settings.TimeoutPolicy,
+	}
+}
@@ -0,0 +1,481 @@
+package runtask
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgtype"
+	"github.com/leg100/otf/internal"
+	"github.com/leg100/otf/internal/run"
+	"github.com/leg100/otf/internal/sql"
+	"github.com/leg100/otf/internal/sql/pggen"
+)
+
+type (
+	// pgdb is a database of run tasks on postgres
+	pgdb struct {
+		*sql.DB // provides access to generated SQL queries
+	}
+
+	taskRow struct {
+		TaskID           pgtype.Text        `json:"task_id"`
+		CreatedAt        pgtype.Timestamptz `json:"created_at"`
+		UpdatedAt        pgtype.Timestamptz `json:"updated_at"`
+		Name             pgtype.Text        `json:"name"`
+		URL              pgtype.Text        `json:"url"`
+		Description      pgtype.Text        `json:"description"`
+		Category         pgtype.Text        `json:"category"`
+		HmacKey          pgtype.Text        `json:"hmac_key"`
+		Enabled          pgtype.Bool        `json:"enabled"`
+		OrganizationName pgtype.Text        `json:"organization_name"`
+	}
+
+	workspaceTaskRow struct {
+		WorkspaceTaskID  pgtype.Text        `json:"workspace_task_id"`
+		CreatedAt        pgtype.Timestamptz `json:"created_at"`
+		UpdatedAt        pgtype.Timestamptz `json:"updated_at"`
+		EnforcementLevel pgtype.Text        `json:"enforcement_level"`
+		Stage            pgtype.Text        `json:"stage"`
+		TaskID           pgtype.Text        `json:"task_id"`
+		WorkspaceID      pgtype.Text        `json:"workspace_id"`
+	}
+
+	stageRow struct {
+		TaskStageID pgtype.Text        `json:"task_stage_id"`
+		CreatedAt   pgtype.Timestamptz `json:"created_at"`
+		UpdatedAt   pgtype.Timestamptz `json:"updated_at"`
+		Stage       pgtype.Text        `json:"stage"`
+		Status      pgtype.Text        `json:"status"`
+		RunID       pgtype.Text        `json:"run_id"`
+	}
+
+	resultRow struct {
+		TaskResultID     pgtype.Text        `json:"task_result_id"`
+		CreatedAt        pgtype.Timestamptz `json:"created_at"`
+		UpdatedAt        pgtype.Timestamptz `json:"updated_at"`
+		Status           pgtype.Text        `json:"status"`
+		Message          pgtype.Text        `json:"message"`
+		URL              pgtype.Text        `json:"url"`
+		TaskID           pgtype.Text        `json:"task_id"`
+		TaskName         pgtype.Text        `json:"task_name"`
+		TaskURL          pgtype.Text        `json:"task_url"`
+		WorkspaceTaskID  pgtype.Text        `json:"workspace_task_id"`
+		EnforcementLevel pgtype.Text        `json:"enforcement_level"`
+		DispatchedAt     pgtype.Timestamptz `json:"dispatched_at"`
+		TaskStageID      pgtype.Text        `json:"task_stage_id"`
+		Stage            pgtype.Text        `json:"stage"`
+		RunID            pgtype.Text        `json:"run_id"`
+	}
+)
+
+func (r taskRow) toTask() *Task {
+	task := &Task{
+		ID:           r.TaskID.String,
+		CreatedAt:    r.CreatedAt.Time.UTC(),
+		UpdatedAt:    r.UpdatedAt.Time.UTC(),
+		Organization: r.OrganizationName.String,
+		Name:         r.Name.String,
+		URL:          r.URL.String,
+		Description:  r.Description.String,
+		Category:     r.Category.String,
+		Enabled:      r.Enabled.Bool,
+	}
+	if r.HmacKey.Status == pgtype.Present {
+		task.HMACKey = &r.HmacKey.String
+	}
+	return task
+}
+
+func (r workspaceTaskRow) toWorkspaceTask() *WorkspaceTask {
+	return &WorkspaceTask{
+		ID:               r.WorkspaceTaskID.String,
+		CreatedAt:        r.CreatedAt.Time.UTC(),
+		UpdatedAt:        r.UpdatedAt.Time.UTC(),
+		WorkspaceID:      r.WorkspaceID.String,
+		TaskID:           r.TaskID.String,
+		EnforcementLevel: EnforcementLevel(r.EnforcementLevel.String),
+		Stage:            run.TaskStage(r.Stage.String),
+	}
+}
+
+func (r stageRow) toStage() *Stage {
+	return &Stage{
+		ID:        r.TaskStageID.String,
+		CreatedAt: r.CreatedAt.Time.UTC(),
+		UpdatedAt: r.UpdatedAt.Time.UTC(),
+		RunID:     r.RunID.String,
+		Stage:     run.TaskStage(r.Stage.String),
+		Status:    StageStatus(r.Status.String),
+	}
+}
+
+func (r resultRow) toResult() *Result {
+	result := &Result{
+		ID:               r.TaskResultID.String,
+		CreatedAt:        r.CreatedAt.Time.UTC(),
+		UpdatedAt:        r.UpdatedAt.Time.UTC(),
+		StageID:          r.TaskStageID.String,
+		RunID:            r.RunID.String,
+		Stage:            run.TaskStage(r.Stage.String),
+		Status:           ResultStatus(r.Status.String),
+		Message:          r.Message.String,
+		URL:              r.URL.String,
+		TaskID:           r.TaskID.String,
+		TaskName:         r.TaskName.String,
+		TaskURL:          r.TaskURL.String,
+		WorkspaceTaskID:  r.WorkspaceTaskID.String,
+		EnforcementLevel: EnforcementLevel(r.EnforcementLevel.String),
+	}
+	if r.DispatchedAt.Status == pgtype.Present {
+		result.DispatchedAt = internal.Time(r.DispatchedAt.Time.UTC())
+	}
+	return result
+}
+
+func (db *pgdb) createTask(ctx context.Context, task *Task) error {
+	_, err := db.Conn(ctx).InsertTask(ctx, pggen.InsertTaskParams{
+		TaskID:           sql.String(task.ID),
+		CreatedAt:        sql.Timestamptz(task.CreatedAt),
+		UpdatedAt:        sql.Timestamptz(task.UpdatedAt),
+		Name:             sql.String(task.Name),
+		URL:              sql.String(task.URL),
+		Description:      sql.String(task.Description),
+		Category:         sql.String(task.Category),
+		HmacKey:          sql.StringPtr(task.HMACKey),
+		Enabled:          sql.Bool(task.Enabled),
+		OrganizationName: sql.String(task.Organization),
+	})
+	return sql.Error(err)
+}
+
+func (db *pgdb) listTasks(ctx context.Context, organization string) ([]*Task, error) {
+	rows, err := db.Conn(ctx).FindTasksByOrganization(ctx, sql.String(organization))
+	if err != nil {
+		return nil, sql.Error(err)
+	}
+	tasks := make([]*Task, len(rows))
+	for i, r := range rows {
+		tasks[i] = taskRow(r).toTask()
+	}
+	return tasks, nil
+}
+
+func (db *pgdb) getTask(ctx context.Context, taskID string) (*Task, error) {
+	row, err := db.Conn(ctx).FindTaskByID(ctx, sql.String(taskID))
+	if err != nil {
+		return nil, sql.Error(err)
+	}
+	return taskRow(row).toTask(), nil
+}
+
+func (db *pgdb) updateTask(ctx context.Context, taskID string, fn func(*Task) error) (*Task, error) {
+	var task *Task
+	err := db.Tx(ctx, func(ctx context.Context, q pggen.Querier) error {
+		row, err := q.FindTaskByIDForUpdate(ctx, sql.String(taskID))
+		if err != nil {
+			return sql.Error(err)
+		}
+		task = taskRow(row).toTask()
+		if err := fn(task); err != nil {
+			return err
+		}
+		_, err = q.UpdateTask(ctx, pggen.UpdateTaskParams{
+			TaskID:      sql.String(task.ID),
+			Name:        sql.String(task.Name),
+			URL:         sql.String(task.URL),
+			Description: sql.String(task.Description),
+			Category:    sql.String(task.Category),
+			HmacKey:     sql.StringPtr(task.HMACKey),
+			Enabled:     sql.Bool(task.Enabled),
+			UpdatedAt:   sql.Timestamptz(task.UpdatedAt),
+		})
+		return sql.Error(err)
+	})
+	return task, err
+}
+
+func (db *pgdb) deleteTask(ctx context.Context, taskID string) error {
+	_, err := db.Conn(ctx).DeleteTaskByID(ctx, sql.String(taskID))
+	return sql.Error(err)
+}
+
+func (db *pgdb) createWorkspaceTask(ctx context.Context, wt *WorkspaceTask) error {
+	_, err := db.Conn(ctx).InsertWorkspaceTask(ctx, pggen.InsertWorkspaceTaskParams{
+		WorkspaceTaskID:  sql.String(wt.ID),
+		CreatedAt:        sql.Timestamptz(wt.CreatedAt),
+		UpdatedAt:        sql.Timestamptz(wt.UpdatedAt),
+		EnforcementLevel: sql.String(string(wt.EnforcementLevel)),
+		Stage:            sql.String(string(wt.Stage)),
+		TaskID:           sql.String(wt.TaskID),
+		WorkspaceID:      sql.String(wt.WorkspaceID),
+	})
+	return sql.Error(err)
+}
+
+func (db *pgdb) listWorkspaceTasks(ctx context.Context, workspaceID string) ([]*WorkspaceTask, error) {
+	rows, err := db.Conn(ctx).FindWorkspaceTasksByWorkspaceID(ctx, sql.String(workspaceID))
+	if err != nil {
+		return nil, sql.Error(err)
+	}
+	tasks := make([]*WorkspaceTask, len(rows))
+	for i, r := range rows {
+		tasks[i] = workspaceTaskRow(r).toWorkspaceTask()
+	}
+	return tasks, nil
+}
+
+func (db *pgdb) getWorkspaceTask(ctx context.Context, workspaceTaskID string) (*WorkspaceTask, error) {
+	row, err := db.Conn(ctx).FindWorkspaceTaskByID(ctx, sql.String(workspaceTaskID))
+	if err != nil {
+		return nil, sql.Error(err)
+	}
+	return workspaceTaskRow(row).toWorkspaceTask(), nil
+}
+
+func (db *pgdb) updateWorkspaceTask(ctx context.Context, workspaceTaskID string, fn func(*WorkspaceTask) error) (*WorkspaceTask, error) {
+	var wt *WorkspaceTask
+	err := db.Tx(ctx, func(ctx context.Context, q pggen.Querier) error {
+		row, err := q.FindWorkspaceTaskByIDForUpdate(ctx, sql.String(workspaceTaskID))
+		if err != nil {
+			return sql.Error(err)
+		}
+		wt = workspaceTaskRow(row).toWorkspaceTask()
+		if err := fn(wt); err != nil {
+			return err
+		}
+		_, err = q.UpdateWorkspaceTask(ctx, pggen.UpdateWorkspaceTaskParams{
+			WorkspaceTaskID:  sql.String(wt.ID),
+			EnforcementLevel: sql.String(string(wt.EnforcementLevel)),
+			Stage:            sql.String(string(wt.Stage)),
+			UpdatedAt:        sql.Timestamptz(wt.UpdatedAt),
+		})
+		return sql.Error(err)
+	})
+	return wt, err
+}
+
+func (db *pgdb) deleteWorkspaceTask(ctx context.Context, workspaceTaskID string) error {
+	_, err := db.Conn(ctx).DeleteWorkspaceTaskByID(ctx, sql.String(workspaceTaskID))
+	return sql.Error(err)
+}
+
+// listEnabledTasks lists the enabled tasks attached to a workspace at the
+// given stage.
+func (db *pgdb) listEnabledTasks(ctx context.Context, workspaceID string, stage run.TaskStage) ([]enabledTask, error) {
+	rows, err := db.Conn(ctx).FindEnabledWorkspaceTasksByStage(ctx, sql.String(workspaceID), sql.String(string(stage)))
+	if err != nil {
+		return nil, sql.Error(err)
+	}
+	tasks := make([]enabledTask, len(rows))
+	for i, r := range rows {
+		tasks[i] = enabledTask{
+			Task: &Task{
+				ID:   r.TaskID.String,
+				Name: r.Name.String,
+				URL:  r.URL.String,
+			},
+			WorkspaceTaskID:  r.WorkspaceTaskID.String,
+			EnforcementLevel: EnforcementLevel(r.EnforcementLevel.String),
+		}
+	}
+	return tasks, nil
+}
+
+func (db *pgdb) createStage(ctx context.Context, stage *Stage) error {
+	return db.Tx(ctx, func(ctx context.Context, q pggen.Querier) error {
+		_, err := q.InsertTaskStage(ctx, pggen.InsertTaskStageParams{
+			TaskStageID: sql.String(stage.ID),
+			CreatedAt:   sql.Timestamptz(stage.CreatedAt),
+			UpdatedAt:   sql.Timestamptz(stage.UpdatedAt),
+			Stage:       sql.String(string(stage.Stage)),
+			Status:      sql.String(string(stage.Status)),
+			RunID:       sql.String(stage.RunID),
+		})
+		if err != nil {
+			return sql.Error(err)
+		}
+		for _, r := range stage.Results {
+			_, err := q.InsertTaskResult(ctx, pggen.InsertTaskResultParams{
+				TaskResultID:     sql.String(r.ID),
+				CreatedAt:        sql.Timestamptz(r.CreatedAt),
+				UpdatedAt:        sql.Timestamptz(r.UpdatedAt),
+				Status:           sql.String(string(r.Status)),
+				Message:          sql.String(r.Message),
+				URL:              sql.String(r.URL),
+				TaskID:           sql.String(r.TaskID),
+				TaskName:         sql.String(r.TaskName),
+				TaskURL:          sql.String(r.TaskURL),
+				WorkspaceTaskID:  sql.String(r.WorkspaceTaskID),
+				EnforcementLevel: sql.String(string(r.EnforcementLevel)),
+				TaskStageID:      sql.String(stage.ID),
+			})
+			if err != nil {
+				return sql.Error(err)
+			}
+		}
+		return nil
+	})
+}
+
+func (db *pgdb) listStages(ctx context.Context, runID string) ([]*Stage, error) {
+	rows, err := db.Conn(ctx).FindTaskStagesByRunID(ctx, sql.String(runID))
+	if err != nil {
+		return nil, sql.Error(err)
+	}
+	stages := make([]*Stage, len(rows))
+	for i, r := range rows {
+		stages[i] = stageRow(r).toStage()
+		stages[i].Results, err = db.listResults(ctx, stages[i].ID)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return stages, nil
+}
+
+func (db *pgdb) getStage(ctx context.Context, stageID string) (*Stage, error) {
+	row, err := db.Conn(ctx).FindTaskStageByID(ctx, sql.String(stageID))
+	if err != nil {
+		return nil, sql.Error(err)
+	}
+	stage := stageRow(row).toStage()
+	stage.Results, err = db.listResults(ctx, stageID)
+	if err != nil {
+		return nil, err
+	}
+	return stage, nil
+}
+
+// updateStage locks the stage and its results for the duration of fn, and
+// persists any change to the status of the stage made by fn.
+func (db *pgdb) updateStage(ctx context.Context, stageID string, fn func(context.Context, *Stage) error) (*Stage, error) {
+	var stage *Stage
+	err := db.Tx(ctx, func(ctx context.Context, q pggen.Querier) error {
+		row, err := q.FindTaskStageByIDForUpdate(ctx, sql.String(stageID))
+		if err != nil {
+			return sql.Error(err)
+		}
+		stage = stageRow(row).toStage()
+		stage.Results, err = db.listResults(ctx, stageID)
+		if err != nil {
+			return err
+		}
+		status := stage.Status
+		if err := fn(ctx, stage); err != nil {
+			return err
+		}
+		if stage.Status == status {
+			return nil
+		}
+		stage.UpdatedAt = internal.CurrentTimestamp(nil)
+		_, err = q.UpdateTaskStageStatus(ctx, pggen.UpdateTaskStageStatusParams{
+			TaskStageID: sql.String(stageID),
+			Status:      sql.String(string(stage.Status)),
+			UpdatedAt:   sql.Timestamptz(stage.UpdatedAt),
+		})
+		return sql.Error(err)
+	})
+	return stage, err
+}
+
+func (db *pgdb) listResults(ctx context.Context, stageID string) ([]*Result, error) {
+	rows, err := db.Conn(ctx).FindTaskResultsByTaskStageID(ctx, sql.String(stageID))
+	if err != nil {
+		return nil, sql.Error(err)
+	}
+	results := make([]*Result, len(rows))
+	for i, r := range rows {
+		results[i] = resultRow(r).toResult()
+	}
+	return results, nil
+}
+
+func (db *pgdb) getResult(ctx context.Context, resultID string) (*Result, error) {
+	row, err := db.Conn(ctx).FindTaskResultByID(ctx, sql.String(resultID))
+	if err != nil {
+		return nil, sql.Error(err)
+	}
+	return resultRow(row).toResult(), nil
+}
+
+// listIncompleteResults lists results that have yet to complete, along with
+// the organization of each result's run, oldest first.
+func (db *pgdb) listIncompleteResults(ctx context.Context) ([]incompleteResult, error) {
+	rows, err := db.Conn(ctx).FindIncompleteTaskResults(ctx)
+	if err != nil {
+		return nil, sql.Error(err)
+	}
+	results := make([]incompleteResult, len(rows))
+	for i, r := range rows {
+		results[i] = incompleteResult{
+			Result: resultRow{
+				TaskResultID:     r.TaskResultID,
+				CreatedAt:        r.CreatedAt,
+				UpdatedAt:        r.UpdatedAt,
+				Status:           r.Status,
+				Message:          r.Message,
+				URL:              r.URL,
+				TaskID:           r.TaskID,
+				TaskName:         r.TaskName,
+				TaskURL:          r.TaskURL,
+				WorkspaceTaskID:  r.WorkspaceTaskID,
+				EnforcementLevel: r.EnforcementLevel,
+				DispatchedAt:     r.DispatchedAt,
+				TaskStageID:      r.TaskStageID,
+				Stage:            r.Stage,
+				RunID:            r.RunID,
+			}.toResult(),
+			Organization: r.OrganizationName.String,
+		}
+	}
+	return results, nil
+}
+
+func (db *pgdb) updateResult(ctx context.Context, result *Result) error {
+	_, err := db.Conn(ctx).UpdateTaskResultStatus(ctx, pggen.UpdateTaskResultStatusParams{
+		TaskResultID:     sql.String(result.ID),
+		Status:           sql.String(string(result.Status)),
+		Message:          sql.String(result.Message),
+		URL:              sql.String(result.URL),
+		EnforcementLevel: sql.String(string(result.EnforcementLevel)),
+		UpdatedAt:        sql.Timestamptz(result.UpdatedAt),
+	})
+	return sql.Error(err)
+}
+
+func (db *pgdb) setResultDispatched(ctx context.Context, resultID string, dispatchedAt time.Time) error {
+	_, err := db.Conn(ctx).UpdateTaskResultDispatchedAt(ctx, sql.Timestamptz(dispatchedAt), sql.String(resultID))
+	return sql.Error(err)
+}
+
+// getSettings retrieves an organization's run task settings, returning the
+// default settings if the organization has yet to change them.
+func (db *pgdb) getSettings(ctx context.Context, organization string) (*Settings, error) {
+	row, err := db.Conn(ctx).FindRunTaskSettings(ctx, sql.String(organization))
+	if err != nil {
+		err = sql.Error(err)
+		if errors.Is(err, internal.ErrResourceNotFound) {
+			return newSettings(organization), nil
+		}
+		return nil, err
+	}
+	return &Settings{
+		Organization:    row.OrganizationName.String,
+		Concurrency:     int(row.Concurrency.Int),
+		PrePlanTimeout:  time.Duration(row.PrePlanTimeout.Int) * time.Second,
+		PostPlanTimeout: time.Duration(row.PostPlanTimeout.Int) * time.Second,
+		TimeoutPolicy:   TimeoutPolicy(row.TimeoutPolicy.String),
+	}, nil
+}
+
+func (db *pgdb) upsertSettings(ctx context.Context, settings *Settings) error {
+	_, err := db.Conn(ctx).UpsertRunTaskSettings(ctx, pggen.UpsertRunTaskSettingsParams{
+		OrganizationName: sql.String(settings.Organization),
+		Concurrency:      sql.Int4(settings.Concurrency),
+		PrePlanTimeout:   sql.Int4(int(settings.PrePlanTimeout.Seconds())),
+		PostPlanTimeout:  sql.Int4(int(settings.PostPlanTimeout.Seconds())),
+		TimeoutPolicy:    sql.String(string(settings.TimeoutPolicy)),
+	})
+	return sql.Error(err)
+}
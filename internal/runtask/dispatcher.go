@@ -0,0 +1,331 @@
+package runtask
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha512"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/leg100/otf/internal"
+	"github.com/leg100/otf/internal/http/html/paths"
+	"github.com/leg100/otf/internal/resource"
+	"github.com/leg100/otf/internal/run"
+	"github.com/leg100/otf/internal/tfeapi"
+	"github.com/leg100/otf/internal/tokens"
+)
+
+const (
+	// DispatcherLockID guarantees only one dispatcher on a cluster is running
+	// at any time.
+	DispatcherLockID int64 = 5577006791947779418
+
+	// signatureHeader is the header containing the HMAC signature of the
+	// request sent to a task.
+	signatureHeader = "X-TFC-Task-Signature"
+
+	// payloadVersion is the version of the request payload sent to a task.
+	payloadVersion = 1
+)
+
+var (
+	defaultDispatcherInterval = 5 * time.Second
+
+	// requestTimeout is the time a task is given to respond to a request,
+	// unless its stage times out sooner.
+	requestTimeout = 10 * time.Second
+)
+
+type (
+	// Dispatcher sends requests to tasks at stages of runs, and errors the
+	// results of tasks that fail to report their result in time.
+	//
+	// Only one dispatcher should be running on an OTF cluster at any one time.
+	Dispatcher struct {
+		logr.Logger
+
+		svc    *Service
+		client *http.Client
+		// frequency with which the dispatcher checks for results.
+		interval time.Duration
+	}
+
+	// incompleteResult is a result that has yet to complete, along with the
+	// organization of its run.
+	incompleteResult struct {
+		*Result
+		Organization string
+	}
+
+	// payload is the request sent to a task:
+	//
+	// https://developer.hashicorp.com/terraform/enterprise/integrations/run-tasks#run-task-request
+	payload struct {
+		PayloadVersion                  int       `json:"payload_version"`
+		AccessToken                     string    `json:"access_token"`
+		Stage                           string    `json:"stage"`
+		IsSpeculative                   bool      `json:"is_speculative"`
+		TaskResultID                    string    `json:"task_result_id"`
+		TaskResultEnforcementLevel      string    `json:"task_result_enforcement_level"`
+		TaskResultCallbackURL           string    `json:"task_result_callback_url"`
+		RunAppURL                       string    `json:"run_app_url"`
+		RunID                           string    `json:"run_id"`
+		RunMessage                      string    `json:"run_message"`
+		RunCreatedAt                    time.Time `json:"run_created_at"`
+		RunCreatedBy                    string    `json:"run_created_by"`
+		WorkspaceID                     string    `json:"workspace_id"`
+		WorkspaceName                   string    `json:"workspace_name"`
+		WorkspaceAppURL                 string    `json:"workspace_app_url"`
+		WorkspaceWorkingDirectory       string    `json:"workspace_working_directory"`
+		OrganizationName                string    `json:"organization_name"`
+		PlanJSONAPIURL                  string    `json:"plan_json_api_url,omitempty"`
+		VCSBranch                       string    `json:"vcs_branch,omitempty"`
+		VCSCommitURL                    string    `json:"vcs_commit_url,omitempty"`
+		VCSPullRequestURL               string    `json:"vcs_pull_request_url,omitempty"`
+		ConfigurationVersionID          string    `json:"configuration_version_id"`
+		ConfigurationVersionDownloadURL string    `json:"configuration_version_download_url"`
+	}
+)
+
+// NewDispatcher constructs a dispatcher of requests to run tasks.
+func (s *Service) NewDispatcher(logger logr.Logger) *Dispatcher {
+	return &Dispatcher{
+		Logger:   logger.WithValues("component", "run-task-dispatcher"),
+		svc:      s,
+		client:   &http.Client{},
+		interval: defaultDispatcherInterval,
+	}
+}
+
+func (d *Dispatcher) String() string { return "run-task-dispatcher" }
+
+// Start the dispatcher. Every interval the results of tasks that have timed
+// out are errored, and requests are sent to tasks that are yet to be
+// dispatched.
+//
+// Should be invoked in a go routine.
+func (d *Dispatcher) Start(ctx context.Context) error {
+	ticker := time.NewTicker(d.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			if err := d.process(ctx); err != nil {
+				return err
+			}
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
+
+// process errors the results of tasks that have timed out and sends requests
+// to tasks that are yet to be dispatched, according to the run task settings
+// of each result's organization.
+func (d *Dispatcher) process(ctx context.Context) error {
+	results, err := d.svc.db.listIncompleteResults(ctx)
+	if err != nil {
+		return err
+	}
+	settings := make(map[string]*Settings)
+	for _, r := range results {
+		if _, ok := settings[r.Organization]; ok {
+			continue
+		}
+		s, err := d.svc.db.getSettings(ctx, r.Organization)
+		if err != nil {
+			return err
+		}
+		settings[r.Organization] = s
+	}
+	expired, dispatch := schedule(results, settings, internal.CurrentTimestamp(nil))
+	for _, r := range expired {
+		d.expire(ctx, r.Result, settings[r.Organization])
+	}
+	// mark as dispatched before sending the requests to ensure each is sent
+	// no more than once.
+	for _, r := range dispatch {
+		if err := d.svc.db.setResultDispatched(ctx, r.ID, internal.CurrentTimestamp(nil)); err != nil {
+			return err
+		}
+	}
+	// send requests concurrently so that a task that is slow to respond does
+	// not hold up requests to other tasks.
+	var wg sync.WaitGroup
+	for _, r := range dispatch {
+		wg.Add(1)
+		go func(r incompleteResult) {
+			defer wg.Done()
+			d.dispatch(ctx, r.Result, settings[r.Organization])
+		}(r)
+	}
+	wg.Wait()
+	return nil
+}
+
+// schedule determines which incomplete results have timed out, and which of
+// the undispatched results are to be dispatched, oldest first, without
+// exceeding the concurrency limit of their organization. A result times out
+// once the timeout for its stage has elapsed since the stage started, whether
+// or not it has been dispatched, so that a queue of tasks cannot hold up a run
+// for longer than the timeout.
+func schedule(results []incompleteResult, settings map[string]*Settings, now time.Time) (expired, dispatch []incompleteResult) {
+	// number of results per organization awaiting a result from their task
+	inflight := make(map[string]int)
+	var undispatched []incompleteResult
+	for _, r := range results {
+		if now.Sub(r.CreatedAt) > settings[r.Organization].timeout(r.Stage) {
+			expired = append(expired, r)
+			continue
+		}
+		if r.DispatchedAt != nil {
+			inflight[r.Organization]++
+			continue
+		}
+		undispatched = append(undispatched, r)
+	}
+	for _, r := range undispatched {
+		if settings[r.Organization].limited(inflight[r.Organization]) {
+			continue
+		}
+		inflight[r.Organization]++
+		dispatch = append(dispatch, r)
+	}
+	return expired, dispatch
+}
+
+// dispatch sends the request for a result to its task, erroring the result if
+// the request fails.
+func (d *Dispatcher) dispatch(ctx context.Context, result *Result, settings *Settings) {
+	if err := d.send(ctx, result, settings); err != nil {
+		d.Error(err, "sending request to run task", "result", result)
+		if _, err := d.svc.updateResult(ctx, result, func(r *Result) error {
+			r.fail(err.Error())
+			return nil
+		}); err != nil {
+			d.Error(err, "erroring task result", "result", result)
+		}
+		return
+	}
+	d.V(1).Info("sent request to run task", "result", result)
+}
+
+// expire errors the result of a task that has timed out, applying the
+// organization's timeout policy.
+func (d *Dispatcher) expire(ctx context.Context, result *Result, settings *Settings) {
+	_, err := d.svc.updateResult(ctx, result, func(r *Result) error {
+		if r.Done() {
+			return nil
+		}
+		r.timeout(settings.timeout(r.Stage), settings.TimeoutPolicy)
+		return nil
+	})
+	if err != nil {
+		d.Error(err, "erroring timed out task result", "result", result)
+		return
+	}
+	d.V(1).Info("errored timed out task result", "result", result, "settings", settings)
+}
+
+// send sends the request for a result to its task.
+func (d *Dispatcher) send(ctx context.Context, result *Result, settings *Settings) error {
+	task, err := d.svc.db.getTask(ctx, result.TaskID)
+	if err != nil {
+		return fmt.Errorf("retrieving task: %w", err)
+	}
+	// the task has until its stage times out to report its result
+	expiry := result.CreatedAt.Add(settings.timeout(result.Stage))
+	p, err := d.newPayload(ctx, result, expiry)
+	if err != nil {
+		return err
+	}
+	body, err := json.Marshal(p)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(ctx, min(requestTimeout, time.Until(expiry)))
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, "POST", task.URL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if task.HMACKey != nil {
+		req.Header.Set(signatureHeader, sign(body, *task.HMACKey))
+	}
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("task responded with unexpected status: %s", resp.Status)
+	}
+	return nil
+}
+
+func (d *Dispatcher) newPayload(ctx context.Context, result *Result, expiry time.Time) (*payload, error) {
+	r, err := d.svc.runs.Get(ctx, result.RunID)
+	if err != nil {
+		return nil, fmt.Errorf("retrieving run: %w", err)
+	}
+	ws, err := d.svc.workspaces.Get(ctx, r.WorkspaceID)
+	if err != nil {
+		return nil, fmt.Errorf("retrieving workspace: %w", err)
+	}
+	token, err := d.svc.tokens.NewToken(tokens.NewTokenOptions{
+		Kind:    ResultTokenKind,
+		Subject: result.ID,
+		Expiry:  &expiry,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("creating access token: %w", err)
+	}
+	p := &payload{
+		PayloadVersion:                  payloadVersion,
+		AccessToken:                     string(token),
+		Stage:                           string(result.Stage),
+		IsSpeculative:                   r.PlanOnly,
+		TaskResultID:                    result.ID,
+		TaskResultEnforcementLevel:      string(result.EnforcementLevel),
+		TaskResultCallbackURL:           d.svc.URL(tfeapi.APIPrefixV2 + "task-results/" + result.ID + "/callback"),
+		RunAppURL:                       d.svc.URL(paths.Run(r.ID)),
+		RunID:                           r.ID,
+		RunMessage:                      r.Message,
+		RunCreatedAt:                    r.CreatedAt,
+		WorkspaceID:                     ws.ID,
+		WorkspaceName:                   ws.Name,
+		WorkspaceAppURL:                 d.svc.URL(paths.Workspace(ws.ID)),
+		WorkspaceWorkingDirectory:       ws.WorkingDirectory,
+		OrganizationName:                r.Organization,
+		ConfigurationVersionID:          r.ConfigurationVersionID,
+		ConfigurationVersionDownloadURL: d.svc.URL(tfeapi.APIPrefixV2 + "configuration-versions/" + r.ConfigurationVersionID + "/download"),
+	}
+	if r.CreatedBy != nil {
+		p.RunCreatedBy = *r.CreatedBy
+	}
+	if result.Stage == run.PostPlanStage {
+		planID := resource.ConvertID(r.ID, resource.PlanKind)
+		p.PlanJSONAPIURL = d.svc.URL(tfeapi.APIPrefixV2 + "plans/" + planID + "/json-output")
+	}
+	if ia := r.IngressAttributes; ia != nil {
+		p.VCSBranch = ia.Branch
+		p.VCSCommitURL = ia.CommitURL
+		p.VCSPullRequestURL = ia.PullRequestURL
+	}
+	return p, nil
+}
+
+// sign returns the hex-encoded HMAC-SHA512 signature of the body using the
+// key.
+func sign(body []byte, key string) string {
+	mac := hmac.New(sha512.New, []byte(key))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
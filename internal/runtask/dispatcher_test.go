@@ -0,0 +1,182 @@
+package runtask
+
+import (
+	"crypto/hmac"
+	"crypto/sha512"
+	"encoding/hex"
+	"testing"
+	"time"
+
+	"github.com/leg100/otf/internal"
+	"github.com/leg100/otf/internal/run"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSign(t *testing.T) {
+	body := []byte(`{"payload_version":1}`)
+
+	got, err := hex.DecodeString(sign(body, "secret"))
+	require.NoError(t, err)
+
+	mac := hmac.New(sha512.New, []byte("secret"))
+	mac.Write(body)
+	assert.True(t, hmac.Equal(mac.Sum(nil), got))
+
+	assert.NotEqual(t, sign(body, "secret"), sign(body, "other"))
+}
+
+func TestSchedule(t *testing.T) {
+	now := internal.CurrentTimestamp(nil)
+	// result constructs an incomplete result created at the given time
+	// before now, and dispatched if specified.
+	result := func(id, org string, stage run.TaskStage, age time.Duration, dispatched bool) incompleteResult {
+		r := &Result{ID: id, Stage: stage, Status: ResultPending, CreatedAt: now.Add(-age)}
+		if dispatched {
+			r.DispatchedAt = &r.CreatedAt
+			r.Status = ResultRunning
+		}
+		return incompleteResult{Result: r, Organization: org}
+	}
+	settings := func(org string, concurrency int, prePlan, postPlan time.Duration) *Settings {
+		s := newSettings(org)
+		s.Concurrency = concurrency
+		s.PrePlanTimeout = prePlan
+		s.PostPlanTimeout = postPlan
+		return s
+	}
+	ids := func(results []incompleteResult) (ids []string) {
+		for _, r := range results {
+			ids = append(ids, r.ID)
+		}
+		return ids
+	}
+
+	tests := []struct {
+		name         string
+		results      []incompleteResult
+		settings     map[string]*Settings
+		wantExpired  []string
+		wantDispatch []string
+	}{
+		{
+			name: "dispatch all when unlimited",
+			results: []incompleteResult{
+				result("tr-1", "acme", run.PostPlanStage, 0, false),
+				result("tr-2", "acme", run.PostPlanStage, 0, false),
+			},
+			settings:     map[string]*Settings{"acme": newSettings("acme")},
+			wantDispatch: []string{"tr-1", "tr-2"},
+		},
+		{
+			name: "limit tasks awaiting a result to organization's concurrency",
+			results: []incompleteResult{
+				result("tr-1", "acme", run.PostPlanStage, 2*time.Minute, true),
+				result("tr-2", "acme", run.PostPlanStage, time.Minute, false),
+				result("tr-3", "acme", run.PostPlanStage, 0, false),
+			},
+			settings:     map[string]*Settings{"acme": settings("acme", 2, time.Hour, time.Hour)},
+			wantDispatch: []string{"tr-2"},
+		},
+		{
+			name: "concurrency is per organization",
+			results: []incompleteResult{
+				result("tr-1", "acme", run.PostPlanStage, time.Minute, true),
+				result("tr-2", "acme", run.PostPlanStage, 0, false),
+				result("tr-3", "umbrella", run.PostPlanStage, 0, false),
+			},
+			settings: map[string]*Settings{
+				"acme":     settings("acme", 1, time.Hour, time.Hour),
+				"umbrella": settings("umbrella", 1, time.Hour, time.Hour),
+			},
+			wantDispatch: []string{"tr-3"},
+		},
+		{
+			name: "expire slow task and dispatch queued task in its place",
+			results: []incompleteResult{
+				result("tr-1", "acme", run.PostPlanStage, 6*time.Minute, true),
+				result("tr-2", "acme", run.PostPlanStage, time.Minute, false),
+			},
+			settings:     map[string]*Settings{"acme": settings("acme", 1, time.Hour, 5*time.Minute)},
+			wantExpired:  []string{"tr-1"},
+			wantDispatch: []string{"tr-2"},
+		},
+		{
+			name: "expire queued task that never got dispatched",
+			results: []incompleteResult{
+				result("tr-1", "acme", run.PostPlanStage, 4*time.Minute, true),
+				result("tr-2", "acme", run.PostPlanStage, 6*time.Minute, false),
+			},
+			settings:    map[string]*Settings{"acme": settings("acme", 1, time.Hour, 5*time.Minute)},
+			wantExpired: []string{"tr-2"},
+		},
+		{
+			name: "timeout is per stage",
+			results: []incompleteResult{
+				result("tr-1", "acme", run.PrePlanStage, 2*time.Minute, true),
+				result("tr-2", "acme", run.PostPlanStage, 2*time.Minute, true),
+			},
+			settings:    map[string]*Settings{"acme": settings("acme", 0, time.Minute, 5*time.Minute)},
+			wantExpired: []string{"tr-1"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			expired, dispatch := schedule(tt.results, tt.settings, now)
+			assert.Equal(t, tt.wantExpired, ids(expired))
+			assert.Equal(t, tt.wantDispatch, ids(dispatch))
+		})
+	}
+}
+
+// TestSchedule_SlowTask demonstrates a task that never reports its result
+// holds up a run no longer than its organization's timeout, with the outcome
+// determined by the organization's timeout policy.
+func TestSchedule_SlowTask(t *testing.T) {
+	tests := []struct {
+		name       string
+		level      EnforcementLevel
+		policy     TimeoutPolicy
+		wantPassed bool
+	}{
+		{"mandatory task", MandatoryEnforcement, EnforcementLevelTimeoutPolicy, false},
+		{"advisory task", AdvisoryEnforcement, EnforcementLevelTimeoutPolicy, true},
+		{"mandatory task with advisory policy", MandatoryEnforcement, AdvisoryTimeoutPolicy, true},
+		{"advisory task with fail-closed policy", AdvisoryEnforcement, FailClosedTimeoutPolicy, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stage := newStage("run-123", run.PostPlanStage, []enabledTask{
+				{
+					Task:             &Task{ID: "task-123", Name: "scanner", URL: "https://scanner.example.com"},
+					EnforcementLevel: tt.level,
+				},
+			})
+			result := stage.Results[0]
+			result.DispatchedAt = internal.Time(result.CreatedAt)
+			results := []incompleteResult{{Result: result, Organization: "acme"}}
+
+			s := newSettings("acme")
+			s.PostPlanTimeout = 5 * time.Minute
+			s.TimeoutPolicy = tt.policy
+			settings := map[string]*Settings{"acme": s}
+
+			// before the timeout the stage waits for the task
+			expired, _ := schedule(results, settings, result.CreatedAt.Add(4*time.Minute))
+			assert.Empty(t, expired)
+			done, _ := stage.evaluate()
+			assert.False(t, done)
+
+			// after the timeout the task's result is errored and the stage
+			// completes
+			expired, _ = schedule(results, settings, result.CreatedAt.Add(6*time.Minute))
+			require.Equal(t, 1, len(expired))
+			expired[0].timeout(s.timeout(run.PostPlanStage), s.TimeoutPolicy)
+
+			assert.Equal(t, ResultErrored, result.Status)
+			done, passed := stage.evaluate()
+			assert.True(t, done)
+			assert.Equal(t, tt.wantPassed, passed)
+		})
+	}
+}
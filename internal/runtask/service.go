@@ -0,0 +1,473 @@
+package runtask
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/gorilla/mux"
+	"github.com/leg100/otf/internal"
+	"github.com/leg100/otf/internal/logr"
+	"github.com/leg100/otf/internal/organization"
+	"github.com/leg100/otf/internal/rbac"
+	"github.com/leg100/otf/internal/run"
+	"github.com/leg100/otf/internal/sql"
+	"github.com/leg100/otf/internal/tfeapi"
+	"github.com/leg100/otf/internal/tokens"
+	"github.com/leg100/otf/internal/workspace"
+)
+
+var ErrTaskNotInOrganization = errors.New("task does not belong to the workspace's organization")
+
+type (
+	Service struct {
+		logr.Logger
+		*internal.HostnameService
+
+		organization        internal.Authorizer // authorize organization actions
+		workspaceAuthorizer internal.Authorizer // authorize workspace actions
+		runAuthorizer       internal.Authorizer // authorize run actions
+
+		db         *pgdb
+		tfeapi     *tfe
+		api        *api
+		runs       runClient
+		workspaces workspaceClient
+		tokens     *tokens.Service
+	}
+
+	Options struct {
+		*sql.DB
+		*tfeapi.Responder
+		*internal.HostnameService
+		logr.Logger
+
+		WorkspaceAuthorizer internal.Authorizer
+		WorkspaceService    *workspace.Service
+		RunService          *run.Service
+		TokensService       *tokens.Service
+	}
+
+	runClient interface {
+		Get(ctx context.Context, runID string) (*run.Run, error)
+		CompleteTaskStage(ctx context.Context, runID string, stage run.TaskStage, passed bool) (*run.Run, error)
+	}
+
+	workspaceClient interface {
+		Get(ctx context.Context, workspaceID string) (*workspace.Workspace, error)
+	}
+)
+
+func NewService(opts Options) *Service {
+	svc := Service{
+		Logger:              opts.Logger,
+		HostnameService:     opts.HostnameService,
+		organization:        &organization.Authorizer{Logger: opts.Logger},
+		workspaceAuthorizer: opts.WorkspaceAuthorizer,
+		runAuthorizer:       opts.RunService,
+		db:                  &pgdb{opts.DB},
+		runs:                opts.RunService,
+		workspaces:          opts.WorkspaceService,
+		tokens:              opts.TokensService,
+	}
+	svc.tfeapi = &tfe{
+		Service:   &svc,
+		Responder: opts.Responder,
+	}
+	svc.api = &api{Service: &svc}
+	// Execute tasks attached to a run's workspace at each stage of the run.
+	opts.RunService.OnTaskStage(svc.startStage)
+	// Register with auth middleware the token sent to a task and a means of
+	// retrieving the task result corresponding to the token.
+	opts.TokensService.RegisterKind(ResultTokenKind, func(ctx context.Context, resultID string) (internal.Subject, error) {
+		result, err := svc.db.getResult(ctx, resultID)
+		if err != nil {
+			return nil, fmt.Errorf("retrieving task result: %w", err)
+		}
+		run, err := svc.runs.Get(ctx, result.RunID)
+		if err != nil {
+			return nil, fmt.Errorf("retrieving run for task result: %w", err)
+		}
+		return &resultSubject{
+			resultID:     result.ID,
+			workspaceID:  run.WorkspaceID,
+			organization: run.Organization,
+		}, nil
+	})
+	return &svc
+}
+
+func (s *Service) AddHandlers(r *mux.Router) {
+	s.tfeapi.addHandlers(r)
+	s.api.addHandlers(r)
+}
+
+func (s *Service) CreateTask(ctx context.Context, opts CreateTaskOptions) (*Task, error) {
+	subject, err := s.organization.CanAccess(ctx, rbac.CreateRunTaskAction, opts.Organization)
+	if err != nil {
+		return nil, err
+	}
+	task, err := newTask(opts)
+	if err != nil {
+		s.Error(err, "constructing run task", "organization", opts.Organization, "subject", subject)
+		return nil, err
+	}
+	if err := s.db.createTask(ctx, task); err != nil {
+		s.Error(err, "creating run task", "task", task, "subject", subject)
+		return nil, err
+	}
+	s.V(0).Info("created run task", "task", task, "subject", subject)
+	return task, nil
+}
+
+func (s *Service) ListTasks(ctx context.Context, organization string) ([]*Task, error) {
+	subject, err := s.organization.CanAccess(ctx, rbac.ListRunTasksAction, organization)
+	if err != nil {
+		return nil, err
+	}
+	tasks, err := s.db.listTasks(ctx, organization)
+	if err != nil {
+		s.Error(err, "listing run tasks", "organization", organization, "subject", subject)
+		return nil, err
+	}
+	s.V(9).Info("listed run tasks", "organization", organization, "count", len(tasks), "subject", subject)
+	return tasks, nil
+}
+
+func (s *Service) GetTask(ctx context.Context, taskID string) (*Task, error) {
+	task, err := s.db.getTask(ctx, taskID)
+	if err != nil {
+		s.Error(err, "retrieving run task", "id", taskID)
+		return nil, err
+	}
+	subject, err := s.organization.CanAccess(ctx, rbac.GetRunTaskAction, task.Organization)
+	if err != nil {
+		return nil, err
+	}
+	s.V(9).Info("retrieved run task", "task", task, "subject", subject)
+	return task, nil
+}
+
+func (s *Service) UpdateTask(ctx context.Context, taskID string, opts UpdateTaskOptions) (*Task, error) {
+	var subject internal.Subject
+	task, err := s.db.updateTask(ctx, taskID, func(task *Task) (err error) {
+		subject, err = s.organization.CanAccess(ctx, rbac.UpdateRunTaskAction, task.Organization)
+		if err != nil {
+			return err
+		}
+		return task.update(opts)
+	})
+	if err != nil {
+		s.Error(err, "updating run task", "id", taskID, "subject", subject)
+		return nil, err
+	}
+	s.V(0).Info("updated run task", "task", task, "subject", subject)
+	return task, nil
+}
+
+// DeleteTask deletes a run task, detaching it from any workspaces. Results of
+// the task are retained.
+func (s *Service) DeleteTask(ctx context.Context, taskID string) error {
+	task, err := s.db.getTask(ctx, taskID)
+	if err != nil {
+		s.Error(err, "retrieving run task", "id", taskID)
+		return err
+	}
+	subject, err := s.organization.CanAccess(ctx, rbac.DeleteRunTaskAction, task.Organization)
+	if err != nil {
+		return err
+	}
+	if err := s.db.deleteTask(ctx, taskID); err != nil {
+		s.Error(err, "deleting run task", "task", task, "subject", subject)
+		return err
+	}
+	s.V(0).Info("deleted run task", "task", task, "subject", subject)
+	return nil
+}
+
+// GetSettings retrieves an organization's run task settings.
+func (s *Service) GetSettings(ctx context.Context, organization string) (*Settings, error) {
+	subject, err := s.organization.CanAccess(ctx, rbac.GetRunTaskSettingsAction, organization)
+	if err != nil {
+		return nil, err
+	}
+	settings, err := s.db.getSettings(ctx, organization)
+	if err != nil {
+		s.Error(err, "retrieving run task settings", "organization", organization, "subject", subject)
+		return nil, err
+	}
+	s.V(9).Info("retrieved run task settings", "settings", settings, "subject", subject)
+	return settings, nil
+}
+
+// UpdateSettings updates an organization's run task settings. The settings
+// apply to tasks that have yet to complete as well as to future tasks.
+func (s *Service) UpdateSettings(ctx context.Context, organization string, opts UpdateSettingsOptions) (*Settings, error) {
+	subject, err := s.organization.CanAccess(ctx, rbac.UpdateRunTaskSettingsAction, organization)
+	if err != nil {
+		return nil, err
+	}
+	settings, err := s.db.getSettings(ctx, organization)
+	if err != nil {
+		s.Error(err, "retrieving run task settings", "organization", organization, "subject", subject)
+		return nil, err
+	}
+	if err := settings.update(opts); err != nil {
+		return nil, err
+	}
+	if err := s.db.upsertSettings(ctx, settings); err != nil {
+		s.Error(err, "updating run task settings", "settings", settings, "subject", subject)
+		return nil, err
+	}
+	s.V(0).Info("updated run task settings", "settings", settings, "subject", subject)
+	return settings, nil
+}
+
+// CreateWorkspaceTask attaches a run task to a workspace. The task must belong
+// to the workspace's organization.
+func (s *Service) CreateWorkspaceTask(ctx context.Context, workspaceID string, opts CreateWorkspaceTaskOptions) (*WorkspaceTask, error) {
+	subject, err := s.workspaceAuthorizer.CanAccess(ctx, rbac.CreateWorkspaceRunTaskAction, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+	wt, err := newWorkspaceTask(workspaceID, opts)
+	if err != nil {
+		s.Error(err, "constructing workspace run task", "workspace", workspaceID, "subject", subject)
+		return nil, err
+	}
+	ws, err := s.workspaces.Get(ctx, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+	task, err := s.db.getTask(ctx, opts.TaskID)
+	if err != nil {
+		s.Error(err, "retrieving run task", "id", opts.TaskID, "subject", subject)
+		return nil, err
+	}
+	if task.Organization != ws.Organization {
+		return nil, &internal.InvalidParameterError{Parameter: "task", Err: ErrTaskNotInOrganization}
+	}
+	if err := s.db.createWorkspaceTask(ctx, wt); err != nil {
+		s.Error(err, "creating workspace run task", "workspace_task", wt, "subject", subject)
+		return nil, err
+	}
+	s.V(0).Info("created workspace run task", "workspace_task", wt, "subject", subject)
+	return wt, nil
+}
+
+func (s *Service) ListWorkspaceTasks(ctx context.Context, workspaceID string) ([]*WorkspaceTask, error) {
+	subject, err := s.workspaceAuthorizer.CanAccess(ctx, rbac.ListWorkspaceRunTasksAction, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+	tasks, err := s.db.listWorkspaceTasks(ctx, workspaceID)
+	if err != nil {
+		s.Error(err, "listing workspace run tasks", "workspace", workspaceID, "subject", subject)
+		return nil, err
+	}
+	s.V(9).Info("listed workspace run tasks", "workspace", workspaceID, "count", len(tasks), "subject", subject)
+	return tasks, nil
+}
+
+func (s *Service) GetWorkspaceTask(ctx context.Context, workspaceID, workspaceTaskID string) (*WorkspaceTask, error) {
+	subject, err := s.workspaceAuthorizer.CanAccess(ctx, rbac.GetWorkspaceRunTaskAction, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+	wt, err := s.getWorkspaceTask(ctx, workspaceID, workspaceTaskID)
+	if err != nil {
+		s.Error(err, "retrieving workspace run task", "id", workspaceTaskID, "subject", subject)
+		return nil, err
+	}
+	s.V(9).Info("retrieved workspace run task", "workspace_task", wt, "subject", subject)
+	return wt, nil
+}
+
+func (s *Service) UpdateWorkspaceTask(ctx context.Context, workspaceID, workspaceTaskID string, opts UpdateWorkspaceTaskOptions) (*WorkspaceTask, error) {
+	subject, err := s.workspaceAuthorizer.CanAccess(ctx, rbac.UpdateWorkspaceRunTaskAction, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+	wt, err := s.db.updateWorkspaceTask(ctx, workspaceTaskID, func(wt *WorkspaceTask) error {
+		if wt.WorkspaceID != workspaceID {
+			return internal.ErrResourceNotFound
+		}
+		return wt.update(opts)
+	})
+	if err != nil {
+		s.Error(err, "updating workspace run task", "id", workspaceTaskID, "subject", subject)
+		return nil, err
+	}
+	s.V(0).Info("updated workspace run task", "workspace_task", wt, "subject", subject)
+	return wt, nil
+}
+
+// DeleteWorkspaceTask detaches a run task from a workspace.
+func (s *Service) DeleteWorkspaceTask(ctx context.Context, workspaceID, workspaceTaskID string) error {
+	subject, err := s.workspaceAuthorizer.CanAccess(ctx, rbac.DeleteWorkspaceRunTaskAction, workspaceID)
+	if err != nil {
+		return err
+	}
+	wt, err := s.getWorkspaceTask(ctx, workspaceID, workspaceTaskID)
+	if err != nil {
+		s.Error(err, "retrieving workspace run task", "id", workspaceTaskID, "subject", subject)
+		return err
+	}
+	if err := s.db.deleteWorkspaceTask(ctx, workspaceTaskID); err != nil {
+		s.Error(err, "deleting workspace run task", "workspace_task", wt, "subject", subject)
+		return err
+	}
+	s.V(0).Info("deleted workspace run task", "workspace_task", wt, "subject", subject)
+	return nil
+}
+
+// getWorkspaceTask retrieves a workspace task, checking it belongs to the
+// workspace.
+func (s *Service) getWorkspaceTask(ctx context.Context, workspaceID, workspaceTaskID string) (*WorkspaceTask, error) {
+	wt, err := s.db.getWorkspaceTask(ctx, workspaceTaskID)
+	if err != nil {
+		return nil, err
+	}
+	if wt.WorkspaceID != workspaceID {
+		return nil, internal.ErrResourceNotFound
+	}
+	return wt, nil
+}
+
+// ListStages lists the task stages of a run along with their results.
+func (s *Service) ListStages(ctx context.Context, runID string) ([]*Stage, error) {
+	subject, err := s.runAuthorizer.CanAccess(ctx, rbac.GetRunAction, runID)
+	if err != nil {
+		return nil, err
+	}
+	stages, err := s.db.listStages(ctx, runID)
+	if err != nil {
+		s.Error(err, "listing task stages", "run_id", runID, "subject", subject)
+		return nil, err
+	}
+	s.V(9).Info("listed task stages", "run_id", runID, "count", len(stages), "subject", subject)
+	return stages, nil
+}
+
+func (s *Service) GetStage(ctx context.Context, stageID string) (*Stage, error) {
+	stage, err := s.db.getStage(ctx, stageID)
+	if err != nil {
+		s.Error(err, "retrieving task stage", "id", stageID)
+		return nil, err
+	}
+	subject, err := s.runAuthorizer.CanAccess(ctx, rbac.GetRunAction, stage.RunID)
+	if err != nil {
+		return nil, err
+	}
+	s.V(9).Info("retrieved task stage", "stage", stage, "subject", subject)
+	return stage, nil
+}
+
+func (s *Service) GetResult(ctx context.Context, resultID string) (*Result, error) {
+	result, err := s.db.getResult(ctx, resultID)
+	if err != nil {
+		s.Error(err, "retrieving task result", "id", resultID)
+		return nil, err
+	}
+	subject, err := s.runAuthorizer.CanAccess(ctx, rbac.GetRunAction, result.RunID)
+	if err != nil {
+		return nil, err
+	}
+	s.V(9).Info("retrieved task result", "result", result, "subject", subject)
+	return result, nil
+}
+
+// Callback updates a task result with that reported by the task. Only the
+// task itself, authenticated with the token sent to it, may report its
+// result. Once all the tasks at the stage have completed the run proceeds.
+func (s *Service) Callback(ctx context.Context, resultID string, cb Callback) (*Result, error) {
+	subject, err := resultSubjectFromContext(ctx)
+	if err != nil || subject.resultID != resultID {
+		return nil, internal.ErrAccessNotPermitted
+	}
+	result, err := s.db.getResult(ctx, resultID)
+	if err != nil {
+		s.Error(err, "retrieving task result", "id", resultID, "subject", subject)
+		return nil, err
+	}
+	result, err = s.updateResult(ctx, result, func(r *Result) error {
+		return r.callback(cb)
+	})
+	if err != nil {
+		s.Error(err, "updating task result", "id", resultID, "subject", subject)
+		return nil, err
+	}
+	s.V(0).Info("updated task result", "result", result, "subject", subject)
+	return result, nil
+}
+
+// startStage executes the enabled tasks attached to a run's workspace at the
+// given stage, returning true if there are any such tasks.
+func (s *Service) startStage(ctx context.Context, r *run.Run, stage run.TaskStage) (bool, error) {
+	tasks, err := s.db.listEnabledTasks(ctx, r.WorkspaceID, stage)
+	if err != nil {
+		return false, err
+	}
+	if len(tasks) == 0 {
+		return false, nil
+	}
+	ts := newStage(r.ID, stage, tasks)
+	if err := s.db.createStage(ctx, ts); err != nil {
+		return false, err
+	}
+	s.V(0).Info("started task stage", "stage", ts, "tasks", len(tasks))
+	return true, nil
+}
+
+// updateResult updates a task result and, if all the results at its stage
+// have now completed, completes the stage.
+func (s *Service) updateResult(ctx context.Context, result *Result, fn func(*Result) error) (*Result, error) {
+	var updated *Result
+	_, err := s.db.updateStage(ctx, result.StageID, func(ctx context.Context, stage *Stage) error {
+		for _, r := range stage.Results {
+			if r.ID == result.ID {
+				updated = r
+			}
+		}
+		if updated == nil {
+			return internal.ErrResourceNotFound
+		}
+		if err := fn(updated); err != nil {
+			return err
+		}
+		if err := s.db.updateResult(ctx, updated); err != nil {
+			return err
+		}
+		return s.completeStage(ctx, stage)
+	})
+	return updated, err
+}
+
+// completeStage completes the stage if all of its results have completed,
+// informing the run of the outcome.
+func (s *Service) completeStage(ctx context.Context, stage *Stage) error {
+	if stage.Status != StageRunning {
+		return nil
+	}
+	done, passed := stage.evaluate()
+	if !done {
+		return nil
+	}
+	if passed {
+		stage.Status = StagePassed
+	} else {
+		stage.Status = StageFailed
+	}
+	ctx = internal.AddSubjectToContext(ctx, &internal.Superuser{Username: "run-tasks"})
+	_, err := s.runs.CompleteTaskStage(ctx, stage.RunID, stage.Stage, passed)
+	if errors.Is(err, run.ErrInvalidRunStateTransition) {
+		// run has moved on, e.g. it has been canceled
+		stage.Status = StageCanceled
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	s.V(0).Info("completed task stage", "stage", stage)
+	return nil
+}
@@ -0,0 +1,137 @@
+package runtask
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/leg100/otf/internal"
+	"github.com/leg100/otf/internal/run"
+)
+
+const (
+	// EnforcementLevelTimeoutPolicy treats a task that times out according
+	// to the enforcement level of the task.
+	EnforcementLevelTimeoutPolicy TimeoutPolicy = "enforcement-level"
+	// FailClosedTimeoutPolicy stops a run if a task times out, regardless of
+	// the enforcement level of the task.
+	FailClosedTimeoutPolicy TimeoutPolicy = "fail-closed"
+	// AdvisoryTimeoutPolicy permits a run to proceed if a task times out,
+	// regardless of the enforcement level of the task.
+	AdvisoryTimeoutPolicy TimeoutPolicy = "advisory"
+
+	// DefaultStageTimeout is the time a task is given to report its result
+	// unless its organization specifies otherwise.
+	DefaultStageTimeout = 10 * time.Minute
+	// MinStageTimeout and MaxStageTimeout bound the time an organization may
+	// give its tasks to report their results.
+	MinStageTimeout = 10 * time.Second
+	MaxStageTimeout = time.Hour
+)
+
+var (
+	ErrInvalidTimeoutPolicy = errors.New("timeout policy must be one of: enforcement-level, fail-closed, advisory")
+	ErrInvalidConcurrency   = errors.New("concurrency cannot be negative")
+	ErrInvalidStageTimeout  = fmt.Errorf("stage timeout must be between %s and %s", MinStageTimeout, MaxStageTimeout)
+)
+
+type (
+	// Settings control how an organization's run tasks are executed.
+	Settings struct {
+		Organization string
+		// Concurrency is the maximum number of the organization's tasks that
+		// may be awaiting a result at any one time. Further tasks are queued
+		// until a task reports its result or times out. Zero means no limit.
+		Concurrency int
+		// PrePlanTimeout and PostPlanTimeout are the times a task is given to
+		// report its result at the respective stages, measured from the start
+		// of the stage, including any time spent queued.
+		PrePlanTimeout  time.Duration
+		PostPlanTimeout time.Duration
+		// TimeoutPolicy determines whether a run proceeds if a task times out.
+		TimeoutPolicy TimeoutPolicy
+	}
+
+	// TimeoutPolicy determines the outcome of a task that times out.
+	TimeoutPolicy string
+
+	UpdateSettingsOptions struct {
+		Concurrency     *int
+		PrePlanTimeout  *time.Duration
+		PostPlanTimeout *time.Duration
+		TimeoutPolicy   *TimeoutPolicy
+	}
+)
+
+// newSettings constructs the default settings for an organization.
+func newSettings(organization string) *Settings {
+	return &Settings{
+		Organization:    organization,
+		PrePlanTimeout:  DefaultStageTimeout,
+		PostPlanTimeout: DefaultStageTimeout,
+		TimeoutPolicy:   EnforcementLevelTimeoutPolicy,
+	}
+}
+
+func (s *Settings) LogValue() slog.Value {
+	attrs := []slog.Attr{
+		slog.String("organization", s.Organization),
+		slog.Int("concurrency", s.Concurrency),
+		slog.Duration("pre_plan_timeout", s.PrePlanTimeout),
+		slog.Duration("post_plan_timeout", s.PostPlanTimeout),
+		slog.String("timeout_policy", string(s.TimeoutPolicy)),
+	}
+	return slog.GroupValue(attrs...)
+}
+
+func (s *Settings) update(opts UpdateSettingsOptions) error {
+	if opts.Concurrency != nil {
+		if *opts.Concurrency < 0 {
+			return &internal.InvalidParameterError{Parameter: "concurrency", Err: ErrInvalidConcurrency}
+		}
+		s.Concurrency = *opts.Concurrency
+	}
+	if opts.PrePlanTimeout != nil {
+		if err := validateStageTimeout("pre_plan_timeout", *opts.PrePlanTimeout); err != nil {
+			return err
+		}
+		s.PrePlanTimeout = *opts.PrePlanTimeout
+	}
+	if opts.PostPlanTimeout != nil {
+		if err := validateStageTimeout("post_plan_timeout", *opts.PostPlanTimeout); err != nil {
+			return err
+		}
+		s.PostPlanTimeout = *opts.PostPlanTimeout
+	}
+	if opts.TimeoutPolicy != nil {
+		switch *opts.TimeoutPolicy {
+		case EnforcementLevelTimeoutPolicy, FailClosedTimeoutPolicy, AdvisoryTimeoutPolicy:
+			s.TimeoutPolicy = *opts.TimeoutPolicy
+		default:
+			return &internal.InvalidParameterError{Parameter: "timeout_policy", Err: ErrInvalidTimeoutPolicy}
+		}
+	}
+	return nil
+}
+
+// timeout returns the time a task is given to report its result at the stage.
+func (s *Settings) timeout(stage run.TaskStage) time.Duration {
+	if stage == run.PrePlanStage {
+		return s.PrePlanTimeout
+	}
+	return s.PostPlanTimeout
+}
+
+// limited determines whether the organization has reached its limit of tasks
+// awaiting a result, given the number currently awaiting a result.
+func (s *Settings) limited(inflight int) bool {
+	return s.Concurrency > 0 && inflight >= s.Concurrency
+}
+
+func validateStageTimeout(param string, timeout time.Duration) error {
+	if timeout < MinStageTimeout || timeout > MaxStageTimeout {
+		return &internal.InvalidParameterError{Parameter: param, Err: ErrInvalidStageTimeout}
+	}
+	return nil
+}
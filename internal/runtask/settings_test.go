@@ -0,0 +1,80 @@
+package runtask
+
+import (
+	"testing"
+	"time"
+
+	"github.com/leg100/otf/internal"
+	"github.com/leg100/otf/internal/run"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSettings_Update(t *testing.T) {
+	duration := func(d time.Duration) *time.Duration { return &d }
+	policy := func(p TimeoutPolicy) *TimeoutPolicy { return &p }
+
+	tests := []struct {
+		name    string
+		opts    UpdateSettingsOptions
+		want    func(*testing.T, *Settings)
+		wantErr bool
+	}{
+		{
+			name: "defaults",
+			want: func(t *testing.T, s *Settings) {
+				assert.Equal(t, 0, s.Concurrency)
+				assert.Equal(t, DefaultStageTimeout, s.timeout(run.PrePlanStage))
+				assert.Equal(t, DefaultStageTimeout, s.timeout(run.PostPlanStage))
+				assert.Equal(t, EnforcementLevelTimeoutPolicy, s.TimeoutPolicy)
+			},
+		},
+		{
+			name: "update all",
+			opts: UpdateSettingsOptions{
+				Concurrency:     internal.Int(2),
+				PrePlanTimeout:  duration(time.Minute),
+				PostPlanTimeout: duration(5 * time.Minute),
+				TimeoutPolicy:   policy(FailClosedTimeoutPolicy),
+			},
+			want: func(t *testing.T, s *Settings) {
+				assert.Equal(t, 2, s.Concurrency)
+				assert.Equal(t, time.Minute, s.timeout(run.PrePlanStage))
+				assert.Equal(t, 5*time.Minute, s.timeout(run.PostPlanStage))
+				assert.Equal(t, FailClosedTimeoutPolicy, s.TimeoutPolicy)
+			},
+		},
+		{
+			name:    "negative concurrency",
+			opts:    UpdateSettingsOptions{Concurrency: internal.Int(-1)},
+			wantErr: true,
+		},
+		{
+			name:    "timeout too short",
+			opts:    UpdateSettingsOptions{PrePlanTimeout: duration(time.Second)},
+			wantErr: true,
+		},
+		{
+			name:    "timeout too long",
+			opts:    UpdateSettingsOptions{PostPlanTimeout: duration(2 * time.Hour)},
+			wantErr: true,
+		},
+		{
+			name:    "invalid timeout policy",
+			opts:    UpdateSettingsOptions{TimeoutPolicy: policy("ignore")},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newSettings("acme")
+			err := s.update(tt.opts)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			tt.want(t, s)
+		})
+	}
+}
@@ -0,0 +1,205 @@
+package runtask
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/leg100/otf/internal"
+	"github.com/leg100/otf/internal/resource"
+	"github.com/leg100/otf/internal/run"
+)
+
+const (
+	StagePending  StageStatus = "pending"
+	StageRunning  StageStatus = "running"
+	StagePassed   StageStatus = "passed"
+	StageFailed   StageStatus = "failed"
+	StageCanceled StageStatus = "canceled"
+
+	ResultPending ResultStatus = "pending"
+	ResultRunning ResultStatus = "running"
+	ResultPassed  ResultStatus = "passed"
+	ResultFailed  ResultStatus = "failed"
+	ResultErrored ResultStatus = "errored"
+)
+
+var (
+	ErrResultAlreadyDone   = errors.New("task result has already completed")
+	ErrInvalidResultStatus = errors.New("status must be one of: running, passed, failed")
+)
+
+type (
+	// Stage is the execution of a run's tasks at a stage of the run.
+	Stage struct {
+		ID        string
+		CreatedAt time.Time
+		UpdatedAt time.Time
+		RunID     string
+		Stage     run.TaskStage
+		Status    StageStatus
+		Results   []*Result
+	}
+
+	StageStatus string
+
+	// Result is the result of a task executed at a stage of a run. Details of
+	// the task are copied to the result, leaving the result intact should the
+	// task subsequently be updated or deleted.
+	Result struct {
+		ID               string
+		CreatedAt        time.Time
+		UpdatedAt        time.Time
+		StageID          string
+		RunID            string
+		Stage            run.TaskStage
+		Status           ResultStatus
+		Message          string
+		URL              string
+		TaskID           string
+		TaskName         string
+		TaskURL          string
+		WorkspaceTaskID  string
+		EnforcementLevel EnforcementLevel
+		// DispatchedAt is the time at which the request was sent to the task;
+		// nil if the request is yet to be sent.
+		DispatchedAt *time.Time
+	}
+
+	ResultStatus string
+
+	// Callback is the result reported by a task.
+	Callback struct {
+		Status  ResultStatus
+		Message *string
+		URL     *string
+	}
+
+	// enabledTask is a task attached to a workspace that is to be executed.
+	enabledTask struct {
+		Task             *Task
+		WorkspaceTaskID  string
+		EnforcementLevel EnforcementLevel
+	}
+)
+
+// newStage constructs a stage for a run, with a pending result for each task.
+func newStage(runID string, stage run.TaskStage, tasks []enabledTask) *Stage {
+	s := &Stage{
+		ID:        resource.NewID(resource.TaskStageKind),
+		CreatedAt: internal.CurrentTimestamp(nil),
+		UpdatedAt: internal.CurrentTimestamp(nil),
+		RunID:     runID,
+		Stage:     stage,
+		Status:    StageRunning,
+	}
+	for _, t := range tasks {
+		s.Results = append(s.Results, &Result{
+			ID:               resource.NewID(resource.TaskResultKind),
+			CreatedAt:        s.CreatedAt,
+			UpdatedAt:        s.CreatedAt,
+			StageID:          s.ID,
+			RunID:            runID,
+			Stage:            stage,
+			Status:           ResultPending,
+			TaskID:           t.Task.ID,
+			TaskName:         t.Task.Name,
+			TaskURL:          t.Task.URL,
+			WorkspaceTaskID:  t.WorkspaceTaskID,
+			EnforcementLevel: t.EnforcementLevel,
+		})
+	}
+	return s
+}
+
+func (s *Stage) LogValue() slog.Value {
+	attrs := []slog.Attr{
+		slog.String("id", s.ID),
+		slog.String("run_id", s.RunID),
+		slog.String("stage", string(s.Stage)),
+		slog.String("status", string(s.Status)),
+	}
+	return slog.GroupValue(attrs...)
+}
+
+// evaluate determines the outcome of the stage from its results. If any
+// result has yet to complete then done is false. Otherwise the stage has
+// passed unless a mandatory task has failed or errored.
+func (s *Stage) evaluate() (done, passed bool) {
+	passed = true
+	for _, r := range s.Results {
+		if !r.Done() {
+			return false, false
+		}
+		if r.EnforcementLevel == MandatoryEnforcement && r.Status != ResultPassed {
+			passed = false
+		}
+	}
+	return true, passed
+}
+
+func (r *Result) LogValue() slog.Value {
+	attrs := []slog.Attr{
+		slog.String("id", r.ID),
+		slog.String("run_id", r.RunID),
+		slog.String("stage", string(r.Stage)),
+		slog.String("task", r.TaskName),
+		slog.String("status", string(r.Status)),
+	}
+	return slog.GroupValue(attrs...)
+}
+
+// Done determines whether the task has completed.
+func (r *Result) Done() bool {
+	switch r.Status {
+	case ResultPassed, ResultFailed, ResultErrored:
+		return true
+	default:
+		return false
+	}
+}
+
+// callback updates the result with that reported by the task.
+func (r *Result) callback(cb Callback) error {
+	if r.Done() {
+		return ErrResultAlreadyDone
+	}
+	switch cb.Status {
+	case ResultRunning, ResultPassed, ResultFailed:
+	default:
+		return &internal.InvalidParameterError{Parameter: "status", Err: ErrInvalidResultStatus}
+	}
+	r.Status = cb.Status
+	if cb.Message != nil {
+		r.Message = *cb.Message
+	}
+	if cb.URL != nil {
+		r.URL = *cb.URL
+	}
+	r.UpdatedAt = internal.CurrentTimestamp(nil)
+	return nil
+}
+
+// fail marks the result as errored, e.g. the task could not be reached.
+func (r *Result) fail(msg string) {
+	r.Status = ResultErrored
+	r.Message = msg
+	r.UpdatedAt = internal.CurrentTimestamp(nil)
+}
+
+// timeout marks the result as errored because the task failed to report its
+// result in time. Unless the policy defers to the enforcement level of the
+// task, the policy overrides the enforcement level.
+func (r *Result) timeout(after time.Duration, policy TimeoutPolicy) {
+	msg := fmt.Sprintf("timed out after %s waiting for task to report result", after)
+	switch policy {
+	case FailClosedTimeoutPolicy:
+		r.EnforcementLevel = MandatoryEnforcement
+		msg += "; organization policy stops the run"
+	case AdvisoryTimeoutPolicy:
+		r.EnforcementLevel = AdvisoryEnforcement
+		msg += "; organization policy permits the run to proceed"
+	}
+	r.fail(msg)
+}
@@ -0,0 +1,85 @@
+package runtask
+
+import (
+	"testing"
+
+	"github.com/leg100/otf/internal"
+	"github.com/leg100/otf/internal/run"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStage_Evaluate(t *testing.T) {
+	result := func(level EnforcementLevel, status ResultStatus) *Result {
+		return &Result{EnforcementLevel: level, Status: status}
+	}
+	tests := []struct {
+		name       string
+		results    []*Result
+		wantDone   bool
+		wantPassed bool
+	}{
+		{
+			"all passed",
+			[]*Result{result(MandatoryEnforcement, ResultPassed), result(AdvisoryEnforcement, ResultPassed)},
+			true, true,
+		},
+		{
+			"still running",
+			[]*Result{result(MandatoryEnforcement, ResultPassed), result(AdvisoryEnforcement, ResultRunning)},
+			false, false,
+		},
+		{
+			"advisory failed",
+			[]*Result{result(MandatoryEnforcement, ResultPassed), result(AdvisoryEnforcement, ResultFailed)},
+			true, true,
+		},
+		{
+			"mandatory failed",
+			[]*Result{result(MandatoryEnforcement, ResultFailed), result(AdvisoryEnforcement, ResultPassed)},
+			true, false,
+		},
+		{
+			"mandatory errored",
+			[]*Result{result(MandatoryEnforcement, ResultErrored)},
+			true, false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stage := &Stage{Results: tt.results}
+			done, passed := stage.evaluate()
+			assert.Equal(t, tt.wantDone, done)
+			assert.Equal(t, tt.wantPassed, passed)
+		})
+	}
+}
+
+func TestResult_Callback(t *testing.T) {
+	stage := newStage("run-123", run.PostPlanStage, []enabledTask{
+		{
+			Task:             &Task{ID: "task-123", Name: "scanner", URL: "https://scanner.example.com"},
+			WorkspaceTaskID:  "wstask-123",
+			EnforcementLevel: MandatoryEnforcement,
+		},
+	})
+	require.Equal(t, 1, len(stage.Results))
+	result := stage.Results[0]
+	assert.Equal(t, ResultPending, result.Status)
+
+	err := result.callback(Callback{Status: ResultRunning})
+	require.NoError(t, err)
+
+	err = result.callback(Callback{Status: ResultPassed, Message: internal.String("no issues found")})
+	require.NoError(t, err)
+	assert.Equal(t, "no issues found", result.Message)
+
+	err = result.callback(Callback{Status: ResultFailed})
+	assert.ErrorIs(t, err, ErrResultAlreadyDone)
+}
+
+func TestResult_CallbackInvalidStatus(t *testing.T) {
+	result := &Result{Status: ResultPending}
+	err := result.callback(Callback{Status: ResultErrored})
+	assert.Error(t, err)
+}
@@ -0,0 +1,294 @@
+// Package runtask provides run tasks, which integrate third-party services
+// with runs, notifying them at stages of a run and waiting for their results
+// before proceeding.
+package runtask
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/url"
+	"time"
+
+	"github.com/leg100/otf/internal"
+	"github.com/leg100/otf/internal/resource"
+	"github.com/leg100/otf/internal/run"
+)
+
+const (
+	// TaskCategory is the only category of run task.
+	TaskCategory = "task"
+
+	// AdvisoryEnforcement permits a run to proceed if the task fails.
+	AdvisoryEnforcement EnforcementLevel = "advisory"
+	// MandatoryEnforcement stops a run if the task fails.
+	MandatoryEnforcement EnforcementLevel = "mandatory"
+)
+
+var (
+	ErrInvalidCategory         = errors.New("category must be: " + TaskCategory)
+	ErrInvalidEnforcementLevel = errors.New("enforcement level must be one of: advisory, mandatory")
+	ErrInvalidStage            = errors.New("stage must be one of: pre_plan, post_plan")
+)
+
+type (
+	// Task is a run task belonging to an organization. It is an external
+	// service that is sent a request at stages of a run.
+	Task struct {
+		ID           string
+		CreatedAt    time.Time
+		UpdatedAt    time.Time
+		Organization string
+		Name         string
+		URL          string
+		Description  string
+		Category     string
+		// HMACKey, if non-nil, is used to sign requests sent to the task.
+		HMACKey *string
+		Enabled bool
+	}
+
+	// EnforcementLevel determines whether a failed task stops a run.
+	EnforcementLevel string
+
+	// WorkspaceTask attaches a run task to a workspace, running the task at
+	// a stage of each run of the workspace.
+	WorkspaceTask struct {
+		ID               string
+		CreatedAt        time.Time
+		UpdatedAt        time.Time
+		WorkspaceID      string
+		TaskID           string
+		EnforcementLevel EnforcementLevel
+		Stage            run.TaskStage
+	}
+
+	CreateTaskOptions struct {
+		// Required: The organization to which the task belongs.
+		Organization string
+
+		// Required: The name of the task.
+		Name string
+
+		// Required: The URL to which requests are sent.
+		URL string
+
+		// Optional: A description of the task.
+		Description *string
+
+		// Required: Must be "task".
+		Category string
+
+		// Optional: A key with which to sign requests.
+		HMACKey *string
+
+		// Optional: Whether the task is enabled; defaults to true.
+		Enabled *bool
+	}
+
+	UpdateTaskOptions struct {
+		Name        *string
+		URL         *string
+		Description *string
+		Category    *string
+		// HMACKey updates the key with which to sign requests. An empty
+		// string removes the key.
+		HMACKey *string
+		Enabled *bool
+	}
+
+	CreateWorkspaceTaskOptions struct {
+		// Required: The ID of the task to attach.
+		TaskID string
+
+		// Required: The enforcement level of the task.
+		EnforcementLevel EnforcementLevel
+
+		// Optional: The stage at which the task runs; defaults to post_plan.
+		Stage *run.TaskStage
+	}
+
+	UpdateWorkspaceTaskOptions struct {
+		EnforcementLevel *EnforcementLevel
+		Stage            *run.TaskStage
+	}
+)
+
+func newTask(opts CreateTaskOptions) (*Task, error) {
+	if opts.Organization == "" {
+		return nil, internal.ErrRequiredOrg
+	}
+	task := &Task{
+		ID:           resource.NewID(resource.RunTaskKind),
+		CreatedAt:    internal.CurrentTimestamp(nil),
+		UpdatedAt:    internal.CurrentTimestamp(nil),
+		Organization: opts.Organization,
+		Enabled:      true,
+	}
+	if err := task.setName(opts.Name); err != nil {
+		return nil, err
+	}
+	if err := task.setURL(opts.URL); err != nil {
+		return nil, err
+	}
+	if err := task.setCategory(opts.Category); err != nil {
+		return nil, err
+	}
+	if opts.Description != nil {
+		task.Description = *opts.Description
+	}
+	if opts.HMACKey != nil && *opts.HMACKey != "" {
+		task.HMACKey = opts.HMACKey
+	}
+	if opts.Enabled != nil {
+		task.Enabled = *opts.Enabled
+	}
+	return task, nil
+}
+
+func (t *Task) LogValue() slog.Value {
+	attrs := []slog.Attr{
+		slog.String("id", t.ID),
+		slog.String("organization", t.Organization),
+		slog.String("name", t.Name),
+		slog.String("url", t.URL),
+		slog.Bool("enabled", t.Enabled),
+	}
+	return slog.GroupValue(attrs...)
+}
+
+func (t *Task) update(opts UpdateTaskOptions) error {
+	if opts.Name != nil {
+		if err := t.setName(*opts.Name); err != nil {
+			return err
+		}
+	}
+	if opts.URL != nil {
+		if err := t.setURL(*opts.URL); err != nil {
+			return err
+		}
+	}
+	if opts.Category != nil {
+		if err := t.setCategory(*opts.Category); err != nil {
+			return err
+		}
+	}
+	if opts.Description != nil {
+		t.Description = *opts.Description
+	}
+	if opts.HMACKey != nil {
+		if *opts.HMACKey == "" {
+			t.HMACKey = nil
+		} else {
+			t.HMACKey = opts.HMACKey
+		}
+	}
+	if opts.Enabled != nil {
+		t.Enabled = *opts.Enabled
+	}
+	t.UpdatedAt = internal.CurrentTimestamp(nil)
+	return nil
+}
+
+func (t *Task) setName(name string) error {
+	if name == "" {
+		return internal.ErrRequiredName
+	}
+	if !internal.ReStringID.MatchString(name) {
+		return internal.ErrInvalidName
+	}
+	t.Name = name
+	return nil
+}
+
+func (t *Task) setURL(s string) error {
+	u, err := url.Parse(s)
+	if err != nil {
+		return &internal.InvalidParameterError{Parameter: "url", Err: err}
+	}
+	if u.Scheme != "http" && u.Scheme != "https" || u.Host == "" {
+		return &internal.InvalidParameterError{
+			Parameter: "url",
+			Err:       fmt.Errorf("must be an absolute http or https url: %s", s),
+		}
+	}
+	t.URL = s
+	return nil
+}
+
+func (t *Task) setCategory(category string) error {
+	if category != TaskCategory {
+		return &internal.InvalidParameterError{Parameter: "category", Err: ErrInvalidCategory}
+	}
+	t.Category = category
+	return nil
+}
+
+func newWorkspaceTask(workspaceID string, opts CreateWorkspaceTaskOptions) (*WorkspaceTask, error) {
+	if opts.TaskID == "" {
+		return nil, &internal.MissingParameterError{Parameter: "task"}
+	}
+	wt := &WorkspaceTask{
+		ID:          resource.NewID(resource.WorkspaceRunTaskKind),
+		CreatedAt:   internal.CurrentTimestamp(nil),
+		UpdatedAt:   internal.CurrentTimestamp(nil),
+		WorkspaceID: workspaceID,
+		TaskID:      opts.TaskID,
+		Stage:       run.PostPlanStage,
+	}
+	if err := wt.setEnforcementLevel(opts.EnforcementLevel); err != nil {
+		return nil, err
+	}
+	if opts.Stage != nil {
+		if err := wt.setStage(*opts.Stage); err != nil {
+			return nil, err
+		}
+	}
+	return wt, nil
+}
+
+func (wt *WorkspaceTask) LogValue() slog.Value {
+	attrs := []slog.Attr{
+		slog.String("id", wt.ID),
+		slog.String("workspace_id", wt.WorkspaceID),
+		slog.String("task_id", wt.TaskID),
+		slog.String("enforcement_level", string(wt.EnforcementLevel)),
+		slog.String("stage", string(wt.Stage)),
+	}
+	return slog.GroupValue(attrs...)
+}
+
+func (wt *WorkspaceTask) update(opts UpdateWorkspaceTaskOptions) error {
+	if opts.EnforcementLevel != nil {
+		if err := wt.setEnforcementLevel(*opts.EnforcementLevel); err != nil {
+			return err
+		}
+	}
+	if opts.Stage != nil {
+		if err := wt.setStage(*opts.Stage); err != nil {
+			return err
+		}
+	}
+	wt.UpdatedAt = internal.CurrentTimestamp(nil)
+	return nil
+}
+
+func (wt *WorkspaceTask) setEnforcementLevel(level EnforcementLevel) error {
+	switch level {
+	case AdvisoryEnforcement, MandatoryEnforcement:
+		wt.EnforcementLevel = level
+		return nil
+	default:
+		return &internal.InvalidParameterError{Parameter: "enforcement-level", Err: ErrInvalidEnforcementLevel}
+	}
+}
+
+func (wt *WorkspaceTask) setStage(stage run.TaskStage) error {
+	switch stage {
+	case run.PrePlanStage, run.PostPlanStage:
+		wt.Stage = stage
+		return nil
+	default:
+		return &internal.InvalidParameterError{Parameter: "stage", Err: ErrInvalidStage}
+	}
+}
@@ -0,0 +1,85 @@
+package runtask
+
+import (
+	"testing"
+
+	"github.com/leg100/otf/internal"
+	"github.com/leg100/otf/internal/run"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewTask(t *testing.T) {
+	tests := []struct {
+		name    string
+		opts    CreateTaskOptions
+		wantErr bool
+	}{
+		{"valid", CreateTaskOptions{Organization: "acme", Name: "scanner", URL: "https://scanner.example.com", Category: TaskCategory}, false},
+		{"missing name", CreateTaskOptions{Organization: "acme", URL: "https://scanner.example.com", Category: TaskCategory}, true},
+		{"relative url", CreateTaskOptions{Organization: "acme", Name: "scanner", URL: "/scanner", Category: TaskCategory}, true},
+		{"unsupported scheme", CreateTaskOptions{Organization: "acme", Name: "scanner", URL: "ftp://scanner.example.com", Category: TaskCategory}, true},
+		{"invalid category", CreateTaskOptions{Organization: "acme", Name: "scanner", URL: "https://scanner.example.com", Category: "check"}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := newTask(tt.opts)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.True(t, got.Enabled)
+			assert.Nil(t, got.HMACKey)
+		})
+	}
+}
+
+func TestTask_Update(t *testing.T) {
+	task, err := newTask(CreateTaskOptions{
+		Organization: "acme",
+		Name:         "scanner",
+		URL:          "https://scanner.example.com",
+		Category:     TaskCategory,
+		HMACKey:      internal.String("secret"),
+	})
+	require.NoError(t, err)
+	require.NotNil(t, task.HMACKey)
+
+	err = task.update(UpdateTaskOptions{
+		Enabled: internal.Bool(false),
+		HMACKey: internal.String(""),
+	})
+	require.NoError(t, err)
+	assert.False(t, task.Enabled)
+	assert.Nil(t, task.HMACKey, "empty key should remove key")
+}
+
+func TestNewWorkspaceTask(t *testing.T) {
+	t.Run("defaults to post-plan stage", func(t *testing.T) {
+		got, err := newWorkspaceTask("ws-123", CreateWorkspaceTaskOptions{
+			TaskID:           "task-123",
+			EnforcementLevel: MandatoryEnforcement,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, run.PostPlanStage, got.Stage)
+	})
+
+	t.Run("invalid enforcement level", func(t *testing.T) {
+		_, err := newWorkspaceTask("ws-123", CreateWorkspaceTaskOptions{
+			TaskID:           "task-123",
+			EnforcementLevel: "strict",
+		})
+		assert.Error(t, err)
+	})
+
+	t.Run("invalid stage", func(t *testing.T) {
+		stage := run.TaskStage("pre_apply")
+		_, err := newWorkspaceTask("ws-123", CreateWorkspaceTaskOptions{
+			TaskID:           "task-123",
+			EnforcementLevel: AdvisoryEnforcement,
+			Stage:            &stage,
+		})
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,380 @@
+package runtask
+
+import (
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/leg100/otf/internal"
+	"github.com/leg100/otf/internal/http/decode"
+	"github.com/leg100/otf/internal/resource"
+	"github.com/leg100/otf/internal/run"
+	"github.com/leg100/otf/internal/tfeapi"
+	"github.com/leg100/otf/internal/tfeapi/types"
+)
+
+type (
+	// tfe implements the TFE run tasks API:
+	//
+	// https://developer.hashicorp.com/terraform/cloud-docs/api-docs/run-tasks/run-tasks
+	tfe struct {
+		*Service
+		*tfeapi.Responder
+	}
+
+	// workspaceTaskParams identifies a workspace task in the path of a
+	// request.
+	workspaceTaskParams struct {
+		WorkspaceID string `schema:"workspace_id,required"`
+		ID          string `schema:"id,required"`
+	}
+)
+
+func (a *tfe) addHandlers(r *mux.Router) {
+	r = r.PathPrefix(tfeapi.APIPrefixV2).Subrouter()
+
+	r.HandleFunc("/organizations/{organization_name}/tasks", a.listTasks).Methods("GET")
+	r.HandleFunc("/organizations/{organization_name}/tasks", a.createTask).Methods("POST")
+	r.HandleFunc("/tasks/{id}", a.getTask).Methods("GET")
+	r.HandleFunc("/tasks/{id}", a.updateTask).Methods("PATCH")
+	r.HandleFunc("/tasks/{id}", a.deleteTask).Methods("DELETE")
+
+	r.HandleFunc("/workspaces/{workspace_id}/tasks", a.listWorkspaceTasks).Methods("GET")
+	r.HandleFunc("/workspaces/{workspace_id}/tasks", a.createWorkspaceTask).Methods("POST")
+	r.HandleFunc("/workspaces/{workspace_id}/tasks/{id}", a.getWorkspaceTask).Methods("GET")
+	r.HandleFunc("/workspaces/{workspace_id}/tasks/{id}", a.updateWorkspaceTask).Methods("PATCH")
+	r.HandleFunc("/workspaces/{workspace_id}/tasks/{id}", a.deleteWorkspaceTask).Methods("DELETE")
+
+	r.HandleFunc("/runs/{run_id}/task-stages", a.listStages).Methods("GET")
+	r.HandleFunc("/task-stages/{id}", a.getStage).Methods("GET")
+	r.HandleFunc("/task-results/{id}", a.getResult).Methods("GET")
+	r.HandleFunc("/task-results/{id}/callback", a.callback).Methods("PATCH")
+}
+
+func (a *tfe) listTasks(w http.ResponseWriter, r *http.Request) {
+	var params struct {
+		Organization string `schema:"organization_name,required"`
+		resource.PageOptions
+	}
+	if err := decode.All(&params, r); err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	tasks, err := a.ListTasks(r.Context(), params.Organization)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	items := make([]*types.RunTask, len(tasks))
+	for i, from := range tasks {
+		items[i] = a.convertTask(from)
+	}
+	page := resource.NewPage(items, params.PageOptions, nil)
+	a.RespondWithPage(w, r, page.Items, page.Pagination)
+}
+
+func (a *tfe) createTask(w http.ResponseWriter, r *http.Request) {
+	org, err := decode.Param("organization_name", r)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	var params types.RunTaskCreateOptions
+	if err := tfeapi.Unmarshal(r.Body, &params); err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	task, err := a.CreateTask(r.Context(), CreateTaskOptions{
+		Organization: org,
+		Name:         params.Name,
+		URL:          params.URL,
+		Description:  params.Description,
+		Category:     params.Category,
+		HMACKey:      params.HMACKey,
+		Enabled:      params.Enabled,
+	})
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	a.Respond(w, r, a.convertTask(task), http.StatusCreated)
+}
+
+func (a *tfe) getTask(w http.ResponseWriter, r *http.Request) {
+	id, err := decode.Param("id", r)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	task, err := a.GetTask(r.Context(), id)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	a.Respond(w, r, a.convertTask(task), http.StatusOK)
+}
+
+func (a *tfe) updateTask(w http.ResponseWriter, r *http.Request) {
+	id, err := decode.Param("id", r)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	var params types.RunTaskUpdateOptions
+	if err := tfeapi.Unmarshal(r.Body, &params); err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	task, err := a.UpdateTask(r.Context(), id, UpdateTaskOptions{
+		Name:        params.Name,
+		URL:         params.URL,
+		Description: params.Description,
+		Category:    params.Category,
+		HMACKey:     params.HMACKey,
+		Enabled:     params.Enabled,
+	})
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	a.Respond(w, r, a.convertTask(task), http.StatusOK)
+}
+
+func (a *tfe) deleteTask(w http.ResponseWriter, r *http.Request) {
+	id, err := decode.Param("id", r)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	if err := a.DeleteTask(r.Context(), id); err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (a *tfe) listWorkspaceTasks(w http.ResponseWriter, r *http.Request) {
+	var params struct {
+		WorkspaceID string `schema:"workspace_id,required"`
+		resource.PageOptions
+	}
+	if err := decode.All(&params, r); err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	tasks, err := a.ListWorkspaceTasks(r.Context(), params.WorkspaceID)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	items := make([]*types.WorkspaceRunTask, len(tasks))
+	for i, from := range tasks {
+		items[i] = a.convertWorkspaceTask(from)
+	}
+	page := resource.NewPage(items, params.PageOptions, nil)
+	a.RespondWithPage(w, r, page.Items, page.Pagination)
+}
+
+func (a *tfe) createWorkspaceTask(w http.ResponseWriter, r *http.Request) {
+	workspaceID, err := decode.Param("workspace_id", r)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	var params types.WorkspaceRunTaskCreateOptions
+	if err := tfeapi.Unmarshal(r.Body, &params); err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	if params.RunTask == nil {
+		tfeapi.Error(w, &internal.MissingParameterError{Parameter: "task"})
+		return
+	}
+	wt, err := a.CreateWorkspaceTask(r.Context(), workspaceID, CreateWorkspaceTaskOptions{
+		TaskID:           params.RunTask.ID,
+		EnforcementLevel: EnforcementLevel(params.EnforcementLevel),
+		Stage:            (*run.TaskStage)(params.Stage),
+	})
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	a.Respond(w, r, a.convertWorkspaceTask(wt), http.StatusCreated)
+}
+
+func (a *tfe) getWorkspaceTask(w http.ResponseWriter, r *http.Request) {
+	var params workspaceTaskParams
+	if err := decode.All(&params, r); err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	wt, err := a.GetWorkspaceTask(r.Context(), params.WorkspaceID, params.ID)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	a.Respond(w, r, a.convertWorkspaceTask(wt), http.StatusOK)
+}
+
+func (a *tfe) updateWorkspaceTask(w http.ResponseWriter, r *http.Request) {
+	var params workspaceTaskParams
+	if err := decode.All(&params, r); err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	var opts types.WorkspaceRunTaskUpdateOptions
+	if err := tfeapi.Unmarshal(r.Body, &opts); err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	wt, err := a.UpdateWorkspaceTask(r.Context(), params.WorkspaceID, params.ID, UpdateWorkspaceTaskOptions{
+		EnforcementLevel: (*EnforcementLevel)(opts.EnforcementLevel),
+		Stage:            (*run.TaskStage)(opts.Stage),
+	})
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	a.Respond(w, r, a.convertWorkspaceTask(wt), http.StatusOK)
+}
+
+func (a *tfe) deleteWorkspaceTask(w http.ResponseWriter, r *http.Request) {
+	var params workspaceTaskParams
+	if err := decode.All(&params, r); err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	if err := a.DeleteWorkspaceTask(r.Context(), params.WorkspaceID, params.ID); err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (a *tfe) listStages(w http.ResponseWriter, r *http.Request) {
+	var params struct {
+		RunID string `schema:"run_id,required"`
+		resource.PageOptions
+	}
+	if err := decode.All(&params, r); err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	stages, err := a.ListStages(r.Context(), params.RunID)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	items := make([]*types.TaskStage, len(stages))
+	for i, from := range stages {
+		items[i] = a.convertStage(from)
+	}
+	page := resource.NewPage(items, params.PageOptions, nil)
+	a.RespondWithPage(w, r, page.Items, page.Pagination)
+}
+
+func (a *tfe) getStage(w http.ResponseWriter, r *http.Request) {
+	id, err := decode.Param("id", r)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	stage, err := a.GetStage(r.Context(), id)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	a.Respond(w, r, a.convertStage(stage), http.StatusOK)
+}
+
+func (a *tfe) getResult(w http.ResponseWriter, r *http.Request) {
+	id, err := decode.Param("id", r)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	result, err := a.GetResult(r.Context(), id)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	a.Respond(w, r, a.convertResult(result), http.StatusOK)
+}
+
+// callback receives the result of a task:
+//
+// https://developer.hashicorp.com/terraform/enterprise/integrations/run-tasks#run-task-callback
+func (a *tfe) callback(w http.ResponseWriter, r *http.Request) {
+	id, err := decode.Param("id", r)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	var params types.TaskResultCallbackOptions
+	if err := tfeapi.Unmarshal(r.Body, &params); err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	if _, err := a.Callback(r.Context(), id, Callback{
+		Status:  ResultStatus(params.Status),
+		Message: params.Message,
+		URL:     params.URL,
+	}); err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+func (a *tfe) convertTask(from *Task) *types.RunTask {
+	return &types.RunTask{
+		ID:           from.ID,
+		Name:         from.Name,
+		URL:          from.URL,
+		Description:  from.Description,
+		Category:     from.Category,
+		Enabled:      from.Enabled,
+		Organization: &types.Organization{Name: from.Organization},
+	}
+}
+
+func (a *tfe) convertWorkspaceTask(from *WorkspaceTask) *types.WorkspaceRunTask {
+	return &types.WorkspaceRunTask{
+		ID:               from.ID,
+		EnforcementLevel: string(from.EnforcementLevel),
+		Stage:            string(from.Stage),
+		RunTask:          &types.RunTask{ID: from.TaskID},
+		Workspace:        &types.Workspace{ID: from.WorkspaceID},
+	}
+}
+
+func (a *tfe) convertStage(from *Stage) *types.TaskStage {
+	to := &types.TaskStage{
+		ID:        from.ID,
+		Stage:     string(from.Stage),
+		Status:    string(from.Status),
+		CreatedAt: from.CreatedAt,
+		UpdatedAt: from.UpdatedAt,
+		Run:       &types.Run{ID: from.RunID},
+	}
+	for _, result := range from.Results {
+		to.TaskResults = append(to.TaskResults, a.convertResult(result))
+	}
+	return to
+}
+
+func (a *tfe) convertResult(from *Result) *types.TaskResult {
+	return &types.TaskResult{
+		ID:                            from.ID,
+		Status:                        string(from.Status),
+		Message:                       from.Message,
+		URL:                           from.URL,
+		CreatedAt:                     from.CreatedAt,
+		UpdatedAt:                     from.UpdatedAt,
+		TaskID:                        from.TaskID,
+		TaskName:                      from.TaskName,
+		TaskURL:                       from.TaskURL,
+		WorkspaceTaskID:               from.WorkspaceTaskID,
+		WorkspaceTaskEnforcementLevel: string(from.EnforcementLevel),
+		TaskStage:                     &types.TaskStage{ID: from.StageID},
+	}
+}
@@ -0,0 +1,67 @@
+package runtask
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/leg100/otf/internal"
+	"github.com/leg100/otf/internal/rbac"
+	"github.com/leg100/otf/internal/tokens"
+)
+
+// ResultTokenKind is the kind of the access token sent to a task, with
+// which the task retrieves details of the run and reports its result.
+const ResultTokenKind tokens.Kind = "task_result_token"
+
+// resultSubject is the subject of a task result token, i.e. a task executing
+// at a stage of a run, for the purposes of authorization and auditing.
+type resultSubject struct {
+	resultID     string
+	workspaceID  string
+	organization string
+}
+
+// resultSubjectFromContext retrieves a task result subject from a context
+func resultSubjectFromContext(ctx context.Context) (*resultSubject, error) {
+	subj, err := internal.SubjectFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	rs, ok := subj.(*resultSubject)
+	if !ok {
+		return nil, fmt.Errorf("subject found in context but it is not a task result")
+	}
+	return rs, nil
+}
+
+func (s *resultSubject) String() string { return s.resultID }
+
+func (s *resultSubject) IsSiteAdmin() bool   { return false }
+func (s *resultSubject) IsOwner(string) bool { return false }
+
+func (s *resultSubject) Organizations() []string { return nil }
+
+func (*resultSubject) CanAccessSite(action rbac.Action) bool {
+	return false
+}
+
+func (*resultSubject) CanAccessTeam(rbac.Action, string) bool {
+	return false
+}
+
+func (*resultSubject) CanAccessOrganization(action rbac.Action, name string) bool {
+	return false
+}
+
+func (s *resultSubject) CanAccessWorkspace(action rbac.Action, policy internal.WorkspacePolicy) bool {
+	if policy.WorkspaceID != s.workspaceID {
+		return false
+	}
+	// a task may only retrieve details of the run and its workspace.
+	switch action {
+	case rbac.GetRunAction, rbac.GetPlanFileAction, rbac.GetWorkspaceAction, rbac.GetConfigurationVersionAction, rbac.DownloadConfigurationVersionAction:
+		return true
+	default:
+		return false
+	}
+}
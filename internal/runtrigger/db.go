@@ -0,0 +1,85 @@
+package runtrigger
+
+import (
+	"context"
+
+	"github.com/jackc/pgtype"
+	"github.com/leg100/otf/internal/sql"
+	"github.com/leg100/otf/internal/sql/pggen"
+)
+
+type (
+	// pgdb is a run trigger database on postgres
+	pgdb struct {
+		*sql.DB // provides access to generated SQL queries
+	}
+
+	pgresult struct {
+		RunTriggerID            pgtype.Text        `json:"run_trigger_id"`
+		CreatedAt               pgtype.Timestamptz `json:"created_at"`
+		WorkspaceID             pgtype.Text        `json:"workspace_id"`
+		WorkspaceName           pgtype.Text        `json:"workspace_name"`
+		SourceableWorkspaceID   pgtype.Text        `json:"sourceable_workspace_id"`
+		SourceableWorkspaceName pgtype.Text        `json:"sourceable_workspace_name"`
+	}
+)
+
+func (r pgresult) toRunTrigger() *RunTrigger {
+	return &RunTrigger{
+		ID:             r.RunTriggerID.String,
+		CreatedAt:      r.CreatedAt.Time.UTC(),
+		WorkspaceID:    r.WorkspaceID.String,
+		WorkspaceName:  r.WorkspaceName.String,
+		SourceableID:   r.SourceableWorkspaceID.String,
+		SourceableName: r.SourceableWorkspaceName.String,
+	}
+}
+
+func (db *pgdb) create(ctx context.Context, rt *RunTrigger) error {
+	_, err := db.Conn(ctx).InsertRunTrigger(ctx, pggen.InsertRunTriggerParams{
+		RunTriggerID:          sql.String(rt.ID),
+		CreatedAt:             sql.Timestamptz(rt.CreatedAt),
+		WorkspaceID:           sql.String(rt.WorkspaceID),
+		SourceableWorkspaceID: sql.String(rt.SourceableID),
+	})
+	return sql.Error(err)
+}
+
+func (db *pgdb) get(ctx context.Context, id string) (*RunTrigger, error) {
+	row, err := db.Conn(ctx).FindRunTrigger(ctx, sql.String(id))
+	if err != nil {
+		return nil, sql.Error(err)
+	}
+	return pgresult(row).toRunTrigger(), nil
+}
+
+// listInbound lists run triggers that queue runs in the given workspace.
+func (db *pgdb) listInbound(ctx context.Context, workspaceID string) ([]*RunTrigger, error) {
+	rows, err := db.Conn(ctx).FindRunTriggersByWorkspaceID(ctx, sql.String(workspaceID))
+	if err != nil {
+		return nil, sql.Error(err)
+	}
+	triggers := make([]*RunTrigger, len(rows))
+	for i, row := range rows {
+		triggers[i] = pgresult(row).toRunTrigger()
+	}
+	return triggers, nil
+}
+
+// listOutbound lists run triggers that are sourced from the given workspace.
+func (db *pgdb) listOutbound(ctx context.Context, workspaceID string) ([]*RunTrigger, error) {
+	rows, err := db.Conn(ctx).FindRunTriggersBySourceableWorkspaceID(ctx, sql.String(workspaceID))
+	if err != nil {
+		return nil, sql.Error(err)
+	}
+	triggers := make([]*RunTrigger, len(rows))
+	for i, row := range rows {
+		triggers[i] = pgresult(row).toRunTrigger()
+	}
+	return triggers, nil
+}
+
+func (db *pgdb) delete(ctx context.Context, id string) error {
+	_, err := db.Conn(ctx).DeleteRunTrigger(ctx, sql.String(id))
+	return sql.Error(err)
+}
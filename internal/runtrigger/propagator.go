@@ -0,0 +1,91 @@
+package runtrigger
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/leg100/otf/internal"
+	"github.com/leg100/otf/internal/logr"
+	"github.com/leg100/otf/internal/pubsub"
+	"github.com/leg100/otf/internal/run"
+	"github.com/leg100/otf/internal/sql"
+)
+
+// LockID guarantees only one propagator on a cluster is running at any
+// time.
+const LockID int64 = 5577006791947779414
+
+type (
+	// Propagator queues runs in workspaces in response to successful applies
+	// in their source workspaces.
+	Propagator struct {
+		logr.Logger
+
+		runs propagatorRunClient
+		db   propagatorDB
+	}
+
+	PropagatorOptions struct {
+		RunClient propagatorRunClient
+
+		logr.Logger
+		*sql.DB
+	}
+
+	propagatorRunClient interface {
+		Create(ctx context.Context, workspaceID string, opts run.CreateOptions) (*run.Run, error)
+		Watch(context.Context) (<-chan pubsub.Event[*run.Run], func())
+	}
+
+	propagatorDB interface {
+		listOutbound(ctx context.Context, workspaceID string) ([]*RunTrigger, error)
+	}
+)
+
+func NewPropagator(opts PropagatorOptions) *Propagator {
+	return &Propagator{
+		Logger: opts.Logger.WithValues("component", "run-trigger-propagator"),
+		runs:   opts.RunClient,
+		db:     &pgdb{opts.DB},
+	}
+}
+
+// Start the propagator daemon. Should be started in a go-routine.
+func (p *Propagator) Start(ctx context.Context) error {
+	sub, unsub := p.runs.Watch(ctx)
+	defer unsub()
+
+	for event := range sub {
+		if event.Type != pubsub.UpdatedEvent {
+			continue
+		}
+		if err := p.handleRun(ctx, event.Payload); err != nil {
+			p.Error(err, "handling event", "event", event.Type)
+		}
+	}
+	return pubsub.ErrSubscriptionTerminated
+}
+
+func (p *Propagator) handleRun(ctx context.Context, r *run.Run) error {
+	if r.Status != run.RunApplied {
+		// only successful applies trigger runs
+		return nil
+	}
+	triggers, err := p.db.listOutbound(ctx, r.WorkspaceID)
+	if err != nil {
+		return err
+	}
+	for _, rt := range triggers {
+		created, err := p.runs.Create(ctx, rt.WorkspaceID, run.CreateOptions{
+			Source:  run.SourceRunTrigger,
+			Message: internal.String(fmt.Sprintf("Queued automatically from %s (%s)", rt.SourceableName, r.ID)),
+		})
+		if err != nil {
+			// carry on queuing runs in remaining workspaces
+			p.Error(err, "queuing run", "trigger", rt, "source_run", r.ID)
+			continue
+		}
+		p.V(1).Info("queued run", "run", created.ID, "trigger", rt, "source_run", r.ID)
+	}
+	return nil
+}
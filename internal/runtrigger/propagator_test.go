@@ -0,0 +1,102 @@
+package runtrigger
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/leg100/otf/internal/logr"
+	"github.com/leg100/otf/internal/pubsub"
+	"github.com/leg100/otf/internal/run"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type (
+	fakePropagatorDB struct {
+		triggers []*RunTrigger
+	}
+	fakePropagatorRunClient struct {
+		// IDs of workspaces in which runs failed to be created
+		failures []string
+		// IDs of workspaces in which runs were created
+		created []string
+	}
+)
+
+func TestPropagator_handleRun(t *testing.T) {
+	ctx := context.Background()
+	triggers := []*RunTrigger{
+		{WorkspaceID: "ws-downstream-1", SourceableID: "ws-upstream", SourceableName: "upstream"},
+		{WorkspaceID: "ws-downstream-2", SourceableID: "ws-upstream", SourceableName: "upstream"},
+	}
+
+	tests := []struct {
+		name     string
+		run      *run.Run
+		failures []string
+		want     []string
+	}{
+		{
+			name: "applied",
+			run:  &run.Run{ID: "run-123", Status: run.RunApplied, WorkspaceID: "ws-upstream"},
+			want: []string{"ws-downstream-1", "ws-downstream-2"},
+		},
+		{
+			name: "planned and finished",
+			run:  &run.Run{ID: "run-123", Status: run.RunPlannedAndFinished, WorkspaceID: "ws-upstream"},
+		},
+		{
+			name: "errored",
+			run:  &run.Run{ID: "run-123", Status: run.RunErrored, WorkspaceID: "ws-upstream"},
+		},
+		{
+			name:     "continue after failing to queue run",
+			run:      &run.Run{ID: "run-123", Status: run.RunApplied, WorkspaceID: "ws-upstream"},
+			failures: []string{"ws-downstream-1"},
+			want:     []string{"ws-downstream-2"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			runs := &fakePropagatorRunClient{failures: tt.failures}
+			p := &Propagator{
+				Logger: logr.Discard(),
+				runs:   runs,
+				db:     &fakePropagatorDB{triggers: triggers},
+			}
+
+			err := p.handleRun(ctx, tt.run)
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.want, runs.created)
+		})
+	}
+}
+
+func (f *fakePropagatorDB) listOutbound(ctx context.Context, workspaceID string) ([]*RunTrigger, error) {
+	var triggers []*RunTrigger
+	for _, rt := range f.triggers {
+		if rt.SourceableID == workspaceID {
+			triggers = append(triggers, rt)
+		}
+	}
+	return triggers, nil
+}
+
+func (f *fakePropagatorRunClient) Create(ctx context.Context, workspaceID string, opts run.CreateOptions) (*run.Run, error) {
+	for _, failure := range f.failures {
+		if failure == workspaceID {
+			return nil, errors.New("no configuration version found")
+		}
+	}
+	if opts.Source != run.SourceRunTrigger {
+		return nil, errors.New("unexpected source")
+	}
+	f.created = append(f.created, workspaceID)
+	return &run.Run{ID: "run-" + workspaceID, WorkspaceID: workspaceID}, nil
+}
+
+func (f *fakePropagatorRunClient) Watch(context.Context) (<-chan pubsub.Event[*run.Run], func()) {
+	return nil, func() {}
+}
@@ -0,0 +1,64 @@
+// Package runtrigger queues runs in workspaces following a successful apply in
+// another workspace.
+package runtrigger
+
+import (
+	"errors"
+	"time"
+
+	"log/slog"
+
+	"github.com/leg100/otf/internal"
+	"github.com/leg100/otf/internal/resource"
+)
+
+// MaxSourceWorkspaces is the maximum number of source workspaces that can be
+// connected to a single workspace.
+const MaxSourceWorkspaces = 20
+
+var (
+	ErrSameWorkspace         = errors.New("a workspace cannot trigger runs in itself")
+	ErrDifferentOrganization = errors.New("source workspace must belong to the same organization")
+	ErrTooManySources        = errors.New("workspace cannot have more than 20 source workspaces")
+)
+
+type (
+	// RunTrigger connects a source workspace to a workspace: when an apply
+	// completes successfully in the source workspace a run is queued in the
+	// workspace.
+	RunTrigger struct {
+		ID             string
+		CreatedAt      time.Time
+		WorkspaceID    string
+		WorkspaceName  string
+		SourceableID   string
+		SourceableName string
+	}
+
+	// Direction is the direction of a run trigger relative to a workspace.
+	Direction string
+)
+
+const (
+	// Inbound run triggers queue runs in the workspace.
+	Inbound Direction = "inbound"
+	// Outbound run triggers queue runs in other workspaces.
+	Outbound Direction = "outbound"
+)
+
+func newRunTrigger(workspaceID, sourceableID string) *RunTrigger {
+	return &RunTrigger{
+		ID:           resource.NewID(resource.RunTriggerKind),
+		CreatedAt:    internal.CurrentTimestamp(nil),
+		WorkspaceID:  workspaceID,
+		SourceableID: sourceableID,
+	}
+}
+
+func (rt *RunTrigger) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.String("id", rt.ID),
+		slog.String("workspace_id", rt.WorkspaceID),
+		slog.String("sourceable_id", rt.SourceableID),
+	)
+}
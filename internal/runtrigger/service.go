@@ -0,0 +1,161 @@
+package runtrigger
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gorilla/mux"
+	"github.com/leg100/otf/internal"
+	"github.com/leg100/otf/internal/logr"
+	"github.com/leg100/otf/internal/rbac"
+	"github.com/leg100/otf/internal/sql"
+	"github.com/leg100/otf/internal/sql/pggen"
+	"github.com/leg100/otf/internal/tfeapi"
+	"github.com/leg100/otf/internal/workspace"
+)
+
+type (
+	Service struct {
+		logr.Logger
+
+		workspaceAuthorizer internal.Authorizer // authorize workspaces actions
+		workspaces          workspaceClient
+		db                  *pgdb
+		api                 *tfe
+	}
+
+	Options struct {
+		*sql.DB
+		*tfeapi.Responder
+		logr.Logger
+
+		WorkspaceAuthorizer internal.Authorizer
+		WorkspaceService    workspaceClient
+	}
+
+	workspaceClient interface {
+		Get(ctx context.Context, workspaceID string) (*workspace.Workspace, error)
+	}
+)
+
+func NewService(opts Options) *Service {
+	svc := Service{
+		Logger:              opts.Logger,
+		workspaceAuthorizer: opts.WorkspaceAuthorizer,
+		workspaces:          opts.WorkspaceService,
+		db:                  &pgdb{opts.DB},
+	}
+	svc.api = &tfe{
+		Service:   &svc,
+		Responder: opts.Responder,
+	}
+	return &svc
+}
+
+func (s *Service) AddHandlers(r *mux.Router) {
+	s.api.addHandlers(r)
+}
+
+// Create a run trigger, queuing runs in the workspace whenever an apply
+// completes successfully in the source workspace.
+func (s *Service) Create(ctx context.Context, workspaceID, sourceableID string) (*RunTrigger, error) {
+	subject, err := s.workspaceAuthorizer.CanAccess(ctx, rbac.CreateRunTriggerAction, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+	// subject must also be able to read the source workspace
+	if _, err := s.workspaceAuthorizer.CanAccess(ctx, rbac.GetWorkspaceAction, sourceableID); err != nil {
+		return nil, err
+	}
+	if workspaceID == sourceableID {
+		return nil, ErrSameWorkspace
+	}
+	ws, err := s.workspaces.Get(ctx, workspaceID)
+	if err != nil {
+		return nil, fmt.Errorf("retrieving workspace: %w", err)
+	}
+	sourceable, err := s.workspaces.Get(ctx, sourceableID)
+	if err != nil {
+		return nil, fmt.Errorf("retrieving source workspace: %w", err)
+	}
+	if ws.Organization != sourceable.Organization {
+		return nil, ErrDifferentOrganization
+	}
+
+	rt := newRunTrigger(workspaceID, sourceableID)
+	rt.WorkspaceName = ws.Name
+	rt.SourceableName = sourceable.Name
+
+	err = s.db.Tx(ctx, func(ctx context.Context, _ pggen.Querier) error {
+		existing, err := s.db.listInbound(ctx, workspaceID)
+		if err != nil {
+			return err
+		}
+		if len(existing) >= MaxSourceWorkspaces {
+			return ErrTooManySources
+		}
+		return s.db.create(ctx, rt)
+	})
+	if err != nil {
+		s.Error(err, "creating run trigger", "trigger", rt, "subject", subject)
+		return nil, err
+	}
+	s.V(1).Info("created run trigger", "trigger", rt, "subject", subject)
+	return rt, nil
+}
+
+func (s *Service) Get(ctx context.Context, id string) (*RunTrigger, error) {
+	rt, err := s.db.get(ctx, id)
+	if err != nil {
+		s.Error(err, "retrieving run trigger", "id", id)
+		return nil, err
+	}
+	subject, err := s.workspaceAuthorizer.CanAccess(ctx, rbac.GetRunTriggerAction, rt.WorkspaceID)
+	if err != nil {
+		return nil, err
+	}
+	s.V(9).Info("retrieved run trigger", "trigger", rt, "subject", subject)
+	return rt, nil
+}
+
+// List run triggers for a workspace: inbound triggers queue runs in the
+// workspace, whereas outbound triggers queue runs in other workspaces.
+func (s *Service) List(ctx context.Context, workspaceID string, direction Direction) ([]*RunTrigger, error) {
+	subject, err := s.workspaceAuthorizer.CanAccess(ctx, rbac.ListRunTriggersAction, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+	var triggers []*RunTrigger
+	switch direction {
+	case Inbound:
+		triggers, err = s.db.listInbound(ctx, workspaceID)
+	case Outbound:
+		triggers, err = s.db.listOutbound(ctx, workspaceID)
+	default:
+		return nil, fmt.Errorf("invalid run trigger direction: %s", direction)
+	}
+	if err != nil {
+		s.Error(err, "listing run triggers", "workspace_id", workspaceID, "direction", direction, "subject", subject)
+		return nil, err
+	}
+	s.V(9).Info("listed run triggers", "workspace_id", workspaceID, "direction", direction, "total", len(triggers), "subject", subject)
+	return triggers, nil
+}
+
+func (s *Service) Delete(ctx context.Context, id string) error {
+	rt, err := s.db.get(ctx, id)
+	if err != nil {
+		s.Error(err, "retrieving run trigger", "id", id)
+		return err
+	}
+	subject, err := s.workspaceAuthorizer.CanAccess(ctx, rbac.DeleteRunTriggerAction, rt.WorkspaceID)
+	if err != nil {
+		return err
+	}
+	if err := s.db.delete(ctx, id); err != nil {
+		s.Error(err, "deleting run trigger", "trigger", rt, "subject", subject)
+		return err
+	}
+	s.V(1).Info("deleted run trigger", "trigger", rt, "subject", subject)
+	return nil
+}
@@ -0,0 +1,130 @@
+package runtrigger
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/leg100/otf/internal"
+	"github.com/leg100/otf/internal/http/decode"
+	"github.com/leg100/otf/internal/resource"
+	"github.com/leg100/otf/internal/tfeapi"
+	"github.com/leg100/otf/internal/tfeapi/types"
+)
+
+type tfe struct {
+	*Service
+	*tfeapi.Responder
+}
+
+func init() {
+	decode.RegisterEnum(types.RunTriggerInbound, types.RunTriggerOutbound)
+}
+
+func (a *tfe) addHandlers(r *mux.Router) {
+	r = r.PathPrefix(tfeapi.APIPrefixV2).Subrouter()
+
+	r.HandleFunc("/workspaces/{workspace_id}/run-triggers", a.createRunTrigger).Methods("POST")
+	r.HandleFunc("/workspaces/{workspace_id}/run-triggers", a.listRunTriggers).Methods("GET")
+	r.HandleFunc("/run-triggers/{id}", a.getRunTrigger).Methods("GET")
+	r.HandleFunc("/run-triggers/{id}", a.deleteRunTrigger).Methods("DELETE")
+}
+
+func (a *tfe) createRunTrigger(w http.ResponseWriter, r *http.Request) {
+	workspaceID, err := decode.Param("workspace_id", r)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	var params types.RunTriggerCreateOptions
+	if err := tfeapi.Unmarshal(r.Body, &params); err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	if params.Sourceable == nil || params.Sourceable.ID == "" {
+		tfeapi.Error(w, &internal.MissingParameterError{Parameter: "sourceable"})
+		return
+	}
+
+	rt, err := a.Create(r.Context(), workspaceID, params.Sourceable.ID)
+	if errors.Is(err, ErrSameWorkspace) ||
+		errors.Is(err, ErrDifferentOrganization) ||
+		errors.Is(err, ErrTooManySources) {
+		tfeapi.Error(w, &internal.HTTPError{Code: http.StatusUnprocessableEntity, Message: err.Error()})
+		return
+	} else if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+
+	a.Respond(w, r, a.convert(rt), http.StatusCreated)
+}
+
+func (a *tfe) listRunTriggers(w http.ResponseWriter, r *http.Request) {
+	var params struct {
+		WorkspaceID string `schema:"workspace_id,required"`
+		// Required: The type of run triggers to list, either inbound or
+		// outbound.
+		Direction types.RunTriggerFilterOp `schema:"filter[run-trigger][type],required"`
+		resource.PageOptions
+	}
+	if err := decode.All(&params, r); err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	triggers, err := a.List(r.Context(), params.WorkspaceID, Direction(params.Direction))
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+
+	// convert items
+	items := make([]*types.RunTrigger, len(triggers))
+	for i, from := range triggers {
+		items[i] = a.convert(from)
+	}
+	page := resource.NewPage(items, params.PageOptions, nil)
+	a.RespondWithPage(w, r, page.Items, page.Pagination)
+}
+
+func (a *tfe) getRunTrigger(w http.ResponseWriter, r *http.Request) {
+	id, err := decode.Param("id", r)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+
+	rt, err := a.Get(r.Context(), id)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+
+	a.Respond(w, r, a.convert(rt), http.StatusOK)
+}
+
+func (a *tfe) deleteRunTrigger(w http.ResponseWriter, r *http.Request) {
+	id, err := decode.Param("id", r)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+
+	if err := a.Delete(r.Context(), id); err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (a *tfe) convert(from *RunTrigger) *types.RunTrigger {
+	return &types.RunTrigger{
+		ID:             from.ID,
+		CreatedAt:      from.CreatedAt,
+		SourceableName: from.SourceableName,
+		WorkspaceName:  from.WorkspaceName,
+		Sourceable:     &types.Workspace{ID: from.SourceableID},
+		Workspace:      &types.Workspace{ID: from.WorkspaceID},
+	}
+}
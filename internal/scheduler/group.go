@@ -0,0 +1,101 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"slices"
+
+	"github.com/go-logr/logr"
+	otfrun "github.com/leg100/otf/internal/run"
+)
+
+// group serializes the applies of the runs in a serialization group: the
+// apply of a confirmed run is enqueued only once no other run in the group is
+// applying.
+type group struct {
+	logr.Logger
+	runClient
+
+	// runs with an enqueued or running apply, keyed by run ID
+	applying map[string]*otfrun.Run
+	// confirmed runs waiting to apply, in the order in which they apply
+	waiting []*otfrun.Run
+}
+
+func newGroup(logger logr.Logger, runs runClient, name string) *group {
+	return &group{
+		Logger:    logger.WithValues("serialization_group", name),
+		runClient: runs,
+		applying:  make(map[string]*otfrun.Run),
+	}
+}
+
+func (g *group) handleRun(ctx context.Context, run *otfrun.Run) error {
+	switch run.Status {
+	case otfrun.RunApplyQueued, otfrun.RunApplying:
+		g.removeWaiting(run.ID)
+		g.applying[run.ID] = run
+		return nil
+	case otfrun.RunConfirmed:
+		if !slices.ContainsFunc(g.waiting, func(waiting *otfrun.Run) bool { return waiting.ID == run.ID }) {
+			g.waiting = append(g.waiting, run)
+		}
+	default:
+		// run is neither applying nor waiting to apply
+		g.removeWaiting(run.ID)
+		delete(g.applying, run.ID)
+	}
+	return g.schedule(ctx)
+}
+
+// removeRun removes the run from the group, e.g. because its workspace has
+// been removed from the group.
+func (g *group) removeRun(ctx context.Context, runID string) error {
+	g.removeWaiting(runID)
+	delete(g.applying, runID)
+	return g.schedule(ctx)
+}
+
+// workspaceRuns returns the runs in the group belonging to the workspace.
+func (g *group) workspaceRuns(workspaceID string) (runs []*otfrun.Run) {
+	for _, run := range g.applying {
+		if run.WorkspaceID == workspaceID {
+			runs = append(runs, run)
+		}
+	}
+	for _, run := range g.waiting {
+		if run.WorkspaceID == workspaceID {
+			runs = append(runs, run)
+		}
+	}
+	return runs
+}
+
+func (g *group) empty() bool {
+	return len(g.applying) == 0 && len(g.waiting) == 0
+}
+
+func (g *group) removeWaiting(runID string) {
+	g.waiting = slices.DeleteFunc(g.waiting, func(waiting *otfrun.Run) bool {
+		return waiting.ID == runID
+	})
+}
+
+// schedule enqueues the apply of the next waiting run if no run in the group
+// is applying.
+func (g *group) schedule(ctx context.Context) error {
+	for len(g.applying) == 0 && len(g.waiting) > 0 {
+		next := g.waiting[0]
+		g.waiting = g.waiting[1:]
+		run, err := g.EnqueueApply(ctx, next.ID)
+		if errors.Is(err, otfrun.ErrInvalidRunStateTransition) {
+			// run has been canceled in the meantime; try next run.
+			continue
+		} else if err != nil {
+			return err
+		}
+		g.V(0).Info("enqueued serialized apply", "run", run.ID)
+		g.applying[run.ID] = run
+	}
+	return nil
+}
@@ -0,0 +1,112 @@
+package scheduler
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/leg100/otf/internal"
+	"github.com/leg100/otf/internal/pubsub"
+	otfrun "github.com/leg100/otf/internal/run"
+	"github.com/leg100/otf/internal/workspace"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGroup(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("serialize applies", func(t *testing.T) {
+		run1 := &otfrun.Run{ID: "run-1", WorkspaceID: "ws-1", Status: otfrun.RunConfirmed}
+		run2 := &otfrun.Run{ID: "run-2", WorkspaceID: "ws-2", Status: otfrun.RunConfirmed}
+		runs := newFakeGroupRuns(run1, run2)
+		g := newGroup(logr.Discard(), runs, "vpc-prod")
+
+		// run1 is enqueued immediately
+		require.NoError(t, g.handleRun(ctx, run1))
+		assert.Equal(t, []string{"run-1"}, runs.enqueued)
+
+		// run2 waits for run1 to apply
+		require.NoError(t, g.handleRun(ctx, run2))
+		assert.Equal(t, []string{"run-1"}, runs.enqueued)
+		assert.Equal(t, 1, len(g.waiting))
+
+		// run1 applies; run2 is enqueued
+		run1.Status = otfrun.RunApplied
+		require.NoError(t, g.handleRun(ctx, run1))
+		assert.Equal(t, []string{"run-1", "run-2"}, runs.enqueued)
+		assert.Equal(t, 0, len(g.waiting))
+	})
+
+	t.Run("remove canceled run", func(t *testing.T) {
+		run1 := &otfrun.Run{ID: "run-1", WorkspaceID: "ws-1", Status: otfrun.RunApplying}
+		run2 := &otfrun.Run{ID: "run-2", WorkspaceID: "ws-2", Status: otfrun.RunConfirmed}
+		runs := newFakeGroupRuns(run1, run2)
+		g := newGroup(logr.Discard(), runs, "vpc-prod")
+
+		require.NoError(t, g.handleRun(ctx, run1))
+		require.NoError(t, g.handleRun(ctx, run2))
+
+		run2.Status = otfrun.RunCanceled
+		require.NoError(t, g.handleRun(ctx, run2))
+		assert.Equal(t, 0, len(g.waiting))
+
+		// run1 applies; there is no run to enqueue
+		run1.Status = otfrun.RunApplied
+		require.NoError(t, g.handleRun(ctx, run1))
+		assert.Empty(t, runs.enqueued)
+		assert.True(t, g.empty())
+	})
+
+	t.Run("release confirmed run when workspace leaves group", func(t *testing.T) {
+		ws := &workspace.Workspace{ID: "ws-2", SerializationGroup: internal.String("vpc-prod")}
+		run1 := &otfrun.Run{ID: "run-1", WorkspaceID: "ws-1", Status: otfrun.RunApplying}
+		run2 := &otfrun.Run{ID: "run-2", WorkspaceID: "ws-2", Status: otfrun.RunConfirmed}
+		runs := newFakeGroupRuns(run1, run2)
+		scheduler := scheduler{
+			Logger:       logr.Discard(),
+			runs:         runs,
+			queues:       map[string]eventHandler{"ws-1": &fakeQueue{}, "ws-2": &fakeQueue{}},
+			queueFactory: &fakeQueueFactory{},
+			groups:       make(map[string]*group),
+			workspaceGroups: map[string]string{
+				"ws-1": "vpc-prod",
+				"ws-2": "vpc-prod",
+			},
+		}
+		for _, run := range []*otfrun.Run{run1, run2} {
+			err := scheduler.handleRunEvent(ctx, pubsub.Event[*otfrun.Run]{Payload: run})
+			require.NoError(t, err)
+		}
+		assert.Empty(t, runs.enqueued)
+
+		ws.SerializationGroup = nil
+		err := scheduler.handleWorkspaceEvent(ctx, pubsub.Event[*workspace.Workspace]{Payload: ws})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"run-2"}, runs.enqueued)
+	})
+}
+
+type fakeGroupRuns struct {
+	runs     map[string]*otfrun.Run
+	enqueued []string
+
+	runClient
+}
+
+func newFakeGroupRuns(runs ...*otfrun.Run) *fakeGroupRuns {
+	db := make(map[string]*otfrun.Run, len(runs))
+	for _, r := range runs {
+		db[r.ID] = r
+	}
+	return &fakeGroupRuns{runs: db}
+}
+
+func (f *fakeGroupRuns) EnqueueApply(ctx context.Context, runID string) (*otfrun.Run, error) {
+	run := f.runs[runID]
+	if err := run.EnqueueApply(); err != nil {
+		return nil, otfrun.ErrInvalidRunStateTransition
+	}
+	f.enqueued = append(f.enqueued, runID)
+	return run, nil
+}
@@ -47,7 +47,7 @@ func TestQueue(t *testing.T) {
 		assert.True(t, q.ws.Locked())
 
 		// cancel run2, check it is removed from queue and run3 is shuffled forward
-		err = run2.Cancel(false, false)
+		err = run2.Cancel(false)
 		require.NoError(t, err)
 		err = q.handleRun(ctx, run2)
 		require.NoError(t, err)
@@ -57,7 +57,7 @@ func TestQueue(t *testing.T) {
 		assert.True(t, q.ws.Locked())
 
 		// cancel run1; check run3 takes its place as current run
-		err = run1.Cancel(false, false)
+		err = run1.Cancel(false)
 		require.NoError(t, err)
 		err = q.handleRun(ctx, run1)
 		require.NoError(t, err)
@@ -66,7 +66,7 @@ func TestQueue(t *testing.T) {
 		assert.True(t, q.ws.Locked())
 
 		// cancel run3; check everything is empty and workspace is unlocked
-		err = run3.Cancel(false, false)
+		err = run3.Cancel(false)
 		require.NoError(t, err)
 		err = q.handleRun(ctx, run3)
 		require.NoError(t, err)
@@ -3,6 +3,7 @@ package scheduler
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
 	"github.com/go-logr/logr"
@@ -17,9 +18,10 @@ import (
 const LockID int64 = 5577006791947779410
 
 type (
-	// scheduler performs two principle tasks :
+	// scheduler performs three principle tasks :
 	// (a) manages lifecycle of workspace queues, creating/destroying them
 	// (b) relays run and workspace events onto queues.
+	// (c) serializes the applies of runs in serialization groups.
 	scheduler struct {
 		logr.Logger
 
@@ -28,6 +30,12 @@ type (
 
 		queues map[string]eventHandler
 		queueFactory
+
+		// serialization groups keyed by name
+		groups map[string]*group
+		// names of the serialization groups of workspaces, keyed by
+		// workspace ID; workspaces not in a group are absent.
+		workspaceGroups map[string]string
 	}
 
 	workspaceClient interface {
@@ -42,6 +50,7 @@ type (
 		List(ctx context.Context, opts run.ListOptions) (*resource.Page[*run.Run], error)
 		Watch(context.Context) (<-chan pubsub.Event[*run.Run], func())
 		EnqueuePlan(ctx context.Context, runID string) (*run.Run, error)
+		EnqueueApply(ctx context.Context, runID string) (*run.Run, error)
 	}
 
 	Options struct {
@@ -65,8 +74,10 @@ func NewScheduler(opts Options) *scheduler {
 // creating/deleting workspace queues accordingly and forwarding events to
 // queues for scheduling.
 func (s *scheduler) Start(ctx context.Context) error {
-	// Reset queues each time scheduler starts
+	// Reset queues and groups each time scheduler starts
 	s.queues = make(map[string]eventHandler)
+	s.groups = make(map[string]*group)
+	s.workspaceGroups = make(map[string]string)
 
 	// subscribe to workspace events
 	subWorkspaces, unsubWorkspaces := s.workspaces.Watch(ctx)
@@ -95,6 +106,21 @@ func (s *scheduler) Start(ctx context.Context) error {
 	if err != nil {
 		return fmt.Errorf("retrieving incomplete runs: %w", err)
 	}
+	// Seed groups with applying runs before relaying runs, to ensure a
+	// confirmed run is not enqueued while another run in its group is already
+	// applying.
+	for _, ws := range workspaces {
+		if ws.SerializationGroup != nil {
+			s.workspaceGroups[ws.ID] = *ws.SerializationGroup
+		}
+	}
+	for _, r := range runs {
+		if r.Status == run.RunApplyQueued || r.Status == run.RunApplying {
+			if name, ok := s.workspaceGroups[r.WorkspaceID]; ok {
+				s.group(name).applying[r.ID] = r
+			}
+		}
+	}
 
 	// feed in existing workspaces and then events to the scheduler for processing
 	workspaceQueue := make(chan pubsub.Event[*workspace.Workspace])
@@ -149,8 +175,12 @@ func (s *scheduler) Start(ctx context.Context) error {
 func (s *scheduler) handleWorkspaceEvent(ctx context.Context, event pubsub.Event[*workspace.Workspace]) error {
 	if event.Type == pubsub.DeletedEvent {
 		delete(s.queues, event.Payload.ID)
+		delete(s.workspaceGroups, event.Payload.ID)
 		return nil
 	}
+	if err := s.setWorkspaceGroup(ctx, event.Payload); err != nil {
+		return err
+	}
 	// create workspace queue if it doesn't exist
 	q, ok := s.queues[event.Payload.ID]
 	if !ok {
@@ -184,5 +214,74 @@ func (s *scheduler) handleRunEvent(ctx context.Context, event pubsub.Event[*run.
 	if err := q.handleRun(ctx, event.Payload); err != nil {
 		return err
 	}
+	return s.handleGroupRun(ctx, event.Payload)
+}
+
+// setWorkspaceGroup records the workspace's serialization group. If the
+// workspace has changed group then its runs are relayed to its new group, if
+// any.
+func (s *scheduler) setWorkspaceGroup(ctx context.Context, ws *workspace.Workspace) error {
+	var name string
+	if ws.SerializationGroup != nil {
+		name = *ws.SerializationGroup
+	}
+	if s.workspaceGroups[ws.ID] == name {
+		return nil
+	}
+	if name == "" {
+		delete(s.workspaceGroups, ws.ID)
+	} else {
+		s.workspaceGroups[ws.ID] = name
+	}
+	var runs []*run.Run
+	for _, g := range s.groups {
+		runs = append(runs, g.workspaceRuns(ws.ID)...)
+	}
+	for _, r := range runs {
+		if err := s.handleGroupRun(ctx, r); err != nil {
+			return err
+		}
+	}
 	return nil
 }
+
+// handleGroupRun relays the run to the serialization group of its workspace.
+func (s *scheduler) handleGroupRun(ctx context.Context, r *run.Run) error {
+	name, ok := s.workspaceGroups[r.WorkspaceID]
+	// remove run from any other group its workspace previously belonged to.
+	for other, g := range s.groups {
+		if other == name {
+			continue
+		}
+		if err := g.removeRun(ctx, r.ID); err != nil {
+			return err
+		}
+		if g.empty() {
+			delete(s.groups, other)
+		}
+	}
+	if !ok {
+		if r.Status == run.RunConfirmed {
+			// the workspace has been removed from its group since the run
+			// was confirmed; enqueue the apply immediately.
+			_, err := s.runs.EnqueueApply(ctx, r.ID)
+			if errors.Is(err, run.ErrInvalidRunStateTransition) {
+				return nil
+			}
+			return err
+		}
+		return nil
+	}
+	return s.group(name).handleRun(ctx, r)
+}
+
+// group retrieves the serialization group with the given name, creating it if
+// it doesn't exist.
+func (s *scheduler) group(name string) *group {
+	g, ok := s.groups[name]
+	if !ok {
+		g = newGroup(s.Logger, s.runs, name)
+		s.groups[name] = g
+	}
+	return g
+}
@@ -0,0 +1,38 @@
+package search
+
+import (
+	"net/http"
+
+	"github.com/gorilla/mux"
+	otfapi "github.com/leg100/otf/internal/api"
+	"github.com/leg100/otf/internal/http/decode"
+	"github.com/leg100/otf/internal/tfeapi"
+)
+
+type api struct {
+	*Service
+	*tfeapi.Responder
+}
+
+func (a *api) addHandlers(r *mux.Router) {
+	r = r.PathPrefix(otfapi.DefaultBasePath).Subrouter()
+
+	r.HandleFunc("/organizations/{organization_name}/search", a.search).Methods("GET")
+}
+
+func (a *api) search(w http.ResponseWriter, r *http.Request) {
+	var params struct {
+		Organization string `schema:"organization_name,required"`
+		SearchOptions
+	}
+	if err := decode.All(&params, r); err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	page, err := a.Search(r.Context(), params.Organization, params.SearchOptions)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	a.RespondWithPage(w, r, page.Items, page.Pagination)
+}
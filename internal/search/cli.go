@@ -0,0 +1,80 @@
+package search
+
+import (
+	"context"
+	"fmt"
+
+	otfapi "github.com/leg100/otf/internal/api"
+	"github.com/leg100/otf/internal/resource"
+	"github.com/spf13/cobra"
+)
+
+type CLI struct {
+	client cliClient
+}
+
+type cliClient interface {
+	Search(ctx context.Context, organization string, opts SearchOptions) (*resource.Page[*Result], error)
+}
+
+func NewCommand(client *otfapi.Client) *cobra.Command {
+	cli := &CLI{}
+	cmd := cli.searchCommand()
+	cmd.PersistentPreRunE = func(cmd *cobra.Command, args []string) error {
+		if err := cmd.Parent().PersistentPreRunE(cmd.Parent(), args); err != nil {
+			return err
+		}
+		cli.client = &Client{Client: client}
+		return nil
+	}
+	return cmd
+}
+
+func (a *CLI) searchCommand() *cobra.Command {
+	var (
+		organization string
+		kind         string
+		opts         SearchOptions
+	)
+	cmd := &cobra.Command{
+		Use:           "search [query]",
+		Short:         "Search workspaces and runs",
+		Args:          cobra.ExactArgs(1),
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.Query = args[0]
+			if kind != "" {
+				opts.Kinds = []Kind{Kind(kind)}
+			}
+			page, err := a.client.Search(cmd.Context(), organization, opts)
+			if err != nil {
+				return err
+			}
+			out := cmd.OutOrStdout()
+			if len(page.Items) == 0 {
+				fmt.Fprintln(out, "No results found")
+				return nil
+			}
+			for _, r := range page.Items {
+				fmt.Fprintf(out, "%s %s", r.ID, r.WorkspaceName)
+				if r.CommitSHA != "" {
+					fmt.Fprintf(out, " (%.7s)", r.CommitSHA)
+				}
+				if r.Kind == RunKind && r.Summary != "" {
+					fmt.Fprintf(out, ": %s", r.Summary)
+				}
+				fmt.Fprintln(out)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&organization, "organization", "", "Name of the organization to search")
+	cmd.MarkFlagRequired("organization")
+
+	cmd.Flags().StringVar(&kind, "kind", "", "Only search resources of this kind: workspace or run")
+	cmd.Flags().IntVar(&opts.PageSize, "limit", resource.DefaultPageSize, "Maximum number of results to show")
+
+	return cmd
+}
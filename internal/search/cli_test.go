@@ -0,0 +1,55 @@
+package search
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/leg100/otf/internal/resource"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSearchCommand(t *testing.T) {
+	t.Run("results", func(t *testing.T) {
+		client := &fakeCLIService{results: []*Result{
+			{ID: "ws-123", Kind: WorkspaceKind, WorkspaceName: "networking", Summary: "vpcs and subnets"},
+			{ID: "run-123", Kind: RunKind, WorkspaceName: "networking", Summary: "add private subnet", CommitSHA: "a1b2c3d4e5f6"},
+			{ID: "run-456", Kind: RunKind, WorkspaceName: "networking"},
+		}}
+		cmd := (&CLI{client: client}).searchCommand()
+		cmd.SetArgs([]string{"networking", "--organization", "acme-corp", "--kind", "run"})
+		got := bytes.Buffer{}
+		cmd.SetOut(&got)
+		require.NoError(t, cmd.Execute())
+
+		want := "ws-123 networking\nrun-123 networking (a1b2c3d): add private subnet\nrun-456 networking\n"
+		assert.Equal(t, want, got.String())
+		assert.Equal(t, "acme-corp", client.organization)
+		assert.Equal(t, "networking", client.opts.Query)
+		assert.Equal(t, []Kind{RunKind}, client.opts.Kinds)
+	})
+
+	t.Run("no results", func(t *testing.T) {
+		cmd := (&CLI{client: &fakeCLIService{}}).searchCommand()
+		cmd.SetArgs([]string{"networking", "--organization", "acme-corp"})
+		got := bytes.Buffer{}
+		cmd.SetOut(&got)
+		require.NoError(t, cmd.Execute())
+
+		assert.Equal(t, "No results found\n", got.String())
+	})
+}
+
+type fakeCLIService struct {
+	results []*Result
+
+	organization string
+	opts         SearchOptions
+}
+
+func (f *fakeCLIService) Search(ctx context.Context, organization string, opts SearchOptions) (*resource.Page[*Result], error) {
+	f.organization = organization
+	f.opts = opts
+	return resource.NewPage(f.results, opts.PageOptions, nil), nil
+}
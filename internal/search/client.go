@@ -0,0 +1,27 @@
+package search
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	otfapi "github.com/leg100/otf/internal/api"
+	"github.com/leg100/otf/internal/resource"
+)
+
+type Client struct {
+	*otfapi.Client
+}
+
+func (c *Client) Search(ctx context.Context, organization string, opts SearchOptions) (*resource.Page[*Result], error) {
+	u := fmt.Sprintf("organizations/%s/search", url.QueryEscape(organization))
+	req, err := c.NewRequest("GET", u, &opts)
+	if err != nil {
+		return nil, err
+	}
+	var page resource.Page[*Result]
+	if err := c.Do(ctx, req, &page); err != nil {
+		return nil, err
+	}
+	return &page, nil
+}
@@ -0,0 +1,53 @@
+package search
+
+import (
+	"context"
+
+	"github.com/leg100/otf/internal"
+	"github.com/leg100/otf/internal/resource"
+	"github.com/leg100/otf/internal/sql"
+	"github.com/leg100/otf/internal/sql/pggen"
+)
+
+type (
+	// pgdb is a database for searching on postgres
+	pgdb struct {
+		*sql.DB // provides access to generated SQL queries
+	}
+)
+
+// search searches the workspaces and runs in an organization. If username is
+// non-empty then only those workspaces, and their runs, on which the user has
+// been granted permissions are searched.
+func (db *pgdb) search(ctx context.Context, organization, username string, q *query, opts resource.PageOptions) (*resource.Page[*Result], error) {
+	rows, err := db.Conn(ctx).Search(ctx, pggen.SearchParams{
+		OrganizationName: sql.String(organization),
+		Username:         sql.String(username),
+		Query:            sql.String(q.tsquery),
+		Kinds:            q.kinds,
+		TagPrefix:        sql.String(q.tagPrefix),
+		CommitSHAPrefix:  sql.String(q.commitSHAPrefix),
+		Limit:            opts.GetLimit(),
+		Offset:           opts.GetOffset(),
+	})
+	if err != nil {
+		return nil, sql.Error(err)
+	}
+	// every row carries the total number of results
+	var count int64
+	items := make([]*Result, len(rows))
+	for i, r := range rows {
+		items[i] = &Result{
+			ID:            r.ResourceID.String,
+			Kind:          Kind(r.Kind.String),
+			WorkspaceID:   r.WorkspaceID.String,
+			WorkspaceName: r.WorkspaceName.String,
+			Summary:       r.Summary.String,
+			CommitSHA:     r.CommitSHA.String,
+			Status:        r.Status.String,
+			Timestamp:     r.Timestamp.Time.UTC(),
+		}
+		count = r.TotalCount.Int
+	}
+	return resource.NewPage(items, opts, internal.Int64(count)), nil
+}
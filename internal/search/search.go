@@ -0,0 +1,115 @@
+// Package search provides full-text search of workspaces and runs.
+package search
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/leg100/otf/internal"
+	"github.com/leg100/otf/internal/resource"
+)
+
+const (
+	WorkspaceKind Kind = "workspace"
+	RunKind       Kind = "run"
+)
+
+var (
+	ErrEmptyQuery  = errors.New("search query must contain at least one letter or digit")
+	ErrInvalidKind = errors.New("kind must be either workspace or run")
+
+	// commitSHARegex matches abbreviated and full git commit SHAs
+	commitSHARegex = regexp.MustCompile(`^[0-9a-f]{7,40}$`)
+)
+
+type (
+	// Kind is the kind of resource found by a search.
+	Kind string
+
+	// Result is a resource matching a search query. Workspaces match on their
+	// name, description, and tags; runs match on their message and commit SHA.
+	Result struct {
+		// ID of the matching workspace or run.
+		ID   string `jsonapi:"primary,search-results"`
+		Kind Kind   `jsonapi:"attribute" json:"kind"`
+		// Workspace is the matching workspace, or the workspace the matching
+		// run belongs to.
+		WorkspaceID   string `jsonapi:"attribute" json:"workspace_id"`
+		WorkspaceName string `jsonapi:"attribute" json:"workspace_name"`
+		// Summary is the description of a workspace, or the message of a run.
+		Summary   string `jsonapi:"attribute" json:"summary"`
+		CommitSHA string `jsonapi:"attribute" json:"commit_sha,omitempty"`
+		// Status is the status of a run, or the status of the latest run of
+		// a workspace.
+		Status string `jsonapi:"attribute" json:"status"`
+		// Timestamp is when a workspace was last updated, or when a run was
+		// created.
+		Timestamp time.Time `jsonapi:"attribute" json:"timestamp"`
+	}
+
+	SearchOptions struct {
+		// Query is the text to search for. Each word in the query is matched
+		// against the start of words in workspace names, descriptions, and
+		// run messages; all words must match.
+		Query string `schema:"q,required"`
+		// Kinds restricts results to the given kinds of resource. If empty
+		// then both workspaces and runs are searched.
+		Kinds []Kind `schema:"filter[kind]"`
+
+		resource.PageOptions
+	}
+
+	// query is a search query prepared for the database.
+	query struct {
+		// tsquery is a postgres full-text query
+		tsquery string
+		// tagPrefix is matched against the start of tag names
+		tagPrefix string
+		// commitSHAPrefix is matched against the start of commit SHAs; empty
+		// if the query does not resemble a commit SHA.
+		commitSHAPrefix string
+		kinds           []string
+	}
+)
+
+func newQuery(opts SearchOptions) (*query, error) {
+	text := strings.ToLower(strings.TrimSpace(opts.Query))
+	// split query into words, discarding anything that is neither a letter
+	// nor a digit, which prevents the query from containing full-text
+	// operators.
+	words := strings.FieldsFunc(text, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	if len(words) == 0 {
+		return nil, &internal.InvalidParameterError{Parameter: "q", Err: ErrEmptyQuery}
+	}
+	for i, w := range words {
+		// match words starting with the word
+		words[i] = w + ":*"
+	}
+	q := query{
+		tsquery:   strings.Join(words, " & "),
+		tagPrefix: escapeLike(text),
+	}
+	if commitSHARegex.MatchString(text) {
+		q.commitSHAPrefix = text
+	}
+	if len(opts.Kinds) == 0 {
+		q.kinds = []string{string(WorkspaceKind), string(RunKind)}
+	}
+	for _, k := range opts.Kinds {
+		if k != WorkspaceKind && k != RunKind {
+			return nil, &internal.InvalidParameterError{Parameter: "filter[kind]", Err: ErrInvalidKind}
+		}
+		q.kinds = append(q.kinds, string(k))
+	}
+	return &q, nil
+}
+
+// escapeLike escapes characters that have special meaning in a LIKE pattern.
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(s)
+}
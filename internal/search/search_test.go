@@ -0,0 +1,106 @@
+package search
+
+import (
+	"testing"
+
+	"github.com/leg100/otf/internal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewQuery(t *testing.T) {
+	tests := []struct {
+		name  string
+		opts  SearchOptions
+		want  *query
+		error error
+	}{
+		{
+			name: "single word",
+			opts: SearchOptions{Query: "Prod"},
+			want: &query{
+				tsquery:   "prod:*",
+				tagPrefix: "prod",
+				kinds:     []string{"workspace", "run"},
+			},
+		},
+		{
+			name: "several words",
+			opts: SearchOptions{Query: " networking-prod  vpc "},
+			want: &query{
+				tsquery:   "networking:* & prod:* & vpc:*",
+				tagPrefix: "networking-prod  vpc",
+				kinds:     []string{"workspace", "run"},
+			},
+		},
+		{
+			name: "strip full-text operators",
+			opts: SearchOptions{Query: "!foo | (bar:*)"},
+			want: &query{
+				tsquery:   "foo:* & bar:*",
+				tagPrefix: "!foo | (bar:*)",
+				kinds:     []string{"workspace", "run"},
+			},
+		},
+		{
+			name: "escape like wildcards in tag prefix",
+			opts: SearchOptions{Query: "app_%"},
+			want: &query{
+				tsquery:   "app:*",
+				tagPrefix: `app\_\%`,
+				kinds:     []string{"workspace", "run"},
+			},
+		},
+		{
+			name: "commit sha",
+			opts: SearchOptions{Query: "A1B2C3D"},
+			want: &query{
+				tsquery:         "a1b2c3d:*",
+				tagPrefix:       "a1b2c3d",
+				commitSHAPrefix: "a1b2c3d",
+				kinds:           []string{"workspace", "run"},
+			},
+		},
+		{
+			name: "too short for commit sha",
+			opts: SearchOptions{Query: "a1b2c3"},
+			want: &query{
+				tsquery:   "a1b2c3:*",
+				tagPrefix: "a1b2c3",
+				kinds:     []string{"workspace", "run"},
+			},
+		},
+		{
+			name: "only runs",
+			opts: SearchOptions{Query: "foo", Kinds: []Kind{RunKind}},
+			want: &query{
+				tsquery:   "foo:*",
+				tagPrefix: "foo",
+				kinds:     []string{"run"},
+			},
+		},
+		{
+			name:  "empty query",
+			opts:  SearchOptions{Query: " !&| "},
+			error: ErrEmptyQuery,
+		},
+		{
+			name:  "invalid kind",
+			opts:  SearchOptions{Query: "foo", Kinds: []Kind{"module"}},
+			error: ErrInvalidKind,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := newQuery(tt.opts)
+			if tt.error != nil {
+				assert.ErrorIs(t, err, tt.error)
+				var invalid *internal.InvalidParameterError
+				assert.ErrorAs(t, err, &invalid)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,83 @@
+package search
+
+import (
+	"context"
+
+	"github.com/gorilla/mux"
+	"github.com/leg100/otf/internal"
+	"github.com/leg100/otf/internal/logr"
+	"github.com/leg100/otf/internal/organization"
+	"github.com/leg100/otf/internal/rbac"
+	"github.com/leg100/otf/internal/resource"
+	"github.com/leg100/otf/internal/sql"
+	"github.com/leg100/otf/internal/tfeapi"
+	"github.com/leg100/otf/internal/user"
+)
+
+type (
+	Service struct {
+		logr.Logger
+
+		organization internal.Authorizer
+
+		db  *pgdb
+		api *api
+		tfe *tfe
+	}
+
+	Options struct {
+		*sql.DB
+		*tfeapi.Responder
+		logr.Logger
+	}
+)
+
+func NewService(opts Options) *Service {
+	svc := Service{
+		Logger:       opts.Logger,
+		organization: &organization.Authorizer{Logger: opts.Logger},
+		db:           &pgdb{opts.DB},
+	}
+	svc.api = &api{Service: &svc, Responder: opts.Responder}
+	svc.tfe = &tfe{Service: &svc, Responder: opts.Responder}
+	return &svc
+}
+
+func (s *Service) AddHandlers(r *mux.Router) {
+	s.api.addHandlers(r)
+	s.tfe.addHandlers(r)
+}
+
+// Search searches the workspaces and runs in an organization, returning the
+// best matches first.
+func (s *Service) Search(ctx context.Context, organization string, opts SearchOptions) (*resource.Page[*Result], error) {
+	q, err := newQuery(opts)
+	if err != nil {
+		return nil, err
+	}
+	// check if subject has perms to list workspaces in organization
+	var username string
+	subject, err := s.organization.CanAccess(ctx, rbac.ListWorkspacesAction, organization)
+	if err == internal.ErrAccessNotPermitted {
+		// user does not have org-wide perms; fallback to searching workspaces
+		// for which they have workspace-level perms.
+		subject, err = internal.SubjectFromContext(ctx)
+		if err != nil {
+			return nil, err
+		}
+		user, ok := subject.(*user.User)
+		if !ok {
+			return nil, internal.ErrAccessNotPermitted
+		}
+		username = user.Username
+	} else if err != nil {
+		return nil, err
+	}
+	page, err := s.db.search(ctx, organization, username, q, opts.PageOptions)
+	if err != nil {
+		s.Error(err, "searching organization", "organization", organization, "query", opts.Query, "subject", subject)
+		return nil, err
+	}
+	s.V(9).Info("searched organization", "organization", organization, "query", opts.Query, "subject", subject)
+	return page, nil
+}
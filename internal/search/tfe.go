@@ -0,0 +1,61 @@
+package search
+
+import (
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/leg100/otf/internal/http/decode"
+	"github.com/leg100/otf/internal/tfeapi"
+	"github.com/leg100/otf/internal/tfeapi/types"
+)
+
+type tfe struct {
+	*Service
+	*tfeapi.Responder
+}
+
+func init() {
+	decode.RegisterEnum(WorkspaceKind, RunKind)
+}
+
+func (a *tfe) addHandlers(r *mux.Router) {
+	r = r.PathPrefix(tfeapi.APIPrefixV2).Subrouter()
+
+	r.HandleFunc("/organizations/{organization_name}/search", a.search).Methods("GET")
+}
+
+func (a *tfe) search(w http.ResponseWriter, r *http.Request) {
+	var params struct {
+		Organization string `schema:"organization_name,required"`
+		SearchOptions
+	}
+	if err := decode.All(&params, r); err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	page, err := a.Search(r.Context(), params.Organization, params.SearchOptions)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+
+	// convert items
+	items := make([]*types.SearchResult, len(page.Items))
+	for i, from := range page.Items {
+		items[i] = a.convert(from)
+	}
+	a.RespondWithPage(w, r, items, page.Pagination)
+}
+
+func (a *tfe) convert(from *Result) *types.SearchResult {
+	return &types.SearchResult{
+		ID:            from.ID,
+		Kind:          string(from.Kind),
+		WorkspaceName: from.WorkspaceName,
+		Summary:       from.Summary,
+		CommitSHA:     from.CommitSHA,
+		Status:        from.Status,
+		Timestamp:     from.Timestamp,
+		Workspace:     &types.Workspace{ID: from.WorkspaceID},
+	}
+}
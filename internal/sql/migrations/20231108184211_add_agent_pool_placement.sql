@@ -0,0 +1,15 @@
+-- +goose Up
+ALTER TABLE agent_pools
+    ADD COLUMN labels TEXT[] DEFAULT '{}' NOT NULL;
+
+CREATE TABLE IF NOT EXISTS workspace_placement_constraints (
+    workspace_id TEXT REFERENCES workspaces ON UPDATE CASCADE ON DELETE CASCADE NOT NULL,
+    label        TEXT NOT NULL,
+    UNIQUE (workspace_id, label)
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS workspace_placement_constraints;
+
+ALTER TABLE agent_pools
+    DROP COLUMN labels;
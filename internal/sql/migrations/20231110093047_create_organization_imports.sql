@@ -0,0 +1,24 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS organization_imports (
+    organization_import_id TEXT,
+    created_at             TIMESTAMPTZ NOT NULL,
+    updated_at             TIMESTAMPTZ NOT NULL,
+    organization_name      TEXT REFERENCES organizations (name) ON UPDATE CASCADE ON DELETE CASCADE NOT NULL,
+    source_address         TEXT NOT NULL,
+    source_organization    TEXT NOT NULL,
+    status                 TEXT NOT NULL,
+    error                  TEXT,
+                           PRIMARY KEY (organization_import_id)
+);
+
+CREATE TABLE IF NOT EXISTS organization_import_items (
+    organization_import_id TEXT REFERENCES organization_imports ON UPDATE CASCADE ON DELETE CASCADE NOT NULL,
+    kind                   TEXT NOT NULL,
+    source_id              TEXT NOT NULL,
+    target_id              TEXT NOT NULL,
+    UNIQUE (organization_import_id, kind, source_id)
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS organization_import_items;
+DROP TABLE IF EXISTS organization_imports;
@@ -0,0 +1,22 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS organization_memberships (
+    organization_membership_id TEXT,
+    created_at                 TIMESTAMPTZ NOT NULL,
+    organization_name          TEXT REFERENCES organizations (name) ON UPDATE CASCADE ON DELETE CASCADE NOT NULL,
+    username                   TEXT REFERENCES users (username) ON UPDATE CASCADE ON DELETE CASCADE NOT NULL,
+                               PRIMARY KEY (organization_membership_id),
+                               UNIQUE (organization_name, username)
+);
+
+-- users are already members of the organizations of the teams they belong to
+INSERT INTO organization_memberships (organization_membership_id, created_at, organization_name, username)
+SELECT 'ou-' || substr(md5(random()::text), 1, 16), now(), organization_name, username
+FROM (
+    SELECT DISTINCT t.organization_name, tm.username
+    FROM team_memberships tm
+    JOIN teams t USING (team_id)
+) AS members
+;
+
+-- +goose Down
+DROP TABLE IF EXISTS organization_memberships;
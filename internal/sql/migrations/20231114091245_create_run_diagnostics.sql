@@ -0,0 +1,16 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS run_diagnostics (
+    run_id   TEXT REFERENCES runs ON UPDATE CASCADE ON DELETE CASCADE NOT NULL,
+    phase    TEXT NOT NULL,
+    position INTEGER NOT NULL,
+    severity TEXT NOT NULL,
+    summary  TEXT NOT NULL,
+    detail   TEXT,
+    address  TEXT,
+    filename TEXT,
+    line     INTEGER,
+             UNIQUE (run_id, phase, position)
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS run_diagnostics;
@@ -0,0 +1,12 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS run_triggers (
+    run_trigger_id          TEXT,
+    created_at              TIMESTAMPTZ NOT NULL,
+    workspace_id            TEXT REFERENCES workspaces ON UPDATE CASCADE ON DELETE CASCADE NOT NULL,
+    sourceable_workspace_id TEXT REFERENCES workspaces (workspace_id) ON UPDATE CASCADE ON DELETE CASCADE NOT NULL,
+                            UNIQUE (workspace_id, sourceable_workspace_id),
+                            PRIMARY KEY (run_trigger_id)
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS run_triggers;
@@ -0,0 +1,21 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS ssh_keys (
+    ssh_key_id        TEXT,
+    created_at        TIMESTAMPTZ NOT NULL,
+    updated_at        TIMESTAMPTZ NOT NULL,
+    name              TEXT NOT NULL,
+    encrypted_value   TEXT NOT NULL,
+    organization_name TEXT REFERENCES organizations (name) ON UPDATE CASCADE ON DELETE CASCADE NOT NULL,
+                      UNIQUE (organization_name, name),
+                      PRIMARY KEY (ssh_key_id)
+);
+
+CREATE TABLE IF NOT EXISTS workspace_ssh_keys (
+    workspace_id TEXT REFERENCES workspaces ON UPDATE CASCADE ON DELETE CASCADE NOT NULL,
+    ssh_key_id   TEXT REFERENCES ssh_keys ON UPDATE CASCADE ON DELETE CASCADE NOT NULL,
+                 PRIMARY KEY (workspace_id)
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS workspace_ssh_keys;
+DROP TABLE IF EXISTS ssh_keys;
@@ -0,0 +1,11 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS configuration_version_dependencies (
+    configuration_version_id TEXT REFERENCES configuration_versions ON UPDATE CASCADE ON DELETE CASCADE NOT NULL,
+    kind                     TEXT NOT NULL,
+    source                   TEXT NOT NULL,
+    version                  TEXT NOT NULL,
+                             PRIMARY KEY (configuration_version_id, kind, source, version)
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS configuration_version_dependencies;
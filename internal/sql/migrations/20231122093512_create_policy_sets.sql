@@ -0,0 +1,48 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS policy_sets (
+    policy_set_id     TEXT,
+    created_at        TIMESTAMPTZ NOT NULL,
+    updated_at        TIMESTAMPTZ NOT NULL,
+    name              TEXT NOT NULL,
+    description       TEXT NOT NULL,
+    kind              TEXT NOT NULL,
+    overridable       BOOLEAN NOT NULL,
+    global            BOOLEAN NOT NULL,
+    organization_name TEXT REFERENCES organizations (name) ON UPDATE CASCADE ON DELETE CASCADE NOT NULL,
+                      UNIQUE (organization_name, name),
+                      PRIMARY KEY (policy_set_id)
+);
+
+CREATE TABLE IF NOT EXISTS policy_set_workspaces (
+    policy_set_id TEXT REFERENCES policy_sets ON UPDATE CASCADE ON DELETE CASCADE NOT NULL,
+    workspace_id  TEXT REFERENCES workspaces ON UPDATE CASCADE ON DELETE CASCADE NOT NULL,
+                  PRIMARY KEY (policy_set_id, workspace_id)
+);
+
+CREATE TABLE IF NOT EXISTS policy_set_parameters (
+    policy_set_parameter_id TEXT,
+    key                     TEXT NOT NULL,
+    value                   TEXT NOT NULL,
+    sensitive               BOOLEAN NOT NULL,
+    policy_set_id           TEXT REFERENCES policy_sets ON UPDATE CASCADE ON DELETE CASCADE NOT NULL,
+                            UNIQUE (policy_set_id, key),
+                            PRIMARY KEY (policy_set_parameter_id)
+);
+
+CREATE TABLE IF NOT EXISTS policy_set_versions (
+    policy_set_version_id TEXT,
+    created_at            TIMESTAMPTZ NOT NULL,
+    updated_at            TIMESTAMPTZ NOT NULL,
+    status                TEXT NOT NULL,
+    error_message         TEXT NOT NULL,
+    policy_count          INTEGER NOT NULL,
+    bundle                BYTEA,
+    policy_set_id         TEXT REFERENCES policy_sets ON UPDATE CASCADE ON DELETE CASCADE NOT NULL,
+                          PRIMARY KEY (policy_set_version_id)
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS policy_set_versions;
+DROP TABLE IF EXISTS policy_set_parameters;
+DROP TABLE IF EXISTS policy_set_workspaces;
+DROP TABLE IF EXISTS policy_sets;
@@ -0,0 +1,27 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS workspace_protection_rules (
+    workspace_id         TEXT REFERENCES workspaces ON UPDATE CASCADE ON DELETE CASCADE NOT NULL,
+    prevent_destroy_runs BOOLEAN NOT NULL,
+    protected_resources  TEXT[] NOT NULL,
+                         PRIMARY KEY (workspace_id)
+);
+
+CREATE TABLE IF NOT EXISTS run_protection_violations (
+    run_id    TEXT REFERENCES runs ON UPDATE CASCADE ON DELETE CASCADE NOT NULL,
+    position  INTEGER NOT NULL,
+    violation TEXT NOT NULL,
+              UNIQUE (run_id, position)
+);
+
+CREATE TABLE IF NOT EXISTS run_protection_overrides (
+    run_id     TEXT REFERENCES runs ON UPDATE CASCADE ON DELETE CASCADE NOT NULL,
+    username   TEXT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL,
+    violations TEXT[] NOT NULL,
+               PRIMARY KEY (run_id)
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS run_protection_overrides;
+DROP TABLE IF EXISTS run_protection_violations;
+DROP TABLE IF EXISTS workspace_protection_rules;
@@ -0,0 +1,34 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS deleted_workspaces (
+    workspace_id      TEXT NOT NULL,
+    organization_name TEXT REFERENCES organizations (name) ON UPDATE CASCADE ON DELETE CASCADE NOT NULL,
+    name              TEXT NOT NULL,
+    deleted_at        TIMESTAMPTZ NOT NULL,
+    deleted_by        TEXT NOT NULL,
+    workspace         BYTEA NOT NULL,
+                      PRIMARY KEY (workspace_id)
+);
+
+CREATE TABLE IF NOT EXISTS deleted_state_versions (
+    state_version_id TEXT NOT NULL,
+    workspace_id     TEXT REFERENCES deleted_workspaces ON UPDATE CASCADE ON DELETE CASCADE NOT NULL,
+    created_at       TIMESTAMPTZ NOT NULL,
+    serial           INTEGER NOT NULL,
+    state            BYTEA NOT NULL,
+                     PRIMARY KEY (state_version_id)
+);
+
+CREATE TABLE IF NOT EXISTS deleted_state_version_outputs (
+    state_version_output_id TEXT NOT NULL,
+    state_version_id        TEXT REFERENCES deleted_state_versions ON UPDATE CASCADE ON DELETE CASCADE NOT NULL,
+    name                    TEXT NOT NULL,
+    sensitive               BOOLEAN NOT NULL,
+    type                    TEXT NOT NULL,
+    value                   BYTEA NOT NULL,
+                            PRIMARY KEY (state_version_output_id)
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS deleted_state_version_outputs;
+DROP TABLE IF EXISTS deleted_state_versions;
+DROP TABLE IF EXISTS deleted_workspaces;
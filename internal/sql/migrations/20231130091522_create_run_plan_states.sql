@@ -0,0 +1,9 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS run_plan_states (
+    run_id       TEXT REFERENCES runs ON UPDATE CASCADE ON DELETE CASCADE NOT NULL,
+    state_serial INTEGER,
+                 PRIMARY KEY (run_id)
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS run_plan_states;
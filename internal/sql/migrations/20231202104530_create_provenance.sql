@@ -0,0 +1,16 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS configuration_version_creators (
+    configuration_version_id TEXT REFERENCES configuration_versions ON UPDATE CASCADE ON DELETE CASCADE NOT NULL,
+    created_by               TEXT NOT NULL,
+                             PRIMARY KEY (configuration_version_id)
+);
+
+CREATE TABLE IF NOT EXISTS state_version_runs (
+    state_version_id TEXT REFERENCES state_versions ON UPDATE CASCADE ON DELETE CASCADE NOT NULL,
+    run_id           TEXT REFERENCES runs ON UPDATE CASCADE ON DELETE CASCADE NOT NULL,
+                     PRIMARY KEY (state_version_id)
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS state_version_runs;
+DROP TABLE IF EXISTS configuration_version_creators;
@@ -0,0 +1,19 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS email_messages (
+    email_message_id TEXT NOT NULL,
+    created_at       TIMESTAMPTZ NOT NULL,
+    recipients       TEXT[] NOT NULL,
+    subject          TEXT NOT NULL,
+    body             TEXT NOT NULL,
+    digest_key       TEXT,
+    attempts         INTEGER NOT NULL,
+    next_attempt_at  TIMESTAMPTZ NOT NULL,
+    last_error       TEXT,
+                     PRIMARY KEY (email_message_id)
+);
+
+ALTER TABLE notification_configurations ADD COLUMN email_addresses TEXT[];
+
+-- +goose Down
+ALTER TABLE notification_configurations DROP COLUMN email_addresses;
+DROP TABLE IF EXISTS email_messages;
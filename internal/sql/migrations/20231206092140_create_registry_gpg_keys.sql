@@ -0,0 +1,14 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS registry_gpg_keys (
+    registry_gpg_key_id TEXT NOT NULL,
+    created_at          TIMESTAMPTZ NOT NULL,
+    updated_at          TIMESTAMPTZ NOT NULL,
+    key_id              TEXT NOT NULL,
+    ascii_armor         TEXT NOT NULL,
+    organization_name   TEXT REFERENCES organizations (name) ON UPDATE CASCADE ON DELETE CASCADE NOT NULL,
+                        PRIMARY KEY (registry_gpg_key_id),
+                        UNIQUE (organization_name, key_id)
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS registry_gpg_keys;
@@ -0,0 +1,66 @@
+-- +goose Up
+INSERT INTO run_statuses (status) VALUES ('pre_plan_running'), ('post_plan_running');
+
+CREATE TABLE IF NOT EXISTS tasks (
+    task_id           TEXT NOT NULL,
+    created_at        TIMESTAMPTZ NOT NULL,
+    updated_at        TIMESTAMPTZ NOT NULL,
+    name              TEXT NOT NULL,
+    url               TEXT NOT NULL,
+    description       TEXT NOT NULL,
+    category          TEXT NOT NULL,
+    hmac_key          TEXT,
+    enabled           BOOLEAN NOT NULL,
+    organization_name TEXT REFERENCES organizations (name) ON UPDATE CASCADE ON DELETE CASCADE NOT NULL,
+                      PRIMARY KEY (task_id),
+                      UNIQUE (organization_name, name)
+);
+
+CREATE TABLE IF NOT EXISTS workspace_tasks (
+    workspace_task_id TEXT NOT NULL,
+    created_at        TIMESTAMPTZ NOT NULL,
+    updated_at        TIMESTAMPTZ NOT NULL,
+    enforcement_level TEXT NOT NULL,
+    stage             TEXT NOT NULL,
+    task_id           TEXT REFERENCES tasks ON UPDATE CASCADE ON DELETE CASCADE NOT NULL,
+    workspace_id      TEXT REFERENCES workspaces ON UPDATE CASCADE ON DELETE CASCADE NOT NULL,
+                      PRIMARY KEY (workspace_task_id),
+                      UNIQUE (workspace_id, task_id)
+);
+
+CREATE TABLE IF NOT EXISTS task_stages (
+    task_stage_id TEXT NOT NULL,
+    created_at    TIMESTAMPTZ NOT NULL,
+    updated_at    TIMESTAMPTZ NOT NULL,
+    stage         TEXT NOT NULL,
+    status        TEXT NOT NULL,
+    run_id        TEXT REFERENCES runs ON UPDATE CASCADE ON DELETE CASCADE NOT NULL,
+                  PRIMARY KEY (task_stage_id),
+                  UNIQUE (run_id, stage)
+);
+
+CREATE TABLE IF NOT EXISTS task_results (
+    task_result_id    TEXT NOT NULL,
+    created_at        TIMESTAMPTZ NOT NULL,
+    updated_at        TIMESTAMPTZ NOT NULL,
+    status            TEXT NOT NULL,
+    message           TEXT NOT NULL,
+    url               TEXT NOT NULL,
+    task_id           TEXT NOT NULL,
+    task_name         TEXT NOT NULL,
+    task_url          TEXT NOT NULL,
+    workspace_task_id TEXT NOT NULL,
+    enforcement_level TEXT NOT NULL,
+    dispatched_at     TIMESTAMPTZ,
+    task_stage_id     TEXT REFERENCES task_stages ON UPDATE CASCADE ON DELETE CASCADE NOT NULL,
+                      PRIMARY KEY (task_result_id)
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS task_results;
+DROP TABLE IF EXISTS task_stages;
+DROP TABLE IF EXISTS workspace_tasks;
+DROP TABLE IF EXISTS tasks;
+DELETE FROM run_status_timestamps WHERE status IN ('pre_plan_running', 'post_plan_running');
+UPDATE runs SET status = 'errored' WHERE status IN ('pre_plan_running', 'post_plan_running');
+DELETE FROM run_statuses WHERE status IN ('pre_plan_running', 'post_plan_running');
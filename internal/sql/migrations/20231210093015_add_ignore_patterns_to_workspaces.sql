@@ -0,0 +1,5 @@
+-- +goose Up
+ALTER TABLE workspaces ADD COLUMN ignore_patterns TEXT[];
+
+-- +goose Down
+ALTER TABLE workspaces DROP COLUMN ignore_patterns;
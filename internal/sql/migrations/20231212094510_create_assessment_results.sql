@@ -0,0 +1,20 @@
+-- +goose Up
+ALTER TABLE workspaces ADD COLUMN assessments_enabled BOOLEAN DEFAULT false NOT NULL;
+
+CREATE TABLE IF NOT EXISTS assessment_results (
+    assessment_result_id TEXT NOT NULL,
+    created_at           TIMESTAMPTZ NOT NULL,
+    updated_at           TIMESTAMPTZ NOT NULL,
+    status               TEXT NOT NULL,
+    drifted              BOOLEAN NOT NULL,
+    drifted_resources    TEXT[],
+    error_message        TEXT NOT NULL,
+    run_id               TEXT REFERENCES runs ON UPDATE CASCADE ON DELETE CASCADE,
+    workspace_id         TEXT REFERENCES workspaces ON UPDATE CASCADE ON DELETE CASCADE NOT NULL,
+                         PRIMARY KEY (assessment_result_id)
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS assessment_results;
+
+ALTER TABLE workspaces DROP COLUMN assessments_enabled;
@@ -0,0 +1,10 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS recovery_tokens (
+    token_hash TEXT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL,
+    used_at    TIMESTAMPTZ,
+               PRIMARY KEY (token_hash)
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS recovery_tokens;
@@ -0,0 +1,12 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS run_annotations (
+    run_id     TEXT REFERENCES runs ON UPDATE CASCADE ON DELETE CASCADE NOT NULL,
+    key        TEXT NOT NULL,
+    value      TEXT NOT NULL,
+    plugin     TEXT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL,
+               PRIMARY KEY (run_id, key)
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS run_annotations;
@@ -0,0 +1,38 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS blobs (
+    digest     TEXT PRIMARY KEY,
+    data       BYTEA NOT NULL,
+    size       BIGINT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL
+);
+
+-- move existing configuration tarballs into the blob store
+INSERT INTO blobs (digest, data, size, created_at)
+SELECT DISTINCT ON (encode(sha256(config), 'hex'))
+    encode(sha256(config), 'hex'),
+    config,
+    octet_length(config),
+    now()
+FROM configuration_versions
+WHERE config IS NOT NULL;
+
+ALTER TABLE configuration_versions
+    ADD COLUMN config_digest TEXT REFERENCES blobs ON UPDATE CASCADE;
+
+UPDATE configuration_versions
+SET config_digest = encode(sha256(config), 'hex')
+WHERE config IS NOT NULL;
+
+ALTER TABLE configuration_versions DROP COLUMN config;
+
+-- +goose Down
+ALTER TABLE configuration_versions ADD COLUMN config BYTEA;
+
+UPDATE configuration_versions cv
+SET config = b.data
+FROM blobs b
+WHERE b.digest = cv.config_digest;
+
+ALTER TABLE configuration_versions DROP COLUMN config_digest;
+
+DROP TABLE IF EXISTS blobs;
@@ -0,0 +1,21 @@
+-- +goose Up
+ALTER TABLE organization_tokens
+    DROP CONSTRAINT IF EXISTS organization_tokens_organization_name_key,
+    ADD COLUMN description TEXT NOT NULL DEFAULT '',
+    ADD COLUMN created_by TEXT,
+    ADD COLUMN last_used_at TIMESTAMPTZ;
+
+-- an organization can have any number of named tokens but only one unnamed
+-- token
+CREATE UNIQUE INDEX IF NOT EXISTS organization_tokens_unnamed_idx
+    ON organization_tokens (organization_name)
+    WHERE description = '';
+
+-- +goose Down
+DELETE FROM organization_tokens WHERE description != '';
+DROP INDEX IF EXISTS organization_tokens_unnamed_idx;
+ALTER TABLE organization_tokens
+    DROP COLUMN last_used_at,
+    DROP COLUMN created_by,
+    DROP COLUMN description,
+    ADD CONSTRAINT organization_tokens_organization_name_key UNIQUE (organization_name);
@@ -0,0 +1,14 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS banners (
+    banner_id     TEXT,
+    created_at    TIMESTAMPTZ NOT NULL,
+    message       TEXT NOT NULL,
+    level         TEXT NOT NULL,
+    starts_at     TIMESTAMPTZ NOT NULL,
+    ends_at       TIMESTAMPTZ,
+    organizations TEXT[] NOT NULL,
+                  PRIMARY KEY (banner_id)
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS banners;
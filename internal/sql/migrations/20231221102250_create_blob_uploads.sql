@@ -0,0 +1,17 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS blob_uploads (
+    upload_id  TEXT PRIMARY KEY,
+    size       BIGINT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS blob_upload_parts (
+    upload_id   TEXT REFERENCES blob_uploads ON UPDATE CASCADE ON DELETE CASCADE NOT NULL,
+    part_offset BIGINT NOT NULL,
+    data        BYTEA NOT NULL,
+    PRIMARY KEY (upload_id, part_offset)
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS blob_upload_parts;
+DROP TABLE IF EXISTS blob_uploads;
@@ -0,0 +1,21 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS vcs_event_deliveries (
+    vcs_provider_id TEXT REFERENCES vcs_providers ON UPDATE CASCADE ON DELETE CASCADE NOT NULL,
+    delivery_id     TEXT NOT NULL,
+    received_at     TIMESTAMPTZ NOT NULL,
+                    PRIMARY KEY (vcs_provider_id, delivery_id)
+);
+
+CREATE TABLE IF NOT EXISTS vcs_event_dead_letters (
+    dead_letter_id  TEXT,
+    vcs_provider_id TEXT REFERENCES vcs_providers ON UPDATE CASCADE ON DELETE CASCADE NOT NULL,
+    subscriber      TEXT NOT NULL,
+    event           BYTEA NOT NULL,
+    error           TEXT NOT NULL,
+    created_at      TIMESTAMPTZ NOT NULL,
+                    PRIMARY KEY (dead_letter_id)
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS vcs_event_dead_letters;
+DROP TABLE IF EXISTS vcs_event_deliveries;
@@ -0,0 +1,9 @@
+-- +goose Up
+ALTER TABLE tokens
+    ADD COLUMN organization_name TEXT REFERENCES organizations (name) ON UPDATE CASCADE ON DELETE CASCADE,
+    ADD COLUMN scopes TEXT[];
+
+-- +goose Down
+ALTER TABLE tokens
+    DROP COLUMN scopes,
+    DROP COLUMN organization_name;
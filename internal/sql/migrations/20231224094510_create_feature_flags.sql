@@ -0,0 +1,15 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS feature_flags (
+    name              TEXT NOT NULL,
+    organization_name TEXT REFERENCES organizations (name) ON UPDATE CASCADE ON DELETE CASCADE,
+    enabled           BOOLEAN NOT NULL,
+    updated_at        TIMESTAMPTZ NOT NULL
+);
+
+-- a flag is set at most once for the installation, i.e. when the organization
+-- is null, and at most once for each organization.
+CREATE UNIQUE INDEX IF NOT EXISTS feature_flags_name_organization_name_idx
+    ON feature_flags (name, COALESCE(organization_name, ''));
+
+-- +goose Down
+DROP TABLE IF EXISTS feature_flags;
@@ -0,0 +1,16 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS change_tickets (
+    change_ticket_id TEXT,
+    run_id           TEXT REFERENCES runs ON UPDATE CASCADE ON DELETE CASCADE NOT NULL,
+    status           TEXT NOT NULL,
+    external_id      TEXT NOT NULL,
+    url              TEXT NOT NULL,
+    message          TEXT NOT NULL,
+    created_at       TIMESTAMPTZ NOT NULL,
+    updated_at       TIMESTAMPTZ NOT NULL,
+                     PRIMARY KEY (change_ticket_id),
+                     UNIQUE (run_id)
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS change_tickets;
@@ -0,0 +1,24 @@
+-- +goose Up
+ALTER TABLE runs
+    ADD COLUMN message TEXT NOT NULL DEFAULT '';
+
+-- full-text indexes use the simple configuration, which neither stems nor
+-- removes stop words, because workspace names are identifiers rather than
+-- natural language.
+CREATE INDEX IF NOT EXISTS workspaces_search_idx ON workspaces
+    USING GIN (to_tsvector('simple', name || ' ' || description));
+
+CREATE INDEX IF NOT EXISTS runs_message_search_idx ON runs
+    USING GIN (to_tsvector('simple', message));
+
+-- permit prefix matching of commit SHAs and tag names
+CREATE INDEX IF NOT EXISTS ingress_attributes_commit_sha_idx ON ingress_attributes (commit_sha text_pattern_ops);
+CREATE INDEX IF NOT EXISTS tags_name_idx ON tags (name text_pattern_ops);
+
+-- +goose Down
+DROP INDEX IF EXISTS tags_name_idx;
+DROP INDEX IF EXISTS ingress_attributes_commit_sha_idx;
+DROP INDEX IF EXISTS runs_message_search_idx;
+DROP INDEX IF EXISTS workspaces_search_idx;
+ALTER TABLE runs
+    DROP COLUMN message;
@@ -0,0 +1,46 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS resource_changes (
+    resource_change_id TEXT,
+    run_id             TEXT REFERENCES runs ON UPDATE CASCADE ON DELETE CASCADE NOT NULL,
+    address            TEXT NOT NULL,
+    module             TEXT NOT NULL,
+    mode               TEXT NOT NULL,
+    type               TEXT NOT NULL,
+    name               TEXT NOT NULL,
+    provider           TEXT NOT NULL,
+    action             TEXT NOT NULL,
+    remote_id          TEXT NOT NULL,
+    created_at         TIMESTAMPTZ NOT NULL,
+                       PRIMARY KEY (resource_change_id),
+                       UNIQUE (run_id, address, action)
+);
+
+-- +goose StatementBegin
+CREATE OR REPLACE FUNCTION resource_changes_notify_event() RETURNS TRIGGER AS $$
+DECLARE
+    record RECORD;
+    notification JSON;
+BEGIN
+    IF (TG_OP = 'DELETE') THEN
+        record = OLD;
+    ELSE
+        record = NEW;
+    END IF;
+    notification = json_build_object(
+                      'table',TG_TABLE_NAME,
+                      'action', TG_OP,
+                      'id', record.resource_change_id);
+    PERFORM pg_notify('events', notification::text);
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+-- +goose StatementEnd
+
+CREATE TRIGGER notify_event
+AFTER INSERT ON resource_changes
+    FOR EACH ROW EXECUTE PROCEDURE resource_changes_notify_event();
+
+-- +goose Down
+DROP TRIGGER IF EXISTS notify_event ON resource_changes;
+DROP FUNCTION IF EXISTS resource_changes_notify_event;
+DROP TABLE IF EXISTS resource_changes;
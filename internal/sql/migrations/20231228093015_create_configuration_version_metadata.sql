@@ -0,0 +1,23 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS configuration_version_variables (
+    configuration_version_id TEXT REFERENCES configuration_versions ON UPDATE CASCADE ON DELETE CASCADE NOT NULL,
+    name                     TEXT NOT NULL,
+    type                     TEXT NOT NULL,
+    description              TEXT NOT NULL,
+    default_value            TEXT,
+    required                 BOOL NOT NULL,
+    sensitive                BOOL NOT NULL,
+                             PRIMARY KEY (configuration_version_id, name)
+);
+
+CREATE TABLE IF NOT EXISTS configuration_version_outputs (
+    configuration_version_id TEXT REFERENCES configuration_versions ON UPDATE CASCADE ON DELETE CASCADE NOT NULL,
+    name                     TEXT NOT NULL,
+    description              TEXT NOT NULL,
+    sensitive                BOOL NOT NULL,
+                             PRIMARY KEY (configuration_version_id, name)
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS configuration_version_outputs;
+DROP TABLE IF EXISTS configuration_version_variables;
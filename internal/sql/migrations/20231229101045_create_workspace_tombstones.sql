@@ -0,0 +1,11 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS workspace_tombstones (
+    organization_name TEXT REFERENCES organizations (name) ON UPDATE CASCADE ON DELETE CASCADE NOT NULL,
+    name              TEXT NOT NULL,
+    workspace_id      TEXT REFERENCES workspaces ON UPDATE CASCADE ON DELETE CASCADE NOT NULL,
+    renamed_at        TIMESTAMPTZ NOT NULL,
+                      PRIMARY KEY (organization_name, name)
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS workspace_tombstones;
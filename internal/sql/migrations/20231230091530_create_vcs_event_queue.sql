@@ -0,0 +1,15 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS vcs_event_queue (
+    queued_event_id TEXT,
+    vcs_provider_id TEXT REFERENCES vcs_providers ON UPDATE CASCADE ON DELETE CASCADE NOT NULL,
+    subscriber      TEXT NOT NULL,
+    event           BYTEA NOT NULL,
+    created_at      TIMESTAMPTZ NOT NULL,
+    claimed_at      TIMESTAMPTZ NOT NULL,
+                    PRIMARY KEY (queued_event_id)
+);
+
+CREATE INDEX IF NOT EXISTS vcs_event_queue_claimed_at_idx ON vcs_event_queue (claimed_at);
+
+-- +goose Down
+DROP TABLE IF EXISTS vcs_event_queue;
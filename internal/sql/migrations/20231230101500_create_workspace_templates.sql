@@ -0,0 +1,9 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS workspace_templates (
+    workspace_id TEXT REFERENCES workspaces ON UPDATE CASCADE ON DELETE CASCADE NOT NULL,
+    created_at   TIMESTAMPTZ NOT NULL,
+                 PRIMARY KEY (workspace_id)
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS workspace_templates;
@@ -0,0 +1,10 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS sso_enforcements (
+    organization_name TEXT REFERENCES organizations (name) ON UPDATE CASCADE ON DELETE CASCADE NOT NULL,
+    exempt_usernames  TEXT[] NOT NULL,
+    updated_at        TIMESTAMPTZ NOT NULL,
+                      PRIMARY KEY (organization_name)
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS sso_enforcements;
@@ -0,0 +1,25 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS audit_events (
+    audit_event_id    TEXT,
+    time              TIMESTAMPTZ NOT NULL,
+    organization_name TEXT REFERENCES organizations (name) ON UPDATE CASCADE ON DELETE CASCADE NOT NULL,
+    action            TEXT NOT NULL,
+    subject           TEXT NOT NULL,
+    token_id          TEXT,
+    source_ip         TEXT,
+    resource_id       TEXT NOT NULL,
+    justification     TEXT,
+                      PRIMARY KEY (audit_event_id)
+);
+
+CREATE INDEX IF NOT EXISTS audit_events_organization_name_time_idx ON audit_events (organization_name, time DESC);
+
+CREATE TABLE IF NOT EXISTS audit_settings (
+    organization_name                    TEXT REFERENCES organizations (name) ON UPDATE CASCADE ON DELETE CASCADE NOT NULL,
+    require_state_download_justification BOOLEAN NOT NULL,
+                                         PRIMARY KEY (organization_name)
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS audit_settings;
+DROP TABLE IF EXISTS audit_events;
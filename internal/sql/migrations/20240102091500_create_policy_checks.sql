@@ -0,0 +1,24 @@
+-- +goose Up
+INSERT INTO run_statuses (status) VALUES ('policy_checking'), ('policy_checked'), ('policy_override'), ('policy_soft_failed');
+
+CREATE TABLE IF NOT EXISTS policy_checks (
+    policy_check_id  TEXT NOT NULL,
+    created_at       TIMESTAMPTZ NOT NULL,
+    updated_at       TIMESTAMPTZ NOT NULL,
+    status           TEXT NOT NULL,
+    passed           INTEGER NOT NULL,
+    advisory_failed  INTEGER NOT NULL,
+    soft_failed      INTEGER NOT NULL,
+    hard_failed      INTEGER NOT NULL,
+    error_message    TEXT NOT NULL,
+    completed_at     TIMESTAMPTZ,
+    overridden_at    TIMESTAMPTZ,
+    run_id           TEXT REFERENCES runs ON UPDATE CASCADE ON DELETE CASCADE NOT NULL,
+                     PRIMARY KEY (policy_check_id)
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS policy_checks;
+DELETE FROM run_status_timestamps WHERE status IN ('policy_checking', 'policy_checked', 'policy_override', 'policy_soft_failed');
+UPDATE runs SET status = 'errored' WHERE status IN ('policy_checking', 'policy_checked', 'policy_override', 'policy_soft_failed');
+DELETE FROM run_statuses WHERE status IN ('policy_checking', 'policy_checked', 'policy_override', 'policy_soft_failed');
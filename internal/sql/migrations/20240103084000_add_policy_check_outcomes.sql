@@ -0,0 +1,7 @@
+-- +goose Up
+ALTER TABLE policy_checks ADD COLUMN outcomes BYTEA;
+UPDATE policy_checks SET outcomes = '[]';
+ALTER TABLE policy_checks ALTER COLUMN outcomes SET NOT NULL;
+
+-- +goose Down
+ALTER TABLE policy_checks DROP COLUMN outcomes;
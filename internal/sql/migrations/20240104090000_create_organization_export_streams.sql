@@ -0,0 +1,352 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS organization_export_streams (
+    organization_name TEXT REFERENCES organizations (name) ON UPDATE CASCADE ON DELETE CASCADE NOT NULL,
+    created_at        TIMESTAMPTZ NOT NULL,
+    exported_sequence BIGINT      NOT NULL,
+    exported_at       TIMESTAMPTZ,
+    last_error        TEXT,
+                      PRIMARY KEY (organization_name)
+);
+
+-- organization_changes is the log of changes made to organizations with an
+-- export stream, pending export.
+CREATE TABLE IF NOT EXISTS organization_changes (
+    sequence          BIGSERIAL,
+    organization_name TEXT REFERENCES organization_export_streams ON UPDATE CASCADE ON DELETE CASCADE NOT NULL,
+    table_name        TEXT        NOT NULL,
+    action            TEXT        NOT NULL,
+    data              BYTEA       NOT NULL,
+    created_at        TIMESTAMPTZ NOT NULL,
+                      PRIMARY KEY (sequence)
+);
+
+CREATE INDEX IF NOT EXISTS organization_changes_organization_name_idx ON organization_changes (organization_name, sequence);
+
+-- +goose StatementBegin
+-- record_organization_change records a change to a row belonging to an
+-- organization, but only if the organization has an export stream. A change
+-- to a row whose organization cannot be determined, e.g. the row is being
+-- deleted along with its parent, is not recorded: its deletion is implied by
+-- the deletion of its parent.
+CREATE OR REPLACE FUNCTION record_organization_change(org TEXT, tbl TEXT, op TEXT, row_data JSON) RETURNS VOID AS $$
+BEGIN
+    IF org IS NULL THEN
+        RETURN;
+    END IF;
+    IF NOT EXISTS (SELECT FROM organization_export_streams WHERE organization_name = org) THEN
+        RETURN;
+    END IF;
+    INSERT INTO organization_changes (
+        organization_name,
+        table_name,
+        action,
+        data,
+        created_at
+    ) VALUES (
+        org,
+        tbl,
+        op,
+        convert_to(row_data::text, 'UTF8'),
+        current_timestamp
+    );
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE OR REPLACE FUNCTION organizations_record_change() RETURNS TRIGGER AS $$
+DECLARE
+    record RECORD;
+BEGIN
+    IF (TG_OP = 'DELETE') THEN
+        record = OLD;
+    ELSE
+        record = NEW;
+    END IF;
+    PERFORM record_organization_change(record.name, TG_TABLE_NAME, TG_OP, row_to_json(record));
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+-- record_change_by_organization_name records changes to tables with an
+-- organization_name column.
+CREATE OR REPLACE FUNCTION record_change_by_organization_name() RETURNS TRIGGER AS $$
+DECLARE
+    record RECORD;
+BEGIN
+    IF (TG_OP = 'DELETE') THEN
+        record = OLD;
+    ELSE
+        record = NEW;
+    END IF;
+    PERFORM record_organization_change(record.organization_name, TG_TABLE_NAME, TG_OP, row_to_json(record));
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+-- record_change_by_workspace_id records changes to tables with a
+-- workspace_id column.
+CREATE OR REPLACE FUNCTION record_change_by_workspace_id() RETURNS TRIGGER AS $$
+DECLARE
+    record RECORD;
+    org TEXT;
+BEGIN
+    IF (TG_OP = 'DELETE') THEN
+        record = OLD;
+    ELSE
+        record = NEW;
+    END IF;
+    SELECT organization_name INTO org FROM workspaces WHERE workspace_id = record.workspace_id;
+    PERFORM record_organization_change(org, TG_TABLE_NAME, TG_OP, row_to_json(record));
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE OR REPLACE FUNCTION team_memberships_record_change() RETURNS TRIGGER AS $$
+DECLARE
+    record RECORD;
+    org TEXT;
+BEGIN
+    IF (TG_OP = 'DELETE') THEN
+        record = OLD;
+    ELSE
+        record = NEW;
+    END IF;
+    SELECT organization_name INTO org FROM teams WHERE team_id = record.team_id;
+    PERFORM record_organization_change(org, TG_TABLE_NAME, TG_OP, row_to_json(record));
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE OR REPLACE FUNCTION state_version_outputs_record_change() RETURNS TRIGGER AS $$
+DECLARE
+    record RECORD;
+    org TEXT;
+BEGIN
+    IF (TG_OP = 'DELETE') THEN
+        record = OLD;
+    ELSE
+        record = NEW;
+    END IF;
+    SELECT w.organization_name INTO org
+    FROM state_versions sv
+    JOIN workspaces w USING (workspace_id)
+    WHERE sv.state_version_id = record.state_version_id;
+    PERFORM record_organization_change(org, TG_TABLE_NAME, TG_OP, row_to_json(record));
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE OR REPLACE FUNCTION variable_set_workspaces_record_change() RETURNS TRIGGER AS $$
+DECLARE
+    record RECORD;
+    org TEXT;
+BEGIN
+    IF (TG_OP = 'DELETE') THEN
+        record = OLD;
+    ELSE
+        record = NEW;
+    END IF;
+    SELECT organization_name INTO org FROM variable_sets WHERE variable_set_id = record.variable_set_id;
+    PERFORM record_organization_change(org, TG_TABLE_NAME, TG_OP, row_to_json(record));
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+-- variables_record_change records updates to variables. A variable is created
+-- before it is linked to a workspace or variable set, and deleted along with
+-- its link, so its creation and deletion are instead recorded along with its
+-- link.
+CREATE OR REPLACE FUNCTION variables_record_change() RETURNS TRIGGER AS $$
+DECLARE
+    org TEXT;
+BEGIN
+    SELECT w.organization_name INTO org
+    FROM workspace_variables wv
+    JOIN workspaces w USING (workspace_id)
+    WHERE wv.variable_id = NEW.variable_id;
+    IF org IS NULL THEN
+        SELECT vs.organization_name INTO org
+        FROM variable_set_variables vsv
+        JOIN variable_sets vs USING (variable_set_id)
+        WHERE vsv.variable_id = NEW.variable_id;
+    END IF;
+    PERFORM record_organization_change(org, TG_TABLE_NAME, TG_OP, row_to_json(NEW));
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+-- variable_links_record_change records changes to the links between variables
+-- and workspaces or variable sets, along with the creation and deletion of the
+-- linked variable.
+CREATE OR REPLACE FUNCTION variable_links_record_change() RETURNS TRIGGER AS $$
+DECLARE
+    record RECORD;
+    org TEXT;
+    variable JSON;
+BEGIN
+    IF (TG_OP = 'DELETE') THEN
+        record = OLD;
+    ELSE
+        record = NEW;
+    END IF;
+    IF (TG_TABLE_NAME = 'workspace_variables') THEN
+        SELECT organization_name INTO org FROM workspaces WHERE workspace_id = record.workspace_id;
+    ELSE
+        SELECT organization_name INTO org FROM variable_sets WHERE variable_set_id = record.variable_set_id;
+    END IF;
+    IF (TG_OP = 'INSERT') THEN
+        SELECT row_to_json(v) INTO variable FROM variables v WHERE v.variable_id = record.variable_id;
+        PERFORM record_organization_change(org, 'variables', TG_OP, variable);
+        PERFORM record_organization_change(org, TG_TABLE_NAME, TG_OP, row_to_json(record));
+    ELSE
+        PERFORM record_organization_change(org, TG_TABLE_NAME, TG_OP, row_to_json(record));
+        PERFORM record_organization_change(org, 'variables', TG_OP, json_build_object('variable_id', record.variable_id));
+    END IF;
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+-- snapshot_organization records the current state of an organization as a
+-- change for each row belonging to the organization, in an order in which
+-- they can be restored.
+CREATE OR REPLACE FUNCTION snapshot_organization(org TEXT) RETURNS VOID AS $$
+BEGIN
+    PERFORM record_organization_change(org, 'organizations', 'SNAPSHOT', row_to_json(o))
+    FROM organizations o WHERE o.name = org;
+    PERFORM record_organization_change(org, 'teams', 'SNAPSHOT', row_to_json(t))
+    FROM teams t WHERE t.organization_name = org;
+    PERFORM record_organization_change(org, 'team_memberships', 'SNAPSHOT', row_to_json(tm))
+    FROM team_memberships tm JOIN teams t USING (team_id) WHERE t.organization_name = org;
+    PERFORM record_organization_change(org, 'tags', 'SNAPSHOT', row_to_json(t))
+    FROM tags t WHERE t.organization_name = org;
+    PERFORM record_organization_change(org, 'vcs_providers', 'SNAPSHOT', row_to_json(vp))
+    FROM vcs_providers vp WHERE vp.organization_name = org;
+    PERFORM record_organization_change(org, 'workspaces', 'SNAPSHOT', row_to_json(w))
+    FROM workspaces w WHERE w.organization_name = org;
+    PERFORM record_organization_change(org, 'workspace_permissions', 'SNAPSHOT', row_to_json(wp))
+    FROM workspace_permissions wp JOIN workspaces w USING (workspace_id) WHERE w.organization_name = org;
+    PERFORM record_organization_change(org, 'workspace_tags', 'SNAPSHOT', row_to_json(wt))
+    FROM workspace_tags wt JOIN workspaces w USING (workspace_id) WHERE w.organization_name = org;
+    PERFORM record_organization_change(org, 'notification_configurations', 'SNAPSHOT', row_to_json(nc))
+    FROM notification_configurations nc JOIN workspaces w USING (workspace_id) WHERE w.organization_name = org;
+    PERFORM record_organization_change(org, 'state_versions', 'SNAPSHOT', row_to_json(sv))
+    FROM state_versions sv JOIN workspaces w USING (workspace_id) WHERE w.organization_name = org;
+    PERFORM record_organization_change(org, 'state_version_outputs', 'SNAPSHOT', row_to_json(svo))
+    FROM state_version_outputs svo
+    JOIN state_versions sv USING (state_version_id)
+    JOIN workspaces w USING (workspace_id)
+    WHERE w.organization_name = org;
+    PERFORM record_organization_change(org, 'variable_sets', 'SNAPSHOT', row_to_json(vs))
+    FROM variable_sets vs WHERE vs.organization_name = org;
+    PERFORM record_organization_change(org, 'variable_set_workspaces', 'SNAPSHOT', row_to_json(vsw))
+    FROM variable_set_workspaces vsw JOIN variable_sets vs USING (variable_set_id) WHERE vs.organization_name = org;
+    PERFORM record_organization_change(org, 'variables', 'SNAPSHOT', row_to_json(v))
+    FROM variables v
+    JOIN workspace_variables wv USING (variable_id)
+    JOIN workspaces w USING (workspace_id)
+    WHERE w.organization_name = org;
+    PERFORM record_organization_change(org, 'workspace_variables', 'SNAPSHOT', row_to_json(wv))
+    FROM workspace_variables wv JOIN workspaces w USING (workspace_id) WHERE w.organization_name = org;
+    PERFORM record_organization_change(org, 'variables', 'SNAPSHOT', row_to_json(v))
+    FROM variables v
+    JOIN variable_set_variables vsv USING (variable_id)
+    JOIN variable_sets vs USING (variable_set_id)
+    WHERE vs.organization_name = org;
+    PERFORM record_organization_change(org, 'variable_set_variables', 'SNAPSHOT', row_to_json(vsv))
+    FROM variable_set_variables vsv JOIN variable_sets vs USING (variable_set_id) WHERE vs.organization_name = org;
+END;
+$$ LANGUAGE plpgsql;
+-- +goose StatementEnd
+
+CREATE TRIGGER record_change
+AFTER INSERT OR UPDATE OR DELETE ON organizations
+    FOR EACH ROW EXECUTE PROCEDURE organizations_record_change();
+
+CREATE TRIGGER record_change
+AFTER INSERT OR UPDATE OR DELETE ON teams
+    FOR EACH ROW EXECUTE PROCEDURE record_change_by_organization_name();
+
+CREATE TRIGGER record_change
+AFTER INSERT OR UPDATE OR DELETE ON team_memberships
+    FOR EACH ROW EXECUTE PROCEDURE team_memberships_record_change();
+
+CREATE TRIGGER record_change
+AFTER INSERT OR UPDATE OR DELETE ON tags
+    FOR EACH ROW EXECUTE PROCEDURE record_change_by_organization_name();
+
+CREATE TRIGGER record_change
+AFTER INSERT OR UPDATE OR DELETE ON vcs_providers
+    FOR EACH ROW EXECUTE PROCEDURE record_change_by_organization_name();
+
+CREATE TRIGGER record_change
+AFTER INSERT OR UPDATE OR DELETE ON workspaces
+    FOR EACH ROW EXECUTE PROCEDURE record_change_by_organization_name();
+
+CREATE TRIGGER record_change
+AFTER INSERT OR UPDATE OR DELETE ON workspace_permissions
+    FOR EACH ROW EXECUTE PROCEDURE record_change_by_workspace_id();
+
+CREATE TRIGGER record_change
+AFTER INSERT OR UPDATE OR DELETE ON workspace_tags
+    FOR EACH ROW EXECUTE PROCEDURE record_change_by_workspace_id();
+
+CREATE TRIGGER record_change
+AFTER INSERT OR UPDATE OR DELETE ON notification_configurations
+    FOR EACH ROW EXECUTE PROCEDURE record_change_by_workspace_id();
+
+CREATE TRIGGER record_change
+AFTER INSERT OR UPDATE OR DELETE ON state_versions
+    FOR EACH ROW EXECUTE PROCEDURE record_change_by_workspace_id();
+
+CREATE TRIGGER record_change
+AFTER INSERT OR UPDATE OR DELETE ON state_version_outputs
+    FOR EACH ROW EXECUTE PROCEDURE state_version_outputs_record_change();
+
+CREATE TRIGGER record_change
+AFTER INSERT OR UPDATE OR DELETE ON variable_sets
+    FOR EACH ROW EXECUTE PROCEDURE record_change_by_organization_name();
+
+CREATE TRIGGER record_change
+AFTER INSERT OR UPDATE OR DELETE ON variable_set_workspaces
+    FOR EACH ROW EXECUTE PROCEDURE variable_set_workspaces_record_change();
+
+CREATE TRIGGER record_change
+AFTER UPDATE ON variables
+    FOR EACH ROW EXECUTE PROCEDURE variables_record_change();
+
+CREATE TRIGGER record_change
+AFTER INSERT OR DELETE ON workspace_variables
+    FOR EACH ROW EXECUTE PROCEDURE variable_links_record_change();
+
+CREATE TRIGGER record_change
+AFTER INSERT OR DELETE ON variable_set_variables
+    FOR EACH ROW EXECUTE PROCEDURE variable_links_record_change();
+
+-- +goose Down
+DROP TRIGGER IF EXISTS record_change ON variable_set_variables;
+DROP TRIGGER IF EXISTS record_change ON workspace_variables;
+DROP TRIGGER IF EXISTS record_change ON variables;
+DROP TRIGGER IF EXISTS record_change ON variable_set_workspaces;
+DROP TRIGGER IF EXISTS record_change ON variable_sets;
+DROP TRIGGER IF EXISTS record_change ON state_version_outputs;
+DROP TRIGGER IF EXISTS record_change ON state_versions;
+DROP TRIGGER IF EXISTS record_change ON notification_configurations;
+DROP TRIGGER IF EXISTS record_change ON workspace_tags;
+DROP TRIGGER IF EXISTS record_change ON workspace_permissions;
+DROP TRIGGER IF EXISTS record_change ON workspaces;
+DROP TRIGGER IF EXISTS record_change ON vcs_providers;
+DROP TRIGGER IF EXISTS record_change ON tags;
+DROP TRIGGER IF EXISTS record_change ON team_memberships;
+DROP TRIGGER IF EXISTS record_change ON teams;
+DROP TRIGGER IF EXISTS record_change ON organizations;
+DROP FUNCTION IF EXISTS snapshot_organization;
+DROP FUNCTION IF EXISTS variable_links_record_change;
+DROP FUNCTION IF EXISTS variables_record_change;
+DROP FUNCTION IF EXISTS variable_set_workspaces_record_change;
+DROP FUNCTION IF EXISTS state_version_outputs_record_change;
+DROP FUNCTION IF EXISTS team_memberships_record_change;
+DROP FUNCTION IF EXISTS record_change_by_workspace_id;
+DROP FUNCTION IF EXISTS record_change_by_organization_name;
+DROP FUNCTION IF EXISTS organizations_record_change;
+DROP FUNCTION IF EXISTS record_organization_change;
+DROP TABLE IF EXISTS organization_changes;
+DROP TABLE IF EXISTS organization_export_streams;
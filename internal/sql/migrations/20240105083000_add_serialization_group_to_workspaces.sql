@@ -0,0 +1,5 @@
+-- +goose Up
+ALTER TABLE workspaces ADD COLUMN serialization_group TEXT;
+
+-- +goose Down
+ALTER TABLE workspaces DROP COLUMN serialization_group;
@@ -0,0 +1,12 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS run_apply_failures (
+    run_id   TEXT REFERENCES runs ON UPDATE CASCADE ON DELETE CASCADE NOT NULL,
+    position INTEGER NOT NULL,
+    address  TEXT NOT NULL,
+    action   TEXT NOT NULL,
+    summary  TEXT,
+             UNIQUE (run_id, position)
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS run_apply_failures;
@@ -0,0 +1,12 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS run_task_settings (
+    organization_name TEXT REFERENCES organizations (name) ON UPDATE CASCADE ON DELETE CASCADE NOT NULL,
+    concurrency       INT NOT NULL,
+    pre_plan_timeout  INT NOT NULL,
+    post_plan_timeout INT NOT NULL,
+    timeout_policy    TEXT NOT NULL,
+                      PRIMARY KEY (organization_name)
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS run_task_settings;
@@ -0,0 +1,27 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS blob_migrations (
+    migration_id TEXT NOT NULL,
+    created_at   TIMESTAMPTZ NOT NULL,
+    updated_at   TIMESTAMPTZ NOT NULL,
+    source       TEXT NOT NULL,
+    destination  TEXT NOT NULL,
+    status       TEXT NOT NULL,
+    batch_size   INT NOT NULL,
+    rate_limit   BIGINT NOT NULL,
+    total        INT NOT NULL,
+    copied       INT NOT NULL,
+    copied_bytes BIGINT NOT NULL,
+    failed       INT NOT NULL,
+    last_digest  TEXT NOT NULL,
+    error        TEXT NOT NULL,
+    completed_at TIMESTAMPTZ,
+                 PRIMARY KEY (migration_id)
+);
+
+-- only one migration may be in progress at any one time
+CREATE UNIQUE INDEX IF NOT EXISTS blob_migrations_in_progress_idx
+    ON blob_migrations ((true))
+    WHERE status IN ('running', 'paused');
+
+-- +goose Down
+DROP TABLE IF EXISTS blob_migrations;
@@ -0,0 +1,5 @@
+-- +goose Up
+ALTER TABLE repohooks ADD COLUMN group_hook BOOLEAN DEFAULT false NOT NULL;
+
+-- +goose Down
+ALTER TABLE repohooks DROP COLUMN group_hook;
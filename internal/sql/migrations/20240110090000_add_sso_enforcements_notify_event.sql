@@ -0,0 +1,29 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE OR REPLACE FUNCTION sso_enforcements_notify_event() RETURNS TRIGGER AS $$
+DECLARE
+    record RECORD;
+    notification JSON;
+BEGIN
+    IF (TG_OP = 'DELETE') THEN
+        record = OLD;
+    ELSE
+        record = NEW;
+    END IF;
+    notification = json_build_object(
+                      'table',TG_TABLE_NAME,
+                      'action', TG_OP,
+                      'id', record.organization_name);
+    PERFORM pg_notify('events', notification::text);
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+-- +goose StatementEnd
+
+CREATE TRIGGER notify_event
+AFTER INSERT OR UPDATE OR DELETE ON sso_enforcements
+    FOR EACH ROW EXECUTE PROCEDURE sso_enforcements_notify_event();
+
+-- +goose Down
+DROP TRIGGER IF EXISTS notify_event ON sso_enforcements;
+DROP FUNCTION IF EXISTS sso_enforcements_notify_event;
@@ -148,6 +148,27 @@ type Querier interface {
 	// DeleteAgentPoolAllowedWorkspaceScan scans the result of an executed DeleteAgentPoolAllowedWorkspaceBatch query.
 	DeleteAgentPoolAllowedWorkspaceScan(results pgx.BatchResults) (pgconn.CommandTag, error)
 
+	InsertWorkspacePlacementConstraint(ctx context.Context, workspaceID pgtype.Text, label pgtype.Text) (pgconn.CommandTag, error)
+	// InsertWorkspacePlacementConstraintBatch enqueues a InsertWorkspacePlacementConstraint query into batch to be executed
+	// later by the batch.
+	InsertWorkspacePlacementConstraintBatch(batch genericBatch, workspaceID pgtype.Text, label pgtype.Text)
+	// InsertWorkspacePlacementConstraintScan scans the result of an executed InsertWorkspacePlacementConstraintBatch query.
+	InsertWorkspacePlacementConstraintScan(results pgx.BatchResults) (pgconn.CommandTag, error)
+
+	FindWorkspacePlacementConstraints(ctx context.Context, workspaceID pgtype.Text) ([]pgtype.Text, error)
+	// FindWorkspacePlacementConstraintsBatch enqueues a FindWorkspacePlacementConstraints query into batch to be executed
+	// later by the batch.
+	FindWorkspacePlacementConstraintsBatch(batch genericBatch, workspaceID pgtype.Text)
+	// FindWorkspacePlacementConstraintsScan scans the result of an executed FindWorkspacePlacementConstraintsBatch query.
+	FindWorkspacePlacementConstraintsScan(results pgx.BatchResults) ([]pgtype.Text, error)
+
+	DeleteWorkspacePlacementConstraints(ctx context.Context, workspaceID pgtype.Text) (pgconn.CommandTag, error)
+	// DeleteWorkspacePlacementConstraintsBatch enqueues a DeleteWorkspacePlacementConstraints query into batch to be executed
+	// later by the batch.
+	DeleteWorkspacePlacementConstraintsBatch(batch genericBatch, workspaceID pgtype.Text)
+	// DeleteWorkspacePlacementConstraintsScan scans the result of an executed DeleteWorkspacePlacementConstraintsBatch query.
+	DeleteWorkspacePlacementConstraintsScan(results pgx.BatchResults) (pgconn.CommandTag, error)
+
 	InsertAgentToken(ctx context.Context, params InsertAgentTokenParams) (pgconn.CommandTag, error)
 	// InsertAgentTokenBatch enqueues a InsertAgentToken query into batch to be executed
 	// later by the batch.
@@ -197,6 +218,272 @@ type Querier interface {
 	// UpdateApplyStatusByIDScan scans the result of an executed UpdateApplyStatusByIDBatch query.
 	UpdateApplyStatusByIDScan(results pgx.BatchResults) (pgtype.Text, error)
 
+	InsertAssessmentResult(ctx context.Context, params InsertAssessmentResultParams) (pgconn.CommandTag, error)
+	// InsertAssessmentResultBatch enqueues a InsertAssessmentResult query into batch to be executed
+	// later by the batch.
+	InsertAssessmentResultBatch(batch genericBatch, params InsertAssessmentResultParams)
+	// InsertAssessmentResultScan scans the result of an executed InsertAssessmentResultBatch query.
+	InsertAssessmentResultScan(results pgx.BatchResults) (pgconn.CommandTag, error)
+
+	FindAssessmentResultsByWorkspaceID(ctx context.Context, workspaceID pgtype.Text) ([]FindAssessmentResultsByWorkspaceIDRow, error)
+	// FindAssessmentResultsByWorkspaceIDBatch enqueues a FindAssessmentResultsByWorkspaceID query into batch to be executed
+	// later by the batch.
+	FindAssessmentResultsByWorkspaceIDBatch(batch genericBatch, workspaceID pgtype.Text)
+	// FindAssessmentResultsByWorkspaceIDScan scans the result of an executed FindAssessmentResultsByWorkspaceIDBatch query.
+	FindAssessmentResultsByWorkspaceIDScan(results pgx.BatchResults) ([]FindAssessmentResultsByWorkspaceIDRow, error)
+
+	FindAssessmentResultByID(ctx context.Context, assessmentResultID pgtype.Text) (FindAssessmentResultByIDRow, error)
+	// FindAssessmentResultByIDBatch enqueues a FindAssessmentResultByID query into batch to be executed
+	// later by the batch.
+	FindAssessmentResultByIDBatch(batch genericBatch, assessmentResultID pgtype.Text)
+	// FindAssessmentResultByIDScan scans the result of an executed FindAssessmentResultByIDBatch query.
+	FindAssessmentResultByIDScan(results pgx.BatchResults) (FindAssessmentResultByIDRow, error)
+
+	FindAssessmentResultsByStatus(ctx context.Context, status pgtype.Text) ([]FindAssessmentResultsByStatusRow, error)
+	// FindAssessmentResultsByStatusBatch enqueues a FindAssessmentResultsByStatus query into batch to be executed
+	// later by the batch.
+	FindAssessmentResultsByStatusBatch(batch genericBatch, status pgtype.Text)
+	// FindAssessmentResultsByStatusScan scans the result of an executed FindAssessmentResultsByStatusBatch query.
+	FindAssessmentResultsByStatusScan(results pgx.BatchResults) ([]FindAssessmentResultsByStatusRow, error)
+
+	UpdateAssessmentResult(ctx context.Context, params UpdateAssessmentResultParams) (pgconn.CommandTag, error)
+	// UpdateAssessmentResultBatch enqueues a UpdateAssessmentResult query into batch to be executed
+	// later by the batch.
+	UpdateAssessmentResultBatch(batch genericBatch, params UpdateAssessmentResultParams)
+	// UpdateAssessmentResultScan scans the result of an executed UpdateAssessmentResultBatch query.
+	UpdateAssessmentResultScan(results pgx.BatchResults) (pgconn.CommandTag, error)
+
+	FindWorkspaceIDsDueAssessment(ctx context.Context, assessedBefore pgtype.Timestamptz) ([]pgtype.Text, error)
+	// FindWorkspaceIDsDueAssessmentBatch enqueues a FindWorkspaceIDsDueAssessment query into batch to be executed
+	// later by the batch.
+	FindWorkspaceIDsDueAssessmentBatch(batch genericBatch, assessedBefore pgtype.Timestamptz)
+	// FindWorkspaceIDsDueAssessmentScan scans the result of an executed FindWorkspaceIDsDueAssessmentBatch query.
+	FindWorkspaceIDsDueAssessmentScan(results pgx.BatchResults) ([]pgtype.Text, error)
+
+	InsertAuditEvent(ctx context.Context, params InsertAuditEventParams) (pgconn.CommandTag, error)
+	// InsertAuditEventBatch enqueues a InsertAuditEvent query into batch to be executed
+	// later by the batch.
+	InsertAuditEventBatch(batch genericBatch, params InsertAuditEventParams)
+	// InsertAuditEventScan scans the result of an executed InsertAuditEventBatch query.
+	InsertAuditEventScan(results pgx.BatchResults) (pgconn.CommandTag, error)
+
+	FindAuditEvents(ctx context.Context, params FindAuditEventsParams) ([]FindAuditEventsRow, error)
+	// FindAuditEventsBatch enqueues a FindAuditEvents query into batch to be executed
+	// later by the batch.
+	FindAuditEventsBatch(batch genericBatch, params FindAuditEventsParams)
+	// FindAuditEventsScan scans the result of an executed FindAuditEventsBatch query.
+	FindAuditEventsScan(results pgx.BatchResults) ([]FindAuditEventsRow, error)
+
+	CountAuditEvents(ctx context.Context, organizationName pgtype.Text, action pgtype.Text) (pgtype.Int8, error)
+	// CountAuditEventsBatch enqueues a CountAuditEvents query into batch to be executed
+	// later by the batch.
+	CountAuditEventsBatch(batch genericBatch, organizationName pgtype.Text, action pgtype.Text)
+	// CountAuditEventsScan scans the result of an executed CountAuditEventsBatch query.
+	CountAuditEventsScan(results pgx.BatchResults) (pgtype.Int8, error)
+
+	UpsertAuditSettings(ctx context.Context, organizationName pgtype.Text, requireStateDownloadJustification pgtype.Bool) (pgconn.CommandTag, error)
+	// UpsertAuditSettingsBatch enqueues a UpsertAuditSettings query into batch to be executed
+	// later by the batch.
+	UpsertAuditSettingsBatch(batch genericBatch, organizationName pgtype.Text, requireStateDownloadJustification pgtype.Bool)
+	// UpsertAuditSettingsScan scans the result of an executed UpsertAuditSettingsBatch query.
+	UpsertAuditSettingsScan(results pgx.BatchResults) (pgconn.CommandTag, error)
+
+	FindAuditSettings(ctx context.Context, organizationName pgtype.Text) (FindAuditSettingsRow, error)
+	// FindAuditSettingsBatch enqueues a FindAuditSettings query into batch to be executed
+	// later by the batch.
+	FindAuditSettingsBatch(batch genericBatch, organizationName pgtype.Text)
+	// FindAuditSettingsScan scans the result of an executed FindAuditSettingsBatch query.
+	FindAuditSettingsScan(results pgx.BatchResults) (FindAuditSettingsRow, error)
+
+	InsertBanner(ctx context.Context, params InsertBannerParams) (pgconn.CommandTag, error)
+	// InsertBannerBatch enqueues a InsertBanner query into batch to be executed
+	// later by the batch.
+	InsertBannerBatch(batch genericBatch, params InsertBannerParams)
+	// InsertBannerScan scans the result of an executed InsertBannerBatch query.
+	InsertBannerScan(results pgx.BatchResults) (pgconn.CommandTag, error)
+
+	FindBanners(ctx context.Context) ([]FindBannersRow, error)
+	// FindBannersBatch enqueues a FindBanners query into batch to be executed
+	// later by the batch.
+	FindBannersBatch(batch genericBatch)
+	// FindBannersScan scans the result of an executed FindBannersBatch query.
+	FindBannersScan(results pgx.BatchResults) ([]FindBannersRow, error)
+
+	DeleteBannerByID(ctx context.Context, bannerID pgtype.Text) (pgtype.Text, error)
+	// DeleteBannerByIDBatch enqueues a DeleteBannerByID query into batch to be executed
+	// later by the batch.
+	DeleteBannerByIDBatch(batch genericBatch, bannerID pgtype.Text)
+	// DeleteBannerByIDScan scans the result of an executed DeleteBannerByIDBatch query.
+	DeleteBannerByIDScan(results pgx.BatchResults) (pgtype.Text, error)
+
+	// InsertBlob inserts a blob. Blobs are content-addressed, so inserting a blob
+	// that already exists is a no-op.
+	//
+	InsertBlob(ctx context.Context, params InsertBlobParams) (pgconn.CommandTag, error)
+	// InsertBlobBatch enqueues a InsertBlob query into batch to be executed
+	// later by the batch.
+	InsertBlobBatch(batch genericBatch, params InsertBlobParams)
+	// InsertBlobScan scans the result of an executed InsertBlobBatch query.
+	InsertBlobScan(results pgx.BatchResults) (pgconn.CommandTag, error)
+
+	FindBlobByDigest(ctx context.Context, digest pgtype.Text) ([]byte, error)
+	// FindBlobByDigestBatch enqueues a FindBlobByDigest query into batch to be executed
+	// later by the batch.
+	FindBlobByDigestBatch(batch genericBatch, digest pgtype.Text)
+	// FindBlobByDigestScan scans the result of an executed FindBlobByDigestBatch query.
+	FindBlobByDigestScan(results pgx.BatchResults) ([]byte, error)
+
+	// InsertBlobUpload starts an upload of a blob.
+	//
+	InsertBlobUpload(ctx context.Context, uploadID pgtype.Text, createdAt pgtype.Timestamptz) (pgconn.CommandTag, error)
+	// InsertBlobUploadBatch enqueues a InsertBlobUpload query into batch to be executed
+	// later by the batch.
+	InsertBlobUploadBatch(batch genericBatch, uploadID pgtype.Text, createdAt pgtype.Timestamptz)
+	// InsertBlobUploadScan scans the result of an executed InsertBlobUploadBatch query.
+	InsertBlobUploadScan(results pgx.BatchResults) (pgconn.CommandTag, error)
+
+	FindBlobUploadSizeForUpdate(ctx context.Context, uploadID pgtype.Text) (pgtype.Int8, error)
+	// FindBlobUploadSizeForUpdateBatch enqueues a FindBlobUploadSizeForUpdate query into batch to be executed
+	// later by the batch.
+	FindBlobUploadSizeForUpdateBatch(batch genericBatch, uploadID pgtype.Text)
+	// FindBlobUploadSizeForUpdateScan scans the result of an executed FindBlobUploadSizeForUpdateBatch query.
+	FindBlobUploadSizeForUpdateScan(results pgx.BatchResults) (pgtype.Int8, error)
+
+	FindBlobUploadSize(ctx context.Context, uploadID pgtype.Text) (pgtype.Int8, error)
+	// FindBlobUploadSizeBatch enqueues a FindBlobUploadSize query into batch to be executed
+	// later by the batch.
+	FindBlobUploadSizeBatch(batch genericBatch, uploadID pgtype.Text)
+	// FindBlobUploadSizeScan scans the result of an executed FindBlobUploadSizeBatch query.
+	FindBlobUploadSizeScan(results pgx.BatchResults) (pgtype.Int8, error)
+
+	InsertBlobUploadPart(ctx context.Context, params InsertBlobUploadPartParams) (pgconn.CommandTag, error)
+	// InsertBlobUploadPartBatch enqueues a InsertBlobUploadPart query into batch to be executed
+	// later by the batch.
+	InsertBlobUploadPartBatch(batch genericBatch, params InsertBlobUploadPartParams)
+	// InsertBlobUploadPartScan scans the result of an executed InsertBlobUploadPartBatch query.
+	InsertBlobUploadPartScan(results pgx.BatchResults) (pgconn.CommandTag, error)
+
+	UpdateBlobUploadSize(ctx context.Context, size pgtype.Int8, uploadID pgtype.Text) (pgconn.CommandTag, error)
+	// UpdateBlobUploadSizeBatch enqueues a UpdateBlobUploadSize query into batch to be executed
+	// later by the batch.
+	UpdateBlobUploadSizeBatch(batch genericBatch, size pgtype.Int8, uploadID pgtype.Text)
+	// UpdateBlobUploadSizeScan scans the result of an executed UpdateBlobUploadSizeBatch query.
+	UpdateBlobUploadSizeScan(results pgx.BatchResults) (pgconn.CommandTag, error)
+
+	FindBlobUploadPart(ctx context.Context, uploadID pgtype.Text, partOffset pgtype.Int8) ([]byte, error)
+	// FindBlobUploadPartBatch enqueues a FindBlobUploadPart query into batch to be executed
+	// later by the batch.
+	FindBlobUploadPartBatch(batch genericBatch, uploadID pgtype.Text, partOffset pgtype.Int8)
+	// FindBlobUploadPartScan scans the result of an executed FindBlobUploadPartBatch query.
+	FindBlobUploadPartScan(results pgx.BatchResults) ([]byte, error)
+
+	// InsertBlobFromUpload assembles the parts of an upload into a blob.
+	//
+	InsertBlobFromUpload(ctx context.Context, params InsertBlobFromUploadParams) (pgconn.CommandTag, error)
+	// InsertBlobFromUploadBatch enqueues a InsertBlobFromUpload query into batch to be executed
+	// later by the batch.
+	InsertBlobFromUploadBatch(batch genericBatch, params InsertBlobFromUploadParams)
+	// InsertBlobFromUploadScan scans the result of an executed InsertBlobFromUploadBatch query.
+	InsertBlobFromUploadScan(results pgx.BatchResults) (pgconn.CommandTag, error)
+
+	DeleteBlobUpload(ctx context.Context, uploadID pgtype.Text) (pgconn.CommandTag, error)
+	// DeleteBlobUploadBatch enqueues a DeleteBlobUpload query into batch to be executed
+	// later by the batch.
+	DeleteBlobUploadBatch(batch genericBatch, uploadID pgtype.Text)
+	// DeleteBlobUploadScan scans the result of an executed DeleteBlobUploadBatch query.
+	DeleteBlobUploadScan(results pgx.BatchResults) (pgconn.CommandTag, error)
+
+	InsertBlobMigration(ctx context.Context, params InsertBlobMigrationParams) (pgconn.CommandTag, error)
+	// InsertBlobMigrationBatch enqueues a InsertBlobMigration query into batch to be executed
+	// later by the batch.
+	InsertBlobMigrationBatch(batch genericBatch, params InsertBlobMigrationParams)
+	// InsertBlobMigrationScan scans the result of an executed InsertBlobMigrationBatch query.
+	InsertBlobMigrationScan(results pgx.BatchResults) (pgconn.CommandTag, error)
+
+	FindBlobMigrations(ctx context.Context) ([]FindBlobMigrationsRow, error)
+	// FindBlobMigrationsBatch enqueues a FindBlobMigrations query into batch to be executed
+	// later by the batch.
+	FindBlobMigrationsBatch(batch genericBatch)
+	// FindBlobMigrationsScan scans the result of an executed FindBlobMigrationsBatch query.
+	FindBlobMigrationsScan(results pgx.BatchResults) ([]FindBlobMigrationsRow, error)
+
+	FindBlobMigrationsByStatus(ctx context.Context, status pgtype.Text) ([]FindBlobMigrationsByStatusRow, error)
+	// FindBlobMigrationsByStatusBatch enqueues a FindBlobMigrationsByStatus query into batch to be executed
+	// later by the batch.
+	FindBlobMigrationsByStatusBatch(batch genericBatch, status pgtype.Text)
+	// FindBlobMigrationsByStatusScan scans the result of an executed FindBlobMigrationsByStatusBatch query.
+	FindBlobMigrationsByStatusScan(results pgx.BatchResults) ([]FindBlobMigrationsByStatusRow, error)
+
+	FindBlobMigrationByID(ctx context.Context, migrationID pgtype.Text) (FindBlobMigrationByIDRow, error)
+	// FindBlobMigrationByIDBatch enqueues a FindBlobMigrationByID query into batch to be executed
+	// later by the batch.
+	FindBlobMigrationByIDBatch(batch genericBatch, migrationID pgtype.Text)
+	// FindBlobMigrationByIDScan scans the result of an executed FindBlobMigrationByIDBatch query.
+	FindBlobMigrationByIDScan(results pgx.BatchResults) (FindBlobMigrationByIDRow, error)
+
+	FindBlobMigrationByIDForUpdate(ctx context.Context, migrationID pgtype.Text) (FindBlobMigrationByIDForUpdateRow, error)
+	// FindBlobMigrationByIDForUpdateBatch enqueues a FindBlobMigrationByIDForUpdate query into batch to be executed
+	// later by the batch.
+	FindBlobMigrationByIDForUpdateBatch(batch genericBatch, migrationID pgtype.Text)
+	// FindBlobMigrationByIDForUpdateScan scans the result of an executed FindBlobMigrationByIDForUpdateBatch query.
+	FindBlobMigrationByIDForUpdateScan(results pgx.BatchResults) (FindBlobMigrationByIDForUpdateRow, error)
+
+	UpdateBlobMigration(ctx context.Context, params UpdateBlobMigrationParams) (pgconn.CommandTag, error)
+	// UpdateBlobMigrationBatch enqueues a UpdateBlobMigration query into batch to be executed
+	// later by the batch.
+	UpdateBlobMigrationBatch(batch genericBatch, params UpdateBlobMigrationParams)
+	// UpdateBlobMigrationScan scans the result of an executed UpdateBlobMigrationBatch query.
+	UpdateBlobMigrationScan(results pgx.BatchResults) (pgconn.CommandTag, error)
+
+	CountBlobs(ctx context.Context) (pgtype.Int8, error)
+	// CountBlobsBatch enqueues a CountBlobs query into batch to be executed
+	// later by the batch.
+	CountBlobsBatch(batch genericBatch)
+	// CountBlobsScan scans the result of an executed CountBlobsBatch query.
+	CountBlobsScan(results pgx.BatchResults) (pgtype.Int8, error)
+
+	FindBlobDigestsAfter(ctx context.Context, params FindBlobDigestsAfterParams) ([]FindBlobDigestsAfterRow, error)
+	// FindBlobDigestsAfterBatch enqueues a FindBlobDigestsAfter query into batch to be executed
+	// later by the batch.
+	FindBlobDigestsAfterBatch(batch genericBatch, params FindBlobDigestsAfterParams)
+	// FindBlobDigestsAfterScan scans the result of an executed FindBlobDigestsAfterBatch query.
+	FindBlobDigestsAfterScan(results pgx.BatchResults) ([]FindBlobDigestsAfterRow, error)
+
+	InsertChangeTicket(ctx context.Context, params InsertChangeTicketParams) (pgconn.CommandTag, error)
+	// InsertChangeTicketBatch enqueues a InsertChangeTicket query into batch to be executed
+	// later by the batch.
+	InsertChangeTicketBatch(batch genericBatch, params InsertChangeTicketParams)
+	// InsertChangeTicketScan scans the result of an executed InsertChangeTicketBatch query.
+	InsertChangeTicketScan(results pgx.BatchResults) (pgconn.CommandTag, error)
+
+	FindChangeTicketByID(ctx context.Context, changeTicketID pgtype.Text) (FindChangeTicketByIDRow, error)
+	// FindChangeTicketByIDBatch enqueues a FindChangeTicketByID query into batch to be executed
+	// later by the batch.
+	FindChangeTicketByIDBatch(batch genericBatch, changeTicketID pgtype.Text)
+	// FindChangeTicketByIDScan scans the result of an executed FindChangeTicketByIDBatch query.
+	FindChangeTicketByIDScan(results pgx.BatchResults) (FindChangeTicketByIDRow, error)
+
+	FindChangeTicketByIDForUpdate(ctx context.Context, changeTicketID pgtype.Text) (FindChangeTicketByIDForUpdateRow, error)
+	// FindChangeTicketByIDForUpdateBatch enqueues a FindChangeTicketByIDForUpdate query into batch to be executed
+	// later by the batch.
+	FindChangeTicketByIDForUpdateBatch(batch genericBatch, changeTicketID pgtype.Text)
+	// FindChangeTicketByIDForUpdateScan scans the result of an executed FindChangeTicketByIDForUpdateBatch query.
+	FindChangeTicketByIDForUpdateScan(results pgx.BatchResults) (FindChangeTicketByIDForUpdateRow, error)
+
+	FindChangeTicketByRunID(ctx context.Context, runID pgtype.Text) (FindChangeTicketByRunIDRow, error)
+	// FindChangeTicketByRunIDBatch enqueues a FindChangeTicketByRunID query into batch to be executed
+	// later by the batch.
+	FindChangeTicketByRunIDBatch(batch genericBatch, runID pgtype.Text)
+	// FindChangeTicketByRunIDScan scans the result of an executed FindChangeTicketByRunIDBatch query.
+	FindChangeTicketByRunIDScan(results pgx.BatchResults) (FindChangeTicketByRunIDRow, error)
+
+	UpdateChangeTicket(ctx context.Context, params UpdateChangeTicketParams) (pgconn.CommandTag, error)
+	// UpdateChangeTicketBatch enqueues a UpdateChangeTicket query into batch to be executed
+	// later by the batch.
+	UpdateChangeTicketBatch(batch genericBatch, params UpdateChangeTicketParams)
+	// UpdateChangeTicketScan scans the result of an executed UpdateChangeTicketBatch query.
+	UpdateChangeTicketScan(results pgx.BatchResults) (pgconn.CommandTag, error)
+
 	InsertConfigurationVersion(ctx context.Context, params InsertConfigurationVersionParams) (pgconn.CommandTag, error)
 	// InsertConfigurationVersionBatch enqueues a InsertConfigurationVersion query into batch to be executed
 	// later by the batch.
@@ -251,15 +538,15 @@ type Querier interface {
 	// FindConfigurationVersionByIDForUpdateScan scans the result of an executed FindConfigurationVersionByIDForUpdateBatch query.
 	FindConfigurationVersionByIDForUpdateScan(results pgx.BatchResults) (FindConfigurationVersionByIDForUpdateRow, error)
 
-	// DownloadConfigurationVersion gets a configuration_version config
-	// tarball.
+	// DownloadConfigurationVersion gets the digest of a configuration_version
+	// config tarball, with which the tarball can be retrieved from the blob store.
 	//
-	DownloadConfigurationVersion(ctx context.Context, configurationVersionID pgtype.Text) ([]byte, error)
+	DownloadConfigurationVersion(ctx context.Context, configurationVersionID pgtype.Text) (pgtype.Text, error)
 	// DownloadConfigurationVersionBatch enqueues a DownloadConfigurationVersion query into batch to be executed
 	// later by the batch.
 	DownloadConfigurationVersionBatch(batch genericBatch, configurationVersionID pgtype.Text)
 	// DownloadConfigurationVersionScan scans the result of an executed DownloadConfigurationVersionBatch query.
-	DownloadConfigurationVersionScan(results pgx.BatchResults) ([]byte, error)
+	DownloadConfigurationVersionScan(results pgx.BatchResults) (pgtype.Text, error)
 
 	UpdateConfigurationVersionErroredByID(ctx context.Context, id pgtype.Text) (pgtype.Text, error)
 	// UpdateConfigurationVersionErroredByIDBatch enqueues a UpdateConfigurationVersionErroredByID query into batch to be executed
@@ -268,10 +555,10 @@ type Querier interface {
 	// UpdateConfigurationVersionErroredByIDScan scans the result of an executed UpdateConfigurationVersionErroredByIDBatch query.
 	UpdateConfigurationVersionErroredByIDScan(results pgx.BatchResults) (pgtype.Text, error)
 
-	UpdateConfigurationVersionConfigByID(ctx context.Context, config []byte, id pgtype.Text) (pgtype.Text, error)
+	UpdateConfigurationVersionConfigByID(ctx context.Context, configDigest pgtype.Text, id pgtype.Text) (pgtype.Text, error)
 	// UpdateConfigurationVersionConfigByIDBatch enqueues a UpdateConfigurationVersionConfigByID query into batch to be executed
 	// later by the batch.
-	UpdateConfigurationVersionConfigByIDBatch(batch genericBatch, config []byte, id pgtype.Text)
+	UpdateConfigurationVersionConfigByIDBatch(batch genericBatch, configDigest pgtype.Text, id pgtype.Text)
 	// UpdateConfigurationVersionConfigByIDScan scans the result of an executed UpdateConfigurationVersionConfigByIDBatch query.
 	UpdateConfigurationVersionConfigByIDScan(results pgx.BatchResults) (pgtype.Text, error)
 
@@ -282,6 +569,216 @@ type Querier interface {
 	// DeleteConfigurationVersionByIDScan scans the result of an executed DeleteConfigurationVersionByIDBatch query.
 	DeleteConfigurationVersionByIDScan(results pgx.BatchResults) (pgtype.Text, error)
 
+	FindConfigurationVersionUsageByOrganization(ctx context.Context, organizationName pgtype.Text) ([]FindConfigurationVersionUsageByOrganizationRow, error)
+	// FindConfigurationVersionUsageByOrganizationBatch enqueues a FindConfigurationVersionUsageByOrganization query into batch to be executed
+	// later by the batch.
+	FindConfigurationVersionUsageByOrganizationBatch(batch genericBatch, organizationName pgtype.Text)
+	// FindConfigurationVersionUsageByOrganizationScan scans the result of an executed FindConfigurationVersionUsageByOrganizationBatch query.
+	FindConfigurationVersionUsageByOrganizationScan(results pgx.BatchResults) ([]FindConfigurationVersionUsageByOrganizationRow, error)
+
+	FindConfigurationVersionStorageUsed(ctx context.Context, workspaceID pgtype.Text) (pgtype.Int8, error)
+	// FindConfigurationVersionStorageUsedBatch enqueues a FindConfigurationVersionStorageUsed query into batch to be executed
+	// later by the batch.
+	FindConfigurationVersionStorageUsedBatch(batch genericBatch, workspaceID pgtype.Text)
+	// FindConfigurationVersionStorageUsedScan scans the result of an executed FindConfigurationVersionStorageUsedBatch query.
+	FindConfigurationVersionStorageUsedScan(results pgx.BatchResults) (pgtype.Int8, error)
+
+	InsertConfigurationVersionDependency(ctx context.Context, params InsertConfigurationVersionDependencyParams) (pgconn.CommandTag, error)
+	// InsertConfigurationVersionDependencyBatch enqueues a InsertConfigurationVersionDependency query into batch to be executed
+	// later by the batch.
+	InsertConfigurationVersionDependencyBatch(batch genericBatch, params InsertConfigurationVersionDependencyParams)
+	// InsertConfigurationVersionDependencyScan scans the result of an executed InsertConfigurationVersionDependencyBatch query.
+	InsertConfigurationVersionDependencyScan(results pgx.BatchResults) (pgconn.CommandTag, error)
+
+	FindDependencyConsumptionByOrganization(ctx context.Context, organizationName pgtype.Text) ([]FindDependencyConsumptionByOrganizationRow, error)
+	// FindDependencyConsumptionByOrganizationBatch enqueues a FindDependencyConsumptionByOrganization query into batch to be executed
+	// later by the batch.
+	FindDependencyConsumptionByOrganizationBatch(batch genericBatch, organizationName pgtype.Text)
+	// FindDependencyConsumptionByOrganizationScan scans the result of an executed FindDependencyConsumptionByOrganizationBatch query.
+	FindDependencyConsumptionByOrganizationScan(results pgx.BatchResults) ([]FindDependencyConsumptionByOrganizationRow, error)
+
+	FindConfigurationVersionWorkingDirectory(ctx context.Context, configurationVersionID pgtype.Text) (pgtype.Text, error)
+	// FindConfigurationVersionWorkingDirectoryBatch enqueues a FindConfigurationVersionWorkingDirectory query into batch to be executed
+	// later by the batch.
+	FindConfigurationVersionWorkingDirectoryBatch(batch genericBatch, configurationVersionID pgtype.Text)
+	// FindConfigurationVersionWorkingDirectoryScan scans the result of an executed FindConfigurationVersionWorkingDirectoryBatch query.
+	FindConfigurationVersionWorkingDirectoryScan(results pgx.BatchResults) (pgtype.Text, error)
+
+	FindConfigurationVersionDependencies(ctx context.Context, configurationVersionID pgtype.Text) ([]FindConfigurationVersionDependenciesRow, error)
+	// FindConfigurationVersionDependenciesBatch enqueues a FindConfigurationVersionDependencies query into batch to be executed
+	// later by the batch.
+	FindConfigurationVersionDependenciesBatch(batch genericBatch, configurationVersionID pgtype.Text)
+	// FindConfigurationVersionDependenciesScan scans the result of an executed FindConfigurationVersionDependenciesBatch query.
+	FindConfigurationVersionDependenciesScan(results pgx.BatchResults) ([]FindConfigurationVersionDependenciesRow, error)
+
+	InsertConfigurationVersionVariable(ctx context.Context, params InsertConfigurationVersionVariableParams) (pgconn.CommandTag, error)
+	// InsertConfigurationVersionVariableBatch enqueues a InsertConfigurationVersionVariable query into batch to be executed
+	// later by the batch.
+	InsertConfigurationVersionVariableBatch(batch genericBatch, params InsertConfigurationVersionVariableParams)
+	// InsertConfigurationVersionVariableScan scans the result of an executed InsertConfigurationVersionVariableBatch query.
+	InsertConfigurationVersionVariableScan(results pgx.BatchResults) (pgconn.CommandTag, error)
+
+	FindConfigurationVersionVariables(ctx context.Context, configurationVersionID pgtype.Text) ([]FindConfigurationVersionVariablesRow, error)
+	// FindConfigurationVersionVariablesBatch enqueues a FindConfigurationVersionVariables query into batch to be executed
+	// later by the batch.
+	FindConfigurationVersionVariablesBatch(batch genericBatch, configurationVersionID pgtype.Text)
+	// FindConfigurationVersionVariablesScan scans the result of an executed FindConfigurationVersionVariablesBatch query.
+	FindConfigurationVersionVariablesScan(results pgx.BatchResults) ([]FindConfigurationVersionVariablesRow, error)
+
+	InsertConfigurationVersionOutput(ctx context.Context, params InsertConfigurationVersionOutputParams) (pgconn.CommandTag, error)
+	// InsertConfigurationVersionOutputBatch enqueues a InsertConfigurationVersionOutput query into batch to be executed
+	// later by the batch.
+	InsertConfigurationVersionOutputBatch(batch genericBatch, params InsertConfigurationVersionOutputParams)
+	// InsertConfigurationVersionOutputScan scans the result of an executed InsertConfigurationVersionOutputBatch query.
+	InsertConfigurationVersionOutputScan(results pgx.BatchResults) (pgconn.CommandTag, error)
+
+	FindConfigurationVersionOutputs(ctx context.Context, configurationVersionID pgtype.Text) ([]FindConfigurationVersionOutputsRow, error)
+	// FindConfigurationVersionOutputsBatch enqueues a FindConfigurationVersionOutputs query into batch to be executed
+	// later by the batch.
+	FindConfigurationVersionOutputsBatch(batch genericBatch, configurationVersionID pgtype.Text)
+	// FindConfigurationVersionOutputsScan scans the result of an executed FindConfigurationVersionOutputsBatch query.
+	FindConfigurationVersionOutputsScan(results pgx.BatchResults) ([]FindConfigurationVersionOutputsRow, error)
+
+	InsertConfigurationVersionCreator(ctx context.Context, configurationVersionID pgtype.Text, createdBy pgtype.Text) (pgconn.CommandTag, error)
+	// InsertConfigurationVersionCreatorBatch enqueues a InsertConfigurationVersionCreator query into batch to be executed
+	// later by the batch.
+	InsertConfigurationVersionCreatorBatch(batch genericBatch, configurationVersionID pgtype.Text, createdBy pgtype.Text)
+	// InsertConfigurationVersionCreatorScan scans the result of an executed InsertConfigurationVersionCreatorBatch query.
+	InsertConfigurationVersionCreatorScan(results pgx.BatchResults) (pgconn.CommandTag, error)
+
+	InsertDeletedStateVersionsByWorkspaceID(ctx context.Context, workspaceID pgtype.Text) (pgconn.CommandTag, error)
+	// InsertDeletedStateVersionsByWorkspaceIDBatch enqueues a InsertDeletedStateVersionsByWorkspaceID query into batch to be executed
+	// later by the batch.
+	InsertDeletedStateVersionsByWorkspaceIDBatch(batch genericBatch, workspaceID pgtype.Text)
+	// InsertDeletedStateVersionsByWorkspaceIDScan scans the result of an executed InsertDeletedStateVersionsByWorkspaceIDBatch query.
+	InsertDeletedStateVersionsByWorkspaceIDScan(results pgx.BatchResults) (pgconn.CommandTag, error)
+
+	InsertDeletedStateVersionOutputsByWorkspaceID(ctx context.Context, workspaceID pgtype.Text) (pgconn.CommandTag, error)
+	// InsertDeletedStateVersionOutputsByWorkspaceIDBatch enqueues a InsertDeletedStateVersionOutputsByWorkspaceID query into batch to be executed
+	// later by the batch.
+	InsertDeletedStateVersionOutputsByWorkspaceIDBatch(batch genericBatch, workspaceID pgtype.Text)
+	// InsertDeletedStateVersionOutputsByWorkspaceIDScan scans the result of an executed InsertDeletedStateVersionOutputsByWorkspaceIDBatch query.
+	InsertDeletedStateVersionOutputsByWorkspaceIDScan(results pgx.BatchResults) (pgconn.CommandTag, error)
+
+	RestoreStateVersionsByWorkspaceID(ctx context.Context, workspaceID pgtype.Text) (pgconn.CommandTag, error)
+	// RestoreStateVersionsByWorkspaceIDBatch enqueues a RestoreStateVersionsByWorkspaceID query into batch to be executed
+	// later by the batch.
+	RestoreStateVersionsByWorkspaceIDBatch(batch genericBatch, workspaceID pgtype.Text)
+	// RestoreStateVersionsByWorkspaceIDScan scans the result of an executed RestoreStateVersionsByWorkspaceIDBatch query.
+	RestoreStateVersionsByWorkspaceIDScan(results pgx.BatchResults) (pgconn.CommandTag, error)
+
+	RestoreStateVersionOutputsByWorkspaceID(ctx context.Context, workspaceID pgtype.Text) (pgconn.CommandTag, error)
+	// RestoreStateVersionOutputsByWorkspaceIDBatch enqueues a RestoreStateVersionOutputsByWorkspaceID query into batch to be executed
+	// later by the batch.
+	RestoreStateVersionOutputsByWorkspaceIDBatch(batch genericBatch, workspaceID pgtype.Text)
+	// RestoreStateVersionOutputsByWorkspaceIDScan scans the result of an executed RestoreStateVersionOutputsByWorkspaceIDBatch query.
+	RestoreStateVersionOutputsByWorkspaceIDScan(results pgx.BatchResults) (pgconn.CommandTag, error)
+
+	RestoreWorkspaceCurrentStateVersionID(ctx context.Context, workspaceID pgtype.Text) (pgconn.CommandTag, error)
+	// RestoreWorkspaceCurrentStateVersionIDBatch enqueues a RestoreWorkspaceCurrentStateVersionID query into batch to be executed
+	// later by the batch.
+	RestoreWorkspaceCurrentStateVersionIDBatch(batch genericBatch, workspaceID pgtype.Text)
+	// RestoreWorkspaceCurrentStateVersionIDScan scans the result of an executed RestoreWorkspaceCurrentStateVersionIDBatch query.
+	RestoreWorkspaceCurrentStateVersionIDScan(results pgx.BatchResults) (pgconn.CommandTag, error)
+
+	InsertDeletedWorkspace(ctx context.Context, params InsertDeletedWorkspaceParams) (pgconn.CommandTag, error)
+	// InsertDeletedWorkspaceBatch enqueues a InsertDeletedWorkspace query into batch to be executed
+	// later by the batch.
+	InsertDeletedWorkspaceBatch(batch genericBatch, params InsertDeletedWorkspaceParams)
+	// InsertDeletedWorkspaceScan scans the result of an executed InsertDeletedWorkspaceBatch query.
+	InsertDeletedWorkspaceScan(results pgx.BatchResults) (pgconn.CommandTag, error)
+
+	FindDeletedWorkspacesByOrganization(ctx context.Context, params FindDeletedWorkspacesByOrganizationParams) ([]FindDeletedWorkspacesByOrganizationRow, error)
+	// FindDeletedWorkspacesByOrganizationBatch enqueues a FindDeletedWorkspacesByOrganization query into batch to be executed
+	// later by the batch.
+	FindDeletedWorkspacesByOrganizationBatch(batch genericBatch, params FindDeletedWorkspacesByOrganizationParams)
+	// FindDeletedWorkspacesByOrganizationScan scans the result of an executed FindDeletedWorkspacesByOrganizationBatch query.
+	FindDeletedWorkspacesByOrganizationScan(results pgx.BatchResults) ([]FindDeletedWorkspacesByOrganizationRow, error)
+
+	CountDeletedWorkspacesByOrganization(ctx context.Context, organizationName pgtype.Text) (pgtype.Int8, error)
+	// CountDeletedWorkspacesByOrganizationBatch enqueues a CountDeletedWorkspacesByOrganization query into batch to be executed
+	// later by the batch.
+	CountDeletedWorkspacesByOrganizationBatch(batch genericBatch, organizationName pgtype.Text)
+	// CountDeletedWorkspacesByOrganizationScan scans the result of an executed CountDeletedWorkspacesByOrganizationBatch query.
+	CountDeletedWorkspacesByOrganizationScan(results pgx.BatchResults) (pgtype.Int8, error)
+
+	FindDeletedWorkspaceByID(ctx context.Context, workspaceID pgtype.Text) (FindDeletedWorkspaceByIDRow, error)
+	// FindDeletedWorkspaceByIDBatch enqueues a FindDeletedWorkspaceByID query into batch to be executed
+	// later by the batch.
+	FindDeletedWorkspaceByIDBatch(batch genericBatch, workspaceID pgtype.Text)
+	// FindDeletedWorkspaceByIDScan scans the result of an executed FindDeletedWorkspaceByIDBatch query.
+	FindDeletedWorkspaceByIDScan(results pgx.BatchResults) (FindDeletedWorkspaceByIDRow, error)
+
+	FindDeletedWorkspaceByIDForUpdate(ctx context.Context, workspaceID pgtype.Text) (FindDeletedWorkspaceByIDForUpdateRow, error)
+	// FindDeletedWorkspaceByIDForUpdateBatch enqueues a FindDeletedWorkspaceByIDForUpdate query into batch to be executed
+	// later by the batch.
+	FindDeletedWorkspaceByIDForUpdateBatch(batch genericBatch, workspaceID pgtype.Text)
+	// FindDeletedWorkspaceByIDForUpdateScan scans the result of an executed FindDeletedWorkspaceByIDForUpdateBatch query.
+	FindDeletedWorkspaceByIDForUpdateScan(results pgx.BatchResults) (FindDeletedWorkspaceByIDForUpdateRow, error)
+
+	DeleteDeletedWorkspaceByID(ctx context.Context, workspaceID pgtype.Text) (pgconn.CommandTag, error)
+	// DeleteDeletedWorkspaceByIDBatch enqueues a DeleteDeletedWorkspaceByID query into batch to be executed
+	// later by the batch.
+	DeleteDeletedWorkspaceByIDBatch(batch genericBatch, workspaceID pgtype.Text)
+	// DeleteDeletedWorkspaceByIDScan scans the result of an executed DeleteDeletedWorkspaceByIDBatch query.
+	DeleteDeletedWorkspaceByIDScan(results pgx.BatchResults) (pgconn.CommandTag, error)
+
+	DeleteDeletedWorkspacesBefore(ctx context.Context, deletedBefore pgtype.Timestamptz) (pgconn.CommandTag, error)
+	// DeleteDeletedWorkspacesBeforeBatch enqueues a DeleteDeletedWorkspacesBefore query into batch to be executed
+	// later by the batch.
+	DeleteDeletedWorkspacesBeforeBatch(batch genericBatch, deletedBefore pgtype.Timestamptz)
+	// DeleteDeletedWorkspacesBeforeScan scans the result of an executed DeleteDeletedWorkspacesBeforeBatch query.
+	DeleteDeletedWorkspacesBeforeScan(results pgx.BatchResults) (pgconn.CommandTag, error)
+
+	InsertEmailMessage(ctx context.Context, params InsertEmailMessageParams) (pgconn.CommandTag, error)
+	// InsertEmailMessageBatch enqueues a InsertEmailMessage query into batch to be executed
+	// later by the batch.
+	InsertEmailMessageBatch(batch genericBatch, params InsertEmailMessageParams)
+	// InsertEmailMessageScan scans the result of an executed InsertEmailMessageBatch query.
+	InsertEmailMessageScan(results pgx.BatchResults) (pgconn.CommandTag, error)
+
+	FindDueEmailMessages(ctx context.Context, now pgtype.Timestamptz) ([]FindDueEmailMessagesRow, error)
+	// FindDueEmailMessagesBatch enqueues a FindDueEmailMessages query into batch to be executed
+	// later by the batch.
+	FindDueEmailMessagesBatch(batch genericBatch, now pgtype.Timestamptz)
+	// FindDueEmailMessagesScan scans the result of an executed FindDueEmailMessagesBatch query.
+	FindDueEmailMessagesScan(results pgx.BatchResults) ([]FindDueEmailMessagesRow, error)
+
+	UpdateEmailMessageAttempt(ctx context.Context, params UpdateEmailMessageAttemptParams) (pgconn.CommandTag, error)
+	// UpdateEmailMessageAttemptBatch enqueues a UpdateEmailMessageAttempt query into batch to be executed
+	// later by the batch.
+	UpdateEmailMessageAttemptBatch(batch genericBatch, params UpdateEmailMessageAttemptParams)
+	// UpdateEmailMessageAttemptScan scans the result of an executed UpdateEmailMessageAttemptBatch query.
+	UpdateEmailMessageAttemptScan(results pgx.BatchResults) (pgconn.CommandTag, error)
+
+	DeleteEmailMessage(ctx context.Context, emailMessageID pgtype.Text) (pgconn.CommandTag, error)
+	// DeleteEmailMessageBatch enqueues a DeleteEmailMessage query into batch to be executed
+	// later by the batch.
+	DeleteEmailMessageBatch(batch genericBatch, emailMessageID pgtype.Text)
+	// DeleteEmailMessageScan scans the result of an executed DeleteEmailMessageBatch query.
+	DeleteEmailMessageScan(results pgx.BatchResults) (pgconn.CommandTag, error)
+
+	UpsertFeatureFlag(ctx context.Context, params UpsertFeatureFlagParams) (pgconn.CommandTag, error)
+	// UpsertFeatureFlagBatch enqueues a UpsertFeatureFlag query into batch to be executed
+	// later by the batch.
+	UpsertFeatureFlagBatch(batch genericBatch, params UpsertFeatureFlagParams)
+	// UpsertFeatureFlagScan scans the result of an executed UpsertFeatureFlagBatch query.
+	UpsertFeatureFlagScan(results pgx.BatchResults) (pgconn.CommandTag, error)
+
+	FindFeatureFlags(ctx context.Context) ([]FindFeatureFlagsRow, error)
+	// FindFeatureFlagsBatch enqueues a FindFeatureFlags query into batch to be executed
+	// later by the batch.
+	FindFeatureFlagsBatch(batch genericBatch)
+	// FindFeatureFlagsScan scans the result of an executed FindFeatureFlagsBatch query.
+	FindFeatureFlagsScan(results pgx.BatchResults) ([]FindFeatureFlagsRow, error)
+
+	DeleteFeatureFlag(ctx context.Context, name pgtype.Text, organizationName pgtype.Text) (pgtype.Text, error)
+	// DeleteFeatureFlagBatch enqueues a DeleteFeatureFlag query into batch to be executed
+	// later by the batch.
+	DeleteFeatureFlagBatch(batch genericBatch, name pgtype.Text, organizationName pgtype.Text)
+	// DeleteFeatureFlagScan scans the result of an executed DeleteFeatureFlagBatch query.
+	DeleteFeatureFlagScan(results pgx.BatchResults) (pgtype.Text, error)
+
 	InsertGithubApp(ctx context.Context, params InsertGithubAppParams) (pgconn.CommandTag, error)
 	// InsertGithubAppBatch enqueues a InsertGithubApp query into batch to be executed
 	// later by the batch.
@@ -352,6 +849,13 @@ type Querier interface {
 	// FindAllocatedJobsScan scans the result of an executed FindAllocatedJobsBatch query.
 	FindAllocatedJobsScan(results pgx.BatchResults) ([]FindAllocatedJobsRow, error)
 
+	FindRunningJobs(ctx context.Context, agentID pgtype.Text) ([]FindRunningJobsRow, error)
+	// FindRunningJobsBatch enqueues a FindRunningJobs query into batch to be executed
+	// later by the batch.
+	FindRunningJobsBatch(batch genericBatch, agentID pgtype.Text)
+	// FindRunningJobsScan scans the result of an executed FindRunningJobsBatch query.
+	FindRunningJobsScan(results pgx.BatchResults) ([]FindRunningJobsRow, error)
+
 	// Find signaled jobs and then immediately update signal with null.
 	//
 	FindAndUpdateSignaledJobs(ctx context.Context, agentID pgtype.Text) ([]FindAndUpdateSignaledJobsRow, error)
@@ -368,6 +872,16 @@ type Querier interface {
 	// UpdateJobScan scans the result of an executed UpdateJobBatch query.
 	UpdateJobScan(results pgx.BatchResults) (UpdateJobRow, error)
 
+	// DeleteJob deletes the job for a run phase, i.e. a previous job for a phase
+	// that is being retried.
+	//
+	DeleteJob(ctx context.Context, runID pgtype.Text, phase pgtype.Text) (pgconn.CommandTag, error)
+	// DeleteJobBatch enqueues a DeleteJob query into batch to be executed
+	// later by the batch.
+	DeleteJobBatch(batch genericBatch, runID pgtype.Text, phase pgtype.Text)
+	// DeleteJobScan scans the result of an executed DeleteJobBatch query.
+	DeleteJobScan(results pgx.BatchResults) (pgconn.CommandTag, error)
+
 	InsertModule(ctx context.Context, params InsertModuleParams) (pgconn.CommandTag, error)
 	// InsertModuleBatch enqueues a InsertModule query into batch to be executed
 	// later by the batch.
@@ -459,6 +973,13 @@ type Querier interface {
 	// DeleteModuleVersionByIDScan scans the result of an executed DeleteModuleVersionByIDBatch query.
 	DeleteModuleVersionByIDScan(results pgx.BatchResults) (pgtype.Text, error)
 
+	FindConnectedModuleIDs(ctx context.Context) ([]pgtype.Text, error)
+	// FindConnectedModuleIDsBatch enqueues a FindConnectedModuleIDs query into batch to be executed
+	// later by the batch.
+	FindConnectedModuleIDsBatch(batch genericBatch)
+	// FindConnectedModuleIDsScan scans the result of an executed FindConnectedModuleIDsBatch query.
+	FindConnectedModuleIDsScan(results pgx.BatchResults) ([]pgtype.Text, error)
+
 	InsertNotificationConfiguration(ctx context.Context, params InsertNotificationConfigurationParams) (pgconn.CommandTag, error)
 	// InsertNotificationConfigurationBatch enqueues a InsertNotificationConfiguration query into batch to be executed
 	// later by the batch.
@@ -571,6 +1092,93 @@ type Querier interface {
 	// DeleteOrganizationByNameScan scans the result of an executed DeleteOrganizationByNameBatch query.
 	DeleteOrganizationByNameScan(results pgx.BatchResults) (pgtype.Text, error)
 
+	InsertOrganizationImport(ctx context.Context, params InsertOrganizationImportParams) (pgconn.CommandTag, error)
+	// InsertOrganizationImportBatch enqueues a InsertOrganizationImport query into batch to be executed
+	// later by the batch.
+	InsertOrganizationImportBatch(batch genericBatch, params InsertOrganizationImportParams)
+	// InsertOrganizationImportScan scans the result of an executed InsertOrganizationImportBatch query.
+	InsertOrganizationImportScan(results pgx.BatchResults) (pgconn.CommandTag, error)
+
+	FindOrganizationImport(ctx context.Context, organizationImportID pgtype.Text) (FindOrganizationImportRow, error)
+	// FindOrganizationImportBatch enqueues a FindOrganizationImport query into batch to be executed
+	// later by the batch.
+	FindOrganizationImportBatch(batch genericBatch, organizationImportID pgtype.Text)
+	// FindOrganizationImportScan scans the result of an executed FindOrganizationImportBatch query.
+	FindOrganizationImportScan(results pgx.BatchResults) (FindOrganizationImportRow, error)
+
+	FindOrganizationImportsByOrganization(ctx context.Context, organizationName pgtype.Text) ([]FindOrganizationImportsByOrganizationRow, error)
+	// FindOrganizationImportsByOrganizationBatch enqueues a FindOrganizationImportsByOrganization query into batch to be executed
+	// later by the batch.
+	FindOrganizationImportsByOrganizationBatch(batch genericBatch, organizationName pgtype.Text)
+	// FindOrganizationImportsByOrganizationScan scans the result of an executed FindOrganizationImportsByOrganizationBatch query.
+	FindOrganizationImportsByOrganizationScan(results pgx.BatchResults) ([]FindOrganizationImportsByOrganizationRow, error)
+
+	UpdateOrganizationImportStatus(ctx context.Context, params UpdateOrganizationImportStatusParams) (pgconn.CommandTag, error)
+	// UpdateOrganizationImportStatusBatch enqueues a UpdateOrganizationImportStatus query into batch to be executed
+	// later by the batch.
+	UpdateOrganizationImportStatusBatch(batch genericBatch, params UpdateOrganizationImportStatusParams)
+	// UpdateOrganizationImportStatusScan scans the result of an executed UpdateOrganizationImportStatusBatch query.
+	UpdateOrganizationImportStatusScan(results pgx.BatchResults) (pgconn.CommandTag, error)
+
+	InsertOrganizationImportItem(ctx context.Context, params InsertOrganizationImportItemParams) (pgconn.CommandTag, error)
+	// InsertOrganizationImportItemBatch enqueues a InsertOrganizationImportItem query into batch to be executed
+	// later by the batch.
+	InsertOrganizationImportItemBatch(batch genericBatch, params InsertOrganizationImportItemParams)
+	// InsertOrganizationImportItemScan scans the result of an executed InsertOrganizationImportItemBatch query.
+	InsertOrganizationImportItemScan(results pgx.BatchResults) (pgconn.CommandTag, error)
+
+	FindOrganizationImportItems(ctx context.Context, organizationImportID pgtype.Text) ([]FindOrganizationImportItemsRow, error)
+	// FindOrganizationImportItemsBatch enqueues a FindOrganizationImportItems query into batch to be executed
+	// later by the batch.
+	FindOrganizationImportItemsBatch(batch genericBatch, organizationImportID pgtype.Text)
+	// FindOrganizationImportItemsScan scans the result of an executed FindOrganizationImportItemsBatch query.
+	FindOrganizationImportItemsScan(results pgx.BatchResults) ([]FindOrganizationImportItemsRow, error)
+
+	TouchOrganizationImports(ctx context.Context, params TouchOrganizationImportsParams) (pgconn.CommandTag, error)
+	// TouchOrganizationImportsBatch enqueues a TouchOrganizationImports query into batch to be executed
+	// later by the batch.
+	TouchOrganizationImportsBatch(batch genericBatch, params TouchOrganizationImportsParams)
+	// TouchOrganizationImportsScan scans the result of an executed TouchOrganizationImportsBatch query.
+	TouchOrganizationImportsScan(results pgx.BatchResults) (pgconn.CommandTag, error)
+
+	UpdateInterruptedOrganizationImports(ctx context.Context, params UpdateInterruptedOrganizationImportsParams) ([]pgtype.Text, error)
+	// UpdateInterruptedOrganizationImportsBatch enqueues a UpdateInterruptedOrganizationImports query into batch to be executed
+	// later by the batch.
+	UpdateInterruptedOrganizationImportsBatch(batch genericBatch, params UpdateInterruptedOrganizationImportsParams)
+	// UpdateInterruptedOrganizationImportsScan scans the result of an executed UpdateInterruptedOrganizationImportsBatch query.
+	UpdateInterruptedOrganizationImportsScan(results pgx.BatchResults) ([]pgtype.Text, error)
+
+	InsertOrganizationMembership(ctx context.Context, params InsertOrganizationMembershipParams) (InsertOrganizationMembershipRow, error)
+	// InsertOrganizationMembershipBatch enqueues a InsertOrganizationMembership query into batch to be executed
+	// later by the batch.
+	InsertOrganizationMembershipBatch(batch genericBatch, params InsertOrganizationMembershipParams)
+	// InsertOrganizationMembershipScan scans the result of an executed InsertOrganizationMembershipBatch query.
+	InsertOrganizationMembershipScan(results pgx.BatchResults) (InsertOrganizationMembershipRow, error)
+
+	FindOrganizationMembershipByID(ctx context.Context, organizationMembershipID pgtype.Text) (FindOrganizationMembershipByIDRow, error)
+	// FindOrganizationMembershipByIDBatch enqueues a FindOrganizationMembershipByID query into batch to be executed
+	// later by the batch.
+	FindOrganizationMembershipByIDBatch(batch genericBatch, organizationMembershipID pgtype.Text)
+	// FindOrganizationMembershipByIDScan scans the result of an executed FindOrganizationMembershipByIDBatch query.
+	FindOrganizationMembershipByIDScan(results pgx.BatchResults) (FindOrganizationMembershipByIDRow, error)
+
+	FindOrganizationMembershipsByOrganization(ctx context.Context, organizationName pgtype.Text) ([]FindOrganizationMembershipsByOrganizationRow, error)
+	// FindOrganizationMembershipsByOrganizationBatch enqueues a FindOrganizationMembershipsByOrganization query into batch to be executed
+	// later by the batch.
+	FindOrganizationMembershipsByOrganizationBatch(batch genericBatch, organizationName pgtype.Text)
+	// FindOrganizationMembershipsByOrganizationScan scans the result of an executed FindOrganizationMembershipsByOrganizationBatch query.
+	FindOrganizationMembershipsByOrganizationScan(results pgx.BatchResults) ([]FindOrganizationMembershipsByOrganizationRow, error)
+
+	DeleteOrganizationMembership(ctx context.Context, organizationMembershipID pgtype.Text) (DeleteOrganizationMembershipRow, error)
+	// DeleteOrganizationMembershipBatch enqueues a DeleteOrganizationMembership query into batch to be executed
+	// later by the batch.
+	DeleteOrganizationMembershipBatch(batch genericBatch, organizationMembershipID pgtype.Text)
+	// DeleteOrganizationMembershipScan scans the result of an executed DeleteOrganizationMembershipBatch query.
+	DeleteOrganizationMembershipScan(results pgx.BatchResults) (DeleteOrganizationMembershipRow, error)
+
+	// UpsertOrganizationToken creates an organization's unnamed token, replacing
+	// any existing unnamed token.
+	//
 	UpsertOrganizationToken(ctx context.Context, params UpsertOrganizationTokenParams) (pgconn.CommandTag, error)
 	// UpsertOrganizationTokenBatch enqueues a UpsertOrganizationToken query into batch to be executed
 	// later by the batch.
@@ -578,6 +1186,13 @@ type Querier interface {
 	// UpsertOrganizationTokenScan scans the result of an executed UpsertOrganizationTokenBatch query.
 	UpsertOrganizationTokenScan(results pgx.BatchResults) (pgconn.CommandTag, error)
 
+	InsertOrganizationToken(ctx context.Context, params InsertOrganizationTokenParams) (pgconn.CommandTag, error)
+	// InsertOrganizationTokenBatch enqueues a InsertOrganizationToken query into batch to be executed
+	// later by the batch.
+	InsertOrganizationTokenBatch(batch genericBatch, params InsertOrganizationTokenParams)
+	// InsertOrganizationTokenScan scans the result of an executed InsertOrganizationTokenBatch query.
+	InsertOrganizationTokenScan(results pgx.BatchResults) (pgconn.CommandTag, error)
+
 	FindOrganizationTokens(ctx context.Context, organizationName pgtype.Text) ([]FindOrganizationTokensRow, error)
 	// FindOrganizationTokensBatch enqueues a FindOrganizationTokens query into batch to be executed
 	// later by the batch.
@@ -585,6 +1200,8 @@ type Querier interface {
 	// FindOrganizationTokensScan scans the result of an executed FindOrganizationTokensBatch query.
 	FindOrganizationTokensScan(results pgx.BatchResults) ([]FindOrganizationTokensRow, error)
 
+	// FindOrganizationTokensByName finds an organization's unnamed token.
+	//
 	FindOrganizationTokensByName(ctx context.Context, organizationName pgtype.Text) (FindOrganizationTokensByNameRow, error)
 	// FindOrganizationTokensByNameBatch enqueues a FindOrganizationTokensByName query into batch to be executed
 	// later by the batch.
@@ -599,6 +1216,15 @@ type Querier interface {
 	// FindOrganizationTokensByIDScan scans the result of an executed FindOrganizationTokensByIDBatch query.
 	FindOrganizationTokensByIDScan(results pgx.BatchResults) (FindOrganizationTokensByIDRow, error)
 
+	UpdateOrganizationTokenLastUsedAt(ctx context.Context, lastUsedAt pgtype.Timestamptz, organizationTokenID pgtype.Text) (pgconn.CommandTag, error)
+	// UpdateOrganizationTokenLastUsedAtBatch enqueues a UpdateOrganizationTokenLastUsedAt query into batch to be executed
+	// later by the batch.
+	UpdateOrganizationTokenLastUsedAtBatch(batch genericBatch, lastUsedAt pgtype.Timestamptz, organizationTokenID pgtype.Text)
+	// UpdateOrganizationTokenLastUsedAtScan scans the result of an executed UpdateOrganizationTokenLastUsedAtBatch query.
+	UpdateOrganizationTokenLastUsedAtScan(results pgx.BatchResults) (pgconn.CommandTag, error)
+
+	// DeleteOrganiationTokenByName deletes an organization's unnamed token.
+	//
 	DeleteOrganiationTokenByName(ctx context.Context, organizationName pgtype.Text) (pgtype.Text, error)
 	// DeleteOrganiationTokenByNameBatch enqueues a DeleteOrganiationTokenByName query into batch to be executed
 	// later by the batch.
@@ -606,6 +1232,13 @@ type Querier interface {
 	// DeleteOrganiationTokenByNameScan scans the result of an executed DeleteOrganiationTokenByNameBatch query.
 	DeleteOrganiationTokenByNameScan(results pgx.BatchResults) (pgtype.Text, error)
 
+	DeleteOrganizationTokenByID(ctx context.Context, organizationTokenID pgtype.Text) (pgtype.Text, error)
+	// DeleteOrganizationTokenByIDBatch enqueues a DeleteOrganizationTokenByID query into batch to be executed
+	// later by the batch.
+	DeleteOrganizationTokenByIDBatch(batch genericBatch, organizationTokenID pgtype.Text)
+	// DeleteOrganizationTokenByIDScan scans the result of an executed DeleteOrganizationTokenByIDBatch query.
+	DeleteOrganizationTokenByIDScan(results pgx.BatchResults) (pgtype.Text, error)
+
 	InsertPhaseStatusTimestamp(ctx context.Context, params InsertPhaseStatusTimestampParams) (pgconn.CommandTag, error)
 	// InsertPhaseStatusTimestampBatch enqueues a InsertPhaseStatusTimestamp query into batch to be executed
 	// later by the batch.
@@ -636,6 +1269,22 @@ type Querier interface {
 	// FindLogChunkByIDScan scans the result of an executed FindLogChunkByIDBatch query.
 	FindLogChunkByIDScan(results pgx.BatchResults) (FindLogChunkByIDRow, error)
 
+	// DeleteLogs deletes all the logs for the given run and phase.
+	//
+	DeleteLogs(ctx context.Context, runID pgtype.Text, phase pgtype.Text) (pgconn.CommandTag, error)
+	// DeleteLogsBatch enqueues a DeleteLogs query into batch to be executed
+	// later by the batch.
+	DeleteLogsBatch(batch genericBatch, runID pgtype.Text, phase pgtype.Text)
+	// DeleteLogsScan scans the result of an executed DeleteLogsBatch query.
+	DeleteLogsScan(results pgx.BatchResults) (pgconn.CommandTag, error)
+
+	FindRecentRunLogs(ctx context.Context, workspaceID pgtype.Text, limit pgtype.Int4) ([]FindRecentRunLogsRow, error)
+	// FindRecentRunLogsBatch enqueues a FindRecentRunLogs query into batch to be executed
+	// later by the batch.
+	FindRecentRunLogsBatch(batch genericBatch, workspaceID pgtype.Text, limit pgtype.Int4)
+	// FindRecentRunLogsScan scans the result of an executed FindRecentRunLogsBatch query.
+	FindRecentRunLogsScan(results pgx.BatchResults) ([]FindRecentRunLogsRow, error)
+
 	InsertPlan(ctx context.Context, runID pgtype.Text, status pgtype.Text) (pgconn.CommandTag, error)
 	// InsertPlanBatch enqueues a InsertPlan query into batch to be executed
 	// later by the batch.
@@ -685,6 +1334,349 @@ type Querier interface {
 	// UpdatePlanJSONByIDScan scans the result of an executed UpdatePlanJSONByIDBatch query.
 	UpdatePlanJSONByIDScan(results pgx.BatchResults) (pgtype.Text, error)
 
+	InsertPolicySet(ctx context.Context, params InsertPolicySetParams) (pgconn.CommandTag, error)
+	// InsertPolicySetBatch enqueues a InsertPolicySet query into batch to be executed
+	// later by the batch.
+	InsertPolicySetBatch(batch genericBatch, params InsertPolicySetParams)
+	// InsertPolicySetScan scans the result of an executed InsertPolicySetBatch query.
+	InsertPolicySetScan(results pgx.BatchResults) (pgconn.CommandTag, error)
+
+	FindPolicySetsByOrganization(ctx context.Context, params FindPolicySetsByOrganizationParams) ([]FindPolicySetsByOrganizationRow, error)
+	// FindPolicySetsByOrganizationBatch enqueues a FindPolicySetsByOrganization query into batch to be executed
+	// later by the batch.
+	FindPolicySetsByOrganizationBatch(batch genericBatch, params FindPolicySetsByOrganizationParams)
+	// FindPolicySetsByOrganizationScan scans the result of an executed FindPolicySetsByOrganizationBatch query.
+	FindPolicySetsByOrganizationScan(results pgx.BatchResults) ([]FindPolicySetsByOrganizationRow, error)
+
+	FindPolicySetsByWorkspaceID(ctx context.Context, workspaceID pgtype.Text) ([]FindPolicySetsByWorkspaceIDRow, error)
+	// FindPolicySetsByWorkspaceIDBatch enqueues a FindPolicySetsByWorkspaceID query into batch to be executed
+	// later by the batch.
+	FindPolicySetsByWorkspaceIDBatch(batch genericBatch, workspaceID pgtype.Text)
+	// FindPolicySetsByWorkspaceIDScan scans the result of an executed FindPolicySetsByWorkspaceIDBatch query.
+	FindPolicySetsByWorkspaceIDScan(results pgx.BatchResults) ([]FindPolicySetsByWorkspaceIDRow, error)
+
+	FindPolicySetByID(ctx context.Context, policySetID pgtype.Text) (FindPolicySetByIDRow, error)
+	// FindPolicySetByIDBatch enqueues a FindPolicySetByID query into batch to be executed
+	// later by the batch.
+	FindPolicySetByIDBatch(batch genericBatch, policySetID pgtype.Text)
+	// FindPolicySetByIDScan scans the result of an executed FindPolicySetByIDBatch query.
+	FindPolicySetByIDScan(results pgx.BatchResults) (FindPolicySetByIDRow, error)
+
+	FindPolicySetByIDForUpdate(ctx context.Context, policySetID pgtype.Text) (FindPolicySetByIDForUpdateRow, error)
+	// FindPolicySetByIDForUpdateBatch enqueues a FindPolicySetByIDForUpdate query into batch to be executed
+	// later by the batch.
+	FindPolicySetByIDForUpdateBatch(batch genericBatch, policySetID pgtype.Text)
+	// FindPolicySetByIDForUpdateScan scans the result of an executed FindPolicySetByIDForUpdateBatch query.
+	FindPolicySetByIDForUpdateScan(results pgx.BatchResults) (FindPolicySetByIDForUpdateRow, error)
+
+	UpdatePolicySetByID(ctx context.Context, params UpdatePolicySetByIDParams) (pgtype.Text, error)
+	// UpdatePolicySetByIDBatch enqueues a UpdatePolicySetByID query into batch to be executed
+	// later by the batch.
+	UpdatePolicySetByIDBatch(batch genericBatch, params UpdatePolicySetByIDParams)
+	// UpdatePolicySetByIDScan scans the result of an executed UpdatePolicySetByIDBatch query.
+	UpdatePolicySetByIDScan(results pgx.BatchResults) (pgtype.Text, error)
+
+	DeletePolicySetByID(ctx context.Context, policySetID pgtype.Text) (pgtype.Text, error)
+	// DeletePolicySetByIDBatch enqueues a DeletePolicySetByID query into batch to be executed
+	// later by the batch.
+	DeletePolicySetByIDBatch(batch genericBatch, policySetID pgtype.Text)
+	// DeletePolicySetByIDScan scans the result of an executed DeletePolicySetByIDBatch query.
+	DeletePolicySetByIDScan(results pgx.BatchResults) (pgtype.Text, error)
+
+	InsertPolicySetWorkspace(ctx context.Context, policySetID pgtype.Text, workspaceID pgtype.Text) (pgconn.CommandTag, error)
+	// InsertPolicySetWorkspaceBatch enqueues a InsertPolicySetWorkspace query into batch to be executed
+	// later by the batch.
+	InsertPolicySetWorkspaceBatch(batch genericBatch, policySetID pgtype.Text, workspaceID pgtype.Text)
+	// InsertPolicySetWorkspaceScan scans the result of an executed InsertPolicySetWorkspaceBatch query.
+	InsertPolicySetWorkspaceScan(results pgx.BatchResults) (pgconn.CommandTag, error)
+
+	DeletePolicySetWorkspace(ctx context.Context, policySetID pgtype.Text, workspaceID pgtype.Text) (pgconn.CommandTag, error)
+	// DeletePolicySetWorkspaceBatch enqueues a DeletePolicySetWorkspace query into batch to be executed
+	// later by the batch.
+	DeletePolicySetWorkspaceBatch(batch genericBatch, policySetID pgtype.Text, workspaceID pgtype.Text)
+	// DeletePolicySetWorkspaceScan scans the result of an executed DeletePolicySetWorkspaceBatch query.
+	DeletePolicySetWorkspaceScan(results pgx.BatchResults) (pgconn.CommandTag, error)
+
+	InsertPolicySetParameter(ctx context.Context, params InsertPolicySetParameterParams) (pgconn.CommandTag, error)
+	// InsertPolicySetParameterBatch enqueues a InsertPolicySetParameter query into batch to be executed
+	// later by the batch.
+	InsertPolicySetParameterBatch(batch genericBatch, params InsertPolicySetParameterParams)
+	// InsertPolicySetParameterScan scans the result of an executed InsertPolicySetParameterBatch query.
+	InsertPolicySetParameterScan(results pgx.BatchResults) (pgconn.CommandTag, error)
+
+	FindPolicySetParameters(ctx context.Context, policySetID pgtype.Text) ([]FindPolicySetParametersRow, error)
+	// FindPolicySetParametersBatch enqueues a FindPolicySetParameters query into batch to be executed
+	// later by the batch.
+	FindPolicySetParametersBatch(batch genericBatch, policySetID pgtype.Text)
+	// FindPolicySetParametersScan scans the result of an executed FindPolicySetParametersBatch query.
+	FindPolicySetParametersScan(results pgx.BatchResults) ([]FindPolicySetParametersRow, error)
+
+	FindPolicySetParameterByID(ctx context.Context, policySetParameterID pgtype.Text) (FindPolicySetParameterByIDRow, error)
+	// FindPolicySetParameterByIDBatch enqueues a FindPolicySetParameterByID query into batch to be executed
+	// later by the batch.
+	FindPolicySetParameterByIDBatch(batch genericBatch, policySetParameterID pgtype.Text)
+	// FindPolicySetParameterByIDScan scans the result of an executed FindPolicySetParameterByIDBatch query.
+	FindPolicySetParameterByIDScan(results pgx.BatchResults) (FindPolicySetParameterByIDRow, error)
+
+	FindPolicySetParameterByIDForUpdate(ctx context.Context, policySetParameterID pgtype.Text) (FindPolicySetParameterByIDForUpdateRow, error)
+	// FindPolicySetParameterByIDForUpdateBatch enqueues a FindPolicySetParameterByIDForUpdate query into batch to be executed
+	// later by the batch.
+	FindPolicySetParameterByIDForUpdateBatch(batch genericBatch, policySetParameterID pgtype.Text)
+	// FindPolicySetParameterByIDForUpdateScan scans the result of an executed FindPolicySetParameterByIDForUpdateBatch query.
+	FindPolicySetParameterByIDForUpdateScan(results pgx.BatchResults) (FindPolicySetParameterByIDForUpdateRow, error)
+
+	UpdatePolicySetParameterByID(ctx context.Context, params UpdatePolicySetParameterByIDParams) (pgtype.Text, error)
+	// UpdatePolicySetParameterByIDBatch enqueues a UpdatePolicySetParameterByID query into batch to be executed
+	// later by the batch.
+	UpdatePolicySetParameterByIDBatch(batch genericBatch, params UpdatePolicySetParameterByIDParams)
+	// UpdatePolicySetParameterByIDScan scans the result of an executed UpdatePolicySetParameterByIDBatch query.
+	UpdatePolicySetParameterByIDScan(results pgx.BatchResults) (pgtype.Text, error)
+
+	DeletePolicySetParameterByID(ctx context.Context, policySetParameterID pgtype.Text) (pgtype.Text, error)
+	// DeletePolicySetParameterByIDBatch enqueues a DeletePolicySetParameterByID query into batch to be executed
+	// later by the batch.
+	DeletePolicySetParameterByIDBatch(batch genericBatch, policySetParameterID pgtype.Text)
+	// DeletePolicySetParameterByIDScan scans the result of an executed DeletePolicySetParameterByIDBatch query.
+	DeletePolicySetParameterByIDScan(results pgx.BatchResults) (pgtype.Text, error)
+
+	InsertPolicySetVersion(ctx context.Context, params InsertPolicySetVersionParams) (pgconn.CommandTag, error)
+	// InsertPolicySetVersionBatch enqueues a InsertPolicySetVersion query into batch to be executed
+	// later by the batch.
+	InsertPolicySetVersionBatch(batch genericBatch, params InsertPolicySetVersionParams)
+	// InsertPolicySetVersionScan scans the result of an executed InsertPolicySetVersionBatch query.
+	InsertPolicySetVersionScan(results pgx.BatchResults) (pgconn.CommandTag, error)
+
+	FindPolicySetVersionByID(ctx context.Context, policySetVersionID pgtype.Text) (FindPolicySetVersionByIDRow, error)
+	// FindPolicySetVersionByIDBatch enqueues a FindPolicySetVersionByID query into batch to be executed
+	// later by the batch.
+	FindPolicySetVersionByIDBatch(batch genericBatch, policySetVersionID pgtype.Text)
+	// FindPolicySetVersionByIDScan scans the result of an executed FindPolicySetVersionByIDBatch query.
+	FindPolicySetVersionByIDScan(results pgx.BatchResults) (FindPolicySetVersionByIDRow, error)
+
+	FindPolicySetVersionByIDForUpdate(ctx context.Context, policySetVersionID pgtype.Text) (FindPolicySetVersionByIDForUpdateRow, error)
+	// FindPolicySetVersionByIDForUpdateBatch enqueues a FindPolicySetVersionByIDForUpdate query into batch to be executed
+	// later by the batch.
+	FindPolicySetVersionByIDForUpdateBatch(batch genericBatch, policySetVersionID pgtype.Text)
+	// FindPolicySetVersionByIDForUpdateScan scans the result of an executed FindPolicySetVersionByIDForUpdateBatch query.
+	FindPolicySetVersionByIDForUpdateScan(results pgx.BatchResults) (FindPolicySetVersionByIDForUpdateRow, error)
+
+	UpdatePolicySetVersionByID(ctx context.Context, params UpdatePolicySetVersionByIDParams) (pgtype.Text, error)
+	// UpdatePolicySetVersionByIDBatch enqueues a UpdatePolicySetVersionByID query into batch to be executed
+	// later by the batch.
+	UpdatePolicySetVersionByIDBatch(batch genericBatch, params UpdatePolicySetVersionByIDParams)
+	// UpdatePolicySetVersionByIDScan scans the result of an executed UpdatePolicySetVersionByIDBatch query.
+	UpdatePolicySetVersionByIDScan(results pgx.BatchResults) (pgtype.Text, error)
+
+	DownloadPolicySetVersion(ctx context.Context, policySetVersionID pgtype.Text) ([]byte, error)
+	// DownloadPolicySetVersionBatch enqueues a DownloadPolicySetVersion query into batch to be executed
+	// later by the batch.
+	DownloadPolicySetVersionBatch(batch genericBatch, policySetVersionID pgtype.Text)
+	// DownloadPolicySetVersionScan scans the result of an executed DownloadPolicySetVersionBatch query.
+	DownloadPolicySetVersionScan(results pgx.BatchResults) ([]byte, error)
+
+	InsertRecoveryToken(ctx context.Context, tokenHash pgtype.Text, createdAt pgtype.Timestamptz) (pgconn.CommandTag, error)
+	// InsertRecoveryTokenBatch enqueues a InsertRecoveryToken query into batch to be executed
+	// later by the batch.
+	InsertRecoveryTokenBatch(batch genericBatch, tokenHash pgtype.Text, createdAt pgtype.Timestamptz)
+	// InsertRecoveryTokenScan scans the result of an executed InsertRecoveryTokenBatch query.
+	InsertRecoveryTokenScan(results pgx.BatchResults) (pgconn.CommandTag, error)
+
+	FindRecoveryTokenByHash(ctx context.Context, tokenHash pgtype.Text) (FindRecoveryTokenByHashRow, error)
+	// FindRecoveryTokenByHashBatch enqueues a FindRecoveryTokenByHash query into batch to be executed
+	// later by the batch.
+	FindRecoveryTokenByHashBatch(batch genericBatch, tokenHash pgtype.Text)
+	// FindRecoveryTokenByHashScan scans the result of an executed FindRecoveryTokenByHashBatch query.
+	FindRecoveryTokenByHashScan(results pgx.BatchResults) (FindRecoveryTokenByHashRow, error)
+
+	CountRecoveryTokens(ctx context.Context) (pgtype.Int8, error)
+	// CountRecoveryTokensBatch enqueues a CountRecoveryTokens query into batch to be executed
+	// later by the batch.
+	CountRecoveryTokensBatch(batch genericBatch)
+	// CountRecoveryTokensScan scans the result of an executed CountRecoveryTokensBatch query.
+	CountRecoveryTokensScan(results pgx.BatchResults) (pgtype.Int8, error)
+
+	DeleteUnusedRecoveryTokens(ctx context.Context) (pgconn.CommandTag, error)
+	// DeleteUnusedRecoveryTokensBatch enqueues a DeleteUnusedRecoveryTokens query into batch to be executed
+	// later by the batch.
+	DeleteUnusedRecoveryTokensBatch(batch genericBatch)
+	// DeleteUnusedRecoveryTokensScan scans the result of an executed DeleteUnusedRecoveryTokensBatch query.
+	DeleteUnusedRecoveryTokensScan(results pgx.BatchResults) (pgconn.CommandTag, error)
+
+	UseRecoveryToken(ctx context.Context, usedAt pgtype.Timestamptz, tokenHash pgtype.Text) (pgtype.Text, error)
+	// UseRecoveryTokenBatch enqueues a UseRecoveryToken query into batch to be executed
+	// later by the batch.
+	UseRecoveryTokenBatch(batch genericBatch, usedAt pgtype.Timestamptz, tokenHash pgtype.Text)
+	// UseRecoveryTokenScan scans the result of an executed UseRecoveryTokenBatch query.
+	UseRecoveryTokenScan(results pgx.BatchResults) (pgtype.Text, error)
+
+	InsertRegistryGPGKey(ctx context.Context, params InsertRegistryGPGKeyParams) (pgconn.CommandTag, error)
+	// InsertRegistryGPGKeyBatch enqueues a InsertRegistryGPGKey query into batch to be executed
+	// later by the batch.
+	InsertRegistryGPGKeyBatch(batch genericBatch, params InsertRegistryGPGKeyParams)
+	// InsertRegistryGPGKeyScan scans the result of an executed InsertRegistryGPGKeyBatch query.
+	InsertRegistryGPGKeyScan(results pgx.BatchResults) (pgconn.CommandTag, error)
+
+	FindRegistryGPGKeysByOrganization(ctx context.Context, organizationName pgtype.Text) ([]FindRegistryGPGKeysByOrganizationRow, error)
+	// FindRegistryGPGKeysByOrganizationBatch enqueues a FindRegistryGPGKeysByOrganization query into batch to be executed
+	// later by the batch.
+	FindRegistryGPGKeysByOrganizationBatch(batch genericBatch, organizationName pgtype.Text)
+	// FindRegistryGPGKeysByOrganizationScan scans the result of an executed FindRegistryGPGKeysByOrganizationBatch query.
+	FindRegistryGPGKeysByOrganizationScan(results pgx.BatchResults) ([]FindRegistryGPGKeysByOrganizationRow, error)
+
+	FindRegistryGPGKey(ctx context.Context, organizationName pgtype.Text, keyID pgtype.Text) (FindRegistryGPGKeyRow, error)
+	// FindRegistryGPGKeyBatch enqueues a FindRegistryGPGKey query into batch to be executed
+	// later by the batch.
+	FindRegistryGPGKeyBatch(batch genericBatch, organizationName pgtype.Text, keyID pgtype.Text)
+	// FindRegistryGPGKeyScan scans the result of an executed FindRegistryGPGKeyBatch query.
+	FindRegistryGPGKeyScan(results pgx.BatchResults) (FindRegistryGPGKeyRow, error)
+
+	UpdateRegistryGPGKeyOrganization(ctx context.Context, params UpdateRegistryGPGKeyOrganizationParams) (UpdateRegistryGPGKeyOrganizationRow, error)
+	// UpdateRegistryGPGKeyOrganizationBatch enqueues a UpdateRegistryGPGKeyOrganization query into batch to be executed
+	// later by the batch.
+	UpdateRegistryGPGKeyOrganizationBatch(batch genericBatch, params UpdateRegistryGPGKeyOrganizationParams)
+	// UpdateRegistryGPGKeyOrganizationScan scans the result of an executed UpdateRegistryGPGKeyOrganizationBatch query.
+	UpdateRegistryGPGKeyOrganizationScan(results pgx.BatchResults) (UpdateRegistryGPGKeyOrganizationRow, error)
+
+	DeleteRegistryGPGKeyByID(ctx context.Context, registryGpgKeyID pgtype.Text) (pgconn.CommandTag, error)
+	// DeleteRegistryGPGKeyByIDBatch enqueues a DeleteRegistryGPGKeyByID query into batch to be executed
+	// later by the batch.
+	DeleteRegistryGPGKeyByIDBatch(batch genericBatch, registryGpgKeyID pgtype.Text)
+	// DeleteRegistryGPGKeyByIDScan scans the result of an executed DeleteRegistryGPGKeyByIDBatch query.
+	DeleteRegistryGPGKeyByIDScan(results pgx.BatchResults) (pgconn.CommandTag, error)
+
+	InsertRegistryProvider(ctx context.Context, params InsertRegistryProviderParams) (pgconn.CommandTag, error)
+	// InsertRegistryProviderBatch enqueues a InsertRegistryProvider query into batch to be executed
+	// later by the batch.
+	InsertRegistryProviderBatch(batch genericBatch, params InsertRegistryProviderParams)
+	// InsertRegistryProviderScan scans the result of an executed InsertRegistryProviderBatch query.
+	InsertRegistryProviderScan(results pgx.BatchResults) (pgconn.CommandTag, error)
+
+	FindRegistryProvidersByOrganization(ctx context.Context, organizationName pgtype.Text) ([]FindRegistryProvidersByOrganizationRow, error)
+	// FindRegistryProvidersByOrganizationBatch enqueues a FindRegistryProvidersByOrganization query into batch to be executed
+	// later by the batch.
+	FindRegistryProvidersByOrganizationBatch(batch genericBatch, organizationName pgtype.Text)
+	// FindRegistryProvidersByOrganizationScan scans the result of an executed FindRegistryProvidersByOrganizationBatch query.
+	FindRegistryProvidersByOrganizationScan(results pgx.BatchResults) ([]FindRegistryProvidersByOrganizationRow, error)
+
+	FindRegistryProviderByName(ctx context.Context, organizationName pgtype.Text, name pgtype.Text) (FindRegistryProviderByNameRow, error)
+	// FindRegistryProviderByNameBatch enqueues a FindRegistryProviderByName query into batch to be executed
+	// later by the batch.
+	FindRegistryProviderByNameBatch(batch genericBatch, organizationName pgtype.Text, name pgtype.Text)
+	// FindRegistryProviderByNameScan scans the result of an executed FindRegistryProviderByNameBatch query.
+	FindRegistryProviderByNameScan(results pgx.BatchResults) (FindRegistryProviderByNameRow, error)
+
+	DeleteRegistryProviderByID(ctx context.Context, registryProviderID pgtype.Text) (pgconn.CommandTag, error)
+	// DeleteRegistryProviderByIDBatch enqueues a DeleteRegistryProviderByID query into batch to be executed
+	// later by the batch.
+	DeleteRegistryProviderByIDBatch(batch genericBatch, registryProviderID pgtype.Text)
+	// DeleteRegistryProviderByIDScan scans the result of an executed DeleteRegistryProviderByIDBatch query.
+	DeleteRegistryProviderByIDScan(results pgx.BatchResults) (pgconn.CommandTag, error)
+
+	InsertRegistryProviderVersion(ctx context.Context, params InsertRegistryProviderVersionParams) (pgconn.CommandTag, error)
+	// InsertRegistryProviderVersionBatch enqueues a InsertRegistryProviderVersion query into batch to be executed
+	// later by the batch.
+	InsertRegistryProviderVersionBatch(batch genericBatch, params InsertRegistryProviderVersionParams)
+	// InsertRegistryProviderVersionScan scans the result of an executed InsertRegistryProviderVersionBatch query.
+	InsertRegistryProviderVersionScan(results pgx.BatchResults) (pgconn.CommandTag, error)
+
+	FindRegistryProviderVersionsByProviderID(ctx context.Context, registryProviderID pgtype.Text) ([]FindRegistryProviderVersionsByProviderIDRow, error)
+	// FindRegistryProviderVersionsByProviderIDBatch enqueues a FindRegistryProviderVersionsByProviderID query into batch to be executed
+	// later by the batch.
+	FindRegistryProviderVersionsByProviderIDBatch(batch genericBatch, registryProviderID pgtype.Text)
+	// FindRegistryProviderVersionsByProviderIDScan scans the result of an executed FindRegistryProviderVersionsByProviderIDBatch query.
+	FindRegistryProviderVersionsByProviderIDScan(results pgx.BatchResults) ([]FindRegistryProviderVersionsByProviderIDRow, error)
+
+	FindRegistryProviderVersion(ctx context.Context, registryProviderID pgtype.Text, version pgtype.Text) (FindRegistryProviderVersionRow, error)
+	// FindRegistryProviderVersionBatch enqueues a FindRegistryProviderVersion query into batch to be executed
+	// later by the batch.
+	FindRegistryProviderVersionBatch(batch genericBatch, registryProviderID pgtype.Text, version pgtype.Text)
+	// FindRegistryProviderVersionScan scans the result of an executed FindRegistryProviderVersionBatch query.
+	FindRegistryProviderVersionScan(results pgx.BatchResults) (FindRegistryProviderVersionRow, error)
+
+	UpdateRegistryProviderVersionShasums(ctx context.Context, params UpdateRegistryProviderVersionShasumsParams) (pgconn.CommandTag, error)
+	// UpdateRegistryProviderVersionShasumsBatch enqueues a UpdateRegistryProviderVersionShasums query into batch to be executed
+	// later by the batch.
+	UpdateRegistryProviderVersionShasumsBatch(batch genericBatch, params UpdateRegistryProviderVersionShasumsParams)
+	// UpdateRegistryProviderVersionShasumsScan scans the result of an executed UpdateRegistryProviderVersionShasumsBatch query.
+	UpdateRegistryProviderVersionShasumsScan(results pgx.BatchResults) (pgconn.CommandTag, error)
+
+	UpdateRegistryProviderVersionShasumsSig(ctx context.Context, params UpdateRegistryProviderVersionShasumsSigParams) (pgconn.CommandTag, error)
+	// UpdateRegistryProviderVersionShasumsSigBatch enqueues a UpdateRegistryProviderVersionShasumsSig query into batch to be executed
+	// later by the batch.
+	UpdateRegistryProviderVersionShasumsSigBatch(batch genericBatch, params UpdateRegistryProviderVersionShasumsSigParams)
+	// UpdateRegistryProviderVersionShasumsSigScan scans the result of an executed UpdateRegistryProviderVersionShasumsSigBatch query.
+	UpdateRegistryProviderVersionShasumsSigScan(results pgx.BatchResults) (pgconn.CommandTag, error)
+
+	FindRegistryProviderVersionShasums(ctx context.Context, registryProviderVersionID pgtype.Text) ([]byte, error)
+	// FindRegistryProviderVersionShasumsBatch enqueues a FindRegistryProviderVersionShasums query into batch to be executed
+	// later by the batch.
+	FindRegistryProviderVersionShasumsBatch(batch genericBatch, registryProviderVersionID pgtype.Text)
+	// FindRegistryProviderVersionShasumsScan scans the result of an executed FindRegistryProviderVersionShasumsBatch query.
+	FindRegistryProviderVersionShasumsScan(results pgx.BatchResults) ([]byte, error)
+
+	FindRegistryProviderVersionShasumsSig(ctx context.Context, registryProviderVersionID pgtype.Text) ([]byte, error)
+	// FindRegistryProviderVersionShasumsSigBatch enqueues a FindRegistryProviderVersionShasumsSig query into batch to be executed
+	// later by the batch.
+	FindRegistryProviderVersionShasumsSigBatch(batch genericBatch, registryProviderVersionID pgtype.Text)
+	// FindRegistryProviderVersionShasumsSigScan scans the result of an executed FindRegistryProviderVersionShasumsSigBatch query.
+	FindRegistryProviderVersionShasumsSigScan(results pgx.BatchResults) ([]byte, error)
+
+	DeleteRegistryProviderVersionByID(ctx context.Context, registryProviderVersionID pgtype.Text) (pgconn.CommandTag, error)
+	// DeleteRegistryProviderVersionByIDBatch enqueues a DeleteRegistryProviderVersionByID query into batch to be executed
+	// later by the batch.
+	DeleteRegistryProviderVersionByIDBatch(batch genericBatch, registryProviderVersionID pgtype.Text)
+	// DeleteRegistryProviderVersionByIDScan scans the result of an executed DeleteRegistryProviderVersionByIDBatch query.
+	DeleteRegistryProviderVersionByIDScan(results pgx.BatchResults) (pgconn.CommandTag, error)
+
+	InsertRegistryProviderPlatform(ctx context.Context, params InsertRegistryProviderPlatformParams) (pgconn.CommandTag, error)
+	// InsertRegistryProviderPlatformBatch enqueues a InsertRegistryProviderPlatform query into batch to be executed
+	// later by the batch.
+	InsertRegistryProviderPlatformBatch(batch genericBatch, params InsertRegistryProviderPlatformParams)
+	// InsertRegistryProviderPlatformScan scans the result of an executed InsertRegistryProviderPlatformBatch query.
+	InsertRegistryProviderPlatformScan(results pgx.BatchResults) (pgconn.CommandTag, error)
+
+	FindRegistryProviderPlatformsByVersionID(ctx context.Context, registryProviderVersionID pgtype.Text) ([]FindRegistryProviderPlatformsByVersionIDRow, error)
+	// FindRegistryProviderPlatformsByVersionIDBatch enqueues a FindRegistryProviderPlatformsByVersionID query into batch to be executed
+	// later by the batch.
+	FindRegistryProviderPlatformsByVersionIDBatch(batch genericBatch, registryProviderVersionID pgtype.Text)
+	// FindRegistryProviderPlatformsByVersionIDScan scans the result of an executed FindRegistryProviderPlatformsByVersionIDBatch query.
+	FindRegistryProviderPlatformsByVersionIDScan(results pgx.BatchResults) ([]FindRegistryProviderPlatformsByVersionIDRow, error)
+
+	FindRegistryProviderPlatform(ctx context.Context, params FindRegistryProviderPlatformParams) (FindRegistryProviderPlatformRow, error)
+	// FindRegistryProviderPlatformBatch enqueues a FindRegistryProviderPlatform query into batch to be executed
+	// later by the batch.
+	FindRegistryProviderPlatformBatch(batch genericBatch, params FindRegistryProviderPlatformParams)
+	// FindRegistryProviderPlatformScan scans the result of an executed FindRegistryProviderPlatformBatch query.
+	FindRegistryProviderPlatformScan(results pgx.BatchResults) (FindRegistryProviderPlatformRow, error)
+
+	FindRegistryProviderPlatformByID(ctx context.Context, registryProviderPlatformID pgtype.Text) (FindRegistryProviderPlatformByIDRow, error)
+	// FindRegistryProviderPlatformByIDBatch enqueues a FindRegistryProviderPlatformByID query into batch to be executed
+	// later by the batch.
+	FindRegistryProviderPlatformByIDBatch(batch genericBatch, registryProviderPlatformID pgtype.Text)
+	// FindRegistryProviderPlatformByIDScan scans the result of an executed FindRegistryProviderPlatformByIDBatch query.
+	FindRegistryProviderPlatformByIDScan(results pgx.BatchResults) (FindRegistryProviderPlatformByIDRow, error)
+
+	UpdateRegistryProviderPlatformBinary(ctx context.Context, params UpdateRegistryProviderPlatformBinaryParams) (pgconn.CommandTag, error)
+	// UpdateRegistryProviderPlatformBinaryBatch enqueues a UpdateRegistryProviderPlatformBinary query into batch to be executed
+	// later by the batch.
+	UpdateRegistryProviderPlatformBinaryBatch(batch genericBatch, params UpdateRegistryProviderPlatformBinaryParams)
+	// UpdateRegistryProviderPlatformBinaryScan scans the result of an executed UpdateRegistryProviderPlatformBinaryBatch query.
+	UpdateRegistryProviderPlatformBinaryScan(results pgx.BatchResults) (pgconn.CommandTag, error)
+
+	FindRegistryProviderPlatformBinary(ctx context.Context, registryProviderPlatformID pgtype.Text) ([]byte, error)
+	// FindRegistryProviderPlatformBinaryBatch enqueues a FindRegistryProviderPlatformBinary query into batch to be executed
+	// later by the batch.
+	FindRegistryProviderPlatformBinaryBatch(batch genericBatch, registryProviderPlatformID pgtype.Text)
+	// FindRegistryProviderPlatformBinaryScan scans the result of an executed FindRegistryProviderPlatformBinaryBatch query.
+	FindRegistryProviderPlatformBinaryScan(results pgx.BatchResults) ([]byte, error)
+
+	DeleteRegistryProviderPlatformByID(ctx context.Context, registryProviderPlatformID pgtype.Text) (pgconn.CommandTag, error)
+	// DeleteRegistryProviderPlatformByIDBatch enqueues a DeleteRegistryProviderPlatformByID query into batch to be executed
+	// later by the batch.
+	DeleteRegistryProviderPlatformByIDBatch(batch genericBatch, registryProviderPlatformID pgtype.Text)
+	// DeleteRegistryProviderPlatformByIDScan scans the result of an executed DeleteRegistryProviderPlatformByIDBatch query.
+	DeleteRegistryProviderPlatformByIDScan(results pgx.BatchResults) (pgconn.CommandTag, error)
+
 	InsertLatestTerraformVersion(ctx context.Context, version pgtype.Text) (pgconn.CommandTag, error)
 	// InsertLatestTerraformVersionBatch enqueues a InsertLatestTerraformVersion query into batch to be executed
 	// later by the batch.
@@ -776,6 +1768,27 @@ type Querier interface {
 	// DeleteRepohookByIDScan scans the result of an executed DeleteRepohookByIDBatch query.
 	DeleteRepohookByIDScan(results pgx.BatchResults) (DeleteRepohookByIDRow, error)
 
+	InsertResourceChange(ctx context.Context, params InsertResourceChangeParams) (pgconn.CommandTag, error)
+	// InsertResourceChangeBatch enqueues a InsertResourceChange query into batch to be executed
+	// later by the batch.
+	InsertResourceChangeBatch(batch genericBatch, params InsertResourceChangeParams)
+	// InsertResourceChangeScan scans the result of an executed InsertResourceChangeBatch query.
+	InsertResourceChangeScan(results pgx.BatchResults) (pgconn.CommandTag, error)
+
+	FindResourceChangeByID(ctx context.Context, resourceChangeID pgtype.Text) (FindResourceChangeByIDRow, error)
+	// FindResourceChangeByIDBatch enqueues a FindResourceChangeByID query into batch to be executed
+	// later by the batch.
+	FindResourceChangeByIDBatch(batch genericBatch, resourceChangeID pgtype.Text)
+	// FindResourceChangeByIDScan scans the result of an executed FindResourceChangeByIDBatch query.
+	FindResourceChangeByIDScan(results pgx.BatchResults) (FindResourceChangeByIDRow, error)
+
+	FindResourceChangesByRunID(ctx context.Context, runID pgtype.Text) ([]FindResourceChangesByRunIDRow, error)
+	// FindResourceChangesByRunIDBatch enqueues a FindResourceChangesByRunID query into batch to be executed
+	// later by the batch.
+	FindResourceChangesByRunIDBatch(batch genericBatch, runID pgtype.Text)
+	// FindResourceChangesByRunIDScan scans the result of an executed FindResourceChangesByRunIDBatch query.
+	FindResourceChangesByRunIDScan(results pgx.BatchResults) ([]FindResourceChangesByRunIDRow, error)
+
 	InsertRun(ctx context.Context, params InsertRunParams) (pgconn.CommandTag, error)
 	// InsertRunBatch enqueues a InsertRun query into batch to be executed
 	// later by the batch.
@@ -860,6 +1873,465 @@ type Querier interface {
 	// DeleteRunByIDScan scans the result of an executed DeleteRunByIDBatch query.
 	DeleteRunByIDScan(results pgx.BatchResults) (pgtype.Text, error)
 
+	FindQueuedRunIDsByWorkspaceID(ctx context.Context, workspaceID pgtype.Text) ([]pgtype.Text, error)
+	// FindQueuedRunIDsByWorkspaceIDBatch enqueues a FindQueuedRunIDsByWorkspaceID query into batch to be executed
+	// later by the batch.
+	FindQueuedRunIDsByWorkspaceIDBatch(batch genericBatch, workspaceID pgtype.Text)
+	// FindQueuedRunIDsByWorkspaceIDScan scans the result of an executed FindQueuedRunIDsByWorkspaceIDBatch query.
+	FindQueuedRunIDsByWorkspaceIDScan(results pgx.BatchResults) ([]pgtype.Text, error)
+
+	// FindSerializationGroupQueueByWorkspaceID finds the runs in the workspace's
+	// serialization group that are applying or waiting to apply, in the order in
+	// which they apply.
+	//
+	FindSerializationGroupQueueByWorkspaceID(ctx context.Context, workspaceID pgtype.Text) ([]FindSerializationGroupQueueByWorkspaceIDRow, error)
+	// FindSerializationGroupQueueByWorkspaceIDBatch enqueues a FindSerializationGroupQueueByWorkspaceID query into batch to be executed
+	// later by the batch.
+	FindSerializationGroupQueueByWorkspaceIDBatch(batch genericBatch, workspaceID pgtype.Text)
+	// FindSerializationGroupQueueByWorkspaceIDScan scans the result of an executed FindSerializationGroupQueueByWorkspaceIDBatch query.
+	FindSerializationGroupQueueByWorkspaceIDScan(results pgx.BatchResults) ([]FindSerializationGroupQueueByWorkspaceIDRow, error)
+
+	UpsertRunAnnotation(ctx context.Context, params UpsertRunAnnotationParams) (pgconn.CommandTag, error)
+	// UpsertRunAnnotationBatch enqueues a UpsertRunAnnotation query into batch to be executed
+	// later by the batch.
+	UpsertRunAnnotationBatch(batch genericBatch, params UpsertRunAnnotationParams)
+	// UpsertRunAnnotationScan scans the result of an executed UpsertRunAnnotationBatch query.
+	UpsertRunAnnotationScan(results pgx.BatchResults) (pgconn.CommandTag, error)
+
+	FindRunAnnotationsByRunID(ctx context.Context, runID pgtype.Text) ([]FindRunAnnotationsByRunIDRow, error)
+	// FindRunAnnotationsByRunIDBatch enqueues a FindRunAnnotationsByRunID query into batch to be executed
+	// later by the batch.
+	FindRunAnnotationsByRunIDBatch(batch genericBatch, runID pgtype.Text)
+	// FindRunAnnotationsByRunIDScan scans the result of an executed FindRunAnnotationsByRunIDBatch query.
+	FindRunAnnotationsByRunIDScan(results pgx.BatchResults) ([]FindRunAnnotationsByRunIDRow, error)
+
+	InsertRunApplyFailure(ctx context.Context, params InsertRunApplyFailureParams) (pgconn.CommandTag, error)
+	// InsertRunApplyFailureBatch enqueues a InsertRunApplyFailure query into batch to be executed
+	// later by the batch.
+	InsertRunApplyFailureBatch(batch genericBatch, params InsertRunApplyFailureParams)
+	// InsertRunApplyFailureScan scans the result of an executed InsertRunApplyFailureBatch query.
+	InsertRunApplyFailureScan(results pgx.BatchResults) (pgconn.CommandTag, error)
+
+	FindRunApplyFailures(ctx context.Context, runID pgtype.Text) ([]FindRunApplyFailuresRow, error)
+	// FindRunApplyFailuresBatch enqueues a FindRunApplyFailures query into batch to be executed
+	// later by the batch.
+	FindRunApplyFailuresBatch(batch genericBatch, runID pgtype.Text)
+	// FindRunApplyFailuresScan scans the result of an executed FindRunApplyFailuresBatch query.
+	FindRunApplyFailuresScan(results pgx.BatchResults) ([]FindRunApplyFailuresRow, error)
+
+	InsertRunDiagnostic(ctx context.Context, params InsertRunDiagnosticParams) (pgconn.CommandTag, error)
+	// InsertRunDiagnosticBatch enqueues a InsertRunDiagnostic query into batch to be executed
+	// later by the batch.
+	InsertRunDiagnosticBatch(batch genericBatch, params InsertRunDiagnosticParams)
+	// InsertRunDiagnosticScan scans the result of an executed InsertRunDiagnosticBatch query.
+	InsertRunDiagnosticScan(results pgx.BatchResults) (pgconn.CommandTag, error)
+
+	FindRunDiagnostics(ctx context.Context, runID pgtype.Text) ([]FindRunDiagnosticsRow, error)
+	// FindRunDiagnosticsBatch enqueues a FindRunDiagnostics query into batch to be executed
+	// later by the batch.
+	FindRunDiagnosticsBatch(batch genericBatch, runID pgtype.Text)
+	// FindRunDiagnosticsScan scans the result of an executed FindRunDiagnosticsBatch query.
+	FindRunDiagnosticsScan(results pgx.BatchResults) ([]FindRunDiagnosticsRow, error)
+
+	UpsertRunPlanState(ctx context.Context, runID pgtype.Text, stateSerial pgtype.Int4) (pgconn.CommandTag, error)
+	// UpsertRunPlanStateBatch enqueues a UpsertRunPlanState query into batch to be executed
+	// later by the batch.
+	UpsertRunPlanStateBatch(batch genericBatch, runID pgtype.Text, stateSerial pgtype.Int4)
+	// UpsertRunPlanStateScan scans the result of an executed UpsertRunPlanStateBatch query.
+	UpsertRunPlanStateScan(results pgx.BatchResults) (pgconn.CommandTag, error)
+
+	FindRunPlanState(ctx context.Context, runID pgtype.Text) (FindRunPlanStateRow, error)
+	// FindRunPlanStateBatch enqueues a FindRunPlanState query into batch to be executed
+	// later by the batch.
+	FindRunPlanStateBatch(batch genericBatch, runID pgtype.Text)
+	// FindRunPlanStateScan scans the result of an executed FindRunPlanStateBatch query.
+	FindRunPlanStateScan(results pgx.BatchResults) (FindRunPlanStateRow, error)
+
+	FindWorkspaceCurrentStateSerial(ctx context.Context, workspaceID pgtype.Text) (pgtype.Int4, error)
+	// FindWorkspaceCurrentStateSerialBatch enqueues a FindWorkspaceCurrentStateSerial query into batch to be executed
+	// later by the batch.
+	FindWorkspaceCurrentStateSerialBatch(batch genericBatch, workspaceID pgtype.Text)
+	// FindWorkspaceCurrentStateSerialScan scans the result of an executed FindWorkspaceCurrentStateSerialBatch query.
+	FindWorkspaceCurrentStateSerialScan(results pgx.BatchResults) (pgtype.Int4, error)
+
+	UpsertWorkspaceProtectionRules(ctx context.Context, params UpsertWorkspaceProtectionRulesParams) (pgconn.CommandTag, error)
+	// UpsertWorkspaceProtectionRulesBatch enqueues a UpsertWorkspaceProtectionRules query into batch to be executed
+	// later by the batch.
+	UpsertWorkspaceProtectionRulesBatch(batch genericBatch, params UpsertWorkspaceProtectionRulesParams)
+	// UpsertWorkspaceProtectionRulesScan scans the result of an executed UpsertWorkspaceProtectionRulesBatch query.
+	UpsertWorkspaceProtectionRulesScan(results pgx.BatchResults) (pgconn.CommandTag, error)
+
+	FindWorkspaceProtectionRules(ctx context.Context, workspaceID pgtype.Text) (FindWorkspaceProtectionRulesRow, error)
+	// FindWorkspaceProtectionRulesBatch enqueues a FindWorkspaceProtectionRules query into batch to be executed
+	// later by the batch.
+	FindWorkspaceProtectionRulesBatch(batch genericBatch, workspaceID pgtype.Text)
+	// FindWorkspaceProtectionRulesScan scans the result of an executed FindWorkspaceProtectionRulesBatch query.
+	FindWorkspaceProtectionRulesScan(results pgx.BatchResults) (FindWorkspaceProtectionRulesRow, error)
+
+	InsertRunProtectionViolation(ctx context.Context, params InsertRunProtectionViolationParams) (pgconn.CommandTag, error)
+	// InsertRunProtectionViolationBatch enqueues a InsertRunProtectionViolation query into batch to be executed
+	// later by the batch.
+	InsertRunProtectionViolationBatch(batch genericBatch, params InsertRunProtectionViolationParams)
+	// InsertRunProtectionViolationScan scans the result of an executed InsertRunProtectionViolationBatch query.
+	InsertRunProtectionViolationScan(results pgx.BatchResults) (pgconn.CommandTag, error)
+
+	FindRunProtectionViolations(ctx context.Context, runID pgtype.Text) ([]FindRunProtectionViolationsRow, error)
+	// FindRunProtectionViolationsBatch enqueues a FindRunProtectionViolations query into batch to be executed
+	// later by the batch.
+	FindRunProtectionViolationsBatch(batch genericBatch, runID pgtype.Text)
+	// FindRunProtectionViolationsScan scans the result of an executed FindRunProtectionViolationsBatch query.
+	FindRunProtectionViolationsScan(results pgx.BatchResults) ([]FindRunProtectionViolationsRow, error)
+
+	InsertRunProtectionOverride(ctx context.Context, params InsertRunProtectionOverrideParams) (pgconn.CommandTag, error)
+	// InsertRunProtectionOverrideBatch enqueues a InsertRunProtectionOverride query into batch to be executed
+	// later by the batch.
+	InsertRunProtectionOverrideBatch(batch genericBatch, params InsertRunProtectionOverrideParams)
+	// InsertRunProtectionOverrideScan scans the result of an executed InsertRunProtectionOverrideBatch query.
+	InsertRunProtectionOverrideScan(results pgx.BatchResults) (pgconn.CommandTag, error)
+
+	FindRunProtectionOverride(ctx context.Context, runID pgtype.Text) (FindRunProtectionOverrideRow, error)
+	// FindRunProtectionOverrideBatch enqueues a FindRunProtectionOverride query into batch to be executed
+	// later by the batch.
+	FindRunProtectionOverrideBatch(batch genericBatch, runID pgtype.Text)
+	// FindRunProtectionOverrideScan scans the result of an executed FindRunProtectionOverrideBatch query.
+	FindRunProtectionOverrideScan(results pgx.BatchResults) (FindRunProtectionOverrideRow, error)
+
+	FindRunProvenanceConfigurationVersion(ctx context.Context, configurationVersionID pgtype.Text) (FindRunProvenanceConfigurationVersionRow, error)
+	// FindRunProvenanceConfigurationVersionBatch enqueues a FindRunProvenanceConfigurationVersion query into batch to be executed
+	// later by the batch.
+	FindRunProvenanceConfigurationVersionBatch(batch genericBatch, configurationVersionID pgtype.Text)
+	// FindRunProvenanceConfigurationVersionScan scans the result of an executed FindRunProvenanceConfigurationVersionBatch query.
+	FindRunProvenanceConfigurationVersionScan(results pgx.BatchResults) (FindRunProvenanceConfigurationVersionRow, error)
+
+	FindRunProvenanceStateVersions(ctx context.Context, runID pgtype.Text) ([]FindRunProvenanceStateVersionsRow, error)
+	// FindRunProvenanceStateVersionsBatch enqueues a FindRunProvenanceStateVersions query into batch to be executed
+	// later by the batch.
+	FindRunProvenanceStateVersionsBatch(batch genericBatch, runID pgtype.Text)
+	// FindRunProvenanceStateVersionsScan scans the result of an executed FindRunProvenanceStateVersionsBatch query.
+	FindRunProvenanceStateVersionsScan(results pgx.BatchResults) ([]FindRunProvenanceStateVersionsRow, error)
+
+	InsertTask(ctx context.Context, params InsertTaskParams) (pgconn.CommandTag, error)
+	// InsertTaskBatch enqueues a InsertTask query into batch to be executed
+	// later by the batch.
+	InsertTaskBatch(batch genericBatch, params InsertTaskParams)
+	// InsertTaskScan scans the result of an executed InsertTaskBatch query.
+	InsertTaskScan(results pgx.BatchResults) (pgconn.CommandTag, error)
+
+	FindTasksByOrganization(ctx context.Context, organizationName pgtype.Text) ([]FindTasksByOrganizationRow, error)
+	// FindTasksByOrganizationBatch enqueues a FindTasksByOrganization query into batch to be executed
+	// later by the batch.
+	FindTasksByOrganizationBatch(batch genericBatch, organizationName pgtype.Text)
+	// FindTasksByOrganizationScan scans the result of an executed FindTasksByOrganizationBatch query.
+	FindTasksByOrganizationScan(results pgx.BatchResults) ([]FindTasksByOrganizationRow, error)
+
+	FindTaskByID(ctx context.Context, taskID pgtype.Text) (FindTaskByIDRow, error)
+	// FindTaskByIDBatch enqueues a FindTaskByID query into batch to be executed
+	// later by the batch.
+	FindTaskByIDBatch(batch genericBatch, taskID pgtype.Text)
+	// FindTaskByIDScan scans the result of an executed FindTaskByIDBatch query.
+	FindTaskByIDScan(results pgx.BatchResults) (FindTaskByIDRow, error)
+
+	FindTaskByIDForUpdate(ctx context.Context, taskID pgtype.Text) (FindTaskByIDForUpdateRow, error)
+	// FindTaskByIDForUpdateBatch enqueues a FindTaskByIDForUpdate query into batch to be executed
+	// later by the batch.
+	FindTaskByIDForUpdateBatch(batch genericBatch, taskID pgtype.Text)
+	// FindTaskByIDForUpdateScan scans the result of an executed FindTaskByIDForUpdateBatch query.
+	FindTaskByIDForUpdateScan(results pgx.BatchResults) (FindTaskByIDForUpdateRow, error)
+
+	UpdateTask(ctx context.Context, params UpdateTaskParams) (pgconn.CommandTag, error)
+	// UpdateTaskBatch enqueues a UpdateTask query into batch to be executed
+	// later by the batch.
+	UpdateTaskBatch(batch genericBatch, params UpdateTaskParams)
+	// UpdateTaskScan scans the result of an executed UpdateTaskBatch query.
+	UpdateTaskScan(results pgx.BatchResults) (pgconn.CommandTag, error)
+
+	DeleteTaskByID(ctx context.Context, taskID pgtype.Text) (pgconn.CommandTag, error)
+	// DeleteTaskByIDBatch enqueues a DeleteTaskByID query into batch to be executed
+	// later by the batch.
+	DeleteTaskByIDBatch(batch genericBatch, taskID pgtype.Text)
+	// DeleteTaskByIDScan scans the result of an executed DeleteTaskByIDBatch query.
+	DeleteTaskByIDScan(results pgx.BatchResults) (pgconn.CommandTag, error)
+
+	InsertWorkspaceTask(ctx context.Context, params InsertWorkspaceTaskParams) (pgconn.CommandTag, error)
+	// InsertWorkspaceTaskBatch enqueues a InsertWorkspaceTask query into batch to be executed
+	// later by the batch.
+	InsertWorkspaceTaskBatch(batch genericBatch, params InsertWorkspaceTaskParams)
+	// InsertWorkspaceTaskScan scans the result of an executed InsertWorkspaceTaskBatch query.
+	InsertWorkspaceTaskScan(results pgx.BatchResults) (pgconn.CommandTag, error)
+
+	FindWorkspaceTasksByWorkspaceID(ctx context.Context, workspaceID pgtype.Text) ([]FindWorkspaceTasksByWorkspaceIDRow, error)
+	// FindWorkspaceTasksByWorkspaceIDBatch enqueues a FindWorkspaceTasksByWorkspaceID query into batch to be executed
+	// later by the batch.
+	FindWorkspaceTasksByWorkspaceIDBatch(batch genericBatch, workspaceID pgtype.Text)
+	// FindWorkspaceTasksByWorkspaceIDScan scans the result of an executed FindWorkspaceTasksByWorkspaceIDBatch query.
+	FindWorkspaceTasksByWorkspaceIDScan(results pgx.BatchResults) ([]FindWorkspaceTasksByWorkspaceIDRow, error)
+
+	FindWorkspaceTaskByID(ctx context.Context, workspaceTaskID pgtype.Text) (FindWorkspaceTaskByIDRow, error)
+	// FindWorkspaceTaskByIDBatch enqueues a FindWorkspaceTaskByID query into batch to be executed
+	// later by the batch.
+	FindWorkspaceTaskByIDBatch(batch genericBatch, workspaceTaskID pgtype.Text)
+	// FindWorkspaceTaskByIDScan scans the result of an executed FindWorkspaceTaskByIDBatch query.
+	FindWorkspaceTaskByIDScan(results pgx.BatchResults) (FindWorkspaceTaskByIDRow, error)
+
+	FindWorkspaceTaskByIDForUpdate(ctx context.Context, workspaceTaskID pgtype.Text) (FindWorkspaceTaskByIDForUpdateRow, error)
+	// FindWorkspaceTaskByIDForUpdateBatch enqueues a FindWorkspaceTaskByIDForUpdate query into batch to be executed
+	// later by the batch.
+	FindWorkspaceTaskByIDForUpdateBatch(batch genericBatch, workspaceTaskID pgtype.Text)
+	// FindWorkspaceTaskByIDForUpdateScan scans the result of an executed FindWorkspaceTaskByIDForUpdateBatch query.
+	FindWorkspaceTaskByIDForUpdateScan(results pgx.BatchResults) (FindWorkspaceTaskByIDForUpdateRow, error)
+
+	UpdateWorkspaceTask(ctx context.Context, params UpdateWorkspaceTaskParams) (pgconn.CommandTag, error)
+	// UpdateWorkspaceTaskBatch enqueues a UpdateWorkspaceTask query into batch to be executed
+	// later by the batch.
+	UpdateWorkspaceTaskBatch(batch genericBatch, params UpdateWorkspaceTaskParams)
+	// UpdateWorkspaceTaskScan scans the result of an executed UpdateWorkspaceTaskBatch query.
+	UpdateWorkspaceTaskScan(results pgx.BatchResults) (pgconn.CommandTag, error)
+
+	DeleteWorkspaceTaskByID(ctx context.Context, workspaceTaskID pgtype.Text) (pgconn.CommandTag, error)
+	// DeleteWorkspaceTaskByIDBatch enqueues a DeleteWorkspaceTaskByID query into batch to be executed
+	// later by the batch.
+	DeleteWorkspaceTaskByIDBatch(batch genericBatch, workspaceTaskID pgtype.Text)
+	// DeleteWorkspaceTaskByIDScan scans the result of an executed DeleteWorkspaceTaskByIDBatch query.
+	DeleteWorkspaceTaskByIDScan(results pgx.BatchResults) (pgconn.CommandTag, error)
+
+	FindEnabledWorkspaceTasksByStage(ctx context.Context, workspaceID pgtype.Text, stage pgtype.Text) ([]FindEnabledWorkspaceTasksByStageRow, error)
+	// FindEnabledWorkspaceTasksByStageBatch enqueues a FindEnabledWorkspaceTasksByStage query into batch to be executed
+	// later by the batch.
+	FindEnabledWorkspaceTasksByStageBatch(batch genericBatch, workspaceID pgtype.Text, stage pgtype.Text)
+	// FindEnabledWorkspaceTasksByStageScan scans the result of an executed FindEnabledWorkspaceTasksByStageBatch query.
+	FindEnabledWorkspaceTasksByStageScan(results pgx.BatchResults) ([]FindEnabledWorkspaceTasksByStageRow, error)
+
+	InsertTaskStage(ctx context.Context, params InsertTaskStageParams) (pgconn.CommandTag, error)
+	// InsertTaskStageBatch enqueues a InsertTaskStage query into batch to be executed
+	// later by the batch.
+	InsertTaskStageBatch(batch genericBatch, params InsertTaskStageParams)
+	// InsertTaskStageScan scans the result of an executed InsertTaskStageBatch query.
+	InsertTaskStageScan(results pgx.BatchResults) (pgconn.CommandTag, error)
+
+	FindTaskStagesByRunID(ctx context.Context, runID pgtype.Text) ([]FindTaskStagesByRunIDRow, error)
+	// FindTaskStagesByRunIDBatch enqueues a FindTaskStagesByRunID query into batch to be executed
+	// later by the batch.
+	FindTaskStagesByRunIDBatch(batch genericBatch, runID pgtype.Text)
+	// FindTaskStagesByRunIDScan scans the result of an executed FindTaskStagesByRunIDBatch query.
+	FindTaskStagesByRunIDScan(results pgx.BatchResults) ([]FindTaskStagesByRunIDRow, error)
+
+	FindTaskStageByID(ctx context.Context, taskStageID pgtype.Text) (FindTaskStageByIDRow, error)
+	// FindTaskStageByIDBatch enqueues a FindTaskStageByID query into batch to be executed
+	// later by the batch.
+	FindTaskStageByIDBatch(batch genericBatch, taskStageID pgtype.Text)
+	// FindTaskStageByIDScan scans the result of an executed FindTaskStageByIDBatch query.
+	FindTaskStageByIDScan(results pgx.BatchResults) (FindTaskStageByIDRow, error)
+
+	FindTaskStageByIDForUpdate(ctx context.Context, taskStageID pgtype.Text) (FindTaskStageByIDForUpdateRow, error)
+	// FindTaskStageByIDForUpdateBatch enqueues a FindTaskStageByIDForUpdate query into batch to be executed
+	// later by the batch.
+	FindTaskStageByIDForUpdateBatch(batch genericBatch, taskStageID pgtype.Text)
+	// FindTaskStageByIDForUpdateScan scans the result of an executed FindTaskStageByIDForUpdateBatch query.
+	FindTaskStageByIDForUpdateScan(results pgx.BatchResults) (FindTaskStageByIDForUpdateRow, error)
+
+	UpdateTaskStageStatus(ctx context.Context, params UpdateTaskStageStatusParams) (pgconn.CommandTag, error)
+	// UpdateTaskStageStatusBatch enqueues a UpdateTaskStageStatus query into batch to be executed
+	// later by the batch.
+	UpdateTaskStageStatusBatch(batch genericBatch, params UpdateTaskStageStatusParams)
+	// UpdateTaskStageStatusScan scans the result of an executed UpdateTaskStageStatusBatch query.
+	UpdateTaskStageStatusScan(results pgx.BatchResults) (pgconn.CommandTag, error)
+
+	InsertTaskResult(ctx context.Context, params InsertTaskResultParams) (pgconn.CommandTag, error)
+	// InsertTaskResultBatch enqueues a InsertTaskResult query into batch to be executed
+	// later by the batch.
+	InsertTaskResultBatch(batch genericBatch, params InsertTaskResultParams)
+	// InsertTaskResultScan scans the result of an executed InsertTaskResultBatch query.
+	InsertTaskResultScan(results pgx.BatchResults) (pgconn.CommandTag, error)
+
+	FindTaskResultsByTaskStageID(ctx context.Context, taskStageID pgtype.Text) ([]FindTaskResultsByTaskStageIDRow, error)
+	// FindTaskResultsByTaskStageIDBatch enqueues a FindTaskResultsByTaskStageID query into batch to be executed
+	// later by the batch.
+	FindTaskResultsByTaskStageIDBatch(batch genericBatch, taskStageID pgtype.Text)
+	// FindTaskResultsByTaskStageIDScan scans the result of an executed FindTaskResultsByTaskStageIDBatch query.
+	FindTaskResultsByTaskStageIDScan(results pgx.BatchResults) ([]FindTaskResultsByTaskStageIDRow, error)
+
+	FindTaskResultByID(ctx context.Context, taskResultID pgtype.Text) (FindTaskResultByIDRow, error)
+	// FindTaskResultByIDBatch enqueues a FindTaskResultByID query into batch to be executed
+	// later by the batch.
+	FindTaskResultByIDBatch(batch genericBatch, taskResultID pgtype.Text)
+	// FindTaskResultByIDScan scans the result of an executed FindTaskResultByIDBatch query.
+	FindTaskResultByIDScan(results pgx.BatchResults) (FindTaskResultByIDRow, error)
+
+	FindIncompleteTaskResults(ctx context.Context) ([]FindIncompleteTaskResultsRow, error)
+	// FindIncompleteTaskResultsBatch enqueues a FindIncompleteTaskResults query into batch to be executed
+	// later by the batch.
+	FindIncompleteTaskResultsBatch(batch genericBatch)
+	// FindIncompleteTaskResultsScan scans the result of an executed FindIncompleteTaskResultsBatch query.
+	FindIncompleteTaskResultsScan(results pgx.BatchResults) ([]FindIncompleteTaskResultsRow, error)
+
+	UpdateTaskResultStatus(ctx context.Context, params UpdateTaskResultStatusParams) (pgconn.CommandTag, error)
+	// UpdateTaskResultStatusBatch enqueues a UpdateTaskResultStatus query into batch to be executed
+	// later by the batch.
+	UpdateTaskResultStatusBatch(batch genericBatch, params UpdateTaskResultStatusParams)
+	// UpdateTaskResultStatusScan scans the result of an executed UpdateTaskResultStatusBatch query.
+	UpdateTaskResultStatusScan(results pgx.BatchResults) (pgconn.CommandTag, error)
+
+	UpdateTaskResultDispatchedAt(ctx context.Context, dispatchedAt pgtype.Timestamptz, taskResultID pgtype.Text) (pgconn.CommandTag, error)
+	// UpdateTaskResultDispatchedAtBatch enqueues a UpdateTaskResultDispatchedAt query into batch to be executed
+	// later by the batch.
+	UpdateTaskResultDispatchedAtBatch(batch genericBatch, dispatchedAt pgtype.Timestamptz, taskResultID pgtype.Text)
+	// UpdateTaskResultDispatchedAtScan scans the result of an executed UpdateTaskResultDispatchedAtBatch query.
+	UpdateTaskResultDispatchedAtScan(results pgx.BatchResults) (pgconn.CommandTag, error)
+
+	UpsertRunTaskSettings(ctx context.Context, params UpsertRunTaskSettingsParams) (pgconn.CommandTag, error)
+	// UpsertRunTaskSettingsBatch enqueues a UpsertRunTaskSettings query into batch to be executed
+	// later by the batch.
+	UpsertRunTaskSettingsBatch(batch genericBatch, params UpsertRunTaskSettingsParams)
+	// UpsertRunTaskSettingsScan scans the result of an executed UpsertRunTaskSettingsBatch query.
+	UpsertRunTaskSettingsScan(results pgx.BatchResults) (pgconn.CommandTag, error)
+
+	FindRunTaskSettings(ctx context.Context, organizationName pgtype.Text) (FindRunTaskSettingsRow, error)
+	// FindRunTaskSettingsBatch enqueues a FindRunTaskSettings query into batch to be executed
+	// later by the batch.
+	FindRunTaskSettingsBatch(batch genericBatch, organizationName pgtype.Text)
+	// FindRunTaskSettingsScan scans the result of an executed FindRunTaskSettingsBatch query.
+	FindRunTaskSettingsScan(results pgx.BatchResults) (FindRunTaskSettingsRow, error)
+
+	InsertRunTrigger(ctx context.Context, params InsertRunTriggerParams) (pgconn.CommandTag, error)
+	// InsertRunTriggerBatch enqueues a InsertRunTrigger query into batch to be executed
+	// later by the batch.
+	InsertRunTriggerBatch(batch genericBatch, params InsertRunTriggerParams)
+	// InsertRunTriggerScan scans the result of an executed InsertRunTriggerBatch query.
+	InsertRunTriggerScan(results pgx.BatchResults) (pgconn.CommandTag, error)
+
+	FindRunTrigger(ctx context.Context, runTriggerID pgtype.Text) (FindRunTriggerRow, error)
+	// FindRunTriggerBatch enqueues a FindRunTrigger query into batch to be executed
+	// later by the batch.
+	FindRunTriggerBatch(batch genericBatch, runTriggerID pgtype.Text)
+	// FindRunTriggerScan scans the result of an executed FindRunTriggerBatch query.
+	FindRunTriggerScan(results pgx.BatchResults) (FindRunTriggerRow, error)
+
+	FindRunTriggersByWorkspaceID(ctx context.Context, workspaceID pgtype.Text) ([]FindRunTriggersByWorkspaceIDRow, error)
+	// FindRunTriggersByWorkspaceIDBatch enqueues a FindRunTriggersByWorkspaceID query into batch to be executed
+	// later by the batch.
+	FindRunTriggersByWorkspaceIDBatch(batch genericBatch, workspaceID pgtype.Text)
+	// FindRunTriggersByWorkspaceIDScan scans the result of an executed FindRunTriggersByWorkspaceIDBatch query.
+	FindRunTriggersByWorkspaceIDScan(results pgx.BatchResults) ([]FindRunTriggersByWorkspaceIDRow, error)
+
+	FindRunTriggersBySourceableWorkspaceID(ctx context.Context, sourceableWorkspaceID pgtype.Text) ([]FindRunTriggersBySourceableWorkspaceIDRow, error)
+	// FindRunTriggersBySourceableWorkspaceIDBatch enqueues a FindRunTriggersBySourceableWorkspaceID query into batch to be executed
+	// later by the batch.
+	FindRunTriggersBySourceableWorkspaceIDBatch(batch genericBatch, sourceableWorkspaceID pgtype.Text)
+	// FindRunTriggersBySourceableWorkspaceIDScan scans the result of an executed FindRunTriggersBySourceableWorkspaceIDBatch query.
+	FindRunTriggersBySourceableWorkspaceIDScan(results pgx.BatchResults) ([]FindRunTriggersBySourceableWorkspaceIDRow, error)
+
+	DeleteRunTrigger(ctx context.Context, runTriggerID pgtype.Text) (pgtype.Text, error)
+	// DeleteRunTriggerBatch enqueues a DeleteRunTrigger query into batch to be executed
+	// later by the batch.
+	DeleteRunTriggerBatch(batch genericBatch, runTriggerID pgtype.Text)
+	// DeleteRunTriggerScan scans the result of an executed DeleteRunTriggerBatch query.
+	DeleteRunTriggerScan(results pgx.BatchResults) (pgtype.Text, error)
+
+	Search(ctx context.Context, params SearchParams) ([]SearchRow, error)
+	// SearchBatch enqueues a Search query into batch to be executed
+	// later by the batch.
+	SearchBatch(batch genericBatch, params SearchParams)
+	// SearchScan scans the result of an executed SearchBatch query.
+	SearchScan(results pgx.BatchResults) ([]SearchRow, error)
+
+	InsertSSHKey(ctx context.Context, params InsertSSHKeyParams) (pgconn.CommandTag, error)
+	// InsertSSHKeyBatch enqueues a InsertSSHKey query into batch to be executed
+	// later by the batch.
+	InsertSSHKeyBatch(batch genericBatch, params InsertSSHKeyParams)
+	// InsertSSHKeyScan scans the result of an executed InsertSSHKeyBatch query.
+	InsertSSHKeyScan(results pgx.BatchResults) (pgconn.CommandTag, error)
+
+	FindSSHKeysByOrganization(ctx context.Context, organizationName pgtype.Text) ([]FindSSHKeysByOrganizationRow, error)
+	// FindSSHKeysByOrganizationBatch enqueues a FindSSHKeysByOrganization query into batch to be executed
+	// later by the batch.
+	FindSSHKeysByOrganizationBatch(batch genericBatch, organizationName pgtype.Text)
+	// FindSSHKeysByOrganizationScan scans the result of an executed FindSSHKeysByOrganizationBatch query.
+	FindSSHKeysByOrganizationScan(results pgx.BatchResults) ([]FindSSHKeysByOrganizationRow, error)
+
+	FindSSHKeyByID(ctx context.Context, sshKeyID pgtype.Text) (FindSSHKeyByIDRow, error)
+	// FindSSHKeyByIDBatch enqueues a FindSSHKeyByID query into batch to be executed
+	// later by the batch.
+	FindSSHKeyByIDBatch(batch genericBatch, sshKeyID pgtype.Text)
+	// FindSSHKeyByIDScan scans the result of an executed FindSSHKeyByIDBatch query.
+	FindSSHKeyByIDScan(results pgx.BatchResults) (FindSSHKeyByIDRow, error)
+
+	FindSSHKeyByIDForUpdate(ctx context.Context, sshKeyID pgtype.Text) (FindSSHKeyByIDForUpdateRow, error)
+	// FindSSHKeyByIDForUpdateBatch enqueues a FindSSHKeyByIDForUpdate query into batch to be executed
+	// later by the batch.
+	FindSSHKeyByIDForUpdateBatch(batch genericBatch, sshKeyID pgtype.Text)
+	// FindSSHKeyByIDForUpdateScan scans the result of an executed FindSSHKeyByIDForUpdateBatch query.
+	FindSSHKeyByIDForUpdateScan(results pgx.BatchResults) (FindSSHKeyByIDForUpdateRow, error)
+
+	UpdateSSHKeyByID(ctx context.Context, params UpdateSSHKeyByIDParams) (pgtype.Text, error)
+	// UpdateSSHKeyByIDBatch enqueues a UpdateSSHKeyByID query into batch to be executed
+	// later by the batch.
+	UpdateSSHKeyByIDBatch(batch genericBatch, params UpdateSSHKeyByIDParams)
+	// UpdateSSHKeyByIDScan scans the result of an executed UpdateSSHKeyByIDBatch query.
+	UpdateSSHKeyByIDScan(results pgx.BatchResults) (pgtype.Text, error)
+
+	DeleteSSHKeyByID(ctx context.Context, sshKeyID pgtype.Text) (pgtype.Text, error)
+	// DeleteSSHKeyByIDBatch enqueues a DeleteSSHKeyByID query into batch to be executed
+	// later by the batch.
+	DeleteSSHKeyByIDBatch(batch genericBatch, sshKeyID pgtype.Text)
+	// DeleteSSHKeyByIDScan scans the result of an executed DeleteSSHKeyByIDBatch query.
+	DeleteSSHKeyByIDScan(results pgx.BatchResults) (pgtype.Text, error)
+
+	UpsertWorkspaceSSHKey(ctx context.Context, workspaceID pgtype.Text, sshKeyID pgtype.Text) (pgconn.CommandTag, error)
+	// UpsertWorkspaceSSHKeyBatch enqueues a UpsertWorkspaceSSHKey query into batch to be executed
+	// later by the batch.
+	UpsertWorkspaceSSHKeyBatch(batch genericBatch, workspaceID pgtype.Text, sshKeyID pgtype.Text)
+	// UpsertWorkspaceSSHKeyScan scans the result of an executed UpsertWorkspaceSSHKeyBatch query.
+	UpsertWorkspaceSSHKeyScan(results pgx.BatchResults) (pgconn.CommandTag, error)
+
+	DeleteWorkspaceSSHKey(ctx context.Context, workspaceID pgtype.Text) (pgconn.CommandTag, error)
+	// DeleteWorkspaceSSHKeyBatch enqueues a DeleteWorkspaceSSHKey query into batch to be executed
+	// later by the batch.
+	DeleteWorkspaceSSHKeyBatch(batch genericBatch, workspaceID pgtype.Text)
+	// DeleteWorkspaceSSHKeyScan scans the result of an executed DeleteWorkspaceSSHKeyBatch query.
+	DeleteWorkspaceSSHKeyScan(results pgx.BatchResults) (pgconn.CommandTag, error)
+
+	FindSSHKeyByWorkspaceID(ctx context.Context, workspaceID pgtype.Text) (FindSSHKeyByWorkspaceIDRow, error)
+	// FindSSHKeyByWorkspaceIDBatch enqueues a FindSSHKeyByWorkspaceID query into batch to be executed
+	// later by the batch.
+	FindSSHKeyByWorkspaceIDBatch(batch genericBatch, workspaceID pgtype.Text)
+	// FindSSHKeyByWorkspaceIDScan scans the result of an executed FindSSHKeyByWorkspaceIDBatch query.
+	FindSSHKeyByWorkspaceIDScan(results pgx.BatchResults) (FindSSHKeyByWorkspaceIDRow, error)
+
+	UpsertSSOEnforcement(ctx context.Context, params UpsertSSOEnforcementParams) (pgconn.CommandTag, error)
+	// UpsertSSOEnforcementBatch enqueues a UpsertSSOEnforcement query into batch to be executed
+	// later by the batch.
+	UpsertSSOEnforcementBatch(batch genericBatch, params UpsertSSOEnforcementParams)
+	// UpsertSSOEnforcementScan scans the result of an executed UpsertSSOEnforcementBatch query.
+	UpsertSSOEnforcementScan(results pgx.BatchResults) (pgconn.CommandTag, error)
+
+	FindSSOEnforcement(ctx context.Context, organizationName pgtype.Text) (FindSSOEnforcementRow, error)
+	// FindSSOEnforcementBatch enqueues a FindSSOEnforcement query into batch to be executed
+	// later by the batch.
+	FindSSOEnforcementBatch(batch genericBatch, organizationName pgtype.Text)
+	// FindSSOEnforcementScan scans the result of an executed FindSSOEnforcementBatch query.
+	FindSSOEnforcementScan(results pgx.BatchResults) (FindSSOEnforcementRow, error)
+
+	FindSSOEnforcementsByOrganizations(ctx context.Context, organizationNames []string) ([]FindSSOEnforcementsByOrganizationsRow, error)
+	// FindSSOEnforcementsByOrganizationsBatch enqueues a FindSSOEnforcementsByOrganizations query into batch to be executed
+	// later by the batch.
+	FindSSOEnforcementsByOrganizationsBatch(batch genericBatch, organizationNames []string)
+	// FindSSOEnforcementsByOrganizationsScan scans the result of an executed FindSSOEnforcementsByOrganizationsBatch query.
+	FindSSOEnforcementsByOrganizationsScan(results pgx.BatchResults) ([]FindSSOEnforcementsByOrganizationsRow, error)
+
+	FindSSOEnforcements(ctx context.Context) ([]FindSSOEnforcementsRow, error)
+	// FindSSOEnforcementsBatch enqueues a FindSSOEnforcements query into batch to be executed
+	// later by the batch.
+	FindSSOEnforcementsBatch(batch genericBatch)
+	// FindSSOEnforcementsScan scans the result of an executed FindSSOEnforcementsBatch query.
+	FindSSOEnforcementsScan(results pgx.BatchResults) ([]FindSSOEnforcementsRow, error)
+
+	DeleteSSOEnforcement(ctx context.Context, organizationName pgtype.Text) (pgtype.Text, error)
+	// DeleteSSOEnforcementBatch enqueues a DeleteSSOEnforcement query into batch to be executed
+	// later by the batch.
+	DeleteSSOEnforcementBatch(batch genericBatch, organizationName pgtype.Text)
+	// DeleteSSOEnforcementScan scans the result of an executed DeleteSSOEnforcementBatch query.
+	DeleteSSOEnforcementScan(results pgx.BatchResults) (pgtype.Text, error)
+
 	InsertStateVersion(ctx context.Context, params InsertStateVersionParams) (pgconn.CommandTag, error)
 	// InsertStateVersionBatch enqueues a InsertStateVersion query into batch to be executed
 	// later by the batch.
@@ -944,6 +2416,13 @@ type Querier interface {
 	// FindStateVersionOutputByIDScan scans the result of an executed FindStateVersionOutputByIDBatch query.
 	FindStateVersionOutputByIDScan(results pgx.BatchResults) (FindStateVersionOutputByIDRow, error)
 
+	InsertStateVersionRun(ctx context.Context, stateVersionID pgtype.Text, runID pgtype.Text) (pgconn.CommandTag, error)
+	// InsertStateVersionRunBatch enqueues a InsertStateVersionRun query into batch to be executed
+	// later by the batch.
+	InsertStateVersionRunBatch(batch genericBatch, stateVersionID pgtype.Text, runID pgtype.Text)
+	// InsertStateVersionRunScan scans the result of an executed InsertStateVersionRunBatch query.
+	InsertStateVersionRunScan(results pgx.BatchResults) (pgconn.CommandTag, error)
+
 	InsertTag(ctx context.Context, params InsertTagParams) (pgconn.CommandTag, error)
 	// InsertTagBatch enqueues a InsertTag query into batch to be executed
 	// later by the batch.
@@ -1091,6 +2570,13 @@ type Querier interface {
 	// DeleteTeamMembershipScan scans the result of an executed DeleteTeamMembershipBatch query.
 	DeleteTeamMembershipScan(results pgx.BatchResults) ([]pgtype.Text, error)
 
+	DeleteTeamMembershipsByOrganization(ctx context.Context, organizationName pgtype.Text, username pgtype.Text) ([]pgtype.Text, error)
+	// DeleteTeamMembershipsByOrganizationBatch enqueues a DeleteTeamMembershipsByOrganization query into batch to be executed
+	// later by the batch.
+	DeleteTeamMembershipsByOrganizationBatch(batch genericBatch, organizationName pgtype.Text, username pgtype.Text)
+	// DeleteTeamMembershipsByOrganizationScan scans the result of an executed DeleteTeamMembershipsByOrganizationBatch query.
+	DeleteTeamMembershipsByOrganizationScan(results pgx.BatchResults) ([]pgtype.Text, error)
+
 	InsertTeamToken(ctx context.Context, params InsertTeamTokenParams) (pgconn.CommandTag, error)
 	// InsertTeamTokenBatch enqueues a InsertTeamToken query into batch to be executed
 	// later by the batch.
@@ -1336,6 +2822,72 @@ type Querier interface {
 	// DeleteVariableSetWorkspacesScan scans the result of an executed DeleteVariableSetWorkspacesBatch query.
 	DeleteVariableSetWorkspacesScan(results pgx.BatchResults) (pgconn.CommandTag, error)
 
+	// InsertVCSEventDelivery records the delivery of a vcs event. If the delivery
+	// has already been recorded then no row is inserted.
+	//
+	InsertVCSEventDelivery(ctx context.Context, params InsertVCSEventDeliveryParams) (pgconn.CommandTag, error)
+	// InsertVCSEventDeliveryBatch enqueues a InsertVCSEventDelivery query into batch to be executed
+	// later by the batch.
+	InsertVCSEventDeliveryBatch(batch genericBatch, params InsertVCSEventDeliveryParams)
+	// InsertVCSEventDeliveryScan scans the result of an executed InsertVCSEventDeliveryBatch query.
+	InsertVCSEventDeliveryScan(results pgx.BatchResults) (pgconn.CommandTag, error)
+
+	DeleteVCSEventDeliveriesBefore(ctx context.Context, receivedBefore pgtype.Timestamptz) (pgconn.CommandTag, error)
+	// DeleteVCSEventDeliveriesBeforeBatch enqueues a DeleteVCSEventDeliveriesBefore query into batch to be executed
+	// later by the batch.
+	DeleteVCSEventDeliveriesBeforeBatch(batch genericBatch, receivedBefore pgtype.Timestamptz)
+	// DeleteVCSEventDeliveriesBeforeScan scans the result of an executed DeleteVCSEventDeliveriesBeforeBatch query.
+	DeleteVCSEventDeliveriesBeforeScan(results pgx.BatchResults) (pgconn.CommandTag, error)
+
+	InsertVCSEventDeadLetter(ctx context.Context, params InsertVCSEventDeadLetterParams) (pgconn.CommandTag, error)
+	// InsertVCSEventDeadLetterBatch enqueues a InsertVCSEventDeadLetter query into batch to be executed
+	// later by the batch.
+	InsertVCSEventDeadLetterBatch(batch genericBatch, params InsertVCSEventDeadLetterParams)
+	// InsertVCSEventDeadLetterScan scans the result of an executed InsertVCSEventDeadLetterBatch query.
+	InsertVCSEventDeadLetterScan(results pgx.BatchResults) (pgconn.CommandTag, error)
+
+	FindVCSEventDeadLetters(ctx context.Context) ([]FindVCSEventDeadLettersRow, error)
+	// FindVCSEventDeadLettersBatch enqueues a FindVCSEventDeadLetters query into batch to be executed
+	// later by the batch.
+	FindVCSEventDeadLettersBatch(batch genericBatch)
+	// FindVCSEventDeadLettersScan scans the result of an executed FindVCSEventDeadLettersBatch query.
+	FindVCSEventDeadLettersScan(results pgx.BatchResults) ([]FindVCSEventDeadLettersRow, error)
+
+	FindVCSEventDeadLetterByID(ctx context.Context, deadLetterID pgtype.Text) (FindVCSEventDeadLetterByIDRow, error)
+	// FindVCSEventDeadLetterByIDBatch enqueues a FindVCSEventDeadLetterByID query into batch to be executed
+	// later by the batch.
+	FindVCSEventDeadLetterByIDBatch(batch genericBatch, deadLetterID pgtype.Text)
+	// FindVCSEventDeadLetterByIDScan scans the result of an executed FindVCSEventDeadLetterByIDBatch query.
+	FindVCSEventDeadLetterByIDScan(results pgx.BatchResults) (FindVCSEventDeadLetterByIDRow, error)
+
+	DeleteVCSEventDeadLetterByID(ctx context.Context, deadLetterID pgtype.Text) (pgtype.Text, error)
+	// DeleteVCSEventDeadLetterByIDBatch enqueues a DeleteVCSEventDeadLetterByID query into batch to be executed
+	// later by the batch.
+	DeleteVCSEventDeadLetterByIDBatch(batch genericBatch, deadLetterID pgtype.Text)
+	// DeleteVCSEventDeadLetterByIDScan scans the result of an executed DeleteVCSEventDeadLetterByIDBatch query.
+	DeleteVCSEventDeadLetterByIDScan(results pgx.BatchResults) (pgtype.Text, error)
+
+	InsertQueuedVCSEvent(ctx context.Context, params InsertQueuedVCSEventParams) (pgconn.CommandTag, error)
+	// InsertQueuedVCSEventBatch enqueues a InsertQueuedVCSEvent query into batch to be executed
+	// later by the batch.
+	InsertQueuedVCSEventBatch(batch genericBatch, params InsertQueuedVCSEventParams)
+	// InsertQueuedVCSEventScan scans the result of an executed InsertQueuedVCSEventBatch query.
+	InsertQueuedVCSEventScan(results pgx.BatchResults) (pgconn.CommandTag, error)
+
+	ClaimQueuedVCSEvents(ctx context.Context, claimedAt pgtype.Timestamptz, claimedBefore pgtype.Timestamptz) ([]ClaimQueuedVCSEventsRow, error)
+	// ClaimQueuedVCSEventsBatch enqueues a ClaimQueuedVCSEvents query into batch to be executed
+	// later by the batch.
+	ClaimQueuedVCSEventsBatch(batch genericBatch, claimedAt pgtype.Timestamptz, claimedBefore pgtype.Timestamptz)
+	// ClaimQueuedVCSEventsScan scans the result of an executed ClaimQueuedVCSEventsBatch query.
+	ClaimQueuedVCSEventsScan(results pgx.BatchResults) ([]ClaimQueuedVCSEventsRow, error)
+
+	DeleteQueuedVCSEventByID(ctx context.Context, queuedEventID pgtype.Text) (pgconn.CommandTag, error)
+	// DeleteQueuedVCSEventByIDBatch enqueues a DeleteQueuedVCSEventByID query into batch to be executed
+	// later by the batch.
+	DeleteQueuedVCSEventByIDBatch(batch genericBatch, queuedEventID pgtype.Text)
+	// DeleteQueuedVCSEventByIDScan scans the result of an executed DeleteQueuedVCSEventByIDBatch query.
+	DeleteQueuedVCSEventByIDScan(results pgx.BatchResults) (pgconn.CommandTag, error)
+
 	InsertVCSProvider(ctx context.Context, params InsertVCSProviderParams) (pgconn.CommandTag, error)
 	// InsertVCSProviderBatch enqueues a InsertVCSProvider query into batch to be executed
 	// later by the batch.
@@ -1511,6 +3063,55 @@ type Querier interface {
 	// DeleteWorkspacePermissionByIDScan scans the result of an executed DeleteWorkspacePermissionByIDBatch query.
 	DeleteWorkspacePermissionByIDScan(results pgx.BatchResults) (pgconn.CommandTag, error)
 
+	InsertWorkspaceTemplate(ctx context.Context, workspaceID pgtype.Text, createdAt pgtype.Timestamptz) (pgconn.CommandTag, error)
+	// InsertWorkspaceTemplateBatch enqueues a InsertWorkspaceTemplate query into batch to be executed
+	// later by the batch.
+	InsertWorkspaceTemplateBatch(batch genericBatch, workspaceID pgtype.Text, createdAt pgtype.Timestamptz)
+	// InsertWorkspaceTemplateScan scans the result of an executed InsertWorkspaceTemplateBatch query.
+	InsertWorkspaceTemplateScan(results pgx.BatchResults) (pgconn.CommandTag, error)
+
+	FindWorkspaceTemplate(ctx context.Context, workspaceID pgtype.Text) (FindWorkspaceTemplateRow, error)
+	// FindWorkspaceTemplateBatch enqueues a FindWorkspaceTemplate query into batch to be executed
+	// later by the batch.
+	FindWorkspaceTemplateBatch(batch genericBatch, workspaceID pgtype.Text)
+	// FindWorkspaceTemplateScan scans the result of an executed FindWorkspaceTemplateBatch query.
+	FindWorkspaceTemplateScan(results pgx.BatchResults) (FindWorkspaceTemplateRow, error)
+
+	FindWorkspaceTemplatesByOrganization(ctx context.Context, organizationName pgtype.Text) ([]FindWorkspaceTemplatesByOrganizationRow, error)
+	// FindWorkspaceTemplatesByOrganizationBatch enqueues a FindWorkspaceTemplatesByOrganization query into batch to be executed
+	// later by the batch.
+	FindWorkspaceTemplatesByOrganizationBatch(batch genericBatch, organizationName pgtype.Text)
+	// FindWorkspaceTemplatesByOrganizationScan scans the result of an executed FindWorkspaceTemplatesByOrganizationBatch query.
+	FindWorkspaceTemplatesByOrganizationScan(results pgx.BatchResults) ([]FindWorkspaceTemplatesByOrganizationRow, error)
+
+	DeleteWorkspaceTemplate(ctx context.Context, workspaceID pgtype.Text) (pgtype.Text, error)
+	// DeleteWorkspaceTemplateBatch enqueues a DeleteWorkspaceTemplate query into batch to be executed
+	// later by the batch.
+	DeleteWorkspaceTemplateBatch(batch genericBatch, workspaceID pgtype.Text)
+	// DeleteWorkspaceTemplateScan scans the result of an executed DeleteWorkspaceTemplateBatch query.
+	DeleteWorkspaceTemplateScan(results pgx.BatchResults) (pgtype.Text, error)
+
+	UpsertWorkspaceTombstone(ctx context.Context, params UpsertWorkspaceTombstoneParams) (pgconn.CommandTag, error)
+	// UpsertWorkspaceTombstoneBatch enqueues a UpsertWorkspaceTombstone query into batch to be executed
+	// later by the batch.
+	UpsertWorkspaceTombstoneBatch(batch genericBatch, params UpsertWorkspaceTombstoneParams)
+	// UpsertWorkspaceTombstoneScan scans the result of an executed UpsertWorkspaceTombstoneBatch query.
+	UpsertWorkspaceTombstoneScan(results pgx.BatchResults) (pgconn.CommandTag, error)
+
+	FindWorkspaceTombstone(ctx context.Context, params FindWorkspaceTombstoneParams) (FindWorkspaceTombstoneRow, error)
+	// FindWorkspaceTombstoneBatch enqueues a FindWorkspaceTombstone query into batch to be executed
+	// later by the batch.
+	FindWorkspaceTombstoneBatch(batch genericBatch, params FindWorkspaceTombstoneParams)
+	// FindWorkspaceTombstoneScan scans the result of an executed FindWorkspaceTombstoneBatch query.
+	FindWorkspaceTombstoneScan(results pgx.BatchResults) (FindWorkspaceTombstoneRow, error)
+
+	DeleteWorkspaceTombstonesBefore(ctx context.Context, renamedBefore pgtype.Timestamptz) (pgconn.CommandTag, error)
+	// DeleteWorkspaceTombstonesBeforeBatch enqueues a DeleteWorkspaceTombstonesBefore query into batch to be executed
+	// later by the batch.
+	DeleteWorkspaceTombstonesBeforeBatch(batch genericBatch, renamedBefore pgtype.Timestamptz)
+	// DeleteWorkspaceTombstonesBeforeScan scans the result of an executed DeleteWorkspaceTombstonesBeforeBatch query.
+	DeleteWorkspaceTombstonesBeforeScan(results pgx.BatchResults) (pgconn.CommandTag, error)
+
 	InsertWorkspaceVariable(ctx context.Context, variableID pgtype.Text, workspaceID pgtype.Text) (pgconn.CommandTag, error)
 	// InsertWorkspaceVariableBatch enqueues a InsertWorkspaceVariable query into batch to be executed
 	// later by the batch.
@@ -1538,6 +3139,104 @@ type Querier interface {
 	DeleteWorkspaceVariableByIDBatch(batch genericBatch, variableID pgtype.Text)
 	// DeleteWorkspaceVariableByIDScan scans the result of an executed DeleteWorkspaceVariableByIDBatch query.
 	DeleteWorkspaceVariableByIDScan(results pgx.BatchResults) (DeleteWorkspaceVariableByIDRow, error)
+
+	InsertPolicyCheck(ctx context.Context, params InsertPolicyCheckParams) (pgconn.CommandTag, error)
+	// InsertPolicyCheckBatch enqueues a InsertPolicyCheck query into batch to be executed
+	// later by the batch.
+	InsertPolicyCheckBatch(batch genericBatch, params InsertPolicyCheckParams)
+	// InsertPolicyCheckScan scans the result of an executed InsertPolicyCheckBatch query.
+	InsertPolicyCheckScan(results pgx.BatchResults) (pgconn.CommandTag, error)
+
+	FindPolicyChecksByRunID(ctx context.Context, runID pgtype.Text) ([]FindPolicyChecksByRunIDRow, error)
+	// FindPolicyChecksByRunIDBatch enqueues a FindPolicyChecksByRunID query into batch to be executed
+	// later by the batch.
+	FindPolicyChecksByRunIDBatch(batch genericBatch, runID pgtype.Text)
+	// FindPolicyChecksByRunIDScan scans the result of an executed FindPolicyChecksByRunIDBatch query.
+	FindPolicyChecksByRunIDScan(results pgx.BatchResults) ([]FindPolicyChecksByRunIDRow, error)
+
+	FindPolicyCheckByID(ctx context.Context, policyCheckID pgtype.Text) (FindPolicyCheckByIDRow, error)
+	// FindPolicyCheckByIDBatch enqueues a FindPolicyCheckByID query into batch to be executed
+	// later by the batch.
+	FindPolicyCheckByIDBatch(batch genericBatch, policyCheckID pgtype.Text)
+	// FindPolicyCheckByIDScan scans the result of an executed FindPolicyCheckByIDBatch query.
+	FindPolicyCheckByIDScan(results pgx.BatchResults) (FindPolicyCheckByIDRow, error)
+
+	FindPolicyCheckByIDForUpdate(ctx context.Context, policyCheckID pgtype.Text) (FindPolicyCheckByIDForUpdateRow, error)
+	// FindPolicyCheckByIDForUpdateBatch enqueues a FindPolicyCheckByIDForUpdate query into batch to be executed
+	// later by the batch.
+	FindPolicyCheckByIDForUpdateBatch(batch genericBatch, policyCheckID pgtype.Text)
+	// FindPolicyCheckByIDForUpdateScan scans the result of an executed FindPolicyCheckByIDForUpdateBatch query.
+	FindPolicyCheckByIDForUpdateScan(results pgx.BatchResults) (FindPolicyCheckByIDForUpdateRow, error)
+
+	FindQueuedPolicyChecks(ctx context.Context) ([]FindQueuedPolicyChecksRow, error)
+	// FindQueuedPolicyChecksBatch enqueues a FindQueuedPolicyChecks query into batch to be executed
+	// later by the batch.
+	FindQueuedPolicyChecksBatch(batch genericBatch)
+	// FindQueuedPolicyChecksScan scans the result of an executed FindQueuedPolicyChecksBatch query.
+	FindQueuedPolicyChecksScan(results pgx.BatchResults) ([]FindQueuedPolicyChecksRow, error)
+
+	UpdatePolicyCheck(ctx context.Context, params UpdatePolicyCheckParams) (pgconn.CommandTag, error)
+	// UpdatePolicyCheckBatch enqueues a UpdatePolicyCheck query into batch to be executed
+	// later by the batch.
+	UpdatePolicyCheckBatch(batch genericBatch, params UpdatePolicyCheckParams)
+	// UpdatePolicyCheckScan scans the result of an executed UpdatePolicyCheckBatch query.
+	UpdatePolicyCheckScan(results pgx.BatchResults) (pgconn.CommandTag, error)
+
+	InsertOrganizationExportStream(ctx context.Context, params InsertOrganizationExportStreamParams) (pgconn.CommandTag, error)
+	// InsertOrganizationExportStreamBatch enqueues a InsertOrganizationExportStream query into batch to be executed
+	// later by the batch.
+	InsertOrganizationExportStreamBatch(batch genericBatch, params InsertOrganizationExportStreamParams)
+	// InsertOrganizationExportStreamScan scans the result of an executed InsertOrganizationExportStreamBatch query.
+	InsertOrganizationExportStreamScan(results pgx.BatchResults) (pgconn.CommandTag, error)
+
+	SnapshotOrganization(ctx context.Context, organizationName pgtype.Text) (pgconn.CommandTag, error)
+	// SnapshotOrganizationBatch enqueues a SnapshotOrganization query into batch to be executed
+	// later by the batch.
+	SnapshotOrganizationBatch(batch genericBatch, organizationName pgtype.Text)
+	// SnapshotOrganizationScan scans the result of an executed SnapshotOrganizationBatch query.
+	SnapshotOrganizationScan(results pgx.BatchResults) (pgconn.CommandTag, error)
+
+	FindOrganizationExportStreams(ctx context.Context) ([]FindOrganizationExportStreamsRow, error)
+	// FindOrganizationExportStreamsBatch enqueues a FindOrganizationExportStreams query into batch to be executed
+	// later by the batch.
+	FindOrganizationExportStreamsBatch(batch genericBatch)
+	// FindOrganizationExportStreamsScan scans the result of an executed FindOrganizationExportStreamsBatch query.
+	FindOrganizationExportStreamsScan(results pgx.BatchResults) ([]FindOrganizationExportStreamsRow, error)
+
+	FindOrganizationExportStream(ctx context.Context, organizationName pgtype.Text) (FindOrganizationExportStreamRow, error)
+	// FindOrganizationExportStreamBatch enqueues a FindOrganizationExportStream query into batch to be executed
+	// later by the batch.
+	FindOrganizationExportStreamBatch(batch genericBatch, organizationName pgtype.Text)
+	// FindOrganizationExportStreamScan scans the result of an executed FindOrganizationExportStreamBatch query.
+	FindOrganizationExportStreamScan(results pgx.BatchResults) (FindOrganizationExportStreamRow, error)
+
+	UpdateOrganizationExportStream(ctx context.Context, params UpdateOrganizationExportStreamParams) (pgconn.CommandTag, error)
+	// UpdateOrganizationExportStreamBatch enqueues a UpdateOrganizationExportStream query into batch to be executed
+	// later by the batch.
+	UpdateOrganizationExportStreamBatch(batch genericBatch, params UpdateOrganizationExportStreamParams)
+	// UpdateOrganizationExportStreamScan scans the result of an executed UpdateOrganizationExportStreamBatch query.
+	UpdateOrganizationExportStreamScan(results pgx.BatchResults) (pgconn.CommandTag, error)
+
+	DeleteOrganizationExportStream(ctx context.Context, organizationName pgtype.Text) (pgtype.Text, error)
+	// DeleteOrganizationExportStreamBatch enqueues a DeleteOrganizationExportStream query into batch to be executed
+	// later by the batch.
+	DeleteOrganizationExportStreamBatch(batch genericBatch, organizationName pgtype.Text)
+	// DeleteOrganizationExportStreamScan scans the result of an executed DeleteOrganizationExportStreamBatch query.
+	DeleteOrganizationExportStreamScan(results pgx.BatchResults) (pgtype.Text, error)
+
+	FindOrganizationChanges(ctx context.Context, params FindOrganizationChangesParams) ([]FindOrganizationChangesRow, error)
+	// FindOrganizationChangesBatch enqueues a FindOrganizationChanges query into batch to be executed
+	// later by the batch.
+	FindOrganizationChangesBatch(batch genericBatch, params FindOrganizationChangesParams)
+	// FindOrganizationChangesScan scans the result of an executed FindOrganizationChangesBatch query.
+	FindOrganizationChangesScan(results pgx.BatchResults) ([]FindOrganizationChangesRow, error)
+
+	DeleteOrganizationChanges(ctx context.Context, sequences []int64) (pgconn.CommandTag, error)
+	// DeleteOrganizationChangesBatch enqueues a DeleteOrganizationChanges query into batch to be executed
+	// later by the batch.
+	DeleteOrganizationChangesBatch(batch genericBatch, sequences []int64)
+	// DeleteOrganizationChangesScan scans the result of an executed DeleteOrganizationChangesBatch query.
+	DeleteOrganizationChangesScan(results pgx.BatchResults) (pgconn.CommandTag, error)
 }
 
 type DBQuerier struct {
@@ -2375,6 +4074,7 @@ type Runs struct {
 	Source                 pgtype.Text        `json:"source"`
 	TerraformVersion       pgtype.Text        `json:"terraform_version"`
 	AllowEmptyApply        pgtype.Bool        `json:"allow_empty_apply"`
+	Message                pgtype.Text        `json:"message"`
 }
 
 // StateVersionOutputs represents the Postgres composite type "state_version_outputs".
@@ -2665,6 +4365,7 @@ func (tr *typeResolver) newRuns() pgtype.ValueTranscoder {
 		compositeField{"source", "text", &pgtype.Text{}},
 		compositeField{"terraform_version", "text", &pgtype.Text{}},
 		compositeField{"allow_empty_apply", "bool", &pgtype.Bool{}},
+		compositeField{"message", "text", &pgtype.Text{}},
 	)
 }
 
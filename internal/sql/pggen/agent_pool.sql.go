@@ -16,13 +16,15 @@ const insertAgentPoolSQL = `INSERT INTO agent_pools (
     name,
     created_at,
     organization_name,
-    organization_scoped
+    organization_scoped,
+    labels
 ) VALUES (
     $1,
     $2,
     $3,
     $4,
-    $5
+    $5,
+    $6
 );`
 
 type InsertAgentPoolParams struct {
@@ -31,12 +33,13 @@ type InsertAgentPoolParams struct {
 	CreatedAt          pgtype.Timestamptz
 	OrganizationName   pgtype.Text
 	OrganizationScoped pgtype.Bool
+	Labels             []string
 }
 
 // InsertAgentPool implements Querier.InsertAgentPool.
 func (q *DBQuerier) InsertAgentPool(ctx context.Context, params InsertAgentPoolParams) (pgconn.CommandTag, error) {
 	ctx = context.WithValue(ctx, "pggen_query_name", "InsertAgentPool")
-	cmdTag, err := q.conn.Exec(ctx, insertAgentPoolSQL, params.AgentPoolID, params.Name, params.CreatedAt, params.OrganizationName, params.OrganizationScoped)
+	cmdTag, err := q.conn.Exec(ctx, insertAgentPoolSQL, params.AgentPoolID, params.Name, params.CreatedAt, params.OrganizationName, params.OrganizationScoped, params.Labels)
 	if err != nil {
 		return cmdTag, fmt.Errorf("exec query InsertAgentPool: %w", err)
 	}
@@ -45,7 +48,7 @@ func (q *DBQuerier) InsertAgentPool(ctx context.Context, params InsertAgentPoolP
 
 // InsertAgentPoolBatch implements Querier.InsertAgentPoolBatch.
 func (q *DBQuerier) InsertAgentPoolBatch(batch genericBatch, params InsertAgentPoolParams) {
-	batch.Queue(insertAgentPoolSQL, params.AgentPoolID, params.Name, params.CreatedAt, params.OrganizationName, params.OrganizationScoped)
+	batch.Queue(insertAgentPoolSQL, params.AgentPoolID, params.Name, params.CreatedAt, params.OrganizationName, params.OrganizationScoped, params.Labels)
 }
 
 // InsertAgentPoolScan implements Querier.InsertAgentPoolScan.
@@ -78,6 +81,7 @@ type FindAgentPoolsRow struct {
 	CreatedAt           pgtype.Timestamptz `json:"created_at"`
 	OrganizationName    pgtype.Text        `json:"organization_name"`
 	OrganizationScoped  pgtype.Bool        `json:"organization_scoped"`
+	Labels              []string           `json:"labels"`
 	WorkspaceIds        []string           `json:"workspace_ids"`
 	AllowedWorkspaceIds []string           `json:"allowed_workspace_ids"`
 }
@@ -93,7 +97,7 @@ func (q *DBQuerier) FindAgentPools(ctx context.Context) ([]FindAgentPoolsRow, er
 	items := []FindAgentPoolsRow{}
 	for rows.Next() {
 		var item FindAgentPoolsRow
-		if err := rows.Scan(&item.AgentPoolID, &item.Name, &item.CreatedAt, &item.OrganizationName, &item.OrganizationScoped, &item.WorkspaceIds, &item.AllowedWorkspaceIds); err != nil {
+		if err := rows.Scan(&item.AgentPoolID, &item.Name, &item.CreatedAt, &item.OrganizationName, &item.OrganizationScoped, &item.Labels, &item.WorkspaceIds, &item.AllowedWorkspaceIds); err != nil {
 			return nil, fmt.Errorf("scan FindAgentPools row: %w", err)
 		}
 		items = append(items, item)
@@ -119,7 +123,7 @@ func (q *DBQuerier) FindAgentPoolsScan(results pgx.BatchResults) ([]FindAgentPoo
 	items := []FindAgentPoolsRow{}
 	for rows.Next() {
 		var item FindAgentPoolsRow
-		if err := rows.Scan(&item.AgentPoolID, &item.Name, &item.CreatedAt, &item.OrganizationName, &item.OrganizationScoped, &item.WorkspaceIds, &item.AllowedWorkspaceIds); err != nil {
+		if err := rows.Scan(&item.AgentPoolID, &item.Name, &item.CreatedAt, &item.OrganizationName, &item.OrganizationScoped, &item.Labels, &item.WorkspaceIds, &item.AllowedWorkspaceIds); err != nil {
 			return nil, fmt.Errorf("scan FindAgentPoolsBatch row: %w", err)
 		}
 		items = append(items, item)
@@ -170,6 +174,7 @@ type FindAgentPoolsByOrganizationRow struct {
 	CreatedAt           pgtype.Timestamptz `json:"created_at"`
 	OrganizationName    pgtype.Text        `json:"organization_name"`
 	OrganizationScoped  pgtype.Bool        `json:"organization_scoped"`
+	Labels              []string           `json:"labels"`
 	WorkspaceIds        []string           `json:"workspace_ids"`
 	AllowedWorkspaceIds []string           `json:"allowed_workspace_ids"`
 }
@@ -185,7 +190,7 @@ func (q *DBQuerier) FindAgentPoolsByOrganization(ctx context.Context, params Fin
 	items := []FindAgentPoolsByOrganizationRow{}
 	for rows.Next() {
 		var item FindAgentPoolsByOrganizationRow
-		if err := rows.Scan(&item.AgentPoolID, &item.Name, &item.CreatedAt, &item.OrganizationName, &item.OrganizationScoped, &item.WorkspaceIds, &item.AllowedWorkspaceIds); err != nil {
+		if err := rows.Scan(&item.AgentPoolID, &item.Name, &item.CreatedAt, &item.OrganizationName, &item.OrganizationScoped, &item.Labels, &item.WorkspaceIds, &item.AllowedWorkspaceIds); err != nil {
 			return nil, fmt.Errorf("scan FindAgentPoolsByOrganization row: %w", err)
 		}
 		items = append(items, item)
@@ -211,7 +216,7 @@ func (q *DBQuerier) FindAgentPoolsByOrganizationScan(results pgx.BatchResults) (
 	items := []FindAgentPoolsByOrganizationRow{}
 	for rows.Next() {
 		var item FindAgentPoolsByOrganizationRow
-		if err := rows.Scan(&item.AgentPoolID, &item.Name, &item.CreatedAt, &item.OrganizationName, &item.OrganizationScoped, &item.WorkspaceIds, &item.AllowedWorkspaceIds); err != nil {
+		if err := rows.Scan(&item.AgentPoolID, &item.Name, &item.CreatedAt, &item.OrganizationName, &item.OrganizationScoped, &item.Labels, &item.WorkspaceIds, &item.AllowedWorkspaceIds); err != nil {
 			return nil, fmt.Errorf("scan FindAgentPoolsByOrganizationBatch row: %w", err)
 		}
 		items = append(items, item)
@@ -244,6 +249,7 @@ type FindAgentPoolRow struct {
 	CreatedAt           pgtype.Timestamptz `json:"created_at"`
 	OrganizationName    pgtype.Text        `json:"organization_name"`
 	OrganizationScoped  pgtype.Bool        `json:"organization_scoped"`
+	Labels              []string           `json:"labels"`
 	WorkspaceIds        []string           `json:"workspace_ids"`
 	AllowedWorkspaceIds []string           `json:"allowed_workspace_ids"`
 }
@@ -253,7 +259,7 @@ func (q *DBQuerier) FindAgentPool(ctx context.Context, poolID pgtype.Text) (Find
 	ctx = context.WithValue(ctx, "pggen_query_name", "FindAgentPool")
 	row := q.conn.QueryRow(ctx, findAgentPoolSQL, poolID)
 	var item FindAgentPoolRow
-	if err := row.Scan(&item.AgentPoolID, &item.Name, &item.CreatedAt, &item.OrganizationName, &item.OrganizationScoped, &item.WorkspaceIds, &item.AllowedWorkspaceIds); err != nil {
+	if err := row.Scan(&item.AgentPoolID, &item.Name, &item.CreatedAt, &item.OrganizationName, &item.OrganizationScoped, &item.Labels, &item.WorkspaceIds, &item.AllowedWorkspaceIds); err != nil {
 		return item, fmt.Errorf("query FindAgentPool: %w", err)
 	}
 	return item, nil
@@ -268,7 +274,7 @@ func (q *DBQuerier) FindAgentPoolBatch(batch genericBatch, poolID pgtype.Text) {
 func (q *DBQuerier) FindAgentPoolScan(results pgx.BatchResults) (FindAgentPoolRow, error) {
 	row := results.QueryRow()
 	var item FindAgentPoolRow
-	if err := row.Scan(&item.AgentPoolID, &item.Name, &item.CreatedAt, &item.OrganizationName, &item.OrganizationScoped, &item.WorkspaceIds, &item.AllowedWorkspaceIds); err != nil {
+	if err := row.Scan(&item.AgentPoolID, &item.Name, &item.CreatedAt, &item.OrganizationName, &item.OrganizationScoped, &item.Labels, &item.WorkspaceIds, &item.AllowedWorkspaceIds); err != nil {
 		return item, fmt.Errorf("scan FindAgentPoolBatch row: %w", err)
 	}
 	return item, nil
@@ -297,6 +303,7 @@ type FindAgentPoolByAgentTokenIDRow struct {
 	CreatedAt           pgtype.Timestamptz `json:"created_at"`
 	OrganizationName    pgtype.Text        `json:"organization_name"`
 	OrganizationScoped  pgtype.Bool        `json:"organization_scoped"`
+	Labels              []string           `json:"labels"`
 	WorkspaceIds        []string           `json:"workspace_ids"`
 	AllowedWorkspaceIds []string           `json:"allowed_workspace_ids"`
 }
@@ -306,7 +313,7 @@ func (q *DBQuerier) FindAgentPoolByAgentTokenID(ctx context.Context, agentTokenI
 	ctx = context.WithValue(ctx, "pggen_query_name", "FindAgentPoolByAgentTokenID")
 	row := q.conn.QueryRow(ctx, findAgentPoolByAgentTokenIDSQL, agentTokenID)
 	var item FindAgentPoolByAgentTokenIDRow
-	if err := row.Scan(&item.AgentPoolID, &item.Name, &item.CreatedAt, &item.OrganizationName, &item.OrganizationScoped, &item.WorkspaceIds, &item.AllowedWorkspaceIds); err != nil {
+	if err := row.Scan(&item.AgentPoolID, &item.Name, &item.CreatedAt, &item.OrganizationName, &item.OrganizationScoped, &item.Labels, &item.WorkspaceIds, &item.AllowedWorkspaceIds); err != nil {
 		return item, fmt.Errorf("query FindAgentPoolByAgentTokenID: %w", err)
 	}
 	return item, nil
@@ -321,7 +328,7 @@ func (q *DBQuerier) FindAgentPoolByAgentTokenIDBatch(batch genericBatch, agentTo
 func (q *DBQuerier) FindAgentPoolByAgentTokenIDScan(results pgx.BatchResults) (FindAgentPoolByAgentTokenIDRow, error) {
 	row := results.QueryRow()
 	var item FindAgentPoolByAgentTokenIDRow
-	if err := row.Scan(&item.AgentPoolID, &item.Name, &item.CreatedAt, &item.OrganizationName, &item.OrganizationScoped, &item.WorkspaceIds, &item.AllowedWorkspaceIds); err != nil {
+	if err := row.Scan(&item.AgentPoolID, &item.Name, &item.CreatedAt, &item.OrganizationName, &item.OrganizationScoped, &item.Labels, &item.WorkspaceIds, &item.AllowedWorkspaceIds); err != nil {
 		return item, fmt.Errorf("scan FindAgentPoolByAgentTokenIDBatch row: %w", err)
 	}
 	return item, nil
@@ -329,13 +336,15 @@ func (q *DBQuerier) FindAgentPoolByAgentTokenIDScan(results pgx.BatchResults) (F
 
 const updateAgentPoolSQL = `UPDATE agent_pools
 SET name = $1,
-    organization_scoped = $2
-WHERE agent_pool_id = $3
+    organization_scoped = $2,
+    labels = $3
+WHERE agent_pool_id = $4
 RETURNING *;`
 
 type UpdateAgentPoolParams struct {
 	Name               pgtype.Text
 	OrganizationScoped pgtype.Bool
+	Labels             []string
 	PoolID             pgtype.Text
 }
 
@@ -345,14 +354,15 @@ type UpdateAgentPoolRow struct {
 	CreatedAt          pgtype.Timestamptz `json:"created_at"`
 	OrganizationName   pgtype.Text        `json:"organization_name"`
 	OrganizationScoped pgtype.Bool        `json:"organization_scoped"`
+	Labels             []string           `json:"labels"`
 }
 
 // UpdateAgentPool implements Querier.UpdateAgentPool.
 func (q *DBQuerier) UpdateAgentPool(ctx context.Context, params UpdateAgentPoolParams) (UpdateAgentPoolRow, error) {
 	ctx = context.WithValue(ctx, "pggen_query_name", "UpdateAgentPool")
-	row := q.conn.QueryRow(ctx, updateAgentPoolSQL, params.Name, params.OrganizationScoped, params.PoolID)
+	row := q.conn.QueryRow(ctx, updateAgentPoolSQL, params.Name, params.OrganizationScoped, params.Labels, params.PoolID)
 	var item UpdateAgentPoolRow
-	if err := row.Scan(&item.AgentPoolID, &item.Name, &item.CreatedAt, &item.OrganizationName, &item.OrganizationScoped); err != nil {
+	if err := row.Scan(&item.AgentPoolID, &item.Name, &item.CreatedAt, &item.OrganizationName, &item.OrganizationScoped, &item.Labels); err != nil {
 		return item, fmt.Errorf("query UpdateAgentPool: %w", err)
 	}
 	return item, nil
@@ -360,14 +370,14 @@ func (q *DBQuerier) UpdateAgentPool(ctx context.Context, params UpdateAgentPoolP
 
 // UpdateAgentPoolBatch implements Querier.UpdateAgentPoolBatch.
 func (q *DBQuerier) UpdateAgentPoolBatch(batch genericBatch, params UpdateAgentPoolParams) {
-	batch.Queue(updateAgentPoolSQL, params.Name, params.OrganizationScoped, params.PoolID)
+	batch.Queue(updateAgentPoolSQL, params.Name, params.OrganizationScoped, params.Labels, params.PoolID)
 }
 
 // UpdateAgentPoolScan implements Querier.UpdateAgentPoolScan.
 func (q *DBQuerier) UpdateAgentPoolScan(results pgx.BatchResults) (UpdateAgentPoolRow, error) {
 	row := results.QueryRow()
 	var item UpdateAgentPoolRow
-	if err := row.Scan(&item.AgentPoolID, &item.Name, &item.CreatedAt, &item.OrganizationName, &item.OrganizationScoped); err != nil {
+	if err := row.Scan(&item.AgentPoolID, &item.Name, &item.CreatedAt, &item.OrganizationName, &item.OrganizationScoped, &item.Labels); err != nil {
 		return item, fmt.Errorf("scan UpdateAgentPoolBatch row: %w", err)
 	}
 	return item, nil
@@ -385,6 +395,7 @@ type DeleteAgentPoolRow struct {
 	CreatedAt          pgtype.Timestamptz `json:"created_at"`
 	OrganizationName   pgtype.Text        `json:"organization_name"`
 	OrganizationScoped pgtype.Bool        `json:"organization_scoped"`
+	Labels             []string           `json:"labels"`
 }
 
 // DeleteAgentPool implements Querier.DeleteAgentPool.
@@ -392,7 +403,7 @@ func (q *DBQuerier) DeleteAgentPool(ctx context.Context, poolID pgtype.Text) (De
 	ctx = context.WithValue(ctx, "pggen_query_name", "DeleteAgentPool")
 	row := q.conn.QueryRow(ctx, deleteAgentPoolSQL, poolID)
 	var item DeleteAgentPoolRow
-	if err := row.Scan(&item.AgentPoolID, &item.Name, &item.CreatedAt, &item.OrganizationName, &item.OrganizationScoped); err != nil {
+	if err := row.Scan(&item.AgentPoolID, &item.Name, &item.CreatedAt, &item.OrganizationName, &item.OrganizationScoped, &item.Labels); err != nil {
 		return item, fmt.Errorf("query DeleteAgentPool: %w", err)
 	}
 	return item, nil
@@ -407,7 +418,7 @@ func (q *DBQuerier) DeleteAgentPoolBatch(batch genericBatch, poolID pgtype.Text)
 func (q *DBQuerier) DeleteAgentPoolScan(results pgx.BatchResults) (DeleteAgentPoolRow, error) {
 	row := results.QueryRow()
 	var item DeleteAgentPoolRow
-	if err := row.Scan(&item.AgentPoolID, &item.Name, &item.CreatedAt, &item.OrganizationName, &item.OrganizationScoped); err != nil {
+	if err := row.Scan(&item.AgentPoolID, &item.Name, &item.CreatedAt, &item.OrganizationName, &item.OrganizationScoped, &item.Labels); err != nil {
 		return item, fmt.Errorf("scan DeleteAgentPoolBatch row: %w", err)
 	}
 	return item, nil
@@ -474,3 +485,118 @@ func (q *DBQuerier) DeleteAgentPoolAllowedWorkspaceScan(results pgx.BatchResults
 	}
 	return cmdTag, err
 }
+
+const insertWorkspacePlacementConstraintSQL = `INSERT INTO workspace_placement_constraints (
+    workspace_id,
+    label
+) VALUES (
+    $1,
+    $2
+);`
+
+// InsertWorkspacePlacementConstraint implements Querier.InsertWorkspacePlacementConstraint.
+func (q *DBQuerier) InsertWorkspacePlacementConstraint(ctx context.Context, workspaceID pgtype.Text, label pgtype.Text) (pgconn.CommandTag, error) {
+	ctx = context.WithValue(ctx, "pggen_query_name", "InsertWorkspacePlacementConstraint")
+	cmdTag, err := q.conn.Exec(ctx, insertWorkspacePlacementConstraintSQL, workspaceID, label)
+	if err != nil {
+		return cmdTag, fmt.Errorf("exec query InsertWorkspacePlacementConstraint: %w", err)
+	}
+	return cmdTag, err
+}
+
+// InsertWorkspacePlacementConstraintBatch implements Querier.InsertWorkspacePlacementConstraintBatch.
+func (q *DBQuerier) InsertWorkspacePlacementConstraintBatch(batch genericBatch, workspaceID pgtype.Text, label pgtype.Text) {
+	batch.Queue(insertWorkspacePlacementConstraintSQL, workspaceID, label)
+}
+
+// InsertWorkspacePlacementConstraintScan implements Querier.InsertWorkspacePlacementConstraintScan.
+func (q *DBQuerier) InsertWorkspacePlacementConstraintScan(results pgx.BatchResults) (pgconn.CommandTag, error) {
+	cmdTag, err := results.Exec()
+	if err != nil {
+		return cmdTag, fmt.Errorf("exec InsertWorkspacePlacementConstraintBatch: %w", err)
+	}
+	return cmdTag, err
+}
+
+const findWorkspacePlacementConstraintsSQL = `SELECT label
+FROM workspace_placement_constraints
+WHERE workspace_id = $1
+ORDER BY label
+;`
+
+// FindWorkspacePlacementConstraints implements Querier.FindWorkspacePlacementConstraints.
+func (q *DBQuerier) FindWorkspacePlacementConstraints(ctx context.Context, workspaceID pgtype.Text) ([]pgtype.Text, error) {
+	ctx = context.WithValue(ctx, "pggen_query_name", "FindWorkspacePlacementConstraints")
+	rows, err := q.conn.Query(ctx, findWorkspacePlacementConstraintsSQL, workspaceID)
+	if err != nil {
+		return nil, fmt.Errorf("query FindWorkspacePlacementConstraints: %w", err)
+	}
+	defer rows.Close()
+	items := []pgtype.Text{}
+	for rows.Next() {
+		var item pgtype.Text
+		if err := rows.Scan(&item); err != nil {
+			return nil, fmt.Errorf("scan FindWorkspacePlacementConstraints row: %w", err)
+		}
+		items = append(items, item)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("close FindWorkspacePlacementConstraints rows: %w", err)
+	}
+	return items, err
+}
+
+// FindWorkspacePlacementConstraintsBatch implements Querier.FindWorkspacePlacementConstraintsBatch.
+func (q *DBQuerier) FindWorkspacePlacementConstraintsBatch(batch genericBatch, workspaceID pgtype.Text) {
+	batch.Queue(findWorkspacePlacementConstraintsSQL, workspaceID)
+}
+
+// FindWorkspacePlacementConstraintsScan implements Querier.FindWorkspacePlacementConstraintsScan.
+func (q *DBQuerier) FindWorkspacePlacementConstraintsScan(results pgx.BatchResults) ([]pgtype.Text, error) {
+	rows, err := results.Query()
+	if err != nil {
+		return nil, fmt.Errorf("query FindWorkspacePlacementConstraintsBatch: %w", err)
+	}
+	defer rows.Close()
+	items := []pgtype.Text{}
+	for rows.Next() {
+		var item pgtype.Text
+		if err := rows.Scan(&item); err != nil {
+			return nil, fmt.Errorf("scan FindWorkspacePlacementConstraintsBatch row: %w", err)
+		}
+		items = append(items, item)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("close FindWorkspacePlacementConstraintsBatch rows: %w", err)
+	}
+	return items, err
+}
+
+const deleteWorkspacePlacementConstraintsSQL = `DELETE
+FROM workspace_placement_constraints
+WHERE workspace_id = $1
+;`
+
+// DeleteWorkspacePlacementConstraints implements Querier.DeleteWorkspacePlacementConstraints.
+func (q *DBQuerier) DeleteWorkspacePlacementConstraints(ctx context.Context, workspaceID pgtype.Text) (pgconn.CommandTag, error) {
+	ctx = context.WithValue(ctx, "pggen_query_name", "DeleteWorkspacePlacementConstraints")
+	cmdTag, err := q.conn.Exec(ctx, deleteWorkspacePlacementConstraintsSQL, workspaceID)
+	if err != nil {
+		return cmdTag, fmt.Errorf("exec query DeleteWorkspacePlacementConstraints: %w", err)
+	}
+	return cmdTag, err
+}
+
+// DeleteWorkspacePlacementConstraintsBatch implements Querier.DeleteWorkspacePlacementConstraintsBatch.
+func (q *DBQuerier) DeleteWorkspacePlacementConstraintsBatch(batch genericBatch, workspaceID pgtype.Text) {
+	batch.Queue(deleteWorkspacePlacementConstraintsSQL, workspaceID)
+}
+
+// DeleteWorkspacePlacementConstraintsScan implements Querier.DeleteWorkspacePlacementConstraintsScan.
+func (q *DBQuerier) DeleteWorkspacePlacementConstraintsScan(results pgx.BatchResults) (pgconn.CommandTag, error) {
+	cmdTag, err := results.Exec()
+	if err != nil {
+		return cmdTag, fmt.Errorf("exec DeleteWorkspacePlacementConstraintsBatch: %w", err)
+	}
+	return cmdTag, err
+}
@@ -120,6 +120,7 @@ const findConfigurationVersionsByWorkspaceIDSQL = `SELECT
     configuration_versions.speculative,
     configuration_versions.status,
     configuration_versions.workspace_id,
+    configuration_versions.config_digest,
     (
         SELECT array_agg(t.*) AS configuration_version_status_timestamps
         FROM configuration_version_status_timestamps t
@@ -148,6 +149,7 @@ type FindConfigurationVersionsByWorkspaceIDRow struct {
 	Speculative                          pgtype.Bool                            `json:"speculative"`
 	Status                               pgtype.Text                            `json:"status"`
 	WorkspaceID                          pgtype.Text                            `json:"workspace_id"`
+	ConfigDigest                         pgtype.Text                            `json:"config_digest"`
 	ConfigurationVersionStatusTimestamps []ConfigurationVersionStatusTimestamps `json:"configuration_version_status_timestamps"`
 	IngressAttributes                    *IngressAttributes                     `json:"ingress_attributes"`
 }
@@ -165,7 +167,7 @@ func (q *DBQuerier) FindConfigurationVersionsByWorkspaceID(ctx context.Context,
 	ingressAttributesRow := q.types.newIngressAttributes()
 	for rows.Next() {
 		var item FindConfigurationVersionsByWorkspaceIDRow
-		if err := rows.Scan(&item.ConfigurationVersionID, &item.CreatedAt, &item.AutoQueueRuns, &item.Source, &item.Speculative, &item.Status, &item.WorkspaceID, configurationVersionStatusTimestampsArray, ingressAttributesRow); err != nil {
+		if err := rows.Scan(&item.ConfigurationVersionID, &item.CreatedAt, &item.AutoQueueRuns, &item.Source, &item.Speculative, &item.Status, &item.WorkspaceID, &item.ConfigDigest, configurationVersionStatusTimestampsArray, ingressAttributesRow); err != nil {
 			return nil, fmt.Errorf("scan FindConfigurationVersionsByWorkspaceID row: %w", err)
 		}
 		if err := configurationVersionStatusTimestampsArray.AssignTo(&item.ConfigurationVersionStatusTimestamps); err != nil {
@@ -199,7 +201,7 @@ func (q *DBQuerier) FindConfigurationVersionsByWorkspaceIDScan(results pgx.Batch
 	ingressAttributesRow := q.types.newIngressAttributes()
 	for rows.Next() {
 		var item FindConfigurationVersionsByWorkspaceIDRow
-		if err := rows.Scan(&item.ConfigurationVersionID, &item.CreatedAt, &item.AutoQueueRuns, &item.Source, &item.Speculative, &item.Status, &item.WorkspaceID, configurationVersionStatusTimestampsArray, ingressAttributesRow); err != nil {
+		if err := rows.Scan(&item.ConfigurationVersionID, &item.CreatedAt, &item.AutoQueueRuns, &item.Source, &item.Speculative, &item.Status, &item.WorkspaceID, &item.ConfigDigest, configurationVersionStatusTimestampsArray, ingressAttributesRow); err != nil {
 			return nil, fmt.Errorf("scan FindConfigurationVersionsByWorkspaceIDBatch row: %w", err)
 		}
 		if err := configurationVersionStatusTimestampsArray.AssignTo(&item.ConfigurationVersionStatusTimestamps); err != nil {
@@ -255,6 +257,7 @@ const findConfigurationVersionByIDSQL = `SELECT
     configuration_versions.speculative,
     configuration_versions.status,
     configuration_versions.workspace_id,
+    configuration_versions.config_digest,
     (
         SELECT array_agg(t.*) AS configuration_version_status_timestamps
         FROM configuration_version_status_timestamps t
@@ -275,6 +278,7 @@ type FindConfigurationVersionByIDRow struct {
 	Speculative                          pgtype.Bool                            `json:"speculative"`
 	Status                               pgtype.Text                            `json:"status"`
 	WorkspaceID                          pgtype.Text                            `json:"workspace_id"`
+	ConfigDigest                         pgtype.Text                            `json:"config_digest"`
 	ConfigurationVersionStatusTimestamps []ConfigurationVersionStatusTimestamps `json:"configuration_version_status_timestamps"`
 	IngressAttributes                    *IngressAttributes                     `json:"ingress_attributes"`
 }
@@ -286,7 +290,7 @@ func (q *DBQuerier) FindConfigurationVersionByID(ctx context.Context, configurat
 	var item FindConfigurationVersionByIDRow
 	configurationVersionStatusTimestampsArray := q.types.newConfigurationVersionStatusTimestampsArray()
 	ingressAttributesRow := q.types.newIngressAttributes()
-	if err := row.Scan(&item.ConfigurationVersionID, &item.CreatedAt, &item.AutoQueueRuns, &item.Source, &item.Speculative, &item.Status, &item.WorkspaceID, configurationVersionStatusTimestampsArray, ingressAttributesRow); err != nil {
+	if err := row.Scan(&item.ConfigurationVersionID, &item.CreatedAt, &item.AutoQueueRuns, &item.Source, &item.Speculative, &item.Status, &item.WorkspaceID, &item.ConfigDigest, configurationVersionStatusTimestampsArray, ingressAttributesRow); err != nil {
 		return item, fmt.Errorf("query FindConfigurationVersionByID: %w", err)
 	}
 	if err := configurationVersionStatusTimestampsArray.AssignTo(&item.ConfigurationVersionStatusTimestamps); err != nil {
@@ -309,7 +313,7 @@ func (q *DBQuerier) FindConfigurationVersionByIDScan(results pgx.BatchResults) (
 	var item FindConfigurationVersionByIDRow
 	configurationVersionStatusTimestampsArray := q.types.newConfigurationVersionStatusTimestampsArray()
 	ingressAttributesRow := q.types.newIngressAttributes()
-	if err := row.Scan(&item.ConfigurationVersionID, &item.CreatedAt, &item.AutoQueueRuns, &item.Source, &item.Speculative, &item.Status, &item.WorkspaceID, configurationVersionStatusTimestampsArray, ingressAttributesRow); err != nil {
+	if err := row.Scan(&item.ConfigurationVersionID, &item.CreatedAt, &item.AutoQueueRuns, &item.Source, &item.Speculative, &item.Status, &item.WorkspaceID, &item.ConfigDigest, configurationVersionStatusTimestampsArray, ingressAttributesRow); err != nil {
 		return item, fmt.Errorf("scan FindConfigurationVersionByIDBatch row: %w", err)
 	}
 	if err := configurationVersionStatusTimestampsArray.AssignTo(&item.ConfigurationVersionStatusTimestamps); err != nil {
@@ -329,6 +333,7 @@ const findConfigurationVersionLatestByWorkspaceIDSQL = `SELECT
     configuration_versions.speculative,
     configuration_versions.status,
     configuration_versions.workspace_id,
+    configuration_versions.config_digest,
     (
         SELECT array_agg(t.*) AS configuration_version_status_timestamps
         FROM configuration_version_status_timestamps t
@@ -350,6 +355,7 @@ type FindConfigurationVersionLatestByWorkspaceIDRow struct {
 	Speculative                          pgtype.Bool                            `json:"speculative"`
 	Status                               pgtype.Text                            `json:"status"`
 	WorkspaceID                          pgtype.Text                            `json:"workspace_id"`
+	ConfigDigest                         pgtype.Text                            `json:"config_digest"`
 	ConfigurationVersionStatusTimestamps []ConfigurationVersionStatusTimestamps `json:"configuration_version_status_timestamps"`
 	IngressAttributes                    *IngressAttributes                     `json:"ingress_attributes"`
 }
@@ -361,7 +367,7 @@ func (q *DBQuerier) FindConfigurationVersionLatestByWorkspaceID(ctx context.Cont
 	var item FindConfigurationVersionLatestByWorkspaceIDRow
 	configurationVersionStatusTimestampsArray := q.types.newConfigurationVersionStatusTimestampsArray()
 	ingressAttributesRow := q.types.newIngressAttributes()
-	if err := row.Scan(&item.ConfigurationVersionID, &item.CreatedAt, &item.AutoQueueRuns, &item.Source, &item.Speculative, &item.Status, &item.WorkspaceID, configurationVersionStatusTimestampsArray, ingressAttributesRow); err != nil {
+	if err := row.Scan(&item.ConfigurationVersionID, &item.CreatedAt, &item.AutoQueueRuns, &item.Source, &item.Speculative, &item.Status, &item.WorkspaceID, &item.ConfigDigest, configurationVersionStatusTimestampsArray, ingressAttributesRow); err != nil {
 		return item, fmt.Errorf("query FindConfigurationVersionLatestByWorkspaceID: %w", err)
 	}
 	if err := configurationVersionStatusTimestampsArray.AssignTo(&item.ConfigurationVersionStatusTimestamps); err != nil {
@@ -384,7 +390,7 @@ func (q *DBQuerier) FindConfigurationVersionLatestByWorkspaceIDScan(results pgx.
 	var item FindConfigurationVersionLatestByWorkspaceIDRow
 	configurationVersionStatusTimestampsArray := q.types.newConfigurationVersionStatusTimestampsArray()
 	ingressAttributesRow := q.types.newIngressAttributes()
-	if err := row.Scan(&item.ConfigurationVersionID, &item.CreatedAt, &item.AutoQueueRuns, &item.Source, &item.Speculative, &item.Status, &item.WorkspaceID, configurationVersionStatusTimestampsArray, ingressAttributesRow); err != nil {
+	if err := row.Scan(&item.ConfigurationVersionID, &item.CreatedAt, &item.AutoQueueRuns, &item.Source, &item.Speculative, &item.Status, &item.WorkspaceID, &item.ConfigDigest, configurationVersionStatusTimestampsArray, ingressAttributesRow); err != nil {
 		return item, fmt.Errorf("scan FindConfigurationVersionLatestByWorkspaceIDBatch row: %w", err)
 	}
 	if err := configurationVersionStatusTimestampsArray.AssignTo(&item.ConfigurationVersionStatusTimestamps); err != nil {
@@ -404,6 +410,7 @@ const findConfigurationVersionByIDForUpdateSQL = `SELECT
     configuration_versions.speculative,
     configuration_versions.status,
     configuration_versions.workspace_id,
+    configuration_versions.config_digest,
     (
         SELECT array_agg(t.*) AS configuration_version_status_timestamps
         FROM configuration_version_status_timestamps t
@@ -425,6 +432,7 @@ type FindConfigurationVersionByIDForUpdateRow struct {
 	Speculative                          pgtype.Bool                            `json:"speculative"`
 	Status                               pgtype.Text                            `json:"status"`
 	WorkspaceID                          pgtype.Text                            `json:"workspace_id"`
+	ConfigDigest                         pgtype.Text                            `json:"config_digest"`
 	ConfigurationVersionStatusTimestamps []ConfigurationVersionStatusTimestamps `json:"configuration_version_status_timestamps"`
 	IngressAttributes                    *IngressAttributes                     `json:"ingress_attributes"`
 }
@@ -436,7 +444,7 @@ func (q *DBQuerier) FindConfigurationVersionByIDForUpdate(ctx context.Context, c
 	var item FindConfigurationVersionByIDForUpdateRow
 	configurationVersionStatusTimestampsArray := q.types.newConfigurationVersionStatusTimestampsArray()
 	ingressAttributesRow := q.types.newIngressAttributes()
-	if err := row.Scan(&item.ConfigurationVersionID, &item.CreatedAt, &item.AutoQueueRuns, &item.Source, &item.Speculative, &item.Status, &item.WorkspaceID, configurationVersionStatusTimestampsArray, ingressAttributesRow); err != nil {
+	if err := row.Scan(&item.ConfigurationVersionID, &item.CreatedAt, &item.AutoQueueRuns, &item.Source, &item.Speculative, &item.Status, &item.WorkspaceID, &item.ConfigDigest, configurationVersionStatusTimestampsArray, ingressAttributesRow); err != nil {
 		return item, fmt.Errorf("query FindConfigurationVersionByIDForUpdate: %w", err)
 	}
 	if err := configurationVersionStatusTimestampsArray.AssignTo(&item.ConfigurationVersionStatusTimestamps); err != nil {
@@ -459,7 +467,7 @@ func (q *DBQuerier) FindConfigurationVersionByIDForUpdateScan(results pgx.BatchR
 	var item FindConfigurationVersionByIDForUpdateRow
 	configurationVersionStatusTimestampsArray := q.types.newConfigurationVersionStatusTimestampsArray()
 	ingressAttributesRow := q.types.newIngressAttributes()
-	if err := row.Scan(&item.ConfigurationVersionID, &item.CreatedAt, &item.AutoQueueRuns, &item.Source, &item.Speculative, &item.Status, &item.WorkspaceID, configurationVersionStatusTimestampsArray, ingressAttributesRow); err != nil {
+	if err := row.Scan(&item.ConfigurationVersionID, &item.CreatedAt, &item.AutoQueueRuns, &item.Source, &item.Speculative, &item.Status, &item.WorkspaceID, &item.ConfigDigest, configurationVersionStatusTimestampsArray, ingressAttributesRow); err != nil {
 		return item, fmt.Errorf("scan FindConfigurationVersionByIDForUpdateBatch row: %w", err)
 	}
 	if err := configurationVersionStatusTimestampsArray.AssignTo(&item.ConfigurationVersionStatusTimestamps); err != nil {
@@ -471,16 +479,16 @@ func (q *DBQuerier) FindConfigurationVersionByIDForUpdateScan(results pgx.BatchR
 	return item, nil
 }
 
-const downloadConfigurationVersionSQL = `SELECT config
+const downloadConfigurationVersionSQL = `SELECT config_digest
 FROM configuration_versions
 WHERE configuration_version_id = $1
 AND   status                   = 'uploaded';`
 
 // DownloadConfigurationVersion implements Querier.DownloadConfigurationVersion.
-func (q *DBQuerier) DownloadConfigurationVersion(ctx context.Context, configurationVersionID pgtype.Text) ([]byte, error) {
+func (q *DBQuerier) DownloadConfigurationVersion(ctx context.Context, configurationVersionID pgtype.Text) (pgtype.Text, error) {
 	ctx = context.WithValue(ctx, "pggen_query_name", "DownloadConfigurationVersion")
 	row := q.conn.QueryRow(ctx, downloadConfigurationVersionSQL, configurationVersionID)
-	item := []byte{}
+	var item pgtype.Text
 	if err := row.Scan(&item); err != nil {
 		return item, fmt.Errorf("query DownloadConfigurationVersion: %w", err)
 	}
@@ -493,9 +501,9 @@ func (q *DBQuerier) DownloadConfigurationVersionBatch(batch genericBatch, config
 }
 
 // DownloadConfigurationVersionScan implements Querier.DownloadConfigurationVersionScan.
-func (q *DBQuerier) DownloadConfigurationVersionScan(results pgx.BatchResults) ([]byte, error) {
+func (q *DBQuerier) DownloadConfigurationVersionScan(results pgx.BatchResults) (pgtype.Text, error) {
 	row := results.QueryRow()
-	item := []byte{}
+	var item pgtype.Text
 	if err := row.Scan(&item); err != nil {
 		return item, fmt.Errorf("scan DownloadConfigurationVersionBatch row: %w", err)
 	}
@@ -536,15 +544,15 @@ func (q *DBQuerier) UpdateConfigurationVersionErroredByIDScan(results pgx.BatchR
 
 const updateConfigurationVersionConfigByIDSQL = `UPDATE configuration_versions
 SET
-    config = $1,
+    config_digest = $1,
     status = 'uploaded'
 WHERE configuration_version_id = $2
 RETURNING configuration_version_id;`
 
 // UpdateConfigurationVersionConfigByID implements Querier.UpdateConfigurationVersionConfigByID.
-func (q *DBQuerier) UpdateConfigurationVersionConfigByID(ctx context.Context, config []byte, id pgtype.Text) (pgtype.Text, error) {
+func (q *DBQuerier) UpdateConfigurationVersionConfigByID(ctx context.Context, configDigest pgtype.Text, id pgtype.Text) (pgtype.Text, error) {
 	ctx = context.WithValue(ctx, "pggen_query_name", "UpdateConfigurationVersionConfigByID")
-	row := q.conn.QueryRow(ctx, updateConfigurationVersionConfigByIDSQL, config, id)
+	row := q.conn.QueryRow(ctx, updateConfigurationVersionConfigByIDSQL, configDigest, id)
 	var item pgtype.Text
 	if err := row.Scan(&item); err != nil {
 		return item, fmt.Errorf("query UpdateConfigurationVersionConfigByID: %w", err)
@@ -553,8 +561,8 @@ func (q *DBQuerier) UpdateConfigurationVersionConfigByID(ctx context.Context, co
 }
 
 // UpdateConfigurationVersionConfigByIDBatch implements Querier.UpdateConfigurationVersionConfigByIDBatch.
-func (q *DBQuerier) UpdateConfigurationVersionConfigByIDBatch(batch genericBatch, config []byte, id pgtype.Text) {
-	batch.Queue(updateConfigurationVersionConfigByIDSQL, config, id)
+func (q *DBQuerier) UpdateConfigurationVersionConfigByIDBatch(batch genericBatch, configDigest pgtype.Text, id pgtype.Text) {
+	batch.Queue(updateConfigurationVersionConfigByIDSQL, configDigest, id)
 }
 
 // UpdateConfigurationVersionConfigByIDScan implements Querier.UpdateConfigurationVersionConfigByIDScan.
@@ -597,3 +605,543 @@ func (q *DBQuerier) DeleteConfigurationVersionByIDScan(results pgx.BatchResults)
 	}
 	return item, nil
 }
+
+const findConfigurationVersionUsageByOrganizationSQL = `SELECT
+    w.workspace_id,
+    w.name AS workspace_name,
+    cv.source,
+    count(*) AS count,
+    coalesce(sum(b.size), 0)::bigint AS bytes
+FROM configuration_versions cv
+JOIN workspaces w USING (workspace_id)
+LEFT JOIN blobs b ON b.digest = cv.config_digest
+WHERE w.organization_name = $1
+GROUP BY w.workspace_id, w.name, cv.source
+ORDER BY w.name, cv.source
+;`
+
+type FindConfigurationVersionUsageByOrganizationRow struct {
+	WorkspaceID   pgtype.Text `json:"workspace_id"`
+	WorkspaceName pgtype.Text `json:"workspace_name"`
+	Source        pgtype.Text `json:"source"`
+	Count         pgtype.Int8 `json:"count"`
+	Bytes         pgtype.Int8 `json:"bytes"`
+}
+
+// FindConfigurationVersionUsageByOrganization implements Querier.FindConfigurationVersionUsageByOrganization.
+func (q *DBQuerier) FindConfigurationVersionUsageByOrganization(ctx context.Context, organizationName pgtype.Text) ([]FindConfigurationVersionUsageByOrganizationRow, error) {
+	ctx = context.WithValue(ctx, "pggen_query_name", "FindConfigurationVersionUsageByOrganization")
+	rows, err := q.conn.Query(ctx, findConfigurationVersionUsageByOrganizationSQL, organizationName)
+	if err != nil {
+		return nil, fmt.Errorf("query FindConfigurationVersionUsageByOrganization: %w", err)
+	}
+	defer rows.Close()
+	items := []FindConfigurationVersionUsageByOrganizationRow{}
+	for rows.Next() {
+		var item FindConfigurationVersionUsageByOrganizationRow
+		if err := rows.Scan(&item.WorkspaceID, &item.WorkspaceName, &item.Source, &item.Count, &item.Bytes); err != nil {
+			return nil, fmt.Errorf("scan FindConfigurationVersionUsageByOrganization row: %w", err)
+		}
+		items = append(items, item)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("close FindConfigurationVersionUsageByOrganization rows: %w", err)
+	}
+	return items, err
+}
+
+// FindConfigurationVersionUsageByOrganizationBatch implements Querier.FindConfigurationVersionUsageByOrganizationBatch.
+func (q *DBQuerier) FindConfigurationVersionUsageByOrganizationBatch(batch genericBatch, organizationName pgtype.Text) {
+	batch.Queue(findConfigurationVersionUsageByOrganizationSQL, organizationName)
+}
+
+// FindConfigurationVersionUsageByOrganizationScan implements Querier.FindConfigurationVersionUsageByOrganizationScan.
+func (q *DBQuerier) FindConfigurationVersionUsageByOrganizationScan(results pgx.BatchResults) ([]FindConfigurationVersionUsageByOrganizationRow, error) {
+	rows, err := results.Query()
+	if err != nil {
+		return nil, fmt.Errorf("query FindConfigurationVersionUsageByOrganizationBatch: %w", err)
+	}
+	defer rows.Close()
+	items := []FindConfigurationVersionUsageByOrganizationRow{}
+	for rows.Next() {
+		var item FindConfigurationVersionUsageByOrganizationRow
+		if err := rows.Scan(&item.WorkspaceID, &item.WorkspaceName, &item.Source, &item.Count, &item.Bytes); err != nil {
+			return nil, fmt.Errorf("scan FindConfigurationVersionUsageByOrganizationBatch row: %w", err)
+		}
+		items = append(items, item)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("close FindConfigurationVersionUsageByOrganizationBatch rows: %w", err)
+	}
+	return items, err
+}
+
+const findConfigurationVersionStorageUsedSQL = `SELECT coalesce(sum(b.size), 0)::bigint AS bytes
+FROM configuration_versions cv
+JOIN workspaces w USING (workspace_id)
+JOIN blobs b ON b.digest = cv.config_digest
+WHERE w.organization_name = (
+    SELECT organization_name
+    FROM workspaces
+    WHERE workspace_id = $1
+);`
+
+// FindConfigurationVersionStorageUsed implements Querier.FindConfigurationVersionStorageUsed.
+func (q *DBQuerier) FindConfigurationVersionStorageUsed(ctx context.Context, workspaceID pgtype.Text) (pgtype.Int8, error) {
+	ctx = context.WithValue(ctx, "pggen_query_name", "FindConfigurationVersionStorageUsed")
+	row := q.conn.QueryRow(ctx, findConfigurationVersionStorageUsedSQL, workspaceID)
+	var item pgtype.Int8
+	if err := row.Scan(&item); err != nil {
+		return item, fmt.Errorf("query FindConfigurationVersionStorageUsed: %w", err)
+	}
+	return item, nil
+}
+
+// FindConfigurationVersionStorageUsedBatch implements Querier.FindConfigurationVersionStorageUsedBatch.
+func (q *DBQuerier) FindConfigurationVersionStorageUsedBatch(batch genericBatch, workspaceID pgtype.Text) {
+	batch.Queue(findConfigurationVersionStorageUsedSQL, workspaceID)
+}
+
+// FindConfigurationVersionStorageUsedScan implements Querier.FindConfigurationVersionStorageUsedScan.
+func (q *DBQuerier) FindConfigurationVersionStorageUsedScan(results pgx.BatchResults) (pgtype.Int8, error) {
+	row := results.QueryRow()
+	var item pgtype.Int8
+	if err := row.Scan(&item); err != nil {
+		return item, fmt.Errorf("scan FindConfigurationVersionStorageUsedBatch row: %w", err)
+	}
+	return item, nil
+}
+
+const insertConfigurationVersionDependencySQL = `INSERT INTO configuration_version_dependencies (
+    configuration_version_id,
+    kind,
+    source,
+    version
+) VALUES (
+    $1,
+    $2,
+    $3,
+    $4
+) ON CONFLICT DO NOTHING;`
+
+type InsertConfigurationVersionDependencyParams struct {
+	ConfigurationVersionID pgtype.Text
+	Kind                   pgtype.Text
+	Source                 pgtype.Text
+	Version                pgtype.Text
+}
+
+// InsertConfigurationVersionDependency implements Querier.InsertConfigurationVersionDependency.
+func (q *DBQuerier) InsertConfigurationVersionDependency(ctx context.Context, params InsertConfigurationVersionDependencyParams) (pgconn.CommandTag, error) {
+	ctx = context.WithValue(ctx, "pggen_query_name", "InsertConfigurationVersionDependency")
+	cmdTag, err := q.conn.Exec(ctx, insertConfigurationVersionDependencySQL, params.ConfigurationVersionID, params.Kind, params.Source, params.Version)
+	if err != nil {
+		return cmdTag, fmt.Errorf("exec query InsertConfigurationVersionDependency: %w", err)
+	}
+	return cmdTag, err
+}
+
+// InsertConfigurationVersionDependencyBatch implements Querier.InsertConfigurationVersionDependencyBatch.
+func (q *DBQuerier) InsertConfigurationVersionDependencyBatch(batch genericBatch, params InsertConfigurationVersionDependencyParams) {
+	batch.Queue(insertConfigurationVersionDependencySQL, params.ConfigurationVersionID, params.Kind, params.Source, params.Version)
+}
+
+// InsertConfigurationVersionDependencyScan implements Querier.InsertConfigurationVersionDependencyScan.
+func (q *DBQuerier) InsertConfigurationVersionDependencyScan(results pgx.BatchResults) (pgconn.CommandTag, error) {
+	cmdTag, err := results.Exec()
+	if err != nil {
+		return cmdTag, fmt.Errorf("exec InsertConfigurationVersionDependencyBatch: %w", err)
+	}
+	return cmdTag, err
+}
+
+const findDependencyConsumptionByOrganizationSQL = `WITH latest AS (
+    SELECT DISTINCT ON (cv.workspace_id)
+        cv.configuration_version_id,
+        w.workspace_id,
+        w.name AS workspace_name
+    FROM configuration_versions cv
+    JOIN workspaces w USING (workspace_id)
+    WHERE w.organization_name = $1
+    AND cv.status = 'uploaded'
+    ORDER BY cv.workspace_id, cv.created_at DESC
+)
+SELECT
+    d.kind,
+    d.source,
+    d.version,
+    l.workspace_id,
+    l.workspace_name
+FROM configuration_version_dependencies d
+JOIN latest l USING (configuration_version_id)
+ORDER BY d.kind, d.source, d.version, l.workspace_name
+;`
+
+type FindDependencyConsumptionByOrganizationRow struct {
+	Kind          pgtype.Text `json:"kind"`
+	Source        pgtype.Text `json:"source"`
+	Version       pgtype.Text `json:"version"`
+	WorkspaceID   pgtype.Text `json:"workspace_id"`
+	WorkspaceName pgtype.Text `json:"workspace_name"`
+}
+
+// FindDependencyConsumptionByOrganization implements Querier.FindDependencyConsumptionByOrganization.
+func (q *DBQuerier) FindDependencyConsumptionByOrganization(ctx context.Context, organizationName pgtype.Text) ([]FindDependencyConsumptionByOrganizationRow, error) {
+	ctx = context.WithValue(ctx, "pggen_query_name", "FindDependencyConsumptionByOrganization")
+	rows, err := q.conn.Query(ctx, findDependencyConsumptionByOrganizationSQL, organizationName)
+	if err != nil {
+		return nil, fmt.Errorf("query FindDependencyConsumptionByOrganization: %w", err)
+	}
+	defer rows.Close()
+	items := []FindDependencyConsumptionByOrganizationRow{}
+	for rows.Next() {
+		var item FindDependencyConsumptionByOrganizationRow
+		if err := rows.Scan(&item.Kind, &item.Source, &item.Version, &item.WorkspaceID, &item.WorkspaceName); err != nil {
+			return nil, fmt.Errorf("scan FindDependencyConsumptionByOrganization row: %w", err)
+		}
+		items = append(items, item)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("close FindDependencyConsumptionByOrganization rows: %w", err)
+	}
+	return items, err
+}
+
+// FindDependencyConsumptionByOrganizationBatch implements Querier.FindDependencyConsumptionByOrganizationBatch.
+func (q *DBQuerier) FindDependencyConsumptionByOrganizationBatch(batch genericBatch, organizationName pgtype.Text) {
+	batch.Queue(findDependencyConsumptionByOrganizationSQL, organizationName)
+}
+
+// FindDependencyConsumptionByOrganizationScan implements Querier.FindDependencyConsumptionByOrganizationScan.
+func (q *DBQuerier) FindDependencyConsumptionByOrganizationScan(results pgx.BatchResults) ([]FindDependencyConsumptionByOrganizationRow, error) {
+	rows, err := results.Query()
+	if err != nil {
+		return nil, fmt.Errorf("query FindDependencyConsumptionByOrganizationBatch: %w", err)
+	}
+	defer rows.Close()
+	items := []FindDependencyConsumptionByOrganizationRow{}
+	for rows.Next() {
+		var item FindDependencyConsumptionByOrganizationRow
+		if err := rows.Scan(&item.Kind, &item.Source, &item.Version, &item.WorkspaceID, &item.WorkspaceName); err != nil {
+			return nil, fmt.Errorf("scan FindDependencyConsumptionByOrganizationBatch row: %w", err)
+		}
+		items = append(items, item)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("close FindDependencyConsumptionByOrganizationBatch rows: %w", err)
+	}
+	return items, err
+}
+
+const findConfigurationVersionWorkingDirectorySQL = `SELECT w.working_directory
+FROM configuration_versions cv
+JOIN workspaces w USING (workspace_id)
+WHERE cv.configuration_version_id = $1
+;`
+
+// FindConfigurationVersionWorkingDirectory implements Querier.FindConfigurationVersionWorkingDirectory.
+func (q *DBQuerier) FindConfigurationVersionWorkingDirectory(ctx context.Context, configurationVersionID pgtype.Text) (pgtype.Text, error) {
+	ctx = context.WithValue(ctx, "pggen_query_name", "FindConfigurationVersionWorkingDirectory")
+	row := q.conn.QueryRow(ctx, findConfigurationVersionWorkingDirectorySQL, configurationVersionID)
+	var item pgtype.Text
+	if err := row.Scan(&item); err != nil {
+		return item, fmt.Errorf("query FindConfigurationVersionWorkingDirectory: %w", err)
+	}
+	return item, nil
+}
+
+// FindConfigurationVersionWorkingDirectoryBatch implements Querier.FindConfigurationVersionWorkingDirectoryBatch.
+func (q *DBQuerier) FindConfigurationVersionWorkingDirectoryBatch(batch genericBatch, configurationVersionID pgtype.Text) {
+	batch.Queue(findConfigurationVersionWorkingDirectorySQL, configurationVersionID)
+}
+
+// FindConfigurationVersionWorkingDirectoryScan implements Querier.FindConfigurationVersionWorkingDirectoryScan.
+func (q *DBQuerier) FindConfigurationVersionWorkingDirectoryScan(results pgx.BatchResults) (pgtype.Text, error) {
+	row := results.QueryRow()
+	var item pgtype.Text
+	if err := row.Scan(&item); err != nil {
+		return item, fmt.Errorf("scan FindConfigurationVersionWorkingDirectoryBatch row: %w", err)
+	}
+	return item, nil
+}
+
+const findConfigurationVersionDependenciesSQL = `SELECT *
+FROM configuration_version_dependencies
+WHERE configuration_version_id = $1
+ORDER BY kind, source, version
+;`
+
+type FindConfigurationVersionDependenciesRow struct {
+	ConfigurationVersionID pgtype.Text `json:"configuration_version_id"`
+	Kind                   pgtype.Text `json:"kind"`
+	Source                 pgtype.Text `json:"source"`
+	Version                pgtype.Text `json:"version"`
+}
+
+// FindConfigurationVersionDependencies implements Querier.FindConfigurationVersionDependencies.
+func (q *DBQuerier) FindConfigurationVersionDependencies(ctx context.Context, configurationVersionID pgtype.Text) ([]FindConfigurationVersionDependenciesRow, error) {
+	ctx = context.WithValue(ctx, "pggen_query_name", "FindConfigurationVersionDependencies")
+	rows, err := q.conn.Query(ctx, findConfigurationVersionDependenciesSQL, configurationVersionID)
+	if err != nil {
+		return nil, fmt.Errorf("query FindConfigurationVersionDependencies: %w", err)
+	}
+	defer rows.Close()
+	items := []FindConfigurationVersionDependenciesRow{}
+	for rows.Next() {
+		var item FindConfigurationVersionDependenciesRow
+		if err := rows.Scan(&item.ConfigurationVersionID, &item.Kind, &item.Source, &item.Version); err != nil {
+			return nil, fmt.Errorf("scan FindConfigurationVersionDependencies row: %w", err)
+		}
+		items = append(items, item)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("close FindConfigurationVersionDependencies rows: %w", err)
+	}
+	return items, err
+}
+
+// FindConfigurationVersionDependenciesBatch implements Querier.FindConfigurationVersionDependenciesBatch.
+func (q *DBQuerier) FindConfigurationVersionDependenciesBatch(batch genericBatch, configurationVersionID pgtype.Text) {
+	batch.Queue(findConfigurationVersionDependenciesSQL, configurationVersionID)
+}
+
+// FindConfigurationVersionDependenciesScan implements Querier.FindConfigurationVersionDependenciesScan.
+func (q *DBQuerier) FindConfigurationVersionDependenciesScan(results pgx.BatchResults) ([]FindConfigurationVersionDependenciesRow, error) {
+	rows, err := results.Query()
+	if err != nil {
+		return nil, fmt.Errorf("query FindConfigurationVersionDependenciesBatch: %w", err)
+	}
+	defer rows.Close()
+	items := []FindConfigurationVersionDependenciesRow{}
+	for rows.Next() {
+		var item FindConfigurationVersionDependenciesRow
+		if err := rows.Scan(&item.ConfigurationVersionID, &item.Kind, &item.Source, &item.Version); err != nil {
+			return nil, fmt.Errorf("scan FindConfigurationVersionDependenciesBatch row: %w", err)
+		}
+		items = append(items, item)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("close FindConfigurationVersionDependenciesBatch rows: %w", err)
+	}
+	return items, err
+}
+
+const insertConfigurationVersionVariableSQL = `INSERT INTO configuration_version_variables (
+    configuration_version_id,
+    name,
+    type,
+    description,
+    default_value,
+    required,
+    sensitive
+) VALUES (
+    $1,
+    $2,
+    $3,
+    $4,
+    $5,
+    $6,
+    $7
+) ON CONFLICT DO NOTHING;`
+
+type InsertConfigurationVersionVariableParams struct {
+	ConfigurationVersionID pgtype.Text
+	Name                   pgtype.Text
+	Type                   pgtype.Text
+	Description            pgtype.Text
+	DefaultValue           pgtype.Text
+	Required               pgtype.Bool
+	Sensitive              pgtype.Bool
+}
+
+// InsertConfigurationVersionVariable implements Querier.InsertConfigurationVersionVariable.
+func (q *DBQuerier) InsertConfigurationVersionVariable(ctx context.Context, params InsertConfigurationVersionVariableParams) (pgconn.CommandTag, error) {
+	ctx = context.WithValue(ctx, "pggen_query_name", "InsertConfigurationVersionVariable")
+	cmdTag, err := q.conn.Exec(ctx, insertConfigurationVersionVariableSQL, params.ConfigurationVersionID, params.Name, params.Type, params.Description, params.DefaultValue, params.Required, params.Sensitive)
+	if err != nil {
+		return cmdTag, fmt.Errorf("exec query InsertConfigurationVersionVariable: %w", err)
+	}
+	return cmdTag, err
+}
+
+// InsertConfigurationVersionVariableBatch implements Querier.InsertConfigurationVersionVariableBatch.
+func (q *DBQuerier) InsertConfigurationVersionVariableBatch(batch genericBatch, params InsertConfigurationVersionVariableParams) {
+	batch.Queue(insertConfigurationVersionVariableSQL, params.ConfigurationVersionID, params.Name, params.Type, params.Description, params.DefaultValue, params.Required, params.Sensitive)
+}
+
+// InsertConfigurationVersionVariableScan implements Querier.InsertConfigurationVersionVariableScan.
+func (q *DBQuerier) InsertConfigurationVersionVariableScan(results pgx.BatchResults) (pgconn.CommandTag, error) {
+	cmdTag, err := results.Exec()
+	if err != nil {
+		return cmdTag, fmt.Errorf("exec InsertConfigurationVersionVariableBatch: %w", err)
+	}
+	return cmdTag, err
+}
+
+const findConfigurationVersionVariablesSQL = `SELECT *
+FROM configuration_version_variables
+WHERE configuration_version_id = $1
+ORDER BY name
+;`
+
+type FindConfigurationVersionVariablesRow struct {
+	ConfigurationVersionID pgtype.Text `json:"configuration_version_id"`
+	Name                   pgtype.Text `json:"name"`
+	Type                   pgtype.Text `json:"type"`
+	Description            pgtype.Text `json:"description"`
+	DefaultValue           pgtype.Text `json:"default_value"`
+	Required               pgtype.Bool `json:"required"`
+	Sensitive              pgtype.Bool `json:"sensitive"`
+}
+
+// FindConfigurationVersionVariables implements Querier.FindConfigurationVersionVariables.
+func (q *DBQuerier) FindConfigurationVersionVariables(ctx context.Context, configurationVersionID pgtype.Text) ([]FindConfigurationVersionVariablesRow, error) {
+	ctx = context.WithValue(ctx, "pggen_query_name", "FindConfigurationVersionVariables")
+	rows, err := q.conn.Query(ctx, findConfigurationVersionVariablesSQL, configurationVersionID)
+	if err != nil {
+		return nil, fmt.Errorf("query FindConfigurationVersionVariables: %w", err)
+	}
+	defer rows.Close()
+	items := []FindConfigurationVersionVariablesRow{}
+	for rows.Next() {
+		var item FindConfigurationVersionVariablesRow
+		if err := rows.Scan(&item.ConfigurationVersionID, &item.Name, &item.Type, &item.Description, &item.DefaultValue, &item.Required, &item.Sensitive); err != nil {
+			return nil, fmt.Errorf("scan FindConfigurationVersionVariables row: %w", err)
+		}
+		items = append(items, item)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("close FindConfigurationVersionVariables rows: %w", err)
+	}
+	return items, err
+}
+
+// FindConfigurationVersionVariablesBatch implements Querier.FindConfigurationVersionVariablesBatch.
+func (q *DBQuerier) FindConfigurationVersionVariablesBatch(batch genericBatch, configurationVersionID pgtype.Text) {
+	batch.Queue(findConfigurationVersionVariablesSQL, configurationVersionID)
+}
+
+// FindConfigurationVersionVariablesScan implements Querier.FindConfigurationVersionVariablesScan.
+func (q *DBQuerier) FindConfigurationVersionVariablesScan(results pgx.BatchResults) ([]FindConfigurationVersionVariablesRow, error) {
+	rows, err := results.Query()
+	if err != nil {
+		return nil, fmt.Errorf("query FindConfigurationVersionVariablesBatch: %w", err)
+	}
+	defer rows.Close()
+	items := []FindConfigurationVersionVariablesRow{}
+	for rows.Next() {
+		var item FindConfigurationVersionVariablesRow
+		if err := rows.Scan(&item.ConfigurationVersionID, &item.Name, &item.Type, &item.Description, &item.DefaultValue, &item.Required, &item.Sensitive); err != nil {
+			return nil, fmt.Errorf("scan FindConfigurationVersionVariablesBatch row: %w", err)
+		}
+		items = append(items, item)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("close FindConfigurationVersionVariablesBatch rows: %w", err)
+	}
+	return items, err
+}
+
+const insertConfigurationVersionOutputSQL = `INSERT INTO configuration_version_outputs (
+    configuration_version_id,
+    name,
+    description,
+    sensitive
+) VALUES (
+    $1,
+    $2,
+    $3,
+    $4
+) ON CONFLICT DO NOTHING;`
+
+type InsertConfigurationVersionOutputParams struct {
+	ConfigurationVersionID pgtype.Text
+	Name                   pgtype.Text
+	Description            pgtype.Text
+	Sensitive              pgtype.Bool
+}
+
+// InsertConfigurationVersionOutput implements Querier.InsertConfigurationVersionOutput.
+func (q *DBQuerier) InsertConfigurationVersionOutput(ctx context.Context, params InsertConfigurationVersionOutputParams) (pgconn.CommandTag, error) {
+	ctx = context.WithValue(ctx, "pggen_query_name", "InsertConfigurationVersionOutput")
+	cmdTag, err := q.conn.Exec(ctx, insertConfigurationVersionOutputSQL, params.ConfigurationVersionID, params.Name, params.Description, params.Sensitive)
+	if err != nil {
+		return cmdTag, fmt.Errorf("exec query InsertConfigurationVersionOutput: %w", err)
+	}
+	return cmdTag, err
+}
+
+// InsertConfigurationVersionOutputBatch implements Querier.InsertConfigurationVersionOutputBatch.
+func (q *DBQuerier) InsertConfigurationVersionOutputBatch(batch genericBatch, params InsertConfigurationVersionOutputParams) {
+	batch.Queue(insertConfigurationVersionOutputSQL, params.ConfigurationVersionID, params.Name, params.Description, params.Sensitive)
+}
+
+// InsertConfigurationVersionOutputScan implements Querier.InsertConfigurationVersionOutputScan.
+func (q *DBQuerier) InsertConfigurationVersionOutputScan(results pgx.BatchResults) (pgconn.CommandTag, error) {
+	cmdTag, err := results.Exec()
+	if err != nil {
+		return cmdTag, fmt.Errorf("exec InsertConfigurationVersionOutputBatch: %w", err)
+	}
+	return cmdTag, err
+}
+
+const findConfigurationVersionOutputsSQL = `SELECT *
+FROM configuration_version_outputs
+WHERE configuration_version_id = $1
+ORDER BY name
+;`
+
+type FindConfigurationVersionOutputsRow struct {
+	ConfigurationVersionID pgtype.Text `json:"configuration_version_id"`
+	Name                   pgtype.Text `json:"name"`
+	Description            pgtype.Text `json:"description"`
+	Sensitive              pgtype.Bool `json:"sensitive"`
+}
+
+// FindConfigurationVersionOutputs implements Querier.FindConfigurationVersionOutputs.
+func (q *DBQuerier) FindConfigurationVersionOutputs(ctx context.Context, configurationVersionID pgtype.Text) ([]FindConfigurationVersionOutputsRow, error) {
+	ctx = context.WithValue(ctx, "pggen_query_name", "FindConfigurationVersionOutputs")
+	rows, err := q.conn.Query(ctx, findConfigurationVersionOutputsSQL, configurationVersionID)
+	if err != nil {
+		return nil, fmt.Errorf("query FindConfigurationVersionOutputs: %w", err)
+	}
+	defer rows.Close()
+	items := []FindConfigurationVersionOutputsRow{}
+	for rows.Next() {
+		var item FindConfigurationVersionOutputsRow
+		if err := rows.Scan(&item.ConfigurationVersionID, &item.Name, &item.Description, &item.Sensitive); err != nil {
+			return nil, fmt.Errorf("scan FindConfigurationVersionOutputs row: %w", err)
+		}
+		items = append(items, item)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("close FindConfigurationVersionOutputs rows: %w", err)
+	}
+	return items, err
+}
+
+// FindConfigurationVersionOutputsBatch implements Querier.FindConfigurationVersionOutputsBatch.
+func (q *DBQuerier) FindConfigurationVersionOutputsBatch(batch genericBatch, configurationVersionID pgtype.Text) {
+	batch.Queue(findConfigurationVersionOutputsSQL, configurationVersionID)
+}
+
+// FindConfigurationVersionOutputsScan implements Querier.FindConfigurationVersionOutputsScan.
+func (q *DBQuerier) FindConfigurationVersionOutputsScan(results pgx.BatchResults) ([]FindConfigurationVersionOutputsRow, error) {
+	rows, err := results.Query()
+	if err != nil {
+		return nil, fmt.Errorf("query FindConfigurationVersionOutputsBatch: %w", err)
+	}
+	defer rows.Close()
+	items := []FindConfigurationVersionOutputsRow{}
+	for rows.Next() {
+		var item FindConfigurationVersionOutputsRow
+		if err := rows.Scan(&item.ConfigurationVersionID, &item.Name, &item.Description, &item.Sensitive); err != nil {
+			return nil, fmt.Errorf("scan FindConfigurationVersionOutputsBatch row: %w", err)
+		}
+		items = append(items, item)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("close FindConfigurationVersionOutputsBatch rows: %w", err)
+	}
+	return items, err
+}
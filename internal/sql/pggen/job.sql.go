@@ -305,6 +305,80 @@ func (q *DBQuerier) FindAllocatedJobsScan(results pgx.BatchResults) ([]FindAlloc
 	return items, err
 }
 
+const findRunningJobsSQL = `SELECT
+    j.run_id,
+    j.phase,
+    j.status,
+    j.signaled,
+    j.agent_id,
+    w.agent_pool_id,
+    r.workspace_id,
+    w.organization_name
+FROM jobs j
+JOIN runs r USING (run_id)
+JOIN workspaces w USING (workspace_id)
+WHERE j.agent_id = $1
+AND   j.status = 'running';`
+
+type FindRunningJobsRow struct {
+	RunID            pgtype.Text `json:"run_id"`
+	Phase            pgtype.Text `json:"phase"`
+	Status           pgtype.Text `json:"status"`
+	Signaled         pgtype.Bool `json:"signaled"`
+	AgentID          pgtype.Text `json:"agent_id"`
+	AgentPoolID      pgtype.Text `json:"agent_pool_id"`
+	WorkspaceID      pgtype.Text `json:"workspace_id"`
+	OrganizationName pgtype.Text `json:"organization_name"`
+}
+
+// FindRunningJobs implements Querier.FindRunningJobs.
+func (q *DBQuerier) FindRunningJobs(ctx context.Context, agentID pgtype.Text) ([]FindRunningJobsRow, error) {
+	ctx = context.WithValue(ctx, "pggen_query_name", "FindRunningJobs")
+	rows, err := q.conn.Query(ctx, findRunningJobsSQL, agentID)
+	if err != nil {
+		return nil, fmt.Errorf("query FindRunningJobs: %w", err)
+	}
+	defer rows.Close()
+	items := []FindRunningJobsRow{}
+	for rows.Next() {
+		var item FindRunningJobsRow
+		if err := rows.Scan(&item.RunID, &item.Phase, &item.Status, &item.Signaled, &item.AgentID, &item.AgentPoolID, &item.WorkspaceID, &item.OrganizationName); err != nil {
+			return nil, fmt.Errorf("scan FindRunningJobs row: %w", err)
+		}
+		items = append(items, item)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("close FindRunningJobs rows: %w", err)
+	}
+	return items, err
+}
+
+// FindRunningJobsBatch implements Querier.FindRunningJobsBatch.
+func (q *DBQuerier) FindRunningJobsBatch(batch genericBatch, agentID pgtype.Text) {
+	batch.Queue(findRunningJobsSQL, agentID)
+}
+
+// FindRunningJobsScan implements Querier.FindRunningJobsScan.
+func (q *DBQuerier) FindRunningJobsScan(results pgx.BatchResults) ([]FindRunningJobsRow, error) {
+	rows, err := results.Query()
+	if err != nil {
+		return nil, fmt.Errorf("query FindRunningJobsBatch: %w", err)
+	}
+	defer rows.Close()
+	items := []FindRunningJobsRow{}
+	for rows.Next() {
+		var item FindRunningJobsRow
+		if err := rows.Scan(&item.RunID, &item.Phase, &item.Status, &item.Signaled, &item.AgentID, &item.AgentPoolID, &item.WorkspaceID, &item.OrganizationName); err != nil {
+			return nil, fmt.Errorf("scan FindRunningJobsBatch row: %w", err)
+		}
+		items = append(items, item)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("close FindRunningJobsBatch rows: %w", err)
+	}
+	return items, err
+}
+
 const findAndUpdateSignaledJobsSQL = `UPDATE jobs AS j
 SET signaled = NULL
 FROM runs r, workspaces w
@@ -432,3 +506,32 @@ func (q *DBQuerier) UpdateJobScan(results pgx.BatchResults) (UpdateJobRow, error
 	}
 	return item, nil
 }
+
+const deleteJobSQL = `DELETE
+FROM jobs
+WHERE run_id = $1
+AND   phase = $2;`
+
+// DeleteJob implements Querier.DeleteJob.
+func (q *DBQuerier) DeleteJob(ctx context.Context, runID pgtype.Text, phase pgtype.Text) (pgconn.CommandTag, error) {
+	ctx = context.WithValue(ctx, "pggen_query_name", "DeleteJob")
+	cmdTag, err := q.conn.Exec(ctx, deleteJobSQL, runID, phase)
+	if err != nil {
+		return cmdTag, fmt.Errorf("exec query DeleteJob: %w", err)
+	}
+	return cmdTag, err
+}
+
+// DeleteJobBatch implements Querier.DeleteJobBatch.
+func (q *DBQuerier) DeleteJobBatch(batch genericBatch, runID pgtype.Text, phase pgtype.Text) {
+	batch.Queue(deleteJobSQL, runID, phase)
+}
+
+// DeleteJobScan implements Querier.DeleteJobScan.
+func (q *DBQuerier) DeleteJobScan(results pgx.BatchResults) (pgconn.CommandTag, error) {
+	cmdTag, err := results.Exec()
+	if err != nil {
+		return cmdTag, fmt.Errorf("exec DeleteJobBatch: %w", err)
+	}
+	return cmdTag, err
+}
@@ -733,3 +733,56 @@ func (q *DBQuerier) DeleteModuleVersionByIDScan(results pgx.BatchResults) (pgtyp
 	}
 	return item, nil
 }
+
+const findConnectedModuleIDsSQL = `SELECT module_id
+FROM repo_connections
+WHERE module_id IS NOT NULL
+;`
+
+// FindConnectedModuleIDs implements Querier.FindConnectedModuleIDs.
+func (q *DBQuerier) FindConnectedModuleIDs(ctx context.Context) ([]pgtype.Text, error) {
+	ctx = context.WithValue(ctx, "pggen_query_name", "FindConnectedModuleIDs")
+	rows, err := q.conn.Query(ctx, findConnectedModuleIDsSQL)
+	if err != nil {
+		return nil, fmt.Errorf("query FindConnectedModuleIDs: %w", err)
+	}
+	defer rows.Close()
+	items := []pgtype.Text{}
+	for rows.Next() {
+		var item pgtype.Text
+		if err := rows.Scan(&item); err != nil {
+			return nil, fmt.Errorf("scan FindConnectedModuleIDs row: %w", err)
+		}
+		items = append(items, item)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("close FindConnectedModuleIDs rows: %w", err)
+	}
+	return items, err
+}
+
+// FindConnectedModuleIDsBatch implements Querier.FindConnectedModuleIDsBatch.
+func (q *DBQuerier) FindConnectedModuleIDsBatch(batch genericBatch) {
+	batch.Queue(findConnectedModuleIDsSQL)
+}
+
+// FindConnectedModuleIDsScan implements Querier.FindConnectedModuleIDsScan.
+func (q *DBQuerier) FindConnectedModuleIDsScan(results pgx.BatchResults) ([]pgtype.Text, error) {
+	rows, err := results.Query()
+	if err != nil {
+		return nil, fmt.Errorf("query FindConnectedModuleIDsBatch: %w", err)
+	}
+	defer rows.Close()
+	items := []pgtype.Text{}
+	for rows.Next() {
+		var item pgtype.Text
+		if err := rows.Scan(&item); err != nil {
+			return nil, fmt.Errorf("scan FindConnectedModuleIDsBatch row: %w", err)
+		}
+		items = append(items, item)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("close FindConnectedModuleIDsBatch rows: %w", err)
+	}
+	return items, err
+}
@@ -20,7 +20,8 @@ const insertNotificationConfigurationSQL = `INSERT INTO notification_configurati
     triggers,
     destination_type,
     enabled,
-    workspace_id
+    workspace_id,
+    email_addresses
 ) VALUES (
     $1,
     $2,
@@ -30,7 +31,8 @@ const insertNotificationConfigurationSQL = `INSERT INTO notification_configurati
     $6,
     $7,
     $8,
-    $9
+    $9,
+    $10
 )
 ;`
 
@@ -44,12 +46,13 @@ type InsertNotificationConfigurationParams struct {
 	DestinationType             pgtype.Text
 	Enabled                     pgtype.Bool
 	WorkspaceID                 pgtype.Text
+	EmailAddresses              []string
 }
 
 // InsertNotificationConfiguration implements Querier.InsertNotificationConfiguration.
 func (q *DBQuerier) InsertNotificationConfiguration(ctx context.Context, params InsertNotificationConfigurationParams) (pgconn.CommandTag, error) {
 	ctx = context.WithValue(ctx, "pggen_query_name", "InsertNotificationConfiguration")
-	cmdTag, err := q.conn.Exec(ctx, insertNotificationConfigurationSQL, params.NotificationConfigurationID, params.CreatedAt, params.UpdatedAt, params.Name, params.URL, params.Triggers, params.DestinationType, params.Enabled, params.WorkspaceID)
+	cmdTag, err := q.conn.Exec(ctx, insertNotificationConfigurationSQL, params.NotificationConfigurationID, params.CreatedAt, params.UpdatedAt, params.Name, params.URL, params.Triggers, params.DestinationType, params.Enabled, params.WorkspaceID, params.EmailAddresses)
 	if err != nil {
 		return cmdTag, fmt.Errorf("exec query InsertNotificationConfiguration: %w", err)
 	}
@@ -58,7 +61,7 @@ func (q *DBQuerier) InsertNotificationConfiguration(ctx context.Context, params
 
 // InsertNotificationConfigurationBatch implements Querier.InsertNotificationConfigurationBatch.
 func (q *DBQuerier) InsertNotificationConfigurationBatch(batch genericBatch, params InsertNotificationConfigurationParams) {
-	batch.Queue(insertNotificationConfigurationSQL, params.NotificationConfigurationID, params.CreatedAt, params.UpdatedAt, params.Name, params.URL, params.Triggers, params.DestinationType, params.Enabled, params.WorkspaceID)
+	batch.Queue(insertNotificationConfigurationSQL, params.NotificationConfigurationID, params.CreatedAt, params.UpdatedAt, params.Name, params.URL, params.Triggers, params.DestinationType, params.Enabled, params.WorkspaceID, params.EmailAddresses)
 }
 
 // InsertNotificationConfigurationScan implements Querier.InsertNotificationConfigurationScan.
@@ -85,6 +88,7 @@ type FindNotificationConfigurationsByWorkspaceIDRow struct {
 	DestinationType             pgtype.Text        `json:"destination_type"`
 	WorkspaceID                 pgtype.Text        `json:"workspace_id"`
 	Enabled                     pgtype.Bool        `json:"enabled"`
+	EmailAddresses              []string           `json:"email_addresses"`
 }
 
 // FindNotificationConfigurationsByWorkspaceID implements Querier.FindNotificationConfigurationsByWorkspaceID.
@@ -98,7 +102,7 @@ func (q *DBQuerier) FindNotificationConfigurationsByWorkspaceID(ctx context.Cont
 	items := []FindNotificationConfigurationsByWorkspaceIDRow{}
 	for rows.Next() {
 		var item FindNotificationConfigurationsByWorkspaceIDRow
-		if err := rows.Scan(&item.NotificationConfigurationID, &item.CreatedAt, &item.UpdatedAt, &item.Name, &item.URL, &item.Triggers, &item.DestinationType, &item.WorkspaceID, &item.Enabled); err != nil {
+		if err := rows.Scan(&item.NotificationConfigurationID, &item.CreatedAt, &item.UpdatedAt, &item.Name, &item.URL, &item.Triggers, &item.DestinationType, &item.WorkspaceID, &item.Enabled, &item.EmailAddresses); err != nil {
 			return nil, fmt.Errorf("scan FindNotificationConfigurationsByWorkspaceID row: %w", err)
 		}
 		items = append(items, item)
@@ -124,7 +128,7 @@ func (q *DBQuerier) FindNotificationConfigurationsByWorkspaceIDScan(results pgx.
 	items := []FindNotificationConfigurationsByWorkspaceIDRow{}
 	for rows.Next() {
 		var item FindNotificationConfigurationsByWorkspaceIDRow
-		if err := rows.Scan(&item.NotificationConfigurationID, &item.CreatedAt, &item.UpdatedAt, &item.Name, &item.URL, &item.Triggers, &item.DestinationType, &item.WorkspaceID, &item.Enabled); err != nil {
+		if err := rows.Scan(&item.NotificationConfigurationID, &item.CreatedAt, &item.UpdatedAt, &item.Name, &item.URL, &item.Triggers, &item.DestinationType, &item.WorkspaceID, &item.Enabled, &item.EmailAddresses); err != nil {
 			return nil, fmt.Errorf("scan FindNotificationConfigurationsByWorkspaceIDBatch row: %w", err)
 		}
 		items = append(items, item)
@@ -149,6 +153,7 @@ type FindAllNotificationConfigurationsRow struct {
 	DestinationType             pgtype.Text        `json:"destination_type"`
 	WorkspaceID                 pgtype.Text        `json:"workspace_id"`
 	Enabled                     pgtype.Bool        `json:"enabled"`
+	EmailAddresses              []string           `json:"email_addresses"`
 }
 
 // FindAllNotificationConfigurations implements Querier.FindAllNotificationConfigurations.
@@ -162,7 +167,7 @@ func (q *DBQuerier) FindAllNotificationConfigurations(ctx context.Context) ([]Fi
 	items := []FindAllNotificationConfigurationsRow{}
 	for rows.Next() {
 		var item FindAllNotificationConfigurationsRow
-		if err := rows.Scan(&item.NotificationConfigurationID, &item.CreatedAt, &item.UpdatedAt, &item.Name, &item.URL, &item.Triggers, &item.DestinationType, &item.WorkspaceID, &item.Enabled); err != nil {
+		if err := rows.Scan(&item.NotificationConfigurationID, &item.CreatedAt, &item.UpdatedAt, &item.Name, &item.URL, &item.Triggers, &item.DestinationType, &item.WorkspaceID, &item.Enabled, &item.EmailAddresses); err != nil {
 			return nil, fmt.Errorf("scan FindAllNotificationConfigurations row: %w", err)
 		}
 		items = append(items, item)
@@ -188,7 +193,7 @@ func (q *DBQuerier) FindAllNotificationConfigurationsScan(results pgx.BatchResul
 	items := []FindAllNotificationConfigurationsRow{}
 	for rows.Next() {
 		var item FindAllNotificationConfigurationsRow
-		if err := rows.Scan(&item.NotificationConfigurationID, &item.CreatedAt, &item.UpdatedAt, &item.Name, &item.URL, &item.Triggers, &item.DestinationType, &item.WorkspaceID, &item.Enabled); err != nil {
+		if err := rows.Scan(&item.NotificationConfigurationID, &item.CreatedAt, &item.UpdatedAt, &item.Name, &item.URL, &item.Triggers, &item.DestinationType, &item.WorkspaceID, &item.Enabled, &item.EmailAddresses); err != nil {
 			return nil, fmt.Errorf("scan FindAllNotificationConfigurationsBatch row: %w", err)
 		}
 		items = append(items, item)
@@ -214,6 +219,7 @@ type FindNotificationConfigurationRow struct {
 	DestinationType             pgtype.Text        `json:"destination_type"`
 	WorkspaceID                 pgtype.Text        `json:"workspace_id"`
 	Enabled                     pgtype.Bool        `json:"enabled"`
+	EmailAddresses              []string           `json:"email_addresses"`
 }
 
 // FindNotificationConfiguration implements Querier.FindNotificationConfiguration.
@@ -221,7 +227,7 @@ func (q *DBQuerier) FindNotificationConfiguration(ctx context.Context, notificat
 	ctx = context.WithValue(ctx, "pggen_query_name", "FindNotificationConfiguration")
 	row := q.conn.QueryRow(ctx, findNotificationConfigurationSQL, notificationConfigurationID)
 	var item FindNotificationConfigurationRow
-	if err := row.Scan(&item.NotificationConfigurationID, &item.CreatedAt, &item.UpdatedAt, &item.Name, &item.URL, &item.Triggers, &item.DestinationType, &item.WorkspaceID, &item.Enabled); err != nil {
+	if err := row.Scan(&item.NotificationConfigurationID, &item.CreatedAt, &item.UpdatedAt, &item.Name, &item.URL, &item.Triggers, &item.DestinationType, &item.WorkspaceID, &item.Enabled, &item.EmailAddresses); err != nil {
 		return item, fmt.Errorf("query FindNotificationConfiguration: %w", err)
 	}
 	return item, nil
@@ -236,7 +242,7 @@ func (q *DBQuerier) FindNotificationConfigurationBatch(batch genericBatch, notif
 func (q *DBQuerier) FindNotificationConfigurationScan(results pgx.BatchResults) (FindNotificationConfigurationRow, error) {
 	row := results.QueryRow()
 	var item FindNotificationConfigurationRow
-	if err := row.Scan(&item.NotificationConfigurationID, &item.CreatedAt, &item.UpdatedAt, &item.Name, &item.URL, &item.Triggers, &item.DestinationType, &item.WorkspaceID, &item.Enabled); err != nil {
+	if err := row.Scan(&item.NotificationConfigurationID, &item.CreatedAt, &item.UpdatedAt, &item.Name, &item.URL, &item.Triggers, &item.DestinationType, &item.WorkspaceID, &item.Enabled, &item.EmailAddresses); err != nil {
 		return item, fmt.Errorf("scan FindNotificationConfigurationBatch row: %w", err)
 	}
 	return item, nil
@@ -258,6 +264,7 @@ type FindNotificationConfigurationForUpdateRow struct {
 	DestinationType             pgtype.Text        `json:"destination_type"`
 	WorkspaceID                 pgtype.Text        `json:"workspace_id"`
 	Enabled                     pgtype.Bool        `json:"enabled"`
+	EmailAddresses              []string           `json:"email_addresses"`
 }
 
 // FindNotificationConfigurationForUpdate implements Querier.FindNotificationConfigurationForUpdate.
@@ -265,7 +272,7 @@ func (q *DBQuerier) FindNotificationConfigurationForUpdate(ctx context.Context,
 	ctx = context.WithValue(ctx, "pggen_query_name", "FindNotificationConfigurationForUpdate")
 	row := q.conn.QueryRow(ctx, findNotificationConfigurationForUpdateSQL, notificationConfigurationID)
 	var item FindNotificationConfigurationForUpdateRow
-	if err := row.Scan(&item.NotificationConfigurationID, &item.CreatedAt, &item.UpdatedAt, &item.Name, &item.URL, &item.Triggers, &item.DestinationType, &item.WorkspaceID, &item.Enabled); err != nil {
+	if err := row.Scan(&item.NotificationConfigurationID, &item.CreatedAt, &item.UpdatedAt, &item.Name, &item.URL, &item.Triggers, &item.DestinationType, &item.WorkspaceID, &item.Enabled, &item.EmailAddresses); err != nil {
 		return item, fmt.Errorf("query FindNotificationConfigurationForUpdate: %w", err)
 	}
 	return item, nil
@@ -280,7 +287,7 @@ func (q *DBQuerier) FindNotificationConfigurationForUpdateBatch(batch genericBat
 func (q *DBQuerier) FindNotificationConfigurationForUpdateScan(results pgx.BatchResults) (FindNotificationConfigurationForUpdateRow, error) {
 	row := results.QueryRow()
 	var item FindNotificationConfigurationForUpdateRow
-	if err := row.Scan(&item.NotificationConfigurationID, &item.CreatedAt, &item.UpdatedAt, &item.Name, &item.URL, &item.Triggers, &item.DestinationType, &item.WorkspaceID, &item.Enabled); err != nil {
+	if err := row.Scan(&item.NotificationConfigurationID, &item.CreatedAt, &item.UpdatedAt, &item.Name, &item.URL, &item.Triggers, &item.DestinationType, &item.WorkspaceID, &item.Enabled, &item.EmailAddresses); err != nil {
 		return item, fmt.Errorf("scan FindNotificationConfigurationForUpdateBatch row: %w", err)
 	}
 	return item, nil
@@ -292,8 +299,9 @@ SET
     enabled    = $2,
     name       = $3,
     triggers   = $4,
-    url        = $5
-WHERE notification_configuration_id = $6
+    url        = $5,
+    email_addresses = $6
+WHERE notification_configuration_id = $7
 RETURNING notification_configuration_id
 ;`
 
@@ -303,13 +311,14 @@ type UpdateNotificationConfigurationByIDParams struct {
 	Name                        pgtype.Text
 	Triggers                    []string
 	URL                         pgtype.Text
+	EmailAddresses              []string
 	NotificationConfigurationID pgtype.Text
 }
 
 // UpdateNotificationConfigurationByID implements Querier.UpdateNotificationConfigurationByID.
 func (q *DBQuerier) UpdateNotificationConfigurationByID(ctx context.Context, params UpdateNotificationConfigurationByIDParams) (pgtype.Text, error) {
 	ctx = context.WithValue(ctx, "pggen_query_name", "UpdateNotificationConfigurationByID")
-	row := q.conn.QueryRow(ctx, updateNotificationConfigurationByIDSQL, params.UpdatedAt, params.Enabled, params.Name, params.Triggers, params.URL, params.NotificationConfigurationID)
+	row := q.conn.QueryRow(ctx, updateNotificationConfigurationByIDSQL, params.UpdatedAt, params.Enabled, params.Name, params.Triggers, params.URL, params.EmailAddresses, params.NotificationConfigurationID)
 	var item pgtype.Text
 	if err := row.Scan(&item); err != nil {
 		return item, fmt.Errorf("query UpdateNotificationConfigurationByID: %w", err)
@@ -319,7 +328,7 @@ func (q *DBQuerier) UpdateNotificationConfigurationByID(ctx context.Context, par
 
 // UpdateNotificationConfigurationByIDBatch implements Querier.UpdateNotificationConfigurationByIDBatch.
 func (q *DBQuerier) UpdateNotificationConfigurationByIDBatch(batch genericBatch, params UpdateNotificationConfigurationByIDParams) {
-	batch.Queue(updateNotificationConfigurationByIDSQL, params.UpdatedAt, params.Enabled, params.Name, params.Triggers, params.URL, params.NotificationConfigurationID)
+	batch.Queue(updateNotificationConfigurationByIDSQL, params.UpdatedAt, params.Enabled, params.Name, params.Triggers, params.URL, params.EmailAddresses, params.NotificationConfigurationID)
 }
 
 // UpdateNotificationConfigurationByIDScan implements Querier.UpdateNotificationConfigurationByIDScan.
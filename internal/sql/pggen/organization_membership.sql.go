@@ -6,72 +6,199 @@ import (
 	"context"
 	"fmt"
 
-	"github.com/jackc/pgconn"
 	"github.com/jackc/pgtype"
 	"github.com/jackc/pgx/v4"
 )
 
 const insertOrganizationMembershipSQL = `INSERT INTO organization_memberships (
-    username,
-    organization_name
+    organization_membership_id,
+    created_at,
+    organization_name,
+    username
 ) VALUES (
     $1,
-    $2
+    $2,
+    $3,
+    $4
 )
+ON CONFLICT (organization_name, username) DO UPDATE
+SET username = EXCLUDED.username
+RETURNING *
 ;`
 
+type InsertOrganizationMembershipParams struct {
+	OrganizationMembershipID pgtype.Text
+	CreatedAt                pgtype.Timestamptz
+	OrganizationName         pgtype.Text
+	Username                 pgtype.Text
+}
+
+type InsertOrganizationMembershipRow struct {
+	OrganizationMembershipID pgtype.Text        `json:"organization_membership_id"`
+	CreatedAt                pgtype.Timestamptz `json:"created_at"`
+	OrganizationName         pgtype.Text        `json:"organization_name"`
+	Username                 pgtype.Text        `json:"username"`
+}
+
 // InsertOrganizationMembership implements Querier.InsertOrganizationMembership.
-func (q *DBQuerier) InsertOrganizationMembership(ctx context.Context, username pgtype.Text, organizationName pgtype.Text) (pgconn.CommandTag, error) {
+func (q *DBQuerier) InsertOrganizationMembership(ctx context.Context, params InsertOrganizationMembershipParams) (InsertOrganizationMembershipRow, error) {
 	ctx = context.WithValue(ctx, "pggen_query_name", "InsertOrganizationMembership")
-	cmdTag, err := q.conn.Exec(ctx, insertOrganizationMembershipSQL, username, organizationName)
-	if err != nil {
-		return cmdTag, fmt.Errorf("exec query InsertOrganizationMembership: %w", err)
+	row := q.conn.QueryRow(ctx, insertOrganizationMembershipSQL, params.OrganizationMembershipID, params.CreatedAt, params.OrganizationName, params.Username)
+	var item InsertOrganizationMembershipRow
+	if err := row.Scan(&item.OrganizationMembershipID, &item.CreatedAt, &item.OrganizationName, &item.Username); err != nil {
+		return item, fmt.Errorf("query InsertOrganizationMembership: %w", err)
 	}
-	return cmdTag, err
+	return item, nil
 }
 
 // InsertOrganizationMembershipBatch implements Querier.InsertOrganizationMembershipBatch.
-func (q *DBQuerier) InsertOrganizationMembershipBatch(batch genericBatch, username pgtype.Text, organizationName pgtype.Text) {
-	batch.Queue(insertOrganizationMembershipSQL, username, organizationName)
+func (q *DBQuerier) InsertOrganizationMembershipBatch(batch genericBatch, params InsertOrganizationMembershipParams) {
+	batch.Queue(insertOrganizationMembershipSQL, params.OrganizationMembershipID, params.CreatedAt, params.OrganizationName, params.Username)
 }
 
 // InsertOrganizationMembershipScan implements Querier.InsertOrganizationMembershipScan.
-func (q *DBQuerier) InsertOrganizationMembershipScan(results pgx.BatchResults) (pgconn.CommandTag, error) {
-	cmdTag, err := results.Exec()
+func (q *DBQuerier) InsertOrganizationMembershipScan(results pgx.BatchResults) (InsertOrganizationMembershipRow, error) {
+	row := results.QueryRow()
+	var item InsertOrganizationMembershipRow
+	if err := row.Scan(&item.OrganizationMembershipID, &item.CreatedAt, &item.OrganizationName, &item.Username); err != nil {
+		return item, fmt.Errorf("scan InsertOrganizationMembershipBatch row: %w", err)
+	}
+	return item, nil
+}
+
+const findOrganizationMembershipByIDSQL = `SELECT *
+FROM organization_memberships
+WHERE organization_membership_id = $1
+;`
+
+type FindOrganizationMembershipByIDRow struct {
+	OrganizationMembershipID pgtype.Text        `json:"organization_membership_id"`
+	CreatedAt                pgtype.Timestamptz `json:"created_at"`
+	OrganizationName         pgtype.Text        `json:"organization_name"`
+	Username                 pgtype.Text        `json:"username"`
+}
+
+// FindOrganizationMembershipByID implements Querier.FindOrganizationMembershipByID.
+func (q *DBQuerier) FindOrganizationMembershipByID(ctx context.Context, organizationMembershipID pgtype.Text) (FindOrganizationMembershipByIDRow, error) {
+	ctx = context.WithValue(ctx, "pggen_query_name", "FindOrganizationMembershipByID")
+	row := q.conn.QueryRow(ctx, findOrganizationMembershipByIDSQL, organizationMembershipID)
+	var item FindOrganizationMembershipByIDRow
+	if err := row.Scan(&item.OrganizationMembershipID, &item.CreatedAt, &item.OrganizationName, &item.Username); err != nil {
+		return item, fmt.Errorf("query FindOrganizationMembershipByID: %w", err)
+	}
+	return item, nil
+}
+
+// FindOrganizationMembershipByIDBatch implements Querier.FindOrganizationMembershipByIDBatch.
+func (q *DBQuerier) FindOrganizationMembershipByIDBatch(batch genericBatch, organizationMembershipID pgtype.Text) {
+	batch.Queue(findOrganizationMembershipByIDSQL, organizationMembershipID)
+}
+
+// FindOrganizationMembershipByIDScan implements Querier.FindOrganizationMembershipByIDScan.
+func (q *DBQuerier) FindOrganizationMembershipByIDScan(results pgx.BatchResults) (FindOrganizationMembershipByIDRow, error) {
+	row := results.QueryRow()
+	var item FindOrganizationMembershipByIDRow
+	if err := row.Scan(&item.OrganizationMembershipID, &item.CreatedAt, &item.OrganizationName, &item.Username); err != nil {
+		return item, fmt.Errorf("scan FindOrganizationMembershipByIDBatch row: %w", err)
+	}
+	return item, nil
+}
+
+const findOrganizationMembershipsByOrganizationSQL = `SELECT *
+FROM organization_memberships
+WHERE organization_name = $1
+ORDER BY created_at ASC
+;`
+
+type FindOrganizationMembershipsByOrganizationRow struct {
+	OrganizationMembershipID pgtype.Text        `json:"organization_membership_id"`
+	CreatedAt                pgtype.Timestamptz `json:"created_at"`
+	OrganizationName         pgtype.Text        `json:"organization_name"`
+	Username                 pgtype.Text        `json:"username"`
+}
+
+// FindOrganizationMembershipsByOrganization implements Querier.FindOrganizationMembershipsByOrganization.
+func (q *DBQuerier) FindOrganizationMembershipsByOrganization(ctx context.Context, organizationName pgtype.Text) ([]FindOrganizationMembershipsByOrganizationRow, error) {
+	ctx = context.WithValue(ctx, "pggen_query_name", "FindOrganizationMembershipsByOrganization")
+	rows, err := q.conn.Query(ctx, findOrganizationMembershipsByOrganizationSQL, organizationName)
+	if err != nil {
+		return nil, fmt.Errorf("query FindOrganizationMembershipsByOrganization: %w", err)
+	}
+	defer rows.Close()
+	items := []FindOrganizationMembershipsByOrganizationRow{}
+	for rows.Next() {
+		var item FindOrganizationMembershipsByOrganizationRow
+		if err := rows.Scan(&item.OrganizationMembershipID, &item.CreatedAt, &item.OrganizationName, &item.Username); err != nil {
+			return nil, fmt.Errorf("scan FindOrganizationMembershipsByOrganization row: %w", err)
+		}
+		items = append(items, item)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("close FindOrganizationMembershipsByOrganization rows: %w", err)
+	}
+	return items, err
+}
+
+// FindOrganizationMembershipsByOrganizationBatch implements Querier.FindOrganizationMembershipsByOrganizationBatch.
+func (q *DBQuerier) FindOrganizationMembershipsByOrganizationBatch(batch genericBatch, organizationName pgtype.Text) {
+	batch.Queue(findOrganizationMembershipsByOrganizationSQL, organizationName)
+}
+
+// FindOrganizationMembershipsByOrganizationScan implements Querier.FindOrganizationMembershipsByOrganizationScan.
+func (q *DBQuerier) FindOrganizationMembershipsByOrganizationScan(results pgx.BatchResults) ([]FindOrganizationMembershipsByOrganizationRow, error) {
+	rows, err := results.Query()
 	if err != nil {
-		return cmdTag, fmt.Errorf("exec InsertOrganizationMembershipBatch: %w", err)
+		return nil, fmt.Errorf("query FindOrganizationMembershipsByOrganizationBatch: %w", err)
+	}
+	defer rows.Close()
+	items := []FindOrganizationMembershipsByOrganizationRow{}
+	for rows.Next() {
+		var item FindOrganizationMembershipsByOrganizationRow
+		if err := rows.Scan(&item.OrganizationMembershipID, &item.CreatedAt, &item.OrganizationName, &item.Username); err != nil {
+			return nil, fmt.Errorf("scan FindOrganizationMembershipsByOrganizationBatch row: %w", err)
+		}
+		items = append(items, item)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("close FindOrganizationMembershipsByOrganizationBatch rows: %w", err)
 	}
-	return cmdTag, err
+	return items, err
 }
 
 const deleteOrganizationMembershipSQL = `DELETE
 FROM organization_memberships
-WHERE username          = $1
-AND   organization_name = $2
-RETURNING username
+WHERE organization_membership_id = $1
+RETURNING *
 ;`
 
+type DeleteOrganizationMembershipRow struct {
+	OrganizationMembershipID pgtype.Text        `json:"organization_membership_id"`
+	CreatedAt                pgtype.Timestamptz `json:"created_at"`
+	OrganizationName         pgtype.Text        `json:"organization_name"`
+	Username                 pgtype.Text        `json:"username"`
+}
+
 // DeleteOrganizationMembership implements Querier.DeleteOrganizationMembership.
-func (q *DBQuerier) DeleteOrganizationMembership(ctx context.Context, username pgtype.Text, organizationName pgtype.Text) (pgtype.Text, error) {
+func (q *DBQuerier) DeleteOrganizationMembership(ctx context.Context, organizationMembershipID pgtype.Text) (DeleteOrganizationMembershipRow, error) {
 	ctx = context.WithValue(ctx, "pggen_query_name", "DeleteOrganizationMembership")
-	row := q.conn.QueryRow(ctx, deleteOrganizationMembershipSQL, username, organizationName)
-	var item pgtype.Text
-	if err := row.Scan(&item); err != nil {
+	row := q.conn.QueryRow(ctx, deleteOrganizationMembershipSQL, organizationMembershipID)
+	var item DeleteOrganizationMembershipRow
+	if err := row.Scan(&item.OrganizationMembershipID, &item.CreatedAt, &item.OrganizationName, &item.Username); err != nil {
 		return item, fmt.Errorf("query DeleteOrganizationMembership: %w", err)
 	}
 	return item, nil
 }
 
 // DeleteOrganizationMembershipBatch implements Querier.DeleteOrganizationMembershipBatch.
-func (q *DBQuerier) DeleteOrganizationMembershipBatch(batch genericBatch, username pgtype.Text, organizationName pgtype.Text) {
-	batch.Queue(deleteOrganizationMembershipSQL, username, organizationName)
+func (q *DBQuerier) DeleteOrganizationMembershipBatch(batch genericBatch, organizationMembershipID pgtype.Text) {
+	batch.Queue(deleteOrganizationMembershipSQL, organizationMembershipID)
 }
 
 // DeleteOrganizationMembershipScan implements Querier.DeleteOrganizationMembershipScan.
-func (q *DBQuerier) DeleteOrganizationMembershipScan(results pgx.BatchResults) (pgtype.Text, error) {
+func (q *DBQuerier) DeleteOrganizationMembershipScan(results pgx.BatchResults) (DeleteOrganizationMembershipRow, error) {
 	row := results.QueryRow()
-	var item pgtype.Text
-	if err := row.Scan(&item); err != nil {
+	var item DeleteOrganizationMembershipRow
+	if err := row.Scan(&item.OrganizationMembershipID, &item.CreatedAt, &item.OrganizationName, &item.Username); err != nil {
 		return item, fmt.Errorf("scan DeleteOrganizationMembershipBatch row: %w", err)
 	}
 	return item, nil
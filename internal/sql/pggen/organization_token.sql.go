@@ -15,28 +15,33 @@ const upsertOrganizationTokenSQL = `INSERT INTO organization_tokens (
     organization_token_id,
     created_at,
     organization_name,
-    expiry
+    expiry,
+    created_by
 ) VALUES (
     $1,
     $2,
     $3,
-    $4
-) ON CONFLICT (organization_name) DO UPDATE
+    $4,
+    $5
+) ON CONFLICT (organization_name) WHERE description = '' DO UPDATE
   SET created_at            = $2,
       organization_token_id = $1,
-      expiry                = $4;`
+      expiry                = $4,
+      created_by            = $5,
+      last_used_at          = NULL;`
 
 type UpsertOrganizationTokenParams struct {
 	OrganizationTokenID pgtype.Text
 	CreatedAt           pgtype.Timestamptz
 	OrganizationName    pgtype.Text
 	Expiry              pgtype.Timestamptz
+	CreatedBy           pgtype.Text
 }
 
 // UpsertOrganizationToken implements Querier.UpsertOrganizationToken.
 func (q *DBQuerier) UpsertOrganizationToken(ctx context.Context, params UpsertOrganizationTokenParams) (pgconn.CommandTag, error) {
 	ctx = context.WithValue(ctx, "pggen_query_name", "UpsertOrganizationToken")
-	cmdTag, err := q.conn.Exec(ctx, upsertOrganizationTokenSQL, params.OrganizationTokenID, params.CreatedAt, params.OrganizationName, params.Expiry)
+	cmdTag, err := q.conn.Exec(ctx, upsertOrganizationTokenSQL, params.OrganizationTokenID, params.CreatedAt, params.OrganizationName, params.Expiry, params.CreatedBy)
 	if err != nil {
 		return cmdTag, fmt.Errorf("exec query UpsertOrganizationToken: %w", err)
 	}
@@ -45,7 +50,7 @@ func (q *DBQuerier) UpsertOrganizationToken(ctx context.Context, params UpsertOr
 
 // UpsertOrganizationTokenBatch implements Querier.UpsertOrganizationTokenBatch.
 func (q *DBQuerier) UpsertOrganizationTokenBatch(batch genericBatch, params UpsertOrganizationTokenParams) {
-	batch.Queue(upsertOrganizationTokenSQL, params.OrganizationTokenID, params.CreatedAt, params.OrganizationName, params.Expiry)
+	batch.Queue(upsertOrganizationTokenSQL, params.OrganizationTokenID, params.CreatedAt, params.OrganizationName, params.Expiry, params.CreatedBy)
 }
 
 // UpsertOrganizationTokenScan implements Querier.UpsertOrganizationTokenScan.
@@ -57,15 +62,68 @@ func (q *DBQuerier) UpsertOrganizationTokenScan(results pgx.BatchResults) (pgcon
 	return cmdTag, err
 }
 
+const insertOrganizationTokenSQL = `INSERT INTO organization_tokens (
+    organization_token_id,
+    created_at,
+    organization_name,
+    expiry,
+    description,
+    created_by
+) VALUES (
+    $1,
+    $2,
+    $3,
+    $4,
+    $5,
+    $6
+);`
+
+type InsertOrganizationTokenParams struct {
+	OrganizationTokenID pgtype.Text
+	CreatedAt           pgtype.Timestamptz
+	OrganizationName    pgtype.Text
+	Expiry              pgtype.Timestamptz
+	Description         pgtype.Text
+	CreatedBy           pgtype.Text
+}
+
+// InsertOrganizationToken implements Querier.InsertOrganizationToken.
+func (q *DBQuerier) InsertOrganizationToken(ctx context.Context, params InsertOrganizationTokenParams) (pgconn.CommandTag, error) {
+	ctx = context.WithValue(ctx, "pggen_query_name", "InsertOrganizationToken")
+	cmdTag, err := q.conn.Exec(ctx, insertOrganizationTokenSQL, params.OrganizationTokenID, params.CreatedAt, params.OrganizationName, params.Expiry, params.Description, params.CreatedBy)
+	if err != nil {
+		return cmdTag, fmt.Errorf("exec query InsertOrganizationToken: %w", err)
+	}
+	return cmdTag, err
+}
+
+// InsertOrganizationTokenBatch implements Querier.InsertOrganizationTokenBatch.
+func (q *DBQuerier) InsertOrganizationTokenBatch(batch genericBatch, params InsertOrganizationTokenParams) {
+	batch.Queue(insertOrganizationTokenSQL, params.OrganizationTokenID, params.CreatedAt, params.OrganizationName, params.Expiry, params.Description, params.CreatedBy)
+}
+
+// InsertOrganizationTokenScan implements Querier.InsertOrganizationTokenScan.
+func (q *DBQuerier) InsertOrganizationTokenScan(results pgx.BatchResults) (pgconn.CommandTag, error) {
+	cmdTag, err := results.Exec()
+	if err != nil {
+		return cmdTag, fmt.Errorf("exec InsertOrganizationTokenBatch: %w", err)
+	}
+	return cmdTag, err
+}
+
 const findOrganizationTokensSQL = `SELECT *
 FROM organization_tokens
-WHERE organization_name = $1;`
+WHERE organization_name = $1
+ORDER BY created_at;`
 
 type FindOrganizationTokensRow struct {
 	OrganizationTokenID pgtype.Text        `json:"organization_token_id"`
 	CreatedAt           pgtype.Timestamptz `json:"created_at"`
 	OrganizationName    pgtype.Text        `json:"organization_name"`
 	Expiry              pgtype.Timestamptz `json:"expiry"`
+	Description         pgtype.Text        `json:"description"`
+	CreatedBy           pgtype.Text        `json:"created_by"`
+	LastUsedAt          pgtype.Timestamptz `json:"last_used_at"`
 }
 
 // FindOrganizationTokens implements Querier.FindOrganizationTokens.
@@ -79,7 +137,7 @@ func (q *DBQuerier) FindOrganizationTokens(ctx context.Context, organizationName
 	items := []FindOrganizationTokensRow{}
 	for rows.Next() {
 		var item FindOrganizationTokensRow
-		if err := rows.Scan(&item.OrganizationTokenID, &item.CreatedAt, &item.OrganizationName, &item.Expiry); err != nil {
+		if err := rows.Scan(&item.OrganizationTokenID, &item.CreatedAt, &item.OrganizationName, &item.Expiry, &item.Description, &item.CreatedBy, &item.LastUsedAt); err != nil {
 			return nil, fmt.Errorf("scan FindOrganizationTokens row: %w", err)
 		}
 		items = append(items, item)
@@ -105,7 +163,7 @@ func (q *DBQuerier) FindOrganizationTokensScan(results pgx.BatchResults) ([]Find
 	items := []FindOrganizationTokensRow{}
 	for rows.Next() {
 		var item FindOrganizationTokensRow
-		if err := rows.Scan(&item.OrganizationTokenID, &item.CreatedAt, &item.OrganizationName, &item.Expiry); err != nil {
+		if err := rows.Scan(&item.OrganizationTokenID, &item.CreatedAt, &item.OrganizationName, &item.Expiry, &item.Description, &item.CreatedBy, &item.LastUsedAt); err != nil {
 			return nil, fmt.Errorf("scan FindOrganizationTokensBatch row: %w", err)
 		}
 		items = append(items, item)
@@ -118,13 +176,17 @@ func (q *DBQuerier) FindOrganizationTokensScan(results pgx.BatchResults) ([]Find
 
 const findOrganizationTokensByNameSQL = `SELECT *
 FROM organization_tokens
-WHERE organization_name = $1;`
+WHERE organization_name = $1
+AND   description = '';`
 
 type FindOrganizationTokensByNameRow struct {
 	OrganizationTokenID pgtype.Text        `json:"organization_token_id"`
 	CreatedAt           pgtype.Timestamptz `json:"created_at"`
 	OrganizationName    pgtype.Text        `json:"organization_name"`
 	Expiry              pgtype.Timestamptz `json:"expiry"`
+	Description         pgtype.Text        `json:"description"`
+	CreatedBy           pgtype.Text        `json:"created_by"`
+	LastUsedAt          pgtype.Timestamptz `json:"last_used_at"`
 }
 
 // FindOrganizationTokensByName implements Querier.FindOrganizationTokensByName.
@@ -132,7 +194,7 @@ func (q *DBQuerier) FindOrganizationTokensByName(ctx context.Context, organizati
 	ctx = context.WithValue(ctx, "pggen_query_name", "FindOrganizationTokensByName")
 	row := q.conn.QueryRow(ctx, findOrganizationTokensByNameSQL, organizationName)
 	var item FindOrganizationTokensByNameRow
-	if err := row.Scan(&item.OrganizationTokenID, &item.CreatedAt, &item.OrganizationName, &item.Expiry); err != nil {
+	if err := row.Scan(&item.OrganizationTokenID, &item.CreatedAt, &item.OrganizationName, &item.Expiry, &item.Description, &item.CreatedBy, &item.LastUsedAt); err != nil {
 		return item, fmt.Errorf("query FindOrganizationTokensByName: %w", err)
 	}
 	return item, nil
@@ -147,7 +209,7 @@ func (q *DBQuerier) FindOrganizationTokensByNameBatch(batch genericBatch, organi
 func (q *DBQuerier) FindOrganizationTokensByNameScan(results pgx.BatchResults) (FindOrganizationTokensByNameRow, error) {
 	row := results.QueryRow()
 	var item FindOrganizationTokensByNameRow
-	if err := row.Scan(&item.OrganizationTokenID, &item.CreatedAt, &item.OrganizationName, &item.Expiry); err != nil {
+	if err := row.Scan(&item.OrganizationTokenID, &item.CreatedAt, &item.OrganizationName, &item.Expiry, &item.Description, &item.CreatedBy, &item.LastUsedAt); err != nil {
 		return item, fmt.Errorf("scan FindOrganizationTokensByNameBatch row: %w", err)
 	}
 	return item, nil
@@ -162,6 +224,9 @@ type FindOrganizationTokensByIDRow struct {
 	CreatedAt           pgtype.Timestamptz `json:"created_at"`
 	OrganizationName    pgtype.Text        `json:"organization_name"`
 	Expiry              pgtype.Timestamptz `json:"expiry"`
+	Description         pgtype.Text        `json:"description"`
+	CreatedBy           pgtype.Text        `json:"created_by"`
+	LastUsedAt          pgtype.Timestamptz `json:"last_used_at"`
 }
 
 // FindOrganizationTokensByID implements Querier.FindOrganizationTokensByID.
@@ -169,7 +234,7 @@ func (q *DBQuerier) FindOrganizationTokensByID(ctx context.Context, organization
 	ctx = context.WithValue(ctx, "pggen_query_name", "FindOrganizationTokensByID")
 	row := q.conn.QueryRow(ctx, findOrganizationTokensByIDSQL, organizationTokenID)
 	var item FindOrganizationTokensByIDRow
-	if err := row.Scan(&item.OrganizationTokenID, &item.CreatedAt, &item.OrganizationName, &item.Expiry); err != nil {
+	if err := row.Scan(&item.OrganizationTokenID, &item.CreatedAt, &item.OrganizationName, &item.Expiry, &item.Description, &item.CreatedBy, &item.LastUsedAt); err != nil {
 		return item, fmt.Errorf("query FindOrganizationTokensByID: %w", err)
 	}
 	return item, nil
@@ -184,15 +249,44 @@ func (q *DBQuerier) FindOrganizationTokensByIDBatch(batch genericBatch, organiza
 func (q *DBQuerier) FindOrganizationTokensByIDScan(results pgx.BatchResults) (FindOrganizationTokensByIDRow, error) {
 	row := results.QueryRow()
 	var item FindOrganizationTokensByIDRow
-	if err := row.Scan(&item.OrganizationTokenID, &item.CreatedAt, &item.OrganizationName, &item.Expiry); err != nil {
+	if err := row.Scan(&item.OrganizationTokenID, &item.CreatedAt, &item.OrganizationName, &item.Expiry, &item.Description, &item.CreatedBy, &item.LastUsedAt); err != nil {
 		return item, fmt.Errorf("scan FindOrganizationTokensByIDBatch row: %w", err)
 	}
 	return item, nil
 }
 
+const updateOrganizationTokenLastUsedAtSQL = `UPDATE organization_tokens
+SET last_used_at = $1
+WHERE organization_token_id = $2;`
+
+// UpdateOrganizationTokenLastUsedAt implements Querier.UpdateOrganizationTokenLastUsedAt.
+func (q *DBQuerier) UpdateOrganizationTokenLastUsedAt(ctx context.Context, lastUsedAt pgtype.Timestamptz, organizationTokenID pgtype.Text) (pgconn.CommandTag, error) {
+	ctx = context.WithValue(ctx, "pggen_query_name", "UpdateOrganizationTokenLastUsedAt")
+	cmdTag, err := q.conn.Exec(ctx, updateOrganizationTokenLastUsedAtSQL, lastUsedAt, organizationTokenID)
+	if err != nil {
+		return cmdTag, fmt.Errorf("exec query UpdateOrganizationTokenLastUsedAt: %w", err)
+	}
+	return cmdTag, err
+}
+
+// UpdateOrganizationTokenLastUsedAtBatch implements Querier.UpdateOrganizationTokenLastUsedAtBatch.
+func (q *DBQuerier) UpdateOrganizationTokenLastUsedAtBatch(batch genericBatch, lastUsedAt pgtype.Timestamptz, organizationTokenID pgtype.Text) {
+	batch.Queue(updateOrganizationTokenLastUsedAtSQL, lastUsedAt, organizationTokenID)
+}
+
+// UpdateOrganizationTokenLastUsedAtScan implements Querier.UpdateOrganizationTokenLastUsedAtScan.
+func (q *DBQuerier) UpdateOrganizationTokenLastUsedAtScan(results pgx.BatchResults) (pgconn.CommandTag, error) {
+	cmdTag, err := results.Exec()
+	if err != nil {
+		return cmdTag, fmt.Errorf("exec UpdateOrganizationTokenLastUsedAtBatch: %w", err)
+	}
+	return cmdTag, err
+}
+
 const deleteOrganiationTokenByNameSQL = `DELETE
 FROM organization_tokens
 WHERE organization_name = $1
+AND   description = ''
 RETURNING organization_token_id;`
 
 // DeleteOrganiationTokenByName implements Querier.DeleteOrganiationTokenByName.
@@ -220,3 +314,34 @@ func (q *DBQuerier) DeleteOrganiationTokenByNameScan(results pgx.BatchResults) (
 	}
 	return item, nil
 }
+
+const deleteOrganizationTokenByIDSQL = `DELETE
+FROM organization_tokens
+WHERE organization_token_id = $1
+RETURNING organization_token_id;`
+
+// DeleteOrganizationTokenByID implements Querier.DeleteOrganizationTokenByID.
+func (q *DBQuerier) DeleteOrganizationTokenByID(ctx context.Context, organizationTokenID pgtype.Text) (pgtype.Text, error) {
+	ctx = context.WithValue(ctx, "pggen_query_name", "DeleteOrganizationTokenByID")
+	row := q.conn.QueryRow(ctx, deleteOrganizationTokenByIDSQL, organizationTokenID)
+	var item pgtype.Text
+	if err := row.Scan(&item); err != nil {
+		return item, fmt.Errorf("query DeleteOrganizationTokenByID: %w", err)
+	}
+	return item, nil
+}
+
+// DeleteOrganizationTokenByIDBatch implements Querier.DeleteOrganizationTokenByIDBatch.
+func (q *DBQuerier) DeleteOrganizationTokenByIDBatch(batch genericBatch, organizationTokenID pgtype.Text) {
+	batch.Queue(deleteOrganizationTokenByIDSQL, organizationTokenID)
+}
+
+// DeleteOrganizationTokenByIDScan implements Querier.DeleteOrganizationTokenByIDScan.
+func (q *DBQuerier) DeleteOrganizationTokenByIDScan(results pgx.BatchResults) (pgtype.Text, error) {
+	row := results.QueryRow()
+	var item pgtype.Text
+	if err := row.Scan(&item); err != nil {
+		return item, fmt.Errorf("scan DeleteOrganizationTokenByIDBatch row: %w", err)
+	}
+	return item, nil
+}
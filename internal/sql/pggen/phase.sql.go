@@ -182,3 +182,106 @@ func (q *DBQuerier) FindLogChunkByIDScan(results pgx.BatchResults) (FindLogChunk
 	}
 	return item, nil
 }
+
+const deleteLogsSQL = `DELETE
+FROM logs
+WHERE run_id = $1
+AND   phase  = $2
+;`
+
+// DeleteLogs implements Querier.DeleteLogs.
+func (q *DBQuerier) DeleteLogs(ctx context.Context, runID pgtype.Text, phase pgtype.Text) (pgconn.CommandTag, error) {
+	ctx = context.WithValue(ctx, "pggen_query_name", "DeleteLogs")
+	cmdTag, err := q.conn.Exec(ctx, deleteLogsSQL, runID, phase)
+	if err != nil {
+		return cmdTag, fmt.Errorf("exec query DeleteLogs: %w", err)
+	}
+	return cmdTag, err
+}
+
+// DeleteLogsBatch implements Querier.DeleteLogsBatch.
+func (q *DBQuerier) DeleteLogsBatch(batch genericBatch, runID pgtype.Text, phase pgtype.Text) {
+	batch.Queue(deleteLogsSQL, runID, phase)
+}
+
+// DeleteLogsScan implements Querier.DeleteLogsScan.
+func (q *DBQuerier) DeleteLogsScan(results pgx.BatchResults) (pgconn.CommandTag, error) {
+	cmdTag, err := results.Exec()
+	if err != nil {
+		return cmdTag, fmt.Errorf("exec DeleteLogsBatch: %w", err)
+	}
+	return cmdTag, err
+}
+
+const findRecentRunLogsSQL = `WITH recent AS (
+    SELECT run_id, created_at
+    FROM runs
+    WHERE workspace_id = $1
+    ORDER BY created_at DESC
+    LIMIT $2
+)
+SELECT
+    r.run_id,
+    r.created_at,
+    l.phase,
+    string_agg(l.chunk, '' ORDER BY l.chunk_id) AS logs
+FROM recent r
+JOIN logs l USING (run_id)
+GROUP BY r.run_id, r.created_at, l.phase
+ORDER BY r.created_at DESC, l.phase DESC
+;`
+
+type FindRecentRunLogsRow struct {
+	RunID     pgtype.Text        `json:"run_id"`
+	CreatedAt pgtype.Timestamptz `json:"created_at"`
+	Phase     pgtype.Text        `json:"phase"`
+	Logs      []byte             `json:"logs"`
+}
+
+// FindRecentRunLogs implements Querier.FindRecentRunLogs.
+func (q *DBQuerier) FindRecentRunLogs(ctx context.Context, workspaceID pgtype.Text, limit pgtype.Int4) ([]FindRecentRunLogsRow, error) {
+	ctx = context.WithValue(ctx, "pggen_query_name", "FindRecentRunLogs")
+	rows, err := q.conn.Query(ctx, findRecentRunLogsSQL, workspaceID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("query FindRecentRunLogs: %w", err)
+	}
+	defer rows.Close()
+	items := []FindRecentRunLogsRow{}
+	for rows.Next() {
+		var item FindRecentRunLogsRow
+		if err := rows.Scan(&item.RunID, &item.CreatedAt, &item.Phase, &item.Logs); err != nil {
+			return nil, fmt.Errorf("scan FindRecentRunLogs row: %w", err)
+		}
+		items = append(items, item)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("close FindRecentRunLogs rows: %w", err)
+	}
+	return items, err
+}
+
+// FindRecentRunLogsBatch implements Querier.FindRecentRunLogsBatch.
+func (q *DBQuerier) FindRecentRunLogsBatch(batch genericBatch, workspaceID pgtype.Text, limit pgtype.Int4) {
+	batch.Queue(findRecentRunLogsSQL, workspaceID, limit)
+}
+
+// FindRecentRunLogsScan implements Querier.FindRecentRunLogsScan.
+func (q *DBQuerier) FindRecentRunLogsScan(results pgx.BatchResults) ([]FindRecentRunLogsRow, error) {
+	rows, err := results.Query()
+	if err != nil {
+		return nil, fmt.Errorf("query FindRecentRunLogsBatch: %w", err)
+	}
+	defer rows.Close()
+	items := []FindRecentRunLogsRow{}
+	for rows.Next() {
+		var item FindRecentRunLogsRow
+		if err := rows.Scan(&item.RunID, &item.CreatedAt, &item.Phase, &item.Logs); err != nil {
+			return nil, fmt.Errorf("scan FindRecentRunLogsBatch row: %w", err)
+		}
+		items = append(items, item)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("close FindRecentRunLogsBatch rows: %w", err)
+	}
+	return items, err
+}
@@ -16,13 +16,15 @@ const insertRepohookSQL = `WITH inserted AS (
         vcs_id,
         vcs_provider_id,
         secret,
-        repo_path
+        repo_path,
+        group_hook
     ) VALUES (
         $1,
         $2,
         $3,
         $4,
-        $5
+        $5,
+        $6
     )
     RETURNING *
 )
@@ -32,6 +34,7 @@ SELECT
     w.vcs_provider_id,
     w.secret,
     w.repo_path,
+    w.group_hook,
     v.vcs_kind
 FROM inserted w
 JOIN vcs_providers v USING (vcs_provider_id);`
@@ -42,6 +45,7 @@ type InsertRepohookParams struct {
 	VCSProviderID pgtype.Text
 	Secret        pgtype.Text
 	RepoPath      pgtype.Text
+	GroupHook     pgtype.Bool
 }
 
 type InsertRepohookRow struct {
@@ -50,15 +54,16 @@ type InsertRepohookRow struct {
 	VCSProviderID pgtype.Text `json:"vcs_provider_id"`
 	Secret        pgtype.Text `json:"secret"`
 	RepoPath      pgtype.Text `json:"repo_path"`
+	GroupHook     pgtype.Bool `json:"group_hook"`
 	VCSKind       pgtype.Text `json:"vcs_kind"`
 }
 
 // InsertRepohook implements Querier.InsertRepohook.
 func (q *DBQuerier) InsertRepohook(ctx context.Context, params InsertRepohookParams) (InsertRepohookRow, error) {
 	ctx = context.WithValue(ctx, "pggen_query_name", "InsertRepohook")
-	row := q.conn.QueryRow(ctx, insertRepohookSQL, params.RepohookID, params.VCSID, params.VCSProviderID, params.Secret, params.RepoPath)
+	row := q.conn.QueryRow(ctx, insertRepohookSQL, params.RepohookID, params.VCSID, params.VCSProviderID, params.Secret, params.RepoPath, params.GroupHook)
 	var item InsertRepohookRow
-	if err := row.Scan(&item.RepohookID, &item.VCSID, &item.VCSProviderID, &item.Secret, &item.RepoPath, &item.VCSKind); err != nil {
+	if err := row.Scan(&item.RepohookID, &item.VCSID, &item.VCSProviderID, &item.Secret, &item.RepoPath, &item.GroupHook, &item.VCSKind); err != nil {
 		return item, fmt.Errorf("query InsertRepohook: %w", err)
 	}
 	return item, nil
@@ -66,14 +71,14 @@ func (q *DBQuerier) InsertRepohook(ctx context.Context, params InsertRepohookPar
 
 // InsertRepohookBatch implements Querier.InsertRepohookBatch.
 func (q *DBQuerier) InsertRepohookBatch(batch genericBatch, params InsertRepohookParams) {
-	batch.Queue(insertRepohookSQL, params.RepohookID, params.VCSID, params.VCSProviderID, params.Secret, params.RepoPath)
+	batch.Queue(insertRepohookSQL, params.RepohookID, params.VCSID, params.VCSProviderID, params.Secret, params.RepoPath, params.GroupHook)
 }
 
 // InsertRepohookScan implements Querier.InsertRepohookScan.
 func (q *DBQuerier) InsertRepohookScan(results pgx.BatchResults) (InsertRepohookRow, error) {
 	row := results.QueryRow()
 	var item InsertRepohookRow
-	if err := row.Scan(&item.RepohookID, &item.VCSID, &item.VCSProviderID, &item.Secret, &item.RepoPath, &item.VCSKind); err != nil {
+	if err := row.Scan(&item.RepohookID, &item.VCSID, &item.VCSProviderID, &item.Secret, &item.RepoPath, &item.GroupHook, &item.VCSKind); err != nil {
 		return item, fmt.Errorf("scan InsertRepohookBatch row: %w", err)
 	}
 	return item, nil
@@ -90,6 +95,7 @@ type UpdateRepohookVCSIDRow struct {
 	Secret        pgtype.Text `json:"secret"`
 	RepoPath      pgtype.Text `json:"repo_path"`
 	VCSProviderID pgtype.Text `json:"vcs_provider_id"`
+	GroupHook     pgtype.Bool `json:"group_hook"`
 }
 
 // UpdateRepohookVCSID implements Querier.UpdateRepohookVCSID.
@@ -97,7 +103,7 @@ func (q *DBQuerier) UpdateRepohookVCSID(ctx context.Context, vcsID pgtype.Text,
 	ctx = context.WithValue(ctx, "pggen_query_name", "UpdateRepohookVCSID")
 	row := q.conn.QueryRow(ctx, updateRepohookVCSIDSQL, vcsID, repohookID)
 	var item UpdateRepohookVCSIDRow
-	if err := row.Scan(&item.RepohookID, &item.VCSID, &item.Secret, &item.RepoPath, &item.VCSProviderID); err != nil {
+	if err := row.Scan(&item.RepohookID, &item.VCSID, &item.Secret, &item.RepoPath, &item.VCSProviderID, &item.GroupHook); err != nil {
 		return item, fmt.Errorf("query UpdateRepohookVCSID: %w", err)
 	}
 	return item, nil
@@ -112,7 +118,7 @@ func (q *DBQuerier) UpdateRepohookVCSIDBatch(batch genericBatch, vcsID pgtype.Te
 func (q *DBQuerier) UpdateRepohookVCSIDScan(results pgx.BatchResults) (UpdateRepohookVCSIDRow, error) {
 	row := results.QueryRow()
 	var item UpdateRepohookVCSIDRow
-	if err := row.Scan(&item.RepohookID, &item.VCSID, &item.Secret, &item.RepoPath, &item.VCSProviderID); err != nil {
+	if err := row.Scan(&item.RepohookID, &item.VCSID, &item.Secret, &item.RepoPath, &item.VCSProviderID, &item.GroupHook); err != nil {
 		return item, fmt.Errorf("scan UpdateRepohookVCSIDBatch row: %w", err)
 	}
 	return item, nil
@@ -124,6 +130,7 @@ const findRepohooksSQL = `SELECT
     w.vcs_provider_id,
     w.secret,
     w.repo_path,
+    w.group_hook,
     v.vcs_kind
 FROM repohooks w
 JOIN vcs_providers v USING (vcs_provider_id);`
@@ -134,6 +141,7 @@ type FindRepohooksRow struct {
 	VCSProviderID pgtype.Text `json:"vcs_provider_id"`
 	Secret        pgtype.Text `json:"secret"`
 	RepoPath      pgtype.Text `json:"repo_path"`
+	GroupHook     pgtype.Bool `json:"group_hook"`
 	VCSKind       pgtype.Text `json:"vcs_kind"`
 }
 
@@ -148,7 +156,7 @@ func (q *DBQuerier) FindRepohooks(ctx context.Context) ([]FindRepohooksRow, erro
 	items := []FindRepohooksRow{}
 	for rows.Next() {
 		var item FindRepohooksRow
-		if err := rows.Scan(&item.RepohookID, &item.VCSID, &item.VCSProviderID, &item.Secret, &item.RepoPath, &item.VCSKind); err != nil {
+		if err := rows.Scan(&item.RepohookID, &item.VCSID, &item.VCSProviderID, &item.Secret, &item.RepoPath, &item.GroupHook, &item.VCSKind); err != nil {
 			return nil, fmt.Errorf("scan FindRepohooks row: %w", err)
 		}
 		items = append(items, item)
@@ -174,7 +182,7 @@ func (q *DBQuerier) FindRepohooksScan(results pgx.BatchResults) ([]FindRepohooks
 	items := []FindRepohooksRow{}
 	for rows.Next() {
 		var item FindRepohooksRow
-		if err := rows.Scan(&item.RepohookID, &item.VCSID, &item.VCSProviderID, &item.Secret, &item.RepoPath, &item.VCSKind); err != nil {
+		if err := rows.Scan(&item.RepohookID, &item.VCSID, &item.VCSProviderID, &item.Secret, &item.RepoPath, &item.GroupHook, &item.VCSKind); err != nil {
 			return nil, fmt.Errorf("scan FindRepohooksBatch row: %w", err)
 		}
 		items = append(items, item)
@@ -191,6 +199,7 @@ const findRepohookByIDSQL = `SELECT
     w.vcs_provider_id,
     w.secret,
     w.repo_path,
+    w.group_hook,
     v.vcs_kind
 FROM repohooks w
 JOIN vcs_providers v USING (vcs_provider_id)
@@ -202,6 +211,7 @@ type FindRepohookByIDRow struct {
 	VCSProviderID pgtype.Text `json:"vcs_provider_id"`
 	Secret        pgtype.Text `json:"secret"`
 	RepoPath      pgtype.Text `json:"repo_path"`
+	GroupHook     pgtype.Bool `json:"group_hook"`
 	VCSKind       pgtype.Text `json:"vcs_kind"`
 }
 
@@ -210,7 +220,7 @@ func (q *DBQuerier) FindRepohookByID(ctx context.Context, repohookID pgtype.UUID
 	ctx = context.WithValue(ctx, "pggen_query_name", "FindRepohookByID")
 	row := q.conn.QueryRow(ctx, findRepohookByIDSQL, repohookID)
 	var item FindRepohookByIDRow
-	if err := row.Scan(&item.RepohookID, &item.VCSID, &item.VCSProviderID, &item.Secret, &item.RepoPath, &item.VCSKind); err != nil {
+	if err := row.Scan(&item.RepohookID, &item.VCSID, &item.VCSProviderID, &item.Secret, &item.RepoPath, &item.GroupHook, &item.VCSKind); err != nil {
 		return item, fmt.Errorf("query FindRepohookByID: %w", err)
 	}
 	return item, nil
@@ -225,7 +235,7 @@ func (q *DBQuerier) FindRepohookByIDBatch(batch genericBatch, repohookID pgtype.
 func (q *DBQuerier) FindRepohookByIDScan(results pgx.BatchResults) (FindRepohookByIDRow, error) {
 	row := results.QueryRow()
 	var item FindRepohookByIDRow
-	if err := row.Scan(&item.RepohookID, &item.VCSID, &item.VCSProviderID, &item.Secret, &item.RepoPath, &item.VCSKind); err != nil {
+	if err := row.Scan(&item.RepohookID, &item.VCSID, &item.VCSProviderID, &item.Secret, &item.RepoPath, &item.GroupHook, &item.VCSKind); err != nil {
 		return item, fmt.Errorf("scan FindRepohookByIDBatch row: %w", err)
 	}
 	return item, nil
@@ -237,6 +247,7 @@ const findRepohookByRepoAndProviderSQL = `SELECT
     w.vcs_provider_id,
     w.secret,
     w.repo_path,
+    w.group_hook,
     v.vcs_kind
 FROM repohooks w
 JOIN vcs_providers v USING (vcs_provider_id)
@@ -249,6 +260,7 @@ type FindRepohookByRepoAndProviderRow struct {
 	VCSProviderID pgtype.Text `json:"vcs_provider_id"`
 	Secret        pgtype.Text `json:"secret"`
 	RepoPath      pgtype.Text `json:"repo_path"`
+	GroupHook     pgtype.Bool `json:"group_hook"`
 	VCSKind       pgtype.Text `json:"vcs_kind"`
 }
 
@@ -263,7 +275,7 @@ func (q *DBQuerier) FindRepohookByRepoAndProvider(ctx context.Context, repoPath
 	items := []FindRepohookByRepoAndProviderRow{}
 	for rows.Next() {
 		var item FindRepohookByRepoAndProviderRow
-		if err := rows.Scan(&item.RepohookID, &item.VCSID, &item.VCSProviderID, &item.Secret, &item.RepoPath, &item.VCSKind); err != nil {
+		if err := rows.Scan(&item.RepohookID, &item.VCSID, &item.VCSProviderID, &item.Secret, &item.RepoPath, &item.GroupHook, &item.VCSKind); err != nil {
 			return nil, fmt.Errorf("scan FindRepohookByRepoAndProvider row: %w", err)
 		}
 		items = append(items, item)
@@ -289,7 +301,7 @@ func (q *DBQuerier) FindRepohookByRepoAndProviderScan(results pgx.BatchResults)
 	items := []FindRepohookByRepoAndProviderRow{}
 	for rows.Next() {
 		var item FindRepohookByRepoAndProviderRow
-		if err := rows.Scan(&item.RepohookID, &item.VCSID, &item.VCSProviderID, &item.Secret, &item.RepoPath, &item.VCSKind); err != nil {
+		if err := rows.Scan(&item.RepohookID, &item.VCSID, &item.VCSProviderID, &item.Secret, &item.RepoPath, &item.GroupHook, &item.VCSKind); err != nil {
 			return nil, fmt.Errorf("scan FindRepohookByRepoAndProviderBatch row: %w", err)
 		}
 		items = append(items, item)
@@ -306,13 +318,18 @@ const findUnreferencedRepohooksSQL = `SELECT
     w.vcs_provider_id,
     w.secret,
     w.repo_path,
+    w.group_hook,
     v.vcs_kind
 FROM repohooks w
 JOIN vcs_providers v USING (vcs_provider_id)
 WHERE NOT EXISTS (
     SELECT FROM repo_connections rc
     WHERE rc.vcs_provider_id = w.vcs_provider_id
-    AND   rc.repo_path = w.repo_path
+    AND   (
+        rc.repo_path = w.repo_path
+        -- a group hook is referenced by a connection to any repo in the group
+        OR (w.group_hook AND rc.repo_path LIKE w.repo_path || '/%')
+    )
 );`
 
 type FindUnreferencedRepohooksRow struct {
@@ -321,6 +338,7 @@ type FindUnreferencedRepohooksRow struct {
 	VCSProviderID pgtype.Text `json:"vcs_provider_id"`
 	Secret        pgtype.Text `json:"secret"`
 	RepoPath      pgtype.Text `json:"repo_path"`
+	GroupHook     pgtype.Bool `json:"group_hook"`
 	VCSKind       pgtype.Text `json:"vcs_kind"`
 }
 
@@ -335,7 +353,7 @@ func (q *DBQuerier) FindUnreferencedRepohooks(ctx context.Context) ([]FindUnrefe
 	items := []FindUnreferencedRepohooksRow{}
 	for rows.Next() {
 		var item FindUnreferencedRepohooksRow
-		if err := rows.Scan(&item.RepohookID, &item.VCSID, &item.VCSProviderID, &item.Secret, &item.RepoPath, &item.VCSKind); err != nil {
+		if err := rows.Scan(&item.RepohookID, &item.VCSID, &item.VCSProviderID, &item.Secret, &item.RepoPath, &item.GroupHook, &item.VCSKind); err != nil {
 			return nil, fmt.Errorf("scan FindUnreferencedRepohooks row: %w", err)
 		}
 		items = append(items, item)
@@ -361,7 +379,7 @@ func (q *DBQuerier) FindUnreferencedRepohooksScan(results pgx.BatchResults) ([]F
 	items := []FindUnreferencedRepohooksRow{}
 	for rows.Next() {
 		var item FindUnreferencedRepohooksRow
-		if err := rows.Scan(&item.RepohookID, &item.VCSID, &item.VCSProviderID, &item.Secret, &item.RepoPath, &item.VCSKind); err != nil {
+		if err := rows.Scan(&item.RepohookID, &item.VCSID, &item.VCSProviderID, &item.Secret, &item.RepoPath, &item.GroupHook, &item.VCSKind); err != nil {
 			return nil, fmt.Errorf("scan FindUnreferencedRepohooksBatch row: %w", err)
 		}
 		items = append(items, item)
@@ -383,6 +401,7 @@ type DeleteRepohookByIDRow struct {
 	Secret        pgtype.Text `json:"secret"`
 	RepoPath      pgtype.Text `json:"repo_path"`
 	VCSProviderID pgtype.Text `json:"vcs_provider_id"`
+	GroupHook     pgtype.Bool `json:"group_hook"`
 }
 
 // DeleteRepohookByID implements Querier.DeleteRepohookByID.
@@ -390,7 +409,7 @@ func (q *DBQuerier) DeleteRepohookByID(ctx context.Context, repohookID pgtype.UU
 	ctx = context.WithValue(ctx, "pggen_query_name", "DeleteRepohookByID")
 	row := q.conn.QueryRow(ctx, deleteRepohookByIDSQL, repohookID)
 	var item DeleteRepohookByIDRow
-	if err := row.Scan(&item.RepohookID, &item.VCSID, &item.Secret, &item.RepoPath, &item.VCSProviderID); err != nil {
+	if err := row.Scan(&item.RepohookID, &item.VCSID, &item.Secret, &item.RepoPath, &item.VCSProviderID, &item.GroupHook); err != nil {
 		return item, fmt.Errorf("query DeleteRepohookByID: %w", err)
 	}
 	return item, nil
@@ -405,7 +424,7 @@ func (q *DBQuerier) DeleteRepohookByIDBatch(batch genericBatch, repohookID pgtyp
 func (q *DBQuerier) DeleteRepohookByIDScan(results pgx.BatchResults) (DeleteRepohookByIDRow, error) {
 	row := results.QueryRow()
 	var item DeleteRepohookByIDRow
-	if err := row.Scan(&item.RepohookID, &item.VCSID, &item.Secret, &item.RepoPath, &item.VCSProviderID); err != nil {
+	if err := row.Scan(&item.RepohookID, &item.VCSID, &item.Secret, &item.RepoPath, &item.VCSProviderID, &item.GroupHook); err != nil {
 		return item, fmt.Errorf("scan DeleteRepohookByIDBatch row: %w", err)
 	}
 	return item, nil
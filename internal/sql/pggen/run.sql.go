@@ -28,7 +28,8 @@ const insertRunSQL = `INSERT INTO runs (
     workspace_id,
     created_by,
     terraform_version,
-    allow_empty_apply
+    allow_empty_apply,
+    message
 ) VALUES (
     $1,
     $2,
@@ -46,7 +47,8 @@ const insertRunSQL = `INSERT INTO runs (
     $14,
     $15,
     $16,
-    $17
+    $17,
+    $18
 );`
 
 type InsertRunParams struct {
@@ -67,12 +69,13 @@ type InsertRunParams struct {
 	CreatedBy              pgtype.Text
 	TerraformVersion       pgtype.Text
 	AllowEmptyApply        pgtype.Bool
+	Message                pgtype.Text
 }
 
 // InsertRun implements Querier.InsertRun.
 func (q *DBQuerier) InsertRun(ctx context.Context, params InsertRunParams) (pgconn.CommandTag, error) {
 	ctx = context.WithValue(ctx, "pggen_query_name", "InsertRun")
-	cmdTag, err := q.conn.Exec(ctx, insertRunSQL, params.ID, params.CreatedAt, params.IsDestroy, params.PositionInQueue, params.Refresh, params.RefreshOnly, params.Source, params.Status, params.ReplaceAddrs, params.TargetAddrs, params.AutoApply, params.PlanOnly, params.ConfigurationVersionID, params.WorkspaceID, params.CreatedBy, params.TerraformVersion, params.AllowEmptyApply)
+	cmdTag, err := q.conn.Exec(ctx, insertRunSQL, params.ID, params.CreatedAt, params.IsDestroy, params.PositionInQueue, params.Refresh, params.RefreshOnly, params.Source, params.Status, params.ReplaceAddrs, params.TargetAddrs, params.AutoApply, params.PlanOnly, params.ConfigurationVersionID, params.WorkspaceID, params.CreatedBy, params.TerraformVersion, params.AllowEmptyApply, params.Message)
 	if err != nil {
 		return cmdTag, fmt.Errorf("exec query InsertRun: %w", err)
 	}
@@ -81,7 +84,7 @@ func (q *DBQuerier) InsertRun(ctx context.Context, params InsertRunParams) (pgco
 
 // InsertRunBatch implements Querier.InsertRunBatch.
 func (q *DBQuerier) InsertRunBatch(batch genericBatch, params InsertRunParams) {
-	batch.Queue(insertRunSQL, params.ID, params.CreatedAt, params.IsDestroy, params.PositionInQueue, params.Refresh, params.RefreshOnly, params.Source, params.Status, params.ReplaceAddrs, params.TargetAddrs, params.AutoApply, params.PlanOnly, params.ConfigurationVersionID, params.WorkspaceID, params.CreatedBy, params.TerraformVersion, params.AllowEmptyApply)
+	batch.Queue(insertRunSQL, params.ID, params.CreatedAt, params.IsDestroy, params.PositionInQueue, params.Refresh, params.RefreshOnly, params.Source, params.Status, params.ReplaceAddrs, params.TargetAddrs, params.AutoApply, params.PlanOnly, params.ConfigurationVersionID, params.WorkspaceID, params.CreatedBy, params.TerraformVersion, params.AllowEmptyApply, params.Message)
 }
 
 // InsertRunScan implements Querier.InsertRunScan.
@@ -197,6 +200,7 @@ const findRunsSQL = `SELECT
     runs.created_by,
     runs.terraform_version,
     runs.allow_empty_apply,
+    runs.message,
     workspaces.execution_mode AS execution_mode,
     CASE WHEN workspaces.latest_run_id = runs.run_id THEN true
          ELSE false
@@ -286,6 +290,7 @@ type FindRunsRow struct {
 	CreatedBy              pgtype.Text             `json:"created_by"`
 	TerraformVersion       pgtype.Text             `json:"terraform_version"`
 	AllowEmptyApply        pgtype.Bool             `json:"allow_empty_apply"`
+	Message                pgtype.Text             `json:"message"`
 	ExecutionMode          pgtype.Text             `json:"execution_mode"`
 	Latest                 pgtype.Bool             `json:"latest"`
 	OrganizationName       pgtype.Text             `json:"organization_name"`
@@ -316,7 +321,7 @@ func (q *DBQuerier) FindRuns(ctx context.Context, params FindRunsParams) ([]Find
 	runVariablesArray := q.types.newRunVariablesArray()
 	for rows.Next() {
 		var item FindRunsRow
-		if err := rows.Scan(&item.RunID, &item.CreatedAt, &item.CancelSignaledAt, &item.IsDestroy, &item.PositionInQueue, &item.Refresh, &item.RefreshOnly, &item.Source, &item.Status, &item.PlanStatus, &item.ApplyStatus, &item.ReplaceAddrs, &item.TargetAddrs, &item.AutoApply, planResourceReportRow, planOutputReportRow, applyResourceReportRow, &item.ConfigurationVersionID, &item.WorkspaceID, &item.PlanOnly, &item.CreatedBy, &item.TerraformVersion, &item.AllowEmptyApply, &item.ExecutionMode, &item.Latest, &item.OrganizationName, &item.CostEstimationEnabled, ingressAttributesRow, runStatusTimestampsArray, planStatusTimestampsArray, applyStatusTimestampsArray, runVariablesArray); err != nil {
+		if err := rows.Scan(&item.RunID, &item.CreatedAt, &item.CancelSignaledAt, &item.IsDestroy, &item.PositionInQueue, &item.Refresh, &item.RefreshOnly, &item.Source, &item.Status, &item.PlanStatus, &item.ApplyStatus, &item.ReplaceAddrs, &item.TargetAddrs, &item.AutoApply, planResourceReportRow, planOutputReportRow, applyResourceReportRow, &item.ConfigurationVersionID, &item.WorkspaceID, &item.PlanOnly, &item.CreatedBy, &item.TerraformVersion, &item.AllowEmptyApply, &item.Message, &item.ExecutionMode, &item.Latest, &item.OrganizationName, &item.CostEstimationEnabled, ingressAttributesRow, runStatusTimestampsArray, planStatusTimestampsArray, applyStatusTimestampsArray, runVariablesArray); err != nil {
 			return nil, fmt.Errorf("scan FindRuns row: %w", err)
 		}
 		if err := planResourceReportRow.AssignTo(&item.PlanResourceReport); err != nil {
@@ -374,7 +379,7 @@ func (q *DBQuerier) FindRunsScan(results pgx.BatchResults) ([]FindRunsRow, error
 	runVariablesArray := q.types.newRunVariablesArray()
 	for rows.Next() {
 		var item FindRunsRow
-		if err := rows.Scan(&item.RunID, &item.CreatedAt, &item.CancelSignaledAt, &item.IsDestroy, &item.PositionInQueue, &item.Refresh, &item.RefreshOnly, &item.Source, &item.Status, &item.PlanStatus, &item.ApplyStatus, &item.ReplaceAddrs, &item.TargetAddrs, &item.AutoApply, planResourceReportRow, planOutputReportRow, applyResourceReportRow, &item.ConfigurationVersionID, &item.WorkspaceID, &item.PlanOnly, &item.CreatedBy, &item.TerraformVersion, &item.AllowEmptyApply, &item.ExecutionMode, &item.Latest, &item.OrganizationName, &item.CostEstimationEnabled, ingressAttributesRow, runStatusTimestampsArray, planStatusTimestampsArray, applyStatusTimestampsArray, runVariablesArray); err != nil {
+		if err := rows.Scan(&item.RunID, &item.CreatedAt, &item.CancelSignaledAt, &item.IsDestroy, &item.PositionInQueue, &item.Refresh, &item.RefreshOnly, &item.Source, &item.Status, &item.PlanStatus, &item.ApplyStatus, &item.ReplaceAddrs, &item.TargetAddrs, &item.AutoApply, planResourceReportRow, planOutputReportRow, applyResourceReportRow, &item.ConfigurationVersionID, &item.WorkspaceID, &item.PlanOnly, &item.CreatedBy, &item.TerraformVersion, &item.AllowEmptyApply, &item.Message, &item.ExecutionMode, &item.Latest, &item.OrganizationName, &item.CostEstimationEnabled, ingressAttributesRow, runStatusTimestampsArray, planStatusTimestampsArray, applyStatusTimestampsArray, runVariablesArray); err != nil {
 			return nil, fmt.Errorf("scan FindRunsBatch row: %w", err)
 		}
 		if err := planResourceReportRow.AssignTo(&item.PlanResourceReport); err != nil {
@@ -485,6 +490,7 @@ const findRunByIDSQL = `SELECT
     runs.created_by,
     runs.terraform_version,
     runs.allow_empty_apply,
+    runs.message,
     workspaces.execution_mode AS execution_mode,
     CASE WHEN workspaces.latest_run_id = runs.run_id THEN true
          ELSE false
@@ -551,6 +557,7 @@ type FindRunByIDRow struct {
 	CreatedBy              pgtype.Text             `json:"created_by"`
 	TerraformVersion       pgtype.Text             `json:"terraform_version"`
 	AllowEmptyApply        pgtype.Bool             `json:"allow_empty_apply"`
+	Message                pgtype.Text             `json:"message"`
 	ExecutionMode          pgtype.Text             `json:"execution_mode"`
 	Latest                 pgtype.Bool             `json:"latest"`
 	OrganizationName       pgtype.Text             `json:"organization_name"`
@@ -575,7 +582,7 @@ func (q *DBQuerier) FindRunByID(ctx context.Context, runID pgtype.Text) (FindRun
 	planStatusTimestampsArray := q.types.newPhaseStatusTimestampsArray()
 	applyStatusTimestampsArray := q.types.newPhaseStatusTimestampsArray()
 	runVariablesArray := q.types.newRunVariablesArray()
-	if err := row.Scan(&item.RunID, &item.CreatedAt, &item.CancelSignaledAt, &item.IsDestroy, &item.PositionInQueue, &item.Refresh, &item.RefreshOnly, &item.Source, &item.Status, &item.PlanStatus, &item.ApplyStatus, &item.ReplaceAddrs, &item.TargetAddrs, &item.AutoApply, planResourceReportRow, planOutputReportRow, applyResourceReportRow, &item.ConfigurationVersionID, &item.WorkspaceID, &item.PlanOnly, &item.CreatedBy, &item.TerraformVersion, &item.AllowEmptyApply, &item.ExecutionMode, &item.Latest, &item.OrganizationName, &item.CostEstimationEnabled, ingressAttributesRow, runStatusTimestampsArray, planStatusTimestampsArray, applyStatusTimestampsArray, runVariablesArray); err != nil {
+	if err := row.Scan(&item.RunID, &item.CreatedAt, &item.CancelSignaledAt, &item.IsDestroy, &item.PositionInQueue, &item.Refresh, &item.RefreshOnly, &item.Source, &item.Status, &item.PlanStatus, &item.ApplyStatus, &item.ReplaceAddrs, &item.TargetAddrs, &item.AutoApply, planResourceReportRow, planOutputReportRow, applyResourceReportRow, &item.ConfigurationVersionID, &item.WorkspaceID, &item.PlanOnly, &item.CreatedBy, &item.TerraformVersion, &item.AllowEmptyApply, &item.Message, &item.ExecutionMode, &item.Latest, &item.OrganizationName, &item.CostEstimationEnabled, ingressAttributesRow, runStatusTimestampsArray, planStatusTimestampsArray, applyStatusTimestampsArray, runVariablesArray); err != nil {
 		return item, fmt.Errorf("query FindRunByID: %w", err)
 	}
 	if err := planResourceReportRow.AssignTo(&item.PlanResourceReport); err != nil {
@@ -622,7 +629,7 @@ func (q *DBQuerier) FindRunByIDScan(results pgx.BatchResults) (FindRunByIDRow, e
 	planStatusTimestampsArray := q.types.newPhaseStatusTimestampsArray()
 	applyStatusTimestampsArray := q.types.newPhaseStatusTimestampsArray()
 	runVariablesArray := q.types.newRunVariablesArray()
-	if err := row.Scan(&item.RunID, &item.CreatedAt, &item.CancelSignaledAt, &item.IsDestroy, &item.PositionInQueue, &item.Refresh, &item.RefreshOnly, &item.Source, &item.Status, &item.PlanStatus, &item.ApplyStatus, &item.ReplaceAddrs, &item.TargetAddrs, &item.AutoApply, planResourceReportRow, planOutputReportRow, applyResourceReportRow, &item.ConfigurationVersionID, &item.WorkspaceID, &item.PlanOnly, &item.CreatedBy, &item.TerraformVersion, &item.AllowEmptyApply, &item.ExecutionMode, &item.Latest, &item.OrganizationName, &item.CostEstimationEnabled, ingressAttributesRow, runStatusTimestampsArray, planStatusTimestampsArray, applyStatusTimestampsArray, runVariablesArray); err != nil {
+	if err := row.Scan(&item.RunID, &item.CreatedAt, &item.CancelSignaledAt, &item.IsDestroy, &item.PositionInQueue, &item.Refresh, &item.RefreshOnly, &item.Source, &item.Status, &item.PlanStatus, &item.ApplyStatus, &item.ReplaceAddrs, &item.TargetAddrs, &item.AutoApply, planResourceReportRow, planOutputReportRow, applyResourceReportRow, &item.ConfigurationVersionID, &item.WorkspaceID, &item.PlanOnly, &item.CreatedBy, &item.TerraformVersion, &item.AllowEmptyApply, &item.Message, &item.ExecutionMode, &item.Latest, &item.OrganizationName, &item.CostEstimationEnabled, ingressAttributesRow, runStatusTimestampsArray, planStatusTimestampsArray, applyStatusTimestampsArray, runVariablesArray); err != nil {
 		return item, fmt.Errorf("scan FindRunByIDBatch row: %w", err)
 	}
 	if err := planResourceReportRow.AssignTo(&item.PlanResourceReport); err != nil {
@@ -676,6 +683,7 @@ const findRunByIDForUpdateSQL = `SELECT
     runs.created_by,
     runs.terraform_version,
     runs.allow_empty_apply,
+    runs.message,
     workspaces.execution_mode AS execution_mode,
     CASE WHEN workspaces.latest_run_id = runs.run_id THEN true
          ELSE false
@@ -743,6 +751,7 @@ type FindRunByIDForUpdateRow struct {
 	CreatedBy              pgtype.Text             `json:"created_by"`
 	TerraformVersion       pgtype.Text             `json:"terraform_version"`
 	AllowEmptyApply        pgtype.Bool             `json:"allow_empty_apply"`
+	Message                pgtype.Text             `json:"message"`
 	ExecutionMode          pgtype.Text             `json:"execution_mode"`
 	Latest                 pgtype.Bool             `json:"latest"`
 	OrganizationName       pgtype.Text             `json:"organization_name"`
@@ -767,7 +776,7 @@ func (q *DBQuerier) FindRunByIDForUpdate(ctx context.Context, runID pgtype.Text)
 	planStatusTimestampsArray := q.types.newPhaseStatusTimestampsArray()
 	applyStatusTimestampsArray := q.types.newPhaseStatusTimestampsArray()
 	runVariablesArray := q.types.newRunVariablesArray()
-	if err := row.Scan(&item.RunID, &item.CreatedAt, &item.CancelSignaledAt, &item.IsDestroy, &item.PositionInQueue, &item.Refresh, &item.RefreshOnly, &item.Source, &item.Status, &item.PlanStatus, &item.ApplyStatus, &item.ReplaceAddrs, &item.TargetAddrs, &item.AutoApply, planResourceReportRow, planOutputReportRow, applyResourceReportRow, &item.ConfigurationVersionID, &item.WorkspaceID, &item.PlanOnly, &item.CreatedBy, &item.TerraformVersion, &item.AllowEmptyApply, &item.ExecutionMode, &item.Latest, &item.OrganizationName, &item.CostEstimationEnabled, ingressAttributesRow, runStatusTimestampsArray, planStatusTimestampsArray, applyStatusTimestampsArray, runVariablesArray); err != nil {
+	if err := row.Scan(&item.RunID, &item.CreatedAt, &item.CancelSignaledAt, &item.IsDestroy, &item.PositionInQueue, &item.Refresh, &item.RefreshOnly, &item.Source, &item.Status, &item.PlanStatus, &item.ApplyStatus, &item.ReplaceAddrs, &item.TargetAddrs, &item.AutoApply, planResourceReportRow, planOutputReportRow, applyResourceReportRow, &item.ConfigurationVersionID, &item.WorkspaceID, &item.PlanOnly, &item.CreatedBy, &item.TerraformVersion, &item.AllowEmptyApply, &item.Message, &item.ExecutionMode, &item.Latest, &item.OrganizationName, &item.CostEstimationEnabled, ingressAttributesRow, runStatusTimestampsArray, planStatusTimestampsArray, applyStatusTimestampsArray, runVariablesArray); err != nil {
 		return item, fmt.Errorf("query FindRunByIDForUpdate: %w", err)
 	}
 	if err := planResourceReportRow.AssignTo(&item.PlanResourceReport); err != nil {
@@ -814,7 +823,7 @@ func (q *DBQuerier) FindRunByIDForUpdateScan(results pgx.BatchResults) (FindRunB
 	planStatusTimestampsArray := q.types.newPhaseStatusTimestampsArray()
 	applyStatusTimestampsArray := q.types.newPhaseStatusTimestampsArray()
 	runVariablesArray := q.types.newRunVariablesArray()
-	if err := row.Scan(&item.RunID, &item.CreatedAt, &item.CancelSignaledAt, &item.IsDestroy, &item.PositionInQueue, &item.Refresh, &item.RefreshOnly, &item.Source, &item.Status, &item.PlanStatus, &item.ApplyStatus, &item.ReplaceAddrs, &item.TargetAddrs, &item.AutoApply, planResourceReportRow, planOutputReportRow, applyResourceReportRow, &item.ConfigurationVersionID, &item.WorkspaceID, &item.PlanOnly, &item.CreatedBy, &item.TerraformVersion, &item.AllowEmptyApply, &item.ExecutionMode, &item.Latest, &item.OrganizationName, &item.CostEstimationEnabled, ingressAttributesRow, runStatusTimestampsArray, planStatusTimestampsArray, applyStatusTimestampsArray, runVariablesArray); err != nil {
+	if err := row.Scan(&item.RunID, &item.CreatedAt, &item.CancelSignaledAt, &item.IsDestroy, &item.PositionInQueue, &item.Refresh, &item.RefreshOnly, &item.Source, &item.Status, &item.PlanStatus, &item.ApplyStatus, &item.ReplaceAddrs, &item.TargetAddrs, &item.AutoApply, planResourceReportRow, planOutputReportRow, applyResourceReportRow, &item.ConfigurationVersionID, &item.WorkspaceID, &item.PlanOnly, &item.CreatedBy, &item.TerraformVersion, &item.AllowEmptyApply, &item.Message, &item.ExecutionMode, &item.Latest, &item.OrganizationName, &item.CostEstimationEnabled, ingressAttributesRow, runStatusTimestampsArray, planStatusTimestampsArray, applyStatusTimestampsArray, runVariablesArray); err != nil {
 		return item, fmt.Errorf("scan FindRunByIDForUpdateBatch row: %w", err)
 	}
 	if err := planResourceReportRow.AssignTo(&item.PlanResourceReport); err != nil {
@@ -1004,3 +1013,125 @@ func (q *DBQuerier) DeleteRunByIDScan(results pgx.BatchResults) (pgtype.Text, er
 	}
 	return item, nil
 }
+
+const findQueuedRunIDsByWorkspaceIDSQL = `SELECT run_id
+FROM runs
+WHERE workspace_id = $1
+AND   plan_only = false
+AND   status NOT IN ('applied', 'planned_and_finished', 'policy_soft_failed', 'discarded', 'canceled', 'force_canceled', 'errored')
+ORDER BY created_at ASC
+;`
+
+// FindQueuedRunIDsByWorkspaceID implements Querier.FindQueuedRunIDsByWorkspaceID.
+func (q *DBQuerier) FindQueuedRunIDsByWorkspaceID(ctx context.Context, workspaceID pgtype.Text) ([]pgtype.Text, error) {
+	ctx = context.WithValue(ctx, "pggen_query_name", "FindQueuedRunIDsByWorkspaceID")
+	rows, err := q.conn.Query(ctx, findQueuedRunIDsByWorkspaceIDSQL, workspaceID)
+	if err != nil {
+		return nil, fmt.Errorf("query FindQueuedRunIDsByWorkspaceID: %w", err)
+	}
+	defer rows.Close()
+	items := []pgtype.Text{}
+	for rows.Next() {
+		var item pgtype.Text
+		if err := rows.Scan(&item); err != nil {
+			return nil, fmt.Errorf("scan FindQueuedRunIDsByWorkspaceID row: %w", err)
+		}
+		items = append(items, item)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("close FindQueuedRunIDsByWorkspaceID rows: %w", err)
+	}
+	return items, err
+}
+
+// FindQueuedRunIDsByWorkspaceIDBatch implements Querier.FindQueuedRunIDsByWorkspaceIDBatch.
+func (q *DBQuerier) FindQueuedRunIDsByWorkspaceIDBatch(batch genericBatch, workspaceID pgtype.Text) {
+	batch.Queue(findQueuedRunIDsByWorkspaceIDSQL, workspaceID)
+}
+
+// FindQueuedRunIDsByWorkspaceIDScan implements Querier.FindQueuedRunIDsByWorkspaceIDScan.
+func (q *DBQuerier) FindQueuedRunIDsByWorkspaceIDScan(results pgx.BatchResults) ([]pgtype.Text, error) {
+	rows, err := results.Query()
+	if err != nil {
+		return nil, fmt.Errorf("query FindQueuedRunIDsByWorkspaceIDBatch: %w", err)
+	}
+	defer rows.Close()
+	items := []pgtype.Text{}
+	for rows.Next() {
+		var item pgtype.Text
+		if err := rows.Scan(&item); err != nil {
+			return nil, fmt.Errorf("scan FindQueuedRunIDsByWorkspaceIDBatch row: %w", err)
+		}
+		items = append(items, item)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("close FindQueuedRunIDsByWorkspaceIDBatch rows: %w", err)
+	}
+	return items, err
+}
+
+const findSerializationGroupQueueByWorkspaceIDSQL = `SELECT r.run_id, w.serialization_group
+FROM runs r
+JOIN workspaces w USING (workspace_id)
+LEFT JOIN run_status_timestamps rst ON rst.run_id = r.run_id AND rst.status = 'confirmed'
+WHERE w.serialization_group = (
+    SELECT serialization_group
+    FROM workspaces
+    WHERE workspace_id = $1
+)
+AND   r.status IN ('confirmed', 'apply_queued', 'applying')
+ORDER BY r.status = 'confirmed', rst.timestamp ASC
+;`
+
+type FindSerializationGroupQueueByWorkspaceIDRow struct {
+	RunID              pgtype.Text `json:"run_id"`
+	SerializationGroup pgtype.Text `json:"serialization_group"`
+}
+
+// FindSerializationGroupQueueByWorkspaceID implements Querier.FindSerializationGroupQueueByWorkspaceID.
+func (q *DBQuerier) FindSerializationGroupQueueByWorkspaceID(ctx context.Context, workspaceID pgtype.Text) ([]FindSerializationGroupQueueByWorkspaceIDRow, error) {
+	ctx = context.WithValue(ctx, "pggen_query_name", "FindSerializationGroupQueueByWorkspaceID")
+	rows, err := q.conn.Query(ctx, findSerializationGroupQueueByWorkspaceIDSQL, workspaceID)
+	if err != nil {
+		return nil, fmt.Errorf("query FindSerializationGroupQueueByWorkspaceID: %w", err)
+	}
+	defer rows.Close()
+	items := []FindSerializationGroupQueueByWorkspaceIDRow{}
+	for rows.Next() {
+		var item FindSerializationGroupQueueByWorkspaceIDRow
+		if err := rows.Scan(&item.RunID, &item.SerializationGroup); err != nil {
+			return nil, fmt.Errorf("scan FindSerializationGroupQueueByWorkspaceID row: %w", err)
+		}
+		items = append(items, item)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("close FindSerializationGroupQueueByWorkspaceID rows: %w", err)
+	}
+	return items, err
+}
+
+// FindSerializationGroupQueueByWorkspaceIDBatch implements Querier.FindSerializationGroupQueueByWorkspaceIDBatch.
+func (q *DBQuerier) FindSerializationGroupQueueByWorkspaceIDBatch(batch genericBatch, workspaceID pgtype.Text) {
+	batch.Queue(findSerializationGroupQueueByWorkspaceIDSQL, workspaceID)
+}
+
+// FindSerializationGroupQueueByWorkspaceIDScan implements Querier.FindSerializationGroupQueueByWorkspaceIDScan.
+func (q *DBQuerier) FindSerializationGroupQueueByWorkspaceIDScan(results pgx.BatchResults) ([]FindSerializationGroupQueueByWorkspaceIDRow, error) {
+	rows, err := results.Query()
+	if err != nil {
+		return nil, fmt.Errorf("query FindSerializationGroupQueueByWorkspaceIDBatch: %w", err)
+	}
+	defer rows.Close()
+	items := []FindSerializationGroupQueueByWorkspaceIDRow{}
+	for rows.Next() {
+		var item FindSerializationGroupQueueByWorkspaceIDRow
+		if err := rows.Scan(&item.RunID, &item.SerializationGroup); err != nil {
+			return nil, fmt.Errorf("scan FindSerializationGroupQueueByWorkspaceIDBatch row: %w", err)
+		}
+		items = append(items, item)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("close FindSerializationGroupQueueByWorkspaceIDBatch rows: %w", err)
+	}
+	return items, err
+}
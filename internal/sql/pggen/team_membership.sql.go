@@ -130,3 +130,61 @@ func (q *DBQuerier) DeleteTeamMembershipScan(results pgx.BatchResults) ([]pgtype
 	}
 	return items, err
 }
+
+const deleteTeamMembershipsByOrganizationSQL = `DELETE
+FROM team_memberships tm
+USING teams t
+WHERE
+    tm.team_id           = t.team_id AND
+    t.organization_name  = $1 AND
+    tm.username          = $2
+RETURNING tm.team_id
+;`
+
+// DeleteTeamMembershipsByOrganization implements Querier.DeleteTeamMembershipsByOrganization.
+func (q *DBQuerier) DeleteTeamMembershipsByOrganization(ctx context.Context, organizationName pgtype.Text, username pgtype.Text) ([]pgtype.Text, error) {
+	ctx = context.WithValue(ctx, "pggen_query_name", "DeleteTeamMembershipsByOrganization")
+	rows, err := q.conn.Query(ctx, deleteTeamMembershipsByOrganizationSQL, organizationName, username)
+	if err != nil {
+		return nil, fmt.Errorf("query DeleteTeamMembershipsByOrganization: %w", err)
+	}
+	defer rows.Close()
+	items := []pgtype.Text{}
+	for rows.Next() {
+		var item pgtype.Text
+		if err := rows.Scan(&item); err != nil {
+			return nil, fmt.Errorf("scan DeleteTeamMembershipsByOrganization row: %w", err)
+		}
+		items = append(items, item)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("close DeleteTeamMembershipsByOrganization rows: %w", err)
+	}
+	return items, err
+}
+
+// DeleteTeamMembershipsByOrganizationBatch implements Querier.DeleteTeamMembershipsByOrganizationBatch.
+func (q *DBQuerier) DeleteTeamMembershipsByOrganizationBatch(batch genericBatch, organizationName pgtype.Text, username pgtype.Text) {
+	batch.Queue(deleteTeamMembershipsByOrganizationSQL, organizationName, username)
+}
+
+// DeleteTeamMembershipsByOrganizationScan implements Querier.DeleteTeamMembershipsByOrganizationScan.
+func (q *DBQuerier) DeleteTeamMembershipsByOrganizationScan(results pgx.BatchResults) ([]pgtype.Text, error) {
+	rows, err := results.Query()
+	if err != nil {
+		return nil, fmt.Errorf("query DeleteTeamMembershipsByOrganizationBatch: %w", err)
+	}
+	defer rows.Close()
+	items := []pgtype.Text{}
+	for rows.Next() {
+		var item pgtype.Text
+		if err := rows.Scan(&item); err != nil {
+			return nil, fmt.Errorf("scan DeleteTeamMembershipsByOrganizationBatch row: %w", err)
+		}
+		items = append(items, item)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("close DeleteTeamMembershipsByOrganizationBatch rows: %w", err)
+	}
+	return items, err
+}
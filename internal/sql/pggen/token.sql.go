@@ -15,25 +15,31 @@ const insertTokenSQL = `INSERT INTO tokens (
     token_id,
     created_at,
     description,
-    username
+    username,
+    organization_name,
+    scopes
 ) VALUES (
     $1,
     $2,
     $3,
-    $4
+    $4,
+    $5,
+    $6
 );`
 
 type InsertTokenParams struct {
-	TokenID     pgtype.Text
-	CreatedAt   pgtype.Timestamptz
-	Description pgtype.Text
-	Username    pgtype.Text
+	TokenID          pgtype.Text
+	CreatedAt        pgtype.Timestamptz
+	Description      pgtype.Text
+	Username         pgtype.Text
+	OrganizationName pgtype.Text
+	Scopes           []string
 }
 
 // InsertToken implements Querier.InsertToken.
 func (q *DBQuerier) InsertToken(ctx context.Context, params InsertTokenParams) (pgconn.CommandTag, error) {
 	ctx = context.WithValue(ctx, "pggen_query_name", "InsertToken")
-	cmdTag, err := q.conn.Exec(ctx, insertTokenSQL, params.TokenID, params.CreatedAt, params.Description, params.Username)
+	cmdTag, err := q.conn.Exec(ctx, insertTokenSQL, params.TokenID, params.CreatedAt, params.Description, params.Username, params.OrganizationName, params.Scopes)
 	if err != nil {
 		return cmdTag, fmt.Errorf("exec query InsertToken: %w", err)
 	}
@@ -42,7 +48,7 @@ func (q *DBQuerier) InsertToken(ctx context.Context, params InsertTokenParams) (
 
 // InsertTokenBatch implements Querier.InsertTokenBatch.
 func (q *DBQuerier) InsertTokenBatch(batch genericBatch, params InsertTokenParams) {
-	batch.Queue(insertTokenSQL, params.TokenID, params.CreatedAt, params.Description, params.Username)
+	batch.Queue(insertTokenSQL, params.TokenID, params.CreatedAt, params.Description, params.Username, params.OrganizationName, params.Scopes)
 }
 
 // InsertTokenScan implements Querier.InsertTokenScan.
@@ -60,10 +66,12 @@ WHERE username = $1
 ;`
 
 type FindTokensByUsernameRow struct {
-	TokenID     pgtype.Text        `json:"token_id"`
-	CreatedAt   pgtype.Timestamptz `json:"created_at"`
-	Description pgtype.Text        `json:"description"`
-	Username    pgtype.Text        `json:"username"`
+	TokenID          pgtype.Text        `json:"token_id"`
+	CreatedAt        pgtype.Timestamptz `json:"created_at"`
+	Description      pgtype.Text        `json:"description"`
+	Username         pgtype.Text        `json:"username"`
+	OrganizationName pgtype.Text        `json:"organization_name"`
+	Scopes           []string           `json:"scopes"`
 }
 
 // FindTokensByUsername implements Querier.FindTokensByUsername.
@@ -77,7 +85,7 @@ func (q *DBQuerier) FindTokensByUsername(ctx context.Context, username pgtype.Te
 	items := []FindTokensByUsernameRow{}
 	for rows.Next() {
 		var item FindTokensByUsernameRow
-		if err := rows.Scan(&item.TokenID, &item.CreatedAt, &item.Description, &item.Username); err != nil {
+		if err := rows.Scan(&item.TokenID, &item.CreatedAt, &item.Description, &item.Username, &item.OrganizationName, &item.Scopes); err != nil {
 			return nil, fmt.Errorf("scan FindTokensByUsername row: %w", err)
 		}
 		items = append(items, item)
@@ -103,7 +111,7 @@ func (q *DBQuerier) FindTokensByUsernameScan(results pgx.BatchResults) ([]FindTo
 	items := []FindTokensByUsernameRow{}
 	for rows.Next() {
 		var item FindTokensByUsernameRow
-		if err := rows.Scan(&item.TokenID, &item.CreatedAt, &item.Description, &item.Username); err != nil {
+		if err := rows.Scan(&item.TokenID, &item.CreatedAt, &item.Description, &item.Username, &item.OrganizationName, &item.Scopes); err != nil {
 			return nil, fmt.Errorf("scan FindTokensByUsernameBatch row: %w", err)
 		}
 		items = append(items, item)
@@ -120,10 +128,12 @@ WHERE token_id = $1
 ;`
 
 type FindTokenByIDRow struct {
-	TokenID     pgtype.Text        `json:"token_id"`
-	CreatedAt   pgtype.Timestamptz `json:"created_at"`
-	Description pgtype.Text        `json:"description"`
-	Username    pgtype.Text        `json:"username"`
+	TokenID          pgtype.Text        `json:"token_id"`
+	CreatedAt        pgtype.Timestamptz `json:"created_at"`
+	Description      pgtype.Text        `json:"description"`
+	Username         pgtype.Text        `json:"username"`
+	OrganizationName pgtype.Text        `json:"organization_name"`
+	Scopes           []string           `json:"scopes"`
 }
 
 // FindTokenByID implements Querier.FindTokenByID.
@@ -131,7 +141,7 @@ func (q *DBQuerier) FindTokenByID(ctx context.Context, tokenID pgtype.Text) (Fin
 	ctx = context.WithValue(ctx, "pggen_query_name", "FindTokenByID")
 	row := q.conn.QueryRow(ctx, findTokenByIDSQL, tokenID)
 	var item FindTokenByIDRow
-	if err := row.Scan(&item.TokenID, &item.CreatedAt, &item.Description, &item.Username); err != nil {
+	if err := row.Scan(&item.TokenID, &item.CreatedAt, &item.Description, &item.Username, &item.OrganizationName, &item.Scopes); err != nil {
 		return item, fmt.Errorf("query FindTokenByID: %w", err)
 	}
 	return item, nil
@@ -146,7 +156,7 @@ func (q *DBQuerier) FindTokenByIDBatch(batch genericBatch, tokenID pgtype.Text)
 func (q *DBQuerier) FindTokenByIDScan(results pgx.BatchResults) (FindTokenByIDRow, error) {
 	row := results.QueryRow()
 	var item FindTokenByIDRow
-	if err := row.Scan(&item.TokenID, &item.CreatedAt, &item.Description, &item.Username); err != nil {
+	if err := row.Scan(&item.TokenID, &item.CreatedAt, &item.Description, &item.Username, &item.OrganizationName, &item.Scopes); err != nil {
 		return item, fmt.Errorf("scan FindTokenByIDBatch row: %w", err)
 	}
 	return item, nil
@@ -16,13 +16,15 @@ const insertVariableSetSQL = `INSERT INTO variable_sets (
     global,
     name,
     description,
-    organization_name
+    organization_name,
+    priority
 ) VALUES (
     $1,
     $2,
     $3,
     $4,
-    $5
+    $5,
+    $6
 );`
 
 type InsertVariableSetParams struct {
@@ -31,12 +33,13 @@ type InsertVariableSetParams struct {
 	Name             pgtype.Text
 	Description      pgtype.Text
 	OrganizationName pgtype.Text
+	Priority         pgtype.Bool
 }
 
 // InsertVariableSet implements Querier.InsertVariableSet.
 func (q *DBQuerier) InsertVariableSet(ctx context.Context, params InsertVariableSetParams) (pgconn.CommandTag, error) {
 	ctx = context.WithValue(ctx, "pggen_query_name", "InsertVariableSet")
-	cmdTag, err := q.conn.Exec(ctx, insertVariableSetSQL, params.VariableSetID, params.Global, params.Name, params.Description, params.OrganizationName)
+	cmdTag, err := q.conn.Exec(ctx, insertVariableSetSQL, params.VariableSetID, params.Global, params.Name, params.Description, params.OrganizationName, params.Priority)
 	if err != nil {
 		return cmdTag, fmt.Errorf("exec query InsertVariableSet: %w", err)
 	}
@@ -45,7 +48,7 @@ func (q *DBQuerier) InsertVariableSet(ctx context.Context, params InsertVariable
 
 // InsertVariableSetBatch implements Querier.InsertVariableSetBatch.
 func (q *DBQuerier) InsertVariableSetBatch(batch genericBatch, params InsertVariableSetParams) {
-	batch.Queue(insertVariableSetSQL, params.VariableSetID, params.Global, params.Name, params.Description, params.OrganizationName)
+	batch.Queue(insertVariableSetSQL, params.VariableSetID, params.Global, params.Name, params.Description, params.OrganizationName, params.Priority)
 }
 
 // InsertVariableSetScan implements Querier.InsertVariableSetScan.
@@ -81,6 +84,7 @@ type FindVariableSetsByOrganizationRow struct {
 	Name             pgtype.Text `json:"name"`
 	Description      pgtype.Text `json:"description"`
 	OrganizationName pgtype.Text `json:"organization_name"`
+	Priority         pgtype.Bool `json:"priority"`
 	Variables        []Variables `json:"variables"`
 	WorkspaceIds     []string    `json:"workspace_ids"`
 }
@@ -97,7 +101,7 @@ func (q *DBQuerier) FindVariableSetsByOrganization(ctx context.Context, organiza
 	variablesArray := q.types.newVariablesArray()
 	for rows.Next() {
 		var item FindVariableSetsByOrganizationRow
-		if err := rows.Scan(&item.VariableSetID, &item.Global, &item.Name, &item.Description, &item.OrganizationName, variablesArray, &item.WorkspaceIds); err != nil {
+		if err := rows.Scan(&item.VariableSetID, &item.Global, &item.Name, &item.Description, &item.OrganizationName, &item.Priority, variablesArray, &item.WorkspaceIds); err != nil {
 			return nil, fmt.Errorf("scan FindVariableSetsByOrganization row: %w", err)
 		}
 		if err := variablesArray.AssignTo(&item.Variables); err != nil {
@@ -127,7 +131,7 @@ func (q *DBQuerier) FindVariableSetsByOrganizationScan(results pgx.BatchResults)
 	variablesArray := q.types.newVariablesArray()
 	for rows.Next() {
 		var item FindVariableSetsByOrganizationRow
-		if err := rows.Scan(&item.VariableSetID, &item.Global, &item.Name, &item.Description, &item.OrganizationName, variablesArray, &item.WorkspaceIds); err != nil {
+		if err := rows.Scan(&item.VariableSetID, &item.Global, &item.Name, &item.Description, &item.OrganizationName, &item.Priority, variablesArray, &item.WorkspaceIds); err != nil {
 			return nil, fmt.Errorf("scan FindVariableSetsByOrganizationBatch row: %w", err)
 		}
 		if err := variablesArray.AssignTo(&item.Variables); err != nil {
@@ -186,6 +190,7 @@ type FindVariableSetsByWorkspaceRow struct {
 	Name             pgtype.Text `json:"name"`
 	Description      pgtype.Text `json:"description"`
 	OrganizationName pgtype.Text `json:"organization_name"`
+	Priority         pgtype.Bool `json:"priority"`
 	Variables        []Variables `json:"variables"`
 	WorkspaceIds     []string    `json:"workspace_ids"`
 }
@@ -202,7 +207,7 @@ func (q *DBQuerier) FindVariableSetsByWorkspace(ctx context.Context, workspaceID
 	variablesArray := q.types.newVariablesArray()
 	for rows.Next() {
 		var item FindVariableSetsByWorkspaceRow
-		if err := rows.Scan(&item.VariableSetID, &item.Global, &item.Name, &item.Description, &item.OrganizationName, variablesArray, &item.WorkspaceIds); err != nil {
+		if err := rows.Scan(&item.VariableSetID, &item.Global, &item.Name, &item.Description, &item.OrganizationName, &item.Priority, variablesArray, &item.WorkspaceIds); err != nil {
 			return nil, fmt.Errorf("scan FindVariableSetsByWorkspace row: %w", err)
 		}
 		if err := variablesArray.AssignTo(&item.Variables); err != nil {
@@ -232,7 +237,7 @@ func (q *DBQuerier) FindVariableSetsByWorkspaceScan(results pgx.BatchResults) ([
 	variablesArray := q.types.newVariablesArray()
 	for rows.Next() {
 		var item FindVariableSetsByWorkspaceRow
-		if err := rows.Scan(&item.VariableSetID, &item.Global, &item.Name, &item.Description, &item.OrganizationName, variablesArray, &item.WorkspaceIds); err != nil {
+		if err := rows.Scan(&item.VariableSetID, &item.Global, &item.Name, &item.Description, &item.OrganizationName, &item.Priority, variablesArray, &item.WorkspaceIds); err != nil {
 			return nil, fmt.Errorf("scan FindVariableSetsByWorkspaceBatch row: %w", err)
 		}
 		if err := variablesArray.AssignTo(&item.Variables); err != nil {
@@ -270,6 +275,7 @@ type FindVariableSetBySetIDRow struct {
 	Name             pgtype.Text `json:"name"`
 	Description      pgtype.Text `json:"description"`
 	OrganizationName pgtype.Text `json:"organization_name"`
+	Priority         pgtype.Bool `json:"priority"`
 	Variables        []Variables `json:"variables"`
 	WorkspaceIds     []string    `json:"workspace_ids"`
 }
@@ -280,7 +286,7 @@ func (q *DBQuerier) FindVariableSetBySetID(ctx context.Context, variableSetID pg
 	row := q.conn.QueryRow(ctx, findVariableSetBySetIDSQL, variableSetID)
 	var item FindVariableSetBySetIDRow
 	variablesArray := q.types.newVariablesArray()
-	if err := row.Scan(&item.VariableSetID, &item.Global, &item.Name, &item.Description, &item.OrganizationName, variablesArray, &item.WorkspaceIds); err != nil {
+	if err := row.Scan(&item.VariableSetID, &item.Global, &item.Name, &item.Description, &item.OrganizationName, &item.Priority, variablesArray, &item.WorkspaceIds); err != nil {
 		return item, fmt.Errorf("query FindVariableSetBySetID: %w", err)
 	}
 	if err := variablesArray.AssignTo(&item.Variables); err != nil {
@@ -299,7 +305,7 @@ func (q *DBQuerier) FindVariableSetBySetIDScan(results pgx.BatchResults) (FindVa
 	row := results.QueryRow()
 	var item FindVariableSetBySetIDRow
 	variablesArray := q.types.newVariablesArray()
-	if err := row.Scan(&item.VariableSetID, &item.Global, &item.Name, &item.Description, &item.OrganizationName, variablesArray, &item.WorkspaceIds); err != nil {
+	if err := row.Scan(&item.VariableSetID, &item.Global, &item.Name, &item.Description, &item.OrganizationName, &item.Priority, variablesArray, &item.WorkspaceIds); err != nil {
 		return item, fmt.Errorf("scan FindVariableSetBySetIDBatch row: %w", err)
 	}
 	if err := variablesArray.AssignTo(&item.Variables); err != nil {
@@ -333,6 +339,7 @@ type FindVariableSetByVariableIDRow struct {
 	Name             pgtype.Text `json:"name"`
 	Description      pgtype.Text `json:"description"`
 	OrganizationName pgtype.Text `json:"organization_name"`
+	Priority         pgtype.Bool `json:"priority"`
 	Variables        []Variables `json:"variables"`
 	WorkspaceIds     []string    `json:"workspace_ids"`
 }
@@ -343,7 +350,7 @@ func (q *DBQuerier) FindVariableSetByVariableID(ctx context.Context, variableID
 	row := q.conn.QueryRow(ctx, findVariableSetByVariableIDSQL, variableID)
 	var item FindVariableSetByVariableIDRow
 	variablesArray := q.types.newVariablesArray()
-	if err := row.Scan(&item.VariableSetID, &item.Global, &item.Name, &item.Description, &item.OrganizationName, variablesArray, &item.WorkspaceIds); err != nil {
+	if err := row.Scan(&item.VariableSetID, &item.Global, &item.Name, &item.Description, &item.OrganizationName, &item.Priority, variablesArray, &item.WorkspaceIds); err != nil {
 		return item, fmt.Errorf("query FindVariableSetByVariableID: %w", err)
 	}
 	if err := variablesArray.AssignTo(&item.Variables); err != nil {
@@ -362,7 +369,7 @@ func (q *DBQuerier) FindVariableSetByVariableIDScan(results pgx.BatchResults) (F
 	row := results.QueryRow()
 	var item FindVariableSetByVariableIDRow
 	variablesArray := q.types.newVariablesArray()
-	if err := row.Scan(&item.VariableSetID, &item.Global, &item.Name, &item.Description, &item.OrganizationName, variablesArray, &item.WorkspaceIds); err != nil {
+	if err := row.Scan(&item.VariableSetID, &item.Global, &item.Name, &item.Description, &item.OrganizationName, &item.Priority, variablesArray, &item.WorkspaceIds); err != nil {
 		return item, fmt.Errorf("scan FindVariableSetByVariableIDBatch row: %w", err)
 	}
 	if err := variablesArray.AssignTo(&item.Variables); err != nil {
@@ -396,6 +403,7 @@ type FindVariableSetForUpdateRow struct {
 	Name             pgtype.Text `json:"name"`
 	Description      pgtype.Text `json:"description"`
 	OrganizationName pgtype.Text `json:"organization_name"`
+	Priority         pgtype.Bool `json:"priority"`
 	Variables        []Variables `json:"variables"`
 	WorkspaceIds     []string    `json:"workspace_ids"`
 }
@@ -406,7 +414,7 @@ func (q *DBQuerier) FindVariableSetForUpdate(ctx context.Context, variableSetID
 	row := q.conn.QueryRow(ctx, findVariableSetForUpdateSQL, variableSetID)
 	var item FindVariableSetForUpdateRow
 	variablesArray := q.types.newVariablesArray()
-	if err := row.Scan(&item.VariableSetID, &item.Global, &item.Name, &item.Description, &item.OrganizationName, variablesArray, &item.WorkspaceIds); err != nil {
+	if err := row.Scan(&item.VariableSetID, &item.Global, &item.Name, &item.Description, &item.OrganizationName, &item.Priority, variablesArray, &item.WorkspaceIds); err != nil {
 		return item, fmt.Errorf("query FindVariableSetForUpdate: %w", err)
 	}
 	if err := variablesArray.AssignTo(&item.Variables); err != nil {
@@ -425,7 +433,7 @@ func (q *DBQuerier) FindVariableSetForUpdateScan(results pgx.BatchResults) (Find
 	row := results.QueryRow()
 	var item FindVariableSetForUpdateRow
 	variablesArray := q.types.newVariablesArray()
-	if err := row.Scan(&item.VariableSetID, &item.Global, &item.Name, &item.Description, &item.OrganizationName, variablesArray, &item.WorkspaceIds); err != nil {
+	if err := row.Scan(&item.VariableSetID, &item.Global, &item.Name, &item.Description, &item.OrganizationName, &item.Priority, variablesArray, &item.WorkspaceIds); err != nil {
 		return item, fmt.Errorf("scan FindVariableSetForUpdateBatch row: %w", err)
 	}
 	if err := variablesArray.AssignTo(&item.Variables); err != nil {
@@ -438,21 +446,23 @@ const updateVariableSetByIDSQL = `UPDATE variable_sets
 SET
     global = $1,
     name = $2,
-    description = $3
-WHERE variable_set_id = $4
+    description = $3,
+    priority = $4
+WHERE variable_set_id = $5
 RETURNING variable_set_id;`
 
 type UpdateVariableSetByIDParams struct {
 	Global        pgtype.Bool
 	Name          pgtype.Text
 	Description   pgtype.Text
+	Priority      pgtype.Bool
 	VariableSetID pgtype.Text
 }
 
 // UpdateVariableSetByID implements Querier.UpdateVariableSetByID.
 func (q *DBQuerier) UpdateVariableSetByID(ctx context.Context, params UpdateVariableSetByIDParams) (pgtype.Text, error) {
 	ctx = context.WithValue(ctx, "pggen_query_name", "UpdateVariableSetByID")
-	row := q.conn.QueryRow(ctx, updateVariableSetByIDSQL, params.Global, params.Name, params.Description, params.VariableSetID)
+	row := q.conn.QueryRow(ctx, updateVariableSetByIDSQL, params.Global, params.Name, params.Description, params.Priority, params.VariableSetID)
 	var item pgtype.Text
 	if err := row.Scan(&item); err != nil {
 		return item, fmt.Errorf("query UpdateVariableSetByID: %w", err)
@@ -462,7 +472,7 @@ func (q *DBQuerier) UpdateVariableSetByID(ctx context.Context, params UpdateVari
 
 // UpdateVariableSetByIDBatch implements Querier.UpdateVariableSetByIDBatch.
 func (q *DBQuerier) UpdateVariableSetByIDBatch(batch genericBatch, params UpdateVariableSetByIDParams) {
-	batch.Queue(updateVariableSetByIDSQL, params.Global, params.Name, params.Description, params.VariableSetID)
+	batch.Queue(updateVariableSetByIDSQL, params.Global, params.Name, params.Description, params.Priority, params.VariableSetID)
 }
 
 // UpdateVariableSetByIDScan implements Querier.UpdateVariableSetByIDScan.
@@ -486,6 +496,7 @@ type DeleteVariableSetByIDRow struct {
 	Name             pgtype.Text `json:"name"`
 	Description      pgtype.Text `json:"description"`
 	OrganizationName pgtype.Text `json:"organization_name"`
+	Priority         pgtype.Bool `json:"priority"`
 }
 
 // DeleteVariableSetByID implements Querier.DeleteVariableSetByID.
@@ -493,7 +504,7 @@ func (q *DBQuerier) DeleteVariableSetByID(ctx context.Context, variableSetID pgt
 	ctx = context.WithValue(ctx, "pggen_query_name", "DeleteVariableSetByID")
 	row := q.conn.QueryRow(ctx, deleteVariableSetByIDSQL, variableSetID)
 	var item DeleteVariableSetByIDRow
-	if err := row.Scan(&item.VariableSetID, &item.Global, &item.Name, &item.Description, &item.OrganizationName); err != nil {
+	if err := row.Scan(&item.VariableSetID, &item.Global, &item.Name, &item.Description, &item.OrganizationName, &item.Priority); err != nil {
 		return item, fmt.Errorf("query DeleteVariableSetByID: %w", err)
 	}
 	return item, nil
@@ -508,7 +519,7 @@ func (q *DBQuerier) DeleteVariableSetByIDBatch(batch genericBatch, variableSetID
 func (q *DBQuerier) DeleteVariableSetByIDScan(results pgx.BatchResults) (DeleteVariableSetByIDRow, error) {
 	row := results.QueryRow()
 	var item DeleteVariableSetByIDRow
-	if err := row.Scan(&item.VariableSetID, &item.Global, &item.Name, &item.Description, &item.OrganizationName); err != nil {
+	if err := row.Scan(&item.VariableSetID, &item.Global, &item.Name, &item.Description, &item.OrganizationName, &item.Priority); err != nil {
 		return item, fmt.Errorf("scan DeleteVariableSetByIDBatch row: %w", err)
 	}
 	return item, nil
@@ -4,13 +4,15 @@ INSERT INTO agent_pools (
     name,
     created_at,
     organization_name,
-    organization_scoped
+    organization_scoped,
+    labels
 ) VALUES (
     pggen.arg('agent_pool_id'),
     pggen.arg('name'),
     pggen.arg('created_at'),
     pggen.arg('organization_name'),
-    pggen.arg('organization_scoped')
+    pggen.arg('organization_scoped'),
+    pggen.arg('labels')
 );
 
 -- name: FindAgentPools :many
@@ -100,7 +102,8 @@ GROUP BY ap.agent_pool_id
 -- name: UpdateAgentPool :one
 UPDATE agent_pools
 SET name = pggen.arg('name'),
-    organization_scoped = pggen.arg('organization_scoped')
+    organization_scoped = pggen.arg('organization_scoped'),
+    labels = pggen.arg('labels')
 WHERE agent_pool_id = pggen.arg('pool_id')
 RETURNING *;
 
@@ -126,3 +129,25 @@ FROM agent_pool_allowed_workspaces
 WHERE agent_pool_id = pggen.arg('pool_id')
 AND workspace_id = pggen.arg('workspace_id')
 ;
+
+-- name: InsertWorkspacePlacementConstraint :exec
+INSERT INTO workspace_placement_constraints (
+    workspace_id,
+    label
+) VALUES (
+    pggen.arg('workspace_id'),
+    pggen.arg('label')
+);
+
+-- name: FindWorkspacePlacementConstraints :many
+SELECT label
+FROM workspace_placement_constraints
+WHERE workspace_id = pggen.arg('workspace_id')
+ORDER BY label
+;
+
+-- name: DeleteWorkspacePlacementConstraints :exec
+DELETE
+FROM workspace_placement_constraints
+WHERE workspace_id = pggen.arg('workspace_id')
+;
@@ -0,0 +1,64 @@
+-- name: InsertAssessmentResult :exec
+INSERT INTO assessment_results (
+    assessment_result_id,
+    created_at,
+    updated_at,
+    status,
+    drifted,
+    drifted_resources,
+    error_message,
+    run_id,
+    workspace_id
+) VALUES (
+    pggen.arg('assessment_result_id'),
+    pggen.arg('created_at'),
+    pggen.arg('updated_at'),
+    pggen.arg('status'),
+    pggen.arg('drifted'),
+    pggen.arg('drifted_resources'),
+    pggen.arg('error_message'),
+    pggen.arg('run_id'),
+    pggen.arg('workspace_id')
+);
+
+-- name: FindAssessmentResultsByWorkspaceID :many
+SELECT *
+FROM assessment_results
+WHERE workspace_id = pggen.arg('workspace_id')
+ORDER BY created_at DESC
+;
+
+-- name: FindAssessmentResultByID :one
+SELECT *
+FROM assessment_results
+WHERE assessment_result_id = pggen.arg('assessment_result_id')
+;
+
+-- name: FindAssessmentResultsByStatus :many
+SELECT *
+FROM assessment_results
+WHERE status = pggen.arg('status')
+ORDER BY created_at
+;
+
+-- name: UpdateAssessmentResult :exec
+UPDATE assessment_results
+SET status = pggen.arg('status'),
+    drifted = pggen.arg('drifted'),
+    drifted_resources = pggen.arg('drifted_resources'),
+    error_message = pggen.arg('error_message'),
+    updated_at = pggen.arg('updated_at')
+WHERE assessment_result_id = pggen.arg('assessment_result_id')
+;
+
+-- name: FindWorkspaceIDsDueAssessment :many
+SELECT w.workspace_id
+FROM workspaces w
+WHERE w.assessments_enabled
+AND NOT EXISTS (
+    SELECT 1
+    FROM assessment_results ar
+    WHERE ar.workspace_id = w.workspace_id
+    AND (ar.status = 'pending' OR ar.created_at > pggen.arg('assessed_before'))
+)
+;
@@ -0,0 +1,56 @@
+-- name: InsertAuditEvent :exec
+INSERT INTO audit_events (
+    audit_event_id,
+    time,
+    organization_name,
+    action,
+    subject,
+    token_id,
+    source_ip,
+    resource_id,
+    justification
+) VALUES (
+    pggen.arg('audit_event_id'),
+    pggen.arg('time'),
+    pggen.arg('organization_name'),
+    pggen.arg('action'),
+    pggen.arg('subject'),
+    pggen.arg('token_id'),
+    pggen.arg('source_ip'),
+    pggen.arg('resource_id'),
+    pggen.arg('justification')
+);
+
+-- name: FindAuditEvents :many
+SELECT *
+FROM audit_events
+WHERE organization_name = pggen.arg('organization_name')
+AND   ((pggen.arg('action')::text IS NULL) OR action = pggen.arg('action'))
+ORDER BY time DESC
+LIMIT pggen.arg('limit')
+OFFSET pggen.arg('offset')
+;
+
+-- name: CountAuditEvents :one
+SELECT count(*)
+FROM audit_events
+WHERE organization_name = pggen.arg('organization_name')
+AND   ((pggen.arg('action')::text IS NULL) OR action = pggen.arg('action'))
+;
+
+-- name: UpsertAuditSettings :exec
+INSERT INTO audit_settings (
+    organization_name,
+    require_state_download_justification
+) VALUES (
+    pggen.arg('organization_name'),
+    pggen.arg('require_state_download_justification')
+)
+ON CONFLICT (organization_name) DO UPDATE
+SET require_state_download_justification = EXCLUDED.require_state_download_justification;
+
+-- name: FindAuditSettings :one
+SELECT *
+FROM audit_settings
+WHERE organization_name = pggen.arg('organization_name');
+
@@ -0,0 +1,29 @@
+-- name: InsertBanner :exec
+INSERT INTO banners (
+    banner_id,
+    created_at,
+    message,
+    level,
+    starts_at,
+    ends_at,
+    organizations
+) VALUES (
+    pggen.arg('banner_id'),
+    pggen.arg('created_at'),
+    pggen.arg('message'),
+    pggen.arg('level'),
+    pggen.arg('starts_at'),
+    pggen.arg('ends_at'),
+    pggen.arg('organizations')
+);
+
+-- name: FindBanners :many
+SELECT *
+FROM banners
+ORDER BY starts_at DESC;
+
+-- name: DeleteBannerByID :one
+DELETE
+FROM banners
+WHERE banner_id = pggen.arg('banner_id')
+RETURNING banner_id;
@@ -0,0 +1,90 @@
+-- InsertBlob inserts a blob. Blobs are content-addressed, so inserting a blob
+-- that already exists is a no-op.
+--
+-- name: InsertBlob :exec
+INSERT INTO blobs (
+    digest,
+    data,
+    size,
+    created_at
+) VALUES (
+    pggen.arg('digest'),
+    pggen.arg('data'),
+    pggen.arg('size'),
+    pggen.arg('created_at')
+)
+ON CONFLICT (digest) DO NOTHING;
+
+-- name: FindBlobByDigest :one
+SELECT data
+FROM blobs
+WHERE digest = pggen.arg('digest');
+
+-- InsertBlobUpload starts an upload of a blob.
+--
+-- name: InsertBlobUpload :exec
+INSERT INTO blob_uploads (
+    upload_id,
+    size,
+    created_at
+) VALUES (
+    pggen.arg('upload_id'),
+    0,
+    pggen.arg('created_at')
+);
+
+-- name: FindBlobUploadSizeForUpdate :one
+SELECT size
+FROM blob_uploads
+WHERE upload_id = pggen.arg('upload_id')
+FOR UPDATE;
+
+-- name: FindBlobUploadSize :one
+SELECT size
+FROM blob_uploads
+WHERE upload_id = pggen.arg('upload_id');
+
+-- name: InsertBlobUploadPart :exec
+INSERT INTO blob_upload_parts (
+    upload_id,
+    part_offset,
+    data
+) VALUES (
+    pggen.arg('upload_id'),
+    pggen.arg('part_offset'),
+    pggen.arg('data')
+);
+
+-- name: UpdateBlobUploadSize :exec
+UPDATE blob_uploads
+SET size = pggen.arg('size')
+WHERE upload_id = pggen.arg('upload_id');
+
+-- name: FindBlobUploadPart :one
+SELECT data
+FROM blob_upload_parts
+WHERE upload_id = pggen.arg('upload_id')
+AND   part_offset = pggen.arg('part_offset');
+
+-- InsertBlobFromUpload assembles the parts of an upload into a blob.
+--
+-- name: InsertBlobFromUpload :exec
+INSERT INTO blobs (
+    digest,
+    data,
+    size,
+    created_at
+)
+SELECT
+    pggen.arg('digest')::text,
+    coalesce(string_agg(data, ''::bytea ORDER BY part_offset), ''::bytea),
+    coalesce(sum(octet_length(data)), 0),
+    pggen.arg('created_at')::timestamptz
+FROM blob_upload_parts
+WHERE upload_id = pggen.arg('upload_id')
+ON CONFLICT (digest) DO NOTHING;
+
+-- name: DeleteBlobUpload :exec
+DELETE
+FROM blob_uploads
+WHERE upload_id = pggen.arg('upload_id');
@@ -0,0 +1,88 @@
+-- name: InsertBlobMigration :exec
+INSERT INTO blob_migrations (
+    migration_id,
+    created_at,
+    updated_at,
+    source,
+    destination,
+    status,
+    batch_size,
+    rate_limit,
+    total,
+    copied,
+    copied_bytes,
+    failed,
+    last_digest,
+    error,
+    completed_at
+) VALUES (
+    pggen.arg('migration_id'),
+    pggen.arg('created_at'),
+    pggen.arg('updated_at'),
+    pggen.arg('source'),
+    pggen.arg('destination'),
+    pggen.arg('status'),
+    pggen.arg('batch_size'),
+    pggen.arg('rate_limit'),
+    pggen.arg('total'),
+    pggen.arg('copied'),
+    pggen.arg('copied_bytes'),
+    pggen.arg('failed'),
+    pggen.arg('last_digest'),
+    pggen.arg('error'),
+    pggen.arg('completed_at')
+);
+
+-- name: FindBlobMigrations :many
+SELECT *
+FROM blob_migrations
+ORDER BY created_at DESC
+;
+
+-- name: FindBlobMigrationsByStatus :many
+SELECT *
+FROM blob_migrations
+WHERE status = pggen.arg('status')
+ORDER BY created_at
+;
+
+-- name: FindBlobMigrationByID :one
+SELECT *
+FROM blob_migrations
+WHERE migration_id = pggen.arg('migration_id')
+;
+
+-- name: FindBlobMigrationByIDForUpdate :one
+SELECT *
+FROM blob_migrations
+WHERE migration_id = pggen.arg('migration_id')
+FOR UPDATE
+;
+
+-- name: UpdateBlobMigration :exec
+UPDATE blob_migrations
+SET updated_at   = pggen.arg('updated_at'),
+    status       = pggen.arg('status'),
+    batch_size   = pggen.arg('batch_size'),
+    rate_limit   = pggen.arg('rate_limit'),
+    copied       = pggen.arg('copied'),
+    copied_bytes = pggen.arg('copied_bytes'),
+    failed       = pggen.arg('failed'),
+    last_digest  = pggen.arg('last_digest'),
+    error        = pggen.arg('error'),
+    completed_at = pggen.arg('completed_at')
+WHERE migration_id = pggen.arg('migration_id')
+;
+
+-- name: CountBlobs :one
+SELECT count(*)
+FROM blobs
+;
+
+-- name: FindBlobDigestsAfter :many
+SELECT digest, size
+FROM blobs
+WHERE digest > pggen.arg('after')
+ORDER BY digest
+LIMIT pggen.arg('limit')
+;
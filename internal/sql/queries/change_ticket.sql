@@ -0,0 +1,49 @@
+-- name: InsertChangeTicket :exec
+INSERT INTO change_tickets (
+    change_ticket_id,
+    run_id,
+    status,
+    external_id,
+    url,
+    message,
+    created_at,
+    updated_at
+) VALUES (
+    pggen.arg('change_ticket_id'),
+    pggen.arg('run_id'),
+    pggen.arg('status'),
+    pggen.arg('external_id'),
+    pggen.arg('url'),
+    pggen.arg('message'),
+    pggen.arg('created_at'),
+    pggen.arg('updated_at')
+) ON CONFLICT (run_id) DO NOTHING;
+
+-- name: FindChangeTicketByID :one
+SELECT *
+FROM change_tickets
+WHERE change_ticket_id = pggen.arg('change_ticket_id')
+;
+
+-- name: FindChangeTicketByIDForUpdate :one
+SELECT *
+FROM change_tickets
+WHERE change_ticket_id = pggen.arg('change_ticket_id')
+FOR UPDATE
+;
+
+-- name: FindChangeTicketByRunID :one
+SELECT *
+FROM change_tickets
+WHERE run_id = pggen.arg('run_id')
+;
+
+-- name: UpdateChangeTicket :exec
+UPDATE change_tickets
+SET status      = pggen.arg('status'),
+    external_id = pggen.arg('external_id'),
+    url         = pggen.arg('url'),
+    message     = pggen.arg('message'),
+    updated_at  = pggen.arg('updated_at')
+WHERE change_ticket_id = pggen.arg('change_ticket_id')
+;
@@ -41,6 +41,7 @@ SELECT
     configuration_versions.speculative,
     configuration_versions.status,
     configuration_versions.workspace_id,
+    configuration_versions.config_digest,
     (
         SELECT array_agg(t.*) AS configuration_version_status_timestamps
         FROM configuration_version_status_timestamps t
@@ -72,6 +73,7 @@ SELECT
     configuration_versions.speculative,
     configuration_versions.status,
     configuration_versions.workspace_id,
+    configuration_versions.config_digest,
     (
         SELECT array_agg(t.*) AS configuration_version_status_timestamps
         FROM configuration_version_status_timestamps t
@@ -93,6 +95,7 @@ SELECT
     configuration_versions.speculative,
     configuration_versions.status,
     configuration_versions.workspace_id,
+    configuration_versions.config_digest,
     (
         SELECT array_agg(t.*) AS configuration_version_status_timestamps
         FROM configuration_version_status_timestamps t
@@ -115,6 +118,7 @@ SELECT
     configuration_versions.speculative,
     configuration_versions.status,
     configuration_versions.workspace_id,
+    configuration_versions.config_digest,
     (
         SELECT array_agg(t.*) AS configuration_version_status_timestamps
         FROM configuration_version_status_timestamps t
@@ -128,11 +132,11 @@ LEFT JOIN ingress_attributes USING (configuration_version_id)
 WHERE configuration_version_id = pggen.arg('configuration_version_id')
 FOR UPDATE OF configuration_versions;
 
--- DownloadConfigurationVersion gets a configuration_version config
--- tarball.
+-- DownloadConfigurationVersion gets the digest of a configuration_version
+-- config tarball, with which the tarball can be retrieved from the blob store.
 --
 -- name: DownloadConfigurationVersion :one
-SELECT config
+SELECT config_digest
 FROM configuration_versions
 WHERE configuration_version_id = pggen.arg('configuration_version_id')
 AND   status                   = 'uploaded';
@@ -147,7 +151,7 @@ RETURNING configuration_version_id;
 -- name: UpdateConfigurationVersionConfigByID :one
 UPDATE configuration_versions
 SET
-    config = pggen.arg('config'),
+    config_digest = pggen.arg('config_digest'),
     status = 'uploaded'
 WHERE configuration_version_id = pggen.arg('id')
 RETURNING configuration_version_id;
@@ -157,3 +161,135 @@ DELETE
 FROM configuration_versions
 WHERE configuration_version_id = pggen.arg('id')
 RETURNING configuration_version_id;
+
+-- FindConfigurationVersionUsageByOrganization summarises the number and size of
+-- configuration versions in an organization, grouped by workspace and source.
+--
+-- name: FindConfigurationVersionUsageByOrganization :many
+SELECT
+    w.workspace_id,
+    w.name AS workspace_name,
+    cv.source,
+    count(*) AS count,
+    coalesce(sum(b.size), 0)::bigint AS bytes
+FROM configuration_versions cv
+JOIN workspaces w USING (workspace_id)
+LEFT JOIN blobs b ON b.digest = cv.config_digest
+WHERE w.organization_name = pggen.arg('organization_name')
+GROUP BY w.workspace_id, w.name, cv.source
+ORDER BY w.name, cv.source
+;
+
+-- FindConfigurationVersionStorageUsed sums the size of the configuration
+-- tarballs of all workspaces in the organization of the given workspace.
+--
+-- name: FindConfigurationVersionStorageUsed :one
+SELECT coalesce(sum(b.size), 0)::bigint AS bytes
+FROM configuration_versions cv
+JOIN workspaces w USING (workspace_id)
+JOIN blobs b ON b.digest = cv.config_digest
+WHERE w.organization_name = (
+    SELECT organization_name
+    FROM workspaces
+    WHERE workspace_id = pggen.arg('workspace_id')
+);
+
+-- name: InsertConfigurationVersionDependency :exec
+INSERT INTO configuration_version_dependencies (
+    configuration_version_id,
+    kind,
+    source,
+    version
+) VALUES (
+    pggen.arg('configuration_version_id'),
+    pggen.arg('kind'),
+    pggen.arg('source'),
+    pggen.arg('version')
+) ON CONFLICT DO NOTHING;
+
+-- FindDependencyConsumptionByOrganization retrieves the dependencies of the
+-- most recently uploaded configuration version of each workspace in an
+-- organization.
+--
+-- name: FindDependencyConsumptionByOrganization :many
+WITH latest AS (
+    SELECT DISTINCT ON (cv.workspace_id)
+        cv.configuration_version_id,
+        w.workspace_id,
+        w.name AS workspace_name
+    FROM configuration_versions cv
+    JOIN workspaces w USING (workspace_id)
+    WHERE w.organization_name = pggen.arg('organization_name')
+    AND cv.status = 'uploaded'
+    ORDER BY cv.workspace_id, cv.created_at DESC
+)
+SELECT
+    d.kind,
+    d.source,
+    d.version,
+    l.workspace_id,
+    l.workspace_name
+FROM configuration_version_dependencies d
+JOIN latest l USING (configuration_version_id)
+ORDER BY d.kind, d.source, d.version, l.workspace_name
+;
+
+-- name: FindConfigurationVersionWorkingDirectory :one
+SELECT w.working_directory
+FROM configuration_versions cv
+JOIN workspaces w USING (workspace_id)
+WHERE cv.configuration_version_id = pggen.arg('configuration_version_id')
+;
+
+-- name: FindConfigurationVersionDependencies :many
+SELECT *
+FROM configuration_version_dependencies
+WHERE configuration_version_id = pggen.arg('configuration_version_id')
+ORDER BY kind, source, version
+;
+
+-- name: InsertConfigurationVersionVariable :exec
+INSERT INTO configuration_version_variables (
+    configuration_version_id,
+    name,
+    type,
+    description,
+    default_value,
+    required,
+    sensitive
+) VALUES (
+    pggen.arg('configuration_version_id'),
+    pggen.arg('name'),
+    pggen.arg('type'),
+    pggen.arg('description'),
+    pggen.arg('default_value'),
+    pggen.arg('required'),
+    pggen.arg('sensitive')
+) ON CONFLICT DO NOTHING;
+
+-- name: FindConfigurationVersionVariables :many
+SELECT *
+FROM configuration_version_variables
+WHERE configuration_version_id = pggen.arg('configuration_version_id')
+ORDER BY name
+;
+
+-- name: InsertConfigurationVersionOutput :exec
+INSERT INTO configuration_version_outputs (
+    configuration_version_id,
+    name,
+    description,
+    sensitive
+) VALUES (
+    pggen.arg('configuration_version_id'),
+    pggen.arg('name'),
+    pggen.arg('description'),
+    pggen.arg('sensitive')
+) ON CONFLICT DO NOTHING;
+
+-- name: FindConfigurationVersionOutputs :many
+SELECT *
+FROM configuration_version_outputs
+WHERE configuration_version_id = pggen.arg('configuration_version_id')
+ORDER BY name
+;
@@ -0,0 +1,8 @@
+-- name: InsertConfigurationVersionCreator :exec
+INSERT INTO configuration_version_creators (
+    configuration_version_id,
+    created_by
+) VALUES (
+    pggen.arg('configuration_version_id'),
+    pggen.arg('created_by')
+);
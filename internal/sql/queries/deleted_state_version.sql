@@ -0,0 +1,93 @@
+-- name: InsertDeletedStateVersionsByWorkspaceID :exec
+INSERT INTO deleted_state_versions (
+    state_version_id,
+    workspace_id,
+    created_at,
+    serial,
+    state
+)
+SELECT
+    state_version_id,
+    workspace_id,
+    created_at,
+    serial,
+    state
+FROM state_versions
+WHERE workspace_id = pggen.arg('workspace_id')
+AND   status = 'finalized'
+;
+
+-- name: InsertDeletedStateVersionOutputsByWorkspaceID :exec
+INSERT INTO deleted_state_version_outputs (
+    state_version_output_id,
+    state_version_id,
+    name,
+    sensitive,
+    type,
+    value
+)
+SELECT
+    svo.state_version_output_id,
+    svo.state_version_id,
+    svo.name,
+    svo.sensitive,
+    svo.type,
+    svo.value
+FROM state_version_outputs svo
+JOIN state_versions sv USING (state_version_id)
+WHERE sv.workspace_id = pggen.arg('workspace_id')
+AND   sv.status = 'finalized'
+;
+
+-- name: RestoreStateVersionsByWorkspaceID :exec
+INSERT INTO state_versions (
+    state_version_id,
+    created_at,
+    serial,
+    state,
+    status,
+    workspace_id
+)
+SELECT
+    state_version_id,
+    created_at,
+    serial,
+    state,
+    'finalized',
+    workspace_id
+FROM deleted_state_versions
+WHERE workspace_id = pggen.arg('workspace_id')
+;
+
+-- name: RestoreStateVersionOutputsByWorkspaceID :exec
+INSERT INTO state_version_outputs (
+    state_version_output_id,
+    name,
+    sensitive,
+    type,
+    value,
+    state_version_id
+)
+SELECT
+    svo.state_version_output_id,
+    svo.name,
+    svo.sensitive,
+    svo.type,
+    svo.value,
+    svo.state_version_id
+FROM deleted_state_version_outputs svo
+JOIN deleted_state_versions sv USING (state_version_id)
+WHERE sv.workspace_id = pggen.arg('workspace_id')
+;
+
+-- name: RestoreWorkspaceCurrentStateVersionID :exec
+UPDATE workspaces
+SET current_state_version_id = (
+    SELECT state_version_id
+    FROM deleted_state_versions
+    WHERE workspace_id = pggen.arg('workspace_id')
+    ORDER BY created_at DESC
+    LIMIT 1
+)
+WHERE workspace_id = pggen.arg('workspace_id')
+;
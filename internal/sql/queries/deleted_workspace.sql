@@ -0,0 +1,56 @@
+-- name: InsertDeletedWorkspace :exec
+INSERT INTO deleted_workspaces (
+    workspace_id,
+    organization_name,
+    name,
+    deleted_at,
+    deleted_by,
+    workspace
+) VALUES (
+    pggen.arg('workspace_id'),
+    pggen.arg('organization_name'),
+    pggen.arg('name'),
+    pggen.arg('deleted_at'),
+    pggen.arg('deleted_by'),
+    pggen.arg('workspace')
+);
+
+-- name: FindDeletedWorkspacesByOrganization :many
+SELECT *
+FROM deleted_workspaces
+WHERE organization_name = pggen.arg('organization_name')
+ORDER BY deleted_at DESC
+LIMIT pggen.arg('limit')
+OFFSET pggen.arg('offset')
+;
+
+-- name: CountDeletedWorkspacesByOrganization :one
+SELECT count(*)
+FROM deleted_workspaces
+WHERE organization_name = pggen.arg('organization_name')
+;
+
+-- name: FindDeletedWorkspaceByID :one
+SELECT *
+FROM deleted_workspaces
+WHERE workspace_id = pggen.arg('workspace_id')
+;
+
+-- name: FindDeletedWorkspaceByIDForUpdate :one
+SELECT *
+FROM deleted_workspaces
+WHERE workspace_id = pggen.arg('workspace_id')
+FOR UPDATE
+;
+
+-- name: DeleteDeletedWorkspaceByID :exec
+DELETE
+FROM deleted_workspaces
+WHERE workspace_id = pggen.arg('workspace_id')
+;
+
+-- name: DeleteDeletedWorkspacesBefore :exec
+DELETE
+FROM deleted_workspaces
+WHERE deleted_at < pggen.arg('deleted_before')
+;
@@ -0,0 +1,42 @@
+-- name: InsertEmailMessage :exec
+INSERT INTO email_messages (
+    email_message_id,
+    created_at,
+    recipients,
+    subject,
+    body,
+    digest_key,
+    attempts,
+    next_attempt_at
+) VALUES (
+    pggen.arg('email_message_id'),
+    pggen.arg('created_at'),
+    pggen.arg('recipients'),
+    pggen.arg('subject'),
+    pggen.arg('body'),
+    pggen.arg('digest_key'),
+    pggen.arg('attempts'),
+    pggen.arg('next_attempt_at')
+);
+
+-- name: FindDueEmailMessages :many
+SELECT *
+FROM email_messages
+WHERE next_attempt_at <= pggen.arg('now')
+ORDER BY created_at
+;
+
+-- name: UpdateEmailMessageAttempt :exec
+UPDATE email_messages
+SET
+    attempts        = pggen.arg('attempts'),
+    next_attempt_at = pggen.arg('next_attempt_at'),
+    last_error      = pggen.arg('last_error')
+WHERE email_message_id = pggen.arg('email_message_id')
+;
+
+-- name: DeleteEmailMessage :exec
+DELETE
+FROM email_messages
+WHERE email_message_id = pggen.arg('email_message_id')
+;
@@ -0,0 +1,27 @@
+-- name: UpsertFeatureFlag :exec
+INSERT INTO feature_flags (
+    name,
+    organization_name,
+    enabled,
+    updated_at
+) VALUES (
+    pggen.arg('name'),
+    pggen.arg('organization_name'),
+    pggen.arg('enabled'),
+    pggen.arg('updated_at')
+)
+ON CONFLICT (name, COALESCE(organization_name, '')) DO UPDATE
+SET enabled    = EXCLUDED.enabled,
+    updated_at = EXCLUDED.updated_at;
+
+-- name: FindFeatureFlags :many
+SELECT *
+FROM feature_flags
+ORDER BY name, organization_name NULLS FIRST;
+
+-- name: DeleteFeatureFlag :one
+DELETE
+FROM feature_flags
+WHERE name = pggen.arg('name')
+AND organization_name IS NOT DISTINCT FROM pggen.arg('organization_name')
+RETURNING name;
@@ -75,6 +75,22 @@ JOIN workspaces w USING (workspace_id)
 WHERE j.agent_id = pggen.arg('agent_id')
 AND   j.status = 'allocated';
 
+-- name: FindRunningJobs :many
+SELECT
+    j.run_id,
+    j.phase,
+    j.status,
+    j.signaled,
+    j.agent_id,
+    w.agent_pool_id,
+    r.workspace_id,
+    w.organization_name
+FROM jobs j
+JOIN runs r USING (run_id)
+JOIN workspaces w USING (workspace_id)
+WHERE j.agent_id = pggen.arg('agent_id')
+AND   j.status = 'running';
+
 -- Find signaled jobs and then immediately update signal with null.
 --
 -- name: FindAndUpdateSignaledJobs :many
@@ -105,3 +121,12 @@ SET status   = pggen.arg('status'),
 WHERE run_id = pggen.arg('run_id')
 AND   phase = pggen.arg('phase')
 RETURNING *;
+
+-- DeleteJob deletes the job for a run phase, i.e. a previous job for a phase
+-- that is being retried.
+--
+-- name: DeleteJob :exec
+DELETE
+FROM jobs
+WHERE run_id = pggen.arg('run_id')
+AND   phase = pggen.arg('phase');
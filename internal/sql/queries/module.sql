@@ -184,3 +184,9 @@ FROM module_versions
 WHERE module_version_id = pggen.arg('module_version_id')
 RETURNING module_version_id
 ;
+
+-- name: FindConnectedModuleIDs :many
+SELECT module_id
+FROM repo_connections
+WHERE module_id IS NOT NULL
+;
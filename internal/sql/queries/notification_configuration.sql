@@ -8,7 +8,8 @@ INSERT INTO notification_configurations (
     triggers,
     destination_type,
     enabled,
-    workspace_id
+    workspace_id,
+    email_addresses
 ) VALUES (
     pggen.arg('notification_configuration_id'),
     pggen.arg('created_at'),
@@ -18,7 +19,8 @@ INSERT INTO notification_configurations (
     pggen.arg('triggers'),
     pggen.arg('destination_type'),
     pggen.arg('enabled'),
-    pggen.arg('workspace_id')
+    pggen.arg('workspace_id'),
+    pggen.arg('email_addresses')
 )
 ;
 
@@ -53,7 +55,8 @@ SET
     enabled    = pggen.arg('enabled'),
     name       = pggen.arg('name'),
     triggers   = pggen.arg('triggers'),
-    url        = pggen.arg('url')
+    url        = pggen.arg('url'),
+    email_addresses = pggen.arg('email_addresses')
 WHERE notification_configuration_id = pggen.arg('notification_configuration_id')
 RETURNING notification_configuration_id
 ;
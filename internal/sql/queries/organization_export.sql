@@ -0,0 +1,67 @@
+-- name: InsertOrganizationExportStream :exec
+INSERT INTO organization_export_streams (
+    organization_name,
+    created_at,
+    exported_sequence
+) VALUES (
+    pggen.arg('organization_name'),
+    pggen.arg('created_at'),
+    0
+);
+
+-- name: SnapshotOrganization :exec
+SELECT snapshot_organization(pggen.arg('organization_name'));
+
+-- name: FindOrganizationExportStreams :many
+SELECT
+    s.*,
+    (
+        SELECT count(*)
+        FROM organization_changes c
+        WHERE c.organization_name = s.organization_name
+    ) AS pending
+FROM organization_export_streams s
+ORDER BY s.organization_name ASC
+;
+
+-- name: FindOrganizationExportStream :one
+SELECT
+    s.*,
+    (
+        SELECT count(*)
+        FROM organization_changes c
+        WHERE c.organization_name = s.organization_name
+    ) AS pending
+FROM organization_export_streams s
+WHERE s.organization_name = pggen.arg('organization_name')
+;
+
+-- name: UpdateOrganizationExportStream :exec
+UPDATE organization_export_streams
+SET
+    exported_sequence = pggen.arg('exported_sequence'),
+    exported_at = pggen.arg('exported_at'),
+    last_error = pggen.arg('last_error')
+WHERE organization_name = pggen.arg('organization_name')
+;
+
+-- name: DeleteOrganizationExportStream :one
+DELETE
+FROM organization_export_streams
+WHERE organization_name = pggen.arg('organization_name')
+RETURNING organization_name
+;
+
+-- name: FindOrganizationChanges :many
+SELECT *
+FROM organization_changes
+WHERE organization_name = pggen.arg('organization_name')
+ORDER BY sequence ASC
+LIMIT pggen.arg('limit')
+;
+
+-- name: DeleteOrganizationChanges :exec
+DELETE
+FROM organization_changes
+WHERE sequence = ANY(pggen.arg('sequences'))
+;
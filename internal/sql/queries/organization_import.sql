@@ -0,0 +1,77 @@
+-- name: InsertOrganizationImport :exec
+INSERT INTO organization_imports (
+    organization_import_id,
+    created_at,
+    updated_at,
+    organization_name,
+    source_address,
+    source_organization,
+    status,
+    error
+) VALUES (
+    pggen.arg('organization_import_id'),
+    pggen.arg('created_at'),
+    pggen.arg('updated_at'),
+    pggen.arg('organization_name'),
+    pggen.arg('source_address'),
+    pggen.arg('source_organization'),
+    pggen.arg('status'),
+    pggen.arg('error')
+);
+
+-- name: FindOrganizationImport :one
+SELECT *
+FROM organization_imports
+WHERE organization_import_id = pggen.arg('organization_import_id')
+;
+
+-- name: FindOrganizationImportsByOrganization :many
+SELECT *
+FROM organization_imports
+WHERE organization_name = pggen.arg('organization_name')
+ORDER BY created_at DESC
+;
+
+-- name: UpdateOrganizationImportStatus :exec
+UPDATE organization_imports
+SET status = pggen.arg('status'),
+    error = pggen.arg('error'),
+    updated_at = pggen.arg('updated_at')
+WHERE organization_import_id = pggen.arg('organization_import_id')
+;
+
+-- name: InsertOrganizationImportItem :exec
+INSERT INTO organization_import_items (
+    organization_import_id,
+    kind,
+    source_id,
+    target_id
+) VALUES (
+    pggen.arg('organization_import_id'),
+    pggen.arg('kind'),
+    pggen.arg('source_id'),
+    pggen.arg('target_id')
+);
+
+-- name: FindOrganizationImportItems :many
+SELECT *
+FROM organization_import_items
+WHERE organization_import_id = pggen.arg('organization_import_id')
+;
+
+-- name: TouchOrganizationImports :exec
+UPDATE organization_imports
+SET updated_at = pggen.arg('updated_at')
+WHERE organization_import_id = ANY(pggen.arg('organization_import_ids')::text[])
+AND   status = 'running'
+;
+
+-- name: UpdateInterruptedOrganizationImports :many
+UPDATE organization_imports
+SET status = 'errored',
+    error = pggen.arg('error'),
+    updated_at = pggen.arg('updated_at')
+WHERE status = 'running'
+AND   updated_at < pggen.arg('cutoff')
+RETURNING organization_import_id
+;
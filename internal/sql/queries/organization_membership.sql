@@ -0,0 +1,36 @@
+-- name: InsertOrganizationMembership :one
+INSERT INTO organization_memberships (
+    organization_membership_id,
+    created_at,
+    organization_name,
+    username
+) VALUES (
+    pggen.arg('organization_membership_id'),
+    pggen.arg('created_at'),
+    pggen.arg('organization_name'),
+    pggen.arg('username')
+)
+ON CONFLICT (organization_name, username) DO UPDATE
+SET username = EXCLUDED.username
+RETURNING *
+;
+
+-- name: FindOrganizationMembershipByID :one
+SELECT *
+FROM organization_memberships
+WHERE organization_membership_id = pggen.arg('organization_membership_id')
+;
+
+-- name: FindOrganizationMembershipsByOrganization :many
+SELECT *
+FROM organization_memberships
+WHERE organization_name = pggen.arg('organization_name')
+ORDER BY created_at ASC
+;
+
+-- name: DeleteOrganizationMembership :one
+DELETE
+FROM organization_memberships
+WHERE organization_membership_id = pggen.arg('organization_membership_id')
+RETURNING *
+;
@@ -1,36 +1,78 @@
+-- UpsertOrganizationToken creates an organization's unnamed token, replacing
+-- any existing unnamed token.
+--
 -- name: UpsertOrganizationToken :exec
 INSERT INTO organization_tokens (
     organization_token_id,
     created_at,
     organization_name,
-    expiry
+    expiry,
+    created_by
 ) VALUES (
     pggen.arg('organization_token_id'),
     pggen.arg('created_at'),
     pggen.arg('organization_name'),
-    pggen.arg('expiry')
-) ON CONFLICT (organization_name) DO UPDATE
+    pggen.arg('expiry'),
+    pggen.arg('created_by')
+) ON CONFLICT (organization_name) WHERE description = '' DO UPDATE
   SET created_at            = pggen.arg('created_at'),
       organization_token_id = pggen.arg('organization_token_id'),
-      expiry                = pggen.arg('expiry');
+      expiry                = pggen.arg('expiry'),
+      created_by            = pggen.arg('created_by'),
+      last_used_at          = NULL;
+
+-- name: InsertOrganizationToken :exec
+INSERT INTO organization_tokens (
+    organization_token_id,
+    created_at,
+    organization_name,
+    expiry,
+    description,
+    created_by
+) VALUES (
+    pggen.arg('organization_token_id'),
+    pggen.arg('created_at'),
+    pggen.arg('organization_name'),
+    pggen.arg('expiry'),
+    pggen.arg('description'),
+    pggen.arg('created_by')
+);
 
 -- name: FindOrganizationTokens :many
 SELECT *
 FROM organization_tokens
-WHERE organization_name = pggen.arg('organization_name');
+WHERE organization_name = pggen.arg('organization_name')
+ORDER BY created_at;
 
+-- FindOrganizationTokensByName finds an organization's unnamed token.
+--
 -- name: FindOrganizationTokensByName :one
 SELECT *
 FROM organization_tokens
-WHERE organization_name = pggen.arg('organization_name');
+WHERE organization_name = pggen.arg('organization_name')
+AND   description = '';
 
 -- name: FindOrganizationTokensByID :one
 SELECT *
 FROM organization_tokens
 WHERE organization_token_id = pggen.arg('organization_token_id');
 
+-- name: UpdateOrganizationTokenLastUsedAt :exec
+UPDATE organization_tokens
+SET last_used_at = pggen.arg('last_used_at')
+WHERE organization_token_id = pggen.arg('organization_token_id');
+
+-- DeleteOrganiationTokenByName deletes an organization's unnamed token.
+--
 -- name: DeleteOrganiationTokenByName :one
 DELETE
 FROM organization_tokens
 WHERE organization_name = pggen.arg('organization_name')
+AND   description = ''
+RETURNING organization_token_id;
+
+-- name: DeleteOrganizationTokenByID :one
+DELETE
+FROM organization_tokens
+WHERE organization_token_id = pggen.arg('organization_token_id')
 RETURNING organization_token_id;
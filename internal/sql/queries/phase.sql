@@ -51,3 +51,34 @@ SELECT
 FROM logs
 WHERE chunk_id = pggen.arg('chunk_id')
 ;
+
+-- DeleteLogs deletes all the logs for the given run and phase.
+--
+-- name: DeleteLogs :exec
+DELETE
+FROM logs
+WHERE run_id = pggen.arg('run_id')
+AND   phase  = pggen.arg('phase')
+;
+
+-- FindRecentRunLogs retrieves the logs of each phase of the most recent runs
+-- of a workspace, newest run first.
+--
+-- name: FindRecentRunLogs :many
+WITH recent AS (
+    SELECT run_id, created_at
+    FROM runs
+    WHERE workspace_id = pggen.arg('workspace_id')
+    ORDER BY created_at DESC
+    LIMIT pggen.arg('limit')
+)
+SELECT
+    r.run_id,
+    r.created_at,
+    l.phase,
+    string_agg(l.chunk, '' ORDER BY l.chunk_id) AS logs
+FROM recent r
+JOIN logs l USING (run_id)
+GROUP BY r.run_id, r.created_at, l.phase
+ORDER BY r.created_at DESC, l.phase DESC
+;
@@ -0,0 +1,68 @@
+-- name: InsertPolicyCheck :exec
+INSERT INTO policy_checks (
+    policy_check_id,
+    created_at,
+    updated_at,
+    status,
+    passed,
+    advisory_failed,
+    soft_failed,
+    hard_failed,
+    error_message,
+    outcomes,
+    run_id
+) VALUES (
+    pggen.arg('policy_check_id'),
+    pggen.arg('created_at'),
+    pggen.arg('updated_at'),
+    pggen.arg('status'),
+    pggen.arg('passed'),
+    pggen.arg('advisory_failed'),
+    pggen.arg('soft_failed'),
+    pggen.arg('hard_failed'),
+    pggen.arg('error_message'),
+    pggen.arg('outcomes'),
+    pggen.arg('run_id')
+);
+
+-- name: FindPolicyChecksByRunID :many
+SELECT *
+FROM policy_checks
+WHERE run_id = pggen.arg('run_id')
+ORDER BY created_at ASC
+;
+
+-- name: FindPolicyCheckByID :one
+SELECT *
+FROM policy_checks
+WHERE policy_check_id = pggen.arg('policy_check_id')
+;
+
+-- name: FindPolicyCheckByIDForUpdate :one
+SELECT *
+FROM policy_checks
+WHERE policy_check_id = pggen.arg('policy_check_id')
+FOR UPDATE
+;
+
+-- name: FindQueuedPolicyChecks :many
+SELECT *
+FROM policy_checks
+WHERE status = 'queued'
+ORDER BY created_at ASC
+;
+
+-- name: UpdatePolicyCheck :exec
+UPDATE policy_checks
+SET status = pggen.arg('status'),
+    passed = pggen.arg('passed'),
+    advisory_failed = pggen.arg('advisory_failed'),
+    soft_failed = pggen.arg('soft_failed'),
+    hard_failed = pggen.arg('hard_failed'),
+    error_message = pggen.arg('error_message'),
+    outcomes = pggen.arg('outcomes'),
+    completed_at = pggen.arg('completed_at'),
+    overridden_at = pggen.arg('overridden_at'),
+    updated_at = pggen.arg('updated_at')
+WHERE policy_check_id = pggen.arg('policy_check_id')
+;
@@ -0,0 +1,333 @@
+-- name: InsertPolicySet :exec
+INSERT INTO policy_sets (
+    policy_set_id,
+    created_at,
+    updated_at,
+    name,
+    description,
+    kind,
+    overridable,
+    global,
+    organization_name
+) VALUES (
+    pggen.arg('policy_set_id'),
+    pggen.arg('created_at'),
+    pggen.arg('updated_at'),
+    pggen.arg('name'),
+    pggen.arg('description'),
+    pggen.arg('kind'),
+    pggen.arg('overridable'),
+    pggen.arg('global'),
+    pggen.arg('organization_name')
+);
+
+-- Find policy sets in an organization, optionally filtering by any combination of:
+-- (a) name_substring: policy set name contains substring
+-- (b) kind: policy set kind
+--
+-- name: FindPolicySetsByOrganization :many
+SELECT ps.*,
+    (
+        SELECT array_agg(psw.workspace_id)
+        FROM policy_set_workspaces psw
+        WHERE psw.policy_set_id = ps.policy_set_id
+    ) AS workspace_ids,
+    (
+        SELECT v.policy_set_version_id
+        FROM policy_set_versions v
+        WHERE v.policy_set_id = ps.policy_set_id
+        ORDER BY v.created_at DESC
+        LIMIT 1
+    ) AS newest_version_id,
+    (
+        SELECT v.policy_set_version_id
+        FROM policy_set_versions v
+        WHERE v.policy_set_id = ps.policy_set_id
+        AND v.status = 'ready'
+        ORDER BY v.created_at DESC
+        LIMIT 1
+    ) AS current_version_id,
+    (
+        SELECT v.policy_count
+        FROM policy_set_versions v
+        WHERE v.policy_set_id = ps.policy_set_id
+        AND v.status = 'ready'
+        ORDER BY v.created_at DESC
+        LIMIT 1
+    ) AS policy_count
+FROM policy_sets ps
+WHERE ps.organization_name = pggen.arg('organization_name')
+AND   ((pggen.arg('name_substring')::text IS NULL) OR ps.name LIKE '%' || pggen.arg('name_substring') || '%')
+AND   ((pggen.arg('kind')::text IS NULL) OR ps.kind = pggen.arg('kind'))
+ORDER BY ps.name ASC
+;
+
+-- FindPolicySetsByWorkspaceID finds the policy sets that apply to a workspace,
+-- i.e. global policy sets in the workspace's organization and policy sets to
+-- which the workspace has been added.
+--
+-- name: FindPolicySetsByWorkspaceID :many
+SELECT ps.*,
+    (
+        SELECT array_agg(psw.workspace_id)
+        FROM policy_set_workspaces psw
+        WHERE psw.policy_set_id = ps.policy_set_id
+    ) AS workspace_ids,
+    (
+        SELECT v.policy_set_version_id
+        FROM policy_set_versions v
+        WHERE v.policy_set_id = ps.policy_set_id
+        ORDER BY v.created_at DESC
+        LIMIT 1
+    ) AS newest_version_id,
+    (
+        SELECT v.policy_set_version_id
+        FROM policy_set_versions v
+        WHERE v.policy_set_id = ps.policy_set_id
+        AND v.status = 'ready'
+        ORDER BY v.created_at DESC
+        LIMIT 1
+    ) AS current_version_id,
+    (
+        SELECT v.policy_count
+        FROM policy_set_versions v
+        WHERE v.policy_set_id = ps.policy_set_id
+        AND v.status = 'ready'
+        ORDER BY v.created_at DESC
+        LIMIT 1
+    ) AS policy_count
+FROM policy_sets ps
+JOIN workspaces w USING (organization_name)
+WHERE w.workspace_id = pggen.arg('workspace_id')
+AND (
+    ps.global
+    OR EXISTS (
+        SELECT 1
+        FROM policy_set_workspaces psw
+        WHERE psw.policy_set_id = ps.policy_set_id
+        AND psw.workspace_id = w.workspace_id
+    )
+)
+ORDER BY ps.name ASC
+;
+
+-- name: FindPolicySetByID :one
+SELECT ps.*,
+    (
+        SELECT array_agg(psw.workspace_id)
+        FROM policy_set_workspaces psw
+        WHERE psw.policy_set_id = ps.policy_set_id
+    ) AS workspace_ids,
+    (
+        SELECT v.policy_set_version_id
+        FROM policy_set_versions v
+        WHERE v.policy_set_id = ps.policy_set_id
+        ORDER BY v.created_at DESC
+        LIMIT 1
+    ) AS newest_version_id,
+    (
+        SELECT v.policy_set_version_id
+        FROM policy_set_versions v
+        WHERE v.policy_set_id = ps.policy_set_id
+        AND v.status = 'ready'
+        ORDER BY v.created_at DESC
+        LIMIT 1
+    ) AS current_version_id,
+    (
+        SELECT v.policy_count
+        FROM policy_set_versions v
+        WHERE v.policy_set_id = ps.policy_set_id
+        AND v.status = 'ready'
+        ORDER BY v.created_at DESC
+        LIMIT 1
+    ) AS policy_count
+FROM policy_sets ps
+WHERE ps.policy_set_id = pggen.arg('policy_set_id')
+;
+
+-- name: FindPolicySetByIDForUpdate :one
+SELECT ps.*,
+    (
+        SELECT array_agg(psw.workspace_id)
+        FROM policy_set_workspaces psw
+        WHERE psw.policy_set_id = ps.policy_set_id
+    ) AS workspace_ids,
+    (
+        SELECT v.policy_set_version_id
+        FROM policy_set_versions v
+        WHERE v.policy_set_id = ps.policy_set_id
+        ORDER BY v.created_at DESC
+        LIMIT 1
+    ) AS newest_version_id,
+    (
+        SELECT v.policy_set_version_id
+        FROM policy_set_versions v
+        WHERE v.policy_set_id = ps.policy_set_id
+        AND v.status = 'ready'
+        ORDER BY v.created_at DESC
+        LIMIT 1
+    ) AS current_version_id,
+    (
+        SELECT v.policy_count
+        FROM policy_set_versions v
+        WHERE v.policy_set_id = ps.policy_set_id
+        AND v.status = 'ready'
+        ORDER BY v.created_at DESC
+        LIMIT 1
+    ) AS policy_count
+FROM policy_sets ps
+WHERE ps.policy_set_id = pggen.arg('policy_set_id')
+FOR UPDATE OF ps
+;
+
+-- name: UpdatePolicySetByID :one
+UPDATE policy_sets
+SET
+    updated_at  = pggen.arg('updated_at'),
+    name        = pggen.arg('name'),
+    description = pggen.arg('description'),
+    overridable = pggen.arg('overridable'),
+    global      = pggen.arg('global')
+WHERE policy_set_id = pggen.arg('policy_set_id')
+RETURNING policy_set_id
+;
+
+-- name: DeletePolicySetByID :one
+DELETE
+FROM policy_sets
+WHERE policy_set_id = pggen.arg('policy_set_id')
+RETURNING policy_set_id
+;
+
+-- name: InsertPolicySetWorkspace :exec
+INSERT INTO policy_set_workspaces (
+    policy_set_id,
+    workspace_id
+) VALUES (
+    pggen.arg('policy_set_id'),
+    pggen.arg('workspace_id')
+) ON CONFLICT DO NOTHING;
+
+-- name: DeletePolicySetWorkspace :exec
+DELETE
+FROM policy_set_workspaces
+WHERE policy_set_id = pggen.arg('policy_set_id')
+AND   workspace_id = pggen.arg('workspace_id')
+;
+
+-- name: InsertPolicySetParameter :exec
+INSERT INTO policy_set_parameters (
+    policy_set_parameter_id,
+    key,
+    value,
+    sensitive,
+    policy_set_id
+) VALUES (
+    pggen.arg('policy_set_parameter_id'),
+    pggen.arg('key'),
+    pggen.arg('value'),
+    pggen.arg('sensitive'),
+    pggen.arg('policy_set_id')
+);
+
+-- name: FindPolicySetParameters :many
+SELECT *
+FROM policy_set_parameters
+WHERE policy_set_id = pggen.arg('policy_set_id')
+ORDER BY key ASC
+;
+
+-- name: FindPolicySetParameterByID :one
+SELECT *
+FROM policy_set_parameters
+WHERE policy_set_parameter_id = pggen.arg('policy_set_parameter_id')
+;
+
+-- name: FindPolicySetParameterByIDForUpdate :one
+SELECT *
+FROM policy_set_parameters
+WHERE policy_set_parameter_id = pggen.arg('policy_set_parameter_id')
+FOR UPDATE
+;
+
+-- name: UpdatePolicySetParameterByID :one
+UPDATE policy_set_parameters
+SET
+    key       = pggen.arg('key'),
+    value     = pggen.arg('value'),
+    sensitive = pggen.arg('sensitive')
+WHERE policy_set_parameter_id = pggen.arg('policy_set_parameter_id')
+RETURNING policy_set_parameter_id
+;
+
+-- name: DeletePolicySetParameterByID :one
+DELETE
+FROM policy_set_parameters
+WHERE policy_set_parameter_id = pggen.arg('policy_set_parameter_id')
+RETURNING policy_set_parameter_id
+;
+
+-- name: InsertPolicySetVersion :exec
+INSERT INTO policy_set_versions (
+    policy_set_version_id,
+    created_at,
+    updated_at,
+    status,
+    error_message,
+    policy_count,
+    policy_set_id
+) VALUES (
+    pggen.arg('policy_set_version_id'),
+    pggen.arg('created_at'),
+    pggen.arg('updated_at'),
+    pggen.arg('status'),
+    pggen.arg('error_message'),
+    pggen.arg('policy_count'),
+    pggen.arg('policy_set_id')
+);
+
+-- name: FindPolicySetVersionByID :one
+SELECT
+    policy_set_version_id,
+    created_at,
+    updated_at,
+    status,
+    error_message,
+    policy_count,
+    policy_set_id
+FROM policy_set_versions
+WHERE policy_set_version_id = pggen.arg('policy_set_version_id')
+;
+
+-- name: FindPolicySetVersionByIDForUpdate :one
+SELECT
+    policy_set_version_id,
+    created_at,
+    updated_at,
+    status,
+    error_message,
+    policy_count,
+    policy_set_id
+FROM policy_set_versions
+WHERE policy_set_version_id = pggen.arg('policy_set_version_id')
+FOR UPDATE
+;
+
+-- name: UpdatePolicySetVersionByID :one
+UPDATE policy_set_versions
+SET
+    updated_at    = pggen.arg('updated_at'),
+    status        = pggen.arg('status'),
+    error_message = pggen.arg('error_message'),
+    policy_count  = pggen.arg('policy_count'),
+    bundle        = pggen.arg('bundle')
+WHERE policy_set_version_id = pggen.arg('policy_set_version_id')
+RETURNING policy_set_version_id
+;
+
+-- name: DownloadPolicySetVersion :one
+SELECT bundle
+FROM policy_set_versions
+WHERE policy_set_version_id = pggen.arg('policy_set_version_id')
+AND   status = 'ready'
+;
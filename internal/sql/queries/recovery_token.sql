@@ -0,0 +1,33 @@
+-- name: InsertRecoveryToken :exec
+INSERT INTO recovery_tokens (
+    token_hash,
+    created_at
+) VALUES (
+    pggen.arg('token_hash'),
+    pggen.arg('created_at')
+);
+
+-- name: FindRecoveryTokenByHash :one
+SELECT *
+FROM recovery_tokens
+WHERE token_hash = pggen.arg('token_hash')
+;
+
+-- name: CountRecoveryTokens :one
+SELECT count(*)
+FROM recovery_tokens
+;
+
+-- name: DeleteUnusedRecoveryTokens :exec
+DELETE
+FROM recovery_tokens
+WHERE used_at IS NULL
+;
+
+-- name: UseRecoveryToken :one
+UPDATE recovery_tokens
+SET used_at = pggen.arg('used_at')
+WHERE token_hash = pggen.arg('token_hash')
+AND used_at IS NULL
+RETURNING token_hash
+;
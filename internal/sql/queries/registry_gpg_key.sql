@@ -0,0 +1,44 @@
+-- name: InsertRegistryGPGKey :exec
+INSERT INTO registry_gpg_keys (
+    registry_gpg_key_id,
+    created_at,
+    updated_at,
+    key_id,
+    ascii_armor,
+    organization_name
+) VALUES (
+    pggen.arg('registry_gpg_key_id'),
+    pggen.arg('created_at'),
+    pggen.arg('updated_at'),
+    pggen.arg('key_id'),
+    pggen.arg('ascii_armor'),
+    pggen.arg('organization_name')
+);
+
+-- name: FindRegistryGPGKeysByOrganization :many
+SELECT *
+FROM registry_gpg_keys
+WHERE organization_name = pggen.arg('organization_name')
+ORDER BY created_at
+;
+
+-- name: FindRegistryGPGKey :one
+SELECT *
+FROM registry_gpg_keys
+WHERE organization_name = pggen.arg('organization_name')
+AND   key_id = pggen.arg('key_id')
+;
+
+-- name: UpdateRegistryGPGKeyOrganization :one
+UPDATE registry_gpg_keys
+SET organization_name = pggen.arg('new_organization_name'),
+    updated_at = pggen.arg('updated_at')
+WHERE registry_gpg_key_id = pggen.arg('registry_gpg_key_id')
+RETURNING *
+;
+
+-- name: DeleteRegistryGPGKeyByID :exec
+DELETE
+FROM registry_gpg_keys
+WHERE registry_gpg_key_id = pggen.arg('registry_gpg_key_id')
+;
@@ -5,13 +5,15 @@ WITH inserted AS (
         vcs_id,
         vcs_provider_id,
         secret,
-        repo_path
+        repo_path,
+        group_hook
     ) VALUES (
         pggen.arg('repohook_id'),
         pggen.arg('vcs_id'),
         pggen.arg('vcs_provider_id'),
         pggen.arg('secret'),
-        pggen.arg('repo_path')
+        pggen.arg('repo_path'),
+        pggen.arg('group_hook')
     )
     RETURNING *
 )
@@ -21,6 +23,7 @@ SELECT
     w.vcs_provider_id,
     w.secret,
     w.repo_path,
+    w.group_hook,
     v.vcs_kind
 FROM inserted w
 JOIN vcs_providers v USING (vcs_provider_id);
@@ -38,6 +41,7 @@ SELECT
     w.vcs_provider_id,
     w.secret,
     w.repo_path,
+    w.group_hook,
     v.vcs_kind
 FROM repohooks w
 JOIN vcs_providers v USING (vcs_provider_id);
@@ -49,6 +53,7 @@ SELECT
     w.vcs_provider_id,
     w.secret,
     w.repo_path,
+    w.group_hook,
     v.vcs_kind
 FROM repohooks w
 JOIN vcs_providers v USING (vcs_provider_id)
@@ -61,6 +66,7 @@ SELECT
     w.vcs_provider_id,
     w.secret,
     w.repo_path,
+    w.group_hook,
     v.vcs_kind
 FROM repohooks w
 JOIN vcs_providers v USING (vcs_provider_id)
@@ -74,13 +80,18 @@ SELECT
     w.vcs_provider_id,
     w.secret,
     w.repo_path,
+    w.group_hook,
     v.vcs_kind
 FROM repohooks w
 JOIN vcs_providers v USING (vcs_provider_id)
 WHERE NOT EXISTS (
     SELECT FROM repo_connections rc
     WHERE rc.vcs_provider_id = w.vcs_provider_id
-    AND   rc.repo_path = w.repo_path
+    AND   (
+        rc.repo_path = w.repo_path
+        -- a group hook is referenced by a connection to any repo in the group
+        OR (w.group_hook AND rc.repo_path LIKE w.repo_path || '/%')
+    )
 );
 
 -- name: DeleteRepohookByID :one
@@ -0,0 +1,51 @@
+-- name: InsertResourceChange :exec
+INSERT INTO resource_changes (
+    resource_change_id,
+    run_id,
+    address,
+    module,
+    mode,
+    type,
+    name,
+    provider,
+    action,
+    remote_id,
+    created_at
+) VALUES (
+    pggen.arg('resource_change_id'),
+    pggen.arg('run_id'),
+    pggen.arg('address'),
+    pggen.arg('module'),
+    pggen.arg('mode'),
+    pggen.arg('type'),
+    pggen.arg('name'),
+    pggen.arg('provider'),
+    pggen.arg('action'),
+    pggen.arg('remote_id'),
+    pggen.arg('created_at')
+) ON CONFLICT (run_id, address, action) DO NOTHING;
+
+-- name: FindResourceChangeByID :one
+SELECT
+    rc.*,
+    r.workspace_id,
+    w.name AS workspace_name,
+    w.organization_name
+FROM resource_changes rc
+JOIN runs r USING (run_id)
+JOIN workspaces w USING (workspace_id)
+WHERE rc.resource_change_id = pggen.arg('resource_change_id')
+;
+
+-- name: FindResourceChangesByRunID :many
+SELECT
+    rc.*,
+    r.workspace_id,
+    w.name AS workspace_name,
+    w.organization_name
+FROM resource_changes rc
+JOIN runs r USING (run_id)
+JOIN workspaces w USING (workspace_id)
+WHERE rc.run_id = pggen.arg('run_id')
+ORDER BY rc.created_at, rc.address
+;
@@ -16,7 +16,8 @@ INSERT INTO runs (
     workspace_id,
     created_by,
     terraform_version,
-    allow_empty_apply
+    allow_empty_apply,
+    message
 ) VALUES (
     pggen.arg('id'),
     pggen.arg('created_at'),
@@ -34,7 +35,8 @@ INSERT INTO runs (
     pggen.arg('workspace_id'),
     pggen.arg('created_by'),
     pggen.arg('terraform_version'),
-    pggen.arg('allow_empty_apply')
+    pggen.arg('allow_empty_apply'),
+    pggen.arg('message')
 );
 
 -- name: InsertRunStatusTimestamp :exec
@@ -84,6 +86,7 @@ SELECT
     runs.created_by,
     runs.terraform_version,
     runs.allow_empty_apply,
+    runs.message,
     workspaces.execution_mode AS execution_mode,
     CASE WHEN workspaces.latest_run_id = runs.run_id THEN true
          ELSE false
@@ -177,6 +180,7 @@ SELECT
     runs.created_by,
     runs.terraform_version,
     runs.allow_empty_apply,
+    runs.message,
     workspaces.execution_mode AS execution_mode,
     CASE WHEN workspaces.latest_run_id = runs.run_id THEN true
          ELSE false
@@ -244,6 +248,7 @@ SELECT
     runs.created_by,
     runs.terraform_version,
     runs.allow_empty_apply,
+    runs.message,
     workspaces.execution_mode AS execution_mode,
     CASE WHEN workspaces.latest_run_id = runs.run_id THEN true
          ELSE false
@@ -322,3 +327,30 @@ FROM runs
 WHERE run_id = pggen.arg('run_id')
 RETURNING run_id
 ;
+
+-- name: FindQueuedRunIDsByWorkspaceID :many
+SELECT run_id
+FROM runs
+WHERE workspace_id = pggen.arg('workspace_id')
+AND   plan_only = false
+AND   status NOT IN ('applied', 'planned_and_finished', 'policy_soft_failed', 'discarded', 'canceled', 'force_canceled', 'errored')
+ORDER BY created_at ASC
+;
+
+-- FindSerializationGroupQueueByWorkspaceID finds the runs in the workspace's
+-- serialization group that are applying or waiting to apply, in the order in
+-- which they apply.
+--
+-- name: FindSerializationGroupQueueByWorkspaceID :many
+SELECT r.run_id, w.serialization_group
+FROM runs r
+JOIN workspaces w USING (workspace_id)
+LEFT JOIN run_status_timestamps rst ON rst.run_id = r.run_id AND rst.status = 'confirmed'
+WHERE w.serialization_group = (
+    SELECT serialization_group
+    FROM workspaces
+    WHERE workspace_id = pggen.arg('workspace_id')
+)
+AND   r.status IN ('confirmed', 'apply_queued', 'applying')
+ORDER BY r.status = 'confirmed', rst.timestamp ASC
+;
@@ -0,0 +1,24 @@
+-- name: UpsertRunAnnotation :exec
+INSERT INTO run_annotations (
+    run_id,
+    key,
+    value,
+    plugin,
+    created_at
+) VALUES (
+    pggen.arg('run_id'),
+    pggen.arg('key'),
+    pggen.arg('value'),
+    pggen.arg('plugin'),
+    pggen.arg('created_at')
+) ON CONFLICT (run_id, key) DO UPDATE
+  SET value      = pggen.arg('value'),
+      plugin     = pggen.arg('plugin'),
+      created_at = pggen.arg('created_at');
+
+-- name: FindRunAnnotationsByRunID :many
+SELECT *
+FROM run_annotations
+WHERE run_id = pggen.arg('run_id')
+ORDER BY key
+;
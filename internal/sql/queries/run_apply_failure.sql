@@ -0,0 +1,21 @@
+-- name: InsertRunApplyFailure :exec
+INSERT INTO run_apply_failures (
+    run_id,
+    position,
+    address,
+    action,
+    summary
+) VALUES (
+    pggen.arg('run_id'),
+    pggen.arg('position'),
+    pggen.arg('address'),
+    pggen.arg('action'),
+    pggen.arg('summary')
+);
+
+-- name: FindRunApplyFailures :many
+SELECT *
+FROM run_apply_failures
+WHERE run_id = pggen.arg('run_id')
+ORDER BY position
+;
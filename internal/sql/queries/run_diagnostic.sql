@@ -0,0 +1,31 @@
+-- name: InsertRunDiagnostic :exec
+INSERT INTO run_diagnostics (
+    run_id,
+    phase,
+    position,
+    severity,
+    summary,
+    detail,
+    address,
+    filename,
+    line
+) VALUES (
+    pggen.arg('run_id'),
+    pggen.arg('phase'),
+    pggen.arg('position'),
+    pggen.arg('severity'),
+    pggen.arg('summary'),
+    pggen.arg('detail'),
+    pggen.arg('address'),
+    pggen.arg('filename'),
+    pggen.arg('line')
+);
+
+-- name: FindRunDiagnostics :many
+SELECT *
+FROM run_diagnostics
+WHERE run_id = pggen.arg('run_id')
+ORDER BY
+    CASE phase WHEN 'plan' THEN 0 ELSE 1 END,
+    position
+;
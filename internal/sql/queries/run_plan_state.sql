@@ -0,0 +1,22 @@
+-- name: UpsertRunPlanState :exec
+INSERT INTO run_plan_states (
+    run_id,
+    state_serial
+) VALUES (
+    pggen.arg('run_id'),
+    pggen.arg('state_serial')
+) ON CONFLICT (run_id) DO UPDATE
+SET state_serial = pggen.arg('state_serial');
+
+-- name: FindRunPlanState :one
+SELECT *
+FROM run_plan_states
+WHERE run_id = pggen.arg('run_id')
+;
+
+-- name: FindWorkspaceCurrentStateSerial :one
+SELECT sv.serial
+FROM workspaces w
+JOIN state_versions sv ON w.current_state_version_id = sv.state_version_id
+WHERE w.workspace_id = pggen.arg('workspace_id')
+;
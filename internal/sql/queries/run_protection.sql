@@ -0,0 +1,55 @@
+-- name: UpsertWorkspaceProtectionRules :exec
+INSERT INTO workspace_protection_rules (
+    workspace_id,
+    prevent_destroy_runs,
+    protected_resources
+) VALUES (
+    pggen.arg('workspace_id'),
+    pggen.arg('prevent_destroy_runs'),
+    pggen.arg('protected_resources')
+) ON CONFLICT (workspace_id) DO UPDATE
+SET prevent_destroy_runs = pggen.arg('prevent_destroy_runs'),
+    protected_resources  = pggen.arg('protected_resources');
+
+-- name: FindWorkspaceProtectionRules :one
+SELECT *
+FROM workspace_protection_rules
+WHERE workspace_id = pggen.arg('workspace_id')
+;
+
+-- name: InsertRunProtectionViolation :exec
+INSERT INTO run_protection_violations (
+    run_id,
+    position,
+    violation
+) VALUES (
+    pggen.arg('run_id'),
+    pggen.arg('position'),
+    pggen.arg('violation')
+);
+
+-- name: FindRunProtectionViolations :many
+SELECT *
+FROM run_protection_violations
+WHERE run_id = pggen.arg('run_id')
+ORDER BY position
+;
+
+-- name: InsertRunProtectionOverride :exec
+INSERT INTO run_protection_overrides (
+    run_id,
+    username,
+    created_at,
+    violations
+) VALUES (
+    pggen.arg('run_id'),
+    pggen.arg('username'),
+    pggen.arg('created_at'),
+    pggen.arg('violations')
+);
+
+-- name: FindRunProtectionOverride :one
+SELECT *
+FROM run_protection_overrides
+WHERE run_id = pggen.arg('run_id')
+;
@@ -0,0 +1,21 @@
+-- name: FindRunProvenanceConfigurationVersion :one
+SELECT
+    cv.configuration_version_id,
+    cv.created_at,
+    cv.source,
+    c.created_by
+FROM configuration_versions cv
+LEFT JOIN configuration_version_creators c USING (configuration_version_id)
+WHERE cv.configuration_version_id = pggen.arg('configuration_version_id')
+;
+
+-- name: FindRunProvenanceStateVersions :many
+SELECT
+    sv.state_version_id,
+    sv.created_at,
+    sv.serial
+FROM state_versions sv
+JOIN state_version_runs r USING (state_version_id)
+WHERE r.run_id = pggen.arg('run_id')
+ORDER BY sv.serial
+;
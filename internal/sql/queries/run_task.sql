@@ -0,0 +1,244 @@
+-- name: InsertTask :exec
+INSERT INTO tasks (
+    task_id,
+    created_at,
+    updated_at,
+    name,
+    url,
+    description,
+    category,
+    hmac_key,
+    enabled,
+    organization_name
+) VALUES (
+    pggen.arg('task_id'),
+    pggen.arg('created_at'),
+    pggen.arg('updated_at'),
+    pggen.arg('name'),
+    pggen.arg('url'),
+    pggen.arg('description'),
+    pggen.arg('category'),
+    pggen.arg('hmac_key'),
+    pggen.arg('enabled'),
+    pggen.arg('organization_name')
+);
+
+-- name: FindTasksByOrganization :many
+SELECT *
+FROM tasks
+WHERE organization_name = pggen.arg('organization_name')
+ORDER BY name
+;
+
+-- name: FindTaskByID :one
+SELECT *
+FROM tasks
+WHERE task_id = pggen.arg('task_id')
+;
+
+-- name: FindTaskByIDForUpdate :one
+SELECT *
+FROM tasks
+WHERE task_id = pggen.arg('task_id')
+FOR UPDATE
+;
+
+-- name: UpdateTask :exec
+UPDATE tasks
+SET name = pggen.arg('name'),
+    url = pggen.arg('url'),
+    description = pggen.arg('description'),
+    category = pggen.arg('category'),
+    hmac_key = pggen.arg('hmac_key'),
+    enabled = pggen.arg('enabled'),
+    updated_at = pggen.arg('updated_at')
+WHERE task_id = pggen.arg('task_id')
+;
+
+-- name: DeleteTaskByID :exec
+DELETE
+FROM tasks
+WHERE task_id = pggen.arg('task_id')
+;
+
+-- name: InsertWorkspaceTask :exec
+INSERT INTO workspace_tasks (
+    workspace_task_id,
+    created_at,
+    updated_at,
+    enforcement_level,
+    stage,
+    task_id,
+    workspace_id
+) VALUES (
+    pggen.arg('workspace_task_id'),
+    pggen.arg('created_at'),
+    pggen.arg('updated_at'),
+    pggen.arg('enforcement_level'),
+    pggen.arg('stage'),
+    pggen.arg('task_id'),
+    pggen.arg('workspace_id')
+);
+
+-- name: FindWorkspaceTasksByWorkspaceID :many
+SELECT *
+FROM workspace_tasks
+WHERE workspace_id = pggen.arg('workspace_id')
+ORDER BY created_at
+;
+
+-- name: FindWorkspaceTaskByID :one
+SELECT *
+FROM workspace_tasks
+WHERE workspace_task_id = pggen.arg('workspace_task_id')
+;
+
+-- name: FindWorkspaceTaskByIDForUpdate :one
+SELECT *
+FROM workspace_tasks
+WHERE workspace_task_id = pggen.arg('workspace_task_id')
+FOR UPDATE
+;
+
+-- name: UpdateWorkspaceTask :exec
+UPDATE workspace_tasks
+SET enforcement_level = pggen.arg('enforcement_level'),
+    stage = pggen.arg('stage'),
+    updated_at = pggen.arg('updated_at')
+WHERE workspace_task_id = pggen.arg('workspace_task_id')
+;
+
+-- name: DeleteWorkspaceTaskByID :exec
+DELETE
+FROM workspace_tasks
+WHERE workspace_task_id = pggen.arg('workspace_task_id')
+;
+
+-- name: FindEnabledWorkspaceTasksByStage :many
+SELECT
+    wt.workspace_task_id,
+    wt.enforcement_level,
+    t.task_id,
+    t.name,
+    t.url
+FROM workspace_tasks wt
+JOIN tasks t USING (task_id)
+WHERE wt.workspace_id = pggen.arg('workspace_id')
+AND   wt.stage = pggen.arg('stage')
+AND   t.enabled
+ORDER BY wt.created_at
+;
+
+-- name: InsertTaskStage :exec
+INSERT INTO task_stages (
+    task_stage_id,
+    created_at,
+    updated_at,
+    stage,
+    status,
+    run_id
+) VALUES (
+    pggen.arg('task_stage_id'),
+    pggen.arg('created_at'),
+    pggen.arg('updated_at'),
+    pggen.arg('stage'),
+    pggen.arg('status'),
+    pggen.arg('run_id')
+);
+
+-- name: FindTaskStagesByRunID :many
+SELECT *
+FROM task_stages
+WHERE run_id = pggen.arg('run_id')
+ORDER BY created_at
+;
+
+-- name: FindTaskStageByID :one
+SELECT *
+FROM task_stages
+WHERE task_stage_id = pggen.arg('task_stage_id')
+;
+
+-- name: FindTaskStageByIDForUpdate :one
+SELECT *
+FROM task_stages
+WHERE task_stage_id = pggen.arg('task_stage_id')
+FOR UPDATE
+;
+
+-- name: UpdateTaskStageStatus :exec
+UPDATE task_stages
+SET status = pggen.arg('status'),
+    updated_at = pggen.arg('updated_at')
+WHERE task_stage_id = pggen.arg('task_stage_id')
+;
+
+-- name: InsertTaskResult :exec
+INSERT INTO task_results (
+    task_result_id,
+    created_at,
+    updated_at,
+    status,
+    message,
+    url,
+    task_id,
+    task_name,
+    task_url,
+    workspace_task_id,
+    enforcement_level,
+    task_stage_id
+) VALUES (
+    pggen.arg('task_result_id'),
+    pggen.arg('created_at'),
+    pggen.arg('updated_at'),
+    pggen.arg('status'),
+    pggen.arg('message'),
+    pggen.arg('url'),
+    pggen.arg('task_id'),
+    pggen.arg('task_name'),
+    pggen.arg('task_url'),
+    pggen.arg('workspace_task_id'),
+    pggen.arg('enforcement_level'),
+    pggen.arg('task_stage_id')
+);
+
+-- name: FindTaskResultsByTaskStageID :many
+SELECT tr.*, ts.stage, ts.run_id
+FROM task_results tr
+JOIN task_stages ts USING (task_stage_id)
+WHERE tr.task_stage_id = pggen.arg('task_stage_id')
+ORDER BY tr.created_at
+;
+
+-- name: FindTaskResultByID :one
+SELECT tr.*, ts.stage, ts.run_id
+FROM task_results tr
+JOIN task_stages ts USING (task_stage_id)
+WHERE tr.task_result_id = pggen.arg('task_result_id')
+;
+
+-- name: FindIncompleteTaskResults :many
+SELECT tr.*, ts.stage, ts.run_id, w.organization_name
+FROM task_results tr
+JOIN task_stages ts USING (task_stage_id)
+JOIN runs r USING (run_id)
+JOIN workspaces w USING (workspace_id)
+WHERE tr.status IN ('pending', 'running')
+ORDER BY tr.created_at
+;
+
+-- name: UpdateTaskResultStatus :exec
+UPDATE task_results
+SET status = pggen.arg('status'),
+    message = pggen.arg('message'),
+    url = pggen.arg('url'),
+    enforcement_level = pggen.arg('enforcement_level'),
+    updated_at = pggen.arg('updated_at')
+WHERE task_result_id = pggen.arg('task_result_id')
+;
+
+-- name: UpdateTaskResultDispatchedAt :exec
+UPDATE task_results
+SET dispatched_at = pggen.arg('dispatched_at')
+WHERE task_result_id = pggen.arg('task_result_id')
+;
@@ -0,0 +1,26 @@
+-- name: UpsertRunTaskSettings :exec
+INSERT INTO run_task_settings (
+    organization_name,
+    concurrency,
+    pre_plan_timeout,
+    post_plan_timeout,
+    timeout_policy
+) VALUES (
+    pggen.arg('organization_name'),
+    pggen.arg('concurrency'),
+    pggen.arg('pre_plan_timeout'),
+    pggen.arg('post_plan_timeout'),
+    pggen.arg('timeout_policy')
+)
+ON CONFLICT (organization_name) DO UPDATE
+SET concurrency       = excluded.concurrency,
+    pre_plan_timeout  = excluded.pre_plan_timeout,
+    post_plan_timeout = excluded.post_plan_timeout,
+    timeout_policy    = excluded.timeout_policy
+;
+
+-- name: FindRunTaskSettings :one
+SELECT *
+FROM run_task_settings
+WHERE organization_name = pggen.arg('organization_name')
+;
@@ -0,0 +1,63 @@
+-- name: InsertRunTrigger :exec
+INSERT INTO run_triggers (
+    run_trigger_id,
+    created_at,
+    workspace_id,
+    sourceable_workspace_id
+) VALUES (
+    pggen.arg('run_trigger_id'),
+    pggen.arg('created_at'),
+    pggen.arg('workspace_id'),
+    pggen.arg('sourceable_workspace_id')
+);
+
+-- name: FindRunTrigger :one
+SELECT
+    rt.run_trigger_id,
+    rt.created_at,
+    rt.workspace_id,
+    w.name AS workspace_name,
+    rt.sourceable_workspace_id,
+    sw.name AS sourceable_workspace_name
+FROM run_triggers rt
+JOIN workspaces w ON rt.workspace_id = w.workspace_id
+JOIN workspaces sw ON rt.sourceable_workspace_id = sw.workspace_id
+WHERE rt.run_trigger_id = pggen.arg('run_trigger_id')
+;
+
+-- name: FindRunTriggersByWorkspaceID :many
+SELECT
+    rt.run_trigger_id,
+    rt.created_at,
+    rt.workspace_id,
+    w.name AS workspace_name,
+    rt.sourceable_workspace_id,
+    sw.name AS sourceable_workspace_name
+FROM run_triggers rt
+JOIN workspaces w ON rt.workspace_id = w.workspace_id
+JOIN workspaces sw ON rt.sourceable_workspace_id = sw.workspace_id
+WHERE rt.workspace_id = pggen.arg('workspace_id')
+ORDER BY rt.created_at ASC
+;
+
+-- name: FindRunTriggersBySourceableWorkspaceID :many
+SELECT
+    rt.run_trigger_id,
+    rt.created_at,
+    rt.workspace_id,
+    w.name AS workspace_name,
+    rt.sourceable_workspace_id,
+    sw.name AS sourceable_workspace_name
+FROM run_triggers rt
+JOIN workspaces w ON rt.workspace_id = w.workspace_id
+JOIN workspaces sw ON rt.sourceable_workspace_id = sw.workspace_id
+WHERE rt.sourceable_workspace_id = pggen.arg('sourceable_workspace_id')
+ORDER BY rt.created_at ASC
+;
+
+-- name: DeleteRunTrigger :one
+DELETE
+FROM run_triggers
+WHERE run_trigger_id = pggen.arg('run_trigger_id')
+RETURNING run_trigger_id
+;
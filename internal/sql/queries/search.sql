@@ -0,0 +1,81 @@
+-- name: Search :many
+WITH
+    -- workspaces the user can access; if no username is given then all
+    -- workspaces in the organization are accessible.
+    accessible AS (
+        SELECT w.*
+        FROM workspaces w
+        WHERE w.organization_name = pggen.arg('organization_name')
+        AND (
+            pggen.arg('username')::text = ''
+            OR EXISTS (
+                SELECT FROM workspace_permissions p
+                JOIN team_memberships tm USING (team_id)
+                WHERE p.workspace_id = w.workspace_id
+                AND tm.username = pggen.arg('username')
+            )
+        )
+    ),
+    query AS (
+        SELECT to_tsquery('simple', pggen.arg('query')) AS q
+    ),
+    results AS (
+        SELECT
+            'workspace' AS kind,
+            w.workspace_id AS resource_id,
+            w.workspace_id,
+            w.name AS workspace_name,
+            w.description AS summary,
+            '' AS commit_sha,
+            COALESCE(r.status, '') AS status,
+            w.updated_at AS timestamp,
+            ts_rank(to_tsvector('simple', w.name || ' ' || w.description), query.q) AS rank
+        FROM accessible w
+        CROSS JOIN query
+        LEFT JOIN runs r ON w.latest_run_id = r.run_id
+        WHERE 'workspace' = ANY(pggen.arg('kinds'))
+        AND (
+            to_tsvector('simple', w.name || ' ' || w.description) @@ query.q
+            OR EXISTS (
+                SELECT FROM workspace_tags wt
+                JOIN tags t USING (tag_id)
+                WHERE wt.workspace_id = w.workspace_id
+                AND t.name LIKE pggen.arg('tag_prefix') || '%'
+            )
+        )
+        UNION ALL
+        SELECT
+            'run' AS kind,
+            r.run_id AS resource_id,
+            w.workspace_id,
+            w.name AS workspace_name,
+            r.message AS summary,
+            COALESCE(ia.commit_sha, '') AS commit_sha,
+            r.status,
+            r.created_at AS timestamp,
+            ts_rank(to_tsvector('simple', r.message), query.q) AS rank
+        FROM runs r
+        CROSS JOIN query
+        JOIN accessible w USING (workspace_id)
+        LEFT JOIN ingress_attributes ia USING (configuration_version_id)
+        WHERE 'run' = ANY(pggen.arg('kinds'))
+        AND (
+            to_tsvector('simple', r.message) @@ query.q
+            OR (pggen.arg('commit_sha_prefix')::text <> '' AND ia.commit_sha LIKE pggen.arg('commit_sha_prefix') || '%')
+        )
+    )
+SELECT
+    kind,
+    resource_id,
+    workspace_id,
+    workspace_name,
+    summary,
+    commit_sha,
+    status,
+    timestamp,
+    count(*) OVER () AS total_count
+FROM results
+ORDER BY rank DESC, timestamp DESC
+LIMIT pggen.arg('limit')
+OFFSET pggen.arg('offset')
+;
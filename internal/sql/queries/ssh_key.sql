@@ -0,0 +1,77 @@
+-- name: InsertSSHKey :exec
+INSERT INTO ssh_keys (
+    ssh_key_id,
+    created_at,
+    updated_at,
+    name,
+    encrypted_value,
+    organization_name
+) VALUES (
+    pggen.arg('ssh_key_id'),
+    pggen.arg('created_at'),
+    pggen.arg('updated_at'),
+    pggen.arg('name'),
+    pggen.arg('encrypted_value'),
+    pggen.arg('organization_name')
+);
+
+-- name: FindSSHKeysByOrganization :many
+SELECT *
+FROM ssh_keys
+WHERE organization_name = pggen.arg('organization_name')
+ORDER BY name ASC
+;
+
+-- name: FindSSHKeyByID :one
+SELECT *
+FROM ssh_keys
+WHERE ssh_key_id = pggen.arg('ssh_key_id')
+;
+
+-- name: FindSSHKeyByIDForUpdate :one
+SELECT *
+FROM ssh_keys
+WHERE ssh_key_id = pggen.arg('ssh_key_id')
+FOR UPDATE
+;
+
+-- name: UpdateSSHKeyByID :one
+UPDATE ssh_keys
+SET
+    updated_at      = pggen.arg('updated_at'),
+    name            = pggen.arg('name'),
+    encrypted_value = pggen.arg('encrypted_value')
+WHERE ssh_key_id = pggen.arg('ssh_key_id')
+RETURNING ssh_key_id
+;
+
+-- name: DeleteSSHKeyByID :one
+DELETE
+FROM ssh_keys
+WHERE ssh_key_id = pggen.arg('ssh_key_id')
+RETURNING ssh_key_id
+;
+
+-- name: UpsertWorkspaceSSHKey :exec
+INSERT INTO workspace_ssh_keys (
+    workspace_id,
+    ssh_key_id
+) VALUES (
+    pggen.arg('workspace_id'),
+    pggen.arg('ssh_key_id')
+) ON CONFLICT (workspace_id) DO UPDATE
+SET ssh_key_id = EXCLUDED.ssh_key_id
+;
+
+-- name: DeleteWorkspaceSSHKey :exec
+DELETE
+FROM workspace_ssh_keys
+WHERE workspace_id = pggen.arg('workspace_id')
+;
+
+-- name: FindSSHKeyByWorkspaceID :one
+SELECT k.*
+FROM ssh_keys k
+JOIN workspace_ssh_keys USING (ssh_key_id)
+WHERE workspace_ssh_keys.workspace_id = pggen.arg('workspace_id')
+;
@@ -0,0 +1,34 @@
+-- name: UpsertSSOEnforcement :exec
+INSERT INTO sso_enforcements (
+    organization_name,
+    exempt_usernames,
+    updated_at
+) VALUES (
+    pggen.arg('organization_name'),
+    pggen.arg('exempt_usernames'),
+    pggen.arg('updated_at')
+)
+ON CONFLICT (organization_name) DO UPDATE
+SET exempt_usernames = EXCLUDED.exempt_usernames,
+    updated_at       = EXCLUDED.updated_at;
+
+-- name: FindSSOEnforcement :one
+SELECT *
+FROM sso_enforcements
+WHERE organization_name = pggen.arg('organization_name');
+
+-- name: FindSSOEnforcementsByOrganizations :many
+SELECT *
+FROM sso_enforcements
+WHERE organization_name = ANY(pggen.arg('organization_names')::text[]);
+
+-- name: DeleteSSOEnforcement :one
+DELETE
+FROM sso_enforcements
+WHERE organization_name = pggen.arg('organization_name')
+RETURNING organization_name;
+
+-- name: FindSSOEnforcements :many
+SELECT *
+FROM sso_enforcements
+;
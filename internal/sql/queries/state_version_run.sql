@@ -0,0 +1,8 @@
+-- name: InsertStateVersionRun :exec
+INSERT INTO state_version_runs (
+    state_version_id,
+    run_id
+) VALUES (
+    pggen.arg('state_version_id'),
+    pggen.arg('run_id')
+);
@@ -24,3 +24,14 @@ WHERE
     tm.team_id  = pggen.arg('team_id')
 RETURNING tm.username
 ;
+
+-- name: DeleteTeamMembershipsByOrganization :many
+DELETE
+FROM team_memberships tm
+USING teams t
+WHERE
+    tm.team_id           = t.team_id AND
+    t.organization_name  = pggen.arg('organization_name') AND
+    tm.username          = pggen.arg('username')
+RETURNING tm.team_id
+;
@@ -3,12 +3,16 @@ INSERT INTO tokens (
     token_id,
     created_at,
     description,
-    username
+    username,
+    organization_name,
+    scopes
 ) VALUES (
     pggen.arg('token_id'),
     pggen.arg('created_at'),
     pggen.arg('description'),
-    pggen.arg('username')
+    pggen.arg('username'),
+    pggen.arg('organization_name'),
+    pggen.arg('scopes')
 );
 
 -- name: FindTokensByUsername :many
@@ -4,13 +4,15 @@ INSERT INTO variable_sets (
     global,
     name,
     description,
-    organization_name
+    organization_name,
+    priority
 ) VALUES (
     pggen.arg('variable_set_id'),
     pggen.arg('global'),
     pggen.arg('name'),
     pggen.arg('description'),
-    pggen.arg('organization_name')
+    pggen.arg('organization_name'),
+    pggen.arg('priority')
 );
 
 -- name: FindVariableSetsByOrganization :many
@@ -136,7 +138,8 @@ UPDATE variable_sets
 SET
     global = pggen.arg('global'),
     name = pggen.arg('name'),
-    description = pggen.arg('description')
+    description = pggen.arg('description'),
+    priority = pggen.arg('priority')
 WHERE variable_set_id = pggen.arg('variable_set_id')
 RETURNING variable_set_id;
 
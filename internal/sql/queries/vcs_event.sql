@@ -0,0 +1,83 @@
+-- InsertVCSEventDelivery records the delivery of a vcs event. If the delivery
+-- has already been recorded then no row is inserted.
+--
+-- name: InsertVCSEventDelivery :exec
+INSERT INTO vcs_event_deliveries (
+    vcs_provider_id,
+    delivery_id,
+    received_at
+) VALUES (
+    pggen.arg('vcs_provider_id'),
+    pggen.arg('delivery_id'),
+    pggen.arg('received_at')
+)
+ON CONFLICT (vcs_provider_id, delivery_id) DO NOTHING;
+
+-- name: DeleteVCSEventDeliveriesBefore :exec
+DELETE
+FROM vcs_event_deliveries
+WHERE received_at < pggen.arg('received_before');
+
+-- name: InsertVCSEventDeadLetter :exec
+INSERT INTO vcs_event_dead_letters (
+    dead_letter_id,
+    vcs_provider_id,
+    subscriber,
+    event,
+    error,
+    created_at
+) VALUES (
+    pggen.arg('dead_letter_id'),
+    pggen.arg('vcs_provider_id'),
+    pggen.arg('subscriber'),
+    pggen.arg('event'),
+    pggen.arg('error'),
+    pggen.arg('created_at')
+);
+
+-- name: FindVCSEventDeadLetters :many
+SELECT *
+FROM vcs_event_dead_letters
+ORDER BY created_at;
+
+-- name: FindVCSEventDeadLetterByID :one
+SELECT *
+FROM vcs_event_dead_letters
+WHERE dead_letter_id = pggen.arg('dead_letter_id');
+
+-- name: DeleteVCSEventDeadLetterByID :one
+DELETE
+FROM vcs_event_dead_letters
+WHERE dead_letter_id = pggen.arg('dead_letter_id')
+RETURNING dead_letter_id;
+
+-- name: InsertQueuedVCSEvent :exec
+INSERT INTO vcs_event_queue (
+    queued_event_id,
+    vcs_provider_id,
+    subscriber,
+    event,
+    created_at,
+    claimed_at
+) VALUES (
+    pggen.arg('queued_event_id'),
+    pggen.arg('vcs_provider_id'),
+    pggen.arg('subscriber'),
+    pggen.arg('event'),
+    pggen.arg('created_at'),
+    pggen.arg('created_at')
+);
+
+-- ClaimQueuedVCSEvents claims queued vcs events that were last claimed before
+-- the given time, i.e. events whose delivery has been abandoned.
+--
+-- name: ClaimQueuedVCSEvents :many
+UPDATE vcs_event_queue
+SET claimed_at = pggen.arg('claimed_at')
+WHERE claimed_at < pggen.arg('claimed_before')
+RETURNING *;
+
+-- name: DeleteQueuedVCSEventByID :exec
+DELETE
+FROM vcs_event_queue
+WHERE queued_event_id = pggen.arg('queued_event_id');
@@ -23,6 +23,9 @@ INSERT INTO workspaces (
     terraform_version,
     trigger_prefixes,
     trigger_patterns,
+    ignore_patterns,
+    assessments_enabled,
+    serialization_group,
     vcs_tags_regex,
     working_directory,
     organization_name
@@ -50,6 +53,9 @@ INSERT INTO workspaces (
     pggen.arg('terraform_version'),
     pggen.arg('trigger_prefixes'),
     pggen.arg('trigger_patterns'),
+    pggen.arg('ignore_patterns'),
+    pggen.arg('assessments_enabled'),
+    pggen.arg('serialization_group'),
     pggen.arg('vcs_tags_regex'),
     pggen.arg('working_directory'),
     pggen.arg('organization_name')
@@ -248,6 +254,9 @@ SET
     terraform_version             = pggen.arg('terraform_version'),
     trigger_prefixes              = pggen.arg('trigger_prefixes'),
     trigger_patterns              = pggen.arg('trigger_patterns'),
+    ignore_patterns               = pggen.arg('ignore_patterns'),
+    assessments_enabled           = pggen.arg('assessments_enabled'),
+    serialization_group           = pggen.arg('serialization_group'),
     vcs_tags_regex                = pggen.arg('vcs_tags_regex'),
     working_directory             = pggen.arg('working_directory'),
     updated_at                    = pggen.arg('updated_at')
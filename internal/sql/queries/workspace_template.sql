@@ -0,0 +1,30 @@
+-- InsertWorkspaceTemplate marks a workspace as a template. If the workspace is
+-- already a template then no row is inserted.
+--
+-- name: InsertWorkspaceTemplate :exec
+INSERT INTO workspace_templates (
+    workspace_id,
+    created_at
+) VALUES (
+    pggen.arg('workspace_id'),
+    pggen.arg('created_at')
+)
+ON CONFLICT (workspace_id) DO NOTHING;
+
+-- name: FindWorkspaceTemplate :one
+SELECT *
+FROM workspace_templates
+WHERE workspace_id = pggen.arg('workspace_id');
+
+-- name: FindWorkspaceTemplatesByOrganization :many
+SELECT t.workspace_id, w.name, t.created_at
+FROM workspace_templates t
+JOIN workspaces w USING (workspace_id)
+WHERE w.organization_name = pggen.arg('organization_name')
+ORDER BY w.name;
+
+-- name: DeleteWorkspaceTemplate :one
+DELETE
+FROM workspace_templates
+WHERE workspace_id = pggen.arg('workspace_id')
+RETURNING workspace_id;
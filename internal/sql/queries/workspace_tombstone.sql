@@ -0,0 +1,38 @@
+-- UpsertWorkspaceTombstone records the former name of a renamed workspace,
+-- replacing any tombstone left by another workspace formerly of that name.
+--
+-- name: UpsertWorkspaceTombstone :exec
+INSERT INTO workspace_tombstones (
+    organization_name,
+    name,
+    workspace_id,
+    renamed_at
+) VALUES (
+    pggen.arg('organization_name'),
+    pggen.arg('name'),
+    pggen.arg('workspace_id'),
+    pggen.arg('renamed_at')
+) ON CONFLICT (organization_name, name) DO UPDATE
+SET workspace_id = EXCLUDED.workspace_id,
+    renamed_at   = EXCLUDED.renamed_at
+;
+
+-- name: FindWorkspaceTombstone :one
+SELECT
+    t.organization_name,
+    t.name,
+    t.workspace_id,
+    t.renamed_at,
+    w.name AS current_name
+FROM workspace_tombstones t
+JOIN workspaces w USING (workspace_id)
+WHERE t.organization_name = pggen.arg('organization_name')
+AND   t.name              = pggen.arg('name')
+AND   t.renamed_at        > pggen.arg('renamed_after')
+;
+
+-- name: DeleteWorkspaceTombstonesBefore :exec
+DELETE
+FROM workspace_tombstones
+WHERE renamed_at < pggen.arg('renamed_before')
+;
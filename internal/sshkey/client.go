@@ -0,0 +1,30 @@
+package sshkey
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/url"
+
+	otfapi "github.com/leg100/otf/internal/api"
+)
+
+type Client struct {
+	*otfapi.Client
+}
+
+// GetWorkspaceKey retrieves the private SSH key assigned to a workspace.
+func (c *Client) GetWorkspaceKey(ctx context.Context, workspaceID string) ([]byte, error) {
+	u := fmt.Sprintf("workspaces/%s/ssh-key", url.QueryEscape(workspaceID))
+	req, err := c.NewRequest("GET", u, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	if err := c.Do(ctx, req, &buf); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
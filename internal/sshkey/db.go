@@ -0,0 +1,134 @@
+package sshkey
+
+import (
+	"context"
+
+	"github.com/jackc/pgtype"
+	"github.com/leg100/otf/internal"
+	"github.com/leg100/otf/internal/sql"
+	"github.com/leg100/otf/internal/sql/pggen"
+)
+
+type (
+	// pgdb is a database of SSH keys on postgres. Keys are encrypted at rest.
+	pgdb struct {
+		*sql.DB // provides access to generated SQL queries
+
+		secret []byte // secret for encrypting and decrypting keys
+	}
+
+	pgresult struct {
+		SshKeyID         pgtype.Text        `json:"ssh_key_id"`
+		CreatedAt        pgtype.Timestamptz `json:"created_at"`
+		UpdatedAt        pgtype.Timestamptz `json:"updated_at"`
+		Name             pgtype.Text        `json:"name"`
+		EncryptedValue   pgtype.Text        `json:"encrypted_value"`
+		OrganizationName pgtype.Text        `json:"organization_name"`
+	}
+)
+
+func (db *pgdb) toSSHKey(r pgresult) (*SSHKey, error) {
+	value, err := internal.Decrypt(r.EncryptedValue.String, db.secret)
+	if err != nil {
+		return nil, err
+	}
+	return &SSHKey{
+		ID:           r.SshKeyID.String,
+		CreatedAt:    r.CreatedAt.Time.UTC(),
+		UpdatedAt:    r.UpdatedAt.Time.UTC(),
+		Name:         r.Name.String,
+		Organization: r.OrganizationName.String,
+		value:        value,
+	}, nil
+}
+
+func (db *pgdb) create(ctx context.Context, key *SSHKey) error {
+	encrypted, err := internal.Encrypt(key.value, db.secret)
+	if err != nil {
+		return err
+	}
+	_, err = db.Conn(ctx).InsertSSHKey(ctx, pggen.InsertSSHKeyParams{
+		SshKeyID:         sql.String(key.ID),
+		CreatedAt:        sql.Timestamptz(key.CreatedAt),
+		UpdatedAt:        sql.Timestamptz(key.UpdatedAt),
+		Name:             sql.String(key.Name),
+		EncryptedValue:   sql.String(encrypted),
+		OrganizationName: sql.String(key.Organization),
+	})
+	return sql.Error(err)
+}
+
+func (db *pgdb) update(ctx context.Context, id string, updateFunc func(*SSHKey) error) (*SSHKey, error) {
+	var key *SSHKey
+	err := db.Tx(ctx, func(ctx context.Context, q pggen.Querier) error {
+		row, err := q.FindSSHKeyByIDForUpdate(ctx, sql.String(id))
+		if err != nil {
+			return sql.Error(err)
+		}
+		key, err = db.toSSHKey(pgresult(row))
+		if err != nil {
+			return err
+		}
+		if err := updateFunc(key); err != nil {
+			return err
+		}
+		encrypted, err := internal.Encrypt(key.value, db.secret)
+		if err != nil {
+			return err
+		}
+		_, err = q.UpdateSSHKeyByID(ctx, pggen.UpdateSSHKeyByIDParams{
+			SshKeyID:       sql.String(key.ID),
+			UpdatedAt:      sql.Timestamptz(key.UpdatedAt),
+			Name:           sql.String(key.Name),
+			EncryptedValue: sql.String(encrypted),
+		})
+		return sql.Error(err)
+	})
+	return key, err
+}
+
+func (db *pgdb) list(ctx context.Context, organization string) ([]*SSHKey, error) {
+	rows, err := db.Conn(ctx).FindSSHKeysByOrganization(ctx, sql.String(organization))
+	if err != nil {
+		return nil, sql.Error(err)
+	}
+	keys := make([]*SSHKey, len(rows))
+	for i, row := range rows {
+		keys[i], err = db.toSSHKey(pgresult(row))
+		if err != nil {
+			return nil, err
+		}
+	}
+	return keys, nil
+}
+
+func (db *pgdb) get(ctx context.Context, id string) (*SSHKey, error) {
+	row, err := db.Conn(ctx).FindSSHKeyByID(ctx, sql.String(id))
+	if err != nil {
+		return nil, sql.Error(err)
+	}
+	return db.toSSHKey(pgresult(row))
+}
+
+func (db *pgdb) getByWorkspace(ctx context.Context, workspaceID string) (*SSHKey, error) {
+	row, err := db.Conn(ctx).FindSSHKeyByWorkspaceID(ctx, sql.String(workspaceID))
+	if err != nil {
+		return nil, sql.Error(err)
+	}
+	return db.toSSHKey(pgresult(row))
+}
+
+func (db *pgdb) delete(ctx context.Context, id string) error {
+	_, err := db.Conn(ctx).DeleteSSHKeyByID(ctx, sql.String(id))
+	return sql.Error(err)
+}
+
+func (db *pgdb) assign(ctx context.Context, workspaceID, keyID string) error {
+	_, err := db.Conn(ctx).UpsertWorkspaceSSHKey(ctx, sql.String(workspaceID), sql.String(keyID))
+	return sql.Error(err)
+}
+
+func (db *pgdb) unassign(ctx context.Context, workspaceID string) error {
+	_, err := db.Conn(ctx).DeleteWorkspaceSSHKey(ctx, sql.String(workspaceID))
+	return sql.Error(err)
+}
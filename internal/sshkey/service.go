@@ -0,0 +1,214 @@
+package sshkey
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/gorilla/mux"
+	"github.com/leg100/otf/internal"
+	"github.com/leg100/otf/internal/logr"
+	"github.com/leg100/otf/internal/organization"
+	"github.com/leg100/otf/internal/rbac"
+	"github.com/leg100/otf/internal/sql"
+	"github.com/leg100/otf/internal/tfeapi"
+	"github.com/leg100/otf/internal/workspace"
+)
+
+var ErrDifferentOrganization = errors.New("SSH key belongs to a different organization to the workspace")
+
+type (
+	Service struct {
+		logr.Logger
+
+		organization        internal.Authorizer
+		workspaceAuthorizer internal.Authorizer
+		workspaces          workspaceClient
+		db                  *pgdb
+		tfeapi              *tfe
+		api                 *api
+	}
+
+	Options struct {
+		*sql.DB
+		*tfeapi.Responder
+		logr.Logger
+
+		// Secret for encrypting keys at rest
+		Secret []byte
+
+		WorkspaceAuthorizer internal.Authorizer
+		WorkspaceService    workspaceClient
+	}
+
+	workspaceClient interface {
+		Get(ctx context.Context, workspaceID string) (*workspace.Workspace, error)
+	}
+)
+
+func NewService(opts Options) *Service {
+	svc := Service{
+		Logger:              opts.Logger,
+		organization:        &organization.Authorizer{Logger: opts.Logger},
+		workspaceAuthorizer: opts.WorkspaceAuthorizer,
+		workspaces:          opts.WorkspaceService,
+		db:                  &pgdb{DB: opts.DB, secret: opts.Secret},
+	}
+	svc.tfeapi = &tfe{
+		Service:   &svc,
+		Responder: opts.Responder,
+	}
+	svc.api = &api{
+		Service: &svc,
+	}
+	return &svc
+}
+
+func (s *Service) AddHandlers(r *mux.Router) {
+	s.tfeapi.addHandlers(r)
+	s.api.addHandlers(r)
+}
+
+func (s *Service) Create(ctx context.Context, organization string, opts CreateOptions) (*SSHKey, error) {
+	subject, err := s.organization.CanAccess(ctx, rbac.CreateSSHKeyAction, organization)
+	if err != nil {
+		return nil, err
+	}
+	key, err := newSSHKey(organization, opts)
+	if err != nil {
+		s.Error(err, "constructing ssh key", "organization", organization, "subject", subject)
+		return nil, err
+	}
+	if err := s.db.create(ctx, key); err != nil {
+		s.Error(err, "creating ssh key", "key", key, "subject", subject)
+		return nil, err
+	}
+	s.V(1).Info("created ssh key", "key", key, "subject", subject)
+	return key, nil
+}
+
+func (s *Service) Update(ctx context.Context, id string, opts UpdateOptions) (*SSHKey, error) {
+	key, err := s.db.get(ctx, id)
+	if err != nil {
+		s.Error(err, "retrieving ssh key", "id", id)
+		return nil, err
+	}
+	subject, err := s.organization.CanAccess(ctx, rbac.UpdateSSHKeyAction, key.Organization)
+	if err != nil {
+		return nil, err
+	}
+	key, err = s.db.update(ctx, id, func(key *SSHKey) error {
+		return key.update(opts)
+	})
+	if err != nil {
+		s.Error(err, "updating ssh key", "id", id, "subject", subject)
+		return nil, err
+	}
+	s.V(1).Info("updated ssh key", "key", key, "subject", subject)
+	return key, nil
+}
+
+func (s *Service) List(ctx context.Context, organization string) ([]*SSHKey, error) {
+	subject, err := s.organization.CanAccess(ctx, rbac.ListSSHKeysAction, organization)
+	if err != nil {
+		return nil, err
+	}
+	keys, err := s.db.list(ctx, organization)
+	if err != nil {
+		s.Error(err, "listing ssh keys", "organization", organization, "subject", subject)
+		return nil, err
+	}
+	s.V(9).Info("listed ssh keys", "organization", organization, "total", len(keys), "subject", subject)
+	return keys, nil
+}
+
+func (s *Service) Get(ctx context.Context, id string) (*SSHKey, error) {
+	key, err := s.db.get(ctx, id)
+	if err != nil {
+		s.Error(err, "retrieving ssh key", "id", id)
+		return nil, err
+	}
+	subject, err := s.organization.CanAccess(ctx, rbac.GetSSHKeyAction, key.Organization)
+	if err != nil {
+		return nil, err
+	}
+	s.V(9).Info("retrieved ssh key", "key", key, "subject", subject)
+	return key, nil
+}
+
+func (s *Service) Delete(ctx context.Context, id string) error {
+	key, err := s.db.get(ctx, id)
+	if err != nil {
+		s.Error(err, "retrieving ssh key", "id", id)
+		return err
+	}
+	subject, err := s.organization.CanAccess(ctx, rbac.DeleteSSHKeyAction, key.Organization)
+	if err != nil {
+		return err
+	}
+	if err := s.db.delete(ctx, id); err != nil {
+		s.Error(err, "deleting ssh key", "key", key, "subject", subject)
+		return err
+	}
+	s.V(1).Info("deleted ssh key", "key", key, "subject", subject)
+	return nil
+}
+
+// AssignToWorkspace assigns an SSH key to a workspace, replacing any key
+// previously assigned. Runs in the workspace then use the key to clone module
+// sources over git+ssh.
+func (s *Service) AssignToWorkspace(ctx context.Context, workspaceID, keyID string) error {
+	subject, err := s.workspaceAuthorizer.CanAccess(ctx, rbac.UpdateWorkspaceAction, workspaceID)
+	if err != nil {
+		return err
+	}
+	ws, err := s.workspaces.Get(ctx, workspaceID)
+	if err != nil {
+		return fmt.Errorf("retrieving workspace: %w", err)
+	}
+	key, err := s.db.get(ctx, keyID)
+	if err != nil {
+		return fmt.Errorf("retrieving ssh key: %w", err)
+	}
+	if ws.Organization != key.Organization {
+		return ErrDifferentOrganization
+	}
+	if err := s.db.assign(ctx, workspaceID, keyID); err != nil {
+		s.Error(err, "assigning ssh key to workspace", "key", key, "workspace_id", workspaceID, "subject", subject)
+		return err
+	}
+	s.V(1).Info("assigned ssh key to workspace", "key", key, "workspace_id", workspaceID, "subject", subject)
+	return nil
+}
+
+// UnassignFromWorkspace removes the SSH key assigned to a workspace, if any.
+func (s *Service) UnassignFromWorkspace(ctx context.Context, workspaceID string) error {
+	subject, err := s.workspaceAuthorizer.CanAccess(ctx, rbac.UpdateWorkspaceAction, workspaceID)
+	if err != nil {
+		return err
+	}
+	if err := s.db.unassign(ctx, workspaceID); err != nil {
+		s.Error(err, "unassigning ssh key from workspace", "workspace_id", workspaceID, "subject", subject)
+		return err
+	}
+	s.V(1).Info("unassigned ssh key from workspace", "workspace_id", workspaceID, "subject", subject)
+	return nil
+}
+
+// GetWorkspaceKey retrieves the decrypted private key assigned to a workspace.
+// Returns internal.ErrResourceNotFound if no key is assigned.
+func (s *Service) GetWorkspaceKey(ctx context.Context, workspaceID string) ([]byte, error) {
+	subject, err := s.workspaceAuthorizer.CanAccess(ctx, rbac.GetWorkspaceSSHKeyAction, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+	key, err := s.db.getByWorkspace(ctx, workspaceID)
+	if err != nil {
+		if !errors.Is(err, internal.ErrResourceNotFound) {
+			s.Error(err, "retrieving workspace ssh key", "workspace_id", workspaceID, "subject", subject)
+		}
+		return nil, err
+	}
+	s.V(9).Info("retrieved workspace ssh key", "key", key, "workspace_id", workspaceID, "subject", subject)
+	return key.value, nil
+}
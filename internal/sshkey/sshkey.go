@@ -0,0 +1,104 @@
+// Package sshkey manages SSH keys with which runs clone module sources over
+// git+ssh.
+package sshkey
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"log/slog"
+
+	"github.com/leg100/otf/internal"
+	"github.com/leg100/otf/internal/resource"
+	"golang.org/x/crypto/ssh"
+)
+
+var ErrInvalidPrivateKey = errors.New("value must be an unencrypted SSH private key")
+
+type (
+	// SSHKey is a private SSH key belonging to an organization, which can be
+	// assigned to the organization's workspaces.
+	SSHKey struct {
+		ID           string
+		CreatedAt    time.Time
+		UpdatedAt    time.Time
+		Name         string
+		Organization string
+
+		// private key; never exposed via the API
+		value []byte
+	}
+
+	CreateOptions struct {
+		Name  *string
+		Value *string
+	}
+
+	UpdateOptions struct {
+		Name  *string
+		Value *string
+	}
+)
+
+func newSSHKey(organization string, opts CreateOptions) (*SSHKey, error) {
+	if opts.Name == nil {
+		return nil, &internal.MissingParameterError{Parameter: "name"}
+	}
+	if opts.Value == nil {
+		return nil, &internal.MissingParameterError{Parameter: "value"}
+	}
+	key := &SSHKey{
+		ID:           resource.NewID(resource.SSHKeyKind),
+		CreatedAt:    internal.CurrentTimestamp(nil),
+		Organization: organization,
+	}
+	key.UpdatedAt = key.CreatedAt
+	if err := key.setName(*opts.Name); err != nil {
+		return nil, err
+	}
+	if err := key.setValue(*opts.Value); err != nil {
+		return nil, err
+	}
+	return key, nil
+}
+
+func (k *SSHKey) update(opts UpdateOptions) error {
+	if opts.Name != nil {
+		if err := k.setName(*opts.Name); err != nil {
+			return err
+		}
+	}
+	if opts.Value != nil {
+		if err := k.setValue(*opts.Value); err != nil {
+			return err
+		}
+	}
+	k.UpdatedAt = internal.CurrentTimestamp(nil)
+	return nil
+}
+
+func (k *SSHKey) setName(name string) error {
+	if name == "" {
+		return internal.ErrRequiredName
+	}
+	k.Name = name
+	return nil
+}
+
+func (k *SSHKey) setValue(value string) error {
+	// the key is used non-interactively so it must not require a passphrase
+	if _, err := ssh.ParseRawPrivateKey([]byte(value)); err != nil {
+		return fmt.Errorf("%w: %s", ErrInvalidPrivateKey, err.Error())
+	}
+	k.value = []byte(value)
+	return nil
+}
+
+func (k *SSHKey) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.String("id", k.ID),
+		slog.String("name", k.Name),
+		slog.String("organization", k.Organization),
+	)
+}
@@ -0,0 +1,55 @@
+package sshkey
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"testing"
+
+	"github.com/leg100/otf/internal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewSSHKey(t *testing.T) {
+	key := newTestPrivateKey(t)
+
+	tests := []struct {
+		name string
+		opts CreateOptions
+		want error
+	}{
+		{"valid", CreateOptions{Name: internal.String("deploy"), Value: &key}, nil},
+		{"missing name", CreateOptions{Value: &key}, &internal.MissingParameterError{Parameter: "name"}},
+		{"missing value", CreateOptions{Name: internal.String("deploy")}, &internal.MissingParameterError{Parameter: "value"}},
+		{"empty name", CreateOptions{Name: internal.String(""), Value: &key}, internal.ErrRequiredName},
+		{"invalid value", CreateOptions{Name: internal.String("deploy"), Value: internal.String("not-a-key")}, ErrInvalidPrivateKey},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := newSSHKey("acme", tt.opts)
+			if tt.want != nil {
+				if !errors.Is(err, tt.want) {
+					assert.Equal(t, tt.want, err)
+				}
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, "deploy", got.Name)
+			assert.Equal(t, "acme", got.Organization)
+			assert.Equal(t, []byte(key), got.value)
+		})
+	}
+}
+
+func newTestPrivateKey(t *testing.T) string {
+	t.Helper()
+
+	_, priv, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	der, err := x509.MarshalPKCS8PrivateKey(priv)
+	require.NoError(t, err)
+	return string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}))
+}
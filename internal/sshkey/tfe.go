@@ -0,0 +1,192 @@
+package sshkey
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/leg100/otf/internal"
+	"github.com/leg100/otf/internal/http/decode"
+	"github.com/leg100/otf/internal/resource"
+	"github.com/leg100/otf/internal/tfeapi"
+	"github.com/leg100/otf/internal/tfeapi/types"
+)
+
+type tfe struct {
+	*Service
+	*tfeapi.Responder
+}
+
+func (a *tfe) addHandlers(r *mux.Router) {
+	r = r.PathPrefix(tfeapi.APIPrefixV2).Subrouter()
+
+	r.HandleFunc("/organizations/{organization_name}/ssh-keys", a.createSSHKey).Methods("POST")
+	r.HandleFunc("/organizations/{organization_name}/ssh-keys", a.listSSHKeys).Methods("GET")
+	r.HandleFunc("/ssh-keys/{id}", a.getSSHKey).Methods("GET")
+	r.HandleFunc("/ssh-keys/{id}", a.updateSSHKey).Methods("PATCH")
+	r.HandleFunc("/ssh-keys/{id}", a.deleteSSHKey).Methods("DELETE")
+
+	r.HandleFunc("/workspaces/{workspace_id}/relationships/ssh-key", a.assignSSHKey).Methods("PATCH")
+}
+
+func (a *tfe) createSSHKey(w http.ResponseWriter, r *http.Request) {
+	org, err := decode.Param("organization_name", r)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	var params types.SSHKeyCreateOptions
+	if err := tfeapi.Unmarshal(r.Body, &params); err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+
+	key, err := a.Create(r.Context(), org, CreateOptions{
+		Name:  params.Name,
+		Value: params.Value,
+	})
+	if errors.Is(err, ErrInvalidPrivateKey) {
+		tfeapi.Error(w, &internal.HTTPError{Code: http.StatusUnprocessableEntity, Message: err.Error()})
+		return
+	} else if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+
+	a.Respond(w, r, a.convert(key), http.StatusCreated)
+}
+
+func (a *tfe) listSSHKeys(w http.ResponseWriter, r *http.Request) {
+	var params struct {
+		Organization string `schema:"organization_name,required"`
+		resource.PageOptions
+	}
+	if err := decode.All(&params, r); err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+
+	keys, err := a.List(r.Context(), params.Organization)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+
+	// convert items
+	items := make([]*types.SSHKey, len(keys))
+	for i, from := range keys {
+		items[i] = a.convert(from)
+	}
+	page := resource.NewPage(items, params.PageOptions, nil)
+	a.RespondWithPage(w, r, page.Items, page.Pagination)
+}
+
+func (a *tfe) getSSHKey(w http.ResponseWriter, r *http.Request) {
+	id, err := decode.Param("id", r)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+
+	key, err := a.Get(r.Context(), id)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+
+	a.Respond(w, r, a.convert(key), http.StatusOK)
+}
+
+func (a *tfe) updateSSHKey(w http.ResponseWriter, r *http.Request) {
+	id, err := decode.Param("id", r)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	var params types.SSHKeyUpdateOptions
+	if err := tfeapi.Unmarshal(r.Body, &params); err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+
+	key, err := a.Update(r.Context(), id, UpdateOptions{
+		Name:  params.Name,
+		Value: params.Value,
+	})
+	if errors.Is(err, ErrInvalidPrivateKey) {
+		tfeapi.Error(w, &internal.HTTPError{Code: http.StatusUnprocessableEntity, Message: err.Error()})
+		return
+	} else if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+
+	a.Respond(w, r, a.convert(key), http.StatusOK)
+}
+
+func (a *tfe) deleteSSHKey(w http.ResponseWriter, r *http.Request) {
+	id, err := decode.Param("id", r)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+
+	if err := a.Delete(r.Context(), id); err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// assignSSHKey assigns an SSH key to a workspace, or unassigns the current key
+// if the ID is null.
+func (a *tfe) assignSSHKey(w http.ResponseWriter, r *http.Request) {
+	workspaceID, err := decode.Param("workspace_id", r)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	// The go-tfe client sends the key ID as an attribute named "id", which
+	// the jsonapi library cannot unmarshal, so decode the payload by hand.
+	var params struct {
+		Data struct {
+			Attributes struct {
+				ID *string `json:"id"`
+			} `json:"attributes"`
+		} `json:"data"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&params); err != nil {
+		tfeapi.Error(w, &internal.HTTPError{Code: http.StatusUnprocessableEntity, Message: err.Error()})
+		return
+	}
+
+	keyID := params.Data.Attributes.ID
+	if keyID != nil {
+		err = a.AssignToWorkspace(r.Context(), workspaceID, *keyID)
+	} else {
+		err = a.UnassignFromWorkspace(r.Context(), workspaceID)
+	}
+	if errors.Is(err, ErrDifferentOrganization) {
+		tfeapi.Error(w, &internal.HTTPError{Code: http.StatusUnprocessableEntity, Message: err.Error()})
+		return
+	} else if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+
+	// respond with the workspace and its (possibly absent) SSH key relationship
+	to := &types.Workspace{ID: workspaceID}
+	if keyID != nil {
+		to.SSHKey = &types.SSHKey{ID: *keyID}
+	}
+	a.Respond(w, r, to, http.StatusOK)
+}
+
+func (a *tfe) convert(from *SSHKey) *types.SSHKey {
+	return &types.SSHKey{
+		ID:   from.ID,
+		Name: from.Name,
+	}
+}
@@ -0,0 +1,80 @@
+package sso
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/leg100/otf/internal"
+	otfapi "github.com/leg100/otf/internal/api"
+	"github.com/leg100/otf/internal/http/decode"
+	"github.com/leg100/otf/internal/tfeapi"
+)
+
+type api struct {
+	*Service
+}
+
+func (a *api) addHandlers(r *mux.Router) {
+	r = r.PathPrefix(otfapi.DefaultBasePath).Subrouter()
+
+	r.HandleFunc("/organizations/{organization_name}/sso-enforcement", a.get).Methods("GET")
+	r.HandleFunc("/organizations/{organization_name}/sso-enforcement", a.enforce).Methods("PUT")
+	r.HandleFunc("/organizations/{organization_name}/sso-enforcement", a.unenforce).Methods("DELETE")
+}
+
+func (a *api) get(w http.ResponseWriter, r *http.Request) {
+	organization, err := decode.Param("organization_name", r)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	enforcement, err := a.Get(r.Context(), organization)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	a.respond(w, enforcement, http.StatusOK)
+}
+
+func (a *api) enforce(w http.ResponseWriter, r *http.Request) {
+	organization, err := decode.Param("organization_name", r)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	var opts EnforceOptions
+	if err := json.NewDecoder(r.Body).Decode(&opts); err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	enforcement, err := a.Enforce(r.Context(), organization, opts)
+	if err != nil {
+		if errors.Is(err, ErrLockout) {
+			err = &internal.HTTPError{Code: http.StatusUnprocessableEntity, Message: err.Error()}
+		}
+		tfeapi.Error(w, err)
+		return
+	}
+	a.respond(w, enforcement, http.StatusOK)
+}
+
+func (a *api) unenforce(w http.ResponseWriter, r *http.Request) {
+	organization, err := decode.Param("organization_name", r)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	if err := a.Unenforce(r.Context(), organization); err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (a *api) respond(w http.ResponseWriter, v any, code int) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	json.NewEncoder(w).Encode(v)
+}
@@ -0,0 +1,69 @@
+package sso
+
+import "sync"
+
+// cache is an in-memory copy of the single sign-on enforcements, keyed by
+// organization, sparing a database query every time a request is
+// authenticated. It is only consulted once it has been loaded.
+type cache struct {
+	mu           sync.RWMutex
+	loaded       bool
+	enforcements map[string]*Enforcement
+}
+
+// load replaces the contents of the cache with the given enforcements and
+// marks it as loaded.
+func (c *cache) load(enforcements []*Enforcement) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.enforcements = make(map[string]*Enforcement, len(enforcements))
+	for _, e := range enforcements {
+		c.enforcements[e.Organization] = e
+	}
+	c.loaded = true
+}
+
+// reset marks the cache as no longer loaded, i.e. when it can no longer be
+// kept up to date.
+func (c *cache) reset() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.enforcements = nil
+	c.loaded = false
+}
+
+func (c *cache) set(enforcement *Enforcement) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.loaded {
+		c.enforcements[enforcement.Organization] = enforcement
+	}
+}
+
+func (c *cache) remove(organization string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	delete(c.enforcements, organization)
+}
+
+// listByOrganizations returns the enforcements for the given organizations,
+// and false if the cache is not loaded.
+func (c *cache) listByOrganizations(organizations []string) ([]*Enforcement, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	if !c.loaded {
+		return nil, false
+	}
+	var enforcements []*Enforcement
+	for _, org := range organizations {
+		if e, ok := c.enforcements[org]; ok {
+			enforcements = append(enforcements, e)
+		}
+	}
+	return enforcements, true
+}
@@ -0,0 +1,74 @@
+package sso
+
+import (
+	"context"
+
+	"github.com/jackc/pgtype"
+	"github.com/leg100/otf/internal/sql"
+	"github.com/leg100/otf/internal/sql/pggen"
+)
+
+// pgdb is a database of single sign-on enforcements on postgres
+type pgdb struct {
+	*sql.DB // provides access to generated SQL queries
+}
+
+type enforcementRow struct {
+	OrganizationName pgtype.Text        `json:"organization_name"`
+	ExemptUsernames  []string           `json:"exempt_usernames"`
+	UpdatedAt        pgtype.Timestamptz `json:"updated_at"`
+}
+
+func (r enforcementRow) toEnforcement() *Enforcement {
+	return &Enforcement{
+		Organization:    r.OrganizationName.String,
+		ExemptUsernames: r.ExemptUsernames,
+		UpdatedAt:       r.UpdatedAt.Time.UTC(),
+	}
+}
+
+func (db *pgdb) upsert(ctx context.Context, enforcement *Enforcement) error {
+	_, err := db.Conn(ctx).UpsertSSOEnforcement(ctx, pggen.UpsertSSOEnforcementParams{
+		OrganizationName: sql.String(enforcement.Organization),
+		ExemptUsernames:  enforcement.ExemptUsernames,
+		UpdatedAt:        sql.Timestamptz(enforcement.UpdatedAt),
+	})
+	return sql.Error(err)
+}
+
+func (db *pgdb) get(ctx context.Context, organization string) (*Enforcement, error) {
+	row, err := db.Conn(ctx).FindSSOEnforcement(ctx, sql.String(organization))
+	if err != nil {
+		return nil, sql.Error(err)
+	}
+	return enforcementRow(row).toEnforcement(), nil
+}
+
+func (db *pgdb) listByOrganizations(ctx context.Context, organizations []string) ([]*Enforcement, error) {
+	rows, err := db.Conn(ctx).FindSSOEnforcementsByOrganizations(ctx, organizations)
+	if err != nil {
+		return nil, sql.Error(err)
+	}
+	enforcements := make([]*Enforcement, len(rows))
+	for i, r := range rows {
+		enforcements[i] = enforcementRow(r).toEnforcement()
+	}
+	return enforcements, nil
+}
+
+func (db *pgdb) listAll(ctx context.Context) ([]*Enforcement, error) {
+	rows, err := db.Conn(ctx).FindSSOEnforcements(ctx)
+	if err != nil {
+		return nil, sql.Error(err)
+	}
+	enforcements := make([]*Enforcement, len(rows))
+	for i, r := range rows {
+		enforcements[i] = enforcementRow(r).toEnforcement()
+	}
+	return enforcements, nil
+}
+
+func (db *pgdb) delete(ctx context.Context, organization string) error {
+	_, err := db.Conn(ctx).DeleteSSOEnforcement(ctx, sql.String(organization))
+	return sql.Error(err)
+}
@@ -0,0 +1,67 @@
+// Package sso enforces single sign-on for the members of organizations.
+package sso
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/leg100/otf/internal"
+)
+
+var (
+	// ErrSSORequired is returned when a member of an organization that
+	// enforces single sign-on authenticates without single sign-on.
+	ErrSSORequired = errors.New("organization requires single sign-on")
+
+	// ErrLockout is returned when enforcing single sign-on would prevent the
+	// subject enforcing it from accessing the organization.
+	ErrLockout = errors.New("enforcing single sign-on would lock you out: log in with single sign-on, or add yourself to the exempt usernames")
+)
+
+type (
+	// Enforcement requires the members of an organization to authenticate
+	// with credentials issued via single sign-on: a session started by logging
+	// in with the OIDC identity provider, or a token created during such a
+	// session. Logins via other providers, and tokens created outside of such
+	// a session, are rejected.
+	Enforcement struct {
+		Organization string `json:"organization"`
+		// ExemptUsernames are members permitted to authenticate without
+		// single sign-on, i.e. break-glass accounts for use should the
+		// identity provider be unavailable.
+		ExemptUsernames []string  `json:"exempt_usernames"`
+		UpdatedAt       time.Time `json:"updated_at"`
+	}
+
+	EnforceOptions struct {
+		ExemptUsernames []string `json:"exempt_usernames"`
+	}
+)
+
+func newEnforcement(organization string, opts EnforceOptions) *Enforcement {
+	exempt := opts.ExemptUsernames
+	if exempt == nil {
+		exempt = []string{}
+	}
+	return &Enforcement{
+		Organization:    organization,
+		ExemptUsernames: exempt,
+		UpdatedAt:       internal.CurrentTimestamp(nil),
+	}
+}
+
+// IsExempt determines whether the user is permitted to authenticate without
+// single sign-on.
+func (e *Enforcement) IsExempt(username string) bool {
+	return slices.Contains(e.ExemptUsernames, username)
+}
+
+// check checks whether the user may authenticate without single sign-on.
+func (e *Enforcement) check(username string) error {
+	if e.IsExempt(username) {
+		return nil
+	}
+	return fmt.Errorf("%w: %s requires its members to log in with single sign-on", ErrSSORequired, e.Organization)
+}
@@ -0,0 +1,186 @@
+package sso
+
+import (
+	"context"
+
+	"github.com/gorilla/mux"
+	"github.com/leg100/otf/internal"
+	"github.com/leg100/otf/internal/logr"
+	"github.com/leg100/otf/internal/organization"
+	"github.com/leg100/otf/internal/pubsub"
+	"github.com/leg100/otf/internal/rbac"
+	"github.com/leg100/otf/internal/sql"
+	"github.com/leg100/otf/internal/tokens"
+	"github.com/leg100/otf/internal/user"
+)
+
+type (
+	Service struct {
+		logr.Logger
+
+		organization internal.Authorizer
+
+		db     store
+		api    *api
+		broker pubsubBroker
+		cache  cache
+	}
+
+	Options struct {
+		logr.Logger
+		*sql.DB
+		*sql.Listener
+
+		TokensService *tokens.Service
+	}
+
+	store interface {
+		upsert(ctx context.Context, enforcement *Enforcement) error
+		get(ctx context.Context, organization string) (*Enforcement, error)
+		listByOrganizations(ctx context.Context, organizations []string) ([]*Enforcement, error)
+		listAll(ctx context.Context) ([]*Enforcement, error)
+		delete(ctx context.Context, organization string) error
+	}
+
+	pubsubBroker interface {
+		Subscribe(ctx context.Context) (<-chan pubsub.Event[*Enforcement], func())
+	}
+)
+
+func NewService(opts Options) *Service {
+	svc := Service{
+		Logger:       opts.Logger,
+		organization: &organization.Authorizer{Logger: opts.Logger},
+		db:           &pgdb{opts.DB},
+	}
+	svc.api = &api{Service: &svc}
+	svc.broker = pubsub.NewBroker(
+		opts.Logger,
+		opts.Listener,
+		"sso_enforcements",
+		func(ctx context.Context, organization string, action sql.Action) (*Enforcement, error) {
+			if action == sql.DeleteAction {
+				return &Enforcement{Organization: organization}, nil
+			}
+			return svc.db.get(ctx, organization)
+		},
+	)
+	// enforce single sign-on when authenticating requests
+	opts.TokensService.RegisterSSOEnforcer(&svc)
+	return &svc
+}
+
+func (s *Service) AddHandlers(r *mux.Router) {
+	s.api.addHandlers(r)
+}
+
+// Start populates the cache of enforcements and keeps it up to date with
+// changes made on any node until the context is canceled. Should be invoked
+// in a go routine.
+func (s *Service) Start(ctx context.Context) error {
+	// subscribe before loading the cache so that no changes are missed
+	sub, unsub := s.broker.Subscribe(ctx)
+	defer unsub()
+	defer s.cache.reset()
+
+	enforcements, err := s.db.listAll(ctx)
+	if err != nil {
+		return err
+	}
+	s.cache.load(enforcements)
+
+	for event := range sub {
+		if event.Type == pubsub.DeletedEvent {
+			s.cache.remove(event.Payload.Organization)
+		} else {
+			s.cache.set(event.Payload)
+		}
+	}
+	return pubsub.ErrSubscriptionTerminated
+}
+
+// Enforce enforces single sign-on for the members of an organization, exempting
+// the given usernames. If already enforced then the exempt usernames are
+// updated. To guard against owners locking themselves out, the subject must
+// have authenticated via single sign-on, or be exempt.
+func (s *Service) Enforce(ctx context.Context, organization string, opts EnforceOptions) (*Enforcement, error) {
+	subject, err := s.organization.CanAccess(ctx, rbac.UpdateSSOEnforcementAction, organization)
+	if err != nil {
+		return nil, err
+	}
+	enforcement := newEnforcement(organization, opts)
+	if u, ok := subject.(*user.User); ok && !tokens.IsSSO(ctx) && !u.IsSiteAdmin() && !enforcement.IsExempt(u.Username) {
+		return nil, ErrLockout
+	}
+	if err := s.db.upsert(ctx, enforcement); err != nil {
+		s.Error(err, "enforcing single sign-on", "organization", organization, "subject", subject)
+		return nil, err
+	}
+	s.cache.set(enforcement)
+	s.V(0).Info("enforced single sign-on", "organization", organization, "exempt", enforcement.ExemptUsernames, "subject", subject)
+	return enforcement, nil
+}
+
+// Get retrieves the enforcement of single sign-on for an organization,
+// returning internal.ErrResourceNotFound if it is not enforced.
+func (s *Service) Get(ctx context.Context, organization string) (*Enforcement, error) {
+	subject, err := s.organization.CanAccess(ctx, rbac.GetSSOEnforcementAction, organization)
+	if err != nil {
+		return nil, err
+	}
+	enforcement, err := s.db.get(ctx, organization)
+	if err != nil {
+		s.Error(err, "retrieving single sign-on enforcement", "organization", organization, "subject", subject)
+		return nil, err
+	}
+	s.V(9).Info("retrieved single sign-on enforcement", "organization", organization, "subject", subject)
+	return enforcement, nil
+}
+
+// Unenforce stops enforcing single sign-on for the members of an
+// organization.
+func (s *Service) Unenforce(ctx context.Context, organization string) error {
+	subject, err := s.organization.CanAccess(ctx, rbac.UpdateSSOEnforcementAction, organization)
+	if err != nil {
+		return err
+	}
+	if err := s.db.delete(ctx, organization); err != nil {
+		s.Error(err, "unenforcing single sign-on", "organization", organization, "subject", subject)
+		return err
+	}
+	s.cache.remove(organization)
+	s.V(0).Info("unenforced single sign-on", "organization", organization, "subject", subject)
+	return nil
+}
+
+// EnforceSSO implements tokens.SSOEnforcer, returning an error if the subject
+// is a member of an organization that enforces single sign-on, and is not
+// exempt. Only users are subject to enforcement; site admins, and other kinds
+// of subjects such as team and agent tokens, are not. Enforcements are read
+// from the cache, falling back to the database until the cache is loaded.
+func (s *Service) EnforceSSO(ctx context.Context, subject internal.Subject) error {
+	u, ok := subject.(*user.User)
+	if !ok || u.IsSiteAdmin() {
+		return nil
+	}
+	organizations := u.Organizations()
+	if len(organizations) == 0 {
+		return nil
+	}
+	enforcements, ok := s.cache.listByOrganizations(organizations)
+	if !ok {
+		var err error
+		enforcements, err = s.db.listByOrganizations(ctx, organizations)
+		if err != nil {
+			s.Error(err, "retrieving single sign-on enforcements", "user", u)
+			return err
+		}
+	}
+	for _, enforcement := range enforcements {
+		if err := enforcement.check(u.Username); err != nil {
+			s.V(1).Info("rejected user authenticating without single sign-on", "organization", enforcement.Organization, "user", u)
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,152 @@
+package sso
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/leg100/otf/internal"
+	"github.com/leg100/otf/internal/organization"
+	"github.com/leg100/otf/internal/pubsub"
+	"github.com/leg100/otf/internal/team"
+	"github.com/leg100/otf/internal/user"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeStore struct {
+	enforcements []*Enforcement
+	upserted     *Enforcement
+	queries      int
+	store
+}
+
+func (f *fakeStore) listAll(context.Context) ([]*Enforcement, error) {
+	return f.enforcements, nil
+}
+
+func (f *fakeStore) listByOrganizations(_ context.Context, organizations []string) ([]*Enforcement, error) {
+	f.queries++
+	var enforcements []*Enforcement
+	for _, e := range f.enforcements {
+		for _, org := range organizations {
+			if e.Organization == org {
+				enforcements = append(enforcements, e)
+			}
+		}
+	}
+	return enforcements, nil
+}
+
+func (f *fakeStore) upsert(_ context.Context, enforcement *Enforcement) error {
+	f.upserted = enforcement
+	return nil
+}
+
+func TestService_EnforceSSO(t *testing.T) {
+	svc := &Service{
+		Logger: logr.Discard(),
+		db: &fakeStore{enforcements: []*Enforcement{
+			{Organization: "acme-corp", ExemptUsernames: []string{"break-glass"}},
+		}},
+	}
+	member := func(username, org string) *user.User {
+		return user.NewUser(username, user.WithTeams(&team.Team{Name: "devs", Organization: org}))
+	}
+
+	tests := []struct {
+		name    string
+		subject internal.Subject
+		wantErr error
+	}{
+		{"member", member("bobby", "acme-corp"), ErrSSORequired},
+		{"exempt member", member("break-glass", "acme-corp"), nil},
+		{"member of other organization", member("bobby", "other-corp"), nil},
+		{"user without memberships", user.NewUser("bobby"), nil},
+		{"site admin", &user.User{ID: user.SiteAdminID, Username: user.SiteAdminUsername}, nil},
+		{"non-user subject", &internal.Superuser{Username: "bobby"}, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := svc.EnforceSSO(context.Background(), tt.subject)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+type fakeBroker struct {
+	events chan pubsub.Event[*Enforcement]
+}
+
+func (f *fakeBroker) Subscribe(context.Context) (<-chan pubsub.Event[*Enforcement], func()) {
+	return f.events, func() {}
+}
+
+func TestService_EnforceSSO_Cache(t *testing.T) {
+	db := &fakeStore{enforcements: []*Enforcement{{Organization: "acme-corp"}}}
+	broker := &fakeBroker{events: make(chan pubsub.Event[*Enforcement])}
+	svc := &Service{Logger: logr.Discard(), db: db, broker: broker}
+	bobby := user.NewUser("bobby", user.WithTeams(
+		&team.Team{Name: "devs", Organization: "acme-corp"},
+		&team.Team{Name: "devs", Organization: "other-corp"},
+	))
+
+	// until the cache is loaded the database is queried
+	assert.ErrorIs(t, svc.EnforceSSO(context.Background(), bobby), ErrSSORequired)
+	assert.Equal(t, 1, db.queries)
+
+	done := make(chan error)
+	go func() { done <- svc.Start(context.Background()) }()
+
+	// the channel is unbuffered, so once an event has been received the
+	// cache has been loaded and all prior events have been applied
+	broker.events <- pubsub.NewDeletedEvent(&Enforcement{Organization: "acme-corp"})
+	broker.events <- pubsub.NewCreatedEvent(&Enforcement{Organization: "other-corp"})
+	broker.events <- pubsub.NewUpdatedEvent(&Enforcement{Organization: "unrelated-corp"})
+	assert.ErrorIs(t, svc.EnforceSSO(context.Background(), bobby), ErrSSORequired)
+
+	broker.events <- pubsub.NewUpdatedEvent(&Enforcement{Organization: "other-corp", ExemptUsernames: []string{"bobby"}})
+	broker.events <- pubsub.NewUpdatedEvent(&Enforcement{Organization: "unrelated-corp"})
+	assert.NoError(t, svc.EnforceSSO(context.Background(), bobby))
+
+	// no further queries made once the cache is loaded
+	assert.Equal(t, 1, db.queries)
+
+	// cache is no longer consulted once it stops being kept up to date
+	close(broker.events)
+	require.ErrorIs(t, <-done, pubsub.ErrSubscriptionTerminated)
+	assert.ErrorIs(t, svc.EnforceSSO(context.Background(), bobby), ErrSSORequired)
+	assert.Equal(t, 2, db.queries)
+}
+
+func TestService_Enforce(t *testing.T) {
+	owner := user.NewUser("bobby", user.WithTeams(&team.Team{Name: "owners", Organization: "acme-corp"}))
+	ctx := internal.AddSubjectToContext(context.Background(), owner)
+
+	t.Run("prevent lockout", func(t *testing.T) {
+		svc := &Service{
+			Logger:       logr.Discard(),
+			organization: &organization.Authorizer{Logger: logr.Discard()},
+			db:           &fakeStore{},
+		}
+		_, err := svc.Enforce(ctx, "acme-corp", EnforceOptions{})
+		assert.ErrorIs(t, err, ErrLockout)
+	})
+
+	t.Run("exempt owner", func(t *testing.T) {
+		db := &fakeStore{}
+		svc := &Service{
+			Logger:       logr.Discard(),
+			organization: &organization.Authorizer{Logger: logr.Discard()},
+			db:           db,
+		}
+		got, err := svc.Enforce(ctx, "acme-corp", EnforceOptions{ExemptUsernames: []string{"bobby"}})
+		require.NoError(t, err)
+		assert.Equal(t, "acme-corp", got.Organization)
+		assert.Equal(t, got, db.upserted)
+	})
+}
@@ -1,10 +1,13 @@
 package state
 
 import (
+	"errors"
 	"net/http"
 
 	"github.com/gorilla/mux"
+	"github.com/leg100/otf/internal"
 	otfapi "github.com/leg100/otf/internal/api"
+	"github.com/leg100/otf/internal/audit"
 	"github.com/leg100/otf/internal/http/decode"
 	"github.com/leg100/otf/internal/resource"
 	"github.com/leg100/otf/internal/tfeapi"
@@ -101,6 +104,9 @@ func (a *api) downloadState(w http.ResponseWriter, r *http.Request) {
 	}
 	resp, err := a.Download(r.Context(), versionID)
 	if err != nil {
+		if errors.Is(err, audit.ErrJustificationRequired) {
+			err = &internal.HTTPError{Code: http.StatusForbidden, Message: err.Error()}
+		}
 		tfeapi.Error(w, err)
 		return
 	}
@@ -6,14 +6,22 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"os"
 
 	"github.com/leg100/otf/internal"
 	otfapi "github.com/leg100/otf/internal/api"
+	"github.com/leg100/otf/internal/audit"
 	"github.com/leg100/otf/internal/resource"
 	"github.com/leg100/otf/internal/workspace"
 	"github.com/spf13/cobra"
 )
 
+var (
+	ErrLineageMismatch = errors.New("the lineage of the state file does not match the lineage of the current state")
+
+	errNoCurrentState = errors.New("workspace has no current state")
+)
+
 type CLI struct {
 	state      cliStateService
 	workspaces cliWorkspaceService
@@ -23,12 +31,15 @@ type cliStateService interface {
 	List(ctx context.Context, workspaceID string, opts resource.PageOptions) (*resource.Page[*Version], error)
 	GetCurrent(ctx context.Context, workspaceID string) (*Version, error)
 	Download(ctx context.Context, versionID string) ([]byte, error)
+	Create(ctx context.Context, opts CreateStateVersionOptions) (*Version, error)
 	Rollback(ctx context.Context, versionID string) (*Version, error)
 	Delete(ctx context.Context, versionID string) error
 }
 
 type cliWorkspaceService interface {
 	GetByName(ctx context.Context, organization, workspace string) (*workspace.Workspace, error)
+	Lock(ctx context.Context, workspaceID string, runID *string) (*workspace.Workspace, error)
+	Unlock(ctx context.Context, workspaceID string, runID *string, force bool) (*workspace.Workspace, error)
 }
 
 func NewCommand(client *otfapi.Client) *cobra.Command {
@@ -50,6 +61,10 @@ func NewCommand(client *otfapi.Client) *cobra.Command {
 	cmd.AddCommand(cli.stateListCommand())
 	cmd.AddCommand(cli.stateDeleteCommand())
 	cmd.AddCommand(cli.stateDownloadCommand())
+	cmd.AddCommand(cli.statePullCommand())
+	cmd.AddCommand(cli.statePushCommand())
+	cmd.AddCommand(cli.stateRemoveCommand())
+	cmd.AddCommand(cli.stateMoveCommand())
 
 	return cmd
 }
@@ -124,14 +139,16 @@ func (a *CLI) stateDeleteCommand() *cobra.Command {
 }
 
 func (a *CLI) stateDownloadCommand() *cobra.Command {
-	return &cobra.Command{
+	var justification string
+	cmd := &cobra.Command{
 		Use:           "download [id]",
 		Short:         "Download state version",
 		Args:          cobra.ExactArgs(1),
 		SilenceUsage:  true,
 		SilenceErrors: true,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			state, err := a.state.Download(cmd.Context(), args[0])
+			ctx := withJustification(cmd.Context(), justification)
+			state, err := a.state.Download(ctx, args[0])
 			if err != nil {
 				return err
 			}
@@ -143,6 +160,10 @@ func (a *CLI) stateDownloadCommand() *cobra.Command {
 			return nil
 		},
 	}
+
+	addJustificationFlag(cmd, &justification)
+
+	return cmd
 }
 
 func (a *CLI) stateRollbackCommand() *cobra.Command {
@@ -162,3 +183,289 @@ func (a *CLI) stateRollbackCommand() *cobra.Command {
 		},
 	}
 }
+
+// editFlags are the flags for commands that modify the current state of a
+// workspace.
+type editFlags struct {
+	organization  string
+	workspace     string
+	backup        string
+	justification string
+}
+
+func (f *editFlags) addFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&f.organization, "organization", "", "Name of the organization the workspace belongs to")
+	cmd.MarkFlagRequired("organization")
+
+	cmd.Flags().StringVar(&f.workspace, "workspace", "", "Name of the workspace")
+	cmd.MarkFlagRequired("workspace")
+
+	cmd.Flags().StringVar(&f.backup, "backup", "", "Path to which the current state is backed up. Defaults to <workspace>.<serial>.tfstate.backup. Set to - to disable backup.")
+
+	addJustificationFlag(cmd, &f.justification)
+}
+
+// addJustificationFlag adds a flag for justifying downloading state, which
+// is required by organizations that audit state downloads.
+func addJustificationFlag(cmd *cobra.Command, justification *string) {
+	cmd.Flags().StringVar(justification, "justification", "", "Justification for downloading state, required by organizations that audit state downloads")
+}
+
+func withJustification(ctx context.Context, justification string) context.Context {
+	if justification == "" {
+		return ctx
+	}
+	return audit.AddJustificationToContext(ctx, justification)
+}
+
+func (a *CLI) statePullCommand() *cobra.Command {
+	var (
+		opts          StateVersionListOptions
+		justification string
+	)
+	cmd := &cobra.Command{
+		Use:           "pull",
+		Short:         "Download the current state of a workspace",
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := withJustification(cmd.Context(), justification)
+
+			workspace, err := a.workspaces.GetByName(ctx, opts.Organization, opts.Workspace)
+			if err != nil {
+				return err
+			}
+			current, err := a.state.GetCurrent(ctx, workspace.ID)
+			if err != nil {
+				return fmt.Errorf("retrieving current state version: %w", err)
+			}
+			state, err := a.state.Download(ctx, current.ID)
+			if err != nil {
+				return err
+			}
+			_, err = cmd.OutOrStdout().Write(state)
+			return err
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.Organization, "organization", "", "Name of the organization the workspace belongs to")
+	cmd.MarkFlagRequired("organization")
+
+	cmd.Flags().StringVar(&opts.Workspace, "workspace", "", "Name of the workspace")
+	cmd.MarkFlagRequired("workspace")
+
+	addJustificationFlag(cmd, &justification)
+
+	return cmd
+}
+
+func (a *CLI) statePushCommand() *cobra.Command {
+	var (
+		flags editFlags
+		force bool
+	)
+	cmd := &cobra.Command{
+		Use:           "push [path]",
+		Short:         "Upload a local state file to a workspace",
+		Args:          cobra.ExactArgs(1),
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			local, err := os.ReadFile(args[0])
+			if err != nil {
+				return err
+			}
+			sv, err := a.editCurrent(cmd, flags, func(current *Version, state []byte) ([]byte, error) {
+				if current == nil {
+					// no state to check against
+					return local, nil
+				}
+				f, err := parseEditableFile(local)
+				if err != nil {
+					return nil, err
+				}
+				serial, err := f.serial()
+				if err != nil {
+					return nil, err
+				}
+				remote, err := parseEditableFile(state)
+				if err != nil {
+					return nil, fmt.Errorf("parsing current state: %w", err)
+				}
+				if force {
+					// overwrite current state regardless of lineage and
+					// serial
+					f.setSerial(current.Serial)
+					return f.marshal()
+				}
+				if f.lineage() != remote.lineage() {
+					return nil, ErrLineageMismatch
+				}
+				if serial <= current.Serial {
+					return nil, ErrSerialNotGreaterThanCurrent
+				}
+				return local, nil
+			})
+			if err != nil {
+				return err
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "Created state version: %s (serial %d)\n", sv.ID, sv.Serial)
+			return nil
+		},
+	}
+
+	flags.addFlags(cmd)
+	cmd.Flags().BoolVar(&force, "force", false, "Overwrite the current state regardless of lineage and serial.")
+
+	return cmd
+}
+
+func (a *CLI) stateRemoveCommand() *cobra.Command {
+	var flags editFlags
+	cmd := &cobra.Command{
+		Use:           "rm [address...]",
+		Short:         "Remove resources from the current state of a workspace",
+		Args:          cobra.MinimumNArgs(1),
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			addrs := make([]address, len(args))
+			for i, arg := range args {
+				addr, err := parseAddress(arg)
+				if err != nil {
+					return err
+				}
+				addrs[i] = addr
+			}
+			sv, err := a.editCurrent(cmd, flags, func(current *Version, state []byte) ([]byte, error) {
+				if current == nil {
+					return nil, errNoCurrentState
+				}
+				f, err := parseEditableFile(state)
+				if err != nil {
+					return nil, err
+				}
+				for _, addr := range addrs {
+					if err := f.remove(addr); err != nil {
+						return nil, err
+					}
+				}
+				return f.marshal()
+			})
+			if err != nil {
+				return err
+			}
+			for _, addr := range addrs {
+				fmt.Fprintf(cmd.OutOrStdout(), "Removed %s\n", addr)
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "Created state version: %s (serial %d)\n", sv.ID, sv.Serial)
+			return nil
+		},
+	}
+
+	flags.addFlags(cmd)
+
+	return cmd
+}
+
+func (a *CLI) stateMoveCommand() *cobra.Command {
+	var flags editFlags
+	cmd := &cobra.Command{
+		Use:           "mv [source] [destination]",
+		Short:         "Move a resource to a different address in the current state of a workspace",
+		Args:          cobra.ExactArgs(2),
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			src, err := parseAddress(args[0])
+			if err != nil {
+				return err
+			}
+			dst, err := parseAddress(args[1])
+			if err != nil {
+				return err
+			}
+			sv, err := a.editCurrent(cmd, flags, func(current *Version, state []byte) ([]byte, error) {
+				if current == nil {
+					return nil, errNoCurrentState
+				}
+				f, err := parseEditableFile(state)
+				if err != nil {
+					return nil, err
+				}
+				if err := f.move(src, dst); err != nil {
+					return nil, err
+				}
+				return f.marshal()
+			})
+			if err != nil {
+				return err
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "Moved %s to %s\n", src, dst)
+			fmt.Fprintf(cmd.OutOrStdout(), "Created state version: %s (serial %d)\n", sv.ID, sv.Serial)
+			return nil
+		},
+	}
+
+	flags.addFlags(cmd)
+
+	return cmd
+}
+
+// editCurrent creates a new state version for a workspace from the state
+// returned by fn, which is passed the current state version and its state, or
+// nil if there is no current state version. The workspace is locked for the
+// duration, and the current state is backed up to a local file before the new
+// state version is created.
+func (a *CLI) editCurrent(cmd *cobra.Command, flags editFlags, fn func(current *Version, state []byte) ([]byte, error)) (sv *Version, err error) {
+	ctx := withJustification(cmd.Context(), flags.justification)
+
+	ws, err := a.workspaces.GetByName(ctx, flags.organization, flags.workspace)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := a.workspaces.Lock(ctx, ws.ID, nil); err != nil {
+		return nil, fmt.Errorf("locking workspace: %w", err)
+	}
+	defer func() {
+		if _, unlockErr := a.workspaces.Unlock(ctx, ws.ID, nil, false); unlockErr != nil && err == nil {
+			err = fmt.Errorf("unlocking workspace: %w", unlockErr)
+		}
+	}()
+
+	current, err := a.state.GetCurrent(ctx, ws.ID)
+	if err != nil && !errors.Is(err, internal.ErrResourceNotFound) {
+		return nil, fmt.Errorf("retrieving current state version: %w", err)
+	}
+	var state []byte
+	if current != nil {
+		state, err = a.state.Download(ctx, current.ID)
+		if err != nil {
+			return nil, fmt.Errorf("downloading current state: %w", err)
+		}
+		if flags.backup != "-" {
+			path := flags.backup
+			if path == "" {
+				path = fmt.Sprintf("%s.%d.tfstate.backup", ws.Name, current.Serial)
+			}
+			if err := os.WriteFile(path, state, 0o600); err != nil {
+				return nil, fmt.Errorf("backing up current state: %w", err)
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "Backed up current state to %s\n", path)
+		}
+	}
+
+	updated, err := fn(current, state)
+	if err != nil {
+		return nil, err
+	}
+	var file File
+	if err := json.Unmarshal(updated, &file); err != nil {
+		return nil, fmt.Errorf("parsing state file: %w", err)
+	}
+	return a.state.Create(ctx, CreateStateVersionOptions{
+		WorkspaceID: &ws.ID,
+		State:       updated,
+		Serial:      &file.Serial,
+	})
+}
@@ -3,6 +3,11 @@ package state
 import (
 	"bytes"
 	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/leg100/otf/internal"
@@ -94,6 +99,140 @@ func TestCLI_State(t *testing.T) {
 
 		assert.Equal(t, "Successfully rolled back state\n", got.String())
 	})
+
+	t.Run("pull", func(t *testing.T) {
+		want := testutils.ReadFile(t, "./testdata/terraform.tfstate")
+		cmd := newFakeCLI(
+			&workspace.Workspace{ID: "ws-123"},
+			withStateVersion(&Version{ID: "sv-123"}),
+			withState(want),
+		).statePullCommand()
+
+		cmd.SetArgs([]string{"--organization", "acme-corp", "--workspace", "dev"})
+		got := bytes.Buffer{}
+		cmd.SetOut(&got)
+		require.NoError(t, cmd.Execute())
+
+		assert.Equal(t, string(want), got.String())
+	})
+
+	t.Run("push", func(t *testing.T) {
+		// current state has serial 1, lineage f1d86b13-...
+		current := testutils.ReadFile(t, "./testdata/terraform.tfstate")
+		newer := strings.Replace(string(current), `"serial": 1`, `"serial": 2`, 1)
+		otherLineage := strings.Replace(newer, `f1d86b13`, `00000000`, 1)
+
+		tests := []struct {
+			name       string
+			local      string
+			force      bool
+			wantSerial int64
+			wantErr    error
+		}{
+			{"newer serial", newer, false, 2, nil},
+			{"same serial", string(current), false, 0, ErrSerialNotGreaterThanCurrent},
+			{"different lineage", otherLineage, false, 0, ErrLineageMismatch},
+			{"force", string(current), true, 2, nil},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				svc := &fakeCLIService{
+					workspace:    &workspace.Workspace{ID: "ws-123", Name: "dev"},
+					stateVersion: &Version{ID: "sv-123", Serial: 1},
+					state:        current,
+				}
+				cli := &CLI{state: svc, workspaces: svc}
+				dir := t.TempDir()
+				path := filepath.Join(dir, "terraform.tfstate")
+				require.NoError(t, os.WriteFile(path, []byte(tt.local), 0o600))
+				backup := filepath.Join(dir, "backup.tfstate")
+
+				cmd := cli.statePushCommand()
+				args := []string{path, "--organization", "acme-corp", "--workspace", "dev", "--backup", backup}
+				if tt.force {
+					args = append(args, "--force")
+				}
+				cmd.SetArgs(args)
+				cmd.SetOut(&bytes.Buffer{})
+				err := cmd.Execute()
+
+				assert.True(t, svc.unlocked)
+				if tt.wantErr != nil {
+					assert.ErrorIs(t, err, tt.wantErr)
+					assert.Nil(t, svc.created)
+					return
+				}
+				require.NoError(t, err)
+				require.NotNil(t, svc.created)
+				assert.Equal(t, tt.wantSerial, *svc.created.Serial)
+				assert.Equal(t, current, testutils.ReadFile(t, backup))
+			})
+		}
+	})
+
+	t.Run("rm", func(t *testing.T) {
+		svc := &fakeCLIService{
+			workspace:    &workspace.Workspace{ID: "ws-123", Name: "dev"},
+			stateVersion: &Version{ID: "sv-123", Serial: 1},
+			state:        testutils.ReadFile(t, "./testdata/terraform.tfstate"),
+		}
+		cli := &CLI{state: svc, workspaces: svc}
+		backup := filepath.Join(t.TempDir(), "backup.tfstate")
+
+		cmd := cli.stateRemoveCommand()
+		cmd.SetArgs([]string{"null_resource.demo", "--organization", "acme-corp", "--workspace", "dev", "--backup", backup})
+		got := bytes.Buffer{}
+		cmd.SetOut(&got)
+		require.NoError(t, cmd.Execute())
+
+		want := fmt.Sprintf("Backed up current state to %s\nRemoved null_resource.demo\nCreated state version: sv-new (serial 2)\n", backup)
+		assert.Equal(t, want, got.String())
+		assert.True(t, svc.unlocked)
+
+		var file File
+		require.NoError(t, json.Unmarshal(svc.created.State, &file))
+		assert.Equal(t, int64(2), file.Serial)
+		assert.Len(t, file.Resources, 0)
+		assert.Len(t, file.Outputs, 3)
+	})
+
+	t.Run("mv", func(t *testing.T) {
+		svc := &fakeCLIService{
+			workspace:    &workspace.Workspace{ID: "ws-123", Name: "dev"},
+			stateVersion: &Version{ID: "sv-123", Serial: 1},
+			state:        testutils.ReadFile(t, "./testdata/terraform.tfstate"),
+		}
+		cli := &CLI{state: svc, workspaces: svc}
+
+		cmd := cli.stateMoveCommand()
+		cmd.SetArgs([]string{"null_resource.demo", "module.foo.null_resource.demo", "--organization", "acme-corp", "--workspace", "dev", "--backup", "-"})
+		got := bytes.Buffer{}
+		cmd.SetOut(&got)
+		require.NoError(t, cmd.Execute())
+
+		want := "Moved null_resource.demo to module.foo.null_resource.demo\nCreated state version: sv-new (serial 2)\n"
+		assert.Equal(t, want, got.String())
+
+		var file File
+		require.NoError(t, json.Unmarshal(svc.created.State, &file))
+		require.Len(t, file.Resources, 1)
+		assert.Equal(t, "module.foo", file.Resources[0].Module)
+	})
+
+	t.Run("workspace already locked", func(t *testing.T) {
+		svc := &fakeCLIService{
+			workspace: &workspace.Workspace{ID: "ws-123", Name: "dev"},
+			locked:    true,
+		}
+		cli := &CLI{state: svc, workspaces: svc}
+
+		cmd := cli.stateRemoveCommand()
+		cmd.SetArgs([]string{"null_resource.demo", "--organization", "acme-corp", "--workspace", "dev"})
+		cmd.SetOut(&bytes.Buffer{})
+		err := cmd.Execute()
+		assert.ErrorIs(t, err, workspace.ErrWorkspaceAlreadyLocked)
+		assert.False(t, svc.unlocked)
+	})
 }
 
 type (
@@ -102,6 +241,10 @@ type (
 		stateVersionList *resource.Page[*Version]
 		state            []byte
 		workspace        *workspace.Workspace
+
+		created  *CreateStateVersionOptions // state version created
+		unlocked bool                       // workspace unlocked after being locked
+		locked   bool
 	}
 
 	fakeCLIOption func(*fakeCLIService)
@@ -148,6 +291,11 @@ func (f *fakeCLIService) Delete(ctx context.Context, svID string) error {
 	return nil
 }
 
+func (f *fakeCLIService) Create(ctx context.Context, opts CreateStateVersionOptions) (*Version, error) {
+	f.created = &opts
+	return &Version{ID: "sv-new", Serial: *opts.Serial}, nil
+}
+
 func (f *fakeCLIService) Rollback(ctx context.Context, svID string) (*Version, error) {
 	return f.stateVersion, nil
 }
@@ -159,3 +307,17 @@ func (f *fakeCLIService) Download(ctx context.Context, svID string) ([]byte, err
 func (f *fakeCLIService) GetByName(context.Context, string, string) (*workspace.Workspace, error) {
 	return f.workspace, nil
 }
+
+func (f *fakeCLIService) Lock(context.Context, string, *string) (*workspace.Workspace, error) {
+	if f.locked {
+		return nil, workspace.ErrWorkspaceAlreadyLocked
+	}
+	f.locked = true
+	return f.workspace, nil
+}
+
+func (f *fakeCLIService) Unlock(context.Context, string, *string, bool) (*workspace.Workspace, error) {
+	f.locked = false
+	f.unlocked = true
+	return f.workspace, nil
+}
@@ -10,6 +10,7 @@ import (
 
 	"github.com/leg100/otf/internal"
 	otfapi "github.com/leg100/otf/internal/api"
+	"github.com/leg100/otf/internal/audit"
 	"github.com/leg100/otf/internal/resource"
 	"github.com/leg100/otf/internal/tfeapi/types"
 )
@@ -90,6 +91,9 @@ func (c *Client) Download(ctx context.Context, svID string) ([]byte, error) {
 	if err != nil {
 		return nil, err
 	}
+	if justification := audit.JustificationFromContext(ctx); justification != nil {
+		req.Header.Set(audit.JustificationHeader, *justification)
+	}
 
 	var buf bytes.Buffer
 	err = c.Do(ctx, req, &buf)
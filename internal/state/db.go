@@ -96,6 +96,12 @@ func (db *pgdb) createOutputs(ctx context.Context, outputs []*Output) error {
 	})
 }
 
+// createVersionRun records the run that created a state version.
+func (db *pgdb) createVersionRun(ctx context.Context, svID, runID string) error {
+	_, err := db.Conn(ctx).InsertStateVersionRun(ctx, sql.String(svID), sql.String(runID))
+	return err
+}
+
 func (db *pgdb) uploadStateAndFinalize(ctx context.Context, svID string, state []byte) error {
 	_, err := db.Conn(ctx).UpdateState(ctx, state, sql.String(svID))
 	return sql.Error(err)
@@ -190,3 +196,35 @@ func (db *pgdb) discardPending(ctx context.Context, workspaceID string) error {
 	}
 	return nil
 }
+
+// archiveVersions copies the finalized state versions of a workspace and their
+// outputs to the archive of deleted state versions.
+func (db *pgdb) archiveVersions(ctx context.Context, workspaceID string) error {
+	return db.Tx(ctx, func(ctx context.Context, q pggen.Querier) error {
+		if _, err := q.InsertDeletedStateVersionsByWorkspaceID(ctx, sql.String(workspaceID)); err != nil {
+			return sql.Error(err)
+		}
+		if _, err := q.InsertDeletedStateVersionOutputsByWorkspaceID(ctx, sql.String(workspaceID)); err != nil {
+			return sql.Error(err)
+		}
+		return nil
+	})
+}
+
+// restoreVersions copies the archived state versions of a workspace and their
+// outputs back into the workspace, setting the latest version as its current
+// version.
+func (db *pgdb) restoreVersions(ctx context.Context, workspaceID string) error {
+	return db.Tx(ctx, func(ctx context.Context, q pggen.Querier) error {
+		if _, err := q.RestoreStateVersionsByWorkspaceID(ctx, sql.String(workspaceID)); err != nil {
+			return sql.Error(err)
+		}
+		if _, err := q.RestoreStateVersionOutputsByWorkspaceID(ctx, sql.String(workspaceID)); err != nil {
+			return sql.Error(err)
+		}
+		if _, err := q.RestoreWorkspaceCurrentStateVersionID(ctx, sql.String(workspaceID)); err != nil {
+			return sql.Error(err)
+		}
+		return nil
+	})
+}
@@ -0,0 +1,423 @@
+package state
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+var (
+	ErrNoMatchingObjects      = errors.New("no matching objects found in state")
+	ErrMoveTargetExists       = errors.New("target address already exists in state")
+	ErrMoveIncompatible       = errors.New("source and target addresses must both be modules, resources, or resource instances")
+	ErrMoveResourceTypeChange = errors.New("cannot move to a resource of a different type")
+)
+
+type (
+	// address identifies a module, resource, or resource instance in
+	// state, e.g. module.vpc, module.vpc.aws_subnet.private, or
+	// module.vpc.aws_subnet.private[0].
+	address struct {
+		module string // module path, e.g. module.a.module.b; empty for root
+		mode   string // managed or data
+		typ    string
+		name   string
+		// key is the instance index key, either a json.Number, a string, or
+		// nil if the instance has no key.
+		key    any
+		hasKey bool
+	}
+
+	// editableFile is a terraform state file that retains all of its
+	// content, unlike File, which decodes only what otf needs.
+	editableFile struct {
+		fields    map[string]any
+		resources []map[string]any
+	}
+)
+
+// parseAddress parses a state address.
+func parseAddress(s string) (address, error) {
+	var (
+		addr = address{mode: "managed"}
+		rest = s
+	)
+	// consume module path, e.g. module.foo["bar"].module.baz[0]
+	var modules []string
+	for strings.HasPrefix(rest, "module.") {
+		name, remaining, err := consumeStep(strings.TrimPrefix(rest, "module."))
+		if err != nil {
+			return address{}, fmt.Errorf("invalid address %s: %w", s, err)
+		}
+		modules = append(modules, "module."+name)
+		rest = remaining
+	}
+	addr.module = strings.Join(modules, ".")
+	if rest == "" {
+		if addr.module == "" {
+			return address{}, fmt.Errorf("invalid address: empty")
+		}
+		// module address
+		return addr, nil
+	}
+	if strings.HasPrefix(rest, "data.") {
+		addr.mode = "data"
+		rest = strings.TrimPrefix(rest, "data.")
+	}
+	typ, rest, _ := strings.Cut(rest, ".")
+	if typ == "" || rest == "" {
+		return address{}, fmt.Errorf("invalid address %s: expected resource type and name", s)
+	}
+	addr.typ = typ
+	name, rest, err := consumeStep(rest)
+	if err != nil {
+		return address{}, fmt.Errorf("invalid address %s: %w", s, err)
+	}
+	if rest != "" {
+		return address{}, fmt.Errorf("invalid address %s: unexpected trailing characters: %s", s, rest)
+	}
+	if open := strings.IndexByte(name, '['); open >= 0 {
+		key, err := parseKey(name[open+1 : len(name)-1])
+		if err != nil {
+			return address{}, fmt.Errorf("invalid address %s: %w", s, err)
+		}
+		addr.key = key
+		addr.hasKey = true
+		name = name[:open]
+	}
+	addr.name = name
+	return addr, nil
+}
+
+// consumeStep consumes a name and an optional index key from the front of an
+// address, along with the dot separating it from the next step, returning the
+// name and key, e.g. foo["bar"], and the remainder of the address.
+func consumeStep(s string) (string, string, error) {
+	end := strings.IndexAny(s, ".[")
+	if end == 0 {
+		return "", "", fmt.Errorf("expected name")
+	}
+	if end < 0 {
+		return s, "", nil
+	}
+	if s[end] == '[' {
+		closing := strings.IndexByte(s[end:], ']')
+		if closing < 0 {
+			return "", "", fmt.Errorf("unclosed index key")
+		}
+		end += closing + 1
+	}
+	step, rest := s[:end], s[end:]
+	if rest != "" {
+		if rest[0] != '.' || len(rest) == 1 {
+			return "", "", fmt.Errorf("unexpected characters: %s", rest)
+		}
+		rest = rest[1:]
+	}
+	return step, rest, nil
+}
+
+// parseKey parses an instance index key, which is either an integer or a
+// quoted string.
+func parseKey(s string) (any, error) {
+	if unquoted, err := strconv.Unquote(s); err == nil {
+		return unquoted, nil
+	}
+	if _, err := strconv.Atoi(s); err != nil {
+		return nil, fmt.Errorf("invalid index key: %s", s)
+	}
+	return json.Number(s), nil
+}
+
+func (a address) isModule() bool { return a.typ == "" }
+
+// sameResource determines whether both addresses refer to the same resource,
+// disregarding instance keys.
+func (a address) sameResource(b address) bool {
+	return a.module == b.module && a.mode == b.mode && a.typ == b.typ && a.name == b.name
+}
+
+func (a address) String() string {
+	var parts []string
+	if a.module != "" {
+		parts = append(parts, a.module)
+	}
+	if a.isModule() {
+		return strings.Join(parts, ".")
+	}
+	if a.mode == "data" {
+		parts = append(parts, "data")
+	}
+	s := strings.Join(append(parts, a.typ, a.name), ".")
+	if a.hasKey {
+		if key, ok := a.key.(string); ok {
+			return fmt.Sprintf("%s[%q]", s, key)
+		}
+		return fmt.Sprintf("%s[%v]", s, a.key)
+	}
+	return s
+}
+
+// inModule determines whether a resource belongs to the module, or to one of
+// its descendants.
+func (a address) inModule(resource map[string]any) bool {
+	module, _ := resource["module"].(string)
+	return module == a.module || strings.HasPrefix(module, a.module+".")
+}
+
+// matchesResource determines whether the address refers to the resource, or
+// to one of its instances.
+func (a address) matchesResource(resource map[string]any) bool {
+	if a.isModule() {
+		return a.inModule(resource)
+	}
+	module, _ := resource["module"].(string)
+	return module == a.module &&
+		resource["mode"] == a.mode &&
+		resource["type"] == a.typ &&
+		resource["name"] == a.name
+}
+
+// matchesInstance determines whether the address refers to the instance.
+func (a address) matchesInstance(instance map[string]any) bool {
+	return keysEqual(instance["index_key"], a.key)
+}
+
+func keysEqual(a, b any) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return fmt.Sprint(a) == fmt.Sprint(b)
+}
+
+func parseEditableFile(state []byte) (*editableFile, error) {
+	dec := json.NewDecoder(bytes.NewReader(state))
+	// preserve numbers exactly as they appear in the state file
+	dec.UseNumber()
+	var f editableFile
+	if err := dec.Decode(&f.fields); err != nil {
+		return nil, fmt.Errorf("parsing state file: %w", err)
+	}
+	resources, _ := f.fields["resources"].([]any)
+	for _, r := range resources {
+		resource, ok := r.(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("parsing state file: invalid resource")
+		}
+		f.resources = append(f.resources, resource)
+	}
+	return &f, nil
+}
+
+func (f *editableFile) serial() (int64, error) {
+	serial, ok := f.fields["serial"].(json.Number)
+	if !ok {
+		return 0, fmt.Errorf("state file is missing serial")
+	}
+	return serial.Int64()
+}
+
+func (f *editableFile) setSerial(serial int64) {
+	f.fields["serial"] = json.Number(strconv.FormatInt(serial, 10))
+}
+
+func (f *editableFile) lineage() string {
+	lineage, _ := f.fields["lineage"].(string)
+	return lineage
+}
+
+// marshal marshals the state file, incrementing its serial.
+func (f *editableFile) marshal() ([]byte, error) {
+	serial, err := f.serial()
+	if err != nil {
+		return nil, err
+	}
+	f.setSerial(serial + 1)
+	resources := make([]any, len(f.resources))
+	for i, r := range f.resources {
+		resources[i] = r
+	}
+	f.fields["resources"] = resources
+	return json.MarshalIndent(f.fields, "", "  ")
+}
+
+// remove removes the objects identified by the address from state.
+func (f *editableFile) remove(addr address) error {
+	var (
+		found     bool
+		resources []map[string]any
+	)
+	for _, resource := range f.resources {
+		if !addr.matchesResource(resource) {
+			resources = append(resources, resource)
+			continue
+		}
+		if !addr.hasKey {
+			found = true
+			continue
+		}
+		var instances []any
+		for _, inst := range instancesOf(resource) {
+			if addr.matchesInstance(inst) {
+				found = true
+				continue
+			}
+			instances = append(instances, inst)
+		}
+		if len(instances) > 0 {
+			resource["instances"] = instances
+			resources = append(resources, resource)
+		}
+	}
+	if !found {
+		return fmt.Errorf("%s: %w", addr, ErrNoMatchingObjects)
+	}
+	f.resources = resources
+	return nil
+}
+
+// move moves the objects identified by the src address to the dst address.
+func (f *editableFile) move(src, dst address) error {
+	switch {
+	case src.isModule() && dst.isModule():
+		return f.moveModule(src, dst)
+	case src.isModule() || dst.isModule():
+		return ErrMoveIncompatible
+	case src.typ != dst.typ || src.mode != dst.mode:
+		return ErrMoveResourceTypeChange
+	case !src.hasKey && !dst.hasKey:
+		return f.moveResource(src, dst)
+	default:
+		return f.moveInstance(src, dst)
+	}
+}
+
+func (f *editableFile) moveModule(src, dst address) error {
+	var found bool
+	for _, resource := range f.resources {
+		if dst.inModule(resource) {
+			return fmt.Errorf("%s: %w", dst, ErrMoveTargetExists)
+		}
+	}
+	for _, resource := range f.resources {
+		if src.inModule(resource) {
+			module, _ := resource["module"].(string)
+			resource["module"] = dst.module + strings.TrimPrefix(module, src.module)
+			found = true
+		}
+	}
+	if !found {
+		return fmt.Errorf("%s: %w", src, ErrNoMatchingObjects)
+	}
+	return nil
+}
+
+func (f *editableFile) moveResource(src, dst address) error {
+	if f.findResource(dst) >= 0 {
+		return fmt.Errorf("%s: %w", dst, ErrMoveTargetExists)
+	}
+	i := f.findResource(src)
+	if i < 0 {
+		return fmt.Errorf("%s: %w", src, ErrNoMatchingObjects)
+	}
+	setResourceAddress(f.resources[i], dst)
+	return nil
+}
+
+func (f *editableFile) moveInstance(src, dst address) error {
+	i := f.findResource(src)
+	if i < 0 {
+		return fmt.Errorf("%s: %w", src, ErrNoMatchingObjects)
+	}
+	from := f.resources[i]
+	var (
+		instance  map[string]any
+		remaining []any
+	)
+	for _, inst := range instancesOf(from) {
+		if instance == nil && src.matchesInstance(inst) {
+			instance = inst
+			continue
+		}
+		remaining = append(remaining, inst)
+	}
+	if instance == nil {
+		return fmt.Errorf("%s: %w", src, ErrNoMatchingObjects)
+	}
+	var to map[string]any
+	if j := f.findResource(dst); j >= 0 {
+		to = f.resources[j]
+		for _, inst := range instancesOf(to) {
+			if dst.matchesInstance(inst) {
+				return fmt.Errorf("%s: %w", dst, ErrMoveTargetExists)
+			}
+		}
+	}
+	from["instances"] = remaining
+	switch {
+	case to != nil && len(remaining) == 0 && !src.sameResource(dst):
+		// remove source resource now that it has no instances
+		f.resources = append(f.resources[:i], f.resources[i+1:]...)
+	case to == nil && len(remaining) == 0:
+		// re-use the source resource for the target
+		to = from
+		setResourceAddress(to, dst)
+	case to == nil:
+		// create target resource from a copy of the source resource
+		to = make(map[string]any, len(from))
+		for k, v := range from {
+			to[k] = v
+		}
+		to["instances"] = []any{}
+		setResourceAddress(to, dst)
+		f.resources = append(f.resources, to)
+	}
+	if dst.hasKey {
+		instance["index_key"] = dst.key
+		if _, ok := dst.key.(string); ok {
+			to["each"] = "map"
+		} else {
+			to["each"] = "list"
+		}
+	} else {
+		delete(instance, "index_key")
+		delete(to, "each")
+	}
+	instances, _ := to["instances"].([]any)
+	to["instances"] = append(instances, instance)
+	return nil
+}
+
+// findResource returns the index of the resource referred to by the address,
+// or -1 if not found.
+func (f *editableFile) findResource(addr address) int {
+	for i, resource := range f.resources {
+		if addr.matchesResource(resource) {
+			return i
+		}
+	}
+	return -1
+}
+
+func setResourceAddress(resource map[string]any, addr address) {
+	if addr.module == "" {
+		delete(resource, "module")
+	} else {
+		resource["module"] = addr.module
+	}
+	resource["name"] = addr.name
+}
+
+func instancesOf(resource map[string]any) []map[string]any {
+	list, _ := resource["instances"].([]any)
+	instances := make([]map[string]any, 0, len(list))
+	for _, inst := range list {
+		if m, ok := inst.(map[string]any); ok {
+			instances = append(instances, m)
+		}
+	}
+	return instances
+}
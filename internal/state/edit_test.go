@@ -0,0 +1,206 @@
+package state
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const editTestState = `{
+  "version": 4,
+  "serial": 3,
+  "lineage": "abc",
+  "resources": [
+    {"mode": "managed", "type": "null_resource", "name": "foo", "instances": [{"attributes": {"id": "1"}}]},
+    {"mode": "managed", "type": "null_resource", "name": "bar", "each": "list", "instances": [{"index_key": 0}, {"index_key": 1}]},
+    {"mode": "data", "type": "null_data_source", "name": "foo", "instances": [{}]},
+    {"module": "module.vpc", "mode": "managed", "type": "aws_vpc", "name": "main", "instances": [{}]},
+    {"module": "module.vpc.module.subnets[\"a\"]", "mode": "managed", "type": "aws_subnet", "name": "main", "instances": [{}]}
+  ]
+}`
+
+func TestParseAddress(t *testing.T) {
+	tests := []struct {
+		addr string
+		want address
+	}{
+		{"null_resource.foo", address{mode: "managed", typ: "null_resource", name: "foo"}},
+		{"data.null_data_source.foo", address{mode: "data", typ: "null_data_source", name: "foo"}},
+		{"null_resource.foo[0]", address{mode: "managed", typ: "null_resource", name: "foo", key: json.Number("0"), hasKey: true}},
+		{`null_resource.foo["a.b"]`, address{mode: "managed", typ: "null_resource", name: "foo", key: "a.b", hasKey: true}},
+		{"module.vpc", address{mode: "managed", module: "module.vpc"}},
+		{`module.vpc.module.subnets["a"].aws_subnet.main`, address{mode: "managed", module: `module.vpc.module.subnets["a"]`, typ: "aws_subnet", name: "main"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.addr, func(t *testing.T) {
+			got, err := parseAddress(tt.addr)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.addr, got.String())
+		})
+	}
+
+	for _, invalid := range []string{"", "null_resource", "null_resource.", "null_resource.foo[", "null_resource.foo[bar]", "null_resource.foo.bar"} {
+		t.Run("invalid "+invalid, func(t *testing.T) {
+			_, err := parseAddress(invalid)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestEditableFile(t *testing.T) {
+	tests := []struct {
+		name string
+		edit func(f *editableFile) error
+		want []string
+	}{
+		{
+			"remove resource",
+			func(f *editableFile) error { return f.remove(mustParseAddress(t, "null_resource.foo")) },
+			[]string{"null_resource.bar[0]", "null_resource.bar[1]", "data.null_data_source.foo", "module.vpc.aws_vpc.main", `module.vpc.module.subnets["a"].aws_subnet.main`},
+		},
+		{
+			"remove instance",
+			func(f *editableFile) error { return f.remove(mustParseAddress(t, "null_resource.bar[1]")) },
+			[]string{"null_resource.foo", "null_resource.bar[0]", "data.null_data_source.foo", "module.vpc.aws_vpc.main", `module.vpc.module.subnets["a"].aws_subnet.main`},
+		},
+		{
+			"remove module and its descendants",
+			func(f *editableFile) error { return f.remove(mustParseAddress(t, "module.vpc")) },
+			[]string{"null_resource.foo", "null_resource.bar[0]", "null_resource.bar[1]", "data.null_data_source.foo"},
+		},
+		{
+			"move resource",
+			func(f *editableFile) error {
+				return f.move(mustParseAddress(t, "null_resource.foo"), mustParseAddress(t, "module.vpc.null_resource.baz"))
+			},
+			[]string{"module.vpc.null_resource.baz", "null_resource.bar[0]", "null_resource.bar[1]", "data.null_data_source.foo", "module.vpc.aws_vpc.main", `module.vpc.module.subnets["a"].aws_subnet.main`},
+		},
+		{
+			"move instance within resource",
+			func(f *editableFile) error {
+				return f.move(mustParseAddress(t, "null_resource.bar[1]"), mustParseAddress(t, "null_resource.bar[2]"))
+			},
+			[]string{"null_resource.foo", "null_resource.bar[0]", "null_resource.bar[2]", "data.null_data_source.foo", "module.vpc.aws_vpc.main", `module.vpc.module.subnets["a"].aws_subnet.main`},
+		},
+		{
+			"move instance to new resource",
+			func(f *editableFile) error {
+				return f.move(mustParseAddress(t, "null_resource.bar[0]"), mustParseAddress(t, "null_resource.qux"))
+			},
+			[]string{"null_resource.foo", "null_resource.bar[1]", "data.null_data_source.foo", "module.vpc.aws_vpc.main", `module.vpc.module.subnets["a"].aws_subnet.main`, "null_resource.qux"},
+		},
+		{
+			"move resource to instance",
+			func(f *editableFile) error {
+				return f.move(mustParseAddress(t, "null_resource.foo"), mustParseAddress(t, "null_resource.bar[2]"))
+			},
+			[]string{"null_resource.bar[0]", "null_resource.bar[1]", "null_resource.bar[2]", "data.null_data_source.foo", "module.vpc.aws_vpc.main", `module.vpc.module.subnets["a"].aws_subnet.main`},
+		},
+		{
+			"move module",
+			func(f *editableFile) error {
+				return f.move(mustParseAddress(t, "module.vpc"), mustParseAddress(t, "module.network"))
+			},
+			[]string{"null_resource.foo", "null_resource.bar[0]", "null_resource.bar[1]", "data.null_data_source.foo", "module.network.aws_vpc.main", `module.network.module.subnets["a"].aws_subnet.main`},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f, err := parseEditableFile([]byte(editTestState))
+			require.NoError(t, err)
+			require.NoError(t, tt.edit(f))
+
+			marshaled, err := f.marshal()
+			require.NoError(t, err)
+			got, err := parseEditableFile(marshaled)
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.want, instanceAddresses(got))
+			serial, err := got.serial()
+			require.NoError(t, err)
+			assert.Equal(t, int64(4), serial)
+			assert.Equal(t, "abc", got.lineage())
+		})
+	}
+
+	errors := []struct {
+		name string
+		edit func(f *editableFile) error
+		want error
+	}{
+		{
+			"remove non-existent resource",
+			func(f *editableFile) error { return f.remove(mustParseAddress(t, "null_resource.qux")) },
+			ErrNoMatchingObjects,
+		},
+		{
+			"remove non-existent instance",
+			func(f *editableFile) error { return f.remove(mustParseAddress(t, "null_resource.bar[2]")) },
+			ErrNoMatchingObjects,
+		},
+		{
+			"move to existing resource",
+			func(f *editableFile) error {
+				return f.move(mustParseAddress(t, "null_resource.foo"), mustParseAddress(t, "null_resource.bar"))
+			},
+			ErrMoveTargetExists,
+		},
+		{
+			"move to existing instance",
+			func(f *editableFile) error {
+				return f.move(mustParseAddress(t, "null_resource.bar[0]"), mustParseAddress(t, "null_resource.bar[1]"))
+			},
+			ErrMoveTargetExists,
+		},
+		{
+			"move to different type",
+			func(f *editableFile) error {
+				return f.move(mustParseAddress(t, "null_resource.foo"), mustParseAddress(t, "random_id.foo"))
+			},
+			ErrMoveResourceTypeChange,
+		},
+		{
+			"move resource to module",
+			func(f *editableFile) error {
+				return f.move(mustParseAddress(t, "null_resource.foo"), mustParseAddress(t, "module.foo"))
+			},
+			ErrMoveIncompatible,
+		},
+	}
+	for _, tt := range errors {
+		t.Run(tt.name, func(t *testing.T) {
+			f, err := parseEditableFile([]byte(editTestState))
+			require.NoError(t, err)
+			assert.ErrorIs(t, tt.edit(f), tt.want)
+		})
+	}
+}
+
+func mustParseAddress(t *testing.T, s string) address {
+	t.Helper()
+
+	addr, err := parseAddress(s)
+	require.NoError(t, err)
+	return addr
+}
+
+// instanceAddresses returns the addresses of all resource instances in state.
+func instanceAddresses(f *editableFile) (addrs []string) {
+	for _, resource := range f.resources {
+		module, _ := resource["module"].(string)
+		addr := address{
+			module: module,
+			mode:   resource["mode"].(string),
+			typ:    resource["type"].(string),
+			name:   resource["name"].(string),
+		}
+		for _, inst := range instancesOf(resource) {
+			addr.key, addr.hasKey = inst["index_key"]
+			addrs = append(addrs, addr.String())
+		}
+	}
+	return
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/go-logr/logr"
 	"github.com/gorilla/mux"
 	"github.com/leg100/otf/internal"
+	"github.com/leg100/otf/internal/audit"
 	"github.com/leg100/otf/internal/http/html"
 	"github.com/leg100/otf/internal/rbac"
 	"github.com/leg100/otf/internal/resource"
@@ -24,6 +25,11 @@ var ErrCurrentVersionDeletionAttempt = errors.New("deleting the current state ve
 func cacheKey(svID string) string { return fmt.Sprintf("%s.json", svID) }
 
 type (
+	StateService interface {
+		// By WorkspaceID
+		List(context.Context, string, resource.PageOptions) (*resource.Page[*Version], error)
+	}
+
 	// Service provides access to state and state versions
 	Service struct {
 		logr.Logger
@@ -31,6 +37,7 @@ type (
 		db        *pgdb
 		cache     internal.Cache // cache state file
 		workspace internal.Authorizer
+		audit     auditor
 		web       *webHandlers
 		tfeapi    *tfe
 		api       *api
@@ -47,6 +54,19 @@ type (
 		*surl.Signer
 
 		WorkspaceService *workspace.Service
+		AuditService     *audit.Service
+	}
+
+	// auditor records downloads of state
+	auditor interface {
+		RecordDownload(ctx context.Context, opts audit.RecordDownloadOptions) error
+	}
+
+	// runSubject is a subject acting on behalf of a run, i.e. the job
+	// executing a run phase.
+	runSubject interface {
+		internal.Subject
+		RunID() string
 	}
 
 	// StateVersionListOptions represents the options for listing state versions.
@@ -64,6 +84,7 @@ func NewService(opts Options) *Service {
 		cache:     opts.Cache,
 		db:        db,
 		workspace: opts.WorkspaceService,
+		audit:     opts.AuditService,
 		factory:   &factory{db},
 	}
 	svc.web = &webHandlers{
@@ -84,6 +105,10 @@ func NewService(opts Options) *Service {
 	// include state version outputs in api responses when requested.
 	opts.Responder.Register(tfeapi.IncludeOutputs, svc.tfeapi.includeOutputs)
 	opts.Responder.Register(tfeapi.IncludeOutputs, svc.tfeapi.includeWorkspaceCurrentOutputs)
+	// retain state versions of deleted workspaces so that they can be
+	// restored.
+	opts.WorkspaceService.AfterArchiveWorkspace(svc.archiveVersions)
+	opts.WorkspaceService.AfterRestoreWorkspace(svc.restoreVersions)
 	return &svc
 }
 
@@ -102,7 +127,19 @@ func (a *Service) Create(ctx context.Context, opts CreateStateVersionOptions) (*
 		return nil, err
 	}
 
-	sv, err := a.new(ctx, opts)
+	var sv *Version
+	err = a.db.Tx(ctx, func(ctx context.Context, q pggen.Querier) (err error) {
+		sv, err = a.new(ctx, opts)
+		if err != nil {
+			return err
+		}
+		// record the run that produced the state version, as a record of its
+		// provenance.
+		if rs, ok := subject.(runSubject); ok {
+			return a.db.createVersionRun(ctx, sv.ID, rs.RunID())
+		}
+		return nil
+	})
 	if err != nil {
 		a.Error(err, "creating state version", "subject", subject)
 		return nil, err
@@ -228,8 +265,22 @@ func (a *Service) Upload(ctx context.Context, svID string, state []byte) error {
 	return nil
 }
 
+// Download downloads the state file of a state version, recording the
+// download in the audit trail.
 func (a *Service) Download(ctx context.Context, svID string) ([]byte, error) {
-	subject, err := a.CanAccess(ctx, rbac.DownloadStateAction, svID)
+	sv, err := a.db.getVersion(ctx, svID)
+	if err != nil {
+		return nil, err
+	}
+	subject, err := a.workspace.CanAccess(ctx, rbac.DownloadStateAction, sv.WorkspaceID)
+	if err != nil {
+		return nil, err
+	}
+	err = a.audit.RecordDownload(ctx, audit.RecordDownloadOptions{
+		Action:      audit.StateDownloadAction,
+		ResourceID:  svID,
+		WorkspaceID: sv.WorkspaceID,
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -272,3 +323,25 @@ func (a *Service) CanAccess(ctx context.Context, action rbac.Action, svID string
 	}
 	return a.workspace.CanAccess(ctx, action, sv.WorkspaceID)
 }
+
+// archiveVersions archives the state versions of a workspace that is being
+// deleted.
+func (a *Service) archiveVersions(ctx context.Context, ws *workspace.Workspace) error {
+	if err := a.db.archiveVersions(ctx, ws.ID); err != nil {
+		a.Error(err, "archiving state versions", "workspace", ws.ID)
+		return err
+	}
+	a.V(1).Info("archived state versions", "workspace", ws.ID)
+	return nil
+}
+
+// restoreVersions restores the archived state versions of a workspace that is
+// being restored.
+func (a *Service) restoreVersions(ctx context.Context, ws *workspace.Workspace) error {
+	if err := a.db.restoreVersions(ctx, ws.ID); err != nil {
+		a.Error(err, "restoring state versions", "workspace", ws.ID)
+		return err
+	}
+	a.V(1).Info("restored state versions", "workspace", ws.ID)
+	return nil
+}
@@ -6,6 +6,7 @@ import (
 	"crypto/md5"
 	"encoding/base64"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -13,6 +14,7 @@ import (
 
 	"github.com/gorilla/mux"
 	"github.com/leg100/otf/internal"
+	"github.com/leg100/otf/internal/audit"
 	otfhttp "github.com/leg100/otf/internal/http"
 	"github.com/leg100/otf/internal/http/decode"
 	"github.com/leg100/otf/internal/resource"
@@ -252,6 +254,9 @@ func (a *tfe) downloadState(w http.ResponseWriter, r *http.Request) {
 	}
 	resp, err := a.state.Download(r.Context(), versionID)
 	if err != nil {
+		if errors.Is(err, audit.ErrJustificationRequired) {
+			err = &internal.HTTPError{Code: http.StatusForbidden, Message: err.Error()}
+		}
 		tfeapi.Error(w, err)
 		return
 	}
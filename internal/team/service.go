@@ -11,6 +11,7 @@ import (
 	"github.com/leg100/otf/internal/http/html"
 	"github.com/leg100/otf/internal/organization"
 	"github.com/leg100/otf/internal/rbac"
+	"github.com/leg100/otf/internal/resource"
 	"github.com/leg100/otf/internal/sql"
 	"github.com/leg100/otf/internal/sql/pggen"
 	"github.com/leg100/otf/internal/tfeapi"
@@ -195,6 +196,19 @@ func (a *Service) Get(ctx context.Context, organization, name string) (*Team, er
 	return team, nil
 }
 
+// ResolveAlias retrieves the ID of a team by its alias, <organization>/<name>.
+func (a *Service) ResolveAlias(ctx context.Context, alias string) (string, error) {
+	organization, name, err := resource.ParseAlias(alias)
+	if err != nil {
+		return "", err
+	}
+	team, err := a.Get(ctx, organization, name)
+	if err != nil {
+		return "", err
+	}
+	return team.ID, nil
+}
+
 func (a *Service) GetByID(ctx context.Context, teamID string) (*Team, error) {
 	team, err := a.db.getTeamByID(ctx, teamID)
 	if err != nil {
@@ -6,6 +6,7 @@ import (
 
 	"github.com/leg100/otf/internal"
 	"github.com/leg100/otf/internal/rbac"
+	"github.com/leg100/otf/internal/resource"
 )
 
 type (
@@ -86,7 +87,7 @@ func newTeam(organization string, opts CreateTeamOptions) (*Team, error) {
 	}
 
 	team := &Team{
-		ID:           internal.NewID("team"),
+		ID:           resource.NewID(resource.TeamKind),
 		Name:         *opts.Name,
 		CreatedAt:    internal.CurrentTimestamp(nil),
 		Organization: organization,
@@ -137,48 +138,96 @@ func (t *Team) CanAccessTeam(action rbac.Action, id string) bool {
 }
 
 func (t *Team) CanAccessOrganization(action rbac.Action, org string) bool {
-	if t.Organization == org {
-		if t.IsOwners() {
-			// owner team can perform all actions on organization
-			return true
-		}
-		if rbac.OrganizationMinPermissions.IsAllowed(action) {
-			return true
-		}
-		if t.Access.ManageWorkspaces {
-			if rbac.WorkspaceManagerRole.IsAllowed(action) {
-				return true
-			}
-		}
-		if t.Access.ManageVCS {
-			if rbac.VCSManagerRole.IsAllowed(action) {
-				return true
-			}
-		}
-		if t.Access.ManageModules {
-			if rbac.VCSManagerRole.IsAllowed(action) {
-				return true
-			}
+	_, ok := t.OrganizationRole(action, org)
+	return ok
+}
+
+func (t *Team) CanAccessWorkspace(action rbac.Action, policy internal.WorkspacePolicy) bool {
+	_, ok := t.WorkspaceRole(action, policy)
+	return ok
+}
+
+// OrganizationRole returns the name of the role permitting the team to carry
+// out the action on the organization. False is returned if no role permits the
+// action.
+func (t *Team) OrganizationRole(action rbac.Action, org string) (string, bool) {
+	if t.Organization != org {
+		return "", false
+	}
+	if t.IsOwners() {
+		// owner team can perform all actions on organization
+		return "owners", true
+	}
+	roles := []rbac.Role{rbac.OrganizationMinPermissions}
+	if t.Access.ManageWorkspaces {
+		roles = append(roles, rbac.WorkspaceManagerRole)
+	}
+	if t.Access.ManageVCS {
+		roles = append(roles, rbac.VCSManagerRole)
+	}
+	if t.Access.ManageModules {
+		roles = append(roles, rbac.VCSManagerRole, rbac.RegistryManagerRole)
+	}
+	if t.Access.ManagePolicies {
+		roles = append(roles, rbac.PolicyManagerRole)
+	}
+	if t.Access.ManagePolicyOverrides {
+		roles = append(roles, rbac.PolicyOverrideRole)
+	}
+	for _, role := range roles {
+		if role.IsAllowed(action) {
+			return role.String(), true
 		}
 	}
-	return false
+	return "", false
 }
 
-func (t *Team) CanAccessWorkspace(action rbac.Action, policy internal.WorkspacePolicy) bool {
+// WorkspaceRole returns the name of the role permitting the team to carry out
+// the action on the workspace. False is returned if no role permits the
+// action.
+func (t *Team) WorkspaceRole(action rbac.Action, policy internal.WorkspacePolicy) (string, bool) {
 	// coarser-grained organization perms take precedence.
-	if t.CanAccessOrganization(action, policy.Organization) {
-		return true
+	if role, ok := t.OrganizationRole(action, policy.Organization); ok {
+		return role, true
 	}
 	// fallback to checking finer-grained workspace perms
 	if t.Organization != policy.Organization {
-		return false
+		return "", false
 	}
 	for _, perm := range policy.Permissions {
 		if t.ID == perm.TeamID {
-			return perm.Role.IsAllowed(action)
+			if perm.Role.IsAllowed(action) {
+				return perm.Role.String(), true
+			}
+			return "", false
 		}
 	}
-	return false
+	return "", false
+}
+
+func (t *Team) ExplainSite(action rbac.Action) internal.Decision {
+	return internal.Decision{Reason: "a team cannot carry out site-level actions"}
+}
+
+func (t *Team) ExplainTeam(action rbac.Action, id string) internal.Decision {
+	if t.CanAccessTeam(action, id) {
+		return internal.Decision{Allowed: true, Reason: "a team can carry out actions on itself", Team: t.Name}
+	}
+	return internal.Decision{Reason: "a team can only carry out actions on itself"}
+}
+
+func (t *Team) ExplainOrganization(action rbac.Action, org string) internal.Decision {
+	if role, ok := t.OrganizationRole(action, org); ok {
+		return internal.Decision{Allowed: true, Reason: "team is granted an organization role permitting the action", Role: role, Team: t.Name}
+	}
+	return internal.Decision{Reason: "team is not granted an organization role permitting the action", Team: t.Name}
+}
+
+func (t *Team) ExplainWorkspace(action rbac.Action, policy internal.WorkspacePolicy) internal.Decision {
+	if role, ok := t.WorkspaceRole(action, policy); ok {
+		return internal.Decision{Allowed: true, Reason: "team is granted a role permitting the action", Role: role, Team: t.Name}
+	}
+	return internal.Decision{Reason: "team is not granted a role permitting the action on the workspace", Team: t.Name}
 }
 
 func (t *Team) Organizations() []string {
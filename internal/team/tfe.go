@@ -2,10 +2,13 @@ package team
 
 import (
 	"net/http"
+	"slices"
+	"strings"
 
 	"github.com/gorilla/mux"
 	"github.com/leg100/otf/internal"
 	"github.com/leg100/otf/internal/http/decode"
+	"github.com/leg100/otf/internal/resource"
 	"github.com/leg100/otf/internal/tfeapi"
 	"github.com/leg100/otf/internal/tfeapi/types"
 )
@@ -109,24 +112,37 @@ func (a *tfe) updateTeam(w http.ResponseWriter, r *http.Request) {
 }
 
 func (a *tfe) listTeams(w http.ResponseWriter, r *http.Request) {
-	organization, err := decode.Param("organization_name", r)
-	if err != nil {
+	var params struct {
+		Organization string `schema:"organization_name,required"`
+		// Filter by team names, separated by commas
+		Names string `schema:"filter[names],omitempty"`
+		resource.PageOptions
+	}
+	if err := decode.All(&params, r); err != nil {
 		tfeapi.Error(w, err)
 		return
 	}
 
-	teams, err := a.List(r.Context(), organization)
+	teams, err := a.List(r.Context(), params.Organization)
 	if err != nil {
 		tfeapi.Error(w, err)
 		return
 	}
 
+	if params.Names != "" {
+		names := strings.Split(params.Names, ",")
+		teams = slices.DeleteFunc(teams, func(t *Team) bool {
+			return !slices.Contains(names, t.Name)
+		})
+	}
+
 	// convert items
 	items := make([]*types.Team, len(teams))
 	for i, from := range teams {
 		items[i] = a.convertTeam(from)
 	}
-	a.Respond(w, r, items, http.StatusOK)
+	page := resource.NewPage(items, params.PageOptions, nil)
+	a.RespondWithPage(w, r, page.Items, page.Pagination)
 }
 
 func (a *tfe) getTeamByName(w http.ResponseWriter, r *http.Request) {
@@ -183,6 +199,9 @@ func (a *tfe) convertTeam(from *Team) *types.Team {
 		Name:       from.Name,
 		SSOTeamID:  from.SSOTeamID,
 		Visibility: from.Visibility,
+		Organization: &types.Organization{
+			Name: from.Organization,
+		},
 		OrganizationAccess: &types.OrganizationAccess{
 			ManageWorkspaces:      from.Access.ManageWorkspaces,
 			ManageVCSSettings:     from.Access.ManageVCS,
@@ -13,6 +13,11 @@ const (
 	APIPrefixV2 = "/api/v2/"
 	// ModuleV1Prefix is the URL path prefix for module registry endpoints
 	ModuleV1Prefix = "/v1/modules/"
+	// ProviderV1Prefix is the URL path prefix for provider registry endpoints
+	ProviderV1Prefix = "/v1/providers/"
+	// RegistryAPIPrefix is the URL path prefix for private registry API
+	// endpoints
+	RegistryAPIPrefix = "/api/registry/"
 )
 
 func Unmarshal(r io.Reader, v any) error {
@@ -20,6 +20,12 @@ func lookupHTTPCode(err error) int {
 	if v, ok := codes[err]; ok {
 		return v
 	}
+	// check wrapped errors too
+	for target, code := range codes {
+		if errors.Is(err, target) {
+			return code
+		}
+	}
 	return http.StatusInternalServerError
 }
 
@@ -28,13 +34,14 @@ func Error(w http.ResponseWriter, err error) {
 	var (
 		httpError *internal.HTTPError
 		missing   *internal.MissingParameterError
+		invalid   *internal.InvalidParameterError
 		code      int
 	)
 	// If error is type internal.HTTPError then extract its status code
 	if errors.As(err, &httpError) {
 		code = httpError.Code
-	} else if errors.As(err, &missing) {
-		// report missing parameter errors as a 422
+	} else if errors.As(err, &missing) || errors.As(err, &invalid) {
+		// report missing and invalid parameter errors as a 422
 		code = http.StatusUnprocessableEntity
 	} else {
 		code = lookupHTTPCode(err)
@@ -51,3 +58,28 @@ func Error(w http.ResponseWriter, err error) {
 	w.WriteHeader(code)
 	w.Write(b)
 }
+
+// Redirect writes an HTTP response redirecting the client to a resource's
+// new location, along with a JSON-API encoded error explaining why. GET and
+// HEAD requests are redirected with a 301, and other requests with a 308,
+// which instructs the client to repeat the request, including its method and
+// body, at the new location.
+func Redirect(w http.ResponseWriter, r *http.Request, location string, err error) {
+	code := http.StatusMovedPermanently
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		code = http.StatusPermanentRedirect
+	}
+	b, err := jsonapi.Marshal(&jsonapi.Error{
+		Status: &code,
+		Title:  http.StatusText(code),
+		Detail: err.Error(),
+		Links:  &jsonapi.ErrorLink{About: location},
+	})
+	if err != nil {
+		panic(err)
+	}
+	w.Header().Set("Content-type", mediaType)
+	w.Header().Set("Location", location)
+	w.WriteHeader(code)
+	w.Write(b)
+}
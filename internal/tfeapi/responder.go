@@ -1,9 +1,11 @@
 package tfeapi
 
 import (
+	"encoding/json"
 	"net/http"
 
 	"github.com/DataDog/jsonapi"
+	"github.com/leg100/otf/internal"
 	"github.com/leg100/otf/internal/resource"
 )
 
@@ -23,13 +25,17 @@ func NewResponder() *Responder {
 }
 
 func (res *Responder) RespondWithPage(w http.ResponseWriter, r *http.Request, items any, pagination *resource.Pagination) {
-	meta := jsonapi.MarshalMeta(map[string]*resource.Pagination{
+	res.respond(w, r, items, http.StatusOK, map[string]any{
 		"pagination": pagination,
 	})
-	res.Respond(w, r, items, http.StatusOK, meta)
 }
 
-func (res *Responder) Respond(w http.ResponseWriter, r *http.Request, payload any, status int, opts ...jsonapi.MarshalOption) {
+func (res *Responder) Respond(w http.ResponseWriter, r *http.Request, payload any, status int) {
+	res.respond(w, r, payload, status, nil)
+}
+
+func (res *Responder) respond(w http.ResponseWriter, r *http.Request, payload any, status int, meta map[string]any) {
+	var opts []jsonapi.MarshalOption
 	includes, err := res.addIncludes(r, payload)
 	if err != nil {
 		Error(w, err)
@@ -38,12 +44,61 @@ func (res *Responder) Respond(w http.ResponseWriter, r *http.Request, payload an
 	if len(includes) > 0 {
 		opts = append(opts, jsonapi.MarshalInclude(includes...))
 	}
+	if meta = addWarnings(r, meta); meta != nil {
+		opts = append(opts, jsonapi.MarshalMeta(meta))
+	}
+	b, err := jsonapi.Marshal(payload, opts...)
+	if err != nil {
+		Error(w, err)
+		return
+	}
+	w.Header().Set("Content-type", mediaType)
+	w.WriteHeader(status)
+	w.Write(b)
+}
+
+// RespondWithLinks responds with a single resource, adding the given links to
+// the resource object. The jsonapi library only supports a fixed set of links,
+// whereas some endpoints return others, e.g. an upload link.
+func (res *Responder) RespondWithLinks(w http.ResponseWriter, r *http.Request, payload any, status int, links map[string]string) {
+	var opts []jsonapi.MarshalOption
+	if meta := addWarnings(r, nil); meta != nil {
+		opts = append(opts, jsonapi.MarshalMeta(meta))
+	}
 	b, err := jsonapi.Marshal(payload, opts...)
 	if err != nil {
 		Error(w, err)
 		return
 	}
+	var doc map[string]any
+	if err := json.Unmarshal(b, &doc); err != nil {
+		Error(w, err)
+		return
+	}
+	if data, ok := doc["data"].(map[string]any); ok {
+		data["links"] = links
+	}
+	b, err = json.Marshal(doc)
+	if err != nil {
+		Error(w, err)
+		return
+	}
 	w.Header().Set("Content-type", mediaType)
 	w.WriteHeader(status)
 	w.Write(b)
 }
+
+// addWarnings adds any warnings collected whilst handling the request to the
+// document meta, so that they are relayed to clients that do not inspect
+// response headers.
+func addWarnings(r *http.Request, meta map[string]any) map[string]any {
+	warnings := internal.WarningsFromContext(r.Context())
+	if len(warnings) == 0 {
+		return meta
+	}
+	if meta == nil {
+		meta = make(map[string]any)
+	}
+	meta["warnings"] = warnings
+	return meta
+}
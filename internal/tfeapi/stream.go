@@ -0,0 +1,154 @@
+package tfeapi
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/DataDog/jsonapi"
+	"github.com/leg100/otf/internal/resource"
+)
+
+type (
+	// StreamFunc emits the items of a list one at a time, returning the
+	// pagination of the list once every item has been emitted.
+	StreamFunc func(emit func(item any) error) (*resource.Pagination, error)
+
+	// streamWriter writes a JSON-API document with an array of primary data,
+	// one item at a time.
+	streamWriter struct {
+		w http.ResponseWriter
+		r *http.Request
+		*includer
+
+		// started is true once the response header has been written
+		started bool
+		// included resources, de-duplicated, and written after all items.
+		included []json.RawMessage
+		seen     map[resourceIdentifier]bool
+	}
+
+	resourceIdentifier struct {
+		Type string `json:"type"`
+		ID   string `json:"id"`
+	}
+)
+
+// RespondWithStream responds with a list of resources, marshaling and writing
+// each item as it is emitted rather than materializing the whole list before
+// marshaling. Included resources and the pagination meta are written once all
+// items have been written.
+//
+// An error occurring before the first item is written is reported to the
+// client as usual. Once the response header has been written that is no longer
+// possible, and the response is instead aborted, leaving the client with a
+// truncated document rather than an apparently complete list.
+func (res *Responder) RespondWithStream(w http.ResponseWriter, r *http.Request, stream StreamFunc) {
+	sw := &streamWriter{
+		w:        w,
+		r:        r,
+		includer: res.includer,
+		seen:     make(map[resourceIdentifier]bool),
+	}
+	pagination, err := stream(sw.emit)
+	if err == nil {
+		err = sw.finish(pagination)
+	}
+	if err != nil {
+		if !sw.started {
+			Error(w, err)
+			return
+		}
+		panic(http.ErrAbortHandler)
+	}
+}
+
+func (s *streamWriter) emit(item any) error {
+	data, err := marshalData(item)
+	if err != nil {
+		return err
+	}
+	includes, err := s.addIncludes(s.r, item)
+	if err != nil {
+		return err
+	}
+	for _, inc := range includes {
+		if err := s.include(inc); err != nil {
+			return err
+		}
+	}
+	if !s.started {
+		s.start()
+	} else if _, err := s.w.Write([]byte(",")); err != nil {
+		return err
+	}
+	_, err = s.w.Write(data)
+	return err
+}
+
+// include adds a resource to the list of included resources unless it has
+// already been added.
+func (s *streamWriter) include(v any) error {
+	data, err := marshalData(v)
+	if err != nil {
+		return err
+	}
+	var id resourceIdentifier
+	if err := json.Unmarshal(data, &id); err != nil {
+		return err
+	}
+	if s.seen[id] {
+		return nil
+	}
+	s.seen[id] = true
+	s.included = append(s.included, data)
+	return nil
+}
+
+func (s *streamWriter) start() {
+	s.started = true
+	s.w.Header().Set("Content-type", mediaType)
+	s.w.WriteHeader(http.StatusOK)
+	s.w.Write([]byte(`{"data":[`))
+}
+
+func (s *streamWriter) finish(pagination *resource.Pagination) error {
+	meta, err := json.Marshal(addWarnings(s.r, map[string]any{
+		"pagination": pagination,
+	}))
+	if err != nil {
+		return err
+	}
+	if !s.started {
+		s.start()
+	}
+	s.w.Write([]byte("]"))
+	if len(s.included) > 0 {
+		s.w.Write([]byte(`,"included":[`))
+		for i, inc := range s.included {
+			if i > 0 {
+				s.w.Write([]byte(","))
+			}
+			s.w.Write(inc)
+		}
+		s.w.Write([]byte("]"))
+	}
+	s.w.Write([]byte(`,"meta":`))
+	s.w.Write(meta)
+	_, err = s.w.Write([]byte("}"))
+	return err
+}
+
+// marshalData marshals a single resource, returning its resource object.
+func marshalData(v any) (json.RawMessage, error) {
+	b, err := jsonapi.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	var doc struct {
+		Data json.RawMessage `json:"data"`
+	}
+	if err := json.Unmarshal(b, &doc); err != nil {
+		return nil, err
+	}
+	return doc.Data, nil
+}
@@ -0,0 +1,120 @@
+package tfeapi
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/leg100/otf/internal/resource"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRespondWithStream(t *testing.T) {
+	type (
+		owner struct {
+			ID   string `jsonapi:"primary,owners"`
+			Name string `jsonapi:"attribute" json:"name"`
+		}
+		pet struct {
+			ID    string `jsonapi:"primary,pets"`
+			Name  string `jsonapi:"attribute" json:"name"`
+			Owner *owner `jsonapi:"relationship" json:"owner"`
+		}
+	)
+	alice := &owner{ID: "owner-1", Name: "alice"}
+	bob := &owner{ID: "owner-2", Name: "bob"}
+	pets := []*pet{
+		{ID: "pet-1", Name: "rex", Owner: alice},
+		{ID: "pet-2", Name: "tom", Owner: bob},
+		{ID: "pet-3", Name: "fido", Owner: alice},
+	}
+	// pets with distinct owners, so that no included resource is duplicated
+	uniquelyOwned := pets[:2]
+	pagination := resource.NewPage(pets, resource.PageOptions{}, nil).Pagination
+
+	res := NewResponder()
+	res.Register(IncludeName("owner"), func(_ context.Context, v any) ([]any, error) {
+		return []any{v.(*pet).Owner}, nil
+	})
+
+	tests := []struct {
+		name  string
+		query string
+		items []*pet
+	}{
+		{"items", "/pets", pets},
+		{"included", "/pets?include=owner", uniquelyOwned},
+		{"no items", "/pets", []*pet{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest("GET", tt.query, nil)
+
+			// streamed response should be equivalent to a materialized response
+			want := httptest.NewRecorder()
+			res.RespondWithPage(want, r, tt.items, pagination)
+
+			got := httptest.NewRecorder()
+			res.RespondWithStream(got, r, func(emit func(any) error) (*resource.Pagination, error) {
+				for _, item := range tt.items {
+					if err := emit(item); err != nil {
+						return nil, err
+					}
+				}
+				return pagination, nil
+			})
+
+			assert.Equal(t, http.StatusOK, got.Code)
+			assert.Equal(t, mediaType, got.Header().Get("Content-type"))
+			assert.JSONEq(t, want.Body.String(), got.Body.String())
+		})
+	}
+
+	t.Run("included resources are not duplicated", func(t *testing.T) {
+		r := httptest.NewRequest("GET", "/pets?include=owner", nil)
+		got := httptest.NewRecorder()
+		res.RespondWithStream(got, r, func(emit func(any) error) (*resource.Pagination, error) {
+			for _, item := range pets {
+				if err := emit(item); err != nil {
+					return nil, err
+				}
+			}
+			return pagination, nil
+		})
+
+		var doc struct {
+			Included []resourceIdentifier `json:"included"`
+		}
+		require.NoError(t, json.Unmarshal(got.Body.Bytes(), &doc))
+		assert.Equal(t, []resourceIdentifier{
+			{Type: "owners", ID: "owner-1"},
+			{Type: "owners", ID: "owner-2"},
+		}, doc.Included)
+	})
+
+	t.Run("error before first item", func(t *testing.T) {
+		r := httptest.NewRequest("GET", "/pets", nil)
+		got := httptest.NewRecorder()
+		res.RespondWithStream(got, r, func(emit func(any) error) (*resource.Pagination, error) {
+			return nil, errors.New("boom")
+		})
+		assert.Equal(t, http.StatusInternalServerError, got.Code)
+	})
+
+	t.Run("error after first item", func(t *testing.T) {
+		r := httptest.NewRequest("GET", "/pets", nil)
+		got := httptest.NewRecorder()
+		assert.PanicsWithValue(t, http.ErrAbortHandler, func() {
+			res.RespondWithStream(got, r, func(emit func(any) error) (*resource.Pagination, error) {
+				if err := emit(pets[0]); err != nil {
+					return nil, err
+				}
+				return nil, errors.New("boom")
+			})
+		})
+	})
+}
@@ -0,0 +1,20 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package types
+
+import "time"
+
+// Agent represents a Terraform Cloud agent.
+type Agent struct {
+	ID         string    `jsonapi:"primary,agents"`
+	Name       string    `jsonapi:"attribute" json:"name"`
+	IP         string    `jsonapi:"attribute" json:"ip-address"`
+	Status     string    `jsonapi:"attribute" json:"status"`
+	LastPingAt time.Time `jsonapi:"attribute" json:"last-ping-at"`
+}
+
+// AgentListOptions represents the options for listing agents.
+type AgentListOptions struct {
+	ListOptions
+}
@@ -0,0 +1,34 @@
+package types
+
+import "time"
+
+// AssessmentResult represents the result of a health assessment of a
+// workspace.
+type AssessmentResult struct {
+	ID string `jsonapi:"primary,assessment-results"`
+
+	// Drifted is true if any resources have changed outside of terraform.
+	Drifted bool `jsonapi:"attribute" json:"drifted"`
+
+	// Succeeded is true if the assessment completed successfully.
+	Succeeded bool `jsonapi:"attribute" json:"succeeded"`
+
+	// ErrorMsg explains why the assessment did not succeed.
+	ErrorMsg *string `jsonapi:"attribute" json:"error-msg"`
+
+	// Status is one of pending, succeeded, or errored. OTF-specific.
+	Status string `jsonapi:"attribute" json:"status"`
+
+	// ResourcesDrifted is the number of resources that have drifted.
+	ResourcesDrifted int `jsonapi:"attribute" json:"resources-drifted"`
+
+	// DriftedResources are the addresses of resources that have drifted.
+	// OTF-specific.
+	DriftedResources []string `jsonapi:"attribute" json:"drifted-resources"`
+
+	CreatedAt time.Time `jsonapi:"attribute" json:"created-at"`
+
+	// Relations
+	Workspace *Workspace `jsonapi:"relationship" json:"workspace"`
+	Run       *Run       `jsonapi:"relationship" json:"run,omitempty"`
+}
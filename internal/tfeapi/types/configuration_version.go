@@ -9,6 +9,7 @@ import (
 type ConfigurationVersion struct {
 	ID               string              `jsonapi:"primary,configuration-versions"`
 	AutoQueueRuns    bool                `jsonapi:"attribute" json:"auto-queue-runs"`
+	Digest           string              `jsonapi:"attribute" json:"digest"`
 	Error            string              `jsonapi:"attribute" json:"error"`
 	ErrorMessage     string              `jsonapi:"attribute" json:"error-message"`
 	Source           string              `jsonapi:"attribute" json:"source"`
@@ -0,0 +1,50 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package types
+
+import "time"
+
+// GPGKey represents a signed GPG key for a provider in the private registry.
+type GPGKey struct {
+	ID             string    `jsonapi:"primary,gpg-keys"`
+	AsciiArmor     string    `jsonapi:"attribute" json:"ascii-armor"`
+	CreatedAt      time.Time `jsonapi:"attribute" json:"created-at"`
+	KeyID          string    `jsonapi:"attribute" json:"key-id"`
+	Namespace      string    `jsonapi:"attribute" json:"namespace"`
+	Source         string    `jsonapi:"attribute" json:"source"`
+	SourceURL      *string   `jsonapi:"attribute" json:"source-url"`
+	TrustSignature string    `jsonapi:"attribute" json:"trust-signature"`
+	UpdatedAt      time.Time `jsonapi:"attribute" json:"updated-at"`
+}
+
+// GPGKeyCreateOptions represents all the available options used to create a
+// GPG key.
+type GPGKeyCreateOptions struct {
+	// Type is a public field utilized by JSON:API to
+	// set the resource type via the field tag.
+	// It is not a user-defined value and does not need to be set.
+	// https://jsonapi.org/format/#crud-creating
+	Type string `jsonapi:"primary,gpg-keys"`
+
+	// Required: The namespace of the key, which is the name of the
+	// organization.
+	Namespace string `jsonapi:"attribute" json:"namespace"`
+
+	// Required: The ASCII armored public key.
+	AsciiArmor string `jsonapi:"attribute" json:"ascii-armor"`
+}
+
+// GPGKeyUpdateOptions represents all the available options used to update a
+// GPG key.
+type GPGKeyUpdateOptions struct {
+	// Type is a public field utilized by JSON:API to
+	// set the resource type via the field tag.
+	// It is not a user-defined value and does not need to be set.
+	// https://jsonapi.org/format/#crud-creating
+	Type string `jsonapi:"primary,gpg-keys"`
+
+	// Required: The namespace to which to move the key, which is the name of
+	// an organization.
+	Namespace string `jsonapi:"attribute" json:"namespace"`
+}
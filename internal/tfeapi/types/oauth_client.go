@@ -81,3 +81,28 @@ type OAuthClientCreateOptions struct {
 	// Required: The VCS provider being connected with.
 	ServiceProvider *ServiceProviderType `jsonapi:"attribute" json:"service-provider"`
 }
+
+// OAuthClientUpdateOptions represents the options for updating an OAuth client.
+type OAuthClientUpdateOptions struct {
+	// Type is a public field utilized by JSON:API to
+	// set the resource type via the field tag.
+	// It is not a user-defined value and does not need to be set.
+	// https://jsonapi.org/format/#crud-creating
+	Type string `jsonapi:"primary,oauth-clients"`
+
+	// Optional: A display name for the OAuth Client.
+	Name *string `jsonapi:"attribute" json:"name,omitempty"`
+
+	// Optional: The OAuth Client key.
+	Key *string `jsonapi:"attribute" json:"key,omitempty"`
+
+	// Optional: Secret key associated with this vcs provider - only available for ado_server
+	Secret *string `jsonapi:"attribute" json:"secret,omitempty"`
+
+	// Optional: RSAPublicKey the text of the SSH public key associated with your BitBucket
+	// Server Application Link.
+	RSAPublicKey *string `jsonapi:"attribute" json:"rsa-public-key,omitempty"`
+
+	// Optional: The token string you were given by your VCS provider.
+	OAuthToken *string `jsonapi:"attribute" json:"oauth-token-string,omitempty"`
+}
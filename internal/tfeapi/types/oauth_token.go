@@ -14,3 +14,15 @@ type OAuthToken struct {
 	// Relations
 	OAuthClient *OAuthClient `jsonapi:"relationship" json:"oauth-client"`
 }
+
+// OAuthTokenUpdateOptions represents the options for updating an OAuth token.
+type OAuthTokenUpdateOptions struct {
+	// Type is a public field utilized by JSON:API to
+	// set the resource type via the field tag.
+	// It is not a user-defined value and does not need to be set.
+	// https://jsonapi.org/format/#crud-creating
+	Type string `jsonapi:"primary,oauth-tokens"`
+
+	// Optional: A private SSH key to be used for git clone operations.
+	PrivateSSHKey *string `jsonapi:"attribute" json:"ssh-key,omitempty"`
+}
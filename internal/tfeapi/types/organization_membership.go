@@ -30,4 +30,7 @@ type OrganizationMembershipCreateOptions struct {
 
 	// Required: User's email address.
 	Email *string `jsonapi:"attribute" json:"email"`
+
+	// Optional: Teams to add the user to.
+	Teams []*Team `jsonapi:"relationship" json:"teams,omitempty"`
 }
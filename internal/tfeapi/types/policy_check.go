@@ -0,0 +1,64 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package types
+
+import "time"
+
+// PolicyCheck represents a Terraform Enterprise policy check.
+type PolicyCheck struct {
+	ID               string                  `jsonapi:"primary,policy-checks"`
+	Actions          *PolicyActions          `jsonapi:"attribute" json:"actions"`
+	Permissions      *PolicyPermissions      `jsonapi:"attribute" json:"permissions"`
+	Result           *PolicyResult           `jsonapi:"attribute" json:"result"`
+	Scope            string                  `jsonapi:"attribute" json:"scope"`
+	Status           string                  `jsonapi:"attribute" json:"status"`
+	StatusTimestamps *PolicyStatusTimestamps `jsonapi:"attribute" json:"status-timestamps"`
+
+	// Relations
+	Run *Run `jsonapi:"relationship" json:"run"`
+}
+
+// PolicyActions represents the policy check actions.
+type PolicyActions struct {
+	IsOverridable bool `json:"is-overridable"`
+}
+
+// PolicyPermissions represents the policy check permissions.
+type PolicyPermissions struct {
+	CanOverride bool `json:"can-override"`
+}
+
+// PolicyResult represents the complete policy check result.
+type PolicyResult struct {
+	AdvisoryFailed int  `json:"advisory-failed"`
+	HardFailed     int  `json:"hard-failed"`
+	Passed         int  `json:"passed"`
+	Result         bool `json:"result"`
+	SoftFailed     int  `json:"soft-failed"`
+	TotalFailed    int  `json:"total-failed"`
+
+	// Outcomes of each policy evaluated. This is an OTF extension.
+	Outcomes []*PolicyOutcome `json:"outcomes,omitempty"`
+}
+
+// PolicyOutcome represents the outcome of evaluating a policy.
+type PolicyOutcome struct {
+	PolicySetName    string   `json:"policy-set-name"`
+	PolicyName       string   `json:"policy-name"`
+	EnforcementLevel string   `json:"enforcement-level"`
+	Status           string   `json:"status"`
+	Violations       []string `json:"violations,omitempty"`
+}
+
+// PolicyStatusTimestamps holds the timestamps for individual policy check
+// statuses.
+type PolicyStatusTimestamps struct {
+	ErroredAt    *time.Time `json:"errored-at,omitempty"`
+	HardFailedAt *time.Time `json:"hard-failed-at,omitempty"`
+	PassedAt     *time.Time `json:"passed-at,omitempty"`
+	QueuedAt     *time.Time `json:"queued-at,omitempty"`
+	SoftFailedAt *time.Time `json:"soft-failed-at,omitempty"`
+	CanceledAt   *time.Time `json:"canceled-at,omitempty"`
+	OverriddenAt *time.Time `json:"overridden-at,omitempty"`
+}
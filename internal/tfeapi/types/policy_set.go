@@ -0,0 +1,162 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package types
+
+import "time"
+
+// PolicySet represents a Terraform Enterprise policy set.
+type PolicySet struct {
+	ID             string    `jsonapi:"primary,policy-sets"`
+	Name           string    `jsonapi:"attribute" json:"name"`
+	Description    string    `jsonapi:"attribute" json:"description"`
+	Kind           string    `jsonapi:"attribute" json:"kind"`
+	Overridable    *bool     `jsonapi:"attribute" json:"overridable"`
+	Global         bool      `jsonapi:"attribute" json:"global"`
+	PolicyCount    int       `jsonapi:"attribute" json:"policy-count"`
+	WorkspaceCount int       `jsonapi:"attribute" json:"workspace-count"`
+	CreatedAt      time.Time `jsonapi:"attribute" json:"created-at"`
+	UpdatedAt      time.Time `jsonapi:"attribute" json:"updated-at"`
+
+	// Relations
+	Organization   *Organization     `jsonapi:"relationship" json:"organization"`
+	Workspaces     []*Workspace      `jsonapi:"relationship" json:"workspaces,omitempty"`
+	NewestVersion  *PolicySetVersion `jsonapi:"relationship" json:"newest-version,omitempty"`
+	CurrentVersion *PolicySetVersion `jsonapi:"relationship" json:"current-version,omitempty"`
+}
+
+// PolicySetListOptions represents the options for listing policy sets.
+type PolicySetListOptions struct {
+	ListOptions
+
+	// Optional: A search string (partial policy set name) used to filter the results.
+	Search *string `schema:"search[name],omitempty"`
+
+	// Optional: A kind string used to filter the results by the policy set kind.
+	Kind *string `schema:"filter[kind],omitempty"`
+}
+
+// PolicySetCreateOptions represents the options for creating a new policy set.
+type PolicySetCreateOptions struct {
+	// Type is a public field utilized by JSON:API to
+	// set the resource type via the field tag.
+	// It is not a user-defined value and does not need to be set.
+	// https://jsonapi.org/format/#crud-creating
+	Type string `jsonapi:"primary,policy-sets"`
+
+	// Required: The name of the policy set.
+	Name *string `jsonapi:"attribute" json:"name"`
+
+	// Optional: The description of the policy set.
+	Description *string `jsonapi:"attribute" json:"description,omitempty"`
+
+	// Optional: Whether or not the policy set is global.
+	Global *bool `jsonapi:"attribute" json:"global,omitempty"`
+
+	// Optional: The underlying technology that the policy set supports
+	Kind *string `jsonapi:"attribute" json:"kind,omitempty"`
+
+	// Optional: Whether or not users can override this policy when it fails
+	// during a run.
+	Overridable *bool `jsonapi:"attribute" json:"overridable,omitempty"`
+
+	// Optional: VCS repository information. Not supported by OTF.
+	VCSRepo *VCSRepoOptions `jsonapi:"attribute" json:"vcs-repo,omitempty"`
+
+	// Optional: The initial list of workspaces for which the policy set should
+	// be enforced.
+	Workspaces []*Workspace `jsonapi:"relationship" json:"workspaces,omitempty"`
+}
+
+// PolicySetUpdateOptions represents the options for updating a policy set.
+type PolicySetUpdateOptions struct {
+	// Type is a public field utilized by JSON:API to
+	// set the resource type via the field tag.
+	// It is not a user-defined value and does not need to be set.
+	// https://jsonapi.org/format/#crud-creating
+	Type string `jsonapi:"primary,policy-sets"`
+
+	// Optional: The name of the policy set.
+	Name *string `jsonapi:"attribute" json:"name,omitempty"`
+
+	// Optional: The description of the policy set.
+	Description *string `jsonapi:"attribute" json:"description,omitempty"`
+
+	// Optional: Whether or not the policy set is global.
+	Global *bool `jsonapi:"attribute" json:"global,omitempty"`
+
+	// Optional: Whether or not users can override this policy when it fails
+	// during a run.
+	Overridable *bool `jsonapi:"attribute" json:"overridable,omitempty"`
+
+	// Optional: VCS repository information. Not supported by OTF.
+	VCSRepo *VCSRepoOptions `jsonapi:"attribute" json:"vcs-repo,omitempty"`
+}
+
+// PolicySetParameter represents a parameter passed to the policies in a
+// policy set.
+type PolicySetParameter struct {
+	ID        string `jsonapi:"primary,vars"`
+	Key       string `jsonapi:"attribute" json:"key"`
+	Value     string `jsonapi:"attribute" json:"value"`
+	Category  string `jsonapi:"attribute" json:"category"`
+	Sensitive bool   `jsonapi:"attribute" json:"sensitive"`
+
+	// Relations
+	PolicySet *PolicySet `jsonapi:"relationship" json:"configurable"`
+}
+
+// PolicySetParameterCreateOptions represents the options for creating a new
+// parameter.
+type PolicySetParameterCreateOptions struct {
+	// Type is a public field utilized by JSON:API to
+	// set the resource type via the field tag.
+	// It is not a user-defined value and does not need to be set.
+	// https://jsonapi.org/format/#crud-creating
+	Type string `jsonapi:"primary,vars"`
+
+	// Required: The name of the parameter.
+	Key *string `jsonapi:"attribute" json:"key"`
+
+	// Optional: The value of the parameter.
+	Value *string `jsonapi:"attribute" json:"value,omitempty"`
+
+	// Required: The Category of the parameter, should always be "policy-set"
+	Category *string `jsonapi:"attribute" json:"category"`
+
+	// Optional: Whether the value is sensitive.
+	Sensitive *bool `jsonapi:"attribute" json:"sensitive,omitempty"`
+}
+
+// PolicySetParameterUpdateOptions represents the options for updating a
+// parameter.
+type PolicySetParameterUpdateOptions struct {
+	// Type is a public field utilized by JSON:API to
+	// set the resource type via the field tag.
+	// It is not a user-defined value and does not need to be set.
+	// https://jsonapi.org/format/#crud-creating
+	Type string `jsonapi:"primary,vars"`
+
+	// Optional: The name of the parameter.
+	Key *string `jsonapi:"attribute" json:"key,omitempty"`
+
+	// Optional: The value of the parameter.
+	Value *string `jsonapi:"attribute" json:"value,omitempty"`
+
+	// Optional: Whether the value is sensitive.
+	Sensitive *bool `jsonapi:"attribute" json:"sensitive,omitempty"`
+}
+
+// PolicySetVersion represents a version of a policy set's policies.
+type PolicySetVersion struct {
+	ID           string    `jsonapi:"primary,policy-set-versions"`
+	Source       string    `jsonapi:"attribute" json:"source"`
+	Status       string    `jsonapi:"attribute" json:"status"`
+	Error        string    `jsonapi:"attribute" json:"error"`
+	ErrorMessage string    `jsonapi:"attribute" json:"error-message"`
+	CreatedAt    time.Time `jsonapi:"attribute" json:"created-at"`
+	UpdatedAt    time.Time `jsonapi:"attribute" json:"updated-at"`
+
+	// Relations
+	PolicySet *PolicySet `jsonapi:"relationship" json:"policy-set"`
+}
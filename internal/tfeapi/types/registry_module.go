@@ -0,0 +1,122 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package types
+
+import "time"
+
+// RegistryName is the name of the registry to which a module belongs.
+type RegistryName string
+
+// OTF only supports private registries.
+const PrivateRegistry RegistryName = "private"
+
+// RegistryModule represents a registry module.
+type RegistryModule struct {
+	ID              string                          `jsonapi:"primary,registry-modules"`
+	Name            string                          `jsonapi:"attribute" json:"name"`
+	Provider        string                          `jsonapi:"attribute" json:"provider"`
+	RegistryName    RegistryName                    `jsonapi:"attribute" json:"registry-name"`
+	Namespace       string                          `jsonapi:"attribute" json:"namespace"`
+	NoCode          bool                            `jsonapi:"attribute" json:"no-code"`
+	Permissions     *RegistryModulePermissions      `jsonapi:"attribute" json:"permissions"`
+	Status          string                          `jsonapi:"attribute" json:"status"`
+	VCSRepo         *VCSRepo                        `jsonapi:"attribute" json:"vcs-repo,omitempty"`
+	VersionStatuses []RegistryModuleVersionStatuses `jsonapi:"attribute" json:"version-statuses"`
+	CreatedAt       time.Time                       `jsonapi:"attribute" json:"created-at"`
+	UpdatedAt       time.Time                       `jsonapi:"attribute" json:"updated-at"`
+
+	// Relations
+	Organization *Organization `jsonapi:"relationship" json:"organization"`
+}
+
+// RegistryModuleVersion represents a registry module version.
+type RegistryModuleVersion struct {
+	ID        string    `jsonapi:"primary,registry-module-versions"`
+	Source    string    `jsonapi:"attribute" json:"source"`
+	Status    string    `jsonapi:"attribute" json:"status"`
+	Version   string    `jsonapi:"attribute" json:"version"`
+	CreatedAt time.Time `jsonapi:"attribute" json:"created-at"`
+	UpdatedAt time.Time `jsonapi:"attribute" json:"updated-at"`
+
+	// Relations
+	RegistryModule *RegistryModule `jsonapi:"relationship" json:"registry-module"`
+}
+
+// RegistryModulePermissions represents the permissions of the caller on a
+// registry module.
+type RegistryModulePermissions struct {
+	CanDelete bool `json:"can-delete"`
+	CanResync bool `json:"can-resync"`
+	CanRetry  bool `json:"can-retry"`
+}
+
+// RegistryModuleVersionStatuses represents the status of a version of a
+// registry module.
+type RegistryModuleVersionStatuses struct {
+	Version string `json:"version"`
+	Status  string `json:"status"`
+	Error   string `json:"error"`
+}
+
+// RegistryModuleCreateOptions is used when creating a registry module without
+// a VCS repo.
+type RegistryModuleCreateOptions struct {
+	// Type is a public field utilized by JSON:API to
+	// set the resource type via the field tag.
+	// It is not a user-defined value and does not need to be set.
+	// https://jsonapi.org/format/#crud-creating
+	Type string `jsonapi:"primary,registry-modules"`
+
+	// Required: The name of the module.
+	Name *string `jsonapi:"attribute" json:"name"`
+
+	// Required: The provider of the module.
+	Provider *string `jsonapi:"attribute" json:"provider"`
+
+	// Optional: Whether this is a publicly maintained module or private.
+	// Only private modules are supported by OTF.
+	RegistryName RegistryName `jsonapi:"attribute" json:"registry-name,omitempty"`
+
+	// Optional: The namespace of the module, which for a private module is
+	// the name of the organization.
+	Namespace string `jsonapi:"attribute" json:"namespace,omitempty"`
+}
+
+// RegistryModuleCreateWithVCSConnectionOptions is used when creating a
+// registry module with a VCS repo.
+type RegistryModuleCreateWithVCSConnectionOptions struct {
+	// Type is a public field utilized by JSON:API to
+	// set the resource type via the field tag.
+	// It is not a user-defined value and does not need to be set.
+	// https://jsonapi.org/format/#crud-creating
+	Type string `jsonapi:"primary,registry-modules"`
+
+	// Required: VCS repository information
+	VCSRepo *RegistryModuleVCSRepoOptions `jsonapi:"attribute" json:"vcs-repo"`
+}
+
+// RegistryModuleVCSRepoOptions are the options for the VCS repo of a registry
+// module.
+type RegistryModuleVCSRepoOptions struct {
+	Identifier        *string `json:"identifier"`
+	OAuthTokenID      *string `json:"oauth-token-id,omitempty"`
+	DisplayIdentifier *string `json:"display-identifier,omitempty"`
+	OrganizationName  *string `json:"organization-name,omitempty"`
+}
+
+// RegistryModuleCreateVersionOptions is used when creating a registry module
+// version.
+type RegistryModuleCreateVersionOptions struct {
+	// Type is a public field utilized by JSON:API to
+	// set the resource type via the field tag.
+	// It is not a user-defined value and does not need to be set.
+	// https://jsonapi.org/format/#crud-creating
+	Type string `jsonapi:"primary,registry-module-versions"`
+
+	// Required: The version of the module.
+	Version *string `jsonapi:"attribute" json:"version"`
+
+	// Optional: The commit SHA of the version. Ignored by OTF.
+	CommitSHA *string `jsonapi:"attribute" json:"commit-sha,omitempty"`
+}
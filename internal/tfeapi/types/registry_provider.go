@@ -0,0 +1,136 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package types
+
+import "time"
+
+// RegistryProvider represents a provider in the private registry.
+type RegistryProvider struct {
+	ID           string                       `jsonapi:"primary,registry-providers"`
+	Name         string                       `jsonapi:"attribute" json:"name"`
+	Namespace    string                       `jsonapi:"attribute" json:"namespace"`
+	RegistryName RegistryName                 `jsonapi:"attribute" json:"registry-name"`
+	Permissions  *RegistryProviderPermissions `jsonapi:"attribute" json:"permissions"`
+	CreatedAt    time.Time                    `jsonapi:"attribute" json:"created-at"`
+	UpdatedAt    time.Time                    `jsonapi:"attribute" json:"updated-at"`
+
+	// Relations
+	Organization             *Organization              `jsonapi:"relationship" json:"organization"`
+	RegistryProviderVersions []*RegistryProviderVersion `jsonapi:"relationship" json:"registry-provider-versions"`
+}
+
+// RegistryProviderPermissions represents the permissions of the caller on a
+// registry provider.
+type RegistryProviderPermissions struct {
+	CanDelete bool `json:"can-delete"`
+}
+
+// RegistryProviderCreateOptions is used when creating a registry provider.
+type RegistryProviderCreateOptions struct {
+	// Type is a public field utilized by JSON:API to
+	// set the resource type via the field tag.
+	// It is not a user-defined value and does not need to be set.
+	// https://jsonapi.org/format/#crud-creating
+	Type string `jsonapi:"primary,registry-providers"`
+
+	// Required: The name of the provider.
+	Name *string `jsonapi:"attribute" json:"name"`
+
+	// Required: The namespace of the provider, which for a private provider
+	// is the name of the organization.
+	Namespace string `jsonapi:"attribute" json:"namespace"`
+
+	// Required: Whether this is a publicly maintained provider or private.
+	// Only private providers are supported by OTF.
+	RegistryName RegistryName `jsonapi:"attribute" json:"registry-name"`
+}
+
+// RegistryProviderVersion represents a version of a registry provider.
+type RegistryProviderVersion struct {
+	ID                 string                              `jsonapi:"primary,registry-provider-versions"`
+	Version            string                              `jsonapi:"attribute" json:"version"`
+	KeyID              string                              `jsonapi:"attribute" json:"key-id"`
+	Protocols          []string                            `jsonapi:"attribute" json:"protocols"`
+	Permissions        *RegistryProviderVersionPermissions `jsonapi:"attribute" json:"permissions"`
+	ShasumsUploaded    bool                                `jsonapi:"attribute" json:"shasums-uploaded"`
+	ShasumsSigUploaded bool                                `jsonapi:"attribute" json:"shasums-sig-uploaded"`
+	CreatedAt          time.Time                           `jsonapi:"attribute" json:"created-at"`
+	UpdatedAt          time.Time                           `jsonapi:"attribute" json:"updated-at"`
+
+	// Relations
+	RegistryProvider          *RegistryProvider           `jsonapi:"relationship" json:"registry-provider"`
+	RegistryProviderPlatforms []*RegistryProviderPlatform `jsonapi:"relationship" json:"platforms"`
+}
+
+// RegistryProviderVersionPermissions represents the permissions of the caller
+// on a registry provider version.
+type RegistryProviderVersionPermissions struct {
+	CanDelete      bool `json:"can-delete"`
+	CanUploadAsset bool `json:"can-upload-asset"`
+}
+
+// RegistryProviderVersionCreateOptions is used when creating a registry
+// provider version.
+type RegistryProviderVersionCreateOptions struct {
+	// Type is a public field utilized by JSON:API to
+	// set the resource type via the field tag.
+	// It is not a user-defined value and does not need to be set.
+	// https://jsonapi.org/format/#crud-creating
+	Type string `jsonapi:"primary,registry-provider-versions"`
+
+	// Required: The version of the provider.
+	Version string `jsonapi:"attribute" json:"version"`
+
+	// Required: The ID of the GPG key used to sign the version's SHA256SUMS
+	// file.
+	KeyID string `jsonapi:"attribute" json:"key-id"`
+
+	// Required: The terraform plugin protocols supported by the version, e.g.
+	// 5.0.
+	Protocols []string `jsonapi:"attribute" json:"protocols"`
+}
+
+// RegistryProviderPlatform represents a platform for which a version of a
+// registry provider is built.
+type RegistryProviderPlatform struct {
+	ID                     string                               `jsonapi:"primary,registry-provider-platforms"`
+	OS                     string                               `jsonapi:"attribute" json:"os"`
+	Arch                   string                               `jsonapi:"attribute" json:"arch"`
+	Filename               string                               `jsonapi:"attribute" json:"filename"`
+	Shasum                 string                               `jsonapi:"attribute" json:"shasum"`
+	Permissions            *RegistryProviderPlatformPermissions `jsonapi:"attribute" json:"permissions"`
+	ProviderBinaryUploaded bool                                 `jsonapi:"attribute" json:"provider-binary-uploaded"`
+
+	// Relations
+	RegistryProviderVersion *RegistryProviderVersion `jsonapi:"relationship" json:"registry-provider-version"`
+}
+
+// RegistryProviderPlatformPermissions represents the permissions of the caller
+// on a registry provider platform.
+type RegistryProviderPlatformPermissions struct {
+	CanDelete      bool `json:"can-delete"`
+	CanUploadAsset bool `json:"can-upload-asset"`
+}
+
+// RegistryProviderPlatformCreateOptions is used when creating a registry
+// provider platform.
+type RegistryProviderPlatformCreateOptions struct {
+	// Type is a public field utilized by JSON:API to
+	// set the resource type via the field tag.
+	// It is not a user-defined value and does not need to be set.
+	// https://jsonapi.org/format/#crud-creating
+	Type string `jsonapi:"primary,registry-provider-platforms"`
+
+	// Required: The operating system of the platform, e.g. linux.
+	OS string `jsonapi:"attribute" json:"os"`
+
+	// Required: The architecture of the platform, e.g. amd64.
+	Arch string `jsonapi:"attribute" json:"arch"`
+
+	// Required: The SHA256 hash of the provider binary.
+	Shasum string `jsonapi:"attribute" json:"shasum"`
+
+	// Required: The filename of the provider binary.
+	Filename string `jsonapi:"attribute" json:"filename"`
+}
@@ -12,6 +12,7 @@ type Run struct {
 	AllowEmptyApply        bool                 `jsonapi:"attribute" json:"allow-empty-apply"`
 	AutoApply              bool                 `jsonapi:"attribute" json:"auto-apply"`
 	CreatedAt              time.Time            `jsonapi:"attribute" json:"created-at"`
+	Diagnostics            []RunDiagnostic      `jsonapi:"attribute" json:"diagnostics,omitempty"`
 	ForceCancelAvailableAt *time.Time           `jsonapi:"attribute" json:"force-cancel-available-at"`
 	ExecutionMode          string               `jsonapi:"attribute" json:"execution-mode"`
 	HasChanges             bool                 `jsonapi:"attribute" json:"has-changes"`
@@ -20,9 +21,11 @@ type Run struct {
 	Permissions            *RunPermissions      `jsonapi:"attribute" json:"permissions"`
 	PlanOnly               bool                 `jsonapi:"attribute" json:"plan-only"`
 	PositionInQueue        int                  `jsonapi:"attribute" json:"position-in-queue"`
+	PositionInGroup        int                  `jsonapi:"attribute" json:"position-in-group"`
 	Refresh                bool                 `jsonapi:"attribute" json:"refresh"`
 	RefreshOnly            bool                 `jsonapi:"attribute" json:"refresh-only"`
 	ReplaceAddrs           []string             `jsonapi:"attribute" json:"replace-addrs,omitempty"`
+	SerializationGroup     *string              `jsonapi:"attribute" json:"serialization-group,omitempty"`
 	Source                 string               `jsonapi:"attribute" json:"source"`
 	Status                 string               `jsonapi:"attribute" json:"status"`
 	StatusTimestamps       *RunStatusTimestamps `jsonapi:"attribute" json:"status-timestamps"`
@@ -60,6 +63,25 @@ type RunStatusTimestamps struct {
 	PolicySoftFailedAt   *time.Time `json:"policy-soft-failed-at,omitempty"`
 }
 
+// RunDiagnostic is an error or warning reported by terraform during a run
+// that errored.
+type RunDiagnostic struct {
+	Phase    string `json:"phase"`
+	Severity string `json:"severity"`
+	Summary  string `json:"summary"`
+	Detail   string `json:"detail,omitempty"`
+	// Address of the resource to which the diagnostic relates.
+	Address string              `json:"address,omitempty"`
+	Range   *RunDiagnosticRange `json:"range,omitempty"`
+}
+
+// RunDiagnosticRange is the location in the configuration to which a
+// diagnostic relates.
+type RunDiagnosticRange struct {
+	Filename string `json:"filename"`
+	Line     int    `json:"line"`
+}
+
 // RunList represents a list of runs.
 type RunList struct {
 	*Pagination
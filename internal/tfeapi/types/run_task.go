@@ -0,0 +1,172 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package types
+
+import "time"
+
+// RunTask represents a TFE run task.
+type RunTask struct {
+	ID          string  `jsonapi:"primary,tasks"`
+	Name        string  `jsonapi:"attribute" json:"name"`
+	URL         string  `jsonapi:"attribute" json:"url"`
+	Description string  `jsonapi:"attribute" json:"description"`
+	Category    string  `jsonapi:"attribute" json:"category"`
+	HMACKey     *string `jsonapi:"attribute" json:"hmac-key,omitempty"`
+	Enabled     bool    `jsonapi:"attribute" json:"enabled"`
+
+	// Relations
+	Organization *Organization `jsonapi:"relationship" json:"organization"`
+}
+
+// RunTaskCreateOptions represents the set of options for creating a run task.
+type RunTaskCreateOptions struct {
+	// Type is a public field utilized by JSON:API to
+	// set the resource type via the field tag.
+	// It is not a user-defined value and does not need to be set.
+	// https://jsonapi.org/format/#crud-creating
+	Type string `jsonapi:"primary,tasks"`
+
+	// Required: The name of the run task
+	Name string `jsonapi:"attribute" json:"name"`
+
+	// Required: The URL to send a run task payload
+	URL string `jsonapi:"attribute" json:"url"`
+
+	// Optional: Description of the task
+	Description *string `jsonapi:"attribute" json:"description,omitempty"`
+
+	// Required: Must be "task"
+	Category string `jsonapi:"attribute" json:"category"`
+
+	// Optional: An HMAC key to verify the run task
+	HMACKey *string `jsonapi:"attribute" json:"hmac-key,omitempty"`
+
+	// Optional: Whether the task should be enabled
+	Enabled *bool `jsonapi:"attribute" json:"enabled,omitempty"`
+}
+
+// RunTaskUpdateOptions represents the set of options for updating an
+// organization's run task.
+type RunTaskUpdateOptions struct {
+	// Type is a public field utilized by JSON:API to
+	// set the resource type via the field tag.
+	// It is not a user-defined value and does not need to be set.
+	// https://jsonapi.org/format/#crud-creating
+	Type string `jsonapi:"primary,tasks"`
+
+	// Optional: The name of the run task, defaults to previous value
+	Name *string `jsonapi:"attribute" json:"name,omitempty"`
+
+	// Optional: The URL to send a run task payload, defaults to previous value
+	URL *string `jsonapi:"attribute" json:"url,omitempty"`
+
+	// Optional: An optional description of the task
+	Description *string `jsonapi:"attribute" json:"description,omitempty"`
+
+	// Optional: Must be "task", defaults to "task"
+	Category *string `jsonapi:"attribute" json:"category,omitempty"`
+
+	// Optional: An HMAC key to verify the run task
+	HMACKey *string `jsonapi:"attribute" json:"hmac-key,omitempty"`
+
+	// Optional: Whether the task should be enabled
+	Enabled *bool `jsonapi:"attribute" json:"enabled,omitempty"`
+}
+
+// WorkspaceRunTask represents a TFE run task attached to a workspace.
+type WorkspaceRunTask struct {
+	ID               string `jsonapi:"primary,workspace-tasks"`
+	EnforcementLevel string `jsonapi:"attribute" json:"enforcement-level"`
+	Stage            string `jsonapi:"attribute" json:"stage"`
+
+	// Relations
+	RunTask   *RunTask   `jsonapi:"relationship" json:"task"`
+	Workspace *Workspace `jsonapi:"relationship" json:"workspace"`
+}
+
+// WorkspaceRunTaskCreateOptions represents the set of options for creating a
+// workspace run task.
+type WorkspaceRunTaskCreateOptions struct {
+	// Type is a public field utilized by JSON:API to
+	// set the resource type via the field tag.
+	// It is not a user-defined value and does not need to be set.
+	// https://jsonapi.org/format/#crud-creating
+	Type string `jsonapi:"primary,workspace-tasks"`
+
+	// Required: The enforcement level for a run task
+	EnforcementLevel string `jsonapi:"attribute" json:"enforcement-level"`
+
+	// Required: The run task to attach to the workspace
+	RunTask *RunTask `jsonapi:"relationship" json:"task"`
+
+	// Optional: The stage to run the task in, defaults to post_plan
+	Stage *string `jsonapi:"attribute" json:"stage,omitempty"`
+}
+
+// WorkspaceRunTaskUpdateOptions represents the set of options for updating a
+// workspace run task.
+type WorkspaceRunTaskUpdateOptions struct {
+	// Type is a public field utilized by JSON:API to
+	// set the resource type via the field tag.
+	// It is not a user-defined value and does not need to be set.
+	// https://jsonapi.org/format/#crud-creating
+	Type string `jsonapi:"primary,workspace-tasks"`
+
+	// Optional: The enforcement level for a run task
+	EnforcementLevel *string `jsonapi:"attribute" json:"enforcement-level,omitempty"`
+
+	// Optional: The stage to run the task in
+	Stage *string `jsonapi:"attribute" json:"stage,omitempty"`
+}
+
+// TaskStage represents a TFE run-task stage
+type TaskStage struct {
+	ID        string    `jsonapi:"primary,task-stages"`
+	Stage     string    `jsonapi:"attribute" json:"stage"`
+	Status    string    `jsonapi:"attribute" json:"status"`
+	CreatedAt time.Time `jsonapi:"attribute" json:"created-at"`
+	UpdatedAt time.Time `jsonapi:"attribute" json:"updated-at"`
+
+	// Relations
+	Run         *Run          `jsonapi:"relationship" json:"run"`
+	TaskResults []*TaskResult `jsonapi:"relationship" json:"task-results"`
+}
+
+// TaskResult represents the result of a TFE run task
+type TaskResult struct {
+	ID                            string    `jsonapi:"primary,task-results"`
+	Status                        string    `jsonapi:"attribute" json:"status"`
+	Message                       string    `jsonapi:"attribute" json:"message"`
+	URL                           string    `jsonapi:"attribute" json:"url"`
+	CreatedAt                     time.Time `jsonapi:"attribute" json:"created-at"`
+	UpdatedAt                     time.Time `jsonapi:"attribute" json:"updated-at"`
+	TaskID                        string    `jsonapi:"attribute" json:"task-id"`
+	TaskName                      string    `jsonapi:"attribute" json:"task-name"`
+	TaskURL                       string    `jsonapi:"attribute" json:"task-url"`
+	WorkspaceTaskID               string    `jsonapi:"attribute" json:"workspace-task-id"`
+	WorkspaceTaskEnforcementLevel string    `jsonapi:"attribute" json:"workspace-task-enforcement-level"`
+
+	// Relations
+	TaskStage *TaskStage `jsonapi:"relationship" json:"task_stage"`
+}
+
+// TaskResultCallbackOptions represents the options sent by a run task server
+// to update the result of a run task.
+type TaskResultCallbackOptions struct {
+	// Type is a public field utilized by JSON:API to
+	// set the resource type via the field tag.
+	// It is not a user-defined value and does not need to be set.
+	// https://jsonapi.org/format/#crud-creating
+	Type string `jsonapi:"primary,task-results"`
+
+	// Required: The status of the run task, one of running, passed, or
+	// failed.
+	Status string `jsonapi:"attribute" json:"status"`
+
+	// Optional: A short message describing the result of the run task.
+	Message *string `jsonapi:"attribute" json:"message,omitempty"`
+
+	// Optional: A URL providing details of the result of the run task.
+	URL *string `jsonapi:"attribute" json:"url,omitempty"`
+}
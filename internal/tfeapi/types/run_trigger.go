@@ -0,0 +1,36 @@
+package types
+
+import "time"
+
+// RunTriggerFilterOp represents which direction of run triggers to list.
+type RunTriggerFilterOp string
+
+const (
+	RunTriggerOutbound RunTriggerFilterOp = "outbound" // create runs in other workspaces.
+	RunTriggerInbound  RunTriggerFilterOp = "inbound"  // create runs in the specified workspace
+)
+
+// RunTrigger represents a run trigger.
+type RunTrigger struct {
+	ID             string    `jsonapi:"primary,run-triggers"`
+	CreatedAt      time.Time `jsonapi:"attribute" json:"created-at"`
+	SourceableName string    `jsonapi:"attribute" json:"sourceable-name"`
+	WorkspaceName  string    `jsonapi:"attribute" json:"workspace-name"`
+
+	// Relations
+	Sourceable *Workspace `jsonapi:"relationship" json:"sourceable"`
+	Workspace  *Workspace `jsonapi:"relationship" json:"workspace"`
+}
+
+// RunTriggerCreateOptions represents the options for
+// creating a new run trigger.
+type RunTriggerCreateOptions struct {
+	// Type is a public field utilized by JSON:API to
+	// set the resource type via the field tag.
+	// It is not a user-defined value and does not need to be set.
+	// https://jsonapi.org/format/#crud-creating
+	Type string `jsonapi:"primary,run-triggers"`
+
+	// Required: The source workspace
+	Sourceable *Workspace `jsonapi:"relationship" json:"sourceable"`
+}
@@ -0,0 +1,18 @@
+package types
+
+import "time"
+
+// SearchResult represents a workspace or run matching a search query.
+type SearchResult struct {
+	// ID of the matching workspace or run.
+	ID            string    `jsonapi:"primary,search-results"`
+	Kind          string    `jsonapi:"attribute" json:"kind"`
+	WorkspaceName string    `jsonapi:"attribute" json:"workspace-name"`
+	Summary       string    `jsonapi:"attribute" json:"summary"`
+	CommitSHA     string    `jsonapi:"attribute" json:"commit-sha,omitempty"`
+	Status        string    `jsonapi:"attribute" json:"status"`
+	Timestamp     time.Time `jsonapi:"attribute" json:"timestamp"`
+
+	// Relations
+	Workspace *Workspace `jsonapi:"relationship" json:"workspace"`
+}
@@ -0,0 +1,37 @@
+package types
+
+// SSHKey represents a SSH key.
+type SSHKey struct {
+	ID   string `jsonapi:"primary,ssh-keys"`
+	Name string `jsonapi:"attribute" json:"name"`
+}
+
+// SSHKeyCreateOptions represents the options for creating an SSH key.
+type SSHKeyCreateOptions struct {
+	// Type is a public field utilized by JSON:API to
+	// set the resource type via the field tag.
+	// It is not a user-defined value and does not need to be set.
+	// https://jsonapi.org/format/#crud-creating
+	Type string `jsonapi:"primary,ssh-keys"`
+
+	// A name to identify the SSH key.
+	Name *string `jsonapi:"attribute" json:"name"`
+
+	// The content of the SSH private key.
+	Value *string `jsonapi:"attribute" json:"value"`
+}
+
+// SSHKeyUpdateOptions represents the options for updating an SSH key.
+type SSHKeyUpdateOptions struct {
+	// Type is a public field utilized by JSON:API to
+	// set the resource type via the field tag.
+	// It is not a user-defined value and does not need to be set.
+	// https://jsonapi.org/format/#crud-creating
+	Type string `jsonapi:"primary,ssh-keys"`
+
+	// Optional: A new name to identify the SSH key.
+	Name *string `jsonapi:"attribute" json:"name,omitempty"`
+
+	// Optional: The new content of the SSH private key.
+	Value *string `jsonapi:"attribute" json:"value,omitempty"`
+}
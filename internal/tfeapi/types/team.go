@@ -12,7 +12,8 @@ type (
 		SSOTeamID          *string             `jsonapi:"attribute" json:"sso-team-id"`
 
 		// Relations
-		Users []*User `jsonapi:"relationship" json:"users"`
+		Organization *Organization `jsonapi:"relationship" json:"organization"`
+		Users        []*User       `jsonapi:"relationship" json:"users"`
 	}
 
 	// OrganizationAccess represents the team's permissions on its organization
@@ -33,4 +33,17 @@ type (
 
 		Username *string `jsonapi:"attribute" json:"username"`
 	}
+
+	// UserUpdateOptions represents the options for updating the current
+	// user's account.
+	UserUpdateOptions struct {
+		// Type is a public field utilized by JSON:API to
+		// set the resource type via the field tag.
+		// It is not a user-defined value and does not need to be set.
+		// https://jsonapi.org/format/#crud-creating
+		Type string `jsonapi:"primary,users"`
+
+		Username *string `jsonapi:"attribute" json:"username,omitempty"`
+		Email    *string `jsonapi:"attribute" json:"email,omitempty"`
+	}
 )
@@ -9,6 +9,7 @@ type VariableSet struct {
 	Name        string `jsonapi:"attribute" json:"name"`
 	Description string `jsonapi:"attribute" json:"description"`
 	Global      bool   `jsonapi:"attribute" json:"global"`
+	Priority    bool   `jsonapi:"attribute" json:"priority"`
 
 	// Relations
 	Organization *Organization          `jsonapi:"relationship" json:"organization"`
@@ -43,6 +44,10 @@ type VariableSetCreateOptions struct {
 
 	// If true the variable set is considered in all runs in the organization.
 	Global bool `jsonapi:"attribute" json:"global,omitempty"`
+
+	// If true the variables in the set override any other variable values set
+	// with a more specific scope including values set on the command line.
+	Priority bool `jsonapi:"attribute" json:"priority,omitempty"`
 }
 
 // VariableSetUpdateOptions represents the options for updating a variable set.
@@ -63,6 +68,10 @@ type VariableSetUpdateOptions struct {
 
 	// If true the variable set is considered in all runs in the organization.
 	Global *bool `jsonapi:"attribute" json:"global,omitempty"`
+
+	// If true the variables in the set override any other variable values set
+	// with a more specific scope including values set on the command line.
+	Priority *bool `jsonapi:"attribute" json:"priority,omitempty"`
 }
 
 // VariableSetVariableCreatOptions represents the options for creating a new variable within a variable set
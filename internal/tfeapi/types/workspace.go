@@ -17,6 +17,7 @@ type Workspace struct {
 	Actions                    *WorkspaceActions     `jsonapi:"attribute" json:"actions"`
 	AgentPoolID                string                `jsonapi:"attribute" json:"agent-pool-id"`
 	AllowDestroyPlan           bool                  `jsonapi:"attribute" json:"allow-destroy-plan"`
+	AssessmentsEnabled         bool                  `jsonapi:"attribute" json:"assessments-enabled"`
 	AutoApply                  bool                  `jsonapi:"attribute" json:"auto-apply"`
 	CanQueueDestroyPlan        bool                  `jsonapi:"attribute" json:"can-queue-destroy-plan"`
 	CreatedAt                  time.Time             `jsonapi:"attribute" json:"created-at"`
@@ -38,6 +39,8 @@ type Workspace struct {
 	TerraformVersion           string                `jsonapi:"attribute" json:"terraform-version"`
 	TriggerPrefixes            []string              `jsonapi:"attribute" json:"trigger-prefixes"`
 	TriggerPatterns            []string              `jsonapi:"attribute" json:"trigger-patterns"`
+	IgnorePatterns             []string              `jsonapi:"attribute" json:"ignore-patterns"`
+	SerializationGroup         *string               `jsonapi:"attribute" json:"serialization-group"`
 	VCSRepo                    *VCSRepo              `jsonapi:"attribute" json:"vcs-repo"`
 	WorkingDirectory           string                `jsonapi:"attribute" json:"working-directory"`
 	UpdatedAt                  time.Time             `jsonapi:"attribute" json:"updated-at"`
@@ -50,9 +53,11 @@ type Workspace struct {
 	TagNames                   []string              `jsonapi:"attribute" json:"tag-names"`
 
 	// Relations
+	AgentPool    *AgentPool         `jsonapi:"relationship" json:"agent-pool,omitempty"`
 	CurrentRun   *Run               `jsonapi:"relationship" json:"current-run"`
 	Organization *Organization      `jsonapi:"relationship" json:"organization"`
 	Outputs      []*WorkspaceOutput `jsonapi:"relationship" json:"outputs"`
+	SSHKey       *SSHKey            `jsonapi:"relationship" json:"ssh-key,omitempty"`
 }
 
 type WorkspaceOutput struct {
@@ -139,6 +144,10 @@ type WorkspaceCreateOptions struct {
 	// Whether destroy plans can be queued on the workspace.
 	AllowDestroyPlan *bool `jsonapi:"attribute" json:"allow-destroy-plan,omitempty"`
 
+	// Optional: Whether to regularly run health assessments, i.e. refresh-only
+	// plans that detect drift, on the workspace.
+	AssessmentsEnabled *bool `jsonapi:"attribute" json:"assessments-enabled,omitempty"`
+
 	// Whether to automatically apply changes when a Terraform plan is successful.
 	AutoApply *bool `jsonapi:"attribute" json:"auto-apply,omitempty"`
 
@@ -214,6 +223,14 @@ type WorkspaceCreateOptions struct {
 	// to decide whether to trigger a run or not.
 	TriggerPatterns []string `jsonapi:"attribute" json:"trigger-patterns,omitempty"`
 
+	// Optional: List of patterns matching changed files that should not
+	// trigger a run. OTF-specific.
+	IgnorePatterns []string `jsonapi:"attribute" json:"ignore-patterns,omitempty"`
+
+	// Optional: Name of the serialization group to which the workspace
+	// belongs. At most one apply in a group runs at any time. OTF-specific.
+	SerializationGroup *string `jsonapi:"attribute" json:"serialization-group,omitempty"`
+
 	// Settings for the workspace's VCS repository. If omitted, the workspace is
 	// created without a VCS repo. If included, you must specify at least the
 	// oauth-token-id and identifier keys below.
@@ -245,6 +262,10 @@ type WorkspaceUpdateOptions struct {
 	// Whether destroy plans can be queued on the workspace.
 	AllowDestroyPlan *bool `jsonapi:"attribute" json:"allow-destroy-plan,omitempty"`
 
+	// Optional: Whether to regularly run health assessments, i.e. refresh-only
+	// plans that detect drift, on the workspace.
+	AssessmentsEnabled *bool `jsonapi:"attribute" json:"assessments-enabled,omitempty"`
+
 	// Whether to automatically apply changes when a Terraform plan is successful.
 	AutoApply *bool `jsonapi:"attribute" json:"auto-apply,omitempty"`
 
@@ -302,6 +323,15 @@ type WorkspaceUpdateOptions struct {
 	// to decide whether to trigger a run or not.
 	TriggerPatterns []string `jsonapi:"attribute" json:"trigger-patterns,omitempty"`
 
+	// Optional: List of patterns matching changed files that should not
+	// trigger a run. OTF-specific.
+	IgnorePatterns []string `jsonapi:"attribute" json:"ignore-patterns,omitempty"`
+
+	// Optional: Name of the serialization group to which the workspace
+	// belongs; an empty string removes the workspace from its group.
+	// OTF-specific.
+	SerializationGroup *string `jsonapi:"attribute" json:"serialization-group,omitempty"`
+
 	// To delete a workspace's existing VCS repo, specify null instead of an
 	// object. To modify a workspace's existing VCS repo, include whichever of
 	// the keys below you wish to modify. To add a new VCS repo to a workspace
@@ -0,0 +1,17 @@
+package tokens
+
+import "context"
+
+type tokenIDContextKey struct{}
+
+// TokenIDFromContext returns the ID of the API token with which the request
+// in the context was authenticated, or an empty string if the request was not
+// authenticated with an API token.
+func TokenIDFromContext(ctx context.Context) string {
+	id, _ := ctx.Value(tokenIDContextKey{}).(string)
+	return id
+}
+
+func addTokenIDToContext(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, tokenIDContextKey{}, id)
+}
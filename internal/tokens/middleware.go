@@ -1,11 +1,11 @@
 package tokens
 
 import (
-	"context"
 	"errors"
 	"fmt"
 	"net/http"
 	"strings"
+	"time"
 
 	"github.com/go-logr/logr"
 	"github.com/gorilla/mux"
@@ -23,12 +23,18 @@ import (
 const (
 	// HTTP header in Google Cloud IAP request containing JWT
 	googleIAPHeader string = "x-goog-iap-jwt-assertion"
+
+	// expiryWarningPeriod is the period before a token expires during which
+	// clients are warned of its impending expiry.
+	expiryWarningPeriod = 7 * 24 * time.Hour
 )
 
 // AuthenticatedPrefixes are those URL path prefixes requiring authentication.
 var AuthenticatedPrefixes = []string{
 	tfeapi.APIPrefixV2,
 	tfeapi.ModuleV1Prefix,
+	tfeapi.ProviderV1Prefix,
+	tfeapi.RegistryAPIPrefix,
 	otfapi.DefaultBasePath,
 	paths.UIPrefix,
 }
@@ -49,6 +55,23 @@ type (
 
 	middleware struct {
 		middlewareOptions
+
+		// built-in authenticators, tried before those registered with the
+		// registry.
+		builtin []builtinAuthenticator
+	}
+
+	// builtinAuthenticator is an authenticator that additionally describes
+	// the credentials with which the request was authenticated.
+	builtinAuthenticator func(r *http.Request) (internal.Subject, credentials, error)
+
+	// credentials describes the credentials with which a request was
+	// authenticated.
+	credentials struct {
+		// sso is true if the credentials were issued via single sign-on.
+		sso bool
+		// tokenID is the ID of the API token, if authenticated with one.
+		tokenID string
 	}
 )
 
@@ -56,26 +79,34 @@ type (
 // to protected endpoints possess a valid token, applying the following logic:
 //
 // 1. Skip authentication for non-protected paths and allow request.
-// 2. If Google IAP header is present then authenticate its token and allow or deny
-// accordingly.
-// 3. If Bearer token is present then authenticate it and allow or deny accordingly.
-// 4. If requested path is for a UI endpoint then check for session cookie. If
-// present then authenticate its token. If cookie is missing or authentication fails
-// then redirect user to login page.
-// 5. Otherwise, return 401
+// 2. Try each authenticator in the chain in turn, until one authenticates the
+// request or fails to authenticate the request. The chain begins with the
+// built-in authenticators - Google IAP token, bearer token, and session cookie
+// - followed by any authenticators registered with RegisterAuthenticator.
+// 3. If authentication fails, or no authenticator finds credentials, and the
+// requested path is for a UI endpoint then redirect the user to the login page.
+// 4. Otherwise, return 401
 //
 // Where authentication succeeds, the authenticated subject is attached to the request
 // context and the upstream handler is called. If the authenticated subject is a
-// user and the user does not exist the user is first created.
+// user and the user does not exist the user is first created. If the
+// credentials were not issued via single sign-on then the registered SSO
+// enforcers are first consulted, and authentication fails if any of them
+// require the subject to use single sign-on.
 func newMiddleware(opts middlewareOptions) mux.MiddlewareFunc {
 	mw := middleware{middlewareOptions: opts}
+	mw.builtin = []builtinAuthenticator{
+		mw.authenticateIAP,
+		mw.authenticateBearer,
+		mw.authenticateSession,
+	}
 
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-			var (
-				subject internal.Subject
-				err     error
-			)
+			if !isProtectedPath(r.URL.Path) {
+				next.ServeHTTP(w, r)
+				return
+			}
 			// Until request is authenticated, call service endpoints using
 			// superuser privileges. Once authenticated, the authenticated user
 			// replaces the superuser in the context.
@@ -83,98 +114,160 @@ func newMiddleware(opts middlewareOptions) mux.MiddlewareFunc {
 				Username: "auth",
 			})
 
-			if !isProtectedPath(r.URL.Path) {
-				next.ServeHTTP(w, r)
-				return
+			subject, creds, err := mw.authenticate(r.WithContext(ctx))
+			if err == nil && subject != nil && !creds.sso {
+				err = mw.enforceSSO(ctx, subject)
 			}
-			if token := r.Header.Get(googleIAPHeader); token != "" {
-				subject, err = mw.validateIAPToken(ctx, token)
-				if err != nil {
-					http.Error(w, err.Error(), http.StatusUnauthorized)
-					return
-				}
-			} else if bearer := r.Header.Get("Authorization"); bearer != "" {
-				subject, err = mw.validateBearer(ctx, bearer)
+			if strings.HasPrefix(r.URL.Path, paths.UIPrefix) && (err != nil || subject == nil) {
 				if err != nil {
-					mw.Error(err, "validating bearer token")
-					http.Error(w, err.Error(), http.StatusUnauthorized)
-					return
+					html.FlashError(w, err.Error())
+				} else {
+					html.FlashSuccess(w, "you need to login to access the requested page")
 				}
-			} else if strings.HasPrefix(r.URL.Path, paths.UIPrefix) {
-				var ok bool
-				subject, ok = mw.validateUIRequest(ctx, w, r)
-				if !ok {
-					html.SendUserToLoginPage(w, r)
-					return
-				}
-			} else {
+				html.SendUserToLoginPage(w, r)
+				return
+			}
+			if err != nil {
+				mw.Error(err, "authenticating request")
+				http.Error(w, err.Error(), http.StatusUnauthorized)
+				return
+			}
+			if subject == nil {
 				http.Error(w, "no authentication token found", http.StatusUnauthorized)
 				return
 			}
 			ctx = internal.AddSubjectToContext(r.Context(), subject)
+			if creds.sso {
+				ctx = AddSSOToContext(ctx)
+			}
+			if creds.tokenID != "" {
+				ctx = addTokenIDToContext(ctx, creds.tokenID)
+			}
 			next.ServeHTTP(w, r.WithContext(ctx))
 		})
 	}
 }
 
-func (m *middleware) validateIAPToken(ctx context.Context, token string) (internal.Subject, error) {
-	payload, err := idtoken.Validate(ctx, token, m.Audience)
+// authenticate tries each authenticator in the chain until one of them either
+// authenticates the request or returns an error. If no authenticator finds
+// credentials in the request then nil is returned. Credentials are only
+// reported as issued via single sign-on by the built-in authenticators.
+func (m *middleware) authenticate(r *http.Request) (internal.Subject, credentials, error) {
+	for _, authenticator := range m.builtin {
+		subject, creds, err := authenticator(r)
+		if err != nil {
+			return nil, credentials{}, err
+		}
+		if subject != nil {
+			return subject, creds, nil
+		}
+	}
+	for _, authenticator := range m.getAuthenticators() {
+		subject, err := authenticator.Authenticate(r)
+		if err != nil {
+			return nil, credentials{}, err
+		}
+		if subject != nil {
+			return subject, credentials{}, nil
+		}
+	}
+	return nil, credentials{}, nil
+}
+
+// authenticateIAP authenticates a Google IAP token. IAP authenticates users
+// with Google's identity provider, so the token is deemed to be issued via
+// single sign-on.
+func (m *middleware) authenticateIAP(r *http.Request) (internal.Subject, credentials, error) {
+	token := r.Header.Get(googleIAPHeader)
+	if token == "" {
+		return nil, credentials{}, nil
+	}
+	payload, err := idtoken.Validate(r.Context(), token, m.Audience)
 	if err != nil {
-		return nil, err
+		return nil, credentials{}, err
 	}
 	email, ok := payload.Claims["email"]
 	if !ok {
-		return nil, fmt.Errorf("IAP token is missing email claim")
+		return nil, credentials{}, fmt.Errorf("IAP token is missing email claim")
 	}
-	return m.GetOrCreateUISubject(ctx, email.(string))
+	subject, err := m.GetOrCreateUISubject(r.Context(), email.(string))
+	return subject, credentials{sso: true}, err
 }
 
-func (m *middleware) validateBearer(ctx context.Context, bearer string) (internal.Subject, error) {
+func (m *middleware) authenticateBearer(r *http.Request) (internal.Subject, credentials, error) {
+	bearer := r.Header.Get("Authorization")
+	if bearer == "" {
+		return nil, credentials{}, nil
+	}
 	splitToken := strings.Split(bearer, "Bearer ")
 	if len(splitToken) != 2 {
-		return nil, fmt.Errorf("malformed bearer token")
+		return nil, credentials{}, fmt.Errorf("malformed bearer token")
 	}
 	token := splitToken[1]
 
 	if m.SiteToken != "" && m.SiteToken == token {
-		return m.SiteAdmin, nil
+		return m.SiteAdmin, credentials{}, nil
 	}
 	//
 	// parse jwt and verify signature
 	parsed, err := jwt.Parse([]byte(token), jwt.WithKey(jwa.HS256, m.key))
 	if err != nil {
-		return nil, err
+		return nil, credentials{}, err
 	}
 	kindClaim, ok := parsed.Get("kind")
 	if !ok {
-		return nil, fmt.Errorf("missing claim: kind")
+		return nil, credentials{}, fmt.Errorf("missing claim: kind")
 	}
 	kind := Kind(kindClaim.(string))
-	return m.GetSubject(ctx, kind, parsed.Subject())
+	if expiry := parsed.Expiration(); !expiry.IsZero() {
+		if warning, ok := expiryWarning(expiry, time.Now()); ok {
+			internal.AddWarning(r.Context(), "%s", warning)
+		}
+	}
+	subject, err := m.GetSubject(r.Context(), kind, parsed.Subject())
+	return subject, credentials{sso: hasSSOClaim(parsed), tokenID: parsed.Subject()}, err
 }
 
-func (m *middleware) validateUIRequest(ctx context.Context, w http.ResponseWriter, r *http.Request) (internal.Subject, bool) {
+// authenticateSession authenticates the session cookie of a request for a UI
+// endpoint.
+func (m *middleware) authenticateSession(r *http.Request) (internal.Subject, credentials, error) {
+	if !strings.HasPrefix(r.URL.Path, paths.UIPrefix) {
+		return nil, credentials{}, nil
+	}
 	cookie, err := r.Cookie(SessionCookie)
 	if err == http.ErrNoCookie {
-		html.FlashSuccess(w, "you need to login to access the requested page")
-		return nil, false
+		return nil, credentials{}, nil
 	}
 	// parse jwt from cookie and verify signature
 	token, err := jwt.Parse([]byte(cookie.Value), jwt.WithKey(jwa.HS256, m.key))
 	if err != nil {
 		if errors.Is(err, jwt.ErrTokenExpired()) {
-			html.FlashError(w, "session expired")
-		} else {
-			html.FlashError(w, "unable to verify session token: "+err.Error())
+			return nil, credentials{}, errors.New("session expired")
 		}
-		return nil, false
+		return nil, credentials{}, fmt.Errorf("unable to verify session token: %w", err)
 	}
-	user, err := m.GetOrCreateUISubject(ctx, token.Subject())
+	user, err := m.GetOrCreateUISubject(r.Context(), token.Subject())
 	if err != nil {
-		html.FlashError(w, "unable to find user: "+err.Error())
-		return nil, false
+		return nil, credentials{}, fmt.Errorf("unable to find user: %w", err)
+	}
+	return user, credentials{sso: hasSSOClaim(token)}, nil
+}
+
+// expiryWarning returns a warning if a token expires within the warning
+// period.
+func expiryWarning(expiry, now time.Time) (string, bool) {
+	remaining := expiry.Sub(now)
+	if remaining > expiryWarningPeriod {
+		return "", false
+	}
+	switch days := int(remaining.Hours() / 24); days {
+	case 0:
+		return "token expires in less than a day", true
+	case 1:
+		return "token expires in 1 day", true
+	default:
+		return fmt.Sprintf("token expires in %d days", days), true
 	}
-	return user, true
 }
 
 func isProtectedPath(path string) bool {
@@ -1,6 +1,8 @@
 package tokens
 
 import (
+	"context"
+	"errors"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -9,6 +11,7 @@ import (
 	"github.com/leg100/otf/internal"
 	"github.com/leg100/otf/internal/testutils"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestMiddleware(t *testing.T) {
@@ -53,6 +56,23 @@ func TestMiddleware(t *testing.T) {
 		assert.Equal(t, 200, w.Code, w.Body.String())
 	})
 
+	t.Run("add token ID to context", func(t *testing.T) {
+		f := &factory{key: newTestJWK(t, secret)}
+		token, err := f.NewToken(NewTokenOptions{
+			Kind:    Kind("test-kind"),
+			Subject: "ut-123",
+		})
+		require.NoError(t, err)
+		r := httptest.NewRequest("GET", "/api/v2/protected", nil)
+		r.Header.Add("Authorization", "Bearer "+string(token))
+		w := httptest.NewRecorder()
+		handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "ut-123", TokenIDFromContext(r.Context()))
+		})
+		fakeTokenMiddleware(t, secret)(handler).ServeHTTP(w, r)
+		assert.Equal(t, 200, w.Code, w.Body.String())
+	})
+
 	t.Run("invalid jwt", func(t *testing.T) {
 		differentSecret := testutils.NewSecret(t)
 		token := newTestJWT(t, differentSecret, Kind("test-kind"), time.Hour)
@@ -119,4 +139,125 @@ func TestMiddleware(t *testing.T) {
 		fakeIAPMiddleware(t, "https://invalid.com")(wantSubjectHandler(t, &internal.Superuser{})).ServeHTTP(w, r)
 		assert.Equal(t, 401, w.Code)
 	})
+
+	t.Run("registered authenticator", func(t *testing.T) {
+		mw, registry := fakeTokenMiddlewareWithRegistry(t, secret)
+		registry.RegisterAuthenticator(AuthenticatorFunc(func(r *http.Request) (internal.Subject, error) {
+			if r.Header.Get("X-Test-Auth") == "" {
+				return nil, nil
+			}
+			return &internal.Superuser{}, nil
+		}))
+
+		r := httptest.NewRequest("GET", "/api/v2/protected", nil)
+		r.Header.Add("X-Test-Auth", "true")
+		w := httptest.NewRecorder()
+		mw(wantSubjectHandler(t, &internal.Superuser{})).ServeHTTP(w, r)
+		assert.Equal(t, 200, w.Code)
+
+		r = httptest.NewRequest("GET", "/api/v2/protected", nil)
+		w = httptest.NewRecorder()
+		mw(emptyHandler).ServeHTTP(w, r)
+		assert.Equal(t, 401, w.Code)
+	})
+
+	t.Run("registered authenticator fails", func(t *testing.T) {
+		mw, registry := fakeTokenMiddlewareWithRegistry(t, secret)
+		registry.RegisterAuthenticator(AuthenticatorFunc(func(r *http.Request) (internal.Subject, error) {
+			return nil, errors.New("invalid credentials")
+		}))
+
+		r := httptest.NewRequest("GET", "/api/v2/protected", nil)
+		w := httptest.NewRecorder()
+		mw(emptyHandler).ServeHTTP(w, r)
+		assert.Equal(t, 401, w.Code)
+
+		r = httptest.NewRequest("GET", "/app/protected", nil)
+		w = httptest.NewRecorder()
+		mw(emptyHandler).ServeHTTP(w, r)
+		assert.Equal(t, 302, w.Code)
+	})
+
+	t.Run("sso enforcer rejects token not issued via sso", func(t *testing.T) {
+		mw, registry := fakeTokenMiddlewareWithRegistry(t, secret)
+		registry.RegisterSSOEnforcer(&fakeSSOEnforcer{err: errors.New("sso required")})
+
+		r := httptest.NewRequest("GET", "/api/v2/protected", nil)
+		token := newTestJWT(t, secret, Kind("test-kind"), time.Hour)
+		r.Header.Add("Authorization", "Bearer "+token)
+		w := httptest.NewRecorder()
+		mw(emptyHandler).ServeHTTP(w, r)
+		assert.Equal(t, 401, w.Code)
+		assert.Contains(t, w.Body.String(), "sso required")
+	})
+
+	t.Run("sso enforcer skipped for token issued via sso", func(t *testing.T) {
+		mw, registry := fakeTokenMiddlewareWithRegistry(t, secret)
+		registry.RegisterSSOEnforcer(&fakeSSOEnforcer{err: errors.New("sso required")})
+
+		r := httptest.NewRequest("GET", "/api/v2/protected", nil)
+		token := newTestJWT(t, secret, Kind("test-kind"), time.Hour, "sso", "true")
+		r.Header.Add("Authorization", "Bearer "+token)
+		w := httptest.NewRecorder()
+		mw(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.True(t, IsSSO(r.Context()))
+		})).ServeHTTP(w, r)
+		assert.Equal(t, 200, w.Code, w.Body.String())
+	})
+
+	t.Run("sso enforcer rejects session not started via sso", func(t *testing.T) {
+		mw, registry := fakeTokenMiddlewareWithRegistry(t, secret)
+		registry.RegisterSSOEnforcer(&fakeSSOEnforcer{err: errors.New("sso required")})
+
+		r := httptest.NewRequest("GET", "/app/protected", nil)
+		token := newTestJWT(t, secret, userSessionKind, time.Hour)
+		r.AddCookie(&http.Cookie{Name: SessionCookie, Value: token})
+		w := httptest.NewRecorder()
+		mw(emptyHandler).ServeHTTP(w, r)
+		assert.Equal(t, 302, w.Code)
+	})
+
+	t.Run("built-in authenticators take precedence", func(t *testing.T) {
+		mw, registry := fakeTokenMiddlewareWithRegistry(t, secret)
+		registry.RegisterAuthenticator(AuthenticatorFunc(func(r *http.Request) (internal.Subject, error) {
+			return nil, errors.New("should not be called")
+		}))
+
+		r := httptest.NewRequest("GET", "/api/v2/protected", nil)
+		token := newTestJWT(t, secret, Kind("test-kind"), time.Hour)
+		r.Header.Add("Authorization", "Bearer "+token)
+		w := httptest.NewRecorder()
+		mw(wantSubjectHandler(t, &internal.Superuser{})).ServeHTTP(w, r)
+		assert.Equal(t, 200, w.Code, w.Body.String())
+	})
+}
+
+func TestExpiryWarning(t *testing.T) {
+	now := time.Now()
+
+	tests := []struct {
+		name   string
+		expiry time.Time
+		want   string
+	}{
+		{"not expiring soon", now.Add(30 * 24 * time.Hour), ""},
+		{"expires in days", now.Add(7*24*time.Hour - time.Minute), "token expires in 6 days"},
+		{"expires in 1 day", now.Add(36 * time.Hour), "token expires in 1 day"},
+		{"expires in hours", now.Add(time.Hour), "token expires in less than a day"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := expiryWarning(tt.expiry, now)
+			assert.Equal(t, tt.want != "", ok)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+type fakeSSOEnforcer struct {
+	err error
+}
+
+func (f *fakeSSOEnforcer) EnforceSSO(context.Context, internal.Subject) error {
+	return f.err
 }
@@ -48,21 +48,31 @@ func getGoogleCredentialsPath(t *testing.T) string {
 func fakeTokenMiddleware(t *testing.T, secret []byte) mux.MiddlewareFunc {
 	t.Helper()
 
+	mw, _ := fakeTokenMiddlewareWithRegistry(t, secret)
+	return mw
+}
+
+// fakeTokenMiddlewareWithRegistry returns middleware along with its registry,
+// permitting a test to register further authenticators.
+func fakeTokenMiddlewareWithRegistry(t *testing.T, secret []byte) (mux.MiddlewareFunc, *registry) {
+	t.Helper()
+
 	key := newTestJWK(t, secret)
-	return newMiddleware(middlewareOptions{
-		Logger: logr.Discard(),
-		key:    key,
-		registry: &registry{
-			kinds: map[Kind]SubjectGetter{
-				"test-kind": func(context.Context, string) (internal.Subject, error) {
-					return &internal.Superuser{}, nil
-				},
-			},
-			uiSubjectGetterOrCreator: func(context.Context, string) (internal.Subject, error) {
+	registry := &registry{
+		kinds: map[Kind]SubjectGetter{
+			"test-kind": func(context.Context, string) (internal.Subject, error) {
 				return &internal.Superuser{}, nil
 			},
 		},
-	})
+		uiSubjectGetterOrCreator: func(context.Context, string) (internal.Subject, error) {
+			return &internal.Superuser{}, nil
+		},
+	}
+	return newMiddleware(middlewareOptions{
+		Logger:   logr.Discard(),
+		key:      key,
+		registry: registry,
+	}), registry
 }
 
 func fakeSiteTokenMiddleware(t *testing.T, token string) mux.MiddlewareFunc {
@@ -3,6 +3,7 @@ package tokens
 import (
 	"context"
 	"fmt"
+	"net/http"
 	"sync"
 
 	"github.com/leg100/otf/internal"
@@ -21,10 +22,30 @@ type registry struct {
 	SiteAdmin internal.Subject
 
 	kinds                    map[Kind]SubjectGetter
+	authenticators           []Authenticator
+	ssoEnforcers             []SSOEnforcer
 	mu                       sync.Mutex
 	uiSubjectGetterOrCreator UISubjectGetterOrCreator
 }
 
+type (
+	// Authenticator authenticates a request using a particular method, e.g.
+	// a bearer token or a client certificate, returning the authenticated
+	// subject. If the request carries no credentials for the method then it
+	// returns a nil subject and a nil error, and the next authenticator in the
+	// chain is tried.
+	Authenticator interface {
+		Authenticate(r *http.Request) (internal.Subject, error)
+	}
+
+	// AuthenticatorFunc permits using a func as an Authenticator.
+	AuthenticatorFunc func(r *http.Request) (internal.Subject, error)
+)
+
+func (f AuthenticatorFunc) Authenticate(r *http.Request) (internal.Subject, error) {
+	return f(r)
+}
+
 // SubjectGetter retrieves an OTF subject given the jwtSubject string, which is the
 // value of the 'subject' field parsed from a JWT.
 type SubjectGetter func(ctx context.Context, jwtSubject string) (internal.Subject, error)
@@ -52,6 +73,23 @@ func (r *registry) GetSubject(ctx context.Context, k Kind, jwtSubject string) (i
 	return subjectGetter(ctx, jwtSubject)
 }
 
+// RegisterAuthenticator appends an authenticator to the chain of
+// authenticators tried by the authentication middleware. Authenticators are
+// tried in the order in which they are registered, after the built-in
+// authenticators.
+func (r *registry) RegisterAuthenticator(a Authenticator) {
+	r.mu.Lock()
+	r.authenticators = append(r.authenticators, a)
+	r.mu.Unlock()
+}
+
+func (r *registry) getAuthenticators() []Authenticator {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.authenticators
+}
+
 // RegisterSiteToken registers a site token which the middleware, and the
 // subject to return as the site admin upon successful authentication.
 func (r *registry) RegisterSiteToken(token string, siteAdmin internal.Subject) {
@@ -20,6 +20,8 @@ type (
 	StartSessionOptions struct {
 		Username *string
 		Expiry   *time.Time
+		// SSO is true if the user logged in via single sign-on.
+		SSO bool
 	}
 
 	// sessionFactory constructs new sessions.
@@ -29,11 +31,19 @@ type (
 )
 
 func (f *sessionFactory) NewSessionToken(username string, expiry time.Time) (string, error) {
-	token, err := f.NewToken(NewTokenOptions{
+	return f.newSessionToken(username, expiry, false)
+}
+
+func (f *sessionFactory) newSessionToken(username string, expiry time.Time, sso bool) (string, error) {
+	opts := NewTokenOptions{
 		Subject: username,
 		Kind:    userSessionKind,
 		Expiry:  &expiry,
-	})
+	}
+	if sso {
+		opts.Claims = map[string]string{ssoClaim: "true"}
+	}
+	token, err := f.NewToken(opts)
 	if err != nil {
 		return "", err
 	}
@@ -48,7 +58,7 @@ func (a *Service) StartSession(w http.ResponseWriter, r *http.Request, opts Star
 	if opts.Expiry != nil {
 		expiry = *opts.Expiry
 	}
-	token, err := a.NewSessionToken(*opts.Username, expiry)
+	token, err := a.newSessionToken(*opts.Username, expiry, opts.SSO)
 	if err != nil {
 		return err
 	}
@@ -56,7 +66,7 @@ func (a *Service) StartSession(w http.ResponseWriter, r *http.Request, opts Star
 	html.SetCookie(w, SessionCookie, string(token), internal.Time(expiry))
 	html.ReturnUserOriginalPage(w, r)
 
-	a.V(2).Info("started session", "username", *opts.Username)
+	a.V(2).Info("started session", "username", *opts.Username, "sso", opts.SSO)
 
 	return nil
 }
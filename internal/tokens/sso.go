@@ -0,0 +1,73 @@
+package tokens
+
+import (
+	"context"
+
+	"github.com/leg100/otf/internal"
+	"github.com/lestrrat-go/jwx/v2/jwt"
+)
+
+// ssoClaim is the JWT claim marking a token as issued via single sign-on,
+// i.e. it is a session started by logging in with the OIDC identity provider,
+// or a token created during such a session.
+const ssoClaim = "sso"
+
+type (
+	// SSOEnforcer determines whether a subject is permitted to authenticate
+	// without single sign-on, returning an error if not.
+	SSOEnforcer interface {
+		EnforceSSO(ctx context.Context, subject internal.Subject) error
+	}
+
+	ssoContextKey struct{}
+)
+
+// RegisterSSOEnforcer registers an enforcer with the authentication
+// middleware, which consults it for every request authenticated with
+// credentials not issued via single sign-on.
+func (r *registry) RegisterSSOEnforcer(e SSOEnforcer) {
+	r.mu.Lock()
+	r.ssoEnforcers = append(r.ssoEnforcers, e)
+	r.mu.Unlock()
+}
+
+func (r *registry) enforceSSO(ctx context.Context, subject internal.Subject) error {
+	r.mu.Lock()
+	enforcers := r.ssoEnforcers
+	r.mu.Unlock()
+
+	for _, e := range enforcers {
+		if err := e.EnforceSSO(ctx, subject); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// SSOClaims returns the claims to add to a token issued to the subject in the
+// context, marking the token as issued via single sign-on if the subject
+// authenticated via single sign-on.
+func SSOClaims(ctx context.Context) map[string]string {
+	if !IsSSO(ctx) {
+		return nil
+	}
+	return map[string]string{ssoClaim: "true"}
+}
+
+// IsSSO determines whether the subject in the context authenticated with
+// credentials issued via single sign-on.
+func IsSSO(ctx context.Context) bool {
+	sso, _ := ctx.Value(ssoContextKey{}).(bool)
+	return sso
+}
+
+// AddSSOToContext marks the subject in the context as having authenticated
+// via single sign-on.
+func AddSSOToContext(ctx context.Context) context.Context {
+	return context.WithValue(ctx, ssoContextKey{}, true)
+}
+
+func hasSSOClaim(token jwt.Token) bool {
+	v, ok := token.Get(ssoClaim)
+	return ok && v == "true"
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 
 	"github.com/jackc/pgtype"
+	"github.com/leg100/otf/internal"
 	"github.com/leg100/otf/internal/logr"
 	"github.com/leg100/otf/internal/sql"
 	"github.com/leg100/otf/internal/sql/pggen"
@@ -35,6 +36,24 @@ func (result dbresult) toUser() *User {
 	return &user
 }
 
+// membershipresult represents the result of a database query for an
+// organization membership.
+type membershipresult struct {
+	OrganizationMembershipID pgtype.Text        `json:"organization_membership_id"`
+	CreatedAt                pgtype.Timestamptz `json:"created_at"`
+	OrganizationName         pgtype.Text        `json:"organization_name"`
+	Username                 pgtype.Text        `json:"username"`
+}
+
+func (result membershipresult) toMembership() *OrganizationMembership {
+	return &OrganizationMembership{
+		ID:           result.OrganizationMembershipID.String,
+		CreatedAt:    result.CreatedAt.Time.UTC(),
+		Organization: result.OrganizationName.String,
+		Username:     result.Username.String,
+	}
+}
+
 // pgdb stores user resources in a postgres database
 type pgdb struct {
 	*sql.DB // provides access to generated SQL queries
@@ -58,6 +77,10 @@ func (db *pgdb) CreateUser(ctx context.Context, user *User) error {
 			if err != nil {
 				return sql.Error(err)
 			}
+			_, err = db.createOrganizationMembership(ctx, newOrganizationMembership(team.Organization, user.Username))
+			if err != nil {
+				return err
+			}
 		}
 		return nil
 	})
@@ -105,7 +128,7 @@ func (db *pgdb) getUser(ctx context.Context, spec UserSpec) (*User, error) {
 	if spec.UserID != nil {
 		result, err := db.Conn(ctx).FindUserByID(ctx, sql.String(*spec.UserID))
 		if err != nil {
-			return nil, err
+			return nil, sql.Error(err)
 		}
 		return dbresult(result).toUser(), nil
 	} else if spec.Username != nil {
@@ -141,6 +164,57 @@ func (db *pgdb) removeTeamMembership(ctx context.Context, teamID string, usernam
 	return nil
 }
 
+// createOrganizationMembership persists an organization membership, returning
+// the existing membership if the user is already a member.
+func (db *pgdb) createOrganizationMembership(ctx context.Context, membership *OrganizationMembership) (*OrganizationMembership, error) {
+	row, err := db.Conn(ctx).InsertOrganizationMembership(ctx, pggen.InsertOrganizationMembershipParams{
+		OrganizationMembershipID: sql.String(membership.ID),
+		CreatedAt:                sql.Timestamptz(membership.CreatedAt),
+		OrganizationName:         sql.String(membership.Organization),
+		Username:                 sql.String(membership.Username),
+	})
+	if err != nil {
+		return nil, sql.Error(err)
+	}
+	return membershipresult(row).toMembership(), nil
+}
+
+func (db *pgdb) getOrganizationMembership(ctx context.Context, membershipID string) (*OrganizationMembership, error) {
+	row, err := db.Conn(ctx).FindOrganizationMembershipByID(ctx, sql.String(membershipID))
+	if err != nil {
+		return nil, sql.Error(err)
+	}
+	return membershipresult(row).toMembership(), nil
+}
+
+func (db *pgdb) listOrganizationMemberships(ctx context.Context, organization string) ([]*OrganizationMembership, error) {
+	rows, err := db.Conn(ctx).FindOrganizationMembershipsByOrganization(ctx, sql.String(organization))
+	if err != nil {
+		return nil, sql.Error(err)
+	}
+	memberships := make([]*OrganizationMembership, len(rows))
+	for i, r := range rows {
+		memberships[i] = membershipresult(r).toMembership()
+	}
+	return memberships, nil
+}
+
+// deleteOrganizationMembership deletes an organization membership along with
+// the user's membership of the organization's teams.
+func (db *pgdb) deleteOrganizationMembership(ctx context.Context, membership *OrganizationMembership) error {
+	return db.Tx(ctx, func(ctx context.Context, q pggen.Querier) error {
+		_, err := q.DeleteTeamMembershipsByOrganization(ctx, sql.String(membership.Organization), sql.String(membership.Username))
+		if err != nil {
+			return sql.Error(err)
+		}
+		_, err = q.DeleteOrganizationMembership(ctx, sql.String(membership.ID))
+		if err != nil {
+			return sql.Error(err)
+		}
+		return nil
+	})
+}
+
 // DeleteUser deletes a user from the DB.
 func (db *pgdb) DeleteUser(ctx context.Context, spec UserSpec) error {
 	if spec.UserID != nil {
@@ -206,10 +280,12 @@ func pgtextSliceDiff(a, b []pgtype.Text) []string {
 
 func (db *pgdb) createUserToken(ctx context.Context, token *UserToken) error {
 	_, err := db.Conn(ctx).InsertToken(ctx, pggen.InsertTokenParams{
-		TokenID:     sql.String(token.ID),
-		Description: sql.String(token.Description),
-		Username:    sql.String(token.Username),
-		CreatedAt:   sql.Timestamptz(token.CreatedAt),
+		TokenID:          sql.String(token.ID),
+		Description:      sql.String(token.Description),
+		Username:         sql.String(token.Username),
+		CreatedAt:        sql.Timestamptz(token.CreatedAt),
+		OrganizationName: sql.StringPtr(token.Organization),
+		Scopes:           token.Scopes,
 	})
 	return err
 }
@@ -226,6 +302,10 @@ func (db *pgdb) listUserTokens(ctx context.Context, username string) ([]*UserTok
 			CreatedAt:   row.CreatedAt.Time.UTC(),
 			Description: row.Description.String,
 			Username:    row.Username.String,
+			Scopes:      row.Scopes,
+		}
+		if row.OrganizationName.Status == pgtype.Present {
+			tokens[i].Organization = &row.OrganizationName.String
 		}
 	}
 	return tokens, nil
@@ -236,12 +316,17 @@ func (db *pgdb) getUserToken(ctx context.Context, id string) (*UserToken, error)
 	if err != nil {
 		return nil, sql.Error(err)
 	}
-	return &UserToken{
+	token := &UserToken{
 		ID:          row.TokenID.String,
 		CreatedAt:   row.CreatedAt.Time.UTC(),
 		Description: row.Description.String,
 		Username:    row.Username.String,
-	}, nil
+		Scopes:      row.Scopes,
+	}
+	if row.OrganizationName.Status == pgtype.Present {
+		token.Organization = &row.OrganizationName.String
+	}
+	return token, nil
 }
 
 func (db *pgdb) deleteUserToken(ctx context.Context, id string) error {
@@ -251,3 +336,46 @@ func (db *pgdb) deleteUserToken(ctx context.Context, id string) error {
 	}
 	return nil
 }
+
+//
+// Recovery token functions
+//
+
+// createRecoveryToken persists the hash of a recovery token, invalidating any
+// other unused recovery tokens.
+func (db *pgdb) createRecoveryToken(ctx context.Context, hash string) error {
+	return db.Tx(ctx, func(ctx context.Context, q pggen.Querier) error {
+		if _, err := q.DeleteUnusedRecoveryTokens(ctx); err != nil {
+			return sql.Error(err)
+		}
+		_, err := q.InsertRecoveryToken(ctx, sql.String(hash), sql.Timestamptz(internal.CurrentTimestamp(nil)))
+		return sql.Error(err)
+	})
+}
+
+// recoveryTokenExists determines whether a recovery token with the given hash
+// has been persisted, regardless of whether it has been used.
+func (db *pgdb) recoveryTokenExists(ctx context.Context, hash string) (bool, error) {
+	_, err := db.Conn(ctx).FindRecoveryTokenByHash(ctx, sql.String(hash))
+	if err := sql.Error(err); err == internal.ErrResourceNotFound {
+		return false, nil
+	} else if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func (db *pgdb) countRecoveryTokens(ctx context.Context) (int, error) {
+	count, err := db.Conn(ctx).CountRecoveryTokens(ctx)
+	if err != nil {
+		return 0, sql.Error(err)
+	}
+	return int(count.Int), nil
+}
+
+// useRecoveryToken marks an unused recovery token with the given hash as used.
+// Returns internal.ErrResourceNotFound if there is no such unused token.
+func (db *pgdb) useRecoveryToken(ctx context.Context, hash string) error {
+	_, err := db.Conn(ctx).UseRecoveryToken(ctx, sql.Timestamptz(internal.CurrentTimestamp(nil)), sql.String(hash))
+	return sql.Error(err)
+}
@@ -0,0 +1,193 @@
+package user
+
+import (
+	"context"
+	"errors"
+	"net/mail"
+	"time"
+
+	"github.com/leg100/otf/internal"
+	"github.com/leg100/otf/internal/http/html/paths"
+	"github.com/leg100/otf/internal/rbac"
+	"github.com/leg100/otf/internal/resource"
+	"github.com/leg100/otf/internal/sql/pggen"
+)
+
+type (
+	// OrganizationMembership is a user's membership of an organization. A
+	// user becomes a member either explicitly, or implicitly upon being added
+	// to one of the organization's teams.
+	OrganizationMembership struct {
+		ID           string
+		CreatedAt    time.Time
+		Organization string
+		Username     string
+	}
+
+	// invitationSender sends invitations to users added to an organization.
+	invitationSender interface {
+		Send(ctx context.Context, to []string, tmpl string, data any) error
+	}
+
+	CreateOrganizationMembershipOptions struct {
+		// Username of user to add to organization. If the user does not exist
+		// then it is created.
+		Username string
+		// TeamIDs are IDs of teams in the organization to add the user to.
+		TeamIDs []string
+	}
+)
+
+func newOrganizationMembership(organization, username string) *OrganizationMembership {
+	return &OrganizationMembership{
+		ID:           resource.NewID(resource.OrganizationMembershipKind),
+		CreatedAt:    internal.CurrentTimestamp(nil),
+		Organization: organization,
+		Username:     username,
+	}
+}
+
+// CreateOrganizationMembership adds a user to an organization, and optionally
+// to teams within the organization. If the user is already a member then the
+// existing membership is returned.
+func (a *Service) CreateOrganizationMembership(ctx context.Context, organization string, opts CreateOrganizationMembershipOptions) (*OrganizationMembership, error) {
+	subject, err := a.organization.CanAccess(ctx, rbac.CreateOrganizationMembershipAction, organization)
+	if err != nil {
+		return nil, err
+	}
+	if opts.Username == "" {
+		return nil, &internal.MissingParameterError{Parameter: "email"}
+	}
+	// check teams belong to the organization before making any changes
+	for _, teamID := range opts.TeamIDs {
+		team, err := a.teams.GetByID(ctx, teamID)
+		if err != nil {
+			return nil, err
+		}
+		if team.Organization != organization {
+			return nil, internal.ErrResourceNotFound
+		}
+	}
+
+	var (
+		created    = newOrganizationMembership(organization, opts.Username)
+		membership *OrganizationMembership
+	)
+	err = a.db.Tx(ctx, func(ctx context.Context, _ pggen.Querier) error {
+		_, err := a.db.getUser(ctx, UserSpec{Username: &opts.Username})
+		if errors.Is(err, internal.ErrResourceNotFound) {
+			if _, err := a.Create(ctx, opts.Username); err != nil {
+				return err
+			}
+		} else if err != nil {
+			return err
+		}
+		membership, err = a.db.createOrganizationMembership(ctx, created)
+		if err != nil {
+			return err
+		}
+		for _, teamID := range opts.TeamIDs {
+			if err := a.db.addTeamMembership(ctx, teamID, opts.Username); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		a.Error(err, "creating organization membership", "organization", organization, "user", opts.Username, "subject", subject)
+		return nil, err
+	}
+	a.V(0).Info("created organization membership", "membership", membership.ID, "organization", organization, "user", opts.Username, "subject", subject)
+
+	if membership.ID == created.ID {
+		a.sendInvitation(ctx, membership, subject)
+	}
+	return membership, nil
+}
+
+// sendInvitation emails an invitation to a user newly added to an
+// organization, if their username is an email address. Failure to send the
+// invitation is logged but otherwise ignored.
+func (a *Service) sendInvitation(ctx context.Context, membership *OrganizationMembership, invitedBy internal.Subject) {
+	if a.emails == nil {
+		return
+	}
+	if _, err := mail.ParseAddress(membership.Username); err != nil {
+		// not an email address
+		return
+	}
+	err := a.emails.Send(ctx, []string{membership.Username}, "invitation", struct {
+		Organization string
+		InvitedBy    string
+		URL          string
+	}{
+		Organization: membership.Organization,
+		InvitedBy:    invitedBy.String(),
+		URL:          a.system.URL(paths.Organization(membership.Organization)),
+	})
+	if err != nil {
+		a.Error(err, "sending invitation", "membership", membership.ID, "user", membership.Username)
+	}
+}
+
+func (a *Service) GetOrganizationMembership(ctx context.Context, membershipID string) (*OrganizationMembership, error) {
+	membership, err := a.db.getOrganizationMembership(ctx, membershipID)
+	if err != nil {
+		a.Error(err, "retrieving organization membership", "membership", membershipID)
+		return nil, err
+	}
+	subject, err := a.organization.CanAccess(ctx, rbac.GetOrganizationMembershipAction, membership.Organization)
+	if err != nil {
+		return nil, err
+	}
+	a.V(9).Info("retrieved organization membership", "membership", membershipID, "subject", subject)
+	return membership, nil
+}
+
+func (a *Service) ListOrganizationMemberships(ctx context.Context, organization string) ([]*OrganizationMembership, error) {
+	subject, err := a.organization.CanAccess(ctx, rbac.ListOrganizationMembershipsAction, organization)
+	if err != nil {
+		return nil, err
+	}
+	memberships, err := a.db.listOrganizationMemberships(ctx, organization)
+	if err != nil {
+		a.Error(err, "listing organization memberships", "organization", organization, "subject", subject)
+		return nil, err
+	}
+	a.V(9).Info("listed organization memberships", "organization", organization, "count", len(memberships), "subject", subject)
+	return memberships, nil
+}
+
+// DeleteOrganizationMembership removes a user from an organization, along
+// with their membership of the organization's teams.
+func (a *Service) DeleteOrganizationMembership(ctx context.Context, membershipID string) error {
+	membership, err := a.db.getOrganizationMembership(ctx, membershipID)
+	if err != nil {
+		a.Error(err, "retrieving organization membership", "membership", membershipID)
+		return err
+	}
+	subject, err := a.organization.CanAccess(ctx, rbac.DeleteOrganizationMembershipAction, membership.Organization)
+	if err != nil {
+		return err
+	}
+
+	// refuse to remove the last owner of the organization
+	owners, err := a.teams.Get(ctx, membership.Organization, "owners")
+	if err != nil {
+		return err
+	}
+	members, err := a.ListTeamUsers(ctx, owners.ID)
+	if err != nil {
+		return err
+	}
+	if len(members) == 1 && members[0].Username == membership.Username {
+		return ErrCannotDeleteOnlyOwner
+	}
+
+	if err := a.db.deleteOrganizationMembership(ctx, membership); err != nil {
+		a.Error(err, "deleting organization membership", "membership", membershipID, "subject", subject)
+		return err
+	}
+	a.V(0).Info("deleted organization membership", "membership", membershipID, "organization", membership.Organization, "user", membership.Username, "subject", subject)
+	return nil
+}
@@ -0,0 +1,88 @@
+package user
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+
+	"github.com/leg100/otf/internal"
+)
+
+// InitRecoveryToken initializes a one-time recovery token for logging in as the
+// site admin. A token can be supplied by the administrator, permitting them to
+// regain access should all site admins be locked out. Otherwise, upon the first
+// start of a new installation, a token is generated and printed to the log,
+// permitting the administrator to bootstrap the first users and organizations.
+//
+// Only the hash of the token is persisted and a token can only be used once.
+// Supplying the same token on successive starts has no effect once it has been
+// used.
+func (a *Service) InitRecoveryToken(ctx context.Context, token string) error {
+	if token != "" {
+		hash := hashRecoveryToken(token)
+		exists, err := a.db.recoveryTokenExists(ctx, hash)
+		if err != nil {
+			a.Error(err, "checking recovery token")
+			return err
+		}
+		if exists {
+			a.V(1).Info("skipping recovery token: token already registered")
+			return nil
+		}
+		if err := a.db.createRecoveryToken(ctx, hash); err != nil {
+			a.Error(err, "registering recovery token")
+			return err
+		}
+		a.V(0).Info("registered recovery token")
+		return nil
+	}
+	// Only generate a token on first start, i.e. when no token has ever been
+	// generated and no users besides the built-in site admin exist.
+	count, err := a.db.countRecoveryTokens(ctx)
+	if err != nil {
+		a.Error(err, "counting recovery tokens")
+		return err
+	}
+	if count > 0 {
+		return nil
+	}
+	users, err := a.db.listUsers(ctx)
+	if err != nil {
+		a.Error(err, "listing users")
+		return err
+	}
+	for _, u := range users {
+		if u.ID != SiteAdminID {
+			return nil
+		}
+	}
+	token, err = internal.GenerateToken()
+	if err != nil {
+		return err
+	}
+	if err := a.db.createRecoveryToken(ctx, hashRecoveryToken(token)); err != nil {
+		a.Error(err, "generating recovery token")
+		return err
+	}
+	a.V(0).Info("generated one-time recovery token: use it to login as site admin", "token", token)
+	return nil
+}
+
+// UseRecoveryToken uses a recovery token, invalidating it so that it cannot be
+// used again. Returns internal.ErrResourceNotFound if the token is unknown or
+// has already been used.
+func (a *Service) UseRecoveryToken(ctx context.Context, token string) error {
+	if err := a.db.useRecoveryToken(ctx, hashRecoveryToken(token)); err != nil {
+		if err != internal.ErrResourceNotFound {
+			a.Error(err, "using recovery token")
+		}
+		return err
+	}
+	a.V(0).Info("used recovery token")
+	return nil
+}
+
+func hashRecoveryToken(token string) string {
+	hash := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(hash[:])
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"slices"
 
 	"github.com/gorilla/mux"
 	"github.com/leg100/otf/internal"
@@ -18,7 +19,15 @@ import (
 	"github.com/leg100/otf/internal/tokens"
 )
 
-var ErrCannotDeleteOnlyOwner = errors.New("cannot remove the last owner")
+var (
+	ErrCannotDeleteOnlyOwner = errors.New("cannot remove the last owner")
+
+	// ErrUsernameImmutable is returned when attempting to change a username,
+	// which is set by the identity provider, or by the administrator when
+	// creating the user, and is used to match subsequent logins to the
+	// user.
+	ErrUsernameImmutable = errors.New("username cannot be changed")
+)
 
 type (
 	Service struct {
@@ -27,6 +36,8 @@ type (
 		site         internal.Authorizer // authorizes site access
 		organization internal.Authorizer // authorizes org access
 		teams        *team.Service
+		emails       invitationSender
+		system       *internal.HostnameService
 
 		db     *pgdb
 		web    *webHandlers
@@ -40,7 +51,10 @@ type (
 		SiteToken     string
 		TokensService *tokens.Service
 		TeamService   *team.Service
+		// EmailService sends invitations to users added to organizations.
+		EmailService invitationSender
 
+		*internal.HostnameService
 		*sql.DB
 		*tfeapi.Responder
 		html.Renderer
@@ -57,7 +71,9 @@ func NewService(opts Options) *Service {
 		userTokenFactory: &userTokenFactory{
 			tokens: opts.TokensService,
 		},
-		teams: opts.TeamService,
+		teams:  opts.TeamService,
+		emails: opts.EmailService,
+		system: opts.HostnameService,
 	}
 	svc.web = &webHandlers{
 		Renderer:  opts.Renderer,
@@ -101,8 +117,15 @@ func NewService(opts Options) *Service {
 	// Register with auth middleware the user token kind and a means of
 	// retrieving user corresponding to token.
 	opts.TokensService.RegisterKind(UserTokenKind, func(ctx context.Context, tokenID string) (internal.Subject, error) {
-		return svc.GetUser(ctx, UserSpec{AuthenticationTokenID: internal.String(tokenID)})
-
+		user, err := svc.GetUser(ctx, UserSpec{AuthenticationTokenID: internal.String(tokenID)})
+		if err != nil {
+			return nil, err
+		}
+		token, err := svc.db.getUserToken(ctx, tokenID)
+		if err != nil {
+			return nil, err
+		}
+		return user.restrict(token), nil
 	})
 	// Register with auth middleware the ability to get or create a user given a
 	// username.
@@ -159,6 +182,67 @@ func (a *Service) GetUser(ctx context.Context, spec UserSpec) (*User, error) {
 	return user, nil
 }
 
+// ResolveAlias retrieves the ID of a user by their alias, their username.
+func (a *Service) ResolveAlias(ctx context.Context, username string) (string, error) {
+	user, err := a.GetUser(ctx, UserSpec{Username: &username})
+	if err != nil {
+		return "", err
+	}
+	return user.ID, nil
+}
+
+// GetUserByID retrieves a user by ID. Besides a user retrieving their own
+// account, a user can retrieve the account of a user with whom they share an
+// organization.
+func (a *Service) GetUserByID(ctx context.Context, userID string) (*User, error) {
+	subject, err := internal.SubjectFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := a.db.getUser(ctx, UserSpec{UserID: &userID})
+	if err != nil {
+		a.Error(err, "retrieving user", "id", userID, "subject", subject)
+		return nil, err
+	}
+
+	if !canViewUser(subject, user) {
+		a.Error(nil, "unauthorized action", "action", rbac.GetUserAction, "id", userID, "subject", subject)
+		return nil, internal.ErrAccessNotPermitted
+	}
+
+	a.V(9).Info("retrieved user", "username", user.Username, "subject", subject)
+
+	return user, nil
+}
+
+// UpdateCurrentUser updates the account of the user making the request.
+func (a *Service) UpdateCurrentUser(ctx context.Context, opts UpdateUserOptions) (*User, error) {
+	user, err := UserFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if opts.Username != nil && *opts.Username != user.Username {
+		return nil, ErrUsernameImmutable
+	}
+	return user, nil
+}
+
+func canViewUser(subject internal.Subject, user *User) bool {
+	if subject.CanAccessSite(rbac.GetUserAction) {
+		return true
+	}
+	if current, ok := subject.(*User); ok && current.ID == user.ID {
+		return true
+	}
+	for _, org := range user.Organizations() {
+		if subject.CanAccessOrganization(rbac.GetUserAction, org) {
+			return true
+		}
+	}
+	return false
+}
+
 // List lists all users.
 func (a *Service) List(ctx context.Context) ([]*User, error) {
 	_, err := a.site.CanAccess(ctx, rbac.ListUsersAction, "")
@@ -249,6 +333,13 @@ func (a *Service) AddTeamMembership(ctx context.Context, teamID string, username
 		if err := a.db.addTeamMembership(ctx, teamID, usernames...); err != nil {
 			return err
 		}
+		// team members are members of the team's organization too
+		for _, username := range usernames {
+			membership := newOrganizationMembership(team.Organization, username)
+			if _, err := a.db.createOrganizationMembership(ctx, membership); err != nil {
+				return err
+			}
+		}
 		return nil
 	})
 	if err != nil {
@@ -317,14 +408,32 @@ func (a *Service) SetSiteAdmins(ctx context.Context, usernames ...string) error
 // User API token endpoints
 
 // CreateToken creates a user token. Only users can create a user token, and
-// they can only create a token for themselves.
+// they can only create a token for themselves. A token can only be restricted
+// to an organization of which the user is a member. A user that authenticated
+// with a restricted token cannot create further tokens.
 func (a *Service) CreateToken(ctx context.Context, opts CreateUserTokenOptions) (*UserToken, []byte, error) {
 	user, err := UserFromContext(ctx)
 	if err != nil {
 		return nil, nil, err
 	}
+	if user.IsRestricted() {
+		return nil, nil, internal.ErrAccessNotPermitted
+	}
+	if opts.Organization != nil && *opts.Organization != "" {
+		// retrieve user afresh from the database, because the user in the
+		// context is not guaranteed to include their team memberships.
+		member, err := a.db.getUser(ctx, UserSpec{Username: &user.Username})
+		if err != nil {
+			a.Error(err, "retrieving user", "user", user)
+			return nil, nil, err
+		}
+		if !member.IsSiteAdmin() && !slices.Contains(member.Organizations(), *opts.Organization) {
+			a.Error(nil, "restricting token to organization of which user is not a member", "organization", *opts.Organization, "user", user)
+			return nil, nil, internal.ErrAccessNotPermitted
+		}
+	}
 
-	ut, token, err := a.NewUserToken(user.Username, opts)
+	ut, token, err := a.NewUserToken(ctx, user.Username, opts)
 	if err != nil {
 		a.Error(err, "constructing user token", "user", user)
 		return nil, nil, err
@@ -335,7 +444,7 @@ func (a *Service) CreateToken(ctx context.Context, opts CreateUserTokenOptions)
 		return nil, nil, err
 	}
 
-	a.V(1).Info("created user token", "user", user)
+	a.V(1).Info("created user token", "user", user, "organization", ut.Organization, "scopes", ut.Scopes)
 
 	return ut, token, nil
 }
@@ -354,6 +463,9 @@ func (a *Service) DeleteToken(ctx context.Context, tokenID string) error {
 	if err != nil {
 		return err
 	}
+	if user.IsRestricted() {
+		return internal.ErrAccessNotPermitted
+	}
 
 	token, err := a.db.getUserToken(ctx, tokenID)
 	if err != nil {
@@ -3,13 +3,15 @@ package user
 import (
 	"context"
 
+	"github.com/leg100/otf/internal"
 	"github.com/leg100/otf/internal/team"
 )
 
 type fakeService struct {
-	user  *User
-	token []byte
-	ut    *UserToken
+	user          *User
+	token         []byte
+	ut            *UserToken
+	recoveryToken string
 
 	*Service
 }
@@ -50,6 +52,15 @@ func (f *fakeService) DeleteToken(context.Context, string) error {
 	return nil
 }
 
+func (f *fakeService) UseRecoveryToken(ctx context.Context, token string) error {
+	if f.recoveryToken == "" || token != f.recoveryToken {
+		return internal.ErrResourceNotFound
+	}
+	// token can only be used once
+	f.recoveryToken = ""
+	return nil
+}
+
 type fakeTeamService struct {
 	team *team.Team
 }
@@ -2,12 +2,16 @@ package user
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/http"
+	"slices"
+	"strings"
 
 	"github.com/gorilla/mux"
 	"github.com/leg100/otf/internal"
 	"github.com/leg100/otf/internal/http/decode"
+	"github.com/leg100/otf/internal/resource"
 	"github.com/leg100/otf/internal/tfeapi"
 	"github.com/leg100/otf/internal/tfeapi/types"
 )
@@ -30,6 +34,8 @@ func (a *tfe) addHandlers(r *mux.Router) {
 	r = r.PathPrefix(tfeapi.APIPrefixV2).Subrouter()
 
 	r.HandleFunc("/account/details", a.getCurrentUser).Methods("GET")
+	r.HandleFunc("/account/update", a.updateCurrentUser).Methods("PATCH")
+	r.HandleFunc("/users/{user_id}", a.getUser).Methods("GET")
 	r.HandleFunc("/teams/{team_id}/memberships/{username}", a.addTeamMembership).Methods("POST")
 	r.HandleFunc("/teams/{team_id}/memberships/{username}", a.removeTeamMembership).Methods("DELETE")
 
@@ -37,13 +43,10 @@ func (a *tfe) addHandlers(r *mux.Router) {
 	r.HandleFunc("/teams/{team_id}/relationships/users", a.addTeamMembers).Methods("POST")
 	r.HandleFunc("/teams/{team_id}/relationships/users", a.removeTeamMembers).Methods("DELETE")
 
-	// Stub implementation of the TFC Organization Memberships API:
-	//
 	// https://developer.hashicorp.com/terraform/cloud-docs/api-docs/organization-memberships
-	//
-	// Note: this is only implemented insofar as to satisfy some of the API tests we
-	// run from the `go-tfe` project.
-	r.HandleFunc("/organizations/{organization_name}/organization-memberships", a.inviteUser).Methods("POST")
+	r.HandleFunc("/organizations/{organization_name}/organization-memberships", a.createMembership).Methods("POST")
+	r.HandleFunc("/organizations/{organization_name}/organization-memberships", a.listMemberships).Methods("GET")
+	r.HandleFunc("/organization-memberships/{id}", a.getMembership).Methods("GET")
 	r.HandleFunc("/organization-memberships/{id}", a.deleteMembership).Methods("DELETE")
 }
 
@@ -92,6 +95,51 @@ func (a *tfe) getCurrentUser(w http.ResponseWriter, r *http.Request) {
 	a.Respond(w, r, a.convertUser(user), http.StatusOK)
 }
 
+func (a *tfe) updateCurrentUser(w http.ResponseWriter, r *http.Request) {
+	var params types.UserUpdateOptions
+	if err := tfeapi.Unmarshal(r.Body, &params); err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+
+	// OTF users are identified by username rather than by email address, so
+	// an email address is only accepted if it is the same as the username.
+	opts := UpdateUserOptions{Username: params.Username}
+	if params.Email != nil {
+		if opts.Username != nil && *opts.Username != *params.Email {
+			tfeapi.Error(w, &internal.InvalidParameterError{
+				Parameter: "email",
+				Err:       errors.New("must be the same as the username"),
+			})
+			return
+		}
+		opts.Username = params.Email
+	}
+	user, err := a.UpdateCurrentUser(r.Context(), opts)
+	if errors.Is(err, ErrUsernameImmutable) {
+		err = &internal.InvalidParameterError{Parameter: "username", Err: err}
+	}
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	a.Respond(w, r, a.convertUser(user), http.StatusOK)
+}
+
+func (a *tfe) getUser(w http.ResponseWriter, r *http.Request) {
+	id, err := decode.Param("user_id", r)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	user, err := a.GetUserByID(r.Context(), id)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	a.Respond(w, r, a.convertUser(user), http.StatusOK)
+}
+
 // https://developer.hashicorp.com/terraform/cloud-docs/api-docs/team-members#add-a-user-to-team-with-user-id
 func (a *tfe) addTeamMembers(w http.ResponseWriter, r *http.Request) {
 	if err := a.modifyTeamMembers(r, addTeamMembersAction); err != nil {
@@ -139,7 +187,7 @@ func (a *tfe) modifyTeamMembers(r *http.Request, action teamMembersAction) error
 	}
 }
 
-func (a *tfe) inviteUser(w http.ResponseWriter, r *http.Request) {
+func (a *tfe) createMembership(w http.ResponseWriter, r *http.Request) {
 	org, err := decode.Param("organization_name", r)
 	if err != nil {
 		tfeapi.Error(w, err)
@@ -151,23 +199,132 @@ func (a *tfe) inviteUser(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	membership := &types.OrganizationMembership{
-		ID: internal.NewID("ou"),
-		User: &types.User{
-			ID: internal.NewID("user"),
-		},
-		Organization: &types.Organization{
-			Name: org,
-		},
+	// OTF users are identified by username rather than by email address, so
+	// the email address is used as the username.
+	opts := CreateOrganizationMembershipOptions{}
+	if params.Email != nil {
+		opts.Username = *params.Email
+	}
+	for _, team := range params.Teams {
+		opts.TeamIDs = append(opts.TeamIDs, team.ID)
 	}
+	membership, err := a.CreateOrganizationMembership(r.Context(), org, opts)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	to, err := a.convertMembership(r.Context(), membership)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	a.Respond(w, r, to, http.StatusCreated)
+}
 
-	a.Respond(w, r, membership, http.StatusCreated)
+func (a *tfe) listMemberships(w http.ResponseWriter, r *http.Request) {
+	var params struct {
+		Organization string `schema:"organization_name,required"`
+		// Filter by email addresses, separated by commas
+		Emails string `schema:"filter[email],omitempty"`
+		// Search by username
+		Query string `schema:"q,omitempty"`
+		resource.PageOptions
+	}
+	if err := decode.All(&params, r); err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+
+	memberships, err := a.ListOrganizationMemberships(r.Context(), params.Organization)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+
+	if params.Emails != "" {
+		emails := strings.Split(params.Emails, ",")
+		memberships = slices.DeleteFunc(memberships, func(m *OrganizationMembership) bool {
+			return !slices.Contains(emails, m.Username)
+		})
+	}
+	if params.Query != "" {
+		memberships = slices.DeleteFunc(memberships, func(m *OrganizationMembership) bool {
+			return !strings.Contains(m.Username, params.Query)
+		})
+	}
+
+	// convert items
+	items := make([]*types.OrganizationMembership, len(memberships))
+	for i, from := range memberships {
+		to, err := a.convertMembership(r.Context(), from)
+		if err != nil {
+			tfeapi.Error(w, err)
+			return
+		}
+		items[i] = to
+	}
+	page := resource.NewPage(items, params.PageOptions, nil)
+	a.RespondWithPage(w, r, page.Items, page.Pagination)
+}
+
+func (a *tfe) getMembership(w http.ResponseWriter, r *http.Request) {
+	id, err := decode.Param("id", r)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	membership, err := a.GetOrganizationMembership(r.Context(), id)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	to, err := a.convertMembership(r.Context(), membership)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	a.Respond(w, r, to, http.StatusOK)
 }
 
 func (a *tfe) deleteMembership(w http.ResponseWriter, r *http.Request) {
+	id, err := decode.Param("id", r)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	if err := a.DeleteOrganizationMembership(r.Context(), id); err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
 	w.WriteHeader(http.StatusNoContent)
 }
 
+// convertMembership converts a membership into its TFE API representation,
+// which relates the membership to the user and to the user's teams in the
+// organization.
+func (a *tfe) convertMembership(ctx context.Context, from *OrganizationMembership) (*types.OrganizationMembership, error) {
+	user, err := a.db.getUser(ctx, UserSpec{Username: &from.Username})
+	if err != nil {
+		return nil, err
+	}
+	to := &types.OrganizationMembership{
+		ID:     from.ID,
+		Status: types.OrganizationMembershipActive,
+		Email:  from.Username,
+		Organization: &types.Organization{
+			Name: from.Organization,
+		},
+		User:  a.convertUser(user),
+		Teams: []*types.Team{},
+	}
+	for _, team := range user.Teams {
+		if team.Organization == from.Organization {
+			to.Teams = append(to.Teams, &types.Team{ID: team.ID})
+		}
+	}
+	return to, nil
+}
+
 func (a *tfe) convertUser(from *User) *types.User {
 	return &types.User{
 		ID:       from.ID,
@@ -1,14 +1,36 @@
 package user
 
 import (
+	"context"
+	"errors"
+	"fmt"
 	"time"
 
 	"github.com/leg100/otf/internal"
+	"github.com/leg100/otf/internal/rbac"
+	"github.com/leg100/otf/internal/resource"
 	"github.com/leg100/otf/internal/tokens"
 )
 
 const UserTokenKind tokens.Kind = "user_token"
 
+var (
+	ErrUnknownTokenScope = errors.New("unknown token scope")
+
+	// tokenScopes are the scopes that can be granted to a user token. Each
+	// scope permits the actions of the role with the same name.
+	tokenScopes = []rbac.Role{
+		rbac.WorkspaceReadRole,
+		rbac.WorkspacePlanRole,
+		rbac.WorkspaceWriteRole,
+		rbac.WorkspaceAdminRole,
+		rbac.WorkspaceManagerRole,
+		rbac.VCSManagerRole,
+		rbac.RegistryManagerRole,
+		rbac.PolicyManagerRole,
+	}
+)
+
 type (
 	// UserToken provides information about an API token for a user.
 	UserToken struct {
@@ -16,12 +38,22 @@ type (
 		CreatedAt   time.Time
 		Description string
 		Username    string // Token belongs to a user
+
+		// Organization, if non-nil, restricts the token to the organization:
+		// it cannot be used to act in any other organization, nor to perform
+		// site-wide actions.
+		Organization *string
+		// Scopes, if non-empty, restrict the token to the actions permitted
+		// by at least one of the scopes.
+		Scopes []string
 	}
 
 	// CreateUserTokenOptions are options for creating a user token via the service
 	// endpoint
 	CreateUserTokenOptions struct {
-		Description string
+		Description  string
+		Organization *string  `schema:"organization"`
+		Scopes       []string `schema:"scopes"`
 	}
 
 	userTokenFactory struct {
@@ -29,19 +61,57 @@ type (
 	}
 )
 
-func (f *userTokenFactory) NewUserToken(username string, opts CreateUserTokenOptions) (*UserToken, []byte, error) {
+// NewUserToken constructs a user token. If the user is creating the token
+// during a single sign-on session then the token is marked as issued via
+// single sign-on.
+func (f *userTokenFactory) NewUserToken(ctx context.Context, username string, opts CreateUserTokenOptions) (*UserToken, []byte, error) {
+	for _, scope := range opts.Scopes {
+		if _, ok := lookupTokenScope(scope); !ok {
+			return nil, nil, fmt.Errorf("%w: %s", ErrUnknownTokenScope, scope)
+		}
+	}
 	ut := UserToken{
-		ID:          internal.NewID("ut"),
+		ID:          resource.NewID(resource.UserTokenKind),
 		CreatedAt:   internal.CurrentTimestamp(nil),
 		Description: opts.Description,
 		Username:    username,
+		Scopes:      opts.Scopes,
+	}
+	if opts.Organization != nil && *opts.Organization != "" {
+		ut.Organization = opts.Organization
 	}
 	token, err := f.tokens.NewToken(tokens.NewTokenOptions{
 		Subject: ut.ID,
 		Kind:    UserTokenKind,
+		Claims:  tokens.SSOClaims(ctx),
 	})
 	if err != nil {
 		return nil, nil, err
 	}
 	return &ut, token, nil
 }
+
+// IsRestricted determines whether the token is restricted to an organization
+// or to scopes.
+func (t *UserToken) IsRestricted() bool {
+	return t.Organization != nil || len(t.Scopes) > 0
+}
+
+// TokenScopes returns the names of the scopes that can be granted to a user
+// token.
+func TokenScopes() []string {
+	names := make([]string, len(tokenScopes))
+	for i, role := range tokenScopes {
+		names[i] = role.String()
+	}
+	return names
+}
+
+func lookupTokenScope(name string) (rbac.Role, bool) {
+	for _, role := range tokenScopes {
+		if role.String() == name {
+			return role, true
+		}
+	}
+	return rbac.Role{}, false
+}
@@ -10,6 +10,7 @@ import (
 
 	"github.com/leg100/otf/internal"
 	"github.com/leg100/otf/internal/rbac"
+	"github.com/leg100/otf/internal/resource"
 	"github.com/leg100/otf/internal/team"
 )
 
@@ -19,8 +20,9 @@ const (
 )
 
 var (
-	SiteAdmin                  = User{ID: SiteAdminID, Username: SiteAdminUsername}
-	_         internal.Subject = (*User)(nil)
+	SiteAdmin                    = User{ID: SiteAdminID, Username: SiteAdminUsername}
+	_         internal.Subject   = (*User)(nil)
+	_         internal.Explainer = (*User)(nil)
 )
 
 type (
@@ -36,6 +38,10 @@ type (
 
 		// user belongs to many teams
 		Teams []*team.Team
+
+		// token is the restricted token with which the user authenticated,
+		// if any.
+		token *UserToken
 	}
 
 	// UserListOptions are options for the ListUsers endpoint.
@@ -50,6 +56,11 @@ type (
 		Username string `json:"username"`
 	}
 
+	// UpdateUserOptions are options for updating a user's own account.
+	UpdateUserOptions struct {
+		Username *string
+	}
+
 	UserSpec struct {
 		UserID                *string
 		Username              *string
@@ -59,7 +70,7 @@ type (
 
 func NewUser(username string, opts ...NewUserOption) *User {
 	user := &User{
-		ID:        internal.NewID("user"),
+		ID:        resource.NewID(resource.UserKind),
 		Username:  username,
 		CreatedAt: internal.CurrentTimestamp(nil),
 		UpdatedAt: internal.CurrentTimestamp(nil),
@@ -116,67 +127,177 @@ func (u *User) Organizations() []string {
 // in either of two cases:
 // (1) their account has been promoted to site admin (think sudo)
 // (2) the account is *the* site admin (think root)
+//
+// A user that authenticated with a token restricted to an organization is
+// never a site admin.
 func (u *User) IsSiteAdmin() bool {
+	if u.token != nil && u.token.Organization != nil {
+		return false
+	}
 	return u.SiteAdmin || u.ID == SiteAdminID
 }
 
+// restrict returns a copy of the user restricted by the token with which the
+// user authenticated. A token restricted to an organization only retains the
+// user's membership of teams in that organization, and never confers site
+// admin privileges.
+func (u *User) restrict(token *UserToken) *User {
+	if !token.IsRestricted() {
+		return u
+	}
+	restricted := *u
+	restricted.token = token
+	if token.Organization != nil {
+		restricted.Teams = nil
+		for _, t := range u.Teams {
+			if t.Organization == *token.Organization {
+				restricted.Teams = append(restricted.Teams, t)
+			}
+		}
+	}
+	return &restricted
+}
+
+// IsRestricted determines whether the user authenticated with a restricted
+// token.
+func (u *User) IsRestricted() bool {
+	return u.token != nil
+}
+
+// inScope determines whether the action is permitted by the scopes of the
+// token with which the user authenticated. Every action is in scope if the
+// token has no scopes. Actions permitted to all members of an organization are
+// always in scope.
+func (u *User) inScope(action rbac.Action) bool {
+	if u.token == nil || len(u.token.Scopes) == 0 {
+		return true
+	}
+	if rbac.OrganizationMinPermissions.IsAllowed(action) {
+		return true
+	}
+	for _, name := range u.token.Scopes {
+		if role, ok := lookupTokenScope(name); ok && role.IsAllowed(action) {
+			return true
+		}
+	}
+	return false
+}
+
 func (u *User) CanAccessSite(action rbac.Action) bool {
+	return u.ExplainSite(action).Allowed
+}
+
+func (u *User) CanAccessTeam(action rbac.Action, teamID string) bool {
+	return u.ExplainTeam(action, teamID).Allowed
+}
+
+func (u *User) CanAccessOrganization(action rbac.Action, org string) bool {
+	return u.ExplainOrganization(action, org).Allowed
+}
+
+func (u *User) CanAccessWorkspace(action rbac.Action, policy internal.WorkspacePolicy) bool {
+	return u.ExplainWorkspace(action, policy).Allowed
+}
+
+func (u *User) ExplainSite(action rbac.Action) internal.Decision {
+	if !u.inScope(action) {
+		return u.outOfScope()
+	}
 	switch action {
 	case rbac.GetGithubAppAction:
-		return true
+		return internal.Decision{Allowed: true, Reason: "action is permitted to all users"}
 	case rbac.CreateUserAction, rbac.ListUsersAction:
 		// A user can perform these actions only if they are an owner of at
 		// least one organization. This permits an owner to search users or create
 		// a user before adding them to a team.
 		for _, team := range u.Teams {
 			if team.IsOwners() {
-				return true
+				return internal.Decision{
+					Allowed: true,
+					Reason:  fmt.Sprintf("user is an owner of organization %s", team.Organization),
+					Team:    team.Name,
+				}
 			}
 		}
 	}
 	// Otherwise only the site admin can perform site actions.
-	return u.IsSiteAdmin()
+	if u.IsSiteAdmin() {
+		return internal.Decision{Allowed: true, Reason: "user is a site admin"}
+	}
+	return internal.Decision{Reason: "only a site admin is permitted to carry out the action"}
 }
 
-func (u *User) CanAccessTeam(action rbac.Action, teamID string) bool {
+func (u *User) ExplainTeam(action rbac.Action, teamID string) internal.Decision {
+	if !u.inScope(action) {
+		return u.outOfScope()
+	}
 	// coarser-grained site-level perms take precedence
-	if u.CanAccessSite(action) {
-		return true
+	if decision := u.ExplainSite(action); decision.Allowed {
+		return decision
 	}
 	for _, team := range u.Teams {
 		if team.ID == teamID {
-			return true
+			return internal.Decision{Allowed: true, Reason: "user is a member of the team", Team: team.Name}
 		}
 	}
-	return false
+	return internal.Decision{Reason: "user is not a member of the team"}
 }
 
-func (u *User) CanAccessOrganization(action rbac.Action, org string) bool {
+func (u *User) ExplainOrganization(action rbac.Action, org string) internal.Decision {
+	if !u.inScope(action) {
+		return u.outOfScope()
+	}
 	// coarser-grained site-level perms take precedence
-	if u.CanAccessSite(action) {
-		return true
+	if decision := u.ExplainSite(action); decision.Allowed {
+		return decision
 	}
 	// fallback to finer-grained organization-level perms
 	for _, team := range u.Teams {
-		if team.CanAccessOrganization(action, org) {
-			return true
+		if role, ok := team.OrganizationRole(action, org); ok {
+			return internal.Decision{
+				Allowed: true,
+				Reason:  "user is a member of a team granted an organization role permitting the action",
+				Role:    role,
+				Team:    team.Name,
+			}
 		}
 	}
-	return false
+	return internal.Decision{
+		Reason: fmt.Sprintf("user is not a member of a team in organization %s granted a role permitting the action", org),
+	}
 }
 
-func (u *User) CanAccessWorkspace(action rbac.Action, policy internal.WorkspacePolicy) bool {
+func (u *User) ExplainWorkspace(action rbac.Action, policy internal.WorkspacePolicy) internal.Decision {
+	if !u.inScope(action) {
+		return u.outOfScope()
+	}
 	// coarser-grained organization perms take precedence.
-	if u.CanAccessOrganization(action, policy.Organization) {
-		return true
+	if decision := u.ExplainOrganization(action, policy.Organization); decision.Allowed {
+		return decision
 	}
 	// fallback to checking finer-grained workspace perms
 	for _, team := range u.Teams {
-		if team.CanAccessWorkspace(action, policy) {
-			return true
+		if role, ok := team.WorkspaceRole(action, policy); ok {
+			return internal.Decision{
+				Allowed: true,
+				Reason:  "user is a member of a team granted a workspace role permitting the action",
+				Role:    role,
+				Team:    team.Name,
+			}
 		}
 	}
-	return false
+	return internal.Decision{
+		Reason: "user is not a member of a team granted a role on the workspace permitting the action",
+	}
+}
+
+// outOfScope explains that an action is denied because it is not permitted
+// by the scopes of the token with which the user authenticated.
+func (u *User) outOfScope() internal.Decision {
+	return internal.Decision{
+		Reason: "action is not permitted by the scopes of the token",
+		Scopes: u.token.Scopes,
+	}
 }
 
 // IsOwner determines if user is an owner of an organization
@@ -3,6 +3,7 @@ package user
 import (
 	"testing"
 
+	"github.com/leg100/otf/internal"
 	"github.com/leg100/otf/internal/rbac"
 	"github.com/leg100/otf/internal/team"
 	"github.com/stretchr/testify/assert"
@@ -54,3 +55,123 @@ func TestUser_Organizations(t *testing.T) {
 	assert.Contains(t, want, "big-tobacco")
 	assert.Contains(t, want, "big-pharma")
 }
+
+func TestUser_Restrict(t *testing.T) {
+	u := &User{
+		SiteAdmin: true,
+		Teams: []*team.Team{
+			{Name: "owners", Organization: "acme-corp"},
+			{Name: "owners", Organization: "big-tobacco"},
+		},
+	}
+
+	t.Run("unrestricted", func(t *testing.T) {
+		got := u.restrict(&UserToken{})
+		assert.False(t, got.IsRestricted())
+		assert.True(t, got.IsSiteAdmin())
+	})
+
+	t.Run("organization", func(t *testing.T) {
+		got := u.restrict(&UserToken{Organization: internal.String("acme-corp")})
+		assert.True(t, got.IsRestricted())
+		assert.False(t, got.IsSiteAdmin())
+		assert.Equal(t, []string{"acme-corp"}, got.Organizations())
+		assert.True(t, got.CanAccessOrganization(rbac.CreateWorkspaceAction, "acme-corp"))
+		assert.False(t, got.CanAccessOrganization(rbac.CreateWorkspaceAction, "big-tobacco"))
+		assert.False(t, got.CanAccessSite(rbac.CreateBannerAction))
+		// original user is unaffected
+		assert.True(t, u.CanAccessOrganization(rbac.CreateWorkspaceAction, "big-tobacco"))
+	})
+
+	t.Run("scopes", func(t *testing.T) {
+		got := u.restrict(&UserToken{Scopes: []string{"plan"}})
+		assert.True(t, got.CanAccessOrganization(rbac.GetOrganizationAction, "big-tobacco"))
+		assert.True(t, got.CanAccessOrganization(rbac.CreateRunAction, "big-tobacco"))
+		assert.False(t, got.CanAccessOrganization(rbac.ApplyRunAction, "big-tobacco"))
+		assert.False(t, got.CanAccessSite(rbac.CreateBannerAction))
+	})
+}
+
+func TestUser_Explain(t *testing.T) {
+	devs := &team.Team{ID: "team-devs", Name: "devs", Organization: "acme-corp"}
+	managers := &team.Team{
+		ID:           "team-managers",
+		Name:         "managers",
+		Organization: "acme-corp",
+		Access:       team.OrganizationAccess{ManageWorkspaces: true},
+	}
+	u := &User{Teams: []*team.Team{devs, managers}}
+	policy := internal.WorkspacePolicy{
+		Organization: "acme-corp",
+		WorkspaceID:  "ws-123",
+		Permissions: []internal.WorkspacePermission{
+			{TeamID: "team-devs", Role: rbac.WorkspacePlanRole},
+		},
+	}
+
+	t.Run("site admin", func(t *testing.T) {
+		got := SiteAdmin.ExplainOrganization(rbac.DeleteOrganizationAction, "acme-corp")
+		assert.True(t, got.Allowed)
+		assert.Equal(t, "user is a site admin", got.Reason)
+	})
+
+	t.Run("organization role", func(t *testing.T) {
+		got := u.ExplainOrganization(rbac.CreateWorkspaceAction, "acme-corp")
+		assert.True(t, got.Allowed)
+		assert.Equal(t, "workspace-manager", got.Role)
+		assert.Equal(t, "managers", got.Team)
+	})
+
+	t.Run("organization role takes precedence over workspace role", func(t *testing.T) {
+		got := u.ExplainWorkspace(rbac.ApplyRunAction, policy)
+		assert.True(t, got.Allowed)
+		assert.Equal(t, "workspace-manager", got.Role)
+		assert.Equal(t, "managers", got.Team)
+	})
+
+	t.Run("workspace role", func(t *testing.T) {
+		u := &User{Teams: []*team.Team{devs}}
+		got := u.ExplainWorkspace(rbac.CreateRunAction, policy)
+		assert.True(t, got.Allowed)
+		assert.Equal(t, "plan", got.Role)
+		assert.Equal(t, "devs", got.Team)
+
+		got = u.ExplainWorkspace(rbac.ApplyRunAction, policy)
+		assert.False(t, got.Allowed)
+		assert.Empty(t, got.Role)
+	})
+
+	t.Run("denied by token scopes", func(t *testing.T) {
+		got := u.restrict(&UserToken{Scopes: []string{"read"}}).ExplainWorkspace(rbac.ApplyRunAction, policy)
+		assert.False(t, got.Allowed)
+		assert.Equal(t, []string{"read"}, got.Scopes)
+	})
+
+	t.Run("site action", func(t *testing.T) {
+		got := u.ExplainSite(rbac.CreateBannerAction)
+		assert.False(t, got.Allowed)
+	})
+}
+
+func TestCanViewUser(t *testing.T) {
+	bob := &User{
+		ID:    "user-bob",
+		Teams: []*team.Team{{Name: "devs", Organization: "acme-corp"}},
+	}
+	tests := []struct {
+		name    string
+		subject *User
+		want    bool
+	}{
+		{"self", &User{ID: "user-bob"}, true},
+		{"site admin", &SiteAdmin, true},
+		{"same organization", &User{ID: "user-alice", Teams: []*team.Team{{Name: "ops", Organization: "acme-corp"}}}, true},
+		{"different organization", &User{ID: "user-alice", Teams: []*team.Team{{Name: "ops", Organization: "big-tobacco"}}}, false},
+		{"no organization", &User{ID: "user-alice"}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, canViewUser(tt.subject, bob))
+		})
+	}
+}
@@ -2,6 +2,7 @@ package user
 
 import (
 	"context"
+	"errors"
 	"net/http"
 	"sort"
 	"time"
@@ -40,6 +41,8 @@ type usersClient interface {
 	CreateToken(ctx context.Context, opts CreateUserTokenOptions) (*UserToken, []byte, error)
 	ListTokens(ctx context.Context) ([]*UserToken, error)
 	DeleteToken(ctx context.Context, tokenID string) error
+
+	UseRecoveryToken(ctx context.Context, token string) error
 }
 
 type tokensClient interface {
@@ -123,12 +126,21 @@ func (h *webHandlers) profileHandler(w http.ResponseWriter, r *http.Request) {
 	})
 }
 
-// adminLoginPromptHandler presents a prompt for logging in as site admin
+// adminLoginPromptHandler presents a prompt for logging in as site admin. The
+// token is pre-filled if provided in the query, e.g. in the login URL printed
+// in demo mode.
 func (h *webHandlers) adminLoginPromptHandler(w http.ResponseWriter, r *http.Request) {
-	h.Render("site_admin_login.tmpl", w, html.NewSitePage(r, "site admin login"))
+	h.Render("site_admin_login.tmpl", w, struct {
+		html.SitePage
+		Token string
+	}{
+		SitePage: html.NewSitePage(r, "site admin login"),
+		Token:    r.URL.Query().Get("token"),
+	})
 }
 
-// adminLogin logs in a site admin
+// adminLogin logs in a site admin, using either the site token or a one-time
+// recovery token.
 func (h *webHandlers) adminLogin(w http.ResponseWriter, r *http.Request) {
 	token, err := decode.Param("token", r)
 	if err != nil {
@@ -136,10 +148,16 @@ func (h *webHandlers) adminLogin(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if token != h.siteToken {
-		html.FlashError(w, "incorrect token")
-		http.Redirect(w, r, paths.AdminLogin(), http.StatusFound)
-		return
+	if h.siteToken == "" || token != h.siteToken {
+		err := h.users.UseRecoveryToken(r.Context(), token)
+		if errors.Is(err, internal.ErrResourceNotFound) {
+			html.FlashError(w, "incorrect token")
+			http.Redirect(w, r, paths.AdminLogin(), http.StatusFound)
+			return
+		} else if err != nil {
+			h.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
 	}
 
 	err = h.tokens.StartSession(w, r, tokens.StartSessionOptions{
@@ -293,7 +311,23 @@ func (h *webHandlers) getTeam(w http.ResponseWriter, r *http.Request) {
 //
 
 func (h *webHandlers) newUserToken(w http.ResponseWriter, r *http.Request) {
-	h.Render("token_new.tmpl", w, html.NewSitePage(r, "new user token"))
+	user, err := UserFromContext(r.Context())
+	if err != nil {
+		h.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	organizations := user.Organizations()
+	sort.Strings(organizations)
+
+	h.Render("token_new.tmpl", w, struct {
+		html.SitePage
+		Organizations []string
+		Scopes        []string
+	}{
+		SitePage:      html.NewSitePage(r, "new user token"),
+		Organizations: organizations,
+		Scopes:        TokenScopes(),
+	})
 }
 
 func (h *webHandlers) createUserToken(w http.ResponseWriter, r *http.Request) {
@@ -25,8 +25,10 @@ func TestWeb_UserTokens(t *testing.T) {
 		h := &webHandlers{
 			Renderer: testutils.NewRenderer(t),
 		}
+		member := NewUser(uuid.NewString(), WithTeams(&team.Team{Name: "devs", Organization: "acme-org"}))
 		q := "/?"
 		r := httptest.NewRequest("GET", q, nil)
+		r = r.WithContext(internal.AddSubjectToContext(context.Background(), member))
 		w := httptest.NewRecorder()
 
 		h.newUserToken(w, r)
@@ -34,6 +36,8 @@ func TestWeb_UserTokens(t *testing.T) {
 		if !assert.Equal(t, 200, w.Code) {
 			t.Log(t, w.Body.String())
 		}
+		assert.Contains(t, w.Body.String(), `<option value="acme-org">`)
+		assert.Contains(t, w.Body.String(), `value="workspace-manager"`)
 	})
 
 	t.Run("create", func(t *testing.T) {
@@ -117,6 +121,7 @@ func TestAdminLoginHandler(t *testing.T) {
 		Renderer:  testutils.NewRenderer(t),
 		siteToken: "secrettoken",
 		tokens:    &fakeTokensService{},
+		users:     &fakeService{recoveryToken: "recoverytoken"},
 	}
 
 	tests := []struct {
@@ -134,6 +139,16 @@ func TestAdminLoginHandler(t *testing.T) {
 			token:        "badtoken",
 			wantRedirect: "/admin/login",
 		},
+		{
+			name:         "valid recovery token",
+			token:        "recoverytoken",
+			wantRedirect: "/app/profile",
+		},
+		{
+			name:         "used recovery token",
+			token:        "recoverytoken",
+			wantRedirect: "/admin/login",
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
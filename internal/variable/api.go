@@ -1,8 +1,12 @@
 package variable
 
 import (
+	"encoding/json"
+	"errors"
+	"io"
 	"net/http"
 
+	"github.com/leg100/otf/internal"
 	otfapi "github.com/leg100/otf/internal/api"
 
 	"github.com/leg100/otf/internal/tfeapi"
@@ -19,6 +23,9 @@ type api struct {
 func (a *api) addHandlers(r *mux.Router) {
 	r = r.PathPrefix(otfapi.DefaultBasePath).Subrouter()
 	r.HandleFunc("/vars/effective/{run_id}", a.listEffectiveVariables).Methods("GET")
+	r.HandleFunc("/runs/{run_id}/variables/debug", a.resolveRunVariables).Methods("GET")
+	r.HandleFunc("/workspaces/{workspace_id}/vars/export", a.exportWorkspaceVariables).Methods("GET")
+	r.HandleFunc("/workspaces/{workspace_id}/vars/import", a.importWorkspaceVariables).Methods("POST")
 }
 
 func (a *api) listEffectiveVariables(w http.ResponseWriter, r *http.Request) {
@@ -34,3 +41,74 @@ func (a *api) listEffectiveVariables(w http.ResponseWriter, r *http.Request) {
 	}
 	a.Respond(w, r, variables, http.StatusOK)
 }
+
+func (a *api) resolveRunVariables(w http.ResponseWriter, r *http.Request) {
+	runID, err := decode.Param("run_id", r)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	variables, err := a.ResolveRunVariables(r.Context(), runID)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(variables)
+}
+
+func (a *api) exportWorkspaceVariables(w http.ResponseWriter, r *http.Request) {
+	var params struct {
+		WorkspaceID string `schema:"workspace_id,required"`
+		Format      Format `schema:"format,required"`
+	}
+	if err := decode.All(&params, r); err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	data, err := a.ExportWorkspaceVariables(r.Context(), params.WorkspaceID, params.Format)
+	if errors.Is(err, ErrUnknownFormat) {
+		tfeapi.Error(w, &internal.HTTPError{Code: http.StatusUnprocessableEntity, Message: err.Error()})
+		return
+	} else if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	if params.Format == FormatJSON {
+		w.Header().Set("Content-Type", "application/json")
+	} else {
+		w.Header().Set("Content-Type", "text/plain")
+	}
+	w.Write(data)
+}
+
+func (a *api) importWorkspaceVariables(w http.ResponseWriter, r *http.Request) {
+	var params struct {
+		WorkspaceID string `schema:"workspace_id,required"`
+		Format      Format `schema:"format,required"`
+		DryRun      bool   `schema:"dry_run"`
+	}
+	if err := decode.All(&params, r); err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	data, err := io.ReadAll(r.Body)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	result, err := a.ImportWorkspaceVariables(r.Context(), params.WorkspaceID, ImportOptions{
+		Format: params.Format,
+		Data:   data,
+		DryRun: params.DryRun,
+	})
+	if errors.Is(err, ErrUnknownFormat) || errors.Is(err, ErrInvalidImport) {
+		tfeapi.Error(w, &internal.HTTPError{Code: http.StatusUnprocessableEntity, Message: err.Error()})
+		return
+	} else if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(result)
+}
@@ -31,6 +31,7 @@ type (
 		Name             pgtype.Text       `json:"name"`
 		Description      pgtype.Text       `json:"description"`
 		OrganizationName pgtype.Text       `json:"organization_name"`
+		Priority         pgtype.Bool       `json:"priority"`
 		Variables        []pggen.Variables `json:"variables"`
 		WorkspaceIds     []string          `json:"workspace_ids"`
 	}
@@ -53,6 +54,7 @@ func (row variableSetRow) convert() *VariableSet {
 	set := &VariableSet{
 		ID:           row.VariableSetID.String,
 		Global:       row.Global.Bool,
+		Priority:     row.Priority.Bool,
 		Description:  row.Description.String,
 		Name:         row.Name.String,
 		Organization: row.OrganizationName.String,
@@ -120,6 +122,7 @@ func (pdb *pgdb) createVariableSet(ctx context.Context, set *VariableSet) error
 		Description:      sql.String(set.Description),
 		Global:           sql.Bool(set.Global),
 		OrganizationName: sql.String(set.Organization),
+		Priority:         sql.Bool(set.Priority),
 	})
 	return sql.Error(err)
 }
@@ -130,6 +133,7 @@ func (pdb *pgdb) updateVariableSet(ctx context.Context, set *VariableSet) error
 			Name:          sql.String(set.Name),
 			Description:   sql.String(set.Description),
 			Global:        sql.Bool(set.Global),
+			Priority:      sql.Bool(set.Priority),
 			VariableSetID: sql.String(set.ID),
 		})
 		if err != nil {
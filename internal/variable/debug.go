@@ -0,0 +1,104 @@
+package variable
+
+import (
+	"context"
+	"sort"
+
+	"github.com/leg100/otf/internal/rbac"
+)
+
+const (
+	SourceVariableSet VariableSourceKind = "variable_set"
+	SourceWorkspace   VariableSourceKind = "workspace"
+	SourceRun         VariableSourceKind = "run"
+)
+
+type (
+	// VariableSourceKind is the kind of source from which a run's variable
+	// originates.
+	VariableSourceKind string
+
+	// VariableSource identifies where a variable used by a run is defined.
+	VariableSource struct {
+		Kind VariableSourceKind `json:"kind"`
+		// ID of the workspace variable or variable set variable. Empty for
+		// run variables.
+		VariableID string `json:"variable_id,omitempty"`
+		// Variable set attributes, only set if the source is a variable set.
+		VariableSetID   string `json:"variable_set_id,omitempty"`
+		VariableSetName string `json:"variable_set_name,omitempty"`
+		Global          bool   `json:"global,omitempty"`
+		Priority        bool   `json:"priority,omitempty"`
+	}
+
+	// ResolvedVariable is a variable as resolved for a run, for the purposes of
+	// debugging which value a run uses and why. The value of a sensitive
+	// variable is omitted.
+	ResolvedVariable struct {
+		Key       string           `json:"key"`
+		Value     string           `json:"value"`
+		Category  VariableCategory `json:"category"`
+		Sensitive bool             `json:"sensitive"`
+		HCL       bool             `json:"hcl"`
+		// Source is where the variable is defined.
+		Source VariableSource `json:"source"`
+		// Overridden are the sources of variables with the same key and
+		// category that the variable takes precedence over, in order of
+		// increasing precedence.
+		Overridden []VariableSource `json:"overridden,omitempty"`
+	}
+)
+
+// ResolveRunVariables resolves the variables for a run exactly as they are
+// provided to the agent executing the run, along with their sources, ordered
+// by category and key.
+//
+// NOTE: variables are resolved using the current workspace variables and
+// variable sets, which may have changed since the run was executed.
+func (s *Service) ResolveRunVariables(ctx context.Context, runID string) ([]*ResolvedVariable, error) {
+	run, err := s.runs.Get(ctx, runID)
+	if err != nil {
+		return nil, err
+	}
+	subject, err := s.workspace.CanAccess(ctx, rbac.DebugRunVariablesAction, run.WorkspaceID)
+	if err != nil {
+		return nil, err
+	}
+	sets, err := s.listWorkspaceVariableSets(ctx, run.WorkspaceID)
+	if err != nil {
+		return nil, err
+	}
+	vars, err := s.ListWorkspaceVariables(ctx, run.WorkspaceID)
+	if err != nil {
+		return nil, err
+	}
+	resolved := newResolvedVariables(resolveVariables(sets, vars, run))
+	s.V(9).Info("resolved run variables", "run_id", runID, "count", len(resolved), "subject", subject)
+	return resolved, nil
+}
+
+// newResolvedVariables constructs resolved variables from resolutions, ordered
+// by category and key, omitting the values of sensitive variables.
+func newResolvedVariables(resolutions []*resolution) []*ResolvedVariable {
+	resolved := make([]*ResolvedVariable, len(resolutions))
+	for i, r := range resolutions {
+		resolved[i] = &ResolvedVariable{
+			Key:        r.variable.Key,
+			Category:   r.variable.Category,
+			Sensitive:  r.variable.Sensitive,
+			HCL:        r.variable.HCL,
+			Source:     r.source,
+			Overridden: r.overridden,
+		}
+		if !r.variable.Sensitive {
+			resolved[i].Value = r.variable.Value
+		}
+	}
+	sort.Slice(resolved, func(i, j int) bool {
+		if resolved[i].Category != resolved[j].Category {
+			return resolved[i].Category < resolved[j].Category
+		}
+		return resolved[i].Key < resolved[j].Key
+	})
+	return resolved
+}
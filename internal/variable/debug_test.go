@@ -0,0 +1,80 @@
+package variable
+
+import (
+	"testing"
+
+	"github.com/leg100/otf/internal/run"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_newResolvedVariables(t *testing.T) {
+	sets := []*VariableSet{
+		{
+			ID:     "varset-global",
+			Name:   "global",
+			Global: true,
+			Variables: []*Variable{
+				{ID: "var-global-foo", Key: "foo", Value: "global", Category: CategoryTerraform},
+				{ID: "var-global-token", Key: "TOKEN", Value: "secret", Category: CategoryEnv, Sensitive: true},
+			},
+		},
+		{
+			ID:       "varset-priority",
+			Name:     "priority",
+			Priority: true,
+			Variables: []*Variable{
+				{ID: "var-priority-bar", Key: "bar", Value: "priority", Category: CategoryTerraform},
+			},
+		},
+	}
+	workspaceVariables := []*Variable{
+		{ID: "var-ws-foo", Key: "foo", Value: "workspace", Category: CategoryTerraform},
+		{ID: "var-ws-bar", Key: "bar", Value: "workspace", Category: CategoryTerraform},
+	}
+	r := &run.Run{Variables: []run.Variable{{Key: "foo", Value: "run"}}}
+
+	got := newResolvedVariables(resolveVariables(sets, workspaceVariables, r))
+	require.Equal(t, 3, len(got))
+
+	// ordered by category then key
+	assert.Equal(t, "TOKEN", got[0].Key)
+	assert.Equal(t, "bar", got[1].Key)
+	assert.Equal(t, "foo", got[2].Key)
+
+	t.Run("sensitive value omitted", func(t *testing.T) {
+		assert.True(t, got[0].Sensitive)
+		assert.Equal(t, "", got[0].Value)
+		assert.Equal(t, VariableSource{
+			Kind:            SourceVariableSet,
+			VariableID:      "var-global-token",
+			VariableSetID:   "varset-global",
+			VariableSetName: "global",
+			Global:          true,
+		}, got[0].Source)
+	})
+
+	t.Run("priority set overrides workspace variable", func(t *testing.T) {
+		assert.Equal(t, "priority", got[1].Value)
+		assert.Equal(t, SourceVariableSet, got[1].Source.Kind)
+		assert.True(t, got[1].Source.Priority)
+		assert.Equal(t, []VariableSource{
+			{Kind: SourceWorkspace, VariableID: "var-ws-bar"},
+		}, got[1].Overridden)
+	})
+
+	t.Run("run variable overrides workspace variable and set", func(t *testing.T) {
+		assert.Equal(t, "run", got[2].Value)
+		assert.Equal(t, VariableSource{Kind: SourceRun}, got[2].Source)
+		assert.Equal(t, []VariableSource{
+			{
+				Kind:            SourceVariableSet,
+				VariableID:      "var-global-foo",
+				VariableSetID:   "varset-global",
+				VariableSetName: "global",
+				Global:          true,
+			},
+			{Kind: SourceWorkspace, VariableID: "var-ws-foo"},
+		}, got[2].Overridden)
+	})
+}
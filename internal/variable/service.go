@@ -2,6 +2,8 @@ package variable
 
 import (
 	"context"
+	"errors"
+	"fmt"
 
 	"github.com/go-logr/logr"
 	"github.com/gorilla/mux"
@@ -533,3 +535,91 @@ func (s *Service) deleteSetFromWorkspaces(ctx context.Context, setID string, wor
 
 	return nil
 }
+
+// ExportWorkspaceVariables exports a workspace's variables in the given
+// format. Sensitive variables are excluded.
+func (s *Service) ExportWorkspaceVariables(ctx context.Context, workspaceID string, format Format) ([]byte, error) {
+	vars, err := s.ListWorkspaceVariables(ctx, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+	return exportVariables(vars, format)
+}
+
+// ImportWorkspaceVariables bulk imports variables into a workspace, creating
+// variables that don't exist and updating those that do. Either all variables
+// are imported or none are.
+func (s *Service) ImportWorkspaceVariables(ctx context.Context, workspaceID string, opts ImportOptions) (*ImportResult, error) {
+	subject, err := s.workspace.CanAccess(ctx, rbac.CreateWorkspaceVariableAction, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := s.workspace.CanAccess(ctx, rbac.UpdateWorkspaceVariableAction, workspaceID); err != nil {
+		return nil, err
+	}
+
+	imported, err := parseVariables(opts.Data, opts.Format)
+	if errors.Is(err, ErrUnknownFormat) {
+		return nil, err
+	} else if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidImport, err)
+	}
+
+	result := ImportResult{DryRun: opts.DryRun}
+	err = s.db.Lock(ctx, "variables", func(ctx context.Context, q pggen.Querier) error {
+		collection, err := s.db.listWorkspaceVariables(ctx, workspaceID)
+		if err != nil {
+			return err
+		}
+		var created, updated []*Variable
+		for _, createOpts := range imported {
+			existing := findVariable(collection, *createOpts.Key, *createOpts.Category)
+			if existing == nil {
+				v, err := newVariable(collection, createOpts)
+				if err != nil {
+					return fmt.Errorf("%w: %s: %w", ErrInvalidImport, *createOpts.Key, err)
+				}
+				collection = append(collection, v)
+				created = append(created, v)
+				result.Created = append(result.Created, v.Key)
+				continue
+			}
+			if existing.Matches(created) || existing.Matches(updated) {
+				return fmt.Errorf("%w: %s: %w", ErrInvalidImport, existing.Key, ErrVariableConflict)
+			}
+			err := existing.update(collection, UpdateVariableOptions{
+				Value:       createOpts.Value,
+				Description: createOpts.Description,
+				HCL:         createOpts.HCL,
+				Sensitive:   createOpts.Sensitive,
+			})
+			if err != nil {
+				return fmt.Errorf("%w: %s: %w", ErrInvalidImport, existing.Key, err)
+			}
+			updated = append(updated, existing)
+			result.Updated = append(result.Updated, existing.Key)
+		}
+		if opts.DryRun {
+			return nil
+		}
+		for _, v := range created {
+			if err := s.db.createWorkspaceVariable(ctx, workspaceID, v); err != nil {
+				return err
+			}
+		}
+		for _, v := range updated {
+			if err := s.db.updateVariable(ctx, v); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		s.Error(err, "importing workspace variables", "subject", subject, "workspace_id", workspaceID, "format", opts.Format)
+		return nil, err
+	}
+	s.V(1).Info("imported workspace variables", "subject", subject, "workspace_id", workspaceID, "format", opts.Format,
+		"created", len(result.Created), "updated", len(result.Updated), "dry_run", opts.DryRun)
+
+	return &result, nil
+}
@@ -4,16 +4,18 @@ import (
 	"fmt"
 	"log/slog"
 
-	"github.com/leg100/otf/internal"
+	"github.com/leg100/otf/internal/resource"
 )
 
 type (
 	// VariableSet is a set of variables
 	VariableSet struct {
-		ID           string
-		Name         string
-		Description  string
-		Global       bool
+		ID          string
+		Name        string
+		Description string
+		Global      bool
+		// Priority sets override workspace and run variables
+		Priority     bool
 		Workspaces   []string // workspace IDs
 		Organization string   // org name
 		Variables    []*Variable
@@ -23,6 +25,7 @@ type (
 		Name        string
 		Description string
 		Global      bool
+		Priority    bool
 		Workspaces  []string // workspace IDs
 	}
 
@@ -30,16 +33,18 @@ type (
 		Name        *string
 		Description *string
 		Global      *bool
+		Priority    *bool
 		Workspaces  []string // workspace IDs
 	}
 )
 
 func newSet(organization string, opts CreateVariableSetOptions) (*VariableSet, error) {
 	return &VariableSet{
-		ID:           internal.NewID("varset"),
+		ID:           resource.NewID(resource.VariableSetKind),
 		Name:         opts.Name,
 		Description:  opts.Description,
 		Global:       opts.Global,
+		Priority:     opts.Priority,
 		Organization: organization,
 	}, nil
 }
@@ -50,6 +55,7 @@ func (s *VariableSet) LogValue() slog.Value {
 		slog.String("name", s.Name),
 		slog.String("organization", s.Organization),
 		slog.Bool("global", s.Global),
+		slog.Bool("priority", s.Priority),
 		slog.Any("workspaces", s.Workspaces),
 	}
 	return slog.GroupValue(attrs...)
@@ -90,6 +96,9 @@ func (s *VariableSet) updateProperties(organizationSets []*VariableSet, opts Upd
 	if opts.Global != nil {
 		s.Global = *opts.Global
 	}
+	if opts.Priority != nil {
+		s.Priority = *opts.Priority
+	}
 	if opts.Workspaces != nil {
 		s.Workspaces = opts.Workspaces
 	}
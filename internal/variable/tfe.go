@@ -162,6 +162,7 @@ func (a *tfe) createVariableSet(w http.ResponseWriter, r *http.Request) {
 		Name:        params.Name,
 		Description: params.Description,
 		Global:      params.Global,
+		Priority:    params.Priority,
 	})
 	if err != nil {
 		tfeapi.Error(w, err)
@@ -185,6 +186,7 @@ func (a *tfe) updateVariableSet(w http.ResponseWriter, r *http.Request) {
 		Name:        params.Name,
 		Description: params.Description,
 		Global:      params.Global,
+		Priority:    params.Priority,
 	})
 	if err != nil {
 		tfeapi.Error(w, err)
@@ -440,6 +442,7 @@ func (a *tfe) convertVariableSet(from *VariableSet) *types.VariableSet {
 		Name:        from.Name,
 		Description: from.Description,
 		Global:      from.Global,
+		Priority:    from.Priority,
 		Organization: &types.Organization{
 			Name: from.Organization,
 		},
@@ -0,0 +1,280 @@
+package variable
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// Format is a file format in which variables are exported and imported.
+type Format string
+
+const (
+	// FormatTFVars is the terraform.tfvars format. Only terraform variables
+	// are exported and imported.
+	FormatTFVars Format = "tfvars"
+	// FormatJSON is a JSON array of variables, including both terraform and
+	// environment variables.
+	FormatJSON Format = "json"
+	// FormatDotEnv is the .env format. Only environment variables are exported
+	// and imported.
+	FormatDotEnv Format = "env"
+)
+
+var (
+	ErrUnknownFormat = errors.New("format must be one of: tfvars, json, env")
+	ErrInvalidImport = errors.New("invalid import")
+)
+
+type (
+	// ImportOptions are options for importing variables.
+	ImportOptions struct {
+		Format Format
+		// Data is the content of the file being imported.
+		Data []byte
+		// DryRun validates the import without persisting any changes.
+		DryRun bool
+	}
+
+	// ImportResult summarises the changes made by an import.
+	ImportResult struct {
+		// Keys of variables created by the import.
+		Created []string `json:"created"`
+		// Keys of existing variables updated by the import.
+		Updated []string `json:"updated"`
+		// DryRun is true if the changes were not persisted.
+		DryRun bool `json:"dry_run"`
+	}
+
+	// jsonVariable is the representation of a variable in the JSON format.
+	jsonVariable struct {
+		Key         string           `json:"key"`
+		Value       string           `json:"value"`
+		Description string           `json:"description,omitempty"`
+		Category    VariableCategory `json:"category"`
+		HCL         bool             `json:"hcl"`
+		Sensitive   bool             `json:"sensitive,omitempty"`
+	}
+)
+
+// exportVariables writes variables in the given format. Sensitive variables
+// are never exported: for the tfvars and env formats a comment is written in
+// their place.
+func exportVariables(vars []*Variable, format Format) ([]byte, error) {
+	// sort by key for a deterministic output
+	vars = slices.Clone(vars)
+	slices.SortFunc(vars, func(a, b *Variable) int {
+		return strings.Compare(a.Key, b.Key)
+	})
+
+	var buf bytes.Buffer
+	switch format {
+	case FormatTFVars:
+		for _, v := range vars {
+			if v.Category != CategoryTerraform {
+				continue
+			}
+			if v.Sensitive {
+				fmt.Fprintf(&buf, "# %s: sensitive value omitted\n", v.Key)
+				continue
+			}
+			buf.WriteString(v.Key)
+			buf.WriteString(" = ")
+			if v.HCL {
+				buf.WriteString(v.Value)
+			} else {
+				buf.Write(hclwrite.TokensForValue(cty.StringVal(v.Value)).Bytes())
+			}
+			buf.WriteRune('\n')
+		}
+	case FormatDotEnv:
+		for _, v := range vars {
+			if v.Category != CategoryEnv {
+				continue
+			}
+			if v.Sensitive {
+				fmt.Fprintf(&buf, "# %s: sensitive value omitted\n", v.Key)
+				continue
+			}
+			buf.WriteString(v.Key)
+			buf.WriteRune('=')
+			if strings.ContainsAny(v.Value, " \t\n\r\"'#\\$") {
+				buf.WriteString(strconv.Quote(v.Value))
+			} else {
+				buf.WriteString(v.Value)
+			}
+			buf.WriteRune('\n')
+		}
+	case FormatJSON:
+		to := []jsonVariable{}
+		for _, v := range vars {
+			if v.Sensitive {
+				continue
+			}
+			to = append(to, jsonVariable{
+				Key:         v.Key,
+				Value:       v.Value,
+				Description: v.Description,
+				Category:    v.Category,
+				HCL:         v.HCL,
+			})
+		}
+		enc := json.NewEncoder(&buf)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(to); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, ErrUnknownFormat
+	}
+	return buf.Bytes(), nil
+}
+
+// parseVariables parses variables from data in the given format, returning
+// options for creating each variable in the order in which they appear.
+func parseVariables(data []byte, format Format) ([]CreateVariableOptions, error) {
+	switch format {
+	case FormatTFVars:
+		return parseTFVars(data)
+	case FormatDotEnv:
+		return parseDotEnv(data)
+	case FormatJSON:
+		return parseJSON(data)
+	default:
+		return nil, ErrUnknownFormat
+	}
+}
+
+func parseTFVars(data []byte) ([]CreateVariableOptions, error) {
+	file, diags := hclsyntax.ParseConfig(data, "terraform.tfvars", hcl.InitialPos)
+	if diags.HasErrors() {
+		return nil, fmt.Errorf("parsing tfvars: %w", diags)
+	}
+	body := file.Body.(*hclsyntax.Body)
+	if len(body.Blocks) > 0 {
+		return nil, errors.New("parsing tfvars: blocks are not permitted")
+	}
+	// attributes are held in a map, so sort them by their position in the
+	// file
+	attrs := make([]*hclsyntax.Attribute, 0, len(body.Attributes))
+	for _, attr := range body.Attributes {
+		attrs = append(attrs, attr)
+	}
+	slices.SortFunc(attrs, func(a, b *hclsyntax.Attribute) int {
+		return a.SrcRange.Start.Byte - b.SrcRange.Start.Byte
+	})
+	opts := make([]CreateVariableOptions, len(attrs))
+	for i, attr := range attrs {
+		var (
+			value string
+			isHCL bool
+		)
+		// a string, including a heredoc, is imported as a string; anything else
+		// is imported as HCL.
+		if v, ok := stringLiteral(attr.Expr); ok {
+			value = v
+		} else {
+			value = string(attr.Expr.Range().SliceBytes(data))
+			isHCL = true
+		}
+		opts[i] = CreateVariableOptions{
+			Key:      &attr.Name,
+			Value:    &value,
+			Category: VariableCategoryPtr(CategoryTerraform),
+			HCL:      &isHCL,
+		}
+	}
+	return opts, nil
+}
+
+// stringLiteral returns the string value of an expression if it is a string
+// template without any interpolations.
+func stringLiteral(expr hclsyntax.Expression) (string, bool) {
+	if _, ok := expr.(*hclsyntax.TemplateExpr); !ok {
+		return "", false
+	}
+	v, diags := expr.Value(nil)
+	if diags.HasErrors() || v.Type() != cty.String || !v.IsKnown() || v.IsNull() {
+		return "", false
+	}
+	return v.AsString(), true
+}
+
+func parseDotEnv(data []byte) ([]CreateVariableOptions, error) {
+	var opts []CreateVariableOptions
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		line = strings.TrimPrefix(line, "export ")
+		key, value, found := strings.Cut(line, "=")
+		if !found {
+			return nil, fmt.Errorf("parsing env: line %d: missing '='", n)
+		}
+		key = strings.TrimSpace(key)
+		if key == "" {
+			return nil, fmt.Errorf("parsing env: line %d: missing key", n)
+		}
+		value = strings.TrimSpace(value)
+		switch {
+		case len(value) >= 2 && value[0] == '"' && value[len(value)-1] == '"':
+			unquoted, err := strconv.Unquote(value)
+			if err != nil {
+				return nil, fmt.Errorf("parsing env: line %d: %w", n, err)
+			}
+			value = unquoted
+		case len(value) >= 2 && value[0] == '\'' && value[len(value)-1] == '\'':
+			value = value[1 : len(value)-1]
+		}
+		opts = append(opts, CreateVariableOptions{
+			Key:      &key,
+			Value:    &value,
+			Category: VariableCategoryPtr(CategoryEnv),
+		})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("parsing env: %w", err)
+	}
+	return opts, nil
+}
+
+func parseJSON(data []byte) ([]CreateVariableOptions, error) {
+	var from []jsonVariable
+	if err := json.Unmarshal(data, &from); err != nil {
+		return nil, fmt.Errorf("parsing json: %w", err)
+	}
+	opts := make([]CreateVariableOptions, len(from))
+	for i, v := range from {
+		v := v
+		if v.Category == "" {
+			v.Category = CategoryTerraform
+		}
+		opts[i] = CreateVariableOptions{
+			Key:      &v.Key,
+			Value:    &v.Value,
+			Category: &v.Category,
+			HCL:      &v.HCL,
+		}
+		// leave the description and sensitivity of existing variables alone
+		// unless specified
+		if v.Description != "" {
+			opts[i].Description = &v.Description
+		}
+		if v.Sensitive {
+			opts[i].Sensitive = &v.Sensitive
+		}
+	}
+	return opts, nil
+}
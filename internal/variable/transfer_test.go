@@ -0,0 +1,185 @@
+package variable
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExportVariables(t *testing.T) {
+	vars := []*Variable{
+		{Key: "foo", Value: "bar", Category: CategoryTerraform},
+		{Key: "list", Value: `["a", "b"]`, Category: CategoryTerraform, HCL: true},
+		{Key: "multiline", Value: "line1\nline2", Category: CategoryTerraform},
+		{Key: "secret", Value: "s3cr3t", Category: CategoryTerraform, Sensitive: true},
+		{Key: "AWS_REGION", Value: "eu-west-2", Category: CategoryEnv},
+		{Key: "GREETING", Value: "hello world", Category: CategoryEnv},
+		{Key: "AWS_SECRET_ACCESS_KEY", Value: "s3cr3t", Category: CategoryEnv, Sensitive: true},
+	}
+
+	tests := []struct {
+		name   string
+		format Format
+		want   string
+	}{
+		{
+			name:   "tfvars",
+			format: FormatTFVars,
+			want: `foo = "bar"
+list = ["a", "b"]
+multiline = "line1\nline2"
+# secret: sensitive value omitted
+`,
+		},
+		{
+			name:   "env",
+			format: FormatDotEnv,
+			want: `AWS_REGION=eu-west-2
+# AWS_SECRET_ACCESS_KEY: sensitive value omitted
+GREETING="hello world"
+`,
+		},
+		{
+			name:   "json",
+			format: FormatJSON,
+			want: `[
+  {
+    "key": "AWS_REGION",
+    "value": "eu-west-2",
+    "category": "env",
+    "hcl": false
+  },
+  {
+    "key": "GREETING",
+    "value": "hello world",
+    "category": "env",
+    "hcl": false
+  },
+  {
+    "key": "foo",
+    "value": "bar",
+    "category": "terraform",
+    "hcl": false
+  },
+  {
+    "key": "list",
+    "value": "[\"a\", \"b\"]",
+    "category": "terraform",
+    "hcl": true
+  },
+  {
+    "key": "multiline",
+    "value": "line1\nline2",
+    "category": "terraform",
+    "hcl": false
+  }
+]
+`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := exportVariables(vars, tt.format)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, string(got))
+		})
+	}
+
+	t.Run("unknown format", func(t *testing.T) {
+		_, err := exportVariables(vars, "yaml")
+		assert.Equal(t, ErrUnknownFormat, err)
+	})
+}
+
+func TestParseVariables(t *testing.T) {
+	type want struct {
+		key      string
+		value    string
+		category VariableCategory
+		hcl      bool
+	}
+	tests := []struct {
+		name   string
+		format Format
+		data   string
+		want   []want
+	}{
+		{
+			name:   "tfvars",
+			format: FormatTFVars,
+			data: `
+foo = "bar"
+# comment
+list = ["a", "b"]
+multiline = <<EOT
+line1
+line2
+EOT
+`,
+			want: []want{
+				{"foo", "bar", CategoryTerraform, false},
+				{"list", `["a", "b"]`, CategoryTerraform, true},
+				{"multiline", "line1\nline2\n", CategoryTerraform, false},
+			},
+		},
+		{
+			name:   "env",
+			format: FormatDotEnv,
+			data: `
+# comment
+AWS_REGION=eu-west-2
+export GREETING="hello\tworld"
+SINGLE='quoted'
+EMPTY=
+`,
+			want: []want{
+				{"AWS_REGION", "eu-west-2", CategoryEnv, false},
+				{"GREETING", "hello\tworld", CategoryEnv, false},
+				{"SINGLE", "quoted", CategoryEnv, false},
+				{"EMPTY", "", CategoryEnv, false},
+			},
+		},
+		{
+			name:   "json",
+			format: FormatJSON,
+			data: `[
+				{"key": "foo", "value": "bar"},
+				{"key": "list", "value": "[1, 2]", "hcl": true},
+				{"key": "AWS_REGION", "value": "eu-west-2", "category": "env"}
+			]`,
+			want: []want{
+				{"foo", "bar", CategoryTerraform, false},
+				{"list", "[1, 2]", CategoryTerraform, true},
+				{"AWS_REGION", "eu-west-2", CategoryEnv, false},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts, err := parseVariables([]byte(tt.data), tt.format)
+			require.NoError(t, err)
+			require.Equal(t, len(tt.want), len(opts))
+			for i, want := range tt.want {
+				assert.Equal(t, want.key, *opts[i].Key)
+				assert.Equal(t, want.value, *opts[i].Value)
+				assert.Equal(t, want.category, *opts[i].Category)
+				if opts[i].HCL != nil {
+					assert.Equal(t, want.hcl, *opts[i].HCL)
+				} else {
+					assert.False(t, want.hcl)
+				}
+			}
+		})
+	}
+
+	t.Run("malformed tfvars", func(t *testing.T) {
+		_, err := parseVariables([]byte(`foo = `), FormatTFVars)
+		assert.Error(t, err)
+	})
+
+	t.Run("malformed env", func(t *testing.T) {
+		_, err := parseVariables([]byte("FOO"), FormatDotEnv)
+		assert.Error(t, err)
+	})
+}
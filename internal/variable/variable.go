@@ -12,7 +12,7 @@ import (
 
 	"log/slog"
 
-	"github.com/leg100/otf/internal"
+	"github.com/leg100/otf/internal/resource"
 	"github.com/leg100/otf/internal/run"
 	"golang.org/x/exp/maps"
 )
@@ -79,7 +79,7 @@ type (
 
 func newVariable(collection []*Variable, opts CreateVariableOptions) (*Variable, error) {
 	v := Variable{
-		ID: internal.NewID("var"),
+		ID: resource.NewID(resource.VariableKind),
 	}
 	if opts.generateVersion == nil {
 		opts.generateVersion = versionGenerator
@@ -311,29 +311,51 @@ func WriteTerraformVars(dir string, vars []*Variable) error {
 //
 // Note: If run is nil then it is ignored.
 func mergeVariables(workspaceSets []*VariableSet, workspaceVariables []*Variable, run *run.Run) []*Variable {
+	resolutions := resolveVariables(workspaceSets, workspaceVariables, run)
+	merged := make([]*Variable, len(resolutions))
+	for i, r := range resolutions {
+		merged[i] = r.variable
+	}
+	return merged
+}
+
+// resolution is a variable resolved for a run, along with its source and the
+// sources of any variables with the same key and category that it overrode.
+type resolution struct {
+	variable   *Variable
+	source     VariableSource
+	overridden []VariableSource
+}
+
+// resolveVariables resolves variables for a run according to the precedence
+// rules, recording where each variable came from. See mergeVariables.
+func resolveVariables(workspaceSets []*VariableSet, workspaceVariables []*Variable, run *run.Run) []*resolution {
 	// terraform variables keyed by variable key
-	tfVars := make(map[string]*Variable)
+	tfVars := make(map[string]*resolution)
 	// environment variables keyed by variable key
-	envVars := make(map[string]*Variable)
-
-	// global sets have lowest precedence
-	for _, s := range workspaceSets {
-		if s.Global {
-			for _, v := range s.Variables {
-				switch v.Category {
-				case CategoryTerraform:
-					tfVars[v.Key] = v
-				case CategoryEnv:
-					envVars[v.Key] = v
-				}
-			}
+	envVars := make(map[string]*resolution)
+
+	add := func(v *Variable, src VariableSource) {
+		var vars map[string]*resolution
+		switch v.Category {
+		case CategoryTerraform:
+			vars = tfVars
+		case CategoryEnv:
+			vars = envVars
+		default:
+			return
+		}
+		r := &resolution{variable: v, source: src}
+		if existing, ok := vars[v.Key]; ok {
+			r.overridden = append(existing.overridden, existing.source)
 		}
+		vars[v.Key] = r
 	}
 
-	// workspace-scoped sets have next lowest precedence; lexical order of the
-	// set name determines precedence if a variable with the same key is found
-	// in more than one set, e.g. variable foo in set named A takes precedence
-	// over variable foo in set named B.
+	// workspace-scoped sets take precedence over global sets; lexical order of
+	// the set name determines precedence if a variable with the same key is
+	// found in more than one workspace-scoped set, e.g. variable foo in set
+	// named A takes precedence over variable foo in set named B.
 	//
 	// sort sets by lexical order, A->Z
 	slices.SortFunc(workspaceSets, func(a, b *VariableSet) int {
@@ -347,37 +369,61 @@ func mergeVariables(workspaceSets []*VariableSet, workspaceVariables []*Variable
 	})
 	// reverse order sets (Z->A), so that sets later in the slice take precedence.
 	slices.Reverse(workspaceSets)
-	for _, s := range workspaceSets {
-		if s.Global {
-			continue
-		}
+	addSet := func(s *VariableSet) {
 		for _, v := range s.Variables {
-			switch v.Category {
-			case CategoryTerraform:
-				tfVars[v.Key] = v
-			case CategoryEnv:
-				envVars[v.Key] = v
+			add(v, VariableSource{
+				Kind:            SourceVariableSet,
+				VariableID:      v.ID,
+				VariableSetID:   s.ID,
+				VariableSetName: s.Name,
+				Global:          s.Global,
+				Priority:        s.Priority,
+			})
+		}
+	}
+	addSets := func(priority bool) {
+		for _, s := range workspaceSets {
+			if s.Global && s.Priority == priority {
+				addSet(s)
+			}
+		}
+		for _, s := range workspaceSets {
+			if !s.Global && s.Priority == priority {
+				addSet(s)
 			}
 		}
 	}
 
-	// workspace variables have higher precedence than sets, so override
-	// anything from sets
+	// non-priority sets have lowest precedence
+	addSets(false)
+
+	// workspace variables have higher precedence than non-priority sets, so
+	// override anything from those sets
 	for _, v := range workspaceVariables {
-		switch v.Category {
-		case CategoryTerraform:
-			tfVars[v.Key] = v
-		case CategoryEnv:
-			envVars[v.Key] = v
-		}
+		add(v, VariableSource{Kind: SourceWorkspace, VariableID: v.ID})
 	}
 
-	// run variables have highest precedence
+	// run variables have higher precedence still
 	if run != nil {
 		for _, v := range run.Variables {
-			tfVars[v.Key] = &Variable{Key: v.Key, Value: v.Value, Category: CategoryTerraform, HCL: true}
+			add(&Variable{Key: v.Key, Value: v.Value, Category: CategoryTerraform, HCL: true}, VariableSource{Kind: SourceRun})
 		}
 	}
 
+	// priority sets have highest precedence, overriding even workspace and
+	// run variables
+	addSets(true)
+
 	return append(maps.Values(tfVars), maps.Values(envVars)...)
 }
+
+// findVariable returns the variable in the collection with the given key and
+// category, or nil if there is no such variable.
+func findVariable(collection []*Variable, key string, category VariableCategory) *Variable {
+	for _, v := range collection {
+		if v.Key == key && v.Category == category {
+			return v
+		}
+	}
+	return nil
+}
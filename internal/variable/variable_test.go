@@ -322,6 +322,74 @@ func Test_mergeVariables(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "priority set overrides workspace and run variables",
+			sets: []*VariableSet{
+				{
+					Name:     "global priority",
+					Global:   true,
+					Priority: true,
+					Variables: []*Variable{
+						{
+							Key:      "foo",
+							Value:    "priority",
+							Category: CategoryTerraform,
+						},
+					},
+				},
+			},
+			workspaceVariables: []*Variable{
+				{
+					Key:      "foo",
+					Value:    "workspace",
+					Category: CategoryTerraform,
+				},
+			},
+			run: run.Run{WorkspaceID: "ws-123", Variables: []run.Variable{{Key: "foo", Value: "run"}}},
+			want: []*Variable{
+				{
+					Key:      "foo",
+					Value:    "priority",
+					Category: CategoryTerraform,
+				},
+			},
+		},
+		{
+			name: "workspace-scoped priority set overrides global priority set",
+			sets: []*VariableSet{
+				{
+					Name:     "a - global priority",
+					Global:   true,
+					Priority: true,
+					Variables: []*Variable{
+						{
+							Key:      "foo",
+							Value:    "global",
+							Category: CategoryTerraform,
+						},
+					},
+				},
+				{
+					Name:       "b - workspace-scoped priority",
+					Priority:   true,
+					Workspaces: []string{"ws-123"},
+					Variables: []*Variable{
+						{
+							Key:      "foo",
+							Value:    "workspace-scoped",
+							Category: CategoryTerraform,
+						},
+					},
+				},
+			},
+			want: []*Variable{
+				{
+					Key:      "foo",
+					Value:    "workspace-scoped",
+					Category: CategoryTerraform,
+				},
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -370,6 +370,7 @@ func (h *web) createVariableSet(w http.ResponseWriter, r *http.Request) {
 		Name           *string `schema:"name,required"`
 		Description    string
 		Global         bool
+		Priority       bool
 		Organization   string `schema:"organization_name,required"`
 		WorkspacesJSON string `schema:"workspaces"`
 	}
@@ -392,6 +393,7 @@ func (h *web) createVariableSet(w http.ResponseWriter, r *http.Request) {
 		Name:        *params.Name,
 		Description: params.Description,
 		Global:      params.Global,
+		Priority:    params.Priority,
 		Workspaces:  workspaceIDs,
 	})
 	if err != nil {
@@ -476,6 +478,7 @@ func (h *web) updateVariableSet(w http.ResponseWriter, r *http.Request) {
 		Name           *string
 		Description    *string
 		Global         *bool
+		Priority       bool
 		WorkspacesJSON string `schema:"workspaces"`
 	}
 	if err := decode.All(&params, r); err != nil {
@@ -497,6 +500,7 @@ func (h *web) updateVariableSet(w http.ResponseWriter, r *http.Request) {
 		Name:        params.Name,
 		Description: params.Description,
 		Global:      params.Global,
+		Priority:    &params.Priority,
 		Workspaces:  workspaceIDs,
 	})
 	if err != nil {
@@ -1,39 +1,310 @@
 package vcs
 
 import (
+	"context"
+	"errors"
+	"fmt"
 	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	"golang.org/x/time/rate"
+)
+
+const (
+	// DefaultEventRateLimit is the default maximum number of events per minute
+	// processed for each repository.
+	DefaultEventRateLimit = 60
+	// maxEventAttempts is the number of times a subscriber is invoked to
+	// handle an event before the event is dead-lettered.
+	maxEventAttempts = 3
+	// maxEventDelay is the maximum time an event waits to be processed when
+	// its repository is rate limited. If it would wait any longer then the
+	// event is dead-lettered instead, so that a flood of events for one
+	// repository cannot build an unbounded backlog.
+	maxEventDelay = 5 * time.Minute
+	// limiterIdleTTL is the period after which the rate limiter for a
+	// repository that has not received an event is discarded. By then any
+	// delayed event has been processed and the limiter has been replenished,
+	// so discarding it is no different from keeping it.
+	limiterIdleTTL = maxEventDelay + time.Minute
+)
+
+var (
+	// ErrEventRateLimited is the reason given for dead-lettering an event
+	// that exceeded the rate limit for its repository.
+	ErrEventRateLimited = errors.New("rate limit for repository exceeded")
+
+	// eventRetryBackoff is the delay before a subscriber is invoked a second
+	// time to handle an event, doubling with each subsequent attempt.
+	eventRetryBackoff = time.Second
 )
 
 type (
 	// Broker is a brokerage for publishers and subscribers of VCS events.
+	//
+	// Each event is processed in the background: redeliveries are discarded,
+	// the event is queued for each subscriber, events for the same repository
+	// are rate limited, and each subscriber is retried should it fail to
+	// handle an event, before the event is dead-lettered.
 	Broker struct {
-		subscribers []func(event Event)
-		mu          sync.RWMutex
+		logr.Logger
+
+		// Deliveries records the delivery of events, so that redeliveries are
+		// discarded. Optional.
+		Deliveries DeliveryRecorder
+		// DeadLetters receives events that subscribers failed to handle.
+		// Optional.
+		DeadLetters DeadLetterer
+		// Queue persists events until they have been handled, so that they
+		// can be resumed should otfd stop beforehand. Optional.
+		Queue Queue
+		// RateLimit is the maximum number of events per minute processed for
+		// each repository. Zero disables rate limiting.
+		RateLimit int
+
+		subscribers []subscriber
+		limiters    map[string]*limiter
+		// lastSweep is when idle limiters were last discarded
+		lastSweep time.Time
+		mu        sync.RWMutex
 	}
 
-	Callback func(event Event)
+	// limiter rate limits the events for a repository
+	limiter struct {
+		*rate.Limiter
+		// lastUsed is when the repository last received an event
+		lastUsed time.Time
+	}
+
+	// Callback handles an event, returning an error if it should be retried.
+	Callback func(event Event) error
+
+	subscriber struct {
+		name string
+		cb   Callback
+	}
 
 	Subscriber interface {
-		Subscribe(cb Callback)
+		// Subscribe registers a callback to be invoked for each event. The
+		// name uniquely identifies the subscriber, for the purposes of
+		// redelivering events to it.
+		Subscribe(name string, cb Callback)
 	}
 
 	Publisher interface {
 		Publish(Event)
 	}
+
+	// DeliveryRecorder records the delivery of events.
+	DeliveryRecorder interface {
+		// RecordDelivery records the delivery of an event, returning false if
+		// the delivery has already been recorded.
+		RecordDelivery(ctx context.Context, event Event) (bool, error)
+	}
+
+	// DeadLetterer receives events that a subscriber failed to handle.
+	DeadLetterer interface {
+		DeadLetter(ctx context.Context, subscriber string, event Event, reason error) error
+	}
+
+	// Queue persists events pending delivery to subscribers.
+	Queue interface {
+		// Enqueue persists an event pending delivery to a subscriber,
+		// returning an ID with which to acknowledge its delivery.
+		Enqueue(ctx context.Context, subscriber string, event Event) (string, error)
+		// Ack removes an event from the queue once the subscriber has
+		// handled it or it has been dead-lettered.
+		Ack(ctx context.Context, id string) error
+	}
 )
 
-func (b *Broker) Subscribe(cb Callback) {
+func (b *Broker) Subscribe(name string, cb Callback) {
 	b.mu.Lock()
 	defer b.mu.Unlock()
 
-	b.subscribers = append(b.subscribers, cb)
+	b.subscribers = append(b.subscribers, subscriber{name: name, cb: cb})
 }
 
+// Publish processes an event in the background.
 func (b *Broker) Publish(event Event) {
+	go b.process(context.Background(), event)
+}
+
+// Redeliver delivers an event to the named subscriber only, bypassing
+// deduplication and rate limiting. The event is queued and then handled in the
+// background.
+func (b *Broker) Redeliver(name string, event Event) error {
+	sub, err := b.getSubscriber(name)
+	if err != nil {
+		return err
+	}
+	ctx := context.Background()
+	go b.deliver(ctx, sub, b.enqueue(ctx, sub, event), event)
+	return nil
+}
+
+// Resume delivers an event that has already been queued to the named
+// subscriber, e.g. because otfd stopped before the subscriber handled it. The
+// event is handled in the background.
+func (b *Broker) Resume(id, name string, event Event) error {
+	sub, err := b.getSubscriber(name)
+	if err != nil {
+		return err
+	}
+	go b.deliver(context.Background(), sub, id, event)
+	return nil
+}
+
+func (b *Broker) getSubscriber(name string) (subscriber, error) {
 	b.mu.RLock()
 	defer b.mu.RUnlock()
 
 	for _, sub := range b.subscribers {
-		go sub(event)
+		if sub.name == name {
+			return sub, nil
+		}
+	}
+	return subscriber{}, fmt.Errorf("no such vcs event subscriber: %s", name)
+}
+
+func (b *Broker) process(ctx context.Context, event Event) {
+	logger := b.WithValues("vcs_provider_id", event.VCSProviderID, "repo", event.RepoPath, "delivery_id", event.DeliveryID)
+
+	if b.Deliveries != nil && event.DeliveryID != "" {
+		first, err := b.Deliveries.RecordDelivery(ctx, event)
+		if err != nil {
+			// better to risk handling a redelivery than to drop an event
+			logger.Error(err, "recording vcs event delivery")
+		} else if !first {
+			logger.V(2).Info("ignoring redelivered vcs event")
+			return
+		}
+	}
+
+	b.mu.RLock()
+	subscribers := make([]subscriber, len(b.subscribers))
+	copy(subscribers, b.subscribers)
+	b.mu.RUnlock()
+
+	// queue the event before waiting on the rate limit, so that it is not
+	// lost should otfd stop in the meantime.
+	ids := make([]string, len(subscribers))
+	for i, sub := range subscribers {
+		ids[i] = b.enqueue(ctx, sub, event)
+	}
+
+	if err := b.wait(ctx, event); err != nil {
+		logger.Error(err, "processing vcs event")
+		for i, sub := range subscribers {
+			b.deadLetter(ctx, sub, ids[i], event, err)
+		}
+		return
+	}
+	for i, sub := range subscribers {
+		go b.deliver(ctx, sub, ids[i], event)
+	}
+}
+
+// enqueue queues an event for delivery to a subscriber, returning the ID of the
+// queued event, or an empty string if it was not queued.
+func (b *Broker) enqueue(ctx context.Context, sub subscriber, event Event) string {
+	if b.Queue == nil {
+		return ""
+	}
+	id, err := b.Queue.Enqueue(ctx, sub.name, event)
+	if err != nil {
+		// better to deliver the event without a guarantee than not at all
+		b.Error(err, "queueing vcs event", "subscriber", sub.name, "vcs_provider_id", event.VCSProviderID, "repo", event.RepoPath)
+		return ""
+	}
+	return id
+}
+
+// ack acknowledges the delivery of a queued event.
+func (b *Broker) ack(ctx context.Context, sub subscriber, id string, event Event) {
+	if b.Queue == nil || id == "" {
+		return
+	}
+	if err := b.Queue.Ack(ctx, id); err != nil {
+		b.Error(err, "acknowledging vcs event", "subscriber", sub.name, "vcs_provider_id", event.VCSProviderID, "repo", event.RepoPath)
+	}
+}
+
+// wait waits until the rate limit for the event's repository permits the event
+// to be processed.
+func (b *Broker) wait(ctx context.Context, event Event) error {
+	if b.RateLimit <= 0 {
+		return nil
+	}
+	reservation := b.limiter(event.VCSProviderID+"/"+event.RepoPath, time.Now()).Reserve()
+	if delay := reservation.Delay(); delay > maxEventDelay {
+		reservation.Cancel()
+		return ErrEventRateLimited
+	} else if delay > 0 {
+		return sleep(ctx, delay)
+	}
+	return nil
+}
+
+// limiter retrieves the rate limiter for a repository, creating it if it does
+// not exist, and discarding the limiters of repositories that have been idle
+// for longer than limiterIdleTTL, so that limiters do not accumulate for
+// repositories that no longer receive events.
+func (b *Broker) limiter(key string, now time.Time) *rate.Limiter {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.limiters == nil {
+		b.limiters = make(map[string]*limiter)
+		b.lastSweep = now
+	}
+	if now.Sub(b.lastSweep) > limiterIdleTTL {
+		for k, l := range b.limiters {
+			if now.Sub(l.lastUsed) > limiterIdleTTL {
+				delete(b.limiters, k)
+			}
+		}
+		b.lastSweep = now
+	}
+	l, ok := b.limiters[key]
+	if !ok {
+		l = &limiter{Limiter: rate.NewLimiter(rate.Limit(float64(b.RateLimit)/60), b.RateLimit)}
+		b.limiters[key] = l
+	}
+	l.lastUsed = now
+	return l.Limiter
+}
+
+// deliver invokes the subscriber to handle the event, retrying with backoff
+// should it fail, and dead-lettering the event should every attempt fail. The
+// queued event is acknowledged once it has been handled or dead-lettered.
+func (b *Broker) deliver(ctx context.Context, sub subscriber, id string, event Event) {
+	var err error
+	for attempt := 0; attempt < maxEventAttempts; attempt++ {
+		if attempt > 0 {
+			if err := sleep(ctx, eventRetryBackoff<<(attempt-1)); err != nil {
+				return
+			}
+		}
+		if err = sub.cb(event); err == nil {
+			b.ack(ctx, sub, id, event)
+			return
+		}
+	}
+	b.Error(err, "handling vcs event", "subscriber", sub.name, "attempts", maxEventAttempts, "vcs_provider_id", event.VCSProviderID, "repo", event.RepoPath)
+	b.deadLetter(ctx, sub, id, event, err)
+}
+
+// deadLetter dead-letters a queued event. The event is only removed from the
+// queue if it is successfully dead-lettered, otherwise it is left to be
+// resumed.
+func (b *Broker) deadLetter(ctx context.Context, sub subscriber, id string, event Event, reason error) {
+	if b.DeadLetters != nil {
+		if err := b.DeadLetters.DeadLetter(ctx, sub.name, event, reason); err != nil {
+			b.Error(err, "dead-lettering vcs event", "subscriber", sub.name, "vcs_provider_id", event.VCSProviderID, "repo", event.RepoPath)
+			return
+		}
 	}
+	b.ack(ctx, sub, id, event)
 }
@@ -0,0 +1,284 @@
+package vcs
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBroker(t *testing.T) {
+	eventRetryBackoff = time.Millisecond
+	t.Cleanup(func() { eventRetryBackoff = time.Second })
+
+	event := Event{EventHeader: EventHeader{VCSProviderID: "vcs-123"}, EventPayload: EventPayload{RepoPath: "leg100/otf", DeliveryID: "delivery-1"}}
+
+	t.Run("deliver to all subscribers", func(t *testing.T) {
+		broker := &Broker{Logger: logr.Discard()}
+		got := make(chan string, 2)
+		broker.Subscribe("a", func(Event) error { got <- "a"; return nil })
+		broker.Subscribe("b", func(Event) error { got <- "b"; return nil })
+
+		broker.Publish(event)
+
+		assert.ElementsMatch(t, []string{"a", "b"}, []string{receive(t, got), receive(t, got)})
+	})
+
+	t.Run("ignore redelivery", func(t *testing.T) {
+		broker := &Broker{Logger: logr.Discard(), Deliveries: &fakeDeliveryRecorder{}}
+		got := make(chan Event, 2)
+		broker.Subscribe("a", func(e Event) error { got <- e; return nil })
+
+		broker.Publish(event)
+		receive(t, got)
+
+		broker.Publish(event)
+		select {
+		case <-got:
+			t.Fatal("redelivered event should have been ignored")
+		case <-time.After(100 * time.Millisecond):
+		}
+	})
+
+	t.Run("retry until handled", func(t *testing.T) {
+		deadLetters := &fakeDeadLetterer{}
+		broker := &Broker{Logger: logr.Discard(), DeadLetters: deadLetters}
+		attempts := make(chan int, maxEventAttempts)
+		var n int
+		broker.Subscribe("a", func(Event) error {
+			n++
+			attempts <- n
+			if n < maxEventAttempts {
+				return errors.New("transient error")
+			}
+			return nil
+		})
+
+		broker.Publish(event)
+
+		for i := 1; i <= maxEventAttempts; i++ {
+			assert.Equal(t, i, receive(t, attempts))
+		}
+		assert.Empty(t, deadLetters.get())
+	})
+
+	t.Run("dead-letter after retries", func(t *testing.T) {
+		deadLetters := &fakeDeadLetterer{done: make(chan struct{}, 1)}
+		broker := &Broker{Logger: logr.Discard(), DeadLetters: deadLetters}
+		var attempts int
+		broker.Subscribe("a", func(Event) error {
+			attempts++
+			return errors.New("permanent error")
+		})
+		broker.Subscribe("b", func(Event) error { return nil })
+
+		broker.Publish(event)
+		receive(t, deadLetters.done)
+
+		assert.Equal(t, maxEventAttempts, attempts)
+		require.Equal(t, 1, len(deadLetters.get()))
+		assert.Equal(t, "a", deadLetters.get()[0].subscriber)
+		assert.EqualError(t, deadLetters.get()[0].reason, "permanent error")
+	})
+
+	t.Run("dead-letter rate limited event", func(t *testing.T) {
+		deadLetters := &fakeDeadLetterer{done: make(chan struct{}, 1)}
+		broker := &Broker{Logger: logr.Discard(), DeadLetters: deadLetters, RateLimit: 1}
+		got := make(chan Event, 1)
+		broker.Subscribe("a", func(e Event) error { got <- e; return nil })
+
+		broker.Publish(event)
+		receive(t, got)
+		// a second event is processed no sooner than a minute later, which is
+		// within the maximum delay, and a further event would be delayed
+		// beyond it.
+		for i := 0; i < int(maxEventDelay/time.Minute); i++ {
+			broker.Publish(event)
+		}
+		broker.Publish(event)
+		receive(t, deadLetters.done)
+
+		require.Equal(t, 1, len(deadLetters.get()))
+		assert.ErrorIs(t, deadLetters.get()[0].reason, ErrEventRateLimited)
+	})
+
+	t.Run("discard idle rate limiters", func(t *testing.T) {
+		broker := &Broker{Logger: logr.Discard(), RateLimit: 1}
+		now := time.Now()
+
+		idle := broker.limiter("vcs-1/idle", now)
+		busy := broker.limiter("vcs-1/busy", now)
+		assert.Equal(t, 2, len(broker.limiters))
+
+		// the busy repository keeps its limiter whereas the idle repository's
+		// limiter is discarded.
+		now = now.Add(limiterIdleTTL / 2)
+		assert.Same(t, busy, broker.limiter("vcs-1/busy", now))
+		now = now.Add(limiterIdleTTL/2 + time.Second)
+		assert.Same(t, busy, broker.limiter("vcs-1/busy", now))
+		assert.Equal(t, 1, len(broker.limiters))
+
+		assert.NotSame(t, idle, broker.limiter("vcs-1/idle", now))
+	})
+
+	t.Run("redeliver to named subscriber", func(t *testing.T) {
+		broker := &Broker{Logger: logr.Discard()}
+		got := make(chan string, 2)
+		broker.Subscribe("a", func(Event) error { got <- "a"; return nil })
+		broker.Subscribe("b", func(Event) error { got <- "b"; return nil })
+
+		err := broker.Redeliver("b", event)
+		require.NoError(t, err)
+		assert.Equal(t, "b", receive(t, got))
+
+		err = broker.Redeliver("c", event)
+		assert.Error(t, err)
+	})
+
+	t.Run("acknowledge handled event", func(t *testing.T) {
+		queue := &fakeQueue{acked: make(chan string, 2)}
+		broker := &Broker{Logger: logr.Discard(), Queue: queue}
+		broker.Subscribe("a", func(Event) error { return nil })
+		broker.Subscribe("b", func(Event) error { return nil })
+
+		broker.Publish(event)
+
+		assert.ElementsMatch(t, []string{"queued-a", "queued-b"}, []string{receive(t, queue.acked), receive(t, queue.acked)})
+	})
+
+	t.Run("acknowledge dead-lettered event", func(t *testing.T) {
+		queue := &fakeQueue{acked: make(chan string, 1)}
+		broker := &Broker{Logger: logr.Discard(), Queue: queue, DeadLetters: &fakeDeadLetterer{}}
+		broker.Subscribe("a", func(Event) error { return errors.New("permanent error") })
+
+		broker.Publish(event)
+
+		assert.Equal(t, "queued-a", receive(t, queue.acked))
+	})
+
+	t.Run("leave event queued when dead-lettering fails", func(t *testing.T) {
+		queue := &fakeQueue{acked: make(chan string, 1)}
+		deadLetters := &fakeDeadLetterer{done: make(chan struct{}, 1), err: errors.New("database unavailable")}
+		broker := &Broker{Logger: logr.Discard(), Queue: queue, DeadLetters: deadLetters}
+		broker.Subscribe("a", func(Event) error { return errors.New("permanent error") })
+
+		broker.Publish(event)
+		receive(t, deadLetters.done)
+
+		select {
+		case <-queue.acked:
+			t.Fatal("event should have been left on the queue")
+		case <-time.After(100 * time.Millisecond):
+		}
+	})
+
+	t.Run("resume queued event", func(t *testing.T) {
+		queue := &fakeQueue{acked: make(chan string, 1)}
+		broker := &Broker{Logger: logr.Discard(), Queue: queue}
+		got := make(chan string, 2)
+		broker.Subscribe("a", func(Event) error { got <- "a"; return nil })
+		broker.Subscribe("b", func(Event) error { got <- "b"; return nil })
+
+		err := broker.Resume("queued-123", "b", event)
+		require.NoError(t, err)
+		assert.Equal(t, "b", receive(t, got))
+		assert.Equal(t, "queued-123", receive(t, queue.acked))
+		assert.Empty(t, queue.queued)
+
+		err = broker.Resume("queued-123", "c", event)
+		assert.Error(t, err)
+	})
+}
+
+func receive[T any](t *testing.T, ch <-chan T) T {
+	t.Helper()
+
+	select {
+	case v := <-ch:
+		return v
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for vcs event")
+	}
+	var v T
+	return v
+}
+
+type (
+	fakeDeliveryRecorder struct {
+		seen map[string]bool
+		mu   sync.Mutex
+	}
+
+	fakeDeadLetterer struct {
+		deadLetters []fakeDeadLetter
+		done        chan struct{}
+		err         error
+		mu          sync.Mutex
+	}
+
+	fakeQueue struct {
+		queued []string
+		acked  chan string
+		mu     sync.Mutex
+	}
+
+	fakeDeadLetter struct {
+		subscriber string
+		reason     error
+	}
+)
+
+func (f *fakeDeliveryRecorder) RecordDelivery(_ context.Context, event Event) (bool, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.seen == nil {
+		f.seen = make(map[string]bool)
+	}
+	key := event.VCSProviderID + "/" + event.DeliveryID
+	if f.seen[key] {
+		return false, nil
+	}
+	f.seen[key] = true
+	return true, nil
+}
+
+func (f *fakeDeadLetterer) DeadLetter(_ context.Context, subscriber string, _ Event, reason error) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.done != nil {
+		defer func() { f.done <- struct{}{} }()
+	}
+	if f.err != nil {
+		return f.err
+	}
+	f.deadLetters = append(f.deadLetters, fakeDeadLetter{subscriber: subscriber, reason: reason})
+	return nil
+}
+
+func (f *fakeDeadLetterer) get() []fakeDeadLetter {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return f.deadLetters
+}
+
+func (f *fakeQueue) Enqueue(_ context.Context, subscriber string, _ Event) (string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	id := "queued-" + subscriber
+	f.queued = append(f.queued, id)
+	return id, nil
+}
+
+func (f *fakeQueue) Ack(_ context.Context, id string) error {
+	f.acked <- id
+	return nil
+}
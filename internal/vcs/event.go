@@ -52,6 +52,11 @@ type (
 	EventPayload struct {
 		RepoPath string
 
+		// DeliveryID uniquely identifies the delivery of the event by the
+		// provider; a redelivery of the event retains the same ID. Empty if
+		// the provider does not identify deliveries.
+		DeliveryID string
+
 		VCSKind Kind
 
 		Type          EventType
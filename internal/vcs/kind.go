@@ -1,10 +1,16 @@
 package vcs
 
 const (
-	GithubKind Kind = "github"
-	GitlabKind Kind = "gitlab"
+	GithubKind          Kind = "github"
+	GitlabKind          Kind = "gitlab"
+	BitbucketKind       Kind = "bitbucket"
+	BitbucketServerKind Kind = "bitbucket-server"
+	GiteaKind           Kind = "gitea"
 )
 
+// Kinds are all the kinds of vcs hosting provider
+var Kinds = []Kind{GithubKind, GitlabKind, BitbucketKind, BitbucketServerKind, GiteaKind}
+
 // Kind of vcs hosting provider
 type Kind string
 
@@ -0,0 +1,275 @@
+package vcs
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	// maxRateLimitRetries is the maximum number of times a rate limited
+	// request is retried.
+	maxRateLimitRetries = 3
+	// maxRateLimitWait is the maximum time a request waits for a rate limit to
+	// reset. If the reset is further away than this then the request fails
+	// immediately rather than blocking for an unreasonable length of time.
+	maxRateLimitWait = 5 * time.Minute
+	// rateLimitLowWatermark is the fraction of the quota below which requests
+	// are spaced out evenly across the remainder of the rate limit window.
+	rateLimitLowWatermark = 0.1
+)
+
+// ErrRateLimited is returned when a request is rate limited by the provider
+// and the rate limit does not reset within a reasonable length of time.
+var ErrRateLimited = errors.New("rate limit exceeded")
+
+var (
+	rateLimitRemaining = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "otf",
+		Subsystem: "vcs_ratelimit",
+		Name:      "remaining",
+		Help:      "Number of requests remaining in the current rate limit window.",
+	}, []string{"hostname", "limiter"})
+	rateLimitRetries = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "otf",
+		Subsystem: "vcs_ratelimit",
+		Name:      "retries_total",
+		Help:      "Number of requests retried after being rate limited.",
+	}, []string{"hostname", "limiter"})
+
+	// limiters are shared between clients using the same credentials, keyed
+	// by hostname and a digest of the credentials.
+	limiters   = make(map[string]*rateLimiter)
+	limitersMu sync.Mutex
+)
+
+func init() {
+	prometheus.MustRegister(rateLimitRemaining)
+	prometheus.MustRegister(rateLimitRetries)
+}
+
+// NewRateLimitTransport returns a transport that honors the rate limit headers
+// returned by a VCS provider. Requests are delayed when the quota is running
+// low, and rate limited requests are retried once the limit resets. State is
+// shared between all transports for the same hostname and credentials, which
+// can be anything identifying the credentials, e.g. a token or an app ID.
+func NewRateLimitTransport(base http.RoundTripper, hostname, credentials string) http.RoundTripper {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return &rateLimitTransport{
+		base:    base,
+		limiter: getRateLimiter(hostname, credentials),
+		sleep:   sleep,
+	}
+}
+
+type rateLimitTransport struct {
+	base    http.RoundTripper
+	limiter *rateLimiter
+	// sleep is overridden in tests
+	sleep func(context.Context, time.Duration) error
+}
+
+func (t *rateLimitTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	for attempt := 0; ; attempt++ {
+		if delay := t.limiter.delay(time.Now()); delay > 0 {
+			if delay > maxRateLimitWait {
+				return nil, fmt.Errorf("%w: resets in %s", ErrRateLimited, delay.Round(time.Second))
+			}
+			if err := t.sleep(req.Context(), delay); err != nil {
+				return nil, err
+			}
+		}
+		resp, err := t.base.RoundTrip(req)
+		if err != nil {
+			return nil, err
+		}
+		t.limiter.update(resp.Header, time.Now())
+
+		if !isRateLimited(resp) || attempt == maxRateLimitRetries {
+			return resp, nil
+		}
+		// the request can only be retried if its body can be re-read.
+		if req.Body != nil && req.Body != http.NoBody {
+			if req.GetBody == nil {
+				return resp, nil
+			}
+			body, err := req.GetBody()
+			if err != nil {
+				return resp, nil
+			}
+			req = req.Clone(req.Context())
+			req.Body = body
+		}
+		t.limiter.block(resp.Header, attempt, time.Now())
+		rateLimitRetries.WithLabelValues(t.limiter.hostname, t.limiter.id).Inc()
+		resp.Body.Close()
+	}
+}
+
+// rateLimiter tracks the rate limit for a set of credentials.
+type rateLimiter struct {
+	hostname string
+	// id is a non-secret identifier for the credentials, for use in metrics.
+	id string
+
+	mu        sync.Mutex
+	limit     int
+	remaining int
+	reset     time.Time
+	// blockedUntil is set after a request is rate limited, and no requests
+	// are made until this time.
+	blockedUntil time.Time
+}
+
+func getRateLimiter(hostname, credentials string) *rateLimiter {
+	digest := sha256.Sum256([]byte(credentials))
+	id := hex.EncodeToString(digest[:])[:8]
+	key := hostname + "/" + id
+
+	limitersMu.Lock()
+	defer limitersMu.Unlock()
+
+	if limiter, ok := limiters[key]; ok {
+		return limiter
+	}
+	limiter := &rateLimiter{hostname: hostname, id: id, remaining: -1}
+	limiters[key] = limiter
+	return limiter
+}
+
+// delay returns how long to wait before making the next request.
+func (l *rateLimiter) delay(now time.Time) time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if now.Before(l.blockedUntil) {
+		return l.blockedUntil.Sub(now)
+	}
+	// nothing to go on if the provider has not yet reported a rate limit, or
+	// the current window has passed.
+	if l.remaining < 0 || !now.Before(l.reset) {
+		return 0
+	}
+	untilReset := l.reset.Sub(now)
+	if l.remaining == 0 {
+		return untilReset
+	}
+	// space out requests evenly across the remainder of the window when the
+	// quota is running low.
+	if l.limit > 0 && float64(l.remaining) < float64(l.limit)*rateLimitLowWatermark {
+		return untilReset / time.Duration(l.remaining+1)
+	}
+	return 0
+}
+
+// update updates the rate limit from the headers of a response.
+func (l *rateLimiter) update(header http.Header, now time.Time) {
+	remaining, ok := parseRateLimitHeader(header, "Remaining")
+	if !ok {
+		return
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.remaining = remaining
+	if limit, ok := parseRateLimitHeader(header, "Limit"); ok {
+		l.limit = limit
+	}
+	if reset, ok := parseRateLimitHeader(header, "Reset"); ok {
+		l.reset = time.Unix(int64(reset), 0)
+	} else {
+		l.reset = time.Time{}
+	}
+	rateLimitRemaining.WithLabelValues(l.hostname, l.id).Set(float64(remaining))
+}
+
+// block prevents requests from being made until a rate limited request can be
+// retried.
+func (l *rateLimiter) block(header http.Header, attempt int, now time.Time) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	var until time.Time
+	switch {
+	case header.Get("Retry-After") != "":
+		until = parseRetryAfter(header.Get("Retry-After"), now)
+	case l.remaining == 0 && now.Before(l.reset):
+		until = l.reset
+	default:
+		// no indication from the provider as to when to retry, so backoff
+		// exponentially: 1s, 2s, 4s, etc.
+		until = now.Add(time.Second << attempt)
+	}
+	if until.After(l.blockedUntil) {
+		l.blockedUntil = until
+	}
+}
+
+// isRateLimited determines whether a response indicates the request was rate
+// limited. Github responds with a 403 for both its primary and secondary rate
+// limits, and Gitlab with a 429.
+func isRateLimited(resp *http.Response) bool {
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests:
+		return true
+	case http.StatusForbidden:
+		if resp.Header.Get("Retry-After") != "" {
+			return true
+		}
+		remaining, ok := parseRateLimitHeader(resp.Header, "Remaining")
+		return ok && remaining == 0
+	default:
+		return false
+	}
+}
+
+// parseRateLimitHeader parses an integer rate limit header, accepting both the
+// Github (X-RateLimit-<name>) and Gitlab (RateLimit-<name>) variants.
+func parseRateLimitHeader(header http.Header, name string) (int, bool) {
+	v := header.Get("X-RateLimit-" + name)
+	if v == "" {
+		v = header.Get("RateLimit-" + name)
+	}
+	if v == "" {
+		return 0, false
+	}
+	i, err := strconv.Atoi(v)
+	if err != nil {
+		return 0, false
+	}
+	return i, true
+}
+
+// parseRetryAfter parses the Retry-After header, which is either a number of
+// seconds or an HTTP date.
+func parseRetryAfter(v string, now time.Time) time.Time {
+	if secs, err := strconv.Atoi(v); err == nil {
+		return now.Add(time.Duration(secs) * time.Second)
+	}
+	if t, err := http.ParseTime(v); err == nil {
+		return t
+	}
+	return now.Add(time.Second)
+}
+
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
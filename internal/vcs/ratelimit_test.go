@@ -0,0 +1,182 @@
+package vcs
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRateLimitTransport(t *testing.T) {
+	// newTransport constructs a transport that records sleeps rather than
+	// sleeping, along with a server that responds with the given handlers in
+	// turn.
+	newTransport := func(t *testing.T, handlers ...http.HandlerFunc) (*rateLimitTransport, *[]time.Duration, string) {
+		var requests int
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			handlers[requests](w, r)
+			requests++
+		}))
+		t.Cleanup(srv.Close)
+
+		var sleeps []time.Duration
+		transport := NewRateLimitTransport(nil, t.Name(), "token").(*rateLimitTransport)
+		transport.sleep = func(_ context.Context, d time.Duration) error {
+			sleeps = append(sleeps, d)
+			return nil
+		}
+		return transport, &sleeps, srv.URL
+	}
+	ok := func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-RateLimit-Limit", "5000")
+		w.Header().Set("X-RateLimit-Remaining", "4999")
+		w.Header().Set("X-RateLimit-Reset", strconv.FormatInt(time.Now().Add(time.Hour).Unix(), 10))
+	}
+
+	t.Run("retry after", func(t *testing.T) {
+		transport, sleeps, url := newTransport(t,
+			func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Retry-After", "30")
+				w.WriteHeader(http.StatusTooManyRequests)
+			},
+			ok,
+		)
+		resp, err := (&http.Client{Transport: transport}).Get(url)
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		require.Equal(t, 1, len(*sleeps))
+		assert.InDelta(t, 30*time.Second, (*sleeps)[0], float64(time.Second))
+	})
+
+	t.Run("github primary rate limit", func(t *testing.T) {
+		transport, sleeps, url := newTransport(t,
+			func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("X-RateLimit-Limit", "5000")
+				w.Header().Set("X-RateLimit-Remaining", "0")
+				w.Header().Set("X-RateLimit-Reset", strconv.FormatInt(time.Now().Add(time.Minute).Unix(), 10))
+				w.WriteHeader(http.StatusForbidden)
+			},
+			ok,
+		)
+		resp, err := (&http.Client{Transport: transport}).Get(url)
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		require.Equal(t, 1, len(*sleeps))
+		assert.InDelta(t, time.Minute, (*sleeps)[0], float64(2*time.Second))
+	})
+
+	t.Run("retry request with body", func(t *testing.T) {
+		var bodies []string
+		record := func(w http.ResponseWriter, r *http.Request) {
+			body, _ := io.ReadAll(r.Body)
+			bodies = append(bodies, string(body))
+		}
+		transport, _, url := newTransport(t,
+			func(w http.ResponseWriter, r *http.Request) {
+				record(w, r)
+				w.WriteHeader(http.StatusTooManyRequests)
+			},
+			record,
+		)
+		resp, err := (&http.Client{Transport: transport}).Post(url, "text/plain", strings.NewReader("hello"))
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, []string{"hello", "hello"}, bodies)
+	})
+
+	t.Run("give up after max retries", func(t *testing.T) {
+		handlers := make([]http.HandlerFunc, maxRateLimitRetries+1)
+		for i := range handlers {
+			handlers[i] = func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusTooManyRequests)
+			}
+		}
+		transport, sleeps, url := newTransport(t, handlers...)
+		resp, err := (&http.Client{Transport: transport}).Get(url)
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusTooManyRequests, resp.StatusCode)
+
+		// exponential backoff in lieu of any headers
+		assert.Equal(t, []time.Duration{time.Second, 2 * time.Second, 4 * time.Second}, roundDurations(*sleeps))
+	})
+
+	t.Run("reset too far away", func(t *testing.T) {
+		transport, _, url := newTransport(t,
+			func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Retry-After", "3600")
+				w.WriteHeader(http.StatusTooManyRequests)
+			},
+		)
+		_, err := (&http.Client{Transport: transport}).Get(url)
+		assert.ErrorIs(t, err, ErrRateLimited)
+	})
+}
+
+func TestRateLimiter_Delay(t *testing.T) {
+	now := time.Now()
+
+	tests := []struct {
+		name    string
+		limiter *rateLimiter
+		want    time.Duration
+	}{
+		{
+			name:    "unknown",
+			limiter: &rateLimiter{remaining: -1},
+			want:    0,
+		},
+		{
+			name:    "plenty remaining",
+			limiter: &rateLimiter{limit: 5000, remaining: 4000, reset: now.Add(time.Hour)},
+			want:    0,
+		},
+		{
+			name:    "running low",
+			limiter: &rateLimiter{limit: 5000, remaining: 99, reset: now.Add(100 * time.Second)},
+			want:    time.Second,
+		},
+		{
+			name:    "exhausted",
+			limiter: &rateLimiter{limit: 5000, remaining: 0, reset: now.Add(time.Minute)},
+			want:    time.Minute,
+		},
+		{
+			name:    "window passed",
+			limiter: &rateLimiter{limit: 5000, remaining: 0, reset: now.Add(-time.Minute)},
+			want:    0,
+		},
+		{
+			name:    "blocked",
+			limiter: &rateLimiter{remaining: -1, blockedUntil: now.Add(time.Minute)},
+			want:    time.Minute,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.limiter.delay(now))
+		})
+	}
+}
+
+func TestRateLimiter_Shared(t *testing.T) {
+	assert.Same(t, getRateLimiter("github.com", "token-a"), getRateLimiter("github.com", "token-a"))
+	assert.NotSame(t, getRateLimiter("github.com", "token-a"), getRateLimiter("github.com", "token-b"))
+	assert.NotSame(t, getRateLimiter("github.com", "token-a"), getRateLimiter("gitlab.com", "token-a"))
+}
+
+func roundDurations(durations []time.Duration) []time.Duration {
+	rounded := make([]time.Duration, len(durations))
+	for i, d := range durations {
+		rounded[i] = d.Round(100 * time.Millisecond)
+	}
+	return rounded
+}
@@ -0,0 +1,180 @@
+package vcs
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	// DefaultMaxConcurrentRequests is the default maximum number of requests
+	// made concurrently to each VCS provider hostname.
+	DefaultMaxConcurrentRequests = 10
+
+	// maxRetries is the maximum number of times a request that failed with
+	// a transient error is retried.
+	maxRetries = 3
+	// retryBaseDelay is the delay before the first retry, doubling for each
+	// subsequent retry.
+	retryBaseDelay = 500 * time.Millisecond
+	// retryMaxDelay caps the delay between retries.
+	retryMaxDelay = 30 * time.Second
+)
+
+var (
+	retries = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "otf",
+		Subsystem: "vcs",
+		Name:      "retries_total",
+		Help:      "Number of requests retried after a transient error.",
+	}, []string{"hostname"})
+	inflightRequests = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "otf",
+		Subsystem: "vcs",
+		Name:      "inflight_requests",
+		Help:      "Number of requests currently being made to a VCS provider.",
+	}, []string{"hostname"})
+
+	// maxConcurrentRequests is the maximum number of requests made
+	// concurrently to each hostname; 0 means no limit.
+	maxConcurrentRequests = DefaultMaxConcurrentRequests
+	// semaphores cap concurrent requests, keyed by hostname.
+	semaphores   = make(map[string]chan struct{})
+	semaphoresMu sync.Mutex
+)
+
+func init() {
+	prometheus.MustRegister(retries)
+	prometheus.MustRegister(inflightRequests)
+}
+
+// SetMaxConcurrentRequests sets the maximum number of requests made
+// concurrently to each VCS provider hostname. Zero means no limit. It only
+// affects transports constructed after it is called.
+func SetMaxConcurrentRequests(n int) {
+	semaphoresMu.Lock()
+	defer semaphoresMu.Unlock()
+
+	maxConcurrentRequests = n
+	semaphores = make(map[string]chan struct{})
+}
+
+// NewTransport returns a transport for use by VCS provider clients. It caps
+// the number of concurrent requests to the hostname, retries requests that
+// fail with a transient error using exponential backoff, and honors the
+// provider's rate limits (see NewRateLimitTransport).
+func NewTransport(base http.RoundTripper, hostname, credentials string) http.RoundTripper {
+	return &retryTransport{
+		base:      NewRateLimitTransport(base, hostname, credentials),
+		hostname:  hostname,
+		semaphore: getSemaphore(hostname),
+		sleep:     sleep,
+	}
+}
+
+type retryTransport struct {
+	base     http.RoundTripper
+	hostname string
+	// semaphore caps concurrent requests; nil means no limit.
+	semaphore chan struct{}
+	// sleep is overridden in tests
+	sleep func(context.Context, time.Duration) error
+}
+
+func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if t.semaphore != nil {
+		select {
+		case t.semaphore <- struct{}{}:
+			defer func() { <-t.semaphore }()
+		case <-req.Context().Done():
+			return nil, req.Context().Err()
+		}
+	}
+	inflightRequests.WithLabelValues(t.hostname).Inc()
+	defer inflightRequests.WithLabelValues(t.hostname).Dec()
+
+	for attempt := 0; ; attempt++ {
+		resp, err := t.base.RoundTrip(req)
+		if attempt == maxRetries || !isRetryable(req, resp, err) {
+			return resp, err
+		}
+		// the request can only be retried if its body can be re-read.
+		if req.Body != nil && req.Body != http.NoBody {
+			if req.GetBody == nil {
+				return resp, err
+			}
+			body, bodyErr := req.GetBody()
+			if bodyErr != nil {
+				return resp, err
+			}
+			req = req.Clone(req.Context())
+			req.Body = body
+		}
+		delay := backoff(attempt)
+		if resp != nil {
+			if v := resp.Header.Get("Retry-After"); v != "" {
+				if until := time.Until(parseRetryAfter(v, time.Now())); until > 0 && until <= maxRateLimitWait {
+					delay = until
+				}
+			}
+			resp.Body.Close()
+		}
+		retries.WithLabelValues(t.hostname).Inc()
+		if err := t.sleep(req.Context(), delay); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// isRetryable determines whether a request should be retried. Only idempotent
+// requests are retried, and only if they failed with a network error or a
+// server error indicating the provider is temporarily unavailable. Rate
+// limited requests are retried by the rate limit transport.
+func isRetryable(req *http.Request, resp *http.Response, err error) bool {
+	switch req.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+	default:
+		return false
+	}
+	if err != nil {
+		// don't retry if the caller has given up, or if the rate limit
+		// transport has already waited as long as is reasonable.
+		return req.Context().Err() == nil && !errors.Is(err, ErrRateLimited)
+	}
+	switch resp.StatusCode {
+	case http.StatusInternalServerError, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	default:
+		return false
+	}
+}
+
+// backoff returns the delay before the given retry attempt: exponential, with
+// jitter to avoid retries from many clients arriving in lockstep.
+func backoff(attempt int) time.Duration {
+	d := retryBaseDelay << attempt
+	if d > retryMaxDelay {
+		d = retryMaxDelay
+	}
+	return d/2 + time.Duration(rand.Int63n(int64(d/2)+1))
+}
+
+func getSemaphore(hostname string) chan struct{} {
+	semaphoresMu.Lock()
+	defer semaphoresMu.Unlock()
+
+	if maxConcurrentRequests <= 0 {
+		return nil
+	}
+	if sem, ok := semaphores[hostname]; ok {
+		return sem
+	}
+	sem := make(chan struct{}, maxConcurrentRequests)
+	semaphores[hostname] = sem
+	return sem
+}
@@ -0,0 +1,180 @@
+package vcs
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRetryTransport(t *testing.T) {
+	// newTransport constructs a transport that records sleeps rather than
+	// sleeping, along with a server that responds with the given handlers in
+	// turn.
+	newTransport := func(t *testing.T, handlers ...http.HandlerFunc) (*retryTransport, *[]time.Duration, string) {
+		var requests int
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			handlers[requests](w, r)
+			requests++
+		}))
+		t.Cleanup(srv.Close)
+
+		var sleeps []time.Duration
+		transport := NewTransport(nil, t.Name(), "token").(*retryTransport)
+		transport.sleep = func(_ context.Context, d time.Duration) error {
+			sleeps = append(sleeps, d)
+			return nil
+		}
+		return transport, &sleeps, srv.URL
+	}
+	unavailable := func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}
+	ok := func(w http.ResponseWriter, r *http.Request) {}
+
+	t.Run("retry server error", func(t *testing.T) {
+		transport, sleeps, url := newTransport(t, unavailable, unavailable, ok)
+		resp, err := (&http.Client{Transport: transport}).Get(url)
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		// exponential backoff with jitter
+		require.Equal(t, 2, len(*sleeps))
+		assert.True(t, (*sleeps)[0] >= retryBaseDelay/2 && (*sleeps)[0] <= retryBaseDelay)
+		assert.True(t, (*sleeps)[1] >= retryBaseDelay && (*sleeps)[1] <= 2*retryBaseDelay)
+	})
+
+	t.Run("honor retry after", func(t *testing.T) {
+		transport, sleeps, url := newTransport(t,
+			func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Retry-After", "10")
+				w.WriteHeader(http.StatusServiceUnavailable)
+			},
+			ok,
+		)
+		resp, err := (&http.Client{Transport: transport}).Get(url)
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		require.Equal(t, 1, len(*sleeps))
+		assert.InDelta(t, 10*time.Second, (*sleeps)[0], float64(time.Second))
+	})
+
+	t.Run("give up after max retries", func(t *testing.T) {
+		handlers := make([]http.HandlerFunc, maxRetries+1)
+		for i := range handlers {
+			handlers[i] = unavailable
+		}
+		transport, sleeps, url := newTransport(t, handlers...)
+		resp, err := (&http.Client{Transport: transport}).Get(url)
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+		assert.Equal(t, maxRetries, len(*sleeps))
+	})
+
+	t.Run("do not retry non-idempotent request", func(t *testing.T) {
+		transport, sleeps, url := newTransport(t, unavailable)
+		resp, err := (&http.Client{Transport: transport}).Post(url, "text/plain", strings.NewReader("hello"))
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+		assert.Equal(t, 0, len(*sleeps))
+	})
+
+	t.Run("do not retry rate limit exceeded", func(t *testing.T) {
+		transport, sleeps, url := newTransport(t, func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Retry-After", "3600")
+			w.WriteHeader(http.StatusTooManyRequests)
+		})
+		_, err := (&http.Client{Transport: transport}).Get(url)
+		assert.ErrorIs(t, err, ErrRateLimited)
+		assert.Equal(t, 0, len(*sleeps))
+	})
+
+	t.Run("do not retry client error", func(t *testing.T) {
+		transport, sleeps, url := newTransport(t, func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusNotFound)
+		})
+		resp, err := (&http.Client{Transport: transport}).Get(url)
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+		assert.Equal(t, 0, len(*sleeps))
+	})
+}
+
+func TestRetryTransport_NetworkError(t *testing.T) {
+	var attempts int
+	transport := &retryTransport{
+		base: roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+			attempts++
+			if attempts == 1 {
+				return nil, errors.New("connection reset by peer")
+			}
+			return &http.Response{StatusCode: http.StatusOK, Body: http.NoBody}, nil
+		}),
+		sleep: func(context.Context, time.Duration) error { return nil },
+	}
+	resp, err := (&http.Client{Transport: transport}).Get("https://github.com")
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, 2, attempts)
+}
+
+func TestRetryTransport_MaxConcurrentRequests(t *testing.T) {
+	SetMaxConcurrentRequests(2)
+	t.Cleanup(func() { SetMaxConcurrentRequests(DefaultMaxConcurrentRequests) })
+
+	var (
+		inflight, peak atomic.Int32
+		release        = make(chan struct{})
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := inflight.Add(1)
+		defer inflight.Add(-1)
+		for {
+			p := peak.Load()
+			if n <= p || peak.CompareAndSwap(p, n) {
+				break
+			}
+		}
+		<-release
+	}))
+	t.Cleanup(srv.Close)
+
+	// clients using different credentials share the cap for the hostname.
+	clients := []*http.Client{
+		{Transport: NewTransport(nil, t.Name(), "token-a")},
+		{Transport: NewTransport(nil, t.Name(), "token-b")},
+	}
+	var wg sync.WaitGroup
+	for i := 0; i < 6; i++ {
+		client := clients[i%2]
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			resp, err := client.Get(srv.URL)
+			if assert.NoError(t, err) {
+				resp.Body.Close()
+			}
+		}()
+	}
+	// let requests pile up before releasing them
+	time.Sleep(100 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	assert.Equal(t, int32(2), peak.Load())
+}
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(r *http.Request) (*http.Response, error) {
+	return f(r)
+}
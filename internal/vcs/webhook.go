@@ -0,0 +1,45 @@
+package vcs
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// DefaultWebhookClockSkew is the default maximum permitted difference between
+// the time a webhook delivery was sent and the time it is received.
+const DefaultWebhookClockSkew = 5 * time.Minute
+
+// ErrStaleDelivery is returned when a webhook delivery was sent outside of the
+// permitted clock skew, and may be the replay of an earlier delivery.
+var ErrStaleDelivery = errors.New("webhook delivery sent outside of permitted clock skew")
+
+// ValidateDeliveryTime guards against the replay of old webhook deliveries,
+// rejecting a delivery whose Date header differs from now by more than the
+// permitted clock skew. If required is true then a delivery without a Date
+// header is rejected too, which should be the case for a provider that always
+// sends one, lest a replayed delivery evade the check by omitting the header.
+// Otherwise a delivery without a Date header is accepted: not all providers
+// send one, and a replayed delivery is instead discarded when its delivery ID
+// is found to have been seen already. A skew of zero disables the check.
+func ValidateDeliveryTime(r *http.Request, skew time.Duration, now time.Time, required bool) error {
+	if skew <= 0 {
+		return nil
+	}
+	date := r.Header.Get("Date")
+	if date == "" {
+		if required {
+			return fmt.Errorf("%w: missing Date header", ErrStaleDelivery)
+		}
+		return nil
+	}
+	sent, err := http.ParseTime(date)
+	if err != nil {
+		return fmt.Errorf("%w: invalid Date header: %q", ErrStaleDelivery, date)
+	}
+	if diff := now.Sub(sent); diff > skew || diff < -skew {
+		return fmt.Errorf("%w: sent at %s", ErrStaleDelivery, sent.Format(time.RFC3339))
+	}
+	return nil
+}
@@ -0,0 +1,42 @@
+package vcs
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateDeliveryTime(t *testing.T) {
+	now := time.Date(2023, 12, 22, 9, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		date     string
+		skew     time.Duration
+		required bool
+		want     error
+	}{
+		{"no date header", "", time.Minute, false, nil},
+		{"missing required date header", "", time.Minute, true, ErrStaleDelivery},
+		{"within skew", now.Add(-30 * time.Second).Format(http.TimeFormat), time.Minute, true, nil},
+		{"ahead within skew", now.Add(30 * time.Second).Format(http.TimeFormat), time.Minute, false, nil},
+		{"stale", now.Add(-2 * time.Minute).Format(http.TimeFormat), time.Minute, false, ErrStaleDelivery},
+		{"from the future", now.Add(2 * time.Minute).Format(http.TimeFormat), time.Minute, false, ErrStaleDelivery},
+		{"invalid date header", "yesterday", time.Minute, false, ErrStaleDelivery},
+		{"check disabled", now.Add(-time.Hour).Format(http.TimeFormat), 0, false, nil},
+		{"check disabled without required date header", "", 0, true, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest("POST", "/", nil)
+			if tt.date != "" {
+				r.Header.Set("Date", tt.date)
+			}
+			err := ValidateDeliveryTime(r, tt.skew, now, tt.required)
+			assert.ErrorIs(t, err, tt.want)
+		})
+	}
+}
@@ -0,0 +1,60 @@
+package vcsevent
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	otfapi "github.com/leg100/otf/internal/api"
+	"github.com/leg100/otf/internal/http/decode"
+	"github.com/leg100/otf/internal/tfeapi"
+)
+
+type api struct {
+	*Service
+}
+
+func (a *api) addHandlers(r *mux.Router) {
+	r = r.PathPrefix(otfapi.DefaultBasePath).Subrouter()
+
+	r.HandleFunc("/admin/vcs-events/dead-letters", a.list).Methods("GET")
+	r.HandleFunc("/admin/vcs-events/dead-letters/{dead_letter_id}/redrive", a.redrive).Methods("POST")
+	r.HandleFunc("/admin/vcs-events/dead-letters/{dead_letter_id}", a.delete).Methods("DELETE")
+}
+
+func (a *api) list(w http.ResponseWriter, r *http.Request) {
+	deadLetters, err := a.ListDeadLetters(r.Context())
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(deadLetters)
+}
+
+func (a *api) redrive(w http.ResponseWriter, r *http.Request) {
+	id, err := decode.Param("dead_letter_id", r)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	if err := a.RedriveDeadLetter(r.Context(), id); err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	// the event is handled asynchronously
+	w.WriteHeader(http.StatusAccepted)
+}
+
+func (a *api) delete(w http.ResponseWriter, r *http.Request) {
+	id, err := decode.Param("dead_letter_id", r)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	if err := a.DeleteDeadLetter(r.Context(), id); err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,180 @@
+package vcsevent
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/jackc/pgtype"
+	"github.com/leg100/otf/internal/sql"
+	"github.com/leg100/otf/internal/sql/pggen"
+)
+
+type (
+	// pgdb is a database of vcs event deliveries, dead letters and queued events
+	// on postgres
+	pgdb struct {
+		*sql.DB // provides access to generated SQL queries
+	}
+
+	deadLetterRow struct {
+		DeadLetterID  pgtype.Text        `json:"dead_letter_id"`
+		VCSProviderID pgtype.Text        `json:"vcs_provider_id"`
+		Subscriber    pgtype.Text        `json:"subscriber"`
+		Event         []byte             `json:"event"`
+		Error         pgtype.Text        `json:"error"`
+		CreatedAt     pgtype.Timestamptz `json:"created_at"`
+	}
+
+	queuedEventRow struct {
+		QueuedEventID pgtype.Text        `json:"queued_event_id"`
+		VCSProviderID pgtype.Text        `json:"vcs_provider_id"`
+		Subscriber    pgtype.Text        `json:"subscriber"`
+		Event         []byte             `json:"event"`
+		CreatedAt     pgtype.Timestamptz `json:"created_at"`
+		ClaimedAt     pgtype.Timestamptz `json:"claimed_at"`
+	}
+)
+
+func (r deadLetterRow) toDeadLetter() (*DeadLetter, error) {
+	dl := &DeadLetter{
+		ID:            r.DeadLetterID.String,
+		VCSProviderID: r.VCSProviderID.String,
+		Subscriber:    r.Subscriber.String,
+		Error:         r.Error.String,
+		CreatedAt:     r.CreatedAt.Time.UTC(),
+	}
+	if err := json.Unmarshal(r.Event, &dl.Event); err != nil {
+		return nil, err
+	}
+	return dl, nil
+}
+
+func (r queuedEventRow) toQueuedEvent() (*queuedEvent, error) {
+	qe := &queuedEvent{
+		ID:         r.QueuedEventID.String,
+		Subscriber: r.Subscriber.String,
+		CreatedAt:  r.CreatedAt.Time.UTC(),
+	}
+	if err := json.Unmarshal(r.Event, &qe.Event); err != nil {
+		return nil, err
+	}
+	return qe, nil
+}
+
+// recordDelivery records the delivery of an event, returning false if the
+// delivery has already been recorded.
+func (db *pgdb) recordDelivery(ctx context.Context, vcsProviderID, deliveryID string, receivedAt time.Time) (bool, error) {
+	tag, err := db.Conn(ctx).InsertVCSEventDelivery(ctx, pggen.InsertVCSEventDeliveryParams{
+		VCSProviderID: sql.String(vcsProviderID),
+		DeliveryID:    sql.String(deliveryID),
+		ReceivedAt:    sql.Timestamptz(receivedAt),
+	})
+	if err != nil {
+		return false, sql.Error(err)
+	}
+	return tag.RowsAffected() > 0, nil
+}
+
+func (db *pgdb) deleteDeliveriesBefore(ctx context.Context, before time.Time) (int64, error) {
+	tag, err := db.Conn(ctx).DeleteVCSEventDeliveriesBefore(ctx, sql.Timestamptz(before))
+	if err != nil {
+		return 0, sql.Error(err)
+	}
+	return tag.RowsAffected(), nil
+}
+
+func (db *pgdb) createDeadLetter(ctx context.Context, dl *DeadLetter) error {
+	event, err := json.Marshal(dl.Event)
+	if err != nil {
+		return err
+	}
+	_, err = db.Conn(ctx).InsertVCSEventDeadLetter(ctx, pggen.InsertVCSEventDeadLetterParams{
+		DeadLetterID:  sql.String(dl.ID),
+		VCSProviderID: sql.String(dl.VCSProviderID),
+		Subscriber:    sql.String(dl.Subscriber),
+		Event:         event,
+		Error:         sql.String(dl.Error),
+		CreatedAt:     sql.Timestamptz(dl.CreatedAt),
+	})
+	if err != nil {
+		return sql.Error(err)
+	}
+	return nil
+}
+
+func (db *pgdb) listDeadLetters(ctx context.Context) ([]*DeadLetter, error) {
+	rows, err := db.Conn(ctx).FindVCSEventDeadLetters(ctx)
+	if err != nil {
+		return nil, sql.Error(err)
+	}
+	deadLetters := make([]*DeadLetter, len(rows))
+	for i, r := range rows {
+		dl, err := deadLetterRow(r).toDeadLetter()
+		if err != nil {
+			return nil, err
+		}
+		deadLetters[i] = dl
+	}
+	return deadLetters, nil
+}
+
+func (db *pgdb) getDeadLetter(ctx context.Context, id string) (*DeadLetter, error) {
+	row, err := db.Conn(ctx).FindVCSEventDeadLetterByID(ctx, sql.String(id))
+	if err != nil {
+		return nil, sql.Error(err)
+	}
+	return deadLetterRow(row).toDeadLetter()
+}
+
+func (db *pgdb) deleteDeadLetter(ctx context.Context, id string) error {
+	_, err := db.Conn(ctx).DeleteVCSEventDeadLetterByID(ctx, sql.String(id))
+	if err != nil {
+		return sql.Error(err)
+	}
+	return nil
+}
+
+func (db *pgdb) enqueue(ctx context.Context, qe *queuedEvent) error {
+	event, err := json.Marshal(qe.Event)
+	if err != nil {
+		return err
+	}
+	_, err = db.Conn(ctx).InsertQueuedVCSEvent(ctx, pggen.InsertQueuedVCSEventParams{
+		QueuedEventID: sql.String(qe.ID),
+		VCSProviderID: sql.String(qe.Event.VCSProviderID),
+		Subscriber:    sql.String(qe.Subscriber),
+		Event:         event,
+		CreatedAt:     sql.Timestamptz(qe.CreatedAt),
+	})
+	if err != nil {
+		return sql.Error(err)
+	}
+	return nil
+}
+
+// claimQueuedEvents claims queued events last claimed before the given time,
+// marking them as claimed at the given time.
+func (db *pgdb) claimQueuedEvents(ctx context.Context, before, claimedAt time.Time) ([]*queuedEvent, error) {
+	rows, err := db.Conn(ctx).ClaimQueuedVCSEvents(ctx, sql.Timestamptz(claimedAt), sql.Timestamptz(before))
+	if err != nil {
+		return nil, sql.Error(err)
+	}
+	events := make([]*queuedEvent, len(rows))
+	for i, r := range rows {
+		qe, err := queuedEventRow(r).toQueuedEvent()
+		if err != nil {
+			return nil, err
+		}
+		events[i] = qe
+	}
+	return events, nil
+}
+
+func (db *pgdb) deleteQueuedEvent(ctx context.Context, id string) error {
+	_, err := db.Conn(ctx).DeleteQueuedVCSEventByID(ctx, sql.String(id))
+	if err != nil {
+		return sql.Error(err)
+	}
+	return nil
+}
@@ -0,0 +1,75 @@
+package vcsevent
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-logr/logr"
+)
+
+// PrunerLockID guarantees only one pruner on a cluster is running at any time.
+const PrunerLockID int64 = 5577006791947779421
+
+var defaultPrunerInterval = time.Hour
+
+type (
+	// Pruner forgets deliveries of vcs events once their retention period
+	// has elapsed.
+	//
+	// Only one pruner should be running on an OTF cluster at any one time.
+	Pruner struct {
+		logr.Logger
+
+		client prunerClient
+		// frequency with which the pruner checks for deliveries to forget.
+		interval time.Duration
+	}
+
+	prunerClient interface {
+		purgeDeliveries(ctx context.Context) (int64, error)
+	}
+)
+
+// NewPruner constructs a pruner of vcs event deliveries.
+func (s *Service) NewPruner(logger logr.Logger) *Pruner {
+	return &Pruner{
+		Logger:   logger.WithValues("component", "vcs-event-pruner"),
+		client:   s,
+		interval: defaultPrunerInterval,
+	}
+}
+
+func (p *Pruner) String() string { return "vcs-event-pruner" }
+
+// Start the pruner. Every interval deliveries older than the retention period
+// are forgotten.
+//
+// Should be invoked in a go routine.
+func (p *Pruner) Start(ctx context.Context) error {
+	purge := func() error {
+		purged, err := p.client.purgeDeliveries(ctx)
+		if err != nil {
+			return err
+		}
+		if purged > 0 {
+			p.V(1).Info("forgot vcs event deliveries", "count", purged)
+		}
+		return nil
+	}
+	// run at startup and then every interval
+	if err := purge(); err != nil {
+		return err
+	}
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			if err := purge(); err != nil {
+				return err
+			}
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
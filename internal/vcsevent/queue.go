@@ -0,0 +1,132 @@
+package vcsevent
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/leg100/otf/internal"
+	"github.com/leg100/otf/internal/resource"
+	"github.com/leg100/otf/internal/vcs"
+)
+
+const (
+	// ResumerLockID guarantees only one resumer on a cluster is running at
+	// any time.
+	ResumerLockID int64 = 8674665223082153551
+
+	// ResumeAfter is how long a queued event waits to be handled before it
+	// is deemed abandoned, e.g. because the otfd node handling it stopped,
+	// and it is delivered again. It exceeds the longest an event can wait for
+	// its repository's rate limit plus the time taken to retry subscribers.
+	ResumeAfter = 15 * time.Minute
+)
+
+var defaultResumerInterval = time.Minute
+
+type (
+	// queuedEvent is an event pending delivery to a subscriber.
+	queuedEvent struct {
+		ID         string
+		Subscriber string
+		Event      vcs.Event
+		CreatedAt  time.Time
+	}
+
+	// Resumer delivers queued events again once they have been abandoned,
+	// guaranteeing each subscriber handles an event at least once.
+	//
+	// Only one resumer should be running on an OTF cluster at any one time.
+	Resumer struct {
+		logr.Logger
+
+		client resumerClient
+		// frequency with which the resumer checks for abandoned events.
+		interval time.Duration
+	}
+
+	resumerClient interface {
+		resumeAbandoned(ctx context.Context) (int, error)
+	}
+)
+
+var _ vcs.Queue = (*Service)(nil)
+
+// Enqueue persists an event pending delivery to a subscriber.
+func (s *Service) Enqueue(ctx context.Context, subscriber string, event vcs.Event) (string, error) {
+	qe := &queuedEvent{
+		ID:         resource.NewID(resource.VCSEventQueueKind),
+		Subscriber: subscriber,
+		Event:      event,
+		CreatedAt:  internal.CurrentTimestamp(nil),
+	}
+	if err := s.db.enqueue(ctx, qe); err != nil {
+		return "", err
+	}
+	s.V(9).Info("queued vcs event", "id", qe.ID, "subscriber", subscriber, "vcs_provider_id", event.VCSProviderID, "repo", event.RepoPath)
+	return qe.ID, nil
+}
+
+// Ack removes an event from the queue.
+func (s *Service) Ack(ctx context.Context, id string) error {
+	if err := s.db.deleteQueuedEvent(ctx, id); err != nil {
+		return err
+	}
+	s.V(9).Info("acknowledged vcs event", "id", id)
+	return nil
+}
+
+// resumeAbandoned delivers again those queued events that have been waiting
+// longer than ResumeAfter to be handled, returning the number of events
+// resumed.
+func (s *Service) resumeAbandoned(ctx context.Context) (int, error) {
+	now := internal.CurrentTimestamp(nil)
+	events, err := s.db.claimQueuedEvents(ctx, now.Add(-ResumeAfter), now)
+	if err != nil {
+		return 0, err
+	}
+	var resumed int
+	for _, qe := range events {
+		if err := s.broker.Resume(qe.ID, qe.Subscriber, qe.Event); err != nil {
+			// the subscriber no longer exists, so the event can never be
+			// delivered.
+			s.Error(err, "resuming vcs event", "id", qe.ID, "subscriber", qe.Subscriber)
+			if err := s.db.deleteQueuedEvent(ctx, qe.ID); err != nil {
+				return resumed, err
+			}
+			continue
+		}
+		s.V(1).Info("resumed abandoned vcs event", "id", qe.ID, "subscriber", qe.Subscriber, "queued", qe.CreatedAt)
+		resumed++
+	}
+	return resumed, nil
+}
+
+// NewResumer constructs a resumer of abandoned vcs events.
+func (s *Service) NewResumer(logger logr.Logger) *Resumer {
+	return &Resumer{
+		Logger:   logger.WithValues("component", "vcs-event-resumer"),
+		client:   s,
+		interval: defaultResumerInterval,
+	}
+}
+
+func (r *Resumer) String() string { return "vcs-event-resumer" }
+
+// Start the resumer. Every interval abandoned events are delivered again.
+//
+// Should be invoked in a go routine.
+func (r *Resumer) Start(ctx context.Context) error {
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			if _, err := r.client.resumeAbandoned(ctx); err != nil {
+				return err
+			}
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
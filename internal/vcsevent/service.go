@@ -0,0 +1,155 @@
+package vcsevent
+
+import (
+	"context"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/leg100/otf/internal"
+	"github.com/leg100/otf/internal/logr"
+	"github.com/leg100/otf/internal/rbac"
+	"github.com/leg100/otf/internal/sql"
+	"github.com/leg100/otf/internal/vcs"
+)
+
+type (
+	Service struct {
+		logr.Logger
+
+		site internal.Authorizer
+
+		db     store
+		api    *api
+		broker broker
+	}
+
+	Options struct {
+		logr.Logger
+		*sql.DB
+
+		// Broker redelivers dead-lettered events and resumes abandoned
+		// events.
+		Broker *vcs.Broker
+	}
+
+	store interface {
+		recordDelivery(ctx context.Context, vcsProviderID, deliveryID string, receivedAt time.Time) (bool, error)
+		deleteDeliveriesBefore(ctx context.Context, before time.Time) (int64, error)
+		createDeadLetter(ctx context.Context, dl *DeadLetter) error
+		listDeadLetters(ctx context.Context) ([]*DeadLetter, error)
+		getDeadLetter(ctx context.Context, id string) (*DeadLetter, error)
+		deleteDeadLetter(ctx context.Context, id string) error
+		enqueue(ctx context.Context, qe *queuedEvent) error
+		claimQueuedEvents(ctx context.Context, before, claimedAt time.Time) ([]*queuedEvent, error)
+		deleteQueuedEvent(ctx context.Context, id string) error
+	}
+
+	broker interface {
+		Redeliver(subscriber string, event vcs.Event) error
+		Resume(id, subscriber string, event vcs.Event) error
+	}
+)
+
+var (
+	_ vcs.DeliveryRecorder = (*Service)(nil)
+	_ vcs.DeadLetterer     = (*Service)(nil)
+)
+
+func NewService(opts Options) *Service {
+	svc := Service{
+		Logger: opts.Logger,
+		site:   &internal.SiteAuthorizer{Logger: opts.Logger},
+		db:     &pgdb{opts.DB},
+		broker: opts.Broker,
+	}
+	svc.api = &api{Service: &svc}
+	return &svc
+}
+
+func (s *Service) AddHandlers(r *mux.Router) {
+	s.api.addHandlers(r)
+}
+
+// RecordDelivery records the delivery of an event, returning false if the
+// delivery has already been recorded, i.e. the event has been redelivered.
+func (s *Service) RecordDelivery(ctx context.Context, event vcs.Event) (bool, error) {
+	first, err := s.db.recordDelivery(ctx, event.VCSProviderID, event.DeliveryID, internal.CurrentTimestamp(nil))
+	if err != nil {
+		return false, err
+	}
+	s.V(9).Info("recorded vcs event delivery", "vcs_provider_id", event.VCSProviderID, "delivery_id", event.DeliveryID, "redelivery", !first)
+	return first, nil
+}
+
+// DeadLetter keeps an event that a subscriber failed to handle, so that it can
+// be redriven.
+func (s *Service) DeadLetter(ctx context.Context, subscriber string, event vcs.Event, reason error) error {
+	dl := newDeadLetter(subscriber, event, reason)
+	if err := s.db.createDeadLetter(ctx, dl); err != nil {
+		return err
+	}
+	s.V(0).Info("dead-lettered vcs event", "id", dl.ID, "subscriber", subscriber, "vcs_provider_id", event.VCSProviderID, "repo", event.RepoPath, "reason", reason)
+	return nil
+}
+
+// ListDeadLetters lists dead-lettered events, oldest first. Only a site admin
+// can list dead-lettered events.
+func (s *Service) ListDeadLetters(ctx context.Context) ([]*DeadLetter, error) {
+	subject, err := s.site.CanAccess(ctx, rbac.ListVCSEventDeadLettersAction, "")
+	if err != nil {
+		return nil, err
+	}
+	deadLetters, err := s.db.listDeadLetters(ctx)
+	if err != nil {
+		s.Error(err, "listing dead-lettered vcs events", "subject", subject)
+		return nil, err
+	}
+	s.V(9).Info("listed dead-lettered vcs events", "count", len(deadLetters), "subject", subject)
+	return deadLetters, nil
+}
+
+// RedriveDeadLetter redelivers a dead-lettered event to the subscriber that
+// failed to handle it, removing it from the dead letters. Should the
+// subscriber fail again then the event is dead-lettered once more. Only a
+// site admin can redrive a dead-lettered event.
+func (s *Service) RedriveDeadLetter(ctx context.Context, id string) error {
+	subject, err := s.site.CanAccess(ctx, rbac.RedriveVCSEventDeadLetterAction, "")
+	if err != nil {
+		return err
+	}
+	dl, err := s.db.getDeadLetter(ctx, id)
+	if err != nil {
+		s.Error(err, "retrieving dead-lettered vcs event", "id", id, "subject", subject)
+		return err
+	}
+	if err := s.broker.Redeliver(dl.Subscriber, dl.Event); err != nil {
+		s.Error(err, "redriving dead-lettered vcs event", "id", id, "subject", subject)
+		return err
+	}
+	if err := s.db.deleteDeadLetter(ctx, id); err != nil {
+		s.Error(err, "deleting dead-lettered vcs event", "id", id, "subject", subject)
+		return err
+	}
+	s.V(0).Info("redrove dead-lettered vcs event", "id", id, "subscriber", dl.Subscriber, "subject", subject)
+	return nil
+}
+
+// DeleteDeadLetter discards a dead-lettered event. Only a site admin can
+// delete a dead-lettered event.
+func (s *Service) DeleteDeadLetter(ctx context.Context, id string) error {
+	subject, err := s.site.CanAccess(ctx, rbac.DeleteVCSEventDeadLetterAction, "")
+	if err != nil {
+		return err
+	}
+	if err := s.db.deleteDeadLetter(ctx, id); err != nil {
+		s.Error(err, "deleting dead-lettered vcs event", "id", id, "subject", subject)
+		return err
+	}
+	s.V(0).Info("deleted dead-lettered vcs event", "id", id, "subject", subject)
+	return nil
+}
+
+// purgeDeliveries forgets deliveries older than the retention period.
+func (s *Service) purgeDeliveries(ctx context.Context) (int64, error) {
+	return s.db.deleteDeliveriesBefore(ctx, internal.CurrentTimestamp(nil).Add(-DeliveryRetention))
+}
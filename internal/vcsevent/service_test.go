@@ -0,0 +1,160 @@
+package vcsevent
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/leg100/otf/internal"
+	"github.com/leg100/otf/internal/user"
+	"github.com/leg100/otf/internal/vcs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type (
+	fakeStore struct {
+		deadLetters map[string]*DeadLetter
+		queued      map[string]*queuedEvent
+		store
+	}
+
+	fakeBroker struct {
+		subscriber string
+		event      vcs.Event
+		resumed    []string
+		err        error
+	}
+)
+
+func (f *fakeStore) getDeadLetter(_ context.Context, id string) (*DeadLetter, error) {
+	dl, ok := f.deadLetters[id]
+	if !ok {
+		return nil, internal.ErrResourceNotFound
+	}
+	return dl, nil
+}
+
+func (f *fakeStore) deleteDeadLetter(_ context.Context, id string) error {
+	if _, ok := f.deadLetters[id]; !ok {
+		return internal.ErrResourceNotFound
+	}
+	delete(f.deadLetters, id)
+	return nil
+}
+
+func (f *fakeStore) claimQueuedEvents(_ context.Context, before, claimedAt time.Time) ([]*queuedEvent, error) {
+	var claimed []*queuedEvent
+	for _, qe := range f.queued {
+		if qe.CreatedAt.Before(before) {
+			claimed = append(claimed, qe)
+		}
+	}
+	return claimed, nil
+}
+
+func (f *fakeStore) deleteQueuedEvent(_ context.Context, id string) error {
+	delete(f.queued, id)
+	return nil
+}
+
+func (f *fakeBroker) Resume(id, subscriber string, event vcs.Event) error {
+	if f.err != nil {
+		return f.err
+	}
+	f.resumed = append(f.resumed, id)
+	return nil
+}
+
+func (f *fakeBroker) Redeliver(subscriber string, event vcs.Event) error {
+	if f.err != nil {
+		return f.err
+	}
+	f.subscriber = subscriber
+	f.event = event
+	return nil
+}
+
+func TestService_RedriveDeadLetter(t *testing.T) {
+	ctx := internal.AddSubjectToContext(context.Background(), &internal.Superuser{Username: "admin"})
+	event := vcs.Event{EventHeader: vcs.EventHeader{VCSProviderID: "vcs-123"}, EventPayload: vcs.EventPayload{RepoPath: "leg100/otf"}}
+	newService := func(broker broker) (*Service, *fakeStore, *DeadLetter) {
+		dl := newDeadLetter("run-spawner", event, errors.New("boom"))
+		db := &fakeStore{deadLetters: map[string]*DeadLetter{dl.ID: dl}}
+		return &Service{
+			Logger: logr.Discard(),
+			site:   &internal.SiteAuthorizer{Logger: logr.Discard()},
+			db:     db,
+			broker: broker,
+		}, db, dl
+	}
+
+	t.Run("redrive", func(t *testing.T) {
+		broker := &fakeBroker{}
+		svc, db, dl := newService(broker)
+
+		err := svc.RedriveDeadLetter(ctx, dl.ID)
+		require.NoError(t, err)
+
+		assert.Equal(t, "run-spawner", broker.subscriber)
+		assert.Equal(t, event, broker.event)
+		assert.Empty(t, db.deadLetters)
+	})
+
+	t.Run("keep dead letter when redelivery fails", func(t *testing.T) {
+		svc, db, dl := newService(&fakeBroker{err: errors.New("no such subscriber")})
+
+		err := svc.RedriveDeadLetter(ctx, dl.ID)
+		assert.Error(t, err)
+		assert.Contains(t, db.deadLetters, dl.ID)
+	})
+
+	t.Run("unauthorized", func(t *testing.T) {
+		svc, db, dl := newService(&fakeBroker{})
+
+		ctx := internal.AddSubjectToContext(context.Background(), &user.User{Username: "bobby"})
+		err := svc.RedriveDeadLetter(ctx, dl.ID)
+		assert.ErrorIs(t, err, internal.ErrAccessNotPermitted)
+		assert.Contains(t, db.deadLetters, dl.ID)
+	})
+}
+
+func TestService_resumeAbandoned(t *testing.T) {
+	ctx := context.Background()
+	now := internal.CurrentTimestamp(nil)
+	event := vcs.Event{EventHeader: vcs.EventHeader{VCSProviderID: "vcs-123"}, EventPayload: vcs.EventPayload{RepoPath: "leg100/otf"}}
+	newStore := func() *fakeStore {
+		return &fakeStore{queued: map[string]*queuedEvent{
+			"vcsq-abandoned": {ID: "vcsq-abandoned", Subscriber: "run-spawner", Event: event, CreatedAt: now.Add(-time.Hour)},
+			"vcsq-pending":   {ID: "vcsq-pending", Subscriber: "run-spawner", Event: event, CreatedAt: now},
+		}}
+	}
+
+	t.Run("resume abandoned events", func(t *testing.T) {
+		db := newStore()
+		broker := &fakeBroker{}
+		svc := &Service{Logger: logr.Discard(), db: db, broker: broker}
+
+		resumed, err := svc.resumeAbandoned(ctx)
+		require.NoError(t, err)
+
+		assert.Equal(t, 1, resumed)
+		assert.Equal(t, []string{"vcsq-abandoned"}, broker.resumed)
+		// the resumed event remains queued until it has been handled
+		assert.Len(t, db.queued, 2)
+	})
+
+	t.Run("discard events for unknown subscriber", func(t *testing.T) {
+		db := newStore()
+		svc := &Service{Logger: logr.Discard(), db: db, broker: &fakeBroker{err: errors.New("no such subscriber")}}
+
+		resumed, err := svc.resumeAbandoned(ctx)
+		require.NoError(t, err)
+
+		assert.Equal(t, 0, resumed)
+		assert.NotContains(t, db.queued, "vcsq-abandoned")
+		assert.Contains(t, db.queued, "vcsq-pending")
+	})
+}
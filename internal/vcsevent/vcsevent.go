@@ -0,0 +1,42 @@
+// Package vcsevent keeps track of the processing of events received from VCS
+// providers: it records deliveries, so that redeliveries can be discarded; it
+// queues events until they are handled, so that they are not lost should otfd
+// stop; and it keeps events that could not be handled, so that they can be
+// redriven.
+package vcsevent
+
+import (
+	"time"
+
+	"github.com/leg100/otf/internal"
+	"github.com/leg100/otf/internal/resource"
+	"github.com/leg100/otf/internal/vcs"
+)
+
+// DeliveryRetention is how long a delivery is remembered. A redelivery
+// received after this period is handled as a new event.
+const DeliveryRetention = 7 * 24 * time.Hour
+
+// DeadLetter is an event that a subscriber repeatedly failed to handle.
+type DeadLetter struct {
+	ID            string `json:"id"`
+	VCSProviderID string `json:"vcs_provider_id"`
+	// Subscriber is the name of the subscriber that failed to handle the
+	// event.
+	Subscriber string    `json:"subscriber"`
+	Event      vcs.Event `json:"event"`
+	// Error is the reason the subscriber failed to handle the event.
+	Error     string    `json:"error"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+func newDeadLetter(subscriber string, event vcs.Event, reason error) *DeadLetter {
+	return &DeadLetter{
+		ID:            resource.NewID(resource.VCSEventDeadLetterKind),
+		VCSProviderID: event.VCSProviderID,
+		Subscriber:    subscriber,
+		Event:         event,
+		Error:         reason.Error(),
+		CreatedAt:     internal.CurrentTimestamp(nil),
+	}
+}
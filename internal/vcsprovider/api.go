@@ -0,0 +1,46 @@
+package vcsprovider
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	otfapi "github.com/leg100/otf/internal/api"
+	"github.com/leg100/otf/internal/http/decode"
+	"github.com/leg100/otf/internal/resource"
+	"github.com/leg100/otf/internal/tfeapi"
+)
+
+type api struct {
+	*Service
+}
+
+func (a *api) addHandlers(r *mux.Router) {
+	r = r.PathPrefix(otfapi.DefaultBasePath).Subrouter()
+
+	r.HandleFunc("/vcs-providers/{vcs_provider_id}/repositories", a.listRepositories).Methods("GET")
+}
+
+func (a *api) listRepositories(w http.ResponseWriter, r *http.Request) {
+	var params struct {
+		VCSProviderID string `schema:"vcs_provider_id,required"`
+		ListRepositoriesOptions
+	}
+	if err := decode.All(&params, r); err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	page, err := a.ListRepositories(r.Context(), params.VCSProviderID, params.ListRepositoriesOptions)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(struct {
+		Repositories []string             `json:"repositories"`
+		Pagination   *resource.Pagination `json:"pagination"`
+	}{
+		Repositories: page.Items,
+		Pagination:   page.Pagination,
+	})
+}
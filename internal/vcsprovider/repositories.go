@@ -0,0 +1,90 @@
+package vcsprovider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/leg100/otf/internal/resource"
+	"github.com/leg100/otf/internal/vcs"
+)
+
+// ListRepositoriesOptions are options for searching the repositories
+// accessible to a vcs provider.
+type ListRepositoriesOptions struct {
+	// Search filters repositories to those whose path contains the search
+	// string, ignoring case.
+	Search string `schema:"search"`
+
+	resource.PageOptions
+}
+
+// ListRepositories lists the repositories accessible to a vcs provider,
+// optionally filtered by a search string. Repositories are retrieved from
+// the cloud provider at most once per cache lifetime, and are then searched
+// and paginated from the cache.
+func (a *Service) ListRepositories(ctx context.Context, providerID string, opts ListRepositoriesOptions) (*resource.Page[string], error) {
+	provider, err := a.Get(ctx, providerID)
+	if err != nil {
+		return nil, err
+	}
+	repos, err := a.listRepositories(ctx, provider.ID, provider.NewClient)
+	if err != nil {
+		a.Error(err, "listing vcs repositories", "provider", provider)
+		return nil, err
+	}
+	return searchRepositories(repos, opts), nil
+}
+
+// listRepositories lists repositories, from the cache if possible; otherwise
+// they are retrieved using a client constructed with newClient.
+func (a *Service) listRepositories(ctx context.Context, providerID string, newClient func() (vcs.Client, error)) ([]string, error) {
+	key := repositoriesCacheKey(providerID)
+	if a.cache != nil {
+		if encoded, err := a.cache.Get(key); err == nil {
+			var repos []string
+			if err := json.Unmarshal(encoded, &repos); err == nil {
+				return repos, nil
+			}
+		}
+	}
+	client, err := newClient()
+	if err != nil {
+		return nil, err
+	}
+	repos, err := client.ListRepositories(ctx, vcs.ListRepositoriesOptions{
+		PageSize: resource.MaxPageSize,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if a.cache != nil {
+		encoded, err := json.Marshal(repos)
+		if err != nil {
+			return nil, err
+		}
+		if err := a.cache.Set(key, encoded); err != nil {
+			a.Error(err, "caching vcs repositories", "provider_id", providerID)
+		}
+	}
+	return repos, nil
+}
+
+func searchRepositories(repos []string, opts ListRepositoriesOptions) *resource.Page[string] {
+	if opts.Search != "" {
+		search := strings.ToLower(opts.Search)
+		matches := make([]string, 0, len(repos))
+		for _, repo := range repos {
+			if strings.Contains(strings.ToLower(repo), search) {
+				matches = append(matches, repo)
+			}
+		}
+		repos = matches
+	}
+	return resource.NewPage(repos, opts.PageOptions, nil)
+}
+
+func repositoriesCacheKey(providerID string) string {
+	return fmt.Sprintf("%s.repos.json", providerID)
+}
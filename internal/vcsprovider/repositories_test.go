@@ -0,0 +1,94 @@
+package vcsprovider
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/leg100/otf/internal/resource"
+	"github.com/leg100/otf/internal/vcs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestService_listRepositories(t *testing.T) {
+	ctx := context.Background()
+	svc := &Service{Logger: logr.Discard(), cache: newFakeCache()}
+	client := &fakeVCSClient{repos: []string{"acme/api", "acme/web"}}
+	newClient := func() (vcs.Client, error) { return client, nil }
+
+	got, err := svc.listRepositories(ctx, "vcs-123", newClient)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"acme/api", "acme/web"}, got)
+	assert.Equal(t, 1, client.calls)
+
+	// second call should be served from the cache
+	got, err = svc.listRepositories(ctx, "vcs-123", newClient)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"acme/api", "acme/web"}, got)
+	assert.Equal(t, 1, client.calls)
+
+	// a different provider should not be served from the cache
+	_, err = svc.listRepositories(ctx, "vcs-456", newClient)
+	require.NoError(t, err)
+	assert.Equal(t, 2, client.calls)
+}
+
+func TestSearchRepositories(t *testing.T) {
+	repos := []string{"acme/api", "acme/web", "leg100/otf", "Acme/Terraform"}
+
+	tests := []struct {
+		name string
+		opts ListRepositoriesOptions
+		want []string
+	}{
+		{"no search", ListRepositoriesOptions{}, repos},
+		{"search ignoring case", ListRepositoriesOptions{Search: "acme"}, []string{"acme/api", "acme/web", "Acme/Terraform"}},
+		{"no matches", ListRepositoriesOptions{Search: "foo"}, []string{}},
+		{
+			"paginate matches",
+			ListRepositoriesOptions{Search: "acme", PageOptions: resource.PageOptions{PageNumber: 2, PageSize: 2}},
+			[]string{"Acme/Terraform"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := searchRepositories(repos, tt.opts)
+			assert.Equal(t, tt.want, got.Items)
+		})
+	}
+}
+
+type (
+	fakeCache struct {
+		items map[string][]byte
+	}
+	fakeVCSClient struct {
+		repos []string
+		calls int
+
+		vcs.Client
+	}
+)
+
+func newFakeCache() *fakeCache {
+	return &fakeCache{items: make(map[string][]byte)}
+}
+
+func (f *fakeCache) Get(key string) ([]byte, error) {
+	if v, ok := f.items[key]; ok {
+		return v, nil
+	}
+	return nil, errors.New("not found")
+}
+
+func (f *fakeCache) Set(key string, val []byte) error {
+	f.items[key] = val
+	return nil
+}
+
+func (f *fakeVCSClient) ListRepositories(context.Context, vcs.ListRepositoriesOptions) ([]string, error) {
+	f.calls++
+	return f.repos, nil
+}
@@ -25,8 +25,10 @@ type (
 		db                *pgdb
 		web               *webHandlers
 		api               *tfe
+		otfapi            *api
 		beforeDeleteHooks []func(context.Context, *VCSProvider) error
 		githubapps        *github.Service
+		cache             internal.Cache
 
 		*internal.HostnameService
 		*factory
@@ -40,24 +42,32 @@ type (
 		logr.Logger
 		vcs.Subscriber
 
-		GithubAppService    *github.Service
-		GithubHostname      string
-		GitlabHostname      string
-		SkipTLSVerification bool
+		Cache                   internal.Cache
+		GithubAppService        *github.Service
+		GithubHostname          string
+		GitlabHostname          string
+		BitbucketHostname       string
+		BitbucketServerHostname string
+		GiteaHostname           string
+		SkipTLSVerification     bool
 	}
 )
 
 func NewService(opts Options) *Service {
 	factory := factory{
-		githubapps:          opts.GithubAppService,
-		githubHostname:      opts.GithubHostname,
-		gitlabHostname:      opts.GitlabHostname,
-		skipTLSVerification: opts.SkipTLSVerification,
+		githubapps:              opts.GithubAppService,
+		githubHostname:          opts.GithubHostname,
+		gitlabHostname:          opts.GitlabHostname,
+		bitbucketHostname:       opts.BitbucketHostname,
+		bitbucketServerHostname: opts.BitbucketServerHostname,
+		giteaHostname:           opts.GiteaHostname,
+		skipTLSVerification:     opts.SkipTLSVerification,
 	}
 	svc := Service{
 		Logger:          opts.Logger,
 		HostnameService: opts.HostnameService,
 		githubapps:      opts.GithubAppService,
+		cache:           opts.Cache,
 		site:            &internal.SiteAuthorizer{Logger: opts.Logger},
 		organization:    &organization.Authorizer{Logger: opts.Logger},
 		factory:         &factory,
@@ -67,22 +77,26 @@ func NewService(opts Options) *Service {
 		},
 	}
 	svc.web = &webHandlers{
-		Renderer:        opts.Renderer,
-		HostnameService: opts.HostnameService,
-		GithubHostname:  opts.GithubHostname,
-		GitlabHostname:  opts.GitlabHostname,
-		client:          &svc,
-		githubApps:      opts.GithubAppService,
+		Renderer:                opts.Renderer,
+		HostnameService:         opts.HostnameService,
+		GithubHostname:          opts.GithubHostname,
+		GitlabHostname:          opts.GitlabHostname,
+		BitbucketHostname:       opts.BitbucketHostname,
+		BitbucketServerHostname: opts.BitbucketServerHostname,
+		GiteaHostname:           opts.GiteaHostname,
+		client:                  &svc,
+		githubApps:              opts.GithubAppService,
 	}
 	svc.api = &tfe{
 		Service:   &svc,
 		Responder: opts.Responder,
 	}
+	svc.otfapi = &api{Service: &svc}
 	// delete vcs providers when a github app is uninstalled
-	opts.Subscribe(func(event vcs.Event) {
+	opts.Subscribe("vcs-provider-uninstaller", func(event vcs.Event) error {
 		// ignore events other than uninstallation events
 		if event.Type != vcs.EventTypeInstallation || event.Action != vcs.ActionDeleted {
-			return
+			return nil
 		}
 		// create user with unlimited permissions
 		user := &internal.Superuser{Username: "vcs-provider-service"}
@@ -90,14 +104,15 @@ func NewService(opts Options) *Service {
 		// list all vcsproviders using the app install
 		providers, err := svc.ListVCSProvidersByGithubAppInstall(ctx, *event.GithubAppInstallID)
 		if err != nil {
-			return
+			return err
 		}
 		// and delete them
 		for _, prov := range providers {
 			if _, err = svc.Delete(ctx, prov.ID); err != nil {
-				return
+				return err
 			}
 		}
+		return nil
 	})
 	return &svc
 }
@@ -105,6 +120,7 @@ func NewService(opts Options) *Service {
 func (a *Service) AddHandlers(r *mux.Router) {
 	a.web.addHandlers(r)
 	a.api.addHandlers(r)
+	a.otfapi.addHandlers(r)
 }
 
 func (a *Service) Create(ctx context.Context, opts CreateOptions) (*VCSProvider, error) {
@@ -4,10 +4,15 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"net/url"
 
 	"github.com/leg100/otf/internal"
 
 	"github.com/gorilla/mux"
+	"github.com/leg100/otf/internal/bitbucket"
+	"github.com/leg100/otf/internal/bitbucketserver"
+	"github.com/leg100/otf/internal/github"
+	"github.com/leg100/otf/internal/gitlab"
 	"github.com/leg100/otf/internal/http/decode"
 	"github.com/leg100/otf/internal/tfeapi"
 	"github.com/leg100/otf/internal/tfeapi/types"
@@ -15,8 +20,9 @@ import (
 )
 
 const (
-	GithubAPIURL  = "https://api.github.com"
-	GithubHTTPURL = "https://github.com"
+	GithubAPIURL    = "https://api.github.com"
+	GithubHTTPURL   = "https://github.com"
+	BitbucketAPIURL = "https://api.bitbucket.org/2.0"
 )
 
 type tfe struct {
@@ -30,7 +36,16 @@ func (a *tfe) addHandlers(r *mux.Router) {
 	r.HandleFunc("/organizations/{organization_name}/oauth-clients", a.createOAuthClient).Methods("POST")
 	r.HandleFunc("/organizations/{organization_name}/oauth-clients", a.listOAuthClients).Methods("GET")
 	r.HandleFunc("/oauth-clients/{oauth_client_id}", a.getOAuthClient).Methods("GET")
+	r.HandleFunc("/oauth-clients/{oauth_client_id}", a.updateOAuthClient).Methods("PATCH")
 	r.HandleFunc("/oauth-clients/{oauth_client_id}", a.deleteOAuthClient).Methods("DELETE")
+
+	// OTF has no separate concept of an OAuth token: each VCS provider has
+	// exactly one token, identified using the VCS provider ID.
+	r.HandleFunc("/organizations/{organization_name}/oauth-tokens", a.listOrganizationOAuthTokens).Methods("GET")
+	r.HandleFunc("/oauth-clients/{oauth_client_id}/oauth-tokens", a.listOAuthTokens).Methods("GET")
+	r.HandleFunc("/oauth-tokens/{oauth_token_id}", a.getOAuthToken).Methods("GET")
+	r.HandleFunc("/oauth-tokens/{oauth_token_id}", a.updateOAuthToken).Methods("PATCH")
+	r.HandleFunc("/oauth-tokens/{oauth_token_id}", a.deleteOAuthToken).Methods("DELETE")
 }
 
 func (a *tfe) createOAuthClient(w http.ResponseWriter, r *http.Request) {
@@ -77,16 +92,13 @@ func (a *tfe) createOAuthClient(w http.ResponseWriter, r *http.Request) {
 		tfeapi.Error(w, errors.New("secret parameter is unsupported"))
 		return
 	}
-	if *params.ServiceProvider != types.ServiceProviderGithub {
-		tfeapi.Error(w, fmt.Errorf("service-provider=%s is unsupported", string(*params.ServiceProvider)))
-		return
-	}
-	if *params.APIURL != GithubAPIURL {
-		tfeapi.Error(w, fmt.Errorf("only api-url=%s is supported", GithubAPIURL))
+	kind, err := a.kindFromServiceProvider(*params.ServiceProvider)
+	if err != nil {
+		tfeapi.Error(w, err)
 		return
 	}
-	if *params.HTTPURL != GithubHTTPURL {
-		tfeapi.Error(w, fmt.Errorf("only http-url=%s is supported", string(*params.HTTPURL)))
+	if err := a.checkHTTPURL(kind, *params.HTTPURL); err != nil {
+		tfeapi.Error(w, err)
 		return
 	}
 
@@ -99,7 +111,7 @@ func (a *tfe) createOAuthClient(w http.ResponseWriter, r *http.Request) {
 		Name:         *params.Name,
 		Organization: org,
 		Token:        params.OAuthToken,
-		Kind:         vcs.KindPtr(vcs.GithubKind),
+		Kind:         &kind,
 	})
 	if err != nil {
 		tfeapi.Error(w, err)
@@ -146,6 +158,55 @@ func (a *tfe) getOAuthClient(w http.ResponseWriter, r *http.Request) {
 	a.Respond(w, r, a.convert(oauthClient), http.StatusOK)
 }
 
+func (a *tfe) updateOAuthClient(w http.ResponseWriter, r *http.Request) {
+	id, err := decode.Param("oauth_client_id", r)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+
+	var params types.OAuthClientUpdateOptions
+	if err := tfeapi.Unmarshal(r.Body, &params); err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+
+	// unsupported parameters
+	if params.Key != nil {
+		tfeapi.Error(w, errors.New("key parameter is unsupported"))
+		return
+	}
+	if params.RSAPublicKey != nil {
+		tfeapi.Error(w, errors.New("rsa-public-key parameter is unsupported"))
+		return
+	}
+	if params.Secret != nil {
+		tfeapi.Error(w, errors.New("secret parameter is unsupported"))
+		return
+	}
+
+	// an unspecified name leaves the name unchanged
+	if params.Name == nil {
+		existing, err := a.Get(r.Context(), id)
+		if err != nil {
+			tfeapi.Error(w, err)
+			return
+		}
+		params.Name = &existing.Name
+	}
+
+	oauthClient, err := a.Update(r.Context(), id, UpdateOptions{
+		Name:  *params.Name,
+		Token: params.OAuthToken,
+	})
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+
+	a.Respond(w, r, a.convert(oauthClient), http.StatusOK)
+}
+
 func (a *tfe) deleteOAuthClient(w http.ResponseWriter, r *http.Request) {
 	id, err := decode.Param("oauth_client_id", r)
 	if err != nil {
@@ -161,21 +222,192 @@ func (a *tfe) deleteOAuthClient(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusNoContent)
 }
 
+func (a *tfe) listOrganizationOAuthTokens(w http.ResponseWriter, r *http.Request) {
+	org, err := decode.Param("organization_name", r)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+
+	providers, err := a.List(r.Context(), org)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+
+	// convert items
+	to := make([]*types.OAuthToken, len(providers))
+	for i, from := range providers {
+		to[i] = a.convertToken(from)
+	}
+	a.Respond(w, r, to, http.StatusOK)
+}
+
+func (a *tfe) listOAuthTokens(w http.ResponseWriter, r *http.Request) {
+	id, err := decode.Param("oauth_client_id", r)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+
+	provider, err := a.Get(r.Context(), id)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+
+	a.Respond(w, r, []*types.OAuthToken{a.convertToken(provider)}, http.StatusOK)
+}
+
+func (a *tfe) getOAuthToken(w http.ResponseWriter, r *http.Request) {
+	id, err := decode.Param("oauth_token_id", r)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+
+	provider, err := a.Get(r.Context(), id)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+
+	a.Respond(w, r, a.convertToken(provider), http.StatusOK)
+}
+
+func (a *tfe) updateOAuthToken(w http.ResponseWriter, r *http.Request) {
+	id, err := decode.Param("oauth_token_id", r)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+
+	var params types.OAuthTokenUpdateOptions
+	if err := tfeapi.Unmarshal(r.Body, &params); err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+
+	// SSH keys are instead assigned to workspaces.
+	if params.PrivateSSHKey != nil {
+		tfeapi.Error(w, errors.New("ssh-key parameter is unsupported"))
+		return
+	}
+
+	provider, err := a.Get(r.Context(), id)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+
+	a.Respond(w, r, a.convertToken(provider), http.StatusOK)
+}
+
+func (a *tfe) deleteOAuthToken(w http.ResponseWriter, r *http.Request) {
+	id, err := decode.Param("oauth_token_id", r)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+
+	// a VCS provider cannot exist without its token, so deleting the token
+	// deletes the VCS provider.
+	if _, err = a.Delete(r.Context(), id); err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// kindFromServiceProvider maps a TFE service provider to a VCS kind.
+func (a *tfe) kindFromServiceProvider(sp types.ServiceProviderType) (vcs.Kind, error) {
+	switch sp {
+	case types.ServiceProviderGithub, types.ServiceProviderGithubEE:
+		return vcs.GithubKind, nil
+	case types.ServiceProviderGitlab, types.ServiceProviderGitlabCE, types.ServiceProviderGitlabEE:
+		return vcs.GitlabKind, nil
+	case types.ServiceProviderBitbucket:
+		return vcs.BitbucketKind, nil
+	case types.ServiceProviderBitbucketServer:
+		return vcs.BitbucketServerKind, nil
+	default:
+		return "", fmt.Errorf("service-provider=%s is unsupported", string(sp))
+	}
+}
+
+// checkHTTPURL checks the http-url parameter refers to either the public
+// hostname of the VCS, e.g. github.com, or the hostname configured for the VCS.
+// Either way, the VCS provider connects to the configured hostname.
+func (a *tfe) checkHTTPURL(kind vcs.Kind, httpURL string) error {
+	u, err := url.Parse(httpURL)
+	if err != nil {
+		return fmt.Errorf("invalid http-url: %w", err)
+	}
+	var defaultHostname, configured string
+	switch kind {
+	case vcs.GithubKind:
+		defaultHostname, configured = github.DefaultHostname, a.githubHostname
+	case vcs.GitlabKind:
+		defaultHostname, configured = gitlab.DefaultHostname, a.gitlabHostname
+	case vcs.BitbucketKind:
+		defaultHostname, configured = bitbucket.DefaultHostname, a.bitbucketHostname
+	case vcs.BitbucketServerKind:
+		// bitbucket server is only ever self-hosted
+		if a.bitbucketServerHostname == "" {
+			return bitbucketserver.ErrHostnameRequired
+		}
+		defaultHostname, configured = a.bitbucketServerHostname, a.bitbucketServerHostname
+	}
+	if u.Host != defaultHostname && u.Host != configured {
+		return fmt.Errorf("only http-url=https://%s is supported", configured)
+	}
+	return nil
+}
+
 func (a *tfe) convert(from *VCSProvider) *types.OAuthClient {
 	to := &types.OAuthClient{
-		ID:        from.ID,
-		CreatedAt: from.CreatedAt,
-		// Only github via github.com is supported currently, so hardcode these values.
-		ServiceProvider: types.ServiceProviderGithub,
-		APIURL:          GithubAPIURL,
-		HTTPURL:         GithubHTTPURL,
+		ID:           from.ID,
+		CreatedAt:    from.CreatedAt,
+		HTTPURL:      (&url.URL{Scheme: "https", Host: from.Hostname}).String(),
+		Organization: &types.Organization{Name: from.Organization},
 		// OTF has no corresponding concept of an OAuthToken, so just use the
 		// VCS provider ID (the go-tfe integration tests we use expect
 		// at least an ID).
 		OAuthTokens: []*types.OAuthToken{
 			{ID: from.ID},
 		},
-		Organization: &types.Organization{Name: from.Organization},
+	}
+	switch from.Kind {
+	case vcs.GithubKind:
+		to.ServiceProviderName = "GitHub"
+		if from.Hostname == github.DefaultHostname {
+			to.ServiceProvider = types.ServiceProviderGithub
+			to.APIURL = GithubAPIURL
+		} else {
+			to.ServiceProvider = types.ServiceProviderGithubEE
+			to.APIURL = (&url.URL{Scheme: "https", Host: from.Hostname, Path: "/api/v3"}).String()
+		}
+	case vcs.GitlabKind:
+		to.ServiceProviderName = "GitLab"
+		if from.Hostname == gitlab.DefaultHostname {
+			to.ServiceProvider = types.ServiceProviderGitlab
+		} else {
+			to.ServiceProvider = types.ServiceProviderGitlabEE
+		}
+		to.APIURL = (&url.URL{Scheme: "https", Host: from.Hostname, Path: "/api/v4"}).String()
+	case vcs.BitbucketKind:
+		to.ServiceProviderName = "Bitbucket Cloud"
+		to.ServiceProvider = types.ServiceProviderBitbucket
+		to.APIURL = BitbucketAPIURL
+	case vcs.BitbucketServerKind:
+		to.ServiceProviderName = "Bitbucket Server"
+		to.ServiceProvider = types.ServiceProviderBitbucketServer
+		to.APIURL = (&url.URL{Scheme: "https", Host: from.Hostname}).String()
+	case vcs.GiteaKind:
+		// TFE has no service provider type for gitea, so it is left empty
+		to.ServiceProviderName = "Gitea"
+		to.APIURL = (&url.URL{Scheme: "https", Host: from.Hostname, Path: "/api/v1"}).String()
 	}
 	// an empty name in otf is equivalent to a nil name in tfe
 	if from.Name != "" {
@@ -183,3 +415,11 @@ func (a *tfe) convert(from *VCSProvider) *types.OAuthClient {
 	}
 	return to
 }
+
+func (a *tfe) convertToken(from *VCSProvider) *types.OAuthToken {
+	return &types.OAuthToken{
+		ID:          from.ID,
+		CreatedAt:   from.CreatedAt,
+		OAuthClient: &types.OAuthClient{ID: from.ID},
+	}
+}
@@ -10,8 +10,12 @@ import (
 	"log/slog"
 
 	"github.com/leg100/otf/internal"
+	"github.com/leg100/otf/internal/bitbucket"
+	"github.com/leg100/otf/internal/bitbucketserver"
+	"github.com/leg100/otf/internal/gitea"
 	"github.com/leg100/otf/internal/github"
 	"github.com/leg100/otf/internal/gitlab"
+	"github.com/leg100/otf/internal/resource"
 	"github.com/leg100/otf/internal/vcs"
 )
 
@@ -22,9 +26,9 @@ type (
 		Name         string
 		CreatedAt    time.Time
 		Organization string // name of OTF organization
-		Hostname     string // hostname of github/gitlab etc
+		Hostname     string // hostname of github/gitlab/bitbucket etc
 
-		Kind  vcs.Kind // github/gitlab etc. Not necessary if GithubApp is non-nil.
+		Kind  vcs.Kind // github/gitlab/bitbucket etc. Not necessary if GithubApp is non-nil.
 		Token *string  // personal access token.
 
 		GithubApp *github.InstallCredentials // mutually exclusive with Token.
@@ -36,9 +40,12 @@ type (
 	factory struct {
 		githubapps *github.Service
 
-		githubHostname      string
-		gitlabHostname      string
-		skipTLSVerification bool // toggle skipping verification of VCS host's TLS cert.
+		githubHostname          string
+		gitlabHostname          string
+		bitbucketHostname       string
+		bitbucketServerHostname string
+		giteaHostname           string
+		skipTLSVerification     bool // toggle skipping verification of VCS host's TLS cert.
 	}
 
 	CreateOptions struct {
@@ -68,12 +75,25 @@ func (f *factory) newProvider(ctx context.Context, opts CreateOptions) (*VCSProv
 			return nil, err
 		}
 	}
-	return f.newWithGithubCredentials(ctx, opts, creds)
+	provider, err := f.newWithGithubCredentials(ctx, opts, creds)
+	if err != nil {
+		return nil, err
+	}
+	// bitbucket server and gitea have no default hostname
+	if provider.Hostname == "" {
+		switch provider.Kind {
+		case vcs.BitbucketServerKind:
+			return nil, bitbucketserver.ErrHostnameRequired
+		case vcs.GiteaKind:
+			return nil, gitea.ErrHostnameRequired
+		}
+	}
+	return provider, nil
 }
 
 func (f *factory) newWithGithubCredentials(ctx context.Context, opts CreateOptions, creds *github.InstallCredentials) (*VCSProvider, error) {
 	provider := &VCSProvider{
-		ID:                  internal.NewID("vcs"),
+		ID:                  resource.NewID(resource.VCSProviderKind),
 		Name:                opts.Name,
 		CreatedAt:           internal.CurrentTimestamp(nil),
 		Organization:        opts.Organization,
@@ -89,6 +109,12 @@ func (f *factory) newWithGithubCredentials(ctx context.Context, opts CreateOptio
 			provider.Hostname = f.githubHostname
 		case vcs.GitlabKind:
 			provider.Hostname = f.gitlabHostname
+		case vcs.BitbucketKind:
+			provider.Hostname = f.bitbucketHostname
+		case vcs.BitbucketServerKind:
+			provider.Hostname = f.bitbucketServerHostname
+		case vcs.GiteaKind:
+			provider.Hostname = f.giteaHostname
 		default:
 			return nil, errors.New("no hostname found for vcs kind")
 		}
@@ -150,6 +176,12 @@ func (t *VCSProvider) NewClient() (vcs.Client, error) {
 			return github.NewTokenClient(opts)
 		case vcs.GitlabKind:
 			return gitlab.NewTokenClient(opts)
+		case vcs.BitbucketKind:
+			return bitbucket.NewTokenClient(opts)
+		case vcs.BitbucketServerKind:
+			return bitbucketserver.NewTokenClient(opts)
+		case vcs.GiteaKind:
+			return gitea.NewTokenClient(opts)
 		default:
 			return nil, fmt.Errorf("unknown kind: %s", t.Kind)
 		}
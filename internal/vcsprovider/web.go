@@ -21,8 +21,11 @@ type webHandlers struct {
 	client     webClient
 	githubApps webGithubAppClient
 
-	GithubHostname string
-	GitlabHostname string
+	GithubHostname          string
+	GitlabHostname          string
+	BitbucketHostname       string
+	BitbucketServerHostname string
+	GiteaHostname           string
 }
 
 type webClient interface {
@@ -84,6 +87,18 @@ func (h *webHandlers) newPersonalToken(w http.ResponseWriter, r *http.Request) {
 		response.Kind = string(vcs.GitlabKind)
 		response.Scope = "api"
 		response.TokensURL = "https://" + h.GitlabHostname + "/-/profile/personal_access_tokens"
+	case vcs.BitbucketKind:
+		response.Kind = string(vcs.BitbucketKind)
+		response.Scope = "repository, pull request and webhook"
+		response.TokensURL = "https://" + h.BitbucketHostname + "/account/settings/app-passwords/"
+	case vcs.BitbucketServerKind:
+		response.Kind = string(vcs.BitbucketServerKind)
+		response.Scope = "repository admin"
+		response.TokensURL = "https://" + h.BitbucketServerHostname + "/plugins/servlet/access-tokens/manage"
+	case vcs.GiteaKind:
+		response.Kind = string(vcs.GiteaKind)
+		response.Scope = "write:repository"
+		response.TokensURL = "https://" + h.GiteaHostname + "/user/settings/applications"
 	}
 	h.Render("vcs_provider_pat_new.tmpl", w, response)
 }
@@ -221,12 +236,18 @@ func (h *webHandlers) list(w http.ResponseWriter, r *http.Request) {
 	}
 	h.Render("vcs_provider_list.tmpl", w, struct {
 		organization.OrganizationPage
-		Items     []*VCSProvider
-		GithubApp *github.App
+		Items           []*VCSProvider
+		GithubApp       *github.App
+		BitbucketServer bool
+		Gitea           bool
 	}{
 		OrganizationPage: organization.NewPage(r, "vcs providers", org),
 		Items:            providers,
 		GithubApp:        app,
+		// bitbucket server and gitea providers can only be created once a
+		// hostname is configured
+		BitbucketServer: h.BitbucketServerHostname != "",
+		Gitea:           h.GiteaHostname != "",
 	})
 }
 
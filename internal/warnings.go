@@ -0,0 +1,58 @@
+package internal
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sync"
+)
+
+// unexported key type prevents collisions
+type warningsCtxKeyType string
+
+const warningsCtxKey warningsCtxKeyType = "warnings"
+
+// warnings collects warnings over the course of a request, e.g. warning the
+// client of deprecated behaviour, which are then relayed to the client in the
+// response.
+type warnings struct {
+	mu   sync.Mutex
+	msgs []string
+}
+
+// AddWarningsToContext adds to the context a collector of warnings.
+func AddWarningsToContext(ctx context.Context) context.Context {
+	return context.WithValue(ctx, warningsCtxKey, &warnings{})
+}
+
+// AddWarning adds a warning to the context, to be relayed to the client. If
+// the context has no collector of warnings, e.g. the context does not belong
+// to an API request, then the warning is discarded. Duplicate warnings are
+// ignored.
+func AddWarning(ctx context.Context, format string, args ...any) {
+	w, ok := ctx.Value(warningsCtxKey).(*warnings)
+	if !ok {
+		return
+	}
+	msg := fmt.Sprintf(format, args...)
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if !slices.Contains(w.msgs, msg) {
+		w.msgs = append(w.msgs, msg)
+	}
+}
+
+// WarningsFromContext retrieves the warnings added to the context.
+func WarningsFromContext(ctx context.Context) []string {
+	w, ok := ctx.Value(warningsCtxKey).(*warnings)
+	if !ok {
+		return nil
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	return slices.Clone(w.msgs)
+}
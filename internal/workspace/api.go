@@ -7,6 +7,7 @@ import (
 	"github.com/gorilla/mux"
 	otfapi "github.com/leg100/otf/internal/api"
 	"github.com/leg100/otf/internal/http/decode"
+	"github.com/leg100/otf/internal/resource"
 	"github.com/leg100/otf/internal/tfeapi"
 )
 
@@ -28,6 +29,10 @@ func (a *api) addHandlers(r *mux.Router) {
 	r.HandleFunc("/workspaces/{workspace_id}/actions/lock", a.lockWorkspace).Methods("POST")
 	r.HandleFunc("/workspaces/{workspace_id}/actions/unlock", a.unlockWorkspace).Methods("POST")
 	r.HandleFunc("/workspaces/{workspace_id}/actions/force-unlock", a.forceUnlockWorkspace).Methods("POST")
+
+	r.HandleFunc("/organizations/{organization_name}/deleted-workspaces", a.listDeletedWorkspaces).Methods("GET")
+	r.HandleFunc("/deleted-workspaces/{workspace_id}/actions/restore", a.restoreWorkspace).Methods("POST")
+	r.HandleFunc("/deleted-workspaces/{workspace_id}", a.purgeWorkspace).Methods("DELETE")
 }
 
 func (a *api) getWorkspace(w http.ResponseWriter, r *http.Request) {
@@ -55,7 +60,7 @@ func (a *api) getWorkspaceByName(w http.ResponseWriter, r *http.Request) {
 
 	ws, err := a.GetByName(r.Context(), params.Organization, params.Name)
 	if err != nil {
-		tfeapi.Error(w, err)
+		byNameError(w, r, err)
 		return
 	}
 
@@ -99,6 +104,7 @@ func (a *api) updateWorkspace(w http.ResponseWriter, r *http.Request) {
 
 	a.Respond(w, r, ws, http.StatusOK)
 }
+
 func (a *api) lockWorkspace(w http.ResponseWriter, r *http.Request) {
 	id, err := decode.Param("workspace_id", r)
 	if err != nil {
@@ -108,7 +114,7 @@ func (a *api) lockWorkspace(w http.ResponseWriter, r *http.Request) {
 
 	ws, err := a.Lock(r.Context(), id, nil)
 	if err != nil {
-		tfeapi.Error(w, err)
+		tfeapi.Error(w, lockError(err))
 		return
 	}
 
@@ -131,6 +137,41 @@ func (a *api) unlock(w http.ResponseWriter, r *http.Request, force bool) {
 	}
 
 	ws, err := a.Unlock(r.Context(), id, nil, force)
+	if err != nil {
+		tfeapi.Error(w, lockError(err))
+		return
+	}
+
+	a.Respond(w, r, ws, http.StatusOK)
+}
+
+func (a *api) listDeletedWorkspaces(w http.ResponseWriter, r *http.Request) {
+	var params struct {
+		Organization string `schema:"organization_name,required"`
+		resource.PageOptions
+	}
+	if err := decode.All(&params, r); err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+
+	page, err := a.ListDeleted(r.Context(), params.Organization, params.PageOptions)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+
+	a.RespondWithPage(w, r, page.Items, page.Pagination)
+}
+
+func (a *api) restoreWorkspace(w http.ResponseWriter, r *http.Request) {
+	id, err := decode.Param("workspace_id", r)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+
+	ws, err := a.Restore(r.Context(), id)
 	if err != nil {
 		tfeapi.Error(w, err)
 		return
@@ -138,3 +179,18 @@ func (a *api) unlock(w http.ResponseWriter, r *http.Request, force bool) {
 
 	a.Respond(w, r, ws, http.StatusOK)
 }
+
+func (a *api) purgeWorkspace(w http.ResponseWriter, r *http.Request) {
+	id, err := decode.Param("workspace_id", r)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+
+	if err := a.Purge(r.Context(), id); err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -90,9 +90,9 @@ func (c *Client) Lock(ctx context.Context, workspaceID string, runID *string) (*
 func (c *Client) Unlock(ctx context.Context, workspaceID string, runID *string, force bool) (*Workspace, error) {
 	var u string
 	if force {
-		u = fmt.Sprintf("workspaces/%s/actions/unlock", workspaceID)
-	} else {
 		u = fmt.Sprintf("workspaces/%s/actions/force-unlock", workspaceID)
+	} else {
+		u = fmt.Sprintf("workspaces/%s/actions/unlock", workspaceID)
 	}
 	req, err := c.NewRequest("POST", u, nil)
 	if err != nil {
@@ -49,6 +49,9 @@ type (
 		VCSTagsRegex               pgtype.Text            `json:"vcs_tags_regex"`
 		AllowCLIApply              pgtype.Bool            `json:"allow_cli_apply"`
 		AgentPoolID                pgtype.Text            `json:"agent_pool_id"`
+		IgnorePatterns             []string               `json:"ignore_patterns"`
+		AssessmentsEnabled         pgtype.Bool            `json:"assessments_enabled"`
+		SerializationGroup         pgtype.Text            `json:"serialization_group"`
 		Tags                       []string               `json:"tags"`
 		LatestRunStatus            pgtype.Text            `json:"latest_run_status"`
 		UserLock                   *pggen.Users           `json:"user_lock"`
@@ -79,6 +82,8 @@ func (r pgresult) toWorkspace() (*Workspace, error) {
 		TerraformVersion:           r.TerraformVersion.String,
 		TriggerPrefixes:            r.TriggerPrefixes,
 		TriggerPatterns:            r.TriggerPatterns,
+		IgnorePatterns:             r.IgnorePatterns,
+		AssessmentsEnabled:         r.AssessmentsEnabled.Bool,
 		WorkingDirectory:           r.WorkingDirectory.String,
 		Organization:               r.OrganizationName.String,
 		Tags:                       r.Tags,
@@ -86,6 +91,9 @@ func (r pgresult) toWorkspace() (*Workspace, error) {
 	if r.AgentPoolID.Status == pgtype.Present {
 		ws.AgentPoolID = &r.AgentPoolID.String
 	}
+	if r.SerializationGroup.Status == pgtype.Present {
+		ws.SerializationGroup = &r.SerializationGroup.String
+	}
 
 	if r.WorkspaceConnection != nil {
 		ws.Connection = &Connection{
@@ -147,6 +155,9 @@ func (db *pgdb) create(ctx context.Context, ws *Workspace) error {
 		TerraformVersion:           sql.String(ws.TerraformVersion),
 		TriggerPrefixes:            ws.TriggerPrefixes,
 		TriggerPatterns:            ws.TriggerPatterns,
+		IgnorePatterns:             ws.IgnorePatterns,
+		AssessmentsEnabled:         sql.Bool(ws.AssessmentsEnabled),
+		SerializationGroup:         sql.StringPtr(ws.SerializationGroup),
 		VCSTagsRegex:               sql.StringPtr(nil),
 		WorkingDirectory:           sql.String(ws.WorkingDirectory),
 		OrganizationName:           sql.String(ws.Organization),
@@ -194,6 +205,9 @@ func (db *pgdb) update(ctx context.Context, workspaceID string, fn func(*Workspa
 			TerraformVersion:           sql.String(ws.TerraformVersion),
 			TriggerPrefixes:            ws.TriggerPrefixes,
 			TriggerPatterns:            ws.TriggerPatterns,
+			IgnorePatterns:             ws.IgnorePatterns,
+			AssessmentsEnabled:         sql.Bool(ws.AssessmentsEnabled),
+			SerializationGroup:         sql.StringPtr(ws.SerializationGroup),
 			VCSTagsRegex:               sql.StringPtr(nil),
 			WorkingDirectory:           sql.String(ws.WorkingDirectory),
 			UpdatedAt:                  sql.Timestamptz(ws.UpdatedAt),
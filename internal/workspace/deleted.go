@@ -0,0 +1,107 @@
+package workspace
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-logr/logr"
+)
+
+const (
+	// DefaultRecoveryDays is the default number of days a deleted workspace
+	// can be restored before it is permanently purged.
+	DefaultRecoveryDays = 7
+
+	// ReaperLockID guarantees only one reaper on a cluster is running at any
+	// time.
+	ReaperLockID int64 = 5577006791947779415
+)
+
+var defaultReaperInterval = time.Hour
+
+type (
+	// DeletedWorkspace is a workspace that has been deleted but can still be
+	// restored, along with its state versions, until its recovery window
+	// elapses.
+	DeletedWorkspace struct {
+		ID           string    `jsonapi:"primary,deleted-workspaces"`
+		Name         string    `jsonapi:"attribute" json:"name"`
+		Organization string    `jsonapi:"attribute" json:"organization"`
+		DeletedAt    time.Time `jsonapi:"attribute" json:"deleted_at"`
+		DeletedBy    string    `jsonapi:"attribute" json:"deleted_by"`
+		// PurgeAt is when the workspace is permanently deleted.
+		PurgeAt time.Time `jsonapi:"attribute" json:"purge_at"`
+
+		// snapshot of the workspace at the time of deletion
+		workspace *Workspace
+	}
+
+	// Reaper permanently purges deleted workspaces once their recovery window
+	// has elapsed, and the former names of renamed workspaces once their
+	// grace period has elapsed.
+	//
+	// Only one reaper should be running on an OTF cluster at any one time.
+	Reaper struct {
+		logr.Logger
+
+		client reaperClient
+		// frequency with which the reaper checks for workspaces to purge.
+		interval time.Duration
+	}
+
+	reaperClient interface {
+		purgeExpired(ctx context.Context) (int64, error)
+		purgeTombstones(ctx context.Context) (int64, error)
+	}
+)
+
+// NewReaper constructs a reaper of deleted workspaces.
+func (s *Service) NewReaper(logger logr.Logger) *Reaper {
+	return &Reaper{
+		Logger:   logger.WithValues("component", "reaper"),
+		client:   s,
+		interval: defaultReaperInterval,
+	}
+}
+
+func (r *Reaper) String() string { return "workspace-reaper" }
+
+// Start the reaper. Every interval workspaces whose recovery window has
+// elapsed are purged.
+//
+// Should be invoked in a go routine.
+func (r *Reaper) Start(ctx context.Context) error {
+	purge := func() error {
+		purged, err := r.client.purgeExpired(ctx)
+		if err != nil {
+			return err
+		}
+		if purged > 0 {
+			r.V(0).Info("purged deleted workspaces", "count", purged)
+		}
+		tombstones, err := r.client.purgeTombstones(ctx)
+		if err != nil {
+			return err
+		}
+		if tombstones > 0 {
+			r.V(1).Info("purged former names of renamed workspaces", "count", tombstones)
+		}
+		return nil
+	}
+	// run at startup and then every interval
+	if err := purge(); err != nil {
+		return err
+	}
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			if err := purge(); err != nil {
+				return err
+			}
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
@@ -0,0 +1,122 @@
+package workspace
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/jackc/pgtype"
+	"github.com/jackc/pgx/v4"
+	"github.com/leg100/otf/internal"
+	"github.com/leg100/otf/internal/resource"
+	"github.com/leg100/otf/internal/sql"
+	"github.com/leg100/otf/internal/sql/pggen"
+)
+
+// deletedRow represents the result of a database query for a deleted
+// workspace.
+type deletedRow struct {
+	WorkspaceID      pgtype.Text        `json:"workspace_id"`
+	OrganizationName pgtype.Text        `json:"organization_name"`
+	Name             pgtype.Text        `json:"name"`
+	DeletedAt        pgtype.Timestamptz `json:"deleted_at"`
+	DeletedBy        pgtype.Text        `json:"deleted_by"`
+	Workspace        []byte             `json:"workspace"`
+}
+
+func (r deletedRow) toDeletedWorkspace(window time.Duration) (*DeletedWorkspace, error) {
+	var ws Workspace
+	if err := json.Unmarshal(r.Workspace, &ws); err != nil {
+		return nil, err
+	}
+	return &DeletedWorkspace{
+		ID:           r.WorkspaceID.String,
+		Name:         r.Name.String,
+		Organization: r.OrganizationName.String,
+		DeletedAt:    r.DeletedAt.Time.UTC(),
+		DeletedBy:    r.DeletedBy.String,
+		PurgeAt:      r.DeletedAt.Time.UTC().Add(window),
+		workspace:    &ws,
+	}, nil
+}
+
+func (db *pgdb) createDeleted(ctx context.Context, dw *DeletedWorkspace) error {
+	snapshot, err := json.Marshal(dw.workspace)
+	if err != nil {
+		return err
+	}
+	_, err = db.Conn(ctx).InsertDeletedWorkspace(ctx, pggen.InsertDeletedWorkspaceParams{
+		WorkspaceID:      sql.String(dw.ID),
+		OrganizationName: sql.String(dw.Organization),
+		Name:             sql.String(dw.Name),
+		DeletedAt:        sql.Timestamptz(dw.DeletedAt),
+		DeletedBy:        sql.String(dw.DeletedBy),
+		Workspace:        snapshot,
+	})
+	return sql.Error(err)
+}
+
+func (db *pgdb) listDeleted(ctx context.Context, organization string, opts resource.PageOptions, window time.Duration) (*resource.Page[*DeletedWorkspace], error) {
+	q := db.Conn(ctx)
+	batch := &pgx.Batch{}
+
+	q.FindDeletedWorkspacesByOrganizationBatch(batch, pggen.FindDeletedWorkspacesByOrganizationParams{
+		OrganizationName: sql.String(organization),
+		Limit:            opts.GetLimit(),
+		Offset:           opts.GetOffset(),
+	})
+	q.CountDeletedWorkspacesByOrganizationBatch(batch, sql.String(organization))
+
+	results := db.SendBatch(ctx, batch)
+	defer results.Close()
+
+	rows, err := q.FindDeletedWorkspacesByOrganizationScan(results)
+	if err != nil {
+		return nil, sql.Error(err)
+	}
+	count, err := q.CountDeletedWorkspacesByOrganizationScan(results)
+	if err != nil {
+		return nil, sql.Error(err)
+	}
+
+	items := make([]*DeletedWorkspace, len(rows))
+	for i, r := range rows {
+		dw, err := deletedRow(r).toDeletedWorkspace(window)
+		if err != nil {
+			return nil, err
+		}
+		items[i] = dw
+	}
+	return resource.NewPage(items, opts, internal.Int64(count.Int)), nil
+}
+
+func (db *pgdb) getDeleted(ctx context.Context, workspaceID string, window time.Duration) (*DeletedWorkspace, error) {
+	row, err := db.Conn(ctx).FindDeletedWorkspaceByID(ctx, sql.String(workspaceID))
+	if err != nil {
+		return nil, sql.Error(err)
+	}
+	return deletedRow(row).toDeletedWorkspace(window)
+}
+
+func (db *pgdb) getDeletedForUpdate(ctx context.Context, workspaceID string, window time.Duration) (*DeletedWorkspace, error) {
+	row, err := db.Conn(ctx).FindDeletedWorkspaceByIDForUpdate(ctx, sql.String(workspaceID))
+	if err != nil {
+		return nil, sql.Error(err)
+	}
+	return deletedRow(row).toDeletedWorkspace(window)
+}
+
+func (db *pgdb) deleteDeleted(ctx context.Context, workspaceID string) error {
+	_, err := db.Conn(ctx).DeleteDeletedWorkspaceByID(ctx, sql.String(workspaceID))
+	return sql.Error(err)
+}
+
+// deleteDeletedBefore permanently deletes workspaces that were deleted before
+// the given time, returning the number deleted.
+func (db *pgdb) deleteDeletedBefore(ctx context.Context, before time.Time) (int64, error) {
+	tag, err := db.Conn(ctx).DeleteDeletedWorkspacesBefore(ctx, sql.Timestamptz(before))
+	if err != nil {
+		return 0, sql.Error(err)
+	}
+	return tag.RowsAffected(), nil
+}
@@ -0,0 +1,167 @@
+package workspace
+
+import (
+	"context"
+
+	"github.com/leg100/otf/internal"
+	"github.com/leg100/otf/internal/rbac"
+	"github.com/leg100/otf/internal/resource"
+	"github.com/leg100/otf/internal/sql/pggen"
+)
+
+// AfterArchiveWorkspace registers a hook that is called when a deleted
+// workspace is archived, before the workspace itself is deleted, permitting
+// its resources to be archived too.
+func (s *Service) AfterArchiveWorkspace(hook func(context.Context, *Workspace) error) {
+	s.afterArchiveHooks = append(s.afterArchiveHooks, hook)
+}
+
+// AfterRestoreWorkspace registers a hook that is called when a deleted
+// workspace is restored, after the workspace itself is restored, permitting
+// its archived resources to be restored too.
+func (s *Service) AfterRestoreWorkspace(hook func(context.Context, *Workspace) error) {
+	s.afterRestoreHooks = append(s.afterRestoreHooks, hook)
+}
+
+// ListDeleted lists the deleted workspaces in an organization that can still
+// be restored.
+func (s *Service) ListDeleted(ctx context.Context, organization string, opts resource.PageOptions) (*resource.Page[*DeletedWorkspace], error) {
+	subject, err := s.organization.CanAccess(ctx, rbac.ListDeletedWorkspacesAction, organization)
+	if err != nil {
+		return nil, err
+	}
+
+	page, err := s.db.listDeleted(ctx, organization, opts, s.recoveryWindow)
+	if err != nil {
+		s.Error(err, "listing deleted workspaces", "organization", organization, "subject", subject)
+		return nil, err
+	}
+
+	s.V(9).Info("listed deleted workspaces", "organization", organization, "subject", subject, "count", len(page.Items))
+
+	return page, nil
+}
+
+// Restore a deleted workspace along with its state versions. The workspace is
+// restored with its original ID but without a VCS connection, run history,
+// variables, or team permissions.
+func (s *Service) Restore(ctx context.Context, workspaceID string) (*Workspace, error) {
+	dw, err := s.db.getDeleted(ctx, workspaceID, s.recoveryWindow)
+	if err != nil {
+		s.Error(err, "retrieving deleted workspace", "workspace", workspaceID)
+		return nil, err
+	}
+
+	subject, err := s.organization.CanAccess(ctx, rbac.RestoreWorkspaceAction, dw.Organization)
+	if err != nil {
+		return nil, err
+	}
+
+	var ws *Workspace
+	err = s.db.Tx(ctx, func(ctx context.Context, _ pggen.Querier) error {
+		// lock deleted workspace to prevent a concurrent restore or purge
+		dw, err := s.db.getDeletedForUpdate(ctx, workspaceID, s.recoveryWindow)
+		if err != nil {
+			return err
+		}
+		ws = dw.workspace
+		ws.UpdatedAt = internal.CurrentTimestamp(nil)
+		// errors with ErrResourceAlreadyExists if a workspace has since been
+		// created with the same name.
+		if err := s.db.create(ctx, ws); err != nil {
+			return err
+		}
+		if len(ws.Tags) > 0 {
+			specs := make([]TagSpec, len(ws.Tags))
+			for i, name := range ws.Tags {
+				specs[i] = TagSpec{Name: name}
+			}
+			added, err := s.addTags(ctx, ws, specs)
+			if err != nil {
+				return err
+			}
+			ws.Tags = added
+		}
+		for _, hook := range s.afterRestoreHooks {
+			if err := hook(ctx, ws); err != nil {
+				return err
+			}
+		}
+		return s.db.deleteDeleted(ctx, workspaceID)
+	})
+	if err != nil {
+		s.Error(err, "restoring workspace", "workspace", workspaceID, "subject", subject)
+		return nil, err
+	}
+
+	s.V(0).Info("restored workspace", "id", ws.ID, "name", ws.Name, "organization", ws.Organization, "subject", subject)
+
+	return ws, nil
+}
+
+// Purge permanently deletes a deleted workspace, without waiting for its
+// recovery window to elapse.
+func (s *Service) Purge(ctx context.Context, workspaceID string) error {
+	dw, err := s.db.getDeleted(ctx, workspaceID, s.recoveryWindow)
+	if err != nil {
+		s.Error(err, "retrieving deleted workspace", "workspace", workspaceID)
+		return err
+	}
+
+	subject, err := s.organization.CanAccess(ctx, rbac.PurgeWorkspaceAction, dw.Organization)
+	if err != nil {
+		return err
+	}
+
+	if err := s.db.deleteDeleted(ctx, workspaceID); err != nil {
+		s.Error(err, "purging workspace", "workspace", workspaceID, "subject", subject)
+		return err
+	}
+
+	s.V(0).Info("purged workspace", "id", dw.ID, "name", dw.Name, "organization", dw.Organization, "subject", subject)
+
+	return nil
+}
+
+// archive a workspace that is about to be deleted so that it can be restored
+// within the recovery window.
+func (s *Service) archive(ctx context.Context, ws *Workspace, subject internal.Subject) error {
+	// take a snapshot of the workspace, sans the attributes that cannot
+	// survive its deletion.
+	snapshot := *ws
+	snapshot.Lock = nil
+	snapshot.LatestRun = nil
+	snapshot.Connection = nil
+
+	dw := &DeletedWorkspace{
+		ID:           ws.ID,
+		Name:         ws.Name,
+		Organization: ws.Organization,
+		DeletedAt:    internal.CurrentTimestamp(nil),
+		DeletedBy:    subject.String(),
+		workspace:    &snapshot,
+	}
+	if err := s.db.createDeleted(ctx, dw); err != nil {
+		return err
+	}
+	for _, hook := range s.afterArchiveHooks {
+		if err := hook(ctx, ws); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// purgeExpired permanently deletes workspaces whose recovery window has
+// elapsed, returning the number purged.
+func (s *Service) purgeExpired(ctx context.Context) (int64, error) {
+	before := internal.CurrentTimestamp(nil).Add(-s.recoveryWindow)
+	return s.db.deleteDeletedBefore(ctx, before)
+}
+
+// purgeTombstones deletes the former names of renamed workspaces once their
+// grace period has elapsed, returning the number deleted.
+func (s *Service) purgeTombstones(ctx context.Context) (int64, error) {
+	before := internal.CurrentTimestamp(nil).Add(-s.renameGracePeriod)
+	return s.db.deleteTombstonesBefore(ctx, before)
+}
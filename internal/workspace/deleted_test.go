@@ -0,0 +1,69 @@
+package workspace
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/jackc/pgtype"
+	"github.com/leg100/otf/internal/sql"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeletedRow(t *testing.T) {
+	deletedAt := time.Date(2023, 11, 26, 9, 0, 0, 0, time.UTC)
+	snapshot, err := json.Marshal(&Workspace{
+		ID:           "ws-123",
+		Name:         "dev",
+		Organization: "acme",
+		Tags:         []string{"foo"},
+	})
+	require.NoError(t, err)
+
+	row := deletedRow{
+		WorkspaceID:      sql.String("ws-123"),
+		OrganizationName: sql.String("acme"),
+		Name:             sql.String("dev"),
+		DeletedAt:        pgtype.Timestamptz{Time: deletedAt, Status: pgtype.Present},
+		DeletedBy:        sql.String("bobby"),
+		Workspace:        snapshot,
+	}
+	got, err := row.toDeletedWorkspace(7 * 24 * time.Hour)
+	require.NoError(t, err)
+
+	assert.Equal(t, "ws-123", got.ID)
+	assert.Equal(t, "bobby", got.DeletedBy)
+	assert.Equal(t, deletedAt.Add(7*24*time.Hour), got.PurgeAt)
+	assert.Equal(t, "dev", got.workspace.Name)
+	assert.Equal(t, []string{"foo"}, got.workspace.Tags)
+}
+
+type fakeReaperClient struct {
+	calls int
+}
+
+func (f *fakeReaperClient) purgeExpired(context.Context) (int64, error) {
+	f.calls++
+	return 1, nil
+}
+
+func (f *fakeReaperClient) purgeTombstones(context.Context) (int64, error) {
+	return 0, nil
+}
+
+func TestReaper(t *testing.T) {
+	client := &fakeReaperClient{}
+	r := &Reaper{Logger: logr.Discard(), client: client, interval: time.Hour}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	// reaper should purge at startup before returning upon the context being
+	// canceled.
+	err := r.Start(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, client.calls)
+}
@@ -4,7 +4,7 @@ import "errors"
 
 var (
 	ErrWorkspaceAlreadyLocked         = errors.New("workspace already locked")
-	ErrWorkspaceLockedByDifferentUser = errors.New("workspace locked by different user")
+	ErrWorkspaceLockedByDifferentUser = errors.New("workspace is locked by User")
 	ErrWorkspaceLockedByRun           = errors.New("workspace is locked by Run")
 	ErrWorkspaceAlreadyUnlocked       = errors.New("workspace already unlocked")
 	ErrWorkspaceUnlockDenied          = errors.New("unauthorized to unlock workspace")
@@ -15,6 +15,7 @@ var (
 	ErrTagsRegexAndAlwaysTrigger       = errors.New("cannot specify both tags-regex and always-trigger")
 	ErrTriggerPatternsAndAlwaysTrigger = errors.New("cannot specify both trigger-patterns and always-trigger")
 	ErrInvalidTriggerPattern           = errors.New("invalid trigger glob pattern")
+	ErrInvalidIgnorePattern            = errors.New("invalid ignore glob pattern")
 	ErrInvalidTagsRegex                = errors.New("invalid vcs tags regular expression")
 	ErrAgentExecutionModeWithoutPool   = errors.New("agent execution mode requires agent pool ID")
 	ErrNonAgentExecutionModeWithPool   = errors.New("agent pool ID can only be specified with agent execution mode")
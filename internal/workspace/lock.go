@@ -1,6 +1,11 @@
 package workspace
 
 import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
 	"github.com/leg100/otf/internal"
 	"github.com/leg100/otf/internal/http/html/paths"
 	"github.com/leg100/otf/internal/rbac"
@@ -34,6 +39,45 @@ type (
 	}
 )
 
+func (k LockKind) String() string {
+	switch k {
+	case UserLock:
+		return "user"
+	case RunLock:
+		return "run"
+	default:
+		return "unknown"
+	}
+}
+
+// lockJSON is the JSON encoding of a lock, identifying the entity holding
+// the lock, i.e. a username or run ID, along with its kind.
+type lockJSON struct {
+	ID   string `json:"id"`
+	Kind string `json:"kind"`
+}
+
+func (l *Lock) MarshalJSON() ([]byte, error) {
+	return json.Marshal(lockJSON{ID: l.id, Kind: l.LockKind.String()})
+}
+
+func (l *Lock) UnmarshalJSON(data []byte) error {
+	var v lockJSON
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	switch v.Kind {
+	case UserLock.String():
+		l.LockKind = UserLock
+	case RunLock.String():
+		l.LockKind = RunLock
+	default:
+		return fmt.Errorf("%w: unknown kind: %s", ErrWorkspaceInvalidLock, v.Kind)
+	}
+	l.id = v.ID
+	return nil
+}
+
 // Locked determines whether workspace is locked.
 func (ws *Workspace) Locked() bool {
 	// a nil receiver means the lock is unlocked
@@ -84,6 +128,25 @@ func (ws *Workspace) Unlock(id string, kind LockKind, force bool) error {
 	return ErrWorkspaceLockedByDifferentUser
 }
 
+// lockError converts an error from locking or unlocking a workspace into an
+// HTTP error, reporting a conflict with the lock's current state as a 409.
+func lockError(err error) error {
+	for _, conflict := range []error{
+		ErrWorkspaceAlreadyLocked,
+		ErrWorkspaceAlreadyUnlocked,
+		ErrWorkspaceLockedByRun,
+		ErrWorkspaceLockedByDifferentUser,
+	} {
+		if errors.Is(err, conflict) {
+			return &internal.HTTPError{
+				Code:    http.StatusConflict,
+				Message: err.Error(),
+			}
+		}
+	}
+	return err
+}
+
 // lockButtonHelper helps the UI determine the button to display for
 // locking/unlocking the workspace.
 func lockButtonHelper(ws *Workspace, policy internal.WorkspacePolicy, user internal.Subject) LockButton {
@@ -1,6 +1,9 @@
 package workspace
 
 import (
+	"encoding/json"
+	"fmt"
+	"net/http"
 	"testing"
 
 	"github.com/leg100/otf/internal"
@@ -59,6 +62,32 @@ func TestWorkspace_Unlock(t *testing.T) {
 	})
 }
 
+func TestLock_JSON(t *testing.T) {
+	lock := &Lock{id: "run-123", LockKind: RunLock}
+
+	b, err := json.Marshal(lock)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"id":"run-123","kind":"run"}`, string(b))
+
+	var got Lock
+	require.NoError(t, json.Unmarshal(b, &got))
+	assert.Equal(t, lock, &got)
+
+	err = json.Unmarshal([]byte(`{"id":"janitor","kind":"team"}`), &got)
+	assert.ErrorIs(t, err, ErrWorkspaceInvalidLock)
+}
+
+func TestLockError(t *testing.T) {
+	var httpErr *internal.HTTPError
+
+	err := lockError(fmt.Errorf("unlocking: %w", ErrWorkspaceLockedByRun))
+	require.ErrorAs(t, err, &httpErr)
+	assert.Equal(t, http.StatusConflict, httpErr.Code)
+	assert.Equal(t, "unlocking: workspace is locked by Run", httpErr.Message)
+
+	assert.Equal(t, internal.ErrAccessNotPermitted, lockError(internal.ErrAccessNotPermitted))
+}
+
 func TestWorkspace_LockButtonHelper(t *testing.T) {
 	tests := []struct {
 		name    string
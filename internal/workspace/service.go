@@ -2,6 +2,8 @@ package workspace
 
 import (
 	"context"
+	"errors"
+	"time"
 
 	"github.com/go-logr/logr"
 	"github.com/gorilla/mux"
@@ -38,6 +40,15 @@ type (
 		beforeCreateHooks []func(context.Context, *Workspace) error
 		afterCreateHooks  []func(context.Context, *Workspace) error
 		beforeUpdateHooks []func(context.Context, *Workspace) error
+		afterArchiveHooks []func(context.Context, *Workspace) error
+		afterRestoreHooks []func(context.Context, *Workspace) error
+
+		// duration for which a deleted workspace can be restored; zero means
+		// deleted workspaces are not recoverable.
+		recoveryWindow time.Duration
+		// duration for which a renamed workspace can be found using its
+		// former name; zero means former names are not retained.
+		renameGracePeriod time.Duration
 	}
 
 	Options struct {
@@ -52,6 +63,14 @@ type (
 		VCSProviderService  *vcsprovider.Service
 		TeamService         *team.Service
 		ConnectionService   *connections.Service
+
+		// RecoveryDays is the number of days for which a deleted workspace
+		// can be restored. Zero disables recovery.
+		RecoveryDays int
+		// RenameGraceDays is the number of days for which a renamed workspace
+		// can be found using its former name. Zero disables retention of
+		// former names.
+		RenameGraceDays int
 	}
 )
 
@@ -63,10 +82,12 @@ func NewService(opts Options) *Service {
 			Logger: opts.Logger,
 			db:     db,
 		},
-		db:           db,
-		connections:  opts.ConnectionService,
-		organization: &organization.Authorizer{Logger: opts.Logger},
-		site:         &internal.SiteAuthorizer{Logger: opts.Logger},
+		db:                db,
+		connections:       opts.ConnectionService,
+		organization:      &organization.Authorizer{Logger: opts.Logger},
+		site:              &internal.SiteAuthorizer{Logger: opts.Logger},
+		recoveryWindow:    time.Duration(opts.RecoveryDays) * 24 * time.Hour,
+		renameGracePeriod: time.Duration(opts.RenameGraceDays) * 24 * time.Hour,
 	}
 	svc.web = &webHandlers{
 		Renderer:     opts.Renderer,
@@ -189,8 +210,22 @@ func (s *Service) Get(ctx context.Context, workspaceID string) (*Workspace, erro
 	return ws, nil
 }
 
+// GetByName retrieves a workspace by its organization and name. If the
+// workspace has since been renamed from the name then a *RenamedError is
+// returned.
 func (s *Service) GetByName(ctx context.Context, organization, workspace string) (*Workspace, error) {
 	ws, err := s.db.getByName(ctx, organization, workspace)
+	if errors.Is(err, internal.ErrResourceNotFound) && s.renameGracePeriod > 0 {
+		after := internal.CurrentTimestamp(nil).Add(-s.renameGracePeriod)
+		if renamed, err := s.db.getTombstone(ctx, organization, workspace, after); err == nil {
+			subject, err := s.CanAccess(ctx, rbac.GetWorkspaceAction, renamed.WorkspaceID)
+			if err != nil {
+				return nil, err
+			}
+			s.V(9).Info("retrieved renamed workspace", "subject", subject, "organization", organization, "workspace", workspace, "name", renamed.Name)
+			return nil, renamed
+		}
+	}
 	if err != nil {
 		s.Error(err, "retrieving workspace", "organization", organization, "workspace", workspace)
 		return nil, err
@@ -206,6 +241,26 @@ func (s *Service) GetByName(ctx context.Context, organization, workspace string)
 	return ws, nil
 }
 
+// ResolveAlias retrieves the ID of a workspace by its alias,
+// <organization>/<name>.
+func (s *Service) ResolveAlias(ctx context.Context, alias string) (string, error) {
+	organization, name, err := resource.ParseAlias(alias)
+	if err != nil {
+		return "", err
+	}
+	ws, err := s.GetByName(ctx, organization, name)
+	if err != nil {
+		// resolve the former name of a renamed workspace, so that references
+		// using the alias survive the rename.
+		var renamed *RenamedError
+		if errors.As(err, &renamed) {
+			return renamed.WorkspaceID, nil
+		}
+		return "", err
+	}
+	return ws.ID, nil
+}
+
 func (s *Service) List(ctx context.Context, opts ListOptions) (*resource.Page[*Workspace], error) {
 	if opts.Organization == nil {
 		// subject needs perms on site to list workspaces across site
@@ -251,19 +306,30 @@ func (s *Service) Update(ctx context.Context, workspaceID string, opts UpdateOpt
 	// update the workspace and optionally connect/disconnect to/from vcs repo.
 	var updated *Workspace
 	err = s.db.Tx(ctx, func(ctx context.Context, _ pggen.Querier) error {
-		var connect *bool
+		var (
+			connect *bool
+			oldName string
+		)
 		updated, err = s.db.update(ctx, workspaceID, func(ws *Workspace) (err error) {
 			for _, hook := range s.beforeUpdateHooks {
 				if err := hook(ctx, ws); err != nil {
 					return err
 				}
 			}
+			oldName = ws.Name
 			connect, err = ws.Update(opts)
 			return err
 		})
 		if err != nil {
 			return err
 		}
+		// retain the former name of a renamed workspace, permitting clients
+		// to be pointed to its new name.
+		if updated.Name != oldName && s.renameGracePeriod > 0 {
+			if err := s.db.createTombstone(ctx, updated, oldName); err != nil {
+				return err
+			}
+		}
 		if connect != nil {
 			if *connect {
 				if err := s.connect(ctx, workspaceID, updated.Connection); err != nil {
@@ -298,14 +364,22 @@ func (s *Service) Delete(ctx context.Context, workspaceID string) (*Workspace, e
 		return nil, err
 	}
 
-	// disconnect repo before deleting
-	if ws.Connection != nil {
-		if err := s.disconnect(ctx, ws.ID); err != nil {
-			return nil, err
+	err = s.db.Tx(ctx, func(ctx context.Context, _ pggen.Querier) error {
+		// disconnect repo before deleting
+		if ws.Connection != nil {
+			if err := s.disconnect(ctx, ws.ID); err != nil {
+				return err
+			}
 		}
-	}
-
-	if err := s.db.delete(ctx, ws.ID); err != nil {
+		// archive workspace so that it can be restored
+		if s.recoveryWindow > 0 {
+			if err := s.archive(ctx, ws, subject); err != nil {
+				return err
+			}
+		}
+		return s.db.delete(ctx, ws.ID)
+	})
+	if err != nil {
 		s.Error(err, "deleting workspace", "id", ws.ID, "name", ws.Name, "subject", subject)
 		return nil, err
 	}
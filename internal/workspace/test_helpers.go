@@ -7,7 +7,6 @@ import (
 	"github.com/leg100/otf/internal/rbac"
 	"github.com/leg100/otf/internal/resource"
 	"github.com/leg100/otf/internal/team"
-	"github.com/leg100/otf/internal/vcs"
 	"github.com/leg100/otf/internal/vcsprovider"
 )
 
@@ -90,18 +89,8 @@ func (f *fakeVCSProviderService) List(context.Context, string) ([]*vcsprovider.V
 	return f.providers, nil
 }
 
-func (f *fakeVCSProviderService) GetVCSClient(ctx context.Context, providerID string) (vcs.Client, error) {
-	return &fakeVCSClient{repos: f.repos}, nil
-}
-
-type fakeVCSClient struct {
-	repos []string
-
-	vcs.Client
-}
-
-func (f *fakeVCSClient) ListRepositories(ctx context.Context, opts vcs.ListRepositoriesOptions) ([]string, error) {
-	return f.repos, nil
+func (f *fakeVCSProviderService) ListRepositories(ctx context.Context, providerID string, opts vcsprovider.ListRepositoriesOptions) (*resource.Page[string], error) {
+	return resource.NewPage(f.repos, opts.PageOptions, nil), nil
 }
 
 type fakeTeamService struct {
@@ -64,6 +64,7 @@ func (a *tfe) createWorkspace(w http.ResponseWriter, r *http.Request) {
 	opts := CreateOptions{
 		AgentPoolID:                params.AgentPoolID,
 		AllowDestroyPlan:           params.AllowDestroyPlan,
+		AssessmentsEnabled:         params.AssessmentsEnabled,
 		AutoApply:                  params.AutoApply,
 		Description:                params.Description,
 		ExecutionMode:              (*ExecutionMode)(params.ExecutionMode),
@@ -79,6 +80,8 @@ func (a *tfe) createWorkspace(w http.ResponseWriter, r *http.Request) {
 		TerraformVersion:           params.TerraformVersion,
 		TriggerPrefixes:            params.TriggerPrefixes,
 		TriggerPatterns:            params.TriggerPatterns,
+		IgnorePatterns:             params.IgnorePatterns,
+		SerializationGroup:         params.SerializationGroup,
 		WorkingDirectory:           params.WorkingDirectory,
 		// convert from json:api structs to tag specs
 		Tags: toTagSpecs(params.Tags),
@@ -157,7 +160,7 @@ func (a *tfe) getWorkspaceByName(w http.ResponseWriter, r *http.Request) {
 
 	ws, err := a.GetByName(r.Context(), params.Organization, params.Name)
 	if err != nil {
-		tfeapi.Error(w, err)
+		byNameError(w, r, err)
 		return
 	}
 
@@ -232,7 +235,7 @@ func (a *tfe) updateWorkspaceByName(w http.ResponseWriter, r *http.Request) {
 
 	ws, err := a.GetByName(r.Context(), params.Organization, params.Name)
 	if err != nil {
-		tfeapi.Error(w, err)
+		byNameError(w, r, err)
 		return
 	}
 
@@ -248,11 +251,7 @@ func (a *tfe) lockWorkspace(w http.ResponseWriter, r *http.Request) {
 
 	ws, err := a.Lock(r.Context(), id, nil)
 	if err != nil {
-		if errors.Is(err, ErrWorkspaceAlreadyLocked) {
-			http.Error(w, "", http.StatusConflict)
-		} else {
-			tfeapi.Error(w, err)
-		}
+		tfeapi.Error(w, lockError(err))
 		return
 	}
 
@@ -282,14 +281,7 @@ func (a *tfe) unlock(w http.ResponseWriter, r *http.Request, force bool) {
 
 	ws, err := a.Unlock(r.Context(), id, nil, force)
 	if err != nil {
-		if errors.Is(err, ErrWorkspaceAlreadyUnlocked) || errors.Is(err, ErrWorkspaceLockedByRun) {
-			tfeapi.Error(w, &internal.HTTPError{
-				Code:    http.StatusConflict,
-				Message: err.Error(),
-			})
-		} else {
-			tfeapi.Error(w, err)
-		}
+		tfeapi.Error(w, lockError(err))
 		return
 	}
 
@@ -326,7 +318,7 @@ func (a *tfe) deleteWorkspaceByName(w http.ResponseWriter, r *http.Request) {
 
 	ws, err := a.GetByName(r.Context(), params.Organization, params.Name)
 	if err != nil {
-		tfeapi.Error(w, err)
+		byNameError(w, r, err)
 		return
 	}
 	_, err = a.Delete(r.Context(), ws.ID)
@@ -351,6 +343,7 @@ func (a *tfe) updateWorkspace(w http.ResponseWriter, r *http.Request, workspaceI
 	opts := UpdateOptions{
 		AgentPoolID:                params.AgentPoolID,
 		AllowDestroyPlan:           params.AllowDestroyPlan,
+		AssessmentsEnabled:         params.AssessmentsEnabled,
 		AutoApply:                  params.AutoApply,
 		Description:                params.Description,
 		ExecutionMode:              (*ExecutionMode)(params.ExecutionMode),
@@ -362,6 +355,8 @@ func (a *tfe) updateWorkspace(w http.ResponseWriter, r *http.Request, workspaceI
 		TerraformVersion:           params.TerraformVersion,
 		TriggerPrefixes:            params.TriggerPrefixes,
 		TriggerPatterns:            params.TriggerPatterns,
+		IgnorePatterns:             params.IgnorePatterns,
+		SerializationGroup:         params.SerializationGroup,
 		WorkingDirectory:           params.WorkingDirectory,
 	}
 
@@ -421,7 +416,7 @@ func (a *tfe) convert(from *Workspace, r *http.Request) (*types.Workspace, error
 	perms := &types.WorkspacePermissions{
 		CanLock:           subject.CanAccessWorkspace(rbac.LockWorkspaceAction, policy),
 		CanUnlock:         subject.CanAccessWorkspace(rbac.UnlockWorkspaceAction, policy),
-		CanForceUnlock:    subject.CanAccessWorkspace(rbac.UnlockWorkspaceAction, policy),
+		CanForceUnlock:    subject.CanAccessWorkspace(rbac.ForceUnlockWorkspaceAction, policy),
 		CanQueueApply:     subject.CanAccessWorkspace(rbac.ApplyRunAction, policy),
 		CanQueueDestroy:   subject.CanAccessWorkspace(rbac.ApplyRunAction, policy),
 		CanQueueRun:       subject.CanAccessWorkspace(rbac.CreateRunAction, policy),
@@ -437,6 +432,7 @@ func (a *tfe) convert(from *Workspace, r *http.Request) (*types.Workspace, error
 			IsDestroyable: true,
 		},
 		AllowDestroyPlan:     from.AllowDestroyPlan,
+		AssessmentsEnabled:   from.AssessmentsEnabled,
 		AutoApply:            from.AutoApply,
 		CanQueueDestroyPlan:  from.CanQueueDestroyPlan,
 		CreatedAt:            from.CreatedAt,
@@ -458,6 +454,8 @@ func (a *tfe) convert(from *Workspace, r *http.Request) (*types.Workspace, error
 		TerraformVersion:           from.TerraformVersion,
 		TriggerPrefixes:            from.TriggerPrefixes,
 		TriggerPatterns:            from.TriggerPatterns,
+		IgnorePatterns:             from.IgnorePatterns,
+		SerializationGroup:         from.SerializationGroup,
 		WorkingDirectory:           from.WorkingDirectory,
 		TagNames:                   from.Tags,
 		UpdatedAt:                  from.UpdatedAt,
@@ -465,6 +463,7 @@ func (a *tfe) convert(from *Workspace, r *http.Request) (*types.Workspace, error
 	}
 	if from.AgentPoolID != nil {
 		to.AgentPoolID = *from.AgentPoolID
+		to.AgentPool = &types.AgentPool{ID: *from.AgentPoolID}
 	}
 	if len(from.TriggerPrefixes) > 0 || len(from.TriggerPatterns) > 0 {
 		to.FileTriggersEnabled = true
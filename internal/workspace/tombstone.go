@@ -0,0 +1,49 @@
+package workspace
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"path"
+
+	"github.com/leg100/otf/internal"
+	"github.com/leg100/otf/internal/tfeapi"
+)
+
+// DefaultRenameGraceDays is the default number of days for which a renamed
+// workspace can still be found using its former name.
+const DefaultRenameGraceDays = 7
+
+// RenamedError is returned when a workspace is retrieved using a name from
+// which it has since been renamed, pointing the caller to its current name.
+type RenamedError struct {
+	WorkspaceID  string
+	Organization string
+	// OldName is the name from which the workspace was renamed.
+	OldName string
+	// Name is the current name of the workspace.
+	Name string
+}
+
+func (e *RenamedError) Error() string {
+	return fmt.Sprintf("workspace %s/%s has been renamed to %s", e.Organization, e.OldName, e.Name)
+}
+
+// Unwrap permits callers unaware of renames to treat the workspace as not
+// found.
+func (e *RenamedError) Unwrap() error { return internal.ErrResourceNotFound }
+
+// byNameError responds to an error retrieving a workspace by name. If the
+// workspace has been renamed then the client is redirected to the same path
+// using the new name; otherwise the error is written as-is.
+func byNameError(w http.ResponseWriter, r *http.Request, err error) {
+	var renamed *RenamedError
+	if !errors.As(err, &renamed) {
+		tfeapi.Error(w, err)
+		return
+	}
+	u := *r.URL
+	u.Path = path.Join(path.Dir(r.URL.Path), renamed.Name)
+	u.RawPath = ""
+	tfeapi.Redirect(w, r, u.RequestURI(), renamed)
+}
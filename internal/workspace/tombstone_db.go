@@ -0,0 +1,49 @@
+package workspace
+
+import (
+	"context"
+	"time"
+
+	"github.com/leg100/otf/internal/sql"
+	"github.com/leg100/otf/internal/sql/pggen"
+)
+
+// createTombstone records the former name of a renamed workspace.
+func (db *pgdb) createTombstone(ctx context.Context, ws *Workspace, oldName string) error {
+	_, err := db.Conn(ctx).UpsertWorkspaceTombstone(ctx, pggen.UpsertWorkspaceTombstoneParams{
+		OrganizationName: sql.String(ws.Organization),
+		Name:             sql.String(oldName),
+		WorkspaceID:      sql.String(ws.ID),
+		RenamedAt:        sql.Timestamptz(ws.UpdatedAt),
+	})
+	return sql.Error(err)
+}
+
+// getTombstone retrieves the workspace renamed from the given name after the
+// given time.
+func (db *pgdb) getTombstone(ctx context.Context, organization, name string, after time.Time) (*RenamedError, error) {
+	row, err := db.Conn(ctx).FindWorkspaceTombstone(ctx, pggen.FindWorkspaceTombstoneParams{
+		OrganizationName: sql.String(organization),
+		Name:             sql.String(name),
+		RenamedAfter:     sql.Timestamptz(after),
+	})
+	if err != nil {
+		return nil, sql.Error(err)
+	}
+	return &RenamedError{
+		WorkspaceID:  row.WorkspaceID.String,
+		Organization: row.OrganizationName.String,
+		OldName:      row.Name.String,
+		Name:         row.CurrentName.String,
+	}, nil
+}
+
+// deleteTombstonesBefore deletes tombstones of workspaces renamed before the
+// given time, returning the number deleted.
+func (db *pgdb) deleteTombstonesBefore(ctx context.Context, before time.Time) (int64, error) {
+	tag, err := db.Conn(ctx).DeleteWorkspaceTombstonesBefore(ctx, sql.Timestamptz(before))
+	if err != nil {
+		return 0, sql.Error(err)
+	}
+	return tag.RowsAffected(), nil
+}
@@ -0,0 +1,97 @@
+package workspace
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/leg100/otf/internal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestByNameError(t *testing.T) {
+	renamed := &RenamedError{
+		WorkspaceID:  "ws-123",
+		Organization: "acme",
+		OldName:      "dev",
+		Name:         "development",
+	}
+
+	tests := []struct {
+		name     string
+		method   string
+		url      string
+		err      error
+		wantCode int
+		wantLoc  string
+	}{
+		{
+			name:     "get",
+			method:   "GET",
+			url:      "/api/v2/organizations/acme/workspaces/dev",
+			err:      renamed,
+			wantCode: http.StatusMovedPermanently,
+			wantLoc:  "/api/v2/organizations/acme/workspaces/development",
+		},
+		{
+			name:     "get with query",
+			method:   "GET",
+			url:      "/api/v2/organizations/acme/workspaces/dev?include=outputs",
+			err:      renamed,
+			wantCode: http.StatusMovedPermanently,
+			wantLoc:  "/api/v2/organizations/acme/workspaces/development?include=outputs",
+		},
+		{
+			name:     "patch",
+			method:   "PATCH",
+			url:      "/api/v2/organizations/acme/workspaces/dev",
+			err:      renamed,
+			wantCode: http.StatusPermanentRedirect,
+			wantLoc:  "/api/v2/organizations/acme/workspaces/development",
+		},
+		{
+			name:     "not found",
+			method:   "GET",
+			url:      "/api/v2/organizations/acme/workspaces/dev",
+			err:      internal.ErrResourceNotFound,
+			wantCode: http.StatusNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(tt.method, tt.url, nil)
+
+			byNameError(w, r, tt.err)
+
+			assert.Equal(t, tt.wantCode, w.Code)
+			assert.Equal(t, tt.wantLoc, w.Header().Get("Location"))
+		})
+	}
+
+	t.Run("error body links to new location", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest("GET", "/api/v2/organizations/acme/workspaces/dev", nil)
+
+		byNameError(w, r, renamed)
+
+		var got struct {
+			Errors []struct {
+				Detail string `json:"detail"`
+				Links  struct {
+					About string `json:"about"`
+				} `json:"links"`
+			} `json:"errors"`
+		}
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&got))
+		require.Len(t, got.Errors, 1)
+		assert.Equal(t, "workspace acme/dev has been renamed to development", got.Errors[0].Detail)
+		assert.Equal(t, "/api/v2/organizations/acme/workspaces/development", got.Errors[0].Links.About)
+	})
+
+	t.Run("renamed error is also not found", func(t *testing.T) {
+		assert.ErrorIs(t, renamed, internal.ErrResourceNotFound)
+	})
+}
@@ -3,6 +3,7 @@ package workspace
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"net/http"
 
 	"github.com/gorilla/mux"
@@ -14,7 +15,6 @@ import (
 	"github.com/leg100/otf/internal/rbac"
 	"github.com/leg100/otf/internal/resource"
 	"github.com/leg100/otf/internal/team"
-	"github.com/leg100/otf/internal/vcs"
 	"github.com/leg100/otf/internal/vcsprovider"
 )
 
@@ -56,7 +56,7 @@ type (
 		Get(ctx context.Context, providerID string) (*vcsprovider.VCSProvider, error)
 		List(context.Context, string) ([]*vcsprovider.VCSProvider, error)
 
-		GetVCSClient(ctx context.Context, providerID string) (vcs.Client, error)
+		ListRepositories(ctx context.Context, providerID string, opts vcsprovider.ListRepositoriesOptions) (*resource.Page[string], error)
 	}
 
 	// webClient provides web handlers with access to the workspace service
@@ -333,7 +333,13 @@ func (h *webHandlers) getWorkspaceByName(w http.ResponseWriter, r *http.Request)
 	}
 
 	ws, err := h.client.GetByName(r.Context(), params.Organization, params.Name)
-	if err != nil {
+	var renamed *RenamedError
+	if errors.As(err, &renamed) {
+		// workspace has since been renamed; the workspace page is addressed
+		// by ID so redirect there instead.
+		http.Redirect(w, r, paths.Workspace(renamed.WorkspaceID), http.StatusFound)
+		return
+	} else if err != nil {
 		h.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
@@ -631,8 +637,7 @@ func (h *webHandlers) listWorkspaceVCSRepos(w http.ResponseWriter, r *http.Reque
 	var params struct {
 		WorkspaceID   string `schema:"workspace_id,required"`
 		VCSProviderID string `schema:"vcs_provider_id,required"`
-
-		// TODO: filters, public/private, etc
+		vcsprovider.ListRepositoriesOptions
 	}
 	if err := decode.All(&params, r); err != nil {
 		h.Error(w, err.Error(), http.StatusUnprocessableEntity)
@@ -644,28 +649,33 @@ func (h *webHandlers) listWorkspaceVCSRepos(w http.ResponseWriter, r *http.Reque
 		h.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
-	client, err := h.vcsproviders.GetVCSClient(r.Context(), params.VCSProviderID)
-	if err != nil {
-		h.Error(w, err.Error(), http.StatusInternalServerError)
-		return
+	if params.PageSize == 0 {
+		params.PageSize = html.PageSize
 	}
-	repos, err := client.ListRepositories(r.Context(), vcs.ListRepositoriesOptions{
-		PageSize: html.PageSize,
-	})
+	repos, err := h.vcsproviders.ListRepositories(r.Context(), params.VCSProviderID, params.ListRepositoriesOptions)
 	if err != nil {
 		h.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
 
-	h.Render("workspace_vcs_repo_list.tmpl", w, struct {
+	response := struct {
 		WorkspacePage
-		Repos         []string
+		*resource.Page[string]
 		VCSProviderID string
+		Search        string
 	}{
 		WorkspacePage: NewPage(r, "list vcs repos | "+ws.ID, ws),
-		Repos:         repos,
+		Page:          repos,
 		VCSProviderID: params.VCSProviderID,
-	})
+		Search:        params.Search,
+	}
+	if isHTMX := r.Header.Get("HX-Request"); isHTMX == "true" {
+		if err := h.RenderTemplate("workspace_vcs_repo_listing.tmpl", w, response); err != nil {
+			h.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+		return
+	}
+	h.Render("workspace_vcs_repo_list.tmpl", w, response)
 }
 
 func (h *webHandlers) connect(w http.ResponseWriter, r *http.Request) {
@@ -384,6 +384,15 @@ func TestListWorkspaceReposHandler(t *testing.T) {
 	w := httptest.NewRecorder()
 	app.listWorkspaceVCSRepos(w, r)
 	assert.Equal(t, 200, w.Code, w.Body.String())
+
+	t.Run("htmx search", func(t *testing.T) {
+		q := "/?workspace_id=ws-123&vcs_provider_id=fake-provider&search=foo"
+		r := httptest.NewRequest("GET", q, nil)
+		r.Header.Set("HX-Request", "true")
+		w := httptest.NewRecorder()
+		app.listWorkspaceVCSRepos(w, r)
+		assert.Equal(t, 200, w.Code, w.Body.String())
+	})
 }
 
 func TestConnectWorkspaceHandler(t *testing.T) {
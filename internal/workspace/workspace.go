@@ -39,6 +39,7 @@ type (
 		UpdatedAt                  time.Time     `jsonapi:"attribute" json:"updated_at"`
 		AgentPoolID                *string       `jsonapi:"attribute" json:"agent-pool-id"`
 		AllowDestroyPlan           bool          `jsonapi:"attribute" json:"allow_destroy_plan"`
+		AssessmentsEnabled         bool          `jsonapi:"attribute" json:"assessments_enabled"`
 		AutoApply                  bool          `jsonapi:"attribute" json:"auto_apply"`
 		CanQueueDestroyPlan        bool          `jsonapi:"attribute" json:"can_queue_destroy_plan"`
 		Description                string        `jsonapi:"attribute" json:"description"`
@@ -69,10 +70,20 @@ type (
 		// this field without setting the connection!
 		TriggerPatterns []string
 
+		// IgnorePatterns are glob patterns of files to disregard when
+		// determining whether a VCS event triggers a run: if every changed file
+		// matches at least one pattern then no run is triggered.
+		IgnorePatterns []string
+
 		// TriggerPrefixes exists only to pass the go-tfe integration tests and
 		// is not used when determining whether to trigger runs. Use
 		// TriggerPatterns instead.
 		TriggerPrefixes []string
+
+		// SerializationGroup is the name of the group of workspaces to which
+		// the workspace belongs, if any. At most one apply in a group runs at
+		// any time.
+		SerializationGroup *string
 	}
 
 	Connection struct {
@@ -107,6 +118,7 @@ type (
 	CreateOptions struct {
 		AgentPoolID                *string
 		AllowDestroyPlan           *bool
+		AssessmentsEnabled         *bool
 		AutoApply                  *bool
 		Description                *string
 		ExecutionMode              *ExecutionMode
@@ -122,6 +134,8 @@ type (
 		TerraformVersion           *string
 		TriggerPrefixes            []string
 		TriggerPatterns            []string
+		IgnorePatterns             []string
+		SerializationGroup         *string
 		WorkingDirectory           *string
 		Organization               *string
 
@@ -135,6 +149,7 @@ type (
 	UpdateOptions struct {
 		AgentPoolID                *string `json:"agent-pool-id,omitempty"`
 		AllowDestroyPlan           *bool
+		AssessmentsEnabled         *bool
 		AutoApply                  *bool
 		Name                       *string
 		Description                *string
@@ -149,6 +164,14 @@ type (
 		TriggerPatterns            []string
 		WorkingDirectory           *string
 
+		// Replace ignore patterns; an empty, non-nil slice removes all
+		// patterns.
+		IgnorePatterns []string
+
+		// Add workspace to serialization group; an empty string removes the
+		// workspace from its group.
+		SerializationGroup *string
+
 		// Always trigger runs. A value of true is mutually exclusive with
 		// setting TriggerPatterns or ConnectOptions.TagsRegex.
 		AlwaysTrigger *bool
@@ -187,7 +210,7 @@ func NewWorkspace(opts CreateOptions) (*Workspace, error) {
 	}
 
 	ws := Workspace{
-		ID:                 internal.NewID("ws"),
+		ID:                 resource.NewID(resource.WorkspaceKind),
 		CreatedAt:          internal.CurrentTimestamp(nil),
 		UpdatedAt:          internal.CurrentTimestamp(nil),
 		AllowDestroyPlan:   DefaultAllowDestroyPlan,
@@ -205,6 +228,9 @@ func NewWorkspace(opts CreateOptions) (*Workspace, error) {
 	if opts.AllowDestroyPlan != nil {
 		ws.AllowDestroyPlan = *opts.AllowDestroyPlan
 	}
+	if opts.AssessmentsEnabled != nil {
+		ws.AssessmentsEnabled = *opts.AssessmentsEnabled
+	}
 	if opts.AutoApply != nil {
 		ws.AutoApply = *opts.AutoApply
 	}
@@ -265,6 +291,16 @@ func NewWorkspace(opts CreateOptions) (*Workspace, error) {
 			return nil, fmt.Errorf("setting trigger patterns: %w", err)
 		}
 	}
+	if opts.IgnorePatterns != nil {
+		if err := ws.setIgnorePatterns(opts.IgnorePatterns); err != nil {
+			return nil, fmt.Errorf("setting ignore patterns: %w", err)
+		}
+	}
+	if opts.SerializationGroup != nil {
+		if err := ws.setSerializationGroup(*opts.SerializationGroup); err != nil {
+			return nil, err
+		}
+	}
 	return &ws, nil
 }
 
@@ -305,6 +341,10 @@ func (ws *Workspace) Update(opts UpdateOptions) (*bool, error) {
 		ws.AllowDestroyPlan = *opts.AllowDestroyPlan
 		updated = true
 	}
+	if opts.AssessmentsEnabled != nil {
+		ws.AssessmentsEnabled = *opts.AssessmentsEnabled
+		updated = true
+	}
 	if opts.AutoApply != nil {
 		ws.AutoApply = *opts.AutoApply
 		updated = true
@@ -387,6 +427,18 @@ func (ws *Workspace) Update(opts UpdateOptions) (*bool, error) {
 		}
 		updated = true
 	}
+	if opts.IgnorePatterns != nil {
+		if err := ws.setIgnorePatterns(opts.IgnorePatterns); err != nil {
+			return nil, fmt.Errorf("setting ignore patterns: %w", err)
+		}
+		updated = true
+	}
+	if opts.SerializationGroup != nil {
+		if err := ws.setSerializationGroup(*opts.SerializationGroup); err != nil {
+			return nil, err
+		}
+		updated = true
+	}
 	// determine whether to connect or disconnect workspace
 	if opts.Disconnect && opts.ConnectOptions != nil {
 		return nil, errors.New("connect options must be nil if disconnect is true")
@@ -540,3 +592,30 @@ func (ws *Workspace) setTriggerPatterns(patterns []string) error {
 	ws.TriggerPatterns = patterns
 	return nil
 }
+
+func (ws *Workspace) setIgnorePatterns(patterns []string) error {
+	for _, patt := range patterns {
+		if _, err := glob.Compile(patt); err != nil {
+			return ErrInvalidIgnorePattern
+		}
+	}
+	if len(patterns) == 0 {
+		// remove all patterns
+		patterns = nil
+	}
+	ws.IgnorePatterns = patterns
+	return nil
+}
+
+func (ws *Workspace) setSerializationGroup(group string) error {
+	if group == "" {
+		// remove workspace from group
+		ws.SerializationGroup = nil
+		return nil
+	}
+	if err := resource.ValidateName(&group); err != nil {
+		return fmt.Errorf("invalid serialization group: %w", err)
+	}
+	ws.SerializationGroup = &group
+	return nil
+}
@@ -112,6 +112,24 @@ func TestNewWorkspace(t *testing.T) {
 			},
 			want: ErrInvalidTriggerPattern,
 		},
+		{
+			name: "invalid ignore pattern",
+			opts: CreateOptions{
+				Name:           internal.String("my-workspace"),
+				Organization:   internal.String("my-org"),
+				IgnorePatterns: []string{"/docs/[**"},
+			},
+			want: ErrInvalidIgnorePattern,
+		},
+		{
+			name: "invalid serialization group",
+			opts: CreateOptions{
+				Name:               internal.String("my-workspace"),
+				Organization:       internal.String("my-org"),
+				SerializationGroup: internal.String("vpc/prod"),
+			},
+			want: internal.ErrInvalidName,
+		},
 		{
 			name: "invalid tags regex",
 			opts: CreateOptions{
@@ -248,6 +266,23 @@ func TestWorkspace_UpdateError(t *testing.T) {
 			},
 			want: ErrInvalidTriggerPattern,
 		},
+		{
+			name: "invalid ignore pattern",
+			ws:   &Workspace{Name: "dev", Organization: "acme"},
+			opts: UpdateOptions{
+				Name:           internal.String("my-workspace"),
+				IgnorePatterns: []string{"/docs/[**"},
+			},
+			want: ErrInvalidIgnorePattern,
+		},
+		{
+			name: "remove from serialization group",
+			ws:   &Workspace{Name: "dev", Organization: "acme", SerializationGroup: internal.String("vpc-prod")},
+			opts: UpdateOptions{
+				SerializationGroup: internal.String(""),
+			},
+			want: nil,
+		},
 		{
 			name: "invalid tags regex",
 			ws:   &Workspace{Name: "dev", Organization: "acme"},
@@ -0,0 +1,63 @@
+package workspacetemplate
+
+import (
+	"context"
+
+	"github.com/leg100/otf/internal"
+	"github.com/leg100/otf/internal/sql"
+	"github.com/leg100/otf/internal/sql/pggen"
+)
+
+// pgdb is a database of workspace templates on postgres
+type pgdb struct {
+	*sql.DB // provides access to generated SQL queries
+}
+
+func (db *pgdb) tx(ctx context.Context, fn func(context.Context) error) error {
+	return db.Tx(ctx, func(ctx context.Context, _ pggen.Querier) error {
+		return fn(ctx)
+	})
+}
+
+func (db *pgdb) createTemplate(ctx context.Context, workspaceID string) error {
+	_, err := db.Conn(ctx).InsertWorkspaceTemplate(ctx, sql.String(workspaceID), sql.Timestamptz(internal.CurrentTimestamp(nil)))
+	if err != nil {
+		return sql.Error(err)
+	}
+	return nil
+}
+
+func (db *pgdb) getTemplate(ctx context.Context, workspaceID string) (*Template, error) {
+	row, err := db.Conn(ctx).FindWorkspaceTemplate(ctx, sql.String(workspaceID))
+	if err != nil {
+		return nil, sql.Error(err)
+	}
+	return &Template{
+		WorkspaceID: row.WorkspaceID.String,
+		CreatedAt:   row.CreatedAt.Time.UTC(),
+	}, nil
+}
+
+func (db *pgdb) listTemplates(ctx context.Context, organization string) ([]*Template, error) {
+	rows, err := db.Conn(ctx).FindWorkspaceTemplatesByOrganization(ctx, sql.String(organization))
+	if err != nil {
+		return nil, sql.Error(err)
+	}
+	templates := make([]*Template, len(rows))
+	for i, r := range rows {
+		templates[i] = &Template{
+			WorkspaceID: r.WorkspaceID.String,
+			Name:        r.Name.String,
+			CreatedAt:   r.CreatedAt.Time.UTC(),
+		}
+	}
+	return templates, nil
+}
+
+func (db *pgdb) deleteTemplate(ctx context.Context, workspaceID string) error {
+	_, err := db.Conn(ctx).DeleteWorkspaceTemplate(ctx, sql.String(workspaceID))
+	if err != nil {
+		return sql.Error(err)
+	}
+	return nil
+}
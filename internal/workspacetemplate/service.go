@@ -0,0 +1,197 @@
+package workspacetemplate
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/gorilla/mux"
+	"github.com/leg100/otf/internal"
+	"github.com/leg100/otf/internal/logr"
+	"github.com/leg100/otf/internal/notifications"
+	"github.com/leg100/otf/internal/organization"
+	"github.com/leg100/otf/internal/rbac"
+	"github.com/leg100/otf/internal/sql"
+	"github.com/leg100/otf/internal/tfeapi"
+	"github.com/leg100/otf/internal/variable"
+	"github.com/leg100/otf/internal/workspace"
+)
+
+type (
+	Service struct {
+		logr.Logger
+
+		organization internal.Authorizer
+		workspace    internal.Authorizer
+
+		db            store
+		api           *api
+		workspaces    WorkspaceService
+		variables     VariableService
+		notifications NotificationService
+	}
+
+	Options struct {
+		logr.Logger
+		*sql.DB
+		*tfeapi.Responder
+
+		WorkspaceService    WorkspaceService
+		VariableService     VariableService
+		NotificationService NotificationService
+	}
+
+	WorkspaceService interface {
+		internal.Authorizer
+
+		Create(ctx context.Context, opts workspace.CreateOptions) (*workspace.Workspace, error)
+		Get(ctx context.Context, workspaceID string) (*workspace.Workspace, error)
+		GetPolicy(ctx context.Context, workspaceID string) (internal.WorkspacePolicy, error)
+		SetPermission(ctx context.Context, workspaceID, teamID string, role rbac.Role) error
+	}
+
+	VariableService interface {
+		CreateWorkspaceVariable(ctx context.Context, workspaceID string, opts variable.CreateVariableOptions) (*variable.Variable, error)
+		ListWorkspaceVariables(ctx context.Context, workspaceID string) ([]*variable.Variable, error)
+	}
+
+	NotificationService interface {
+		Create(ctx context.Context, workspaceID string, opts notifications.CreateConfigOptions) (*notifications.Config, error)
+		List(ctx context.Context, workspaceID string) ([]*notifications.Config, error)
+	}
+
+	store interface {
+		tx(ctx context.Context, fn func(context.Context) error) error
+		createTemplate(ctx context.Context, workspaceID string) error
+		getTemplate(ctx context.Context, workspaceID string) (*Template, error)
+		listTemplates(ctx context.Context, organization string) ([]*Template, error)
+		deleteTemplate(ctx context.Context, workspaceID string) error
+	}
+)
+
+func NewService(opts Options) *Service {
+	svc := &Service{
+		Logger:        opts.Logger,
+		organization:  &organization.Authorizer{Logger: opts.Logger},
+		workspace:     opts.WorkspaceService,
+		db:            &pgdb{opts.DB},
+		workspaces:    opts.WorkspaceService,
+		variables:     opts.VariableService,
+		notifications: opts.NotificationService,
+	}
+	svc.api = &api{
+		Service:   svc,
+		Responder: opts.Responder,
+	}
+	return svc
+}
+
+func (s *Service) AddHandlers(r *mux.Router) {
+	s.api.addHandlers(r)
+}
+
+// MarkTemplate marks a workspace as a template, from which new workspaces can
+// be created.
+func (s *Service) MarkTemplate(ctx context.Context, workspaceID string) error {
+	subject, err := s.workspace.CanAccess(ctx, rbac.UpdateWorkspaceAction, workspaceID)
+	if err != nil {
+		return err
+	}
+	if err := s.db.createTemplate(ctx, workspaceID); err != nil {
+		s.Error(err, "marking workspace as template", "workspace", workspaceID, "subject", subject)
+		return err
+	}
+	s.V(1).Info("marked workspace as template", "workspace", workspaceID, "subject", subject)
+	return nil
+}
+
+// UnmarkTemplate stops a workspace from being a template. Workspaces
+// previously created from the template are unaffected.
+func (s *Service) UnmarkTemplate(ctx context.Context, workspaceID string) error {
+	subject, err := s.workspace.CanAccess(ctx, rbac.UpdateWorkspaceAction, workspaceID)
+	if err != nil {
+		return err
+	}
+	if err := s.db.deleteTemplate(ctx, workspaceID); err != nil {
+		s.Error(err, "unmarking workspace as template", "workspace", workspaceID, "subject", subject)
+		return err
+	}
+	s.V(1).Info("unmarked workspace as template", "workspace", workspaceID, "subject", subject)
+	return nil
+}
+
+// ListTemplates lists an organization's templates, ordered by name.
+func (s *Service) ListTemplates(ctx context.Context, organization string) ([]*Template, error) {
+	subject, err := s.organization.CanAccess(ctx, rbac.ListWorkspacesAction, organization)
+	if err != nil {
+		return nil, err
+	}
+	templates, err := s.db.listTemplates(ctx, organization)
+	if err != nil {
+		s.Error(err, "listing workspace templates", "organization", organization, "subject", subject)
+		return nil, err
+	}
+	s.V(9).Info("listed workspace templates", "organization", organization, "count", len(templates), "subject", subject)
+	return templates, nil
+}
+
+// CreateFromTemplate creates a workspace with the same settings, variables,
+// team access and notification configurations as a template. Either the
+// workspace is created along with all of its copies or nothing is created.
+func (s *Service) CreateFromTemplate(ctx context.Context, templateID string, opts CreateOptions) (*workspace.Workspace, error) {
+	if err := opts.validate(); err != nil {
+		return nil, err
+	}
+	tmpl, err := s.workspaces.Get(ctx, templateID)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := s.db.getTemplate(ctx, templateID); errors.Is(err, internal.ErrResourceNotFound) {
+		return nil, ErrNotTemplate
+	} else if err != nil {
+		return nil, err
+	}
+	vars, err := s.variables.ListWorkspaceVariables(ctx, templateID)
+	if err != nil {
+		return nil, err
+	}
+	policy, err := s.workspaces.GetPolicy(ctx, templateID)
+	if err != nil {
+		return nil, err
+	}
+	configs, err := s.notifications.List(ctx, templateID)
+	if err != nil {
+		return nil, err
+	}
+
+	var ws *workspace.Workspace
+	err = s.db.tx(ctx, func(ctx context.Context) (err error) {
+		ws, err = s.workspaces.Create(ctx, newWorkspaceOptions(tmpl, opts))
+		if err != nil {
+			return err
+		}
+		for _, v := range newVariableOptions(vars, opts.Variables) {
+			if _, err := s.variables.CreateWorkspaceVariable(ctx, ws.ID, v); err != nil {
+				return fmt.Errorf("creating variable %s: %w", *v.Key, err)
+			}
+		}
+		for _, perm := range policy.Permissions {
+			if err := s.workspaces.SetPermission(ctx, ws.ID, perm.TeamID, perm.Role); err != nil {
+				return fmt.Errorf("setting team access: %w", err)
+			}
+		}
+		for _, cfg := range configs {
+			if _, err := s.notifications.Create(ctx, ws.ID, newNotificationOptions(cfg)); err != nil {
+				return fmt.Errorf("creating notification configuration %s: %w", cfg.Name, err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		s.Error(err, "creating workspace from template", "template", templateID, "name", opts.Name)
+		return nil, err
+	}
+	s.V(0).Info("created workspace from template", "template", templateID, "workspace", ws.ID, "name", ws.Name,
+		"variables", len(vars), "permissions", len(policy.Permissions), "notification_configs", len(configs))
+	return ws, nil
+}
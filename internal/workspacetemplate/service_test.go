@@ -0,0 +1,169 @@
+package workspacetemplate
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/leg100/otf/internal"
+	"github.com/leg100/otf/internal/notifications"
+	"github.com/leg100/otf/internal/rbac"
+	"github.com/leg100/otf/internal/variable"
+	"github.com/leg100/otf/internal/workspace"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type (
+	fakeStore struct {
+		templates map[string]bool
+		store
+	}
+
+	fakeWorkspaceService struct {
+		template *workspace.Workspace
+		policy   internal.WorkspacePolicy
+		created  *workspace.Workspace
+		perms    []internal.WorkspacePermission
+		WorkspaceService
+	}
+
+	fakeVariableService struct {
+		vars    []*variable.Variable
+		created []variable.CreateVariableOptions
+		err     error
+		VariableService
+	}
+
+	fakeNotificationService struct {
+		configs []*notifications.Config
+		created []notifications.CreateConfigOptions
+		NotificationService
+	}
+)
+
+func (f *fakeStore) tx(ctx context.Context, fn func(context.Context) error) error {
+	return fn(ctx)
+}
+
+func (f *fakeStore) getTemplate(_ context.Context, workspaceID string) (*Template, error) {
+	if !f.templates[workspaceID] {
+		return nil, internal.ErrResourceNotFound
+	}
+	return &Template{WorkspaceID: workspaceID}, nil
+}
+
+func (f *fakeWorkspaceService) Get(context.Context, string) (*workspace.Workspace, error) {
+	return f.template, nil
+}
+
+func (f *fakeWorkspaceService) GetPolicy(context.Context, string) (internal.WorkspacePolicy, error) {
+	return f.policy, nil
+}
+
+func (f *fakeWorkspaceService) Create(_ context.Context, opts workspace.CreateOptions) (*workspace.Workspace, error) {
+	ws, err := workspace.NewWorkspace(opts)
+	if err != nil {
+		return nil, err
+	}
+	f.created = ws
+	return ws, nil
+}
+
+func (f *fakeWorkspaceService) SetPermission(_ context.Context, _, teamID string, role rbac.Role) error {
+	f.perms = append(f.perms, internal.WorkspacePermission{TeamID: teamID, Role: role})
+	return nil
+}
+
+func (f *fakeVariableService) ListWorkspaceVariables(context.Context, string) ([]*variable.Variable, error) {
+	return f.vars, nil
+}
+
+func (f *fakeVariableService) CreateWorkspaceVariable(_ context.Context, _ string, opts variable.CreateVariableOptions) (*variable.Variable, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	f.created = append(f.created, opts)
+	return &variable.Variable{}, nil
+}
+
+func (f *fakeNotificationService) List(context.Context, string) ([]*notifications.Config, error) {
+	return f.configs, nil
+}
+
+func (f *fakeNotificationService) Create(_ context.Context, _ string, opts notifications.CreateConfigOptions) (*notifications.Config, error) {
+	f.created = append(f.created, opts)
+	return &notifications.Config{}, nil
+}
+
+func TestService_CreateFromTemplate(t *testing.T) {
+	ctx := context.Background()
+	tmpl := &workspace.Workspace{ID: "ws-template", Name: "golden-path", Organization: "acme", TerraformVersion: "1.6.0", ExecutionMode: workspace.RemoteExecutionMode}
+	newService := func(templates map[string]bool, variables *fakeVariableService) (*Service, *fakeWorkspaceService, *fakeNotificationService) {
+		workspaces := &fakeWorkspaceService{
+			template: tmpl,
+			policy: internal.WorkspacePolicy{Permissions: []internal.WorkspacePermission{
+				{TeamID: "team-devs", Role: rbac.WorkspaceWriteRole},
+			}},
+		}
+		notifications := &fakeNotificationService{configs: []*notifications.Config{
+			{Name: "slack", DestinationType: notifications.DestinationSlack, Enabled: true, URL: internal.String("https://hooks.slack.com/abc")},
+		}}
+		return &Service{
+			Logger:        logr.Discard(),
+			db:            &fakeStore{templates: templates},
+			workspaces:    workspaces,
+			variables:     variables,
+			notifications: notifications,
+		}, workspaces, notifications
+	}
+
+	t.Run("create", func(t *testing.T) {
+		variables := &fakeVariableService{vars: []*variable.Variable{
+			{Key: "region", Value: "eu-west-1", Category: variable.CategoryTerraform},
+		}}
+		svc, workspaces, notifications := newService(map[string]bool{"ws-template": true}, variables)
+
+		ws, err := svc.CreateFromTemplate(ctx, "ws-template", CreateOptions{
+			Name:      "dev",
+			Variables: []VariableOverride{{Key: "region", Value: "eu-west-2"}},
+		})
+		require.NoError(t, err)
+
+		assert.Equal(t, "dev", ws.Name)
+		assert.Equal(t, "acme", ws.Organization)
+		require.Len(t, variables.created, 1)
+		assert.Equal(t, "eu-west-2", *variables.created[0].Value)
+		assert.Equal(t, workspaces.policy.Permissions, workspaces.perms)
+		require.Len(t, notifications.created, 1)
+		assert.Equal(t, "slack", *notifications.created[0].Name)
+	})
+
+	t.Run("not a template", func(t *testing.T) {
+		svc, workspaces, _ := newService(nil, &fakeVariableService{})
+
+		_, err := svc.CreateFromTemplate(ctx, "ws-template", CreateOptions{Name: "dev"})
+		assert.ErrorIs(t, err, ErrNotTemplate)
+		assert.Nil(t, workspaces.created)
+	})
+
+	t.Run("missing name", func(t *testing.T) {
+		svc, _, _ := newService(map[string]bool{"ws-template": true}, &fakeVariableService{})
+
+		_, err := svc.CreateFromTemplate(ctx, "ws-template", CreateOptions{})
+		var missing *internal.MissingParameterError
+		assert.ErrorAs(t, err, &missing)
+	})
+
+	t.Run("copy fails", func(t *testing.T) {
+		variables := &fakeVariableService{
+			vars: []*variable.Variable{{Key: "region", Value: "eu-west-1", Category: variable.CategoryTerraform}},
+			err:  errors.New("boom"),
+		}
+		svc, _, _ := newService(map[string]bool{"ws-template": true}, variables)
+
+		_, err := svc.CreateFromTemplate(ctx, "ws-template", CreateOptions{Name: "dev"})
+		assert.EqualError(t, err, "creating variable region: boom")
+	})
+}
@@ -0,0 +1,168 @@
+// Package workspacetemplate creates workspaces from template workspaces.
+package workspacetemplate
+
+import (
+	"errors"
+	"time"
+
+	"github.com/leg100/otf/internal"
+	"github.com/leg100/otf/internal/notifications"
+	"github.com/leg100/otf/internal/variable"
+	"github.com/leg100/otf/internal/workspace"
+)
+
+var ErrNotTemplate = errors.New("workspace is not a template")
+
+type (
+	// Template is a workspace from which new workspaces can be created.
+	Template struct {
+		WorkspaceID string    `json:"workspace_id"`
+		Name        string    `json:"name"`
+		CreatedAt   time.Time `json:"created_at"`
+	}
+
+	// CreateOptions are options for creating a workspace from a template.
+	CreateOptions struct {
+		// Name of the new workspace. Required.
+		Name string `json:"name"`
+		// Description of the new workspace. Defaults to the template's
+		// description.
+		Description *string `json:"description,omitempty"`
+		// Variables override the values of the template's variables. A
+		// variable that the template does not have is added to the new
+		// workspace.
+		Variables []VariableOverride `json:"variables,omitempty"`
+	}
+
+	// VariableOverride overrides the value of a template's variable.
+	VariableOverride struct {
+		Key   string `json:"key"`
+		Value string `json:"value"`
+		// Category of the variable. Defaults to terraform.
+		Category variable.VariableCategory `json:"category,omitempty"`
+		// Sensitive and HCL only apply to a variable the template does not
+		// have; otherwise those of the template's variable are retained.
+		Sensitive bool `json:"sensitive"`
+		HCL       bool `json:"hcl"`
+	}
+)
+
+func (opts CreateOptions) validate() error {
+	if opts.Name == "" {
+		return &internal.MissingParameterError{Parameter: "name"}
+	}
+	for _, v := range opts.Variables {
+		if v.Key == "" {
+			return &internal.MissingParameterError{Parameter: "variables.key"}
+		}
+	}
+	return nil
+}
+
+// newWorkspaceOptions constructs options for creating a workspace with the
+// same settings as the template.
+func newWorkspaceOptions(tmpl *workspace.Workspace, opts CreateOptions) workspace.CreateOptions {
+	create := workspace.CreateOptions{
+		Name:                       &opts.Name,
+		Organization:               &tmpl.Organization,
+		AgentPoolID:                tmpl.AgentPoolID,
+		AllowDestroyPlan:           &tmpl.AllowDestroyPlan,
+		AssessmentsEnabled:         &tmpl.AssessmentsEnabled,
+		AutoApply:                  &tmpl.AutoApply,
+		Description:                &tmpl.Description,
+		ExecutionMode:              &tmpl.ExecutionMode,
+		GlobalRemoteState:          &tmpl.GlobalRemoteState,
+		QueueAllRuns:               &tmpl.QueueAllRuns,
+		SpeculativeEnabled:         &tmpl.SpeculativeEnabled,
+		StructuredRunOutputEnabled: &tmpl.StructuredRunOutputEnabled,
+		TerraformVersion:           &tmpl.TerraformVersion,
+		WorkingDirectory:           &tmpl.WorkingDirectory,
+		IgnorePatterns:             tmpl.IgnorePatterns,
+	}
+	if opts.Description != nil {
+		create.Description = opts.Description
+	}
+	if len(tmpl.TriggerPatterns) > 0 {
+		create.TriggerPatterns = tmpl.TriggerPatterns
+	}
+	for _, tag := range tmpl.Tags {
+		create.Tags = append(create.Tags, workspace.TagSpec{Name: tag})
+	}
+	if conn := tmpl.Connection; conn != nil {
+		create.ConnectOptions = &workspace.ConnectOptions{
+			RepoPath:      &conn.Repo,
+			VCSProviderID: &conn.VCSProviderID,
+			Branch:        &conn.Branch,
+			AllowCLIApply: &conn.AllowCLIApply,
+		}
+		if conn.TagsRegex != "" {
+			create.ConnectOptions.TagsRegex = &conn.TagsRegex
+		}
+	}
+	return create
+}
+
+// newVariableOptions constructs options for creating the template's variables
+// in a new workspace, overriding their values where specified.
+func newVariableOptions(vars []*variable.Variable, overrides []VariableOverride) []variable.CreateVariableOptions {
+	type key struct {
+		key      string
+		category variable.VariableCategory
+	}
+	values := make(map[key]VariableOverride, len(overrides))
+	for _, o := range overrides {
+		if o.Category == "" {
+			o.Category = variable.CategoryTerraform
+		}
+		values[key{o.Key, o.Category}] = o
+	}
+	create := make([]variable.CreateVariableOptions, 0, len(vars)+len(overrides))
+	for _, v := range vars {
+		opts := variable.CreateVariableOptions{
+			Key:         internal.String(v.Key),
+			Value:       internal.String(v.Value),
+			Description: internal.String(v.Description),
+			Category:    &v.Category,
+			Sensitive:   internal.Bool(v.Sensitive),
+			HCL:         internal.Bool(v.HCL),
+		}
+		if o, ok := values[key{v.Key, v.Category}]; ok {
+			opts.Value = internal.String(o.Value)
+			delete(values, key{v.Key, v.Category})
+		}
+		create = append(create, opts)
+	}
+	// add variables the template does not have, in the order given.
+	for _, o := range overrides {
+		category := o.Category
+		if category == "" {
+			category = variable.CategoryTerraform
+		}
+		if _, ok := values[key{o.Key, category}]; !ok {
+			continue
+		}
+		create = append(create, variable.CreateVariableOptions{
+			Key:       internal.String(o.Key),
+			Value:     internal.String(o.Value),
+			Category:  &category,
+			Sensitive: internal.Bool(o.Sensitive),
+			HCL:       internal.Bool(o.HCL),
+		})
+		delete(values, key{o.Key, category})
+	}
+	return create
+}
+
+// newNotificationOptions constructs options for creating a copy of a
+// notification configuration.
+func newNotificationOptions(cfg *notifications.Config) notifications.CreateConfigOptions {
+	return notifications.CreateConfigOptions{
+		DestinationType: cfg.DestinationType,
+		Enabled:         internal.Bool(cfg.Enabled),
+		Name:            internal.String(cfg.Name),
+		Token:           internal.String(cfg.Token),
+		Triggers:        cfg.Triggers,
+		URL:             cfg.URL,
+		EmailAddresses:  cfg.EmailAddresses,
+	}
+}
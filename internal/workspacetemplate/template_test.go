@@ -0,0 +1,79 @@
+package workspacetemplate
+
+import (
+	"testing"
+
+	"github.com/leg100/otf/internal"
+	"github.com/leg100/otf/internal/variable"
+	"github.com/leg100/otf/internal/workspace"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewWorkspaceOptions(t *testing.T) {
+	tmpl := &workspace.Workspace{
+		Name:             "golden-path",
+		Organization:     "acme",
+		Description:      "the golden path",
+		TerraformVersion: "1.6.0",
+		WorkingDirectory: "envs/dev",
+		TriggerPatterns:  []string{"/envs/dev/**"},
+		Tags:             []string{"dev", "networking"},
+		Connection: &workspace.Connection{
+			Repo:          "acme/infra",
+			VCSProviderID: "vcs-123",
+			Branch:        "main",
+		},
+	}
+
+	t.Run("copy settings", func(t *testing.T) {
+		got := newWorkspaceOptions(tmpl, CreateOptions{Name: "dev-networking"})
+
+		ws, err := workspace.NewWorkspace(got)
+		require.NoError(t, err)
+
+		assert.Equal(t, "dev-networking", ws.Name)
+		assert.Equal(t, "acme", ws.Organization)
+		assert.Equal(t, "the golden path", ws.Description)
+		assert.Equal(t, "1.6.0", ws.TerraformVersion)
+		assert.Equal(t, "envs/dev", ws.WorkingDirectory)
+		assert.Equal(t, []string{"/envs/dev/**"}, ws.TriggerPatterns)
+		assert.Equal(t, []workspace.TagSpec{{Name: "dev"}, {Name: "networking"}}, got.Tags)
+		assert.Equal(t, &workspace.Connection{Repo: "acme/infra", VCSProviderID: "vcs-123", Branch: "main"}, ws.Connection)
+	})
+
+	t.Run("override description", func(t *testing.T) {
+		got := newWorkspaceOptions(tmpl, CreateOptions{Name: "dev-networking", Description: internal.String("networking")})
+
+		assert.Equal(t, "networking", *got.Description)
+	})
+}
+
+func TestNewVariableOptions(t *testing.T) {
+	vars := []*variable.Variable{
+		{Key: "region", Value: "eu-west-1", Category: variable.CategoryTerraform, Description: "aws region"},
+		{Key: "region", Value: "us-east-1", Category: variable.CategoryEnv},
+		{Key: "password", Value: "secret", Category: variable.CategoryTerraform, Sensitive: true},
+	}
+
+	got := newVariableOptions(vars, []VariableOverride{
+		{Key: "region", Value: "eu-west-2"},
+		{Key: "tags", Value: `{team = "networking"}`, HCL: true},
+	})
+
+	require.Len(t, got, 4)
+	// overridden value retains the template's other attributes
+	assert.Equal(t, "region", *got[0].Key)
+	assert.Equal(t, "eu-west-2", *got[0].Value)
+	assert.Equal(t, "aws region", *got[0].Description)
+	// variable with same key but different category is untouched
+	assert.Equal(t, "us-east-1", *got[1].Value)
+	assert.Equal(t, variable.CategoryEnv, *got[1].Category)
+	// sensitive value is copied
+	assert.Equal(t, "secret", *got[2].Value)
+	assert.True(t, *got[2].Sensitive)
+	// variable the template lacks is added
+	assert.Equal(t, "tags", *got[3].Key)
+	assert.Equal(t, variable.CategoryTerraform, *got[3].Category)
+	assert.True(t, *got[3].HCL)
+}
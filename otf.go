@@ -0,0 +1,76 @@
+// Package otf permits embedding an otf server within another Go program, e.g.
+// for integration tests or a bundled platform, without running otfd.
+package otf
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/go-logr/logr"
+	"github.com/leg100/otf/internal"
+	"github.com/leg100/otf/internal/daemon"
+)
+
+type (
+	// Config configures the server. It is the same configuration as
+	// accepted by otfd; use ApplyDefaults to populate the defaults that
+	// otfd would otherwise apply via its flags.
+	Config = daemon.Config
+
+	// Server is an embeddable otf server. The caller serves the Handler on
+	// an http server of its own choosing, and calls Start to run the
+	// background processes that otf relies upon.
+	Server struct {
+		daemon  *daemon.Daemon
+		handler http.Handler
+	}
+)
+
+// ApplyDefaults sets default values on the config.
+func ApplyDefaults(cfg *Config) {
+	daemon.ApplyDefaults(cfg)
+}
+
+// NewServer constructs a server, connecting to the database and migrating it
+// to the latest schema. The hostname at which the handler is served should be
+// set either via Config.Host or via SetHostname before calling Start; it is
+// used to construct links and URLs handed out to clients.
+func NewServer(ctx context.Context, logger logr.Logger, cfg Config) (*Server, error) {
+	ctx = internal.AddSubjectToContext(ctx, &internal.Superuser{Username: "app-user"})
+
+	d, err := daemon.New(ctx, logger, cfg)
+	if err != nil {
+		return nil, err
+	}
+	handler, err := d.Handler()
+	if err != nil {
+		return nil, err
+	}
+	return &Server{daemon: d, handler: handler}, nil
+}
+
+// Handler returns the http handler serving the API and web app.
+func (s *Server) Handler() http.Handler {
+	return s.handler
+}
+
+// SetHostname sets the hostname at which the handler is served, e.g. the
+// address of a httptest.Server.
+func (s *Server) SetHostname(hostname string) {
+	s.daemon.System.SetHostname(hostname)
+}
+
+// Hostname returns the hostname at which the handler is served.
+func (s *Server) Hostname() string {
+	return s.daemon.System.Hostname()
+}
+
+// Start runs the server's background processes and blocks until ctx is
+// cancelled or an error is returned, at which point the database connections
+// are closed. The started channel is closed once the processes have started,
+// after which the handler is ready to serve requests.
+func (s *Server) Start(ctx context.Context, started chan struct{}) error {
+	ctx = internal.AddSubjectToContext(ctx, &internal.Superuser{Username: "app-user"})
+
+	return s.daemon.StartSubsystems(ctx, started)
+}